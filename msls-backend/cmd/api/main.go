@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	// swaggerFiles "github.com/swaggo/files"
 	// ginSwagger "github.com/swaggo/gin-swagger"
 	"go.uber.org/zap"
@@ -32,13 +33,16 @@ import (
 	adminhandler "msls-backend/internal/handlers/admin"
 	authhandler "msls-backend/internal/handlers/auth"
 	branchhandler "msls-backend/internal/handlers/branch"
+	paymenthandler "msls-backend/internal/handlers/payment"
 	profilehandler "msls-backend/internal/handlers/profile"
 	rbachandler "msls-backend/internal/handlers/rbac"
 	"msls-backend/internal/middleware"
+	"msls-backend/internal/modules/appraisal"
 	"msls-backend/internal/modules/assignment"
 	"msls-backend/internal/modules/attendance"
 	"msls-backend/internal/modules/behavioral"
 	"msls-backend/internal/modules/bulk"
+	"msls-backend/internal/modules/certificate"
 	"msls-backend/internal/modules/department"
 	"msls-backend/internal/modules/designation"
 	"msls-backend/internal/modules/document"
@@ -48,19 +52,29 @@ import (
 	"msls-backend/internal/modules/hallticket"
 	"msls-backend/internal/modules/academic"
 	"msls-backend/internal/modules/timetable"
+	"msls-backend/internal/modules/family"
 	"msls-backend/internal/modules/guardian"
 	"msls-backend/internal/modules/health"
+	"msls-backend/internal/modules/immunization"
+	"msls-backend/internal/modules/infirmary"
+	"msls-backend/internal/modules/medicalalert"
+	"msls-backend/internal/modules/orgchart"
 	"msls-backend/internal/modules/payroll"
 	"msls-backend/internal/modules/promotion"
 	"msls-backend/internal/modules/salary"
 	"msls-backend/internal/modules/staff"
+	"msls-backend/internal/modules/staffchecklist"
 	"msls-backend/internal/modules/staffdocument"
 	"msls-backend/internal/modules/student"
 	"msls-backend/internal/modules/studentattendance"
 	"msls-backend/internal/pkg/config"
 	"msls-backend/internal/pkg/database"
 	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/healthcheck"
 	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/metrics"
+	"msls-backend/internal/pkg/payments"
+	"msls-backend/internal/pkg/ratelimit"
 	"msls-backend/internal/pkg/response"
 	"msls-backend/internal/pkg/sms"
 	"msls-backend/internal/services/academicyear"
@@ -68,8 +82,10 @@ import (
 	"msls-backend/internal/services/auth"
 	"msls-backend/internal/services/branch"
 	"msls-backend/internal/services/featureflag"
+	"msls-backend/internal/services/payment"
 	"msls-backend/internal/services/profile"
 	"msls-backend/internal/services/rbac"
+	"msls-backend/internal/services/sso"
 	"msls-backend/internal/pkg/storage"
 )
 
@@ -86,6 +102,12 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	if err := cfg.Payment.Validate(cfg.App); err != nil {
+		return fmt.Errorf("invalid payment configuration: %w", err)
+	}
+	if err := cfg.Data.Validate(cfg.App); err != nil {
+		return fmt.Errorf("invalid data encryption configuration: %w", err)
+	}
 
 	// Initialize logger
 	log, err := logger.New(logger.Config{
@@ -208,17 +230,57 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	// 5. Error Handler - Convert errors to RFC 7807 responses
 	router.Use(apperrors.Handler(log))
 
-	// 6. Rate Limiting - Global rate limit (100 req/min by default)
-	router.Use(middleware.RateLimitDefault())
+	// 6. Metrics - Record request latency by route template (before rate
+	// limiting, so rejected requests are counted too)
+	router.Use(middleware.MetricsDefault())
+
+	// 7. Rate Limiting - Global per-IP limit, counters shared across replicas
+	rateLimitStore, redisClient := newRateLimitStore(cfg, log, db)
+	rateLimits := middleware.RateLimitPolicies{
+		Store:       rateLimitStore,
+		Global:      ratelimit.PerMinute(cfg.RateLimit.GlobalPerMinute),
+		Login:       ratelimit.PerMinute(cfg.RateLimit.LoginPerMinute),
+		OTP:         ratelimit.PerMinute(cfg.RateLimit.OTPPerMinute),
+		UserReads:   ratelimit.PerMinute(cfg.RateLimit.UserReadsPerMinute),
+		UserWrites:  ratelimit.PerMinute(cfg.RateLimit.UserWritesPerMinute),
+		TenantQuota: ratelimit.NewTenantQuotaResolver(db, ratelimit.PerMinute(cfg.RateLimit.TenantPerMinute)),
+		OnStoreError: func(c *gin.Context, err error) {
+			log.Warn("rate limit store unavailable, allowing request", zap.Error(err))
+		},
+	}
+	router.Use(rateLimits.GlobalLimiter())
 
 	// === Static File Serving ===
 	// Serve uploaded files (documents, avatars, etc.)
 	router.Static("/uploads", "./uploads")
 
+	// Initialize file storage for staff documents
+	fileStorage, err := storage.NewLocalStorage("./uploads", "/uploads")
+	if err != nil {
+		log.Warn("failed to initialize file storage", zap.Error(err))
+	}
+
+	// Readiness checks for runtime dependencies
+	readiness := healthcheck.NewChecker(healthcheck.DefaultTimeout)
+	readiness.Register("database", healthcheck.DatabaseCheck(db))
+	readiness.Register("database_pool", healthcheck.PoolCheck(db))
+	readiness.Register("storage", healthcheck.StorageCheck(fileStorage))
+	if redisClient != nil {
+		readiness.Register("redis", func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		})
+	}
+
 	// === Public Routes (no tenant required) ===
-	// Health check endpoint (excluded from tenant middleware)
+	// Health check endpoints (excluded from tenant middleware)
 	router.GET("/health", healthHandler)
-	router.GET("/ready", readyHandler)
+	router.GET("/ready", readyHandler(readiness))
+
+	// Prometheus metrics endpoint (token or allowed networks only)
+	router.GET("/metrics", middleware.MetricsAccess(middleware.MetricsAccessConfig{
+		Token:           cfg.Metrics.Token,
+		AllowedNetworks: cfg.Metrics.AllowedNetworks,
+	}), metrics.Handler(metrics.Default))
 
 	// Swagger documentation endpoint
 	// TODO: Enable after running `swag init` to generate docs
@@ -231,22 +293,36 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 		AccessTTL:  cfg.JWT.AccessExpiresIn,
 		RefreshTTL: cfg.JWT.RefreshExpiresIn,
 	})
+	if cfg.JWT.KeyRingFile != "" {
+		if err := jwtService.UseKeyRing(cfg.JWT.KeyRingFile); err != nil {
+			log.Fatal("failed to load JWT key ring", zap.String("path", cfg.JWT.KeyRingFile), zap.Error(err))
+		}
+		log.Info("JWT key ring loaded", zap.String("path", cfg.JWT.KeyRingFile))
+	}
 	authService := auth.NewAuthService(db, jwtService)
+	// Reject access tokens of revoked sessions and outdated token versions
+	jwtService.SetRevocationChecker(authService.Sessions())
 
-	// Initialize RBAC services
+	// Initialize RBAC services; role and permission changes invalidate affected access tokens
 	permissionService := rbac.NewPermissionService(db)
 	roleService := rbac.NewRoleService(db, permissionService)
+	roleService.SetTokenInvalidator(authService.Sessions())
 	userRoleService := rbac.NewUserRoleService(db, roleService)
+	userRoleService.SetTokenInvalidator(authService.Sessions())
 
-	// Initialize SMS provider (mock for development)
-	smsProvider, err := sms.NewMockProvider("")
+	// Initialize SMS provider (mock for development), instrumented with send counters
+	var smsProvider sms.Provider
+	mockSMSProvider, err := sms.NewMockProvider("")
 	if err != nil {
 		log.Warn("failed to initialize SMS provider, OTP via SMS will not work", zap.Error(err))
+	} else {
+		smsProvider = sms.NewInstrumentedProvider(mockSMSProvider)
 	}
 
 	// Initialize OTP service
 	otpService := auth.NewOTPService(db, jwtService, auth.OTPConfig{
 		SMSProvider: smsProvider,
+		Sessions:    authService.Sessions(),
 	})
 
 	// Initialize TOTP service for 2FA
@@ -256,6 +332,13 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	}
 	authService.SetTOTPService(totpService)
 
+	// Initialize SSO service (OIDC and SAML identity providers per tenant)
+	ssoService := sso.NewService(db, authService, sso.Config{
+		BaseURL:                cfg.SSO.BaseURL,
+		AllowedRedirectOrigins: cfg.SSO.AllowedRedirectOrigins,
+		EncryptionKey:          cfg.Data.EncryptionKey,
+	})
+
 	// Initialize profile service
 	profileService := profile.NewProfileService(db, profile.Config{
 		UploadDir: "./uploads/avatars",
@@ -270,16 +353,92 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	// Initialize academic year service
 	academicYearService := academicyear.NewService(db)
 
+	// Initialize payment gateway and ledger
+	var paymentProvider payments.Provider
+	switch cfg.Payment.Provider {
+	case config.PaymentProviderRazorpay:
+		paymentProvider = payments.NewRazorpayProvider(payments.RazorpayConfig{
+			KeyID:         cfg.Payment.RazorpayKeyID,
+			KeySecret:     cfg.Payment.RazorpayKeySecret,
+			WebhookSecret: cfg.Payment.RazorpayWebhookSecret,
+		})
+	default:
+		// run has rejected unknown providers and the fake gateway in production
+		log.Warn("using the fake payment gateway, online payments are simulated", zap.String("provider", cfg.Payment.Provider))
+		paymentProvider = payments.NewFakeProvider(payments.FakeSecret)
+	}
+	paymentService := payment.NewService(db, paymentProvider)
+
 	// Initialize admission services
 	admissionSessionService := admission.NewSessionService(db)
 	admissionReportService := admission.NewReportService(db)
 	admissionExportService := admission.NewExportService(db, admissionReportService)
 	enquiryService := admission.NewEnquiryService(db)
 	applicationService := admission.NewApplicationService(db)
+	admissionPortalService := admission.NewPortalService(db, applicationService, admission.PortalConfig{
+		OTPService:  otpService,
+		SMSProvider: smsProvider,
+		Storage:     fileStorage,
+		Payments:    paymentService,
+	})
 	testService := admission.NewTestService(db)
 	reviewService := admission.NewReviewService(db)
 	meritService := admission.NewMeritService(db)
-	decisionService := admission.NewDecisionService(db)
+	decisionService := admission.NewDecisionService(db, fileStorage)
+	questionBankService := admission.NewQuestionBankService(db)
+	onlineTestService := admission.NewOnlineTestService(db, testService)
+	interviewService := admission.NewInterviewService(db, admission.InterviewConfig{
+		SMSProvider:   smsProvider,
+		PublicBaseURL: cfg.SSO.BaseURL,
+	})
+
+	// Lapse unaccepted offers past their validity date and release the seats to the waitlist
+	go func() {
+		ticker := time.NewTicker(admission.OfferExpiryInterval)
+		defer ticker.Stop()
+		for {
+			lapsed, err := decisionService.LapseExpiredOffers(context.Background(), time.Now())
+			if err != nil {
+				log.Error("failed to lapse expired offers", zap.Error(err))
+			}
+			if lapsed > 0 {
+				log.Info("lapsed expired offers", zap.Int("count", lapsed))
+			}
+			<-ticker.C
+		}
+	}()
+
+	// Auto-submit online entrance test attempts whose time has run out
+	go func() {
+		ticker := time.NewTicker(admission.OnlineTestSweepInterval)
+		defer ticker.Stop()
+		for {
+			submitted, err := onlineTestService.SubmitExpiredAttempts(context.Background(), time.Now())
+			if err != nil {
+				log.Error("failed to submit expired test attempts", zap.Error(err))
+			}
+			if submitted > 0 {
+				log.Info("auto-submitted expired test attempts", zap.Int("count", submitted))
+			}
+			<-ticker.C
+		}
+	}()
+
+	// Close enquiries with no activity for each tenant's auto-close period
+	go func() {
+		ticker := time.NewTicker(admission.EnquiryAutoCloseInterval)
+		defer ticker.Stop()
+		for {
+			closed, err := enquiryService.CloseInactiveEnquiries(context.Background(), time.Now())
+			if err != nil {
+				log.Error("failed to close inactive enquiries", zap.Error(err))
+			}
+			if closed > 0 {
+				log.Info("closed inactive enquiries", zap.Int("count", closed))
+			}
+			<-ticker.C
+		}
+	}()
 
 	// Initialize student service
 	studentService := student.NewService(db, branchService)
@@ -288,13 +447,29 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	guardianRepo := guardian.NewRepository(db)
 	guardianService := guardian.NewService(guardianRepo)
 
+	// Initialize family service
+	familyRepo := family.NewRepository(db)
+	familyService := family.NewService(familyRepo)
+
 	// Initialize health service
 	healthRepo := health.NewRepository(db)
 	healthService := health.NewService(healthRepo)
 
+	// Initialize infirmary service (guardians are notified by SMS)
+	infirmaryRepo := infirmary.NewRepository(db)
+	infirmaryService := infirmary.NewService(infirmaryRepo, smsProvider)
+
+	// Initialize immunization service (reminder campaigns are sent by SMS)
+	immunizationRepo := immunization.NewRepository(db)
+	immunizationService := immunization.NewService(immunizationRepo, smsProvider)
+
+	// Initialize medical alert service (badges shown on rosters across modules)
+	medicalAlertRepo := medicalalert.NewRepository(db)
+	medicalAlertService := medicalalert.NewService(medicalAlertRepo)
+
 	// Initialize behavioral service
 	behavioralRepo := behavioral.NewRepository(db)
-	behavioralService := behavioral.NewService(behavioralRepo)
+	behavioralService := behavioral.NewService(behavioralRepo, smsProvider)
 
 	// Initialize document service
 	documentRepo := document.NewRepository(db)
@@ -304,6 +479,11 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	enrollmentRepo := enrollment.NewRepository(db)
 	enrollmentService := enrollment.NewService(db, nil, nil) // Adapters will be added when needed
 
+	// Initialize certificate service (transfer certificates are raised when a student leaves)
+	certificateRepo := certificate.NewRepository(db)
+	certificateService := certificate.NewService(certificateRepo, behavioralService, cfg.SSO.BaseURL)
+	enrollmentService.SetCertificateRequester(certificateService)
+
 	// Initialize promotion service
 	promotionRepo := promotion.NewRepository(db)
 	promotionService := promotion.NewService(promotionRepo, enrollmentRepo)
@@ -313,6 +493,9 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	bulkService := bulk.NewService(db, bulkExportService)
 	bulkImportService := bulk.NewImportService(db)
 
+	// Register metrics that are collected at scrape time
+	registerRuntimeMetrics(log, db, bulkService)
+
 	// Initialize department service
 	departmentRepo := department.NewRepository(db)
 	departmentService := department.NewService(departmentRepo)
@@ -324,13 +507,40 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	// Initialize staff service
 	staffService := staff.NewService(db, branchService)
 
+	// Initialize staff onboarding and exit checklist service
+	staffChecklistService := staffchecklist.NewService(staffchecklist.NewRepository(db))
+	staffService.SetChecklistStarter(staffChecklistService)
+
+	// Initialize org chart service
+	orgChartService := orgchart.NewService(orgchart.NewRepository(db))
+
 	// Initialize salary service
 	salaryRepo := salary.NewRepository(db)
 	salaryService := salary.NewService(salaryRepo)
 
+	// Initialize appraisal service
+	appraisalRepo := appraisal.NewRepository(db)
+	appraisalService := appraisal.NewService(appraisalRepo, salaryService)
+
+	// Open probation confirmation reviews ahead of each probation end date
+	go func() {
+		ticker := time.NewTicker(appraisal.ProbationReviewInterval)
+		defer ticker.Stop()
+		for {
+			created, err := appraisalService.CreateDueProbationReviews(context.Background(), time.Now())
+			if err != nil {
+				log.Error("failed to create probation reviews", zap.Error(err))
+			}
+			if created > 0 {
+				log.Info("created probation reviews", zap.Int("count", created))
+			}
+			<-ticker.C
+		}
+	}()
+
 	// Initialize payroll service
 	payrollRepo := payroll.NewRepository(db)
-	payrollService := payroll.NewService(payrollRepo)
+	payrollService := payroll.NewService(payrollRepo, cfg.Data.EncryptionKey)
 
 	// Initialize assignment service
 	assignmentService := assignment.NewService(db)
@@ -342,6 +552,23 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	// Initialize timetable service
 	timetableRepo := timetable.NewRepository(db)
 	timetableService := timetable.NewService(timetableRepo)
+	timetableService.SetMedicalAlertProvider(medicalAlertService)
+
+	// Publish scheduled timetable versions once their effective date arrives
+	go func() {
+		ticker := time.NewTicker(timetable.ScheduledActivationInterval)
+		defer ticker.Stop()
+		for {
+			activated, err := timetableService.ActivateScheduledTimetables(context.Background(), time.Now())
+			if err != nil {
+				log.Error("failed to activate scheduled timetables", zap.Error(err))
+			}
+			if activated > 0 {
+				log.Info("activated scheduled timetables", zap.Int("count", activated))
+			}
+			<-ticker.C
+		}
+	}()
 
 	// Initialize exam service
 	examRepo := exam.NewRepository(db)
@@ -353,12 +580,7 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 
 	// Initialize hall ticket service
 	hallTicketService := hallticket.NewService(db, cfg.JWT.Secret)
-
-	// Initialize file storage for staff documents
-	fileStorage, err := storage.NewLocalStorage("./uploads", "/uploads")
-	if err != nil {
-		log.Warn("failed to initialize file storage", zap.Error(err))
-	}
+	hallTicketService.SetMedicalAlertProvider(medicalAlertService)
 
 	// Initialize staff document service
 	staffDocumentRepo := staffdocument.NewRepository(db)
@@ -367,7 +589,10 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	// Initialize handlers
 	authHandler := authhandler.NewHandler(authService)
 	otpHandler := authhandler.NewOTPHandler(otpService)
+	jwksHandler := authhandler.NewJWKSHandler(jwtService)
+	sessionHandler := authhandler.NewSessionHandler(authService)
 	twoFactorHandler := authhandler.NewTwoFactorHandler(authService, totpService)
+	ssoHandler := authhandler.NewSSOHandler(ssoService)
 	profileHandler := profilehandler.NewHandler(profileService)
 	roleHandler := rbachandler.NewRoleHandler(roleService)
 	permissionHandler := rbachandler.NewPermissionHandler(permissionService)
@@ -380,22 +605,36 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 	admissionExportHandler := admissionhandler.NewExportHandler(admissionExportService)
 	enquiryHandler := admissionhandler.NewEnquiryHandler(enquiryService)
 	applicationHandler := admissionhandler.NewApplicationHandler(applicationService)
+	admissionPortalHandler := admissionhandler.NewPortalHandler(admissionPortalService)
+	paymentHandler := paymenthandler.NewHandler(paymentService)
 	testHandler := admissionhandler.NewTestHandler(testService)
+	questionHandler := admissionhandler.NewQuestionHandler(questionBankService)
+	onlineTestHandler := admissionhandler.NewOnlineTestHandler(onlineTestService)
 	reviewHandler := admissionhandler.NewReviewHandler(reviewService)
+	interviewHandler := admissionhandler.NewInterviewHandler(interviewService)
 	meritHandler := admissionhandler.NewMeritHandler(meritService)
 	decisionHandler := admissionhandler.NewDecisionHandler(decisionService)
+	offerHandler := admissionhandler.NewOfferHandler(decisionService)
 	studentHandler := student.NewHandler(studentService)
 	guardianHandler := guardian.NewHandler(guardianService)
+	familyHandler := family.NewHandler(familyService)
 	healthHandler := health.NewHandler(healthService)
+	infirmaryHandler := infirmary.NewHandler(infirmaryService)
+	immunizationHandler := immunization.NewHandler(immunizationService)
+	medicalAlertHandler := medicalalert.NewHandler(medicalAlertService)
 	behavioralHandler := behavioral.NewHandler(behavioralService)
 	documentHandler := document.NewHandler(documentService)
 	enrollmentHandler := enrollment.NewHandler(enrollmentService)
+	certificateHandler := certificate.NewHandler(certificateService)
 	promotionHandler := promotion.NewHandler(promotionService)
 	bulkHandler := bulk.NewHandler(bulkService, bulkImportService)
 	departmentHandler := department.NewHandler(departmentService)
 	designationHandler := designation.NewHandler(designationService)
 	staffHandler := staff.NewHandler(staffService)
+	staffChecklistHandler := staffchecklist.NewHandler(staffChecklistService)
+	orgChartHandler := orgchart.NewHandler(orgChartService)
 	salaryHandler := salary.NewHandler(salaryService)
+	appraisalHandler := appraisal.NewHandler(appraisalService)
 	payrollHandler := payroll.NewHandler(payrollService)
 	assignmentHandler := assignment.NewHandler(assignmentService)
 	academicHandler := academic.NewHandler(academicService)
@@ -411,8 +650,12 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 
 	// Initialize student attendance service
 	studentAttendanceService := studentattendance.NewService(db)
+	studentAttendanceService.SetMedicalAlertProvider(medicalAlertService)
 	studentAttendanceHandler := studentattendance.NewHandler(studentAttendanceService)
 
+	// Public keys for verifying access tokens (RFC 7517)
+	router.GET("/.well-known/jwks.json", jwksHandler.JWKS)
+
 	// === API v1 Routes ===
 	v1 := router.Group("/api/v1")
 	{
@@ -422,26 +665,96 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 			public.GET("/ping", pingHandler)
 		}
 
+		// Public offer links (the token identifies the tenant)
+		publicOffers := v1.Group("/public/offers")
+		{
+			publicOffers.GET("/:token", offerHandler.Get)
+			publicOffers.GET("/:token/letter.pdf", offerHandler.DownloadLetter)
+			publicOffers.POST("/:token/accept", offerHandler.Accept)
+		}
+
+		// Public certificate verification (reached by scanning the certificate QR code)
+		publicCertificates := v1.Group("/public/certificates")
+		{
+			publicCertificates.GET("/verify/:code", certificateHandler.Verify)
+		}
+
+		// Public interview reschedule links (the token identifies the tenant)
+		publicInterviews := v1.Group("/public/interviews")
+		{
+			publicInterviews.GET("/:token", interviewHandler.GetPublicInterview)
+			publicInterviews.POST("/:token/reschedule", interviewHandler.ReschedulePublicInterview)
+		}
+
+		// Public timetable calendar feeds (the token identifies the tenant)
+		publicTimetableFeeds := v1.Group("/public/timetable-feeds")
+		{
+			publicTimetableFeeds.GET("/:token", timetableHandler.GetFeedCalendar)
+		}
+
+		// Payment gateway webhooks (the signature authenticates the gateway, the order identifies the tenant)
+		publicPayments := v1.Group("/public/payments")
+		{
+			publicPayments.POST("/webhook", paymentHandler.Webhook)
+		}
+
 		// Public routes that require tenant ID but no authentication
 		publicTenant := v1.Group("/public")
 		publicTenant.Use(middleware.TenantRequired())
 		{
 			// Application status check (for parents to check their application status)
 			publicTenant.POST("/applications/status", applicationHandler.CheckStatus)
+
+			// Online admission portal (parents apply after verifying their phone by OTP)
+			portalRoutes := publicTenant.Group("/admissions")
+			{
+				portalRoutes.GET("/sessions", admissionPortalHandler.ListSessions)
+				portalRoutes.POST("/otp", rateLimits.OTPLimiter(), admissionPortalHandler.RequestOTP)
+				portalRoutes.POST("/verify", rateLimits.OTPLimiter(), admissionPortalHandler.VerifyOTP)
+
+				portalApplications := portalRoutes.Group("/applications")
+				portalApplications.Use(admissionPortalHandler.RequirePortalSession())
+				{
+					portalApplications.GET("", admissionPortalHandler.ListApplications)
+					portalApplications.POST("", admissionPortalHandler.Create)
+					portalApplications.GET("/:id", admissionPortalHandler.Get)
+					portalApplications.PUT("/:id", admissionPortalHandler.Update)
+					portalApplications.POST("/:id/documents", admissionPortalHandler.UploadDocument)
+					portalApplications.DELETE("/:id/documents/:documentId", admissionPortalHandler.DeleteDocument)
+					portalApplications.POST("/:id/submit", admissionPortalHandler.Submit)
+					portalApplications.POST("/:id/payment", admissionPortalHandler.CreatePayment)
+					portalApplications.POST("/:id/payment/verify", admissionPortalHandler.VerifyPayment)
+				}
+			}
+
+			// Online entrance tests (candidates log in with their hall ticket roll number)
+			onlineTestRoutes := publicTenant.Group("/entrance-tests")
+			{
+				onlineTestRoutes.POST("/login", rateLimits.LoginLimiter(), onlineTestHandler.Login)
+
+				candidateRoutes := onlineTestRoutes.Group("")
+				candidateRoutes.Use(onlineTestHandler.RequireTestSession())
+				{
+					candidateRoutes.GET("/paper", onlineTestHandler.GetPaper)
+					candidateRoutes.PUT("/answers/:questionId", onlineTestHandler.SaveAnswer)
+					candidateRoutes.POST("/submit", onlineTestHandler.Submit)
+				}
+			}
 		}
 
 		// Auth routes (public - no authentication required)
 		authRoutes := v1.Group("/auth")
 		{
 			// Public auth endpoints
-			authRoutes.POST("/login", authHandler.Login)
+			authRoutes.POST("/login", rateLimits.LoginLimiter(), authHandler.Login)
 			authRoutes.POST("/refresh", authHandler.RefreshToken)
 			authRoutes.POST("/verify-email", authHandler.VerifyEmail)
-			authRoutes.POST("/forgot-password", authHandler.ForgotPassword)
-			authRoutes.POST("/reset-password", authHandler.ResetPassword)
+			authRoutes.POST("/forgot-password", rateLimits.LoginLimiter(), authHandler.ForgotPassword)
+			authRoutes.POST("/reset-password", rateLimits.LoginLimiter(), authHandler.ResetPassword)
 
 			// OTP endpoints (public - for passwordless login)
 			otpRoutes := authRoutes.Group("/otp")
+			otpRoutes.Use(rateLimits.OTPLimiter())
 			{
 				otpRoutes.POST("/request", otpHandler.RequestOTP)
 				otpRoutes.POST("/verify", otpHandler.VerifyOTP)
@@ -449,7 +762,19 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 			}
 
 			// 2FA validation endpoint (public - uses partial token)
-			authRoutes.POST("/2fa/validate", twoFactorHandler.Validate2FA)
+			authRoutes.POST("/2fa/validate", rateLimits.LoginLimiter(), twoFactorHandler.Validate2FA)
+
+			// Single sign-on (public - the browser is redirected through the identity provider)
+			ssoRoutes := authRoutes.Group("/sso")
+			ssoRoutes.Use(rateLimits.LoginLimiter())
+			{
+				ssoRoutes.GET("/providers", ssoHandler.ListLoginProviders)
+				ssoRoutes.POST("/exchange", ssoHandler.Exchange)
+				ssoRoutes.GET("/:providerId/login", ssoHandler.Login)
+				ssoRoutes.GET("/:providerId/callback", ssoHandler.Callback)
+				ssoRoutes.POST("/:providerId/acs", ssoHandler.AssertionConsumer)
+				ssoRoutes.GET("/:providerId/metadata", ssoHandler.Metadata)
+			}
 
 			// Protected auth endpoints (require authentication)
 			authProtected := authRoutes.Group("")
@@ -458,6 +783,11 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				authProtected.POST("/logout", authHandler.Logout)
 				authProtected.GET("/me", authHandler.Me)
 
+				// Session management for the current user
+				authProtected.GET("/sessions", sessionHandler.ListMySessions)
+				authProtected.DELETE("/sessions", sessionHandler.RevokeMyOtherSessions)
+				authProtected.DELETE("/sessions/:id", sessionHandler.RevokeMySession)
+
 				// 2FA management endpoints (require authentication)
 				twoFactorRoutes := authProtected.Group("/2fa")
 				{
@@ -500,6 +830,8 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 		protected := v1.Group("")
 		protected.Use(middleware.TenantRequired())
 		protected.Use(middleware.AuthRequired(jwtService))
+		protected.Use(rateLimits.TenantLimiter())
+		protected.Use(rateLimits.UserLimiter())
 		{
 			// Role management routes
 			roles := protected.Group("/roles")
@@ -553,6 +885,34 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					userRoles.POST("", userRoleHandler.AssignRoles)
 					userRoles.DELETE("", userRoleHandler.RemoveRoles)
 				}
+
+				// Admin session management (forced logout) - require users:write permission
+				userSessions := users.Group("/:id/sessions")
+				userSessions.Use(middleware.PermissionRequired("users:write"))
+				{
+					userSessions.GET("", sessionHandler.ListUserSessions)
+					userSessions.DELETE("", sessionHandler.RevokeAllUserSessions)
+					userSessions.DELETE("/:sessionId", sessionHandler.RevokeUserSession)
+				}
+			}
+
+			// SSO identity provider management
+			ssoProviders := protected.Group("/sso/providers")
+			{
+				ssoRead := ssoProviders.Group("")
+				ssoRead.Use(middleware.PermissionRequired("sso:read"))
+				{
+					ssoRead.GET("", ssoHandler.ListProviders)
+					ssoRead.GET("/:id", ssoHandler.GetProvider)
+				}
+
+				ssoWrite := ssoProviders.Group("")
+				ssoWrite.Use(middleware.PermissionRequired("sso:write"))
+				{
+					ssoWrite.POST("", ssoHandler.CreateProvider)
+					ssoWrite.PUT("/:id", ssoHandler.UpdateProvider)
+					ssoWrite.DELETE("/:id", ssoHandler.DeleteProvider)
+				}
 			}
 
 			// Branch management routes
@@ -732,6 +1092,22 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					}
 				}
 
+				// Student family (siblings and shared guardians) - requires guardians:read permission
+				students.GET("/:id/family", middleware.PermissionRequired("guardians:read"), familyHandler.GetStudentFamily)
+
+				// Student certificates - requires certificates:read permission
+				students.GET("/:id/certificates", middleware.PermissionRequired("certificates:read"), certificateHandler.ListStudentCertificates)
+
+				// Student infirmary visits - requires health:read permission
+				students.GET("/:id/infirmary-visits", middleware.PermissionRequired("health:read"), infirmaryHandler.ListStudentVisits)
+
+				// Student immunization compliance - requires health:read permission
+				students.GET("/:id/immunization-compliance", middleware.PermissionRequired("health:read"), immunizationHandler.GetStudentCompliance)
+
+				// Student medical alerts and emergency card - requires health:read permission
+				students.GET("/:id/medical-alerts", middleware.PermissionRequired("health:read"), medicalAlertHandler.GetStudentAlerts)
+				students.GET("/:id/emergency-card", middleware.PermissionRequired("health:read"), medicalAlertHandler.DownloadEmergencyCard)
+
 				// Health records management routes (nested under students)
 				healthRoutes := students.Group("/:id/health")
 				{
@@ -804,6 +1180,9 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					behavioralSummary.GET("", behavioralHandler.GetBehaviorSummary)
 				}
 
+				// Behaviour points and conduct grade for a term
+				students.GET("/:id/behavior-points", middleware.PermissionRequired("behavior:read"), behavioralHandler.GetStudentPoints)
+
 				// Enrollment management routes (nested under students)
 				enrollments := students.Group("/:id/enrollments")
 				{
@@ -882,6 +1261,137 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					documentChecklist.GET("", documentHandler.GetDocumentChecklist)
 				}
 
+			// Family management routes (siblings and shared guardians)
+			families := protected.Group("/families")
+			{
+				// Read operations - require guardians:read permission
+				familiesRead := families.Group("")
+				familiesRead.Use(middleware.PermissionRequired("guardians:read"))
+				{
+					familiesRead.GET("", familyHandler.ListFamilies)
+					familiesRead.GET("/duplicates", familyHandler.FindDuplicates)
+					familiesRead.GET("/:id", familyHandler.GetFamily)
+				}
+
+				// Write operations - require guardians:write permission
+				familiesWrite := families.Group("")
+				familiesWrite.Use(middleware.PermissionRequired("guardians:write"))
+				{
+					familiesWrite.POST("", familyHandler.CreateFamily)
+					familiesWrite.POST("/auto-link", familyHandler.AutoLink)
+					familiesWrite.PUT("/:id", familyHandler.UpdateFamily)
+					familiesWrite.DELETE("/:id", familyHandler.DeleteFamily)
+					familiesWrite.POST("/:id/students", familyHandler.AddStudent)
+					familiesWrite.DELETE("/:id/students/:studentId", familyHandler.RemoveStudent)
+					familiesWrite.POST("/:id/sync", familyHandler.SyncFamily)
+					familiesWrite.POST("/:id/merge", familyHandler.MergeFamilies)
+				}
+			}
+
+			// Family guardian routes - require guardians:write permission
+			familyGuardians := protected.Group("/family-guardians")
+			familyGuardians.Use(middleware.PermissionRequired("guardians:write"))
+			{
+				familyGuardians.POST("/:id/merge", familyHandler.MergeGuardians)
+				familyGuardians.PUT("/:id/staff", familyHandler.LinkStaff)
+			}
+
+			// Certificate register (transfer, bonafide and character certificates)
+			certificates := protected.Group("/certificates")
+			{
+				// Read operations - require certificates:read permission
+				certificatesRead := certificates.Group("")
+				certificatesRead.Use(middleware.PermissionRequired("certificates:read"))
+				{
+					certificatesRead.GET("", certificateHandler.List)
+					certificatesRead.GET("/:id", certificateHandler.Get)
+					certificatesRead.GET("/:id/pdf", certificateHandler.DownloadPDF)
+				}
+
+				// Request operations - require certificates:write permission
+				certificatesWrite := certificates.Group("")
+				certificatesWrite.Use(middleware.PermissionRequired("certificates:write"))
+				{
+					certificatesWrite.POST("", certificateHandler.Request)
+					certificatesWrite.PUT("/:id", certificateHandler.Update)
+				}
+
+				// Approval operations - require certificates:approve permission
+				certificatesApprove := certificates.Group("")
+				certificatesApprove.Use(middleware.PermissionRequired("certificates:approve"))
+				{
+					certificatesApprove.POST("/:id/approve", certificateHandler.Approve)
+					certificatesApprove.POST("/:id/reject", certificateHandler.Reject)
+					certificatesApprove.POST("/:id/cancel", certificateHandler.Cancel)
+				}
+			}
+
+			// Certificate template routes
+			certificateTemplates := protected.Group("/certificate-templates")
+			{
+				certificateTemplatesRead := certificateTemplates.Group("")
+				certificateTemplatesRead.Use(middleware.PermissionRequired("certificates:read"))
+				{
+					certificateTemplatesRead.GET("", certificateHandler.ListTemplates)
+					certificateTemplatesRead.GET("/:id", certificateHandler.GetTemplate)
+				}
+
+				certificateTemplatesWrite := certificateTemplates.Group("")
+				certificateTemplatesWrite.Use(middleware.PermissionRequired("certificates:write"))
+				{
+					certificateTemplatesWrite.POST("", certificateHandler.CreateTemplate)
+					certificateTemplatesWrite.PUT("/:id", certificateHandler.UpdateTemplate)
+					certificateTemplatesWrite.DELETE("/:id", certificateHandler.DeleteTemplate)
+				}
+			}
+
+			// Infirmary visit log and medication administration
+			infirmaryRoutes := protected.Group("/infirmary")
+			{
+				// Read operations - require health:read permission
+				infirmaryRead := infirmaryRoutes.Group("")
+				infirmaryRead.Use(middleware.PermissionRequired("health:read"))
+				{
+					infirmaryRead.GET("/visits", infirmaryHandler.ListVisits)
+					infirmaryRead.GET("/visits/:id", infirmaryHandler.GetVisit)
+					infirmaryRead.GET("/medication-schedule", infirmaryHandler.GetSchedule)
+				}
+
+				// Write operations - require health:write permission
+				infirmaryWrite := infirmaryRoutes.Group("")
+				infirmaryWrite.Use(middleware.PermissionRequired("health:write"))
+				{
+					infirmaryWrite.POST("/visits", infirmaryHandler.RecordVisit)
+					infirmaryWrite.PUT("/visits/:id", infirmaryHandler.UpdateVisit)
+					infirmaryWrite.POST("/visits/:id/discharge", infirmaryHandler.Discharge)
+					infirmaryWrite.POST("/medication-administrations", infirmaryHandler.RecordDose)
+				}
+			}
+
+			// Immunization schedule, compliance report and guardian reminders
+			immunizationRoutes := protected.Group("/immunization")
+			{
+				// Read operations - require health:read permission
+				immunizationRead := immunizationRoutes.Group("")
+				immunizationRead.Use(middleware.PermissionRequired("health:read"))
+				{
+					immunizationRead.GET("/schedule", immunizationHandler.ListSchedule)
+					immunizationRead.GET("/compliance-report", immunizationHandler.ComplianceReport)
+					immunizationRead.GET("/reminder-campaigns", immunizationHandler.ListCampaigns)
+					immunizationRead.GET("/reminder-campaigns/:id", immunizationHandler.GetCampaign)
+				}
+
+				// Write operations - require health:write permission
+				immunizationWrite := immunizationRoutes.Group("")
+				immunizationWrite.Use(middleware.PermissionRequired("health:write"))
+				{
+					immunizationWrite.POST("/schedule", immunizationHandler.CreateScheduleItem)
+					immunizationWrite.PUT("/schedule/:id", immunizationHandler.UpdateScheduleItem)
+					immunizationWrite.DELETE("/schedule/:id", immunizationHandler.DeleteScheduleItem)
+					immunizationWrite.POST("/reminder-campaigns", immunizationHandler.CreateCampaign)
+				}
+			}
+
 			// Document type management routes
 			documentTypes := protected.Group("/document-types")
 			{
@@ -1013,6 +1523,48 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				followUpsRoute.GET("/pending", behavioralHandler.ListPendingFollowUps)
 			}
 
+			// Behaviour points configuration (merit/demerit rules and escalation thresholds)
+			behaviorPoints := protected.Group("/behavior-points")
+			{
+				behaviorPointsRead := behaviorPoints.Group("")
+				behaviorPointsRead.Use(middleware.PermissionRequired("behavior:read"))
+				{
+					behaviorPointsRead.GET("/rules", behavioralHandler.GetPointRules)
+					behaviorPointsRead.GET("/thresholds", behavioralHandler.ListThresholds)
+				}
+
+				behaviorPointsWrite := behaviorPoints.Group("")
+				behaviorPointsWrite.Use(middleware.PermissionRequired("behavior:write"))
+				{
+					behaviorPointsWrite.PUT("/rules", behavioralHandler.UpdatePointRules)
+					behaviorPointsWrite.POST("/thresholds", behavioralHandler.CreateThreshold)
+					behaviorPointsWrite.PUT("/thresholds/:id", behavioralHandler.UpdateThreshold)
+					behaviorPointsWrite.DELETE("/thresholds/:id", behavioralHandler.DeleteThreshold)
+				}
+			}
+
+			// House routes (membership and points leaderboard)
+			houses := protected.Group("/houses")
+			{
+				housesRead := houses.Group("")
+				housesRead.Use(middleware.PermissionRequired("behavior:read"))
+				{
+					housesRead.GET("", behavioralHandler.ListHouses)
+					housesRead.GET("/leaderboard", behavioralHandler.GetHouseLeaderboard)
+					housesRead.GET("/:id/members", behavioralHandler.ListHouseMembers)
+				}
+
+				housesWrite := houses.Group("")
+				housesWrite.Use(middleware.PermissionRequired("behavior:write"))
+				{
+					housesWrite.POST("", behavioralHandler.CreateHouse)
+					housesWrite.PUT("/:id", behavioralHandler.UpdateHouse)
+					housesWrite.DELETE("/:id", behavioralHandler.DeleteHouse)
+					housesWrite.POST("/:id/members", behavioralHandler.AddHouseMembers)
+					housesWrite.DELETE("/:id/members/:studentId", behavioralHandler.RemoveHouseMember)
+				}
+			}
+
 			// Admission session management routes
 			admissionSessions := protected.Group("/admission-sessions")
 			{
@@ -1090,8 +1642,10 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				enquiriesRead.Use(middleware.PermissionRequired("enquiries:read"))
 				{
 					enquiriesRead.GET("", enquiryHandler.List)
+					enquiriesRead.GET("/work-queue", enquiryHandler.WorkQueue)
 					enquiriesRead.GET("/:id", enquiryHandler.GetByID)
 					enquiriesRead.GET("/:id/follow-ups", enquiryHandler.ListFollowUps)
+					enquiriesRead.GET("/:id/lead-score", enquiryHandler.GetLeadScore)
 				}
 
 				// Create operations - require enquiries:create permission
@@ -1108,6 +1662,15 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					enquiriesUpdate.PUT("/:id", enquiryHandler.Update)
 					enquiriesUpdate.POST("/:id/follow-ups", enquiryHandler.AddFollowUp)
 					enquiriesUpdate.POST("/:id/convert", enquiryHandler.ConvertToApplication)
+					enquiriesUpdate.POST("/lead-scores/recalculate", enquiryHandler.RecalculateLeadScores)
+				}
+
+				// Assignment operations - require enquiries:assign permission
+				enquiriesAssign := enquiries.Group("")
+				enquiriesAssign.Use(middleware.PermissionRequired("enquiries:assign"))
+				{
+					enquiriesAssign.POST("/:id/assign", enquiryHandler.Assign)
+					enquiriesAssign.POST("/distribute", enquiryHandler.Distribute)
 				}
 
 				// Delete operations - require enquiries:delete permission
@@ -1118,6 +1681,25 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				}
 			}
 
+			// Enquiry counsellor pool and CRM settings routes
+			enquiryCounsellors := protected.Group("/enquiry-counsellors")
+			{
+				enquiryCounsellors.GET("", middleware.PermissionRequired("enquiries:read"), enquiryHandler.ListCounsellors)
+
+				counsellorsAssign := enquiryCounsellors.Group("")
+				counsellorsAssign.Use(middleware.PermissionRequired("enquiries:assign"))
+				{
+					counsellorsAssign.POST("", enquiryHandler.AddCounsellor)
+					counsellorsAssign.DELETE("/:userId", enquiryHandler.RemoveCounsellor)
+				}
+			}
+
+			enquirySettings := protected.Group("/enquiry-settings")
+			{
+				enquirySettings.GET("", middleware.PermissionRequired("enquiries:read"), enquiryHandler.GetSettings)
+				enquirySettings.PUT("", middleware.PermissionRequired("enquiries:assign"), enquiryHandler.UpdateSettings)
+			}
+
 			// Admission reports and analytics routes
 			admissions := protected.Group("/admissions")
 			{
@@ -1133,6 +1715,8 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					admissionsReportsRead.GET("/reports/class-wise", admissionReportHandler.GetClassWise)
 					admissionsReportsRead.GET("/reports/source-analysis", admissionReportHandler.GetSourceAnalysis)
 					admissionsReportsRead.GET("/reports/daily-trend", admissionReportHandler.GetDailyTrend)
+					admissionsReportsRead.GET("/reports/counsellors", admissionReportHandler.GetCounsellorPerformance)
+					admissionsReportsRead.GET("/reports/seat-matrix", admissionReportHandler.GetSeatMatrix)
 
 					// Export endpoint
 					admissionsReportsRead.GET("/export", admissionExportHandler.Export)
@@ -1151,6 +1735,7 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					applicationsRead.GET("/:id/parents", applicationHandler.ListParents)
 					applicationsRead.GET("/:id/documents", applicationHandler.ListDocuments)
 					applicationsRead.GET("/:id/decision", decisionHandler.GetDecision)
+					applicationsRead.GET("/:id/offer-letter.pdf", decisionHandler.DownloadOfferLetter)
 				}
 
 				// Create operations - require applications:create permission
@@ -1166,6 +1751,7 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				{
 					applicationsUpdate.PUT("/:id", applicationHandler.Update)
 					applicationsUpdate.POST("/:id/submit", applicationHandler.Submit)
+					applicationsUpdate.POST("/:id/refresh-family", applicationHandler.RefreshFamily)
 					applicationsUpdate.PATCH("/:id/stage", applicationHandler.UpdateStage)
 					applicationsUpdate.POST("/:id/parents", applicationHandler.AddParent)
 					applicationsUpdate.PUT("/:id/parents/:parentId", applicationHandler.UpdateParent)
@@ -1211,6 +1797,30 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				}
 			}
 
+			// Payments ledger routes
+			paymentRoutes := protected.Group("/payments")
+			{
+				paymentsRead := paymentRoutes.Group("")
+				paymentsRead.Use(middleware.PermissionRequired("payments.view"))
+				{
+					paymentsRead.GET("", paymentHandler.List)
+					paymentsRead.GET("/:id", paymentHandler.Get)
+				}
+
+				paymentsManage := paymentRoutes.Group("")
+				paymentsManage.Use(middleware.PermissionRequired("payments.manage"))
+				{
+					paymentsManage.POST("/:id/confirm", paymentHandler.Confirm)
+					paymentsManage.POST("/:id/refund", paymentHandler.Refund)
+				}
+
+				paymentsReconcile := paymentRoutes.Group("")
+				paymentsReconcile.Use(middleware.PermissionRequired("payments.reconcile"))
+				{
+					paymentsReconcile.POST("/reconcile", paymentHandler.Reconcile)
+				}
+			}
+
 			// Entrance test management routes
 			entranceTests := protected.Group("/entrance-tests")
 			{
@@ -1223,6 +1833,8 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					testsRead.GET("/:id/registrations", testHandler.ListRegistrations)
 					testsRead.GET("/:id/hall-tickets", testHandler.GetHallTickets)
 					testsRead.GET("/:id/hall-tickets/:registrationId", testHandler.GetHallTicket)
+					testsRead.GET("/:id/paper", questionHandler.GetPaper)
+					testsRead.GET("/:id/attempts", onlineTestHandler.ListAttempts)
 				}
 
 				// Create operations - require tests:create permission
@@ -1239,6 +1851,13 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 					testsUpdate.PUT("/:id", testHandler.UpdateTest)
 					testsUpdate.POST("/:id/register", testHandler.RegisterCandidate)
 					testsUpdate.DELETE("/:id/registrations/:registrationId", testHandler.CancelRegistration)
+					testsUpdate.POST("/:id/paper/sections", questionHandler.CreateSection)
+					testsUpdate.PUT("/:id/paper/sections/:sectionId", questionHandler.UpdateSection)
+					testsUpdate.DELETE("/:id/paper/sections/:sectionId", questionHandler.DeleteSection)
+					testsUpdate.POST("/:id/paper/sections/:sectionId/questions", questionHandler.AddSectionQuestion)
+					testsUpdate.DELETE("/:id/paper/sections/:sectionId/questions/:questionId", questionHandler.RemoveSectionQuestion)
+					testsUpdate.POST("/:id/paper/publish", questionHandler.PublishPaper)
+					testsUpdate.POST("/:id/paper/unpublish", questionHandler.UnpublishPaper)
 				}
 
 				// Results management - require tests:manage permission
@@ -1247,6 +1866,8 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				{
 					testsManage.POST("/:id/results", testHandler.SubmitResult)
 					testsManage.POST("/:id/results/bulk", testHandler.BulkSubmitResults)
+					testsManage.GET("/:id/grading-queue", onlineTestHandler.GradingQueue)
+					testsManage.PUT("/:id/answers/:answerId/grade", onlineTestHandler.GradeAnswer)
 				}
 
 				// Delete operations - require tests:delete permission
@@ -1257,6 +1878,82 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				}
 			}
 
+			// Entrance test question bank routes
+			questionBank := protected.Group("/question-bank")
+			{
+				questionBankRead := questionBank.Group("")
+				questionBankRead.Use(middleware.PermissionRequired("tests:read"))
+				{
+					questionBankRead.GET("", questionHandler.ListQuestions)
+					questionBankRead.GET("/:id", questionHandler.GetQuestion)
+				}
+
+				questionBankWrite := questionBank.Group("")
+				questionBankWrite.Use(middleware.PermissionRequired("tests:update"))
+				{
+					questionBankWrite.POST("", questionHandler.CreateQuestion)
+					questionBankWrite.PUT("/:id", questionHandler.UpdateQuestion)
+					questionBankWrite.DELETE("/:id", questionHandler.DeleteQuestion)
+				}
+			}
+
+			// Interview rubric routes
+			interviewRubrics := protected.Group("/interview-rubrics")
+			{
+				interviewRubrics.GET("", middleware.PermissionRequired("interviews:read"), interviewHandler.ListRubrics)
+
+				rubricsManage := interviewRubrics.Group("")
+				rubricsManage.Use(middleware.PermissionRequired("interviews:manage"))
+				{
+					rubricsManage.POST("", interviewHandler.CreateRubric)
+					rubricsManage.PUT("/:id", interviewHandler.UpdateRubric)
+					rubricsManage.DELETE("/:id", interviewHandler.DeleteRubric)
+				}
+			}
+
+			// Interview slot and panel routes
+			interviewSlots := protected.Group("/interview-slots")
+			{
+				slotsRead := interviewSlots.Group("")
+				slotsRead.Use(middleware.PermissionRequired("interviews:read"))
+				{
+					slotsRead.GET("", interviewHandler.ListSlots)
+					slotsRead.GET("/:id", interviewHandler.GetSlot)
+				}
+
+				slotsManage := interviewSlots.Group("")
+				slotsManage.Use(middleware.PermissionRequired("interviews:manage"))
+				{
+					slotsManage.POST("", interviewHandler.CreateSlot)
+					slotsManage.PUT("/:id", interviewHandler.UpdateSlot)
+					slotsManage.DELETE("/:id", interviewHandler.DeleteSlot)
+					slotsManage.POST("/:id/panelists", interviewHandler.AddPanelist)
+					slotsManage.DELETE("/:id/panelists/:staffId", interviewHandler.RemovePanelist)
+				}
+			}
+
+			// Interview scheduling and scoring routes
+			interviews := protected.Group("/interviews")
+			{
+				interviewsRead := interviews.Group("")
+				interviewsRead.Use(middleware.PermissionRequired("interviews:read"))
+				{
+					interviewsRead.GET("", interviewHandler.ListInterviews)
+					interviewsRead.GET("/:id", interviewHandler.GetInterview)
+				}
+
+				interviewsManage := interviews.Group("")
+				interviewsManage.Use(middleware.PermissionRequired("interviews:manage"))
+				{
+					interviewsManage.POST("", interviewHandler.ScheduleInterview)
+					interviewsManage.POST("/:id/reschedule", interviewHandler.RescheduleInterview)
+					interviewsManage.POST("/:id/cancel", interviewHandler.CancelInterview)
+					interviewsManage.POST("/:id/no-show", interviewHandler.MarkNoShow)
+				}
+
+				interviews.PUT("/:id/scores", middleware.PermissionRequired("interviews:score"), interviewHandler.SubmitScore)
+			}
+
 			// Department management routes
 			departmentHandler.RegisterRoutes(protected, middleware.AuthRequired(jwtService))
 
@@ -1300,6 +1997,10 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 				}
 			}
 
+			// Org chart and reporting hierarchy routes
+			orgChartHandler.RegisterRoutes(protected)
+			orgChartHandler.RegisterStaffHierarchyRoutes(staffRoutes)
+
 			// Attendance management routes
 			attendanceRoutes := protected.Group("/attendance")
 			{
@@ -1458,9 +2159,18 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 			salaryHandler.RegisterRoutes(protected, middleware.AuthRequired(jwtService))
 			salaryHandler.RegisterStaffSalaryRoutes(staffRoutes)
 
+			// Staff appraisal and probation review routes
+			appraisalHandler.RegisterRoutes(protected)
+			appraisalHandler.RegisterStaffAppraisalRoutes(staffRoutes)
+
+			// Staff onboarding checklist and exit workflow routes
+			staffChecklistHandler.RegisterRoutes(protected)
+			staffChecklistHandler.RegisterStaffChecklistRoutes(staffRoutes)
+
 			// Payroll management routes
 			payrollHandler.RegisterRoutes(protected)
 			payrollHandler.RegisterStaffPayslipRoutes(staffRoutes)
+			payrollHandler.RegisterStaffBankAccountRoutes(staffRoutes)
 
 			// Academic structure routes (classes, sections, streams)
 			academicHandler.RegisterRoutes(protected)
@@ -1471,6 +2181,15 @@ func setupRouter(cfg *config.Config, log *logger.Logger, db *gorm.DB) *gin.Engin
 			// Substitution management routes
 			timetableHandler.RegisterSubstitutionRoutes(protected)
 
+			// Timetable calendar feed and printable timetable routes
+			timetableHandler.RegisterExportRoutes(protected)
+
+			// Timetable version history, in-force lookup and diff routes
+			timetableHandler.RegisterVersionRoutes(protected)
+
+			// Shift staff and section assignment routes
+			timetableHandler.RegisterShiftAssignmentRoutes(protected)
+
 			// Exam type management routes
 			examHandler.RegisterRoutes(protected)
 
@@ -1550,12 +2269,90 @@ func healthHandler(c *gin.Context) {
 	})
 }
 
-func readyHandler(c *gin.Context) {
-	// TODO: Add database and cache connectivity checks
-	response.OK(c, HealthResponse{
-		Status:    "ready",
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-	})
+// ReadyResponse represents the readiness check response.
+type ReadyResponse struct {
+	Status    string                             `json:"status"`
+	Timestamp string                             `json:"timestamp"`
+	Checks    map[string]healthcheck.CheckResult `json:"checks"`
+}
+
+// readyHandler reports whether the database, its connection pool and file storage are usable.
+// It responds 503 when any check fails so load balancers stop routing traffic to this instance.
+func readyHandler(checker *healthcheck.Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := checker.Run(c.Request.Context())
+
+		resp := ReadyResponse{
+			Status:    "ready",
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Checks:    report.Checks,
+		}
+		if !report.Ready {
+			resp.Status = "not_ready"
+			c.JSON(http.StatusServiceUnavailable, response.Success{Success: false, Data: resp})
+			return
+		}
+
+		response.OK(c, resp)
+	}
+}
+
+// registerRuntimeMetrics registers database pool statistics and job queue depth with the default registry.
+func registerRuntimeMetrics(log *logger.Logger, db *gorm.DB, bulkService *bulk.Service) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Warn("failed to register database pool metrics", zap.Error(err))
+	} else {
+		metrics.Default.MustRegister(metrics.DBStatsMetrics(sqlDB)...)
+	}
+
+	metrics.Default.MustRegister(metrics.NewGaugeFunc(
+		"msls_job_queue_depth",
+		"Bulk operations waiting or in progress, by status.",
+		[]string{"status"},
+		func() []metrics.Sample {
+			ctx, cancel := context.WithTimeout(context.Background(), healthcheck.DefaultTimeout)
+			defer cancel()
+
+			depth, err := bulkService.QueueDepth(ctx)
+			if err != nil {
+				log.Warn("failed to collect job queue depth", zap.Error(err))
+				return nil
+			}
+
+			samples := make([]metrics.Sample, 0, len(depth))
+			for status, count := range depth {
+				samples = append(samples, metrics.Sample{LabelValues: []string{string(status)}, Value: float64(count)})
+			}
+			return samples
+		},
+	))
+}
+
+// newRateLimitStore builds the rate limit counter store selected by RATE_LIMIT_STORE.
+// The Redis store falls back to PostgreSQL when Redis is unreachable.
+// The returned Redis client is nil unless the Redis store is in use.
+func newRateLimitStore(cfg *config.Config, log *logger.Logger, db *gorm.DB) (ratelimit.Store, *redis.Client) {
+	switch cfg.RateLimit.Store {
+	case "redis":
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr(),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		store := ratelimit.NewFallbackStore(
+			ratelimit.NewRedisStore(client),
+			ratelimit.NewPostgresStore(db),
+			func(err error) {
+				log.Warn("rate limit falling back to postgres", zap.Error(err))
+			},
+		)
+		return store, client
+	case "postgres":
+		return ratelimit.NewPostgresStore(db), nil
+	default:
+		return ratelimit.NewMemoryStore(), nil
+	}
 }
 
 func pingHandler(c *gin.Context) {
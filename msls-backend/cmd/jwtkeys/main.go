@@ -0,0 +1,183 @@
+// Package main provides a tool for managing the JWT signing key ring.
+//
+// Rotation procedure:
+//
+//  1. jwtkeys generate -activate-in 1h   (new key is published in the JWKS right away
+//     and starts signing after 1h, giving verifiers time to refresh their caches)
+//  2. jwtkeys retire -kid <old> -overlap 1h   (once the new key signs; old tokens
+//     keep verifying for the overlap, which must exceed JWT_ACCESS_EXPIRES_IN)
+//  3. jwtkeys prune   (removes keys whose overlap has ended)
+//
+// Running API servers pick up changes to the key ring file without a restart.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"msls-backend/internal/services/auth"
+)
+
+const usage = `Usage: jwtkeys <command> [flags]
+
+Commands:
+  generate   Generate a new signing key (-alg RS256|EdDSA, -activate-in)
+  retire     Stop a key from signing and keep it verifying for -overlap
+  prune      Remove keys that no longer verify tokens
+  list       List keys and their state
+
+The key ring file defaults to $JWT_KEYRING_FILE (override with -file).
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "jwtkeys error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return errors.New("missing command")
+	}
+
+	switch args[0] {
+	case "generate":
+		return generate(args[1:])
+	case "retire":
+		return retire(args[1:])
+	case "prune":
+		return prune(args[1:])
+	case "list":
+		return list(args[1:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func generate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	file := fileFlag(fs)
+	alg := fs.String("alg", auth.AlgEdDSA, "signing algorithm (RS256 or EdDSA)")
+	activateIn := fs.Duration("activate-in", 0, "delay before the key starts signing")
+	_ = fs.Parse(args)
+
+	ring, err := loadOrCreate(*file)
+	if err != nil {
+		return err
+	}
+
+	record, err := ring.Generate(*alg, time.Now().Add(*activateIn))
+	if err != nil {
+		return err
+	}
+	if err := ring.Save(*file); err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated %s key %s, signing from %s\n", record.Algorithm, record.KID, record.ActiveFrom.Format(time.RFC3339))
+	return nil
+}
+
+func retire(args []string) error {
+	fs := flag.NewFlagSet("retire", flag.ExitOnError)
+	file := fileFlag(fs)
+	kid := fs.String("kid", "", "key ID to retire")
+	overlap := fs.Duration("overlap", time.Hour, "how long the key keeps verifying tokens after retirement")
+	_ = fs.Parse(args)
+
+	if *kid == "" {
+		return errors.New("-kid is required")
+	}
+
+	ring, err := auth.LoadKeyRing(*file)
+	if err != nil {
+		return err
+	}
+	if err := ring.Retire(*kid, time.Now(), *overlap); err != nil {
+		return err
+	}
+	if err := ring.Save(*file); err != nil {
+		return err
+	}
+
+	fmt.Printf("Retired key %s, verifying until %s\n", *kid, time.Now().Add(*overlap).UTC().Format(time.RFC3339))
+	return nil
+}
+
+func prune(args []string) error {
+	fs := flag.NewFlagSet("prune", flag.ExitOnError)
+	file := fileFlag(fs)
+	_ = fs.Parse(args)
+
+	ring, err := auth.LoadKeyRing(*file)
+	if err != nil {
+		return err
+	}
+	removed := ring.Prune(time.Now())
+	if err := ring.Save(*file); err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed %d expired key(s)\n", removed)
+	return nil
+}
+
+func list(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	file := fileFlag(fs)
+	_ = fs.Parse(args)
+
+	ring, err := auth.LoadKeyRing(*file)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KID\tALG\tSTATE\tACTIVE FROM\tEXPIRES AT")
+	for _, record := range ring.Records() {
+		expires := "-"
+		if record.ExpiresAt != nil {
+			expires = record.ExpiresAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			record.KID, record.Algorithm, keyState(record, now), record.ActiveFrom.Format(time.RFC3339), expires)
+	}
+	return w.Flush()
+}
+
+// keyState describes where a key is in its rotation lifecycle.
+func keyState(record auth.KeyRecord, now time.Time) string {
+	switch {
+	case record.ExpiresAt != nil && !now.Before(*record.ExpiresAt):
+		return "expired"
+	case record.RetiredAt != nil && !now.Before(*record.RetiredAt):
+		return "retired"
+	case now.Before(record.ActiveFrom):
+		return "pending"
+	default:
+		return "active"
+	}
+}
+
+func fileFlag(fs *flag.FlagSet) *string {
+	return fs.String("file", os.Getenv("JWT_KEYRING_FILE"), "key ring file")
+}
+
+// loadOrCreate loads the key ring, or returns an empty one if the file does not exist yet.
+func loadOrCreate(path string) (*auth.KeyRing, error) {
+	if path == "" {
+		return nil, errors.New("key ring file not set (use -file or JWT_KEYRING_FILE)")
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return auth.NewKeyRing(), nil
+	}
+	return auth.LoadKeyRing(path)
+}
@@ -0,0 +1,67 @@
+// Package main runs a local OpenID Connect and SAML 2.0 identity provider for
+// trying single sign-on without a Google Workspace or Microsoft Entra tenant.
+//
+// Every login is approved immediately as the user given on the command line.
+// Configure an identity provider in MSLS with:
+//
+//	OIDC: issuer_url = -issuer, client_id = -client-id, client_secret = -client-secret
+//	SAML: metadata_url = <issuer>/saml/metadata
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"msls-backend/internal/pkg/mockidp"
+)
+
+func main() {
+	addr := flag.String("addr", ":9999", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9999", "public base URL of this server")
+	clientID := flag.String("client-id", "msls", "OIDC client ID")
+	clientSecret := flag.String("client-secret", "msls-secret", "OIDC client secret")
+	subject := flag.String("subject", "mock-user-1", "subject (sub / NameID) of the user")
+	email := flag.String("email", "teacher@example.com", "email address of the user")
+	unverified := flag.Bool("unverified", false, "report the email address as unverified")
+	givenName := flag.String("given-name", "Mock", "given name of the user")
+	familyName := flag.String("family-name", "Teacher", "family name of the user")
+	groups := flag.String("groups", "staff", "comma-separated groups of the user")
+	flag.Parse()
+
+	var groupList []string
+	for _, group := range strings.Split(*groups, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groupList = append(groupList, group)
+		}
+	}
+
+	server, err := mockidp.New(mockidp.Config{
+		Issuer:       strings.TrimRight(*issuer, "/"),
+		ClientID:     *clientID,
+		ClientSecret: *clientSecret,
+		User: mockidp.User{
+			Subject:       *subject,
+			Email:         *email,
+			EmailVerified: !*unverified,
+			GivenName:     *givenName,
+			FamilyName:    *familyName,
+			Groups:        groupList,
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mockidp error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("mock identity provider listening on %s\n", *addr)
+	fmt.Printf("  OIDC issuer:   %s (client %q)\n", *issuer, *clientID)
+	fmt.Printf("  SAML metadata: %s/saml/metadata\n", strings.TrimRight(*issuer, "/"))
+	fmt.Printf("  user:          %s %v\n", *email, groupList)
+	if err := http.ListenAndServe(*addr, server.Handler()); err != nil {
+		fmt.Fprintf(os.Stderr, "mockidp error: %v\n", err)
+		os.Exit(1)
+	}
+}
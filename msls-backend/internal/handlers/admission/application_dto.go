@@ -8,6 +8,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"msls-backend/internal/pkg/database/models"
+	admissionservice "msls-backend/internal/services/admission"
 )
 
 // =============================================================================
@@ -22,6 +23,10 @@ type ApplicationResponse struct {
 	ClassName         string                      `json:"className"`
 	Status            string                      `json:"status"`
 	Source            string                      `json:"source"`
+	QuotaCategory     string                      `json:"quotaCategory"`
+	FamilyID          *string                     `json:"familyId,omitempty"`
+	HasSibling        bool                        `json:"hasSibling"`
+	StaffParentID     *string                     `json:"staffParentId,omitempty"`
 	ApplicantDetails  ApplicantDetailsDTO         `json:"applicantDetails"`
 	ParentInfo        ParentGuardianInfoDTO       `json:"parentInfo"`
 	ApplicationFee    decimal.Decimal             `json:"applicationFee"`
@@ -92,6 +97,7 @@ type CreateApplicationRequest struct {
 	StudentName      string               `json:"studentName" binding:"required"`
 	ClassName        string               `json:"className" binding:"required"`
 	Source           string               `json:"source,omitempty"`
+	QuotaCategory    string               `json:"quotaCategory,omitempty"`
 	ApplicantDetails *ApplicantDetailsDTO `json:"applicantDetails,omitempty"`
 	ParentInfo       *ParentGuardianInfoDTO `json:"parentInfo,omitempty"`
 }
@@ -100,6 +106,7 @@ type CreateApplicationRequest struct {
 type UpdateApplicationRequest struct {
 	StudentName      *string               `json:"studentName,omitempty"`
 	ClassName        *string               `json:"className,omitempty"`
+	QuotaCategory    *string               `json:"quotaCategory,omitempty"`
 	ApplicantDetails *ApplicantDetailsDTO  `json:"applicantDetails,omitempty"`
 	ParentInfo       *ParentGuardianInfoDTO `json:"parentInfo,omitempty"`
 }
@@ -222,7 +229,8 @@ func applicationToResponse(app *models.AdmissionApplication) ApplicationResponse
 		StudentName:       app.StudentName,
 		ClassName:         app.ClassApplying,
 		Status:            string(app.Status),
-		Source:            "", // Source field removed from model
+		Source:            string(app.Source),
+		QuotaCategory:     app.QuotaCategory,
 		ApplicantDetails: ApplicantDetailsDTO{
 			Gender:         app.Gender,
 			DateOfBirth:    dobStr,
@@ -266,6 +274,16 @@ func applicationToResponse(app *models.AdmissionApplication) ApplicationResponse
 		}
 	}
 
+	resp.HasSibling = app.HasSibling
+	if app.FamilyID != nil {
+		familyID := app.FamilyID.String()
+		resp.FamilyID = &familyID
+	}
+	if app.StaffParentID != nil {
+		staffParentID := app.StaffParentID.String()
+		resp.StaffParentID = &staffParentID
+	}
+
 	return resp
 }
 
@@ -299,6 +317,144 @@ func applicationsToResponses(apps []models.AdmissionApplication) []ApplicationRe
 	return responses
 }
 
+// toCreateApplicationRequest maps the applicant and parent fields of a create request.
+// Tenant, session, enquiry and audit fields are set by the caller.
+func toCreateApplicationRequest(req CreateApplicationRequest) admissionservice.CreateApplicationRequest {
+	createReq := admissionservice.CreateApplicationRequest{
+		StudentName:   req.StudentName,
+		ClassApplying: req.ClassName,
+		QuotaCategory: req.QuotaCategory,
+	}
+
+	// Set applicant details if provided
+	if req.ApplicantDetails != nil {
+		createReq.Gender = req.ApplicantDetails.Gender
+		if req.ApplicantDetails.DateOfBirth != "" {
+			if dob, err := time.Parse("2006-01-02", req.ApplicantDetails.DateOfBirth); err == nil {
+				createReq.DateOfBirth = &dob
+			}
+		}
+		createReq.Nationality = req.ApplicantDetails.Nationality
+		createReq.Religion = req.ApplicantDetails.Religion
+		createReq.Category = req.ApplicantDetails.Category
+		createReq.BloodGroup = req.ApplicantDetails.BloodGroup
+		createReq.AddressLine1 = req.ApplicantDetails.Address
+		createReq.City = req.ApplicantDetails.City
+		createReq.State = req.ApplicantDetails.State
+		createReq.PostalCode = req.ApplicantDetails.PinCode
+		createReq.PreviousSchool = req.ApplicantDetails.PreviousSchool
+	}
+
+	// Set parent info if provided
+	if req.ParentInfo != nil {
+		createReq.FatherName = req.ParentInfo.FatherName
+		createReq.FatherPhone = req.ParentInfo.FatherPhone
+		createReq.FatherEmail = req.ParentInfo.FatherEmail
+		createReq.FatherOccupation = req.ParentInfo.FatherOccupation
+		createReq.MotherName = req.ParentInfo.MotherName
+		createReq.MotherPhone = req.ParentInfo.MotherPhone
+		createReq.MotherEmail = req.ParentInfo.MotherEmail
+		createReq.MotherOccupation = req.ParentInfo.MotherOccupation
+		createReq.GuardianName = req.ParentInfo.GuardianName
+		createReq.GuardianPhone = req.ParentInfo.GuardianPhone
+		createReq.GuardianEmail = req.ParentInfo.GuardianEmail
+		createReq.GuardianRelation = req.ParentInfo.GuardianRelation
+	}
+
+	return createReq
+}
+
+// toUpdateApplicationRequest maps the non-empty fields of an update request.
+func toUpdateApplicationRequest(req UpdateApplicationRequest) admissionservice.UpdateApplicationRequest {
+	updateReq := admissionservice.UpdateApplicationRequest{
+		StudentName:   req.StudentName,
+		ClassApplying: req.ClassName,
+		QuotaCategory: req.QuotaCategory,
+	}
+
+	// Set applicant details if provided
+	if req.ApplicantDetails != nil {
+		if req.ApplicantDetails.Gender != "" {
+			updateReq.Gender = &req.ApplicantDetails.Gender
+		}
+		if req.ApplicantDetails.DateOfBirth != "" {
+			// Parse date string to time.Time
+			if dob, err := time.Parse("2006-01-02", req.ApplicantDetails.DateOfBirth); err == nil {
+				updateReq.DateOfBirth = &dob
+			}
+		}
+		if req.ApplicantDetails.Nationality != "" {
+			updateReq.Nationality = &req.ApplicantDetails.Nationality
+		}
+		if req.ApplicantDetails.Religion != "" {
+			updateReq.Religion = &req.ApplicantDetails.Religion
+		}
+		if req.ApplicantDetails.Category != "" {
+			updateReq.Category = &req.ApplicantDetails.Category
+		}
+		if req.ApplicantDetails.BloodGroup != "" {
+			updateReq.BloodGroup = &req.ApplicantDetails.BloodGroup
+		}
+		if req.ApplicantDetails.Address != "" {
+			updateReq.AddressLine1 = &req.ApplicantDetails.Address
+		}
+		if req.ApplicantDetails.City != "" {
+			updateReq.City = &req.ApplicantDetails.City
+		}
+		if req.ApplicantDetails.State != "" {
+			updateReq.State = &req.ApplicantDetails.State
+		}
+		if req.ApplicantDetails.PinCode != "" {
+			updateReq.PostalCode = &req.ApplicantDetails.PinCode
+		}
+		if req.ApplicantDetails.PreviousSchool != "" {
+			updateReq.PreviousSchool = &req.ApplicantDetails.PreviousSchool
+		}
+	}
+
+	// Set parent info if provided
+	if req.ParentInfo != nil {
+		if req.ParentInfo.FatherName != "" {
+			updateReq.FatherName = &req.ParentInfo.FatherName
+		}
+		if req.ParentInfo.FatherPhone != "" {
+			updateReq.FatherPhone = &req.ParentInfo.FatherPhone
+		}
+		if req.ParentInfo.FatherEmail != "" {
+			updateReq.FatherEmail = &req.ParentInfo.FatherEmail
+		}
+		if req.ParentInfo.FatherOccupation != "" {
+			updateReq.FatherOccupation = &req.ParentInfo.FatherOccupation
+		}
+		if req.ParentInfo.MotherName != "" {
+			updateReq.MotherName = &req.ParentInfo.MotherName
+		}
+		if req.ParentInfo.MotherPhone != "" {
+			updateReq.MotherPhone = &req.ParentInfo.MotherPhone
+		}
+		if req.ParentInfo.MotherEmail != "" {
+			updateReq.MotherEmail = &req.ParentInfo.MotherEmail
+		}
+		if req.ParentInfo.MotherOccupation != "" {
+			updateReq.MotherOccupation = &req.ParentInfo.MotherOccupation
+		}
+		if req.ParentInfo.GuardianName != "" {
+			updateReq.GuardianName = &req.ParentInfo.GuardianName
+		}
+		if req.ParentInfo.GuardianPhone != "" {
+			updateReq.GuardianPhone = &req.ParentInfo.GuardianPhone
+		}
+		if req.ParentInfo.GuardianEmail != "" {
+			updateReq.GuardianEmail = &req.ParentInfo.GuardianEmail
+		}
+		if req.ParentInfo.GuardianRelation != "" {
+			updateReq.GuardianRelation = &req.ParentInfo.GuardianRelation
+		}
+	}
+
+	return updateReq
+}
+
 // parentToResponse converts a parent model to response DTO.
 func parentToResponse(p *models.ApplicationParent) ApplicationParentResponse {
 	return ApplicationParentResponse{
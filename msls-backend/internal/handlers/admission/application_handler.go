@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -210,49 +209,11 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		enquiryID = &eid
 	}
 
-	createReq := admissionservice.CreateApplicationRequest{
-		TenantID:      tenantID,
-		SessionID:     sessionID,
-		EnquiryID:     enquiryID,
-		StudentName:   req.StudentName,
-		ClassApplying: req.ClassName,
-		CreatedBy:     &userID,
-	}
-
-	// Set applicant details if provided
-	if req.ApplicantDetails != nil {
-		createReq.Gender = req.ApplicantDetails.Gender
-		if req.ApplicantDetails.DateOfBirth != "" {
-			if dob, err := time.Parse("2006-01-02", req.ApplicantDetails.DateOfBirth); err == nil {
-				createReq.DateOfBirth = &dob
-			}
-		}
-		createReq.Nationality = req.ApplicantDetails.Nationality
-		createReq.Religion = req.ApplicantDetails.Religion
-		createReq.Category = req.ApplicantDetails.Category
-		createReq.BloodGroup = req.ApplicantDetails.BloodGroup
-		createReq.AddressLine1 = req.ApplicantDetails.Address
-		createReq.City = req.ApplicantDetails.City
-		createReq.State = req.ApplicantDetails.State
-		createReq.PostalCode = req.ApplicantDetails.PinCode
-		createReq.PreviousSchool = req.ApplicantDetails.PreviousSchool
-	}
-
-	// Set parent info if provided
-	if req.ParentInfo != nil {
-		createReq.FatherName = req.ParentInfo.FatherName
-		createReq.FatherPhone = req.ParentInfo.FatherPhone
-		createReq.FatherEmail = req.ParentInfo.FatherEmail
-		createReq.FatherOccupation = req.ParentInfo.FatherOccupation
-		createReq.MotherName = req.ParentInfo.MotherName
-		createReq.MotherPhone = req.ParentInfo.MotherPhone
-		createReq.MotherEmail = req.ParentInfo.MotherEmail
-		createReq.MotherOccupation = req.ParentInfo.MotherOccupation
-		createReq.GuardianName = req.ParentInfo.GuardianName
-		createReq.GuardianPhone = req.ParentInfo.GuardianPhone
-		createReq.GuardianEmail = req.ParentInfo.GuardianEmail
-		createReq.GuardianRelation = req.ParentInfo.GuardianRelation
-	}
+	createReq := toCreateApplicationRequest(req)
+	createReq.TenantID = tenantID
+	createReq.SessionID = sessionID
+	createReq.EnquiryID = enquiryID
+	createReq.CreatedBy = &userID
 
 	application, err := h.applicationService.Create(c.Request.Context(), createReq)
 	if err != nil {
@@ -265,6 +226,8 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 			apperrors.Abort(c, apperrors.BadRequest("Student name is required"))
 		case admissionservice.ErrClassApplyingRequired:
 			apperrors.Abort(c, apperrors.BadRequest("Class is required"))
+		case admissionservice.ErrInvalidQuotaCategory:
+			apperrors.Abort(c, apperrors.BadRequest("Invalid quota category"))
 		default:
 			apperrors.Abort(c, apperrors.InternalError("Failed to create application"))
 		}
@@ -311,91 +274,8 @@ func (h *ApplicationHandler) Update(c *gin.Context) {
 		return
 	}
 
-	updateReq := admissionservice.UpdateApplicationRequest{
-		StudentName:   req.StudentName,
-		ClassApplying: req.ClassName,
-		UpdatedBy:     &userID,
-	}
-
-	// Set applicant details if provided
-	if req.ApplicantDetails != nil {
-		if req.ApplicantDetails.Gender != "" {
-			updateReq.Gender = &req.ApplicantDetails.Gender
-		}
-		if req.ApplicantDetails.DateOfBirth != "" {
-			// Parse date string to time.Time
-			if dob, err := time.Parse("2006-01-02", req.ApplicantDetails.DateOfBirth); err == nil {
-				updateReq.DateOfBirth = &dob
-			}
-		}
-		if req.ApplicantDetails.Nationality != "" {
-			updateReq.Nationality = &req.ApplicantDetails.Nationality
-		}
-		if req.ApplicantDetails.Religion != "" {
-			updateReq.Religion = &req.ApplicantDetails.Religion
-		}
-		if req.ApplicantDetails.Category != "" {
-			updateReq.Category = &req.ApplicantDetails.Category
-		}
-		if req.ApplicantDetails.BloodGroup != "" {
-			updateReq.BloodGroup = &req.ApplicantDetails.BloodGroup
-		}
-		if req.ApplicantDetails.Address != "" {
-			updateReq.AddressLine1 = &req.ApplicantDetails.Address
-		}
-		if req.ApplicantDetails.City != "" {
-			updateReq.City = &req.ApplicantDetails.City
-		}
-		if req.ApplicantDetails.State != "" {
-			updateReq.State = &req.ApplicantDetails.State
-		}
-		if req.ApplicantDetails.PinCode != "" {
-			updateReq.PostalCode = &req.ApplicantDetails.PinCode
-		}
-		if req.ApplicantDetails.PreviousSchool != "" {
-			updateReq.PreviousSchool = &req.ApplicantDetails.PreviousSchool
-		}
-	}
-
-	// Set parent info if provided
-	if req.ParentInfo != nil {
-		if req.ParentInfo.FatherName != "" {
-			updateReq.FatherName = &req.ParentInfo.FatherName
-		}
-		if req.ParentInfo.FatherPhone != "" {
-			updateReq.FatherPhone = &req.ParentInfo.FatherPhone
-		}
-		if req.ParentInfo.FatherEmail != "" {
-			updateReq.FatherEmail = &req.ParentInfo.FatherEmail
-		}
-		if req.ParentInfo.FatherOccupation != "" {
-			updateReq.FatherOccupation = &req.ParentInfo.FatherOccupation
-		}
-		if req.ParentInfo.MotherName != "" {
-			updateReq.MotherName = &req.ParentInfo.MotherName
-		}
-		if req.ParentInfo.MotherPhone != "" {
-			updateReq.MotherPhone = &req.ParentInfo.MotherPhone
-		}
-		if req.ParentInfo.MotherEmail != "" {
-			updateReq.MotherEmail = &req.ParentInfo.MotherEmail
-		}
-		if req.ParentInfo.MotherOccupation != "" {
-			updateReq.MotherOccupation = &req.ParentInfo.MotherOccupation
-		}
-		if req.ParentInfo.GuardianName != "" {
-			updateReq.GuardianName = &req.ParentInfo.GuardianName
-		}
-		if req.ParentInfo.GuardianPhone != "" {
-			updateReq.GuardianPhone = &req.ParentInfo.GuardianPhone
-		}
-		if req.ParentInfo.GuardianEmail != "" {
-			updateReq.GuardianEmail = &req.ParentInfo.GuardianEmail
-		}
-		if req.ParentInfo.GuardianRelation != "" {
-			updateReq.GuardianRelation = &req.ParentInfo.GuardianRelation
-		}
-	}
+	updateReq := toUpdateApplicationRequest(req)
+	updateReq.UpdatedBy = &userID
 
 	application, err := h.applicationService.Update(c.Request.Context(), tenantID, id, updateReq)
 	if err != nil {
@@ -404,6 +284,8 @@ func (h *ApplicationHandler) Update(c *gin.Context) {
 			apperrors.Abort(c, apperrors.NotFound("Application not found"))
 		case admissionservice.ErrCannotUpdateApplication:
 			apperrors.Abort(c, apperrors.BadRequest("Cannot update application in current status"))
+		case admissionservice.ErrInvalidQuotaCategory:
+			apperrors.Abort(c, apperrors.BadRequest("Invalid quota category"))
 		default:
 			apperrors.Abort(c, apperrors.InternalError("Failed to update application"))
 		}
@@ -461,6 +343,47 @@ func (h *ApplicationHandler) Submit(c *gin.Context) {
 	response.OK(c, applicationToResponse(application))
 }
 
+// RefreshFamily re-detects the family, siblings and staff parent of an application.
+// @Summary Refresh application family
+// @Description Re-match parent contacts against families, enrolled siblings and staff
+// @Tags Admission Applications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Application ID" format(uuid)
+// @Success 200 {object} response.Success{data=ApplicationResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/applications/{id}/refresh-family [post]
+func (h *ApplicationHandler) RefreshFamily(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	application, err := h.applicationService.RefreshFamily(c.Request.Context(), tenantID, id)
+	if err != nil {
+		switch err {
+		case admissionservice.ErrApplicationNotFound:
+			apperrors.Abort(c, apperrors.NotFound("Application not found"))
+		default:
+			apperrors.Abort(c, apperrors.InternalError("Failed to refresh application family"))
+		}
+		return
+	}
+
+	response.OK(c, applicationToResponse(application))
+}
+
 // UpdateStage updates the stage of an application.
 // @Summary Update application stage
 // @Description Update the processing stage of an application
@@ -4,7 +4,10 @@ package admission
 import (
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"msls-backend/internal/pkg/database/models"
+	admissionservice "msls-backend/internal/services/admission"
 )
 
 // ============================================================================
@@ -33,7 +36,14 @@ type BulkDecisionRequest struct {
 
 // GenerateOfferLetterRequest represents the request body for generating an offer letter.
 type GenerateOfferLetterRequest struct {
-	ValidUntil *string `json:"validUntil,omitempty"`
+	ValidUntil *string           `json:"validUntil,omitempty"`
+	Fees       []OfferFeeRequest `json:"fees,omitempty" binding:"omitempty,dive"`
+}
+
+// OfferFeeRequest represents a fee due on acceptance printed on the offer letter.
+type OfferFeeRequest struct {
+	Name   string          `json:"name" binding:"required,max=100"`
+	Amount decimal.Decimal `json:"amount"`
 }
 
 // AcceptOfferRequest represents the request body for accepting an offer.
@@ -57,29 +67,57 @@ type EnrollRequest struct {
 
 // DecisionResponse represents an admission decision in API responses.
 type DecisionResponse struct {
-	ID               string  `json:"id"`
-	ApplicationID    string  `json:"applicationId"`
-	Decision         string  `json:"decision"`
-	DecisionDate     string  `json:"decisionDate"`
-	DecidedBy        *string `json:"decidedBy,omitempty"`
-	SectionAssigned  *string `json:"sectionAssigned,omitempty"`
-	WaitlistPosition *int    `json:"waitlistPosition,omitempty"`
-	RejectionReason  *string `json:"rejectionReason,omitempty"`
-	OfferLetterURL   *string `json:"offerLetterUrl,omitempty"`
-	OfferValidUntil  *string `json:"offerValidUntil,omitempty"`
-	OfferAccepted    *bool   `json:"offerAccepted,omitempty"`
-	OfferAcceptedAt  *string `json:"offerAcceptedAt,omitempty"`
-	Remarks          *string `json:"remarks,omitempty"`
-	CreatedAt        string  `json:"createdAt"`
-	UpdatedAt        string  `json:"updatedAt"`
+	ID               string             `json:"id"`
+	ApplicationID    string             `json:"applicationId"`
+	Decision         string             `json:"decision"`
+	DecisionDate     string             `json:"decisionDate"`
+	DecidedBy        *string            `json:"decidedBy,omitempty"`
+	SectionAssigned  *string            `json:"sectionAssigned,omitempty"`
+	WaitlistPosition *int               `json:"waitlistPosition,omitempty"`
+	RejectionReason  *string            `json:"rejectionReason,omitempty"`
+	OfferLetterURL   *string            `json:"offerLetterUrl,omitempty"`
+	OfferValidUntil  *string            `json:"offerValidUntil,omitempty"`
+	OfferAccepted    *bool              `json:"offerAccepted,omitempty"`
+	OfferAcceptedAt  *string            `json:"offerAcceptedAt,omitempty"`
+	OfferViewedAt    *string            `json:"offerViewedAt,omitempty"`
+	OfferLapsedAt    *string            `json:"offerLapsedAt,omitempty"`
+	AllocatedQuota   *string            `json:"allocatedQuota,omitempty"`
+	OfferFees        []OfferFeeResponse `json:"offerFees,omitempty"`
+	Remarks          *string            `json:"remarks,omitempty"`
+	CreatedAt        string             `json:"createdAt"`
+	UpdatedAt        string             `json:"updatedAt"`
+}
+
+// OfferFeeResponse represents a fee due on acceptance of an offer.
+type OfferFeeResponse struct {
+	Name   string          `json:"name"`
+	Amount decimal.Decimal `json:"amount"`
 }
 
 // OfferLetterResponse represents the offer letter generation response.
 type OfferLetterResponse struct {
-	URL          string `json:"url"`
-	ValidUntil   string `json:"validUntil"`
-	GeneratedAt  string `json:"generatedAt"`
+	URL           string `json:"url"`
+	ValidUntil    string `json:"validUntil"`
+	GeneratedAt   string `json:"generatedAt"`
 	ApplicationID string `json:"applicationId"`
+	Token         string `json:"token"`
+	PublicPath    string `json:"publicPath"`
+}
+
+// PublicOfferResponse represents an offer as shown through the public offer link.
+type PublicOfferResponse struct {
+	SchoolName        string             `json:"schoolName"`
+	ApplicationNumber string             `json:"applicationNumber"`
+	StudentName       string             `json:"studentName"`
+	ClassName         string             `json:"className"`
+	SectionAssigned   *string            `json:"sectionAssigned,omitempty"`
+	SessionName       string             `json:"sessionName,omitempty"`
+	Currency          string             `json:"currency"`
+	Fees              []OfferFeeResponse `json:"fees"`
+	TotalFees         decimal.Decimal    `json:"totalFees"`
+	ValidUntil        string             `json:"validUntil,omitempty"`
+	Status            string             `json:"status"`
+	AcceptedAt        *string            `json:"acceptedAt,omitempty"`
 }
 
 // EnrollmentResponse represents the enrollment completion response.
@@ -96,9 +134,9 @@ type EnrollmentResponse struct {
 
 // BulkDecisionResponse represents the bulk decision response.
 type BulkDecisionResponse struct {
-	Successful int                `json:"successful"`
-	Failed     int                `json:"failed"`
-	Decisions  []DecisionResponse `json:"decisions"`
+	Successful int                 `json:"successful"`
+	Failed     int                 `json:"failed"`
+	Decisions  []DecisionResponse  `json:"decisions"`
 	Errors     []BulkDecisionError `json:"errors,omitempty"`
 }
 
@@ -124,6 +162,7 @@ func decisionToResponse(d *models.AdmissionDecision) DecisionResponse {
 		RejectionReason:  d.RejectionReason,
 		OfferLetterURL:   d.OfferLetterURL,
 		OfferAccepted:    d.OfferAccepted,
+		AllocatedQuota:   d.AllocatedQuota,
 		Remarks:          d.Remarks,
 		CreatedAt:        d.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:        d.UpdatedAt.Format(time.RFC3339),
@@ -144,6 +183,66 @@ func decisionToResponse(d *models.AdmissionDecision) DecisionResponse {
 		resp.OfferAcceptedAt = &acceptedAt
 	}
 
+	if d.OfferViewedAt != nil {
+		viewedAt := d.OfferViewedAt.Format(time.RFC3339)
+		resp.OfferViewedAt = &viewedAt
+	}
+
+	if d.OfferLapsedAt != nil {
+		lapsedAt := d.OfferLapsedAt.Format(time.RFC3339)
+		resp.OfferLapsedAt = &lapsedAt
+	}
+
+	if len(d.OfferFees) > 0 {
+		resp.OfferFees = offerFeesToResponse(d.OfferFees)
+	}
+
+	return resp
+}
+
+// offerFeesToResponse converts offer fee items to responses.
+func offerFeesToResponse(fees models.OfferFeeItems) []OfferFeeResponse {
+	responses := make([]OfferFeeResponse, len(fees))
+	for i, fee := range fees {
+		responses[i] = OfferFeeResponse{Name: fee.Name, Amount: fee.Amount}
+	}
+	return responses
+}
+
+// offerToPublicResponse converts an offer view to the public offer response.
+func offerToPublicResponse(offer *admissionservice.OfferView) PublicOfferResponse {
+	d := offer.Decision
+	resp := PublicOfferResponse{
+		SchoolName:        offer.SchoolName,
+		ApplicationNumber: offer.Application.ApplicationNumber,
+		StudentName:       offer.Application.StudentName,
+		ClassName:         offer.Application.ClassApplying,
+		SectionAssigned:   d.SectionAssigned,
+		Currency:          offer.Currency,
+		Fees:              offerFeesToResponse(d.OfferFees),
+		TotalFees:         d.OfferFees.Total(),
+		Status:            "open",
+	}
+
+	if offer.Application.Session != nil {
+		resp.SessionName = offer.Application.Session.Name
+	}
+
+	if d.OfferValidUntil != nil {
+		resp.ValidUntil = d.OfferValidUntil.Format("2006-01-02")
+	}
+
+	switch {
+	case d.IsOfferAccepted():
+		resp.Status = "accepted"
+		if d.OfferAcceptedAt != nil {
+			acceptedAt := d.OfferAcceptedAt.Format(time.RFC3339)
+			resp.AcceptedAt = &acceptedAt
+		}
+	case d.OfferLapsedAt != nil:
+		resp.Status = "lapsed"
+	}
+
 	return resp
 }
 
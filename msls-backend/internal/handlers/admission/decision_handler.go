@@ -2,7 +2,10 @@
 package admission
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -111,6 +114,8 @@ func (h *DecisionHandler) MakeDecision(c *gin.Context) {
 			apperrors.Abort(c, apperrors.BadRequest("Rejection reason is required for rejected decision"))
 		case admissionservice.ErrInvalidDecisionType:
 			apperrors.Abort(c, apperrors.BadRequest("Invalid decision type"))
+		case admissionservice.ErrNoSeatsAvailable:
+			apperrors.Abort(c, apperrors.Conflict("No seats available in the applicant's quota or the general pool"))
 		default:
 			apperrors.Abort(c, apperrors.InternalError("Failed to make decision"))
 		}
@@ -270,7 +275,8 @@ func (h *DecisionHandler) GetDecision(c *gin.Context) {
 
 // GenerateOfferLetter generates an offer letter for an approved application.
 // @Summary Generate offer letter
-// @Description Generate an offer letter PDF for an approved application
+// @Description Generate the offer letter PDF for an approved application and issue a public acceptance link.
+// @Description Regenerating replaces the letter and invalidates the previous link.
 // @Tags Admission Decisions
 // @Accept json
 // @Produce json
@@ -301,7 +307,10 @@ func (h *DecisionHandler) GenerateOfferLetter(c *gin.Context) {
 
 	var req GenerateOfferLetterRequest
 	// Bind JSON is optional here
-	_ = c.ShouldBindJSON(&req)
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
 
 	// Parse validity date
 	var validUntil *time.Time
@@ -314,20 +323,36 @@ func (h *DecisionHandler) GenerateOfferLetter(c *gin.Context) {
 		validUntil = &t
 	}
 
+	fees := make(models.OfferFeeItems, 0, len(req.Fees))
+	for _, fee := range req.Fees {
+		fees = append(fees, models.OfferFeeItem{Name: fee.Name, Amount: fee.Amount})
+	}
+
 	generateReq := admissionservice.GenerateOfferLetterRequest{
 		TenantID:      tenantID,
 		ApplicationID: applicationID,
 		ValidUntil:    validUntil,
+		Fees:          fees,
 		GeneratedBy:   &userID,
 	}
 
-	decision, err := h.decisionService.GenerateOfferLetter(c.Request.Context(), generateReq)
+	decision, token, err := h.decisionService.GenerateOfferLetter(c.Request.Context(), generateReq)
 	if err != nil {
 		switch err {
 		case admissionservice.ErrDecisionNotFound:
 			apperrors.Abort(c, apperrors.NotFound("Decision not found"))
+		case admissionservice.ErrApplicationNotFound:
+			apperrors.Abort(c, apperrors.NotFound("Application not found"))
 		case admissionservice.ErrOfferNotFound:
 			apperrors.Abort(c, apperrors.BadRequest("Application is not approved"))
+		case admissionservice.ErrOfferAlreadyAccepted:
+			apperrors.Abort(c, apperrors.BadRequest("Offer has already been accepted"))
+		case admissionservice.ErrOfferLapsed:
+			apperrors.Abort(c, apperrors.BadRequest("Offer has lapsed"))
+		case admissionservice.ErrOfferExpired:
+			apperrors.Abort(c, apperrors.BadRequest("Offer validity date is in the past"))
+		case admissionservice.ErrInvalidOfferFee:
+			apperrors.Abort(c, apperrors.BadRequest("Each fee needs a name and a non-negative amount"))
 		default:
 			apperrors.Abort(c, apperrors.InternalError("Failed to generate offer letter"))
 		}
@@ -349,11 +374,62 @@ func (h *DecisionHandler) GenerateOfferLetter(c *gin.Context) {
 		ValidUntil:    validUntilStr,
 		GeneratedAt:   time.Now().Format(time.RFC3339),
 		ApplicationID: applicationID.String(),
+		Token:         token,
+		PublicPath:    "/api/v1/public/offers/" + token,
 	}
 
 	response.OK(c, resp)
 }
 
+// DownloadOfferLetter downloads the offer letter PDF of an application.
+// @Summary Download offer letter
+// @Description Download the generated offer letter PDF of an application
+// @Tags Admission Decisions
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Application ID" format(uuid)
+// @Success 200 {file} binary
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/applications/{id}/offer-letter.pdf [get]
+func (h *DecisionHandler) DownloadOfferLetter(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	applicationIDParam := c.Param("id")
+	applicationID, err := uuid.Parse(applicationIDParam)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	pdf, err := h.decisionService.GetOfferLetter(c.Request.Context(), tenantID, applicationID)
+	if err != nil {
+		switch err {
+		case admissionservice.ErrDecisionNotFound:
+			apperrors.Abort(c, apperrors.NotFound("Decision not found"))
+		case admissionservice.ErrOfferLetterNotGenerated:
+			apperrors.Abort(c, apperrors.NotFound("Offer letter has not been generated"))
+		default:
+			apperrors.Abort(c, apperrors.InternalError("Failed to download offer letter"))
+		}
+		return
+	}
+
+	writeOfferLetter(c, applicationID.String(), pdf)
+}
+
+// writeOfferLetter writes an offer letter PDF as a file download.
+func writeOfferLetter(c *gin.Context, name string, pdf []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"offer-letter-%s.pdf\"", name))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
 // AcceptOffer accepts an offer for an application.
 // @Summary Accept offer
 // @Description Accept an admission offer
@@ -406,6 +482,8 @@ func (h *DecisionHandler) AcceptOffer(c *gin.Context) {
 			apperrors.Abort(c, apperrors.BadRequest("Offer has already been accepted"))
 		case admissionservice.ErrOfferExpired:
 			apperrors.Abort(c, apperrors.BadRequest("Offer has expired"))
+		case admissionservice.ErrOfferLapsed:
+			apperrors.Abort(c, apperrors.BadRequest("Offer has lapsed"))
 		default:
 			apperrors.Abort(c, apperrors.InternalError("Failed to accept offer"))
 		}
@@ -536,6 +614,8 @@ func (h *DecisionHandler) PromoteFromWaitlist(c *gin.Context) {
 			apperrors.Abort(c, apperrors.NotFound("Decision not found"))
 		case admissionservice.ErrInvalidDecisionType:
 			apperrors.Abort(c, apperrors.BadRequest("Application is not waitlisted"))
+		case admissionservice.ErrNoSeatsAvailable:
+			apperrors.Abort(c, apperrors.Conflict("No seats available in the applicant's quota or the general pool"))
 		default:
 			apperrors.Abort(c, apperrors.InternalError("Failed to promote from waitlist"))
 		}
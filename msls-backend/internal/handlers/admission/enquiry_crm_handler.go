@@ -0,0 +1,415 @@
+package admission
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+	admissionservice "msls-backend/internal/services/admission"
+)
+
+// WorkQueue returns a counsellor's overdue and due-today follow-ups.
+// @Summary Get counsellor work queue
+// @Description Get open enquiries with a follow-up overdue or due on the given date, highest lead score first. Defaults to the current user's queue.
+// @Tags Enquiries
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param assignedTo query string false "Counsellor user ID (defaults to the current user)"
+// @Param branchId query string false "Filter by branch ID"
+// @Param date query string false "Queue date (YYYY-MM-DD, defaults to today)"
+// @Success 200 {object} response.Success{data=WorkQueueResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/enquiries/work-queue [get]
+func (h *EnquiryHandler) WorkQueue(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var params WorkQueueParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	filter := admissionservice.WorkQueueFilter{
+		TenantID:   tenantID,
+		AssignedTo: currentUserID(c),
+	}
+	if params.AssignedTo != "" {
+		filter.AssignedTo = parseUUID(params.AssignedTo)
+		if filter.AssignedTo == nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid assigned to ID"))
+			return
+		}
+	}
+	if params.BranchID != "" {
+		filter.BranchID = parseUUID(params.BranchID)
+		if filter.BranchID == nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid branch ID"))
+			return
+		}
+	}
+	if params.Date != "" {
+		date := parseDate(params.Date)
+		if date == nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid date format (use YYYY-MM-DD)"))
+			return
+		}
+		filter.Date = *date
+	}
+
+	queue, err := h.enquiryService.WorkQueue(c.Request.Context(), filter)
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to retrieve work queue")
+		return
+	}
+
+	response.OK(c, workQueueToResponse(queue))
+}
+
+// GetLeadScore returns the lead score breakdown for an enquiry.
+// @Summary Get enquiry lead score
+// @Description Get the lead score of an enquiry broken down by source, responsiveness and seat availability
+// @Tags Enquiries
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Enquiry ID" format(uuid)
+// @Success 200 {object} response.Success{data=LeadScoreResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/enquiries/{id}/lead-score [get]
+func (h *EnquiryHandler) GetLeadScore(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	enquiryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid enquiry ID"))
+		return
+	}
+
+	score, err := h.enquiryService.GetLeadScore(c.Request.Context(), tenantID, enquiryID)
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to calculate lead score")
+		return
+	}
+
+	response.OK(c, LeadScoreResponse{
+		Source:           score.Source,
+		Responsiveness:   score.Responsiveness,
+		SeatAvailability: score.SeatAvailability,
+		Total:            score.Total,
+	})
+}
+
+// RecalculateLeadScores refreshes the stored lead score of every open enquiry.
+// @Summary Recalculate lead scores
+// @Description Recalculate the lead score of every open enquiry, e.g. after seat configurations change
+// @Tags Enquiries
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {object} response.Success{data=EnquiryBatchResponse}
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/enquiries/lead-scores/recalculate [post]
+func (h *EnquiryHandler) RecalculateLeadScores(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	count, err := h.enquiryService.RecalculateLeadScores(c.Request.Context(), tenantID)
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to recalculate lead scores")
+		return
+	}
+
+	response.OK(c, EnquiryBatchResponse{Count: count})
+}
+
+// Assign assigns an enquiry to a counsellor.
+// @Summary Assign enquiry
+// @Description Assign an open enquiry to a counsellor in the counsellor pool
+// @Tags Enquiries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Enquiry ID" format(uuid)
+// @Param request body AssignEnquiryRequest true "Counsellor"
+// @Success 200 {object} response.Success{data=EnquiryResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/enquiries/{id}/assign [post]
+func (h *EnquiryHandler) Assign(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	enquiryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid enquiry ID"))
+		return
+	}
+
+	var req AssignEnquiryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	enquiry, err := h.enquiryService.AssignEnquiry(c.Request.Context(), tenantID, enquiryID,
+		uuid.MustParse(req.CounsellorID), currentUserID(c))
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to assign enquiry")
+		return
+	}
+
+	response.OK(c, enquiryToResponse(enquiry))
+}
+
+// Distribute assigns unassigned open enquiries round-robin across the counsellor pool.
+// @Summary Distribute unassigned enquiries
+// @Description Assign every open unassigned enquiry round-robin across counsellors, highest lead score first
+// @Tags Enquiries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body DistributeEnquiriesRequest false "Optional branch filter"
+// @Success 200 {object} response.Success{data=EnquiryBatchResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/enquiries/distribute [post]
+func (h *EnquiryHandler) Distribute(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req DistributeEnquiriesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+			return
+		}
+	}
+
+	count, err := h.enquiryService.DistributeUnassigned(c.Request.Context(), tenantID, parseUUID(req.BranchID))
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to distribute enquiries")
+		return
+	}
+
+	response.OK(c, EnquiryBatchResponse{Count: count})
+}
+
+// ListCounsellors returns the enquiry counsellor pool.
+// @Summary List enquiry counsellors
+// @Description Get the counsellors enquiries are assigned to, with their open enquiry counts
+// @Tags Enquiries
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {object} response.Success{data=CounsellorListResponse}
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/enquiry-counsellors [get]
+func (h *EnquiryHandler) ListCounsellors(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	counsellors, err := h.enquiryService.ListCounsellors(c.Request.Context(), tenantID)
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to retrieve counsellors")
+		return
+	}
+
+	response.OK(c, counsellorsToResponse(counsellors))
+}
+
+// AddCounsellor adds a user to the enquiry counsellor pool.
+// @Summary Add enquiry counsellor
+// @Description Add an active user to the counsellor pool used for enquiry assignment
+// @Tags Enquiries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body AddCounsellorRequest true "User"
+// @Success 201 {object} response.Success{data=CounsellorListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/enquiry-counsellors [post]
+func (h *EnquiryHandler) AddCounsellor(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req AddCounsellorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.enquiryService.AddCounsellor(ctx, tenantID, uuid.MustParse(req.UserID), currentUserID(c)); err != nil {
+		handleEnquiryCRMError(c, err, "Failed to add counsellor")
+		return
+	}
+
+	counsellors, err := h.enquiryService.ListCounsellors(ctx, tenantID)
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to retrieve counsellors")
+		return
+	}
+
+	response.Created(c, counsellorsToResponse(counsellors))
+}
+
+// RemoveCounsellor removes a user from the enquiry counsellor pool.
+// @Summary Remove enquiry counsellor
+// @Description Remove a user from the counsellor pool. Enquiries already assigned to them stay assigned.
+// @Tags Enquiries
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param userId path string true "User ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/enquiry-counsellors/{userId} [delete]
+func (h *EnquiryHandler) RemoveCounsellor(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid user ID"))
+		return
+	}
+
+	if err := h.enquiryService.RemoveCounsellor(c.Request.Context(), tenantID, userID); err != nil {
+		handleEnquiryCRMError(c, err, "Failed to remove counsellor")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// GetSettings returns the enquiry assignment and auto-close settings.
+// @Summary Get enquiry settings
+// @Description Get the tenant's enquiry auto-assignment and inactivity auto-close settings
+// @Tags Enquiries
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {object} response.Success{data=EnquirySettingsResponse}
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/enquiry-settings [get]
+func (h *EnquiryHandler) GetSettings(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	settings, err := h.enquiryService.GetCRMSettings(c.Request.Context(), tenantID)
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to retrieve enquiry settings")
+		return
+	}
+
+	response.OK(c, EnquirySettingsResponse{
+		AutoAssign:         settings.AutoAssign,
+		AutoCloseAfterDays: settings.AutoCloseAfterDays,
+	})
+}
+
+// UpdateSettings updates the enquiry assignment and auto-close settings.
+// @Summary Update enquiry settings
+// @Description Turn auto-assignment of new enquiries on or off and set the inactivity period after which open enquiries close (0 disables auto-close)
+// @Tags Enquiries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body UpdateEnquirySettingsRequest true "Settings"
+// @Success 200 {object} response.Success{data=EnquirySettingsResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/enquiry-settings [put]
+func (h *EnquiryHandler) UpdateSettings(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req UpdateEnquirySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	settings, err := h.enquiryService.UpdateCRMSettings(c.Request.Context(), admissionservice.UpdateCRMSettingsRequest{
+		TenantID:           tenantID,
+		AutoAssign:         req.AutoAssign,
+		AutoCloseAfterDays: req.AutoCloseAfterDays,
+		UpdatedBy:          currentUserID(c),
+	})
+	if err != nil {
+		handleEnquiryCRMError(c, err, "Failed to update enquiry settings")
+		return
+	}
+
+	response.OK(c, EnquirySettingsResponse{
+		AutoAssign:         settings.AutoAssign,
+		AutoCloseAfterDays: settings.AutoCloseAfterDays,
+	})
+}
+
+// handleEnquiryCRMError maps enquiry CRM service errors to HTTP responses.
+func handleEnquiryCRMError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, admissionservice.ErrTenantIDRequired):
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+	case errors.Is(err, admissionservice.ErrEnquiryNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Enquiry not found"))
+	case errors.Is(err, admissionservice.ErrEnquiryClosed):
+		apperrors.Abort(c, apperrors.BadRequest("Enquiry is closed and cannot be assigned"))
+	case errors.Is(err, admissionservice.ErrUserNotFound):
+		apperrors.Abort(c, apperrors.NotFound("User not found"))
+	case errors.Is(err, admissionservice.ErrCounsellorNotFound):
+		apperrors.Abort(c, apperrors.NotFound("User is not an enquiry counsellor"))
+	case errors.Is(err, admissionservice.ErrCounsellorAlreadyAdded):
+		apperrors.Abort(c, apperrors.Conflict("User is already an enquiry counsellor"))
+	case errors.Is(err, admissionservice.ErrNoCounsellors):
+		apperrors.Abort(c, apperrors.BadRequest("Add enquiry counsellors before distributing enquiries"))
+	case errors.Is(err, admissionservice.ErrInvalidAutoCloseDays):
+		apperrors.Abort(c, apperrors.BadRequest("Auto-close days must be zero or more"))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
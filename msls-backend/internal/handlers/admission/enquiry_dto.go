@@ -81,6 +81,34 @@ type ListEnquiriesParams struct {
 	PageSize      int    `form:"pageSize"`
 }
 
+// WorkQueueParams represents query parameters for a counsellor work queue.
+type WorkQueueParams struct {
+	AssignedTo string `form:"assignedTo"`
+	BranchID   string `form:"branchId"`
+	Date       string `form:"date"`
+}
+
+// AssignEnquiryRequest represents the request body for assigning an enquiry.
+type AssignEnquiryRequest struct {
+	CounsellorID string `json:"counsellorId" binding:"required,uuid"`
+}
+
+// DistributeEnquiriesRequest represents the request body for round-robin distribution.
+type DistributeEnquiriesRequest struct {
+	BranchID string `json:"branchId" binding:"omitempty,uuid"`
+}
+
+// AddCounsellorRequest represents the request body for adding a counsellor.
+type AddCounsellorRequest struct {
+	UserID string `json:"userId" binding:"required,uuid"`
+}
+
+// UpdateEnquirySettingsRequest represents the request body for updating enquiry CRM settings.
+type UpdateEnquirySettingsRequest struct {
+	AutoAssign         *bool `json:"autoAssign"`
+	AutoCloseAfterDays *int  `json:"autoCloseAfterDays" binding:"omitempty,min=0,max=365"`
+}
+
 // ============================================================================
 // Response DTOs
 // ============================================================================
@@ -104,6 +132,10 @@ type EnquiryResponse struct {
 	Status                 string  `json:"status"`
 	FollowUpDate           *string `json:"followUpDate,omitempty"`
 	AssignedTo             *string `json:"assignedTo,omitempty"`
+	AssignedAt             *string `json:"assignedAt,omitempty"`
+	LeadScore              int     `json:"leadScore"`
+	LastActivityAt         *string `json:"lastActivityAt,omitempty"`
+	ClosedReason           string  `json:"closedReason,omitempty"`
 	ConvertedApplicationID *string `json:"convertedApplicationId,omitempty"`
 	CreatedAt              string  `json:"createdAt"`
 	UpdatedAt              string  `json:"updatedAt"`
@@ -124,6 +156,45 @@ type FollowUpResponse struct {
 	CreatedBy    *string `json:"createdBy,omitempty"`
 }
 
+// WorkQueueResponse represents a counsellor's overdue and due follow-ups.
+type WorkQueueResponse struct {
+	Date     string            `json:"date"`
+	Overdue  []EnquiryResponse `json:"overdue"`
+	DueToday []EnquiryResponse `json:"dueToday"`
+}
+
+// LeadScoreResponse represents the breakdown of an enquiry's lead score.
+type LeadScoreResponse struct {
+	Source           int `json:"source"`
+	Responsiveness   int `json:"responsiveness"`
+	SeatAvailability int `json:"seatAvailability"`
+	Total            int `json:"total"`
+}
+
+// CounsellorResponse represents an enquiry counsellor in API responses.
+type CounsellorResponse struct {
+	UserID         string  `json:"userId"`
+	Name           string  `json:"name"`
+	LastAssignedAt *string `json:"lastAssignedAt,omitempty"`
+	OpenEnquiries  int64   `json:"openEnquiries"`
+}
+
+// CounsellorListResponse represents the counsellor pool.
+type CounsellorListResponse struct {
+	Counsellors []CounsellorResponse `json:"counsellors"`
+}
+
+// EnquirySettingsResponse represents enquiry CRM settings in API responses.
+type EnquirySettingsResponse struct {
+	AutoAssign         bool `json:"autoAssign"`
+	AutoCloseAfterDays int  `json:"autoCloseAfterDays"`
+}
+
+// EnquiryBatchResponse reports how many enquiries a bulk operation touched.
+type EnquiryBatchResponse struct {
+	Count int `json:"count"`
+}
+
 // EnquiryListResponse represents a list of enquiries with pagination.
 type EnquiryListResponse struct {
 	Enquiries []EnquiryResponse `json:"enquiries"`
@@ -157,6 +228,8 @@ func enquiryToResponse(e *admission.AdmissionEnquiry) EnquiryResponse {
 		ReferralDetails: e.ReferralDetails,
 		Remarks:         e.Remarks,
 		Status:          string(e.Status),
+		LeadScore:       e.LeadScore,
+		ClosedReason:    e.ClosedReason,
 		CreatedAt:       e.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:       e.UpdatedAt.Format(time.RFC3339),
 	}
@@ -186,6 +259,16 @@ func enquiryToResponse(e *admission.AdmissionEnquiry) EnquiryResponse {
 		resp.AssignedTo = &assignedTo
 	}
 
+	if e.AssignedAt != nil {
+		assignedAt := e.AssignedAt.Format(time.RFC3339)
+		resp.AssignedAt = &assignedAt
+	}
+
+	if e.LastActivityAt != nil {
+		lastActivityAt := e.LastActivityAt.Format(time.RFC3339)
+		resp.LastActivityAt = &lastActivityAt
+	}
+
 	if e.ConvertedApplicationID != nil {
 		appID := e.ConvertedApplicationID.String()
 		resp.ConvertedApplicationID = &appID
@@ -251,6 +334,32 @@ func followUpsToResponses(followUps []admission.EnquiryFollowUp) []FollowUpRespo
 	return responses
 }
 
+// workQueueToResponse converts a WorkQueue to a WorkQueueResponse.
+func workQueueToResponse(q *admission.WorkQueue) WorkQueueResponse {
+	return WorkQueueResponse{
+		Date:     q.Date.Format("2006-01-02"),
+		Overdue:  enquiriesToResponses(q.Overdue),
+		DueToday: enquiriesToResponses(q.DueToday),
+	}
+}
+
+// counsellorsToResponse converts counsellor summaries to a CounsellorListResponse.
+func counsellorsToResponse(counsellors []admission.CounsellorSummary) CounsellorListResponse {
+	resp := CounsellorListResponse{Counsellors: make([]CounsellorResponse, len(counsellors))}
+	for i, c := range counsellors {
+		resp.Counsellors[i] = CounsellorResponse{
+			UserID:        c.UserID.String(),
+			Name:          c.Name,
+			OpenEnquiries: c.OpenEnquiries,
+		}
+		if c.LastAssignedAt != nil {
+			lastAssignedAt := c.LastAssignedAt.Format(time.RFC3339)
+			resp.Counsellors[i].LastAssignedAt = &lastAssignedAt
+		}
+	}
+	return resp
+}
+
 // parseUUID parses a string to UUID, returning nil if empty.
 func parseUUID(s string) *uuid.UUID {
 	if s == "" {
@@ -0,0 +1,343 @@
+package admission
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/services/admission"
+)
+
+// =====================================================================
+// Interview DTOs
+// =====================================================================
+
+// InterviewCriterionDTO represents one criterion of an interview rubric.
+type InterviewCriterionDTO struct {
+	Key      string          `json:"key" binding:"required,max=50"`
+	Name     string          `json:"name" binding:"required,max=100"`
+	MaxScore decimal.Decimal `json:"maxScore" binding:"required"`
+}
+
+// RubricRequest represents a request to create or replace an interview rubric.
+type RubricRequest struct {
+	SessionID string                  `json:"sessionId" binding:"required,uuid"`
+	Name      string                  `json:"name" binding:"required,max=100"`
+	Criteria  []InterviewCriterionDTO `json:"criteria" binding:"required,min=1,dive"`
+}
+
+// CreateSlotRequest represents a request to create an interview slot with its panel.
+type CreateSlotRequest struct {
+	SessionID string    `json:"sessionId" binding:"required,uuid"`
+	RubricID  string    `json:"rubricId" binding:"required,uuid"`
+	ClassName string    `json:"className" binding:"omitempty,max=50"`
+	StartsAt  time.Time `json:"startsAt" binding:"required"`
+	EndsAt    time.Time `json:"endsAt" binding:"required"`
+	Venue     string    `json:"venue" binding:"omitempty,max=200"`
+	Capacity  int       `json:"capacity" binding:"omitempty,min=1"`
+	StaffIDs  []string  `json:"staffIds" binding:"omitempty,dive,uuid"`
+}
+
+// UpdateSlotRequest represents a request to update an interview slot.
+type UpdateSlotRequest struct {
+	StartsAt *time.Time `json:"startsAt"`
+	EndsAt   *time.Time `json:"endsAt"`
+	Venue    *string    `json:"venue" binding:"omitempty,max=200"`
+	Capacity *int       `json:"capacity" binding:"omitempty,min=1"`
+}
+
+// AddPanelistRequest represents a request to add a staff member to a slot's panel.
+type AddPanelistRequest struct {
+	StaffID string `json:"staffId" binding:"required,uuid"`
+}
+
+// ScheduleInterviewRequest represents a request to book an application into a slot.
+type ScheduleInterviewRequest struct {
+	ApplicationID string `json:"applicationId" binding:"required,uuid"`
+	SlotID        string `json:"slotId" binding:"required,uuid"`
+}
+
+// RescheduleInterviewRequest represents a request to move an interview to another slot.
+type RescheduleInterviewRequest struct {
+	SlotID string `json:"slotId" binding:"required,uuid"`
+}
+
+// CloseInterviewRequest represents a request to cancel an interview or mark it a no-show.
+type CloseInterviewRequest struct {
+	Remarks string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// SubmitInterviewScoreRequest represents a panelist's rubric scores.
+type SubmitInterviewScoreRequest struct {
+	StaffID string                     `json:"staffId" binding:"required,uuid"`
+	Scores  map[string]decimal.Decimal `json:"scores" binding:"required"`
+	Remarks string                     `json:"remarks" binding:"omitempty,max=2000"`
+}
+
+// RubricResponse represents an interview rubric in the response.
+type RubricResponse struct {
+	ID        string                  `json:"id"`
+	SessionID string                  `json:"sessionId"`
+	Name      string                  `json:"name"`
+	Criteria  []InterviewCriterionDTO `json:"criteria"`
+	MaxTotal  string                  `json:"maxTotal"`
+	CreatedAt string                  `json:"createdAt"`
+	UpdatedAt string                  `json:"updatedAt"`
+}
+
+// RubricListResponse represents a list of interview rubrics.
+type RubricListResponse struct {
+	Rubrics []RubricResponse `json:"rubrics"`
+	Total   int              `json:"total"`
+}
+
+// PanelistResponse represents a member of an interview panel.
+type PanelistResponse struct {
+	StaffID    string `json:"staffId"`
+	Name       string `json:"name,omitempty"`
+	EmployeeID string `json:"employeeId,omitempty"`
+}
+
+// SlotResponse represents an interview slot in the response.
+type SlotResponse struct {
+	ID         string                     `json:"id"`
+	SessionID  string                     `json:"sessionId"`
+	RubricID   string                     `json:"rubricId"`
+	RubricName string                     `json:"rubricName,omitempty"`
+	ClassName  string                     `json:"className,omitempty"`
+	StartsAt   time.Time                  `json:"startsAt"`
+	EndsAt     time.Time                  `json:"endsAt"`
+	Venue      string                     `json:"venue,omitempty"`
+	Capacity   int                        `json:"capacity"`
+	Booked     int                        `json:"booked"`
+	Panelists  []PanelistResponse         `json:"panelists"`
+	Interviews []InterviewSummaryResponse `json:"interviews"`
+}
+
+// SlotListResponse represents a list of interview slots.
+type SlotListResponse struct {
+	Slots []SlotResponse `json:"slots"`
+	Total int            `json:"total"`
+}
+
+// InterviewSummaryResponse represents a booked candidate within a slot.
+type InterviewSummaryResponse struct {
+	ID                string  `json:"id"`
+	ApplicationID     string  `json:"applicationId"`
+	ApplicationNumber string  `json:"applicationNumber,omitempty"`
+	StudentName       string  `json:"studentName,omitempty"`
+	Status            string  `json:"status"`
+	ScorePercentage   *string `json:"scorePercentage,omitempty"`
+}
+
+// InterviewScoreResponse represents one panelist's scores.
+type InterviewScoreResponse struct {
+	StaffID     string            `json:"staffId"`
+	Scores      map[string]string `json:"scores"`
+	TotalScore  string            `json:"totalScore"`
+	MaxScore    string            `json:"maxScore"`
+	Remarks     string            `json:"remarks,omitempty"`
+	SubmittedAt time.Time         `json:"submittedAt"`
+}
+
+// InterviewResponse represents an interview in the response.
+type InterviewResponse struct {
+	ID                string                   `json:"id"`
+	SlotID            string                   `json:"slotId"`
+	ApplicationID     string                   `json:"applicationId"`
+	ApplicationNumber string                   `json:"applicationNumber,omitempty"`
+	StudentName       string                   `json:"studentName,omitempty"`
+	ClassName         string                   `json:"className,omitempty"`
+	Status            string                   `json:"status"`
+	StartsAt          *time.Time               `json:"startsAt,omitempty"`
+	EndsAt            *time.Time               `json:"endsAt,omitempty"`
+	Venue             string                   `json:"venue,omitempty"`
+	RescheduleCount   int                      `json:"rescheduleCount"`
+	NotifiedAt        *time.Time               `json:"notifiedAt,omitempty"`
+	ScorePercentage   *string                  `json:"scorePercentage,omitempty"`
+	CompletedAt       *time.Time               `json:"completedAt,omitempty"`
+	Remarks           string                   `json:"remarks,omitempty"`
+	Panelists         []PanelistResponse       `json:"panelists,omitempty"`
+	Scores            []InterviewScoreResponse `json:"scores"`
+	// Token and PublicPath are the parent's reschedule link, returned when an
+	// interview is scheduled or moved.
+	Token      string `json:"token,omitempty"`
+	PublicPath string `json:"publicPath,omitempty"`
+}
+
+// InterviewListResponse represents a list of interviews.
+type InterviewListResponse struct {
+	Interviews []InterviewResponse `json:"interviews"`
+	Total      int                 `json:"total"`
+}
+
+// PublicSlotResponse represents a slot a parent can move the interview to.
+type PublicSlotResponse struct {
+	ID       string    `json:"id"`
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	Venue    string    `json:"venue,omitempty"`
+}
+
+// PublicInterviewResponse represents an interview as shown through the parent's reschedule link.
+type PublicInterviewResponse struct {
+	SchoolName     string               `json:"schoolName"`
+	StudentName    string               `json:"studentName"`
+	StartsAt       time.Time            `json:"startsAt"`
+	EndsAt         time.Time            `json:"endsAt"`
+	Venue          string               `json:"venue,omitempty"`
+	CanReschedule  bool                 `json:"canReschedule"`
+	AvailableSlots []PublicSlotResponse `json:"availableSlots"`
+	// Token is the new reschedule link token after a reschedule; the old link stops working.
+	Token string `json:"token,omitempty"`
+}
+
+// NewRubricResponse creates a RubricResponse from an InterviewRubric entity.
+func NewRubricResponse(rubric *admission.InterviewRubric) RubricResponse {
+	criteria := make([]InterviewCriterionDTO, len(rubric.Criteria))
+	for i, c := range rubric.Criteria {
+		criteria[i] = InterviewCriterionDTO{Key: c.Key, Name: c.Name, MaxScore: c.MaxScore}
+	}
+	return RubricResponse{
+		ID:        rubric.ID.String(),
+		SessionID: rubric.SessionID.String(),
+		Name:      rubric.Name,
+		Criteria:  criteria,
+		MaxTotal:  rubric.Criteria.MaxTotal().String(),
+		CreatedAt: rubric.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: rubric.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// NewSlotResponse creates a SlotResponse from an InterviewSlot entity.
+func NewSlotResponse(slot *admission.InterviewSlot) SlotResponse {
+	resp := SlotResponse{
+		ID:         slot.ID.String(),
+		SessionID:  slot.SessionID.String(),
+		RubricID:   slot.RubricID.String(),
+		ClassName:  slot.ClassName,
+		StartsAt:   slot.StartsAt,
+		EndsAt:     slot.EndsAt,
+		Venue:      slot.Venue,
+		Capacity:   slot.Capacity,
+		Booked:     len(slot.Interviews),
+		Panelists:  newPanelistResponses(slot.Panelists),
+		Interviews: make([]InterviewSummaryResponse, len(slot.Interviews)),
+	}
+	if slot.Rubric != nil {
+		resp.RubricName = slot.Rubric.Name
+	}
+	for i := range slot.Interviews {
+		interview := &slot.Interviews[i]
+		summary := InterviewSummaryResponse{
+			ID:              interview.ID.String(),
+			ApplicationID:   interview.ApplicationID.String(),
+			Status:          string(interview.Status),
+			ScorePercentage: decimalString(interview.ScorePercentage),
+		}
+		if interview.Application != nil {
+			summary.ApplicationNumber = interview.Application.ApplicationNumber
+			summary.StudentName = interview.Application.StudentName
+		}
+		resp.Interviews[i] = summary
+	}
+	return resp
+}
+
+// NewInterviewResponse creates an InterviewResponse from an Interview entity.
+func NewInterviewResponse(interview *admission.Interview) InterviewResponse {
+	resp := InterviewResponse{
+		ID:              interview.ID.String(),
+		SlotID:          interview.SlotID.String(),
+		ApplicationID:   interview.ApplicationID.String(),
+		Status:          string(interview.Status),
+		RescheduleCount: interview.RescheduleCount,
+		NotifiedAt:      interview.NotifiedAt,
+		ScorePercentage: decimalString(interview.ScorePercentage),
+		CompletedAt:     interview.CompletedAt,
+		Remarks:         interview.Remarks,
+		Scores:          make([]InterviewScoreResponse, len(interview.Scores)),
+	}
+	if interview.Application != nil {
+		resp.ApplicationNumber = interview.Application.ApplicationNumber
+		resp.StudentName = interview.Application.StudentName
+		resp.ClassName = interview.Application.ClassApplying
+	}
+	if interview.Slot != nil {
+		resp.StartsAt = &interview.Slot.StartsAt
+		resp.EndsAt = &interview.Slot.EndsAt
+		resp.Venue = interview.Slot.Venue
+		if len(interview.Slot.Panelists) > 0 {
+			resp.Panelists = newPanelistResponses(interview.Slot.Panelists)
+		}
+	}
+	for i, score := range interview.Scores {
+		scores := make(map[string]string, len(score.Scores))
+		for key, value := range score.Scores {
+			scores[key] = value.String()
+		}
+		resp.Scores[i] = InterviewScoreResponse{
+			StaffID:     score.StaffID.String(),
+			Scores:      scores,
+			TotalScore:  score.TotalScore.String(),
+			MaxScore:    score.MaxScore.String(),
+			Remarks:     score.Remarks,
+			SubmittedAt: score.SubmittedAt,
+		}
+	}
+	return resp
+}
+
+// NewScheduledInterviewResponse creates an InterviewResponse with the parent's reschedule link.
+func NewScheduledInterviewResponse(scheduled *admission.ScheduledInterview) InterviewResponse {
+	resp := NewInterviewResponse(scheduled.Interview)
+	resp.Token = scheduled.Token
+	resp.PublicPath = "/api/v1/public/interviews/" + scheduled.Token
+	return resp
+}
+
+// NewPublicInterviewResponse creates a PublicInterviewResponse from an InterviewView.
+func NewPublicInterviewResponse(view *admission.InterviewView) PublicInterviewResponse {
+	resp := PublicInterviewResponse{
+		SchoolName:     view.SchoolName,
+		StudentName:    view.StudentName,
+		CanReschedule:  view.CanReschedule,
+		AvailableSlots: make([]PublicSlotResponse, len(view.AvailableSlots)),
+	}
+	if slot := view.Interview.Slot; slot != nil {
+		resp.StartsAt = slot.StartsAt
+		resp.EndsAt = slot.EndsAt
+		resp.Venue = slot.Venue
+	}
+	for i, slot := range view.AvailableSlots {
+		resp.AvailableSlots[i] = PublicSlotResponse{
+			ID:       slot.ID.String(),
+			StartsAt: slot.StartsAt,
+			EndsAt:   slot.EndsAt,
+			Venue:    slot.Venue,
+		}
+	}
+	return resp
+}
+
+// newPanelistResponses creates PanelistResponses from a slot's panel.
+func newPanelistResponses(panelists []admission.InterviewPanelist) []PanelistResponse {
+	resp := make([]PanelistResponse, len(panelists))
+	for i, panelist := range panelists {
+		resp[i] = PanelistResponse{StaffID: panelist.StaffID.String()}
+		if panelist.Staff != nil {
+			resp[i].Name = panelist.Staff.FullName()
+			resp[i].EmployeeID = panelist.Staff.EmployeeID
+		}
+	}
+	return resp
+}
+
+// decimalString formats an optional decimal.
+func decimalString(value *decimal.Decimal) *string {
+	if value == nil {
+		return nil
+	}
+	s := value.String()
+	return &s
+}
@@ -0,0 +1,862 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+	admissionservice "msls-backend/internal/services/admission"
+)
+
+// InterviewHandler handles interview rubric, slot, booking and scoring endpoints,
+// and the public reschedule links used by parents.
+type InterviewHandler struct {
+	interviewService *admissionservice.InterviewService
+}
+
+// NewInterviewHandler creates a new InterviewHandler.
+func NewInterviewHandler(interviewService *admissionservice.InterviewService) *InterviewHandler {
+	return &InterviewHandler{interviewService: interviewService}
+}
+
+// =====================================================================
+// Rubrics
+// =====================================================================
+
+// ListRubrics godoc
+// @Summary List interview rubrics
+// @Description Retrieves the interview rubrics of an admission session
+// @Tags Interviews
+// @Produce json
+// @Param sessionId query string true "Admission session ID"
+// @Success 200 {object} response.Success{data=RubricListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/interview-rubrics [get]
+func (h *InterviewHandler) ListRubrics(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Query("sessionId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid session ID"))
+		return
+	}
+
+	rubrics, err := h.interviewService.ListRubrics(c.Request.Context(), tenantID, sessionID)
+	if err != nil {
+		handleInterviewError(c, err, "Failed to retrieve rubrics")
+		return
+	}
+
+	resp := make([]RubricResponse, len(rubrics))
+	for i := range rubrics {
+		resp[i] = NewRubricResponse(&rubrics[i])
+	}
+	response.OK(c, RubricListResponse{Rubrics: resp, Total: len(resp)})
+}
+
+// CreateRubric godoc
+// @Summary Create interview rubric
+// @Description Creates a scoring rubric for an admission session's interviews
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param request body RubricRequest true "Rubric details"
+// @Success 201 {object} response.Success{data=RubricResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/interview-rubrics [post]
+func (h *InterviewHandler) CreateRubric(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req RubricRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	rubric, err := h.interviewService.CreateRubric(c.Request.Context(), rubricRequest(c, tenantID, &req))
+	if err != nil {
+		handleInterviewError(c, err, "Failed to create rubric")
+		return
+	}
+
+	response.Created(c, NewRubricResponse(rubric))
+}
+
+// UpdateRubric godoc
+// @Summary Update interview rubric
+// @Description Replaces a rubric's name and criteria. Not allowed once interviews on the rubric have been scored.
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Rubric ID"
+// @Param request body RubricRequest true "Rubric details"
+// @Success 200 {object} response.Success{data=RubricResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interview-rubrics/{id} [put]
+func (h *InterviewHandler) UpdateRubric(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	rubricID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid rubric ID"))
+		return
+	}
+
+	var req RubricRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	rubric, err := h.interviewService.UpdateRubric(c.Request.Context(), rubricID, rubricRequest(c, tenantID, &req))
+	if err != nil {
+		handleInterviewError(c, err, "Failed to update rubric")
+		return
+	}
+
+	response.OK(c, NewRubricResponse(rubric))
+}
+
+// DeleteRubric godoc
+// @Summary Delete interview rubric
+// @Description Deletes a rubric that no interview slot uses
+// @Tags Interviews
+// @Param id path string true "Rubric ID"
+// @Success 204
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interview-rubrics/{id} [delete]
+func (h *InterviewHandler) DeleteRubric(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	rubricID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid rubric ID"))
+		return
+	}
+
+	if err := h.interviewService.DeleteRubric(c.Request.Context(), tenantID, rubricID); err != nil {
+		handleInterviewError(c, err, "Failed to delete rubric")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// =====================================================================
+// Slots
+// =====================================================================
+
+// ListSlots godoc
+// @Summary List interview slots
+// @Description Retrieves interview slots in time order with their panels and booked candidates
+// @Tags Interviews
+// @Produce json
+// @Param sessionId query string false "Filter by admission session"
+// @Param className query string false "Filter by class (includes slots open to all classes)"
+// @Param fromDate query string false "Slots starting on or after this date (YYYY-MM-DD)"
+// @Param toDate query string false "Slots starting on or before this date (YYYY-MM-DD)"
+// @Param available query bool false "Only future slots with free capacity"
+// @Success 200 {object} response.Success{data=SlotListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/interview-slots [get]
+func (h *InterviewHandler) ListSlots(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := admissionservice.SlotListFilter{
+		TenantID:      tenantID,
+		ClassName:     c.Query("className"),
+		AvailableOnly: c.Query("available") == "true",
+	}
+	if sessionIDStr := c.Query("sessionId"); sessionIDStr != "" {
+		sessionID, err := uuid.Parse(sessionIDStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid session ID"))
+			return
+		}
+		filter.SessionID = &sessionID
+	}
+	if fromDate := c.Query("fromDate"); fromDate != "" {
+		from, err := time.ParseInLocation("2006-01-02", fromDate, time.Local)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid fromDate format. Use YYYY-MM-DD"))
+			return
+		}
+		filter.FromDate = &from
+	}
+	if toDate := c.Query("toDate"); toDate != "" {
+		to, err := time.ParseInLocation("2006-01-02", toDate, time.Local)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid toDate format. Use YYYY-MM-DD"))
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.ToDate = &to
+	}
+
+	slots, err := h.interviewService.ListSlots(c.Request.Context(), filter)
+	if err != nil {
+		handleInterviewError(c, err, "Failed to retrieve interview slots")
+		return
+	}
+
+	resp := make([]SlotResponse, len(slots))
+	for i := range slots {
+		resp[i] = NewSlotResponse(&slots[i])
+	}
+	response.OK(c, SlotListResponse{Slots: resp, Total: len(resp)})
+}
+
+// GetSlot godoc
+// @Summary Get interview slot
+// @Description Retrieves an interview slot with its panel and booked candidates
+// @Tags Interviews
+// @Produce json
+// @Param id path string true "Slot ID"
+// @Success 200 {object} response.Success{data=SlotResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/interview-slots/{id} [get]
+func (h *InterviewHandler) GetSlot(c *gin.Context) {
+	tenantID, slotID, ok := slotParams(c)
+	if !ok {
+		return
+	}
+
+	slot, err := h.interviewService.GetSlot(c.Request.Context(), tenantID, slotID)
+	if err != nil {
+		handleInterviewError(c, err, "Failed to retrieve interview slot")
+		return
+	}
+
+	response.OK(c, NewSlotResponse(slot))
+}
+
+// CreateSlot godoc
+// @Summary Create interview slot
+// @Description Creates an interview slot with its panel. Panelists must be active staff without another slot at an overlapping time.
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param request body CreateSlotRequest true "Slot details"
+// @Success 201 {object} response.Success{data=SlotResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interview-slots [post]
+func (h *InterviewHandler) CreateSlot(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req CreateSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	staffIDs := make([]uuid.UUID, len(req.StaffIDs))
+	for i, id := range req.StaffIDs {
+		staffIDs[i] = uuid.MustParse(id)
+	}
+
+	slot, err := h.interviewService.CreateSlot(c.Request.Context(), admissionservice.CreateSlotRequest{
+		TenantID:  tenantID,
+		SessionID: uuid.MustParse(req.SessionID),
+		RubricID:  uuid.MustParse(req.RubricID),
+		ClassName: req.ClassName,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Venue:     req.Venue,
+		Capacity:  req.Capacity,
+		StaffIDs:  staffIDs,
+		CreatedBy: currentUserID(c),
+	})
+	if err != nil {
+		handleInterviewError(c, err, "Failed to create interview slot")
+		return
+	}
+
+	response.Created(c, NewSlotResponse(slot))
+}
+
+// UpdateSlot godoc
+// @Summary Update interview slot
+// @Description Updates a slot's time, venue or capacity. Parents of booked candidates are sent the new schedule when the time or venue changes.
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Slot ID"
+// @Param request body UpdateSlotRequest true "Slot changes"
+// @Success 200 {object} response.Success{data=SlotResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interview-slots/{id} [put]
+func (h *InterviewHandler) UpdateSlot(c *gin.Context) {
+	tenantID, slotID, ok := slotParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	slot, err := h.interviewService.UpdateSlot(c.Request.Context(), tenantID, slotID, admissionservice.UpdateSlotRequest{
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Venue:     req.Venue,
+		Capacity:  req.Capacity,
+		UpdatedBy: currentUserID(c),
+	})
+	if err != nil {
+		handleInterviewError(c, err, "Failed to update interview slot")
+		return
+	}
+
+	response.OK(c, NewSlotResponse(slot))
+}
+
+// DeleteSlot godoc
+// @Summary Delete interview slot
+// @Description Deletes a slot without scheduled or completed interviews
+// @Tags Interviews
+// @Param id path string true "Slot ID"
+// @Success 204
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interview-slots/{id} [delete]
+func (h *InterviewHandler) DeleteSlot(c *gin.Context) {
+	tenantID, slotID, ok := slotParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.interviewService.DeleteSlot(c.Request.Context(), tenantID, slotID); err != nil {
+		handleInterviewError(c, err, "Failed to delete interview slot")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// AddPanelist godoc
+// @Summary Add panelist
+// @Description Adds a staff member to an interview slot's panel
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Slot ID"
+// @Param request body AddPanelistRequest true "Staff member"
+// @Success 200 {object} response.Success{data=SlotResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interview-slots/{id}/panelists [post]
+func (h *InterviewHandler) AddPanelist(c *gin.Context) {
+	tenantID, slotID, ok := slotParams(c)
+	if !ok {
+		return
+	}
+
+	var req AddPanelistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	slot, err := h.interviewService.AddPanelist(c.Request.Context(), tenantID, slotID, uuid.MustParse(req.StaffID))
+	if err != nil {
+		handleInterviewError(c, err, "Failed to add panelist")
+		return
+	}
+
+	response.OK(c, NewSlotResponse(slot))
+}
+
+// RemovePanelist godoc
+// @Summary Remove panelist
+// @Description Removes a staff member who has not scored anyone from an interview slot's panel
+// @Tags Interviews
+// @Produce json
+// @Param id path string true "Slot ID"
+// @Param staffId path string true "Staff ID"
+// @Success 200 {object} response.Success{data=SlotResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interview-slots/{id}/panelists/{staffId} [delete]
+func (h *InterviewHandler) RemovePanelist(c *gin.Context) {
+	tenantID, slotID, ok := slotParams(c)
+	if !ok {
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("staffId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid staff ID"))
+		return
+	}
+
+	slot, err := h.interviewService.RemovePanelist(c.Request.Context(), tenantID, slotID, staffID)
+	if err != nil {
+		handleInterviewError(c, err, "Failed to remove panelist")
+		return
+	}
+
+	response.OK(c, NewSlotResponse(slot))
+}
+
+// =====================================================================
+// Interviews
+// =====================================================================
+
+// ListInterviews godoc
+// @Summary List interviews
+// @Description Retrieves interviews in slot order with optional filtering
+// @Tags Interviews
+// @Produce json
+// @Param sessionId query string false "Filter by admission session"
+// @Param slotId query string false "Filter by slot"
+// @Param applicationId query string false "Filter by application"
+// @Param status query string false "Filter by status (scheduled, completed, cancelled, no_show)"
+// @Success 200 {object} response.Success{data=InterviewListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/interviews [get]
+func (h *InterviewHandler) ListInterviews(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := admissionservice.InterviewListFilter{TenantID: tenantID}
+	for param, target := range map[string]**uuid.UUID{
+		"sessionId":     &filter.SessionID,
+		"slotId":        &filter.SlotID,
+		"applicationId": &filter.ApplicationID,
+	} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid "+param))
+			return
+		}
+		*target = &id
+	}
+	if status := c.Query("status"); status != "" {
+		s := admissionservice.InterviewStatus(status)
+		filter.Status = &s
+	}
+
+	interviews, err := h.interviewService.ListInterviews(c.Request.Context(), filter)
+	if err != nil {
+		handleInterviewError(c, err, "Failed to retrieve interviews")
+		return
+	}
+
+	resp := make([]InterviewResponse, len(interviews))
+	for i := range interviews {
+		resp[i] = NewInterviewResponse(&interviews[i])
+	}
+	response.OK(c, InterviewListResponse{Interviews: resp, Total: len(resp)})
+}
+
+// GetInterview godoc
+// @Summary Get interview
+// @Description Retrieves an interview with its panel and the panelists' scores
+// @Tags Interviews
+// @Produce json
+// @Param id path string true "Interview ID"
+// @Success 200 {object} response.Success{data=InterviewResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/interviews/{id} [get]
+func (h *InterviewHandler) GetInterview(c *gin.Context) {
+	tenantID, interviewID, ok := interviewParams(c)
+	if !ok {
+		return
+	}
+
+	interview, err := h.interviewService.GetInterview(c.Request.Context(), tenantID, interviewID)
+	if err != nil {
+		handleInterviewError(c, err, "Failed to retrieve interview")
+		return
+	}
+
+	response.OK(c, NewInterviewResponse(interview))
+}
+
+// ScheduleInterview godoc
+// @Summary Schedule interview
+// @Description Books an application into an interview slot, moves it to interview_scheduled and sends the parent the schedule with a reschedule link
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param request body ScheduleInterviewRequest true "Application and slot"
+// @Success 201 {object} response.Success{data=InterviewResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interviews [post]
+func (h *InterviewHandler) ScheduleInterview(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req ScheduleInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	scheduled, err := h.interviewService.ScheduleInterview(c.Request.Context(), admissionservice.ScheduleInterviewRequest{
+		TenantID:      tenantID,
+		ApplicationID: uuid.MustParse(req.ApplicationID),
+		SlotID:        uuid.MustParse(req.SlotID),
+		ScheduledBy:   currentUserID(c),
+	})
+	if err != nil {
+		handleInterviewError(c, err, "Failed to schedule interview")
+		return
+	}
+
+	response.Created(c, NewScheduledInterviewResponse(scheduled))
+}
+
+// RescheduleInterview godoc
+// @Summary Reschedule interview
+// @Description Moves a scheduled interview to another slot and sends the parent the new schedule
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Interview ID"
+// @Param request body RescheduleInterviewRequest true "New slot"
+// @Success 200 {object} response.Success{data=InterviewResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interviews/{id}/reschedule [post]
+func (h *InterviewHandler) RescheduleInterview(c *gin.Context) {
+	tenantID, interviewID, ok := interviewParams(c)
+	if !ok {
+		return
+	}
+
+	var req RescheduleInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	scheduled, err := h.interviewService.RescheduleInterview(c.Request.Context(), tenantID, interviewID, uuid.MustParse(req.SlotID), currentUserID(c))
+	if err != nil {
+		handleInterviewError(c, err, "Failed to reschedule interview")
+		return
+	}
+
+	response.OK(c, NewScheduledInterviewResponse(scheduled))
+}
+
+// CancelInterview godoc
+// @Summary Cancel interview
+// @Description Cancels a scheduled interview. The application can then be booked into another slot.
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Interview ID"
+// @Param request body CloseInterviewRequest false "Remarks"
+// @Success 200 {object} response.Success{data=InterviewResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interviews/{id}/cancel [post]
+func (h *InterviewHandler) CancelInterview(c *gin.Context) {
+	h.closeInterview(c, h.interviewService.CancelInterview, "Failed to cancel interview")
+}
+
+// MarkNoShow godoc
+// @Summary Mark interview no-show
+// @Description Records that the candidate did not attend a scheduled interview
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Interview ID"
+// @Param request body CloseInterviewRequest false "Remarks"
+// @Success 200 {object} response.Success{data=InterviewResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interviews/{id}/no-show [post]
+func (h *InterviewHandler) MarkNoShow(c *gin.Context) {
+	h.closeInterview(c, h.interviewService.MarkNoShow, "Failed to update interview")
+}
+
+// SubmitScore godoc
+// @Summary Submit panel score
+// @Description Records a panelist's rubric scores, replacing their earlier scores. The interview completes once every panelist has scored it.
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Interview ID"
+// @Param request body SubmitInterviewScoreRequest true "Rubric scores keyed by criterion"
+// @Success 200 {object} response.Success{data=InterviewResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/interviews/{id}/scores [put]
+func (h *InterviewHandler) SubmitScore(c *gin.Context) {
+	tenantID, interviewID, ok := interviewParams(c)
+	if !ok {
+		return
+	}
+
+	var req SubmitInterviewScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	interview, err := h.interviewService.SubmitScore(c.Request.Context(), admissionservice.SubmitScoreRequest{
+		TenantID:    tenantID,
+		InterviewID: interviewID,
+		StaffID:     uuid.MustParse(req.StaffID),
+		Scores:      req.Scores,
+		Remarks:     req.Remarks,
+		SubmittedBy: currentUserID(c),
+	})
+	if err != nil {
+		handleInterviewError(c, err, "Failed to submit score")
+		return
+	}
+
+	response.OK(c, NewInterviewResponse(interview))
+}
+
+// =====================================================================
+// Public reschedule links
+// =====================================================================
+
+// GetPublicInterview godoc
+// @Summary View interview schedule
+// @Description View an interview through the parent's reschedule link, with the slots it can move to
+// @Tags Interviews
+// @Produce json
+// @Param token path string true "Reschedule link token"
+// @Success 200 {object} response.Success{data=PublicInterviewResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/public/interviews/{token} [get]
+func (h *InterviewHandler) GetPublicInterview(c *gin.Context) {
+	view, err := h.interviewService.GetInterviewByToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleInterviewError(c, err, "Failed to retrieve interview")
+		return
+	}
+
+	response.OK(c, NewPublicInterviewResponse(view))
+}
+
+// ReschedulePublicInterview godoc
+// @Summary Reschedule interview
+// @Description Move an interview to another slot through the parent's reschedule link. The old link stops working; the response and the confirmation SMS carry the new one.
+// @Tags Interviews
+// @Accept json
+// @Produce json
+// @Param token path string true "Reschedule link token"
+// @Param request body RescheduleInterviewRequest true "New slot"
+// @Success 200 {object} response.Success{data=PublicInterviewResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/public/interviews/{token}/reschedule [post]
+func (h *InterviewHandler) ReschedulePublicInterview(c *gin.Context) {
+	var req RescheduleInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	scheduled, err := h.interviewService.RescheduleByToken(c.Request.Context(), c.Param("token"), uuid.MustParse(req.SlotID))
+	if err != nil {
+		handleInterviewError(c, err, "Failed to reschedule interview")
+		return
+	}
+
+	view, err := h.interviewService.GetInterviewByToken(c.Request.Context(), scheduled.Token)
+	if err != nil {
+		handleInterviewError(c, err, "Failed to retrieve interview")
+		return
+	}
+
+	resp := NewPublicInterviewResponse(view)
+	resp.Token = scheduled.Token
+	response.OK(c, resp)
+}
+
+// =====================================================================
+// Helpers
+// =====================================================================
+
+// closeInterview runs a cancel or no-show action on the interview in the path.
+func (h *InterviewHandler) closeInterview(c *gin.Context, action func(ctx context.Context, tenantID, id uuid.UUID, remarks string, updatedBy *uuid.UUID) (*admissionservice.Interview, error), fallback string) {
+	tenantID, interviewID, ok := interviewParams(c)
+	if !ok {
+		return
+	}
+
+	var req CloseInterviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+			return
+		}
+	}
+
+	interview, err := action(c.Request.Context(), tenantID, interviewID, req.Remarks, currentUserID(c))
+	if err != nil {
+		handleInterviewError(c, err, fallback)
+		return
+	}
+
+	response.OK(c, NewInterviewResponse(interview))
+}
+
+// rubricRequest converts a RubricRequest DTO to a service request.
+func rubricRequest(c *gin.Context, tenantID uuid.UUID, req *RubricRequest) admissionservice.RubricRequest {
+	criteria := make(admissionservice.InterviewCriteria, len(req.Criteria))
+	for i, criterion := range req.Criteria {
+		criteria[i] = admissionservice.InterviewCriterion{Key: criterion.Key, Name: criterion.Name, MaxScore: criterion.MaxScore}
+	}
+	return admissionservice.RubricRequest{
+		TenantID:  tenantID,
+		SessionID: uuid.MustParse(req.SessionID),
+		Name:      req.Name,
+		Criteria:  criteria,
+		UserID:    currentUserID(c),
+	}
+}
+
+// slotParams reads the tenant and the slot ID path parameter.
+func slotParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	slotID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid slot ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, slotID, true
+}
+
+// interviewParams reads the tenant and the interview ID path parameter.
+func interviewParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	interviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid interview ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, interviewID, true
+}
+
+// currentUserID returns the authenticated user's ID, if any.
+func currentUserID(c *gin.Context) *uuid.UUID {
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		return nil
+	}
+	return &userID
+}
+
+// handleInterviewError maps interview service errors to HTTP responses.
+func handleInterviewError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, admissionservice.ErrSessionNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Admission session not found"))
+	case errors.Is(err, admissionservice.ErrApplicationNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Application not found"))
+	case errors.Is(err, admissionservice.ErrRubricNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Interview rubric not found"))
+	case errors.Is(err, admissionservice.ErrInterviewSlotNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Interview slot not found"))
+	case errors.Is(err, admissionservice.ErrInterviewNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Interview not found"))
+	case errors.Is(err, admissionservice.ErrStaffNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff member not found"))
+	case errors.Is(err, admissionservice.ErrPanelistNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff member is not on the interview panel"))
+	case errors.Is(err, admissionservice.ErrRescheduleLinkInvalid):
+		apperrors.Abort(c, apperrors.NotFound("Interview not found"))
+	case errors.Is(err, admissionservice.ErrInvalidRubric),
+		errors.Is(err, admissionservice.ErrInvalidSlotTime),
+		errors.Is(err, admissionservice.ErrInvalidInterviewScore),
+		errors.Is(err, admissionservice.ErrSlotClassMismatch),
+		errors.Is(err, admissionservice.ErrSlotInPast),
+		errors.Is(err, admissionservice.ErrSessionIDRequired),
+		errors.Is(err, admissionservice.ErrApplicationIDRequired):
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+	case errors.Is(err, admissionservice.ErrSlotFull):
+		apperrors.Abort(c, apperrors.Conflict("Interview slot is full"))
+	case errors.Is(err, admissionservice.ErrPanelistConflict):
+		apperrors.Abort(c, apperrors.Conflict("A panelist has another interview slot at this time"))
+	case errors.Is(err, admissionservice.ErrPanelistAlreadyAssigned):
+		apperrors.Abort(c, apperrors.Conflict("Staff member is already on the interview panel"))
+	case errors.Is(err, admissionservice.ErrPanelistHasScores):
+		apperrors.Abort(c, apperrors.Conflict("Panelist has already scored interviews in this slot"))
+	case errors.Is(err, admissionservice.ErrRubricInUse):
+		apperrors.Abort(c, apperrors.Conflict("Rubric is in use by interview slots or scored interviews"))
+	case errors.Is(err, admissionservice.ErrSlotHasInterviews):
+		apperrors.Abort(c, apperrors.Conflict("Interview slot has interviews. Reschedule or cancel them first."))
+	case errors.Is(err, admissionservice.ErrInterviewAlreadyScheduled):
+		apperrors.Abort(c, apperrors.Conflict("Application already has an interview"))
+	case errors.Is(err, admissionservice.ErrApplicationNotInterviewable):
+		apperrors.Abort(c, apperrors.Conflict("Application cannot be scheduled for an interview in its current status"))
+	case errors.Is(err, admissionservice.ErrInterviewNotScheduled):
+		apperrors.Abort(c, apperrors.Conflict("Interview is not scheduled"))
+	case errors.Is(err, admissionservice.ErrInterviewNotScorable):
+		apperrors.Abort(c, apperrors.Conflict("Cancelled and no-show interviews cannot be scored"))
+	case errors.Is(err, admissionservice.ErrRescheduleClosed):
+		apperrors.Abort(c, apperrors.Conflict("This interview can no longer be rescheduled online. Please contact the school."))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
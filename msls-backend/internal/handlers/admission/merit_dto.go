@@ -16,6 +16,8 @@ type GenerateMeritListRequest struct {
 	ClassName   string   `json:"className" binding:"required,max=50"`
 	TestID      *string  `json:"testId,omitempty"`
 	CutoffScore *float64 `json:"cutoffScore,omitempty"`
+	// InterviewWeight is the percentage of the score taken from the interview (default 30).
+	InterviewWeight *float64 `json:"interviewWeight,omitempty" binding:"omitempty,min=0,max=100"`
 }
 
 // UpdateCutoffRequest represents the request body for updating cutoff score.
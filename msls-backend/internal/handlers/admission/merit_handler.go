@@ -27,7 +27,7 @@ func NewMeritHandler(meritService *admissionservice.MeritService) *MeritHandler
 
 // GenerateMeritList generates a merit list for a session and class.
 // @Summary Generate merit list
-// @Description Generate a merit list based on test scores for a session and class
+// @Description Generate a merit list for a session and class, ranking applicants on entrance test results and interview panel scores
 // @Tags Merit Lists
 // @Accept json
 // @Produce json
@@ -74,12 +74,13 @@ func (h *MeritHandler) GenerateMeritList(c *gin.Context) {
 	}
 
 	generateReq := admissionservice.GenerateMeritListRequest{
-		TenantID:    tenantID,
-		SessionID:   sessionID,
-		ClassName:   req.ClassName,
-		TestID:      testID,
-		CutoffScore: req.CutoffScore,
-		GeneratedBy: &userID,
+		TenantID:        tenantID,
+		SessionID:       sessionID,
+		ClassName:       req.ClassName,
+		TestID:          testID,
+		CutoffScore:     req.CutoffScore,
+		InterviewWeight: req.InterviewWeight,
+		GeneratedBy:     &userID,
 	}
 
 	meritList, err := h.meritService.GenerateMeritList(c.Request.Context(), generateReq)
@@ -97,6 +98,8 @@ func (h *MeritHandler) GenerateMeritList(c *gin.Context) {
 			apperrors.Abort(c, apperrors.BadRequest("Merit list is already finalized"))
 		case admissionservice.ErrNoApplicantsForMeritList:
 			apperrors.Abort(c, apperrors.BadRequest("No applicants found to generate merit list"))
+		case admissionservice.ErrInvalidInterviewWeight:
+			apperrors.Abort(c, apperrors.BadRequest("Interview weight must be between 0 and 100"))
 		default:
 			apperrors.Abort(c, apperrors.InternalError("Failed to generate merit list"))
 		}
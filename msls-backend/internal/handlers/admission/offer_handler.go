@@ -0,0 +1,102 @@
+// Package admission provides HTTP handlers for admission management endpoints.
+package admission
+
+import (
+	"github.com/gin-gonic/gin"
+
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+	admissionservice "msls-backend/internal/services/admission"
+)
+
+// OfferHandler handles the public offer link endpoints used by parents.
+// The link token identifies the tenant, so no X-Tenant-ID header is needed.
+type OfferHandler struct {
+	decisionService *admissionservice.DecisionService
+}
+
+// NewOfferHandler creates a new OfferHandler.
+func NewOfferHandler(decisionService *admissionservice.DecisionService) *OfferHandler {
+	return &OfferHandler{decisionService: decisionService}
+}
+
+// Get returns the offer behind a public offer link.
+// @Summary View admission offer
+// @Description View an admission offer through its public link. The first view is recorded.
+// @Tags Offer Letters
+// @Produce json
+// @Param token path string true "Offer link token"
+// @Success 200 {object} response.Success{data=PublicOfferResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/public/offers/{token} [get]
+func (h *OfferHandler) Get(c *gin.Context) {
+	offer, err := h.decisionService.GetOfferByToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleOfferError(c, err, "Failed to retrieve offer")
+		return
+	}
+
+	response.OK(c, offerToPublicResponse(offer))
+}
+
+// DownloadLetter downloads the offer letter PDF behind a public offer link.
+// @Summary Download offer letter
+// @Description Download the offer letter PDF through its public link
+// @Tags Offer Letters
+// @Produce application/pdf
+// @Param token path string true "Offer link token"
+// @Success 200 {file} binary
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/public/offers/{token}/letter.pdf [get]
+func (h *OfferHandler) DownloadLetter(c *gin.Context) {
+	pdf, err := h.decisionService.GetOfferLetterByToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleOfferError(c, err, "Failed to download offer letter")
+		return
+	}
+
+	writeOfferLetter(c, "admission", pdf)
+}
+
+// Accept accepts the offer behind a public offer link.
+// @Summary Accept admission offer
+// @Description Accept an admission offer through its public link before it expires
+// @Tags Offer Letters
+// @Produce json
+// @Param token path string true "Offer link token"
+// @Success 200 {object} response.Success{data=PublicOfferResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/public/offers/{token}/accept [post]
+func (h *OfferHandler) Accept(c *gin.Context) {
+	token := c.Param("token")
+	if _, err := h.decisionService.AcceptOfferByToken(c.Request.Context(), token); err != nil {
+		handleOfferError(c, err, "Failed to accept offer")
+		return
+	}
+
+	offer, err := h.decisionService.GetOfferByToken(c.Request.Context(), token)
+	if err != nil {
+		handleOfferError(c, err, "Failed to retrieve offer")
+		return
+	}
+
+	response.OK(c, offerToPublicResponse(offer))
+}
+
+// handleOfferError maps offer link errors to HTTP responses.
+func handleOfferError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case admissionservice.ErrOfferLinkInvalid, admissionservice.ErrDecisionNotFound, admissionservice.ErrOfferNotFound:
+		apperrors.Abort(c, apperrors.NotFound("Offer not found"))
+	case admissionservice.ErrOfferLetterNotGenerated:
+		apperrors.Abort(c, apperrors.NotFound("Offer letter not found"))
+	case admissionservice.ErrOfferAlreadyAccepted:
+		apperrors.Abort(c, apperrors.Conflict("Offer has already been accepted"))
+	case admissionservice.ErrOfferExpired, admissionservice.ErrOfferLapsed:
+		apperrors.Abort(c, apperrors.BadRequest("Offer has expired"))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
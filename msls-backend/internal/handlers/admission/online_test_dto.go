@@ -0,0 +1,263 @@
+package admission
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/services/admission"
+)
+
+// =====================================================================
+// Online Entrance Test DTOs
+// =====================================================================
+
+// TestLoginRequest represents a candidate's login with their hall ticket details.
+type TestLoginRequest struct {
+	RollNumber  string `json:"rollNumber" binding:"required,max=20"`
+	DateOfBirth string `json:"dateOfBirth" binding:"required"`
+}
+
+// TestLoginResponse represents a successful candidate login.
+type TestLoginResponse struct {
+	Token            string    `json:"token"`
+	TestName         string    `json:"testName"`
+	StartedAt        time.Time `json:"startedAt"`
+	Deadline         time.Time `json:"deadline"`
+	RemainingSeconds int       `json:"remainingSeconds"`
+}
+
+// SaveAnswerRequest represents a candidate's answer to a question. Send empty
+// values to clear an answer.
+type SaveAnswerRequest struct {
+	SelectedOptions []string         `json:"selectedOptions"`
+	NumericAnswer   *decimal.Decimal `json:"numericAnswer"`
+	TextAnswer      string           `json:"textAnswer"`
+}
+
+// CandidateAnswerResponse represents a saved answer as shown to the candidate.
+type CandidateAnswerResponse struct {
+	QuestionID      string   `json:"questionId"`
+	SelectedOptions []string `json:"selectedOptions"`
+	NumericAnswer   *string  `json:"numericAnswer,omitempty"`
+	TextAnswer      string   `json:"textAnswer,omitempty"`
+	AnsweredAt      string   `json:"answeredAt"`
+}
+
+// CandidateQuestionResponse represents a question on the candidate's paper, without its answer key.
+type CandidateQuestionResponse struct {
+	ID            string                   `json:"id"`
+	QuestionType  string                   `json:"questionType"`
+	QuestionText  string                   `json:"questionText"`
+	Options       []QuestionOptionDTO      `json:"options,omitempty"`
+	Marks         string                   `json:"marks"`
+	NegativeMarks string                   `json:"negativeMarks"`
+	Answer        *CandidateAnswerResponse `json:"answer,omitempty"`
+}
+
+// CandidateSectionResponse represents a section of the candidate's paper.
+type CandidateSectionResponse struct {
+	ID           string                      `json:"id"`
+	Name         string                      `json:"name"`
+	Subject      string                      `json:"subject"`
+	Instructions string                      `json:"instructions,omitempty"`
+	Questions    []CandidateQuestionResponse `json:"questions"`
+}
+
+// CandidatePaperResponse represents the question paper served to a candidate.
+type CandidatePaperResponse struct {
+	TestName         string                     `json:"testName"`
+	Instructions     string                     `json:"instructions,omitempty"`
+	Deadline         time.Time                  `json:"deadline"`
+	RemainingSeconds int                        `json:"remainingSeconds"`
+	Sections         []CandidateSectionResponse `json:"sections"`
+}
+
+// TestSubmitResponse acknowledges a submitted online test.
+type TestSubmitResponse struct {
+	Status        string     `json:"status"`
+	SubmittedAt   *time.Time `json:"submittedAt,omitempty"`
+	AutoSubmitted bool       `json:"autoSubmitted"`
+}
+
+// GradeAnswerRequest represents a grader's marks for a descriptive answer.
+type GradeAnswerRequest struct {
+	Marks    decimal.Decimal `json:"marks" binding:"required"`
+	Feedback string          `json:"feedback" binding:"omitempty,max=2000"`
+}
+
+// TestAttemptResponse represents a candidate's online test attempt.
+type TestAttemptResponse struct {
+	ID               string  `json:"id"`
+	RegistrationID   string  `json:"registrationId"`
+	RollNumber       string  `json:"rollNumber,omitempty"`
+	Status           string  `json:"status"`
+	StartedAt        string  `json:"startedAt"`
+	Deadline         string  `json:"deadline"`
+	SubmittedAt      *string `json:"submittedAt,omitempty"`
+	AutoSubmitted    bool    `json:"autoSubmitted"`
+	ObjectiveScore   string  `json:"objectiveScore"`
+	DescriptiveScore string  `json:"descriptiveScore"`
+	PendingGrading   int     `json:"pendingGrading"`
+	GradedAt         *string `json:"gradedAt,omitempty"`
+}
+
+// TestAttemptListResponse represents the attempts at an online test.
+type TestAttemptListResponse struct {
+	Attempts []TestAttemptResponse `json:"attempts"`
+	Total    int                   `json:"total"`
+}
+
+// GradingQueueItemResponse represents a descriptive answer waiting for a grader.
+type GradingQueueItemResponse struct {
+	AnswerID     string `json:"answerId"`
+	RollNumber   string `json:"rollNumber"`
+	SectionName  string `json:"sectionName"`
+	Subject      string `json:"subject"`
+	QuestionID   string `json:"questionId"`
+	QuestionText string `json:"questionText"`
+	TextAnswer   string `json:"textAnswer"`
+	MaxMarks     string `json:"maxMarks"`
+}
+
+// GradingQueueResponse represents the grading queue of a test.
+type GradingQueueResponse struct {
+	Answers []GradingQueueItemResponse `json:"answers"`
+	Total   int                        `json:"total"`
+}
+
+// GradedAnswerResponse represents a graded descriptive answer.
+type GradedAnswerResponse struct {
+	AnswerID     string `json:"answerId"`
+	MarksAwarded string `json:"marksAwarded"`
+	Feedback     string `json:"feedback,omitempty"`
+	GradedAt     string `json:"gradedAt"`
+}
+
+// NewCandidateAnswerResponse creates a CandidateAnswerResponse from a TestAnswer entity.
+func NewCandidateAnswerResponse(answer *admission.TestAnswer) CandidateAnswerResponse {
+	selected := []string(answer.SelectedOptions)
+	if selected == nil {
+		selected = []string{}
+	}
+
+	resp := CandidateAnswerResponse{
+		QuestionID:      answer.QuestionID.String(),
+		SelectedOptions: selected,
+		TextAnswer:      answer.TextAnswer,
+		AnsweredAt:      answer.AnsweredAt.Format(time.RFC3339),
+	}
+	if answer.NumericAnswer != nil {
+		value := answer.NumericAnswer.String()
+		resp.NumericAnswer = &value
+	}
+	return resp
+}
+
+// NewCandidatePaperResponse creates a CandidatePaperResponse from a CandidatePaper.
+// Answer keys and tolerances are never included.
+func NewCandidatePaperResponse(paper *admission.CandidatePaper) CandidatePaperResponse {
+	answers := make(map[string]*admission.TestAnswer, len(paper.Answers))
+	for i := range paper.Answers {
+		answers[paper.Answers[i].QuestionID.String()] = &paper.Answers[i]
+	}
+
+	sections := make([]CandidateSectionResponse, 0, len(paper.Sections))
+	for i := range paper.Sections {
+		section := &paper.Sections[i]
+		sectionResp := CandidateSectionResponse{
+			ID:           section.ID.String(),
+			Name:         section.Name,
+			Subject:      section.Subject,
+			Instructions: section.Instructions,
+			Questions:    make([]CandidateQuestionResponse, 0, len(section.Questions)),
+		}
+
+		for _, sq := range section.Questions {
+			q := sq.Question
+			if q == nil {
+				continue
+			}
+			marks, negative := section.QuestionMarks(q)
+			questionResp := CandidateQuestionResponse{
+				ID:            q.ID.String(),
+				QuestionType:  string(q.QuestionType),
+				QuestionText:  q.QuestionText,
+				Marks:         marks.String(),
+				NegativeMarks: negative.String(),
+			}
+			for _, o := range q.Options {
+				questionResp.Options = append(questionResp.Options, QuestionOptionDTO{Key: o.Key, Text: o.Text})
+			}
+			if answer, ok := answers[q.ID.String()]; ok {
+				answerResp := NewCandidateAnswerResponse(answer)
+				questionResp.Answer = &answerResp
+			}
+			sectionResp.Questions = append(sectionResp.Questions, questionResp)
+		}
+		sections = append(sections, sectionResp)
+	}
+
+	return CandidatePaperResponse{
+		TestName:         paper.Test.TestName,
+		Instructions:     paper.Test.Instructions,
+		Deadline:         paper.Attempt.Deadline,
+		RemainingSeconds: remainingSeconds(paper.Attempt.Deadline),
+		Sections:         sections,
+	}
+}
+
+// NewTestAttemptResponse creates a TestAttemptResponse from a TestAttempt entity.
+func NewTestAttemptResponse(attempt *admission.TestAttempt) TestAttemptResponse {
+	resp := TestAttemptResponse{
+		ID:               attempt.ID.String(),
+		RegistrationID:   attempt.RegistrationID.String(),
+		Status:           string(attempt.Status),
+		StartedAt:        attempt.StartedAt.Format(time.RFC3339),
+		Deadline:         attempt.Deadline.Format(time.RFC3339),
+		AutoSubmitted:    attempt.AutoSubmitted,
+		ObjectiveScore:   attempt.ObjectiveScore.String(),
+		DescriptiveScore: attempt.DescriptiveScore.String(),
+		PendingGrading:   attempt.PendingGrading,
+	}
+	if attempt.Registration != nil {
+		resp.RollNumber = attempt.Registration.RollNumber
+	}
+	if attempt.SubmittedAt != nil {
+		submittedAt := attempt.SubmittedAt.Format(time.RFC3339)
+		resp.SubmittedAt = &submittedAt
+	}
+	if attempt.GradedAt != nil {
+		gradedAt := attempt.GradedAt.Format(time.RFC3339)
+		resp.GradedAt = &gradedAt
+	}
+	return resp
+}
+
+// NewGradingQueueItemResponse creates a GradingQueueItemResponse from a GradingQueueItem.
+func NewGradingQueueItemResponse(item *admission.GradingQueueItem) GradingQueueItemResponse {
+	resp := GradingQueueItemResponse{
+		AnswerID:   item.Answer.ID.String(),
+		RollNumber: item.RollNumber,
+		QuestionID: item.Answer.QuestionID.String(),
+		TextAnswer: item.Answer.TextAnswer,
+		MaxMarks:   item.MaxMarks.String(),
+	}
+	if item.Answer.Section != nil {
+		resp.SectionName = item.Answer.Section.Name
+		resp.Subject = item.Answer.Section.Subject
+	}
+	if item.Answer.Question != nil {
+		resp.QuestionText = item.Answer.Question.QuestionText
+	}
+	return resp
+}
+
+// remainingSeconds returns the whole seconds left until deadline, never negative.
+func remainingSeconds(deadline time.Time) int {
+	remaining := int(time.Until(deadline).Seconds())
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
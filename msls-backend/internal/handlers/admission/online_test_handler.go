@@ -0,0 +1,360 @@
+package admission
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+	admissionservice "msls-backend/internal/services/admission"
+)
+
+// TestTokenHeader carries the online test token issued at candidate login.
+const TestTokenHeader = "X-Test-Token"
+
+// testAttemptKey is the gin context key for the authenticated test attempt.
+const testAttemptKey = "online_test_attempt"
+
+// OnlineTestHandler handles online entrance test delivery and grading endpoints.
+type OnlineTestHandler struct {
+	onlineService *admissionservice.OnlineTestService
+}
+
+// NewOnlineTestHandler creates a new OnlineTestHandler.
+func NewOnlineTestHandler(onlineService *admissionservice.OnlineTestService) *OnlineTestHandler {
+	return &OnlineTestHandler{onlineService: onlineService}
+}
+
+// RequireTestSession authenticates the X-Test-Token header.
+// Must run after middleware.TenantRequired.
+func (h *OnlineTestHandler) RequireTestSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, ok := middleware.GetCurrentTenantID(c)
+		if !ok {
+			apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+			return
+		}
+
+		attempt, err := h.onlineService.Authenticate(c.Request.Context(), tenantID, c.GetHeader(TestTokenHeader))
+		if err != nil {
+			handleOnlineTestError(c, err, "Failed to authenticate test session")
+			return
+		}
+
+		c.Set(testAttemptKey, attempt)
+		c.Next()
+	}
+}
+
+// Login godoc
+// @Summary Start online entrance test
+// @Description Log in with the hall ticket roll number and date of birth to start or resume the online test. Returns a token for the X-Test-Token header.
+// @Tags Online Entrance Tests
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body TestLoginRequest true "Hall ticket details"
+// @Success 200 {object} response.Success{data=TestLoginResponse}
+// @Failure 401 {object} apperrors.AppError
+// @Failure 403 {object} apperrors.AppError
+// @Router /api/v1/public/entrance-tests/login [post]
+func (h *OnlineTestHandler) Login(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req TestLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	dob, err := time.Parse("2006-01-02", req.DateOfBirth)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid date of birth format. Use YYYY-MM-DD"))
+		return
+	}
+
+	login, err := h.onlineService.Login(c.Request.Context(), admissionservice.TestLoginRequest{
+		TenantID:    tenantID,
+		RollNumber:  req.RollNumber,
+		DateOfBirth: dob,
+	})
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to start online test")
+		return
+	}
+
+	response.OK(c, TestLoginResponse{
+		Token:            login.Token,
+		TestName:         login.Test.TestName,
+		StartedAt:        login.Attempt.StartedAt,
+		Deadline:         login.Attempt.Deadline,
+		RemainingSeconds: remainingSeconds(login.Attempt.Deadline),
+	})
+}
+
+// GetPaper godoc
+// @Summary Get online test paper
+// @Description Returns the question paper with the candidate's saved answers and remaining time
+// @Tags Online Entrance Tests
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Test-Token header string true "Test token"
+// @Success 200 {object} response.Success{data=CandidatePaperResponse}
+// @Failure 401 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/public/entrance-tests/paper [get]
+func (h *OnlineTestHandler) GetPaper(c *gin.Context) {
+	attempt := c.MustGet(testAttemptKey).(*admissionservice.TestAttempt)
+
+	paper, err := h.onlineService.GetPaper(c.Request.Context(), attempt)
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to load question paper")
+		return
+	}
+
+	response.OK(c, NewCandidatePaperResponse(paper))
+}
+
+// SaveAnswer godoc
+// @Summary Save answer
+// @Description Saves or replaces the candidate's answer to a question
+// @Tags Online Entrance Tests
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Test-Token header string true "Test token"
+// @Param questionId path string true "Question ID"
+// @Param request body SaveAnswerRequest true "Answer"
+// @Success 200 {object} response.Success{data=CandidateAnswerResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/public/entrance-tests/answers/{questionId} [put]
+func (h *OnlineTestHandler) SaveAnswer(c *gin.Context) {
+	attempt := c.MustGet(testAttemptKey).(*admissionservice.TestAttempt)
+
+	questionID, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid question ID"))
+		return
+	}
+
+	var req SaveAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	answer, err := h.onlineService.SaveAnswer(c.Request.Context(), attempt, admissionservice.AnswerRequest{
+		QuestionID:      questionID,
+		SelectedOptions: req.SelectedOptions,
+		NumericAnswer:   req.NumericAnswer,
+		TextAnswer:      req.TextAnswer,
+	})
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to save answer")
+		return
+	}
+
+	response.OK(c, NewCandidateAnswerResponse(answer))
+}
+
+// Submit godoc
+// @Summary Submit online test
+// @Description Ends the online test. Objective answers are scored immediately.
+// @Tags Online Entrance Tests
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Test-Token header string true "Test token"
+// @Success 200 {object} response.Success{data=TestSubmitResponse}
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/public/entrance-tests/submit [post]
+func (h *OnlineTestHandler) Submit(c *gin.Context) {
+	attempt := c.MustGet(testAttemptKey).(*admissionservice.TestAttempt)
+
+	attempt, err := h.onlineService.Submit(c.Request.Context(), attempt)
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to submit online test")
+		return
+	}
+
+	response.OK(c, TestSubmitResponse{
+		Status:        string(attempt.Status),
+		SubmittedAt:   attempt.SubmittedAt,
+		AutoSubmitted: attempt.AutoSubmitted,
+	})
+}
+
+// ListAttempts godoc
+// @Summary List online test attempts
+// @Description Lists candidates' online attempts at a test with their scores
+// @Tags Entrance Tests
+// @Produce json
+// @Param id path string true "Test ID"
+// @Success 200 {object} response.Success{data=TestAttemptListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/attempts [get]
+func (h *OnlineTestHandler) ListAttempts(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	attempts, err := h.onlineService.ListAttempts(c.Request.Context(), tenantID, testID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to retrieve attempts"))
+		return
+	}
+
+	resp := make([]TestAttemptResponse, len(attempts))
+	for i := range attempts {
+		resp[i] = NewTestAttemptResponse(&attempts[i])
+	}
+	response.OK(c, TestAttemptListResponse{Attempts: resp, Total: len(resp)})
+}
+
+// GradingQueue godoc
+// @Summary Get grading queue
+// @Description Lists descriptive answers of submitted attempts that still need marks
+// @Tags Entrance Tests
+// @Produce json
+// @Param id path string true "Test ID"
+// @Success 200 {object} response.Success{data=GradingQueueResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/grading-queue [get]
+func (h *OnlineTestHandler) GradingQueue(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	queue, err := h.onlineService.GradingQueue(c.Request.Context(), tenantID, testID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to retrieve grading queue"))
+		return
+	}
+
+	resp := make([]GradingQueueItemResponse, len(queue))
+	for i := range queue {
+		resp[i] = NewGradingQueueItemResponse(&queue[i])
+	}
+	response.OK(c, GradingQueueResponse{Answers: resp, Total: len(resp)})
+}
+
+// GradeAnswer godoc
+// @Summary Grade descriptive answer
+// @Description Awards marks to a descriptive answer. The result is recorded once every answer of the attempt is graded.
+// @Tags Entrance Tests
+// @Accept json
+// @Produce json
+// @Param id path string true "Test ID"
+// @Param answerId path string true "Answer ID"
+// @Param request body GradeAnswerRequest true "Marks and feedback"
+// @Success 200 {object} response.Success{data=GradedAnswerResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/answers/{answerId}/grade [put]
+func (h *OnlineTestHandler) GradeAnswer(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	answerID, err := uuid.Parse(c.Param("answerId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid answer ID"))
+		return
+	}
+
+	var req GradeAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.GetCurrentUserID(c); ok {
+		userID = &id
+	}
+
+	answer, err := h.onlineService.GradeAnswer(c.Request.Context(), admissionservice.GradeAnswerRequest{
+		TenantID: tenantID,
+		TestID:   testID,
+		AnswerID: answerID,
+		Marks:    req.Marks,
+		Feedback: req.Feedback,
+		GradedBy: userID,
+	})
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to grade answer")
+		return
+	}
+
+	response.OK(c, GradedAnswerResponse{
+		AnswerID:     answer.ID.String(),
+		MarksAwarded: answer.MarksAwarded.String(),
+		Feedback:     answer.Feedback,
+		GradedAt:     answer.GradedAt.Format(time.RFC3339),
+	})
+}
+
+// handleOnlineTestError maps question bank and online test errors to HTTP responses.
+// Validation errors carry their detail, so their message is passed through.
+func handleOnlineTestError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, admissionservice.ErrTestNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Entrance test not found"))
+	case errors.Is(err, admissionservice.ErrQuestionNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Question not found"))
+	case errors.Is(err, admissionservice.ErrSectionNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Question paper section not found"))
+	case errors.Is(err, admissionservice.ErrAnswerNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Answer not found"))
+	case errors.Is(err, admissionservice.ErrQuestionNotOnPaper):
+		apperrors.Abort(c, apperrors.NotFound("Question is not on the question paper"))
+	case errors.Is(err, admissionservice.ErrInvalidQuestion),
+		errors.Is(err, admissionservice.ErrInvalidAnswer),
+		errors.Is(err, admissionservice.ErrPaperIncomplete):
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+	case errors.Is(err, admissionservice.ErrSubjectNotInTest):
+		apperrors.Abort(c, apperrors.BadRequest("Subject is not one of the test's subjects"))
+	case errors.Is(err, admissionservice.ErrInvalidGradeMarks):
+		apperrors.Abort(c, apperrors.BadRequest("Awarded marks must be between zero and the question's marks"))
+	case errors.Is(err, admissionservice.ErrQuestionInUse):
+		apperrors.Abort(c, apperrors.Conflict("Question is used on a question paper. Deactivate it instead."))
+	case errors.Is(err, admissionservice.ErrQuestionAlreadyOnPaper):
+		apperrors.Abort(c, apperrors.Conflict("Question is already on the question paper"))
+	case errors.Is(err, admissionservice.ErrPaperPublished):
+		apperrors.Abort(c, apperrors.Conflict("Question paper is published. Unpublish it before making changes."))
+	case errors.Is(err, admissionservice.ErrPaperLocked):
+		apperrors.Abort(c, apperrors.Conflict("Candidates have already started the online test"))
+	case errors.Is(err, admissionservice.ErrCannotModifyCompletedTest):
+		apperrors.Abort(c, apperrors.Conflict("Cannot modify a completed test"))
+	case errors.Is(err, admissionservice.ErrTestNotScheduled):
+		apperrors.Abort(c, apperrors.Conflict("Test is not in scheduled status"))
+	case errors.Is(err, admissionservice.ErrAnswerNotGradable):
+		apperrors.Abort(c, apperrors.Conflict("Only descriptive answers awaiting results can be graded"))
+	case errors.Is(err, admissionservice.ErrTestLoginFailed):
+		apperrors.Abort(c, apperrors.Unauthorized("Roll number or date of birth is incorrect, or no online test is open"))
+	case errors.Is(err, admissionservice.ErrTestSessionInvalid):
+		apperrors.Abort(c, apperrors.Unauthorized("Test session is invalid. Please log in again."))
+	case errors.Is(err, admissionservice.ErrTestNotStarted):
+		apperrors.Abort(c, apperrors.Forbidden("The online test has not started yet"))
+	case errors.Is(err, admissionservice.ErrTestEntryClosed):
+		apperrors.Abort(c, apperrors.Forbidden("Entry to the online test has closed"))
+	case errors.Is(err, admissionservice.ErrTestTimeUp):
+		apperrors.Abort(c, apperrors.Conflict("Test time is over. Your answers have been submitted."))
+	case errors.Is(err, admissionservice.ErrAttemptSubmitted):
+		apperrors.Abort(c, apperrors.Conflict("The online test has already been submitted"))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
@@ -0,0 +1,127 @@
+// Package admission provides HTTP handlers for admission management endpoints.
+package admission
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// =============================================================================
+// Online Admission Portal DTOs
+// =============================================================================
+
+// PortalSessionResponse represents a session open for online applications.
+type PortalSessionResponse struct {
+	ID                string               `json:"id"`
+	Name              string               `json:"name"`
+	Description       string               `json:"description,omitempty"`
+	StartDate         string               `json:"startDate"`
+	EndDate           string               `json:"endDate"`
+	ApplicationFee    decimal.Decimal      `json:"applicationFee"`
+	RequiredDocuments []string             `json:"requiredDocuments"`
+	Instructions      string               `json:"instructions,omitempty"`
+	Seats             []PortalSeatResponse `json:"seats"`
+}
+
+// PortalSeatResponse represents seat availability for a class.
+type PortalSeatResponse struct {
+	ClassName      string `json:"className"`
+	TotalSeats     int    `json:"totalSeats"`
+	AvailableSeats int    `json:"availableSeats"`
+}
+
+// PortalOTPRequest represents a request to send a phone verification OTP.
+type PortalOTPRequest struct {
+	Phone           string `json:"phone" binding:"required"`
+	CaptchaResponse string `json:"captchaResponse,omitempty"`
+}
+
+// PortalOTPResponse represents the response after sending an OTP.
+type PortalOTPResponse struct {
+	Message     string `json:"message"`
+	ExpiresIn   int    `json:"expiresIn"`
+	MaskedPhone string `json:"maskedPhone"`
+}
+
+// PortalVerifyRequest represents a request to verify a phone number.
+type PortalVerifyRequest struct {
+	Phone string `json:"phone" binding:"required"`
+	Code  string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// PortalVerifyResponse represents a portal session token.
+type PortalVerifyResponse struct {
+	Token     string    `json:"token"`
+	Phone     string    `json:"phone"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// PortalSubmitRequest represents a request to submit an online application.
+type PortalSubmitRequest struct {
+	CaptchaResponse string `json:"captchaResponse,omitempty"`
+}
+
+// PortalCheckoutResponse holds what the browser needs to open the payment gateway checkout.
+type PortalCheckoutResponse struct {
+	PaymentID   string          `json:"paymentId"`
+	Provider    string          `json:"provider"`
+	CheckoutKey string          `json:"checkoutKey"`
+	OrderID     string          `json:"orderId"`
+	Amount      decimal.Decimal `json:"amount"`
+	Currency    string          `json:"currency"`
+}
+
+// PortalPaymentVerifyRequest represents the result returned by the gateway checkout.
+type PortalPaymentVerifyRequest struct {
+	OrderID   string `json:"orderId" binding:"required"`
+	PaymentID string `json:"paymentId" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
+}
+
+// PortalPaymentResponse represents the status of an application fee payment.
+type PortalPaymentResponse struct {
+	PaymentID string          `json:"paymentId"`
+	Status    string          `json:"status"`
+	Amount    decimal.Decimal `json:"amount"`
+	Confirmed bool            `json:"confirmed"`
+	PaidAt    *time.Time      `json:"paidAt,omitempty"`
+}
+
+// =============================================================================
+// Conversion helpers
+// =============================================================================
+
+// portalSessionToResponse converts a session with its seats to a portal response.
+func portalSessionToResponse(session *models.AdmissionSession) PortalSessionResponse {
+	resp := PortalSessionResponse{
+		ID:                session.ID.String(),
+		Name:              session.Name,
+		Description:       session.Description,
+		StartDate:         session.StartDate.Format("2006-01-02"),
+		EndDate:           session.EndDate.Format("2006-01-02"),
+		ApplicationFee:    session.ApplicationFee,
+		RequiredDocuments: []string(session.RequiredDocuments),
+		Instructions:      session.Settings.Instructions,
+		Seats:             make([]PortalSeatResponse, 0, len(session.Seats)),
+	}
+	if resp.RequiredDocuments == nil {
+		resp.RequiredDocuments = []string{}
+	}
+
+	for _, seat := range session.Seats {
+		available := seat.TotalSeats - seat.FilledSeats
+		if available < 0 {
+			available = 0
+		}
+		resp.Seats = append(resp.Seats, PortalSeatResponse{
+			ClassName:      seat.ClassName,
+			TotalSeats:     seat.TotalSeats,
+			AvailableSeats: available,
+		})
+	}
+
+	return resp
+}
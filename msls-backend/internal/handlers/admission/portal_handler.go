@@ -0,0 +1,605 @@
+// Package admission provides HTTP handlers for admission management endpoints.
+package admission
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/payments"
+	"msls-backend/internal/pkg/response"
+	admissionservice "msls-backend/internal/services/admission"
+	authservice "msls-backend/internal/services/auth"
+	paymentservice "msls-backend/internal/services/payment"
+)
+
+// PortalTokenHeader carries the portal session token issued after phone verification.
+const PortalTokenHeader = "X-Portal-Token"
+
+// portalSessionKey is the gin context key for the authenticated portal session.
+const portalSessionKey = "admission_portal_session"
+
+// PortalHandler handles the public online admission portal endpoints.
+type PortalHandler struct {
+	portalService *admissionservice.PortalService
+}
+
+// NewPortalHandler creates a new PortalHandler.
+func NewPortalHandler(portalService *admissionservice.PortalService) *PortalHandler {
+	return &PortalHandler{portalService: portalService}
+}
+
+// RequirePortalSession authenticates the X-Portal-Token header.
+// Must run after middleware.TenantRequired.
+func (h *PortalHandler) RequirePortalSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, ok := middleware.GetCurrentTenantID(c)
+		if !ok {
+			apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+			return
+		}
+
+		portal, err := h.portalService.Authenticate(c.Request.Context(), tenantID, c.GetHeader(PortalTokenHeader))
+		if err != nil {
+			handlePortalError(c, err, "Failed to authenticate portal session")
+			return
+		}
+
+		c.Set(portalSessionKey, portal)
+		c.Next()
+	}
+}
+
+// ListSessions returns the admission sessions open for online applications.
+// @Summary List open admission sessions
+// @Description List sessions accepting online applications with seat availability and required documents
+// @Tags Online Admissions
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {object} response.Success{data=[]PortalSessionResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/sessions [get]
+func (h *PortalHandler) ListSessions(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	sessions, err := h.portalService.ListOpenSessions(c.Request.Context(), tenantID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to list admission sessions"))
+		return
+	}
+
+	resp := make([]PortalSessionResponse, len(sessions))
+	for i := range sessions {
+		resp[i] = portalSessionToResponse(&sessions[i])
+	}
+
+	response.OK(c, resp)
+}
+
+// RequestOTP sends a verification OTP to a parent's phone.
+// @Summary Request phone verification OTP
+// @Description Send an OTP by SMS to verify the parent's phone number
+// @Tags Online Admissions
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body PortalOTPRequest true "Phone number"
+// @Success 200 {object} response.Success{data=PortalOTPResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 403 {object} apperrors.AppError
+// @Failure 429 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/otp [post]
+func (h *PortalHandler) RequestOTP(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req PortalOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.portalService.RequestOTP(c.Request.Context(), tenantID, req.Phone, req.CaptchaResponse, c.ClientIP())
+	if err != nil {
+		handlePortalError(c, err, "Failed to send OTP")
+		return
+	}
+
+	response.OK(c, PortalOTPResponse{
+		Message:     result.Message,
+		ExpiresIn:   result.ExpiresIn,
+		MaskedPhone: result.MaskedIdentifier,
+	})
+}
+
+// VerifyOTP verifies a parent's phone and issues a portal token.
+// @Summary Verify phone number
+// @Description Verify the OTP and return a portal token for the X-Portal-Token header
+// @Tags Online Admissions
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body PortalVerifyRequest true "Phone and OTP code"
+// @Success 200 {object} response.Success{data=PortalVerifyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/verify [post]
+func (h *PortalHandler) VerifyOTP(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req PortalVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	token, portal, err := h.portalService.VerifyPhone(c.Request.Context(), tenantID, req.Phone, req.Code)
+	if err != nil {
+		handlePortalError(c, err, "Failed to verify phone number")
+		return
+	}
+
+	response.OK(c, PortalVerifyResponse{
+		Token:     token,
+		Phone:     portal.Phone,
+		ExpiresAt: portal.ExpiresAt,
+	})
+}
+
+// ListApplications returns the online applications of the verified phone.
+// @Summary List my applications
+// @Description List online applications started with the verified phone number
+// @Tags Online Admissions
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Success 200 {object} response.Success{data=[]ApplicationResponse}
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications [get]
+func (h *PortalHandler) ListApplications(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	applications, err := h.portalService.ListApplications(c.Request.Context(), portal)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to list applications"))
+		return
+	}
+
+	response.OK(c, applicationsToResponses(applications))
+}
+
+// Create starts a draft online application.
+// @Summary Start an application
+// @Description Save a draft online application for an open session
+// @Tags Online Admissions
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Param request body CreateApplicationRequest true "Application details"
+// @Success 201 {object} response.Success{data=ApplicationResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 403 {object} apperrors.AppError
+// @Failure 429 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications [post]
+func (h *PortalHandler) Create(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	var req CreateApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	sessionID, err := uuid.Parse(req.SessionID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid session ID"))
+		return
+	}
+
+	createReq := toCreateApplicationRequest(req)
+	createReq.SessionID = sessionID
+
+	application, err := h.portalService.StartApplication(c.Request.Context(), portal, createReq)
+	if err != nil {
+		handlePortalError(c, err, "Failed to create application")
+		return
+	}
+
+	response.Created(c, applicationToResponse(application))
+}
+
+// Get returns one of the parent's online applications.
+// @Summary Get my application
+// @Description Get an online application with its parents and documents
+// @Tags Online Admissions
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Param id path string true "Application ID" format(uuid)
+// @Success 200 {object} response.Success{data=ApplicationResponse}
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications/{id} [get]
+func (h *PortalHandler) Get(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	application, err := h.portalService.GetApplication(c.Request.Context(), portal, id)
+	if err != nil {
+		handlePortalError(c, err, "Failed to get application")
+		return
+	}
+
+	response.OK(c, applicationToResponseWithRelations(application, application.Parents, application.Documents))
+}
+
+// Update updates a draft online application.
+// @Summary Update my application
+// @Description Update applicant and parent details of a draft online application
+// @Tags Online Admissions
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Param id path string true "Application ID" format(uuid)
+// @Param request body UpdateApplicationRequest true "Application updates"
+// @Success 200 {object} response.Success{data=ApplicationResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications/{id} [put]
+func (h *PortalHandler) Update(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	var req UpdateApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	application, err := h.portalService.UpdateApplication(c.Request.Context(), portal, id, toUpdateApplicationRequest(req))
+	if err != nil {
+		handlePortalError(c, err, "Failed to update application")
+		return
+	}
+
+	response.OK(c, applicationToResponse(application))
+}
+
+// UploadDocument uploads a document for a draft online application.
+// @Summary Upload a document
+// @Description Upload a PDF, JPEG or PNG document (max 5MB) for a draft online application
+// @Tags Online Admissions
+// @Accept multipart/form-data
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Param id path string true "Application ID" format(uuid)
+// @Param documentType formData string true "Document type"
+// @Param file formData file true "Document file"
+// @Success 201 {object} response.Success{data=AppDocumentResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications/{id}/documents [post]
+func (h *PortalHandler) UploadDocument(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	documentType := c.PostForm("documentType")
+	if documentType == "" {
+		apperrors.Abort(c, apperrors.BadRequest("Document type is required"))
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("File is required"))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > admissionservice.MaxPortalDocumentSize {
+		apperrors.Abort(c, apperrors.BadRequest("File size exceeds maximum allowed (5MB)"))
+		return
+	}
+
+	// Read one byte past the limit so oversized files are still rejected by the service
+	data, err := io.ReadAll(io.LimitReader(file, admissionservice.MaxPortalDocumentSize+1))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Failed to read file"))
+		return
+	}
+
+	document, err := h.portalService.UploadDocument(c.Request.Context(), portal, id, admissionservice.PortalDocumentUpload{
+		DocumentType: documentType,
+		FileName:     header.Filename,
+		MimeType:     http.DetectContentType(data),
+		Data:         data,
+	})
+	if err != nil {
+		handlePortalError(c, err, "Failed to upload document")
+		return
+	}
+
+	response.Created(c, documentToResponse(document))
+}
+
+// DeleteDocument removes a document from a draft online application.
+// @Summary Delete a document
+// @Description Remove an uploaded document from a draft online application
+// @Tags Online Admissions
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Param id path string true "Application ID" format(uuid)
+// @Param documentId path string true "Document ID" format(uuid)
+// @Success 204 "No Content"
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications/{id}/documents/{documentId} [delete]
+func (h *PortalHandler) DeleteDocument(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	documentID, err := uuid.Parse(c.Param("documentId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid document ID"))
+		return
+	}
+
+	if err := h.portalService.DeleteDocument(c.Request.Context(), portal, id, documentID); err != nil {
+		handlePortalError(c, err, "Failed to delete document")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// Submit submits a draft online application.
+// @Summary Submit my application
+// @Description Submit a draft online application once required fields and documents are complete
+// @Tags Online Admissions
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Param id path string true "Application ID" format(uuid)
+// @Param request body PortalSubmitRequest false "CAPTCHA response"
+// @Success 200 {object} response.Success{data=ApplicationResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 429 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications/{id}/submit [post]
+func (h *PortalHandler) Submit(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	var req PortalSubmitRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+			return
+		}
+	}
+
+	application, err := h.portalService.Submit(c.Request.Context(), portal, id, req.CaptchaResponse, c.ClientIP())
+	if err != nil {
+		handlePortalError(c, err, "Failed to submit application")
+		return
+	}
+
+	response.OK(c, applicationToResponse(application))
+}
+
+// CreatePayment opens a payment gateway order for an application fee.
+// @Summary Pay the application fee
+// @Description Create a payment gateway order for the application fee and return the checkout details
+// @Tags Online Admissions
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Param id path string true "Application ID" format(uuid)
+// @Success 200 {object} response.Success{data=PortalCheckoutResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications/{id}/payment [post]
+func (h *PortalHandler) CreatePayment(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	checkout, err := h.portalService.CreateFeeOrder(c.Request.Context(), portal, id)
+	if err != nil {
+		handlePortalError(c, err, "Failed to start payment")
+		return
+	}
+
+	response.OK(c, PortalCheckoutResponse{
+		PaymentID:   checkout.Payment.ID.String(),
+		Provider:    checkout.Provider,
+		CheckoutKey: checkout.CheckoutKey,
+		OrderID:     checkout.Payment.ProviderOrderID,
+		Amount:      checkout.Payment.Amount,
+		Currency:    checkout.Payment.Currency,
+	})
+}
+
+// VerifyPayment records the payment gateway checkout result for an application fee.
+// @Summary Verify the application fee payment
+// @Description Verify the signed checkout result and record the captured payment
+// @Tags Online Admissions
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param X-Portal-Token header string true "Portal token"
+// @Param id path string true "Application ID" format(uuid)
+// @Param request body PortalPaymentVerifyRequest true "Checkout result"
+// @Success 200 {object} response.Success{data=PortalPaymentResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/public/admissions/applications/{id}/payment/verify [post]
+func (h *PortalHandler) VerifyPayment(c *gin.Context) {
+	portal := getPortalSession(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+		return
+	}
+
+	var req PortalPaymentVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	payment, err := h.portalService.ConfirmFeePayment(c.Request.Context(), portal, id, payments.CheckoutConfirmation{
+		OrderID:   req.OrderID,
+		PaymentID: req.PaymentID,
+		Signature: req.Signature,
+	})
+	if err != nil {
+		handlePortalError(c, err, "Failed to verify payment")
+		return
+	}
+
+	response.OK(c, PortalPaymentResponse{
+		PaymentID: payment.ID.String(),
+		Status:    string(payment.Status),
+		Amount:    payment.CapturedAmount,
+		Confirmed: payment.ConfirmedAt != nil,
+		PaidAt:    payment.PaidAt,
+	})
+}
+
+// getPortalSession returns the portal session set by RequirePortalSession.
+func getPortalSession(c *gin.Context) *models.AdmissionPortalSession {
+	portal, _ := c.MustGet(portalSessionKey).(*models.AdmissionPortalSession)
+	return portal
+}
+
+// handlePortalError maps portal and OTP service errors to HTTP responses.
+func handlePortalError(c *gin.Context, err error, fallback string) {
+	var missing *admissionservice.MissingDocumentsError
+	switch {
+	case errors.As(err, &missing):
+		apperrors.Abort(c, apperrors.BadRequest(missing.Error()))
+	case errors.Is(err, admissionservice.ErrPortalSessionInvalid):
+		apperrors.Abort(c, apperrors.Unauthorized("Portal session is invalid or has expired. Please verify your phone again."))
+	case errors.Is(err, admissionservice.ErrCaptchaFailed):
+		apperrors.Abort(c, apperrors.BadRequest("CAPTCHA verification failed"))
+	case errors.Is(err, admissionservice.ErrOnlineApplicationsClosed):
+		apperrors.Abort(c, apperrors.Forbidden("Online applications are not open"))
+	case errors.Is(err, admissionservice.ErrDailyApplicationLimitReached):
+		apperrors.Abort(c, apperrors.TooManyRequests(3600))
+	case errors.Is(err, admissionservice.ErrSessionNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Admission session not found"))
+	case errors.Is(err, admissionservice.ErrApplicationNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Application not found"))
+	case errors.Is(err, admissionservice.ErrDocumentNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Document not found"))
+	case errors.Is(err, admissionservice.ErrApplicationAlreadySubmitted):
+		apperrors.Abort(c, apperrors.Conflict("Application has already been submitted"))
+	case errors.Is(err, admissionservice.ErrClassNotOffered):
+		apperrors.Abort(c, apperrors.BadRequest("Class is not offered in this admission session"))
+	case errors.Is(err, admissionservice.ErrInvalidQuotaCategory):
+		apperrors.Abort(c, apperrors.BadRequest("Invalid quota category"))
+	case errors.Is(err, admissionservice.ErrMissingRequiredFields):
+		apperrors.Abort(c, apperrors.BadRequest("Student name, class, date of birth and gender are required"))
+	case errors.Is(err, admissionservice.ErrParentNameRequired):
+		apperrors.Abort(c, apperrors.BadRequest("At least one parent or guardian name is required"))
+	case errors.Is(err, admissionservice.ErrInvalidDocumentType):
+		apperrors.Abort(c, apperrors.BadRequest("Invalid document type"))
+	case errors.Is(err, admissionservice.ErrFileTooLarge):
+		apperrors.Abort(c, apperrors.BadRequest("File size exceeds maximum allowed (5MB)"))
+	case errors.Is(err, admissionservice.ErrInvalidFileType):
+		apperrors.Abort(c, apperrors.BadRequest("Only PDF, JPEG and PNG files are allowed"))
+	case errors.Is(err, admissionservice.ErrOnlinePaymentUnavailable):
+		apperrors.Abort(c, apperrors.Forbidden("Online payment is not available"))
+	case errors.Is(err, paymentservice.ErrApplicationNotFound), errors.Is(err, paymentservice.ErrPaymentNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Payment not found"))
+	case errors.Is(err, paymentservice.ErrNoFeeDue):
+		apperrors.Abort(c, apperrors.BadRequest("No application fee is due"))
+	case errors.Is(err, paymentservice.ErrAlreadyPaid):
+		apperrors.Abort(c, apperrors.Conflict("Application fee has already been paid"))
+	case errors.Is(err, paymentservice.ErrInvalidSignature):
+		apperrors.Abort(c, apperrors.BadRequest("Payment verification failed"))
+	case errors.Is(err, paymentservice.ErrPaymentNotCaptured):
+		apperrors.Abort(c, apperrors.Conflict("Payment has not been completed"))
+	case errors.Is(err, paymentservice.ErrGatewayFailed):
+		apperrors.Abort(c, apperrors.InternalError("Payment gateway is unavailable. Please try again."))
+	case errors.Is(err, authservice.ErrInvalidIdentifier):
+		apperrors.Abort(c, apperrors.BadRequest("Invalid phone number"))
+	case errors.Is(err, authservice.ErrOTPInvalid):
+		apperrors.Abort(c, apperrors.Unauthorized("Invalid OTP code"))
+	case errors.Is(err, authservice.ErrOTPExpired):
+		apperrors.Abort(c, apperrors.BadRequest("OTP has expired. Please request a new one."))
+	case errors.Is(err, authservice.ErrOTPAlreadyUsed):
+		apperrors.Abort(c, apperrors.BadRequest("OTP has already been used"))
+	case errors.Is(err, authservice.ErrOTPMaxAttempts):
+		apperrors.Abort(c, apperrors.BadRequest("Maximum verification attempts exceeded. Please request a new OTP."))
+	case errors.Is(err, authservice.ErrOTPRateLimited):
+		apperrors.Abort(c, apperrors.TooManyRequests(3600))
+	case errors.Is(err, authservice.ErrOTPCooldown):
+		apperrors.Abort(c, apperrors.TooManyRequests(60))
+	case errors.Is(err, authservice.ErrSMSSendFailed):
+		apperrors.Abort(c, apperrors.InternalError("Failed to send SMS. Please try again."))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
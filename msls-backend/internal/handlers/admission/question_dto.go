@@ -0,0 +1,247 @@
+package admission
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/services/admission"
+)
+
+// =====================================================================
+// Question Bank DTOs
+// =====================================================================
+
+// QuestionOptionDTO represents an answer choice of a multiple-choice question.
+type QuestionOptionDTO struct {
+	Key  string `json:"key" binding:"required,max=10"`
+	Text string `json:"text" binding:"required"`
+}
+
+// QuestionRequest represents a request to create or replace a question bank question.
+type QuestionRequest struct {
+	Subject          string              `json:"subject" binding:"required,max=100"`
+	Topic            string              `json:"topic" binding:"omitempty,max=200"`
+	QuestionType     string              `json:"questionType" binding:"required,oneof=mcq numeric descriptive"`
+	QuestionText     string              `json:"questionText" binding:"required"`
+	Options          []QuestionOptionDTO `json:"options" binding:"omitempty,dive"`
+	CorrectOptions   []string            `json:"correctOptions"`
+	NumericAnswer    *decimal.Decimal    `json:"numericAnswer"`
+	NumericTolerance decimal.Decimal     `json:"numericTolerance"`
+	Marks            decimal.Decimal     `json:"marks" binding:"required"`
+	NegativeMarks    decimal.Decimal     `json:"negativeMarks"`
+	IsActive         *bool               `json:"isActive"`
+}
+
+// ToServiceRequest converts the DTO to a service request.
+func (r *QuestionRequest) ToServiceRequest(tenantID uuid.UUID, userID *uuid.UUID) admission.QuestionRequest {
+	options := make([]admission.QuestionOption, len(r.Options))
+	for i, o := range r.Options {
+		options[i] = admission.QuestionOption{Key: o.Key, Text: o.Text}
+	}
+
+	return admission.QuestionRequest{
+		TenantID:         tenantID,
+		Subject:          r.Subject,
+		Topic:            r.Topic,
+		QuestionType:     admission.QuestionType(r.QuestionType),
+		QuestionText:     r.QuestionText,
+		Options:          options,
+		CorrectOptions:   r.CorrectOptions,
+		NumericAnswer:    r.NumericAnswer,
+		NumericTolerance: r.NumericTolerance,
+		Marks:            r.Marks,
+		NegativeMarks:    r.NegativeMarks,
+		IsActive:         r.IsActive,
+		UserID:           userID,
+	}
+}
+
+// SectionRequest represents a request to create or update a question paper section.
+type SectionRequest struct {
+	Name             string           `json:"name" binding:"required,max=100"`
+	Subject          string           `json:"subject" binding:"required,max=100"`
+	Sequence         int              `json:"sequence" binding:"omitempty,min=0"`
+	Instructions     string           `json:"instructions"`
+	MarksPerQuestion *decimal.Decimal `json:"marksPerQuestion"`
+	NegativeMarks    *decimal.Decimal `json:"negativeMarks"`
+}
+
+// ToServiceRequest converts the DTO to a service request.
+func (r *SectionRequest) ToServiceRequest(tenantID, testID uuid.UUID) admission.SectionRequest {
+	return admission.SectionRequest{
+		TenantID:         tenantID,
+		TestID:           testID,
+		Name:             r.Name,
+		Subject:          r.Subject,
+		Sequence:         r.Sequence,
+		Instructions:     r.Instructions,
+		MarksPerQuestion: r.MarksPerQuestion,
+		NegativeMarks:    r.NegativeMarks,
+	}
+}
+
+// AddSectionQuestionRequest represents a request to place a question in a section.
+type AddSectionQuestionRequest struct {
+	QuestionID string `json:"questionId" binding:"required,uuid"`
+	Sequence   int    `json:"sequence" binding:"omitempty,min=0"`
+}
+
+// PublishPaperRequest represents a request to publish a question paper for online delivery.
+type PublishPaperRequest struct {
+	// LateEntryMinutes is how long after the start time candidates may still begin.
+	LateEntryMinutes *int `json:"lateEntryMinutes" binding:"omitempty,min=0"`
+}
+
+// QuestionResponse represents a question bank question in the response.
+type QuestionResponse struct {
+	ID               string              `json:"id"`
+	Subject          string              `json:"subject"`
+	Topic            string              `json:"topic,omitempty"`
+	QuestionType     string              `json:"questionType"`
+	QuestionText     string              `json:"questionText"`
+	Options          []QuestionOptionDTO `json:"options"`
+	CorrectOptions   []string            `json:"correctOptions"`
+	NumericAnswer    *string             `json:"numericAnswer,omitempty"`
+	NumericTolerance string              `json:"numericTolerance"`
+	Marks            string              `json:"marks"`
+	NegativeMarks    string              `json:"negativeMarks"`
+	IsActive         bool                `json:"isActive"`
+	CreatedAt        string              `json:"createdAt"`
+	UpdatedAt        string              `json:"updatedAt"`
+}
+
+// QuestionListResponse represents a list of question bank questions.
+type QuestionListResponse struct {
+	Questions []QuestionResponse `json:"questions"`
+	Total     int                `json:"total"`
+}
+
+// SectionQuestionResponse represents a question placed in a paper section.
+type SectionQuestionResponse struct {
+	Sequence      int              `json:"sequence"`
+	Marks         string           `json:"marks"`
+	NegativeMarks string           `json:"negativeMarks"`
+	Question      QuestionResponse `json:"question"`
+}
+
+// SectionResponse represents a question paper section in the response.
+type SectionResponse struct {
+	ID               string                    `json:"id"`
+	TestID           string                    `json:"testId"`
+	Name             string                    `json:"name"`
+	Subject          string                    `json:"subject"`
+	Sequence         int                       `json:"sequence"`
+	Instructions     string                    `json:"instructions,omitempty"`
+	MarksPerQuestion *string                   `json:"marksPerQuestion,omitempty"`
+	NegativeMarks    *string                   `json:"negativeMarks,omitempty"`
+	Questions        []SectionQuestionResponse `json:"questions"`
+}
+
+// QuestionPaperResponse represents a test's question paper in the response.
+type QuestionPaperResponse struct {
+	TestID           string            `json:"testId"`
+	TestName         string            `json:"testName"`
+	OnlineEnabled    bool              `json:"onlineEnabled"`
+	LateEntryMinutes int               `json:"lateEntryMinutes"`
+	Subjects         []SubjectMarksDTO `json:"subjects"`
+	SubjectTotals    map[string]string `json:"subjectTotals"`
+	Sections         []SectionResponse `json:"sections"`
+}
+
+// NewQuestionResponse creates a QuestionResponse from a TestQuestion entity.
+func NewQuestionResponse(q *admission.TestQuestion) QuestionResponse {
+	options := make([]QuestionOptionDTO, len(q.Options))
+	for i, o := range q.Options {
+		options[i] = QuestionOptionDTO{Key: o.Key, Text: o.Text}
+	}
+	correct := []string(q.CorrectOptions)
+	if correct == nil {
+		correct = []string{}
+	}
+
+	resp := QuestionResponse{
+		ID:               q.ID.String(),
+		Subject:          q.Subject,
+		Topic:            q.Topic,
+		QuestionType:     string(q.QuestionType),
+		QuestionText:     q.QuestionText,
+		Options:          options,
+		CorrectOptions:   correct,
+		NumericTolerance: q.NumericTolerance.String(),
+		Marks:            q.Marks.String(),
+		NegativeMarks:    q.NegativeMarks.String(),
+		IsActive:         q.IsActive,
+		CreatedAt:        q.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        q.UpdatedAt.Format(time.RFC3339),
+	}
+	if q.NumericAnswer != nil {
+		answer := q.NumericAnswer.String()
+		resp.NumericAnswer = &answer
+	}
+	return resp
+}
+
+// NewSectionResponse creates a SectionResponse from a TestSection entity.
+func NewSectionResponse(section *admission.TestSection) SectionResponse {
+	resp := SectionResponse{
+		ID:           section.ID.String(),
+		TestID:       section.TestID.String(),
+		Name:         section.Name,
+		Subject:      section.Subject,
+		Sequence:     section.Sequence,
+		Instructions: section.Instructions,
+		Questions:    make([]SectionQuestionResponse, 0, len(section.Questions)),
+	}
+	if section.MarksPerQuestion != nil {
+		marks := section.MarksPerQuestion.String()
+		resp.MarksPerQuestion = &marks
+	}
+	if section.NegativeMarks != nil {
+		negative := section.NegativeMarks.String()
+		resp.NegativeMarks = &negative
+	}
+
+	for _, sq := range section.Questions {
+		if sq.Question == nil {
+			continue
+		}
+		marks, negative := section.QuestionMarks(sq.Question)
+		resp.Questions = append(resp.Questions, SectionQuestionResponse{
+			Sequence:      sq.Sequence,
+			Marks:         marks.String(),
+			NegativeMarks: negative.String(),
+			Question:      NewQuestionResponse(sq.Question),
+		})
+	}
+	return resp
+}
+
+// NewQuestionPaperResponse creates a QuestionPaperResponse from a QuestionPaper.
+func NewQuestionPaperResponse(paper *admission.QuestionPaper) QuestionPaperResponse {
+	subjects := make([]SubjectMarksDTO, len(paper.Test.Subjects))
+	for i, s := range paper.Test.Subjects {
+		subjects[i] = SubjectMarksDTO{Subject: s.Subject, MaxMarks: s.MaxMarks}
+	}
+
+	totals := make(map[string]string)
+	for subject, total := range paper.SubjectTotals() {
+		totals[subject] = total.String()
+	}
+
+	sections := make([]SectionResponse, len(paper.Sections))
+	for i := range paper.Sections {
+		sections[i] = NewSectionResponse(&paper.Sections[i])
+	}
+
+	return QuestionPaperResponse{
+		TestID:           paper.Test.ID.String(),
+		TestName:         paper.Test.TestName,
+		OnlineEnabled:    paper.Test.OnlineEnabled,
+		LateEntryMinutes: paper.Test.LateEntryMinutes,
+		Subjects:         subjects,
+		SubjectTotals:    totals,
+		Sections:         sections,
+	}
+}
@@ -0,0 +1,495 @@
+package admission
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+	"msls-backend/internal/services/admission"
+)
+
+// QuestionHandler handles question bank and question paper HTTP requests.
+type QuestionHandler struct {
+	questionService *admission.QuestionBankService
+}
+
+// NewQuestionHandler creates a new QuestionHandler.
+func NewQuestionHandler(questionService *admission.QuestionBankService) *QuestionHandler {
+	return &QuestionHandler{questionService: questionService}
+}
+
+// ListQuestions godoc
+// @Summary List question bank questions
+// @Description Retrieves question bank questions with optional filtering
+// @Tags Question Bank
+// @Produce json
+// @Param subject query string false "Filter by subject"
+// @Param questionType query string false "Filter by type (mcq, numeric, descriptive)"
+// @Param isActive query bool false "Filter by active flag"
+// @Param search query string false "Search question text and topic"
+// @Success 200 {object} response.Success{data=QuestionListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/question-bank [get]
+func (h *QuestionHandler) ListQuestions(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := admission.QuestionListFilter{
+		TenantID: tenantID,
+		Subject:  c.Query("subject"),
+		Search:   c.Query("search"),
+	}
+	if questionType := c.Query("questionType"); questionType != "" {
+		t := admission.QuestionType(questionType)
+		if t.IsValid() {
+			filter.QuestionType = &t
+		}
+	}
+	if isActive := c.Query("isActive"); isActive != "" {
+		active := isActive == "true"
+		filter.IsActive = &active
+	}
+
+	questions, err := h.questionService.ListQuestions(c.Request.Context(), filter)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to retrieve questions"))
+		return
+	}
+
+	resp := make([]QuestionResponse, len(questions))
+	for i := range questions {
+		resp[i] = NewQuestionResponse(&questions[i])
+	}
+	response.OK(c, QuestionListResponse{Questions: resp, Total: len(resp)})
+}
+
+// GetQuestion godoc
+// @Summary Get question
+// @Description Retrieves a question bank question with its answer key
+// @Tags Question Bank
+// @Produce json
+// @Param id path string true "Question ID"
+// @Success 200 {object} response.Success{data=QuestionResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/question-bank/{id} [get]
+func (h *QuestionHandler) GetQuestion(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid question ID"))
+		return
+	}
+
+	question, err := h.questionService.GetQuestion(c.Request.Context(), tenantID, questionID)
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to retrieve question")
+		return
+	}
+
+	response.OK(c, NewQuestionResponse(question))
+}
+
+// CreateQuestion godoc
+// @Summary Create question
+// @Description Adds an MCQ, numeric or descriptive question to the question bank
+// @Tags Question Bank
+// @Accept json
+// @Produce json
+// @Param request body QuestionRequest true "Question details"
+// @Success 201 {object} response.Success{data=QuestionResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/question-bank [post]
+func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req QuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.GetCurrentUserID(c); ok {
+		userID = &id
+	}
+
+	question, err := h.questionService.CreateQuestion(c.Request.Context(), req.ToServiceRequest(tenantID, userID))
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to create question")
+		return
+	}
+
+	response.Created(c, NewQuestionResponse(question))
+}
+
+// UpdateQuestion godoc
+// @Summary Update question
+// @Description Replaces a question bank question. Questions on a published paper cannot change.
+// @Tags Question Bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Question ID"
+// @Param request body QuestionRequest true "Question details"
+// @Success 200 {object} response.Success{data=QuestionResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/question-bank/{id} [put]
+func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid question ID"))
+		return
+	}
+
+	var req QuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.GetCurrentUserID(c); ok {
+		userID = &id
+	}
+
+	question, err := h.questionService.UpdateQuestion(c.Request.Context(), questionID, req.ToServiceRequest(tenantID, userID))
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to update question")
+		return
+	}
+
+	response.OK(c, NewQuestionResponse(question))
+}
+
+// DeleteQuestion godoc
+// @Summary Delete question
+// @Description Deletes a question that is not on any question paper
+// @Tags Question Bank
+// @Param id path string true "Question ID"
+// @Success 204
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/question-bank/{id} [delete]
+func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid question ID"))
+		return
+	}
+
+	if err := h.questionService.DeleteQuestion(c.Request.Context(), tenantID, questionID); err != nil {
+		handleOnlineTestError(c, err, "Failed to delete question")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// GetPaper godoc
+// @Summary Get question paper
+// @Description Retrieves a test's question paper with sections, questions and marking scheme
+// @Tags Entrance Tests
+// @Produce json
+// @Param id path string true "Test ID"
+// @Success 200 {object} response.Success{data=QuestionPaperResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/paper [get]
+func (h *QuestionHandler) GetPaper(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	paper, err := h.questionService.GetPaper(c.Request.Context(), tenantID, testID)
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to retrieve question paper")
+		return
+	}
+
+	response.OK(c, NewQuestionPaperResponse(paper))
+}
+
+// CreateSection godoc
+// @Summary Create paper section
+// @Description Adds a section for one of the test's subjects to the question paper
+// @Tags Entrance Tests
+// @Accept json
+// @Produce json
+// @Param id path string true "Test ID"
+// @Param request body SectionRequest true "Section details"
+// @Success 201 {object} response.Success{data=SectionResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/paper/sections [post]
+func (h *QuestionHandler) CreateSection(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	var req SectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	section, err := h.questionService.CreateSection(c.Request.Context(), req.ToServiceRequest(tenantID, testID))
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to create section")
+		return
+	}
+
+	response.Created(c, NewSectionResponse(section))
+}
+
+// UpdateSection godoc
+// @Summary Update paper section
+// @Description Updates a question paper section and its marking scheme
+// @Tags Entrance Tests
+// @Accept json
+// @Produce json
+// @Param id path string true "Test ID"
+// @Param sectionId path string true "Section ID"
+// @Param request body SectionRequest true "Section details"
+// @Success 200 {object} response.Success{data=SectionResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/paper/sections/{sectionId} [put]
+func (h *QuestionHandler) UpdateSection(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	sectionID, err := uuid.Parse(c.Param("sectionId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid section ID"))
+		return
+	}
+
+	var req SectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	section, err := h.questionService.UpdateSection(c.Request.Context(), sectionID, req.ToServiceRequest(tenantID, testID))
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to update section")
+		return
+	}
+
+	response.OK(c, NewSectionResponse(section))
+}
+
+// DeleteSection godoc
+// @Summary Delete paper section
+// @Description Removes a section and its questions from the question paper
+// @Tags Entrance Tests
+// @Param id path string true "Test ID"
+// @Param sectionId path string true "Section ID"
+// @Success 204
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/paper/sections/{sectionId} [delete]
+func (h *QuestionHandler) DeleteSection(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	sectionID, err := uuid.Parse(c.Param("sectionId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid section ID"))
+		return
+	}
+
+	if err := h.questionService.DeleteSection(c.Request.Context(), tenantID, testID, sectionID); err != nil {
+		handleOnlineTestError(c, err, "Failed to delete section")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// AddSectionQuestion godoc
+// @Summary Add question to section
+// @Description Places a question bank question in a paper section and returns the updated paper
+// @Tags Entrance Tests
+// @Accept json
+// @Produce json
+// @Param id path string true "Test ID"
+// @Param sectionId path string true "Section ID"
+// @Param request body AddSectionQuestionRequest true "Question to add"
+// @Success 201 {object} response.Success{data=QuestionPaperResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/paper/sections/{sectionId}/questions [post]
+func (h *QuestionHandler) AddSectionQuestion(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	sectionID, err := uuid.Parse(c.Param("sectionId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid section ID"))
+		return
+	}
+
+	var req AddSectionQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+	questionID, _ := uuid.Parse(req.QuestionID)
+
+	if _, err := h.questionService.AddSectionQuestion(c.Request.Context(), tenantID, testID, sectionID, questionID, req.Sequence); err != nil {
+		handleOnlineTestError(c, err, "Failed to add question to section")
+		return
+	}
+
+	// Return the whole paper so the subject totals reflect the new question
+	paper, err := h.questionService.GetPaper(c.Request.Context(), tenantID, testID)
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to retrieve question paper")
+		return
+	}
+
+	response.Created(c, NewQuestionPaperResponse(paper))
+}
+
+// RemoveSectionQuestion godoc
+// @Summary Remove question from section
+// @Description Removes a question from a paper section
+// @Tags Entrance Tests
+// @Param id path string true "Test ID"
+// @Param sectionId path string true "Section ID"
+// @Param questionId path string true "Question ID"
+// @Success 204
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/paper/sections/{sectionId}/questions/{questionId} [delete]
+func (h *QuestionHandler) RemoveSectionQuestion(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	sectionID, err := uuid.Parse(c.Param("sectionId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid section ID"))
+		return
+	}
+	questionID, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid question ID"))
+		return
+	}
+
+	if err := h.questionService.RemoveSectionQuestion(c.Request.Context(), tenantID, testID, sectionID, questionID); err != nil {
+		handleOnlineTestError(c, err, "Failed to remove question from section")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// PublishPaper godoc
+// @Summary Publish question paper
+// @Description Validates the question paper against the test's subjects and opens the test for online delivery
+// @Tags Entrance Tests
+// @Accept json
+// @Produce json
+// @Param id path string true "Test ID"
+// @Param request body PublishPaperRequest false "Publish options"
+// @Success 200 {object} response.Success{data=QuestionPaperResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/paper/publish [post]
+func (h *QuestionHandler) PublishPaper(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	var req PublishPaperRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+			return
+		}
+	}
+
+	paper, err := h.questionService.PublishPaper(c.Request.Context(), tenantID, testID, req.LateEntryMinutes)
+	if err != nil {
+		handleOnlineTestError(c, err, "Failed to publish question paper")
+		return
+	}
+
+	response.OK(c, NewQuestionPaperResponse(paper))
+}
+
+// UnpublishPaper godoc
+// @Summary Unpublish question paper
+// @Description Withdraws the test from online delivery so the paper can be edited
+// @Tags Entrance Tests
+// @Param id path string true "Test ID"
+// @Success 204
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/entrance-tests/{id}/paper/unpublish [post]
+func (h *QuestionHandler) UnpublishPaper(c *gin.Context) {
+	tenantID, testID, ok := paperParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.questionService.UnpublishPaper(c.Request.Context(), tenantID, testID); err != nil {
+		handleOnlineTestError(c, err, "Failed to unpublish question paper")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// paperParams reads the tenant and test ID of a question paper request,
+// aborting the request when either is missing.
+func paperParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	testID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid test ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, testID, true
+}
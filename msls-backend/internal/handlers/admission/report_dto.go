@@ -84,6 +84,57 @@ type DailyTrendResponse struct {
 	Trends []DailyTrendItem `json:"trends"`
 }
 
+// CounsellorPerformanceItem represents enquiry outcomes for one counsellor.
+type CounsellorPerformanceItem struct {
+	CounsellorID   string  `json:"counsellorId"`
+	CounsellorName string  `json:"counsellorName"`
+	Assigned       int64   `json:"assigned"`
+	Open           int64   `json:"open"`
+	Converted      int64   `json:"converted"`
+	Closed         int64   `json:"closed"`
+	ConversionRate float64 `json:"conversionRate"`
+	AvgLeadScore   float64 `json:"avgLeadScore"`
+}
+
+// CounsellorPerformanceResponse represents the counsellor performance response.
+type CounsellorPerformanceResponse struct {
+	Counsellors []CounsellorPerformanceItem `json:"counsellors"`
+}
+
+// SeatQuotaItem represents seat usage for one quota of a class.
+type SeatQuotaItem struct {
+	Quota      string `json:"quota"`
+	Reserved   int    `json:"reserved"`
+	Effective  int    `json:"effective"`
+	Allocated  int    `json:"allocated"`
+	Available  int    `json:"available"`
+	Waitlisted int    `json:"waitlisted"`
+}
+
+// SeatMatrixClassItem represents allocated versus available seats per quota for a class.
+type SeatMatrixClassItem struct {
+	ClassName          string          `json:"className"`
+	TotalSeats         int             `json:"totalSeats"`
+	FilledSeats        int             `json:"filledSeats"`
+	QuotaReleaseDate   *string         `json:"quotaReleaseDate,omitempty"`
+	QuotasReleased     bool            `json:"quotasReleased"`
+	ConvertedToGeneral int             `json:"convertedToGeneral"`
+	Quotas             []SeatQuotaItem `json:"quotas"`
+	General            SeatQuotaItem   `json:"general"`
+	Allocated          int             `json:"allocated"`
+	Available          int             `json:"available"`
+}
+
+// SeatMatrixResponse represents the seat matrix response.
+type SeatMatrixResponse struct {
+	SessionID          string                `json:"sessionId"`
+	Classes            []SeatMatrixClassItem `json:"classes"`
+	TotalSeats         int                   `json:"totalSeats"`
+	Allocated          int                   `json:"allocated"`
+	Available          int                   `json:"available"`
+	ConvertedToGeneral int                   `json:"convertedToGeneral"`
+}
+
 // ============================================================================
 // Request Query Parameters
 // ============================================================================
@@ -180,6 +231,60 @@ func dailyTrendToResponse(report *admission.DailyTrendResponse) DailyTrendRespon
 	return DailyTrendResponse{Trends: trends}
 }
 
+// counsellorPerformanceToResponse converts service counsellor performance to response DTO.
+func counsellorPerformanceToResponse(report *admission.CounsellorPerformanceResponse) CounsellorPerformanceResponse {
+	counsellors := make([]CounsellorPerformanceItem, len(report.Counsellors))
+	for i, c := range report.Counsellors {
+		counsellors[i] = CounsellorPerformanceItem{
+			CounsellorID:   c.CounsellorID.String(),
+			CounsellorName: c.CounsellorName,
+			Assigned:       c.Assigned,
+			Open:           c.Open,
+			Converted:      c.Converted,
+			Closed:         c.Closed,
+			ConversionRate: c.ConversionRate,
+			AvgLeadScore:   c.AvgLeadScore,
+		}
+	}
+	return CounsellorPerformanceResponse{Counsellors: counsellors}
+}
+
+// seatMatrixToResponse converts the service seat matrix to response DTO.
+func seatMatrixToResponse(report *admission.SeatMatrixResponse) SeatMatrixResponse {
+	classes := make([]SeatMatrixClassItem, len(report.Classes))
+	for i, row := range report.Classes {
+		quotas := make([]SeatQuotaItem, len(row.Quotas))
+		for j, q := range row.Quotas {
+			quotas[j] = SeatQuotaItem(q)
+		}
+		var releaseDate *string
+		if row.QuotaReleaseDate != nil {
+			date := row.QuotaReleaseDate.Format("2006-01-02")
+			releaseDate = &date
+		}
+		classes[i] = SeatMatrixClassItem{
+			ClassName:          row.ClassName,
+			TotalSeats:         row.TotalSeats,
+			FilledSeats:        row.FilledSeats,
+			QuotaReleaseDate:   releaseDate,
+			QuotasReleased:     row.QuotasReleased,
+			ConvertedToGeneral: row.ConvertedToGeneral,
+			Quotas:             quotas,
+			General:            SeatQuotaItem(row.General),
+			Allocated:          row.Allocated,
+			Available:          row.Available,
+		}
+	}
+	return SeatMatrixResponse{
+		SessionID:          report.SessionID.String(),
+		Classes:            classes,
+		TotalSeats:         report.TotalSeats,
+		Allocated:          report.Allocated,
+		Available:          report.Available,
+		ConvertedToGeneral: report.ConvertedToGeneral,
+	}
+}
+
 // parseDateWithError parses a date string in YYYY-MM-DD format.
 func parseDateWithError(dateStr string) (*time.Time, error) {
 	if dateStr == "" {
@@ -250,6 +250,93 @@ func (h *ReportHandler) GetDailyTrend(c *gin.Context) {
 	response.OK(c, dailyTrendToResponse(report))
 }
 
+// GetCounsellorPerformance returns enquiry conversion rates per counsellor.
+// @Summary Get counsellor performance
+// @Description Get assigned, open, converted and closed enquiry counts and the conversion rate for each counsellor
+// @Tags Admissions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param session_id query string false "Filter by admission session ID"
+// @Param branch_id query string false "Filter by branch ID"
+// @Param start_date query string false "Filter by enquiry start date (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by enquiry end date (YYYY-MM-DD)"
+// @Success 200 {object} response.Success{data=CounsellorPerformanceResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 403 {object} apperrors.AppError
+// @Router /api/v1/admissions/reports/counsellors [get]
+func (h *ReportHandler) GetCounsellorPerformance(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter, err := h.parseQueryParams(c, tenantID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	report, err := h.reportService.GetCounsellorPerformance(c.Request.Context(), filter)
+	if err != nil {
+		switch err {
+		case admissionservice.ErrTenantIDRequired:
+			apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		default:
+			apperrors.Abort(c, apperrors.InternalError("Failed to retrieve counsellor performance"))
+		}
+		return
+	}
+
+	response.OK(c, counsellorPerformanceToResponse(report))
+}
+
+// GetSeatMatrix returns allocated versus available seats per quota for each class.
+// @Summary Get seat matrix
+// @Description Get reserved, allocated and available seats per quota for each class in an admission session, including reserved seats released to general
+// @Tags Admissions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param session_id query string true "Admission session ID"
+// @Success 200 {object} response.Success{data=SeatMatrixResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 403 {object} apperrors.AppError
+// @Router /api/v1/admissions/reports/seat-matrix [get]
+func (h *ReportHandler) GetSeatMatrix(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter, err := h.parseQueryParams(c, tenantID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	report, err := h.reportService.GetSeatMatrix(c.Request.Context(), filter)
+	if err != nil {
+		switch err {
+		case admissionservice.ErrTenantIDRequired:
+			apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		case admissionservice.ErrSessionIDRequired:
+			apperrors.Abort(c, apperrors.BadRequest("session_id is required"))
+		default:
+			apperrors.Abort(c, apperrors.InternalError("Failed to retrieve seat matrix"))
+		}
+		return
+	}
+
+	response.OK(c, seatMatrixToResponse(report))
+}
+
 // parseQueryParams extracts and validates query parameters for report filters.
 func (h *ReportHandler) parseQueryParams(c *gin.Context, tenantID uuid.UUID) (admissionservice.DashboardFilter, error) {
 	var params ReportQueryParams
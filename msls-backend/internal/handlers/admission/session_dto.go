@@ -53,6 +53,8 @@ type CreateSeatRequest struct {
 	TotalSeats    int            `json:"totalSeats" binding:"min=0"`
 	WaitlistLimit int            `json:"waitlistLimit" binding:"min=0"`
 	ReservedSeats map[string]int `json:"reservedSeats"`
+	// QuotaReleaseDate (YYYY-MM-DD) is when unfilled reserved seats become general seats.
+	QuotaReleaseDate *string `json:"quotaReleaseDate,omitempty"`
 }
 
 // UpdateSeatRequest represents the request body for updating a seat configuration.
@@ -60,6 +62,8 @@ type UpdateSeatRequest struct {
 	TotalSeats    *int            `json:"totalSeats" binding:"omitempty,min=0"`
 	WaitlistLimit *int            `json:"waitlistLimit" binding:"omitempty,min=0"`
 	ReservedSeats *map[string]int `json:"reservedSeats"`
+	// QuotaReleaseDate (YYYY-MM-DD) sets the release date; an empty string clears it.
+	QuotaReleaseDate *string `json:"quotaReleaseDate,omitempty"`
 }
 
 // SessionSettings represents session settings in API requests/responses.
@@ -104,6 +108,7 @@ type SeatResponse struct {
 	AvailableSeats int            `json:"availableSeats"`
 	WaitlistLimit  int            `json:"waitlistLimit"`
 	ReservedSeats  map[string]int `json:"reservedSeats"`
+	QuotaReleaseDate *string      `json:"quotaReleaseDate,omitempty"`
 	CreatedAt      string         `json:"createdAt"`
 	UpdatedAt      string         `json:"updatedAt"`
 }
@@ -199,6 +204,12 @@ func seatToResponse(seat *models.AdmissionSeat) SeatResponse {
 		reservedSeats = seat.ReservedSeats
 	}
 
+	var quotaReleaseDate *string
+	if seat.QuotaReleaseDate != nil {
+		date := seat.QuotaReleaseDate.Format("2006-01-02")
+		quotaReleaseDate = &date
+	}
+
 	return SeatResponse{
 		ID:             seat.ID.String(),
 		SessionID:      seat.SessionID.String(),
@@ -208,6 +219,7 @@ func seatToResponse(seat *models.AdmissionSeat) SeatResponse {
 		AvailableSeats: seat.AvailableSeats(),
 		WaitlistLimit:  seat.WaitlistLimit,
 		ReservedSeats:  reservedSeats,
+		QuotaReleaseDate: quotaReleaseDate,
 		CreatedAt:      seat.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:      seat.UpdatedAt.Format(time.RFC3339),
 	}
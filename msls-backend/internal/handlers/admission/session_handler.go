@@ -614,6 +614,15 @@ func (h *SessionHandler) CreateSeat(c *gin.Context) {
 		ReservedSeats: models.ReservedSeats(req.ReservedSeats),
 	}
 
+	if req.QuotaReleaseDate != nil && *req.QuotaReleaseDate != "" {
+		releaseDate, err := time.Parse("2006-01-02", *req.QuotaReleaseDate)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid quota release date format. Use YYYY-MM-DD"))
+			return
+		}
+		createReq.QuotaReleaseDate = &releaseDate
+	}
+
 	seat, err := h.sessionService.CreateSeat(c.Request.Context(), createReq)
 	if err != nil {
 		switch err {
@@ -625,6 +634,8 @@ func (h *SessionHandler) CreateSeat(c *gin.Context) {
 			apperrors.Abort(c, apperrors.Conflict("Seat configuration for this class already exists"))
 		case admissionservice.ErrInvalidTotalSeats:
 			apperrors.Abort(c, apperrors.BadRequest("Total seats must be greater than or equal to zero"))
+		case admissionservice.ErrInvalidReservedSeats:
+			apperrors.Abort(c, apperrors.BadRequest("Reserved seats must use known quotas, be zero or more and not exceed total seats"))
 		case admissionservice.ErrCannotModifyClosedSession:
 			apperrors.Abort(c, apperrors.BadRequest("Cannot add seats to a closed session"))
 		default:
@@ -682,6 +693,19 @@ func (h *SessionHandler) UpdateSeat(c *gin.Context) {
 		updateReq.ReservedSeats = &reservedSeats
 	}
 
+	if req.QuotaReleaseDate != nil {
+		if *req.QuotaReleaseDate == "" {
+			updateReq.ClearQuotaReleaseDate = true
+		} else {
+			releaseDate, err := time.Parse("2006-01-02", *req.QuotaReleaseDate)
+			if err != nil {
+				apperrors.Abort(c, apperrors.BadRequest("Invalid quota release date format. Use YYYY-MM-DD"))
+				return
+			}
+			updateReq.QuotaReleaseDate = &releaseDate
+		}
+	}
+
 	seat, err := h.sessionService.UpdateSeat(c.Request.Context(), tenantID, seatID, updateReq)
 	if err != nil {
 		switch err {
@@ -691,6 +715,8 @@ func (h *SessionHandler) UpdateSeat(c *gin.Context) {
 			apperrors.Abort(c, apperrors.BadRequest("Total seats must be greater than or equal to zero"))
 		case admissionservice.ErrFilledExceedsTotal:
 			apperrors.Abort(c, apperrors.BadRequest("Filled seats cannot exceed total seats"))
+		case admissionservice.ErrInvalidReservedSeats:
+			apperrors.Abort(c, apperrors.BadRequest("Reserved seats must use known quotas, be zero or more and not exceed total seats"))
 		case admissionservice.ErrCannotModifyClosedSession:
 			apperrors.Abort(c, apperrors.BadRequest("Cannot modify seats in a closed session"))
 		default:
@@ -191,3 +191,107 @@ type OTPResendRequest struct {
 	Type       string `json:"type" binding:"required,oneof=sms email"` // Delivery channel
 	TenantID   string `json:"tenant_id,omitempty"` // Optional tenant ID
 }
+
+// ==================== Session DTOs ====================
+
+// SessionDTO represents an active login session.
+type SessionDTO struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	Location   string    `json:"location,omitempty"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// RevokeSessionsResponse represents the response after revoking sessions.
+type RevokeSessionsResponse struct {
+	Message string `json:"message"`
+	Revoked int64  `json:"revoked"`
+}
+
+// ==================== SSO DTOs ====================
+
+// SSOLoginProviderDTO represents an identity provider offered on the login page.
+type SSOLoginProviderDTO struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Protocol string    `json:"protocol"`
+	LoginURL string    `json:"login_url"`
+}
+
+// SSOExchangeRequest represents the request to redeem a one-time SSO code.
+type SSOExchangeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// SSORoleMappingDTO maps an identity provider group to a role.
+type SSORoleMappingDTO struct {
+	Group  string `json:"group" binding:"required,max=255"`
+	RoleID string `json:"role_id" binding:"required,uuid"`
+}
+
+// CreateSSOProviderRequest represents the request to create an identity provider.
+type CreateSSOProviderRequest struct {
+	Name                 string              `json:"name" binding:"required,max=100"`
+	Protocol             string              `json:"protocol" binding:"required,oneof=oidc saml"`
+	Enabled              *bool               `json:"enabled"`
+	IssuerURL            string              `json:"issuer_url" binding:"omitempty,url,max=500"`
+	ClientID             string              `json:"client_id" binding:"max=255"`
+	ClientSecret         string              `json:"client_secret"`
+	Scopes               []string            `json:"scopes"`
+	MetadataURL          string              `json:"metadata_url" binding:"omitempty,url,max=500"`
+	MetadataXML          string              `json:"metadata_xml"`
+	AllowedDomains       []string            `json:"allowed_domains"`
+	RequireVerifiedEmail *bool               `json:"require_verified_email"`
+	AutoCreateUsers      bool                `json:"auto_create_users"`
+	GroupsClaim          string              `json:"groups_claim" binding:"max=255"`
+	RoleMappings         []SSORoleMappingDTO `json:"role_mappings" binding:"dive"`
+	DefaultRoleID        string              `json:"default_role_id" binding:"omitempty,uuid"`
+}
+
+// UpdateSSOProviderRequest represents the request to update an identity provider.
+// An empty client_secret removes the secret; an empty default_role_id clears the default role.
+type UpdateSSOProviderRequest struct {
+	Name                 *string             `json:"name" binding:"omitempty,max=100"`
+	Enabled              *bool               `json:"enabled"`
+	IssuerURL            *string             `json:"issuer_url" binding:"omitempty,max=500"`
+	ClientID             *string             `json:"client_id" binding:"omitempty,max=255"`
+	ClientSecret         *string             `json:"client_secret"`
+	Scopes               []string            `json:"scopes"`
+	MetadataURL          *string             `json:"metadata_url" binding:"omitempty,max=500"`
+	MetadataXML          *string             `json:"metadata_xml"`
+	AllowedDomains       []string            `json:"allowed_domains"`
+	RequireVerifiedEmail *bool               `json:"require_verified_email"`
+	AutoCreateUsers      *bool               `json:"auto_create_users"`
+	GroupsClaim          *string             `json:"groups_claim" binding:"omitempty,max=255"`
+	RoleMappings         []SSORoleMappingDTO `json:"role_mappings" binding:"omitempty,dive"`
+	DefaultRoleID        *string             `json:"default_role_id" binding:"omitempty"`
+}
+
+// SSOProviderDTO represents an identity provider in admin API responses.
+type SSOProviderDTO struct {
+	ID                   uuid.UUID           `json:"id"`
+	Name                 string              `json:"name"`
+	Protocol             string              `json:"protocol"`
+	Enabled              bool                `json:"enabled"`
+	IssuerURL            string              `json:"issuer_url,omitempty"`
+	ClientID             string              `json:"client_id,omitempty"`
+	HasClientSecret      bool                `json:"has_client_secret"`
+	Scopes               []string            `json:"scopes,omitempty"`
+	MetadataURL          string              `json:"metadata_url,omitempty"`
+	MetadataXML          string              `json:"metadata_xml,omitempty"`
+	AllowedDomains       []string            `json:"allowed_domains"`
+	RequireVerifiedEmail bool                `json:"require_verified_email"`
+	AutoCreateUsers      bool                `json:"auto_create_users"`
+	GroupsClaim          string              `json:"groups_claim,omitempty"`
+	RoleMappings         []SSORoleMappingDTO `json:"role_mappings"`
+	DefaultRoleID        *uuid.UUID          `json:"default_role_id,omitempty"`
+	CallbackURL          string              `json:"callback_url"`
+	SPMetadataURL        string              `json:"sp_metadata_url,omitempty"`
+	CreatedAt            time.Time           `json:"created_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+}
@@ -0,0 +1,35 @@
+// Package auth provides HTTP handlers for authentication endpoints.
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	authservice "msls-backend/internal/services/auth"
+)
+
+// JWKSHandler serves the public keys used to verify access tokens.
+type JWKSHandler struct {
+	jwtService *authservice.JWTService
+}
+
+// NewJWKSHandler creates a new JWKSHandler.
+func NewJWKSHandler(jwtService *authservice.JWTService) *JWKSHandler {
+	return &JWKSHandler{
+		jwtService: jwtService,
+	}
+}
+
+// JWKS returns the JSON Web Key Set for access token verification.
+// The response is a bare RFC 7517 key set so that standard JWT libraries can consume it.
+// @Summary JSON Web Key Set
+// @Description Public keys (by kid) for verifying access tokens, including keys published ahead of rotation
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} authservice.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwtService.JWKS())
+}
@@ -0,0 +1,276 @@
+// Package auth provides HTTP handlers for authentication endpoints.
+package auth
+
+import (
+	"net"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+	authservice "msls-backend/internal/services/auth"
+)
+
+// SessionHandler handles login session management requests.
+type SessionHandler struct {
+	authService *authservice.AuthService
+	sessions    *authservice.SessionService
+}
+
+// NewSessionHandler creates a new SessionHandler.
+func NewSessionHandler(authService *authservice.AuthService) *SessionHandler {
+	return &SessionHandler{
+		authService: authService,
+		sessions:    authService.Sessions(),
+	}
+}
+
+// ListMySessions lists the current user's active sessions.
+// @Summary List my sessions
+// @Description List the devices the current user is logged in on
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Success{data=[]SessionDTO}
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/auth/sessions [get]
+func (h *SessionHandler) ListMySessions(c *gin.Context) {
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.Unauthorized("Authentication required"))
+		return
+	}
+
+	h.listSessions(c, userID)
+}
+
+// RevokeMySession revokes one of the current user's sessions.
+// @Summary Revoke a session
+// @Description Log out one of the current user's devices
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID" format(uuid)
+// @Success 200 {object} response.Success{data=MessageResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/auth/sessions/{id} [delete]
+func (h *SessionHandler) RevokeMySession(c *gin.Context) {
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.Unauthorized("Authentication required"))
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid session ID"))
+		return
+	}
+
+	h.revokeSession(c, userID, sessionID, models.SessionRevokeReasonUser)
+}
+
+// RevokeMyOtherSessions revokes all of the current user's sessions except the current one.
+// @Summary Revoke other sessions
+// @Description Log out every device except the one making the request
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Success{data=RevokeSessionsResponse}
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/auth/sessions [delete]
+func (h *SessionHandler) RevokeMyOtherSessions(c *gin.Context) {
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.Unauthorized("Authentication required"))
+		return
+	}
+
+	// Tokens issued before sessions existed have no session to keep, so all are revoked
+	var keep *uuid.UUID
+	if claims, ok := middleware.GetCurrentClaims(c); ok && claims.SessionID != uuid.Nil {
+		keep = &claims.SessionID
+	}
+
+	revoked, err := h.sessions.RevokeAll(c.Request.Context(), userID, models.SessionRevokeReasonUser, keep)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to revoke sessions"))
+		return
+	}
+
+	h.audit(c, userID, models.AuditActionSessionsRevokedAll)
+	response.OK(c, RevokeSessionsResponse{Message: "Other sessions revoked", Revoked: revoked})
+}
+
+// ListUserSessions lists a user's active sessions (admin).
+// @Summary List user sessions
+// @Description List the devices a user is logged in on
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} response.Success{data=[]SessionDTO}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/users/{id}/sessions [get]
+func (h *SessionHandler) ListUserSessions(c *gin.Context) {
+	user, ok := h.tenantUser(c)
+	if !ok {
+		return
+	}
+
+	h.listSessions(c, user.ID)
+}
+
+// RevokeUserSession revokes one of a user's sessions (admin).
+// @Summary Revoke a user session
+// @Description Force logout of one of a user's devices
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "User ID" format(uuid)
+// @Param sessionId path string true "Session ID" format(uuid)
+// @Success 200 {object} response.Success{data=MessageResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/users/{id}/sessions/{sessionId} [delete]
+func (h *SessionHandler) RevokeUserSession(c *gin.Context) {
+	user, ok := h.tenantUser(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid session ID"))
+		return
+	}
+
+	h.revokeSession(c, user.ID, sessionID, models.SessionRevokeReasonAdmin)
+}
+
+// RevokeAllUserSessions revokes all of a user's sessions and access tokens (admin).
+// @Summary Force logout everywhere
+// @Description Revoke all of a user's sessions; outstanding access tokens stop working immediately
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} response.Success{data=RevokeSessionsResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/users/{id}/sessions [delete]
+func (h *SessionHandler) RevokeAllUserSessions(c *gin.Context) {
+	user, ok := h.tenantUser(c)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.sessions.RevokeAll(c.Request.Context(), user.ID, models.SessionRevokeReasonAdmin, nil)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to revoke sessions"))
+		return
+	}
+
+	h.audit(c, user.ID, models.AuditActionSessionsRevokedAll)
+	response.OK(c, RevokeSessionsResponse{Message: "All sessions revoked", Revoked: revoked})
+}
+
+// listSessions responds with a user's active sessions, flagging the caller's own.
+func (h *SessionHandler) listSessions(c *gin.Context, userID uuid.UUID) {
+	sessions, err := h.sessions.ListActive(c.Request.Context(), userID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to retrieve sessions"))
+		return
+	}
+
+	var currentID uuid.UUID
+	if claims, ok := middleware.GetCurrentClaims(c); ok {
+		currentID = claims.SessionID
+	}
+
+	dtos := make([]SessionDTO, len(sessions))
+	for i := range sessions {
+		dtos[i] = sessionToDTO(&sessions[i], currentID)
+	}
+	response.OK(c, dtos)
+}
+
+// revokeSession revokes a session and writes the audit log.
+func (h *SessionHandler) revokeSession(c *gin.Context, userID, sessionID uuid.UUID, reason models.SessionRevokeReason) {
+	if err := h.sessions.Revoke(c.Request.Context(), userID, sessionID, reason); err != nil {
+		switch err {
+		case authservice.ErrSessionNotFound:
+			apperrors.Abort(c, apperrors.NotFound("Session not found"))
+		default:
+			apperrors.Abort(c, apperrors.InternalError("Failed to revoke session"))
+		}
+		return
+	}
+
+	h.audit(c, userID, models.AuditActionSessionRevoked)
+	response.OK(c, MessageResponse{Message: "Session revoked"})
+}
+
+// tenantUser loads the user in the :id path parameter, which must belong to the caller's tenant.
+func (h *SessionHandler) tenantUser(c *gin.Context) (*models.User, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid user ID"))
+		return nil, false
+	}
+
+	user, err := h.authService.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case authservice.ErrUserNotFound:
+			apperrors.Abort(c, apperrors.NotFound("User not found"))
+		default:
+			apperrors.Abort(c, apperrors.InternalError("Failed to retrieve user"))
+		}
+		return nil, false
+	}
+
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok || user.TenantID != tenantID {
+		apperrors.Abort(c, apperrors.NotFound("User not found"))
+		return nil, false
+	}
+
+	return user, true
+}
+
+// audit records a session revocation against the affected user.
+func (h *SessionHandler) audit(c *gin.Context, userID uuid.UUID, action models.AuditAction) {
+	user, err := h.authService.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		return
+	}
+	h.authService.CreateAuditLog(c.Request.Context(), user, action, net.ParseIP(c.ClientIP()), c.GetHeader("User-Agent"))
+}
+
+// sessionToDTO converts a session model to a DTO.
+func sessionToDTO(session *models.UserSession, currentID uuid.UUID) SessionDTO {
+	dto := SessionDTO{
+		ID:         session.ID.String(),
+		Device:     session.Device,
+		UserAgent:  session.UserAgent,
+		Location:   session.Location,
+		LastUsedAt: session.LastUsedAt,
+		CreatedAt:  session.CreatedAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    session.ID == currentID,
+	}
+	if session.IPAddress != nil {
+		dto.IPAddress = session.IPAddress.String()
+	}
+	return dto
+}
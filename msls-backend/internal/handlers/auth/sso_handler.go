@@ -0,0 +1,532 @@
+// Package auth provides HTTP handlers for authentication endpoints.
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+	authservice "msls-backend/internal/services/auth"
+	ssoservice "msls-backend/internal/services/sso"
+)
+
+// SSOHandler handles single sign-on logins and identity provider management.
+type SSOHandler struct {
+	ssoService *ssoservice.Service
+}
+
+// NewSSOHandler creates a new SSOHandler.
+func NewSSOHandler(ssoService *ssoservice.Service) *SSOHandler {
+	return &SSOHandler{ssoService: ssoService}
+}
+
+// ==================== Login flow ====================
+
+// ListLoginProviders lists the identity providers offered on a tenant's login page.
+// @Summary List SSO login providers
+// @Description List the enabled identity providers for a tenant
+// @Tags Auth - SSO
+// @Produce json
+// @Param tenant_id query string true "Tenant ID" format(uuid)
+// @Success 200 {object} response.Success{data=[]SSOLoginProviderDTO}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/auth/sso/providers [get]
+func (h *SSOHandler) ListLoginProviders(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Query("tenant_id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid tenant ID"))
+		return
+	}
+
+	providers, err := h.ssoService.ListEnabledProviders(c.Request.Context(), tenantID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to retrieve identity providers"))
+		return
+	}
+
+	dtos := make([]SSOLoginProviderDTO, len(providers))
+	for i, provider := range providers {
+		dtos[i] = SSOLoginProviderDTO{
+			ID:       provider.ID,
+			Name:     provider.Name,
+			Protocol: string(provider.Protocol),
+			LoginURL: fmt.Sprintf("/api/v1/auth/sso/%s/login", provider.ID),
+		}
+	}
+	response.OK(c, dtos)
+}
+
+// Login redirects the browser to the identity provider.
+// @Summary Start SSO login
+// @Description Redirect to the identity provider; the browser returns to redirect_uri with a one-time code or an error
+// @Tags Auth - SSO
+// @Param providerId path string true "Identity provider ID" format(uuid)
+// @Param redirect_uri query string true "Frontend URL that receives the code"
+// @Success 302
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/auth/sso/{providerId}/login [get]
+func (h *SSOHandler) Login(c *gin.Context) {
+	providerID, ok := parseProviderID(c)
+	if !ok {
+		return
+	}
+
+	authURL, err := h.ssoService.BeginLogin(c.Request.Context(), providerID, c.Query("redirect_uri"))
+	if err != nil {
+		abortSSOError(c, err, "Failed to start SSO login")
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback handles the OpenID Connect redirect back from the identity provider.
+// @Summary OIDC callback
+// @Description Complete an OpenID Connect login and redirect to the frontend
+// @Tags Auth - SSO
+// @Param providerId path string true "Identity provider ID" format(uuid)
+// @Param state query string true "Login state"
+// @Param code query string false "Authorization code"
+// @Param error query string false "Error returned by the identity provider"
+// @Success 302
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/auth/sso/{providerId}/callback [get]
+func (h *SSOHandler) Callback(c *gin.Context) {
+	providerID, ok := parseProviderID(c)
+	if !ok {
+		return
+	}
+
+	redirectURL, err := h.ssoService.CompleteOIDC(c.Request.Context(), providerID,
+		c.Query("state"), c.Query("code"), c.Query("error"),
+		net.ParseIP(c.ClientIP()), c.GetHeader("User-Agent"))
+	if err != nil {
+		abortSSOError(c, err, "Failed to complete SSO login")
+		return
+	}
+
+	c.Redirect(http.StatusFound, redirectURL)
+}
+
+// AssertionConsumer handles a SAML response posted by the identity provider.
+// @Summary SAML assertion consumer service
+// @Description Complete a SAML login and redirect to the frontend
+// @Tags Auth - SSO
+// @Accept x-www-form-urlencoded
+// @Param providerId path string true "Identity provider ID" format(uuid)
+// @Success 303
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/auth/sso/{providerId}/acs [post]
+func (h *SSOHandler) AssertionConsumer(c *gin.Context) {
+	providerID, ok := parseProviderID(c)
+	if !ok {
+		return
+	}
+
+	redirectURL, err := h.ssoService.CompleteSAML(c.Request.Context(), providerID, c.Request,
+		net.ParseIP(c.ClientIP()), c.GetHeader("User-Agent"))
+	if err != nil {
+		abortSSOError(c, err, "Failed to complete SSO login")
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, redirectURL)
+}
+
+// Metadata returns the SAML service provider metadata.
+// @Summary SAML service provider metadata
+// @Description Metadata to register with a SAML identity provider
+// @Tags Auth - SSO
+// @Produce xml
+// @Param providerId path string true "Identity provider ID" format(uuid)
+// @Success 200 {string} string
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/auth/sso/{providerId}/metadata [get]
+func (h *SSOHandler) Metadata(c *gin.Context) {
+	providerID, ok := parseProviderID(c)
+	if !ok {
+		return
+	}
+
+	metadata, err := h.ssoService.ServiceProviderMetadata(c.Request.Context(), providerID)
+	if err != nil {
+		abortSSOError(c, err, "Failed to build SAML metadata")
+		return
+	}
+
+	c.Data(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
+
+// Exchange redeems the one-time code from an SSO redirect for tokens.
+// @Summary Exchange SSO code
+// @Description Exchange the one-time code returned after SSO login for access and refresh tokens
+// @Tags Auth - SSO
+// @Accept json
+// @Produce json
+// @Param request body SSOExchangeRequest true "Exchange code"
+// @Success 200 {object} response.Success{data=LoginResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Failure 423 {object} apperrors.AppError
+// @Router /api/v1/auth/sso/exchange [post]
+func (h *SSOHandler) Exchange(c *gin.Context) {
+	var req SSOExchangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	tokenPair, user, err := h.ssoService.Exchange(c.Request.Context(), req.Code,
+		net.ParseIP(c.ClientIP()), c.GetHeader("User-Agent"))
+	if err != nil {
+		switch err {
+		case ssoservice.ErrInvalidExchangeCode:
+			apperrors.Abort(c, apperrors.Unauthorized("Invalid or expired code"))
+		case authservice.ErrAccountLocked:
+			apperrors.Abort(c, &apperrors.AppError{
+				Type:   "https://httpstatuses.com/423",
+				Title:  "Locked",
+				Status: http.StatusLocked,
+				Detail: "Account is locked due to too many failed login attempts. Please try again later.",
+			})
+		case authservice.ErrAccountInactive:
+			apperrors.Abort(c, apperrors.Unauthorized("Account is not active"))
+		default:
+			apperrors.Abort(c, apperrors.InternalError("Login failed"))
+		}
+		return
+	}
+
+	response.OK(c, LoginResponse{
+		User:             userToDTO(user),
+		AccessToken:      tokenPair.AccessToken,
+		RefreshToken:     tokenPair.RefreshToken,
+		ExpiresIn:        tokenPair.ExpiresIn,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+	})
+}
+
+// ==================== Provider management ====================
+
+// ListProviders lists the tenant's identity providers.
+// @Summary List identity providers
+// @Description List the tenant's SSO identity providers
+// @Tags SSO
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {object} response.Success{data=[]SSOProviderDTO}
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/sso/providers [get]
+func (h *SSOHandler) ListProviders(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	providers, err := h.ssoService.ListProviders(c.Request.Context(), tenantID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to retrieve identity providers"))
+		return
+	}
+
+	dtos := make([]SSOProviderDTO, len(providers))
+	for i := range providers {
+		dtos[i] = h.providerToDTO(&providers[i])
+	}
+	response.OK(c, dtos)
+}
+
+// GetProvider returns one identity provider.
+// @Summary Get identity provider
+// @Description Get an SSO identity provider by ID
+// @Tags SSO
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Identity provider ID" format(uuid)
+// @Success 200 {object} response.Success{data=SSOProviderDTO}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/sso/providers/{id} [get]
+func (h *SSOHandler) GetProvider(c *gin.Context) {
+	tenantID, id, ok := tenantAndProviderID(c)
+	if !ok {
+		return
+	}
+
+	provider, err := h.ssoService.GetProvider(c.Request.Context(), tenantID, id)
+	if err != nil {
+		abortSSOError(c, err, "Failed to retrieve identity provider")
+		return
+	}
+	response.OK(c, h.providerToDTO(provider))
+}
+
+// CreateProvider creates an identity provider.
+// @Summary Create identity provider
+// @Description Configure an OpenID Connect or SAML identity provider for the tenant
+// @Tags SSO
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body CreateSSOProviderRequest true "Identity provider"
+// @Success 201 {object} response.Success{data=SSOProviderDTO}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/sso/providers [post]
+func (h *SSOHandler) CreateProvider(c *gin.Context) {
+	var req CreateSSOProviderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	mappings, err := parseRoleMappings(req.RoleMappings)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid role ID format"))
+		return
+	}
+
+	createReq := ssoservice.CreateProviderRequest{
+		TenantID:             tenantID,
+		Name:                 req.Name,
+		Protocol:             models.SSOProtocol(req.Protocol),
+		Enabled:              req.Enabled == nil || *req.Enabled,
+		IssuerURL:            req.IssuerURL,
+		ClientID:             req.ClientID,
+		ClientSecret:         req.ClientSecret,
+		Scopes:               req.Scopes,
+		MetadataURL:          req.MetadataURL,
+		MetadataXML:          req.MetadataXML,
+		AllowedDomains:       req.AllowedDomains,
+		RequireVerifiedEmail: req.RequireVerifiedEmail == nil || *req.RequireVerifiedEmail,
+		AutoCreateUsers:      req.AutoCreateUsers,
+		GroupsClaim:          req.GroupsClaim,
+		RoleMappings:         mappings,
+	}
+	if req.DefaultRoleID != "" {
+		roleID := uuid.MustParse(req.DefaultRoleID)
+		createReq.DefaultRoleID = &roleID
+	}
+
+	provider, err := h.ssoService.CreateProvider(c.Request.Context(), createReq)
+	if err != nil {
+		abortSSOError(c, err, "Failed to create identity provider")
+		return
+	}
+	response.Created(c, h.providerToDTO(provider))
+}
+
+// UpdateProvider updates an identity provider.
+// @Summary Update identity provider
+// @Description Update an SSO identity provider
+// @Tags SSO
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Identity provider ID" format(uuid)
+// @Param request body UpdateSSOProviderRequest true "Identity provider updates"
+// @Success 200 {object} response.Success{data=SSOProviderDTO}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/sso/providers/{id} [put]
+func (h *SSOHandler) UpdateProvider(c *gin.Context) {
+	tenantID, id, ok := tenantAndProviderID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateSSOProviderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	updateReq := ssoservice.UpdateProviderRequest{
+		Name:                 req.Name,
+		Enabled:              req.Enabled,
+		IssuerURL:            req.IssuerURL,
+		ClientID:             req.ClientID,
+		ClientSecret:         req.ClientSecret,
+		Scopes:               req.Scopes,
+		MetadataURL:          req.MetadataURL,
+		MetadataXML:          req.MetadataXML,
+		AllowedDomains:       req.AllowedDomains,
+		RequireVerifiedEmail: req.RequireVerifiedEmail,
+		AutoCreateUsers:      req.AutoCreateUsers,
+		GroupsClaim:          req.GroupsClaim,
+	}
+	if req.RoleMappings != nil {
+		mappings, err := parseRoleMappings(req.RoleMappings)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid role ID format"))
+			return
+		}
+		updateReq.RoleMappings = mappings
+	}
+	if req.DefaultRoleID != nil {
+		if *req.DefaultRoleID == "" {
+			updateReq.ClearDefaultRole = true
+		} else {
+			roleID, err := uuid.Parse(*req.DefaultRoleID)
+			if err != nil {
+				apperrors.Abort(c, apperrors.BadRequest("Invalid role ID format"))
+				return
+			}
+			updateReq.DefaultRoleID = &roleID
+		}
+	}
+
+	provider, err := h.ssoService.UpdateProvider(c.Request.Context(), tenantID, id, updateReq)
+	if err != nil {
+		abortSSOError(c, err, "Failed to update identity provider")
+		return
+	}
+	response.OK(c, h.providerToDTO(provider))
+}
+
+// DeleteProvider deletes an identity provider and unlinks its identities.
+// @Summary Delete identity provider
+// @Description Delete an SSO identity provider; users keep their accounts
+// @Tags SSO
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Identity provider ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/sso/providers/{id} [delete]
+func (h *SSOHandler) DeleteProvider(c *gin.Context) {
+	tenantID, id, ok := tenantAndProviderID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.ssoService.DeleteProvider(c.Request.Context(), tenantID, id); err != nil {
+		abortSSOError(c, err, "Failed to delete identity provider")
+		return
+	}
+	response.NoContent(c)
+}
+
+// providerToDTO converts an identity provider model to a DTO.
+func (h *SSOHandler) providerToDTO(provider *models.IdentityProvider) SSOProviderDTO {
+	dto := SSOProviderDTO{
+		ID:                   provider.ID,
+		Name:                 provider.Name,
+		Protocol:             string(provider.Protocol),
+		Enabled:              provider.Enabled,
+		IssuerURL:            provider.IssuerURL,
+		ClientID:             provider.ClientID,
+		HasClientSecret:      provider.ClientSecretEncrypted != "",
+		Scopes:               provider.Scopes,
+		MetadataURL:          provider.MetadataURL,
+		MetadataXML:          provider.MetadataXML,
+		AllowedDomains:       provider.AllowedDomains,
+		RequireVerifiedEmail: provider.RequireVerifiedEmail,
+		AutoCreateUsers:      provider.AutoCreateUsers,
+		GroupsClaim:          provider.GroupsClaim,
+		RoleMappings:         make([]SSORoleMappingDTO, len(provider.RoleMappings)),
+		DefaultRoleID:        provider.DefaultRoleID,
+		CallbackURL:          h.ssoService.CallbackURL(provider),
+		CreatedAt:            provider.CreatedAt,
+		UpdatedAt:            provider.UpdatedAt,
+	}
+	if dto.AllowedDomains == nil {
+		dto.AllowedDomains = []string{}
+	}
+	for i, mapping := range provider.RoleMappings {
+		dto.RoleMappings[i] = SSORoleMappingDTO{Group: mapping.Group, RoleID: mapping.RoleID.String()}
+	}
+	if provider.Protocol == models.SSOProtocolSAML {
+		dto.SPMetadataURL = h.ssoService.ServiceProviderMetadataURL(provider)
+	}
+	return dto
+}
+
+// parseRoleMappings converts role mapping DTOs to models.
+func parseRoleMappings(dtos []SSORoleMappingDTO) ([]models.SSORoleMapping, error) {
+	mappings := make([]models.SSORoleMapping, len(dtos))
+	for i, dto := range dtos {
+		roleID, err := uuid.Parse(dto.RoleID)
+		if err != nil {
+			return nil, err
+		}
+		mappings[i] = models.SSORoleMapping{Group: dto.Group, RoleID: roleID}
+	}
+	return mappings, nil
+}
+
+// parseProviderID parses the :providerId path parameter.
+func parseProviderID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("providerId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid identity provider ID"))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// tenantAndProviderID returns the caller's tenant and the :id path parameter.
+func tenantAndProviderID(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid identity provider ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, id, true
+}
+
+// abortSSOError maps SSO service errors to HTTP errors.
+func abortSSOError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ssoservice.ErrProviderNotFound), errors.Is(err, ssoservice.ErrProviderDisabled),
+		errors.Is(err, authservice.ErrTenantNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Identity provider not found"))
+	case errors.Is(err, authservice.ErrTenantInactive):
+		apperrors.Abort(c, apperrors.Unauthorized("Tenant is not active"))
+	case errors.Is(err, ssoservice.ErrInvalidRedirectURI):
+		apperrors.Abort(c, apperrors.BadRequest("Redirect URI is not allowed"))
+	case errors.Is(err, ssoservice.ErrLoginRequestInvalid):
+		apperrors.Abort(c, apperrors.BadRequest("Login request not found or already used"))
+	case errors.Is(err, ssoservice.ErrProviderNameRequired), errors.Is(err, ssoservice.ErrInvalidProtocol),
+		errors.Is(err, ssoservice.ErrOIDCConfigRequired), errors.Is(err, ssoservice.ErrSAMLConfigRequired),
+		errors.Is(err, ssoservice.ErrInvalidSAMLMetadata), errors.Is(err, ssoservice.ErrInvalidRoleMapping):
+		apperrors.Abort(c, apperrors.BadRequest(capitalize(err.Error())))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
+
+// capitalize upper-cases the first letter of a service error message.
+func capitalize(message string) string {
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:]
+}
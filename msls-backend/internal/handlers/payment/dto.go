@@ -0,0 +1,202 @@
+// Package payment provides HTTP handlers for the payments ledger.
+package payment
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+	paymentservice "msls-backend/internal/services/payment"
+)
+
+// ============================================================================
+// Request DTOs
+// ============================================================================
+
+// ListPaymentsParams represents query parameters for listing payments.
+type ListPaymentsParams struct {
+	Status               string `form:"status"`
+	Purpose              string `form:"purpose"`
+	ApplicationID        string `form:"applicationId"`
+	ReconciliationStatus string `form:"reconciliationStatus"`
+	StartDate            string `form:"startDate"`
+	EndDate              string `form:"endDate"`
+	Page                 int    `form:"page"`
+	PageSize             int    `form:"pageSize"`
+}
+
+// RefundRequest represents a request to refund a payment.
+type RefundRequest struct {
+	// Amount to refund. Omit to refund the whole refundable amount.
+	Amount decimal.Decimal `json:"amount"`
+	Reason string          `json:"reason" binding:"required,max=500"`
+}
+
+// ReconcileRequest represents a request to reconcile payments with the gateway.
+type ReconcileRequest struct {
+	StartDate string `json:"startDate" binding:"required"`
+	EndDate   string `json:"endDate" binding:"required"`
+}
+
+// ============================================================================
+// Response DTOs
+// ============================================================================
+
+// PaymentResponse represents a payment in API responses.
+type PaymentResponse struct {
+	ID                   string           `json:"id"`
+	Purpose              string           `json:"purpose"`
+	ApplicationID        *string          `json:"applicationId,omitempty"`
+	InvoiceID            *string          `json:"invoiceId,omitempty"`
+	Provider             string           `json:"provider"`
+	ProviderOrderID      string           `json:"providerOrderId"`
+	ProviderPaymentID    *string          `json:"providerPaymentId,omitempty"`
+	Amount               decimal.Decimal  `json:"amount"`
+	Currency             string           `json:"currency"`
+	Status               string           `json:"status"`
+	Method               string           `json:"method,omitempty"`
+	CapturedAmount       decimal.Decimal  `json:"capturedAmount"`
+	RefundedAmount       decimal.Decimal  `json:"refundedAmount"`
+	FailureReason        string           `json:"failureReason,omitempty"`
+	PaidAt               *string          `json:"paidAt,omitempty"`
+	ConfirmedAt          *string          `json:"confirmedAt,omitempty"`
+	ReconciliationStatus string           `json:"reconciliationStatus"`
+	ReconciliationNote   string           `json:"reconciliationNote,omitempty"`
+	ReconciledAt         *string          `json:"reconciledAt,omitempty"`
+	Refunds              []RefundResponse `json:"refunds,omitempty"`
+	CreatedAt            string           `json:"createdAt"`
+	UpdatedAt            string           `json:"updatedAt"`
+}
+
+// RefundResponse represents a refund in API responses.
+type RefundResponse struct {
+	ID               string          `json:"id"`
+	ProviderRefundID string          `json:"providerRefundId"`
+	Amount           decimal.Decimal `json:"amount"`
+	Status           string          `json:"status"`
+	Reason           string          `json:"reason,omitempty"`
+	ProcessedAt      *string         `json:"processedAt,omitempty"`
+	CreatedAt        string          `json:"createdAt"`
+}
+
+// PaymentListResponse represents a list of payments with pagination.
+type PaymentListResponse struct {
+	Payments []PaymentResponse `json:"payments"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"pageSize"`
+}
+
+// ReconciliationResponse represents the outcome of a reconciliation run.
+type ReconciliationResponse struct {
+	StartDate  string             `json:"startDate"`
+	EndDate    string             `json:"endDate"`
+	Checked    int                `json:"checked"`
+	Matched    int                `json:"matched"`
+	Mismatched int                `json:"mismatched"`
+	Errors     int                `json:"errors"`
+	Mismatches []MismatchResponse `json:"mismatches"`
+}
+
+// MismatchResponse represents a payment flagged during reconciliation.
+type MismatchResponse struct {
+	PaymentID     string   `json:"paymentId"`
+	OrderID       string   `json:"orderId"`
+	ApplicationID *string  `json:"applicationId,omitempty"`
+	Notes         []string `json:"notes"`
+}
+
+// WebhookResponse acknowledges a gateway webhook.
+type WebhookResponse struct {
+	EventID   string `json:"eventId"`
+	Duplicate bool   `json:"duplicate"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// paymentToResponse converts a Payment model to PaymentResponse.
+func paymentToResponse(p *models.Payment) PaymentResponse {
+	resp := PaymentResponse{
+		ID:                   p.ID.String(),
+		Purpose:              string(p.Purpose),
+		Provider:             p.Provider,
+		ProviderOrderID:      p.ProviderOrderID,
+		ProviderPaymentID:    p.ProviderPaymentID,
+		Amount:               p.Amount,
+		Currency:             p.Currency,
+		Status:               string(p.Status),
+		Method:               p.Method,
+		CapturedAmount:       p.CapturedAmount,
+		RefundedAmount:       p.RefundedAmount,
+		FailureReason:        p.FailureReason,
+		PaidAt:               formatTime(p.PaidAt),
+		ConfirmedAt:          formatTime(p.ConfirmedAt),
+		ReconciliationStatus: string(p.ReconciliationStatus),
+		ReconciliationNote:   p.ReconciliationNote,
+		ReconciledAt:         formatTime(p.ReconciledAt),
+		CreatedAt:            p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            p.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if p.ApplicationID != nil {
+		id := p.ApplicationID.String()
+		resp.ApplicationID = &id
+	}
+	if p.InvoiceID != nil {
+		id := p.InvoiceID.String()
+		resp.InvoiceID = &id
+	}
+
+	for i := range p.Refunds {
+		r := &p.Refunds[i]
+		resp.Refunds = append(resp.Refunds, RefundResponse{
+			ID:               r.ID.String(),
+			ProviderRefundID: r.ProviderRefundID,
+			Amount:           r.Amount,
+			Status:           r.Status,
+			Reason:           r.Reason,
+			ProcessedAt:      formatTime(r.ProcessedAt),
+			CreatedAt:        r.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return resp
+}
+
+// reportToResponse converts a reconciliation report to ReconciliationResponse.
+func reportToResponse(report *paymentservice.ReconciliationReport) ReconciliationResponse {
+	resp := ReconciliationResponse{
+		StartDate:  report.From.Format("2006-01-02"),
+		EndDate:    report.To.AddDate(0, 0, -1).Format("2006-01-02"),
+		Checked:    report.Checked,
+		Matched:    report.Matched,
+		Mismatched: report.Mismatched,
+		Errors:     report.Errors,
+		Mismatches: make([]MismatchResponse, 0, len(report.Mismatches)),
+	}
+	for _, m := range report.Mismatches {
+		mismatch := MismatchResponse{
+			PaymentID: m.PaymentID.String(),
+			OrderID:   m.OrderID,
+			Notes:     m.Notes,
+		}
+		if m.ApplicationID != nil {
+			id := m.ApplicationID.String()
+			mismatch.ApplicationID = &id
+		}
+		resp.Mismatches = append(resp.Mismatches, mismatch)
+	}
+	return resp
+}
+
+// formatTime formats an optional timestamp as RFC 3339.
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,378 @@
+// Package payment provides HTTP handlers for the payments ledger.
+package payment
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/payments"
+	"msls-backend/internal/pkg/response"
+	paymentservice "msls-backend/internal/services/payment"
+)
+
+// maxWebhookBodySize limits the size of gateway webhook bodies.
+const maxWebhookBodySize = 1 << 20
+
+// Handler handles payment-related HTTP requests.
+type Handler struct {
+	paymentService *paymentservice.Service
+}
+
+// NewHandler creates a new payment Handler.
+func NewHandler(paymentService *paymentservice.Service) *Handler {
+	return &Handler{paymentService: paymentService}
+}
+
+// List returns payments for the tenant.
+// @Summary List payments
+// @Description List gateway payments with optional filters
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param status query string false "Payment status"
+// @Param purpose query string false "Payment purpose"
+// @Param applicationId query string false "Admission application ID"
+// @Param reconciliationStatus query string false "Reconciliation status"
+// @Param startDate query string false "Created on or after (YYYY-MM-DD)"
+// @Param endDate query string false "Created on or before (YYYY-MM-DD)"
+// @Param page query int false "Page number (default 1)"
+// @Param pageSize query int false "Page size (default 20, max 100)"
+// @Success 200 {object} response.Success{data=PaymentListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/payments [get]
+func (h *Handler) List(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var params ListPaymentsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	filter := paymentservice.ListFilter{TenantID: tenantID}
+
+	if params.Status != "" {
+		status := models.PaymentStatus(params.Status)
+		if !status.IsValid() {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid payment status"))
+			return
+		}
+		filter.Status = &status
+	}
+	if params.Purpose != "" {
+		purpose := models.PaymentPurpose(params.Purpose)
+		if !purpose.IsValid() {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid payment purpose"))
+			return
+		}
+		filter.Purpose = &purpose
+	}
+	if params.ApplicationID != "" {
+		applicationID, err := uuid.Parse(params.ApplicationID)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid application ID"))
+			return
+		}
+		filter.ApplicationID = &applicationID
+	}
+	if params.ReconciliationStatus != "" {
+		status := models.ReconciliationStatus(params.ReconciliationStatus)
+		filter.ReconciliationStatus = &status
+	}
+	if params.StartDate != "" {
+		from, err := time.Parse("2006-01-02", params.StartDate)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid start date format (use YYYY-MM-DD)"))
+			return
+		}
+		filter.From = &from
+	}
+	if params.EndDate != "" {
+		to, err := time.Parse("2006-01-02", params.EndDate)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid end date format (use YYYY-MM-DD)"))
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	page := params.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := params.PageSize
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	list, total, err := h.paymentService.ListPayments(c.Request.Context(), filter)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to list payments"))
+		return
+	}
+
+	resp := PaymentListResponse{
+		Payments: make([]PaymentResponse, len(list)),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for i := range list {
+		resp.Payments[i] = paymentToResponse(&list[i])
+	}
+
+	response.OK(c, resp)
+}
+
+// Get returns a payment with its refunds.
+// @Summary Get payment
+// @Description Get a payment with its refunds
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Payment ID" format(uuid)
+// @Success 200 {object} response.Success{data=PaymentResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/payments/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid payment ID"))
+		return
+	}
+
+	payment, err := h.paymentService.GetPayment(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handlePaymentError(c, err, "Failed to get payment")
+		return
+	}
+
+	response.OK(c, paymentToResponse(payment))
+}
+
+// Confirm applies a captured payment to the application it pays for.
+// @Summary Confirm payment
+// @Description Mark the application fee paid for a captured payment that was not confirmed automatically
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Payment ID" format(uuid)
+// @Success 200 {object} response.Success{data=PaymentResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/payments/{id}/confirm [post]
+func (h *Handler) Confirm(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.Unauthorized("User ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid payment ID"))
+		return
+	}
+
+	payment, err := h.paymentService.ConfirmPayment(c.Request.Context(), tenantID, id, userID)
+	if err != nil {
+		handlePaymentError(c, err, "Failed to confirm payment")
+		return
+	}
+
+	response.OK(c, paymentToResponse(payment))
+}
+
+// Refund refunds all or part of a payment through the gateway.
+// @Summary Refund payment
+// @Description Refund all or part of a captured payment
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Payment ID" format(uuid)
+// @Param request body RefundRequest true "Refund details"
+// @Success 200 {object} response.Success{data=PaymentResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/payments/{id}/refund [post]
+func (h *Handler) Refund(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.Unauthorized("User ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid payment ID"))
+		return
+	}
+
+	var req RefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+	if req.Amount.IsNegative() {
+		apperrors.Abort(c, apperrors.BadRequest("Refund amount must be positive"))
+		return
+	}
+
+	payment, err := h.paymentService.Refund(c.Request.Context(), paymentservice.RefundRequest{
+		TenantID:   tenantID,
+		PaymentID:  id,
+		Amount:     req.Amount,
+		Reason:     req.Reason,
+		RefundedBy: &userID,
+	})
+	if err != nil {
+		handlePaymentError(c, err, "Failed to refund payment")
+		return
+	}
+
+	response.OK(c, paymentToResponse(payment))
+}
+
+// Reconcile compares payments in a date range with the gateway.
+// @Summary Reconcile payments
+// @Description Compare payments created in a date range with the gateway and flag mismatches
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body ReconcileRequest true "Date range"
+// @Success 200 {object} response.Success{data=ReconciliationResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/payments/reconcile [post]
+func (h *Handler) Reconcile(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req ReconcileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid start date format (use YYYY-MM-DD)"))
+		return
+	}
+	to, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid end date format (use YYYY-MM-DD)"))
+		return
+	}
+
+	report, err := h.paymentService.Reconcile(c.Request.Context(), tenantID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		handlePaymentError(c, err, "Failed to reconcile payments")
+		return
+	}
+
+	response.OK(c, reportToResponse(report))
+}
+
+// Webhook receives payment gateway notifications.
+// @Summary Payment gateway webhook
+// @Description Receive signed payment and refund notifications from the gateway. Redelivered events are acknowledged without being applied again.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Success{data=WebhookResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/public/payments/webhook [post]
+func (h *Handler) Webhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Failed to read request body"))
+		return
+	}
+
+	result, err := h.paymentService.HandleWebhook(c.Request.Context(), c.Request.Header, body)
+	if err != nil {
+		handlePaymentError(c, err, "Failed to process webhook")
+		return
+	}
+
+	response.OK(c, WebhookResponse{EventID: result.EventID, Duplicate: result.Duplicate})
+}
+
+// handlePaymentError maps payment service errors to HTTP responses.
+func handlePaymentError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, paymentservice.ErrPaymentNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Payment not found"))
+	case errors.Is(err, paymentservice.ErrApplicationNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Application not found"))
+	case errors.Is(err, paymentservice.ErrNoFeeDue):
+		apperrors.Abort(c, apperrors.BadRequest("No application fee is due"))
+	case errors.Is(err, paymentservice.ErrAlreadyPaid):
+		apperrors.Abort(c, apperrors.Conflict("Fee has already been paid"))
+	case errors.Is(err, paymentservice.ErrPaymentNotCaptured):
+		apperrors.Abort(c, apperrors.Conflict("Payment has not been captured"))
+	case errors.Is(err, paymentservice.ErrAlreadyConfirmed):
+		apperrors.Abort(c, apperrors.Conflict("Payment has already been confirmed"))
+	case errors.Is(err, paymentservice.ErrInvalidSignature):
+		apperrors.Abort(c, apperrors.Unauthorized("Payment signature verification failed"))
+	case errors.Is(err, payments.ErrInvalidWebhook):
+		apperrors.Abort(c, apperrors.BadRequest("Invalid webhook payload"))
+	case errors.Is(err, paymentservice.ErrGatewayFailed):
+		apperrors.Abort(c, apperrors.InternalError("Payment gateway request failed. Please try again."))
+	case errors.Is(err, paymentservice.ErrPaymentNotRefundable):
+		apperrors.Abort(c, apperrors.Conflict("Payment cannot be refunded"))
+	case errors.Is(err, paymentservice.ErrInvalidRefundAmount):
+		apperrors.Abort(c, apperrors.BadRequest("Refund amount exceeds the refundable amount"))
+	case errors.Is(err, paymentservice.ErrInvalidDateRange):
+		apperrors.Abort(c, apperrors.BadRequest("End date must not be before start date"))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
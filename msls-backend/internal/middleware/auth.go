@@ -68,13 +68,17 @@ func Auth(config AuthConfig) gin.HandlerFunc {
 		}
 
 		// Validate token
-		claims, err := config.JWTService.ValidateAccessToken(tokenString)
+		claims, err := config.JWTService.ValidateAccessTokenContext(c.Request.Context(), tokenString)
 		if err != nil {
 			switch err {
 			case auth.ErrExpiredToken:
 				apperrors.Abort(c, apperrors.Unauthorized("Token has expired"))
 			case auth.ErrTokenNotYetValid:
 				apperrors.Abort(c, apperrors.Unauthorized("Token is not yet valid"))
+			case auth.ErrSessionRevoked:
+				apperrors.Abort(c, apperrors.Unauthorized("Session has been revoked"))
+			case auth.ErrTokenRevoked:
+				apperrors.Abort(c, apperrors.Unauthorized("Token has been revoked"))
 			default:
 				apperrors.Abort(c, apperrors.Unauthorized("Invalid token"))
 			}
@@ -124,7 +128,7 @@ func AuthOptional(jwtService *auth.JWTService) gin.HandlerFunc {
 		}
 
 		// Validate token
-		claims, err := jwtService.ValidateAccessToken(tokenString)
+		claims, err := jwtService.ValidateAccessTokenContext(c.Request.Context(), tokenString)
 		if err != nil {
 			// Token is invalid, but continue without authentication
 			c.Next()
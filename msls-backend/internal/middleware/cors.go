@@ -65,9 +65,11 @@ func DefaultCORSConfig() CORSConfig {
 			"Content-Length",
 			"Content-Type",
 			"X-Request-ID",
-			"X-RateLimit-Limit",
-			"X-RateLimit-Remaining",
-			"X-RateLimit-Reset",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"RateLimit-Policy",
+			"Retry-After",
 		},
 		AllowCredentials: false,
 		MaxAge:           12 * time.Hour,
@@ -0,0 +1,105 @@
+// Package middleware provides HTTP middleware components for the Gin framework.
+package middleware
+
+import (
+	"crypto/subtle"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/metrics"
+)
+
+// unmatchedRoute is the route label used for requests that matched no route.
+const unmatchedRoute = "unmatched"
+
+// MetricsConfig holds configuration for request metrics middleware.
+type MetricsConfig struct {
+	// Histogram records request latency by method, route and status.
+	Histogram *metrics.HistogramVec
+
+	// SkipPaths lists paths that are not recorded (e.g., the metrics endpoint itself).
+	SkipPaths []string
+}
+
+// DefaultMetricsConfig returns the default metrics middleware configuration.
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		Histogram: metrics.HTTPRequestDuration,
+		SkipPaths: []string{"/health", "/ready", "/metrics"},
+	}
+}
+
+// Metrics returns a middleware that records request latency per route.
+// The route label is the matched route template (e.g. /api/v1/students/:id),
+// which keeps label cardinality bounded regardless of path parameters.
+func Metrics(config MetricsConfig) gin.HandlerFunc {
+	skipPaths := make(map[string]bool)
+	for _, path := range config.SkipPaths {
+		skipPaths[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		config.Histogram.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// MetricsDefault returns a metrics middleware with default configuration.
+func MetricsDefault() gin.HandlerFunc {
+	return Metrics(DefaultMetricsConfig())
+}
+
+// MetricsAccessConfig controls who may scrape the metrics endpoint.
+type MetricsAccessConfig struct {
+	// Token, when set, admits requests with an "Authorization: Bearer <token>" header.
+	Token string
+
+	// AllowedNetworks admits requests connecting from these networks without a token.
+	AllowedNetworks []*net.IPNet
+}
+
+// MetricsAccess returns a middleware that restricts the metrics endpoint to
+// scrapers presenting the token or connecting from an allowed network. The
+// network check uses the connecting peer address rather than ClientIP, so a
+// forwarded header cannot claim an allowed address.
+func MetricsAccess(config MetricsAccessConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if config.Token != "" {
+			token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if found && subtle.ConstantTimeCompare([]byte(token), []byte(config.Token)) == 1 {
+				c.Next()
+				return
+			}
+		}
+
+		if ip := net.ParseIP(c.RemoteIP()); ip != nil {
+			for _, network := range config.AllowedNetworks {
+				if network.Contains(ip) {
+					c.Next()
+					return
+				}
+			}
+		}
+
+		apperrors.Abort(c, apperrors.Forbidden("Metrics access is restricted"))
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsAccess(t *testing.T) {
+	_, private, _ := net.ParseCIDR("10.0.0.0/8")
+	router := gin.New()
+	router.GET("/metrics", MetricsAccess(MetricsAccessConfig{
+		Token:           "scrape-token",
+		AllowedNetworks: []*net.IPNet{private},
+	}), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	scrape := func(ip, authorization, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.RemoteAddr = ip + ":1234"
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, scrape("10.1.2.3", "", ""))
+	assert.Equal(t, http.StatusOK, scrape("203.0.113.7", "Bearer scrape-token", ""))
+	assert.Equal(t, http.StatusForbidden, scrape("203.0.113.7", "", ""))
+	assert.Equal(t, http.StatusForbidden, scrape("203.0.113.7", "Bearer wrong", ""))
+	assert.Equal(t, http.StatusForbidden, scrape("203.0.113.7", "scrape-token", ""))
+	assert.Equal(t, http.StatusForbidden, scrape("203.0.113.7", "", "10.1.2.3"))
+}
+
+func TestMetricsAccess_NoTokenConfigured(t *testing.T) {
+	router := gin.New()
+	router.GET("/metrics", MetricsAccess(MetricsAccessConfig{}), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.RemoteAddr = "127.0.0.1:1234"
+	req.Header.Set("Authorization", "Bearer ")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	apperrors "msls-backend/internal/pkg/errors"
+)
+
+// TenantAndID returns the current tenant ID and a UUID path parameter. It
+// aborts the request with a bad request error when either is missing or
+// invalid.
+func TenantAndID(c *gin.Context, param, invalidMessage string) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, ok := GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(invalidMessage))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return tenantID, id, true
+}
+
+// ParseOptionalUUID parses an optional UUID from a request body. An empty
+// value yields nil; an invalid one aborts the request with a bad request error.
+func ParseOptionalUUID(c *gin.Context, value *string, invalidMessage string) (*uuid.UUID, bool) {
+	if value == nil || *value == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(*value)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(invalidMessage))
+		return nil, false
+	}
+	return &id, true
+}
+
+// ParseUUIDQuery parses an optional UUID query parameter into target. An
+// invalid value aborts the request with a bad request error.
+func ParseUUIDQuery(c *gin.Context, name string, target **uuid.UUID) bool {
+	value := c.Query(name)
+	if value == "" {
+		return true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid "+name))
+		return false
+	}
+	*target = &id
+	return true
+}
+
+// ParseDate parses an optional YYYY-MM-DD date from a request body. An empty
+// value yields nil; an invalid one aborts the request with a bad request error.
+func ParseDate(c *gin.Context, value *string, name string) (*time.Time, bool) {
+	if value == nil || *value == "" {
+		return nil, true
+	}
+	date, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid "+name+", expected YYYY-MM-DD"))
+		return nil, false
+	}
+	return &date, true
+}
+
+// ParseDateQuery parses an optional YYYY-MM-DD query parameter into target.
+// An invalid value aborts the request with a bad request error.
+func ParseDateQuery(c *gin.Context, name string, target **time.Time) bool {
+	value := c.Query(name)
+	date, ok := ParseDate(c, &value, name)
+	if ok && date != nil {
+		*target = date
+	}
+	return ok
+}
+
+// ParsePaging parses the limit and offset query parameters, leaving the
+// targets unchanged when a parameter is absent. An invalid value aborts the
+// request with a bad request error.
+func ParsePaging(c *gin.Context, limit, offset *int) bool {
+	if limitStr := c.Query("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid limit"))
+			return false
+		}
+		*limit = value
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		value, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid offset"))
+			return false
+		}
+		*offset = value
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newParamsContext(query string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	return c, w
+}
+
+func TestParseUUIDQuery(t *testing.T) {
+	id := uuid.New()
+	var target *uuid.UUID
+
+	c, _ := newParamsContext("")
+	assert.True(t, ParseUUIDQuery(c, "staffId", &target))
+	assert.Nil(t, target)
+
+	c, _ = newParamsContext("staffId=" + id.String())
+	assert.True(t, ParseUUIDQuery(c, "staffId", &target))
+	assert.Equal(t, &id, target)
+
+	c, w := newParamsContext("staffId=nope")
+	assert.False(t, ParseUUIDQuery(c, "staffId", &target))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestParseDateQuery(t *testing.T) {
+	var target *time.Time
+
+	c, _ := newParamsContext("")
+	assert.True(t, ParseDateQuery(c, "from", &target))
+	assert.Nil(t, target)
+
+	c, _ = newParamsContext("from=2026-06-01")
+	assert.True(t, ParseDateQuery(c, "from", &target))
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), *target)
+
+	c, w := newParamsContext("from=01-06-2026")
+	assert.False(t, ParseDateQuery(c, "from", &target))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestParseDate(t *testing.T) {
+	c, _ := newParamsContext("")
+	date, ok := ParseDate(c, nil, "dueDate")
+	assert.True(t, ok)
+	assert.Nil(t, date)
+
+	value := "2026-06-01"
+	date, ok = ParseDate(c, &value, "dueDate")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), *date)
+
+	value = "June 1"
+	c, w := newParamsContext("")
+	_, ok = ParseDate(c, &value, "dueDate")
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestParsePaging(t *testing.T) {
+	limit, offset := 20, 0
+
+	c, _ := newParamsContext("")
+	assert.True(t, ParsePaging(c, &limit, &offset))
+	assert.Equal(t, 20, limit)
+	assert.Equal(t, 0, offset)
+
+	c, _ = newParamsContext("limit=50&offset=100")
+	assert.True(t, ParsePaging(c, &limit, &offset))
+	assert.Equal(t, 50, limit)
+	assert.Equal(t, 100, offset)
+
+	c, w := newParamsContext("limit=all")
+	assert.False(t, ParsePaging(c, &limit, &offset))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
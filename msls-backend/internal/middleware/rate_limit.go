@@ -2,24 +2,40 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
 	"strconv"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"msls-backend/internal/pkg/ratelimit"
 )
 
+// rateLimitResultKey stores the most restrictive rate limit result seen so far
+// so that stacked limiters report the tightest quota in response headers.
+const rateLimitResultKey = "rate_limit_result"
+
 // RateLimitConfig holds configuration for rate limiting middleware.
 type RateLimitConfig struct {
+	// Name namespaces the counters of this limiter (e.g. "global", "login").
+	// Limiters sharing a store must use distinct names.
+	Name string
+
 	// Rate is the number of requests allowed per interval.
 	Rate int
 
 	// Interval is the time window for rate limiting.
 	Interval time.Duration
 
-	// BurstSize is the maximum burst size (bucket capacity).
-	BurstSize int
+	// Store holds the request counters.
+	// Default: a new in-memory store (per replica).
+	Store ratelimit.Store
+
+	// LimitFunc optionally resolves the limit per request (e.g. per-tenant quotas).
+	// Default: Rate per Interval.
+	LimitFunc func(c *gin.Context) ratelimit.Limit
 
 	// KeyFunc extracts the rate limiting key from the request.
 	// Default: client IP address.
@@ -30,163 +46,40 @@ type RateLimitConfig struct {
 
 	// OnLimitReached is an optional callback when rate limit is exceeded.
 	OnLimitReached func(c *gin.Context)
+
+	// OnStoreError is an optional callback when the store fails.
+	// Requests are allowed through when the store is unavailable.
+	OnStoreError func(c *gin.Context, err error)
 }
 
 // DefaultRateLimitConfig returns the default rate limit configuration.
 func DefaultRateLimitConfig() RateLimitConfig {
 	return RateLimitConfig{
+		Name:           "global",
 		Rate:           200,
 		Interval:       time.Minute,
-		BurstSize:      50,
+		Store:          nil,
+		LimitFunc:      nil,
 		KeyFunc:        nil,
 		ExcludedPaths:  []string{"/health", "/ready", "/metrics"},
 		OnLimitReached: nil,
+		OnStoreError:   nil,
 	}
 }
 
-// tokenBucket implements the token bucket algorithm.
-type tokenBucket struct {
-	tokens     float64
-	capacity   float64
-	rate       float64 // tokens per second
-	lastUpdate time.Time
-	mu         sync.Mutex
-}
-
-// newTokenBucket creates a new token bucket.
-func newTokenBucket(capacity int, rate float64) *tokenBucket {
-	return &tokenBucket{
-		tokens:     float64(capacity),
-		capacity:   float64(capacity),
-		rate:       rate,
-		lastUpdate: time.Now(),
-	}
-}
-
-// take attempts to take n tokens from the bucket.
-// Returns true if successful, false if rate limited.
-func (b *tokenBucket) take(n float64) bool {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	now := time.Now()
-	elapsed := now.Sub(b.lastUpdate).Seconds()
-	b.lastUpdate = now
-
-	// Add tokens based on elapsed time
-	b.tokens += elapsed * b.rate
-	if b.tokens > b.capacity {
-		b.tokens = b.capacity
-	}
-
-	// Check if we have enough tokens
-	if b.tokens < n {
-		return false
-	}
-
-	b.tokens -= n
-	return true
-}
-
-// remaining returns the current number of tokens.
-func (b *tokenBucket) remaining() int {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	return int(b.tokens)
-}
-
-// rateLimiter manages rate limiting for multiple clients.
-type rateLimiter struct {
-	buckets   map[string]*tokenBucket
-	capacity  int
-	rate      float64
-	mu        sync.RWMutex
-	cleanupMu sync.Mutex
-	lastClean time.Time
-}
-
-// newRateLimiter creates a new rate limiter.
-func newRateLimiter(capacity int, rate float64) *rateLimiter {
-	return &rateLimiter{
-		buckets:   make(map[string]*tokenBucket),
-		capacity:  capacity,
-		rate:      rate,
-		lastClean: time.Now(),
-	}
-}
-
-// getBucket returns the token bucket for a key, creating one if necessary.
-func (r *rateLimiter) getBucket(key string) *tokenBucket {
-	r.mu.RLock()
-	bucket, exists := r.buckets[key]
-	r.mu.RUnlock()
-
-	if exists {
-		return bucket
-	}
-
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	// Double-check after acquiring write lock
-	if bucket, exists = r.buckets[key]; exists {
-		return bucket
-	}
-
-	bucket = newTokenBucket(r.capacity, r.rate)
-	r.buckets[key] = bucket
-
-	// Periodic cleanup of old buckets
-	r.maybeCleanup()
-
-	return bucket
-}
-
-// maybeCleanup removes stale buckets periodically.
-func (r *rateLimiter) maybeCleanup() {
-	// Only cleanup every 5 minutes
-	if time.Since(r.lastClean) < 5*time.Minute {
-		return
-	}
-
-	r.cleanupMu.Lock()
-	defer r.cleanupMu.Unlock()
-
-	// Double-check after acquiring lock
-	if time.Since(r.lastClean) < 5*time.Minute {
-		return
+// RateLimit returns a middleware that implements fixed-window rate limiting.
+// It sets the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers and returns 429 Too Many Requests when the limit is exceeded.
+func RateLimit(config RateLimitConfig) gin.HandlerFunc {
+	store := config.Store
+	if store == nil {
+		store = ratelimit.NewMemoryStore()
 	}
 
-	r.lastClean = time.Now()
-	threshold := time.Now().Add(-10 * time.Minute)
-
-	for key, bucket := range r.buckets {
-		bucket.mu.Lock()
-		if bucket.lastUpdate.Before(threshold) {
-			delete(r.buckets, key)
-		}
-		bucket.mu.Unlock()
+	name := config.Name
+	if name == "" {
+		name = "default"
 	}
-}
-
-// take attempts to take a token for the given key.
-func (r *rateLimiter) take(key string) bool {
-	return r.getBucket(key).take(1)
-}
-
-// remaining returns the remaining tokens for a key.
-func (r *rateLimiter) remaining(key string) int {
-	return r.getBucket(key).remaining()
-}
-
-// RateLimit returns a middleware that implements token bucket rate limiting.
-// It returns 429 Too Many Requests when the rate limit is exceeded.
-func RateLimit(config RateLimitConfig) gin.HandlerFunc {
-	// Calculate rate (tokens per second)
-	rate := float64(config.Rate) / config.Interval.Seconds()
-
-	// Create rate limiter
-	limiter := newRateLimiter(config.BurstSize, rate)
 
 	// Build excluded paths map
 	excludedPaths := make(map[string]bool)
@@ -202,6 +95,14 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 		}
 	}
 
+	staticLimit := ratelimit.Limit{Rate: config.Rate, Window: config.Interval}
+	limitFunc := config.LimitFunc
+	if limitFunc == nil {
+		limitFunc = func(c *gin.Context) ratelimit.Limit {
+			return staticLimit
+		}
+	}
+
 	return func(c *gin.Context) {
 		// Skip rate limiting for excluded paths
 		if excludedPaths[c.Request.URL.Path] {
@@ -209,17 +110,23 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 			return
 		}
 
-		key := keyFunc(c)
+		limit := limitFunc(c)
+		key := name + ":" + keyFunc(c)
 
-		// Attempt to take a token
-		if !limiter.take(key) {
-			// Rate limit exceeded
-			retryAfter := int(config.Interval.Seconds())
+		result, err := store.Take(c.Request.Context(), key, limit)
+		if err != nil {
+			// Fail open: an unavailable store must not take the API down
+			if config.OnStoreError != nil {
+				config.OnStoreError(c, err)
+			}
+			c.Next()
+			return
+		}
 
-			// Set headers
-			c.Header("X-RateLimit-Limit", strconv.Itoa(config.Rate))
-			c.Header("X-RateLimit-Remaining", "0")
-			c.Header("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(config.Interval).Unix(), 10))
+		setRateLimitHeaders(c, limit, result)
+
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.ResetAfter)
 			c.Header("Retry-After", strconv.Itoa(retryAfter))
 
 			// Call optional callback
@@ -241,16 +148,41 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 			return
 		}
 
-		// Set rate limit headers for successful requests
-		remaining := limiter.remaining(key)
-		c.Header("X-RateLimit-Limit", strconv.Itoa(config.Rate))
-		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
-		c.Header("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(config.Interval).Unix(), 10))
-
 		c.Next()
 	}
 }
 
+// setRateLimitHeaders writes the RateLimit-* headers. When several limiters apply
+// to a request, the most restrictive one is reported and all policies are listed.
+func setRateLimitHeaders(c *gin.Context, limit ratelimit.Limit, result ratelimit.Result) {
+	policies := []string{limit.Policy()}
+	if existing := c.Writer.Header().Get("RateLimit-Policy"); existing != "" {
+		policies = append([]string{existing}, policies...)
+	}
+	c.Header("RateLimit-Policy", strings.Join(policies, ", "))
+
+	if prev, exists := c.Get(rateLimitResultKey); exists {
+		if p, ok := prev.(ratelimit.Result); ok && p.Remaining <= result.Remaining {
+			return
+		}
+	}
+	c.Set(rateLimitResultKey, result)
+
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+}
+
+// ceilSeconds rounds a duration up to whole seconds.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// isSafeMethod reports whether the request method does not modify state.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
 // RateLimitDefault returns a middleware with default configuration.
 func RateLimitDefault() gin.HandlerFunc {
 	return RateLimit(DefaultRateLimitConfig())
@@ -275,30 +207,109 @@ func RateLimitPerSecond(rate int) gin.HandlerFunc {
 // RateLimitByTenant returns a middleware that rate limits by tenant ID.
 func RateLimitByTenant(rate int, interval time.Duration) gin.HandlerFunc {
 	config := DefaultRateLimitConfig()
+	config.Name = "tenant"
 	config.Rate = rate
 	config.Interval = interval
-	config.KeyFunc = func(c *gin.Context) string {
-		tenantID := GetTenantID(c)
-		if tenantID == "" {
-			return c.ClientIP()
-		}
-		return tenantID
-	}
+	config.KeyFunc = tenantKey
 	return RateLimit(config)
 }
 
-// RateLimitByUser returns a middleware that rate limits by user ID (from context).
-func RateLimitByUser(rate int, interval time.Duration, userIDKey string) gin.HandlerFunc {
-	config := DefaultRateLimitConfig()
-	config.Rate = rate
-	config.Interval = interval
-	config.KeyFunc = func(c *gin.Context) string {
-		if userID, exists := c.Get(userIDKey); exists {
-			if id, ok := userID.(string); ok && id != "" {
-				return id
-			}
-		}
+// tenantKey keys requests by tenant ID, falling back to client IP.
+func tenantKey(c *gin.Context) string {
+	tenantID := GetTenantID(c)
+	if tenantID == "" {
 		return c.ClientIP()
 	}
+	return tenantID
+}
+
+// userKey keys requests by authenticated user ID, falling back to client IP.
+func userKey(c *gin.Context) string {
+	if userID, ok := GetCurrentUserID(c); ok {
+		return userID.String()
+	}
+	return c.ClientIP()
+}
+
+// RateLimitPolicies builds the rate limiters applied to each route group.
+// All limiters share one store so that limits hold across API replicas.
+type RateLimitPolicies struct {
+	Store ratelimit.Store
+
+	// Global applies to every request, per client IP.
+	Global ratelimit.Limit
+	// Login applies to password login and token endpoints, per client IP.
+	Login ratelimit.Limit
+	// OTP applies to OTP send/verify endpoints, per client IP.
+	OTP ratelimit.Limit
+	// UserReads applies to safe (GET/HEAD/OPTIONS) requests, per user.
+	UserReads ratelimit.Limit
+	// UserWrites applies to state-changing requests, per user.
+	UserWrites ratelimit.Limit
+
+	// TenantQuota resolves the per-tenant quota; nil disables tenant quotas.
+	TenantQuota *ratelimit.TenantQuotaResolver
+
+	// OnStoreError is called when the store fails.
+	OnStoreError func(c *gin.Context, err error)
+}
+
+// newConfig returns a base configuration bound to the shared store.
+func (p RateLimitPolicies) newConfig(name string, limit ratelimit.Limit) RateLimitConfig {
+	config := DefaultRateLimitConfig()
+	config.Name = name
+	config.Rate = limit.Rate
+	config.Interval = limit.Window
+	config.Store = p.Store
+	config.OnStoreError = p.OnStoreError
+	return config
+}
+
+// GlobalLimiter limits all requests per client IP.
+func (p RateLimitPolicies) GlobalLimiter() gin.HandlerFunc {
+	return RateLimit(p.newConfig("global", p.Global))
+}
+
+// LoginLimiter applies the strict login limit per client IP.
+func (p RateLimitPolicies) LoginLimiter() gin.HandlerFunc {
+	return RateLimit(p.newConfig("login", p.Login))
+}
+
+// OTPLimiter applies the strict OTP limit per client IP.
+func (p RateLimitPolicies) OTPLimiter() gin.HandlerFunc {
+	return RateLimit(p.newConfig("otp", p.OTP))
+}
+
+// TenantLimiter applies the per-tenant quota. Tenants without an override
+// in their settings get the resolver's default quota.
+func (p RateLimitPolicies) TenantLimiter() gin.HandlerFunc {
+	if p.TenantQuota == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	config := p.newConfig("tenant", ratelimit.Limit{})
+	config.KeyFunc = tenantKey
+	config.LimitFunc = func(c *gin.Context) ratelimit.Limit {
+		return p.TenantQuota.Resolve(c.Request.Context(), GetTenantID(c))
+	}
 	return RateLimit(config)
 }
+
+// UserLimiter applies separate read and write limits per authenticated user.
+// Must be registered after the Auth middleware.
+func (p RateLimitPolicies) UserLimiter() gin.HandlerFunc {
+	readsConfig := p.newConfig("user_read", p.UserReads)
+	readsConfig.KeyFunc = userKey
+	reads := RateLimit(readsConfig)
+
+	writesConfig := p.newConfig("user_write", p.UserWrites)
+	writesConfig.KeyFunc = userKey
+	writes := RateLimit(writesConfig)
+
+	return func(c *gin.Context) {
+		if isSafeMethod(c.Request.Method) {
+			reads(c)
+			return
+		}
+		writes(c)
+	}
+}
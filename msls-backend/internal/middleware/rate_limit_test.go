@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"msls-backend/internal/pkg/ratelimit"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// failingStore is a store whose backend is unavailable.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func (failingStore) Name() string { return "failing" }
+
+// newRateLimitRouter builds a router with the given middleware in front of
+// GET and POST handlers. setup runs first to stand in for auth and tenant
+// middleware.
+func newRateLimitRouter(setup gin.HandlerFunc, limiters ...gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	if setup != nil {
+		router.Use(setup)
+	}
+	router.Use(limiters...)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/items", ok)
+	router.POST("/items", ok)
+	router.GET("/health", ok)
+	return router
+}
+
+func doRequest(router *gin.Engine, method, path, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func testPolicies(store ratelimit.Store) RateLimitPolicies {
+	return RateLimitPolicies{
+		Store:      store,
+		Global:     ratelimit.PerMinute(10),
+		Login:      ratelimit.PerMinute(2),
+		OTP:        ratelimit.PerMinute(1),
+		UserReads:  ratelimit.PerMinute(3),
+		UserWrites: ratelimit.PerMinute(1),
+	}
+}
+
+func TestRateLimit_Headers(t *testing.T) {
+	config := DefaultRateLimitConfig()
+	config.Rate = 2
+	router := newRateLimitRouter(nil, RateLimit(config))
+
+	w := doRequest(router, http.MethodGet, "/items", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+
+	doRequest(router, http.MethodGet, "/items", "10.0.0.1")
+	w = doRequest(router, http.MethodGet, "/items", "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	// Another client has its own window; excluded paths are not limited
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.2").Code)
+	w = doRequest(router, http.MethodGet, "/health", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimit_StackedLimitersReportTightest(t *testing.T) {
+	policies := testPolicies(ratelimit.NewMemoryStore())
+	router := newRateLimitRouter(nil, policies.GlobalLimiter(), policies.LoginLimiter())
+
+	w := doRequest(router, http.MethodPost, "/items", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "10;w=60, 2;w=60", w.Header().Get("RateLimit-Policy"))
+}
+
+func TestRateLimitPolicies_LoginAndOTP(t *testing.T) {
+	policies := testPolicies(ratelimit.NewMemoryStore())
+	login := newRateLimitRouter(nil, policies.LoginLimiter())
+	otp := newRateLimitRouter(nil, policies.OTPLimiter())
+
+	assert.Equal(t, http.StatusOK, doRequest(login, http.MethodPost, "/items", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, doRequest(login, http.MethodPost, "/items", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(login, http.MethodPost, "/items", "10.0.0.1").Code)
+
+	// OTP counters are separate from login counters in the shared store
+	assert.Equal(t, http.StatusOK, doRequest(otp, http.MethodPost, "/items", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(otp, http.MethodPost, "/items", "10.0.0.1").Code)
+}
+
+func TestRateLimitPolicies_Global(t *testing.T) {
+	policies := testPolicies(ratelimit.NewMemoryStore())
+	policies.Global = ratelimit.PerMinute(1)
+	router := newRateLimitRouter(nil, policies.GlobalLimiter())
+
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodPost, "/items", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.2").Code)
+}
+
+func TestRateLimitPolicies_UserReadsAndWrites(t *testing.T) {
+	policies := testPolicies(ratelimit.NewMemoryStore())
+	alice, bob := uuid.New(), uuid.New()
+	current := alice
+	router := newRateLimitRouter(func(c *gin.Context) {
+		c.Set(UserIDKey, current)
+		c.Next()
+	}, policies.UserLimiter())
+
+	// Writes have their own, smaller limit
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodPost, "/items", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodPost, "/items", "10.0.0.1").Code)
+
+	// Reads are still allowed and limited per user, not per IP
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.1").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodGet, "/items", "10.0.0.2").Code)
+
+	current = bob
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodPost, "/items", "10.0.0.1").Code)
+}
+
+func TestRateLimitPolicies_Tenant(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	policies := testPolicies(ratelimit.NewMemoryStore())
+	policies.TenantQuota = ratelimit.NewTenantQuotaResolver(db, ratelimit.PerMinute(2))
+	tenantA, tenantB := uuid.New().String(), uuid.New().String()
+	current := tenantA
+	router := newRateLimitRouter(func(c *gin.Context) {
+		c.Set(TenantIDKey, current)
+		c.Next()
+	}, policies.TenantLimiter())
+
+	// Tenants share a quota across client IPs, and each has its own
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.2").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodGet, "/items", "10.0.0.3").Code)
+
+	current = tenantB
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.1").Code)
+
+	// Without a resolver, tenant quotas are disabled
+	policies.TenantQuota = nil
+	router = newRateLimitRouter(nil, policies.TenantLimiter())
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/items", "10.0.0.1").Code)
+	}
+}
+
+func TestRateLimit_FailsOpenWhenStoreErrors(t *testing.T) {
+	var storeErrors int
+	policies := testPolicies(failingStore{})
+	policies.OnStoreError = func(c *gin.Context, err error) { storeErrors++ }
+	router := newRateLimitRouter(nil, policies.GlobalLimiter(), policies.OTPLimiter())
+
+	for i := 0; i < 3; i++ {
+		w := doRequest(router, http.MethodPost, "/items", "10.0.0.1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+	assert.Equal(t, 6, storeErrors)
+}
+
+func TestCeilSeconds(t *testing.T) {
+	assert.Equal(t, 1, ceilSeconds(200*time.Millisecond))
+	assert.Equal(t, 60, ceilSeconds(time.Minute))
+	assert.Equal(t, 0, ceilSeconds(0))
+}
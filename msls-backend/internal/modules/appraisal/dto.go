@@ -0,0 +1,455 @@
+// Package appraisal provides staff appraisal cycles and probation confirmation reviews.
+package appraisal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// =========================================================================
+// Service DTOs
+// =========================================================================
+
+// GoalDTO is a goal on a template.
+type GoalDTO struct {
+	Title       string
+	Description string
+	Weight      int
+}
+
+// CreateTemplateDTO represents a request to create an appraisal template.
+type CreateTemplateDTO struct {
+	TenantID      uuid.UUID
+	Name          string
+	DesignationID *uuid.UUID
+	Description   string
+	Goals         []GoalDTO
+	UserID        *uuid.UUID
+}
+
+// UpdateTemplateDTO represents a request to update an appraisal template.
+// Goals, when given, replace the template's goals; appraisals already
+// created keep their own copy.
+type UpdateTemplateDTO struct {
+	Name        *string
+	Description *string
+	IsActive    *bool
+	Goals       []GoalDTO
+	UserID      *uuid.UUID
+}
+
+// CreateCycleDTO represents a request to create an appraisal cycle.
+type CreateCycleDTO struct {
+	TenantID          uuid.UUID
+	Name              string
+	BranchID          *uuid.UUID
+	PeriodStart       time.Time
+	PeriodEnd         time.Time
+	SelfAssessmentDue *time.Time
+	ManagerReviewDue  *time.Time
+	UserID            *uuid.UUID
+}
+
+// UpdateCycleDTO represents a request to update an appraisal cycle.
+type UpdateCycleDTO struct {
+	Name              *string
+	PeriodStart       *time.Time
+	PeriodEnd         *time.Time
+	SelfAssessmentDue *time.Time
+	ManagerReviewDue  *time.Time
+	UserID            *uuid.UUID
+}
+
+// GoalRatingDTO is a rating and comment for one goal.
+type GoalRatingDTO struct {
+	GoalID  uuid.UUID
+	Rating  *int
+	Comment string
+}
+
+// SelfAssessmentDTO represents a staff member's self-assessment.
+type SelfAssessmentDTO struct {
+	TenantID    uuid.UUID
+	AppraisalID uuid.UUID
+	Ratings     []GoalRatingDTO
+	Comments    *string
+	Submit      bool
+}
+
+// ManagerReviewDTO represents the reporting manager's review.
+type ManagerReviewDTO struct {
+	TenantID               uuid.UUID
+	AppraisalID            uuid.UUID
+	Ratings                []GoalRatingDTO
+	Comments               *string
+	ProbationOutcome       *models.ProbationOutcome
+	ExtendProbationTo      *time.Time
+	IncrementPercent       *decimal.Decimal
+	IncrementEffectiveFrom *time.Time
+	Submit                 bool
+}
+
+// SignOffDTO represents the reviewer's decision on a manager review.
+type SignOffDTO struct {
+	TenantID    uuid.UUID
+	AppraisalID uuid.UUID
+	Approve     bool
+	Comments    string
+	UserID      *uuid.UUID
+}
+
+// AppraisalFilter contains filter options for listing appraisals.
+type AppraisalFilter struct {
+	TenantID   uuid.UUID
+	CycleID    *uuid.UUID
+	StaffID    *uuid.UUID
+	ManagerID  *uuid.UUID
+	ReviewerID *uuid.UUID
+	Type       *models.AppraisalType
+	Status     *models.AppraisalStatus
+	Limit      int
+	Offset     int
+}
+
+// SkippedStaff is a staff member left out of a cycle launch.
+type SkippedStaff struct {
+	StaffID uuid.UUID
+	Name    string
+	Reason  string
+}
+
+// LaunchResult summarises the appraisals created when a cycle is launched.
+type LaunchResult struct {
+	Cycle   *models.AppraisalCycle
+	Created int
+	Skipped []SkippedStaff
+}
+
+// =========================================================================
+// Request Types
+// =========================================================================
+
+// GoalRequest represents a goal in a template request.
+type GoalRequest struct {
+	Title       string `json:"title" binding:"required,max=200"`
+	Description string `json:"description"`
+	Weight      int    `json:"weight" binding:"required,min=1,max=100"`
+}
+
+// CreateTemplateRequest represents the request body for creating a template.
+type CreateTemplateRequest struct {
+	Name          string        `json:"name" binding:"required,max=200"`
+	DesignationID *string       `json:"designationId" binding:"omitempty,uuid"`
+	Description   string        `json:"description"`
+	Goals         []GoalRequest `json:"goals" binding:"required,min=1,dive"`
+}
+
+// UpdateTemplateRequest represents the request body for updating a template.
+type UpdateTemplateRequest struct {
+	Name        *string       `json:"name" binding:"omitempty,max=200"`
+	Description *string       `json:"description"`
+	IsActive    *bool         `json:"isActive"`
+	Goals       []GoalRequest `json:"goals" binding:"omitempty,dive"`
+}
+
+// CreateCycleRequest represents the request body for creating a cycle.
+type CreateCycleRequest struct {
+	Name              string  `json:"name" binding:"required,max=200"`
+	BranchID          *string `json:"branchId" binding:"omitempty,uuid"`
+	PeriodStart       string  `json:"periodStart" binding:"required"`
+	PeriodEnd         string  `json:"periodEnd" binding:"required"`
+	SelfAssessmentDue *string `json:"selfAssessmentDue"`
+	ManagerReviewDue  *string `json:"managerReviewDue"`
+}
+
+// UpdateCycleRequest represents the request body for updating a cycle.
+type UpdateCycleRequest struct {
+	Name              *string `json:"name" binding:"omitempty,max=200"`
+	PeriodStart       *string `json:"periodStart"`
+	PeriodEnd         *string `json:"periodEnd"`
+	SelfAssessmentDue *string `json:"selfAssessmentDue"`
+	ManagerReviewDue  *string `json:"managerReviewDue"`
+}
+
+// GoalRatingRequest represents a rating for one goal.
+type GoalRatingRequest struct {
+	GoalID  string `json:"goalId" binding:"required,uuid"`
+	Rating  *int   `json:"rating" binding:"omitempty,min=1,max=5"`
+	Comment string `json:"comment"`
+}
+
+// SelfAssessmentRequest represents the request body for a self-assessment.
+// Leave submit unset to save a draft.
+type SelfAssessmentRequest struct {
+	Ratings  []GoalRatingRequest `json:"ratings" binding:"dive"`
+	Comments *string             `json:"comments"`
+	Submit   bool                `json:"submit"`
+}
+
+// ManagerReviewRequest represents the request body for a manager review.
+// Leave submit unset to save a draft.
+type ManagerReviewRequest struct {
+	Ratings                []GoalRatingRequest `json:"ratings" binding:"dive"`
+	Comments               *string             `json:"comments"`
+	ProbationOutcome       *string             `json:"probationOutcome" binding:"omitempty,oneof=confirm extend not_confirmed"`
+	ExtendProbationTo      *string             `json:"extendProbationTo"`
+	IncrementPercent       *string             `json:"incrementPercent"`
+	IncrementEffectiveFrom *string             `json:"incrementEffectiveFrom"`
+	Submit                 bool                `json:"submit"`
+}
+
+// SignOffRequest represents the request body for the reviewer's sign-off.
+// Set approve to false to send the review back to the manager.
+type SignOffRequest struct {
+	Approve  bool   `json:"approve"`
+	Comments string `json:"comments"`
+}
+
+// =========================================================================
+// Response Types
+// =========================================================================
+
+// TemplateGoalResponse represents a template goal in API responses.
+type TemplateGoalResponse struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Weight      int    `json:"weight"`
+}
+
+// TemplateResponse represents an appraisal template in API responses.
+type TemplateResponse struct {
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	DesignationID   string                 `json:"designationId,omitempty"`
+	DesignationName string                 `json:"designationName,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	IsActive        bool                   `json:"isActive"`
+	Goals           []TemplateGoalResponse `json:"goals"`
+}
+
+// CycleResponse represents an appraisal cycle in API responses.
+type CycleResponse struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	BranchID          string `json:"branchId,omitempty"`
+	PeriodStart       string `json:"periodStart"`
+	PeriodEnd         string `json:"periodEnd"`
+	SelfAssessmentDue string `json:"selfAssessmentDue,omitempty"`
+	ManagerReviewDue  string `json:"managerReviewDue,omitempty"`
+	Status            string `json:"status"`
+	LaunchedAt        string `json:"launchedAt,omitempty"`
+	ClosedAt          string `json:"closedAt,omitempty"`
+}
+
+// SkippedStaffResponse represents a staff member left out of a launch.
+type SkippedStaffResponse struct {
+	StaffID string `json:"staffId"`
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
+}
+
+// LaunchResponse represents the result of launching a cycle.
+type LaunchResponse struct {
+	Cycle   CycleResponse          `json:"cycle"`
+	Created int                    `json:"created"`
+	Skipped []SkippedStaffResponse `json:"skipped"`
+}
+
+// AppraisalGoalResponse represents an appraisal goal in API responses.
+type AppraisalGoalResponse struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Description    string `json:"description,omitempty"`
+	Weight         int    `json:"weight"`
+	SelfRating     *int   `json:"selfRating,omitempty"`
+	SelfComment    string `json:"selfComment,omitempty"`
+	ManagerRating  *int   `json:"managerRating,omitempty"`
+	ManagerComment string `json:"managerComment,omitempty"`
+}
+
+// AppraisalResponse represents an appraisal in API responses.
+type AppraisalResponse struct {
+	ID                     string                  `json:"id"`
+	Type                   string                  `json:"type"`
+	CycleID                string                  `json:"cycleId,omitempty"`
+	CycleName              string                  `json:"cycleName,omitempty"`
+	StaffID                string                  `json:"staffId"`
+	StaffName              string                  `json:"staffName,omitempty"`
+	EmployeeID             string                  `json:"employeeId,omitempty"`
+	ManagerID              string                  `json:"managerId,omitempty"`
+	ManagerName            string                  `json:"managerName,omitempty"`
+	ReviewerID             string                  `json:"reviewerId,omitempty"`
+	ReviewerName           string                  `json:"reviewerName,omitempty"`
+	Status                 string                  `json:"status"`
+	DueDate                string                  `json:"dueDate,omitempty"`
+	SelfComments           string                  `json:"selfComments,omitempty"`
+	SelfSubmittedAt        string                  `json:"selfSubmittedAt,omitempty"`
+	ManagerComments        string                  `json:"managerComments,omitempty"`
+	ManagerSubmittedAt     string                  `json:"managerSubmittedAt,omitempty"`
+	OverallRating          string                  `json:"overallRating,omitempty"`
+	ProbationOutcome       string                  `json:"probationOutcome,omitempty"`
+	ExtendProbationTo      string                  `json:"extendProbationTo,omitempty"`
+	IncrementPercent       string                  `json:"incrementPercent,omitempty"`
+	IncrementEffectiveFrom string                  `json:"incrementEffectiveFrom,omitempty"`
+	ReviewerComments       string                  `json:"reviewerComments,omitempty"`
+	SignedOffAt            string                  `json:"signedOffAt,omitempty"`
+	SalaryRevisionID       string                  `json:"salaryRevisionId,omitempty"`
+	Goals                  []AppraisalGoalResponse `json:"goals,omitempty"`
+}
+
+// AppraisalListResponse represents a page of appraisals.
+type AppraisalListResponse struct {
+	Appraisals []AppraisalResponse `json:"appraisals"`
+	Total      int64               `json:"total"`
+}
+
+// ToTemplateResponse converts an AppraisalTemplate model to a TemplateResponse.
+func ToTemplateResponse(t *models.AppraisalTemplate) TemplateResponse {
+	resp := TemplateResponse{
+		ID:          t.ID.String(),
+		Name:        t.Name,
+		Description: t.Description,
+		IsActive:    t.IsActive,
+		Goals:       make([]TemplateGoalResponse, len(t.Goals)),
+	}
+	if t.DesignationID != nil {
+		resp.DesignationID = t.DesignationID.String()
+	}
+	if t.Designation != nil {
+		resp.DesignationName = t.Designation.Name
+	}
+	for i, goal := range t.Goals {
+		resp.Goals[i] = TemplateGoalResponse{
+			ID:          goal.ID.String(),
+			Title:       goal.Title,
+			Description: goal.Description,
+			Weight:      goal.Weight,
+		}
+	}
+	return resp
+}
+
+// ToCycleResponse converts an AppraisalCycle model to a CycleResponse.
+func ToCycleResponse(c *models.AppraisalCycle) CycleResponse {
+	resp := CycleResponse{
+		ID:                c.ID.String(),
+		Name:              c.Name,
+		PeriodStart:       c.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:         c.PeriodEnd.Format("2006-01-02"),
+		SelfAssessmentDue: formatDate(c.SelfAssessmentDue),
+		ManagerReviewDue:  formatDate(c.ManagerReviewDue),
+		Status:            string(c.Status),
+		LaunchedAt:        formatTimestamp(c.LaunchedAt),
+		ClosedAt:          formatTimestamp(c.ClosedAt),
+	}
+	if c.BranchID != nil {
+		resp.BranchID = c.BranchID.String()
+	}
+	return resp
+}
+
+// ToLaunchResponse converts a LaunchResult to a LaunchResponse.
+func ToLaunchResponse(result *LaunchResult) LaunchResponse {
+	resp := LaunchResponse{
+		Cycle:   ToCycleResponse(result.Cycle),
+		Created: result.Created,
+		Skipped: make([]SkippedStaffResponse, len(result.Skipped)),
+	}
+	for i, skipped := range result.Skipped {
+		resp.Skipped[i] = SkippedStaffResponse{
+			StaffID: skipped.StaffID.String(),
+			Name:    skipped.Name,
+			Reason:  skipped.Reason,
+		}
+	}
+	return resp
+}
+
+// ToAppraisalResponse converts an Appraisal model to an AppraisalResponse,
+// including its goals when withGoals is set.
+func ToAppraisalResponse(a *models.Appraisal, withGoals bool) AppraisalResponse {
+	resp := AppraisalResponse{
+		ID:                     a.ID.String(),
+		Type:                   string(a.Type),
+		StaffID:                a.StaffID.String(),
+		Status:                 string(a.Status),
+		DueDate:                formatDate(a.DueDate),
+		SelfComments:           a.SelfComments,
+		SelfSubmittedAt:        formatTimestamp(a.SelfSubmittedAt),
+		ManagerComments:        a.ManagerComments,
+		ManagerSubmittedAt:     formatTimestamp(a.ManagerSubmittedAt),
+		ExtendProbationTo:      formatDate(a.ExtendProbationTo),
+		IncrementEffectiveFrom: formatDate(a.IncrementEffectiveFrom),
+		ReviewerComments:       a.ReviewerComments,
+		SignedOffAt:            formatTimestamp(a.SignedOffAt),
+	}
+	if a.CycleID != nil {
+		resp.CycleID = a.CycleID.String()
+	}
+	if a.Cycle != nil {
+		resp.CycleName = a.Cycle.Name
+	}
+	if a.Staff != nil {
+		resp.StaffName = a.Staff.FullName()
+		resp.EmployeeID = a.Staff.EmployeeID
+	}
+	if a.ManagerID != nil {
+		resp.ManagerID = a.ManagerID.String()
+	}
+	if a.Manager != nil {
+		resp.ManagerName = a.Manager.FullName()
+	}
+	if a.ReviewerID != nil {
+		resp.ReviewerID = a.ReviewerID.String()
+	}
+	if a.Reviewer != nil {
+		resp.ReviewerName = a.Reviewer.FullName()
+	}
+	if a.OverallRating != nil {
+		resp.OverallRating = a.OverallRating.StringFixed(2)
+	}
+	if a.ProbationOutcome != nil {
+		resp.ProbationOutcome = string(*a.ProbationOutcome)
+	}
+	if a.IncrementPercent != nil {
+		resp.IncrementPercent = a.IncrementPercent.StringFixed(2)
+	}
+	if a.SalaryRevisionID != nil {
+		resp.SalaryRevisionID = a.SalaryRevisionID.String()
+	}
+	if withGoals {
+		resp.Goals = make([]AppraisalGoalResponse, len(a.Goals))
+		for i, goal := range a.Goals {
+			resp.Goals[i] = AppraisalGoalResponse{
+				ID:             goal.ID.String(),
+				Title:          goal.Title,
+				Description:    goal.Description,
+				Weight:         goal.Weight,
+				SelfRating:     goal.SelfRating,
+				SelfComment:    goal.SelfComment,
+				ManagerRating:  goal.ManagerRating,
+				ManagerComment: goal.ManagerComment,
+			}
+		}
+	}
+	return resp
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,67 @@
+// Package appraisal provides staff appraisal cycles and probation confirmation reviews.
+package appraisal
+
+import "errors"
+
+// Appraisal-related errors.
+var (
+	// ErrTemplateNotFound is returned when an appraisal template is not found.
+	ErrTemplateNotFound = errors.New("appraisal template not found")
+
+	// ErrCycleNotFound is returned when an appraisal cycle is not found.
+	ErrCycleNotFound = errors.New("appraisal cycle not found")
+
+	// ErrAppraisalNotFound is returned when an appraisal is not found.
+	ErrAppraisalNotFound = errors.New("appraisal not found")
+
+	// ErrStaffNotFound is returned when the staff member is not found.
+	ErrStaffNotFound = errors.New("staff not found")
+
+	// ErrGoalNotFound is returned when a rating refers to a goal that is not on the appraisal.
+	ErrGoalNotFound = errors.New("goal is not on this appraisal")
+
+	// ErrNameRequired is returned when a template or cycle has no name.
+	ErrNameRequired = errors.New("name is required")
+
+	// ErrGoalsRequired is returned when a template has no goals.
+	ErrGoalsRequired = errors.New("template must have at least one goal")
+
+	// ErrInvalidWeights is returned when goal weights are not positive or do not total 100.
+	ErrInvalidWeights = errors.New("goal weights must be positive and total 100")
+
+	// ErrDuplicateTemplate is returned when a designation already has a template.
+	ErrDuplicateTemplate = errors.New("an appraisal template already exists for this designation")
+
+	// ErrInvalidPeriod is returned when a cycle ends before it starts.
+	ErrInvalidPeriod = errors.New("period end must not be before period start")
+
+	// ErrCycleNotDraft is returned when a cycle that has been launched is launched again, re-dated or deleted.
+	ErrCycleNotDraft = errors.New("appraisal cycle has already been launched")
+
+	// ErrCycleNotLaunched is returned when a draft cycle is closed.
+	ErrCycleNotLaunched = errors.New("appraisal cycle has not been launched")
+
+	// ErrCycleClosed is returned when an appraisal in a closed cycle is changed.
+	ErrCycleClosed = errors.New("appraisal cycle is closed")
+
+	// ErrInvalidStatus is returned when an appraisal is not at the workflow step being submitted.
+	ErrInvalidStatus = errors.New("appraisal is not awaiting this step")
+
+	// ErrInvalidRating is returned when a rating is outside 1-5.
+	ErrInvalidRating = errors.New("ratings must be between 1 and 5")
+
+	// ErrIncompleteRatings is returned when a step is submitted without rating every goal.
+	ErrIncompleteRatings = errors.New("every goal must be rated before submitting")
+
+	// ErrOutcomeRequired is returned when a probation review is submitted without an outcome.
+	ErrOutcomeRequired = errors.New("probation outcome is required")
+
+	// ErrInvalidExtension is returned when probation is extended without a later end date.
+	ErrInvalidExtension = errors.New("extended probation must end after the current probation end date")
+
+	// ErrInvalidIncrement is returned when the increment percentage is negative or too large.
+	ErrInvalidIncrement = errors.New("increment percent must be between 0 and 100")
+
+	// ErrNoCurrentSalary is returned when an increment is approved for staff without a salary.
+	ErrNoCurrentSalary = errors.New("staff member has no current salary to revise")
+)
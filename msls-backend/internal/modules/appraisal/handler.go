@@ -0,0 +1,898 @@
+// Package appraisal provides staff appraisal cycles and probation confirmation reviews.
+package appraisal
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/response"
+)
+
+// Handler handles appraisal HTTP requests.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new appraisal handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers appraisal template, cycle and appraisal routes.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup) {
+	// Templates
+	templates := rg.Group("/appraisal-templates")
+	{
+		templatesRead := templates.Group("")
+		templatesRead.Use(middleware.PermissionRequired("appraisals.view"))
+		{
+			templatesRead.GET("", h.ListTemplates)
+			templatesRead.GET("/:id", h.GetTemplate)
+		}
+
+		templatesManage := templates.Group("")
+		templatesManage.Use(middleware.PermissionRequired("appraisals.manage"))
+		{
+			templatesManage.POST("", h.CreateTemplate)
+			templatesManage.PUT("/:id", h.UpdateTemplate)
+			templatesManage.DELETE("/:id", h.DeleteTemplate)
+		}
+	}
+
+	// Cycles
+	cycles := rg.Group("/appraisal-cycles")
+	{
+		cyclesRead := cycles.Group("")
+		cyclesRead.Use(middleware.PermissionRequired("appraisals.view"))
+		{
+			cyclesRead.GET("", h.ListCycles)
+			cyclesRead.GET("/:id", h.GetCycle)
+		}
+
+		cyclesManage := cycles.Group("")
+		cyclesManage.Use(middleware.PermissionRequired("appraisals.manage"))
+		{
+			cyclesManage.POST("", h.CreateCycle)
+			cyclesManage.PUT("/:id", h.UpdateCycle)
+			cyclesManage.DELETE("/:id", h.DeleteCycle)
+			cyclesManage.POST("/:id/launch", h.LaunchCycle)
+			cyclesManage.POST("/:id/close", h.CloseCycle)
+		}
+	}
+
+	// Appraisals
+	appraisals := rg.Group("/appraisals")
+	{
+		appraisalsRead := appraisals.Group("")
+		appraisalsRead.Use(middleware.PermissionRequired("appraisals.view"))
+		{
+			appraisalsRead.GET("", h.ListAppraisals)
+			appraisalsRead.GET("/:id", h.GetAppraisal)
+		}
+
+		appraisals.PUT("/:id/self-assessment", middleware.PermissionRequired("appraisals.self_assess"), h.SaveSelfAssessment)
+		appraisals.PUT("/:id/manager-review", middleware.PermissionRequired("appraisals.review"), h.SaveManagerReview)
+		appraisals.POST("/:id/sign-off", middleware.PermissionRequired("appraisals.signoff"), h.SignOff)
+	}
+}
+
+// RegisterStaffAppraisalRoutes registers staff appraisal routes.
+func (h *Handler) RegisterStaffAppraisalRoutes(staffGroup *gin.RouterGroup) {
+	staffGroup.GET("/:id/appraisals", middleware.PermissionRequired("appraisals.view"), h.ListStaffAppraisals)
+}
+
+// =========================================================================
+// Templates
+// =========================================================================
+
+// ListTemplates returns appraisal templates.
+// @Summary List appraisal templates
+// @Description List the KRA/goal templates used for each designation
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param activeOnly query bool false "Only active templates"
+// @Success 200 {object} response.Success{data=[]TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/appraisal-templates [get]
+func (h *Handler) ListTemplates(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	templates, err := h.service.ListTemplates(c.Request.Context(), tenantID, c.Query("activeOnly") == "true")
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := make([]TemplateResponse, len(templates))
+	for i := range templates {
+		resp[i] = ToTemplateResponse(&templates[i])
+	}
+	response.OK(c, resp)
+}
+
+// GetTemplate returns an appraisal template.
+// @Summary Get appraisal template
+// @Description Get an appraisal template with its goals
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Success 200 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/appraisal-templates/{id} [get]
+func (h *Handler) GetTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	template, err := h.service.GetTemplate(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTemplateResponse(template))
+}
+
+// CreateTemplate creates an appraisal template.
+// @Summary Create appraisal template
+// @Description Create a KRA/goal template for a designation, or the default template when no designation is given. Goal weights must total 100.
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body CreateTemplateRequest true "Template"
+// @Success 201 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/appraisal-templates [post]
+func (h *Handler) CreateTemplate(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	designationID, ok := middleware.ParseOptionalUUID(c, req.DesignationID, "Invalid designation ID")
+	if !ok {
+		return
+	}
+
+	template, err := h.service.CreateTemplate(c.Request.Context(), CreateTemplateDTO{
+		TenantID:      tenantID,
+		Name:          req.Name,
+		DesignationID: designationID,
+		Description:   req.Description,
+		Goals:         toGoalDTOs(req.Goals),
+		UserID:        &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToTemplateResponse(template))
+}
+
+// UpdateTemplate updates an appraisal template.
+// @Summary Update appraisal template
+// @Description Update an appraisal template. Goals, when given, replace the template's goals; existing appraisals keep theirs.
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Param request body UpdateTemplateRequest true "Template"
+// @Success 200 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/appraisal-templates/{id} [put]
+func (h *Handler) UpdateTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	dto := UpdateTemplateDTO{
+		Name:        req.Name,
+		Description: req.Description,
+		IsActive:    req.IsActive,
+		UserID:      &userID,
+	}
+	if req.Goals != nil {
+		dto.Goals = toGoalDTOs(req.Goals)
+	}
+
+	template, err := h.service.UpdateTemplate(c.Request.Context(), tenantID, id, dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTemplateResponse(template))
+}
+
+// DeleteTemplate deletes an appraisal template.
+// @Summary Delete appraisal template
+// @Description Delete an appraisal template. Appraisals already created from it keep their goals.
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/appraisal-templates/{id} [delete]
+func (h *Handler) DeleteTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTemplate(c.Request.Context(), tenantID, id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// =========================================================================
+// Cycles
+// =========================================================================
+
+// ListCycles returns appraisal cycles.
+// @Summary List appraisal cycles
+// @Description List appraisal cycles, newest first
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param status query string false "Status (draft, active, closed)"
+// @Success 200 {object} response.Success{data=[]CycleResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/appraisal-cycles [get]
+func (h *Handler) ListCycles(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var status *models.AppraisalCycleStatus
+	if value := c.Query("status"); value != "" {
+		s := models.AppraisalCycleStatus(value)
+		if !s.IsValid() {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid status"))
+			return
+		}
+		status = &s
+	}
+
+	cycles, err := h.service.ListCycles(c.Request.Context(), tenantID, status)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := make([]CycleResponse, len(cycles))
+	for i := range cycles {
+		resp[i] = ToCycleResponse(&cycles[i])
+	}
+	response.OK(c, resp)
+}
+
+// GetCycle returns an appraisal cycle.
+// @Summary Get appraisal cycle
+// @Description Get an appraisal cycle
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Cycle ID"
+// @Success 200 {object} response.Success{data=CycleResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/appraisal-cycles/{id} [get]
+func (h *Handler) GetCycle(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid cycle ID")
+	if !ok {
+		return
+	}
+
+	cycle, err := h.service.GetCycle(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCycleResponse(cycle))
+}
+
+// CreateCycle creates an appraisal cycle.
+// @Summary Create appraisal cycle
+// @Description Create a draft appraisal cycle, optionally limited to a branch
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body CreateCycleRequest true "Cycle"
+// @Success 201 {object} response.Success{data=CycleResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/appraisal-cycles [post]
+func (h *Handler) CreateCycle(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req CreateCycleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	branchID, ok := middleware.ParseOptionalUUID(c, req.BranchID, "Invalid branch ID")
+	if !ok {
+		return
+	}
+	periodStart, ok := middleware.ParseDate(c, &req.PeriodStart, "periodStart")
+	if !ok {
+		return
+	}
+	periodEnd, ok := middleware.ParseDate(c, &req.PeriodEnd, "periodEnd")
+	if !ok {
+		return
+	}
+	selfDue, ok := middleware.ParseDate(c, req.SelfAssessmentDue, "selfAssessmentDue")
+	if !ok {
+		return
+	}
+	managerDue, ok := middleware.ParseDate(c, req.ManagerReviewDue, "managerReviewDue")
+	if !ok {
+		return
+	}
+
+	cycle, err := h.service.CreateCycle(c.Request.Context(), CreateCycleDTO{
+		TenantID:          tenantID,
+		Name:              req.Name,
+		BranchID:          branchID,
+		PeriodStart:       *periodStart,
+		PeriodEnd:         *periodEnd,
+		SelfAssessmentDue: selfDue,
+		ManagerReviewDue:  managerDue,
+		UserID:            &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToCycleResponse(cycle))
+}
+
+// UpdateCycle updates an appraisal cycle.
+// @Summary Update appraisal cycle
+// @Description Update an appraisal cycle. The period can only change before the cycle is launched.
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Cycle ID"
+// @Param request body UpdateCycleRequest true "Cycle"
+// @Success 200 {object} response.Success{data=CycleResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/appraisal-cycles/{id} [put]
+func (h *Handler) UpdateCycle(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid cycle ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req UpdateCycleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	dto := UpdateCycleDTO{Name: req.Name, UserID: &userID}
+	if dto.PeriodStart, ok = middleware.ParseDate(c, req.PeriodStart, "periodStart"); !ok {
+		return
+	}
+	if dto.PeriodEnd, ok = middleware.ParseDate(c, req.PeriodEnd, "periodEnd"); !ok {
+		return
+	}
+	if dto.SelfAssessmentDue, ok = middleware.ParseDate(c, req.SelfAssessmentDue, "selfAssessmentDue"); !ok {
+		return
+	}
+	if dto.ManagerReviewDue, ok = middleware.ParseDate(c, req.ManagerReviewDue, "managerReviewDue"); !ok {
+		return
+	}
+
+	cycle, err := h.service.UpdateCycle(c.Request.Context(), tenantID, id, dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCycleResponse(cycle))
+}
+
+// DeleteCycle deletes a draft appraisal cycle.
+// @Summary Delete appraisal cycle
+// @Description Delete an appraisal cycle that has not been launched
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Cycle ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/appraisal-cycles/{id} [delete]
+func (h *Handler) DeleteCycle(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid cycle ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCycle(c.Request.Context(), tenantID, id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// LaunchCycle launches an appraisal cycle.
+// @Summary Launch appraisal cycle
+// @Description Create an appraisal for each active staff member from their designation's template and open self-assessment. Staff without a template or still on probation are skipped and listed.
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Cycle ID"
+// @Success 200 {object} response.Success{data=LaunchResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/appraisal-cycles/{id}/launch [post]
+func (h *Handler) LaunchCycle(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid cycle ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	result, err := h.service.LaunchCycle(c.Request.Context(), tenantID, id, &userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToLaunchResponse(result))
+}
+
+// CloseCycle closes an appraisal cycle.
+// @Summary Close appraisal cycle
+// @Description Close an active appraisal cycle; its appraisals can no longer change
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Cycle ID"
+// @Success 200 {object} response.Success{data=CycleResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/appraisal-cycles/{id}/close [post]
+func (h *Handler) CloseCycle(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid cycle ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	cycle, err := h.service.CloseCycle(c.Request.Context(), tenantID, id, &userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCycleResponse(cycle))
+}
+
+// =========================================================================
+// Appraisals
+// =========================================================================
+
+// ListAppraisals returns appraisals.
+// @Summary List appraisals
+// @Description List appraisals and probation reviews, for example a manager's or reviewer's queue
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param cycleId query string false "Cycle ID"
+// @Param staffId query string false "Staff ID"
+// @Param managerId query string false "Manager staff ID"
+// @Param reviewerId query string false "Reviewer staff ID"
+// @Param type query string false "Type (cycle, probation)"
+// @Param status query string false "Status (self_assessment, manager_review, reviewer_signoff, completed)"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=AppraisalListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/appraisals [get]
+func (h *Handler) ListAppraisals(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := AppraisalFilter{TenantID: tenantID}
+	if !middleware.ParseUUIDQuery(c, "cycleId", &filter.CycleID) ||
+		!middleware.ParseUUIDQuery(c, "staffId", &filter.StaffID) ||
+		!middleware.ParseUUIDQuery(c, "managerId", &filter.ManagerID) ||
+		!middleware.ParseUUIDQuery(c, "reviewerId", &filter.ReviewerID) ||
+		!middleware.ParsePaging(c, &filter.Limit, &filter.Offset) {
+		return
+	}
+	if value := c.Query("type"); value != "" {
+		t := models.AppraisalType(value)
+		if !t.IsValid() {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid type"))
+			return
+		}
+		filter.Type = &t
+	}
+	if value := c.Query("status"); value != "" {
+		s := models.AppraisalStatus(value)
+		if !s.IsValid() {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid status"))
+			return
+		}
+		filter.Status = &s
+	}
+
+	appraisals, total, err := h.service.ListAppraisals(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, toListResponse(appraisals, total))
+}
+
+// ListStaffAppraisals returns a staff member's appraisals.
+// @Summary List staff appraisals
+// @Description List a staff member's appraisals and probation reviews
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Success 200 {object} response.Success{data=AppraisalListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/appraisals [get]
+func (h *Handler) ListStaffAppraisals(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+
+	appraisals, total, err := h.service.ListStaffAppraisals(c.Request.Context(), tenantID, staffID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, toListResponse(appraisals, total))
+}
+
+// GetAppraisal returns an appraisal.
+// @Summary Get appraisal
+// @Description Get an appraisal with its goals and ratings
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Appraisal ID"
+// @Success 200 {object} response.Success{data=AppraisalResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/appraisals/{id} [get]
+func (h *Handler) GetAppraisal(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid appraisal ID")
+	if !ok {
+		return
+	}
+
+	appraisal, err := h.service.GetAppraisal(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToAppraisalResponse(appraisal, true))
+}
+
+// SaveSelfAssessment saves or submits a self-assessment.
+// @Summary Save self-assessment
+// @Description Save the staff member's goal ratings (1-5) and comments. With submit, every goal must be rated and the appraisal moves to manager review.
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Appraisal ID"
+// @Param request body SelfAssessmentRequest true "Self-assessment"
+// @Success 200 {object} response.Success{data=AppraisalResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/appraisals/{id}/self-assessment [put]
+func (h *Handler) SaveSelfAssessment(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid appraisal ID")
+	if !ok {
+		return
+	}
+
+	var req SelfAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	ratings, ok := toRatingDTOs(c, req.Ratings)
+	if !ok {
+		return
+	}
+
+	appraisal, err := h.service.SaveSelfAssessment(c.Request.Context(), SelfAssessmentDTO{
+		TenantID:    tenantID,
+		AppraisalID: id,
+		Ratings:     ratings,
+		Comments:    req.Comments,
+		Submit:      req.Submit,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToAppraisalResponse(appraisal, true))
+}
+
+// SaveManagerReview saves or submits a manager review.
+// @Summary Save manager review
+// @Description Save the reporting manager's goal ratings, comments, increment recommendation and (for probation reviews) outcome. With submit, the weighted overall rating is calculated and the appraisal moves to reviewer sign-off.
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Appraisal ID"
+// @Param request body ManagerReviewRequest true "Manager review"
+// @Success 200 {object} response.Success{data=AppraisalResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/appraisals/{id}/manager-review [put]
+func (h *Handler) SaveManagerReview(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid appraisal ID")
+	if !ok {
+		return
+	}
+
+	var req ManagerReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	ratings, ok := toRatingDTOs(c, req.Ratings)
+	if !ok {
+		return
+	}
+
+	dto := ManagerReviewDTO{
+		TenantID:    tenantID,
+		AppraisalID: id,
+		Ratings:     ratings,
+		Comments:    req.Comments,
+		Submit:      req.Submit,
+	}
+	if req.ProbationOutcome != nil {
+		outcome := models.ProbationOutcome(*req.ProbationOutcome)
+		dto.ProbationOutcome = &outcome
+	}
+	if dto.ExtendProbationTo, ok = middleware.ParseDate(c, req.ExtendProbationTo, "extendProbationTo"); !ok {
+		return
+	}
+	if dto.IncrementEffectiveFrom, ok = middleware.ParseDate(c, req.IncrementEffectiveFrom, "incrementEffectiveFrom"); !ok {
+		return
+	}
+	if req.IncrementPercent != nil && *req.IncrementPercent != "" {
+		percent, err := decimal.NewFromString(*req.IncrementPercent)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid incrementPercent"))
+			return
+		}
+		dto.IncrementPercent = &percent
+	}
+
+	appraisal, err := h.service.SaveManagerReview(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToAppraisalResponse(appraisal, true))
+}
+
+// SignOff records the reviewer's sign-off.
+// @Summary Reviewer sign-off
+// @Description Approve a manager review to complete the appraisal, applying any increment as a new salary revision and any probation outcome to the staff record, or send it back to the manager.
+// @Tags Appraisals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Appraisal ID"
+// @Param request body SignOffRequest true "Sign-off"
+// @Success 200 {object} response.Success{data=AppraisalResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/appraisals/{id}/sign-off [post]
+func (h *Handler) SignOff(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid appraisal ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req SignOffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	appraisal, err := h.service.SignOff(c.Request.Context(), SignOffDTO{
+		TenantID:    tenantID,
+		AppraisalID: id,
+		Approve:     req.Approve,
+		Comments:    req.Comments,
+		UserID:      &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToAppraisalResponse(appraisal, true))
+}
+
+// =========================================================================
+// Helpers
+// =========================================================================
+
+func toGoalDTOs(goals []GoalRequest) []GoalDTO {
+	result := make([]GoalDTO, len(goals))
+	for i, goal := range goals {
+		result[i] = GoalDTO{Title: goal.Title, Description: goal.Description, Weight: goal.Weight}
+	}
+	return result
+}
+
+func toRatingDTOs(c *gin.Context, ratings []GoalRatingRequest) ([]GoalRatingDTO, bool) {
+	result := make([]GoalRatingDTO, len(ratings))
+	for i, rating := range ratings {
+		goalID, err := uuid.Parse(rating.GoalID)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid goal ID"))
+			return nil, false
+		}
+		result[i] = GoalRatingDTO{GoalID: goalID, Rating: rating.Rating, Comment: rating.Comment}
+	}
+	return result, true
+}
+
+func toListResponse(appraisals []models.Appraisal, total int64) AppraisalListResponse {
+	resp := AppraisalListResponse{Appraisals: make([]AppraisalResponse, len(appraisals)), Total: total}
+	for i := range appraisals {
+		resp.Appraisals[i] = ToAppraisalResponse(&appraisals[i], false)
+	}
+	return resp
+}
+
+// handleServiceError converts service errors to API errors.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrTemplateNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Appraisal template not found"))
+	case errors.Is(err, ErrCycleNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Appraisal cycle not found"))
+	case errors.Is(err, ErrAppraisalNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Appraisal not found"))
+	case errors.Is(err, ErrStaffNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff not found"))
+	case errors.Is(err, ErrGoalNotFound),
+		errors.Is(err, ErrNameRequired),
+		errors.Is(err, ErrGoalsRequired),
+		errors.Is(err, ErrInvalidWeights),
+		errors.Is(err, ErrInvalidPeriod),
+		errors.Is(err, ErrInvalidRating),
+		errors.Is(err, ErrIncompleteRatings),
+		errors.Is(err, ErrOutcomeRequired),
+		errors.Is(err, ErrInvalidExtension),
+		errors.Is(err, ErrInvalidIncrement),
+		errors.Is(err, ErrNoCurrentSalary):
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+	case errors.Is(err, ErrDuplicateTemplate),
+		errors.Is(err, ErrCycleNotDraft),
+		errors.Is(err, ErrCycleNotLaunched),
+		errors.Is(err, ErrCycleClosed),
+		errors.Is(err, ErrInvalidStatus):
+		apperrors.Abort(c, apperrors.Conflict(err.Error()))
+	default:
+		logger.Error("Appraisal operation error", zap.Error(err))
+		apperrors.Abort(c, apperrors.InternalError("Failed to process appraisal request"))
+	}
+}
@@ -0,0 +1,455 @@
+// Package appraisal provides staff appraisal cycles and probation confirmation reviews.
+package appraisal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// Repository handles database operations for staff appraisals.
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new appraisal repository.
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// =========================================================================
+// Template Operations
+// =========================================================================
+
+// ListTemplates retrieves the tenant's appraisal templates.
+func (r *Repository) ListTemplates(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]models.AppraisalTemplate, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var templates []models.AppraisalTemplate
+	err := query.
+		Preload("Designation").
+		Preload("Goals", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Order("name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("list appraisal templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetTemplate retrieves a template with its goals.
+func (r *Repository) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*models.AppraisalTemplate, error) {
+	var template models.AppraisalTemplate
+	err := r.db.WithContext(ctx).
+		Preload("Designation").
+		Preload("Goals", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("get appraisal template: %w", err)
+	}
+	return &template, nil
+}
+
+// TemplateExistsForDesignation checks whether a template already covers a
+// designation (or is the default when designationID is nil).
+func (r *Repository) TemplateExistsForDesignation(ctx context.Context, tenantID uuid.UUID, designationID *uuid.UUID, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&models.AppraisalTemplate{}).Where("tenant_id = ?", tenantID)
+	if designationID != nil {
+		query = query.Where("designation_id = ?", *designationID)
+	} else {
+		query = query.Where("designation_id IS NULL")
+	}
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check appraisal template: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CreateTemplate creates a template with its goals.
+func (r *Repository) CreateTemplate(ctx context.Context, template *models.AppraisalTemplate) error {
+	if err := r.db.WithContext(ctx).Create(template).Error; err != nil {
+		return fmt.Errorf("create appraisal template: %w", err)
+	}
+	return nil
+}
+
+// UpdateTemplate saves a template, replacing its goals when replaceGoals is set.
+func (r *Repository) UpdateTemplate(ctx context.Context, template *models.AppraisalTemplate, replaceGoals bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Goals", "Designation").Save(template).Error; err != nil {
+			return fmt.Errorf("update appraisal template: %w", err)
+		}
+		if !replaceGoals {
+			return nil
+		}
+		if err := tx.Where("template_id = ?", template.ID).Delete(&models.AppraisalTemplateGoal{}).Error; err != nil {
+			return fmt.Errorf("delete template goals: %w", err)
+		}
+		if len(template.Goals) > 0 {
+			if err := tx.Create(&template.Goals).Error; err != nil {
+				return fmt.Errorf("create template goals: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteTemplate deletes a template and its goals.
+func (r *Repository) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", id).Delete(&models.AppraisalTemplateGoal{}).Error; err != nil {
+			return fmt.Errorf("delete template goals: %w", err)
+		}
+		result := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&models.AppraisalTemplate{})
+		if result.Error != nil {
+			return fmt.Errorf("delete appraisal template: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrTemplateNotFound
+		}
+		return nil
+	})
+}
+
+// =========================================================================
+// Cycle Operations
+// =========================================================================
+
+// ListCycles retrieves appraisal cycles, most recent first.
+func (r *Repository) ListCycles(ctx context.Context, tenantID uuid.UUID, status *models.AppraisalCycleStatus) ([]models.AppraisalCycle, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	var cycles []models.AppraisalCycle
+	if err := query.Order("period_start DESC, name ASC").Find(&cycles).Error; err != nil {
+		return nil, fmt.Errorf("list appraisal cycles: %w", err)
+	}
+	return cycles, nil
+}
+
+// GetCycle retrieves a cycle by ID.
+func (r *Repository) GetCycle(ctx context.Context, tenantID, id uuid.UUID) (*models.AppraisalCycle, error) {
+	var cycle models.AppraisalCycle
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&cycle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCycleNotFound
+		}
+		return nil, fmt.Errorf("get appraisal cycle: %w", err)
+	}
+	return &cycle, nil
+}
+
+// CreateCycle creates a cycle.
+func (r *Repository) CreateCycle(ctx context.Context, cycle *models.AppraisalCycle) error {
+	if err := r.db.WithContext(ctx).Create(cycle).Error; err != nil {
+		return fmt.Errorf("create appraisal cycle: %w", err)
+	}
+	return nil
+}
+
+// UpdateCycle saves a cycle.
+func (r *Repository) UpdateCycle(ctx context.Context, cycle *models.AppraisalCycle) error {
+	if err := r.db.WithContext(ctx).Save(cycle).Error; err != nil {
+		return fmt.Errorf("update appraisal cycle: %w", err)
+	}
+	return nil
+}
+
+// DeleteCycle deletes a cycle.
+func (r *Repository) DeleteCycle(ctx context.Context, tenantID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&models.AppraisalCycle{})
+	if result.Error != nil {
+		return fmt.Errorf("delete appraisal cycle: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCycleNotFound
+	}
+	return nil
+}
+
+// LaunchCycle marks a cycle active and creates its appraisals in one transaction.
+func (r *Repository) LaunchCycle(ctx context.Context, cycle *models.AppraisalCycle, appraisals []models.Appraisal) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(cycle).Error; err != nil {
+			return fmt.Errorf("update appraisal cycle: %w", err)
+		}
+		if len(appraisals) > 0 {
+			if err := tx.Create(&appraisals).Error; err != nil {
+				return fmt.Errorf("create appraisals: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// =========================================================================
+// Appraisal Operations
+// =========================================================================
+
+// ListAppraisals retrieves appraisals matching the filter, soonest due first.
+func (r *Repository) ListAppraisals(ctx context.Context, filter AppraisalFilter) ([]models.Appraisal, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Appraisal{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.CycleID != nil {
+		query = query.Where("cycle_id = ?", *filter.CycleID)
+	}
+	if filter.StaffID != nil {
+		query = query.Where("staff_id = ?", *filter.StaffID)
+	}
+	if filter.ManagerID != nil {
+		query = query.Where("manager_id = ?", *filter.ManagerID)
+	}
+	if filter.ReviewerID != nil {
+		query = query.Where("reviewer_id = ?", *filter.ReviewerID)
+	}
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count appraisals: %w", err)
+	}
+
+	query = query.Preload("Cycle").Preload("Staff").Preload("Manager").Preload("Reviewer").
+		Order("due_date ASC NULLS LAST, created_at DESC")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var appraisals []models.Appraisal
+	if err := query.Find(&appraisals).Error; err != nil {
+		return nil, 0, fmt.Errorf("list appraisals: %w", err)
+	}
+	return appraisals, total, nil
+}
+
+// GetAppraisal retrieves an appraisal with its goals and people.
+func (r *Repository) GetAppraisal(ctx context.Context, tenantID, id uuid.UUID) (*models.Appraisal, error) {
+	var appraisal models.Appraisal
+	err := r.db.WithContext(ctx).
+		Preload("Cycle").
+		Preload("Staff").
+		Preload("Manager").
+		Preload("Reviewer").
+		Preload("Goals", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&appraisal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAppraisalNotFound
+		}
+		return nil, fmt.Errorf("get appraisal: %w", err)
+	}
+	return &appraisal, nil
+}
+
+// SaveAppraisal saves an appraisal and its goals.
+func (r *Repository) SaveAppraisal(ctx context.Context, appraisal *models.Appraisal) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Cycle", "Staff", "Manager", "Reviewer", "Goals").Save(appraisal).Error; err != nil {
+			return fmt.Errorf("update appraisal: %w", err)
+		}
+		for i := range appraisal.Goals {
+			if err := tx.Save(&appraisal.Goals[i]).Error; err != nil {
+				return fmt.Errorf("update appraisal goal: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimSignOffWithTx marks an appraisal awaiting reviewer sign-off as
+// completed, returning ErrInvalidStatus if it has already left that step, so
+// that only one sign-off goes on to revise the salary.
+func (r *Repository) ClaimSignOffWithTx(ctx context.Context, tx *gorm.DB, appraisal *models.Appraisal) error {
+	result := tx.WithContext(ctx).Model(&models.Appraisal{}).
+		Where("tenant_id = ? AND id = ? AND status = ?", appraisal.TenantID, appraisal.ID, models.AppraisalStatusReviewerSignOff).
+		Updates(map[string]interface{}{
+			"status":            models.AppraisalStatusCompleted,
+			"reviewer_comments": appraisal.ReviewerComments,
+			"signed_off_at":     appraisal.SignedOffAt,
+			"signed_off_by":     appraisal.SignedOffBy,
+			"updated_at":        appraisal.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("claim appraisal sign-off: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// CompleteAppraisalWithTx saves a signed-off appraisal and applies its
+// probation outcome to the staff record within a transaction. staffUpdates
+// may be empty.
+func (r *Repository) CompleteAppraisalWithTx(ctx context.Context, tx *gorm.DB, appraisal *models.Appraisal, staffUpdates map[string]interface{}) error {
+	tx = tx.WithContext(ctx)
+	if err := tx.Omit("Cycle", "Staff", "Manager", "Reviewer", "Goals").Save(appraisal).Error; err != nil {
+		return fmt.Errorf("update appraisal: %w", err)
+	}
+	if len(staffUpdates) == 0 {
+		return nil
+	}
+	if err := tx.Model(&models.Staff{}).
+		Where("tenant_id = ? AND id = ?", appraisal.TenantID, appraisal.StaffID).
+		Updates(staffUpdates).Error; err != nil {
+		return fmt.Errorf("update staff probation: %w", err)
+	}
+	return nil
+}
+
+// =========================================================================
+// Staff Operations
+// =========================================================================
+
+// GetStaff retrieves a staff member by ID.
+func (r *Repository) GetStaff(ctx context.Context, tenantID, id uuid.UUID) (*models.Staff, error) {
+	var staff models.Staff
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&staff).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStaffNotFound
+		}
+		return nil, fmt.Errorf("get staff: %w", err)
+	}
+	return &staff, nil
+}
+
+// ListActiveStaff retrieves active staff, optionally in one branch.
+func (r *Repository) ListActiveStaff(ctx context.Context, tenantID uuid.UUID, branchID *uuid.UUID) ([]models.Staff, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ? AND status = ?", tenantID, models.StaffStatusActive)
+	if branchID != nil {
+		query = query.Where("branch_id = ?", *branchID)
+	}
+
+	var staff []models.Staff
+	if err := query.Order("first_name ASC, last_name ASC").Find(&staff).Error; err != nil {
+		return nil, fmt.Errorf("list active staff: %w", err)
+	}
+	return staff, nil
+}
+
+// GetReportingManagers maps each given staff ID to its reporting manager.
+func (r *Repository) GetReportingManagers(ctx context.Context, tenantID uuid.UUID, staffIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	managers := make(map[uuid.UUID]uuid.UUID)
+	if len(staffIDs) == 0 {
+		return managers, nil
+	}
+
+	var rows []struct {
+		ID                 uuid.UUID
+		ReportingManagerID uuid.UUID
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.Staff{}).
+		Select("id, reporting_manager_id").
+		Where("tenant_id = ? AND id IN ? AND reporting_manager_id IS NOT NULL", tenantID, staffIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("get reporting managers: %w", err)
+	}
+	for _, row := range rows {
+		managers[row.ID] = row.ReportingManagerID
+	}
+	return managers, nil
+}
+
+// =========================================================================
+// Probation Reviews
+// =========================================================================
+
+// ListStaffDueProbationReview retrieves active, unconfirmed staff in every
+// tenant whose probation ends on or before the given date and who have no
+// probation review for that end date yet.
+func (r *Repository) ListStaffDueProbationReview(ctx context.Context, before time.Time) ([]models.Staff, error) {
+	var staff []models.Staff
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the sweep runs outside any tenant request
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Where("status = ? AND confirmation_date IS NULL AND probation_end_date IS NOT NULL AND probation_end_date <= ?",
+			models.StaffStatusActive, before.Format("2006-01-02")).
+			Where(`NOT EXISTS (SELECT 1 FROM appraisals a WHERE a.tenant_id = staff.tenant_id AND a.staff_id = staff.id
+				AND a.type = ? AND a.due_date = staff.probation_end_date)`, models.AppraisalTypeProbation).
+			Order("probation_end_date ASC").
+			Find(&staff).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list staff due probation review: %w", err)
+	}
+	return staff, nil
+}
+
+// CreateProbationAppraisal creates a probation review outside a tenant request.
+func (r *Repository) CreateProbationAppraisal(ctx context.Context, appraisal *models.Appraisal) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		if err := tx.Create(appraisal).Error; err != nil {
+			return fmt.Errorf("create probation appraisal: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListTemplatesForSweep retrieves a tenant's active templates outside a tenant request.
+func (r *Repository) ListTemplatesForSweep(ctx context.Context, tenantID uuid.UUID) ([]models.AppraisalTemplate, error) {
+	var templates []models.AppraisalTemplate
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Preload("Goals", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+			Where("tenant_id = ? AND is_active = ?", tenantID, true).
+			Find(&templates).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list appraisal templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetReportingManagersForSweep maps staff to their reporting managers outside a tenant request.
+func (r *Repository) GetReportingManagersForSweep(ctx context.Context, tenantID uuid.UUID, staffIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	var managers map[uuid.UUID]uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		var err error
+		managers, err = (&Repository{db: tx}).GetReportingManagers(ctx, tenantID, staffIDs)
+		return err
+	})
+	return managers, err
+}
+
+// DB returns the underlying database connection.
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
@@ -0,0 +1,830 @@
+// Package appraisal provides staff appraisal cycles and probation confirmation reviews.
+package appraisal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/modules/salary"
+	"msls-backend/internal/pkg/database/models"
+)
+
+// ProbationReviewInterval is how often staff nearing the end of probation are checked for a review.
+const ProbationReviewInterval = 6 * time.Hour
+
+// ProbationReviewLeadDays is how many days before the probation end date the review is created.
+const ProbationReviewLeadDays = 30
+
+// SalaryReviser revises a staff member's salary when an appraisal approves an increment.
+type SalaryReviser interface {
+	GetCurrentStaffSalary(ctx context.Context, tenantID, staffID uuid.UUID) (*models.StaffSalary, error)
+	AssignSalaryWithTx(ctx context.Context, tx *gorm.DB, dto salary.AssignSalaryDTO) (*models.StaffSalary, error)
+}
+
+// Service handles appraisal business logic.
+type Service struct {
+	repo   *Repository
+	salary SalaryReviser
+}
+
+// NewService creates a new appraisal service.
+func NewService(repo *Repository, salary SalaryReviser) *Service {
+	return &Service{repo: repo, salary: salary}
+}
+
+// =========================================================================
+// Templates
+// =========================================================================
+
+// ListTemplates returns the tenant's appraisal templates.
+func (s *Service) ListTemplates(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]models.AppraisalTemplate, error) {
+	return s.repo.ListTemplates(ctx, tenantID, activeOnly)
+}
+
+// GetTemplate returns an appraisal template.
+func (s *Service) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*models.AppraisalTemplate, error) {
+	return s.repo.GetTemplate(ctx, tenantID, id)
+}
+
+// CreateTemplate creates an appraisal template for a designation, or the
+// tenant's default template when no designation is given.
+func (s *Service) CreateTemplate(ctx context.Context, dto CreateTemplateDTO) (*models.AppraisalTemplate, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if err := validateGoals(dto.Goals); err != nil {
+		return nil, err
+	}
+	exists, err := s.repo.TemplateExistsForDesignation(ctx, dto.TenantID, dto.DesignationID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrDuplicateTemplate
+	}
+
+	template := &models.AppraisalTemplate{
+		ID:            uuid.New(),
+		TenantID:      dto.TenantID,
+		Name:          name,
+		DesignationID: dto.DesignationID,
+		Description:   strings.TrimSpace(dto.Description),
+		IsActive:      true,
+		CreatedBy:     dto.UserID,
+		UpdatedBy:     dto.UserID,
+	}
+	template.Goals = templateGoals(template.ID, dto.Goals)
+
+	if err := s.repo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTemplate(ctx, dto.TenantID, template.ID)
+}
+
+// UpdateTemplate updates an appraisal template.
+func (s *Service) UpdateTemplate(ctx context.Context, tenantID, id uuid.UUID, dto UpdateTemplateDTO) (*models.AppraisalTemplate, error) {
+	template, err := s.repo.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, ErrNameRequired
+		}
+		template.Name = name
+	}
+	if dto.Description != nil {
+		template.Description = strings.TrimSpace(*dto.Description)
+	}
+	if dto.IsActive != nil {
+		template.IsActive = *dto.IsActive
+	}
+	replaceGoals := dto.Goals != nil
+	if replaceGoals {
+		if err := validateGoals(dto.Goals); err != nil {
+			return nil, err
+		}
+		template.Goals = templateGoals(template.ID, dto.Goals)
+	}
+	template.UpdatedBy = dto.UserID
+	template.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateTemplate(ctx, template, replaceGoals); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTemplate(ctx, tenantID, id)
+}
+
+// DeleteTemplate deletes an appraisal template. Appraisals created from it keep their goals.
+func (s *Service) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return s.repo.DeleteTemplate(ctx, tenantID, id)
+}
+
+// =========================================================================
+// Cycles
+// =========================================================================
+
+// ListCycles returns appraisal cycles, optionally with a given status.
+func (s *Service) ListCycles(ctx context.Context, tenantID uuid.UUID, status *models.AppraisalCycleStatus) ([]models.AppraisalCycle, error) {
+	return s.repo.ListCycles(ctx, tenantID, status)
+}
+
+// GetCycle returns an appraisal cycle.
+func (s *Service) GetCycle(ctx context.Context, tenantID, id uuid.UUID) (*models.AppraisalCycle, error) {
+	return s.repo.GetCycle(ctx, tenantID, id)
+}
+
+// CreateCycle creates a draft appraisal cycle.
+func (s *Service) CreateCycle(ctx context.Context, dto CreateCycleDTO) (*models.AppraisalCycle, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if dto.PeriodEnd.Before(dto.PeriodStart) {
+		return nil, ErrInvalidPeriod
+	}
+
+	cycle := &models.AppraisalCycle{
+		ID:                uuid.New(),
+		TenantID:          dto.TenantID,
+		Name:              name,
+		BranchID:          dto.BranchID,
+		PeriodStart:       dto.PeriodStart,
+		PeriodEnd:         dto.PeriodEnd,
+		SelfAssessmentDue: dto.SelfAssessmentDue,
+		ManagerReviewDue:  dto.ManagerReviewDue,
+		Status:            models.AppraisalCycleStatusDraft,
+		CreatedBy:         dto.UserID,
+		UpdatedBy:         dto.UserID,
+	}
+	if err := s.repo.CreateCycle(ctx, cycle); err != nil {
+		return nil, err
+	}
+	return cycle, nil
+}
+
+// UpdateCycle updates an appraisal cycle. The period can only change before launch.
+func (s *Service) UpdateCycle(ctx context.Context, tenantID, id uuid.UUID, dto UpdateCycleDTO) (*models.AppraisalCycle, error) {
+	cycle, err := s.repo.GetCycle(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if cycle.Status == models.AppraisalCycleStatusClosed {
+		return nil, ErrCycleClosed
+	}
+
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, ErrNameRequired
+		}
+		cycle.Name = name
+	}
+	if dto.PeriodStart != nil || dto.PeriodEnd != nil {
+		if cycle.Status != models.AppraisalCycleStatusDraft {
+			return nil, ErrCycleNotDraft
+		}
+		if dto.PeriodStart != nil {
+			cycle.PeriodStart = *dto.PeriodStart
+		}
+		if dto.PeriodEnd != nil {
+			cycle.PeriodEnd = *dto.PeriodEnd
+		}
+		if cycle.PeriodEnd.Before(cycle.PeriodStart) {
+			return nil, ErrInvalidPeriod
+		}
+	}
+	if dto.SelfAssessmentDue != nil {
+		cycle.SelfAssessmentDue = dto.SelfAssessmentDue
+	}
+	if dto.ManagerReviewDue != nil {
+		cycle.ManagerReviewDue = dto.ManagerReviewDue
+	}
+	cycle.UpdatedBy = dto.UserID
+	cycle.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateCycle(ctx, cycle); err != nil {
+		return nil, err
+	}
+	return cycle, nil
+}
+
+// DeleteCycle deletes a cycle that has not been launched.
+func (s *Service) DeleteCycle(ctx context.Context, tenantID, id uuid.UUID) error {
+	cycle, err := s.repo.GetCycle(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if cycle.Status != models.AppraisalCycleStatusDraft {
+		return ErrCycleNotDraft
+	}
+	return s.repo.DeleteCycle(ctx, tenantID, id)
+}
+
+// LaunchCycle opens a draft cycle and creates an appraisal for each active
+// staff member (in the cycle's branch, if set) from the template for their
+// designation. Staff with no template, or still on probation after the
+// cycle ends, are skipped and reported.
+func (s *Service) LaunchCycle(ctx context.Context, tenantID, id uuid.UUID, userID *uuid.UUID) (*LaunchResult, error) {
+	cycle, err := s.repo.GetCycle(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if cycle.Status != models.AppraisalCycleStatusDraft {
+		return nil, ErrCycleNotDraft
+	}
+
+	staff, err := s.repo.ListActiveStaff(ctx, tenantID, cycle.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.repo.ListTemplates(ctx, tenantID, true)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err := s.repo.GetReportingManagers(ctx, tenantID, managerIDs(staff))
+	if err != nil {
+		return nil, err
+	}
+
+	due := cycle.ManagerReviewDue
+	if due == nil {
+		due = &cycle.PeriodEnd
+	}
+
+	result := &LaunchResult{Cycle: cycle, Skipped: []SkippedStaff{}}
+	var appraisals []models.Appraisal
+	for i := range staff {
+		member := &staff[i]
+		if onProbationAfter(member, cycle.PeriodEnd) {
+			result.Skipped = append(result.Skipped, SkippedStaff{
+				StaffID: member.ID,
+				Name:    member.FullName(),
+				Reason:  "on probation until " + member.ProbationEndDate.Format("2006-01-02"),
+			})
+			continue
+		}
+		template := pickTemplate(templates, member.DesignationID)
+		if template == nil {
+			result.Skipped = append(result.Skipped, SkippedStaff{
+				StaffID: member.ID,
+				Name:    member.FullName(),
+				Reason:  "no appraisal template for designation",
+			})
+			continue
+		}
+		appraisal := newAppraisal(member, template, models.AppraisalTypeCycle, due, reviewers)
+		appraisal.CycleID = &cycle.ID
+		appraisals = append(appraisals, *appraisal)
+	}
+
+	now := time.Now()
+	cycle.Status = models.AppraisalCycleStatusActive
+	cycle.LaunchedAt = &now
+	cycle.UpdatedBy = userID
+	cycle.UpdatedAt = now
+	if err := s.repo.LaunchCycle(ctx, cycle, appraisals); err != nil {
+		return nil, err
+	}
+
+	result.Created = len(appraisals)
+	return result, nil
+}
+
+// CloseCycle closes an active cycle. Appraisals in a closed cycle can no longer change.
+func (s *Service) CloseCycle(ctx context.Context, tenantID, id uuid.UUID, userID *uuid.UUID) (*models.AppraisalCycle, error) {
+	cycle, err := s.repo.GetCycle(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch cycle.Status {
+	case models.AppraisalCycleStatusClosed:
+		return nil, ErrCycleClosed
+	case models.AppraisalCycleStatusDraft:
+		return nil, ErrCycleNotLaunched
+	}
+
+	now := time.Now()
+	cycle.Status = models.AppraisalCycleStatusClosed
+	cycle.ClosedAt = &now
+	cycle.UpdatedBy = userID
+	cycle.UpdatedAt = now
+	if err := s.repo.UpdateCycle(ctx, cycle); err != nil {
+		return nil, err
+	}
+	return cycle, nil
+}
+
+// =========================================================================
+// Appraisals
+// =========================================================================
+
+// ListAppraisals returns appraisals matching the filter.
+func (s *Service) ListAppraisals(ctx context.Context, filter AppraisalFilter) ([]models.Appraisal, int64, error) {
+	return s.repo.ListAppraisals(ctx, filter)
+}
+
+// ListStaffAppraisals returns a staff member's appraisals and probation reviews.
+func (s *Service) ListStaffAppraisals(ctx context.Context, tenantID, staffID uuid.UUID) ([]models.Appraisal, int64, error) {
+	if _, err := s.repo.GetStaff(ctx, tenantID, staffID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListAppraisals(ctx, AppraisalFilter{TenantID: tenantID, StaffID: &staffID})
+}
+
+// GetAppraisal returns an appraisal with its goals.
+func (s *Service) GetAppraisal(ctx context.Context, tenantID, id uuid.UUID) (*models.Appraisal, error) {
+	return s.repo.GetAppraisal(ctx, tenantID, id)
+}
+
+// SaveSelfAssessment records the staff member's ratings and comments. When
+// submitted, every goal must be rated and the appraisal moves to manager review.
+func (s *Service) SaveSelfAssessment(ctx context.Context, dto SelfAssessmentDTO) (*models.Appraisal, error) {
+	appraisal, err := s.repo.GetAppraisal(ctx, dto.TenantID, dto.AppraisalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStep(appraisal, models.AppraisalStatusSelfAssessment); err != nil {
+		return nil, err
+	}
+
+	if err := applyRatings(appraisal.Goals, dto.Ratings, false); err != nil {
+		return nil, err
+	}
+	if dto.Comments != nil {
+		appraisal.SelfComments = strings.TrimSpace(*dto.Comments)
+	}
+
+	if dto.Submit {
+		if !allRated(appraisal.Goals, false) {
+			return nil, ErrIncompleteRatings
+		}
+		now := time.Now()
+		appraisal.SelfSubmittedAt = &now
+		appraisal.Status = models.AppraisalStatusManagerReview
+	}
+	appraisal.UpdatedAt = time.Now()
+
+	if err := s.repo.SaveAppraisal(ctx, appraisal); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAppraisal(ctx, dto.TenantID, dto.AppraisalID)
+}
+
+// SaveManagerReview records the manager's ratings, comments and
+// recommendation. When submitted, every goal must be rated (and a probation
+// review needs an outcome); the overall rating is calculated and the
+// appraisal moves to reviewer sign-off.
+func (s *Service) SaveManagerReview(ctx context.Context, dto ManagerReviewDTO) (*models.Appraisal, error) {
+	appraisal, err := s.repo.GetAppraisal(ctx, dto.TenantID, dto.AppraisalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStep(appraisal, models.AppraisalStatusManagerReview); err != nil {
+		return nil, err
+	}
+
+	if err := applyRatings(appraisal.Goals, dto.Ratings, true); err != nil {
+		return nil, err
+	}
+	if dto.Comments != nil {
+		appraisal.ManagerComments = strings.TrimSpace(*dto.Comments)
+	}
+	if dto.ProbationOutcome != nil && appraisal.Type == models.AppraisalTypeProbation {
+		appraisal.ProbationOutcome = dto.ProbationOutcome
+	}
+	if dto.ExtendProbationTo != nil {
+		appraisal.ExtendProbationTo = dto.ExtendProbationTo
+	}
+	if dto.IncrementPercent != nil {
+		if dto.IncrementPercent.IsNegative() || dto.IncrementPercent.GreaterThan(decimal.NewFromInt(100)) {
+			return nil, ErrInvalidIncrement
+		}
+		appraisal.IncrementPercent = dto.IncrementPercent
+	}
+	if dto.IncrementEffectiveFrom != nil {
+		appraisal.IncrementEffectiveFrom = dto.IncrementEffectiveFrom
+	}
+
+	if dto.Submit {
+		if !allRated(appraisal.Goals, true) {
+			return nil, ErrIncompleteRatings
+		}
+		if err := validateProbationOutcome(appraisal); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		appraisal.OverallRating = weightedRating(appraisal.Goals)
+		appraisal.ManagerSubmittedAt = &now
+		appraisal.Status = models.AppraisalStatusReviewerSignOff
+	}
+	appraisal.UpdatedAt = time.Now()
+
+	if err := s.repo.SaveAppraisal(ctx, appraisal); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAppraisal(ctx, dto.TenantID, dto.AppraisalID)
+}
+
+// SignOff records the reviewer's decision. Sending the review back returns it
+// to the manager. Approving completes the appraisal: an increment is applied
+// as a new salary revision, and a probation outcome confirms the staff member
+// or extends their probation.
+func (s *Service) SignOff(ctx context.Context, dto SignOffDTO) (*models.Appraisal, error) {
+	appraisal, err := s.repo.GetAppraisal(ctx, dto.TenantID, dto.AppraisalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStep(appraisal, models.AppraisalStatusReviewerSignOff); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	appraisal.ReviewerComments = strings.TrimSpace(dto.Comments)
+	appraisal.UpdatedAt = now
+
+	if !dto.Approve {
+		appraisal.Status = models.AppraisalStatusManagerReview
+		appraisal.ManagerSubmittedAt = nil
+		if err := s.repo.SaveAppraisal(ctx, appraisal); err != nil {
+			return nil, err
+		}
+		return s.repo.GetAppraisal(ctx, dto.TenantID, dto.AppraisalID)
+	}
+
+	// Claim the sign-off, revise the salary and complete the appraisal in one
+	// transaction. The claim makes a concurrent sign-off fail instead of
+	// applying the increment twice, and a failure at any step leaves neither
+	// an increment nor a completed appraisal.
+	appraisal.Status = models.AppraisalStatusCompleted
+	appraisal.SignedOffAt = &now
+	appraisal.SignedOffBy = dto.UserID
+	err = s.repo.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.ClaimSignOffWithTx(ctx, tx, appraisal); err != nil {
+			return err
+		}
+
+		if appraisal.IncrementPercent != nil && appraisal.IncrementPercent.IsPositive() && !notConfirmed(appraisal) {
+			revisionID, err := s.reviseSalary(ctx, tx, appraisal, dto.UserID)
+			if err != nil {
+				return err
+			}
+			appraisal.SalaryRevisionID = &revisionID
+		}
+
+		return s.repo.CompleteAppraisalWithTx(ctx, tx, appraisal, probationUpdates(appraisal, now))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetAppraisal(ctx, dto.TenantID, dto.AppraisalID)
+}
+
+// reviseSalary creates a salary revision, within tx, raising the staff
+// member's earning components by the appraisal's increment percentage.
+func (s *Service) reviseSalary(ctx context.Context, tx *gorm.DB, appraisal *models.Appraisal, userID *uuid.UUID) (uuid.UUID, error) {
+	if s.salary == nil {
+		return uuid.Nil, ErrNoCurrentSalary
+	}
+	current, err := s.salary.GetCurrentStaffSalary(ctx, appraisal.TenantID, appraisal.StaffID)
+	if err != nil {
+		if errors.Is(err, salary.ErrStaffSalaryNotFound) {
+			return uuid.Nil, ErrNoCurrentSalary
+		}
+		return uuid.Nil, err
+	}
+
+	effectiveFrom := time.Now()
+	if appraisal.IncrementEffectiveFrom != nil {
+		effectiveFrom = *appraisal.IncrementEffectiveFrom
+	}
+	reason := incrementReason(appraisal)
+
+	revision, err := s.salary.AssignSalaryWithTx(ctx, tx, salary.AssignSalaryDTO{
+		TenantID:       appraisal.TenantID,
+		StaffID:        appraisal.StaffID,
+		StructureID:    current.StructureID,
+		EffectiveFrom:  effectiveFrom,
+		Components:     incrementComponents(current.Components, *appraisal.IncrementPercent),
+		RevisionReason: &reason,
+		CreatedBy:      userID,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("revise salary: %w", err)
+	}
+	return revision.ID, nil
+}
+
+// =========================================================================
+// Probation Reviews
+// =========================================================================
+
+// CreateDueProbationReviews creates a probation confirmation review, across
+// all tenants, for each unconfirmed staff member whose probation ends within
+// ProbationReviewLeadDays and who has no review for that end date. It
+// returns the number of reviews created.
+func (s *Service) CreateDueProbationReviews(ctx context.Context, now time.Time) (int, error) {
+	staff, err := s.repo.ListStaffDueProbationReview(ctx, now.AddDate(0, 0, ProbationReviewLeadDays))
+	if err != nil {
+		return 0, err
+	}
+
+	byTenant := make(map[uuid.UUID][]models.Staff)
+	var tenants []uuid.UUID
+	for _, member := range staff {
+		if _, ok := byTenant[member.TenantID]; !ok {
+			tenants = append(tenants, member.TenantID)
+		}
+		byTenant[member.TenantID] = append(byTenant[member.TenantID], member)
+	}
+
+	created := 0
+	var errs []error
+	for _, tenantID := range tenants {
+		members := byTenant[tenantID]
+		templates, err := s.repo.ListTemplatesForSweep(ctx, tenantID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reviewers, err := s.repo.GetReportingManagersForSweep(ctx, tenantID, managerIDs(members))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for i := range members {
+			member := &members[i]
+			appraisal := newAppraisal(member, pickTemplate(templates, member.DesignationID),
+				models.AppraisalTypeProbation, member.ProbationEndDate, reviewers)
+			if err := s.repo.CreateProbationAppraisal(ctx, appraisal); err != nil {
+				errs = append(errs, fmt.Errorf("staff %s: %w", member.ID, err))
+				continue
+			}
+			created++
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// =========================================================================
+// Helpers
+// =========================================================================
+
+// validateGoals checks that a template has goals with positive weights totalling 100.
+func validateGoals(goals []GoalDTO) error {
+	if len(goals) == 0 {
+		return ErrGoalsRequired
+	}
+	total := 0
+	for _, goal := range goals {
+		if strings.TrimSpace(goal.Title) == "" {
+			return ErrGoalsRequired
+		}
+		if goal.Weight <= 0 {
+			return ErrInvalidWeights
+		}
+		total += goal.Weight
+	}
+	if total != 100 {
+		return ErrInvalidWeights
+	}
+	return nil
+}
+
+func templateGoals(templateID uuid.UUID, goals []GoalDTO) []models.AppraisalTemplateGoal {
+	result := make([]models.AppraisalTemplateGoal, len(goals))
+	for i, goal := range goals {
+		result[i] = models.AppraisalTemplateGoal{
+			ID:           uuid.New(),
+			TemplateID:   templateID,
+			Title:        strings.TrimSpace(goal.Title),
+			Description:  strings.TrimSpace(goal.Description),
+			Weight:       goal.Weight,
+			DisplayOrder: i + 1,
+		}
+	}
+	return result
+}
+
+// pickTemplate returns the active template for a designation, falling back
+// to the default template, or nil if there is neither.
+func pickTemplate(templates []models.AppraisalTemplate, designationID *uuid.UUID) *models.AppraisalTemplate {
+	var fallback *models.AppraisalTemplate
+	for i := range templates {
+		template := &templates[i]
+		if !template.IsActive {
+			continue
+		}
+		if template.DesignationID == nil {
+			fallback = template
+			continue
+		}
+		if designationID != nil && *template.DesignationID == *designationID {
+			return template
+		}
+	}
+	return fallback
+}
+
+// newAppraisal creates an appraisal for a staff member with goals copied from
+// the template. The reviewer is the manager's own reporting manager.
+func newAppraisal(member *models.Staff, template *models.AppraisalTemplate, appraisalType models.AppraisalType,
+	due *time.Time, reviewers map[uuid.UUID]uuid.UUID) *models.Appraisal {
+	appraisal := &models.Appraisal{
+		ID:        uuid.New(),
+		TenantID:  member.TenantID,
+		StaffID:   member.ID,
+		Type:      appraisalType,
+		ManagerID: member.ReportingManagerID,
+		Status:    models.AppraisalStatusSelfAssessment,
+		DueDate:   due,
+	}
+	if member.ReportingManagerID != nil {
+		if reviewerID, ok := reviewers[*member.ReportingManagerID]; ok && reviewerID != member.ID {
+			appraisal.ReviewerID = &reviewerID
+		}
+	}
+	if template != nil {
+		appraisal.TemplateID = &template.ID
+		for _, goal := range template.Goals {
+			appraisal.Goals = append(appraisal.Goals, models.AppraisalGoal{
+				ID:           uuid.New(),
+				AppraisalID:  appraisal.ID,
+				Title:        goal.Title,
+				Description:  goal.Description,
+				Weight:       goal.Weight,
+				DisplayOrder: goal.DisplayOrder,
+			})
+		}
+	}
+	return appraisal
+}
+
+// managerIDs returns the distinct reporting managers of the given staff.
+func managerIDs(staff []models.Staff) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, member := range staff {
+		if member.ReportingManagerID != nil && !seen[*member.ReportingManagerID] {
+			seen[*member.ReportingManagerID] = true
+			ids = append(ids, *member.ReportingManagerID)
+		}
+	}
+	return ids
+}
+
+// onProbationAfter reports whether an unconfirmed staff member's probation runs past the date.
+func onProbationAfter(member *models.Staff, date time.Time) bool {
+	return member.ConfirmationDate == nil && member.ProbationEndDate != nil && member.ProbationEndDate.After(date)
+}
+
+// checkStep verifies an appraisal is open and waiting on the given step.
+func checkStep(appraisal *models.Appraisal, status models.AppraisalStatus) error {
+	if appraisal.Cycle != nil && appraisal.Cycle.Status == models.AppraisalCycleStatusClosed {
+		return ErrCycleClosed
+	}
+	if appraisal.Status != status {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// applyRatings sets the self or manager rating and comment on each rated goal.
+func applyRatings(goals []models.AppraisalGoal, ratings []GoalRatingDTO, manager bool) error {
+	index := make(map[uuid.UUID]int, len(goals))
+	for i, goal := range goals {
+		index[goal.ID] = i
+	}
+	for _, rating := range ratings {
+		i, ok := index[rating.GoalID]
+		if !ok {
+			return ErrGoalNotFound
+		}
+		if rating.Rating != nil && (*rating.Rating < 1 || *rating.Rating > 5) {
+			return ErrInvalidRating
+		}
+		comment := strings.TrimSpace(rating.Comment)
+		if manager {
+			goals[i].ManagerRating = rating.Rating
+			goals[i].ManagerComment = comment
+		} else {
+			goals[i].SelfRating = rating.Rating
+			goals[i].SelfComment = comment
+		}
+	}
+	return nil
+}
+
+// allRated reports whether every goal has a self or manager rating.
+func allRated(goals []models.AppraisalGoal, manager bool) bool {
+	for _, goal := range goals {
+		if (manager && goal.ManagerRating == nil) || (!manager && goal.SelfRating == nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// weightedRating averages the manager's ratings by goal weight, or returns
+// nil when the appraisal has no goals.
+func weightedRating(goals []models.AppraisalGoal) *decimal.Decimal {
+	totalWeight := 0
+	weighted := 0
+	for _, goal := range goals {
+		if goal.ManagerRating == nil {
+			continue
+		}
+		totalWeight += goal.Weight
+		weighted += goal.Weight * *goal.ManagerRating
+	}
+	if totalWeight == 0 {
+		return nil
+	}
+	rating := decimal.NewFromInt(int64(weighted)).Div(decimal.NewFromInt(int64(totalWeight))).Round(2)
+	return &rating
+}
+
+// validateProbationOutcome checks a probation review has an outcome, and a
+// later end date when probation is extended.
+func validateProbationOutcome(appraisal *models.Appraisal) error {
+	if appraisal.Type != models.AppraisalTypeProbation {
+		return nil
+	}
+	if appraisal.ProbationOutcome == nil || !appraisal.ProbationOutcome.IsValid() {
+		return ErrOutcomeRequired
+	}
+	if *appraisal.ProbationOutcome == models.ProbationOutcomeExtend {
+		if appraisal.ExtendProbationTo == nil {
+			return ErrInvalidExtension
+		}
+		if appraisal.DueDate != nil && !appraisal.ExtendProbationTo.After(*appraisal.DueDate) {
+			return ErrInvalidExtension
+		}
+	}
+	return nil
+}
+
+func notConfirmed(appraisal *models.Appraisal) bool {
+	return appraisal.ProbationOutcome != nil && *appraisal.ProbationOutcome == models.ProbationOutcomeNotConfirmed
+}
+
+// probationUpdates returns the staff changes for an approved probation
+// outcome: confirmation from the probation end date, or a new end date.
+// A staff member who is not confirmed is left unchanged for HR to act on.
+func probationUpdates(appraisal *models.Appraisal, now time.Time) map[string]interface{} {
+	if appraisal.Type != models.AppraisalTypeProbation || appraisal.ProbationOutcome == nil {
+		return nil
+	}
+	switch *appraisal.ProbationOutcome {
+	case models.ProbationOutcomeConfirm:
+		confirmed := now
+		if appraisal.DueDate != nil {
+			confirmed = *appraisal.DueDate
+		}
+		return map[string]interface{}{"confirmation_date": confirmed, "updated_at": now}
+	case models.ProbationOutcomeExtend:
+		return map[string]interface{}{"probation_end_date": *appraisal.ExtendProbationTo, "updated_at": now}
+	}
+	return nil
+}
+
+// incrementComponents raises earning components by the percentage;
+// deductions are carried over unchanged.
+func incrementComponents(components []models.StaffSalaryComponent, percent decimal.Decimal) []salary.StaffComponentDTO {
+	factor := decimal.NewFromInt(1).Add(percent.Div(decimal.NewFromInt(100)))
+	result := make([]salary.StaffComponentDTO, len(components))
+	for i, comp := range components {
+		amount := comp.Amount
+		if comp.Component != nil && comp.Component.ComponentType == models.ComponentTypeEarning {
+			amount = amount.Mul(factor).Round(2)
+		}
+		result[i] = salary.StaffComponentDTO{
+			ComponentID:  comp.ComponentID,
+			Amount:       amount,
+			IsOverridden: comp.IsOverridden,
+		}
+	}
+	return result
+}
+
+// incrementReason describes a salary revision made by an appraisal.
+func incrementReason(appraisal *models.Appraisal) string {
+	reason := fmt.Sprintf("Appraisal increment of %s%%", appraisal.IncrementPercent.StringFixed(2))
+	switch {
+	case appraisal.Cycle != nil:
+		reason += " (" + appraisal.Cycle.Name + ")"
+	case appraisal.Type == models.AppraisalTypeProbation:
+		reason += " (probation confirmation)"
+	}
+	return reason
+}
@@ -0,0 +1,190 @@
+package appraisal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func intPtr(i int) *int { return &i }
+
+func datePtr(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestValidateGoals(t *testing.T) {
+	assert.NoError(t, validateGoals([]GoalDTO{{Title: "Teaching", Weight: 60}, {Title: "Results", Weight: 40}}))
+	assert.ErrorIs(t, validateGoals(nil), ErrGoalsRequired)
+	assert.ErrorIs(t, validateGoals([]GoalDTO{{Title: " ", Weight: 100}}), ErrGoalsRequired)
+	assert.ErrorIs(t, validateGoals([]GoalDTO{{Title: "Teaching", Weight: 60}, {Title: "Results", Weight: 30}}), ErrInvalidWeights)
+	assert.ErrorIs(t, validateGoals([]GoalDTO{{Title: "Teaching", Weight: 110}, {Title: "Results", Weight: -10}}), ErrInvalidWeights)
+}
+
+func TestPickTemplate(t *testing.T) {
+	teacher := uuid.New()
+	clerk := uuid.New()
+	templates := []models.AppraisalTemplate{
+		{ID: uuid.New(), Name: "Default", IsActive: true},
+		{ID: uuid.New(), Name: "Teacher", DesignationID: &teacher, IsActive: true},
+		{ID: uuid.New(), Name: "Clerk", DesignationID: &clerk, IsActive: false},
+	}
+
+	assert.Equal(t, "Teacher", pickTemplate(templates, &teacher).Name)
+	assert.Equal(t, "Default", pickTemplate(templates, &clerk).Name)
+	assert.Equal(t, "Default", pickTemplate(templates, nil).Name)
+	assert.Nil(t, pickTemplate(templates[1:], &clerk))
+}
+
+func TestNewAppraisal(t *testing.T) {
+	managerID := uuid.New()
+	reviewerID := uuid.New()
+	member := &models.Staff{ID: uuid.New(), TenantID: uuid.New(), ReportingManagerID: &managerID}
+	template := &models.AppraisalTemplate{
+		ID: uuid.New(),
+		Goals: []models.AppraisalTemplateGoal{
+			{Title: "Teaching", Weight: 70, DisplayOrder: 1},
+			{Title: "Duties", Weight: 30, DisplayOrder: 2},
+		},
+	}
+	due := datePtr(2027, 3, 31)
+
+	appraisal := newAppraisal(member, template, models.AppraisalTypeCycle, due, map[uuid.UUID]uuid.UUID{managerID: reviewerID})
+	assert.Equal(t, member.ID, appraisal.StaffID)
+	assert.Equal(t, models.AppraisalStatusSelfAssessment, appraisal.Status)
+	assert.Equal(t, &managerID, appraisal.ManagerID)
+	require.NotNil(t, appraisal.ReviewerID)
+	assert.Equal(t, reviewerID, *appraisal.ReviewerID)
+	assert.Equal(t, &template.ID, appraisal.TemplateID)
+	require.Len(t, appraisal.Goals, 2)
+	assert.Equal(t, appraisal.ID, appraisal.Goals[0].AppraisalID)
+	assert.Equal(t, "Duties", appraisal.Goals[1].Title)
+
+	noTemplate := newAppraisal(&models.Staff{ID: uuid.New()}, nil, models.AppraisalTypeProbation, due, nil)
+	assert.Nil(t, noTemplate.TemplateID)
+	assert.Nil(t, noTemplate.ManagerID)
+	assert.Nil(t, noTemplate.ReviewerID)
+	assert.Empty(t, noTemplate.Goals)
+}
+
+func TestApplyRatings(t *testing.T) {
+	goals := []models.AppraisalGoal{{ID: uuid.New(), Weight: 50}, {ID: uuid.New(), Weight: 50}}
+
+	require.NoError(t, applyRatings(goals, []GoalRatingDTO{{GoalID: goals[0].ID, Rating: intPtr(4), Comment: " good "}}, false))
+	assert.Equal(t, 4, *goals[0].SelfRating)
+	assert.Equal(t, "good", goals[0].SelfComment)
+	assert.Nil(t, goals[0].ManagerRating)
+	assert.False(t, allRated(goals, false))
+
+	require.NoError(t, applyRatings(goals, []GoalRatingDTO{{GoalID: goals[1].ID, Rating: intPtr(2)}}, false))
+	assert.True(t, allRated(goals, false))
+	assert.False(t, allRated(goals, true))
+
+	assert.ErrorIs(t, applyRatings(goals, []GoalRatingDTO{{GoalID: uuid.New(), Rating: intPtr(3)}}, true), ErrGoalNotFound)
+	assert.ErrorIs(t, applyRatings(goals, []GoalRatingDTO{{GoalID: goals[0].ID, Rating: intPtr(6)}}, true), ErrInvalidRating)
+}
+
+func TestWeightedRating(t *testing.T) {
+	goals := []models.AppraisalGoal{
+		{Weight: 60, ManagerRating: intPtr(4)},
+		{Weight: 30, ManagerRating: intPtr(3)},
+		{Weight: 10, ManagerRating: intPtr(5)},
+	}
+	rating := weightedRating(goals)
+	require.NotNil(t, rating)
+	assert.Equal(t, "3.80", rating.StringFixed(2))
+
+	thirds := weightedRating([]models.AppraisalGoal{{Weight: 1, ManagerRating: intPtr(4)}, {Weight: 2, ManagerRating: intPtr(5)}})
+	assert.Equal(t, "4.67", thirds.StringFixed(2))
+
+	assert.Nil(t, weightedRating(nil))
+}
+
+func TestCheckStep(t *testing.T) {
+	appraisal := &models.Appraisal{Status: models.AppraisalStatusManagerReview}
+	assert.NoError(t, checkStep(appraisal, models.AppraisalStatusManagerReview))
+	assert.ErrorIs(t, checkStep(appraisal, models.AppraisalStatusSelfAssessment), ErrInvalidStatus)
+
+	appraisal.Cycle = &models.AppraisalCycle{Status: models.AppraisalCycleStatusClosed}
+	assert.ErrorIs(t, checkStep(appraisal, models.AppraisalStatusManagerReview), ErrCycleClosed)
+}
+
+func TestValidateProbationOutcome(t *testing.T) {
+	confirm := models.ProbationOutcomeConfirm
+	extend := models.ProbationOutcomeExtend
+
+	assert.NoError(t, validateProbationOutcome(&models.Appraisal{Type: models.AppraisalTypeCycle}))
+	assert.ErrorIs(t, validateProbationOutcome(&models.Appraisal{Type: models.AppraisalTypeProbation}), ErrOutcomeRequired)
+	assert.NoError(t, validateProbationOutcome(&models.Appraisal{Type: models.AppraisalTypeProbation, ProbationOutcome: &confirm}))
+
+	due := datePtr(2026, 12, 31)
+	assert.ErrorIs(t, validateProbationOutcome(&models.Appraisal{
+		Type: models.AppraisalTypeProbation, ProbationOutcome: &extend, DueDate: due,
+	}), ErrInvalidExtension)
+	assert.ErrorIs(t, validateProbationOutcome(&models.Appraisal{
+		Type: models.AppraisalTypeProbation, ProbationOutcome: &extend, DueDate: due, ExtendProbationTo: due,
+	}), ErrInvalidExtension)
+	assert.NoError(t, validateProbationOutcome(&models.Appraisal{
+		Type: models.AppraisalTypeProbation, ProbationOutcome: &extend, DueDate: due, ExtendProbationTo: datePtr(2027, 3, 31),
+	}))
+}
+
+func TestProbationUpdates(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	due := datePtr(2026, 11, 1)
+	confirm := models.ProbationOutcomeConfirm
+	extend := models.ProbationOutcomeExtend
+	notConfirmed := models.ProbationOutcomeNotConfirmed
+
+	updates := probationUpdates(&models.Appraisal{Type: models.AppraisalTypeProbation, ProbationOutcome: &confirm, DueDate: due}, now)
+	assert.Equal(t, *due, updates["confirmation_date"])
+
+	extendTo := datePtr(2027, 2, 1)
+	updates = probationUpdates(&models.Appraisal{Type: models.AppraisalTypeProbation, ProbationOutcome: &extend, ExtendProbationTo: extendTo}, now)
+	assert.Equal(t, *extendTo, updates["probation_end_date"])
+
+	assert.Nil(t, probationUpdates(&models.Appraisal{Type: models.AppraisalTypeProbation, ProbationOutcome: &notConfirmed}, now))
+	assert.Nil(t, probationUpdates(&models.Appraisal{Type: models.AppraisalTypeCycle}, now))
+}
+
+func TestIncrementComponents(t *testing.T) {
+	basic := models.StaffSalaryComponent{
+		ComponentID: uuid.New(),
+		Amount:      decimal.NewFromInt(30000),
+		Component:   &models.SalaryComponent{ComponentType: models.ComponentTypeEarning},
+	}
+	hra := models.StaffSalaryComponent{
+		ComponentID:  uuid.New(),
+		Amount:       decimal.RequireFromString("12345.67"),
+		IsOverridden: true,
+		Component:    &models.SalaryComponent{ComponentType: models.ComponentTypeEarning},
+	}
+	pf := models.StaffSalaryComponent{
+		ComponentID: uuid.New(),
+		Amount:      decimal.NewFromInt(1800),
+		Component:   &models.SalaryComponent{ComponentType: models.ComponentTypeDeduction},
+	}
+
+	result := incrementComponents([]models.StaffSalaryComponent{basic, hra, pf}, decimal.RequireFromString("7.5"))
+	require.Len(t, result, 3)
+	assert.Equal(t, "32250.00", result[0].Amount.StringFixed(2))
+	assert.Equal(t, "13271.60", result[1].Amount.StringFixed(2))
+	assert.True(t, result[1].IsOverridden)
+	assert.Equal(t, "1800.00", result[2].Amount.StringFixed(2))
+	assert.Equal(t, pf.ComponentID, result[2].ComponentID)
+}
+
+func TestOnProbationAfter(t *testing.T) {
+	periodEnd := time.Date(2027, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, onProbationAfter(&models.Staff{ProbationEndDate: datePtr(2027, 6, 30)}, periodEnd))
+	assert.False(t, onProbationAfter(&models.Staff{ProbationEndDate: datePtr(2027, 1, 31)}, periodEnd))
+	assert.False(t, onProbationAfter(&models.Staff{ProbationEndDate: datePtr(2027, 6, 30), ConfirmationDate: datePtr(2026, 9, 1)}, periodEnd))
+	assert.False(t, onProbationAfter(&models.Staff{}, periodEnd))
+}
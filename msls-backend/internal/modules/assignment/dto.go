@@ -117,6 +117,7 @@ type WorkloadSummary struct {
 	StaffEmployeeID  string `json:"staffEmployeeId"`
 	DepartmentName   string `json:"departmentName,omitempty"`
 	TotalPeriods     int    `json:"totalPeriods"`
+	TimetablePeriods *int   `json:"timetablePeriods,omitempty"` // periods on the timetables in force on the report date
 	TotalSubjects    int    `json:"totalSubjects"`
 	TotalClasses     int    `json:"totalClasses"`
 	IsClassTeacher   bool   `json:"isClassTeacher"`
@@ -128,6 +129,7 @@ type WorkloadSummary struct {
 
 // WorkloadReportResponse represents the workload report for all teachers.
 type WorkloadReportResponse struct {
+	Date           string            `json:"date,omitempty"`
 	Teachers       []WorkloadSummary `json:"teachers"`
 	TotalTeachers  int               `json:"totalTeachers"`
 	OverAssigned   int               `json:"overAssigned"`
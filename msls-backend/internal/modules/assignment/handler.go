@@ -499,6 +499,7 @@ func (h *Handler) GetStaffAssignments(c *gin.Context) {
 // @Param X-Tenant-ID header string true "Tenant ID"
 // @Param academic_year_id query string true "Academic year ID"
 // @Param branch_id query string false "Filter by branch ID"
+// @Param date query string false "Count periods on the timetables in force on this date (YYYY-MM-DD)"
 // @Success 200 {object} response.Success{data=WorkloadReportResponse}
 // @Router /api/v1/teacher-assignments/workload [get]
 func (h *Handler) GetWorkloadReport(c *gin.Context) {
@@ -528,7 +529,17 @@ func (h *Handler) GetWorkloadReport(c *gin.Context) {
 		}
 	}
 
-	report, err := h.service.GetWorkloadReport(c.Request.Context(), tenantID, academicYearID, branchID)
+	var on *time.Time
+	if dateStr := c.Query("date"); dateStr != "" {
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid date format, use YYYY-MM-DD"))
+			return
+		}
+		on = &date
+	}
+
+	report, err := h.service.GetWorkloadReport(c.Request.Context(), tenantID, academicYearID, branchID, on)
 	if err != nil {
 		logger.Error("Failed to get workload report",
 			zap.String("tenant_id", tenantID.String()),
@@ -6,10 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"msls-backend/internal/modules/timetable"
 	"msls-backend/internal/pkg/database/models"
 )
 
@@ -312,6 +314,35 @@ func (r *Repository) GetTeachersWithWorkload(ctx context.Context, tenantID, acad
 	return summaries, nil
 }
 
+// GetTimetablePeriods counts each teacher's weekly periods on the timetable
+// versions in force on a date, keyed by staff ID. Free periods are not counted.
+func (r *Repository) GetTimetablePeriods(ctx context.Context, tenantID, academicYearID uuid.UUID, date time.Time) (map[uuid.UUID]int, error) {
+	type periodRow struct {
+		StaffID uuid.UUID `gorm:"column:staff_id"`
+		Periods int       `gorm:"column:periods"`
+	}
+
+	day := date.Format("2006-01-02")
+	var rows []periodRow
+	err := r.db.WithContext(ctx).
+		Table("timetable_entries te").
+		Select("te.staff_id, COUNT(*) AS periods").
+		Joins("JOIN timetables t ON t.id = te.timetable_id").
+		Where("te.tenant_id = ? AND te.staff_id IS NOT NULL AND te.is_free_period = false", tenantID).
+		Where("t.academic_year_id = ? AND t.id IN ("+timetable.InForceSQL+")", academicYearID, tenantID, day, day).
+		Group("te.staff_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("get timetable periods: %w", err)
+	}
+
+	periods := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		periods[row.StaffID] = row.Periods
+	}
+	return periods, nil
+}
+
 // GetUnassignedSubjects retrieves subjects without teachers for a given academic year.
 func (r *Repository) GetUnassignedSubjects(ctx context.Context, tenantID, academicYearID uuid.UUID) ([]UnassignedSubject, error) {
 	// Get all class-subjects that don't have an active assignment
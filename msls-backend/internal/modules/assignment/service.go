@@ -281,13 +281,30 @@ func (s *Service) SetClassTeacher(ctx context.Context, tenantID, classID uuid.UU
 	})
 }
 
-// GetWorkloadReport generates a workload report for all teachers.
-func (s *Service) GetWorkloadReport(ctx context.Context, tenantID, academicYearID uuid.UUID, branchID *uuid.UUID) (*WorkloadReportResponse, error) {
+// GetWorkloadReport generates a workload report for all teachers. With a
+// date, each teacher's load is also counted from the timetable versions in
+// force on that date, and the workload status is judged on that count.
+func (s *Service) GetWorkloadReport(ctx context.Context, tenantID, academicYearID uuid.UUID, branchID *uuid.UUID, on *time.Time) (*WorkloadReportResponse, error) {
 	teachers, err := s.repo.GetTeachersWithWorkload(ctx, tenantID, academicYearID, branchID)
 	if err != nil {
 		return nil, err
 	}
 
+	if on != nil {
+		periods, err := s.repo.GetTimetablePeriods(ctx, tenantID, academicYearID, *on)
+		if err != nil {
+			return nil, err
+		}
+		for i := range teachers {
+			staffID, err := uuid.Parse(teachers[i].StaffID)
+			if err != nil {
+				continue
+			}
+			count := periods[staffID]
+			teachers[i].TimetablePeriods = &count
+		}
+	}
+
 	// Get workload settings
 	var minPeriods, maxPeriods int
 	if branchID != nil {
@@ -313,10 +330,15 @@ func (s *Service) GetWorkloadReport(ctx context.Context, tenantID, academicYearI
 		teachers[i].MinPeriods = minPeriods
 		teachers[i].MaxPeriods = maxPeriods
 
-		if teachers[i].TotalPeriods > maxPeriods {
+		load := teachers[i].TotalPeriods
+		if teachers[i].TimetablePeriods != nil {
+			load = *teachers[i].TimetablePeriods
+		}
+
+		if load > maxPeriods {
 			teachers[i].WorkloadStatus = "over"
 			overAssigned++
-		} else if teachers[i].TotalPeriods < minPeriods {
+		} else if load < minPeriods {
 			teachers[i].WorkloadStatus = "under"
 			underAssigned++
 		} else {
@@ -325,13 +347,18 @@ func (s *Service) GetWorkloadReport(ctx context.Context, tenantID, academicYearI
 		}
 	}
 
-	return &WorkloadReportResponse{
+	report := &WorkloadReportResponse{
 		Teachers:       teachers,
 		TotalTeachers:  len(teachers),
 		OverAssigned:   overAssigned,
 		UnderAssigned:  underAssigned,
 		NormalAssigned: normalAssigned,
-	}, nil
+	}
+	if on != nil {
+		report.Date = on.Format("2006-01-02")
+	}
+
+	return report, nil
 }
 
 // GetUnassignedSubjects retrieves subjects without teachers for a given academic year.
@@ -118,6 +118,7 @@ type SettingsDTO struct {
 	WorkStartTime              time.Time
 	WorkEndTime                time.Time
 	LateThresholdMinutes       int
+	EarlyExitThresholdMinutes  int
 	HalfDayThresholdHours      float64
 	AllowSelfCheckout          bool
 	RequireRegularizationApproval bool
@@ -142,31 +143,38 @@ type ListFilter struct {
 type RegularizationFilter struct {
 	TenantID uuid.UUID
 	StaffID  *uuid.UUID
-	Status   *RegularizationStatus
-	DateFrom *time.Time
-	DateTo   *time.Time
-	Cursor   string
-	Limit    int
+	// ManagerID limits to requests from the manager's direct reports;
+	// SkipManagerID to requests from the reports of their direct reports.
+	ManagerID     *uuid.UUID
+	SkipManagerID *uuid.UUID
+	Status        *RegularizationStatus
+	DateFrom      *time.Time
+	DateTo        *time.Time
+	Cursor        string
+	Limit         int
 }
 
 // AttendanceResponse represents an attendance record in API responses.
 type AttendanceResponse struct {
-	ID             string `json:"id"`
-	StaffID        string `json:"staffId"`
-	StaffName      string `json:"staffName,omitempty"`
-	EmployeeID     string `json:"employeeId,omitempty"`
-	AttendanceDate string `json:"attendanceDate"`
-	Status         string `json:"status"`
-	CheckInTime    string `json:"checkInTime,omitempty"`
-	CheckOutTime   string `json:"checkOutTime,omitempty"`
-	IsLate         bool   `json:"isLate"`
-	LateMinutes    int    `json:"lateMinutes"`
-	HalfDayType    string `json:"halfDayType,omitempty"`
-	Remarks        string `json:"remarks,omitempty"`
-	MarkedBy       string `json:"markedBy,omitempty"`
-	MarkedAt       string `json:"markedAt"`
-	CreatedAt      string `json:"createdAt"`
-	UpdatedAt      string `json:"updatedAt"`
+	ID               string `json:"id"`
+	StaffID          string `json:"staffId"`
+	StaffName        string `json:"staffName,omitempty"`
+	EmployeeID       string `json:"employeeId,omitempty"`
+	AttendanceDate   string `json:"attendanceDate"`
+	Status           string `json:"status"`
+	CheckInTime      string `json:"checkInTime,omitempty"`
+	CheckOutTime     string `json:"checkOutTime,omitempty"`
+	IsLate           bool   `json:"isLate"`
+	LateMinutes      int    `json:"lateMinutes"`
+	IsEarlyExit      bool   `json:"isEarlyExit"`
+	EarlyExitMinutes int    `json:"earlyExitMinutes"`
+	ShiftID          string `json:"shiftId,omitempty"`
+	HalfDayType      string `json:"halfDayType,omitempty"`
+	Remarks          string `json:"remarks,omitempty"`
+	MarkedBy         string `json:"markedBy,omitempty"`
+	MarkedAt         string `json:"markedAt"`
+	CreatedAt        string `json:"createdAt"`
+	UpdatedAt        string `json:"updatedAt"`
 }
 
 // TodayAttendanceResponse represents today's attendance status.
@@ -179,16 +187,21 @@ type TodayAttendanceResponse struct {
 
 // AttendanceSummaryResponse represents monthly attendance summary.
 type AttendanceSummaryResponse struct {
-	Month         string `json:"month"`
-	Year          int    `json:"year"`
-	TotalDays     int    `json:"totalDays"`
-	PresentDays   int    `json:"presentDays"`
-	AbsentDays    int    `json:"absentDays"`
-	HalfDays      int    `json:"halfDays"`
-	LeaveDays     int    `json:"leaveDays"`
-	HolidayDays   int    `json:"holidayDays"`
-	LateDays      int    `json:"lateDays"`
-	TotalLateMinutes int `json:"totalLateMinutes"`
+	Month                 string     `json:"month"`
+	Year                  int        `json:"year"`
+	TotalDays             int        `json:"totalDays"`
+	PresentDays           int        `json:"presentDays"`
+	AbsentDays            int        `json:"absentDays"`
+	HalfDays              int        `json:"halfDays"`
+	LeaveDays             int        `json:"leaveDays"`
+	HolidayDays           int        `json:"holidayDays"`
+	LateDays              int        `json:"lateDays"`
+	TotalLateMinutes      int        `json:"totalLateMinutes"`
+	EarlyExitDays         int        `json:"earlyExitDays"`
+	TotalEarlyExitMinutes int        `json:"totalEarlyExitMinutes"`
+	WorkingDays           int        `json:"workingDays"`
+	ShiftID               *uuid.UUID `json:"shiftId,omitempty"`
+	ShiftName             string     `json:"shiftName,omitempty"`
 }
 
 // AttendanceListResponse represents a paginated list of attendance records.
@@ -234,6 +247,7 @@ type SettingsResponse struct {
 	WorkStartTime              string  `json:"workStartTime"`
 	WorkEndTime                string  `json:"workEndTime"`
 	LateThresholdMinutes       int     `json:"lateThresholdMinutes"`
+	EarlyExitThresholdMinutes  int     `json:"earlyExitThresholdMinutes"`
 	HalfDayThresholdHours      float64 `json:"halfDayThresholdHours"`
 	AllowSelfCheckout          bool    `json:"allowSelfCheckout"`
 	RequireRegularizationApproval bool `json:"requireRegularizationApproval"`
@@ -14,6 +14,9 @@ var (
 	// ErrSettingsNotFound is returned when attendance settings are not found.
 	ErrSettingsNotFound = errors.New("attendance settings not found")
 
+	// ErrShiftNotFound is returned when a staff member's shift is not found.
+	ErrShiftNotFound = errors.New("shift not found")
+
 	// ErrDuplicateAttendance is returned when attendance already exists for the date.
 	ErrDuplicateAttendance = errors.New("attendance already marked for this date")
 
@@ -49,6 +49,7 @@ type SettingsRequest struct {
 	WorkStartTime                 string  `json:"workStartTime" binding:"required"` // Format: HH:MM
 	WorkEndTime                   string  `json:"workEndTime" binding:"required"`   // Format: HH:MM
 	LateThresholdMinutes          int     `json:"lateThresholdMinutes" binding:"min=0,max=120"`
+	EarlyExitThresholdMinutes     int     `json:"earlyExitThresholdMinutes" binding:"min=0,max=120"`
 	HalfDayThresholdHours         float64 `json:"halfDayThresholdHours" binding:"min=0,max=12"`
 	AllowSelfCheckout             bool    `json:"allowSelfCheckout"`
 	RequireRegularizationApproval bool    `json:"requireRegularizationApproval"`
@@ -672,6 +673,8 @@ func (h *Handler) SubmitRegularization(c *gin.Context) {
 // @Security BearerAuth
 // @Param X-Tenant-ID header string true "Tenant ID"
 // @Param staff_id query string false "Filter by staff ID"
+// @Param manager_id query string false "Requests from this manager's direct reports"
+// @Param skip_manager_id query string false "Requests escalated to this manager's manager"
 // @Param status query string false "Filter by status (pending, approved, rejected)"
 // @Param date_from query string false "Start date (YYYY-MM-DD)"
 // @Param date_to query string false "End date (YYYY-MM-DD)"
@@ -698,6 +701,18 @@ func (h *Handler) ListRegularizations(c *gin.Context) {
 			filter.StaffID = &staffID
 		}
 	}
+	if managerIDStr := c.Query("manager_id"); managerIDStr != "" {
+		managerID, err := uuid.Parse(managerIDStr)
+		if err == nil {
+			filter.ManagerID = &managerID
+		}
+	}
+	if skipManagerIDStr := c.Query("skip_manager_id"); skipManagerIDStr != "" {
+		skipManagerID, err := uuid.Parse(skipManagerIDStr)
+		if err == nil {
+			filter.SkipManagerID = &skipManagerID
+		}
+	}
 
 	// Parse status filter
 	if statusStr := c.Query("status"); statusStr != "" {
@@ -902,6 +917,7 @@ func (h *Handler) GetSettings(c *gin.Context) {
 				WorkStartTime:                 "09:00",
 				WorkEndTime:                   "17:00",
 				LateThresholdMinutes:          15,
+				EarlyExitThresholdMinutes:     0,
 				HalfDayThresholdHours:         4.0,
 				AllowSelfCheckout:             true,
 				RequireRegularizationApproval: true,
@@ -967,6 +983,7 @@ func (h *Handler) UpdateSettings(c *gin.Context) {
 		WorkStartTime:                 workStartTime,
 		WorkEndTime:                   workEndTime,
 		LateThresholdMinutes:          req.LateThresholdMinutes,
+		EarlyExitThresholdMinutes:     req.EarlyExitThresholdMinutes,
 		HalfDayThresholdHours:         req.HalfDayThresholdHours,
 		AllowSelfCheckout:             req.AllowSelfCheckout,
 		RequireRegularizationApproval: req.RequireRegularizationApproval,
@@ -994,17 +1011,19 @@ func toAttendanceResponse(a *models.StaffAttendance) AttendanceResponse {
 	}
 
 	resp := AttendanceResponse{
-		ID:             a.ID.String(),
-		StaffID:        a.StaffID.String(),
-		AttendanceDate: a.AttendanceDate.Format("2006-01-02"),
-		Status:         string(a.Status),
-		IsLate:         a.IsLate,
-		LateMinutes:    a.LateMinutes,
-		HalfDayType:    halfDayType,
-		Remarks:        a.Remarks,
-		MarkedAt:       a.MarkedAt.Format(time.RFC3339),
-		CreatedAt:      a.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:      a.UpdatedAt.Format(time.RFC3339),
+		ID:               a.ID.String(),
+		StaffID:          a.StaffID.String(),
+		AttendanceDate:   a.AttendanceDate.Format("2006-01-02"),
+		Status:           string(a.Status),
+		IsLate:           a.IsLate,
+		LateMinutes:      a.LateMinutes,
+		IsEarlyExit:      a.IsEarlyExit,
+		EarlyExitMinutes: a.EarlyExitMinutes,
+		HalfDayType:      halfDayType,
+		Remarks:          a.Remarks,
+		MarkedAt:         a.MarkedAt.Format(time.RFC3339),
+		CreatedAt:        a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        a.UpdatedAt.Format(time.RFC3339),
 	}
 
 	if a.CheckInTime != nil {
@@ -1016,6 +1035,9 @@ func toAttendanceResponse(a *models.StaffAttendance) AttendanceResponse {
 	if a.MarkedBy != nil {
 		resp.MarkedBy = a.MarkedBy.String()
 	}
+	if a.ShiftID != nil {
+		resp.ShiftID = a.ShiftID.String()
+	}
 
 	// Add staff name if loaded
 	if a.Staff.ID != uuid.Nil {
@@ -1082,6 +1104,7 @@ func toSettingsResponse(s *models.StaffAttendanceSettings) SettingsResponse {
 		WorkStartTime:                 s.WorkStartTime.Format("15:04"),
 		WorkEndTime:                   s.WorkEndTime.Format("15:04"),
 		LateThresholdMinutes:          s.LateThresholdMinutes,
+		EarlyExitThresholdMinutes:     s.EarlyExitThresholdMinutes,
 		HalfDayThresholdHours:         s.HalfDayThresholdHours,
 		AllowSelfCheckout:             s.AllowSelfCheckout,
 		RequireRegularizationApproval: s.RequireRegularizationApproval,
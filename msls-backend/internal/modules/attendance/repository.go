@@ -244,6 +244,17 @@ func (r *Repository) ListRegularizations(ctx context.Context, filter Regularizat
 		query = query.Where("staff_attendance_regularization.staff_id = ?", *filter.StaffID)
 	}
 
+	if filter.ManagerID != nil {
+		query = query.Where("staff_attendance_regularization.staff_id IN (?)",
+			r.db.Model(&models.Staff{}).Select("id").Where("reporting_manager_id = ?", *filter.ManagerID))
+	}
+
+	if filter.SkipManagerID != nil {
+		query = query.Where("staff_attendance_regularization.staff_id IN (?)",
+			r.db.Model(&models.Staff{}).Select("id").Where("reporting_manager_id IN (?)",
+				r.db.Model(&models.Staff{}).Select("id").Where("reporting_manager_id = ?", *filter.SkipManagerID)))
+	}
+
 	if filter.Status != nil {
 		query = query.Where("staff_attendance_regularization.status = ?", *filter.Status)
 	}
@@ -321,6 +332,7 @@ func (r *Repository) CreateOrUpdateSettings(ctx context.Context, settings *model
 			WorkStartTime:                 settings.WorkStartTime,
 			WorkEndTime:                   settings.WorkEndTime,
 			LateThresholdMinutes:          settings.LateThresholdMinutes,
+			EarlyExitThresholdMinutes:     settings.EarlyExitThresholdMinutes,
 			HalfDayThresholdHours:         settings.HalfDayThresholdHours,
 			AllowSelfCheckout:             settings.AllowSelfCheckout,
 			RequireRegularizationApproval: settings.RequireRegularizationApproval,
@@ -333,6 +345,21 @@ func (r *Repository) CreateOrUpdateSettings(ctx context.Context, settings *model
 	return nil
 }
 
+// GetShift retrieves a shift by ID.
+func (r *Repository) GetShift(ctx context.Context, tenantID, id uuid.UUID) (*models.Shift, error) {
+	var shift models.Shift
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&shift).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShiftNotFound
+		}
+		return nil, fmt.Errorf("get shift: %w", err)
+	}
+	return &shift, nil
+}
+
 // DB returns the underlying database connection.
 func (r *Repository) DB() *gorm.DB {
 	return r.db
@@ -60,23 +60,17 @@ func (s *Service) CheckIn(ctx context.Context, dto CheckInDTO) (*models.StaffAtt
 		}
 	}
 
-	// Get branch settings for late calculation
-	settings, _ := s.repo.GetSettings(ctx, dto.TenantID, staff.BranchID)
+	// Get the staff member's shift or branch hours for late calculation
+	hours := s.getWorkHours(ctx, dto.TenantID, staff)
 
 	now := time.Now()
 	isLate := false
 	lateMinutes := 0
+	var shiftID *uuid.UUID
 
-	if settings != nil {
-		// Calculate if late
-		workStart := time.Date(now.Year(), now.Month(), now.Day(),
-			settings.WorkStartTime.Hour(), settings.WorkStartTime.Minute(), 0, 0, now.Location())
-		threshold := workStart.Add(time.Duration(settings.LateThresholdMinutes) * time.Minute)
-
-		if now.After(threshold) {
-			isLate = true
-			lateMinutes = int(now.Sub(workStart).Minutes())
-		}
+	if hours != nil {
+		isLate, lateMinutes = hours.lateBy(now)
+		shiftID = hours.ShiftID
 	}
 
 	status := models.AttendanceStatusPresent
@@ -97,6 +91,7 @@ func (s *Service) CheckIn(ctx context.Context, dto CheckInDTO) (*models.StaffAtt
 		existing.HalfDayType = halfDayType
 		existing.IsLate = isLate
 		existing.LateMinutes = lateMinutes
+		existing.ShiftID = shiftID
 		existing.Remarks = dto.Remarks
 		existing.MarkedBy = dto.MarkedBy
 		existing.MarkedAt = now
@@ -117,6 +112,7 @@ func (s *Service) CheckIn(ctx context.Context, dto CheckInDTO) (*models.StaffAtt
 		CheckInTime:    &now,
 		IsLate:         isLate,
 		LateMinutes:    lateMinutes,
+		ShiftID:        shiftID,
 		HalfDayType:    halfDayType,
 		Remarks:        dto.Remarks,
 		MarkedBy:       dto.MarkedBy,
@@ -161,6 +157,17 @@ func (s *Service) CheckOut(ctx context.Context, dto CheckOutDTO) (*models.StaffA
 
 	now := time.Now()
 	attendance.CheckOutTime = &now
+
+	// Flag an early exit against the staff member's shift or branch hours
+	if attendance.Status != models.AttendanceStatusHalfDay {
+		if staff, err := s.staffService.GetByID(ctx, dto.TenantID, dto.StaffID); err == nil {
+			if hours := s.getWorkHours(ctx, dto.TenantID, staff); hours != nil {
+				attendance.IsEarlyExit, attendance.EarlyExitMinutes = hours.earlyBy(*attendance.CheckInTime, now)
+				attendance.ShiftID = hours.ShiftID
+			}
+		}
+	}
+
 	if dto.Remarks != "" {
 		if attendance.Remarks != "" {
 			attendance.Remarks = attendance.Remarks + "; " + dto.Remarks
@@ -215,20 +222,19 @@ func (s *Service) MarkAttendance(ctx context.Context, dto MarkAttendanceDTO) (*m
 		return nil, err
 	}
 
-	// Calculate late status if present
+	// Calculate late and early-exit status if present
 	isLate := false
 	lateMinutes := 0
+	isEarlyExit := false
+	earlyExitMinutes := 0
+	var shiftID *uuid.UUID
 	if dto.Status == StatusPresent && dto.CheckInTime != nil {
-		settings, _ := s.repo.GetSettings(ctx, dto.TenantID, staff.BranchID)
-		if settings != nil {
-			workStart := time.Date(dto.CheckInTime.Year(), dto.CheckInTime.Month(), dto.CheckInTime.Day(),
-				settings.WorkStartTime.Hour(), settings.WorkStartTime.Minute(), 0, 0, dto.CheckInTime.Location())
-			threshold := workStart.Add(time.Duration(settings.LateThresholdMinutes) * time.Minute)
-
-			if dto.CheckInTime.After(threshold) {
-				isLate = true
-				lateMinutes = int(dto.CheckInTime.Sub(workStart).Minutes())
+		if hours := s.getWorkHours(ctx, dto.TenantID, staff); hours != nil {
+			isLate, lateMinutes = hours.lateBy(*dto.CheckInTime)
+			if dto.CheckOutTime != nil {
+				isEarlyExit, earlyExitMinutes = hours.earlyBy(*dto.CheckInTime, *dto.CheckOutTime)
 			}
+			shiftID = hours.ShiftID
 		}
 	}
 
@@ -248,6 +254,9 @@ func (s *Service) MarkAttendance(ctx context.Context, dto MarkAttendanceDTO) (*m
 		existing.CheckOutTime = dto.CheckOutTime
 		existing.IsLate = isLate
 		existing.LateMinutes = lateMinutes
+		existing.IsEarlyExit = isEarlyExit
+		existing.EarlyExitMinutes = earlyExitMinutes
+		existing.ShiftID = shiftID
 		existing.HalfDayType = halfDayTypePtr
 		existing.Remarks = dto.Remarks
 		existing.MarkedBy = dto.MarkedBy
@@ -262,18 +271,21 @@ func (s *Service) MarkAttendance(ctx context.Context, dto MarkAttendanceDTO) (*m
 
 	// Create new attendance record
 	attendance := &models.StaffAttendance{
-		TenantID:       dto.TenantID,
-		StaffID:        dto.StaffID,
-		AttendanceDate: date,
-		Status:         models.AttendanceStatus(dto.Status),
-		CheckInTime:    dto.CheckInTime,
-		CheckOutTime:   dto.CheckOutTime,
-		IsLate:         isLate,
-		LateMinutes:    lateMinutes,
-		HalfDayType:    halfDayTypePtr,
-		Remarks:        dto.Remarks,
-		MarkedBy:       dto.MarkedBy,
-		MarkedAt:       now,
+		TenantID:         dto.TenantID,
+		StaffID:          dto.StaffID,
+		AttendanceDate:   date,
+		Status:           models.AttendanceStatus(dto.Status),
+		CheckInTime:      dto.CheckInTime,
+		CheckOutTime:     dto.CheckOutTime,
+		IsLate:           isLate,
+		LateMinutes:      lateMinutes,
+		IsEarlyExit:      isEarlyExit,
+		EarlyExitMinutes: earlyExitMinutes,
+		ShiftID:          shiftID,
+		HalfDayType:      halfDayTypePtr,
+		Remarks:          dto.Remarks,
+		MarkedBy:         dto.MarkedBy,
+		MarkedAt:         now,
 	}
 
 	if err := s.repo.CreateAttendance(ctx, attendance); err != nil {
@@ -300,16 +312,29 @@ func (s *Service) ListAttendance(ctx context.Context, filter ListFilter) ([]mode
 }
 
 // GetMonthlySummary retrieves monthly attendance summary for a staff member.
+// Working days are those of the staff member's shift, or every day but Sunday.
 func (s *Service) GetMonthlySummary(ctx context.Context, tenantID, staffID uuid.UUID, year, month int) (*AttendanceSummaryResponse, error) {
 	attendanceList, err := s.repo.GetMonthlyAttendance(ctx, tenantID, staffID, year, month)
 	if err != nil {
 		return nil, err
 	}
 
+	var shift *models.Shift
+	if staff, err := s.staffService.GetByID(ctx, tenantID, staffID); err == nil && staff.ShiftID != nil {
+		if shift, err = s.repo.GetShift(ctx, tenantID, *staff.ShiftID); err != nil {
+			shift = nil
+		}
+	}
+
 	summary := &AttendanceSummaryResponse{
-		Month:     time.Month(month).String(),
-		Year:      year,
-		TotalDays: len(attendanceList),
+		Month:       time.Month(month).String(),
+		Year:        year,
+		TotalDays:   len(attendanceList),
+		WorkingDays: shift.WorkingDaysInMonth(year, month),
+	}
+	if shift != nil {
+		summary.ShiftID = &shift.ID
+		summary.ShiftName = shift.Name
 	}
 
 	for _, a := range attendanceList {
@@ -329,6 +354,10 @@ func (s *Service) GetMonthlySummary(ctx context.Context, tenantID, staffID uuid.
 			summary.LateDays++
 			summary.TotalLateMinutes += a.LateMinutes
 		}
+		if a.IsEarlyExit {
+			summary.EarlyExitDays++
+			summary.TotalEarlyExitMinutes += a.EarlyExitMinutes
+		}
 	}
 
 	return summary, nil
@@ -528,6 +557,7 @@ func (s *Service) UpdateSettings(ctx context.Context, dto SettingsDTO) (*models.
 		WorkStartTime:                 dto.WorkStartTime,
 		WorkEndTime:                   dto.WorkEndTime,
 		LateThresholdMinutes:          dto.LateThresholdMinutes,
+		EarlyExitThresholdMinutes:     dto.EarlyExitThresholdMinutes,
 		HalfDayThresholdHours:         dto.HalfDayThresholdHours,
 		AllowSelfCheckout:             dto.AllowSelfCheckout,
 		RequireRegularizationApproval: dto.RequireRegularizationApproval,
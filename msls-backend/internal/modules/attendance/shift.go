@@ -0,0 +1,142 @@
+// Package attendance provides staff attendance management functionality.
+package attendance
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// workHours are the hours a staff member's attendance is evaluated against:
+// those of their shift, or else the branch attendance settings.
+type workHours struct {
+	ShiftID            *uuid.UUID
+	Start              time.Duration // since midnight
+	End                time.Duration // since midnight; before Start for a shift ending after midnight
+	LateThreshold      int           // minutes
+	EarlyExitThreshold int           // minutes
+}
+
+// getWorkHours returns the work hours for a staff member, or nil if neither
+// their shift nor their branch defines any.
+func (s *Service) getWorkHours(ctx context.Context, tenantID uuid.UUID, staff *models.Staff) *workHours {
+	settings, _ := s.repo.GetSettings(ctx, tenantID, staff.BranchID)
+
+	var shift *models.Shift
+	if staff.ShiftID != nil {
+		if found, err := s.repo.GetShift(ctx, tenantID, *staff.ShiftID); err == nil {
+			shift = found
+		}
+	}
+	return resolveWorkHours(shift, settings)
+}
+
+// resolveWorkHours returns the hours of an active shift, or else those of the
+// branch settings, or nil if there are neither.
+func resolveWorkHours(shift *models.Shift, settings *models.StaffAttendanceSettings) *workHours {
+	if shift != nil && shift.IsActive {
+		if hours, ok := shiftWorkHours(shift, settings); ok {
+			return hours
+		}
+	}
+
+	if settings == nil {
+		return nil
+	}
+	return &workHours{
+		Start:              clockOf(settings.WorkStartTime),
+		End:                clockOf(settings.WorkEndTime),
+		LateThreshold:      settings.LateThresholdMinutes,
+		EarlyExitThreshold: settings.EarlyExitThresholdMinutes,
+	}
+}
+
+// shiftWorkHours returns the work hours of a shift. Thresholds the shift does
+// not set come from the branch settings, if any.
+func shiftWorkHours(shift *models.Shift, settings *models.StaffAttendanceSettings) (*workHours, bool) {
+	start, ok := parseClock(shift.StartTime)
+	if !ok {
+		return nil, false
+	}
+	end, ok := parseClock(shift.EndTime)
+	if !ok {
+		return nil, false
+	}
+
+	hours := &workHours{ShiftID: &shift.ID, Start: start, End: end}
+	if settings != nil {
+		hours.LateThreshold = settings.LateThresholdMinutes
+		hours.EarlyExitThreshold = settings.EarlyExitThresholdMinutes
+	}
+	if shift.LateThresholdMinutes != nil {
+		hours.LateThreshold = *shift.LateThresholdMinutes
+	}
+	if shift.EarlyExitThresholdMinutes != nil {
+		hours.EarlyExitThreshold = *shift.EarlyExitThresholdMinutes
+	}
+	return hours, true
+}
+
+// startFor returns the start of the working day a check-in belongs to. A
+// check-in in the early hours of a shift ending after midnight belongs to the
+// shift that started the day before.
+func (h *workHours) startFor(checkIn time.Time) time.Time {
+	start := midnight(checkIn).Add(h.Start)
+	if h.End <= h.Start && clockOf(checkIn) < h.End {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// length returns how long the working day lasts.
+func (h *workHours) length() time.Duration {
+	if h.End <= h.Start {
+		return h.End + 24*time.Hour - h.Start
+	}
+	return h.End - h.Start
+}
+
+// lateBy reports whether a check-in is past the start of work plus the late
+// threshold, and if so by how many minutes after the start.
+func (h *workHours) lateBy(checkIn time.Time) (bool, int) {
+	start := h.startFor(checkIn)
+	threshold := start.Add(time.Duration(h.LateThreshold) * time.Minute)
+	if !checkIn.After(threshold) {
+		return false, 0
+	}
+	return true, int(checkIn.Sub(start).Minutes())
+}
+
+// earlyBy reports whether a check-out is earlier than the end of work less the
+// early-exit threshold, and if so by how many minutes before the end. The
+// working day is the one started by the check-in.
+func (h *workHours) earlyBy(checkIn, checkOut time.Time) (bool, int) {
+	end := h.startFor(checkIn).Add(h.length())
+	threshold := end.Add(-time.Duration(h.EarlyExitThreshold) * time.Minute)
+	if !checkOut.Before(threshold) {
+		return false, 0
+	}
+	return true, int(end.Sub(checkOut).Minutes())
+}
+
+// parseClock parses a time of day as stored in TIME columns (HH:MM:SS) or as
+// entered (HH:MM).
+func parseClock(value string) (time.Duration, bool) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return clockOf(t), true
+		}
+	}
+	return 0, false
+}
+
+func clockOf(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,144 @@
+package attendance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2026, time.June, day, hour, minute, 0, 0, time.UTC)
+}
+
+func clock(hour, minute int) time.Duration {
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestWorkHoursLateBy(t *testing.T) {
+	day := &workHours{Start: clock(9, 0), End: clock(17, 0), LateThreshold: 10}
+	night := &workHours{Start: clock(22, 0), End: clock(6, 0), LateThreshold: 10}
+
+	tests := []struct {
+		name        string
+		hours       *workHours
+		checkIn     time.Time
+		wantLate    bool
+		wantMinutes int
+	}{
+		{"day shift early", day, at(10, 8, 50), false, 0},
+		{"day shift within threshold", day, at(10, 9, 10), false, 0},
+		{"day shift late", day, at(10, 9, 11), true, 11},
+		{"night shift within threshold", night, at(10, 22, 5), false, 0},
+		{"night shift late", night, at(10, 22, 30), true, 30},
+		{"night shift late after midnight", night, at(11, 0, 30), true, 150},
+		{"night shift early arrival", night, at(10, 21, 40), false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			late, minutes := tt.hours.lateBy(tt.checkIn)
+			assert.Equal(t, tt.wantLate, late)
+			assert.Equal(t, tt.wantMinutes, minutes)
+		})
+	}
+}
+
+func TestWorkHoursEarlyBy(t *testing.T) {
+	day := &workHours{Start: clock(9, 0), End: clock(17, 0), EarlyExitThreshold: 15}
+	night := &workHours{Start: clock(22, 0), End: clock(6, 0)}
+
+	tests := []struct {
+		name        string
+		hours       *workHours
+		checkIn     time.Time
+		checkOut    time.Time
+		wantEarly   bool
+		wantMinutes int
+	}{
+		{"day shift within threshold", day, at(10, 9, 0), at(10, 16, 45), false, 0},
+		{"day shift early", day, at(10, 9, 0), at(10, 16, 30), true, 30},
+		{"day shift overtime", day, at(10, 9, 0), at(10, 18, 0), false, 0},
+		{"night shift full", night, at(10, 22, 0), at(11, 6, 0), false, 0},
+		{"night shift early", night, at(10, 22, 0), at(11, 5, 30), true, 30},
+		{"night shift early before midnight", night, at(10, 22, 0), at(10, 23, 0), true, 420},
+		{"night shift checked in after midnight", night, at(11, 0, 30), at(11, 6, 0), false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			early, minutes := tt.hours.earlyBy(tt.checkIn, tt.checkOut)
+			assert.Equal(t, tt.wantEarly, early)
+			assert.Equal(t, tt.wantMinutes, minutes)
+		})
+	}
+}
+
+func TestResolveWorkHours(t *testing.T) {
+	settings := &models.StaffAttendanceSettings{
+		WorkStartTime:             time.Date(0, 1, 1, 8, 30, 0, 0, time.UTC),
+		WorkEndTime:               time.Date(0, 1, 1, 16, 30, 0, 0, time.UTC),
+		LateThresholdMinutes:      15,
+		EarlyExitThresholdMinutes: 5,
+	}
+	shiftID := uuid.New()
+
+	tests := []struct {
+		name     string
+		shift    *models.Shift
+		settings *models.StaffAttendanceSettings
+		want     *workHours
+	}{
+		{
+			name:     "shift thresholds",
+			shift:    &models.Shift{ID: shiftID, StartTime: "07:00:00", EndTime: "13:00:00", IsActive: true, LateThresholdMinutes: intPtr(5), EarlyExitThresholdMinutes: intPtr(0)},
+			settings: settings,
+			want:     &workHours{ShiftID: &shiftID, Start: clock(7, 0), End: clock(13, 0), LateThreshold: 5, EarlyExitThreshold: 0},
+		},
+		{
+			name:     "shift falls back to branch thresholds",
+			shift:    &models.Shift{ID: shiftID, StartTime: "22:00", EndTime: "06:00", IsActive: true},
+			settings: settings,
+			want:     &workHours{ShiftID: &shiftID, Start: clock(22, 0), End: clock(6, 0), LateThreshold: 15, EarlyExitThreshold: 5},
+		},
+		{
+			name:  "shift without branch settings",
+			shift: &models.Shift{ID: shiftID, StartTime: "07:00", EndTime: "13:00", IsActive: true},
+			want:  &workHours{ShiftID: &shiftID, Start: clock(7, 0), End: clock(13, 0)},
+		},
+		{
+			name:     "inactive shift uses branch settings",
+			shift:    &models.Shift{ID: shiftID, StartTime: "07:00", EndTime: "13:00", LateThresholdMinutes: intPtr(5)},
+			settings: settings,
+			want:     &workHours{Start: clock(8, 30), End: clock(16, 30), LateThreshold: 15, EarlyExitThreshold: 5},
+		},
+		{
+			name:     "no shift uses branch settings",
+			settings: settings,
+			want:     &workHours{Start: clock(8, 30), End: clock(16, 30), LateThreshold: 15, EarlyExitThreshold: 5},
+		},
+		{
+			name: "neither",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveWorkHours(tt.shift, tt.settings)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, *tt.want, *got)
+		})
+	}
+}
@@ -85,6 +85,7 @@ type IncidentResponse struct {
 	StudentResponse       *string                 `json:"studentResponse,omitempty"`
 	ActionTaken           string                  `json:"actionTaken"`
 	ParentMeetingRequired bool                    `json:"parentMeetingRequired"`
+	Points                int                     `json:"points"`
 	ParentNotified        bool                    `json:"parentNotified"`
 	ParentNotifiedAt      *time.Time              `json:"parentNotifiedAt,omitempty"`
 	ReportedBy            uuid.UUID               `json:"reportedBy"`
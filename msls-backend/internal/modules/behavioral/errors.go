@@ -17,4 +17,28 @@ var (
 
 	// ErrUnauthorized is returned when user doesn't have permission
 	ErrUnauthorized = errors.New("unauthorized to perform this action")
+
+	// ErrInvalidPoints is returned when a merit rule has negative points or a demerit rule positive points
+	ErrInvalidPoints = errors.New("positive recognition must earn merits and infractions must carry demerits")
+
+	// ErrThresholdNotFound is returned when a behavior threshold is not found
+	ErrThresholdNotFound = errors.New("behavior threshold not found")
+
+	// ErrInvalidThreshold is returned when a threshold's demerit points are not positive
+	ErrInvalidThreshold = errors.New("threshold demerit points must be greater than zero")
+
+	// ErrDuplicateThreshold is returned when a threshold with the same demerit points exists
+	ErrDuplicateThreshold = errors.New("a threshold with these demerit points already exists")
+
+	// ErrTermNotFound is returned when no academic term covers the requested date
+	ErrTermNotFound = errors.New("academic term not found")
+
+	// ErrStudentNotFound is returned when a student is not found
+	ErrStudentNotFound = errors.New("student not found")
+
+	// ErrHouseNotFound is returned when a house is not found
+	ErrHouseNotFound = errors.New("house not found")
+
+	// ErrDuplicateHouse is returned when a house with the same name exists
+	ErrDuplicateHouse = errors.New("a house with this name already exists")
 )
@@ -0,0 +1,196 @@
+package behavioral
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// =============================================================================
+// Points Request DTOs
+// =============================================================================
+
+// PointRuleInput sets the points for one incident type and severity
+type PointRuleInput struct {
+	IncidentType models.BehavioralIncidentType `json:"incidentType" binding:"required,oneof=positive_recognition minor_infraction major_violation"`
+	Severity     models.BehavioralSeverity     `json:"severity" binding:"required,oneof=low medium high critical"`
+	Points       int                           `json:"points"`
+}
+
+// UpdatePointRulesRequest represents a request to change the points matrix
+type UpdatePointRulesRequest struct {
+	Rules []PointRuleInput `json:"rules" binding:"required,min=1,dive"`
+}
+
+// CreateThresholdRequest represents a request to create a behavior threshold
+type CreateThresholdRequest struct {
+	Name              string `json:"name" binding:"required,max=100"`
+	DemeritPoints     int    `json:"demeritPoints"`
+	CreateFollowUp    *bool  `json:"createFollowUp"`
+	FollowUpAfterDays *int   `json:"followUpAfterDays" binding:"omitempty,min=0,max=60"`
+	NotifyGuardian    *bool  `json:"notifyGuardian"`
+	AlertClassTeacher *bool  `json:"alertClassTeacher"`
+}
+
+// UpdateThresholdRequest represents a request to update a behavior threshold
+type UpdateThresholdRequest struct {
+	Name              *string `json:"name" binding:"omitempty,max=100"`
+	DemeritPoints     *int    `json:"demeritPoints"`
+	CreateFollowUp    *bool   `json:"createFollowUp"`
+	FollowUpAfterDays *int    `json:"followUpAfterDays" binding:"omitempty,min=0,max=60"`
+	NotifyGuardian    *bool   `json:"notifyGuardian"`
+	AlertClassTeacher *bool   `json:"alertClassTeacher"`
+	IsActive          *bool   `json:"isActive"`
+}
+
+// CreateHouseRequest represents a request to create a house
+type CreateHouseRequest struct {
+	Name  string  `json:"name" binding:"required,max=100"`
+	Color *string `json:"color" binding:"omitempty,max=20"`
+}
+
+// UpdateHouseRequest represents a request to update a house
+type UpdateHouseRequest struct {
+	Name     *string `json:"name" binding:"omitempty,max=100"`
+	Color    *string `json:"color" binding:"omitempty,max=20"`
+	IsActive *bool   `json:"isActive"`
+}
+
+// AddHouseMembersRequest represents a request to assign students to a house
+type AddHouseMembersRequest struct {
+	StudentIDs []uuid.UUID `json:"studentIds" binding:"required,min=1"`
+}
+
+// =============================================================================
+// Points Response DTOs
+// =============================================================================
+
+// PointRuleResponse represents the points for one incident type and severity
+type PointRuleResponse struct {
+	IncidentType      models.BehavioralIncidentType `json:"incidentType"`
+	IncidentTypeLabel string                        `json:"incidentTypeLabel"`
+	Severity          models.BehavioralSeverity     `json:"severity"`
+	SeverityLabel     string                        `json:"severityLabel"`
+	Points            int                           `json:"points"`
+	IsDefault         bool                          `json:"isDefault"`
+}
+
+// PointRulesResponse represents the full points matrix
+type PointRulesResponse struct {
+	Rules []PointRuleResponse `json:"rules"`
+}
+
+// ThresholdResponse represents a behavior threshold in the response
+type ThresholdResponse struct {
+	ID                uuid.UUID `json:"id"`
+	Name              string    `json:"name"`
+	DemeritPoints     int       `json:"demeritPoints"`
+	CreateFollowUp    bool      `json:"createFollowUp"`
+	FollowUpAfterDays int       `json:"followUpAfterDays"`
+	NotifyGuardian    bool      `json:"notifyGuardian"`
+	AlertClassTeacher bool      `json:"alertClassTeacher"`
+	IsActive          bool      `json:"isActive"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// ThresholdListResponse represents a list of behavior thresholds
+type ThresholdListResponse struct {
+	Thresholds []ThresholdResponse `json:"thresholds"`
+}
+
+// TermResponse represents the academic term points are totalled for
+type TermResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	StartDate string    `json:"startDate"`
+	EndDate   string    `json:"endDate"`
+}
+
+// ConductGrade represents a student's conduct grade for a term
+type ConductGrade struct {
+	Grade string `json:"grade"`
+	Label string `json:"label"`
+}
+
+// ThresholdTriggerResponse represents a threshold reached by a student
+type ThresholdTriggerResponse struct {
+	ThresholdID         uuid.UUID  `json:"thresholdId"`
+	ThresholdName       string     `json:"thresholdName"`
+	DemeritTotal        int        `json:"demeritTotal"`
+	IncidentID          uuid.UUID  `json:"incidentId"`
+	FollowUpID          *uuid.UUID `json:"followUpId,omitempty"`
+	GuardianNotified    bool       `json:"guardianNotified"`
+	ClassTeacherAlerted bool       `json:"classTeacherAlerted"`
+	TriggeredAt         time.Time  `json:"triggeredAt"`
+}
+
+// StudentPointsResponse represents a student's points for a term
+type StudentPointsResponse struct {
+	StudentID       uuid.UUID                  `json:"studentId"`
+	Term            TermResponse               `json:"term"`
+	Merits          int                        `json:"merits"`
+	Demerits        int                        `json:"demerits"`
+	NetPoints       int                        `json:"netPoints"`
+	MajorViolations int                        `json:"majorViolations"`
+	ConductGrade    ConductGrade               `json:"conductGrade"`
+	Triggers        []ThresholdTriggerResponse `json:"triggers"`
+}
+
+// HouseResponse represents a house in the response
+type HouseResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Color       *string   `json:"color,omitempty"`
+	IsActive    bool      `json:"isActive"`
+	MemberCount int       `json:"memberCount"`
+}
+
+// HouseListResponse represents a list of houses
+type HouseListResponse struct {
+	Houses []HouseResponse `json:"houses"`
+}
+
+// HouseMemberResponse represents a student in a house
+type HouseMemberResponse struct {
+	StudentID       uuid.UUID `json:"studentId"`
+	StudentName     string    `json:"studentName"`
+	AdmissionNumber string    `json:"admissionNumber"`
+}
+
+// HouseMembersResponse represents the students in a house
+type HouseMembersResponse struct {
+	Members []HouseMemberResponse `json:"members"`
+}
+
+// LeaderboardEntry represents a house's standing for a term
+type LeaderboardEntry struct {
+	Rank        int       `json:"rank"`
+	HouseID     uuid.UUID `json:"houseId"`
+	HouseName   string    `json:"houseName"`
+	Color       *string   `json:"color,omitempty"`
+	MemberCount int       `json:"memberCount"`
+	Merits      int       `json:"merits"`
+	Demerits    int       `json:"demerits"`
+	NetPoints   int       `json:"netPoints"`
+}
+
+// LeaderboardResponse represents the house leaderboard for a term
+type LeaderboardResponse struct {
+	Term   TermResponse       `json:"term"`
+	Houses []LeaderboardEntry `json:"houses"`
+}
+
+// PointTotals holds a student's point totals over a date range
+type PointTotals struct {
+	Merits          int
+	Demerits        int
+	MajorViolations int
+}
+
+// NetPoints returns merits less demerits
+func (t PointTotals) NetPoints() int {
+	return t.Merits - t.Demerits
+}
@@ -0,0 +1,504 @@
+package behavioral
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/response"
+)
+
+// =============================================================================
+// Point Rule Handlers
+// =============================================================================
+
+// GetPointRules godoc
+// @Summary Get the behaviour points matrix
+// @Tags Behavioral
+// @Produce json
+// @Success 200 {object} response.Response{data=PointRulesResponse}
+// @Router /behavior-points/rules [get]
+func (h *Handler) GetPointRules(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.GetPointRules(c.Request.Context(), tenantID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// UpdatePointRules godoc
+// @Summary Update the behaviour points matrix
+// @Tags Behavioral
+// @Accept json
+// @Produce json
+// @Param request body UpdatePointRulesRequest true "Point rules"
+// @Success 200 {object} response.Response{data=PointRulesResponse}
+// @Router /behavior-points/rules [put]
+func (h *Handler) UpdatePointRules(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	var req UpdatePointRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.UpdatePointRules(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// =============================================================================
+// Threshold Handlers
+// =============================================================================
+
+// ListThresholds godoc
+// @Summary List behaviour thresholds
+// @Tags Behavioral
+// @Produce json
+// @Success 200 {object} response.Response{data=ThresholdListResponse}
+// @Router /behavior-points/thresholds [get]
+func (h *Handler) ListThresholds(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.ListThresholds(c.Request.Context(), tenantID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// CreateThreshold godoc
+// @Summary Create a behaviour threshold
+// @Tags Behavioral
+// @Accept json
+// @Produce json
+// @Param request body CreateThresholdRequest true "Threshold data"
+// @Success 201 {object} response.Response{data=ThresholdResponse}
+// @Router /behavior-points/thresholds [post]
+func (h *Handler) CreateThreshold(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	var req CreateThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.CreateThreshold(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// UpdateThreshold godoc
+// @Summary Update a behaviour threshold
+// @Tags Behavioral
+// @Accept json
+// @Produce json
+// @Param id path string true "Threshold ID"
+// @Param request body UpdateThresholdRequest true "Threshold data"
+// @Success 200 {object} response.Response{data=ThresholdResponse}
+// @Router /behavior-points/thresholds/{id} [put]
+func (h *Handler) UpdateThreshold(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	thresholdID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid threshold ID")
+		return
+	}
+
+	var req UpdateThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.UpdateThreshold(c.Request.Context(), tenantID, thresholdID, req)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// DeleteThreshold godoc
+// @Summary Delete a behaviour threshold
+// @Tags Behavioral
+// @Param id path string true "Threshold ID"
+// @Success 204 "No Content"
+// @Router /behavior-points/thresholds/{id} [delete]
+func (h *Handler) DeleteThreshold(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	thresholdID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid threshold ID")
+		return
+	}
+
+	if err := h.service.DeleteThreshold(c.Request.Context(), tenantID, thresholdID); err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// =============================================================================
+// Student Points Handlers
+// =============================================================================
+
+// GetStudentPoints godoc
+// @Summary Get a student's behaviour points and conduct grade for a term
+// @Tags Behavioral
+// @Produce json
+// @Param id path string true "Student ID"
+// @Param termId query string false "Academic term ID (defaults to the current term)"
+// @Success 200 {object} response.Response{data=StudentPointsResponse}
+// @Router /students/{id}/behavior-points [get]
+func (h *Handler) GetStudentPoints(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid student ID")
+		return
+	}
+
+	termID, err := parseTermID(c)
+	if err != nil {
+		response.BadRequest(c, "invalid term ID")
+		return
+	}
+
+	result, err := h.service.GetStudentPoints(c.Request.Context(), tenantID, studentID, termID)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// =============================================================================
+// House Handlers
+// =============================================================================
+
+// ListHouses godoc
+// @Summary List houses
+// @Tags Behavioral
+// @Produce json
+// @Success 200 {object} response.Response{data=HouseListResponse}
+// @Router /houses [get]
+func (h *Handler) ListHouses(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.ListHouses(c.Request.Context(), tenantID)
+	if err != nil {
+		response.InternalServerError(c, err.Error())
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// CreateHouse godoc
+// @Summary Create a house
+// @Tags Behavioral
+// @Accept json
+// @Produce json
+// @Param request body CreateHouseRequest true "House data"
+// @Success 201 {object} response.Response{data=HouseResponse}
+// @Router /houses [post]
+func (h *Handler) CreateHouse(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	var req CreateHouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.CreateHouse(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// UpdateHouse godoc
+// @Summary Update a house
+// @Tags Behavioral
+// @Accept json
+// @Produce json
+// @Param id path string true "House ID"
+// @Param request body UpdateHouseRequest true "House data"
+// @Success 200 {object} response.Response{data=HouseResponse}
+// @Router /houses/{id} [put]
+func (h *Handler) UpdateHouse(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	houseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid house ID")
+		return
+	}
+
+	var req UpdateHouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.UpdateHouse(c.Request.Context(), tenantID, houseID, req)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// DeleteHouse godoc
+// @Summary Delete a house
+// @Tags Behavioral
+// @Param id path string true "House ID"
+// @Success 204 "No Content"
+// @Router /houses/{id} [delete]
+func (h *Handler) DeleteHouse(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	houseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid house ID")
+		return
+	}
+
+	if err := h.service.DeleteHouse(c.Request.Context(), tenantID, houseID); err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListHouseMembers godoc
+// @Summary List the students in a house
+// @Tags Behavioral
+// @Produce json
+// @Param id path string true "House ID"
+// @Success 200 {object} response.Response{data=HouseMembersResponse}
+// @Router /houses/{id}/members [get]
+func (h *Handler) ListHouseMembers(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	houseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid house ID")
+		return
+	}
+
+	result, err := h.service.ListHouseMembers(c.Request.Context(), tenantID, houseID)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// AddHouseMembers godoc
+// @Summary Assign students to a house
+// @Tags Behavioral
+// @Accept json
+// @Produce json
+// @Param id path string true "House ID"
+// @Param request body AddHouseMembersRequest true "Student IDs"
+// @Success 200 {object} response.Response{data=HouseMembersResponse}
+// @Router /houses/{id}/members [post]
+func (h *Handler) AddHouseMembers(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	houseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid house ID")
+		return
+	}
+
+	var req AddHouseMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.AddHouseMembers(c.Request.Context(), tenantID, houseID, req)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// RemoveHouseMember godoc
+// @Summary Remove a student from a house
+// @Tags Behavioral
+// @Param id path string true "House ID"
+// @Param studentId path string true "Student ID"
+// @Success 204 "No Content"
+// @Router /houses/{id}/members/{studentId} [delete]
+func (h *Handler) RemoveHouseMember(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	houseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid house ID")
+		return
+	}
+
+	studentID, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		response.BadRequest(c, "invalid student ID")
+		return
+	}
+
+	if err := h.service.RemoveHouseMember(c.Request.Context(), tenantID, houseID, studentID); err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetHouseLeaderboard godoc
+// @Summary Rank houses by their members' behaviour points for a term
+// @Tags Behavioral
+// @Produce json
+// @Param termId query string false "Academic term ID (defaults to the current term)"
+// @Success 200 {object} response.Response{data=LeaderboardResponse}
+// @Router /houses/leaderboard [get]
+func (h *Handler) GetHouseLeaderboard(c *gin.Context) {
+	tenantID, err := h.getTenantID(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	termID, err := parseTermID(c)
+	if err != nil {
+		response.BadRequest(c, "invalid term ID")
+		return
+	}
+
+	result, err := h.service.GetHouseLeaderboard(c.Request.Context(), tenantID, termID)
+	if err != nil {
+		h.handlePointsError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// handlePointsError converts points, threshold and house errors to API errors
+func (h *Handler) handlePointsError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrThresholdNotFound), errors.Is(err, ErrHouseNotFound),
+		errors.Is(err, ErrTermNotFound), errors.Is(err, ErrStudentNotFound):
+		response.NotFound(c, err.Error())
+	case errors.Is(err, ErrDuplicateThreshold), errors.Is(err, ErrDuplicateHouse):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, ErrInvalidPoints), errors.Is(err, ErrInvalidThreshold):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, err.Error())
+	}
+}
+
+// parseTermID reads the optional termId query parameter
+func parseTermID(c *gin.Context) (*uuid.UUID, error) {
+	value := c.Query("termId")
+	if value == "" {
+		return nil, nil
+	}
+	termID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &termID, nil
+}
@@ -0,0 +1,423 @@
+package behavioral
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// =============================================================================
+// Point Rule Operations
+// =============================================================================
+
+// ListPointRules retrieves the configured point rules for a tenant
+func (r *Repository) ListPointRules(ctx context.Context, tenantID uuid.UUID) ([]models.BehaviorPointRule, error) {
+	var rules []models.BehaviorPointRule
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetPointRule retrieves the rule for an incident type and severity, or nil if none is configured
+func (r *Repository) GetPointRule(ctx context.Context, tenantID uuid.UUID, incidentType models.BehavioralIncidentType, severity models.BehavioralSeverity) (*models.BehaviorPointRule, error) {
+	var rule models.BehaviorPointRule
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND incident_type = ? AND severity = ?", tenantID, incidentType, severity).
+		First(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// SavePointRules replaces the rules for the given incident types and severities
+func (r *Repository) SavePointRules(ctx context.Context, tenantID uuid.UUID, rules []models.BehaviorPointRule) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, rule := range rules {
+			if err := tx.
+				Where("tenant_id = ? AND incident_type = ? AND severity = ?", tenantID, rule.IncidentType, rule.Severity).
+				Delete(&models.BehaviorPointRule{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&rules).Error
+	})
+}
+
+// =============================================================================
+// Threshold Operations
+// =============================================================================
+
+// ListThresholds retrieves behavior thresholds in ascending order of demerit points
+func (r *Repository) ListThresholds(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]models.BehaviorThreshold, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var thresholds []models.BehaviorThreshold
+	if err := query.Order("demerit_points ASC").Find(&thresholds).Error; err != nil {
+		return nil, err
+	}
+	return thresholds, nil
+}
+
+// GetThreshold retrieves a behavior threshold by ID
+func (r *Repository) GetThreshold(ctx context.Context, tenantID, thresholdID uuid.UUID) (*models.BehaviorThreshold, error) {
+	var threshold models.BehaviorThreshold
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, thresholdID).
+		First(&threshold).Error
+	if err != nil {
+		return nil, err
+	}
+	return &threshold, nil
+}
+
+// ThresholdExists checks whether a threshold with the given demerit points exists
+func (r *Repository) ThresholdExists(ctx context.Context, tenantID uuid.UUID, demeritPoints int, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.BehaviorThreshold{}).
+		Where("tenant_id = ? AND demerit_points = ?", tenantID, demeritPoints)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateThreshold creates a behavior threshold
+func (r *Repository) CreateThreshold(ctx context.Context, threshold *models.BehaviorThreshold) error {
+	return r.db.WithContext(ctx).Create(threshold).Error
+}
+
+// UpdateThreshold updates a behavior threshold
+func (r *Repository) UpdateThreshold(ctx context.Context, threshold *models.BehaviorThreshold) error {
+	return r.db.WithContext(ctx).Save(threshold).Error
+}
+
+// DeleteThreshold deletes a behavior threshold and its trigger history
+func (r *Repository) DeleteThreshold(ctx context.Context, tenantID, thresholdID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("tenant_id = ? AND threshold_id = ?", tenantID, thresholdID).
+			Delete(&models.BehaviorThresholdTrigger{}).Error; err != nil {
+			return err
+		}
+		return tx.
+			Where("tenant_id = ? AND id = ?", tenantID, thresholdID).
+			Delete(&models.BehaviorThreshold{}).Error
+	})
+}
+
+// ListTriggers retrieves the thresholds a student reached in a term
+func (r *Repository) ListTriggers(ctx context.Context, tenantID, studentID, termID uuid.UUID) ([]models.BehaviorThresholdTrigger, error) {
+	var triggers []models.BehaviorThresholdTrigger
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id = ? AND term_id = ?", tenantID, studentID, termID).
+		Preload("Threshold").
+		Order("triggered_at ASC").
+		Find(&triggers).Error
+	if err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
+
+// CreateTrigger records a threshold reached by a student
+func (r *Repository) CreateTrigger(ctx context.Context, trigger *models.BehaviorThresholdTrigger) error {
+	return r.db.WithContext(ctx).Create(trigger).Error
+}
+
+// MarkParentNotified records that the guardian was notified about an incident
+func (r *Repository) MarkParentNotified(ctx context.Context, tenantID, incidentID uuid.UUID, notifiedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.StudentBehavioralIncident{}).
+		Where("tenant_id = ? AND id = ?", tenantID, incidentID).
+		Updates(map[string]interface{}{
+			"parent_notified":    true,
+			"parent_notified_at": notifiedAt,
+			"updated_at":         time.Now(),
+		}).Error
+}
+
+// =============================================================================
+// Term and Totals Operations
+// =============================================================================
+
+// GetTerm retrieves an academic term by ID
+func (r *Repository) GetTerm(ctx context.Context, tenantID, termID uuid.UUID) (*models.AcademicTerm, error) {
+	var term models.AcademicTerm
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, termID).
+		First(&term).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTermNotFound
+		}
+		return nil, err
+	}
+	return &term, nil
+}
+
+// GetTermForDate retrieves the academic term covering a date, or nil if there is none
+func (r *Repository) GetTermForDate(ctx context.Context, tenantID uuid.UUID, date time.Time) (*models.AcademicTerm, error) {
+	var terms []models.AcademicTerm
+	day := date.Format("2006-01-02")
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND start_date <= ? AND end_date >= ?", tenantID, day, day).
+		Order("start_date DESC").
+		Limit(1).
+		Find(&terms).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	return &terms[0], nil
+}
+
+// SumStudentPoints totals a student's merits, demerits and major violations between two dates
+func (r *Repository) SumStudentPoints(ctx context.Context, tenantID, studentID uuid.UUID, from, to time.Time) (PointTotals, error) {
+	var totals PointTotals
+	err := r.db.WithContext(ctx).
+		Model(&models.StudentBehavioralIncident{}).
+		Select(`COALESCE(SUM(CASE WHEN points > 0 THEN points ELSE 0 END), 0) AS merits,
+			COALESCE(SUM(CASE WHEN points < 0 THEN -points ELSE 0 END), 0) AS demerits,
+			COUNT(CASE WHEN incident_type = ? THEN 1 END) AS major_violations`, models.BehavioralIncidentTypeMajorViolation).
+		Where("tenant_id = ? AND student_id = ? AND incident_date >= ? AND incident_date <= ?",
+			tenantID, studentID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// =============================================================================
+// Escalation Contacts
+// =============================================================================
+
+// GetStudent retrieves a student by ID
+func (r *Repository) GetStudent(ctx context.Context, tenantID, studentID uuid.UUID) (*models.Student, error) {
+	var student models.Student
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, studentID).
+		First(&student).Error
+	if err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+// GetPrimaryGuardian retrieves the guardian to contact for a student, or nil if none has a phone number
+func (r *Repository) GetPrimaryGuardian(ctx context.Context, tenantID, studentID uuid.UUID) (*models.StudentGuardian, error) {
+	var guardians []models.StudentGuardian
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id = ? AND phone <> ''", tenantID, studentID).
+		Order("is_primary DESC, created_at ASC").
+		Limit(1).
+		Find(&guardians).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(guardians) == 0 {
+		return nil, nil
+	}
+	return &guardians[0], nil
+}
+
+// GetClassTeacher retrieves the class teacher of the student's current section, or nil if none is assigned
+func (r *Repository) GetClassTeacher(ctx context.Context, tenantID, studentID uuid.UUID) (*models.Staff, error) {
+	var staff []models.Staff
+	err := r.db.WithContext(ctx).
+		Table("staff").
+		Joins("JOIN sections sec ON sec.class_teacher_id = staff.id").
+		Joins("JOIN student_enrollments se ON se.section_id = sec.id AND se.status = 'active'").
+		Where("se.tenant_id = ? AND se.student_id = ?", tenantID, studentID).
+		Limit(1).
+		Find(&staff).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(staff) == 0 {
+		return nil, nil
+	}
+	return &staff[0], nil
+}
+
+// =============================================================================
+// House Operations
+// =============================================================================
+
+// ListHouses retrieves the houses of a tenant in name order
+func (r *Repository) ListHouses(ctx context.Context, tenantID uuid.UUID) ([]models.House, error) {
+	var houses []models.House
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&houses).Error
+	if err != nil {
+		return nil, err
+	}
+	return houses, nil
+}
+
+// CountHouseMembers counts the members of each house, keyed by house ID
+func (r *Repository) CountHouseMembers(ctx context.Context, tenantID uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		HouseID uuid.UUID
+		Count   int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.HouseMember{}).
+		Select("house_id, COUNT(*) AS count").
+		Where("tenant_id = ?", tenantID).
+		Group("house_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[row.HouseID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetHouse retrieves a house by ID
+func (r *Repository) GetHouse(ctx context.Context, tenantID, houseID uuid.UUID) (*models.House, error) {
+	var house models.House
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, houseID).
+		First(&house).Error
+	if err != nil {
+		return nil, err
+	}
+	return &house, nil
+}
+
+// HouseNameExists checks whether a house with the given name exists
+func (r *Repository) HouseNameExists(ctx context.Context, tenantID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.House{}).
+		Where("tenant_id = ? AND LOWER(name) = LOWER(?)", tenantID, name)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateHouse creates a house
+func (r *Repository) CreateHouse(ctx context.Context, house *models.House) error {
+	return r.db.WithContext(ctx).Create(house).Error
+}
+
+// UpdateHouse updates a house
+func (r *Repository) UpdateHouse(ctx context.Context, house *models.House) error {
+	return r.db.WithContext(ctx).Save(house).Error
+}
+
+// DeleteHouse deletes a house and its memberships
+func (r *Repository) DeleteHouse(ctx context.Context, tenantID, houseID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("tenant_id = ? AND house_id = ?", tenantID, houseID).
+			Delete(&models.HouseMember{}).Error; err != nil {
+			return err
+		}
+		return tx.
+			Where("tenant_id = ? AND id = ?", tenantID, houseID).
+			Delete(&models.House{}).Error
+	})
+}
+
+// ListHouseMembers retrieves the students in a house
+func (r *Repository) ListHouseMembers(ctx context.Context, tenantID, houseID uuid.UUID) ([]models.HouseMember, error) {
+	var members []models.HouseMember
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND house_id = ?", tenantID, houseID).
+		Preload("Student").
+		Order("created_at ASC").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// CountStudents counts the given students that belong to the tenant
+func (r *Repository) CountStudents(ctx context.Context, tenantID uuid.UUID, studentIDs []uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Student{}).
+		Where("tenant_id = ? AND id IN ?", tenantID, studentIDs).
+		Count(&count).Error
+	return count, err
+}
+
+// SetHouseMembers moves the given students into a house, removing them from any other house
+func (r *Repository) SetHouseMembers(ctx context.Context, tenantID, houseID uuid.UUID, members []models.HouseMember) error {
+	studentIDs := make([]uuid.UUID, len(members))
+	for i, member := range members {
+		studentIDs[i] = member.StudentID
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("tenant_id = ? AND student_id IN ?", tenantID, studentIDs).
+			Delete(&models.HouseMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&members).Error
+	})
+}
+
+// RemoveHouseMember removes a student from a house
+func (r *Repository) RemoveHouseMember(ctx context.Context, tenantID, houseID, studentID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND house_id = ? AND student_id = ?", tenantID, houseID, studentID).
+		Delete(&models.HouseMember{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// HouseLeaderboard totals the merits and demerits of each active house's members between two dates
+func (r *Repository) HouseLeaderboard(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]LeaderboardEntry, error) {
+	var entries []LeaderboardEntry
+	err := r.db.WithContext(ctx).
+		Table("houses h").
+		Select(`h.id AS house_id, h.name AS house_name, h.color,
+			COUNT(DISTINCT hm.student_id) AS member_count,
+			COALESCE(SUM(CASE WHEN i.points > 0 THEN i.points ELSE 0 END), 0) AS merits,
+			COALESCE(SUM(CASE WHEN i.points < 0 THEN -i.points ELSE 0 END), 0) AS demerits`).
+		Joins("LEFT JOIN house_members hm ON hm.house_id = h.id").
+		Joins(`LEFT JOIN student_behavioral_incidents i ON i.student_id = hm.student_id AND i.tenant_id = h.tenant_id
+			AND i.incident_date >= ? AND i.incident_date <= ?`, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Where("h.tenant_id = ? AND h.is_active = ?", tenantID, true).
+		Group("h.id, h.name, h.color").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,783 @@
+package behavioral
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/sms"
+)
+
+// incidentTypes and severities list the points matrix in display order
+var (
+	incidentTypes = []models.BehavioralIncidentType{
+		models.BehavioralIncidentTypePositiveRecognition,
+		models.BehavioralIncidentTypeMinorInfraction,
+		models.BehavioralIncidentTypeMajorViolation,
+	}
+	severities = []models.BehavioralSeverity{
+		models.BehavioralSeverityLow,
+		models.BehavioralSeverityMedium,
+		models.BehavioralSeverityHigh,
+		models.BehavioralSeverityCritical,
+	}
+)
+
+// defaultPoints are used for any incident type and severity the tenant has not configured
+var defaultPoints = map[models.BehavioralIncidentType]map[models.BehavioralSeverity]int{
+	models.BehavioralIncidentTypePositiveRecognition: {
+		models.BehavioralSeverityLow: 1, models.BehavioralSeverityMedium: 2,
+		models.BehavioralSeverityHigh: 3, models.BehavioralSeverityCritical: 5,
+	},
+	models.BehavioralIncidentTypeMinorInfraction: {
+		models.BehavioralSeverityLow: -1, models.BehavioralSeverityMedium: -2,
+		models.BehavioralSeverityHigh: -3, models.BehavioralSeverityCritical: -5,
+	},
+	models.BehavioralIncidentTypeMajorViolation: {
+		models.BehavioralSeverityLow: -5, models.BehavioralSeverityMedium: -10,
+		models.BehavioralSeverityHigh: -15, models.BehavioralSeverityCritical: -20,
+	},
+}
+
+// =============================================================================
+// Point Rule Operations
+// =============================================================================
+
+// GetPointRules returns the full points matrix, falling back to the defaults
+func (s *Service) GetPointRules(ctx context.Context, tenantID uuid.UUID) (*PointRulesResponse, error) {
+	rules, err := s.repo.ListPointRules(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &PointRulesResponse{Rules: pointMatrix(rules)}, nil
+}
+
+// UpdatePointRules changes the points for the given incident types and severities.
+// Points already awarded to incidents are not recalculated.
+func (s *Service) UpdatePointRules(ctx context.Context, tenantID uuid.UUID, req UpdatePointRulesRequest) (*PointRulesResponse, error) {
+	rules := make([]models.BehaviorPointRule, 0, len(req.Rules))
+	seen := make(map[string]bool)
+	for _, input := range req.Rules {
+		if !validPoints(input.IncidentType, input.Points) {
+			return nil, ErrInvalidPoints
+		}
+		key := string(input.IncidentType) + "/" + string(input.Severity)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rules = append(rules, models.BehaviorPointRule{
+			ID:           uuid.Must(uuid.NewV7()),
+			TenantID:     tenantID,
+			IncidentType: input.IncidentType,
+			Severity:     input.Severity,
+			Points:       input.Points,
+		})
+	}
+
+	if err := s.repo.SavePointRules(ctx, tenantID, rules); err != nil {
+		return nil, err
+	}
+	return s.GetPointRules(ctx, tenantID)
+}
+
+// pointsFor returns the points an incident of the given type and severity is worth
+func (s *Service) pointsFor(ctx context.Context, tenantID uuid.UUID, incidentType models.BehavioralIncidentType, severity models.BehavioralSeverity) (int, error) {
+	rule, err := s.repo.GetPointRule(ctx, tenantID, incidentType, severity)
+	if err != nil {
+		return 0, err
+	}
+	if rule != nil {
+		return rule.Points, nil
+	}
+	return defaultPoints[incidentType][severity], nil
+}
+
+// validPoints reports whether points have the right sign for the incident type
+func validPoints(incidentType models.BehavioralIncidentType, points int) bool {
+	if incidentType == models.BehavioralIncidentTypePositiveRecognition {
+		return points >= 0
+	}
+	return points <= 0
+}
+
+// pointMatrix lists the points for every incident type and severity, using
+// configured rules where present and the defaults otherwise
+func pointMatrix(rules []models.BehaviorPointRule) []PointRuleResponse {
+	configured := make(map[string]int, len(rules))
+	for _, rule := range rules {
+		configured[string(rule.IncidentType)+"/"+string(rule.Severity)] = rule.Points
+	}
+
+	matrix := make([]PointRuleResponse, 0, len(incidentTypes)*len(severities))
+	for _, incidentType := range incidentTypes {
+		for _, severity := range severities {
+			points, ok := configured[string(incidentType)+"/"+string(severity)]
+			if !ok {
+				points = defaultPoints[incidentType][severity]
+			}
+			matrix = append(matrix, PointRuleResponse{
+				IncidentType:      incidentType,
+				IncidentTypeLabel: GetIncidentTypeLabel(incidentType),
+				Severity:          severity,
+				SeverityLabel:     GetSeverityLabel(severity),
+				Points:            points,
+				IsDefault:         !ok,
+			})
+		}
+	}
+	return matrix
+}
+
+// =============================================================================
+// Threshold Operations
+// =============================================================================
+
+// ListThresholds retrieves all behavior thresholds
+func (s *Service) ListThresholds(ctx context.Context, tenantID uuid.UUID) (*ThresholdListResponse, error) {
+	thresholds, err := s.repo.ListThresholds(ctx, tenantID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ThresholdListResponse{Thresholds: make([]ThresholdResponse, len(thresholds))}
+	for i := range thresholds {
+		response.Thresholds[i] = *toThresholdResponse(&thresholds[i])
+	}
+	return response, nil
+}
+
+// CreateThreshold creates a behavior threshold
+func (s *Service) CreateThreshold(ctx context.Context, tenantID uuid.UUID, req CreateThresholdRequest) (*ThresholdResponse, error) {
+	if req.DemeritPoints <= 0 {
+		return nil, ErrInvalidThreshold
+	}
+	exists, err := s.repo.ThresholdExists(ctx, tenantID, req.DemeritPoints, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrDuplicateThreshold
+	}
+
+	threshold := &models.BehaviorThreshold{
+		ID:                uuid.Must(uuid.NewV7()),
+		TenantID:          tenantID,
+		Name:              strings.TrimSpace(req.Name),
+		DemeritPoints:     req.DemeritPoints,
+		CreateFollowUp:    boolOrDefault(req.CreateFollowUp, true),
+		FollowUpAfterDays: 2,
+		NotifyGuardian:    boolOrDefault(req.NotifyGuardian, true),
+		AlertClassTeacher: boolOrDefault(req.AlertClassTeacher, true),
+		IsActive:          true,
+	}
+	if req.FollowUpAfterDays != nil {
+		threshold.FollowUpAfterDays = *req.FollowUpAfterDays
+	}
+
+	if err := s.repo.CreateThreshold(ctx, threshold); err != nil {
+		return nil, err
+	}
+	return toThresholdResponse(threshold), nil
+}
+
+// UpdateThreshold updates a behavior threshold
+func (s *Service) UpdateThreshold(ctx context.Context, tenantID, thresholdID uuid.UUID, req UpdateThresholdRequest) (*ThresholdResponse, error) {
+	threshold, err := s.repo.GetThreshold(ctx, tenantID, thresholdID)
+	if err != nil {
+		return nil, ErrThresholdNotFound
+	}
+
+	if req.DemeritPoints != nil && *req.DemeritPoints != threshold.DemeritPoints {
+		if *req.DemeritPoints <= 0 {
+			return nil, ErrInvalidThreshold
+		}
+		exists, err := s.repo.ThresholdExists(ctx, tenantID, *req.DemeritPoints, &thresholdID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrDuplicateThreshold
+		}
+		threshold.DemeritPoints = *req.DemeritPoints
+	}
+	if req.Name != nil {
+		threshold.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.CreateFollowUp != nil {
+		threshold.CreateFollowUp = *req.CreateFollowUp
+	}
+	if req.FollowUpAfterDays != nil {
+		threshold.FollowUpAfterDays = *req.FollowUpAfterDays
+	}
+	if req.NotifyGuardian != nil {
+		threshold.NotifyGuardian = *req.NotifyGuardian
+	}
+	if req.AlertClassTeacher != nil {
+		threshold.AlertClassTeacher = *req.AlertClassTeacher
+	}
+	if req.IsActive != nil {
+		threshold.IsActive = *req.IsActive
+	}
+	threshold.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateThreshold(ctx, threshold); err != nil {
+		return nil, err
+	}
+	return toThresholdResponse(threshold), nil
+}
+
+// DeleteThreshold deletes a behavior threshold
+func (s *Service) DeleteThreshold(ctx context.Context, tenantID, thresholdID uuid.UUID) error {
+	if _, err := s.repo.GetThreshold(ctx, tenantID, thresholdID); err != nil {
+		return ErrThresholdNotFound
+	}
+	return s.repo.DeleteThreshold(ctx, tenantID, thresholdID)
+}
+
+// =============================================================================
+// Escalations
+// =============================================================================
+
+// applyThresholds escalates a student whose demerits for the term of the
+// incident have reached one or more thresholds. Each threshold escalates once
+// per student per term. Failures are logged rather than returned because the
+// incident has already been recorded.
+func (s *Service) applyThresholds(ctx context.Context, incident *models.StudentBehavioralIncident) {
+	if incident.Points >= 0 {
+		return
+	}
+	if err := s.escalate(ctx, incident); err != nil {
+		logger.Warn("Failed to apply behavior thresholds",
+			zap.String("incident_id", incident.ID.String()),
+			zap.Error(err))
+	}
+}
+
+func (s *Service) escalate(ctx context.Context, incident *models.StudentBehavioralIncident) error {
+	term, err := s.repo.GetTermForDate(ctx, incident.TenantID, incident.IncidentDate)
+	if err != nil || term == nil {
+		return err
+	}
+	thresholds, err := s.repo.ListThresholds(ctx, incident.TenantID, true)
+	if err != nil || len(thresholds) == 0 {
+		return err
+	}
+	totals, err := s.repo.SumStudentPoints(ctx, incident.TenantID, incident.StudentID, term.StartDate, term.EndDate)
+	if err != nil {
+		return err
+	}
+	triggers, err := s.repo.ListTriggers(ctx, incident.TenantID, incident.StudentID, term.ID)
+	if err != nil {
+		return err
+	}
+	triggered := make(map[uuid.UUID]bool, len(triggers))
+	for _, trigger := range triggers {
+		triggered[trigger.ThresholdID] = true
+	}
+
+	reached := reachedThresholds(thresholds, totals.Demerits, triggered)
+	if len(reached) == 0 {
+		return nil
+	}
+
+	student, err := s.repo.GetStudent(ctx, incident.TenantID, incident.StudentID)
+	if err != nil {
+		return err
+	}
+	guardian, err := s.repo.GetPrimaryGuardian(ctx, incident.TenantID, incident.StudentID)
+	if err != nil {
+		return err
+	}
+	teacher, err := s.repo.GetClassTeacher(ctx, incident.TenantID, incident.StudentID)
+	if err != nil {
+		return err
+	}
+
+	// Only the highest threshold reached escalates; lower ones reached at the
+	// same time are recorded without repeating the meeting and messages.
+	highest := reached[len(reached)-1]
+	for _, threshold := range reached {
+		trigger := &models.BehaviorThresholdTrigger{
+			ID:           uuid.Must(uuid.NewV7()),
+			TenantID:     incident.TenantID,
+			ThresholdID:  threshold.ID,
+			StudentID:    incident.StudentID,
+			TermID:       term.ID,
+			IncidentID:   incident.ID,
+			DemeritTotal: totals.Demerits,
+			TriggeredAt:  time.Now(),
+		}
+		if threshold.ID == highest.ID {
+			s.runEscalation(ctx, incident, &threshold, trigger, student, guardian, teacher)
+		}
+		if err := s.repo.CreateTrigger(ctx, trigger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runEscalation schedules the follow-up meeting and sends the guardian and
+// class teacher messages configured on a threshold, recording the outcome on the trigger
+func (s *Service) runEscalation(ctx context.Context, incident *models.StudentBehavioralIncident, threshold *models.BehaviorThreshold,
+	trigger *models.BehaviorThresholdTrigger, student *models.Student, guardian *models.StudentGuardian, teacher *models.Staff) {
+	var meetingDate *time.Time
+	if threshold.CreateFollowUp {
+		followUp, err := s.createThresholdFollowUp(ctx, incident, threshold, trigger.DemeritTotal, guardian, teacher)
+		if err != nil {
+			logger.Warn("Failed to schedule behavior follow-up", zap.String("incident_id", incident.ID.String()), zap.Error(err))
+		} else {
+			trigger.FollowUpID = &followUp.ID
+			meetingDate = &followUp.ScheduledDate
+		}
+	}
+
+	if threshold.NotifyGuardian && guardian != nil {
+		body := guardianThresholdMessage(student.FullName(), threshold, trigger.DemeritTotal, meetingDate)
+		if s.sendSMS(ctx, guardian.Phone, body) {
+			trigger.GuardianNotified = true
+			if err := s.repo.MarkParentNotified(ctx, incident.TenantID, incident.ID, time.Now()); err != nil {
+				logger.Warn("Failed to mark guardian notified", zap.String("incident_id", incident.ID.String()), zap.Error(err))
+			}
+		}
+	}
+
+	if threshold.AlertClassTeacher && teacher != nil {
+		body := teacherThresholdMessage(student.FullName(), student.AdmissionNumber, threshold, trigger.DemeritTotal)
+		trigger.ClassTeacherAlerted = s.sendSMS(ctx, teacher.WorkPhone, body)
+	}
+}
+
+func (s *Service) createThresholdFollowUp(ctx context.Context, incident *models.StudentBehavioralIncident, threshold *models.BehaviorThreshold,
+	demerits int, guardian *models.StudentGuardian, teacher *models.Staff) (*models.IncidentFollowUp, error) {
+	var participants []Participant
+	if guardian != nil {
+		participants = append(participants, Participant{Name: guardian.FullName(), Role: "Guardian"})
+	}
+	if teacher != nil {
+		participants = append(participants, Participant{Name: strings.TrimSpace(teacher.FirstName + " " + teacher.LastName), Role: "Class Teacher"})
+	}
+	var participantsJSON []byte
+	if len(participants) > 0 {
+		participantsJSON, _ = json.Marshal(participants)
+	}
+
+	expected := fmt.Sprintf("Review behaviour after reaching %d demerit points this term (%s)", demerits, threshold.Name)
+	followUp := &models.IncidentFollowUp{
+		ID:               uuid.Must(uuid.NewV7()),
+		TenantID:         incident.TenantID,
+		IncidentID:       incident.ID,
+		ScheduledDate:    dateOnly(time.Now()).AddDate(0, 0, threshold.FollowUpAfterDays),
+		Participants:     participantsJSON,
+		ExpectedOutcomes: &expected,
+		Status:           models.FollowUpStatusPending,
+		CreatedBy:        &incident.ReportedBy,
+	}
+	if err := s.repo.CreateFollowUp(ctx, followUp); err != nil {
+		return nil, err
+	}
+	return followUp, nil
+}
+
+// sendSMS sends a message and reports whether it was sent
+func (s *Service) sendSMS(ctx context.Context, to, body string) bool {
+	if s.smsProvider == nil || !s.smsProvider.IsReady() || to == "" {
+		return false
+	}
+	if _, err := s.smsProvider.Send(ctx, sms.Message{To: to, Body: body}); err != nil {
+		logger.Warn("Failed to send behavior escalation SMS", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// reachedThresholds returns the thresholds at or below the demerit total that
+// have not yet been triggered, in ascending order of demerit points
+func reachedThresholds(thresholds []models.BehaviorThreshold, demerits int, triggered map[uuid.UUID]bool) []models.BehaviorThreshold {
+	var reached []models.BehaviorThreshold
+	for _, threshold := range thresholds {
+		if threshold.IsActive && demerits >= threshold.DemeritPoints && !triggered[threshold.ID] {
+			reached = append(reached, threshold)
+		}
+	}
+	sort.SliceStable(reached, func(i, j int) bool {
+		return reached[i].DemeritPoints < reached[j].DemeritPoints
+	})
+	return reached
+}
+
+// guardianThresholdMessage builds the SMS sent to the guardian when a threshold is reached
+func guardianThresholdMessage(studentName string, threshold *models.BehaviorThreshold, demerits int, meetingDate *time.Time) string {
+	body := fmt.Sprintf("%s has reached %d demerit points this term (%s).", studentName, demerits, threshold.Name)
+	if meetingDate != nil {
+		body += fmt.Sprintf(" A meeting with the school has been scheduled for %s.", meetingDate.Format("02 Jan 2006"))
+	} else {
+		body += " Please contact the school to discuss."
+	}
+	return body
+}
+
+// teacherThresholdMessage builds the SMS sent to the class teacher when a threshold is reached
+func teacherThresholdMessage(studentName, admissionNumber string, threshold *models.BehaviorThreshold, demerits int) string {
+	return fmt.Sprintf("Behaviour alert: %s (%s) has reached %d demerit points this term (%s).",
+		studentName, admissionNumber, demerits, threshold.Name)
+}
+
+// =============================================================================
+// Student Points and Conduct
+// =============================================================================
+
+// GetStudentPoints returns a student's points, conduct grade and escalations for a term.
+// When termID is nil the term covering today is used.
+func (s *Service) GetStudentPoints(ctx context.Context, tenantID, studentID uuid.UUID, termID *uuid.UUID) (*StudentPointsResponse, error) {
+	term, err := s.resolveTerm(ctx, tenantID, termID)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.repo.SumStudentPoints(ctx, tenantID, studentID, term.StartDate, term.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	triggers, err := s.repo.ListTriggers(ctx, tenantID, studentID, term.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StudentPointsResponse{
+		StudentID:       studentID,
+		Term:            toTermResponse(term),
+		Merits:          totals.Merits,
+		Demerits:        totals.Demerits,
+		NetPoints:       totals.NetPoints(),
+		MajorViolations: totals.MajorViolations,
+		ConductGrade:    conductGrade(totals),
+		Triggers:        make([]ThresholdTriggerResponse, len(triggers)),
+	}
+	for i, trigger := range triggers {
+		item := ThresholdTriggerResponse{
+			ThresholdID:         trigger.ThresholdID,
+			DemeritTotal:        trigger.DemeritTotal,
+			IncidentID:          trigger.IncidentID,
+			FollowUpID:          trigger.FollowUpID,
+			GuardianNotified:    trigger.GuardianNotified,
+			ClassTeacherAlerted: trigger.ClassTeacherAlerted,
+			TriggeredAt:         trigger.TriggeredAt,
+		}
+		if trigger.Threshold != nil {
+			item.ThresholdName = trigger.Threshold.Name
+		}
+		response.Triggers[i] = item
+	}
+	return response, nil
+}
+
+// GetConductGrade returns a student's conduct grade for a term, for report
+// cards. When termID is nil the term covering today is used.
+func (s *Service) GetConductGrade(ctx context.Context, tenantID, studentID uuid.UUID, termID *uuid.UUID) (*ConductGrade, error) {
+	term, err := s.resolveTerm(ctx, tenantID, termID)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.repo.SumStudentPoints(ctx, tenantID, studentID, term.StartDate, term.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	grade := conductGrade(totals)
+	return &grade, nil
+}
+
+// resolveTerm returns the requested term, or the term covering today
+func (s *Service) resolveTerm(ctx context.Context, tenantID uuid.UUID, termID *uuid.UUID) (*models.AcademicTerm, error) {
+	if termID != nil {
+		return s.repo.GetTerm(ctx, tenantID, *termID)
+	}
+	term, err := s.repo.GetTermForDate(ctx, tenantID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if term == nil {
+		return nil, ErrTermNotFound
+	}
+	return term, nil
+}
+
+// conductGrade grades a term's conduct from net points; any major violation
+// caps the grade at C
+func conductGrade(totals PointTotals) ConductGrade {
+	net := totals.NetPoints()
+	switch {
+	case net >= 10 && totals.MajorViolations == 0:
+		return ConductGrade{Grade: "A", Label: "Outstanding"}
+	case net >= 0 && totals.MajorViolations == 0:
+		return ConductGrade{Grade: "B", Label: "Very Good"}
+	case net >= -10:
+		return ConductGrade{Grade: "C", Label: "Good"}
+	case net >= -25:
+		return ConductGrade{Grade: "D", Label: "Satisfactory"}
+	default:
+		return ConductGrade{Grade: "E", Label: "Needs Improvement"}
+	}
+}
+
+// =============================================================================
+// House Operations
+// =============================================================================
+
+// ListHouses retrieves all houses with their member counts
+func (s *Service) ListHouses(ctx context.Context, tenantID uuid.UUID) (*HouseListResponse, error) {
+	houses, err := s.repo.ListHouses(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.CountHouseMembers(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HouseListResponse{Houses: make([]HouseResponse, len(houses))}
+	for i := range houses {
+		response.Houses[i] = *toHouseResponse(&houses[i], counts[houses[i].ID])
+	}
+	return response, nil
+}
+
+// CreateHouse creates a house
+func (s *Service) CreateHouse(ctx context.Context, tenantID uuid.UUID, req CreateHouseRequest) (*HouseResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	exists, err := s.repo.HouseNameExists(ctx, tenantID, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrDuplicateHouse
+	}
+
+	house := &models.House{
+		ID:       uuid.Must(uuid.NewV7()),
+		TenantID: tenantID,
+		Name:     name,
+		Color:    req.Color,
+		IsActive: true,
+	}
+	if err := s.repo.CreateHouse(ctx, house); err != nil {
+		return nil, err
+	}
+	return toHouseResponse(house, 0), nil
+}
+
+// UpdateHouse updates a house
+func (s *Service) UpdateHouse(ctx context.Context, tenantID, houseID uuid.UUID, req UpdateHouseRequest) (*HouseResponse, error) {
+	house, err := s.repo.GetHouse(ctx, tenantID, houseID)
+	if err != nil {
+		return nil, ErrHouseNotFound
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		exists, err := s.repo.HouseNameExists(ctx, tenantID, name, &houseID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrDuplicateHouse
+		}
+		house.Name = name
+	}
+	if req.Color != nil {
+		house.Color = req.Color
+	}
+	if req.IsActive != nil {
+		house.IsActive = *req.IsActive
+	}
+	house.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateHouse(ctx, house); err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.CountHouseMembers(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return toHouseResponse(house, counts[house.ID]), nil
+}
+
+// DeleteHouse deletes a house
+func (s *Service) DeleteHouse(ctx context.Context, tenantID, houseID uuid.UUID) error {
+	if _, err := s.repo.GetHouse(ctx, tenantID, houseID); err != nil {
+		return ErrHouseNotFound
+	}
+	return s.repo.DeleteHouse(ctx, tenantID, houseID)
+}
+
+// ListHouseMembers retrieves the students in a house
+func (s *Service) ListHouseMembers(ctx context.Context, tenantID, houseID uuid.UUID) (*HouseMembersResponse, error) {
+	if _, err := s.repo.GetHouse(ctx, tenantID, houseID); err != nil {
+		return nil, ErrHouseNotFound
+	}
+	members, err := s.repo.ListHouseMembers(ctx, tenantID, houseID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HouseMembersResponse{Members: make([]HouseMemberResponse, len(members))}
+	for i, member := range members {
+		item := HouseMemberResponse{StudentID: member.StudentID}
+		if member.Student != nil {
+			item.StudentName = member.Student.FullName()
+			item.AdmissionNumber = member.Student.AdmissionNumber
+		}
+		response.Members[i] = item
+	}
+	return response, nil
+}
+
+// AddHouseMembers assigns students to a house, moving them out of any other house
+func (s *Service) AddHouseMembers(ctx context.Context, tenantID, houseID uuid.UUID, req AddHouseMembersRequest) (*HouseMembersResponse, error) {
+	if _, err := s.repo.GetHouse(ctx, tenantID, houseID); err != nil {
+		return nil, ErrHouseNotFound
+	}
+
+	studentIDs := uniqueIDs(req.StudentIDs)
+	count, err := s.repo.CountStudents(ctx, tenantID, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if int(count) != len(studentIDs) {
+		return nil, ErrStudentNotFound
+	}
+
+	members := make([]models.HouseMember, len(studentIDs))
+	for i, studentID := range studentIDs {
+		members[i] = models.HouseMember{
+			ID:        uuid.Must(uuid.NewV7()),
+			TenantID:  tenantID,
+			HouseID:   houseID,
+			StudentID: studentID,
+		}
+	}
+	if err := s.repo.SetHouseMembers(ctx, tenantID, houseID, members); err != nil {
+		return nil, err
+	}
+	return s.ListHouseMembers(ctx, tenantID, houseID)
+}
+
+// RemoveHouseMember removes a student from a house
+func (s *Service) RemoveHouseMember(ctx context.Context, tenantID, houseID, studentID uuid.UUID) error {
+	removed, err := s.repo.RemoveHouseMember(ctx, tenantID, houseID, studentID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrHouseNotFound
+	}
+	return nil
+}
+
+// GetHouseLeaderboard ranks active houses by their members' net points for a term.
+// When termID is nil the term covering today is used.
+func (s *Service) GetHouseLeaderboard(ctx context.Context, tenantID uuid.UUID, termID *uuid.UUID) (*LeaderboardResponse, error) {
+	term, err := s.resolveTerm(ctx, tenantID, termID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.HouseLeaderboard(ctx, tenantID, term.StartDate, term.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	return &LeaderboardResponse{Term: toTermResponse(term), Houses: rankHouses(entries)}, nil
+}
+
+// rankHouses orders houses by net points, then merits, then name, giving
+// tied houses the same rank
+func rankHouses(entries []LeaderboardEntry) []LeaderboardEntry {
+	for i := range entries {
+		entries[i].NetPoints = entries[i].Merits - entries[i].Demerits
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.NetPoints != b.NetPoints {
+			return a.NetPoints > b.NetPoints
+		}
+		if a.Merits != b.Merits {
+			return a.Merits > b.Merits
+		}
+		return strings.ToLower(a.HouseName) < strings.ToLower(b.HouseName)
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && entries[i].NetPoints == entries[i-1].NetPoints && entries[i].Merits == entries[i-1].Merits {
+			entries[i].Rank = entries[i-1].Rank
+		}
+	}
+	return entries
+}
+
+// =============================================================================
+// Points Helpers
+// =============================================================================
+
+func toThresholdResponse(threshold *models.BehaviorThreshold) *ThresholdResponse {
+	return &ThresholdResponse{
+		ID:                threshold.ID,
+		Name:              threshold.Name,
+		DemeritPoints:     threshold.DemeritPoints,
+		CreateFollowUp:    threshold.CreateFollowUp,
+		FollowUpAfterDays: threshold.FollowUpAfterDays,
+		NotifyGuardian:    threshold.NotifyGuardian,
+		AlertClassTeacher: threshold.AlertClassTeacher,
+		IsActive:          threshold.IsActive,
+		CreatedAt:         threshold.CreatedAt,
+		UpdatedAt:         threshold.UpdatedAt,
+	}
+}
+
+func toHouseResponse(house *models.House, memberCount int) *HouseResponse {
+	return &HouseResponse{
+		ID:          house.ID,
+		Name:        house.Name,
+		Color:       house.Color,
+		IsActive:    house.IsActive,
+		MemberCount: memberCount,
+	}
+}
+
+func toTermResponse(term *models.AcademicTerm) TermResponse {
+	return TermResponse{
+		ID:        term.ID,
+		Name:      term.Name,
+		StartDate: term.StartDate.Format("2006-01-02"),
+		EndDate:   term.EndDate.Format("2006-01-02"),
+	}
+}
+
+func boolOrDefault(value *bool, fallback bool) bool {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,116 @@
+package behavioral
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func TestPointMatrix_DefaultsAndOverrides(t *testing.T) {
+	rules := []models.BehaviorPointRule{
+		{IncidentType: models.BehavioralIncidentTypeMajorViolation, Severity: models.BehavioralSeverityHigh, Points: -25},
+	}
+
+	matrix := pointMatrix(rules)
+	assert.Len(t, matrix, 12)
+
+	for _, rule := range matrix {
+		if rule.IncidentType == models.BehavioralIncidentTypeMajorViolation && rule.Severity == models.BehavioralSeverityHigh {
+			assert.Equal(t, -25, rule.Points)
+			assert.False(t, rule.IsDefault)
+			continue
+		}
+		assert.True(t, rule.IsDefault)
+		assert.Equal(t, defaultPoints[rule.IncidentType][rule.Severity], rule.Points)
+	}
+}
+
+func TestValidPoints(t *testing.T) {
+	assert.True(t, validPoints(models.BehavioralIncidentTypePositiveRecognition, 3))
+	assert.False(t, validPoints(models.BehavioralIncidentTypePositiveRecognition, -1))
+	assert.True(t, validPoints(models.BehavioralIncidentTypeMinorInfraction, -2))
+	assert.False(t, validPoints(models.BehavioralIncidentTypeMajorViolation, 5))
+	assert.True(t, validPoints(models.BehavioralIncidentTypeMajorViolation, 0))
+}
+
+func TestReachedThresholds(t *testing.T) {
+	warning := models.BehaviorThreshold{ID: uuid.New(), DemeritPoints: 10, IsActive: true}
+	serious := models.BehaviorThreshold{ID: uuid.New(), DemeritPoints: 20, IsActive: true}
+	inactive := models.BehaviorThreshold{ID: uuid.New(), DemeritPoints: 5, IsActive: false}
+	severe := models.BehaviorThreshold{ID: uuid.New(), DemeritPoints: 40, IsActive: true}
+	thresholds := []models.BehaviorThreshold{serious, inactive, severe, warning}
+
+	t.Run("returns reached thresholds in ascending order", func(t *testing.T) {
+		reached := reachedThresholds(thresholds, 25, nil)
+		assert.Len(t, reached, 2)
+		assert.Equal(t, warning.ID, reached[0].ID)
+		assert.Equal(t, serious.ID, reached[1].ID)
+	})
+
+	t.Run("skips thresholds already triggered this term", func(t *testing.T) {
+		reached := reachedThresholds(thresholds, 25, map[uuid.UUID]bool{warning.ID: true})
+		assert.Len(t, reached, 1)
+		assert.Equal(t, serious.ID, reached[0].ID)
+	})
+
+	t.Run("nothing reached below the lowest threshold", func(t *testing.T) {
+		assert.Empty(t, reachedThresholds(thresholds, 9, nil))
+	})
+}
+
+func TestConductGrade(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals PointTotals
+		grade  string
+	}{
+		{"outstanding", PointTotals{Merits: 12}, "A"},
+		{"very good", PointTotals{Merits: 4, Demerits: 2}, "B"},
+		{"major violation caps grade", PointTotals{Merits: 30, Demerits: 5, MajorViolations: 1}, "C"},
+		{"good", PointTotals{Demerits: 10}, "C"},
+		{"satisfactory", PointTotals{Merits: 2, Demerits: 20}, "D"},
+		{"needs improvement", PointTotals{Demerits: 40, MajorViolations: 2}, "E"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.grade, conductGrade(tt.totals).Grade)
+		})
+	}
+}
+
+func TestRankHouses(t *testing.T) {
+	entries := []LeaderboardEntry{
+		{HouseName: "Blue", Merits: 10, Demerits: 4},
+		{HouseName: "Red", Merits: 20, Demerits: 5},
+		{HouseName: "Green", Merits: 10, Demerits: 4},
+		{HouseName: "Yellow", Merits: 0, Demerits: 0},
+	}
+
+	ranked := rankHouses(entries)
+	assert.Equal(t, "Red", ranked[0].HouseName)
+	assert.Equal(t, 1, ranked[0].Rank)
+	assert.Equal(t, 15, ranked[0].NetPoints)
+	assert.Equal(t, "Blue", ranked[1].HouseName)
+	assert.Equal(t, 2, ranked[1].Rank)
+	assert.Equal(t, "Green", ranked[2].HouseName)
+	assert.Equal(t, 2, ranked[2].Rank)
+	assert.Equal(t, "Yellow", ranked[3].HouseName)
+	assert.Equal(t, 4, ranked[3].Rank)
+}
+
+func TestGuardianThresholdMessage(t *testing.T) {
+	threshold := &models.BehaviorThreshold{Name: "Level 1"}
+	meeting := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	body := guardianThresholdMessage("Asha Rao", threshold, 12, &meeting)
+	assert.Contains(t, body, "Asha Rao has reached 12 demerit points")
+	assert.Contains(t, body, "12 Mar 2026")
+
+	body = guardianThresholdMessage("Asha Rao", threshold, 12, nil)
+	assert.Contains(t, body, "Please contact the school")
+}
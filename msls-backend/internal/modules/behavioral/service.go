@@ -8,16 +8,18 @@ import (
 	"github.com/google/uuid"
 
 	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/sms"
 )
 
 // Service handles business logic for behavioral incidents
 type Service struct {
-	repo *Repository
+	repo        *Repository
+	smsProvider sms.Provider
 }
 
 // NewService creates a new behavioral service
-func NewService(repo *Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo *Repository, smsProvider sms.Provider) *Service {
+	return &Service{repo: repo, smsProvider: smsProvider}
 }
 
 // =============================================================================
@@ -44,6 +46,11 @@ func (s *Service) CreateIncident(ctx context.Context, tenantID, studentID, repor
 		witnessesJSON, _ = json.Marshal(req.Witnesses)
 	}
 
+	points, err := s.pointsFor(ctx, tenantID, req.IncidentType, severity)
+	if err != nil {
+		return nil, err
+	}
+
 	incident := &models.StudentBehavioralIncident{
 		ID:                    uuid.Must(uuid.NewV7()),
 		TenantID:              tenantID,
@@ -58,6 +65,7 @@ func (s *Service) CreateIncident(ctx context.Context, tenantID, studentID, repor
 		StudentResponse:       req.StudentResponse,
 		ActionTaken:           req.ActionTaken,
 		ParentMeetingRequired: req.ParentMeetingRequired,
+		Points:                points,
 		ReportedBy:            reportedBy,
 	}
 
@@ -65,6 +73,8 @@ func (s *Service) CreateIncident(ctx context.Context, tenantID, studentID, repor
 		return nil, err
 	}
 
+	s.applyThresholds(ctx, incident)
+
 	// Fetch with relations
 	incident, err = s.repo.GetIncident(ctx, tenantID, incident.ID)
 	if err != nil {
@@ -110,11 +120,21 @@ func (s *Service) UpdateIncident(ctx context.Context, tenantID, studentID, incid
 	}
 
 	// Update fields
-	if req.IncidentType != nil {
+	rescore := false
+	if req.IncidentType != nil && *req.IncidentType != incident.IncidentType {
 		incident.IncidentType = *req.IncidentType
+		rescore = true
 	}
-	if req.Severity != nil {
+	if req.Severity != nil && *req.Severity != incident.Severity {
 		incident.Severity = *req.Severity
+		rescore = true
+	}
+	if rescore {
+		points, err := s.pointsFor(ctx, tenantID, incident.IncidentType, incident.Severity)
+		if err != nil {
+			return nil, err
+		}
+		incident.Points = points
 	}
 	if req.IncidentDate != nil {
 		date, err := time.Parse("2006-01-02", *req.IncidentDate)
@@ -159,6 +179,13 @@ func (s *Service) UpdateIncident(ctx context.Context, tenantID, studentID, incid
 		return nil, err
 	}
 
+	if rescore {
+		s.applyThresholds(ctx, incident)
+		if incident, err = s.repo.GetIncident(ctx, tenantID, incident.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	return s.toIncidentResponse(incident), nil
 }
 
@@ -337,6 +364,7 @@ func (s *Service) toIncidentResponse(incident *models.StudentBehavioralIncident)
 		StudentResponse:       incident.StudentResponse,
 		ActionTaken:           incident.ActionTaken,
 		ParentMeetingRequired: incident.ParentMeetingRequired,
+		Points:                incident.Points,
 		ParentNotified:        incident.ParentNotified,
 		ParentNotifiedAt:      incident.ParentNotifiedAt,
 		ReportedBy:            incident.ReportedBy,
@@ -196,6 +196,32 @@ func (r *Repository) GetPendingItems(ctx context.Context, opID uuid.UUID, limit
 	return items, nil
 }
 
+// CountByStatus returns the number of operations in each of the given statuses across all tenants.
+func (r *Repository) CountByStatus(ctx context.Context, statuses ...models.BulkOperationStatus) (map[models.BulkOperationStatus]int64, error) {
+	var rows []struct {
+		Status models.BulkOperationStatus
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.BulkOperation{}).
+		Select("status, COUNT(*) AS count").
+		Where("status IN ?", statuses).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("count bulk operations: %w", err)
+	}
+
+	counts := make(map[models.BulkOperationStatus]int64, len(statuses))
+	for _, status := range statuses {
+		counts[status] = 0
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
 // DB returns the underlying database connection.
 func (r *Repository) DB() *gorm.DB {
 	return r.db
@@ -122,6 +122,11 @@ func (s *Service) ListByUser(ctx context.Context, tenantID, userID uuid.UUID, li
 	return s.repo.ListByUser(ctx, tenantID, userID, limit)
 }
 
+// QueueDepth returns the number of pending and processing operations, keyed by status.
+func (s *Service) QueueDepth(ctx context.Context) (map[models.BulkOperationStatus]int64, error) {
+	return s.repo.CountByStatus(ctx, models.BulkOperationStatusPending, models.BulkOperationStatusProcessing)
+}
+
 // ProcessStatusUpdate processes a bulk status update operation synchronously.
 func (s *Service) ProcessStatusUpdate(ctx context.Context, tenantID, opID uuid.UUID, newStatus models.StudentStatus) error {
 	// Mark as started
@@ -0,0 +1,276 @@
+// Package certificate provides transfer, bonafide and character certificate issuance.
+package certificate
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// ListFilter contains filters for the certificate register.
+type ListFilter struct {
+	TenantID  uuid.UUID
+	StudentID *uuid.UUID
+	Type      models.CertificateType
+	Status    models.CertificateStatus
+	Search    string
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
+
+// RequestCertificateDTO represents a request for a certificate.
+type RequestCertificateDTO struct {
+	TenantID   uuid.UUID
+	StudentID  uuid.UUID
+	Type       models.CertificateType
+	TemplateID *uuid.UUID
+	Purpose    string
+	// Fields overrides values compiled from student records, e.g. conduct.
+	Fields      map[string]string
+	RequestedBy *uuid.UUID
+}
+
+// UpdateCertificateDTO represents corrections to a pending certificate.
+type UpdateCertificateDTO struct {
+	TemplateID *uuid.UUID
+	Purpose    *string
+	Fields     map[string]string
+}
+
+// TemplateDTO represents a request to create or update a certificate template.
+type TemplateDTO struct {
+	TenantID             uuid.UUID
+	CertificateType      models.CertificateType
+	Name                 string
+	Title                string
+	Body                 string
+	SchoolName           string
+	SchoolAddress        string
+	Affiliation          string
+	SignatoryName        string
+	SignatoryDesignation string
+	FooterText           string
+	IsDefault            bool
+	UserID               *uuid.UUID
+}
+
+// EnrollmentRecord is one enrollment in a student's history.
+type EnrollmentRecord struct {
+	AcademicYear   string
+	ClassName      string
+	SectionName    string
+	Status         string
+	EnrollmentDate time.Time
+	CompletionDate *time.Time
+	TransferDate   *time.Time
+	TransferReason string
+	DropoutDate    *time.Time
+	DropoutReason  string
+}
+
+// VerificationResult is the public result of verifying a certificate QR code.
+type VerificationResult struct {
+	Valid           bool
+	Message         string
+	Certificate     *models.Certificate
+	SchoolName      string
+	StudentName     string
+	AdmissionNumber string
+}
+
+// =========================================================================
+// Request Types
+// =========================================================================
+
+// RequestCertificateRequest represents the request body for requesting a certificate.
+type RequestCertificateRequest struct {
+	StudentID       string            `json:"studentId" binding:"required,uuid"`
+	CertificateType string            `json:"certificateType" binding:"required,oneof=transfer bonafide character"`
+	TemplateID      *string           `json:"templateId" binding:"omitempty,uuid"`
+	Purpose         string            `json:"purpose" binding:"max=500"`
+	Fields          map[string]string `json:"fields"`
+}
+
+// UpdateCertificateRequest represents the request body for correcting a pending certificate.
+type UpdateCertificateRequest struct {
+	TemplateID *string           `json:"templateId" binding:"omitempty,uuid"`
+	Purpose    *string           `json:"purpose" binding:"omitempty,max=500"`
+	Fields     map[string]string `json:"fields"`
+}
+
+// ReasonRequest represents the request body for rejecting or cancelling a certificate.
+type ReasonRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// TemplateRequest represents the request body for creating or updating a certificate template.
+type TemplateRequest struct {
+	CertificateType      string `json:"certificateType" binding:"required,oneof=transfer bonafide character"`
+	Name                 string `json:"name" binding:"required,max=200"`
+	Title                string `json:"title" binding:"max=200"`
+	Body                 string `json:"body"`
+	SchoolName           string `json:"schoolName" binding:"max=200"`
+	SchoolAddress        string `json:"schoolAddress" binding:"max=500"`
+	Affiliation          string `json:"affiliation" binding:"max=200"`
+	SignatoryName        string `json:"signatoryName" binding:"max=200"`
+	SignatoryDesignation string `json:"signatoryDesignation" binding:"max=100"`
+	FooterText           string `json:"footerText"`
+	IsDefault            bool   `json:"isDefault"`
+}
+
+// =========================================================================
+// Response Types
+// =========================================================================
+
+// CertificateResponse represents a certificate in API responses.
+type CertificateResponse struct {
+	ID              string            `json:"id"`
+	StudentID       string            `json:"studentId"`
+	StudentName     string            `json:"studentName,omitempty"`
+	AdmissionNumber string            `json:"admissionNumber,omitempty"`
+	CertificateType string            `json:"certificateType"`
+	SerialNumber    string            `json:"serialNumber,omitempty"`
+	Status          string            `json:"status"`
+	Purpose         string            `json:"purpose,omitempty"`
+	TemplateID      *string           `json:"templateId,omitempty"`
+	Fields          map[string]string `json:"fields"`
+	RequestedAt     string            `json:"requestedAt"`
+	IssuedAt        string            `json:"issuedAt,omitempty"`
+	RejectedAt      string            `json:"rejectedAt,omitempty"`
+	RejectionReason string            `json:"rejectionReason,omitempty"`
+	CancelledAt     string            `json:"cancelledAt,omitempty"`
+	CancelReason    string            `json:"cancelReason,omitempty"`
+	VerificationURL string            `json:"verificationUrl,omitempty"`
+}
+
+// CertificateListResponse represents the certificate register.
+type CertificateListResponse struct {
+	Certificates []CertificateResponse `json:"certificates"`
+	Total        int64                 `json:"total"`
+}
+
+// TemplateResponse represents a certificate template in API responses.
+type TemplateResponse struct {
+	ID                   string `json:"id"`
+	CertificateType      string `json:"certificateType"`
+	Name                 string `json:"name"`
+	Title                string `json:"title"`
+	Body                 string `json:"body"`
+	SchoolName           string `json:"schoolName,omitempty"`
+	SchoolAddress        string `json:"schoolAddress,omitempty"`
+	Affiliation          string `json:"affiliation,omitempty"`
+	SignatoryName        string `json:"signatoryName,omitempty"`
+	SignatoryDesignation string `json:"signatoryDesignation,omitempty"`
+	FooterText           string `json:"footerText,omitempty"`
+	IsDefault            bool   `json:"isDefault"`
+	CreatedAt            string `json:"createdAt"`
+	UpdatedAt            string `json:"updatedAt"`
+}
+
+// VerifyResponse represents the public verification result.
+type VerifyResponse struct {
+	Valid           bool   `json:"valid"`
+	Message         string `json:"message"`
+	CertificateType string `json:"certificateType,omitempty"`
+	SerialNumber    string `json:"serialNumber,omitempty"`
+	Status          string `json:"status,omitempty"`
+	StudentName     string `json:"studentName,omitempty"`
+	AdmissionNumber string `json:"admissionNumber,omitempty"`
+	SchoolName      string `json:"schoolName,omitempty"`
+	IssuedAt        string `json:"issuedAt,omitempty"`
+}
+
+// ToCertificateResponse converts a Certificate model to a CertificateResponse.
+func ToCertificateResponse(certificate *models.Certificate, verificationURL string) CertificateResponse {
+	resp := CertificateResponse{
+		ID:              certificate.ID.String(),
+		StudentID:       certificate.StudentID.String(),
+		CertificateType: string(certificate.CertificateType),
+		Status:          string(certificate.Status),
+		Purpose:         certificate.Purpose,
+		Fields:          certificate.Fields,
+		RequestedAt:     certificate.RequestedAt.Format(time.RFC3339),
+		RejectionReason: certificate.RejectionReason,
+		CancelReason:    certificate.CancelReason,
+		VerificationURL: verificationURL,
+	}
+	if resp.Fields == nil {
+		resp.Fields = map[string]string{}
+	}
+	if certificate.Student != nil {
+		resp.StudentName = certificate.Student.FullName()
+		resp.AdmissionNumber = certificate.Student.AdmissionNumber
+	}
+	if certificate.SerialNumber != nil {
+		resp.SerialNumber = *certificate.SerialNumber
+	}
+	if certificate.TemplateID != nil {
+		templateID := certificate.TemplateID.String()
+		resp.TemplateID = &templateID
+	}
+	if certificate.IssuedAt != nil {
+		resp.IssuedAt = certificate.IssuedAt.Format(time.RFC3339)
+	}
+	if certificate.RejectedAt != nil {
+		resp.RejectedAt = certificate.RejectedAt.Format(time.RFC3339)
+	}
+	if certificate.CancelledAt != nil {
+		resp.CancelledAt = certificate.CancelledAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// ToTemplateResponse converts a CertificateTemplate model to a TemplateResponse.
+func ToTemplateResponse(template *models.CertificateTemplate) TemplateResponse {
+	return TemplateResponse{
+		ID:                   template.ID.String(),
+		CertificateType:      string(template.CertificateType),
+		Name:                 template.Name,
+		Title:                template.Title,
+		Body:                 template.Body,
+		SchoolName:           template.SchoolName,
+		SchoolAddress:        template.SchoolAddress,
+		Affiliation:          template.Affiliation,
+		SignatoryName:        template.SignatoryName,
+		SignatoryDesignation: template.SignatoryDesignation,
+		FooterText:           template.FooterText,
+		IsDefault:            template.IsDefault,
+		CreatedAt:            template.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            template.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToTemplateResponses converts a slice of CertificateTemplate models to TemplateResponses.
+func ToTemplateResponses(templates []models.CertificateTemplate) []TemplateResponse {
+	responses := make([]TemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = ToTemplateResponse(&templates[i])
+	}
+	return responses
+}
+
+// ToVerifyResponse converts a verification result to a VerifyResponse.
+func ToVerifyResponse(result *VerificationResult) VerifyResponse {
+	resp := VerifyResponse{
+		Valid:           result.Valid,
+		Message:         result.Message,
+		SchoolName:      result.SchoolName,
+		StudentName:     result.StudentName,
+		AdmissionNumber: result.AdmissionNumber,
+	}
+	if certificate := result.Certificate; certificate != nil {
+		resp.CertificateType = string(certificate.CertificateType)
+		resp.Status = string(certificate.Status)
+		if certificate.SerialNumber != nil {
+			resp.SerialNumber = *certificate.SerialNumber
+		}
+		if certificate.IssuedAt != nil {
+			resp.IssuedAt = certificate.IssuedAt.Format("2006-01-02")
+		}
+	}
+	return resp
+}
@@ -0,0 +1,43 @@
+// Package certificate provides transfer, bonafide and character certificate issuance.
+package certificate
+
+import "errors"
+
+// Certificate-related errors.
+var (
+	// ErrCertificateNotFound is returned when a certificate is not found.
+	ErrCertificateNotFound = errors.New("certificate not found")
+
+	// ErrTemplateNotFound is returned when a certificate template is not found.
+	ErrTemplateNotFound = errors.New("certificate template not found")
+
+	// ErrStudentNotFound is returned when the student is not found.
+	ErrStudentNotFound = errors.New("student not found")
+
+	// ErrInvalidCertificateType is returned when the certificate type is not recognised.
+	ErrInvalidCertificateType = errors.New("invalid certificate type")
+
+	// ErrTemplateTypeMismatch is returned when a template is used for a different certificate type.
+	ErrTemplateTypeMismatch = errors.New("template is for a different certificate type")
+
+	// ErrTemplateNameRequired is returned when a template name is not provided.
+	ErrTemplateNameRequired = errors.New("template name is required")
+
+	// ErrStudentNotLeft is returned when a transfer certificate is requested for a student still enrolled.
+	ErrStudentNotLeft = errors.New("student has no transfer or dropout recorded")
+
+	// ErrStudentNotActive is returned when a bonafide certificate is requested for a student who has left.
+	ErrStudentNotActive = errors.New("bonafide certificates are only issued to current students")
+
+	// ErrTransferCertificateExists is returned when a student already has a pending or issued transfer certificate.
+	ErrTransferCertificateExists = errors.New("student already has a transfer certificate; cancel it to issue a duplicate")
+
+	// ErrCertificateNotPending is returned when approving, rejecting or editing a certificate that is not pending.
+	ErrCertificateNotPending = errors.New("certificate is not pending approval")
+
+	// ErrCertificateNotIssued is returned when downloading or cancelling a certificate that has not been issued.
+	ErrCertificateNotIssued = errors.New("certificate has not been issued")
+
+	// ErrReasonRequired is returned when rejecting or cancelling without a reason.
+	ErrReasonRequired = errors.New("reason is required")
+)
@@ -0,0 +1,634 @@
+// Package certificate provides transfer, bonafide and character certificate issuance.
+package certificate
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/response"
+)
+
+// Handler handles certificate-related HTTP requests.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new certificate handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// =========================================================================
+// Certificates
+// =========================================================================
+
+// List returns the certificate register.
+// @Summary List certificates
+// @Description List requested and issued certificates, newest first
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param studentId query string false "Filter by student"
+// @Param type query string false "Filter by type (transfer, bonafide, character)"
+// @Param status query string false "Filter by status (pending, issued, rejected, cancelled)"
+// @Param from query string false "Requested on or after (YYYY-MM-DD)"
+// @Param to query string false "Requested on or before (YYYY-MM-DD)"
+// @Param search query string false "Search by serial number, admission number or student name"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=CertificateListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/certificates [get]
+func (h *Handler) List(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := ListFilter{
+		TenantID: tenantID,
+		Type:     models.CertificateType(c.Query("type")),
+		Status:   models.CertificateStatus(c.Query("status")),
+		Search:   c.Query("search"),
+	}
+	if filter.Type != "" && !filter.Type.IsValid() {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid certificate type"))
+		return
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid certificate status"))
+		return
+	}
+	if studentIDStr := c.Query("studentId"); studentIDStr != "" {
+		studentID, err := uuid.Parse(studentIDStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid student ID"))
+			return
+		}
+		filter.StudentID = &studentID
+	}
+	if !middleware.ParseDateQuery(c, "from", &filter.From) ||
+		!middleware.ParseDateQuery(c, "to", &filter.To) ||
+		!middleware.ParsePaging(c, &filter.Limit, &filter.Offset) {
+		return
+	}
+
+	certificates, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := CertificateListResponse{
+		Certificates: make([]CertificateResponse, len(certificates)),
+		Total:        total,
+	}
+	for i := range certificates {
+		resp.Certificates[i] = ToCertificateResponse(&certificates[i], h.service.VerificationURL(&certificates[i]))
+	}
+	response.OK(c, resp)
+}
+
+// ListStudentCertificates returns a student's certificates.
+// @Summary List student certificates
+// @Description List all certificates requested for a student
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Student ID"
+// @Success 200 {object} response.Success{data=CertificateListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/students/{id}/certificates [get]
+func (h *Handler) ListStudentCertificates(c *gin.Context) {
+	tenantID, studentID, ok := middleware.TenantAndID(c, "id", "Invalid student ID")
+	if !ok {
+		return
+	}
+
+	certificates, total, err := h.service.List(c.Request.Context(), ListFilter{
+		TenantID:  tenantID,
+		StudentID: &studentID,
+		Limit:     100,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := CertificateListResponse{
+		Certificates: make([]CertificateResponse, len(certificates)),
+		Total:        total,
+	}
+	for i := range certificates {
+		resp.Certificates[i] = ToCertificateResponse(&certificates[i], h.service.VerificationURL(&certificates[i]))
+	}
+	response.OK(c, resp)
+}
+
+// Get returns a certificate.
+// @Summary Get certificate
+// @Description Get a certificate with the values that will be printed on it
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Certificate ID"
+// @Success 200 {object} response.Success{data=CertificateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/certificates/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid certificate ID")
+	if !ok {
+		return
+	}
+
+	certificate, err := h.service.Get(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCertificateResponse(certificate, h.service.VerificationURL(certificate)))
+}
+
+// Request requests a certificate for a student.
+// @Summary Request certificate
+// @Description Request a transfer, bonafide or character certificate; values are compiled from the student's records and the request waits for approval
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body RequestCertificateRequest true "Certificate request"
+// @Success 201 {object} response.Success{data=CertificateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/certificates [post]
+func (h *Handler) Request(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req RequestCertificateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	studentID, err := uuid.Parse(req.StudentID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid student ID"))
+		return
+	}
+	templateID, ok := middleware.ParseOptionalUUID(c, req.TemplateID, "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	certificate, err := h.service.RequestCertificate(c.Request.Context(), RequestCertificateDTO{
+		TenantID:    tenantID,
+		StudentID:   studentID,
+		Type:        models.CertificateType(req.CertificateType),
+		TemplateID:  templateID,
+		Purpose:     req.Purpose,
+		Fields:      req.Fields,
+		RequestedBy: &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToCertificateResponse(certificate, ""))
+}
+
+// Update corrects a pending certificate.
+// @Summary Update certificate
+// @Description Correct the template, purpose or printed values of a certificate pending approval
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Certificate ID"
+// @Param request body UpdateCertificateRequest true "Corrections"
+// @Success 200 {object} response.Success{data=CertificateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/certificates/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid certificate ID")
+	if !ok {
+		return
+	}
+
+	var req UpdateCertificateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+	templateID, ok := middleware.ParseOptionalUUID(c, req.TemplateID, "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	certificate, err := h.service.UpdateCertificate(c.Request.Context(), tenantID, id, UpdateCertificateDTO{
+		TemplateID: templateID,
+		Purpose:    req.Purpose,
+		Fields:     req.Fields,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCertificateResponse(certificate, ""))
+}
+
+// Approve approves and issues a certificate.
+// @Summary Approve certificate
+// @Description Approve a pending certificate, assigning its serial number and verification QR code
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Certificate ID"
+// @Success 200 {object} response.Success{data=CertificateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/certificates/{id}/approve [post]
+func (h *Handler) Approve(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid certificate ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	certificate, err := h.service.Approve(c.Request.Context(), tenantID, id, &userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCertificateResponse(certificate, h.service.VerificationURL(certificate)))
+}
+
+// Reject rejects a certificate request.
+// @Summary Reject certificate
+// @Description Reject a pending certificate request
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Certificate ID"
+// @Param request body ReasonRequest true "Rejection reason"
+// @Success 200 {object} response.Success{data=CertificateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/certificates/{id}/reject [post]
+func (h *Handler) Reject(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid certificate ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req ReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	certificate, err := h.service.Reject(c.Request.Context(), tenantID, id, &userID, req.Reason)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCertificateResponse(certificate, ""))
+}
+
+// Cancel cancels an issued certificate.
+// @Summary Cancel certificate
+// @Description Cancel an issued certificate; its serial number stays in the register and verification reports it as cancelled
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Certificate ID"
+// @Param request body ReasonRequest true "Cancellation reason"
+// @Success 200 {object} response.Success{data=CertificateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/certificates/{id}/cancel [post]
+func (h *Handler) Cancel(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid certificate ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req ReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	certificate, err := h.service.Cancel(c.Request.Context(), tenantID, id, &userID, req.Reason)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCertificateResponse(certificate, h.service.VerificationURL(certificate)))
+}
+
+// DownloadPDF downloads an issued certificate.
+// @Summary Download certificate PDF
+// @Description Download an issued certificate printed on the school letterhead with a verification QR code
+// @Tags Certificates
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Certificate ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/certificates/{id}/pdf [get]
+func (h *Handler) DownloadPDF(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid certificate ID")
+	if !ok {
+		return
+	}
+
+	pdf, filename, err := h.service.RenderPDF(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// Verify verifies a certificate from its QR code.
+// @Summary Verify certificate
+// @Description Public endpoint reached by scanning a certificate's QR code
+// @Tags Certificates
+// @Produce json
+// @Param code path string true "Verification code"
+// @Success 200 {object} response.Success{data=VerifyResponse}
+// @Router /api/v1/public/certificates/verify/{code} [get]
+func (h *Handler) Verify(c *gin.Context) {
+	result, err := h.service.Verify(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToVerifyResponse(result))
+}
+
+// =========================================================================
+// Templates
+// =========================================================================
+
+// ListTemplates returns certificate templates.
+// @Summary List certificate templates
+// @Description List certificate templates, optionally for one certificate type
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param type query string false "Filter by type (transfer, bonafide, character)"
+// @Success 200 {object} response.Success{data=[]TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/certificate-templates [get]
+func (h *Handler) ListTemplates(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	templates, err := h.service.ListTemplates(c.Request.Context(), tenantID, models.CertificateType(c.Query("type")))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTemplateResponses(templates))
+}
+
+// GetTemplate returns a certificate template.
+// @Summary Get certificate template
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Success 200 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/certificate-templates/{id} [get]
+func (h *Handler) GetTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	template, err := h.service.GetTemplate(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTemplateResponse(template))
+}
+
+// CreateTemplate creates a certificate template.
+// @Summary Create certificate template
+// @Description Create a certificate template; the body may use {{placeholders}} such as {{student_name}} and {{conduct}}
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body TemplateRequest true "Template"
+// @Success 201 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/certificate-templates [post]
+func (h *Handler) CreateTemplate(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	template, err := h.service.CreateTemplate(c.Request.Context(), toTemplateDTO(tenantID, &userID, req))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToTemplateResponse(template))
+}
+
+// UpdateTemplate updates a certificate template.
+// @Summary Update certificate template
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Param request body TemplateRequest true "Template"
+// @Success 200 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/certificate-templates/{id} [put]
+func (h *Handler) UpdateTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(c.Request.Context(), id, toTemplateDTO(tenantID, &userID, req))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTemplateResponse(template))
+}
+
+// DeleteTemplate deletes a certificate template.
+// @Summary Delete certificate template
+// @Description Delete a certificate template; issued certificates keep the wording they were printed with
+// @Tags Certificates
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Success 204
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/certificate-templates/{id} [delete]
+func (h *Handler) DeleteTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTemplate(c.Request.Context(), tenantID, id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// =========================================================================
+// Helpers
+// =========================================================================
+
+// toTemplateDTO converts a template request to a TemplateDTO.
+func toTemplateDTO(tenantID uuid.UUID, userID *uuid.UUID, req TemplateRequest) TemplateDTO {
+	return TemplateDTO{
+		TenantID:             tenantID,
+		CertificateType:      models.CertificateType(req.CertificateType),
+		Name:                 req.Name,
+		Title:                req.Title,
+		Body:                 req.Body,
+		SchoolName:           req.SchoolName,
+		SchoolAddress:        req.SchoolAddress,
+		Affiliation:          req.Affiliation,
+		SignatoryName:        req.SignatoryName,
+		SignatoryDesignation: req.SignatoryDesignation,
+		FooterText:           req.FooterText,
+		IsDefault:            req.IsDefault,
+		UserID:               userID,
+	}
+}
+
+// handleServiceError converts service errors to API errors.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrCertificateNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Certificate not found"))
+	case errors.Is(err, ErrTemplateNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Certificate template not found"))
+	case errors.Is(err, ErrStudentNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Student not found"))
+	case errors.Is(err, ErrInvalidCertificateType):
+		apperrors.Abort(c, apperrors.BadRequest("Invalid certificate type"))
+	case errors.Is(err, ErrTemplateTypeMismatch):
+		apperrors.Abort(c, apperrors.BadRequest("Template is for a different certificate type"))
+	case errors.Is(err, ErrTemplateNameRequired):
+		apperrors.Abort(c, apperrors.BadRequest("Template name is required"))
+	case errors.Is(err, ErrReasonRequired):
+		apperrors.Abort(c, apperrors.BadRequest("Reason is required"))
+	case errors.Is(err, ErrStudentNotLeft):
+		apperrors.Abort(c, apperrors.BadRequest("Transfer certificates are issued only after a transfer or dropout is recorded"))
+	case errors.Is(err, ErrStudentNotActive):
+		apperrors.Abort(c, apperrors.BadRequest("Bonafide certificates are only issued to current students"))
+	case errors.Is(err, ErrTransferCertificateExists):
+		apperrors.Abort(c, apperrors.Conflict("Student already has a transfer certificate; cancel it to issue a duplicate"))
+	case errors.Is(err, ErrCertificateNotPending):
+		apperrors.Abort(c, apperrors.Conflict("Certificate is not pending approval"))
+	case errors.Is(err, ErrCertificateNotIssued):
+		apperrors.Abort(c, apperrors.Conflict("Certificate has not been issued"))
+	default:
+		logger.Error("Certificate operation error", zap.Error(err))
+		apperrors.Abort(c, apperrors.InternalError("Failed to process certificate request"))
+	}
+}
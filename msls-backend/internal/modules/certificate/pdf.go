@@ -0,0 +1,172 @@
+// Package certificate provides transfer, bonafide and character certificate issuance.
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// transferParticulars are the rows printed in the particulars table of a transfer certificate.
+var transferParticulars = [][2]string{
+	{"Name of the Student", "student_name"},
+	{"Admission Number", "admission_number"},
+	{"Father's Name", "father_name"},
+	{"Mother's Name", "mother_name"},
+	{"Date of Birth", "date_of_birth"},
+	{"Date of Birth (in words)", "date_of_birth_words"},
+	{"Date of Admission", "admission_date"},
+	{"Class at Admission", "admission_class"},
+	{"Class Last Studied", "current_class"},
+	{"Date of Leaving", "leaving_date"},
+	{"Reason for Leaving", "leaving_reason"},
+	{"Conduct", "conduct"},
+}
+
+// renderCertificate renders an issued certificate from its frozen fields.
+func renderCertificate(certificate *models.Certificate, verificationURL string) ([]byte, error) {
+	fields := certificate.Fields
+	builtin := defaultTemplate(certificate.CertificateType)
+	title := fields["title"]
+	if title == "" {
+		title = builtin.Title
+	}
+	body := fields["body"]
+	if body == "" {
+		body = builtin.Body
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth := 170.0 // 210 - 40 (margins)
+
+	primaryColor := []int{31, 41, 55}
+	accentColor := []int{16, 185, 129}
+	lightBg := []int{249, 250, 251}
+	borderColor := []int{229, 231, 235}
+	mutedText := []int{107, 114, 128}
+
+	// Letterhead
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	pdf.SetFont("Arial", "B", 18)
+	pdf.CellFormat(pageWidth, 10, tr(fields["school_name"]), "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	for _, line := range []string{fields["school_address"], fields["affiliation"]} {
+		if line != "" {
+			pdf.CellFormat(pageWidth, 5, tr(line), "", 1, "C", false, 0, "")
+		}
+	}
+	pdf.Ln(4)
+	pdf.SetDrawColor(accentColor[0], accentColor[1], accentColor[2])
+	pdf.SetLineWidth(0.8)
+	pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+	pdf.Ln(6)
+
+	// Serial number and date
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(pageWidth/2, 6, "Serial No: "+fields["serial_number"], "", 0, "L", false, 0, "")
+	pdf.CellFormat(pageWidth/2, 6, "Date: "+fields["issue_date"], "", 1, "R", false, 0, "")
+	pdf.Ln(4)
+
+	// Title
+	pdf.SetFillColor(accentColor[0], accentColor[1], accentColor[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(pageWidth, 10, tr(strings.ToUpper(title)), "0", 1, "C", true, 0, "")
+	pdf.Ln(6)
+
+	// Particulars
+	if certificate.CertificateType == models.CertificateTypeTransfer {
+		pdf.SetDrawColor(borderColor[0], borderColor[1], borderColor[2])
+		pdf.SetLineWidth(0.2)
+		for i, row := range transferParticulars {
+			value := fields[row[1]]
+			if value == "" {
+				value = "-"
+			}
+			fill := i%2 == 0
+			pdf.SetFillColor(lightBg[0], lightBg[1], lightBg[2])
+			pdf.SetFont("Arial", "", 10)
+			pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+			pdf.CellFormat(60, 7, row[0], "1", 0, "L", fill, 0, "")
+			pdf.SetFont("Arial", "B", 10)
+			pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+			pdf.CellFormat(pageWidth-60, 7, tr(value), "1", 1, "L", fill, 0, "")
+		}
+		pdf.Ln(6)
+	}
+
+	// Body
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	pdf.SetFont("Arial", "", 11)
+	for _, paragraph := range strings.Split(renderBody(body, fields), "\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			pdf.Ln(3)
+			continue
+		}
+		pdf.MultiCell(pageWidth, 6, tr(paragraph), "", "J", false)
+	}
+	pdf.Ln(16)
+
+	// QR code and signatory
+	signatureY := pdf.GetY()
+	if verificationURL != "" {
+		png, err := qrcode.Encode(verificationURL, qrcode.Medium, 256)
+		if err != nil {
+			return nil, fmt.Errorf("encode certificate qr code: %w", err)
+		}
+		imgName := "qr_" + certificate.ID.String()
+		pdf.RegisterImageOptionsReader(imgName, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+		pdf.ImageOptions(imgName, 20, signatureY-6, 30, 30, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+		pdf.SetXY(20, signatureY+25)
+		pdf.SetFont("Arial", "", 7)
+		pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+		pdf.CellFormat(60, 4, "Scan to verify", "", 0, "L", false, 0, "")
+	}
+	pdf.SetXY(20, signatureY+8)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	if fields["signatory_name"] != "" {
+		pdf.CellFormat(pageWidth, 5, tr(fields["signatory_name"]), "", 1, "R", false, 0, "")
+		pdf.SetFont("Arial", "", 9)
+	}
+	pdf.CellFormat(pageWidth, 5, tr(fields["signatory_designation"]), "", 1, "R", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	pdf.CellFormat(pageWidth, 5, tr(fields["school_name"]), "", 1, "R", false, 0, "")
+
+	// Cancelled copies are marked so they cannot be passed off as valid
+	if certificate.Status == models.CertificateStatusCancelled {
+		pdf.SetFont("Arial", "B", 60)
+		pdf.SetTextColor(220, 38, 38)
+		pdf.TransformBegin()
+		pdf.TransformRotate(35, 105, 150)
+		pdf.Text(45, 160, "CANCELLED")
+		pdf.TransformEnd()
+	}
+
+	// Footer
+	pdf.SetY(-25)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	footer := fields["footer_text"]
+	if footer == "" {
+		footer = "The authenticity of this certificate can be verified by scanning the QR code."
+	}
+	pdf.MultiCell(pageWidth, 4, tr(footer), "", "C", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("generate certificate pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,385 @@
+// Package certificate provides transfer, bonafide and character certificate issuance.
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// Repository handles database operations for certificates.
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new certificate repository.
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// =========================================================================
+// Certificate Operations
+// =========================================================================
+
+// List retrieves certificates in the register matching the filter.
+func (r *Repository) List(ctx context.Context, filter ListFilter) ([]models.Certificate, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.Certificate{}).
+		Where("certificates.tenant_id = ?", filter.TenantID)
+
+	if filter.StudentID != nil {
+		query = query.Where("certificates.student_id = ?", *filter.StudentID)
+	}
+	if filter.Type != "" {
+		query = query.Where("certificates.certificate_type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("certificates.status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("certificates.requested_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("certificates.requested_at < ?", filter.To.AddDate(0, 0, 1))
+	}
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Joins("JOIN students ON students.id = certificates.student_id").
+			Where("certificates.serial_number ILIKE ? OR students.admission_number ILIKE ? OR "+
+				"(students.first_name || ' ' || students.last_name) ILIKE ?", search, search, search)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count certificates: %w", err)
+	}
+
+	var certificates []models.Certificate
+	err := query.
+		Preload("Student").
+		Order("certificates.requested_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&certificates).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("list certificates: %w", err)
+	}
+
+	return certificates, total, nil
+}
+
+// GetByID retrieves a certificate by ID.
+func (r *Repository) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*models.Certificate, error) {
+	var certificate models.Certificate
+	err := r.db.WithContext(ctx).
+		Preload("Student").
+		Preload("Template").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&certificate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificateNotFound
+		}
+		return nil, fmt.Errorf("get certificate: %w", err)
+	}
+	return &certificate, nil
+}
+
+// GetByVerificationCode retrieves a certificate by the code printed in its QR code.
+// The lookup is not tenant-scoped because verification is public.
+func (r *Repository) GetByVerificationCode(ctx context.Context, code string) (*models.Certificate, error) {
+	var certificate models.Certificate
+	err := r.db.WithContext(ctx).
+		Preload("Student").
+		Where("verification_code = ?", code).
+		First(&certificate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificateNotFound
+		}
+		return nil, fmt.Errorf("get certificate by verification code: %w", err)
+	}
+	return &certificate, nil
+}
+
+// Create creates a certificate request.
+func (r *Repository) Create(ctx context.Context, certificate *models.Certificate) error {
+	if err := r.db.WithContext(ctx).Create(certificate).Error; err != nil {
+		return fmt.Errorf("create certificate: %w", err)
+	}
+	return nil
+}
+
+// UpdatePending updates a certificate that is still pending approval.
+func (r *Repository) UpdatePending(ctx context.Context, certificate *models.Certificate, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Certificate{}).
+		Where("tenant_id = ? AND id = ? AND status = ?", certificate.TenantID, certificate.ID, models.CertificateStatusPending).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("update certificate: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCertificateNotPending
+	}
+	return nil
+}
+
+// Issue approves a pending certificate, assigning the next serial number for
+// its type and year and a verification code.
+func (r *Repository) Issue(ctx context.Context, certificate *models.Certificate, fields models.CertificateFields, approvedBy *uuid.UUID, verificationCode string, issuedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sequence, err := nextSequence(tx, certificate.TenantID, certificate.CertificateType, issuedAt.Year())
+		if err != nil {
+			return err
+		}
+		serial := formatSerialNumber(certificate.CertificateType, issuedAt.Year(), sequence)
+		fields["serial_number"] = serial
+
+		result := tx.Model(&models.Certificate{}).
+			Where("tenant_id = ? AND id = ? AND status = ?", certificate.TenantID, certificate.ID, models.CertificateStatusPending).
+			Updates(map[string]interface{}{
+				"status":            models.CertificateStatusIssued,
+				"serial_number":     serial,
+				"verification_code": verificationCode,
+				"fields":            fields,
+				"approved_by":       approvedBy,
+				"issued_at":         issuedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("issue certificate: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrCertificateNotPending
+		}
+		return nil
+	})
+}
+
+// Reject rejects a pending certificate.
+func (r *Repository) Reject(ctx context.Context, certificate *models.Certificate, rejectedBy *uuid.UUID, reason string, rejectedAt time.Time) error {
+	return r.UpdatePending(ctx, certificate, map[string]interface{}{
+		"status":           models.CertificateStatusRejected,
+		"rejected_by":      rejectedBy,
+		"rejected_at":      rejectedAt,
+		"rejection_reason": reason,
+	})
+}
+
+// Cancel cancels an issued certificate. Its serial number stays in the register
+// and verification reports it as cancelled.
+func (r *Repository) Cancel(ctx context.Context, certificate *models.Certificate, cancelledBy *uuid.UUID, reason string, cancelledAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Certificate{}).
+		Where("tenant_id = ? AND id = ? AND status = ?", certificate.TenantID, certificate.ID, models.CertificateStatusIssued).
+		Updates(map[string]interface{}{
+			"status":        models.CertificateStatusCancelled,
+			"cancelled_by":  cancelledBy,
+			"cancelled_at":  cancelledAt,
+			"cancel_reason": reason,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("cancel certificate: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCertificateNotIssued
+	}
+	return nil
+}
+
+// HasOpenTransferCertificate reports whether the student has a pending or issued transfer certificate.
+func (r *Repository) HasOpenTransferCertificate(ctx context.Context, tenantID, studentID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Certificate{}).
+		Where("tenant_id = ? AND student_id = ? AND certificate_type = ? AND status IN ?",
+			tenantID, studentID, models.CertificateTypeTransfer,
+			[]models.CertificateStatus{models.CertificateStatusPending, models.CertificateStatusIssued}).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("check transfer certificates: %w", err)
+	}
+	return count > 0, nil
+}
+
+// nextSequenceSQL creates or increments the sequence row in one statement, so
+// concurrent issues for the same type and year each get their own serial.
+const nextSequenceSQL = `
+INSERT INTO certificate_sequences (tenant_id, certificate_type, year, last_sequence, updated_at)
+VALUES (@tenant_id, @certificate_type, @year, 1, @now)
+ON CONFLICT (tenant_id, certificate_type, year) DO UPDATE SET
+	last_sequence = certificate_sequences.last_sequence + 1,
+	updated_at = excluded.updated_at
+RETURNING last_sequence`
+
+// nextSequence increments and returns the serial sequence for a certificate type and year.
+func nextSequence(tx *gorm.DB, tenantID uuid.UUID, certificateType models.CertificateType, year int) (int, error) {
+	var sequence int
+	err := tx.Raw(nextSequenceSQL, map[string]interface{}{
+		"tenant_id":        tenantID,
+		"certificate_type": certificateType,
+		"year":             year,
+		"now":              time.Now(),
+	}).Scan(&sequence).Error
+	if err != nil {
+		return 0, fmt.Errorf("update certificate sequence: %w", err)
+	}
+	return sequence, nil
+}
+
+// =========================================================================
+// Student Data
+// =========================================================================
+
+// GetStudent retrieves a student with their branch.
+func (r *Repository) GetStudent(ctx context.Context, tenantID, studentID uuid.UUID) (*models.Student, error) {
+	var student models.Student
+	err := r.db.WithContext(ctx).
+		Preload("Branch").
+		Where("tenant_id = ? AND id = ?", tenantID, studentID).
+		First(&student).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+		return nil, fmt.Errorf("get student: %w", err)
+	}
+	return &student, nil
+}
+
+// GetTenant retrieves the tenant for the letterhead.
+func (r *Repository) GetTenant(ctx context.Context, tenantID uuid.UUID) (*models.Tenant, error) {
+	var tenant models.Tenant
+	if err := r.db.WithContext(ctx).First(&tenant, "id = ?", tenantID).Error; err != nil {
+		return nil, fmt.Errorf("get tenant: %w", err)
+	}
+	return &tenant, nil
+}
+
+// ListGuardians retrieves a student's guardians, primary guardian first.
+func (r *Repository) ListGuardians(ctx context.Context, tenantID, studentID uuid.UUID) ([]models.StudentGuardian, error) {
+	var guardians []models.StudentGuardian
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id = ?", tenantID, studentID).
+		Order("is_primary DESC, created_at ASC").
+		Find(&guardians).Error
+	if err != nil {
+		return nil, fmt.Errorf("list guardians: %w", err)
+	}
+	return guardians, nil
+}
+
+// ListEnrollmentHistory retrieves a student's enrollments, oldest first, with
+// class, section and academic year names.
+func (r *Repository) ListEnrollmentHistory(ctx context.Context, tenantID, studentID uuid.UUID) ([]EnrollmentRecord, error) {
+	var records []EnrollmentRecord
+	err := r.db.WithContext(ctx).
+		Table("student_enrollments se").
+		Select(`se.status, se.enrollment_date, se.completion_date, se.transfer_date, se.transfer_reason,
+			se.dropout_date, se.dropout_reason,
+			COALESCE(ay.name, '') AS academic_year,
+			COALESCE(c.name, '') AS class_name,
+			COALESCE(sec.name, '') AS section_name`).
+		Joins("LEFT JOIN academic_years ay ON ay.id = se.academic_year_id").
+		Joins("LEFT JOIN classes c ON c.id = se.class_id").
+		Joins("LEFT JOIN sections sec ON sec.id = se.section_id").
+		Where("se.tenant_id = ? AND se.student_id = ?", tenantID, studentID).
+		Order("se.enrollment_date ASC").
+		Scan(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("list enrollment history: %w", err)
+	}
+	return records, nil
+}
+
+// =========================================================================
+// Template Operations
+// =========================================================================
+
+// ListTemplates retrieves templates, optionally for one certificate type.
+func (r *Repository) ListTemplates(ctx context.Context, tenantID uuid.UUID, certificateType models.CertificateType) ([]models.CertificateTemplate, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if certificateType != "" {
+		query = query.Where("certificate_type = ?", certificateType)
+	}
+
+	var templates []models.CertificateTemplate
+	if err := query.Order("certificate_type ASC, is_default DESC, name ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("list certificate templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetTemplateByID retrieves a template by ID.
+func (r *Repository) GetTemplateByID(ctx context.Context, tenantID, id uuid.UUID) (*models.CertificateTemplate, error) {
+	var template models.CertificateTemplate
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("get certificate template: %w", err)
+	}
+	return &template, nil
+}
+
+// GetDefaultTemplate retrieves the default template for a certificate type, or nil when none is set.
+func (r *Repository) GetDefaultTemplate(ctx context.Context, tenantID uuid.UUID, certificateType models.CertificateType) (*models.CertificateTemplate, error) {
+	var templates []models.CertificateTemplate
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND certificate_type = ? AND is_default = ?", tenantID, certificateType, true).
+		Limit(1).
+		Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("get default certificate template: %w", err)
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	return &templates[0], nil
+}
+
+// SaveTemplate creates or updates a template. A default template replaces the
+// previous default for its certificate type.
+func (r *Repository) SaveTemplate(ctx context.Context, template *models.CertificateTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if template.IsDefault {
+			if err := tx.Model(&models.CertificateTemplate{}).
+				Where("tenant_id = ? AND certificate_type = ? AND id <> ?", template.TenantID, template.CertificateType, template.ID).
+				Update("is_default", false).Error; err != nil {
+				return fmt.Errorf("clear default certificate template: %w", err)
+			}
+		}
+		if err := tx.Save(template).Error; err != nil {
+			return fmt.Errorf("save certificate template: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteTemplate deletes a template. Certificates printed from it fall back to the default.
+func (r *Repository) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Certificate{}).
+			Where("tenant_id = ? AND template_id = ?", tenantID, id).
+			Update("template_id", nil).Error; err != nil {
+			return fmt.Errorf("unlink certificate template: %w", err)
+		}
+		result := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&models.CertificateTemplate{})
+		if result.Error != nil {
+			return fmt.Errorf("delete certificate template: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrTemplateNotFound
+		}
+		return nil
+	})
+}
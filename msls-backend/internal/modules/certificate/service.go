@@ -0,0 +1,706 @@
+// Package certificate provides transfer, bonafide and character certificate issuance.
+package certificate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/modules/behavioral"
+	"msls-backend/internal/pkg/database/models"
+)
+
+// ConductProvider supplies a student's behaviour record for the conduct line.
+type ConductProvider interface {
+	GetBehaviorSummary(ctx context.Context, tenantID, studentID uuid.UUID) (*behavioral.BehaviorSummary, error)
+}
+
+// Service handles certificate business logic.
+type Service struct {
+	repo          *Repository
+	conduct       ConductProvider
+	publicBaseURL string
+}
+
+// NewService creates a new certificate service. publicBaseURL is printed in
+// the QR code so that anyone holding the certificate can verify it.
+func NewService(repo *Repository, conduct ConductProvider, publicBaseURL string) *Service {
+	return &Service{
+		repo:          repo,
+		conduct:       conduct,
+		publicBaseURL: strings.TrimRight(publicBaseURL, "/"),
+	}
+}
+
+// =========================================================================
+// Requests and Approval
+// =========================================================================
+
+// RequestCertificate records a certificate request pending approval. Values
+// printed on the certificate are compiled from the student's records and may
+// be corrected until it is approved.
+func (s *Service) RequestCertificate(ctx context.Context, dto RequestCertificateDTO) (*models.Certificate, error) {
+	if !dto.Type.IsValid() {
+		return nil, ErrInvalidCertificateType
+	}
+
+	student, err := s.repo.GetStudent(ctx, dto.TenantID, dto.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.repo.ListEnrollmentHistory(ctx, dto.TenantID, dto.StudentID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch dto.Type {
+	case models.CertificateTypeTransfer:
+		if !hasLeft(student, history) {
+			return nil, ErrStudentNotLeft
+		}
+		exists, err := s.repo.HasOpenTransferCertificate(ctx, dto.TenantID, dto.StudentID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrTransferCertificateExists
+		}
+	case models.CertificateTypeBonafide:
+		if student.Status != models.StudentStatusActive {
+			return nil, ErrStudentNotActive
+		}
+	}
+
+	if dto.TemplateID != nil {
+		template, err := s.repo.GetTemplateByID(ctx, dto.TenantID, *dto.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if template.CertificateType != dto.Type {
+			return nil, ErrTemplateTypeMismatch
+		}
+	}
+
+	guardians, err := s.repo.ListGuardians(ctx, dto.TenantID, dto.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	conduct := ""
+	if s.conduct != nil {
+		if summary, err := s.conduct.GetBehaviorSummary(ctx, dto.TenantID, dto.StudentID); err == nil {
+			conduct = conductRating(summary)
+		}
+	}
+
+	fields := buildFields(student, guardians, history, conduct, dto.Purpose)
+	for key, value := range dto.Fields {
+		fields[key] = value
+	}
+
+	certificate := &models.Certificate{
+		ID:              uuid.New(),
+		TenantID:        dto.TenantID,
+		StudentID:       dto.StudentID,
+		TemplateID:      dto.TemplateID,
+		CertificateType: dto.Type,
+		Status:          models.CertificateStatusPending,
+		Purpose:         dto.Purpose,
+		Fields:          fields,
+		RequestedBy:     dto.RequestedBy,
+		RequestedAt:     time.Now(),
+	}
+	if err := s.repo.Create(ctx, certificate); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, dto.TenantID, certificate.ID)
+}
+
+// RequestTransferCertificate raises a pending transfer certificate for a
+// student who has just been transferred out or dropped out.
+func (s *Service) RequestTransferCertificate(ctx context.Context, tenantID, studentID uuid.UUID, requestedBy *uuid.UUID) error {
+	_, err := s.RequestCertificate(ctx, RequestCertificateDTO{
+		TenantID:    tenantID,
+		StudentID:   studentID,
+		Type:        models.CertificateTypeTransfer,
+		RequestedBy: requestedBy,
+	})
+	if errors.Is(err, ErrTransferCertificateExists) {
+		return nil
+	}
+	return err
+}
+
+// UpdateCertificate corrects a certificate that is pending approval.
+func (s *Service) UpdateCertificate(ctx context.Context, tenantID, id uuid.UUID, dto UpdateCertificateDTO) (*models.Certificate, error) {
+	certificate, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if certificate.Status != models.CertificateStatusPending {
+		return nil, ErrCertificateNotPending
+	}
+
+	updates := map[string]interface{}{}
+	if dto.TemplateID != nil {
+		template, err := s.repo.GetTemplateByID(ctx, tenantID, *dto.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if template.CertificateType != certificate.CertificateType {
+			return nil, ErrTemplateTypeMismatch
+		}
+		updates["template_id"] = *dto.TemplateID
+	}
+
+	fields := models.CertificateFields{}
+	for key, value := range certificate.Fields {
+		fields[key] = value
+	}
+	if dto.Purpose != nil {
+		updates["purpose"] = *dto.Purpose
+		fields["purpose"] = *dto.Purpose
+	}
+	for key, value := range dto.Fields {
+		fields[key] = value
+	}
+	updates["fields"] = fields
+
+	if err := s.repo.UpdatePending(ctx, certificate, updates); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, tenantID, id)
+}
+
+// Approve issues a pending certificate. The next serial number is assigned
+// and the wording and letterhead are frozen so later template changes do not
+// alter certificates already handed out.
+func (s *Service) Approve(ctx context.Context, tenantID, id uuid.UUID, approvedBy *uuid.UUID) (*models.Certificate, error) {
+	certificate, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if certificate.Status != models.CertificateStatusPending {
+		return nil, ErrCertificateNotPending
+	}
+
+	template, err := s.resolveTemplate(ctx, certificate)
+	if err != nil {
+		return nil, err
+	}
+	letterhead, err := s.letterhead(ctx, certificate, template)
+	if err != nil {
+		return nil, err
+	}
+
+	issuedAt := time.Now()
+	fields := models.CertificateFields{}
+	for key, value := range certificate.Fields {
+		fields[key] = value
+	}
+	fields["issue_date"] = issuedAt.Format("02 January 2006")
+	fields["school_name"] = letterhead.SchoolName
+	fields["school_address"] = letterhead.SchoolAddress
+	fields["affiliation"] = letterhead.Affiliation
+	fields["signatory_name"] = letterhead.SignatoryName
+	fields["signatory_designation"] = letterhead.SignatoryDesignation
+	fields["title"] = template.Title
+	fields["body"] = template.Body
+	fields["footer_text"] = template.FooterText
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Issue(ctx, certificate, fields, approvedBy, code, issuedAt); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, tenantID, id)
+}
+
+// Reject rejects a pending certificate request.
+func (s *Service) Reject(ctx context.Context, tenantID, id uuid.UUID, rejectedBy *uuid.UUID, reason string) (*models.Certificate, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+	certificate, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Reject(ctx, certificate, rejectedBy, reason, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, tenantID, id)
+}
+
+// Cancel cancels an issued certificate, e.g. before a duplicate is issued.
+func (s *Service) Cancel(ctx context.Context, tenantID, id uuid.UUID, cancelledBy *uuid.UUID, reason string) (*models.Certificate, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+	certificate, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Cancel(ctx, certificate, cancelledBy, reason, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, tenantID, id)
+}
+
+// =========================================================================
+// Register, Printing and Verification
+// =========================================================================
+
+// List retrieves the certificate register.
+func (s *Service) List(ctx context.Context, filter ListFilter) ([]models.Certificate, int64, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Get retrieves a certificate by ID.
+func (s *Service) Get(ctx context.Context, tenantID, id uuid.UUID) (*models.Certificate, error) {
+	return s.repo.GetByID(ctx, tenantID, id)
+}
+
+// VerificationURL returns the public URL encoded in an issued certificate's QR code.
+func (s *Service) VerificationURL(certificate *models.Certificate) string {
+	if certificate.VerificationCode == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/v1/public/certificates/verify/%s", s.publicBaseURL, *certificate.VerificationCode)
+}
+
+// RenderPDF renders an issued certificate. Cancelled certificates are printed
+// with a CANCELLED watermark for the office file.
+func (s *Service) RenderPDF(ctx context.Context, tenantID, id uuid.UUID) ([]byte, string, error) {
+	certificate, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if certificate.Status != models.CertificateStatusIssued && certificate.Status != models.CertificateStatusCancelled {
+		return nil, "", ErrCertificateNotIssued
+	}
+
+	pdf, err := renderCertificate(certificate, s.VerificationURL(certificate))
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := string(certificate.CertificateType) + "-certificate.pdf"
+	if certificate.SerialNumber != nil {
+		filename = strings.ReplaceAll(*certificate.SerialNumber, "/", "-") + ".pdf"
+	}
+	return pdf, filename, nil
+}
+
+// Verify checks the code from a certificate's QR code.
+func (s *Service) Verify(ctx context.Context, code string) (*VerificationResult, error) {
+	certificate, err := s.repo.GetByVerificationCode(ctx, code)
+	if errors.Is(err, ErrCertificateNotFound) {
+		return &VerificationResult{Valid: false, Message: "Certificate not found"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerificationResult{
+		Certificate:     certificate,
+		SchoolName:      certificate.Fields["school_name"],
+		StudentName:     certificate.Fields["student_name"],
+		AdmissionNumber: certificate.Fields["admission_number"],
+	}
+	switch certificate.Status {
+	case models.CertificateStatusIssued:
+		result.Valid = true
+		result.Message = "Certificate is genuine"
+	case models.CertificateStatusCancelled:
+		result.Message = "Certificate has been cancelled by the school"
+	default:
+		result.Message = "Certificate has not been issued"
+	}
+	return result, nil
+}
+
+// =========================================================================
+// Templates
+// =========================================================================
+
+// ListTemplates retrieves certificate templates.
+func (s *Service) ListTemplates(ctx context.Context, tenantID uuid.UUID, certificateType models.CertificateType) ([]models.CertificateTemplate, error) {
+	if certificateType != "" && !certificateType.IsValid() {
+		return nil, ErrInvalidCertificateType
+	}
+	return s.repo.ListTemplates(ctx, tenantID, certificateType)
+}
+
+// GetTemplate retrieves a certificate template by ID.
+func (s *Service) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*models.CertificateTemplate, error) {
+	return s.repo.GetTemplateByID(ctx, tenantID, id)
+}
+
+// CreateTemplate creates a certificate template. Blank title and body fall
+// back to the built-in wording for the certificate type.
+func (s *Service) CreateTemplate(ctx context.Context, dto TemplateDTO) (*models.CertificateTemplate, error) {
+	template := &models.CertificateTemplate{
+		ID:        uuid.New(),
+		TenantID:  dto.TenantID,
+		CreatedBy: dto.UserID,
+	}
+	if err := applyTemplate(template, dto); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateTemplate updates a certificate template.
+func (s *Service) UpdateTemplate(ctx context.Context, id uuid.UUID, dto TemplateDTO) (*models.CertificateTemplate, error) {
+	template, err := s.repo.GetTemplateByID(ctx, dto.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTemplate(template, dto); err != nil {
+		return nil, err
+	}
+	template.UpdatedAt = time.Now()
+	if err := s.repo.SaveTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate deletes a certificate template.
+func (s *Service) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return s.repo.DeleteTemplate(ctx, tenantID, id)
+}
+
+// applyTemplate copies a template request onto the model.
+func applyTemplate(template *models.CertificateTemplate, dto TemplateDTO) error {
+	if !dto.CertificateType.IsValid() {
+		return ErrInvalidCertificateType
+	}
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return ErrTemplateNameRequired
+	}
+
+	builtin := defaultTemplate(dto.CertificateType)
+	template.CertificateType = dto.CertificateType
+	template.Name = name
+	template.Title = strings.TrimSpace(dto.Title)
+	if template.Title == "" {
+		template.Title = builtin.Title
+	}
+	template.Body = strings.TrimSpace(dto.Body)
+	if template.Body == "" {
+		template.Body = builtin.Body
+	}
+	template.SchoolName = strings.TrimSpace(dto.SchoolName)
+	template.SchoolAddress = strings.TrimSpace(dto.SchoolAddress)
+	template.Affiliation = strings.TrimSpace(dto.Affiliation)
+	template.SignatoryName = strings.TrimSpace(dto.SignatoryName)
+	template.SignatoryDesignation = strings.TrimSpace(dto.SignatoryDesignation)
+	template.FooterText = strings.TrimSpace(dto.FooterText)
+	template.IsDefault = dto.IsDefault
+	template.UpdatedBy = dto.UserID
+	return nil
+}
+
+// resolveTemplate returns the certificate's chosen template, the tenant's
+// default for its type, or the built-in template.
+func (s *Service) resolveTemplate(ctx context.Context, certificate *models.Certificate) (*models.CertificateTemplate, error) {
+	if certificate.Template != nil {
+		return certificate.Template, nil
+	}
+	template, err := s.repo.GetDefaultTemplate(ctx, certificate.TenantID, certificate.CertificateType)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		return template, nil
+	}
+	return defaultTemplate(certificate.CertificateType), nil
+}
+
+// letterhead returns the template's letterhead, filling blanks from the
+// tenant and the student's branch.
+func (s *Service) letterhead(ctx context.Context, certificate *models.Certificate, template *models.CertificateTemplate) (*models.CertificateTemplate, error) {
+	letterhead := *template
+	if letterhead.SignatoryDesignation == "" {
+		letterhead.SignatoryDesignation = "Principal"
+	}
+	if letterhead.SchoolName != "" && letterhead.SchoolAddress != "" {
+		return &letterhead, nil
+	}
+
+	tenant, err := s.repo.GetTenant(ctx, certificate.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	schoolName := tenant.Name
+	schoolAddress := ""
+	if student := certificate.Student; student != nil {
+		branch := student.Branch
+		if branch.ID == uuid.Nil {
+			if loaded, err := s.repo.GetStudent(ctx, certificate.TenantID, student.ID); err == nil {
+				branch = loaded.Branch
+			}
+		}
+		if branch.ID != uuid.Nil {
+			if !branch.IsPrimary {
+				schoolName = fmt.Sprintf("%s - %s", tenant.Name, branch.Name)
+			}
+			var parts []string
+			for _, part := range []string{branch.Address.Street, branch.Address.City, branch.Address.State, branch.Address.PostalCode} {
+				if part != "" {
+					parts = append(parts, part)
+				}
+			}
+			schoolAddress = strings.Join(parts, ", ")
+		}
+	}
+
+	if letterhead.SchoolName == "" {
+		letterhead.SchoolName = schoolName
+	}
+	if letterhead.SchoolAddress == "" {
+		letterhead.SchoolAddress = schoolAddress
+	}
+	return &letterhead, nil
+}
+
+// generateVerificationCode returns a random code for the certificate's QR code.
+func generateVerificationCode() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate verification code: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// =========================================================================
+// Certificate Content
+// =========================================================================
+
+// hasLeft reports whether the student has left the school and is due a
+// transfer certificate.
+func hasLeft(student *models.Student, history []EnrollmentRecord) bool {
+	switch student.Status {
+	case models.StudentStatusTransferred, models.StudentStatusGraduated:
+		return true
+	}
+	if len(history) == 0 {
+		return false
+	}
+	latest := history[len(history)-1]
+	return latest.Status == "transferred" || latest.Status == "dropout"
+}
+
+// buildFields compiles the values printed on a certificate from the student's records.
+func buildFields(student *models.Student, guardians []models.StudentGuardian, history []EnrollmentRecord, conduct, purpose string) models.CertificateFields {
+	fields := models.CertificateFields{
+		"student_name":        student.FullName(),
+		"admission_number":    student.AdmissionNumber,
+		"date_of_birth":       student.DateOfBirth.Format("02 January 2006"),
+		"date_of_birth_words": dateInWords(student.DateOfBirth),
+		"gender":              titleCase(string(student.Gender)),
+		"admission_date":      student.AdmissionDate.Format("02 January 2006"),
+		"conduct":             conduct,
+		"purpose":             purpose,
+	}
+
+	switch student.Gender {
+	case models.GenderMale:
+		fields["child_relation"] = "son"
+	case models.GenderFemale:
+		fields["child_relation"] = "daughter"
+	default:
+		fields["child_relation"] = "ward"
+	}
+
+	for _, guardian := range guardians {
+		name := strings.TrimSpace(guardian.FirstName + " " + guardian.LastName)
+		switch guardian.Relation {
+		case models.GuardianRelationFather:
+			if fields["father_name"] == "" {
+				fields["father_name"] = name
+			}
+		case models.GuardianRelationMother:
+			if fields["mother_name"] == "" {
+				fields["mother_name"] = name
+			}
+		}
+		if fields["parent_name"] == "" {
+			fields["parent_name"] = name
+		}
+	}
+	if fields["father_name"] != "" {
+		fields["parent_name"] = fields["father_name"]
+	}
+
+	if len(history) > 0 {
+		first := history[0]
+		latest := history[len(history)-1]
+		fields["admission_class"] = first.ClassName
+		fields["current_class"] = classLabel(latest)
+		fields["academic_year"] = latest.AcademicYear
+
+		end := ""
+		switch {
+		case latest.TransferDate != nil:
+			end = latest.TransferDate.Format("02 January 2006")
+			fields["leaving_reason"] = latest.TransferReason
+		case latest.DropoutDate != nil:
+			end = latest.DropoutDate.Format("02 January 2006")
+			fields["leaving_reason"] = latest.DropoutReason
+		case latest.CompletionDate != nil && student.Status != models.StudentStatusActive:
+			end = latest.CompletionDate.Format("02 January 2006")
+			fields["leaving_reason"] = "Completed " + latest.ClassName
+		}
+		fields["leaving_date"] = end
+		if end == "" {
+			end = "date"
+		}
+		fields["study_period"] = fmt.Sprintf("%s to %s", first.EnrollmentDate.Format("02 January 2006"), end)
+	}
+
+	return fields
+}
+
+// classLabel returns the class and section of an enrollment.
+func classLabel(record EnrollmentRecord) string {
+	if record.SectionName == "" {
+		return record.ClassName
+	}
+	return record.ClassName + " - " + record.SectionName
+}
+
+// conductRating summarises a behaviour record as the conduct line on a certificate.
+func conductRating(summary *behavioral.BehaviorSummary) string {
+	if summary == nil {
+		return "Good"
+	}
+	switch {
+	case summary.MajorViolationCount > 0:
+		return "Satisfactory"
+	case summary.PositiveCount > 0 && summary.MinorInfractionCount == 0:
+		return "Very Good"
+	default:
+		return "Good"
+	}
+}
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// renderBody replaces {{placeholder}} tokens with certificate fields. Blank
+// values are printed as a line to be filled in by hand.
+func renderBody(body string, fields models.CertificateFields) string {
+	return placeholderPattern.ReplaceAllStringFunc(body, func(token string) string {
+		key := placeholderPattern.FindStringSubmatch(token)[1]
+		if value := strings.TrimSpace(fields[key]); value != "" {
+			return value
+		}
+		return "________"
+	})
+}
+
+// formatSerialNumber formats a certificate serial number, e.g. TC/2026/0001.
+func formatSerialNumber(certificateType models.CertificateType, year, sequence int) string {
+	return fmt.Sprintf("%s/%d/%04d", certificateType.SerialPrefix(), year, sequence)
+}
+
+// titleCase capitalises the first letter of a word.
+func titleCase(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
+
+var (
+	ordinalWords = []string{
+		"", "First", "Second", "Third", "Fourth", "Fifth", "Sixth", "Seventh", "Eighth", "Ninth", "Tenth",
+		"Eleventh", "Twelfth", "Thirteenth", "Fourteenth", "Fifteenth", "Sixteenth", "Seventeenth",
+		"Eighteenth", "Nineteenth", "Twentieth", "Twenty First", "Twenty Second", "Twenty Third",
+		"Twenty Fourth", "Twenty Fifth", "Twenty Sixth", "Twenty Seventh", "Twenty Eighth",
+		"Twenty Ninth", "Thirtieth", "Thirty First",
+	}
+	unitWords = []string{
+		"Zero", "One", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten",
+		"Eleven", "Twelve", "Thirteen", "Fourteen", "Fifteen", "Sixteen", "Seventeen", "Eighteen", "Nineteen",
+	}
+	tensWords = []string{"", "", "Twenty", "Thirty", "Forty", "Fifty", "Sixty", "Seventy", "Eighty", "Ninety"}
+)
+
+// dateInWords writes a date as it appears on a transfer certificate,
+// e.g. "Fifth March Two Thousand Eighteen".
+func dateInWords(date time.Time) string {
+	return fmt.Sprintf("%s %s %s", ordinalWords[date.Day()], date.Month().String(), numberInWords(date.Year()))
+}
+
+// numberInWords writes a number below ten thousand in words.
+func numberInWords(n int) string {
+	var parts []string
+	if n >= 1000 {
+		parts = append(parts, unitWords[n/1000], "Thousand")
+		n %= 1000
+	}
+	if n >= 100 {
+		parts = append(parts, unitWords[n/100], "Hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20:
+		parts = append(parts, tensWords[n/10])
+		if n%10 != 0 {
+			parts = append(parts, unitWords[n%10])
+		}
+	case n > 0 || len(parts) == 0:
+		parts = append(parts, unitWords[n])
+	}
+	return strings.Join(parts, " ")
+}
+
+// defaultTemplate returns the built-in wording for a certificate type.
+func defaultTemplate(certificateType models.CertificateType) *models.CertificateTemplate {
+	template := &models.CertificateTemplate{CertificateType: certificateType, Name: "Default"}
+	switch certificateType {
+	case models.CertificateTypeTransfer:
+		template.Title = "TRANSFER CERTIFICATE"
+		template.Body = "This is to certify that {{student_name}}, {{child_relation}} of {{parent_name}}, " +
+			"Admission No. {{admission_number}}, was a bonafide student of this school from {{study_period}}. " +
+			"The student was last studying in {{current_class}} during the academic year {{academic_year}} " +
+			"and left the school on {{leaving_date}} ({{leaving_reason}}). " +
+			"All dues to the school have been paid. Conduct and character: {{conduct}}.\n\n" +
+			"We wish the student every success in the future."
+	case models.CertificateTypeBonafide:
+		template.Title = "BONAFIDE CERTIFICATE"
+		template.Body = "This is to certify that {{student_name}}, {{child_relation}} of {{parent_name}}, " +
+			"Admission No. {{admission_number}}, is a bonafide student of this school studying in " +
+			"{{current_class}} during the academic year {{academic_year}}. " +
+			"According to our records the date of birth is {{date_of_birth}} ({{date_of_birth_words}}).\n\n" +
+			"This certificate is issued on request for the purpose of {{purpose}}."
+	case models.CertificateTypeCharacter:
+		template.Title = "CHARACTER CERTIFICATE"
+		template.Body = "This is to certify that {{student_name}}, {{child_relation}} of {{parent_name}}, " +
+			"Admission No. {{admission_number}}, has been a student of this school from {{study_period}}. " +
+			"During this period the student's conduct and character were {{conduct}}. " +
+			"To the best of our knowledge the student has not taken part in any activity against the school " +
+			"or society.\n\nWe wish the student every success in life."
+	}
+	return template
+}
@@ -0,0 +1,120 @@
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/modules/behavioral"
+	"msls-backend/internal/pkg/database/models"
+)
+
+func TestFormatSerialNumber(t *testing.T) {
+	assert.Equal(t, "TC/2026/0001", formatSerialNumber(models.CertificateTypeTransfer, 2026, 1))
+	assert.Equal(t, "BC/2026/0042", formatSerialNumber(models.CertificateTypeBonafide, 2026, 42))
+	assert.Equal(t, "CC/2027/12345", formatSerialNumber(models.CertificateTypeCharacter, 2027, 12345))
+}
+
+func TestDateInWords(t *testing.T) {
+	assert.Equal(t, "Fifth March Two Thousand Eighteen", dateInWords(time.Date(2018, 3, 5, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Thirty First December One Thousand Nine Hundred Ninety Nine",
+		dateInWords(time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Twenty Second July Two Thousand", dateInWords(time.Date(2000, 7, 22, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestRenderBody(t *testing.T) {
+	fields := models.CertificateFields{"student_name": "Asha Sharma", "conduct": "Good"}
+
+	body := renderBody("This is to certify that {{student_name}} ({{ admission_number }}) has {{conduct}} conduct.", fields)
+
+	assert.Equal(t, "This is to certify that Asha Sharma (________) has Good conduct.", body)
+}
+
+func TestConductRating(t *testing.T) {
+	assert.Equal(t, "Good", conductRating(nil))
+	assert.Equal(t, "Good", conductRating(&behavioral.BehaviorSummary{}))
+	assert.Equal(t, "Very Good", conductRating(&behavioral.BehaviorSummary{PositiveCount: 3}))
+	assert.Equal(t, "Good", conductRating(&behavioral.BehaviorSummary{PositiveCount: 3, MinorInfractionCount: 1}))
+	assert.Equal(t, "Satisfactory", conductRating(&behavioral.BehaviorSummary{PositiveCount: 5, MajorViolationCount: 1}))
+}
+
+func TestHasLeft(t *testing.T) {
+	active := &models.Student{Status: models.StudentStatusActive}
+	inactive := &models.Student{Status: models.StudentStatusInactive}
+
+	assert.False(t, hasLeft(active, nil))
+	assert.False(t, hasLeft(active, []EnrollmentRecord{{Status: "active"}}))
+	assert.True(t, hasLeft(inactive, []EnrollmentRecord{{Status: "completed"}, {Status: "transferred"}}))
+	assert.True(t, hasLeft(inactive, []EnrollmentRecord{{Status: "dropout"}}))
+	assert.True(t, hasLeft(&models.Student{Status: models.StudentStatusGraduated}, nil))
+}
+
+func TestBuildFields(t *testing.T) {
+	transferDate := time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)
+	student := &models.Student{
+		ID:              uuid.New(),
+		AdmissionNumber: "ADM-2020-001",
+		FirstName:       "Asha",
+		LastName:        "Sharma",
+		DateOfBirth:     time.Date(2015, 3, 5, 0, 0, 0, 0, time.UTC),
+		Gender:          models.GenderFemale,
+		Status:          models.StudentStatusInactive,
+		AdmissionDate:   time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	guardians := []models.StudentGuardian{
+		{Relation: models.GuardianRelationMother, FirstName: "Priya", LastName: "Sharma", IsPrimary: true},
+		{Relation: models.GuardianRelationFather, FirstName: "Raj", LastName: "Sharma"},
+	}
+	history := []EnrollmentRecord{
+		{AcademicYear: "2020-21", ClassName: "Class 1", SectionName: "A", Status: "completed",
+			EnrollmentDate: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{AcademicYear: "2025-26", ClassName: "Class 6", SectionName: "B", Status: "transferred",
+			EnrollmentDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			TransferDate:   &transferDate, TransferReason: "Parent relocation"},
+	}
+
+	fields := buildFields(student, guardians, history, "Very Good", "")
+
+	assert.Equal(t, "Asha Sharma", fields["student_name"])
+	assert.Equal(t, "ADM-2020-001", fields["admission_number"])
+	assert.Equal(t, "05 March 2015", fields["date_of_birth"])
+	assert.Equal(t, "Fifth March Two Thousand Fifteen", fields["date_of_birth_words"])
+	assert.Equal(t, "Female", fields["gender"])
+	assert.Equal(t, "daughter", fields["child_relation"])
+	assert.Equal(t, "Raj Sharma", fields["father_name"])
+	assert.Equal(t, "Priya Sharma", fields["mother_name"])
+	assert.Equal(t, "Raj Sharma", fields["parent_name"])
+	assert.Equal(t, "Class 1", fields["admission_class"])
+	assert.Equal(t, "Class 6 - B", fields["current_class"])
+	assert.Equal(t, "2025-26", fields["academic_year"])
+	assert.Equal(t, "15 April 2026", fields["leaving_date"])
+	assert.Equal(t, "Parent relocation", fields["leaving_reason"])
+	assert.Equal(t, "01 June 2020 to 15 April 2026", fields["study_period"])
+	assert.Equal(t, "Very Good", fields["conduct"])
+}
+
+func TestRenderCertificate(t *testing.T) {
+	serial := "TC/2026/0001"
+	issuedAt := time.Now()
+	certificate := &models.Certificate{
+		ID:              uuid.New(),
+		CertificateType: models.CertificateTypeTransfer,
+		SerialNumber:    &serial,
+		Status:          models.CertificateStatusIssued,
+		IssuedAt:        &issuedAt,
+		Fields: models.CertificateFields{
+			"student_name":          "Asha Sharma",
+			"school_name":           "Green Valley School",
+			"signatory_designation": "Principal",
+			"serial_number":         serial,
+		},
+	}
+
+	pdf, err := renderCertificate(certificate, "https://school.example/api/v1/public/certificates/verify/abc")
+
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdf[:4]))
+}
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/logger"
 )
 
 // StudentRepository defines the interface for student operations.
@@ -23,11 +25,17 @@ type AcademicYearRepository interface {
 	GetByID(ctx context.Context, tenantID, id uuid.UUID) (*models.AcademicYear, error)
 }
 
+// CertificateRequester raises a transfer certificate when a student leaves.
+type CertificateRequester interface {
+	RequestTransferCertificate(ctx context.Context, tenantID, studentID uuid.UUID, requestedBy *uuid.UUID) error
+}
+
 // Service handles enrollment business logic.
 type Service struct {
 	repo            *Repository
 	studentRepo     StudentRepository
 	academicYearRepo AcademicYearRepository
+	certificates    CertificateRequester
 	db              *gorm.DB
 }
 
@@ -41,6 +49,25 @@ func NewService(db *gorm.DB, studentRepo StudentRepository, academicYearRepo Aca
 	}
 }
 
+// SetCertificateRequester sets the requester used to raise transfer
+// certificates for students who transfer out or drop out.
+func (s *Service) SetCertificateRequester(requester CertificateRequester) {
+	s.certificates = requester
+}
+
+// requestTransferCertificate raises a pending transfer certificate. Failures
+// are logged rather than returned because the student has already left.
+func (s *Service) requestTransferCertificate(ctx context.Context, tenantID, studentID uuid.UUID, requestedBy *uuid.UUID) {
+	if s.certificates == nil {
+		return
+	}
+	if err := s.certificates.RequestTransferCertificate(ctx, tenantID, studentID, requestedBy); err != nil {
+		logger.Warn("Failed to request transfer certificate",
+			zap.String("student_id", studentID.String()),
+			zap.Error(err))
+	}
+}
+
 // Create creates a new enrollment for a student.
 func (s *Service) Create(ctx context.Context, dto CreateEnrollmentDTO) (*StudentEnrollment, error) {
 	// Validate required fields
@@ -253,6 +280,8 @@ func (s *Service) ProcessTransfer(ctx context.Context, tenantID, studentID uuid.
 		return nil, err
 	}
 
+	s.requestTransferCertificate(ctx, tenantID, studentID, dto.UpdatedBy)
+
 	return s.GetByID(ctx, tenantID, enrollment.ID)
 }
 
@@ -320,6 +349,8 @@ func (s *Service) ProcessDropout(ctx context.Context, tenantID, studentID uuid.U
 		return nil, err
 	}
 
+	s.requestTransferCertificate(ctx, tenantID, studentID, dto.UpdatedBy)
+
 	return s.GetByID(ctx, tenantID, enrollment.ID)
 }
 
@@ -0,0 +1,234 @@
+// Package family provides household management linking siblings and their guardians.
+package family
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// ListFilter contains filters for listing families.
+type ListFilter struct {
+	TenantID uuid.UUID
+	Search   string
+	Limit    int
+	Offset   int
+}
+
+// CreateFamilyDTO represents a request to create a family.
+type CreateFamilyDTO struct {
+	TenantID   uuid.UUID
+	Name       string
+	Notes      string
+	StudentIDs []uuid.UUID
+	CreatedBy  *uuid.UUID
+}
+
+// UpdateFamilyDTO represents a request to update a family.
+type UpdateFamilyDTO struct {
+	Name      *string
+	Notes     *string
+	UpdatedBy *uuid.UUID
+}
+
+// DuplicateGroup is a set of family guardians sharing a phone number or email.
+type DuplicateGroup struct {
+	MatchedOn string
+	Value     string
+	Guardians []models.FamilyGuardian
+}
+
+// AutoLinkResult summarises an auto-link run.
+type AutoLinkResult struct {
+	FamiliesCreated int
+	StudentsLinked  int
+	// Conflicts lists students whose guardians match more than one family;
+	// they are left unlinked until the families are merged.
+	Conflicts []uuid.UUID
+}
+
+// plannedFamily is a family to create (FamilyID nil) or extend with students.
+type plannedFamily struct {
+	FamilyID   uuid.UUID
+	Name       string
+	StudentIDs []uuid.UUID
+}
+
+// =========================================================================
+// Request Types
+// =========================================================================
+
+// CreateFamilyRequest represents the request body for creating a family.
+type CreateFamilyRequest struct {
+	Name       string   `json:"name" binding:"required,max=200"`
+	Notes      string   `json:"notes" binding:"max=1000"`
+	StudentIDs []string `json:"studentIds"`
+}
+
+// UpdateFamilyRequest represents the request body for updating a family.
+type UpdateFamilyRequest struct {
+	Name  *string `json:"name" binding:"omitempty,max=200"`
+	Notes *string `json:"notes" binding:"omitempty,max=1000"`
+}
+
+// AddStudentRequest represents the request body for adding a student to a family.
+type AddStudentRequest struct {
+	StudentID string `json:"studentId" binding:"required,uuid"`
+}
+
+// MergeFamilyRequest represents the request body for merging another family into this one.
+type MergeFamilyRequest struct {
+	SourceFamilyID string `json:"sourceFamilyId" binding:"required,uuid"`
+}
+
+// MergeGuardianRequest represents the request body for merging a duplicate guardian into this one.
+type MergeGuardianRequest struct {
+	DuplicateID string `json:"duplicateId" binding:"required,uuid"`
+}
+
+// LinkStaffRequest represents the request body for linking a guardian to a staff member.
+// A null staffId removes the link.
+type LinkStaffRequest struct {
+	StaffID *string `json:"staffId" binding:"omitempty,uuid"`
+}
+
+// =========================================================================
+// Response Types
+// =========================================================================
+
+// FamilyGuardianResponse represents a family guardian in API responses.
+type FamilyGuardianResponse struct {
+	ID        string  `json:"id"`
+	FamilyID  string  `json:"familyId"`
+	FirstName string  `json:"firstName"`
+	LastName  string  `json:"lastName"`
+	FullName  string  `json:"fullName"`
+	Phone     string  `json:"phone,omitempty"`
+	Email     string  `json:"email,omitempty"`
+	StaffID   *string `json:"staffId,omitempty"`
+	UserID    *string `json:"userId,omitempty"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+}
+
+// FamilyStudentResponse represents a student of a family in API responses.
+type FamilyStudentResponse struct {
+	ID              string `json:"id"`
+	AdmissionNumber string `json:"admissionNumber"`
+	FullName        string `json:"fullName"`
+	DateOfBirth     string `json:"dateOfBirth"`
+	Status          string `json:"status"`
+}
+
+// FamilyResponse represents a family in API responses.
+type FamilyResponse struct {
+	ID           string                   `json:"id"`
+	Name         string                   `json:"name"`
+	Notes        string                   `json:"notes,omitempty"`
+	SiblingCount int                      `json:"siblingCount"`
+	StaffWard    bool                     `json:"staffWard"`
+	Guardians    []FamilyGuardianResponse `json:"guardians"`
+	Students     []FamilyStudentResponse  `json:"students"`
+	CreatedAt    string                   `json:"createdAt"`
+	UpdatedAt    string                   `json:"updatedAt"`
+}
+
+// FamilyListResponse represents a list of families.
+type FamilyListResponse struct {
+	Families []FamilyResponse `json:"families"`
+	Total    int64            `json:"total"`
+}
+
+// DuplicateGroupResponse represents guardians sharing a phone number or email.
+type DuplicateGroupResponse struct {
+	MatchedOn string                   `json:"matchedOn"`
+	Value     string                   `json:"value"`
+	Guardians []FamilyGuardianResponse `json:"guardians"`
+}
+
+// AutoLinkResponse represents the result of an auto-link run.
+type AutoLinkResponse struct {
+	FamiliesCreated int      `json:"familiesCreated"`
+	StudentsLinked  int      `json:"studentsLinked"`
+	Conflicts       []string `json:"conflicts"`
+}
+
+// ToFamilyGuardianResponse converts a FamilyGuardian model to a FamilyGuardianResponse.
+func ToFamilyGuardianResponse(guardian *models.FamilyGuardian) FamilyGuardianResponse {
+	resp := FamilyGuardianResponse{
+		ID:        guardian.ID.String(),
+		FamilyID:  guardian.FamilyID.String(),
+		FirstName: guardian.FirstName,
+		LastName:  guardian.LastName,
+		FullName:  guardian.FullName(),
+		Phone:     guardian.Phone,
+		Email:     guardian.Email,
+		CreatedAt: guardian.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: guardian.UpdatedAt.Format(time.RFC3339),
+	}
+	if guardian.StaffID != nil {
+		staffID := guardian.StaffID.String()
+		resp.StaffID = &staffID
+	}
+	if guardian.UserID != nil {
+		userID := guardian.UserID.String()
+		resp.UserID = &userID
+	}
+	return resp
+}
+
+// ToFamilyResponse converts a Family model to a FamilyResponse.
+func ToFamilyResponse(family *models.Family) FamilyResponse {
+	resp := FamilyResponse{
+		ID:           family.ID.String(),
+		Name:         family.Name,
+		Notes:        family.Notes,
+		SiblingCount: len(family.Students),
+		StaffWard:    IsStaffWard(family),
+		Guardians:    make([]FamilyGuardianResponse, len(family.Guardians)),
+		Students:     make([]FamilyStudentResponse, len(family.Students)),
+		CreatedAt:    family.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    family.UpdatedAt.Format(time.RFC3339),
+	}
+	for i := range family.Guardians {
+		resp.Guardians[i] = ToFamilyGuardianResponse(&family.Guardians[i])
+	}
+	for i, student := range family.Students {
+		resp.Students[i] = FamilyStudentResponse{
+			ID:              student.ID.String(),
+			AdmissionNumber: student.AdmissionNumber,
+			FullName:        student.FullName(),
+			DateOfBirth:     student.DateOfBirth.Format("2006-01-02"),
+			Status:          string(student.Status),
+		}
+	}
+	return resp
+}
+
+// ToFamilyResponses converts a slice of Family models to FamilyResponses.
+func ToFamilyResponses(families []models.Family) []FamilyResponse {
+	responses := make([]FamilyResponse, len(families))
+	for i := range families {
+		responses[i] = ToFamilyResponse(&families[i])
+	}
+	return responses
+}
+
+// ToDuplicateGroupResponses converts duplicate groups to responses.
+func ToDuplicateGroupResponses(groups []DuplicateGroup) []DuplicateGroupResponse {
+	responses := make([]DuplicateGroupResponse, len(groups))
+	for i, group := range groups {
+		guardians := make([]FamilyGuardianResponse, len(group.Guardians))
+		for j := range group.Guardians {
+			guardians[j] = ToFamilyGuardianResponse(&group.Guardians[j])
+		}
+		responses[i] = DuplicateGroupResponse{
+			MatchedOn: group.MatchedOn,
+			Value:     group.Value,
+			Guardians: guardians,
+		}
+	}
+	return responses
+}
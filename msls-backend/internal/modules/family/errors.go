@@ -0,0 +1,40 @@
+// Package family provides household management linking siblings and their guardians.
+package family
+
+import "errors"
+
+// Family-related errors.
+var (
+	// ErrFamilyNotFound is returned when a family is not found.
+	ErrFamilyNotFound = errors.New("family not found")
+
+	// ErrFamilyGuardianNotFound is returned when a family guardian is not found.
+	ErrFamilyGuardianNotFound = errors.New("family guardian not found")
+
+	// ErrStudentNotFound is returned when the student is not found.
+	ErrStudentNotFound = errors.New("student not found")
+
+	// ErrStaffNotFound is returned when the staff member is not found.
+	ErrStaffNotFound = errors.New("staff member not found")
+
+	// ErrNameRequired is returned when a family name is not provided.
+	ErrNameRequired = errors.New("family name is required")
+
+	// ErrStudentInAnotherFamily is returned when adding a student who already belongs to a different family.
+	ErrStudentInAnotherFamily = errors.New("student already belongs to another family")
+
+	// ErrStudentNotInFamily is returned when removing a student who is not a member of the family.
+	ErrStudentNotInFamily = errors.New("student does not belong to this family")
+
+	// ErrStudentHasNoFamily is returned when a student has not been linked to a family.
+	ErrStudentHasNoFamily = errors.New("student is not linked to a family")
+
+	// ErrMergeSameFamily is returned when merging a family into itself.
+	ErrMergeSameFamily = errors.New("cannot merge a family into itself")
+
+	// ErrMergeSameGuardian is returned when merging a guardian into itself.
+	ErrMergeSameGuardian = errors.New("cannot merge a guardian into itself")
+
+	// ErrGuardiansInDifferentFamilies is returned when merging guardians of two families.
+	ErrGuardiansInDifferentFamilies = errors.New("guardians belong to different families; merge the families first")
+)
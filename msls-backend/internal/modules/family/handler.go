@@ -0,0 +1,554 @@
+// Package family provides household management linking siblings and their guardians.
+package family
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/middleware"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/response"
+)
+
+// Handler handles family-related HTTP requests.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new family handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListFamilies returns families for the tenant.
+// @Summary List families
+// @Description List families with their guardians and students
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param search query string false "Search by family, guardian or student name"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=FamilyListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/families [get]
+func (h *Handler) ListFamilies(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := ListFilter{
+		TenantID: tenantID,
+		Search:   c.Query("search"),
+	}
+	if !middleware.ParsePaging(c, &filter.Limit, &filter.Offset) {
+		return
+	}
+
+	families, total, err := h.service.ListFamilies(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, FamilyListResponse{
+		Families: ToFamilyResponses(families),
+		Total:    total,
+	})
+}
+
+// GetFamily returns a family by ID.
+// @Summary Get family by ID
+// @Description Get a family with its guardians and students
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Family ID" format(uuid)
+// @Success 200 {object} response.Success{data=FamilyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/families/{id} [get]
+func (h *Handler) GetFamily(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid family ID")
+	if !ok {
+		return
+	}
+
+	family, err := h.service.GetFamily(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyResponse(family))
+}
+
+// GetStudentFamily returns the family of a student.
+// @Summary Get student family
+// @Description Get the family a student belongs to, including siblings and shared guardians
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Student ID" format(uuid)
+// @Success 200 {object} response.Success{data=FamilyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/students/{id}/family [get]
+func (h *Handler) GetStudentFamily(c *gin.Context) {
+	tenantID, studentID, ok := middleware.TenantAndID(c, "id", "Invalid student ID")
+	if !ok {
+		return
+	}
+
+	family, err := h.service.GetStudentFamily(c.Request.Context(), tenantID, studentID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyResponse(family))
+}
+
+// CreateFamily creates a new family.
+// @Summary Create family
+// @Description Create a family from one or more students; their guardians are deduplicated by phone and email
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body CreateFamilyRequest true "Family details"
+// @Success 201 {object} response.Success{data=FamilyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/families [post]
+func (h *Handler) CreateFamily(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req CreateFamilyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	studentIDs := make([]uuid.UUID, 0, len(req.StudentIDs))
+	for _, idStr := range req.StudentIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid student ID"))
+			return
+		}
+		studentIDs = append(studentIDs, id)
+	}
+
+	family, err := h.service.CreateFamily(c.Request.Context(), CreateFamilyDTO{
+		TenantID:   tenantID,
+		Name:       req.Name,
+		Notes:      req.Notes,
+		StudentIDs: studentIDs,
+		CreatedBy:  &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToFamilyResponse(family))
+}
+
+// UpdateFamily updates a family.
+// @Summary Update family
+// @Description Update a family's name and notes
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Family ID" format(uuid)
+// @Param request body UpdateFamilyRequest true "Family updates"
+// @Success 200 {object} response.Success{data=FamilyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/families/{id} [put]
+func (h *Handler) UpdateFamily(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid family ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req UpdateFamilyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	family, err := h.service.UpdateFamily(c.Request.Context(), tenantID, id, UpdateFamilyDTO{
+		Name:      req.Name,
+		Notes:     req.Notes,
+		UpdatedBy: &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyResponse(family))
+}
+
+// DeleteFamily deletes a family.
+// @Summary Delete family
+// @Description Delete a family; its students and guardians are kept but unlinked
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Family ID" format(uuid)
+// @Success 204 "No Content"
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/families/{id} [delete]
+func (h *Handler) DeleteFamily(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid family ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteFamily(c.Request.Context(), tenantID, id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// AddStudent adds a student to a family.
+// @Summary Add student to family
+// @Description Add a student to a family, linking their guardians to matching family guardians
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Family ID" format(uuid)
+// @Param request body AddStudentRequest true "Student"
+// @Success 200 {object} response.Success{data=FamilyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/families/{id}/students [post]
+func (h *Handler) AddStudent(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid family ID")
+	if !ok {
+		return
+	}
+
+	var req AddStudentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+	studentID, _ := uuid.Parse(req.StudentID)
+
+	family, err := h.service.AddStudent(c.Request.Context(), tenantID, id, studentID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyResponse(family))
+}
+
+// RemoveStudent removes a student from a family.
+// @Summary Remove student from family
+// @Description Remove a student from a family
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Family ID" format(uuid)
+// @Param studentId path string true "Student ID" format(uuid)
+// @Success 200 {object} response.Success{data=FamilyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/families/{id}/students/{studentId} [delete]
+func (h *Handler) RemoveStudent(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid family ID")
+	if !ok {
+		return
+	}
+
+	studentID, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid student ID"))
+		return
+	}
+
+	family, err := h.service.RemoveStudent(c.Request.Context(), tenantID, id, studentID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyResponse(family))
+}
+
+// SyncFamily links guardians added to the family's students since they joined.
+// @Summary Sync family guardians
+// @Description Link student guardians that are not yet attached to a family guardian
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Family ID" format(uuid)
+// @Success 200 {object} response.Success{data=FamilyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/families/{id}/sync [post]
+func (h *Handler) SyncFamily(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid family ID")
+	if !ok {
+		return
+	}
+
+	family, err := h.service.SyncFamily(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyResponse(family))
+}
+
+// MergeFamilies merges another family into this one.
+// @Summary Merge families
+// @Description Move the source family's students and guardians into this family and delete the source
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Target family ID" format(uuid)
+// @Param request body MergeFamilyRequest true "Source family"
+// @Success 200 {object} response.Success{data=FamilyResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/families/{id}/merge [post]
+func (h *Handler) MergeFamilies(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid family ID")
+	if !ok {
+		return
+	}
+
+	var req MergeFamilyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+	sourceID, _ := uuid.Parse(req.SourceFamilyID)
+
+	family, err := h.service.MergeFamilies(c.Request.Context(), tenantID, id, sourceID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyResponse(family))
+}
+
+// FindDuplicates lists family guardians sharing a phone number or email.
+// @Summary Find duplicate guardians
+// @Description List family guardians that share a phone number or email
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {object} response.Success{data=[]DuplicateGroupResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/families/duplicates [get]
+func (h *Handler) FindDuplicates(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	groups, err := h.service.FindDuplicates(c.Request.Context(), tenantID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToDuplicateGroupResponses(groups))
+}
+
+// AutoLink groups unlinked students into families by shared guardian contacts.
+// @Summary Auto-link families
+// @Description Link students not yet in a family by their guardians' phone numbers and emails
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {object} response.Success{data=AutoLinkResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/families/auto-link [post]
+func (h *Handler) AutoLink(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	result, err := h.service.AutoLink(c.Request.Context(), tenantID, &userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	conflicts := make([]string, len(result.Conflicts))
+	for i, id := range result.Conflicts {
+		conflicts[i] = id.String()
+	}
+
+	response.OK(c, AutoLinkResponse{
+		FamiliesCreated: result.FamiliesCreated,
+		StudentsLinked:  result.StudentsLinked,
+		Conflicts:       conflicts,
+	})
+}
+
+// MergeGuardians merges a duplicate guardian into this one.
+// @Summary Merge family guardians
+// @Description Merge a duplicate guardian of the same family into this guardian
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Family guardian ID to keep" format(uuid)
+// @Param request body MergeGuardianRequest true "Duplicate guardian"
+// @Success 200 {object} response.Success{data=FamilyGuardianResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/family-guardians/{id}/merge [post]
+func (h *Handler) MergeGuardians(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid guardian ID")
+	if !ok {
+		return
+	}
+
+	var req MergeGuardianRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+	duplicateID, _ := uuid.Parse(req.DuplicateID)
+
+	guardian, err := h.service.MergeGuardians(c.Request.Context(), tenantID, id, duplicateID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyGuardianResponse(guardian))
+}
+
+// LinkStaff links a family guardian to a staff member.
+// @Summary Link guardian to staff
+// @Description Mark a family guardian as a staff member so the family's students count as staff wards
+// @Tags Families
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Family guardian ID" format(uuid)
+// @Param request body LinkStaffRequest true "Staff member; null removes the link"
+// @Success 200 {object} response.Success{data=FamilyGuardianResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/family-guardians/{id}/staff [put]
+func (h *Handler) LinkStaff(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid guardian ID")
+	if !ok {
+		return
+	}
+
+	var req LinkStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	var staffID *uuid.UUID
+	if req.StaffID != nil {
+		parsed, _ := uuid.Parse(*req.StaffID)
+		staffID = &parsed
+	}
+
+	guardian, err := h.service.LinkStaff(c.Request.Context(), tenantID, id, staffID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToFamilyGuardianResponse(guardian))
+}
+
+// handleServiceError converts service errors to API errors.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrFamilyNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Family not found"))
+	case errors.Is(err, ErrFamilyGuardianNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Family guardian not found"))
+	case errors.Is(err, ErrStudentNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Student not found"))
+	case errors.Is(err, ErrStaffNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff member not found"))
+	case errors.Is(err, ErrStudentHasNoFamily):
+		apperrors.Abort(c, apperrors.NotFound("Student is not linked to a family"))
+	case errors.Is(err, ErrNameRequired):
+		apperrors.Abort(c, apperrors.BadRequest("Family name is required"))
+	case errors.Is(err, ErrStudentInAnotherFamily):
+		apperrors.Abort(c, apperrors.Conflict("Student already belongs to another family"))
+	case errors.Is(err, ErrStudentNotInFamily):
+		apperrors.Abort(c, apperrors.BadRequest("Student does not belong to this family"))
+	case errors.Is(err, ErrMergeSameFamily):
+		apperrors.Abort(c, apperrors.BadRequest("Cannot merge a family into itself"))
+	case errors.Is(err, ErrMergeSameGuardian):
+		apperrors.Abort(c, apperrors.BadRequest("Cannot merge a guardian into itself"))
+	case errors.Is(err, ErrGuardiansInDifferentFamilies):
+		apperrors.Abort(c, apperrors.BadRequest("Guardians belong to different families; merge the families first"))
+	default:
+		logger.Error("Family operation error", zap.Error(err))
+		apperrors.Abort(c, apperrors.InternalError("Failed to process family request"))
+	}
+}
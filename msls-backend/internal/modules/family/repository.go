@@ -0,0 +1,437 @@
+// Package family provides household management linking siblings and their guardians.
+package family
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// Repository handles database operations for families.
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new family repository.
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// =========================================================================
+// Family Operations
+// =========================================================================
+
+// ListFamilies retrieves families with their guardians and students.
+func (r *Repository) ListFamilies(ctx context.Context, filter ListFilter) ([]models.Family, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Family{}).Where("families.tenant_id = ?", filter.TenantID)
+
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where(`families.name ILIKE ? OR EXISTS (
+			SELECT 1 FROM family_guardians g
+			WHERE g.family_id = families.id AND (g.first_name ILIKE ? OR g.last_name ILIKE ? OR g.phone LIKE ? OR g.email ILIKE ?)
+		)`, search, search, search, search, search)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var families []models.Family
+	err := query.
+		Preload("Guardians", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Students", func(db *gorm.DB) *gorm.DB { return db.Order("date_of_birth ASC") }).
+		Order("families.name ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&families).Error
+	return families, total, err
+}
+
+// GetFamilyByID retrieves a family with its guardians and students.
+func (r *Repository) GetFamilyByID(ctx context.Context, tenantID, id uuid.UUID) (*models.Family, error) {
+	var family models.Family
+	err := r.db.WithContext(ctx).
+		Preload("Guardians", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Students", func(db *gorm.DB) *gorm.DB { return db.Order("date_of_birth ASC") }).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&family).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, err
+	}
+	return &family, nil
+}
+
+// CreateFamily creates a family and adds the given students to it.
+func (r *Repository) CreateFamily(ctx context.Context, family *models.Family, studentIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(family).Error; err != nil {
+			return err
+		}
+		for _, studentID := range studentIDs {
+			if err := addStudent(tx, family, studentID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateFamily updates a family in the database.
+func (r *Repository) UpdateFamily(ctx context.Context, family *models.Family) error {
+	return r.db.WithContext(ctx).
+		Model(family).
+		Updates(map[string]interface{}{
+			"name":       family.Name,
+			"notes":      family.Notes,
+			"updated_by": family.UpdatedBy,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// DeleteFamily unlinks a family's students and guardians and deletes it.
+func (r *Repository) DeleteFamily(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Student{}).
+			Where("tenant_id = ? AND family_id = ?", tenantID, id).
+			Update("family_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.StudentGuardian{}).
+			Where("tenant_id = ? AND family_guardian_id IN (?)", tenantID,
+				tx.Model(&models.FamilyGuardian{}).Select("id").Where("family_id = ?", id)).
+			Update("family_guardian_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tenant_id = ? AND family_id = ?", tenantID, id).
+			Delete(&models.FamilyGuardian{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&models.Family{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrFamilyNotFound
+		}
+		return nil
+	})
+}
+
+// AddStudent links a student and their guardians to a family.
+func (r *Repository) AddStudent(ctx context.Context, family *models.Family, studentID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return addStudent(tx, family, studentID)
+	})
+}
+
+// RemoveStudent unlinks a student from a family. Family guardians no longer
+// shared with any remaining student guardian are removed.
+func (r *Repository) RemoveStudent(ctx context.Context, family *models.Family, studentID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Student{}).
+			Where("tenant_id = ? AND id = ? AND family_id = ?", family.TenantID, studentID, family.ID).
+			Update("family_id", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStudentNotInFamily
+		}
+		if err := tx.Model(&models.StudentGuardian{}).
+			Where("tenant_id = ? AND student_id = ?", family.TenantID, studentID).
+			Update("family_guardian_id", nil).Error; err != nil {
+			return err
+		}
+		return deleteOrphanGuardians(tx, family)
+	})
+}
+
+// SyncFamily links every student guardian of the family's students to a family guardian.
+func (r *Repository) SyncFamily(ctx context.Context, family *models.Family) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var studentIDs []uuid.UUID
+		if err := tx.Model(&models.Student{}).
+			Where("tenant_id = ? AND family_id = ?", family.TenantID, family.ID).
+			Pluck("id", &studentIDs).Error; err != nil {
+			return err
+		}
+		for _, studentID := range studentIDs {
+			if err := syncStudentGuardians(tx, family, studentID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MergeFamilies moves the source family's students and guardians into the
+// target, folding duplicate guardians together, and deletes the source.
+func (r *Repository) MergeFamilies(ctx context.Context, target, source *models.Family) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Student{}).
+			Where("tenant_id = ? AND family_id = ?", source.TenantID, source.ID).
+			Update("family_id", target.ID).Error; err != nil {
+			return err
+		}
+
+		var targetGuardians []models.FamilyGuardian
+		if err := tx.Where("tenant_id = ? AND family_id = ?", target.TenantID, target.ID).
+			Order("created_at ASC").
+			Find(&targetGuardians).Error; err != nil {
+			return err
+		}
+
+		var sourceGuardians []models.FamilyGuardian
+		if err := tx.Where("tenant_id = ? AND family_id = ?", source.TenantID, source.ID).
+			Order("created_at ASC").
+			Find(&sourceGuardians).Error; err != nil {
+			return err
+		}
+
+		for i := range sourceGuardians {
+			g := &sourceGuardians[i]
+			if idx := matchGuardian(targetGuardians, g.Phone, g.Email); idx >= 0 {
+				if err := mergeGuardian(tx, &targetGuardians[idx], g); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Model(g).Update("family_id", target.ID).Error; err != nil {
+				return err
+			}
+			g.FamilyID = target.ID
+			targetGuardians = append(targetGuardians, *g)
+		}
+
+		return tx.Where("tenant_id = ? AND id = ?", source.TenantID, source.ID).
+			Delete(&models.Family{}).Error
+	})
+}
+
+// FindStudentFamilyID returns the family a student belongs to, if any.
+func (r *Repository) FindStudentFamilyID(ctx context.Context, tenantID, studentID uuid.UUID) (*uuid.UUID, error) {
+	var student models.Student
+	err := r.db.WithContext(ctx).
+		Select("id", "family_id").
+		Where("id = ? AND tenant_id = ?", studentID, tenantID).
+		First(&student).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+		return nil, err
+	}
+	return student.FamilyID, nil
+}
+
+// =========================================================================
+// Family Guardian Operations
+// =========================================================================
+
+// GetGuardianByID retrieves a family guardian by ID.
+func (r *Repository) GetGuardianByID(ctx context.Context, tenantID, id uuid.UUID) (*models.FamilyGuardian, error) {
+	var guardian models.FamilyGuardian
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&guardian).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFamilyGuardianNotFound
+		}
+		return nil, err
+	}
+	return &guardian, nil
+}
+
+// ListGuardians retrieves every family guardian of a tenant.
+func (r *Repository) ListGuardians(ctx context.Context, tenantID uuid.UUID) ([]models.FamilyGuardian, error) {
+	var guardians []models.FamilyGuardian
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at ASC").
+		Find(&guardians).Error
+	return guardians, err
+}
+
+// MergeGuardians folds a duplicate guardian into the one being kept.
+func (r *Repository) MergeGuardians(ctx context.Context, keep, duplicate *models.FamilyGuardian) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return mergeGuardian(tx, keep, duplicate)
+	})
+}
+
+// UpdateGuardianStaff links a family guardian to a staff member, or unlinks it when staffID is nil.
+func (r *Repository) UpdateGuardianStaff(ctx context.Context, guardian *models.FamilyGuardian, staffID *uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(guardian).
+		Updates(map[string]interface{}{
+			"staff_id":   staffID,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// StaffExists checks if a staff member exists.
+func (r *Repository) StaffExists(ctx context.Context, tenantID, staffID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Staff{}).
+		Where("id = ? AND tenant_id = ?", staffID, tenantID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// =========================================================================
+// Auto-linking
+// =========================================================================
+
+// ListUnlinkedStudentGuardians retrieves guardians of active students not yet in a family.
+func (r *Repository) ListUnlinkedStudentGuardians(ctx context.Context, tenantID uuid.UUID) ([]models.StudentGuardian, error) {
+	var guardians []models.StudentGuardian
+	err := r.db.WithContext(ctx).
+		Joins("JOIN students s ON s.id = student_guardians.student_id").
+		Where("student_guardians.tenant_id = ? AND s.family_id IS NULL AND s.deleted_at IS NULL", tenantID).
+		Order("student_guardians.is_primary DESC, student_guardians.created_at ASC").
+		Find(&guardians).Error
+	return guardians, err
+}
+
+// ApplyLinkPlan creates the planned families and links each planned student in one transaction.
+func (r *Repository) ApplyLinkPlan(ctx context.Context, tenantID uuid.UUID, plan []plannedFamily, createdBy *uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, p := range plan {
+			family := &models.Family{ID: p.FamilyID, TenantID: tenantID, Name: p.Name, CreatedBy: createdBy, UpdatedBy: createdBy}
+			if p.FamilyID == uuid.Nil {
+				if err := tx.Create(family).Error; err != nil {
+					return err
+				}
+			}
+			for _, studentID := range p.StudentIDs {
+				if err := addStudent(tx, family, studentID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// =========================================================================
+// Transaction helpers
+// =========================================================================
+
+// addStudent sets a student's family and links their guardians to it.
+func addStudent(tx *gorm.DB, family *models.Family, studentID uuid.UUID) error {
+	var student models.Student
+	if err := tx.Select("id", "family_id").
+		Where("id = ? AND tenant_id = ?", studentID, family.TenantID).
+		First(&student).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrStudentNotFound
+		}
+		return err
+	}
+	if student.FamilyID != nil && *student.FamilyID != family.ID {
+		return ErrStudentInAnotherFamily
+	}
+
+	if err := tx.Model(&models.Student{}).
+		Where("id = ?", studentID).
+		Update("family_id", family.ID).Error; err != nil {
+		return err
+	}
+	return syncStudentGuardians(tx, family, studentID)
+}
+
+// syncStudentGuardians links each guardian of a student to the family guardian
+// with the same phone or email, creating family guardians for new contacts.
+func syncStudentGuardians(tx *gorm.DB, family *models.Family, studentID uuid.UUID) error {
+	var familyGuardians []models.FamilyGuardian
+	if err := tx.Where("tenant_id = ? AND family_id = ?", family.TenantID, family.ID).
+		Order("created_at ASC").
+		Find(&familyGuardians).Error; err != nil {
+		return err
+	}
+
+	var studentGuardians []models.StudentGuardian
+	if err := tx.Where("tenant_id = ? AND student_id = ?", family.TenantID, studentID).
+		Order("is_primary DESC, created_at ASC").
+		Find(&studentGuardians).Error; err != nil {
+		return err
+	}
+
+	for _, sg := range studentGuardians {
+		idx := matchGuardian(familyGuardians, sg.Phone, sg.Email)
+		if idx < 0 {
+			fg := models.FamilyGuardian{
+				ID:        uuid.New(),
+				TenantID:  family.TenantID,
+				FamilyID:  family.ID,
+				FirstName: sg.FirstName,
+				LastName:  sg.LastName,
+				Phone:     models.NormalizeContactPhone(sg.Phone),
+				Email:     models.NormalizeContactEmail(sg.Email),
+				UserID:    sg.UserID,
+			}
+			if err := tx.Create(&fg).Error; err != nil {
+				return err
+			}
+			familyGuardians = append(familyGuardians, fg)
+			idx = len(familyGuardians) - 1
+		} else if fill := fillContact(&familyGuardians[idx], sg.Phone, sg.Email, sg.UserID); len(fill) > 0 {
+			if err := tx.Model(&familyGuardians[idx]).Updates(fill).Error; err != nil {
+				return err
+			}
+		}
+
+		if sg.FamilyGuardianID == nil || *sg.FamilyGuardianID != familyGuardians[idx].ID {
+			if err := tx.Model(&models.StudentGuardian{}).
+				Where("id = ?", sg.ID).
+				Update("family_guardian_id", familyGuardians[idx].ID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeGuardian re-points the duplicate's student guardians at keep, copies
+// contact details keep lacks and deletes the duplicate.
+func mergeGuardian(tx *gorm.DB, keep, duplicate *models.FamilyGuardian) error {
+	if err := tx.Model(&models.StudentGuardian{}).
+		Where("tenant_id = ? AND family_guardian_id = ?", duplicate.TenantID, duplicate.ID).
+		Update("family_guardian_id", keep.ID).Error; err != nil {
+		return err
+	}
+
+	fill := fillContact(keep, duplicate.Phone, duplicate.Email, duplicate.UserID)
+	if keep.StaffID == nil && duplicate.StaffID != nil {
+		keep.StaffID = duplicate.StaffID
+		fill["staff_id"] = duplicate.StaffID
+	}
+	if err := tx.Delete(duplicate).Error; err != nil {
+		return err
+	}
+	if len(fill) > 0 {
+		fill["updated_at"] = time.Now()
+		return tx.Model(keep).Updates(fill).Error
+	}
+	return nil
+}
+
+// deleteOrphanGuardians removes family guardians no student guardian points at,
+// keeping those linked to staff so staff-ward links survive.
+func deleteOrphanGuardians(tx *gorm.DB, family *models.Family) error {
+	return tx.Where("tenant_id = ? AND family_id = ? AND staff_id IS NULL", family.TenantID, family.ID).
+		Where("NOT EXISTS (SELECT 1 FROM student_guardians sg WHERE sg.family_guardian_id = family_guardians.id)").
+		Delete(&models.FamilyGuardian{}).Error
+}
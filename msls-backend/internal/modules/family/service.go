@@ -0,0 +1,480 @@
+// Package family provides household management linking siblings and their guardians.
+package family
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/logger"
+)
+
+// Default and maximum page sizes for listing families.
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// Service handles business logic for families.
+type Service struct {
+	repo *Repository
+}
+
+// NewService creates a new family service.
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// =========================================================================
+// Family Operations
+// =========================================================================
+
+// ListFamilies retrieves families matching the filter.
+func (s *Service) ListFamilies(ctx context.Context, filter ListFilter) ([]models.Family, int64, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	filter.Search = strings.TrimSpace(filter.Search)
+	return s.repo.ListFamilies(ctx, filter)
+}
+
+// GetFamily retrieves a family with its guardians and students.
+func (s *Service) GetFamily(ctx context.Context, tenantID, id uuid.UUID) (*models.Family, error) {
+	return s.repo.GetFamilyByID(ctx, tenantID, id)
+}
+
+// GetStudentFamily retrieves the family a student belongs to.
+func (s *Service) GetStudentFamily(ctx context.Context, tenantID, studentID uuid.UUID) (*models.Family, error) {
+	familyID, err := s.repo.FindStudentFamilyID(ctx, tenantID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if familyID == nil {
+		return nil, ErrStudentHasNoFamily
+	}
+	return s.repo.GetFamilyByID(ctx, tenantID, *familyID)
+}
+
+// CreateFamily creates a family, adding the given students and their guardians.
+func (s *Service) CreateFamily(ctx context.Context, dto CreateFamilyDTO) (*models.Family, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+
+	family := &models.Family{
+		ID:        uuid.New(),
+		TenantID:  dto.TenantID,
+		Name:      name,
+		Notes:     dto.Notes,
+		CreatedBy: dto.CreatedBy,
+		UpdatedBy: dto.CreatedBy,
+	}
+
+	if err := s.repo.CreateFamily(ctx, family, dto.StudentIDs); err != nil {
+		logger.Error("Failed to create family", zap.Error(err))
+		return nil, err
+	}
+
+	return s.repo.GetFamilyByID(ctx, dto.TenantID, family.ID)
+}
+
+// UpdateFamily updates a family's name and notes.
+func (s *Service) UpdateFamily(ctx context.Context, tenantID, id uuid.UUID, dto UpdateFamilyDTO) (*models.Family, error) {
+	family, err := s.repo.GetFamilyByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, ErrNameRequired
+		}
+		family.Name = name
+	}
+	if dto.Notes != nil {
+		family.Notes = *dto.Notes
+	}
+	family.UpdatedBy = dto.UpdatedBy
+
+	if err := s.repo.UpdateFamily(ctx, family); err != nil {
+		logger.Error("Failed to update family",
+			zap.String("family_id", id.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return s.repo.GetFamilyByID(ctx, tenantID, id)
+}
+
+// DeleteFamily deletes a family. Its students and guardians are kept but unlinked.
+func (s *Service) DeleteFamily(ctx context.Context, tenantID, id uuid.UUID) error {
+	return s.repo.DeleteFamily(ctx, tenantID, id)
+}
+
+// AddStudent adds a student and their guardians to a family. Guardians with the
+// same phone number or email as an existing family guardian are linked to it.
+func (s *Service) AddStudent(ctx context.Context, tenantID, familyID, studentID uuid.UUID) (*models.Family, error) {
+	family, err := s.repo.GetFamilyByID(ctx, tenantID, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddStudent(ctx, family, studentID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetFamilyByID(ctx, tenantID, familyID)
+}
+
+// RemoveStudent removes a student from a family.
+func (s *Service) RemoveStudent(ctx context.Context, tenantID, familyID, studentID uuid.UUID) (*models.Family, error) {
+	family, err := s.repo.GetFamilyByID(ctx, tenantID, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RemoveStudent(ctx, family, studentID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetFamilyByID(ctx, tenantID, familyID)
+}
+
+// SyncFamily links guardians added to the family's students since they joined.
+func (s *Service) SyncFamily(ctx context.Context, tenantID, familyID uuid.UUID) (*models.Family, error) {
+	family, err := s.repo.GetFamilyByID(ctx, tenantID, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SyncFamily(ctx, family); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetFamilyByID(ctx, tenantID, familyID)
+}
+
+// MergeFamilies merges the source family into the target family.
+func (s *Service) MergeFamilies(ctx context.Context, tenantID, targetID, sourceID uuid.UUID) (*models.Family, error) {
+	if targetID == sourceID {
+		return nil, ErrMergeSameFamily
+	}
+
+	target, err := s.repo.GetFamilyByID(ctx, tenantID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.repo.GetFamilyByID(ctx, tenantID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.MergeFamilies(ctx, target, source); err != nil {
+		logger.Error("Failed to merge families",
+			zap.String("target_id", targetID.String()),
+			zap.String("source_id", sourceID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return s.repo.GetFamilyByID(ctx, tenantID, targetID)
+}
+
+// =========================================================================
+// Guardian Operations
+// =========================================================================
+
+// FindDuplicates lists family guardians that share a phone number or email.
+func (s *Service) FindDuplicates(ctx context.Context, tenantID uuid.UUID) ([]DuplicateGroup, error) {
+	guardians, err := s.repo.ListGuardians(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return findDuplicates(guardians), nil
+}
+
+// MergeGuardians merges a duplicate guardian into the guardian being kept.
+// Both must belong to the same family.
+func (s *Service) MergeGuardians(ctx context.Context, tenantID, keepID, duplicateID uuid.UUID) (*models.FamilyGuardian, error) {
+	if keepID == duplicateID {
+		return nil, ErrMergeSameGuardian
+	}
+
+	keep, err := s.repo.GetGuardianByID(ctx, tenantID, keepID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.repo.GetGuardianByID(ctx, tenantID, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if keep.FamilyID != duplicate.FamilyID {
+		return nil, ErrGuardiansInDifferentFamilies
+	}
+
+	if err := s.repo.MergeGuardians(ctx, keep, duplicate); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetGuardianByID(ctx, tenantID, keepID)
+}
+
+// LinkStaff marks a family guardian as a staff member, making the family's
+// students staff wards. A nil staffID removes the link.
+func (s *Service) LinkStaff(ctx context.Context, tenantID, guardianID uuid.UUID, staffID *uuid.UUID) (*models.FamilyGuardian, error) {
+	guardian, err := s.repo.GetGuardianByID(ctx, tenantID, guardianID)
+	if err != nil {
+		return nil, err
+	}
+
+	if staffID != nil {
+		exists, err := s.repo.StaffExists(ctx, tenantID, *staffID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrStaffNotFound
+		}
+	}
+
+	if err := s.repo.UpdateGuardianStaff(ctx, guardian, staffID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetGuardianByID(ctx, tenantID, guardianID)
+}
+
+// =========================================================================
+// Auto-linking
+// =========================================================================
+
+// AutoLink groups students not yet in a family by their guardians' phone
+// numbers and emails. Students matching an existing family guardian join that
+// family; unmatched students sharing a guardian form a new family. Students
+// without a shared guardian are left alone.
+func (s *Service) AutoLink(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID) (*AutoLinkResult, error) {
+	unlinked, err := s.repo.ListUnlinkedStudentGuardians(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.ListGuardians(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, conflicts := planLinks(unlinked, existing)
+
+	result := &AutoLinkResult{Conflicts: conflicts}
+	for _, p := range plan {
+		if p.FamilyID == uuid.Nil {
+			result.FamiliesCreated++
+		}
+		result.StudentsLinked += len(p.StudentIDs)
+	}
+	if len(plan) == 0 {
+		return result, nil
+	}
+
+	if err := s.repo.ApplyLinkPlan(ctx, tenantID, plan, createdBy); err != nil {
+		logger.Error("Failed to auto-link families",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// IsStaffWard reports whether any guardian of the family is linked to a staff member.
+func IsStaffWard(family *models.Family) bool {
+	for _, g := range family.Guardians {
+		if g.StaffID != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// =========================================================================
+// Matching helpers
+// =========================================================================
+
+// contactKeys returns the matching keys for a phone number and email.
+func contactKeys(phone, email string) []string {
+	var keys []string
+	if p := models.NormalizeContactPhone(phone); p != "" {
+		keys = append(keys, "phone:"+p)
+	}
+	if e := models.NormalizeContactEmail(email); e != "" {
+		keys = append(keys, "email:"+e)
+	}
+	return keys
+}
+
+// matchGuardian returns the index of the guardian with the same phone number
+// or email, or -1 when none matches.
+func matchGuardian(guardians []models.FamilyGuardian, phone, email string) int {
+	keys := contactKeys(phone, email)
+	for i := range guardians {
+		for _, gk := range contactKeys(guardians[i].Phone, guardians[i].Email) {
+			for _, k := range keys {
+				if gk == k {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// fillContact copies a phone number, email and portal user onto the guardian
+// where it has none, returning the column updates to persist.
+func fillContact(guardian *models.FamilyGuardian, phone, email string, userID *uuid.UUID) map[string]interface{} {
+	updates := make(map[string]interface{})
+	if guardian.Phone == "" {
+		if p := models.NormalizeContactPhone(phone); p != "" {
+			guardian.Phone = p
+			updates["phone"] = p
+		}
+	}
+	if guardian.Email == "" {
+		if e := models.NormalizeContactEmail(email); e != "" {
+			guardian.Email = e
+			updates["email"] = e
+		}
+	}
+	if guardian.UserID == nil && userID != nil {
+		guardian.UserID = userID
+		updates["user_id"] = userID
+	}
+	return updates
+}
+
+// findDuplicates groups guardians sharing a phone number or email.
+func findDuplicates(guardians []models.FamilyGuardian) []DuplicateGroup {
+	groups := make(map[string][]models.FamilyGuardian)
+	for _, g := range guardians {
+		for _, key := range contactKeys(g.Phone, g.Email) {
+			groups[key] = append(groups[key], g)
+		}
+	}
+
+	var duplicates []DuplicateGroup
+	for key, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		matchedOn, value, _ := strings.Cut(key, ":")
+		duplicates = append(duplicates, DuplicateGroup{MatchedOn: matchedOn, Value: value, Guardians: members})
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].MatchedOn != duplicates[j].MatchedOn {
+			return duplicates[i].MatchedOn > duplicates[j].MatchedOn
+		}
+		return duplicates[i].Value < duplicates[j].Value
+	})
+	return duplicates
+}
+
+// planLinks works out which unlinked students join existing families and which
+// form new ones. Students connected through shared guardian contacts form one
+// group; a group matching one existing family joins it, a group matching
+// several is reported as a conflict, and an unmatched group of two or more
+// students becomes a new family named after its first guardian.
+func planLinks(unlinked []models.StudentGuardian, existing []models.FamilyGuardian) ([]plannedFamily, []uuid.UUID) {
+	familyByKey := make(map[string]uuid.UUID)
+	for _, g := range existing {
+		for _, key := range contactKeys(g.Phone, g.Email) {
+			if _, ok := familyByKey[key]; !ok {
+				familyByKey[key] = g.FamilyID
+			}
+		}
+	}
+
+	// Union students sharing a contact key
+	parent := make(map[uuid.UUID]uuid.UUID)
+	var find func(uuid.UUID) uuid.UUID
+	find = func(id uuid.UUID) uuid.UUID {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+
+	var order []uuid.UUID
+	studentByKey := make(map[string]uuid.UUID)
+	keysByStudent := make(map[uuid.UUID][]string)
+	firstGuardian := make(map[uuid.UUID]models.StudentGuardian)
+	for _, g := range unlinked {
+		if _, ok := parent[g.StudentID]; !ok {
+			parent[g.StudentID] = g.StudentID
+			order = append(order, g.StudentID)
+			firstGuardian[g.StudentID] = g
+		}
+		for _, key := range contactKeys(g.Phone, g.Email) {
+			keysByStudent[g.StudentID] = append(keysByStudent[g.StudentID], key)
+			if other, ok := studentByKey[key]; ok {
+				parent[find(g.StudentID)] = find(other)
+			} else {
+				studentByKey[key] = g.StudentID
+			}
+		}
+	}
+
+	groups := make(map[uuid.UUID][]uuid.UUID)
+	var roots []uuid.UUID
+	for _, id := range order {
+		root := find(id)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], id)
+	}
+
+	var plan []plannedFamily
+	var conflicts []uuid.UUID
+	for _, root := range roots {
+		members := groups[root]
+		families := make(map[uuid.UUID]bool)
+		for _, id := range members {
+			for _, key := range keysByStudent[id] {
+				if familyID, ok := familyByKey[key]; ok {
+					families[familyID] = true
+				}
+			}
+		}
+
+		switch {
+		case len(families) > 1:
+			conflicts = append(conflicts, members...)
+		case len(families) == 1:
+			for familyID := range families {
+				plan = append(plan, plannedFamily{FamilyID: familyID, StudentIDs: members})
+			}
+		case len(members) > 1:
+			plan = append(plan, plannedFamily{Name: familyName(firstGuardian[members[0]]), StudentIDs: members})
+		}
+	}
+	return plan, conflicts
+}
+
+// familyName names a new family after a guardian's surname.
+func familyName(guardian models.StudentGuardian) string {
+	name := strings.TrimSpace(guardian.LastName)
+	if name == "" {
+		name = strings.TrimSpace(guardian.FirstName)
+	}
+	return name + " Family"
+}
@@ -0,0 +1,102 @@
+package family
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func TestNormalizeContactPhone(t *testing.T) {
+	assert.Equal(t, "9876543210", models.NormalizeContactPhone("+91 98765-43210"))
+	assert.Equal(t, "9876543210", models.NormalizeContactPhone("09876543210"))
+	assert.Equal(t, "12345", models.NormalizeContactPhone("12345"))
+	assert.Equal(t, "", models.NormalizeContactPhone(" - "))
+	assert.Equal(t, "ravi@example.com", models.NormalizeContactEmail("  Ravi@Example.COM "))
+}
+
+func TestMatchGuardian(t *testing.T) {
+	guardians := []models.FamilyGuardian{
+		{FirstName: "Ravi", Phone: "9876543210"},
+		{FirstName: "Meera", Email: "meera@example.com"},
+	}
+
+	assert.Equal(t, 0, matchGuardian(guardians, "+91 98765 43210", ""))
+	assert.Equal(t, 1, matchGuardian(guardians, "", "MEERA@example.com"))
+	assert.Equal(t, -1, matchGuardian(guardians, "9999999999", "other@example.com"))
+	assert.Equal(t, -1, matchGuardian(guardians, "", ""))
+}
+
+func TestFillContact(t *testing.T) {
+	userID := uuid.New()
+	guardian := &models.FamilyGuardian{Phone: "9876543210"}
+
+	updates := fillContact(guardian, "1111111111", " Ravi@Example.com", &userID)
+
+	assert.Equal(t, "9876543210", guardian.Phone)
+	assert.Equal(t, "ravi@example.com", guardian.Email)
+	assert.Equal(t, &userID, guardian.UserID)
+	assert.Equal(t, map[string]interface{}{"email": "ravi@example.com", "user_id": &userID}, updates)
+
+	assert.Empty(t, fillContact(guardian, "2222222222", "other@example.com", nil))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	familyID := uuid.New()
+	guardians := []models.FamilyGuardian{
+		{ID: uuid.New(), FamilyID: familyID, Phone: "9876543210", Email: "ravi@example.com"},
+		{ID: uuid.New(), FamilyID: familyID, Phone: "9876543210"},
+		{ID: uuid.New(), FamilyID: uuid.New(), Email: "ravi@example.com"},
+		{ID: uuid.New(), FamilyID: uuid.New(), Phone: "1111111111"},
+	}
+
+	groups := findDuplicates(guardians)
+
+	require.Len(t, groups, 2)
+	assert.Equal(t, "phone", groups[0].MatchedOn)
+	assert.Equal(t, "9876543210", groups[0].Value)
+	assert.Len(t, groups[0].Guardians, 2)
+	assert.Equal(t, "email", groups[1].MatchedOn)
+	assert.Len(t, groups[1].Guardians, 2)
+}
+
+func TestPlanLinks(t *testing.T) {
+	existingFamily := uuid.New()
+	otherFamily := uuid.New()
+	existing := []models.FamilyGuardian{
+		{FamilyID: existingFamily, Phone: "9000000001"},
+		{FamilyID: existingFamily, Email: "raj@example.com"},
+		{FamilyID: otherFamily, Email: "other@example.com"},
+	}
+
+	asha, arjun := uuid.New(), uuid.New()
+	kiran := uuid.New()
+	dev := uuid.New()
+	sara := uuid.New()
+	unlinked := []models.StudentGuardian{
+		// Asha and Arjun share a mother; Arjun's father is new
+		{StudentID: asha, FirstName: "Priya", LastName: "Sharma", Phone: "+91 98765 43210"},
+		{StudentID: arjun, FirstName: "Priya", LastName: "Sharma", Phone: "9876543210"},
+		{StudentID: arjun, FirstName: "Amit", LastName: "Sharma", Email: "amit@example.com"},
+		// Kiran's father is already in a family
+		{StudentID: kiran, FirstName: "Vikram", LastName: "Rao", Phone: "09000000001"},
+		// Dev matches both existing families
+		{StudentID: dev, FirstName: "Raj", LastName: "Nair", Email: "raj@example.com"},
+		{StudentID: dev, FirstName: "Lata", LastName: "Nair", Email: "OTHER@example.com"},
+		// Sara shares nothing
+		{StudentID: sara, FirstName: "Neha", LastName: "Gupta", Phone: "9111111111"},
+	}
+
+	plan, conflicts := planLinks(unlinked, existing)
+
+	require.Len(t, plan, 2)
+	assert.Equal(t, uuid.Nil, plan[0].FamilyID)
+	assert.Equal(t, "Sharma Family", plan[0].Name)
+	assert.ElementsMatch(t, []uuid.UUID{asha, arjun}, plan[0].StudentIDs)
+	assert.Equal(t, existingFamily, plan[1].FamilyID)
+	assert.Equal(t, []uuid.UUID{kiran}, plan[1].StudentIDs)
+	assert.Equal(t, []uuid.UUID{dev}, conflicts)
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"msls-backend/internal/modules/medicalalert"
 )
 
 // HallTicketStatus represents the status of a hall ticket.
@@ -41,6 +43,7 @@ type HallTicket struct {
 	ClassName         string `json:"className,omitempty"`
 	SectionName       string `json:"sectionName,omitempty"`
 	ExaminationName   string `json:"examinationName,omitempty"`
+	MedicalAlert      *medicalalert.Badge `json:"medicalAlert,omitempty"`
 }
 
 // HallTicketTemplate represents a template for generating hall tickets.
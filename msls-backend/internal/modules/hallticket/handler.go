@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 
 	"msls-backend/internal/middleware"
+	"msls-backend/internal/modules/medicalalert"
 )
 
 // Handler handles HTTP requests for hall tickets.
@@ -122,6 +123,13 @@ func (h *Handler) ListHallTickets(c *gin.Context) {
 		return
 	}
 
+	// Staff without health access only see that a student has an alert
+	if !middleware.HasPermission(c, medicalalert.DetailPermission) {
+		for _, ticket := range tickets {
+			ticket.MedicalAlert = ticket.MedicalAlert.Redact()
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": tickets,
 		"meta": gin.H{
@@ -7,15 +7,25 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+
+	"msls-backend/internal/modules/medicalalert"
+	"msls-backend/internal/pkg/logger"
 )
 
+// MedicalAlertProvider looks up the medical alert badges shown on exam rosters.
+type MedicalAlertProvider interface {
+	BadgesForStudents(ctx context.Context, tenantID uuid.UUID, studentIDs []uuid.UUID) (map[uuid.UUID]*medicalalert.Badge, error)
+}
+
 // Service provides business logic for hall ticket operations.
 type Service struct {
-	repo         *Repository
-	db           *gorm.DB
-	qrGenerator  *QRCodeGenerator
-	pdfGenerator *PDFGenerator
+	repo          *Repository
+	db            *gorm.DB
+	qrGenerator   *QRCodeGenerator
+	pdfGenerator  *PDFGenerator
+	medicalAlerts MedicalAlertProvider
 }
 
 // NewService creates a new hall ticket service.
@@ -32,6 +42,12 @@ func NewService(db *gorm.DB, qrSecret string) *Service {
 	}
 }
 
+// SetMedicalAlertProvider sets the provider used to badge students with
+// medical alerts on exam rosters, so invigilators know who may need help.
+func (s *Service) SetMedicalAlertProvider(provider MedicalAlertProvider) {
+	s.medicalAlerts = provider
+}
+
 // GenerateHallTickets generates hall tickets for students in an examination.
 func (s *Service) GenerateHallTickets(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
 	if req.TenantID == uuid.Nil {
@@ -192,7 +208,26 @@ func (s *Service) GenerateHallTickets(ctx context.Context, req *GenerateRequest)
 
 // ListHallTickets lists hall tickets with filters.
 func (s *Service) ListHallTickets(ctx context.Context, filter ListFilter) ([]*HallTicket, int64, error) {
-	return s.repo.ListHallTickets(ctx, filter)
+	tickets, total, err := s.repo.ListHallTickets(ctx, filter)
+	if err != nil || s.medicalAlerts == nil || len(tickets) == 0 {
+		return tickets, total, err
+	}
+
+	studentIDs := make([]uuid.UUID, len(tickets))
+	for i, ticket := range tickets {
+		studentIDs[i] = ticket.StudentID
+	}
+	badges, err := s.medicalAlerts.BadgesForStudents(ctx, filter.TenantID, studentIDs)
+	if err != nil {
+		logger.Warn("Failed to load medical alerts for hall tickets",
+			zap.String("tenant_id", filter.TenantID.String()),
+			zap.Error(err))
+		return tickets, total, nil
+	}
+	for _, ticket := range tickets {
+		ticket.MedicalAlert = badges[ticket.StudentID]
+	}
+	return tickets, total, nil
 }
 
 // GetHallTicket retrieves a single hall ticket.
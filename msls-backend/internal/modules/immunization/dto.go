@@ -0,0 +1,424 @@
+// Package immunization provides the immunization schedule and vaccination compliance tracking.
+package immunization
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// DoseStatus is the status of one scheduled dose for a student.
+type DoseStatus string
+
+// DoseStatus constants.
+const (
+	DoseStatusGiven   DoseStatus = "given"
+	DoseStatusNotDue  DoseStatus = "not_due"
+	DoseStatusDue     DoseStatus = "due"
+	DoseStatusOverdue DoseStatus = "overdue"
+)
+
+// ComplianceStatus is a student's overall vaccination compliance.
+type ComplianceStatus string
+
+// ComplianceStatus constants.
+const (
+	ComplianceStatusCompliant    ComplianceStatus = "compliant"
+	ComplianceStatusDue          ComplianceStatus = "due"
+	ComplianceStatusNonCompliant ComplianceStatus = "non_compliant"
+)
+
+// IsValid checks if the compliance status is a valid value.
+func (s ComplianceStatus) IsValid() bool {
+	switch s {
+	case ComplianceStatusCompliant, ComplianceStatusDue, ComplianceStatusNonCompliant:
+		return true
+	}
+	return false
+}
+
+// CreateScheduleItemDTO represents a request to add a dose to the schedule.
+type CreateScheduleItemDTO struct {
+	TenantID     uuid.UUID
+	VaccineName  string
+	DoseNumber   int
+	MinAgeMonths int
+	MaxAgeMonths *int
+	IsMandatory  bool
+	Description  string
+	UserID       *uuid.UUID
+}
+
+// UpdateScheduleItemDTO represents a request to update a schedule item.
+type UpdateScheduleItemDTO struct {
+	VaccineName  *string
+	DoseNumber   *int
+	MinAgeMonths *int
+	MaxAgeMonths *int
+	ClearMaxAge  bool
+	IsMandatory  *bool
+	Description  *string
+	IsActive     *bool
+	UserID       *uuid.UUID
+}
+
+// StudentFilter selects the students included in compliance reports and campaigns.
+type StudentFilter struct {
+	TenantID  uuid.UUID
+	BranchID  *uuid.UUID
+	ClassID   *uuid.UUID
+	SectionID *uuid.UUID
+	StudentID *uuid.UUID
+}
+
+// StudentRow is an active student with their current class and section.
+type StudentRow struct {
+	ID              uuid.UUID
+	FirstName       string
+	MiddleName      string
+	LastName        string
+	AdmissionNumber string
+	DateOfBirth     time.Time
+	ClassID         *uuid.UUID
+	ClassName       string
+	ClassOrder      int
+	SectionID       *uuid.UUID
+	SectionName     string
+	SectionOrder    int
+}
+
+// FullName returns the student's full name.
+func (r StudentRow) FullName() string {
+	if r.MiddleName != "" {
+		return r.FirstName + " " + r.MiddleName + " " + r.LastName
+	}
+	return r.FirstName + " " + r.LastName
+}
+
+// DoseCompliance is the status of one scheduled dose for a student.
+type DoseCompliance struct {
+	Item             *models.ImmunizationScheduleItem
+	Status           DoseStatus
+	DueFrom          time.Time
+	DueBy            *time.Time
+	AdministeredDate *time.Time
+}
+
+// StudentCompliance is a student's vaccination status against the schedule.
+type StudentCompliance struct {
+	Student   StudentRow
+	AgeMonths int
+	Status    ComplianceStatus
+	Doses     []DoseCompliance
+}
+
+// ReportFilter contains filters for the class-wise compliance report.
+type ReportFilter struct {
+	StudentFilter
+	Status ComplianceStatus
+	AsOf   time.Time
+}
+
+// ComplianceSummary counts students by compliance status.
+type ComplianceSummary struct {
+	TotalStudents int `json:"totalStudents"`
+	Compliant     int `json:"compliant"`
+	Due           int `json:"due"`
+	NonCompliant  int `json:"nonCompliant"`
+}
+
+// add counts a student's status in the summary.
+func (s *ComplianceSummary) add(status ComplianceStatus) {
+	s.TotalStudents++
+	switch status {
+	case ComplianceStatusCompliant:
+		s.Compliant++
+	case ComplianceStatusDue:
+		s.Due++
+	case ComplianceStatusNonCompliant:
+		s.NonCompliant++
+	}
+}
+
+// ClassCompliance is the compliance of one class section.
+type ClassCompliance struct {
+	ClassID     *uuid.UUID
+	ClassName   string
+	SectionID   *uuid.UUID
+	SectionName string
+	Summary     ComplianceSummary
+	Students    []StudentCompliance
+}
+
+// ComplianceReport is the class-wise vaccination compliance report.
+type ComplianceReport struct {
+	AsOf    time.Time
+	Summary ComplianceSummary
+	Classes []ClassCompliance
+}
+
+// CreateCampaignDTO represents a request to send vaccination reminders.
+type CreateCampaignDTO struct {
+	TenantID   uuid.UUID
+	Name       string
+	BranchID   *uuid.UUID
+	ClassID    *uuid.UUID
+	SectionID  *uuid.UUID
+	IncludeDue bool
+	Message    string
+	UserID     *uuid.UUID
+}
+
+// =========================================================================
+// Request Types
+// =========================================================================
+
+// CreateScheduleItemRequest represents the request body for adding a schedule item.
+type CreateScheduleItemRequest struct {
+	VaccineName  string `json:"vaccineName" binding:"required,max=100"`
+	DoseNumber   int    `json:"doseNumber" binding:"omitempty,min=1"`
+	MinAgeMonths int    `json:"minAgeMonths" binding:"min=0"`
+	MaxAgeMonths *int   `json:"maxAgeMonths" binding:"omitempty,min=0"`
+	IsMandatory  *bool  `json:"isMandatory"`
+	Description  string `json:"description"`
+}
+
+// UpdateScheduleItemRequest represents the request body for updating a schedule item.
+type UpdateScheduleItemRequest struct {
+	VaccineName  *string `json:"vaccineName" binding:"omitempty,max=100"`
+	DoseNumber   *int    `json:"doseNumber" binding:"omitempty,min=1"`
+	MinAgeMonths *int    `json:"minAgeMonths" binding:"omitempty,min=0"`
+	MaxAgeMonths *int    `json:"maxAgeMonths" binding:"omitempty,min=0"`
+	ClearMaxAge  bool    `json:"clearMaxAge"`
+	IsMandatory  *bool   `json:"isMandatory"`
+	Description  *string `json:"description"`
+	IsActive     *bool   `json:"isActive"`
+}
+
+// CreateCampaignRequest represents the request body for sending reminders.
+type CreateCampaignRequest struct {
+	Name       string  `json:"name" binding:"required,max=200"`
+	BranchID   *string `json:"branchId" binding:"omitempty,uuid"`
+	ClassID    *string `json:"classId" binding:"omitempty,uuid"`
+	SectionID  *string `json:"sectionId" binding:"omitempty,uuid"`
+	IncludeDue bool    `json:"includeDue"`
+	Message    string  `json:"message"`
+}
+
+// =========================================================================
+// Response Types
+// =========================================================================
+
+// ScheduleItemResponse represents a schedule item in API responses.
+type ScheduleItemResponse struct {
+	ID           string `json:"id"`
+	VaccineName  string `json:"vaccineName"`
+	DoseNumber   int    `json:"doseNumber"`
+	MinAgeMonths int    `json:"minAgeMonths"`
+	MaxAgeMonths *int   `json:"maxAgeMonths,omitempty"`
+	IsMandatory  bool   `json:"isMandatory"`
+	Description  string `json:"description,omitempty"`
+	IsActive     bool   `json:"isActive"`
+}
+
+// DoseComplianceResponse represents a scheduled dose's status in API responses.
+type DoseComplianceResponse struct {
+	ScheduleItemID   string `json:"scheduleItemId"`
+	VaccineName      string `json:"vaccineName"`
+	DoseNumber       int    `json:"doseNumber"`
+	IsMandatory      bool   `json:"isMandatory"`
+	Status           string `json:"status"`
+	DueFrom          string `json:"dueFrom"`
+	DueBy            string `json:"dueBy,omitempty"`
+	AdministeredDate string `json:"administeredDate,omitempty"`
+}
+
+// StudentComplianceResponse represents a student's compliance in API responses.
+type StudentComplianceResponse struct {
+	StudentID       string                   `json:"studentId"`
+	StudentName     string                   `json:"studentName"`
+	AdmissionNumber string                   `json:"admissionNumber"`
+	DateOfBirth     string                   `json:"dateOfBirth"`
+	AgeMonths       int                      `json:"ageMonths"`
+	ClassName       string                   `json:"className,omitempty"`
+	SectionName     string                   `json:"sectionName,omitempty"`
+	Status          string                   `json:"status"`
+	Pending         []string                 `json:"pending"`
+	Doses           []DoseComplianceResponse `json:"doses,omitempty"`
+}
+
+// ClassComplianceResponse represents one class section in the compliance report.
+type ClassComplianceResponse struct {
+	ClassID     string                      `json:"classId,omitempty"`
+	ClassName   string                      `json:"className"`
+	SectionID   string                      `json:"sectionId,omitempty"`
+	SectionName string                      `json:"sectionName,omitempty"`
+	Summary     ComplianceSummary           `json:"summary"`
+	Students    []StudentComplianceResponse `json:"students"`
+}
+
+// ComplianceReportResponse represents the class-wise compliance report.
+type ComplianceReportResponse struct {
+	AsOf    string                    `json:"asOf"`
+	Summary ComplianceSummary         `json:"summary"`
+	Classes []ClassComplianceResponse `json:"classes"`
+}
+
+// ReminderResponse represents a reminder in API responses.
+type ReminderResponse struct {
+	ID              string `json:"id"`
+	StudentID       string `json:"studentId"`
+	GuardianID      string `json:"guardianId,omitempty"`
+	Phone           string `json:"phone,omitempty"`
+	PendingVaccines string `json:"pendingVaccines"`
+	Status          string `json:"status"`
+	Error           string `json:"error,omitempty"`
+}
+
+// CampaignResponse represents a reminder campaign in API responses.
+type CampaignResponse struct {
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	BranchID       string             `json:"branchId,omitempty"`
+	ClassID        string             `json:"classId,omitempty"`
+	SectionID      string             `json:"sectionId,omitempty"`
+	IncludeDue     bool               `json:"includeDue"`
+	Message        string             `json:"message"`
+	RecipientCount int                `json:"recipientCount"`
+	SentCount      int                `json:"sentCount"`
+	FailedCount    int                `json:"failedCount"`
+	SkippedCount   int                `json:"skippedCount"`
+	SentAt         string             `json:"sentAt"`
+	Reminders      []ReminderResponse `json:"reminders,omitempty"`
+}
+
+// CampaignListResponse represents a page of reminder campaigns.
+type CampaignListResponse struct {
+	Campaigns []CampaignResponse `json:"campaigns"`
+	Total     int64              `json:"total"`
+}
+
+// ToScheduleItemResponse converts an ImmunizationScheduleItem model to a ScheduleItemResponse.
+func ToScheduleItemResponse(item *models.ImmunizationScheduleItem) ScheduleItemResponse {
+	return ScheduleItemResponse{
+		ID:           item.ID.String(),
+		VaccineName:  item.VaccineName,
+		DoseNumber:   item.DoseNumber,
+		MinAgeMonths: item.MinAgeMonths,
+		MaxAgeMonths: item.MaxAgeMonths,
+		IsMandatory:  item.IsMandatory,
+		Description:  item.Description,
+		IsActive:     item.IsActive,
+	}
+}
+
+// ToStudentComplianceResponse converts a StudentCompliance to a response,
+// including every scheduled dose when withDoses is set.
+func ToStudentComplianceResponse(compliance *StudentCompliance, withDoses bool) StudentComplianceResponse {
+	student := compliance.Student
+	resp := StudentComplianceResponse{
+		StudentID:       student.ID.String(),
+		StudentName:     student.FullName(),
+		AdmissionNumber: student.AdmissionNumber,
+		DateOfBirth:     student.DateOfBirth.Format("2006-01-02"),
+		AgeMonths:       compliance.AgeMonths,
+		ClassName:       student.ClassName,
+		SectionName:     student.SectionName,
+		Status:          string(compliance.Status),
+		Pending:         pendingVaccines(compliance, true),
+	}
+	if !withDoses {
+		return resp
+	}
+
+	resp.Doses = make([]DoseComplianceResponse, len(compliance.Doses))
+	for i, dose := range compliance.Doses {
+		item := DoseComplianceResponse{
+			ScheduleItemID: dose.Item.ID.String(),
+			VaccineName:    dose.Item.VaccineName,
+			DoseNumber:     dose.Item.DoseNumber,
+			IsMandatory:    dose.Item.IsMandatory,
+			Status:         string(dose.Status),
+			DueFrom:        dose.DueFrom.Format("2006-01-02"),
+		}
+		if dose.DueBy != nil {
+			item.DueBy = dose.DueBy.Format("2006-01-02")
+		}
+		if dose.AdministeredDate != nil {
+			item.AdministeredDate = dose.AdministeredDate.Format("2006-01-02")
+		}
+		resp.Doses[i] = item
+	}
+	return resp
+}
+
+// ToComplianceReportResponse converts a ComplianceReport to a ComplianceReportResponse.
+func ToComplianceReportResponse(report *ComplianceReport) ComplianceReportResponse {
+	resp := ComplianceReportResponse{
+		AsOf:    report.AsOf.Format("2006-01-02"),
+		Summary: report.Summary,
+		Classes: make([]ClassComplianceResponse, len(report.Classes)),
+	}
+	for i, class := range report.Classes {
+		item := ClassComplianceResponse{
+			ClassName:   class.ClassName,
+			SectionName: class.SectionName,
+			Summary:     class.Summary,
+			Students:    make([]StudentComplianceResponse, len(class.Students)),
+		}
+		if class.ClassID != nil {
+			item.ClassID = class.ClassID.String()
+		}
+		if class.SectionID != nil {
+			item.SectionID = class.SectionID.String()
+		}
+		for j := range class.Students {
+			item.Students[j] = ToStudentComplianceResponse(&class.Students[j], false)
+		}
+		resp.Classes[i] = item
+	}
+	return resp
+}
+
+// ToCampaignResponse converts an ImmunizationReminderCampaign model to a CampaignResponse.
+func ToCampaignResponse(campaign *models.ImmunizationReminderCampaign) CampaignResponse {
+	resp := CampaignResponse{
+		ID:             campaign.ID.String(),
+		Name:           campaign.Name,
+		BranchID:       uuidString(campaign.BranchID),
+		ClassID:        uuidString(campaign.ClassID),
+		SectionID:      uuidString(campaign.SectionID),
+		IncludeDue:     campaign.IncludeDue,
+		Message:        campaign.Message,
+		RecipientCount: campaign.RecipientCount,
+		SentCount:      campaign.SentCount,
+		FailedCount:    campaign.FailedCount,
+		SkippedCount:   campaign.SkippedCount,
+		SentAt:         campaign.SentAt.Format(time.RFC3339),
+	}
+	if len(campaign.Reminders) > 0 {
+		resp.Reminders = make([]ReminderResponse, len(campaign.Reminders))
+		for i, reminder := range campaign.Reminders {
+			resp.Reminders[i] = ReminderResponse{
+				ID:              reminder.ID.String(),
+				StudentID:       reminder.StudentID.String(),
+				GuardianID:      uuidString(reminder.GuardianID),
+				Phone:           reminder.Phone,
+				PendingVaccines: reminder.PendingVaccines,
+				Status:          string(reminder.Status),
+				Error:           reminder.Error,
+			}
+		}
+	}
+	return resp
+}
+
+// uuidString formats an optional UUID.
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
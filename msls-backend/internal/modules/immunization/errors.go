@@ -0,0 +1,37 @@
+// Package immunization provides the immunization schedule and vaccination compliance tracking.
+package immunization
+
+import "errors"
+
+// Immunization-related errors.
+var (
+	// ErrScheduleItemNotFound is returned when an immunization schedule item is not found.
+	ErrScheduleItemNotFound = errors.New("immunization schedule item not found")
+
+	// ErrCampaignNotFound is returned when a reminder campaign is not found.
+	ErrCampaignNotFound = errors.New("reminder campaign not found")
+
+	// ErrStudentNotFound is returned when the student is not found.
+	ErrStudentNotFound = errors.New("student not found")
+
+	// ErrVaccineNameRequired is returned when a schedule item has no vaccine name.
+	ErrVaccineNameRequired = errors.New("vaccine name is required")
+
+	// ErrInvalidDoseNumber is returned when the dose number is less than one.
+	ErrInvalidDoseNumber = errors.New("dose number must be at least 1")
+
+	// ErrInvalidAgeWindow is returned when the age window is negative or ends before it starts.
+	ErrInvalidAgeWindow = errors.New("invalid age window")
+
+	// ErrDuplicateScheduleItem is returned when the vaccine dose is already on the schedule.
+	ErrDuplicateScheduleItem = errors.New("vaccine dose is already on the schedule")
+
+	// ErrCampaignNameRequired is returned when a reminder campaign has no name.
+	ErrCampaignNameRequired = errors.New("campaign name is required")
+
+	// ErrNoRecipients is returned when no students match a reminder campaign.
+	ErrNoRecipients = errors.New("no students are missing vaccinations")
+
+	// ErrSMSUnavailable is returned when reminders cannot be sent because SMS is not configured.
+	ErrSMSUnavailable = errors.New("sms provider is not available")
+)
@@ -0,0 +1,445 @@
+// Package immunization provides the immunization schedule and vaccination compliance tracking.
+package immunization
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/middleware"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/response"
+)
+
+// Handler handles immunization-related HTTP requests.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new immunization handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// =========================================================================
+// Schedule
+// =========================================================================
+
+// ListSchedule returns the immunization schedule.
+// @Summary List immunization schedule
+// @Description List the vaccine doses children are expected to have, in age order
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param activeOnly query bool false "Only active schedule items"
+// @Success 200 {object} response.Success{data=[]ScheduleItemResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/immunization/schedule [get]
+func (h *Handler) ListSchedule(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	items, err := h.service.ListSchedule(c.Request.Context(), tenantID, c.Query("activeOnly") == "true")
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := make([]ScheduleItemResponse, len(items))
+	for i := range items {
+		resp[i] = ToScheduleItemResponse(&items[i])
+	}
+	response.OK(c, resp)
+}
+
+// CreateScheduleItem adds a vaccine dose to the schedule.
+// @Summary Create immunization schedule item
+// @Description Add a vaccine dose with the age window (in months) in which it is due
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body CreateScheduleItemRequest true "Schedule item"
+// @Success 201 {object} response.Success{data=ScheduleItemResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/immunization/schedule [post]
+func (h *Handler) CreateScheduleItem(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req CreateScheduleItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	isMandatory := true
+	if req.IsMandatory != nil {
+		isMandatory = *req.IsMandatory
+	}
+
+	item, err := h.service.CreateScheduleItem(c.Request.Context(), CreateScheduleItemDTO{
+		TenantID:     tenantID,
+		VaccineName:  req.VaccineName,
+		DoseNumber:   req.DoseNumber,
+		MinAgeMonths: req.MinAgeMonths,
+		MaxAgeMonths: req.MaxAgeMonths,
+		IsMandatory:  isMandatory,
+		Description:  req.Description,
+		UserID:       &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToScheduleItemResponse(item))
+}
+
+// UpdateScheduleItem updates a schedule item.
+// @Summary Update immunization schedule item
+// @Description Update a vaccine dose on the schedule; set clearMaxAge to remove the upper age limit
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Schedule item ID"
+// @Param request body UpdateScheduleItemRequest true "Schedule item"
+// @Success 200 {object} response.Success{data=ScheduleItemResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/immunization/schedule/{id} [put]
+func (h *Handler) UpdateScheduleItem(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid schedule item ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req UpdateScheduleItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	item, err := h.service.UpdateScheduleItem(c.Request.Context(), tenantID, id, UpdateScheduleItemDTO{
+		VaccineName:  req.VaccineName,
+		DoseNumber:   req.DoseNumber,
+		MinAgeMonths: req.MinAgeMonths,
+		MaxAgeMonths: req.MaxAgeMonths,
+		ClearMaxAge:  req.ClearMaxAge,
+		IsMandatory:  req.IsMandatory,
+		Description:  req.Description,
+		IsActive:     req.IsActive,
+		UserID:       &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToScheduleItemResponse(item))
+}
+
+// DeleteScheduleItem removes a dose from the schedule.
+// @Summary Delete immunization schedule item
+// @Description Remove a vaccine dose from the schedule
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Schedule item ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/immunization/schedule/{id} [delete]
+func (h *Handler) DeleteScheduleItem(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid schedule item ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteScheduleItem(c.Request.Context(), tenantID, id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// =========================================================================
+// Compliance
+// =========================================================================
+
+// GetStudentCompliance returns a student's vaccination status against the schedule.
+// @Summary Get student immunization compliance
+// @Description Get the status of each scheduled dose for a student, computed from date of birth
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Student ID"
+// @Param asOf query string false "Evaluate as of date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} response.Success{data=StudentComplianceResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/students/{id}/immunization-compliance [get]
+func (h *Handler) GetStudentCompliance(c *gin.Context) {
+	tenantID, studentID, ok := middleware.TenantAndID(c, "id", "Invalid student ID")
+	if !ok {
+		return
+	}
+	asOf, ok := parseAsOf(c)
+	if !ok {
+		return
+	}
+
+	compliance, err := h.service.GetStudentCompliance(c.Request.Context(), tenantID, studentID, asOf)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToStudentComplianceResponse(compliance, true))
+}
+
+// ComplianceReport returns the class-wise vaccination compliance report.
+// @Summary Immunization compliance report
+// @Description Class-wise counts of compliant, due and non-compliant students with the students missing doses
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param branchId query string false "Filter by branch"
+// @Param classId query string false "Filter by class"
+// @Param sectionId query string false "Filter by section"
+// @Param status query string false "List students with this status (compliant, due, non_compliant) instead of all missing doses"
+// @Param asOf query string false "Evaluate as of date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} response.Success{data=ComplianceReportResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/immunization/compliance-report [get]
+func (h *Handler) ComplianceReport(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := ReportFilter{
+		StudentFilter: StudentFilter{TenantID: tenantID},
+		Status:        ComplianceStatus(c.Query("status")),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid compliance status"))
+		return
+	}
+	if !middleware.ParseUUIDQuery(c, "branchId", &filter.BranchID) ||
+		!middleware.ParseUUIDQuery(c, "classId", &filter.ClassID) ||
+		!middleware.ParseUUIDQuery(c, "sectionId", &filter.SectionID) {
+		return
+	}
+	if filter.AsOf, ok = parseAsOf(c); !ok {
+		return
+	}
+
+	report, err := h.service.ComplianceReport(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToComplianceReportResponse(report))
+}
+
+// =========================================================================
+// Reminder Campaigns
+// =========================================================================
+
+// ListCampaigns returns reminder campaigns.
+// @Summary List vaccination reminder campaigns
+// @Description List reminder campaigns sent to guardians, newest first
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=CampaignListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/immunization/reminder-campaigns [get]
+func (h *Handler) ListCampaigns(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var limit, offset int
+	if !middleware.ParsePaging(c, &limit, &offset) {
+		return
+	}
+
+	campaigns, total, err := h.service.ListCampaigns(c.Request.Context(), tenantID, limit, offset)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := CampaignListResponse{
+		Campaigns: make([]CampaignResponse, len(campaigns)),
+		Total:     total,
+	}
+	for i := range campaigns {
+		resp.Campaigns[i] = ToCampaignResponse(&campaigns[i])
+	}
+	response.OK(c, resp)
+}
+
+// GetCampaign returns a reminder campaign with the outcome of each reminder.
+// @Summary Get vaccination reminder campaign
+// @Description Get a reminder campaign with each student's reminder status
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} response.Success{data=CampaignResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/immunization/reminder-campaigns/{id} [get]
+func (h *Handler) GetCampaign(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid campaign ID")
+	if !ok {
+		return
+	}
+
+	campaign, err := h.service.GetCampaign(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToCampaignResponse(campaign))
+}
+
+// CreateCampaign sends vaccination reminders to guardians.
+// @Summary Send vaccination reminders
+// @Description Send an SMS to the guardian of every non-compliant student (and due students when includeDue is set). The message may use {{student_name}}, {{guardian_name}}, {{class}} and {{vaccines}}.
+// @Tags Immunization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body CreateCampaignRequest true "Campaign"
+// @Success 201 {object} response.Success{data=CampaignResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/immunization/reminder-campaigns [post]
+func (h *Handler) CreateCampaign(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req CreateCampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	dto := CreateCampaignDTO{
+		TenantID:   tenantID,
+		Name:       req.Name,
+		IncludeDue: req.IncludeDue,
+		Message:    req.Message,
+		UserID:     &userID,
+	}
+	if dto.BranchID, ok = middleware.ParseOptionalUUID(c, req.BranchID, "Invalid branch ID"); !ok {
+		return
+	}
+	if dto.ClassID, ok = middleware.ParseOptionalUUID(c, req.ClassID, "Invalid class ID"); !ok {
+		return
+	}
+	if dto.SectionID, ok = middleware.ParseOptionalUUID(c, req.SectionID, "Invalid section ID"); !ok {
+		return
+	}
+
+	campaign, err := h.service.CreateCampaign(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToCampaignResponse(campaign))
+}
+
+// =========================================================================
+// Helpers
+// =========================================================================
+
+// parseAsOf parses the asOf query parameter, defaulting to today.
+func parseAsOf(c *gin.Context) (time.Time, bool) {
+	value := c.Query("asOf")
+	if value == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), true
+	}
+	asOf, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid asOf date, expected YYYY-MM-DD"))
+		return time.Time{}, false
+	}
+	return asOf, true
+}
+
+// handleServiceError converts service errors to API errors.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrScheduleItemNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Immunization schedule item not found"))
+	case errors.Is(err, ErrCampaignNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Reminder campaign not found"))
+	case errors.Is(err, ErrStudentNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Student not found"))
+	case errors.Is(err, ErrVaccineNameRequired):
+		apperrors.Abort(c, apperrors.BadRequest("Vaccine name is required"))
+	case errors.Is(err, ErrInvalidDoseNumber):
+		apperrors.Abort(c, apperrors.BadRequest("Dose number must be at least 1"))
+	case errors.Is(err, ErrInvalidAgeWindow):
+		apperrors.Abort(c, apperrors.BadRequest("Maximum age must not be less than minimum age"))
+	case errors.Is(err, ErrCampaignNameRequired):
+		apperrors.Abort(c, apperrors.BadRequest("Campaign name is required"))
+	case errors.Is(err, ErrNoRecipients):
+		apperrors.Abort(c, apperrors.BadRequest("No students are missing vaccinations"))
+	case errors.Is(err, ErrDuplicateScheduleItem):
+		apperrors.Abort(c, apperrors.Conflict("This vaccine dose is already on the schedule"))
+	case errors.Is(err, ErrSMSUnavailable):
+		apperrors.Abort(c, apperrors.BadRequest("SMS is not configured, so reminders cannot be sent"))
+	default:
+		logger.Error("Immunization operation error", zap.Error(err))
+		apperrors.Abort(c, apperrors.InternalError("Failed to process immunization request"))
+	}
+}
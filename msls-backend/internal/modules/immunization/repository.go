@@ -0,0 +1,276 @@
+// Package immunization provides the immunization schedule and vaccination compliance tracking.
+package immunization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// Repository handles database operations for immunization tracking.
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new immunization repository.
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// =========================================================================
+// Schedule Operations
+// =========================================================================
+
+// ListScheduleItems retrieves the tenant's immunization schedule in age order.
+func (r *Repository) ListScheduleItems(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]models.ImmunizationScheduleItem, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var items []models.ImmunizationScheduleItem
+	err := query.Order("min_age_months ASC, vaccine_name ASC, dose_number ASC").Find(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("list immunization schedule: %w", err)
+	}
+	return items, nil
+}
+
+// GetScheduleItem retrieves a schedule item by ID.
+func (r *Repository) GetScheduleItem(ctx context.Context, tenantID, id uuid.UUID) (*models.ImmunizationScheduleItem, error) {
+	var item models.ImmunizationScheduleItem
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduleItemNotFound
+		}
+		return nil, fmt.Errorf("get immunization schedule item: %w", err)
+	}
+	return &item, nil
+}
+
+// ScheduleItemExists checks whether a vaccine dose is already on the schedule.
+func (r *Repository) ScheduleItemExists(ctx context.Context, tenantID uuid.UUID, vaccineName string, doseNumber int, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.ImmunizationScheduleItem{}).
+		Where("tenant_id = ? AND LOWER(vaccine_name) = ? AND dose_number = ?", tenantID, strings.ToLower(vaccineName), doseNumber)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check immunization schedule item: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CreateScheduleItem creates a schedule item.
+func (r *Repository) CreateScheduleItem(ctx context.Context, item *models.ImmunizationScheduleItem) error {
+	if err := r.db.WithContext(ctx).Create(item).Error; err != nil {
+		return fmt.Errorf("create immunization schedule item: %w", err)
+	}
+	return nil
+}
+
+// UpdateScheduleItem saves a schedule item.
+func (r *Repository) UpdateScheduleItem(ctx context.Context, item *models.ImmunizationScheduleItem) error {
+	item.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(item).Error; err != nil {
+		return fmt.Errorf("update immunization schedule item: %w", err)
+	}
+	return nil
+}
+
+// DeleteScheduleItem deletes a schedule item.
+func (r *Repository) DeleteScheduleItem(ctx context.Context, tenantID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		Delete(&models.ImmunizationScheduleItem{})
+	if result.Error != nil {
+		return fmt.Errorf("delete immunization schedule item: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrScheduleItemNotFound
+	}
+	return nil
+}
+
+// =========================================================================
+// Student Data
+// =========================================================================
+
+// ListStudents retrieves active students with their current class and section.
+func (r *Repository) ListStudents(ctx context.Context, filter StudentFilter) ([]StudentRow, error) {
+	query := r.db.WithContext(ctx).
+		Table("students s").
+		Select(`s.id, s.first_name, s.middle_name, s.last_name, s.admission_number, s.date_of_birth,
+			se.class_id, COALESCE(c.name, '') AS class_name, COALESCE(c.display_order, 0) AS class_order,
+			se.section_id, COALESCE(sec.name, '') AS section_name, COALESCE(sec.display_order, 0) AS section_order`).
+		Joins("LEFT JOIN student_enrollments se ON se.student_id = s.id AND se.status = 'active'").
+		Joins("LEFT JOIN classes c ON c.id = se.class_id").
+		Joins("LEFT JOIN sections sec ON sec.id = se.section_id").
+		Where("s.tenant_id = ? AND s.status = ? AND s.deleted_at IS NULL", filter.TenantID, models.StudentStatusActive)
+
+	if filter.BranchID != nil {
+		query = query.Where("s.branch_id = ?", *filter.BranchID)
+	}
+	if filter.ClassID != nil {
+		query = query.Where("se.class_id = ?", *filter.ClassID)
+	}
+	if filter.SectionID != nil {
+		query = query.Where("se.section_id = ?", *filter.SectionID)
+	}
+	if filter.StudentID != nil {
+		query = query.Where("s.id = ?", *filter.StudentID)
+	}
+
+	var rows []StudentRow
+	err := query.
+		Order("class_order ASC, class_name ASC, section_order ASC, section_name ASC, s.first_name ASC, s.last_name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("list students: %w", err)
+	}
+	return rows, nil
+}
+
+// GetStudent retrieves a student with their current class and section.
+func (r *Repository) GetStudent(ctx context.Context, tenantID, studentID uuid.UUID) (*StudentRow, error) {
+	var student models.Student
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, studentID).
+		First(&student).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+		return nil, fmt.Errorf("get student: %w", err)
+	}
+
+	row := StudentRow{
+		ID:              student.ID,
+		FirstName:       student.FirstName,
+		MiddleName:      student.MiddleName,
+		LastName:        student.LastName,
+		AdmissionNumber: student.AdmissionNumber,
+		DateOfBirth:     student.DateOfBirth,
+	}
+
+	var rows []StudentRow
+	err = r.db.WithContext(ctx).
+		Table("student_enrollments se").
+		Select("se.class_id, COALESCE(c.name, '') AS class_name, se.section_id, COALESCE(sec.name, '') AS section_name").
+		Joins("LEFT JOIN classes c ON c.id = se.class_id").
+		Joins("LEFT JOIN sections sec ON sec.id = se.section_id").
+		Where("se.tenant_id = ? AND se.student_id = ? AND se.status = 'active'", tenantID, studentID).
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("get student enrollment: %w", err)
+	}
+	if len(rows) > 0 {
+		row.ClassID, row.ClassName = rows[0].ClassID, rows[0].ClassName
+		row.SectionID, row.SectionName = rows[0].SectionID, rows[0].SectionName
+	}
+	return &row, nil
+}
+
+// ListVaccinations retrieves the vaccination records of the given students.
+func (r *Repository) ListVaccinations(ctx context.Context, tenantID uuid.UUID, studentIDs []uuid.UUID) ([]models.StudentVaccination, error) {
+	if len(studentIDs) == 0 {
+		return nil, nil
+	}
+	var vaccinations []models.StudentVaccination
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id IN ?", tenantID, studentIDs).
+		Order("administered_date ASC").
+		Find(&vaccinations).Error
+	if err != nil {
+		return nil, fmt.Errorf("list vaccinations: %w", err)
+	}
+	return vaccinations, nil
+}
+
+// ListPrimaryGuardians retrieves the guardian to contact for each student,
+// primary guardian first, keyed by student ID.
+func (r *Repository) ListPrimaryGuardians(ctx context.Context, tenantID uuid.UUID, studentIDs []uuid.UUID) (map[uuid.UUID]models.StudentGuardian, error) {
+	guardians := make(map[uuid.UUID]models.StudentGuardian)
+	if len(studentIDs) == 0 {
+		return guardians, nil
+	}
+
+	var rows []models.StudentGuardian
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id IN ? AND phone <> ''", tenantID, studentIDs).
+		Order("is_primary DESC, created_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("list guardians: %w", err)
+	}
+
+	for _, guardian := range rows {
+		if _, ok := guardians[guardian.StudentID]; !ok {
+			guardians[guardian.StudentID] = guardian
+		}
+	}
+	return guardians, nil
+}
+
+// =========================================================================
+// Reminder Campaigns
+// =========================================================================
+
+// CreateCampaign creates a reminder campaign with its reminders.
+func (r *Repository) CreateCampaign(ctx context.Context, campaign *models.ImmunizationReminderCampaign) error {
+	if err := r.db.WithContext(ctx).Create(campaign).Error; err != nil {
+		return fmt.Errorf("create reminder campaign: %w", err)
+	}
+	return nil
+}
+
+// ListCampaigns retrieves reminder campaigns, newest first.
+func (r *Repository) ListCampaigns(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]models.ImmunizationReminderCampaign, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.ImmunizationReminderCampaign{}).
+		Where("tenant_id = ?", tenantID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count reminder campaigns: %w", err)
+	}
+
+	var campaigns []models.ImmunizationReminderCampaign
+	err := query.Order("sent_at DESC").Limit(limit).Offset(offset).Find(&campaigns).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("list reminder campaigns: %w", err)
+	}
+	return campaigns, total, nil
+}
+
+// GetCampaign retrieves a reminder campaign with its reminders.
+func (r *Repository) GetCampaign(ctx context.Context, tenantID, id uuid.UUID) (*models.ImmunizationReminderCampaign, error) {
+	var campaign models.ImmunizationReminderCampaign
+	err := r.db.WithContext(ctx).
+		Preload("Reminders", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&campaign).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, fmt.Errorf("get reminder campaign: %w", err)
+	}
+	return &campaign, nil
+}
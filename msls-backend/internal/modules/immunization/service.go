@@ -0,0 +1,472 @@
+// Package immunization provides the immunization schedule and vaccination compliance tracking.
+package immunization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/sms"
+)
+
+// defaultReminderMessage is used when a campaign does not provide its own wording.
+const defaultReminderMessage = "Dear {{guardian_name}}, school health records show {{student_name}} ({{class}}) " +
+	"has not received: {{vaccines}}. Please complete the vaccination and share the record with the school."
+
+// Service handles immunization business logic.
+type Service struct {
+	repo        *Repository
+	smsProvider sms.Provider
+}
+
+// NewService creates a new immunization service.
+func NewService(repo *Repository, smsProvider sms.Provider) *Service {
+	return &Service{repo: repo, smsProvider: smsProvider}
+}
+
+// =========================================================================
+// Schedule
+// =========================================================================
+
+// ListSchedule returns the tenant's immunization schedule.
+func (s *Service) ListSchedule(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]models.ImmunizationScheduleItem, error) {
+	return s.repo.ListScheduleItems(ctx, tenantID, activeOnly)
+}
+
+// CreateScheduleItem adds a vaccine dose to the schedule.
+func (s *Service) CreateScheduleItem(ctx context.Context, dto CreateScheduleItemDTO) (*models.ImmunizationScheduleItem, error) {
+	item := &models.ImmunizationScheduleItem{
+		ID:           uuid.New(),
+		TenantID:     dto.TenantID,
+		VaccineName:  strings.TrimSpace(dto.VaccineName),
+		DoseNumber:   dto.DoseNumber,
+		MinAgeMonths: dto.MinAgeMonths,
+		MaxAgeMonths: dto.MaxAgeMonths,
+		IsMandatory:  dto.IsMandatory,
+		Description:  strings.TrimSpace(dto.Description),
+		IsActive:     true,
+		CreatedBy:    dto.UserID,
+		UpdatedBy:    dto.UserID,
+	}
+	if item.DoseNumber == 0 {
+		item.DoseNumber = 1
+	}
+	if err := s.validateScheduleItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateScheduleItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateScheduleItem updates a schedule item.
+func (s *Service) UpdateScheduleItem(ctx context.Context, tenantID, id uuid.UUID, dto UpdateScheduleItemDTO) (*models.ImmunizationScheduleItem, error) {
+	item, err := s.repo.GetScheduleItem(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.VaccineName != nil {
+		item.VaccineName = strings.TrimSpace(*dto.VaccineName)
+	}
+	if dto.DoseNumber != nil {
+		item.DoseNumber = *dto.DoseNumber
+	}
+	if dto.MinAgeMonths != nil {
+		item.MinAgeMonths = *dto.MinAgeMonths
+	}
+	if dto.ClearMaxAge {
+		item.MaxAgeMonths = nil
+	} else if dto.MaxAgeMonths != nil {
+		item.MaxAgeMonths = dto.MaxAgeMonths
+	}
+	if dto.IsMandatory != nil {
+		item.IsMandatory = *dto.IsMandatory
+	}
+	if dto.Description != nil {
+		item.Description = strings.TrimSpace(*dto.Description)
+	}
+	if dto.IsActive != nil {
+		item.IsActive = *dto.IsActive
+	}
+	item.UpdatedBy = dto.UserID
+
+	if err := s.validateScheduleItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateScheduleItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteScheduleItem removes a dose from the schedule.
+func (s *Service) DeleteScheduleItem(ctx context.Context, tenantID, id uuid.UUID) error {
+	return s.repo.DeleteScheduleItem(ctx, tenantID, id)
+}
+
+// validateScheduleItem checks a schedule item's fields and that the dose is not already scheduled.
+func (s *Service) validateScheduleItem(ctx context.Context, item *models.ImmunizationScheduleItem) error {
+	if item.VaccineName == "" {
+		return ErrVaccineNameRequired
+	}
+	if item.DoseNumber < 1 {
+		return ErrInvalidDoseNumber
+	}
+	if item.MinAgeMonths < 0 || (item.MaxAgeMonths != nil && *item.MaxAgeMonths < item.MinAgeMonths) {
+		return ErrInvalidAgeWindow
+	}
+
+	exists, err := s.repo.ScheduleItemExists(ctx, item.TenantID, item.VaccineName, item.DoseNumber, &item.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrDuplicateScheduleItem
+	}
+	return nil
+}
+
+// =========================================================================
+// Compliance
+// =========================================================================
+
+// GetStudentCompliance returns a student's vaccination status against the schedule.
+func (s *Service) GetStudentCompliance(ctx context.Context, tenantID, studentID uuid.UUID, asOf time.Time) (*StudentCompliance, error) {
+	student, err := s.repo.GetStudent(ctx, tenantID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListScheduleItems(ctx, tenantID, true)
+	if err != nil {
+		return nil, err
+	}
+	vaccinations, err := s.repo.ListVaccinations(ctx, tenantID, []uuid.UUID{studentID})
+	if err != nil {
+		return nil, err
+	}
+
+	compliance := evaluateCompliance(*student, items, vaccinations, asOf)
+	return &compliance, nil
+}
+
+// ComplianceReport returns the class-wise vaccination compliance report. Each
+// class lists its non-compliant and due students, or the students with the
+// requested status.
+func (s *Service) ComplianceReport(ctx context.Context, filter ReportFilter) (*ComplianceReport, error) {
+	compliances, err := s.studentCompliances(ctx, filter.StudentFilter, filter.AsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ComplianceReport{AsOf: filter.AsOf}
+	index := make(map[string]int)
+	for _, compliance := range compliances {
+		student := compliance.Student
+		key := uuidString(student.ClassID) + "|" + uuidString(student.SectionID)
+		i, ok := index[key]
+		if !ok {
+			i = len(report.Classes)
+			index[key] = i
+			report.Classes = append(report.Classes, ClassCompliance{
+				ClassID:     student.ClassID,
+				ClassName:   student.ClassName,
+				SectionID:   student.SectionID,
+				SectionName: student.SectionName,
+			})
+		}
+
+		report.Summary.add(compliance.Status)
+		report.Classes[i].Summary.add(compliance.Status)
+
+		include := compliance.Status != ComplianceStatusCompliant
+		if filter.Status != "" {
+			include = compliance.Status == filter.Status
+		}
+		if include {
+			report.Classes[i].Students = append(report.Classes[i].Students, compliance)
+		}
+	}
+
+	return report, nil
+}
+
+// studentCompliances evaluates every matching student against the schedule.
+func (s *Service) studentCompliances(ctx context.Context, filter StudentFilter, asOf time.Time) ([]StudentCompliance, error) {
+	students, err := s.repo.ListStudents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListScheduleItems(ctx, filter.TenantID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(students))
+	for i := range students {
+		ids[i] = students[i].ID
+	}
+	vaccinations, err := s.repo.ListVaccinations(ctx, filter.TenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+	byStudent := make(map[uuid.UUID][]models.StudentVaccination)
+	for _, vaccination := range vaccinations {
+		byStudent[vaccination.StudentID] = append(byStudent[vaccination.StudentID], vaccination)
+	}
+
+	compliances := make([]StudentCompliance, len(students))
+	for i := range students {
+		compliances[i] = evaluateCompliance(students[i], items, byStudent[students[i].ID], asOf)
+	}
+	return compliances, nil
+}
+
+// evaluateCompliance works out a student's status for each scheduled dose.
+// A dose counts as given when the student has a record of that vaccine with
+// the same or a later dose number. A student is non-compliant when a
+// mandatory dose is past its age window, and due when one is in its window.
+func evaluateCompliance(student StudentRow, items []models.ImmunizationScheduleItem, vaccinations []models.StudentVaccination, asOf time.Time) StudentCompliance {
+	compliance := StudentCompliance{
+		Student:   student,
+		AgeMonths: ageInMonths(student.DateOfBirth, asOf),
+		Status:    ComplianceStatusCompliant,
+		Doses:     make([]DoseCompliance, 0, len(items)),
+	}
+
+	for i := range items {
+		item := &items[i]
+		dose := DoseCompliance{
+			Item:    item,
+			DueFrom: student.DateOfBirth.AddDate(0, item.MinAgeMonths, 0),
+		}
+		if item.MaxAgeMonths != nil {
+			dueBy := student.DateOfBirth.AddDate(0, *item.MaxAgeMonths, 0)
+			dose.DueBy = &dueBy
+		}
+
+		dose.AdministeredDate = administeredDate(item, vaccinations)
+		switch {
+		case dose.AdministeredDate != nil:
+			dose.Status = DoseStatusGiven
+		case asOf.Before(dose.DueFrom):
+			dose.Status = DoseStatusNotDue
+		case dose.DueBy != nil && asOf.After(*dose.DueBy):
+			dose.Status = DoseStatusOverdue
+		default:
+			dose.Status = DoseStatusDue
+		}
+
+		if item.IsMandatory {
+			switch {
+			case dose.Status == DoseStatusOverdue:
+				compliance.Status = ComplianceStatusNonCompliant
+			case dose.Status == DoseStatusDue && compliance.Status == ComplianceStatusCompliant:
+				compliance.Status = ComplianceStatusDue
+			}
+		}
+
+		compliance.Doses = append(compliance.Doses, dose)
+	}
+
+	return compliance
+}
+
+// administeredDate returns when a scheduled dose was given, preferring the
+// record of that exact dose over a later one.
+func administeredDate(item *models.ImmunizationScheduleItem, vaccinations []models.StudentVaccination) *time.Time {
+	name := normalizeVaccineName(item.VaccineName)
+
+	var found *time.Time
+	for i := range vaccinations {
+		vaccination := &vaccinations[i]
+		if normalizeVaccineName(vaccination.VaccineName) != name || vaccination.DoseNumber < item.DoseNumber {
+			continue
+		}
+		if vaccination.DoseNumber == item.DoseNumber {
+			return &vaccination.AdministeredDate
+		}
+		if found == nil {
+			found = &vaccination.AdministeredDate
+		}
+	}
+	return found
+}
+
+// pendingVaccines lists the mandatory doses a student is missing: overdue
+// doses, and due doses when includeDue is set.
+func pendingVaccines(compliance *StudentCompliance, includeDue bool) []string {
+	pending := []string{}
+	for _, dose := range compliance.Doses {
+		if !dose.Item.IsMandatory {
+			continue
+		}
+		if dose.Status == DoseStatusOverdue || (includeDue && dose.Status == DoseStatusDue) {
+			pending = append(pending, doseLabel(dose.Item))
+		}
+	}
+	return pending
+}
+
+// doseLabel names a scheduled dose, e.g. "MMR dose 2".
+func doseLabel(item *models.ImmunizationScheduleItem) string {
+	return fmt.Sprintf("%s dose %d", item.VaccineName, item.DoseNumber)
+}
+
+// normalizeVaccineName lower-cases a vaccine name and collapses whitespace so
+// records entered by hand match the schedule.
+func normalizeVaccineName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// ageInMonths returns the number of whole months from birth to a date.
+func ageInMonths(dateOfBirth, asOf time.Time) int {
+	months := (asOf.Year()-dateOfBirth.Year())*12 + int(asOf.Month()-dateOfBirth.Month())
+	if asOf.Day() < dateOfBirth.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// =========================================================================
+// Reminder Campaigns
+// =========================================================================
+
+// CreateCampaign sends an SMS reminder to the guardian of each matching
+// student who is non-compliant, or also due when IncludeDue is set, and
+// records the outcome of every reminder.
+func (s *Service) CreateCampaign(ctx context.Context, dto CreateCampaignDTO) (*models.ImmunizationReminderCampaign, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, ErrCampaignNameRequired
+	}
+	if s.smsProvider == nil || !s.smsProvider.IsReady() {
+		return nil, ErrSMSUnavailable
+	}
+
+	message := strings.TrimSpace(dto.Message)
+	if message == "" {
+		message = defaultReminderMessage
+	}
+
+	compliances, err := s.studentCompliances(ctx, StudentFilter{
+		TenantID:  dto.TenantID,
+		BranchID:  dto.BranchID,
+		ClassID:   dto.ClassID,
+		SectionID: dto.SectionID,
+	}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []StudentCompliance
+	for _, compliance := range compliances {
+		if compliance.Status == ComplianceStatusNonCompliant ||
+			(dto.IncludeDue && compliance.Status == ComplianceStatusDue) {
+			targets = append(targets, compliance)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, ErrNoRecipients
+	}
+
+	ids := make([]uuid.UUID, len(targets))
+	for i := range targets {
+		ids[i] = targets[i].Student.ID
+	}
+	guardians, err := s.repo.ListPrimaryGuardians(ctx, dto.TenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign := &models.ImmunizationReminderCampaign{
+		ID:         uuid.New(),
+		TenantID:   dto.TenantID,
+		Name:       name,
+		BranchID:   dto.BranchID,
+		ClassID:    dto.ClassID,
+		SectionID:  dto.SectionID,
+		IncludeDue: dto.IncludeDue,
+		Message:    message,
+		SentAt:     time.Now(),
+		CreatedBy:  dto.UserID,
+	}
+
+	for i := range targets {
+		target := &targets[i]
+		vaccines := strings.Join(pendingVaccines(target, dto.IncludeDue), ", ")
+		reminder := models.ImmunizationReminder{
+			ID:              uuid.New(),
+			TenantID:        dto.TenantID,
+			CampaignID:      campaign.ID,
+			StudentID:       target.Student.ID,
+			PendingVaccines: vaccines,
+		}
+
+		guardian, ok := guardians[target.Student.ID]
+		if !ok {
+			reminder.Status = models.ImmunizationReminderSkipped
+			reminder.Error = "no guardian phone number"
+			campaign.SkippedCount++
+		} else {
+			reminder.GuardianID = &guardian.ID
+			reminder.Phone = guardian.Phone
+			body := renderReminder(message, target.Student, guardian.FullName(), vaccines)
+			if _, err := s.smsProvider.Send(ctx, sms.Message{To: guardian.Phone, Body: body}); err != nil {
+				reminder.Status = models.ImmunizationReminderFailed
+				reminder.Error = err.Error()
+				campaign.FailedCount++
+			} else {
+				reminder.Status = models.ImmunizationReminderSent
+				campaign.SentCount++
+			}
+		}
+
+		campaign.Reminders = append(campaign.Reminders, reminder)
+	}
+	campaign.RecipientCount = len(campaign.Reminders)
+
+	if err := s.repo.CreateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// ListCampaigns returns reminder campaigns, newest first.
+func (s *Service) ListCampaigns(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]models.ImmunizationReminderCampaign, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListCampaigns(ctx, tenantID, limit, offset)
+}
+
+// GetCampaign returns a reminder campaign with its reminders.
+func (s *Service) GetCampaign(ctx context.Context, tenantID, id uuid.UUID) (*models.ImmunizationReminderCampaign, error) {
+	return s.repo.GetCampaign(ctx, tenantID, id)
+}
+
+// renderReminder fills the {{student_name}}, {{guardian_name}}, {{class}} and
+// {{vaccines}} placeholders of a reminder message.
+func renderReminder(message string, student StudentRow, guardianName, vaccines string) string {
+	class := strings.TrimSpace(student.ClassName + " " + student.SectionName)
+	return strings.NewReplacer(
+		"{{student_name}}", student.FullName(),
+		"{{guardian_name}}", strings.TrimSpace(guardianName),
+		"{{class}}", class,
+		"{{vaccines}}", vaccines,
+	).Replace(message)
+}
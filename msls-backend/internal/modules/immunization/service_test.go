@@ -0,0 +1,138 @@
+package immunization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func intPtr(i int) *int { return &i }
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func testSchedule() []models.ImmunizationScheduleItem {
+	return []models.ImmunizationScheduleItem{
+		{ID: uuid.New(), VaccineName: "MMR", DoseNumber: 1, MinAgeMonths: 9, MaxAgeMonths: intPtr(12), IsMandatory: true},
+		{ID: uuid.New(), VaccineName: "MMR", DoseNumber: 2, MinAgeMonths: 48, MaxAgeMonths: intPtr(72), IsMandatory: true},
+		{ID: uuid.New(), VaccineName: "Tdap", DoseNumber: 1, MinAgeMonths: 120, MaxAgeMonths: intPtr(144), IsMandatory: true},
+		{ID: uuid.New(), VaccineName: "Influenza", DoseNumber: 1, MinAgeMonths: 6, IsMandatory: false},
+	}
+}
+
+func TestAgeInMonths(t *testing.T) {
+	dob := date(2018, 3, 15)
+
+	assert.Equal(t, 0, ageInMonths(dob, dob))
+	assert.Equal(t, 0, ageInMonths(dob, date(2018, 4, 14)))
+	assert.Equal(t, 1, ageInMonths(dob, date(2018, 4, 15)))
+	assert.Equal(t, 95, ageInMonths(dob, date(2026, 3, 14)))
+	assert.Equal(t, 96, ageInMonths(dob, date(2026, 3, 15)))
+	assert.Equal(t, 0, ageInMonths(dob, date(2017, 1, 1)))
+}
+
+func TestEvaluateCompliance(t *testing.T) {
+	student := StudentRow{ID: uuid.New(), FirstName: "Asha", LastName: "Sharma", DateOfBirth: date(2018, 3, 15)}
+	items := testSchedule()
+
+	t.Run("missed dose past its window is non-compliant", func(t *testing.T) {
+		vaccinations := []models.StudentVaccination{
+			{VaccineName: "MMR", DoseNumber: 1, AdministeredDate: date(2019, 1, 10)},
+		}
+
+		compliance := evaluateCompliance(student, items, vaccinations, date(2026, 3, 15))
+
+		assert.Equal(t, 96, compliance.AgeMonths)
+		assert.Equal(t, ComplianceStatusNonCompliant, compliance.Status)
+		require.Len(t, compliance.Doses, 4)
+		assert.Equal(t, DoseStatusGiven, compliance.Doses[0].Status)
+		assert.Equal(t, date(2019, 1, 10), *compliance.Doses[0].AdministeredDate)
+		assert.Equal(t, DoseStatusOverdue, compliance.Doses[1].Status)
+		assert.Equal(t, date(2022, 3, 15), compliance.Doses[1].DueFrom)
+		assert.Equal(t, date(2024, 3, 15), *compliance.Doses[1].DueBy)
+		assert.Equal(t, DoseStatusNotDue, compliance.Doses[2].Status)
+		assert.Equal(t, DoseStatusDue, compliance.Doses[3].Status)
+		assert.Equal(t, []string{"MMR dose 2"}, pendingVaccines(&compliance, false))
+	})
+
+	t.Run("dose within its window is due", func(t *testing.T) {
+		vaccinations := []models.StudentVaccination{
+			{VaccineName: " mmr ", DoseNumber: 1, AdministeredDate: date(2019, 1, 10)},
+		}
+
+		compliance := evaluateCompliance(student, items, vaccinations, date(2023, 1, 1))
+
+		assert.Equal(t, ComplianceStatusDue, compliance.Status)
+		assert.Empty(t, pendingVaccines(&compliance, false))
+		assert.Equal(t, []string{"MMR dose 2"}, pendingVaccines(&compliance, true))
+	})
+
+	t.Run("later dose covers earlier doses", func(t *testing.T) {
+		vaccinations := []models.StudentVaccination{
+			{VaccineName: "MMR", DoseNumber: 2, AdministeredDate: date(2022, 6, 1)},
+		}
+
+		compliance := evaluateCompliance(student, items, vaccinations, date(2026, 3, 15))
+
+		assert.Equal(t, ComplianceStatusCompliant, compliance.Status)
+		assert.Equal(t, date(2022, 6, 1), *compliance.Doses[0].AdministeredDate)
+		assert.Equal(t, DoseStatusGiven, compliance.Doses[1].Status)
+	})
+
+	t.Run("optional doses do not affect compliance", func(t *testing.T) {
+		compliance := evaluateCompliance(student, items[3:], nil, date(2026, 3, 15))
+
+		assert.Equal(t, ComplianceStatusCompliant, compliance.Status)
+		assert.Equal(t, DoseStatusDue, compliance.Doses[0].Status)
+	})
+}
+
+func TestRenderReminder(t *testing.T) {
+	student := StudentRow{FirstName: "Asha", LastName: "Sharma", ClassName: "Class 3", SectionName: "B"}
+
+	body := renderReminder(defaultReminderMessage, student, "Priya Sharma", "MMR dose 2, Tdap dose 1")
+
+	assert.Equal(t, "Dear Priya Sharma, school health records show Asha Sharma (Class 3 B) has not received: "+
+		"MMR dose 2, Tdap dose 1. Please complete the vaccination and share the record with the school.", body)
+}
+
+func TestToComplianceReportResponse(t *testing.T) {
+	classID := uuid.New()
+	student := StudentRow{ID: uuid.New(), FirstName: "Asha", LastName: "Sharma", DateOfBirth: date(2018, 3, 15), ClassID: &classID, ClassName: "Class 3"}
+	compliance := evaluateCompliance(student, testSchedule(), nil, date(2026, 3, 15))
+	report := &ComplianceReport{
+		AsOf:    date(2026, 3, 15),
+		Summary: ComplianceSummary{TotalStudents: 2, Compliant: 1, NonCompliant: 1},
+		Classes: []ClassCompliance{{
+			ClassID:   &classID,
+			ClassName: "Class 3",
+			Summary:   ComplianceSummary{TotalStudents: 2, Compliant: 1, NonCompliant: 1},
+			Students:  []StudentCompliance{compliance},
+		}},
+	}
+
+	resp := ToComplianceReportResponse(report)
+
+	assert.Equal(t, "2026-03-15", resp.AsOf)
+	require.Len(t, resp.Classes, 1)
+	assert.Equal(t, classID.String(), resp.Classes[0].ClassID)
+	require.Len(t, resp.Classes[0].Students, 1)
+	assert.Equal(t, "non_compliant", resp.Classes[0].Students[0].Status)
+	assert.Equal(t, []string{"MMR dose 1", "MMR dose 2"}, resp.Classes[0].Students[0].Pending)
+	assert.Nil(t, resp.Classes[0].Students[0].Doses)
+}
+
+func TestCreateCampaign_RequiresSMS(t *testing.T) {
+	service := NewService(nil, nil)
+
+	_, err := service.CreateCampaign(context.Background(), CreateCampaignDTO{TenantID: uuid.New(), Name: "Annual inspection"})
+
+	assert.ErrorIs(t, err, ErrSMSUnavailable)
+}
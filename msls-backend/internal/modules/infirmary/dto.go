@@ -0,0 +1,441 @@
+// Package infirmary provides the school clinic visit log and medication administration schedule.
+package infirmary
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// VisitFilter contains filters for the infirmary visit log.
+type VisitFilter struct {
+	TenantID    uuid.UUID
+	StudentID   *uuid.UUID
+	Date        *time.Time
+	Disposition models.InfirmaryDisposition
+	OpenOnly    bool
+	Limit       int
+	Offset      int
+}
+
+// Vitals are the measurements taken during a visit.
+type Vitals struct {
+	Temperature      *decimal.Decimal
+	PulseRate        *int
+	RespiratoryRate  *int
+	BloodPressureSys *int
+	BloodPressureDia *int
+	OxygenSaturation *int
+}
+
+// RecordVisitDTO represents a request to record an infirmary visit.
+type RecordVisitDTO struct {
+	TenantID    uuid.UUID
+	StudentID   uuid.UUID
+	CheckInAt   time.Time
+	Complaint   string
+	Symptoms    string
+	Vitals      Vitals
+	Treatment   string
+	Disposition models.InfirmaryDisposition
+	CheckOutAt  *time.Time
+	ReleasedTo  string
+	Notes       string
+	UserID      *uuid.UUID
+}
+
+// UpdateVisitDTO represents corrections to a visit's clinical details.
+type UpdateVisitDTO struct {
+	Complaint *string
+	Symptoms  *string
+	Vitals    Vitals
+	Treatment *string
+	Notes     *string
+	UserID    *uuid.UUID
+}
+
+// DischargeDTO represents a request to discharge a student from the infirmary.
+type DischargeDTO struct {
+	Disposition models.InfirmaryDisposition
+	CheckOutAt  *time.Time
+	ReleasedTo  string
+	Notes       string
+	UserID      *uuid.UUID
+}
+
+// ScheduleFilter contains filters for the daily medication schedule.
+type ScheduleFilter struct {
+	TenantID  uuid.UUID
+	Date      time.Time
+	BranchID  *uuid.UUID
+	StudentID *uuid.UUID
+}
+
+// MedicationRow is an active school-administered medication with the student's details.
+type MedicationRow struct {
+	models.StudentMedication
+	StudentName     string
+	AdmissionNumber string
+	ClassName       string
+	SectionName     string
+}
+
+// ScheduledDose is one dose on the daily medication schedule.
+type ScheduledDose struct {
+	Medication     *MedicationRow
+	ScheduledTime  string
+	Administration *models.MedicationAdministration
+}
+
+// Schedule is the medication administration schedule for a day.
+type Schedule struct {
+	Date     time.Time
+	Doses    []ScheduledDose
+	AsNeeded []MedicationRow
+}
+
+// RecordDoseDTO represents a request to record a dose as given, missed or refused.
+type RecordDoseDTO struct {
+	TenantID       uuid.UUID
+	MedicationID   uuid.UUID
+	Date           time.Time
+	ScheduledTime  string
+	Status         models.MedicationAdministrationStatus
+	DoseGiven      string
+	AdministeredAt *time.Time
+	VisitID        *uuid.UUID
+	Notes          string
+	UserID         *uuid.UUID
+}
+
+// PeriodWindow is a teaching period on a section's timetable for a day.
+type PeriodWindow struct {
+	PeriodSlotID     uuid.UUID
+	TimetableEntryID uuid.UUID
+	StartTime        string
+	EndTime          string
+}
+
+// attendanceMark is an attendance change made by the infirmary.
+type attendanceMark struct {
+	PeriodSlotID     *uuid.UUID
+	TimetableEntryID *uuid.UUID
+	Status           models.StudentAttendanceStatus
+	Remark           string
+}
+
+// =========================================================================
+// Request Types
+// =========================================================================
+
+// VitalsRequest represents vitals in request bodies.
+type VitalsRequest struct {
+	Temperature      *decimal.Decimal `json:"temperature"`
+	PulseRate        *int             `json:"pulseRate" binding:"omitempty,min=20,max=250"`
+	RespiratoryRate  *int             `json:"respiratoryRate" binding:"omitempty,min=5,max=80"`
+	BloodPressureSys *int             `json:"bloodPressureSys" binding:"omitempty,min=50,max=250"`
+	BloodPressureDia *int             `json:"bloodPressureDia" binding:"omitempty,min=30,max=150"`
+	OxygenSaturation *int             `json:"oxygenSaturation" binding:"omitempty,min=50,max=100"`
+}
+
+// RecordVisitRequest represents the request body for recording a visit.
+type RecordVisitRequest struct {
+	StudentID   string        `json:"studentId" binding:"required,uuid"`
+	CheckInAt   *time.Time    `json:"checkInAt"`
+	Complaint   string        `json:"complaint" binding:"required,max=500"`
+	Symptoms    string        `json:"symptoms"`
+	Vitals      VitalsRequest `json:"vitals"`
+	Treatment   string        `json:"treatment"`
+	Disposition string        `json:"disposition" binding:"omitempty,oneof=under_observation returned_to_class sent_home referred_to_hospital"`
+	CheckOutAt  *time.Time    `json:"checkOutAt"`
+	ReleasedTo  string        `json:"releasedTo" binding:"max=200"`
+	Notes       string        `json:"notes"`
+}
+
+// UpdateVisitRequest represents the request body for updating a visit.
+type UpdateVisitRequest struct {
+	Complaint *string       `json:"complaint" binding:"omitempty,max=500"`
+	Symptoms  *string       `json:"symptoms"`
+	Vitals    VitalsRequest `json:"vitals"`
+	Treatment *string       `json:"treatment"`
+	Notes     *string       `json:"notes"`
+}
+
+// DischargeRequest represents the request body for discharging a student.
+type DischargeRequest struct {
+	Disposition string     `json:"disposition" binding:"required,oneof=returned_to_class sent_home referred_to_hospital"`
+	CheckOutAt  *time.Time `json:"checkOutAt"`
+	ReleasedTo  string     `json:"releasedTo" binding:"max=200"`
+	Notes       string     `json:"notes"`
+}
+
+// RecordDoseRequest represents the request body for recording a dose.
+type RecordDoseRequest struct {
+	MedicationID   string     `json:"medicationId" binding:"required,uuid"`
+	Date           string     `json:"date" binding:"required"`
+	ScheduledTime  string     `json:"scheduledTime"`
+	Status         string     `json:"status" binding:"required,oneof=given missed refused"`
+	DoseGiven      string     `json:"doseGiven" binding:"max=50"`
+	AdministeredAt *time.Time `json:"administeredAt"`
+	VisitID        *string    `json:"visitId" binding:"omitempty,uuid"`
+	Notes          string     `json:"notes"`
+}
+
+// =========================================================================
+// Response Types
+// =========================================================================
+
+// VitalsResponse represents vitals in API responses.
+type VitalsResponse struct {
+	Temperature      *string `json:"temperature,omitempty"`
+	PulseRate        *int    `json:"pulseRate,omitempty"`
+	RespiratoryRate  *int    `json:"respiratoryRate,omitempty"`
+	BloodPressureSys *int    `json:"bloodPressureSys,omitempty"`
+	BloodPressureDia *int    `json:"bloodPressureDia,omitempty"`
+	OxygenSaturation *int    `json:"oxygenSaturation,omitempty"`
+}
+
+// VisitResponse represents an infirmary visit in API responses.
+type VisitResponse struct {
+	ID                 string         `json:"id"`
+	StudentID          string         `json:"studentId"`
+	StudentName        string         `json:"studentName,omitempty"`
+	AdmissionNumber    string         `json:"admissionNumber,omitempty"`
+	CheckInAt          string         `json:"checkInAt"`
+	CheckOutAt         string         `json:"checkOutAt,omitempty"`
+	Complaint          string         `json:"complaint"`
+	Symptoms           string         `json:"symptoms,omitempty"`
+	Vitals             VitalsResponse `json:"vitals"`
+	Treatment          string         `json:"treatment,omitempty"`
+	Disposition        string         `json:"disposition"`
+	ReleasedTo         string         `json:"releasedTo,omitempty"`
+	Notes              string         `json:"notes,omitempty"`
+	GuardianNotifiedAt string         `json:"guardianNotifiedAt,omitempty"`
+	AttendanceMarkedAt string         `json:"attendanceMarkedAt,omitempty"`
+	CreatedAt          string         `json:"createdAt"`
+}
+
+// VisitListResponse represents the visit log.
+type VisitListResponse struct {
+	Visits []VisitResponse `json:"visits"`
+	Total  int64           `json:"total"`
+}
+
+// DoseResponse represents a dose on the medication schedule.
+type DoseResponse struct {
+	MedicationID        string `json:"medicationId"`
+	StudentID           string `json:"studentId"`
+	StudentName         string `json:"studentName"`
+	AdmissionNumber     string `json:"admissionNumber"`
+	ClassName           string `json:"className,omitempty"`
+	SectionName         string `json:"sectionName,omitempty"`
+	MedicationName      string `json:"medicationName"`
+	Dosage              string `json:"dosage"`
+	Route               string `json:"route"`
+	SpecialInstructions string `json:"specialInstructions,omitempty"`
+	ScheduledTime       string `json:"scheduledTime"`
+	Status              string `json:"status"`
+	AdministrationID    string `json:"administrationId,omitempty"`
+	AdministeredAt      string `json:"administeredAt,omitempty"`
+	DoseGiven           string `json:"doseGiven,omitempty"`
+	Notes               string `json:"notes,omitempty"`
+}
+
+// AsNeededResponse represents a medication given only when needed.
+type AsNeededResponse struct {
+	MedicationID        string `json:"medicationId"`
+	StudentID           string `json:"studentId"`
+	StudentName         string `json:"studentName"`
+	AdmissionNumber     string `json:"admissionNumber"`
+	MedicationName      string `json:"medicationName"`
+	Dosage              string `json:"dosage"`
+	Route               string `json:"route"`
+	SpecialInstructions string `json:"specialInstructions,omitempty"`
+}
+
+// ScheduleSummary counts doses on the schedule by status.
+type ScheduleSummary struct {
+	Total   int `json:"total"`
+	Given   int `json:"given"`
+	Missed  int `json:"missed"`
+	Refused int `json:"refused"`
+	Pending int `json:"pending"`
+	Overdue int `json:"overdue"`
+}
+
+// ScheduleResponse represents the daily medication schedule.
+type ScheduleResponse struct {
+	Date     string             `json:"date"`
+	Doses    []DoseResponse     `json:"doses"`
+	AsNeeded []AsNeededResponse `json:"asNeeded"`
+	Summary  ScheduleSummary    `json:"summary"`
+}
+
+// AdministrationResponse represents a recorded dose in API responses.
+type AdministrationResponse struct {
+	ID                 string `json:"id"`
+	StudentID          string `json:"studentId"`
+	MedicationID       string `json:"medicationId"`
+	AdministrationDate string `json:"administrationDate"`
+	ScheduledTime      string `json:"scheduledTime"`
+	Status             string `json:"status"`
+	DoseGiven          string `json:"doseGiven,omitempty"`
+	AdministeredAt     string `json:"administeredAt,omitempty"`
+	VisitID            string `json:"visitId,omitempty"`
+	Notes              string `json:"notes,omitempty"`
+}
+
+// ToVisitResponse converts an InfirmaryVisit model to a VisitResponse.
+func ToVisitResponse(visit *models.InfirmaryVisit) VisitResponse {
+	resp := VisitResponse{
+		ID:        visit.ID.String(),
+		StudentID: visit.StudentID.String(),
+		CheckInAt: visit.CheckInAt.Format(time.RFC3339),
+		Complaint: visit.Complaint,
+		Symptoms:  visit.Symptoms,
+		Vitals: VitalsResponse{
+			PulseRate:        visit.PulseRate,
+			RespiratoryRate:  visit.RespiratoryRate,
+			BloodPressureSys: visit.BloodPressureSys,
+			BloodPressureDia: visit.BloodPressureDia,
+			OxygenSaturation: visit.OxygenSaturation,
+		},
+		Treatment:          visit.Treatment,
+		Disposition:        string(visit.Disposition),
+		ReleasedTo:         visit.ReleasedTo,
+		Notes:              visit.Notes,
+		GuardianNotifiedAt: formatTime(visit.GuardianNotifiedAt),
+		AttendanceMarkedAt: formatTime(visit.AttendanceMarkedAt),
+		CheckOutAt:         formatTime(visit.CheckOutAt),
+		CreatedAt:          visit.CreatedAt.Format(time.RFC3339),
+	}
+	if visit.Temperature != nil {
+		temperature := visit.Temperature.StringFixed(1)
+		resp.Vitals.Temperature = &temperature
+	}
+	if visit.Student != nil {
+		resp.StudentName = visit.Student.FullName()
+		resp.AdmissionNumber = visit.Student.AdmissionNumber
+	}
+	return resp
+}
+
+// ToVisitResponses converts a slice of InfirmaryVisit models to VisitResponses.
+func ToVisitResponses(visits []models.InfirmaryVisit) []VisitResponse {
+	responses := make([]VisitResponse, len(visits))
+	for i := range visits {
+		responses[i] = ToVisitResponse(&visits[i])
+	}
+	return responses
+}
+
+// ToScheduleResponse converts a Schedule to a ScheduleResponse. Pending doses
+// whose time has passed are reported as overdue.
+func ToScheduleResponse(schedule *Schedule, now time.Time) ScheduleResponse {
+	resp := ScheduleResponse{
+		Date:     schedule.Date.Format("2006-01-02"),
+		Doses:    make([]DoseResponse, len(schedule.Doses)),
+		AsNeeded: make([]AsNeededResponse, len(schedule.AsNeeded)),
+	}
+
+	for i, dose := range schedule.Doses {
+		medication := dose.Medication
+		item := DoseResponse{
+			MedicationID:    medication.ID.String(),
+			StudentID:       medication.StudentID.String(),
+			StudentName:     medication.StudentName,
+			AdmissionNumber: medication.AdmissionNumber,
+			ClassName:       medication.ClassName,
+			SectionName:     medication.SectionName,
+			MedicationName:  medication.MedicationName,
+			Dosage:          medication.Dosage,
+			Route:           string(medication.Route),
+			ScheduledTime:   dose.ScheduledTime,
+			Status:          doseStatus(schedule.Date, dose, now),
+		}
+		if medication.SpecialInstructions != nil {
+			item.SpecialInstructions = *medication.SpecialInstructions
+		}
+		if administration := dose.Administration; administration != nil {
+			item.AdministrationID = administration.ID.String()
+			item.AdministeredAt = formatTime(administration.AdministeredAt)
+			item.DoseGiven = administration.DoseGiven
+			item.Notes = administration.Notes
+		}
+		resp.Doses[i] = item
+
+		resp.Summary.Total++
+		switch item.Status {
+		case string(models.MedicationAdministrationGiven):
+			resp.Summary.Given++
+		case string(models.MedicationAdministrationMissed):
+			resp.Summary.Missed++
+		case string(models.MedicationAdministrationRefused):
+			resp.Summary.Refused++
+		case doseStatusOverdue:
+			resp.Summary.Overdue++
+		default:
+			resp.Summary.Pending++
+		}
+	}
+
+	for i, medication := range schedule.AsNeeded {
+		item := AsNeededResponse{
+			MedicationID:    medication.ID.String(),
+			StudentID:       medication.StudentID.String(),
+			StudentName:     medication.StudentName,
+			AdmissionNumber: medication.AdmissionNumber,
+			MedicationName:  medication.MedicationName,
+			Dosage:          medication.Dosage,
+			Route:           string(medication.Route),
+		}
+		if medication.SpecialInstructions != nil {
+			item.SpecialInstructions = *medication.SpecialInstructions
+		}
+		resp.AsNeeded[i] = item
+	}
+
+	return resp
+}
+
+// ToAdministrationResponse converts a MedicationAdministration model to an AdministrationResponse.
+func ToAdministrationResponse(administration *models.MedicationAdministration) AdministrationResponse {
+	resp := AdministrationResponse{
+		ID:                 administration.ID.String(),
+		StudentID:          administration.StudentID.String(),
+		MedicationID:       administration.MedicationID.String(),
+		AdministrationDate: administration.AdministrationDate.Format("2006-01-02"),
+		ScheduledTime:      administration.ScheduledTime,
+		Status:             string(administration.Status),
+		DoseGiven:          administration.DoseGiven,
+		AdministeredAt:     formatTime(administration.AdministeredAt),
+		Notes:              administration.Notes,
+	}
+	if administration.VisitID != nil {
+		resp.VisitID = administration.VisitID.String()
+	}
+	return resp
+}
+
+// toVitals converts a vitals request to Vitals.
+func toVitals(req VitalsRequest) Vitals {
+	return Vitals{
+		Temperature:      req.Temperature,
+		PulseRate:        req.PulseRate,
+		RespiratoryRate:  req.RespiratoryRate,
+		BloodPressureSys: req.BloodPressureSys,
+		BloodPressureDia: req.BloodPressureDia,
+		OxygenSaturation: req.OxygenSaturation,
+	}
+}
+
+// formatTime formats an optional timestamp as RFC3339.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
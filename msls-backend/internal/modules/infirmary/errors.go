@@ -0,0 +1,37 @@
+// Package infirmary provides the school clinic visit log and medication administration schedule.
+package infirmary
+
+import "errors"
+
+// Infirmary-related errors.
+var (
+	// ErrVisitNotFound is returned when an infirmary visit is not found.
+	ErrVisitNotFound = errors.New("infirmary visit not found")
+
+	// ErrStudentNotFound is returned when the student is not found.
+	ErrStudentNotFound = errors.New("student not found")
+
+	// ErrMedicationNotFound is returned when the student medication is not found.
+	ErrMedicationNotFound = errors.New("medication not found")
+
+	// ErrComplaintRequired is returned when a visit is recorded without a complaint.
+	ErrComplaintRequired = errors.New("complaint is required")
+
+	// ErrInvalidDisposition is returned when the disposition is not recognised.
+	ErrInvalidDisposition = errors.New("invalid disposition")
+
+	// ErrVisitClosed is returned when discharging a visit that has already been discharged.
+	ErrVisitClosed = errors.New("visit has already been discharged")
+
+	// ErrInvalidCheckOut is returned when the check-out time is before check-in.
+	ErrInvalidCheckOut = errors.New("check-out time is before check-in")
+
+	// ErrInvalidAdministrationStatus is returned when the dose status is not recognised.
+	ErrInvalidAdministrationStatus = errors.New("invalid administration status")
+
+	// ErrMedicationNotScheduled is returned when recording a dose that is not on the schedule for the day.
+	ErrMedicationNotScheduled = errors.New("medication is not scheduled at school for this date and time")
+
+	// ErrFutureDate is returned when recording a dose for a future date.
+	ErrFutureDate = errors.New("cannot record doses for a future date")
+)
@@ -0,0 +1,451 @@
+// Package infirmary provides the school clinic visit log and medication administration schedule.
+package infirmary
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/response"
+)
+
+// Handler handles infirmary-related HTTP requests.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new infirmary handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// =========================================================================
+// Visits
+// =========================================================================
+
+// ListVisits returns the infirmary visit log.
+// @Summary List infirmary visits
+// @Description List infirmary visits, newest first
+// @Tags Infirmary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param studentId query string false "Filter by student"
+// @Param date query string false "Filter by check-in date (YYYY-MM-DD)"
+// @Param disposition query string false "Filter by disposition (under_observation, returned_to_class, sent_home, referred_to_hospital)"
+// @Param open query bool false "Only students still in the infirmary"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=VisitListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 401 {object} apperrors.AppError
+// @Router /api/v1/infirmary/visits [get]
+func (h *Handler) ListVisits(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := VisitFilter{
+		TenantID:    tenantID,
+		Disposition: models.InfirmaryDisposition(c.Query("disposition")),
+		OpenOnly:    c.Query("open") == "true",
+	}
+	if studentIDStr := c.Query("studentId"); studentIDStr != "" {
+		studentID, err := uuid.Parse(studentIDStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid student ID"))
+			return
+		}
+		filter.StudentID = &studentID
+	}
+	if !middleware.ParseDateQuery(c, "date", &filter.Date) || !middleware.ParsePaging(c, &filter.Limit, &filter.Offset) {
+		return
+	}
+
+	visits, total, err := h.service.ListVisits(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, VisitListResponse{Visits: ToVisitResponses(visits), Total: total})
+}
+
+// ListStudentVisits returns a student's infirmary visits.
+// @Summary List student infirmary visits
+// @Description List a student's infirmary visits, newest first
+// @Tags Infirmary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Student ID"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=VisitListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/students/{id}/infirmary-visits [get]
+func (h *Handler) ListStudentVisits(c *gin.Context) {
+	tenantID, studentID, ok := middleware.TenantAndID(c, "id", "Invalid student ID")
+	if !ok {
+		return
+	}
+
+	filter := VisitFilter{TenantID: tenantID, StudentID: &studentID}
+	if !middleware.ParsePaging(c, &filter.Limit, &filter.Offset) {
+		return
+	}
+
+	visits, total, err := h.service.ListVisits(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, VisitListResponse{Visits: ToVisitResponses(visits), Total: total})
+}
+
+// GetVisit returns an infirmary visit.
+// @Summary Get infirmary visit
+// @Description Get an infirmary visit by ID
+// @Tags Infirmary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Visit ID"
+// @Success 200 {object} response.Success{data=VisitResponse}
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/infirmary/visits/{id} [get]
+func (h *Handler) GetVisit(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid visit ID")
+	if !ok {
+		return
+	}
+
+	visit, err := h.service.GetVisit(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToVisitResponse(visit))
+}
+
+// RecordVisit records a student's visit to the infirmary.
+// @Summary Record infirmary visit
+// @Description Record a clinic visit with vitals and treatment; notifies the guardian and marks attendance
+// @Tags Infirmary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body RecordVisitRequest true "Visit details"
+// @Success 201 {object} response.Success{data=VisitResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/infirmary/visits [post]
+func (h *Handler) RecordVisit(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req RecordVisitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	studentID, err := uuid.Parse(req.StudentID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid student ID"))
+		return
+	}
+
+	dto := RecordVisitDTO{
+		TenantID:    tenantID,
+		StudentID:   studentID,
+		Complaint:   req.Complaint,
+		Symptoms:    req.Symptoms,
+		Vitals:      toVitals(req.Vitals),
+		Treatment:   req.Treatment,
+		Disposition: models.InfirmaryDisposition(req.Disposition),
+		CheckOutAt:  req.CheckOutAt,
+		ReleasedTo:  req.ReleasedTo,
+		Notes:       req.Notes,
+		UserID:      &userID,
+	}
+	if req.CheckInAt != nil {
+		dto.CheckInAt = *req.CheckInAt
+	}
+
+	visit, err := h.service.RecordVisit(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToVisitResponse(visit))
+}
+
+// UpdateVisit corrects the clinical details of a visit.
+// @Summary Update infirmary visit
+// @Description Correct the complaint, symptoms, vitals, treatment or notes of a visit
+// @Tags Infirmary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Visit ID"
+// @Param request body UpdateVisitRequest true "Visit details"
+// @Success 200 {object} response.Success{data=VisitResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/infirmary/visits/{id} [put]
+func (h *Handler) UpdateVisit(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid visit ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req UpdateVisitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	visit, err := h.service.UpdateVisit(c.Request.Context(), tenantID, id, UpdateVisitDTO{
+		Complaint: req.Complaint,
+		Symptoms:  req.Symptoms,
+		Vitals:    toVitals(req.Vitals),
+		Treatment: req.Treatment,
+		Notes:     req.Notes,
+		UserID:    &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToVisitResponse(visit))
+}
+
+// Discharge records where a student went after an infirmary visit.
+// @Summary Discharge student from infirmary
+// @Description Return the student to class or send them home or to hospital; sending home notifies the guardian and marks the rest of the day absent
+// @Tags Infirmary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Visit ID"
+// @Param request body DischargeRequest true "Discharge details"
+// @Success 200 {object} response.Success{data=VisitResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/infirmary/visits/{id}/discharge [post]
+func (h *Handler) Discharge(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid visit ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req DischargeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	visit, err := h.service.Discharge(c.Request.Context(), tenantID, id, DischargeDTO{
+		Disposition: models.InfirmaryDisposition(req.Disposition),
+		CheckOutAt:  req.CheckOutAt,
+		ReleasedTo:  req.ReleasedTo,
+		Notes:       req.Notes,
+		UserID:      &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToVisitResponse(visit))
+}
+
+// =========================================================================
+// Medication Administration
+// =========================================================================
+
+// GetSchedule returns the medication administration schedule for a day.
+// @Summary Get medication schedule
+// @Description Get the doses due at school on a day, generated from active medications, with recorded outcomes
+// @Tags Infirmary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Param branchId query string false "Filter by branch"
+// @Param studentId query string false "Filter by student"
+// @Success 200 {object} response.Success{data=ScheduleResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/infirmary/medication-schedule [get]
+func (h *Handler) GetSchedule(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	now := time.Now()
+	filter := ScheduleFilter{
+		TenantID: tenantID,
+		Date:     time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local),
+	}
+	if dateStr := c.Query("date"); dateStr != "" {
+		date, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid date, expected YYYY-MM-DD"))
+			return
+		}
+		filter.Date = date
+	}
+	if branchIDStr := c.Query("branchId"); branchIDStr != "" {
+		branchID, err := uuid.Parse(branchIDStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid branch ID"))
+			return
+		}
+		filter.BranchID = &branchID
+	}
+	if studentIDStr := c.Query("studentId"); studentIDStr != "" {
+		studentID, err := uuid.Parse(studentIDStr)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid student ID"))
+			return
+		}
+		filter.StudentID = &studentID
+	}
+
+	schedule, err := h.service.GetSchedule(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToScheduleResponse(schedule, now))
+}
+
+// RecordDose records a dose as given, missed or refused.
+// @Summary Record medication dose
+// @Description Record a scheduled dose as given, missed or refused; as-needed doses are recorded at the time given
+// @Tags Infirmary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body RecordDoseRequest true "Dose details"
+// @Success 201 {object} response.Success{data=AdministrationResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/infirmary/medication-administrations [post]
+func (h *Handler) RecordDose(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req RecordDoseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	medicationID, err := uuid.Parse(req.MedicationID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid medication ID"))
+		return
+	}
+	date, err := time.ParseInLocation("2006-01-02", req.Date, time.Local)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid date, expected YYYY-MM-DD"))
+		return
+	}
+	visitID, ok := middleware.ParseOptionalUUID(c, req.VisitID, "Invalid visit ID")
+	if !ok {
+		return
+	}
+
+	administration, err := h.service.RecordDose(c.Request.Context(), RecordDoseDTO{
+		TenantID:       tenantID,
+		MedicationID:   medicationID,
+		Date:           date,
+		ScheduledTime:  req.ScheduledTime,
+		Status:         models.MedicationAdministrationStatus(req.Status),
+		DoseGiven:      req.DoseGiven,
+		AdministeredAt: req.AdministeredAt,
+		VisitID:        visitID,
+		Notes:          req.Notes,
+		UserID:         &userID,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToAdministrationResponse(administration))
+}
+
+// =========================================================================
+// Helpers
+// =========================================================================
+
+// handleServiceError converts service errors to API errors.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrVisitNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Infirmary visit not found"))
+	case errors.Is(err, ErrStudentNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Student not found"))
+	case errors.Is(err, ErrMedicationNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Medication not found"))
+	case errors.Is(err, ErrComplaintRequired):
+		apperrors.Abort(c, apperrors.BadRequest("Complaint is required"))
+	case errors.Is(err, ErrInvalidDisposition):
+		apperrors.Abort(c, apperrors.BadRequest("Invalid disposition"))
+	case errors.Is(err, ErrInvalidCheckOut):
+		apperrors.Abort(c, apperrors.BadRequest("Check-out time cannot be before check-in"))
+	case errors.Is(err, ErrInvalidAdministrationStatus):
+		apperrors.Abort(c, apperrors.BadRequest("Invalid administration status"))
+	case errors.Is(err, ErrMedicationNotScheduled):
+		apperrors.Abort(c, apperrors.BadRequest("Medication is not scheduled at school for this date and time"))
+	case errors.Is(err, ErrFutureDate):
+		apperrors.Abort(c, apperrors.BadRequest("Cannot record doses for a future date"))
+	case errors.Is(err, ErrVisitClosed):
+		apperrors.Abort(c, apperrors.Conflict("Visit has already been discharged"))
+	default:
+		logger.Error("Infirmary operation error", zap.Error(err))
+		apperrors.Abort(c, apperrors.InternalError("Failed to process infirmary request"))
+	}
+}
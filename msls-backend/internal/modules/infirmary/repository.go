@@ -0,0 +1,333 @@
+// Package infirmary provides the school clinic visit log and medication administration schedule.
+package infirmary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/modules/timetable"
+	"msls-backend/internal/pkg/database/models"
+)
+
+// Repository handles database operations for the infirmary.
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new infirmary repository.
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// =========================================================================
+// Visit Operations
+// =========================================================================
+
+// ListVisits retrieves infirmary visits matching the filter, newest first.
+func (r *Repository) ListVisits(ctx context.Context, filter VisitFilter) ([]models.InfirmaryVisit, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.InfirmaryVisit{}).
+		Where("tenant_id = ?", filter.TenantID)
+
+	if filter.StudentID != nil {
+		query = query.Where("student_id = ?", *filter.StudentID)
+	}
+	if filter.Date != nil {
+		start := time.Date(filter.Date.Year(), filter.Date.Month(), filter.Date.Day(), 0, 0, 0, 0, time.Local)
+		query = query.Where("check_in_at >= ? AND check_in_at < ?", start, start.AddDate(0, 0, 1))
+	}
+	if filter.Disposition != "" {
+		query = query.Where("disposition = ?", filter.Disposition)
+	}
+	if filter.OpenOnly {
+		query = query.Where("check_out_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count infirmary visits: %w", err)
+	}
+
+	var visits []models.InfirmaryVisit
+	err := query.
+		Preload("Student").
+		Order("check_in_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&visits).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("list infirmary visits: %w", err)
+	}
+
+	return visits, total, nil
+}
+
+// GetVisit retrieves an infirmary visit by ID.
+func (r *Repository) GetVisit(ctx context.Context, tenantID, id uuid.UUID) (*models.InfirmaryVisit, error) {
+	var visit models.InfirmaryVisit
+	err := r.db.WithContext(ctx).
+		Preload("Student").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&visit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVisitNotFound
+		}
+		return nil, fmt.Errorf("get infirmary visit: %w", err)
+	}
+	return &visit, nil
+}
+
+// CreateVisit creates an infirmary visit.
+func (r *Repository) CreateVisit(ctx context.Context, visit *models.InfirmaryVisit) error {
+	if err := r.db.WithContext(ctx).Omit("Student").Create(visit).Error; err != nil {
+		return fmt.Errorf("create infirmary visit: %w", err)
+	}
+	return nil
+}
+
+// UpdateVisit updates columns of an infirmary visit.
+func (r *Repository) UpdateVisit(ctx context.Context, visit *models.InfirmaryVisit, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&models.InfirmaryVisit{}).
+		Where("tenant_id = ? AND id = ?", visit.TenantID, visit.ID).
+		Updates(updates).Error
+	if err != nil {
+		return fmt.Errorf("update infirmary visit: %w", err)
+	}
+	return nil
+}
+
+// =========================================================================
+// Student Data
+// =========================================================================
+
+// GetStudent retrieves a student.
+func (r *Repository) GetStudent(ctx context.Context, tenantID, studentID uuid.UUID) (*models.Student, error) {
+	var student models.Student
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, studentID).
+		First(&student).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+		return nil, fmt.Errorf("get student: %w", err)
+	}
+	return &student, nil
+}
+
+// GetPrimaryGuardian retrieves the guardian to notify, primary guardian
+// first, or nil when the student has none with a phone number.
+func (r *Repository) GetPrimaryGuardian(ctx context.Context, tenantID, studentID uuid.UUID) (*models.StudentGuardian, error) {
+	var guardians []models.StudentGuardian
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id = ? AND phone <> ''", tenantID, studentID).
+		Order("is_primary DESC, created_at ASC").
+		Limit(1).
+		Find(&guardians).Error
+	if err != nil {
+		return nil, fmt.Errorf("get primary guardian: %w", err)
+	}
+	if len(guardians) == 0 {
+		return nil, nil
+	}
+	return &guardians[0], nil
+}
+
+// GetActiveSectionID retrieves the section of the student's active enrollment, or nil.
+func (r *Repository) GetActiveSectionID(ctx context.Context, tenantID, studentID uuid.UUID) (*uuid.UUID, error) {
+	var sectionIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Table("student_enrollments").
+		Where("tenant_id = ? AND student_id = ? AND status = ? AND section_id IS NOT NULL", tenantID, studentID, "active").
+		Limit(1).
+		Pluck("section_id", &sectionIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("get active section: %w", err)
+	}
+	if len(sectionIDs) == 0 {
+		return nil, nil
+	}
+	return &sectionIDs[0], nil
+}
+
+// ListDayPeriods retrieves the teaching periods on a date from the section's
+// timetable version in force on that date, in time order.
+func (r *Repository) ListDayPeriods(ctx context.Context, tenantID, sectionID uuid.UUID, date time.Time) ([]PeriodWindow, error) {
+	var periods []PeriodWindow
+	day := date.Format("2006-01-02")
+	err := r.db.WithContext(ctx).
+		Table("timetable_entries te").
+		Select("te.period_slot_id, te.id AS timetable_entry_id, ps.start_time, ps.end_time").
+		Joins("JOIN timetables t ON t.id = te.timetable_id").
+		Joins("JOIN period_slots ps ON ps.id = te.period_slot_id").
+		Where("te.tenant_id = ? AND t.section_id = ? AND te.day_of_week = ?", tenantID, sectionID, int(date.Weekday())).
+		Where("t.id IN ("+timetable.InForceSQL+")", tenantID, day, day).
+		Where("ps.slot_type IN ?", []models.PeriodSlotType{models.PeriodSlotTypeRegular, models.PeriodSlotTypeShort}).
+		Order("ps.start_time ASC").
+		Scan(&periods).Error
+	if err != nil {
+		return nil, fmt.Errorf("list day periods: %w", err)
+	}
+	return periods, nil
+}
+
+// SaveAttendanceMarks applies infirmary attendance marks for a student on a
+// date. Existing records keep their other details and gain the remark.
+func (r *Repository) SaveAttendanceMarks(ctx context.Context, tenantID, studentID, sectionID uuid.UUID, date time.Time, marks []attendanceMark, markedBy uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, mark := range marks {
+			query := tx.Where("tenant_id = ? AND student_id = ? AND attendance_date = ?",
+				tenantID, studentID, date.Format("2006-01-02"))
+			if mark.PeriodSlotID == nil {
+				query = query.Where("period_id IS NULL")
+			} else {
+				query = query.Where("period_id = ?", *mark.PeriodSlotID)
+			}
+
+			var existing []models.StudentAttendance
+			if err := query.Limit(1).Find(&existing).Error; err != nil {
+				return fmt.Errorf("get attendance: %w", err)
+			}
+
+			if len(existing) == 0 {
+				record := models.StudentAttendance{
+					TenantID:         tenantID,
+					StudentID:        studentID,
+					SectionID:        sectionID,
+					AttendanceDate:   date,
+					Status:           mark.Status,
+					Remarks:          mark.Remark,
+					MarkedBy:         markedBy,
+					MarkedAt:         now,
+					PeriodID:         mark.PeriodSlotID,
+					TimetableEntryID: mark.TimetableEntryID,
+				}
+				if err := tx.Omit("Tenant", "Student", "Section", "MarkedByUser", "PeriodSlot", "TimetableEntry").
+					Create(&record).Error; err != nil {
+					return fmt.Errorf("create attendance: %w", err)
+				}
+				continue
+			}
+
+			record := existing[0]
+			remarks := record.Remarks
+			if !strings.Contains(remarks, mark.Remark) {
+				remarks = strings.TrimSpace(strings.TrimSpace(remarks) + "; " + mark.Remark)
+				remarks = strings.TrimPrefix(remarks, "; ")
+			}
+			if err := tx.Model(&models.StudentAttendance{}).
+				Where("id = ?", record.ID).
+				Updates(map[string]interface{}{
+					"status":     mark.Status,
+					"remarks":    remarks,
+					"updated_at": now,
+				}).Error; err != nil {
+				return fmt.Errorf("update attendance: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// =========================================================================
+// Medication Administration
+// =========================================================================
+
+// ListSchoolMedications retrieves active medications given at school for
+// active students, with the student's name and class.
+func (r *Repository) ListSchoolMedications(ctx context.Context, filter ScheduleFilter) ([]MedicationRow, error) {
+	date := filter.Date.Format("2006-01-02")
+	query := r.db.WithContext(ctx).
+		Table("student_medications sm").
+		Select(`sm.*,
+			TRIM(s.first_name || ' ' || s.last_name) AS student_name,
+			s.admission_number,
+			COALESCE(c.name, '') AS class_name,
+			COALESCE(sec.name, '') AS section_name`).
+		Joins("JOIN students s ON s.id = sm.student_id AND s.deleted_at IS NULL").
+		Joins("LEFT JOIN student_enrollments se ON se.student_id = s.id AND se.status = 'active'").
+		Joins("LEFT JOIN classes c ON c.id = se.class_id").
+		Joins("LEFT JOIN sections sec ON sec.id = se.section_id").
+		Where("sm.tenant_id = ? AND sm.is_active = ? AND sm.administered_at_school = ?", filter.TenantID, true, true).
+		Where("s.status = ?", models.StudentStatusActive).
+		Where("sm.start_date <= ? AND (sm.end_date IS NULL OR sm.end_date >= ?)", date, date)
+
+	if filter.BranchID != nil {
+		query = query.Where("s.branch_id = ?", *filter.BranchID)
+	}
+	if filter.StudentID != nil {
+		query = query.Where("sm.student_id = ?", *filter.StudentID)
+	}
+
+	var rows []MedicationRow
+	if err := query.Order("class_name ASC, section_name ASC, student_name ASC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list school medications: %w", err)
+	}
+	return rows, nil
+}
+
+// GetMedication retrieves a student medication by ID.
+func (r *Repository) GetMedication(ctx context.Context, tenantID, id uuid.UUID) (*models.StudentMedication, error) {
+	var medication models.StudentMedication
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&medication).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMedicationNotFound
+		}
+		return nil, fmt.Errorf("get medication: %w", err)
+	}
+	return &medication, nil
+}
+
+// ListAdministrations retrieves doses recorded on a date for the given medications.
+func (r *Repository) ListAdministrations(ctx context.Context, tenantID uuid.UUID, date time.Time, medicationIDs []uuid.UUID) ([]models.MedicationAdministration, error) {
+	if len(medicationIDs) == 0 {
+		return nil, nil
+	}
+	var administrations []models.MedicationAdministration
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND administration_date = ? AND medication_id IN ?", tenantID, date.Format("2006-01-02"), medicationIDs).
+		Order("scheduled_time ASC").
+		Find(&administrations).Error
+	if err != nil {
+		return nil, fmt.Errorf("list medication administrations: %w", err)
+	}
+	return administrations, nil
+}
+
+// SaveAdministration records a dose, replacing an earlier record for the same
+// medication, date and scheduled time.
+func (r *Repository) SaveAdministration(ctx context.Context, administration *models.MedicationAdministration) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []models.MedicationAdministration
+		err := tx.Where("tenant_id = ? AND medication_id = ? AND administration_date = ? AND scheduled_time = ?",
+			administration.TenantID, administration.MedicationID,
+			administration.AdministrationDate.Format("2006-01-02"), administration.ScheduledTime).
+			Limit(1).
+			Find(&existing).Error
+		if err != nil {
+			return fmt.Errorf("get medication administration: %w", err)
+		}
+		if len(existing) > 0 {
+			administration.ID = existing[0].ID
+			administration.CreatedAt = existing[0].CreatedAt
+		}
+		administration.UpdatedAt = time.Now()
+		if err := tx.Save(administration).Error; err != nil {
+			return fmt.Errorf("save medication administration: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,610 @@
+// Package infirmary provides the school clinic visit log and medication administration schedule.
+package infirmary
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/sms"
+)
+
+const (
+	// doseStatusPending is a scheduled dose that has not been recorded yet.
+	doseStatusPending = "pending"
+	// doseStatusOverdue is a pending dose whose scheduled time has passed.
+	doseStatusOverdue = "overdue"
+
+	remarkInfirmaryVisit = "Infirmary visit"
+	remarkSentHome       = "Sent home from infirmary"
+)
+
+// defaultDoseTimes are the school dose times used when a medication does not
+// specify its administration time.
+var defaultDoseTimes = map[models.MedicationFrequency][]string{
+	models.MedicationFrequencyDaily:      {"12:00"},
+	models.MedicationFrequencyWeekly:     {"12:00"},
+	models.MedicationFrequencyOther:      {"12:00"},
+	models.MedicationFrequencyTwiceDaily: {"10:00", "14:00"},
+	models.MedicationFrequencyThreeDaily: {"09:00", "12:00", "15:00"},
+}
+
+var clockTimePattern = regexp.MustCompile(`(?i)\b(\d{1,2})[:.](\d{2})\s*(am|pm)?`)
+
+// Service handles infirmary business logic.
+type Service struct {
+	repo        *Repository
+	smsProvider sms.Provider
+}
+
+// NewService creates a new infirmary service.
+func NewService(repo *Repository, smsProvider sms.Provider) *Service {
+	return &Service{repo: repo, smsProvider: smsProvider}
+}
+
+// =========================================================================
+// Visits
+// =========================================================================
+
+// ListVisits returns the infirmary visit log.
+func (s *Service) ListVisits(ctx context.Context, filter VisitFilter) ([]models.InfirmaryVisit, int64, error) {
+	if filter.Disposition != "" && !filter.Disposition.IsValid() {
+		return nil, 0, ErrInvalidDisposition
+	}
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.ListVisits(ctx, filter)
+}
+
+// GetVisit returns an infirmary visit.
+func (s *Service) GetVisit(ctx context.Context, tenantID, id uuid.UUID) (*models.InfirmaryVisit, error) {
+	return s.repo.GetVisit(ctx, tenantID, id)
+}
+
+// RecordVisit records a student's visit to the infirmary, notifies the
+// guardian and marks the student's attendance for the periods spent there.
+func (s *Service) RecordVisit(ctx context.Context, dto RecordVisitDTO) (*models.InfirmaryVisit, error) {
+	complaint := strings.TrimSpace(dto.Complaint)
+	if complaint == "" {
+		return nil, ErrComplaintRequired
+	}
+	disposition := dto.Disposition
+	if disposition == "" {
+		disposition = models.InfirmaryDispositionUnderObservation
+	}
+	if !disposition.IsValid() {
+		return nil, ErrInvalidDisposition
+	}
+
+	student, err := s.repo.GetStudent(ctx, dto.TenantID, dto.StudentID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkInAt := dto.CheckInAt
+	if checkInAt.IsZero() {
+		checkInAt = time.Now()
+	}
+
+	var checkOutAt *time.Time
+	if disposition != models.InfirmaryDispositionUnderObservation {
+		checkOut := time.Now()
+		if dto.CheckOutAt != nil {
+			checkOut = *dto.CheckOutAt
+		}
+		if checkOut.Before(checkInAt) {
+			return nil, ErrInvalidCheckOut
+		}
+		checkOutAt = &checkOut
+	}
+
+	visit := &models.InfirmaryVisit{
+		ID:               uuid.New(),
+		TenantID:         dto.TenantID,
+		StudentID:        dto.StudentID,
+		CheckInAt:        checkInAt,
+		CheckOutAt:       checkOutAt,
+		Complaint:        complaint,
+		Symptoms:         strings.TrimSpace(dto.Symptoms),
+		Temperature:      dto.Vitals.Temperature,
+		PulseRate:        dto.Vitals.PulseRate,
+		RespiratoryRate:  dto.Vitals.RespiratoryRate,
+		BloodPressureSys: dto.Vitals.BloodPressureSys,
+		BloodPressureDia: dto.Vitals.BloodPressureDia,
+		OxygenSaturation: dto.Vitals.OxygenSaturation,
+		Treatment:        strings.TrimSpace(dto.Treatment),
+		Disposition:      disposition,
+		ReleasedTo:       strings.TrimSpace(dto.ReleasedTo),
+		Notes:            strings.TrimSpace(dto.Notes),
+		AttendedBy:       dto.UserID,
+		UpdatedBy:        dto.UserID,
+	}
+
+	if err := s.repo.CreateVisit(ctx, visit); err != nil {
+		return nil, err
+	}
+	visit.Student = student
+
+	s.afterVisitChange(ctx, visit, student, dto.UserID)
+
+	return visit, nil
+}
+
+// UpdateVisit corrects the clinical details of a visit.
+func (s *Service) UpdateVisit(ctx context.Context, tenantID, id uuid.UUID, dto UpdateVisitDTO) (*models.InfirmaryVisit, error) {
+	visit, err := s.repo.GetVisit(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{"updated_by": dto.UserID}
+	if dto.Complaint != nil {
+		complaint := strings.TrimSpace(*dto.Complaint)
+		if complaint == "" {
+			return nil, ErrComplaintRequired
+		}
+		updates["complaint"] = complaint
+	}
+	if dto.Symptoms != nil {
+		updates["symptoms"] = strings.TrimSpace(*dto.Symptoms)
+	}
+	if dto.Treatment != nil {
+		updates["treatment"] = strings.TrimSpace(*dto.Treatment)
+	}
+	if dto.Notes != nil {
+		updates["notes"] = strings.TrimSpace(*dto.Notes)
+	}
+	if dto.Vitals.Temperature != nil {
+		updates["temperature"] = dto.Vitals.Temperature
+	}
+	if dto.Vitals.PulseRate != nil {
+		updates["pulse_rate"] = dto.Vitals.PulseRate
+	}
+	if dto.Vitals.RespiratoryRate != nil {
+		updates["respiratory_rate"] = dto.Vitals.RespiratoryRate
+	}
+	if dto.Vitals.BloodPressureSys != nil {
+		updates["blood_pressure_sys"] = dto.Vitals.BloodPressureSys
+	}
+	if dto.Vitals.BloodPressureDia != nil {
+		updates["blood_pressure_dia"] = dto.Vitals.BloodPressureDia
+	}
+	if dto.Vitals.OxygenSaturation != nil {
+		updates["oxygen_saturation"] = dto.Vitals.OxygenSaturation
+	}
+
+	if err := s.repo.UpdateVisit(ctx, visit, updates); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetVisit(ctx, tenantID, id)
+}
+
+// Discharge records where the student went after the visit. Sending the
+// student home or to hospital notifies the guardian and marks the student
+// absent for the rest of the day.
+func (s *Service) Discharge(ctx context.Context, tenantID, id uuid.UUID, dto DischargeDTO) (*models.InfirmaryVisit, error) {
+	if !dto.Disposition.IsValid() || dto.Disposition == models.InfirmaryDispositionUnderObservation {
+		return nil, ErrInvalidDisposition
+	}
+
+	visit, err := s.repo.GetVisit(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if visit.CheckOutAt != nil {
+		return nil, ErrVisitClosed
+	}
+
+	checkOutAt := time.Now()
+	if dto.CheckOutAt != nil {
+		checkOutAt = *dto.CheckOutAt
+	}
+	if checkOutAt.Before(visit.CheckInAt) {
+		return nil, ErrInvalidCheckOut
+	}
+
+	updates := map[string]interface{}{
+		"disposition":  dto.Disposition,
+		"check_out_at": checkOutAt,
+		"updated_by":   dto.UserID,
+	}
+	if releasedTo := strings.TrimSpace(dto.ReleasedTo); releasedTo != "" {
+		updates["released_to"] = releasedTo
+	}
+	if notes := strings.TrimSpace(dto.Notes); notes != "" {
+		updates["notes"] = strings.TrimSpace(visit.Notes + "\n" + notes)
+	}
+	if err := s.repo.UpdateVisit(ctx, visit, updates); err != nil {
+		return nil, err
+	}
+
+	visit, err = s.repo.GetVisit(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if visit.Disposition.LeavesSchool() {
+		visit.GuardianNotifiedAt = nil
+	}
+	s.afterVisitChange(ctx, visit, visit.Student, dto.UserID)
+
+	return visit, nil
+}
+
+// afterVisitChange notifies the guardian and marks attendance for a visit.
+// Failures are logged rather than returned so the visit itself is kept.
+func (s *Service) afterVisitChange(ctx context.Context, visit *models.InfirmaryVisit, student *models.Student, userID *uuid.UUID) {
+	updates := map[string]interface{}{}
+
+	if visit.GuardianNotifiedAt == nil {
+		notified, err := s.notifyGuardian(ctx, visit, student)
+		if err != nil {
+			logger.Warn("Failed to notify guardian of infirmary visit",
+				zap.String("visit_id", visit.ID.String()), zap.Error(err))
+		}
+		if notified {
+			now := time.Now()
+			visit.GuardianNotifiedAt = &now
+			updates["guardian_notified_at"] = now
+		}
+	}
+
+	if userID != nil {
+		marked, err := s.markAttendance(ctx, visit, *userID)
+		if err != nil {
+			logger.Warn("Failed to mark attendance for infirmary visit",
+				zap.String("visit_id", visit.ID.String()), zap.Error(err))
+		}
+		if marked {
+			now := time.Now()
+			visit.AttendanceMarkedAt = &now
+			updates["attendance_marked_at"] = now
+		}
+	}
+
+	if len(updates) == 0 {
+		return
+	}
+	if err := s.repo.UpdateVisit(ctx, visit, updates); err != nil {
+		logger.Warn("Failed to update infirmary visit", zap.String("visit_id", visit.ID.String()), zap.Error(err))
+	}
+}
+
+// notifyGuardian sends the guardian an SMS about the visit.
+func (s *Service) notifyGuardian(ctx context.Context, visit *models.InfirmaryVisit, student *models.Student) (bool, error) {
+	if s.smsProvider == nil || !s.smsProvider.IsReady() || student == nil {
+		return false, nil
+	}
+
+	guardian, err := s.repo.GetPrimaryGuardian(ctx, visit.TenantID, visit.StudentID)
+	if err != nil || guardian == nil {
+		return false, err
+	}
+
+	if _, err := s.smsProvider.Send(ctx, sms.Message{To: guardian.Phone, Body: guardianMessage(visit, student)}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// guardianMessage builds the SMS sent to the guardian about a visit.
+func guardianMessage(visit *models.InfirmaryVisit, student *models.Student) string {
+	body := fmt.Sprintf("%s visited the school infirmary at %s with %s",
+		student.FullName(), visit.CheckInAt.Format("3:04 PM"), strings.ToLower(visit.Complaint))
+	if visit.Treatment != "" {
+		body += ". Treatment: " + visit.Treatment
+	}
+	body += ". Status: " + visit.Disposition.Label()
+	if visit.Disposition.LeavesSchool() && visit.ReleasedTo != "" {
+		body += " (released to " + visit.ReleasedTo + ")"
+	}
+	return body + "."
+}
+
+// markAttendance marks the periods the student spent in the infirmary and,
+// when the student left school, the rest of the day.
+func (s *Service) markAttendance(ctx context.Context, visit *models.InfirmaryVisit, markedBy uuid.UUID) (bool, error) {
+	sectionID, err := s.repo.GetActiveSectionID(ctx, visit.TenantID, visit.StudentID)
+	if err != nil || sectionID == nil {
+		return false, err
+	}
+
+	checkIn := visit.CheckInAt.In(time.Local)
+	periods, err := s.repo.ListDayPeriods(ctx, visit.TenantID, *sectionID, checkIn)
+	if err != nil {
+		return false, err
+	}
+
+	marks := attendanceMarks(visit, periods)
+	if len(marks) == 0 {
+		return false, nil
+	}
+
+	date := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, time.UTC)
+	if err := s.repo.SaveAttendanceMarks(ctx, visit.TenantID, visit.StudentID, *sectionID, date, marks, markedBy); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// attendanceMarks works out the attendance changes for a visit. Periods
+// overlapping the visit are marked present with an infirmary remark; if the
+// student left school, later periods are marked absent and the day half day.
+func attendanceMarks(visit *models.InfirmaryVisit, periods []PeriodWindow) []attendanceMark {
+	checkIn := clockTime(visit.CheckInAt)
+	checkOut := checkIn
+	if visit.CheckOutAt != nil {
+		checkOut = clockTime(*visit.CheckOutAt)
+	}
+	leftSchool := visit.CheckOutAt != nil && visit.Disposition.LeavesSchool()
+
+	var marks []attendanceMark
+	for i := range periods {
+		period := periods[i]
+		start, end := normalizeClock(period.StartTime), normalizeClock(period.EndTime)
+
+		var status models.StudentAttendanceStatus
+		var remark string
+		switch {
+		case start <= checkOut && end > checkIn:
+			status, remark = models.StudentAttendancePresent, remarkInfirmaryVisit
+		case leftSchool && start > checkOut:
+			status, remark = models.StudentAttendanceAbsent, remarkSentHome
+		default:
+			continue
+		}
+
+		marks = append(marks, attendanceMark{
+			PeriodSlotID:     &period.PeriodSlotID,
+			TimetableEntryID: &period.TimetableEntryID,
+			Status:           status,
+			Remark:           remark,
+		})
+	}
+
+	if leftSchool {
+		marks = append(marks, attendanceMark{
+			Status: models.StudentAttendanceHalfDay,
+			Remark: remarkSentHome,
+		})
+	}
+
+	return marks
+}
+
+// =========================================================================
+// Medication Administration
+// =========================================================================
+
+// GetSchedule builds the medication administration schedule for a day from
+// the active school-administered medications and the doses recorded so far.
+func (s *Service) GetSchedule(ctx context.Context, filter ScheduleFilter) (*Schedule, error) {
+	medications, err := s.repo.ListSchoolMedications(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(medications))
+	for i := range medications {
+		ids[i] = medications[i].ID
+	}
+	administrations, err := s.repo.ListAdministrations(ctx, filter.TenantID, filter.Date, ids)
+	if err != nil {
+		return nil, err
+	}
+	recorded := make(map[string]*models.MedicationAdministration, len(administrations))
+	for i := range administrations {
+		recorded[administrationKey(administrations[i].MedicationID, administrations[i].ScheduledTime)] = &administrations[i]
+	}
+
+	schedule := &Schedule{Date: filter.Date}
+	for i := range medications {
+		medication := &medications[i]
+		if medication.Frequency == models.MedicationFrequencyAsNeeded {
+			schedule.AsNeeded = append(schedule.AsNeeded, *medication)
+			continue
+		}
+		for _, scheduledTime := range doseTimes(&medication.StudentMedication, filter.Date) {
+			schedule.Doses = append(schedule.Doses, ScheduledDose{
+				Medication:     medication,
+				ScheduledTime:  scheduledTime,
+				Administration: recorded[administrationKey(medication.ID, scheduledTime)],
+			})
+		}
+	}
+
+	sort.SliceStable(schedule.Doses, func(i, j int) bool {
+		return schedule.Doses[i].ScheduledTime < schedule.Doses[j].ScheduledTime
+	})
+
+	return schedule, nil
+}
+
+// RecordDose records a scheduled dose as given, missed or refused. Doses of
+// as-needed medications are recorded against the time they were given.
+func (s *Service) RecordDose(ctx context.Context, dto RecordDoseDTO) (*models.MedicationAdministration, error) {
+	if !dto.Status.IsValid() {
+		return nil, ErrInvalidAdministrationStatus
+	}
+	today := time.Now()
+	if dto.Date.After(time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, dto.Date.Location())) {
+		return nil, ErrFutureDate
+	}
+
+	medication, err := s.repo.GetMedication(ctx, dto.TenantID, dto.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledTime := normalizeClock(dto.ScheduledTime)
+	administeredAt := dto.AdministeredAt
+	if dto.Status != models.MedicationAdministrationMissed && administeredAt == nil {
+		now := time.Now()
+		administeredAt = &now
+	}
+
+	if medication.Frequency == models.MedicationFrequencyAsNeeded {
+		if !medication.IsActive || !medication.AdministeredAtSchool ||
+			dto.Status == models.MedicationAdministrationMissed || !withinCourse(medication, dto.Date) {
+			return nil, ErrMedicationNotScheduled
+		}
+		scheduledTime = clockTime(*administeredAt)
+	} else if !containsTime(doseTimes(medication, dto.Date), scheduledTime) {
+		return nil, ErrMedicationNotScheduled
+	}
+
+	if dto.Status == models.MedicationAdministrationMissed {
+		administeredAt = nil
+	}
+
+	administration := &models.MedicationAdministration{
+		ID:                 uuid.New(),
+		TenantID:           dto.TenantID,
+		StudentID:          medication.StudentID,
+		MedicationID:       medication.ID,
+		AdministrationDate: dto.Date,
+		ScheduledTime:      scheduledTime,
+		Status:             dto.Status,
+		DoseGiven:          strings.TrimSpace(dto.DoseGiven),
+		AdministeredAt:     administeredAt,
+		RecordedBy:         dto.UserID,
+		VisitID:            dto.VisitID,
+		Notes:              strings.TrimSpace(dto.Notes),
+	}
+	if administration.DoseGiven == "" && dto.Status == models.MedicationAdministrationGiven {
+		administration.DoseGiven = medication.Dosage
+	}
+
+	if err := s.repo.SaveAdministration(ctx, administration); err != nil {
+		return nil, err
+	}
+	return administration, nil
+}
+
+// doseTimes returns the times a medication is due at school on a date, as
+// HH:MM. Times are read from the medication's school administration time,
+// falling back to defaults for its frequency. As-needed medications have no
+// fixed times.
+func doseTimes(medication *models.StudentMedication, date time.Time) []string {
+	if !medication.IsActive || !medication.AdministeredAtSchool || !withinCourse(medication, date) {
+		return nil
+	}
+
+	switch medication.Frequency {
+	case models.MedicationFrequencyAsNeeded:
+		return nil
+	case models.MedicationFrequencyWeekly:
+		if date.Weekday() != medication.StartDate.Weekday() {
+			return nil
+		}
+	}
+
+	var times []string
+	if medication.SchoolAdministrationTime != nil {
+		times = parseClockTimes(*medication.SchoolAdministrationTime)
+	}
+	if len(times) == 0 {
+		times = append(times, defaultDoseTimes[medication.Frequency]...)
+	}
+	return times
+}
+
+// withinCourse reports whether a date falls within the medication's start and end dates.
+func withinCourse(medication *models.StudentMedication, date time.Time) bool {
+	day := date.Format("2006-01-02")
+	if day < medication.StartDate.Format("2006-01-02") {
+		return false
+	}
+	return medication.EndDate == nil || day <= medication.EndDate.Format("2006-01-02")
+}
+
+// parseClockTimes extracts the HH:MM times from free text such as
+// "10:30 and 2.15 pm", in order and without duplicates.
+func parseClockTimes(text string) []string {
+	var times []string
+	for _, match := range clockTimePattern.FindAllStringSubmatch(text, -1) {
+		hour, _ := strconv.Atoi(match[1])
+		minute, _ := strconv.Atoi(match[2])
+		switch strings.ToLower(match[3]) {
+		case "pm":
+			if hour < 12 {
+				hour += 12
+			}
+		case "am":
+			if hour == 12 {
+				hour = 0
+			}
+		}
+		if hour > 23 || minute > 59 {
+			continue
+		}
+		clock := fmt.Sprintf("%02d:%02d", hour, minute)
+		if !containsTime(times, clock) {
+			times = append(times, clock)
+		}
+	}
+	sort.Strings(times)
+	return times
+}
+
+// doseStatus returns the status of a scheduled dose: the recorded outcome,
+// or pending/overdue depending on whether its time has passed.
+func doseStatus(date time.Time, dose ScheduledDose, now time.Time) string {
+	if dose.Administration != nil {
+		return string(dose.Administration.Status)
+	}
+
+	hour, minute := 0, 0
+	if parts := strings.SplitN(dose.ScheduledTime, ":", 2); len(parts) == 2 {
+		hour, _ = strconv.Atoi(parts[0])
+		minute, _ = strconv.Atoi(parts[1])
+	}
+	due := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, now.Location())
+	if now.After(due) {
+		return doseStatusOverdue
+	}
+	return doseStatusPending
+}
+
+// administrationKey identifies a dose by medication and scheduled time.
+func administrationKey(medicationID uuid.UUID, scheduledTime string) string {
+	return medicationID.String() + "|" + scheduledTime
+}
+
+// containsTime reports whether a list of HH:MM times contains a time.
+func containsTime(times []string, clock string) bool {
+	for _, t := range times {
+		if t == clock {
+			return true
+		}
+	}
+	return false
+}
+
+// clockTime formats the local time of day as HH:MM.
+func clockTime(t time.Time) string {
+	return t.In(time.Local).Format("15:04")
+}
+
+// normalizeClock trims a time-of-day value such as "09:00:00" to HH:MM.
+func normalizeClock(value string) string {
+	value = strings.TrimSpace(value)
+	if times := parseClockTimes(value); len(times) > 0 {
+		return times[0]
+	}
+	return value
+}
@@ -0,0 +1,184 @@
+package infirmary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func strPtr(s string) *string { return &s }
+
+func schoolMedication(frequency models.MedicationFrequency, schoolTime *string) *models.StudentMedication {
+	return &models.StudentMedication{
+		ID:                       uuid.New(),
+		StudentID:                uuid.New(),
+		MedicationName:           "Salbutamol",
+		Dosage:                   "2 puffs",
+		Frequency:                frequency,
+		Route:                    models.MedicationRouteInhaler,
+		StartDate:                time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), // Monday
+		AdministeredAtSchool:     true,
+		SchoolAdministrationTime: schoolTime,
+		IsActive:                 true,
+	}
+}
+
+func TestParseClockTimes(t *testing.T) {
+	assert.Equal(t, []string{"10:30", "14:15"}, parseClockTimes("10:30 and 2.15 pm"))
+	assert.Equal(t, []string{"00:05", "12:00"}, parseClockTimes("12:00, 12:05 am, 12:00"))
+	assert.Equal(t, []string{"09:00"}, parseClockTimes("09:00:00"))
+	assert.Empty(t, parseClockTimes("after lunch"))
+	assert.Empty(t, parseClockTimes("25:00"))
+}
+
+func TestDoseTimes(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	assert.Equal(t, []string{"12:00"}, doseTimes(schoolMedication(models.MedicationFrequencyDaily, nil), monday))
+	assert.Equal(t, []string{"10:00", "14:00"}, doseTimes(schoolMedication(models.MedicationFrequencyTwiceDaily, nil), monday))
+	assert.Equal(t, []string{"09:00", "12:00", "15:00"}, doseTimes(schoolMedication(models.MedicationFrequencyThreeDaily, nil), monday))
+	assert.Equal(t, []string{"11:30"}, doseTimes(schoolMedication(models.MedicationFrequencyDaily, strPtr("11:30 after snack")), monday))
+	assert.Equal(t, []string{"13:00"}, doseTimes(schoolMedication(models.MedicationFrequencyDaily, strPtr("after lunch, 1:00 pm")), monday))
+	assert.Nil(t, doseTimes(schoolMedication(models.MedicationFrequencyAsNeeded, nil), monday))
+
+	weekly := schoolMedication(models.MedicationFrequencyWeekly, nil)
+	assert.Equal(t, []string{"12:00"}, doseTimes(weekly, monday))
+	assert.Nil(t, doseTimes(weekly, tuesday))
+
+	notStarted := schoolMedication(models.MedicationFrequencyDaily, nil)
+	assert.Nil(t, doseTimes(notStarted, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	ended := schoolMedication(models.MedicationFrequencyDaily, nil)
+	endDate := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	ended.EndDate = &endDate
+	assert.Equal(t, []string{"12:00"}, doseTimes(ended, endDate))
+	assert.Nil(t, doseTimes(ended, monday))
+
+	atHome := schoolMedication(models.MedicationFrequencyDaily, nil)
+	atHome.AdministeredAtSchool = false
+	assert.Nil(t, doseTimes(atHome, monday))
+
+	inactive := schoolMedication(models.MedicationFrequencyDaily, nil)
+	inactive.IsActive = false
+	assert.Nil(t, doseTimes(inactive, monday))
+}
+
+func TestDoseStatus(t *testing.T) {
+	date := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	dose := ScheduledDose{ScheduledTime: "12:00"}
+
+	assert.Equal(t, doseStatusPending, doseStatus(date, dose, time.Date(2026, 3, 9, 11, 0, 0, 0, time.Local)))
+	assert.Equal(t, doseStatusOverdue, doseStatus(date, dose, time.Date(2026, 3, 9, 12, 30, 0, 0, time.Local)))
+	assert.Equal(t, doseStatusOverdue, doseStatus(date, dose, time.Date(2026, 3, 10, 8, 0, 0, 0, time.Local)))
+
+	dose.Administration = &models.MedicationAdministration{Status: models.MedicationAdministrationGiven}
+	assert.Equal(t, "given", doseStatus(date, dose, time.Date(2026, 3, 9, 12, 30, 0, 0, time.Local)))
+}
+
+func TestAttendanceMarks(t *testing.T) {
+	periods := []PeriodWindow{
+		{PeriodSlotID: uuid.New(), TimetableEntryID: uuid.New(), StartTime: "09:00:00", EndTime: "09:45:00"},
+		{PeriodSlotID: uuid.New(), TimetableEntryID: uuid.New(), StartTime: "09:45:00", EndTime: "10:30:00"},
+		{PeriodSlotID: uuid.New(), TimetableEntryID: uuid.New(), StartTime: "10:45:00", EndTime: "11:30:00"},
+		{PeriodSlotID: uuid.New(), TimetableEntryID: uuid.New(), StartTime: "11:30:00", EndTime: "12:15:00"},
+	}
+	checkIn := time.Date(2026, 3, 9, 9, 30, 0, 0, time.Local)
+
+	t.Run("under observation marks the current period", func(t *testing.T) {
+		visit := &models.InfirmaryVisit{CheckInAt: checkIn, Disposition: models.InfirmaryDispositionUnderObservation}
+
+		marks := attendanceMarks(visit, periods)
+
+		require.Len(t, marks, 1)
+		assert.Equal(t, periods[0].PeriodSlotID, *marks[0].PeriodSlotID)
+		assert.Equal(t, models.StudentAttendancePresent, marks[0].Status)
+		assert.Equal(t, remarkInfirmaryVisit, marks[0].Remark)
+	})
+
+	t.Run("returned to class marks the periods spent in the infirmary", func(t *testing.T) {
+		checkOut := time.Date(2026, 3, 9, 10, 0, 0, 0, time.Local)
+		visit := &models.InfirmaryVisit{CheckInAt: checkIn, CheckOutAt: &checkOut, Disposition: models.InfirmaryDispositionReturnedToClass}
+
+		marks := attendanceMarks(visit, periods)
+
+		require.Len(t, marks, 2)
+		assert.Equal(t, periods[1].PeriodSlotID, *marks[1].PeriodSlotID)
+		assert.Equal(t, models.StudentAttendancePresent, marks[1].Status)
+	})
+
+	t.Run("sent home marks later periods absent and the day half day", func(t *testing.T) {
+		checkOut := time.Date(2026, 3, 9, 10, 0, 0, 0, time.Local)
+		visit := &models.InfirmaryVisit{CheckInAt: checkIn, CheckOutAt: &checkOut, Disposition: models.InfirmaryDispositionSentHome}
+
+		marks := attendanceMarks(visit, periods)
+
+		require.Len(t, marks, 5)
+		assert.Equal(t, models.StudentAttendancePresent, marks[1].Status)
+		assert.Equal(t, models.StudentAttendanceAbsent, marks[2].Status)
+		assert.Equal(t, models.StudentAttendanceAbsent, marks[3].Status)
+		assert.Equal(t, remarkSentHome, marks[3].Remark)
+		assert.Nil(t, marks[4].PeriodSlotID)
+		assert.Equal(t, models.StudentAttendanceHalfDay, marks[4].Status)
+	})
+
+	t.Run("visit outside teaching periods marks nothing", func(t *testing.T) {
+		visit := &models.InfirmaryVisit{
+			CheckInAt:   time.Date(2026, 3, 9, 10, 35, 0, 0, time.Local),
+			Disposition: models.InfirmaryDispositionUnderObservation,
+		}
+
+		assert.Empty(t, attendanceMarks(visit, periods))
+	})
+}
+
+func TestGuardianMessage(t *testing.T) {
+	student := &models.Student{FirstName: "Asha", LastName: "Sharma"}
+	visit := &models.InfirmaryVisit{
+		CheckInAt:   time.Date(2026, 3, 9, 9, 30, 0, 0, time.Local),
+		Complaint:   "Headache",
+		Treatment:   "Rest",
+		Disposition: models.InfirmaryDispositionSentHome,
+		ReleasedTo:  "Priya Sharma (mother)",
+	}
+
+	assert.Equal(t,
+		"Asha Sharma visited the school infirmary at 9:30 AM with headache. Treatment: Rest. Status: sent home (released to Priya Sharma (mother)).",
+		guardianMessage(visit, student))
+}
+
+func TestToScheduleResponse(t *testing.T) {
+	date := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	medication := &MedicationRow{
+		StudentMedication: *schoolMedication(models.MedicationFrequencyTwiceDaily, nil),
+		StudentName:       "Asha Sharma",
+		AdmissionNumber:   "ADM-001",
+	}
+	schedule := &Schedule{
+		Date: date,
+		Doses: []ScheduledDose{
+			{Medication: medication, ScheduledTime: "10:00", Administration: &models.MedicationAdministration{
+				ID: uuid.New(), Status: models.MedicationAdministrationGiven, DoseGiven: "2 puffs",
+			}},
+			{Medication: medication, ScheduledTime: "12:00"},
+			{Medication: medication, ScheduledTime: "14:00"},
+		},
+		AsNeeded: []MedicationRow{*medication},
+	}
+
+	resp := ToScheduleResponse(schedule, time.Date(2026, 3, 9, 13, 0, 0, 0, time.Local))
+
+	assert.Equal(t, "2026-03-09", resp.Date)
+	require.Len(t, resp.Doses, 3)
+	assert.Equal(t, "given", resp.Doses[0].Status)
+	assert.Equal(t, "2 puffs", resp.Doses[0].DoseGiven)
+	assert.Equal(t, "overdue", resp.Doses[1].Status)
+	assert.Equal(t, "pending", resp.Doses[2].Status)
+	assert.Equal(t, ScheduleSummary{Total: 3, Given: 1, Pending: 1, Overdue: 1}, resp.Summary)
+	assert.Len(t, resp.AsNeeded, 1)
+}
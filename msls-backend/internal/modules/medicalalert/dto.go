@@ -0,0 +1,113 @@
+// Package medicalalert surfaces life-threatening allergies and severe chronic conditions to other modules.
+package medicalalert
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DetailPermission is the permission required to see what a student's alerts
+// are. Without it callers only see that a student has an alert.
+const DetailPermission = "health:read"
+
+// AlertKind identifies where an alert comes from.
+type AlertKind string
+
+// Alert kinds.
+const (
+	AlertKindAllergy   AlertKind = "allergy"
+	AlertKindCondition AlertKind = "condition"
+)
+
+// AlertLevel is the level shown on a student's badge.
+type AlertLevel string
+
+// Alert levels.
+const (
+	// AlertLevelCritical marks students with a life-threatening allergy.
+	AlertLevelCritical AlertLevel = "critical"
+	// AlertLevelSevere marks students with a severe allergy or condition.
+	AlertLevelSevere AlertLevel = "severe"
+)
+
+// Alert is a single allergy or condition that staff need to know about.
+type Alert struct {
+	Kind                AlertKind `json:"kind"`
+	Name                string    `json:"name"`
+	Severity            string    `json:"severity"`
+	Reaction            *string   `json:"reaction,omitempty"`
+	Instructions        *string   `json:"instructions,omitempty"`
+	EmergencyMedication *string   `json:"emergencyMedication,omitempty"`
+	Restrictions        *string   `json:"restrictions,omitempty"`
+}
+
+// Badge summarises a student's medical alerts for rosters.
+type Badge struct {
+	Level  AlertLevel `json:"level"`
+	Count  int        `json:"count"`
+	Alerts []Alert    `json:"alerts,omitempty"`
+}
+
+// Redact returns a copy of the badge without alert details, for callers
+// without DetailPermission.
+func (b *Badge) Redact() *Badge {
+	if b == nil {
+		return nil
+	}
+	return &Badge{Level: b.Level, Count: b.Count}
+}
+
+// StudentAlert is a student with medical alerts in a section roster.
+type StudentAlert struct {
+	StudentID       uuid.UUID `json:"studentId"`
+	SectionID       uuid.UUID `json:"sectionId"`
+	AdmissionNumber string    `json:"admissionNumber"`
+	RollNumber      string    `json:"rollNumber,omitempty"`
+	StudentName     string    `json:"studentName"`
+	Badge           *Badge    `json:"badge"`
+}
+
+// Redact returns a copy of the student alert without alert details.
+func (a StudentAlert) Redact() StudentAlert {
+	a.Badge = a.Badge.Redact()
+	return a
+}
+
+// rosterRow is a student in a section roster.
+type rosterRow struct {
+	StudentID       uuid.UUID
+	SectionID       uuid.UUID
+	AdmissionNumber string
+	RollNumber      string
+	FirstName       string
+	MiddleName      string
+	LastName        string
+}
+
+// studentRow is a student with their current class and section, for the emergency card.
+type studentRow struct {
+	ID              uuid.UUID
+	FirstName       string
+	MiddleName      string
+	LastName        string
+	AdmissionNumber string
+	DateOfBirth     time.Time
+	BloodGroup      string
+	PhotoURL        string
+	ClassName       string
+	SectionName     string
+	RollNumber      string
+}
+
+// FullName returns the student's full name.
+func (s studentRow) FullName() string {
+	return joinName(s.FirstName, s.MiddleName, s.LastName)
+}
+
+// StudentAlertsResponse is the API response for a student's medical alerts.
+type StudentAlertsResponse struct {
+	StudentID uuid.UUID `json:"studentId"`
+	HasAlerts bool      `json:"hasAlerts"`
+	Badge     *Badge    `json:"badge,omitempty"`
+}
@@ -0,0 +1,10 @@
+// Package medicalalert surfaces life-threatening allergies and severe chronic conditions to other modules.
+package medicalalert
+
+import "errors"
+
+// Medical alert errors.
+var (
+	// ErrStudentNotFound is returned when the student is not found.
+	ErrStudentNotFound = errors.New("student not found")
+)
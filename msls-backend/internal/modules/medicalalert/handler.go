@@ -0,0 +1,92 @@
+// Package medicalalert surfaces life-threatening allergies and severe chronic conditions to other modules.
+package medicalalert
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/middleware"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/response"
+)
+
+// Handler handles medical alert HTTP requests.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new medical alert handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetStudentAlerts returns a student's medical alerts.
+// @Summary Get student medical alerts
+// @Description Get a student's life-threatening and severe allergies and severe chronic conditions
+// @Tags Medical Alerts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Student ID"
+// @Success 200 {object} response.Success{data=StudentAlertsResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/students/{id}/medical-alerts [get]
+func (h *Handler) GetStudentAlerts(c *gin.Context) {
+	tenantID, studentID, ok := middleware.TenantAndID(c, "id", "Invalid student ID")
+	if !ok {
+		return
+	}
+
+	badge, err := h.service.GetStudentAlerts(c.Request.Context(), tenantID, studentID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, StudentAlertsResponse{StudentID: studentID, HasAlerts: badge != nil, Badge: badge})
+}
+
+// DownloadEmergencyCard downloads a student's printable emergency card.
+// @Summary Download emergency card
+// @Description Download a student's emergency card with allergies, conditions, medications and emergency contacts
+// @Tags Medical Alerts
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Student ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/students/{id}/emergency-card [get]
+func (h *Handler) DownloadEmergencyCard(c *gin.Context) {
+	tenantID, studentID, ok := middleware.TenantAndID(c, "id", "Invalid student ID")
+	if !ok {
+		return
+	}
+
+	pdf, filename, err := h.service.EmergencyCard(c.Request.Context(), tenantID, studentID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// handleServiceError converts service errors to API errors.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrStudentNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Student not found"))
+	default:
+		logger.Error("Medical alert operation error", zap.Error(err))
+		apperrors.Abort(c, apperrors.InternalError("Failed to process medical alert request"))
+	}
+}
@@ -0,0 +1,185 @@
+// Package medicalalert surfaces life-threatening allergies and severe chronic conditions to other modules.
+package medicalalert
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// emergencyCard is the data printed on a student's emergency card.
+type emergencyCard struct {
+	SchoolName  string
+	Student     studentRow
+	Alerts      []Alert
+	Medications []models.StudentMedication
+	Guardians   []models.StudentGuardian
+	Profile     *models.StudentHealthProfile
+	PrintedAt   time.Time
+}
+
+// bloodGroup returns the blood group from the health profile, falling back to
+// the student record.
+func (c emergencyCard) bloodGroup() string {
+	if c.Profile != nil && c.Profile.BloodGroup != nil && *c.Profile.BloodGroup != "" {
+		return *c.Profile.BloodGroup
+	}
+	return c.Student.BloodGroup
+}
+
+// renderEmergencyCard renders a one-page A5 emergency card.
+func renderEmergencyCard(card emergencyCard) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A5", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 12)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth := 128.0 // 148 - 20 (margins)
+
+	primaryColor := []int{31, 41, 55}
+	alertColor := []int{220, 38, 38}
+	lightBg := []int{249, 250, 251}
+	borderColor := []int{229, 231, 235}
+	mutedText := []int{107, 114, 128}
+
+	// Heading
+	pdf.SetFillColor(alertColor[0], alertColor[1], alertColor[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(pageWidth, 9, "MEDICAL EMERGENCY CARD", "0", 1, "C", true, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	pdf.CellFormat(pageWidth, 6, tr(card.SchoolName), "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+
+	// Student particulars
+	class := strings.TrimSpace(card.Student.ClassName + " " + card.Student.SectionName)
+	particulars := [][2]string{
+		{"Student", card.Student.FullName()},
+		{"Admission No", card.Student.AdmissionNumber},
+		{"Class", class},
+		{"Date of Birth", card.Student.DateOfBirth.Format("02 Jan 2006")},
+		{"Blood Group", card.bloodGroup()},
+	}
+	pdf.SetDrawColor(borderColor[0], borderColor[1], borderColor[2])
+	pdf.SetLineWidth(0.2)
+	for i, row := range particulars {
+		value := row[1]
+		if value == "" {
+			value = "-"
+		}
+		fill := i%2 == 0
+		pdf.SetFillColor(lightBg[0], lightBg[1], lightBg[2])
+		pdf.SetFont("Arial", "", 9)
+		pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+		pdf.CellFormat(35, 6, row[0], "1", 0, "L", fill, 0, "")
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.CellFormat(pageWidth-35, 6, tr(value), "1", 1, "L", fill, 0, "")
+	}
+
+	section := func(title string) {
+		pdf.Ln(3)
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.CellFormat(pageWidth, 6, title, "B", 1, "L", false, 0, "")
+		pdf.Ln(1)
+	}
+	line := func(label string, value *string) {
+		if value == nil || strings.TrimSpace(*value) == "" {
+			return
+		}
+		pdf.SetFont("Arial", "", 8)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.MultiCell(pageWidth, 4, tr(label+": "+*value), "", "L", false)
+	}
+
+	// Allergies and conditions
+	section("Allergies and Conditions")
+	if len(card.Alerts) == 0 {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+		pdf.CellFormat(pageWidth, 5, "No known allergies or chronic conditions recorded.", "", 1, "L", false, 0, "")
+	}
+	for _, alert := range card.Alerts {
+		severity := strings.ToUpper(strings.ReplaceAll(alert.Severity, "_", "-"))
+		if alert.Severity == string(models.AllergySeverityLifeThreatening) || alert.Severity == string(models.AllergySeveritySevere) {
+			pdf.SetTextColor(alertColor[0], alertColor[1], alertColor[2])
+		} else {
+			pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		}
+		pdf.SetFont("Arial", "B", 9)
+		pdf.MultiCell(pageWidth, 5, tr(fmt.Sprintf("%s (%s, %s)", alert.Name, alert.Kind, severity)), "", "L", false)
+		line("Reaction", alert.Reaction)
+		if alert.Kind == AlertKindAllergy {
+			line("Treatment", alert.Instructions)
+		} else {
+			line("Management", alert.Instructions)
+		}
+		line("Emergency medication", alert.EmergencyMedication)
+		line("Restrictions", alert.Restrictions)
+		pdf.Ln(1)
+	}
+
+	// Medications
+	if len(card.Medications) > 0 {
+		section("Current Medications")
+		for _, medication := range card.Medications {
+			text := fmt.Sprintf("%s - %s, %s", medication.MedicationName, medication.Dosage, strings.ReplaceAll(string(medication.Frequency), "_", " "))
+			if medication.AdministeredAtSchool && medication.SchoolAdministrationTime != nil {
+				text += " (at school: " + *medication.SchoolAdministrationTime + ")"
+			}
+			pdf.SetFont("Arial", "", 8)
+			pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+			pdf.MultiCell(pageWidth, 4, tr(text), "", "L", false)
+		}
+	}
+
+	// Emergency contacts
+	section("Emergency Contacts")
+	for _, guardian := range card.Guardians {
+		name := joinName(guardian.FirstName, guardian.LastName)
+		text := fmt.Sprintf("%s (%s): %s", name, guardian.Relation, guardian.Phone)
+		if guardian.IsPrimary {
+			text += " - primary"
+		}
+		pdf.SetFont("Arial", "", 9)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.CellFormat(pageWidth, 5, tr(text), "", 1, "L", false, 0, "")
+	}
+	if profile := card.Profile; profile != nil {
+		line("Preferred hospital", profile.PreferredHospital)
+		if profile.FamilyDoctorName != nil && *profile.FamilyDoctorName != "" {
+			doctor := *profile.FamilyDoctorName
+			if profile.FamilyDoctorPhone != nil && *profile.FamilyDoctorPhone != "" {
+				doctor += ", " + *profile.FamilyDoctorPhone
+			}
+			line("Family doctor", &doctor)
+		}
+		if profile.InsuranceProvider != nil && *profile.InsuranceProvider != "" {
+			insurance := *profile.InsuranceProvider
+			if profile.InsurancePolicyNumber != nil && *profile.InsurancePolicyNumber != "" {
+				insurance += ", policy " + *profile.InsurancePolicyNumber
+			}
+			line("Insurance", &insurance)
+		}
+	}
+
+	// Footer
+	pdf.SetY(-14)
+	pdf.SetFont("Arial", "I", 7)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	pdf.CellFormat(pageWidth, 4, "Confidential medical information. Printed "+card.PrintedAt.Format("02 Jan 2006 15:04"), "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("generate emergency card pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,177 @@
+// Package medicalalert surfaces life-threatening allergies and severe chronic conditions to other modules.
+package medicalalert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// alertAllergySeverities are the allergy severities that raise an alert.
+var alertAllergySeverities = []models.AllergySeverity{models.AllergySeveritySevere, models.AllergySeverityLifeThreatening}
+
+// Repository handles database operations for medical alerts.
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new medical alert repository.
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// ListAllergies retrieves the active allergies of the given students. When
+// alertsOnly is set only severe and life-threatening allergies are returned.
+func (r *Repository) ListAllergies(ctx context.Context, tenantID uuid.UUID, studentIDs []uuid.UUID, alertsOnly bool) ([]models.StudentAllergy, error) {
+	if len(studentIDs) == 0 {
+		return nil, nil
+	}
+	query := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id IN ? AND is_active = ?", tenantID, studentIDs, true)
+	if alertsOnly {
+		query = query.Where("severity IN ?", alertAllergySeverities)
+	}
+
+	var allergies []models.StudentAllergy
+	if err := query.Order("allergen ASC").Find(&allergies).Error; err != nil {
+		return nil, fmt.Errorf("list allergies: %w", err)
+	}
+	return allergies, nil
+}
+
+// ListConditions retrieves the active chronic conditions of the given
+// students. When alertsOnly is set only severe conditions are returned.
+func (r *Repository) ListConditions(ctx context.Context, tenantID uuid.UUID, studentIDs []uuid.UUID, alertsOnly bool) ([]models.StudentChronicCondition, error) {
+	if len(studentIDs) == 0 {
+		return nil, nil
+	}
+	query := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id IN ? AND is_active = ?", tenantID, studentIDs, true)
+	if alertsOnly {
+		query = query.Where("severity = ?", models.ConditionSeveritySevere)
+	}
+
+	var conditions []models.StudentChronicCondition
+	if err := query.Order("condition_name ASC").Find(&conditions).Error; err != nil {
+		return nil, fmt.Errorf("list chronic conditions: %w", err)
+	}
+	return conditions, nil
+}
+
+// ListSectionRoster retrieves the active students enrolled in the given sections.
+func (r *Repository) ListSectionRoster(ctx context.Context, tenantID uuid.UUID, sectionIDs []uuid.UUID) ([]rosterRow, error) {
+	if len(sectionIDs) == 0 {
+		return nil, nil
+	}
+	var rows []rosterRow
+	err := r.db.WithContext(ctx).
+		Table("student_enrollments se").
+		Select(`s.id AS student_id, se.section_id, s.admission_number, COALESCE(se.roll_number, '') AS roll_number,
+			s.first_name, s.middle_name, s.last_name`).
+		Joins("JOIN students s ON s.id = se.student_id").
+		Where("se.tenant_id = ? AND se.section_id IN ? AND se.status = 'active'", tenantID, sectionIDs).
+		Where("s.status = ? AND s.deleted_at IS NULL", models.StudentStatusActive).
+		Order("se.roll_number ASC, s.first_name ASC, s.last_name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("list section roster: %w", err)
+	}
+	return rows, nil
+}
+
+// GetStudent retrieves a student with their current class and section.
+func (r *Repository) GetStudent(ctx context.Context, tenantID, studentID uuid.UUID) (*studentRow, error) {
+	var student models.Student
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, studentID).
+		First(&student).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+		return nil, fmt.Errorf("get student: %w", err)
+	}
+
+	row := studentRow{
+		ID:              student.ID,
+		FirstName:       student.FirstName,
+		MiddleName:      student.MiddleName,
+		LastName:        student.LastName,
+		AdmissionNumber: student.AdmissionNumber,
+		DateOfBirth:     student.DateOfBirth,
+		BloodGroup:      student.BloodGroup,
+		PhotoURL:        student.PhotoURL,
+	}
+
+	var rows []studentRow
+	err = r.db.WithContext(ctx).
+		Table("student_enrollments se").
+		Select("COALESCE(c.name, '') AS class_name, COALESCE(sec.name, '') AS section_name, COALESCE(se.roll_number, '') AS roll_number").
+		Joins("LEFT JOIN classes c ON c.id = se.class_id").
+		Joins("LEFT JOIN sections sec ON sec.id = se.section_id").
+		Where("se.tenant_id = ? AND se.student_id = ? AND se.status = 'active'", tenantID, studentID).
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("get student enrollment: %w", err)
+	}
+	if len(rows) > 0 {
+		row.ClassName, row.SectionName, row.RollNumber = rows[0].ClassName, rows[0].SectionName, rows[0].RollNumber
+	}
+	return &row, nil
+}
+
+// GetHealthProfile retrieves a student's health profile, or nil if none has been recorded.
+func (r *Repository) GetHealthProfile(ctx context.Context, tenantID, studentID uuid.UUID) (*models.StudentHealthProfile, error) {
+	var profile models.StudentHealthProfile
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id = ?", tenantID, studentID).
+		First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get health profile: %w", err)
+	}
+	return &profile, nil
+}
+
+// ListMedications retrieves a student's active medications.
+func (r *Repository) ListMedications(ctx context.Context, tenantID, studentID uuid.UUID) ([]models.StudentMedication, error) {
+	var medications []models.StudentMedication
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id = ? AND is_active = ?", tenantID, studentID, true).
+		Order("medication_name ASC").
+		Find(&medications).Error
+	if err != nil {
+		return nil, fmt.Errorf("list medications: %w", err)
+	}
+	return medications, nil
+}
+
+// ListGuardians retrieves a student's guardians, primary guardian first.
+func (r *Repository) ListGuardians(ctx context.Context, tenantID, studentID uuid.UUID) ([]models.StudentGuardian, error) {
+	var guardians []models.StudentGuardian
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND student_id = ?", tenantID, studentID).
+		Order("is_primary DESC, created_at ASC").
+		Find(&guardians).Error
+	if err != nil {
+		return nil, fmt.Errorf("list guardians: %w", err)
+	}
+	return guardians, nil
+}
+
+// GetSchoolName retrieves the tenant's name for the emergency card heading.
+func (r *Repository) GetSchoolName(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	var tenant models.Tenant
+	if err := r.db.WithContext(ctx).First(&tenant, "id = ?", tenantID).Error; err != nil {
+		return "", fmt.Errorf("get tenant: %w", err)
+	}
+	return tenant.Name, nil
+}
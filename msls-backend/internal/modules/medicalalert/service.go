@@ -0,0 +1,251 @@
+// Package medicalalert surfaces life-threatening allergies and severe chronic conditions to other modules.
+package medicalalert
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// Service builds medical alert badges and emergency cards.
+type Service struct {
+	repo *Repository
+}
+
+// NewService creates a new medical alert service.
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// BadgesForStudents returns the alert badge of each of the given students
+// that has one. Students without alerts are absent from the map. Two queries
+// are made regardless of the number of students, so rosters can call this
+// on every request.
+func (s *Service) BadgesForStudents(ctx context.Context, tenantID uuid.UUID, studentIDs []uuid.UUID) (map[uuid.UUID]*Badge, error) {
+	allergies, err := s.repo.ListAllergies(ctx, tenantID, studentIDs, true)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := s.repo.ListConditions(ctx, tenantID, studentIDs, true)
+	if err != nil {
+		return nil, err
+	}
+	return buildBadges(allergies, conditions), nil
+}
+
+// StudentsWithAlerts returns the students with medical alerts in each of the
+// given sections, keyed by section ID.
+func (s *Service) StudentsWithAlerts(ctx context.Context, tenantID uuid.UUID, sectionIDs []uuid.UUID) (map[uuid.UUID][]StudentAlert, error) {
+	roster, err := s.repo.ListSectionRoster(ctx, tenantID, sectionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	studentIDs := make([]uuid.UUID, 0, len(roster))
+	for _, row := range roster {
+		studentIDs = append(studentIDs, row.StudentID)
+	}
+	badges, err := s.BadgesForStudents(ctx, tenantID, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID][]StudentAlert)
+	for _, row := range roster {
+		badge, ok := badges[row.StudentID]
+		if !ok {
+			continue
+		}
+		result[row.SectionID] = append(result[row.SectionID], StudentAlert{
+			StudentID:       row.StudentID,
+			SectionID:       row.SectionID,
+			AdmissionNumber: row.AdmissionNumber,
+			RollNumber:      row.RollNumber,
+			StudentName:     joinName(row.FirstName, row.MiddleName, row.LastName),
+			Badge:           badge,
+		})
+	}
+	return result, nil
+}
+
+// GetStudentAlerts returns a student's alert badge, or nil if they have no alerts.
+func (s *Service) GetStudentAlerts(ctx context.Context, tenantID, studentID uuid.UUID) (*Badge, error) {
+	if _, err := s.repo.GetStudent(ctx, tenantID, studentID); err != nil {
+		return nil, err
+	}
+	badges, err := s.BadgesForStudents(ctx, tenantID, []uuid.UUID{studentID})
+	if err != nil {
+		return nil, err
+	}
+	return badges[studentID], nil
+}
+
+// EmergencyCard renders a student's printable emergency card. Unlike badges
+// the card lists every active allergy and condition, with the alerts first.
+func (s *Service) EmergencyCard(ctx context.Context, tenantID, studentID uuid.UUID) ([]byte, string, error) {
+	student, err := s.repo.GetStudent(ctx, tenantID, studentID)
+	if err != nil {
+		return nil, "", err
+	}
+	ids := []uuid.UUID{studentID}
+	allergies, err := s.repo.ListAllergies(ctx, tenantID, ids, false)
+	if err != nil {
+		return nil, "", err
+	}
+	conditions, err := s.repo.ListConditions(ctx, tenantID, ids, false)
+	if err != nil {
+		return nil, "", err
+	}
+	medications, err := s.repo.ListMedications(ctx, tenantID, studentID)
+	if err != nil {
+		return nil, "", err
+	}
+	guardians, err := s.repo.ListGuardians(ctx, tenantID, studentID)
+	if err != nil {
+		return nil, "", err
+	}
+	profile, err := s.repo.GetHealthProfile(ctx, tenantID, studentID)
+	if err != nil {
+		return nil, "", err
+	}
+	schoolName, err := s.repo.GetSchoolName(ctx, tenantID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	card := emergencyCard{
+		SchoolName:  schoolName,
+		Student:     *student,
+		Alerts:      cardAlerts(allergies, conditions),
+		Medications: medications,
+		Guardians:   guardians,
+		Profile:     profile,
+		PrintedAt:   time.Now(),
+	}
+	pdf, err := renderEmergencyCard(card)
+	if err != nil {
+		return nil, "", err
+	}
+	return pdf, fmt.Sprintf("emergency-card-%s.pdf", student.AdmissionNumber), nil
+}
+
+// buildBadges groups alert-level allergies and conditions into a badge per student.
+func buildBadges(allergies []models.StudentAllergy, conditions []models.StudentChronicCondition) map[uuid.UUID]*Badge {
+	badges := make(map[uuid.UUID]*Badge)
+	badgeFor := func(studentID uuid.UUID) *Badge {
+		badge, ok := badges[studentID]
+		if !ok {
+			badge = &Badge{Level: AlertLevelSevere}
+			badges[studentID] = badge
+		}
+		return badge
+	}
+
+	for _, allergy := range allergies {
+		if !isAlertAllergy(allergy.Severity) {
+			continue
+		}
+		badge := badgeFor(allergy.StudentID)
+		if allergy.Severity == models.AllergySeverityLifeThreatening {
+			badge.Level = AlertLevelCritical
+		}
+		badge.Alerts = append(badge.Alerts, allergyAlert(allergy))
+	}
+	for _, condition := range conditions {
+		if condition.Severity != models.ConditionSeveritySevere {
+			continue
+		}
+		badge := badgeFor(condition.StudentID)
+		badge.Alerts = append(badge.Alerts, conditionAlert(condition))
+	}
+
+	for _, badge := range badges {
+		sortAlerts(badge.Alerts)
+		badge.Count = len(badge.Alerts)
+	}
+	return badges
+}
+
+// cardAlerts returns all of a student's allergies and conditions for the
+// emergency card, most severe first.
+func cardAlerts(allergies []models.StudentAllergy, conditions []models.StudentChronicCondition) []Alert {
+	alerts := make([]Alert, 0, len(allergies)+len(conditions))
+	for _, allergy := range allergies {
+		alerts = append(alerts, allergyAlert(allergy))
+	}
+	for _, condition := range conditions {
+		alerts = append(alerts, conditionAlert(condition))
+	}
+	sortAlerts(alerts)
+	return alerts
+}
+
+// isAlertAllergy reports whether an allergy severity raises an alert.
+func isAlertAllergy(severity models.AllergySeverity) bool {
+	for _, s := range alertAllergySeverities {
+		if severity == s {
+			return true
+		}
+	}
+	return false
+}
+
+func allergyAlert(allergy models.StudentAllergy) Alert {
+	return Alert{
+		Kind:                AlertKindAllergy,
+		Name:                allergy.Allergen,
+		Severity:            string(allergy.Severity),
+		Reaction:            allergy.ReactionDescription,
+		Instructions:        allergy.TreatmentInstructions,
+		EmergencyMedication: allergy.EmergencyMedication,
+	}
+}
+
+func conditionAlert(condition models.StudentChronicCondition) Alert {
+	return Alert{
+		Kind:         AlertKindCondition,
+		Name:         condition.ConditionName,
+		Severity:     string(condition.Severity),
+		Instructions: condition.ManagementPlan,
+		Restrictions: condition.Restrictions,
+	}
+}
+
+// severityRank orders severities from most to least severe.
+var severityRank = map[string]int{
+	string(models.AllergySeverityLifeThreatening): 0,
+	string(models.AllergySeveritySevere):          1,
+	string(models.AllergySeverityModerate):        2,
+	string(models.AllergySeverityMild):            3,
+}
+
+// sortAlerts orders alerts by severity, allergies before conditions, then by name.
+func sortAlerts(alerts []Alert) {
+	sort.SliceStable(alerts, func(i, j int) bool {
+		a, b := alerts[i], alerts[j]
+		if severityRank[a.Severity] != severityRank[b.Severity] {
+			return severityRank[a.Severity] < severityRank[b.Severity]
+		}
+		if a.Kind != b.Kind {
+			return a.Kind == AlertKindAllergy
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
+
+// joinName joins the non-empty parts of a name.
+func joinName(parts ...string) string {
+	var name []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			name = append(name, part)
+		}
+	}
+	return strings.Join(name, " ")
+}
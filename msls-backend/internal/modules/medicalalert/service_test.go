@@ -0,0 +1,104 @@
+package medicalalert
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestBuildBadges(t *testing.T) {
+	critical, severe, mild := uuid.New(), uuid.New(), uuid.New()
+	allergies := []models.StudentAllergy{
+		{StudentID: critical, Allergen: "Peanuts", Severity: models.AllergySeverityLifeThreatening, EmergencyMedication: strPtr("EpiPen")},
+		{StudentID: critical, Allergen: "Bee stings", Severity: models.AllergySeveritySevere},
+		{StudentID: severe, Allergen: "Penicillin", Severity: models.AllergySeveritySevere},
+		{StudentID: mild, Allergen: "Dust", Severity: models.AllergySeverityMild},
+	}
+	conditions := []models.StudentChronicCondition{
+		{StudentID: critical, ConditionName: "Asthma", Severity: models.ConditionSeveritySevere, ManagementPlan: strPtr("Inhaler")},
+		{StudentID: mild, ConditionName: "Eczema", Severity: models.ConditionSeverityModerate},
+	}
+
+	badges := buildBadges(allergies, conditions)
+
+	require.Len(t, badges, 2)
+	assert.NotContains(t, badges, mild)
+
+	badge := badges[critical]
+	assert.Equal(t, AlertLevelCritical, badge.Level)
+	assert.Equal(t, 3, badge.Count)
+	require.Len(t, badge.Alerts, 3)
+	assert.Equal(t, "Peanuts", badge.Alerts[0].Name)
+	assert.Equal(t, "EpiPen", *badge.Alerts[0].EmergencyMedication)
+	assert.Equal(t, "Bee stings", badge.Alerts[1].Name)
+	assert.Equal(t, AlertKindCondition, badge.Alerts[2].Kind)
+	assert.Equal(t, "Inhaler", *badge.Alerts[2].Instructions)
+
+	assert.Equal(t, AlertLevelSevere, badges[severe].Level)
+	assert.Equal(t, 1, badges[severe].Count)
+}
+
+func TestBadgeRedact(t *testing.T) {
+	badge := &Badge{Level: AlertLevelCritical, Count: 1, Alerts: []Alert{{Kind: AlertKindAllergy, Name: "Peanuts"}}}
+
+	redacted := badge.Redact()
+
+	assert.Equal(t, &Badge{Level: AlertLevelCritical, Count: 1}, redacted)
+	assert.Len(t, badge.Alerts, 1)
+
+	var none *Badge
+	assert.Nil(t, none.Redact())
+
+	alert := StudentAlert{StudentID: uuid.New(), StudentName: "Asha Sharma", Badge: badge}
+	assert.Nil(t, alert.Redact().Badge.Alerts)
+	assert.Len(t, alert.Badge.Alerts, 1)
+}
+
+func TestCardAlerts(t *testing.T) {
+	alerts := cardAlerts(
+		[]models.StudentAllergy{
+			{Allergen: "Dust", Severity: models.AllergySeverityMild},
+			{Allergen: "Peanuts", Severity: models.AllergySeverityLifeThreatening},
+		},
+		[]models.StudentChronicCondition{
+			{ConditionName: "Asthma", Severity: models.ConditionSeverityModerate},
+		},
+	)
+
+	var names []string
+	for _, alert := range alerts {
+		names = append(names, alert.Name)
+	}
+	assert.Equal(t, []string{"Peanuts", "Asthma", "Dust"}, names)
+}
+
+func TestRenderEmergencyCard(t *testing.T) {
+	card := emergencyCard{
+		SchoolName: "Greenwood Public School",
+		Student: studentRow{
+			FirstName: "Asha", LastName: "Sharma", AdmissionNumber: "ADM-001",
+			DateOfBirth: time.Date(2016, 5, 4, 0, 0, 0, 0, time.UTC), ClassName: "Class 3", SectionName: "B",
+		},
+		Alerts: []Alert{
+			{Kind: AlertKindAllergy, Name: "Peanuts", Severity: string(models.AllergySeverityLifeThreatening), EmergencyMedication: strPtr("EpiPen")},
+		},
+		Guardians: []models.StudentGuardian{{FirstName: "Priya", LastName: "Sharma", Relation: "mother", Phone: "9876543210", IsPrimary: true}},
+		Profile:   &models.StudentHealthProfile{BloodGroup: strPtr("B+"), PreferredHospital: strPtr("City Hospital")},
+		PrintedAt: time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, "B+", card.bloodGroup())
+
+	pdf, err := renderEmergencyCard(card)
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+}
@@ -0,0 +1,160 @@
+// Package orgchart provides the staff reporting hierarchy and organisation chart.
+package orgchart
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// =========================================================================
+// Response Types
+// =========================================================================
+
+// StaffRefResponse is a brief reference to a staff member.
+type StaffRefResponse struct {
+	ID              string `json:"id"`
+	EmployeeID      string `json:"employeeId"`
+	Name            string `json:"name"`
+	PhotoURL        string `json:"photoUrl,omitempty"`
+	DesignationName string `json:"designationName,omitempty"`
+	DepartmentName  string `json:"departmentName,omitempty"`
+}
+
+// OrgNodeResponse represents a staff member and their reports in the org chart.
+type OrgNodeResponse struct {
+	StaffRefResponse
+	BranchID           string            `json:"branchId"`
+	ReportingManagerID string            `json:"reportingManagerId,omitempty"`
+	DirectReports      int               `json:"directReports"`
+	TotalReports       int               `json:"totalReports"`
+	Reports            []OrgNodeResponse `json:"reports"`
+}
+
+// OrgChartResponse represents the org chart in API responses.
+type OrgChartResponse struct {
+	SchoolName  string            `json:"schoolName"`
+	BranchID    string            `json:"branchId,omitempty"`
+	BranchName  string            `json:"branchName,omitempty"`
+	TotalStaff  int               `json:"totalStaff"`
+	GeneratedAt string            `json:"generatedAt"`
+	Roots       []OrgNodeResponse `json:"roots"`
+	Cycles      [][]string        `json:"cycles,omitempty"`
+}
+
+// ReportResponse represents a staff member below a manager.
+type ReportResponse struct {
+	StaffRefResponse
+	ReportingManagerID string `json:"reportingManagerId,omitempty"`
+	Depth              int    `json:"depth"`
+}
+
+// ReportsResponse represents the staff below a manager.
+type ReportsResponse struct {
+	ManagerID string           `json:"managerId"`
+	Reports   []ReportResponse `json:"reports"`
+	Total     int              `json:"total"`
+}
+
+// ApproversResponse represents the managers who approve a staff member's requests.
+type ApproversResponse struct {
+	StaffID     string            `json:"staffId"`
+	Manager     *StaffRefResponse `json:"manager"`
+	SkipManager *StaffRefResponse `json:"skipManager"`
+}
+
+// =========================================================================
+// Converters
+// =========================================================================
+
+// ToStaffRef converts a staff member to a brief reference.
+func ToStaffRef(staff *models.Staff) StaffRefResponse {
+	ref := StaffRefResponse{
+		ID:         staff.ID.String(),
+		EmployeeID: staff.EmployeeID,
+		Name:       staff.FullName(),
+		PhotoURL:   staff.PhotoURL,
+	}
+	if staff.Designation != nil {
+		ref.DesignationName = staff.Designation.Name
+	}
+	if staff.Department != nil {
+		ref.DepartmentName = staff.Department.Name
+	}
+	return ref
+}
+
+// ToOrgNodeResponse converts a tree node and its reports to a response.
+func ToOrgNodeResponse(node *Node) OrgNodeResponse {
+	resp := OrgNodeResponse{
+		StaffRefResponse: ToStaffRef(node.Staff),
+		BranchID:         node.Staff.BranchID.String(),
+		DirectReports:    len(node.Reports),
+		TotalReports:     node.Size(),
+		Reports:          make([]OrgNodeResponse, len(node.Reports)),
+	}
+	if node.Staff.ReportingManagerID != nil {
+		resp.ReportingManagerID = node.Staff.ReportingManagerID.String()
+	}
+	for i, report := range node.Reports {
+		resp.Reports[i] = ToOrgNodeResponse(report)
+	}
+	return resp
+}
+
+// ToOrgChartResponse converts an org chart to a response.
+func ToOrgChartResponse(chart *OrgChart) OrgChartResponse {
+	resp := OrgChartResponse{
+		SchoolName:  chart.SchoolName,
+		BranchName:  chart.BranchName,
+		TotalStaff:  chart.TotalStaff,
+		GeneratedAt: chart.GeneratedAt.Format(time.RFC3339),
+		Roots:       make([]OrgNodeResponse, len(chart.Roots)),
+	}
+	if chart.BranchID != nil {
+		resp.BranchID = chart.BranchID.String()
+	}
+	for i, root := range chart.Roots {
+		resp.Roots[i] = ToOrgNodeResponse(root)
+	}
+	for _, cycle := range chart.Cycles {
+		ids := make([]string, len(cycle))
+		for i, id := range cycle {
+			ids[i] = id.String()
+		}
+		resp.Cycles = append(resp.Cycles, ids)
+	}
+	return resp
+}
+
+// ToReportsResponse converts a manager's reports to a response.
+func ToReportsResponse(managerID uuid.UUID, reports []Report) ReportsResponse {
+	resp := ReportsResponse{
+		ManagerID: managerID.String(),
+		Reports:   make([]ReportResponse, len(reports)),
+		Total:     len(reports),
+	}
+	for i, report := range reports {
+		resp.Reports[i] = ReportResponse{StaffRefResponse: ToStaffRef(report.Staff), Depth: report.Depth}
+		if report.Staff.ReportingManagerID != nil {
+			resp.Reports[i].ReportingManagerID = report.Staff.ReportingManagerID.String()
+		}
+	}
+	return resp
+}
+
+// ToApproversResponse converts a staff member's approvers to a response.
+func ToApproversResponse(staffID uuid.UUID, approvers *Approvers) ApproversResponse {
+	resp := ApproversResponse{StaffID: staffID.String()}
+	if approvers.Manager != nil {
+		ref := ToStaffRef(approvers.Manager)
+		resp.Manager = &ref
+	}
+	if approvers.SkipManager != nil {
+		ref := ToStaffRef(approvers.SkipManager)
+		resp.SkipManager = &ref
+	}
+	return resp
+}
@@ -0,0 +1,22 @@
+// Package orgchart provides the staff reporting hierarchy and organisation chart.
+package orgchart
+
+import "errors"
+
+// Org chart errors.
+var (
+	// ErrStaffNotFound is returned when a staff member is not found.
+	ErrStaffNotFound = errors.New("staff not found")
+
+	// ErrBranchNotFound is returned when a branch is not found.
+	ErrBranchNotFound = errors.New("branch not found")
+
+	// ErrSelfManager is returned when a staff member is made their own reporting manager.
+	ErrSelfManager = errors.New("staff member cannot report to themselves")
+
+	// ErrReportingCycle is returned when a reporting manager change would create a cycle.
+	ErrReportingCycle = errors.New("reporting manager reports to this staff member")
+
+	// ErrInvalidFormat is returned when an unsupported export format is requested.
+	ErrInvalidFormat = errors.New("export format must be json or pdf")
+)
@@ -0,0 +1,206 @@
+// Package orgchart provides the staff reporting hierarchy and organisation chart.
+package orgchart
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/middleware"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/response"
+)
+
+// Handler handles org chart HTTP requests.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new org chart handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers org chart routes.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup) {
+	orgChart := rg.Group("/org-chart")
+	orgChart.Use(middleware.PermissionRequired("staff:read"))
+	{
+		orgChart.GET("", h.GetOrgChart)
+		orgChart.GET("/export", h.ExportOrgChart)
+	}
+}
+
+// RegisterStaffHierarchyRoutes registers staff reporting hierarchy routes.
+func (h *Handler) RegisterStaffHierarchyRoutes(staffGroup *gin.RouterGroup) {
+	staffGroup.GET("/:id/reports", middleware.PermissionRequired("staff:read"), h.ListReports)
+	staffGroup.GET("/:id/approvers", middleware.PermissionRequired("staff:read"), h.GetApprovers)
+}
+
+// GetOrgChart returns the reporting tree.
+// @Summary Get org chart
+// @Description Get the reporting tree for a branch, or the whole school. Staff whose manager is outside the branch appear at the top level. With rootId, only that staff member's subtree is returned.
+// @Tags Org Chart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param branchId query string false "Branch ID"
+// @Param rootId query string false "Staff ID to return the subtree of"
+// @Success 200 {object} response.Success{data=OrgChartResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/org-chart [get]
+func (h *Handler) GetOrgChart(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var branchID, rootID *uuid.UUID
+	if !middleware.ParseUUIDQuery(c, "branchId", &branchID) || !middleware.ParseUUIDQuery(c, "rootId", &rootID) {
+		return
+	}
+
+	chart, err := h.service.GetOrgChart(c.Request.Context(), tenantID, branchID, rootID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToOrgChartResponse(chart))
+}
+
+// ExportOrgChart downloads the org chart.
+// @Summary Export org chart
+// @Description Download the org chart for a branch, or the whole school, as JSON or a printable PDF
+// @Tags Org Chart
+// @Produce application/json,application/pdf
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param branchId query string false "Branch ID"
+// @Param format query string false "Export format (json, pdf)" default(pdf)
+// @Success 200 {file} binary
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/org-chart/export [get]
+func (h *Handler) ExportOrgChart(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var branchID *uuid.UUID
+	if !middleware.ParseUUIDQuery(c, "branchId", &branchID) {
+		return
+	}
+
+	switch c.DefaultQuery("format", "pdf") {
+	case "pdf":
+		pdf, filename, err := h.service.ExportPDF(c.Request.Context(), tenantID, branchID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+		c.Data(http.StatusOK, "application/pdf", pdf)
+	case "json":
+		chart, err := h.service.GetOrgChart(c.Request.Context(), tenantID, branchID, nil)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		data, err := json.MarshalIndent(ToOrgChartResponse(chart), "", "  ")
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=\""+exportFilename(chart, "json")+"\"")
+		c.Data(http.StatusOK, "application/json", data)
+	default:
+		handleServiceError(c, ErrInvalidFormat)
+	}
+}
+
+// ListReports returns the staff below a manager.
+// @Summary List reports
+// @Description List everyone below a staff member in the reporting hierarchy (for example all staff under a HOD), across branches, with their depth below the manager
+// @Tags Org Chart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Param direct query bool false "Only direct reports"
+// @Success 200 {object} response.Success{data=ReportsResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/reports [get]
+func (h *Handler) ListReports(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+
+	reports, err := h.service.ListReports(c.Request.Context(), tenantID, staffID, c.Query("direct") == "true")
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToReportsResponse(staffID, reports))
+}
+
+// GetApprovers returns a staff member's manager and manager's manager.
+// @Summary Get approvers
+// @Description Get the reporting manager and the manager's manager who approve a staff member's regularizations, leave and appraisals
+// @Tags Org Chart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Success 200 {object} response.Success{data=ApproversResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/approvers [get]
+func (h *Handler) GetApprovers(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+
+	approvers, err := h.service.GetApprovers(c.Request.Context(), tenantID, staffID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToApproversResponse(staffID, approvers))
+}
+
+// =========================================================================
+// Helpers
+// =========================================================================
+
+// handleServiceError converts service errors to API errors.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrStaffNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff not found"))
+	case errors.Is(err, ErrBranchNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Branch not found"))
+	case errors.Is(err, ErrInvalidFormat):
+		apperrors.Abort(c, apperrors.BadRequest("Export format must be json or pdf"))
+	default:
+		logger.Error("Org chart operation error", zap.Error(err))
+		apperrors.Abort(c, apperrors.InternalError("Failed to process org chart request"))
+	}
+}
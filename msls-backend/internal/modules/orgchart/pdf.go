@@ -0,0 +1,133 @@
+// Package orgchart provides the staff reporting hierarchy and organisation chart.
+package orgchart
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// renderOrgChart renders the org chart as an indented A4 outline, one row per
+// staff member beneath their manager.
+func renderOrgChart(chart *OrgChart) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(12, 12, 12)
+	pdf.SetAutoPageBreak(true, 15)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth := 186.0 // 210 - 24 (margins)
+	indentStep := 6.0
+	maxIndent := 60.0
+	deptWidth := 40.0
+	idWidth := 22.0
+	reportsWidth := 16.0
+
+	primaryColor := []int{31, 41, 55}
+	headerBg := []int{243, 244, 246}
+	borderColor := []int{229, 231, 235}
+	mutedText := []int{107, 114, 128}
+	warnColor := []int{180, 83, 9}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.SetFont("Arial", "I", 7)
+		pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+		pdf.CellFormat(pageWidth/2, 4, "Printed "+chart.GeneratedAt.Format("02 Jan 2006 15:04"), "", 0, "L", false, 0, "")
+		pdf.CellFormat(pageWidth/2, 4, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	columnHeader := func() {
+		pdf.SetFillColor(headerBg[0], headerBg[1], headerBg[2])
+		pdf.SetDrawColor(borderColor[0], borderColor[1], borderColor[2])
+		pdf.SetFont("Arial", "B", 8)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.CellFormat(pageWidth-deptWidth-idWidth-reportsWidth, 6, "Name / Designation", "B", 0, "L", true, 0, "")
+		pdf.CellFormat(deptWidth, 6, "Department", "B", 0, "L", true, 0, "")
+		pdf.CellFormat(idWidth, 6, "Employee ID", "B", 0, "L", true, 0, "")
+		pdf.CellFormat(reportsWidth, 6, "Reports", "B", 1, "R", true, 0, "")
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 14)
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	pdf.CellFormat(pageWidth, 8, tr(chart.SchoolName), "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	subtitle := "Organisation Chart"
+	if chart.BranchName != "" {
+		subtitle += " - " + chart.BranchName
+	}
+	pdf.CellFormat(pageWidth, 6, tr(subtitle), "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 8)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	pdf.CellFormat(pageWidth, 5, fmt.Sprintf("%d staff", chart.TotalStaff), "", 1, "C", false, 0, "")
+	pdf.Ln(3)
+	columnHeader()
+
+	var row func(node *Node, depth int)
+	row = func(node *Node, depth int) {
+		if pdf.GetY() > 275 {
+			pdf.AddPage()
+			columnHeader()
+		}
+		indent := float64(depth) * indentStep
+		if indent > maxIndent {
+			indent = maxIndent
+		}
+		nameWidth := pageWidth - deptWidth - idWidth - reportsWidth - indent
+		member := node.Staff
+
+		designation := ""
+		if member.Designation != nil {
+			designation = member.Designation.Name
+		}
+		department := ""
+		if member.Department != nil {
+			department = member.Department.Name
+		}
+		reports := ""
+		if len(node.Reports) > 0 {
+			reports = strconv.Itoa(len(node.Reports))
+		}
+
+		x, y := pdf.GetX(), pdf.GetY()
+		pdf.SetX(x + indent)
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.CellFormat(nameWidth, 5, tr(member.FullName()), "", 2, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 7)
+		pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+		pdf.CellFormat(nameWidth, 4, tr(designation), "", 0, "L", false, 0, "")
+
+		pdf.SetXY(x+indent+nameWidth, y)
+		pdf.SetFont("Arial", "", 8)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.CellFormat(deptWidth, 9, tr(department), "", 0, "L", false, 0, "")
+		pdf.CellFormat(idWidth, 9, tr(member.EmployeeID), "", 0, "L", false, 0, "")
+		pdf.CellFormat(reportsWidth, 9, reports, "", 1, "R", false, 0, "")
+
+		pdf.SetDrawColor(borderColor[0], borderColor[1], borderColor[2])
+		pdf.Line(x+indent, pdf.GetY(), x+pageWidth, pdf.GetY())
+
+		for _, report := range node.Reports {
+			row(report, depth+1)
+		}
+	}
+	for _, root := range chart.Roots {
+		row(root, 0)
+	}
+
+	if len(chart.Cycles) > 0 {
+		pdf.Ln(4)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.SetTextColor(warnColor[0], warnColor[1], warnColor[2])
+		pdf.MultiCell(pageWidth, 4, fmt.Sprintf("%d reporting cycle(s) found; the first member of each is shown at the top level. Correct their reporting managers.", len(chart.Cycles)), "", "L", false)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("generate org chart pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
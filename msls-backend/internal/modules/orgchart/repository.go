@@ -0,0 +1,109 @@
+// Package orgchart provides the staff reporting hierarchy and organisation chart.
+package orgchart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// maxChainDepth bounds reporting chain walks so existing cycles in the data cannot loop forever.
+const maxChainDepth = 50
+
+// Repository handles reporting hierarchy database operations.
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new org chart repository.
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// ListStaff returns the staff shown on the org chart: everyone not terminated,
+// optionally in one branch, ordered by name.
+func (r *Repository) ListStaff(ctx context.Context, tenantID uuid.UUID, branchID *uuid.UUID) ([]models.Staff, error) {
+	query := r.db.WithContext(ctx).
+		Preload("Department").
+		Preload("Designation").
+		Where("tenant_id = ? AND status <> ?", tenantID, models.StaffStatusTerminated)
+	if branchID != nil {
+		query = query.Where("branch_id = ?", *branchID)
+	}
+
+	var staff []models.Staff
+	if err := query.Order("first_name ASC, last_name ASC").Find(&staff).Error; err != nil {
+		return nil, fmt.Errorf("list staff: %w", err)
+	}
+	return staff, nil
+}
+
+// GetStaff retrieves a staff member with their department and designation.
+func (r *Repository) GetStaff(ctx context.Context, tenantID, id uuid.UUID) (*models.Staff, error) {
+	var staff models.Staff
+	err := r.db.WithContext(ctx).
+		Preload("Department").
+		Preload("Designation").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&staff).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStaffNotFound
+		}
+		return nil, fmt.Errorf("get staff: %w", err)
+	}
+	return &staff, nil
+}
+
+// ManagerChain returns the IDs of a staff member's managers, nearest first,
+// up to depth levels.
+func (r *Repository) ManagerChain(ctx context.Context, tenantID, staffID uuid.UUID, depth int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE chain AS (
+			SELECT s.reporting_manager_id AS id, 1 AS depth
+			FROM staff s
+			WHERE s.tenant_id = ? AND s.id = ? AND s.deleted_at IS NULL
+			UNION ALL
+			SELECT s.reporting_manager_id, chain.depth + 1
+			FROM staff s
+			JOIN chain ON s.id = chain.id
+			WHERE s.tenant_id = ? AND s.deleted_at IS NULL AND chain.depth < ?
+		)
+		SELECT id FROM chain WHERE id IS NOT NULL ORDER BY depth`,
+		tenantID, staffID, tenantID, depth).
+		Scan(&ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("get manager chain: %w", err)
+	}
+	return ids, nil
+}
+
+// GetBranchName retrieves a branch's name for the org chart heading.
+func (r *Repository) GetBranchName(ctx context.Context, tenantID, branchID uuid.UUID) (string, error) {
+	var branch models.Branch
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, branchID).
+		First(&branch).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrBranchNotFound
+		}
+		return "", fmt.Errorf("get branch: %w", err)
+	}
+	return branch.Name, nil
+}
+
+// GetSchoolName retrieves the tenant's name for the org chart heading.
+func (r *Repository) GetSchoolName(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	var tenant models.Tenant
+	if err := r.db.WithContext(ctx).First(&tenant, "id = ?", tenantID).Error; err != nil {
+		return "", fmt.Errorf("get tenant: %w", err)
+	}
+	return tenant.Name, nil
+}
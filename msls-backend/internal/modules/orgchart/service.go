@@ -0,0 +1,309 @@
+// Package orgchart provides the staff reporting hierarchy and organisation chart.
+package orgchart
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// Service builds reporting trees and resolves managers for approval routing.
+type Service struct {
+	repo *Repository
+}
+
+// NewService creates a new org chart service.
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Node is a staff member in the reporting tree with their direct reports.
+type Node struct {
+	Staff   *models.Staff
+	Reports []*Node
+}
+
+// Size returns the number of staff below the node.
+func (n *Node) Size() int {
+	total := 0
+	for _, report := range n.Reports {
+		total += 1 + report.Size()
+	}
+	return total
+}
+
+// OrgChart is the reporting tree for a branch or the whole tenant. Staff whose
+// manager is outside the chart (for example in another branch) appear as
+// roots. Reporting cycles found in the data are broken at one member, which
+// is shown as a root, and listed in Cycles.
+type OrgChart struct {
+	BranchID    *uuid.UUID
+	BranchName  string
+	SchoolName  string
+	Roots       []*Node
+	Cycles      [][]uuid.UUID
+	TotalStaff  int
+	GeneratedAt time.Time
+}
+
+// Report is a staff member below a manager, with their distance from the manager.
+type Report struct {
+	Staff *models.Staff
+	Depth int
+}
+
+// Approvers are the managers who approve a staff member's requests.
+type Approvers struct {
+	Manager     *models.Staff
+	SkipManager *models.Staff
+}
+
+// GetOrgChart builds the reporting tree for a branch, or for the whole tenant
+// when branchID is nil. With rootID, only that staff member's subtree is
+// returned.
+func (s *Service) GetOrgChart(ctx context.Context, tenantID uuid.UUID, branchID, rootID *uuid.UUID) (*OrgChart, error) {
+	chart := &OrgChart{BranchID: branchID, GeneratedAt: time.Now()}
+
+	schoolName, err := s.repo.GetSchoolName(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	chart.SchoolName = schoolName
+
+	if branchID != nil {
+		branchName, err := s.repo.GetBranchName(ctx, tenantID, *branchID)
+		if err != nil {
+			return nil, err
+		}
+		chart.BranchName = branchName
+	}
+
+	staff, err := s.repo.ListStaff(ctx, tenantID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	chart.Roots, chart.Cycles = buildTree(staff)
+	chart.TotalStaff = len(staff)
+
+	if rootID != nil {
+		node := findNode(chart.Roots, *rootID)
+		if node == nil {
+			return nil, ErrStaffNotFound
+		}
+		chart.Roots = []*Node{node}
+		chart.TotalStaff = 1 + node.Size()
+	}
+	return chart, nil
+}
+
+// ExportPDF renders the org chart for a branch, or the whole tenant, as a
+// printable PDF and returns it with a download filename.
+func (s *Service) ExportPDF(ctx context.Context, tenantID uuid.UUID, branchID *uuid.UUID) ([]byte, string, error) {
+	chart, err := s.GetOrgChart(ctx, tenantID, branchID, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	pdf, err := renderOrgChart(chart)
+	if err != nil {
+		return nil, "", err
+	}
+	return pdf, exportFilename(chart, "pdf"), nil
+}
+
+// ListReports returns the staff below a manager across all branches, or only
+// their direct reports, in tree order.
+func (s *Service) ListReports(ctx context.Context, tenantID, managerID uuid.UUID, directOnly bool) ([]Report, error) {
+	if _, err := s.repo.GetStaff(ctx, tenantID, managerID); err != nil {
+		return nil, err
+	}
+	staff, err := s.repo.ListStaff(ctx, tenantID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, _ := buildTree(staff)
+	node := findNode(roots, managerID)
+	if node == nil {
+		// Terminated managers are not on the chart and have no reports.
+		return []Report{}, nil
+	}
+
+	reports := []Report{}
+	var walk func(n *Node, depth int)
+	walk = func(n *Node, depth int) {
+		for _, report := range n.Reports {
+			reports = append(reports, Report{Staff: report.Staff, Depth: depth})
+			if !directOnly {
+				walk(report, depth+1)
+			}
+		}
+	}
+	walk(node, 1)
+	return reports, nil
+}
+
+// GetApprovers returns a staff member's reporting manager and their manager,
+// for routing approvals of regularizations, leave and appraisals. Either may
+// be nil at the top of the hierarchy.
+func (s *Service) GetApprovers(ctx context.Context, tenantID, staffID uuid.UUID) (*Approvers, error) {
+	if _, err := s.repo.GetStaff(ctx, tenantID, staffID); err != nil {
+		return nil, err
+	}
+	chain, err := s.repo.ManagerChain(ctx, tenantID, staffID, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers := &Approvers{}
+	if len(chain) > 0 && chain[0] != staffID {
+		if approvers.Manager, err = s.lookupStaff(ctx, tenantID, chain[0]); err != nil {
+			return nil, err
+		}
+	}
+	if len(chain) > 1 && chain[1] != staffID && chain[1] != chain[0] {
+		if approvers.SkipManager, err = s.lookupStaff(ctx, tenantID, chain[1]); err != nil {
+			return nil, err
+		}
+	}
+	return approvers, nil
+}
+
+// ValidateReportingManager checks that staffID can report to managerID
+// without creating a cycle in the hierarchy.
+func (s *Service) ValidateReportingManager(ctx context.Context, tenantID, staffID, managerID uuid.UUID) error {
+	if staffID == managerID {
+		return ErrSelfManager
+	}
+	chain, err := s.repo.ManagerChain(ctx, tenantID, managerID, maxChainDepth)
+	if err != nil {
+		return err
+	}
+	for _, id := range chain {
+		if id == staffID {
+			return ErrReportingCycle
+		}
+	}
+	return nil
+}
+
+// lookupStaff returns a staff member, or nil if they have been removed.
+func (s *Service) lookupStaff(ctx context.Context, tenantID, id uuid.UUID) (*models.Staff, error) {
+	staff, err := s.repo.GetStaff(ctx, tenantID, id)
+	if errors.Is(err, ErrStaffNotFound) {
+		return nil, nil
+	}
+	return staff, err
+}
+
+// buildTree arranges staff into reporting trees, keeping the input order
+// among siblings. A staff member whose manager is not in the list is a root.
+// Each reporting cycle is broken by making the first member reached a root;
+// the cycles are returned so they can be fixed.
+func buildTree(staff []models.Staff) ([]*Node, [][]uuid.UUID) {
+	nodes := make(map[uuid.UUID]*Node, len(staff))
+	for i := range staff {
+		nodes[staff[i].ID] = &Node{Staff: &staff[i]}
+	}
+
+	parent := make(map[uuid.UUID]uuid.UUID, len(staff))
+	for i := range staff {
+		member := &staff[i]
+		if member.ReportingManagerID == nil {
+			continue
+		}
+		if _, ok := nodes[*member.ReportingManagerID]; ok {
+			parent[member.ID] = *member.ReportingManagerID
+		}
+	}
+
+	// Walk up from each staff member; meeting the current path again is a cycle.
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[uuid.UUID]int, len(staff))
+	var cycles [][]uuid.UUID
+	for i := range staff {
+		var path []uuid.UUID
+		id := staff[i].ID
+		for state[id] == unvisited {
+			state[id] = onPath
+			path = append(path, id)
+			next, ok := parent[id]
+			if !ok {
+				break
+			}
+			if state[next] == onPath {
+				for j, member := range path {
+					if member == next {
+						cycles = append(cycles, append([]uuid.UUID(nil), path[j:]...))
+						break
+					}
+				}
+				delete(parent, next)
+				break
+			}
+			id = next
+		}
+		for _, member := range path {
+			state[member] = done
+		}
+	}
+
+	var roots []*Node
+	for i := range staff {
+		node := nodes[staff[i].ID]
+		if managerID, ok := parent[staff[i].ID]; ok {
+			nodes[managerID].Reports = append(nodes[managerID].Reports, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, cycles
+}
+
+// findNode finds a staff member's node in the trees.
+func findNode(roots []*Node, id uuid.UUID) *Node {
+	for _, node := range roots {
+		if node.Staff.ID == id {
+			return node
+		}
+		if found := findNode(node.Reports, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// exportFilename names an org chart download, e.g. org-chart-main-campus-2026-10-18.pdf.
+func exportFilename(chart *OrgChart, ext string) string {
+	name := "org-chart"
+	if slug := slugify(chart.BranchName); slug != "" {
+		name += "-" + slug
+	}
+	return name + "-" + chart.GeneratedAt.Format("2006-01-02") + "." + ext
+}
+
+// slugify lower-cases a name and joins its letters and digits with hyphens.
+func slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
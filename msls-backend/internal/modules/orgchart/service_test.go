@@ -0,0 +1,115 @@
+package orgchart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func member(first string, manager *models.Staff) models.Staff {
+	s := models.Staff{ID: uuid.New(), FirstName: first, LastName: "Rao", EmployeeID: "EMP-" + first}
+	if manager != nil {
+		s.ReportingManagerID = &manager.ID
+	}
+	return s
+}
+
+func ids(nodes []*Node) []uuid.UUID {
+	result := make([]uuid.UUID, len(nodes))
+	for i, node := range nodes {
+		result[i] = node.Staff.ID
+	}
+	return result
+}
+
+func TestBuildTree(t *testing.T) {
+	principal := member("Asha", nil)
+	hod := member("Bala", &principal)
+	teacherA := member("Chitra", &hod)
+	teacherB := member("Deepak", &hod)
+	clerk := member("Esha", &principal)
+	outsider := member("Farhan", nil)
+	otherBranchManager := uuid.New()
+	outsider.ReportingManagerID = &otherBranchManager
+
+	roots, cycles := buildTree([]models.Staff{principal, hod, teacherA, teacherB, clerk, outsider})
+	assert.Empty(t, cycles)
+	require.Len(t, roots, 2)
+	assert.Equal(t, []uuid.UUID{principal.ID, outsider.ID}, ids(roots))
+	assert.Equal(t, []uuid.UUID{hod.ID, clerk.ID}, ids(roots[0].Reports))
+	assert.Equal(t, []uuid.UUID{teacherA.ID, teacherB.ID}, ids(roots[0].Reports[0].Reports))
+	assert.Equal(t, 4, roots[0].Size())
+	assert.Equal(t, 0, roots[1].Size())
+
+	node := findNode(roots, hod.ID)
+	require.NotNil(t, node)
+	assert.Equal(t, 2, node.Size())
+	assert.Nil(t, findNode(roots, uuid.New()))
+}
+
+func TestBuildTree_BreaksCycles(t *testing.T) {
+	a := member("Asha", nil)
+	b := member("Bala", &a)
+	c := member("Chitra", &b)
+	a.ReportingManagerID = &c.ID
+	d := member("Deepak", &c)
+	self := member("Esha", nil)
+	self.ReportingManagerID = &self.ID
+
+	roots, cycles := buildTree([]models.Staff{a, b, c, d, self})
+	require.Len(t, cycles, 2)
+	assert.ElementsMatch(t, []uuid.UUID{a.ID, b.ID, c.ID}, cycles[0])
+	assert.Equal(t, []uuid.UUID{self.ID}, cycles[1])
+
+	// Every staff member still appears exactly once.
+	require.Len(t, roots, 2)
+	assert.Equal(t, 5, len(roots)+roots[0].Size()+roots[1].Size())
+	assert.Equal(t, a.ID, roots[0].Staff.ID)
+	assert.Equal(t, self.ID, roots[1].Staff.ID)
+}
+
+func TestExportFilename(t *testing.T) {
+	generated := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "org-chart-2026-10-18.pdf", exportFilename(&OrgChart{GeneratedAt: generated}, "pdf"))
+	assert.Equal(t, "org-chart-main-campus-2026-10-18.json",
+		exportFilename(&OrgChart{BranchName: "  Main Campus! ", GeneratedAt: generated}, "json"))
+}
+
+func TestRenderOrgChart(t *testing.T) {
+	principal := member("Asha", nil)
+	principal.Designation = &models.Designation{Name: "Principal"}
+	hod := member("Bala", &principal)
+	hod.Department = &models.Department{Name: "Science"}
+	roots, _ := buildTree([]models.Staff{principal, hod})
+
+	pdf, err := renderOrgChart(&OrgChart{
+		SchoolName:  "Green Valley School",
+		BranchName:  "Main Campus",
+		Roots:       roots,
+		Cycles:      [][]uuid.UUID{{uuid.New()}},
+		TotalStaff:  2,
+		GeneratedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdf[:4]))
+}
+
+func TestToOrgChartResponse(t *testing.T) {
+	principal := member("Asha", nil)
+	hod := member("Bala", &principal)
+	roots, _ := buildTree([]models.Staff{principal, hod})
+
+	resp := ToOrgChartResponse(&OrgChart{Roots: roots, TotalStaff: 2, GeneratedAt: time.Now()})
+	require.Len(t, resp.Roots, 1)
+	assert.Equal(t, "Asha Rao", resp.Roots[0].Name)
+	assert.Equal(t, 1, resp.Roots[0].DirectReports)
+	require.Len(t, resp.Roots[0].Reports, 1)
+	assert.Equal(t, principal.ID.String(), resp.Roots[0].Reports[0].ReportingManagerID)
+	assert.Empty(t, resp.Cycles)
+}
@@ -0,0 +1,223 @@
+// Package payroll provides payroll processing functionality.
+package payroll
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/crypto"
+	"msls-backend/internal/pkg/database/models"
+)
+
+var (
+	ifscPattern          = regexp.MustCompile(`^[A-Z]{4}0[A-Z0-9]{6}$`)
+	accountNumberPattern = regexp.MustCompile(`^[0-9]{9,18}$`)
+)
+
+// ========================================
+// Staff Bank Account Service Methods
+// ========================================
+
+// ListStaffBankAccounts retrieves the bank accounts of a staff member.
+func (s *Service) ListStaffBankAccounts(ctx context.Context, tenantID, staffID uuid.UUID) ([]models.StaffBankAccount, error) {
+	if _, err := s.repo.GetStaff(ctx, tenantID, staffID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListStaffBankAccounts(ctx, tenantID, staffID)
+}
+
+// CreateStaffBankAccount adds a bank account for a staff member. The first
+// account becomes the primary account. New accounts await verification.
+func (s *Service) CreateStaffBankAccount(ctx context.Context, dto BankAccountDTO) (*models.StaffBankAccount, error) {
+	if err := normalizeBankAccountDTO(&dto); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetStaff(ctx, dto.TenantID, dto.StaffID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountStaffBankAccounts(ctx, dto.TenantID, dto.StaffID)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.encryptAccountNumber(dto.AccountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account := &models.StaffBankAccount{
+		TenantID:               dto.TenantID,
+		StaffID:                dto.StaffID,
+		AccountHolderName:      dto.AccountHolderName,
+		BankName:               dto.BankName,
+		BranchName:             dto.BranchName,
+		IFSCCode:               dto.IFSCCode,
+		AccountType:            dto.AccountType,
+		AccountNumberEncrypted: encrypted,
+		AccountNumberLast4:     dto.AccountNumber[len(dto.AccountNumber)-4:],
+		AccountNumberHash:      s.hashAccountNumber(dto.AccountNumber),
+		IsPrimary:              dto.IsPrimary || count == 0,
+		VerificationStatus:     models.BankVerificationPending,
+		CreatedAt:              now,
+		UpdatedAt:              now,
+		CreatedBy:              dto.UpdatedBy,
+		UpdatedBy:              dto.UpdatedBy,
+	}
+
+	if err := s.repo.SaveStaffBankAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetStaffBankAccount(ctx, dto.TenantID, dto.StaffID, account.ID)
+}
+
+// UpdateStaffBankAccount updates a staff bank account. Changing the account
+// number, IFSC code or holder name sends the account back for verification.
+// An empty account number keeps the stored one.
+func (s *Service) UpdateStaffBankAccount(ctx context.Context, accountID uuid.UUID, dto BankAccountDTO) (*models.StaffBankAccount, error) {
+	account, err := s.repo.GetStaffBankAccount(ctx, dto.TenantID, dto.StaffID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	keepNumber := strings.TrimSpace(dto.AccountNumber) == ""
+	if keepNumber {
+		dto.AccountNumber = "000000000" + account.AccountNumberLast4
+	}
+	if err := normalizeBankAccountDTO(&dto); err != nil {
+		return nil, err
+	}
+
+	changed := dto.IFSCCode != account.IFSCCode || dto.AccountHolderName != account.AccountHolderName
+	if !keepNumber {
+		hash := s.hashAccountNumber(dto.AccountNumber)
+		if hash != account.AccountNumberHash {
+			encrypted, err := s.encryptAccountNumber(dto.AccountNumber)
+			if err != nil {
+				return nil, err
+			}
+			account.AccountNumberEncrypted = encrypted
+			account.AccountNumberLast4 = dto.AccountNumber[len(dto.AccountNumber)-4:]
+			account.AccountNumberHash = hash
+			changed = true
+		}
+	}
+
+	account.AccountHolderName = dto.AccountHolderName
+	account.BankName = dto.BankName
+	account.BranchName = dto.BranchName
+	account.IFSCCode = dto.IFSCCode
+	account.AccountType = dto.AccountType
+	account.IsPrimary = account.IsPrimary || dto.IsPrimary
+	account.UpdatedAt = time.Now()
+	account.UpdatedBy = dto.UpdatedBy
+
+	if changed {
+		account.VerificationStatus = models.BankVerificationPending
+		account.VerifiedAt = nil
+		account.VerifiedBy = nil
+		account.VerificationNote = nil
+	}
+
+	account.Verifier = nil
+	if err := s.repo.SaveStaffBankAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetStaffBankAccount(ctx, dto.TenantID, dto.StaffID, account.ID)
+}
+
+// VerifyStaffBankAccount records the verification outcome of a staff bank account.
+func (s *Service) VerifyStaffBankAccount(ctx context.Context, dto VerifyBankAccountDTO) (*models.StaffBankAccount, error) {
+	if dto.Status != models.BankVerificationVerified && dto.Status != models.BankVerificationRejected {
+		return nil, ErrInvalidVerificationStatus
+	}
+
+	account, err := s.repo.GetStaffBankAccount(ctx, dto.TenantID, dto.StaffID, dto.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account.VerificationStatus = dto.Status
+	account.VerifiedAt = &now
+	account.VerifiedBy = &dto.VerifiedBy
+	account.VerificationNote = dto.Note
+	account.UpdatedAt = now
+	account.UpdatedBy = &dto.VerifiedBy
+
+	account.Verifier = nil
+	if err := s.repo.SaveStaffBankAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetStaffBankAccount(ctx, dto.TenantID, dto.StaffID, account.ID)
+}
+
+// DeleteStaffBankAccount removes a staff bank account.
+func (s *Service) DeleteStaffBankAccount(ctx context.Context, tenantID, staffID, accountID uuid.UUID) error {
+	return s.repo.DeleteStaffBankAccount(ctx, tenantID, staffID, accountID)
+}
+
+// normalizeBankAccountDTO trims and validates bank account details.
+func normalizeBankAccountDTO(dto *BankAccountDTO) error {
+	dto.AccountHolderName = strings.TrimSpace(dto.AccountHolderName)
+	dto.BankName = strings.TrimSpace(dto.BankName)
+	dto.IFSCCode = strings.ToUpper(strings.TrimSpace(dto.IFSCCode))
+	dto.AccountNumber = strings.ReplaceAll(strings.TrimSpace(dto.AccountNumber), " ", "")
+	if dto.AccountType == "" {
+		dto.AccountType = models.BankAccountSavings
+	}
+
+	if dto.AccountHolderName == "" {
+		return ErrAccountHolderRequired
+	}
+	if dto.BankName == "" {
+		return ErrBankNameRequired
+	}
+	if !ifscPattern.MatchString(dto.IFSCCode) {
+		return ErrInvalidIFSC
+	}
+	if !accountNumberPattern.MatchString(dto.AccountNumber) {
+		return ErrInvalidAccountNumber
+	}
+	if !dto.AccountType.IsValid() {
+		return ErrInvalidAccountType
+	}
+	return nil
+}
+
+// maskAccountNumber hides all but the last four digits of an account number.
+func maskAccountNumber(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return accountNumber
+	}
+	return "XXXXXX" + accountNumber[len(accountNumber)-4:]
+}
+
+// hashAccountNumber returns a keyed hash of an account number for duplicate detection.
+func (s *Service) hashAccountNumber(accountNumber string) string {
+	mac := hmac.New(sha256.New, s.hashKey)
+	mac.Write([]byte(accountNumber))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// encryptAccountNumber encrypts an account number for storage.
+func (s *Service) encryptAccountNumber(plaintext string) (string, error) {
+	return crypto.Encrypt(s.encryptionKey, plaintext)
+}
+
+// decryptAccountNumber decrypts an account number encrypted with encryptAccountNumber.
+func (s *Service) decryptAccountNumber(ciphertext string) (string, error) {
+	return crypto.Decrypt(s.encryptionKey, ciphertext)
+}
@@ -0,0 +1,522 @@
+// Package payroll provides payroll processing functionality.
+package payroll
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+)
+
+// maxBankResponseSize is the largest bank response file accepted for import.
+const maxBankResponseSize = 5 << 20
+
+// ========================================
+// Bank File Handlers
+// ========================================
+
+// GenerateBankFileRequest represents the request body for generating a bank transfer file.
+type GenerateBankFileRequest struct {
+	Format       string  `json:"format" binding:"required"`
+	TemplateID   *string `json:"templateId" binding:"omitempty,uuid"`
+	DebitAccount string  `json:"debitAccount"`
+	ValueDate    *string `json:"valueDate"`
+	PaymentMode  string  `json:"paymentMode" binding:"omitempty,oneof=NEFT RTGS"`
+}
+
+// BankAccountRequest represents the request body for creating or updating a staff bank account.
+type BankAccountRequest struct {
+	AccountHolderName string  `json:"accountHolderName" binding:"required,max=200"`
+	BankName          string  `json:"bankName" binding:"required,max=100"`
+	BranchName        *string `json:"branchName" binding:"omitempty,max=100"`
+	IFSCCode          string  `json:"ifscCode" binding:"required"`
+	AccountType       string  `json:"accountType" binding:"omitempty,oneof=savings current salary"`
+	AccountNumber     string  `json:"accountNumber"`
+	IsPrimary         bool    `json:"isPrimary"`
+}
+
+// VerifyBankAccountRequest represents the request body for verifying a staff bank account.
+type VerifyBankAccountRequest struct {
+	Status string  `json:"status" binding:"required,oneof=verified rejected"`
+	Note   *string `json:"note"`
+}
+
+// BankFileTemplateRequest represents the request body for creating or updating a bank file template.
+type BankFileTemplateRequest struct {
+	Name          string                 `json:"name" binding:"required,max=100"`
+	Description   *string                `json:"description"`
+	HeaderFields  []models.BankFileField `json:"headerFields"`
+	DetailFields  []models.BankFileField `json:"detailFields" binding:"required"`
+	TrailerFields []models.BankFileField `json:"trailerFields"`
+	LineEnding    string                 `json:"lineEnding"`
+	FileExtension string                 `json:"fileExtension"`
+	IsActive      *bool                  `json:"isActive"`
+}
+
+// GenerateBankFile generates a salary transfer file for a pay run.
+func (h *Handler) GenerateBankFile(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid pay run ID"))
+		return
+	}
+
+	var req GenerateBankFileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	dto := BankFileDTO{
+		TenantID:     tenantID,
+		PayRunID:     id,
+		Format:       req.Format,
+		DebitAccount: req.DebitAccount,
+		PaymentMode:  req.PaymentMode,
+	}
+
+	if req.TemplateID != nil {
+		templateID, _ := uuid.Parse(*req.TemplateID)
+		dto.TemplateID = &templateID
+	}
+
+	if req.ValueDate != nil && *req.ValueDate != "" {
+		valueDate, err := time.Parse("2006-01-02", *req.ValueDate)
+		if err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid value date, expected YYYY-MM-DD"))
+			return
+		}
+		dto.ValueDate = valueDate
+	}
+
+	file, err := h.service.GenerateBankFile(c.Request.Context(), dto)
+	if err != nil {
+		handleBankFileError(c, err, "Failed to generate bank file")
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+file.Filename)
+	c.Header("X-Record-Count", strconv.Itoa(file.Records))
+	c.Header("X-Total-Amount", file.TotalAmount.StringFixed(2))
+	c.Header("X-Skipped-Records", strconv.Itoa(len(file.Skipped)))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// ImportBankResponse imports a bank payment status file for a pay run.
+func (h *Handler) ImportBankResponse(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid pay run ID"))
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Response file is required"))
+		return
+	}
+	if header.Size > maxBankResponseSize {
+		apperrors.Abort(c, apperrors.BadRequest("Response file is too large"))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Failed to read response file"))
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportBankResponse(c.Request.Context(), tenantID, id, file)
+	if err != nil {
+		handleBankFileError(c, err, "Failed to import bank response")
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// ListBankFormats returns the supported bank file formats.
+func (h *Handler) ListBankFormats(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	formats, err := h.service.ListBankFormats(c.Request.Context(), tenantID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to list bank formats"))
+		return
+	}
+
+	response.OK(c, formats)
+}
+
+// ========================================
+// Bank File Template Handlers
+// ========================================
+
+// ListBankFileTemplates returns the tenant's bank file templates.
+func (h *Handler) ListBankFileTemplates(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	templates, err := h.service.ListBankFileTemplates(c.Request.Context(), tenantID)
+	if err != nil {
+		apperrors.Abort(c, apperrors.InternalError("Failed to list bank file templates"))
+		return
+	}
+
+	resp := make([]BankFileTemplateResponse, 0, len(templates))
+	for i := range templates {
+		resp = append(resp, ToBankFileTemplateResponse(&templates[i]))
+	}
+
+	response.OK(c, resp)
+}
+
+// CreateBankFileTemplate creates a bank file template.
+func (h *Handler) CreateBankFileTemplate(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var req BankFileTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	template, err := h.service.CreateBankFileTemplate(c.Request.Context(), bankFileTemplateDTO(c, tenantID, req))
+	if err != nil {
+		handleBankFileError(c, err, "Failed to create bank file template")
+		return
+	}
+
+	response.Created(c, ToBankFileTemplateResponse(template))
+}
+
+// UpdateBankFileTemplate updates a bank file template.
+func (h *Handler) UpdateBankFileTemplate(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("templateId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid template ID"))
+		return
+	}
+
+	var req BankFileTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	template, err := h.service.UpdateBankFileTemplate(c.Request.Context(), id, bankFileTemplateDTO(c, tenantID, req))
+	if err != nil {
+		handleBankFileError(c, err, "Failed to update bank file template")
+		return
+	}
+
+	response.OK(c, ToBankFileTemplateResponse(template))
+}
+
+// DeleteBankFileTemplate deletes a bank file template.
+func (h *Handler) DeleteBankFileTemplate(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("templateId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid template ID"))
+		return
+	}
+
+	if err := h.service.DeleteBankFileTemplate(c.Request.Context(), tenantID, id); err != nil {
+		handleBankFileError(c, err, "Failed to delete bank file template")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// bankFileTemplateDTO builds a template DTO from a request. Templates are active unless disabled.
+func bankFileTemplateDTO(c *gin.Context, tenantID uuid.UUID, req BankFileTemplateRequest) BankFileTemplateDTO {
+	dto := BankFileTemplateDTO{
+		TenantID:      tenantID,
+		Name:          req.Name,
+		Description:   req.Description,
+		HeaderFields:  req.HeaderFields,
+		DetailFields:  req.DetailFields,
+		TrailerFields: req.TrailerFields,
+		LineEnding:    req.LineEnding,
+		FileExtension: req.FileExtension,
+		IsActive:      req.IsActive == nil || *req.IsActive,
+	}
+	if userID, ok := middleware.GetCurrentUserID(c); ok {
+		dto.UpdatedBy = &userID
+	}
+	return dto
+}
+
+// handleBankFileError maps bank file errors to HTTP responses.
+func handleBankFileError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrPayRunNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Pay run not found"))
+	case errors.Is(err, ErrBankFileTemplateNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Bank file template not found"))
+	case errors.Is(err, ErrDuplicateBankFileTemplate):
+		apperrors.Abort(c, apperrors.Conflict("A bank file template with this name already exists"))
+	case errors.Is(err, ErrPayRunNotApproved):
+		apperrors.Abort(c, apperrors.BadRequest("Pay run must be approved before bank transfers"))
+	case errors.Is(err, ErrUnsupportedBankFormat), errors.Is(err, ErrInvalidBankFileTemplate),
+		errors.Is(err, ErrDebitAccountRequired), errors.Is(err, ErrNoPayableStaff),
+		errors.Is(err, ErrInvalidBankResponse):
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
+
+// ========================================
+// Staff Bank Account Handlers
+// ========================================
+
+// ListStaffBankAccounts returns the bank accounts of a staff member.
+func (h *Handler) ListStaffBankAccounts(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid staff ID"))
+		return
+	}
+
+	accounts, err := h.service.ListStaffBankAccounts(c.Request.Context(), tenantID, staffID)
+	if err != nil {
+		handleBankAccountError(c, err, "Failed to list bank accounts")
+		return
+	}
+
+	resp := make([]BankAccountResponse, 0, len(accounts))
+	for i := range accounts {
+		resp = append(resp, ToBankAccountResponse(&accounts[i]))
+	}
+
+	response.OK(c, resp)
+}
+
+// CreateStaffBankAccount adds a bank account for a staff member.
+func (h *Handler) CreateStaffBankAccount(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid staff ID"))
+		return
+	}
+
+	var req BankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	account, err := h.service.CreateStaffBankAccount(c.Request.Context(), bankAccountDTO(c, tenantID, staffID, req))
+	if err != nil {
+		handleBankAccountError(c, err, "Failed to create bank account")
+		return
+	}
+
+	response.Created(c, ToBankAccountResponse(account))
+}
+
+// UpdateStaffBankAccount updates a staff bank account.
+func (h *Handler) UpdateStaffBankAccount(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid staff ID"))
+		return
+	}
+
+	accountID, err := uuid.Parse(c.Param("accountId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid bank account ID"))
+		return
+	}
+
+	var req BankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	account, err := h.service.UpdateStaffBankAccount(c.Request.Context(), accountID, bankAccountDTO(c, tenantID, staffID, req))
+	if err != nil {
+		handleBankAccountError(c, err, "Failed to update bank account")
+		return
+	}
+
+	response.OK(c, ToBankAccountResponse(account))
+}
+
+// DeleteStaffBankAccount removes a staff bank account.
+func (h *Handler) DeleteStaffBankAccount(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid staff ID"))
+		return
+	}
+
+	accountID, err := uuid.Parse(c.Param("accountId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid bank account ID"))
+		return
+	}
+
+	if err := h.service.DeleteStaffBankAccount(c.Request.Context(), tenantID, staffID, accountID); err != nil {
+		handleBankAccountError(c, err, "Failed to delete bank account")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// VerifyStaffBankAccount records the verification outcome of a staff bank account.
+func (h *Handler) VerifyStaffBankAccount(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.Unauthorized("User not authenticated"))
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid staff ID"))
+		return
+	}
+
+	accountID, err := uuid.Parse(c.Param("accountId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid bank account ID"))
+		return
+	}
+
+	var req VerifyBankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+		return
+	}
+
+	account, err := h.service.VerifyStaffBankAccount(c.Request.Context(), VerifyBankAccountDTO{
+		TenantID:   tenantID,
+		StaffID:    staffID,
+		AccountID:  accountID,
+		Status:     models.BankVerificationStatus(req.Status),
+		Note:       req.Note,
+		VerifiedBy: userID,
+	})
+	if err != nil {
+		handleBankAccountError(c, err, "Failed to verify bank account")
+		return
+	}
+
+	response.OK(c, ToBankAccountResponse(account))
+}
+
+// bankAccountDTO builds a bank account DTO from a request.
+func bankAccountDTO(c *gin.Context, tenantID, staffID uuid.UUID, req BankAccountRequest) BankAccountDTO {
+	dto := BankAccountDTO{
+		TenantID:          tenantID,
+		StaffID:           staffID,
+		AccountHolderName: req.AccountHolderName,
+		BankName:          req.BankName,
+		BranchName:        req.BranchName,
+		IFSCCode:          req.IFSCCode,
+		AccountType:       models.BankAccountType(req.AccountType),
+		AccountNumber:     req.AccountNumber,
+		IsPrimary:         req.IsPrimary,
+	}
+	if userID, ok := middleware.GetCurrentUserID(c); ok {
+		dto.UpdatedBy = &userID
+	}
+	return dto
+}
+
+// handleBankAccountError maps staff bank account errors to HTTP responses.
+func handleBankAccountError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrStaffNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff not found"))
+	case errors.Is(err, ErrBankAccountNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Bank account not found"))
+	case errors.Is(err, ErrDuplicateBankAccount):
+		apperrors.Abort(c, apperrors.Conflict("This bank account is already registered for the staff member"))
+	case errors.Is(err, ErrAccountHolderRequired), errors.Is(err, ErrBankNameRequired),
+		errors.Is(err, ErrInvalidIFSC), errors.Is(err, ErrInvalidAccountNumber),
+		errors.Is(err, ErrInvalidAccountType), errors.Is(err, ErrInvalidVerificationStatus):
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+	default:
+		apperrors.Abort(c, apperrors.InternalError(fallback))
+	}
+}
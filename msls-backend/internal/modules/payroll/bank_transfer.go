@@ -0,0 +1,491 @@
+// Package payroll provides payroll processing functionality.
+package payroll
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// ========================================
+// Bank Transfer Service Methods
+// ========================================
+
+// buildTransferBatch collects the payslips of a pay run that can be paid by
+// bank transfer. Payslips that are already paid, have nothing to pay or have
+// no verified primary bank account are returned as skipped.
+func (s *Service) buildTransferBatch(ctx context.Context, payRun *models.PayRun, requestedMode string) (*transferBatch, []BankTransferSkipped, error) {
+	payslips, _, err := s.repo.ListPayslipsByPayRun(ctx, payRun.TenantID, payRun.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	staffIDs := make([]uuid.UUID, 0, len(payslips))
+	for _, ps := range payslips {
+		staffIDs = append(staffIDs, ps.StaffID)
+	}
+	accounts, err := s.repo.GetPrimaryBankAccounts(ctx, payRun.TenantID, staffIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	narration := "Salary " + getMonthName(payRun.PayPeriodMonth) + " " + formatYear(payRun.PayPeriodYear)
+	batch := &transferBatch{Total: decimal.Zero}
+	skipped := []BankTransferSkipped{}
+
+	for _, ps := range payslips {
+		var staffName, employeeCode, email string
+		if ps.Staff != nil {
+			staffName = ps.Staff.FullName()
+			employeeCode = ps.Staff.EmployeeID
+			email = ps.Staff.WorkEmail
+		}
+
+		skip := func(reason string) {
+			skipped = append(skipped, BankTransferSkipped{
+				PayslipID:    ps.ID.String(),
+				StaffName:    staffName,
+				EmployeeCode: employeeCode,
+				NetAmount:    ps.NetSalary.StringFixed(2),
+				Reason:       reason,
+			})
+		}
+
+		account := accounts[ps.StaffID]
+		switch {
+		case ps.Status == models.PayslipStatusPaid:
+			skip("already paid")
+			continue
+		case !ps.NetSalary.IsPositive():
+			skip("no net salary to pay")
+			continue
+		case account == nil:
+			skip("no primary bank account")
+			continue
+		case account.VerificationStatus != models.BankVerificationVerified:
+			skip("bank account not verified")
+			continue
+		}
+
+		accountNumber, err := s.decryptAccountNumber(account.AccountNumberEncrypted)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decrypt bank account of %s: %w", employeeCode, err)
+		}
+
+		batch.Records = append(batch.Records, transferRecord{
+			Serial:          len(batch.Records) + 1,
+			PayslipID:       ps.ID,
+			StaffName:       staffName,
+			EmployeeCode:    employeeCode,
+			BeneficiaryName: account.AccountHolderName,
+			AccountNumber:   accountNumber,
+			IFSC:            account.IFSCCode,
+			BankName:        account.BankName,
+			AccountType:     string(account.AccountType),
+			Email:           email,
+			Amount:          ps.NetSalary,
+			PaymentMode:     paymentModeFor(ps.NetSalary, requestedMode),
+			Reference:       transferReference(payRun, employeeCode),
+			Narration:       narration,
+		})
+		batch.Total = batch.Total.Add(ps.NetSalary)
+	}
+
+	return batch, skipped, nil
+}
+
+// GenerateBankFile generates a salary transfer file for an approved pay run,
+// either in a bank's bulk upload CSV layout or using a tenant template.
+func (s *Service) GenerateBankFile(ctx context.Context, dto BankFileDTO) (*BankFile, error) {
+	payRun, err := s.repo.GetPayRunByID(ctx, dto.TenantID, dto.PayRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payRun.Status != models.PayRunStatusApproved && payRun.Status != models.PayRunStatusFinalized {
+		return nil, ErrPayRunNotApproved
+	}
+
+	format := strings.ToLower(strings.TrimSpace(dto.Format))
+	var layout bankCSVLayout
+	var template *models.BankFileTemplate
+	needsDebitAccount := false
+
+	if format == BankFileFormatTemplate {
+		if dto.TemplateID == nil {
+			return nil, ErrBankFileTemplateNotFound
+		}
+		template, err = s.repo.GetBankFileTemplate(ctx, dto.TenantID, *dto.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if !template.IsActive {
+			return nil, ErrBankFileTemplateNotFound
+		}
+		needsDebitAccount = templateUsesField(template, "debit_account")
+	} else {
+		var ok bool
+		layout, ok = bankCSVLayouts[format]
+		if !ok {
+			return nil, ErrUnsupportedBankFormat
+		}
+		needsDebitAccount = layoutUsesField(layout, "debit_account")
+	}
+
+	debitAccount := strings.ReplaceAll(strings.TrimSpace(dto.DebitAccount), " ", "")
+	if needsDebitAccount && debitAccount == "" {
+		return nil, ErrDebitAccountRequired
+	}
+
+	mode := strings.ToUpper(strings.TrimSpace(dto.PaymentMode))
+	batch, skipped, err := s.buildTransferBatch(ctx, payRun, mode)
+	if err != nil {
+		return nil, err
+	}
+	if len(batch.Records) == 0 {
+		return nil, ErrNoPayableStaff
+	}
+
+	batch.DebitAccount = debitAccount
+	batch.ValueDate = dto.ValueDate
+	if batch.ValueDate.IsZero() {
+		batch.ValueDate = time.Now()
+	}
+	batch.BatchReference = fmt.Sprintf("SAL%04d%02d", payRun.PayPeriodYear, payRun.PayPeriodMonth)
+
+	period := fmt.Sprintf("%04d-%02d", payRun.PayPeriodYear, payRun.PayPeriodMonth)
+	file := &BankFile{
+		Records:     len(batch.Records),
+		TotalAmount: batch.Total,
+		Skipped:     skipped,
+	}
+
+	if template != nil {
+		file.Content = renderFixedWidth(template, batch)
+		file.ContentType = "text/plain"
+		file.Filename = fmt.Sprintf("salary-%s-%s.%s", templateSlug(template.Name), period, template.FileExtension)
+		return file, nil
+	}
+
+	file.Content, err = renderBankCSV(layout, batch)
+	if err != nil {
+		return nil, err
+	}
+	file.ContentType = "text/csv"
+	file.Filename = fmt.Sprintf("salary-%s-%s.csv", layout.Code, period)
+	return file, nil
+}
+
+// ListBankFormats lists the built-in bank layouts and the tenant's active templates.
+func (s *Service) ListBankFormats(ctx context.Context, tenantID uuid.UUID) ([]BankFormatResponse, error) {
+	codes := make([]string, 0, len(bankCSVLayouts))
+	for code := range bankCSVLayouts {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	formats := make([]BankFormatResponse, 0, len(codes))
+	for _, code := range codes {
+		layout := bankCSVLayouts[code]
+		columns := make([]string, len(layout.Columns))
+		for i, col := range layout.Columns {
+			columns[i] = col.Header
+		}
+		formats = append(formats, BankFormatResponse{
+			Code:         layout.Code,
+			Name:         layout.Name,
+			Columns:      columns,
+			DebitAccount: layoutUsesField(layout, "debit_account"),
+		})
+	}
+
+	templates, err := s.repo.ListBankFileTemplates(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range templates {
+		if !templates[i].IsActive {
+			continue
+		}
+		formats = append(formats, BankFormatResponse{
+			Code:         BankFileFormatTemplate,
+			Name:         templates[i].Name,
+			TemplateID:   templates[i].ID.String(),
+			FixedWidth:   true,
+			DebitAccount: templateUsesField(&templates[i], "debit_account"),
+		})
+	}
+
+	return formats, nil
+}
+
+// ImportBankResponse reads a bank's payment status file for a pay run and
+// marks the payslips the bank credited as paid. Rows are matched by customer
+// reference, or by account number and amount when the bank omits the reference.
+func (s *Service) ImportBankResponse(ctx context.Context, tenantID, payRunID uuid.UUID, r io.Reader) (*BankResponseImportResult, error) {
+	payRun, err := s.repo.GetPayRunByID(ctx, tenantID, payRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payRun.Status != models.PayRunStatusApproved && payRun.Status != models.PayRunStatusFinalized {
+		return nil, ErrPayRunNotApproved
+	}
+
+	rows, err := parseBankResponse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	payslips, _, err := s.repo.ListPayslipsByPayRun(ctx, tenantID, payRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	staffIDs := make([]uuid.UUID, 0, len(payslips))
+	for _, ps := range payslips {
+		staffIDs = append(staffIDs, ps.StaffID)
+	}
+	accounts, err := s.repo.GetPrimaryBankAccounts(ctx, tenantID, staffIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byReference := make(map[string]*models.Payslip, len(payslips))
+	byAccount := make(map[string][]*models.Payslip)
+	codes := make(map[uuid.UUID]string, len(payslips))
+	for i := range payslips {
+		ps := &payslips[i]
+		if ps.Staff != nil {
+			codes[ps.ID] = ps.Staff.EmployeeID
+		}
+		byReference[strings.ToUpper(transferReference(payRun, codes[ps.ID]))] = ps
+		if account := accounts[ps.StaffID]; account != nil {
+			number, err := s.decryptAccountNumber(account.AccountNumberEncrypted)
+			if err != nil {
+				return nil, fmt.Errorf("decrypt bank account of %s: %w", codes[ps.ID], err)
+			}
+			byAccount[number] = append(byAccount[number], ps)
+		}
+	}
+
+	result := &BankResponseImportResult{TotalRows: len(rows), Rows: []BankResponseRowResult{}}
+	payments := []PayslipPayment{}
+	paid := make(map[uuid.UUID]bool)
+
+	for _, row := range rows {
+		res := BankResponseRowResult{Line: row.Line, Reference: row.Reference, UTR: row.UTR}
+
+		ps := byReference[strings.ToUpper(row.Reference)]
+		if ps == nil && row.AccountNumber != "" {
+			ps = matchByAccount(byAccount[strings.ReplaceAll(row.AccountNumber, " ", "")], row.Amount)
+		}
+
+		switch {
+		case ps == nil:
+			res.Result = BankResponseUnmatched
+			res.Message = "no payslip in this pay run matches the row"
+			result.Unmatched++
+		case ps.Status == models.PayslipStatusPaid || paid[ps.ID]:
+			res.EmployeeCode = codes[ps.ID]
+			res.Result = BankResponseAlreadyPaid
+			result.AlreadyPaid++
+		case !row.Success:
+			res.EmployeeCode = codes[ps.ID]
+			res.Result = BankResponseFailed
+			res.Message = row.Reason
+			if res.Message == "" {
+				res.Message = row.Status
+			}
+			result.Failed++
+		default:
+			res.EmployeeCode = codes[ps.ID]
+			res.Result = BankResponsePaid
+			result.Paid++
+
+			reference := row.UTR
+			if reference == "" {
+				reference = row.Reference
+			}
+			paymentDate := time.Now()
+			if row.PaymentDate != nil {
+				paymentDate = *row.PaymentDate
+			}
+			payments = append(payments, PayslipPayment{PayslipID: ps.ID, PaymentDate: paymentDate, Reference: reference})
+			paid[ps.ID] = true
+		}
+
+		result.Rows = append(result.Rows, res)
+	}
+
+	if err := s.repo.MarkPayslipsPaid(ctx, payments); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// matchByAccount picks the payslip credited to an account, narrowed by amount
+// when the bank reports one. It returns nil unless exactly one payslip fits.
+func matchByAccount(candidates []*models.Payslip, amount *decimal.Decimal) *models.Payslip {
+	var match *models.Payslip
+	for _, candidate := range candidates {
+		if amount != nil && !candidate.NetSalary.Equal(*amount) {
+			continue
+		}
+		if match != nil {
+			return nil
+		}
+		match = candidate
+	}
+	return match
+}
+
+// ========================================
+// Bank File Template Service Methods
+// ========================================
+
+// ListBankFileTemplates retrieves the tenant's bank file templates.
+func (s *Service) ListBankFileTemplates(ctx context.Context, tenantID uuid.UUID) ([]models.BankFileTemplate, error) {
+	return s.repo.ListBankFileTemplates(ctx, tenantID)
+}
+
+// GetBankFileTemplate retrieves a bank file template by ID.
+func (s *Service) GetBankFileTemplate(ctx context.Context, tenantID, id uuid.UUID) (*models.BankFileTemplate, error) {
+	return s.repo.GetBankFileTemplate(ctx, tenantID, id)
+}
+
+// CreateBankFileTemplate creates a fixed-width bank file template.
+func (s *Service) CreateBankFileTemplate(ctx context.Context, dto BankFileTemplateDTO) (*models.BankFileTemplate, error) {
+	if err := normalizeBankFileTemplateDTO(&dto); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &models.BankFileTemplate{
+		TenantID:      dto.TenantID,
+		Name:          dto.Name,
+		Description:   dto.Description,
+		HeaderFields:  dto.HeaderFields,
+		DetailFields:  dto.DetailFields,
+		TrailerFields: dto.TrailerFields,
+		LineEnding:    dto.LineEnding,
+		FileExtension: dto.FileExtension,
+		IsActive:      dto.IsActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		CreatedBy:     dto.UpdatedBy,
+		UpdatedBy:     dto.UpdatedBy,
+	}
+
+	if err := s.repo.SaveBankFileTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateBankFileTemplate updates a bank file template.
+func (s *Service) UpdateBankFileTemplate(ctx context.Context, id uuid.UUID, dto BankFileTemplateDTO) (*models.BankFileTemplate, error) {
+	template, err := s.repo.GetBankFileTemplate(ctx, dto.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := normalizeBankFileTemplateDTO(&dto); err != nil {
+		return nil, err
+	}
+
+	template.Name = dto.Name
+	template.Description = dto.Description
+	template.HeaderFields = dto.HeaderFields
+	template.DetailFields = dto.DetailFields
+	template.TrailerFields = dto.TrailerFields
+	template.LineEnding = dto.LineEnding
+	template.FileExtension = dto.FileExtension
+	template.IsActive = dto.IsActive
+	template.UpdatedAt = time.Now()
+	template.UpdatedBy = dto.UpdatedBy
+
+	if err := s.repo.SaveBankFileTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteBankFileTemplate deletes a bank file template.
+func (s *Service) DeleteBankFileTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return s.repo.DeleteBankFileTemplate(ctx, tenantID, id)
+}
+
+// normalizeBankFileTemplateDTO applies defaults and validates a template.
+func normalizeBankFileTemplateDTO(dto *BankFileTemplateDTO) error {
+	dto.Name = strings.TrimSpace(dto.Name)
+	dto.LineEnding = strings.ToLower(strings.TrimSpace(dto.LineEnding))
+	dto.FileExtension = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(dto.FileExtension)), ".")
+	if dto.LineEnding == "" {
+		dto.LineEnding = "crlf"
+	}
+	if dto.FileExtension == "" {
+		dto.FileExtension = "txt"
+	}
+
+	if dto.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidBankFileTemplate)
+	}
+	if dto.LineEnding != "crlf" && dto.LineEnding != "lf" {
+		return fmt.Errorf("%w: line ending must be crlf or lf", ErrInvalidBankFileTemplate)
+	}
+	if len(dto.FileExtension) > 10 || nonAlphanumeric.MatchString(dto.FileExtension) {
+		return fmt.Errorf("%w: invalid file extension", ErrInvalidBankFileTemplate)
+	}
+	if len(dto.DetailFields) == 0 {
+		return fmt.Errorf("%w: at least one detail field is required", ErrInvalidBankFileTemplate)
+	}
+
+	if err := validateBankFileFields(dto.HeaderFields, batchFields); err != nil {
+		return err
+	}
+	if err := validateBankFileFields(dto.DetailFields, detailFields); err != nil {
+		return err
+	}
+	return validateBankFileFields(dto.TrailerFields, batchFields)
+}
+
+// layoutUsesField reports whether a bank CSV layout has a column for a field.
+func layoutUsesField(layout bankCSVLayout, field string) bool {
+	for _, col := range layout.Columns {
+		if col.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// templateUsesField reports whether any record of a template uses a field.
+func templateUsesField(template *models.BankFileTemplate, field string) bool {
+	for _, fields := range []models.BankFileFields{template.HeaderFields, template.DetailFields, template.TrailerFields} {
+		for _, f := range fields {
+			if f.Field == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// templateSlug turns a template name into a file name fragment.
+func templateSlug(name string) string {
+	slug := strings.Trim(strings.ToLower(nonAlphanumericRun.ReplaceAllString(name, "-")), "-")
+	if slug == "" {
+		return BankFileFormatTemplate
+	}
+	return slug
+}
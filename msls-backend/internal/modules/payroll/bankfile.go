@@ -0,0 +1,525 @@
+// Package payroll provides payroll processing functionality.
+package payroll
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// BankFileFormatTemplate selects a tenant-defined fixed-width template instead of a bank CSV layout.
+const BankFileFormatTemplate = "template"
+
+// rtgsThreshold is the smallest amount RTGS accepts; smaller transfers go by NEFT.
+var rtgsThreshold = decimal.NewFromInt(200000)
+
+// Payment modes.
+const (
+	PaymentModeNEFT = "NEFT"
+	PaymentModeRTGS = "RTGS"
+)
+
+// transferRecord is one salary payment in a bank file.
+type transferRecord struct {
+	Serial          int
+	PayslipID       uuid.UUID
+	StaffName       string
+	EmployeeCode    string
+	BeneficiaryName string
+	AccountNumber   string
+	IFSC            string
+	BankName        string
+	AccountType     string
+	Email           string
+	Amount          decimal.Decimal
+	PaymentMode     string
+	Reference       string
+	Narration       string
+}
+
+// transferBatch is the set of payments written to one bank file.
+type transferBatch struct {
+	Records        []transferRecord
+	DebitAccount   string
+	ValueDate      time.Time
+	BatchReference string
+	Total          decimal.Decimal
+}
+
+// bankCSVColumn maps a CSV column to a transfer field.
+type bankCSVColumn struct {
+	Header string
+	Field  string
+	Format string
+}
+
+// bankCSVLayout is a bank's bulk NEFT/RTGS upload layout.
+type bankCSVLayout struct {
+	Code    string
+	Name    string
+	Columns []bankCSVColumn
+}
+
+// bankCSVLayouts are the built-in bulk payment layouts, by bank code.
+var bankCSVLayouts = map[string]bankCSVLayout{
+	"hdfc": {
+		Code: "hdfc",
+		Name: "HDFC Bank (ENet bulk upload)",
+		Columns: []bankCSVColumn{
+			{Header: "Transaction Type", Field: "payment_mode", Format: "initial"},
+			{Header: "Beneficiary Code", Field: "employee_code"},
+			{Header: "Beneficiary Account Number", Field: "account_number"},
+			{Header: "Instrument Amount", Field: "amount"},
+			{Header: "Beneficiary Name", Field: "beneficiary_name"},
+			{Header: "Customer Reference Number", Field: "reference"},
+			{Header: "Payment Details 1", Field: "narration"},
+			{Header: "Chq / Trn Date", Field: "value_date", Format: "02/01/2006"},
+			{Header: "IFSC Code", Field: "ifsc"},
+			{Header: "Beneficiary Bank Name", Field: "bank_name"},
+			{Header: "Beneficiary Email ID", Field: "email"},
+		},
+	},
+	"icici": {
+		Code: "icici",
+		Name: "ICICI Bank (CIB bulk payments)",
+		Columns: []bankCSVColumn{
+			{Header: "Debit Ac No", Field: "debit_account"},
+			{Header: "Beneficiary Ac No", Field: "account_number"},
+			{Header: "Beneficiary Name", Field: "beneficiary_name"},
+			{Header: "Amt", Field: "amount"},
+			{Header: "Pay Mod", Field: "payment_mode"},
+			{Header: "Date", Field: "value_date", Format: "02-Jan-2006"},
+			{Header: "IFSC", Field: "ifsc"},
+			{Header: "Bene email ID", Field: "email"},
+			{Header: "Remarks", Field: "narration"},
+			{Header: "Reference No", Field: "reference"},
+		},
+	},
+	"sbi": {
+		Code: "sbi",
+		Name: "State Bank of India (CMP bulk upload)",
+		Columns: []bankCSVColumn{
+			{Header: "Beneficiary Name", Field: "beneficiary_name"},
+			{Header: "Beneficiary Account No", Field: "account_number"},
+			{Header: "IFSC", Field: "ifsc"},
+			{Header: "Transaction Type", Field: "payment_mode"},
+			{Header: "Debit Account No", Field: "debit_account"},
+			{Header: "Transaction Date", Field: "value_date", Format: "02-01-2006"},
+			{Header: "Amount", Field: "amount"},
+			{Header: "Currency", Field: "literal", Format: "INR"},
+			{Header: "Beneficiary Email ID", Field: "email"},
+			{Header: "Remarks", Field: "narration"},
+			{Header: "Customer Reference", Field: "reference"},
+		},
+	},
+	"axis": {
+		Code: "axis",
+		Name: "Axis Bank (Power Access bulk upload)",
+		Columns: []bankCSVColumn{
+			{Header: "Payment Method Name", Field: "payment_mode"},
+			{Header: "Payment Amount", Field: "amount"},
+			{Header: "Activation Date", Field: "value_date", Format: "02-01-2006"},
+			{Header: "Beneficiary Name", Field: "beneficiary_name"},
+			{Header: "Account No", Field: "account_number"},
+			{Header: "Debit Account No", Field: "debit_account"},
+			{Header: "CRN No", Field: "reference"},
+			{Header: "Receiver IFSC", Field: "ifsc"},
+			{Header: "Receiver A/c Type", Field: "account_type", Format: "code"},
+			{Header: "Remarks", Field: "narration"},
+			{Header: "Email ID", Field: "email"},
+		},
+	},
+	"kotak": {
+		Code: "kotak",
+		Name: "Kotak Mahindra Bank (CMS bulk upload)",
+		Columns: []bankCSVColumn{
+			{Header: "Payment Type", Field: "payment_mode"},
+			{Header: "Payment Ref No", Field: "reference"},
+			{Header: "Payment Date", Field: "value_date", Format: "02/01/2006"},
+			{Header: "Dr Ac No", Field: "debit_account"},
+			{Header: "Amount", Field: "amount"},
+			{Header: "Bene Code", Field: "employee_code"},
+			{Header: "Bene Name", Field: "beneficiary_name"},
+			{Header: "Bene Acc No", Field: "account_number"},
+			{Header: "IFSC Code", Field: "ifsc"},
+			{Header: "Bene Email", Field: "email"},
+			{Header: "Payment Narration", Field: "narration"},
+		},
+	},
+}
+
+// detailFields are the transfer fields available in payment records.
+var detailFields = map[string]bool{
+	"serial": true, "employee_code": true, "beneficiary_name": true, "account_number": true,
+	"ifsc": true, "bank_name": true, "account_type": true, "email": true, "amount": true,
+	"payment_mode": true, "reference": true, "narration": true, "value_date": true,
+	"debit_account": true, "literal": true,
+}
+
+// batchFields are the fields available in header and trailer records.
+var batchFields = map[string]bool{
+	"record_count": true, "total_amount": true, "value_date": true, "file_date": true,
+	"debit_account": true, "batch_reference": true, "literal": true,
+}
+
+// paymentModeFor returns the payment mode for an amount. An explicit mode wins
+// unless it is RTGS and the amount is below the RTGS minimum.
+func paymentModeFor(amount decimal.Decimal, requested string) string {
+	switch requested {
+	case PaymentModeNEFT:
+		return PaymentModeNEFT
+	case PaymentModeRTGS:
+		if amount.GreaterThanOrEqual(rtgsThreshold) {
+			return PaymentModeRTGS
+		}
+		return PaymentModeNEFT
+	}
+	if amount.GreaterThanOrEqual(rtgsThreshold) {
+		return PaymentModeRTGS
+	}
+	return PaymentModeNEFT
+}
+
+var (
+	nonAlphanumeric    = regexp.MustCompile(`[^A-Za-z0-9]`)
+	nonAlphanumericRun = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// transferReference returns the customer reference of a salary payment:
+// SAL, the pay period and the employee code, at most 20 characters.
+func transferReference(payRun *models.PayRun, employeeCode string) string {
+	code := strings.ToUpper(nonAlphanumeric.ReplaceAllString(employeeCode, ""))
+	if len(code) > 11 {
+		code = code[len(code)-11:]
+	}
+	return fmt.Sprintf("SAL%04d%02d%s", payRun.PayPeriodYear, payRun.PayPeriodMonth, code)
+}
+
+// recordValue returns a payment record field formatted for a bank file.
+func recordValue(field, format, literal string, rec *transferRecord, batch *transferBatch) string {
+	switch field {
+	case "serial":
+		return fmt.Sprintf("%d", rec.Serial)
+	case "employee_code":
+		return rec.EmployeeCode
+	case "beneficiary_name":
+		return rec.BeneficiaryName
+	case "account_number":
+		return rec.AccountNumber
+	case "ifsc":
+		return rec.IFSC
+	case "bank_name":
+		return rec.BankName
+	case "account_type":
+		if format == "code" {
+			if rec.AccountType == string(models.BankAccountCurrent) {
+				return "11"
+			}
+			return "10"
+		}
+		return rec.AccountType
+	case "email":
+		return rec.Email
+	case "amount":
+		return formatTransferAmount(rec.Amount, format)
+	case "payment_mode":
+		if format == "initial" {
+			return rec.PaymentMode[:1]
+		}
+		return rec.PaymentMode
+	case "reference":
+		return rec.Reference
+	case "narration":
+		return rec.Narration
+	}
+	return batchValue(field, format, literal, batch)
+}
+
+// batchValue returns a batch field formatted for a bank file.
+func batchValue(field, format, literal string, batch *transferBatch) string {
+	switch field {
+	case "record_count":
+		return fmt.Sprintf("%d", len(batch.Records))
+	case "total_amount":
+		return formatTransferAmount(batch.Total, format)
+	case "value_date":
+		return formatTransferDate(batch.ValueDate, format)
+	case "file_date":
+		return formatTransferDate(time.Now(), format)
+	case "debit_account":
+		return batch.DebitAccount
+	case "batch_reference":
+		return batch.BatchReference
+	case "literal":
+		if literal != "" {
+			return literal
+		}
+		return format
+	}
+	return ""
+}
+
+// formatTransferAmount formats an amount as rupees with two decimals or, with "paise", as whole paise.
+func formatTransferAmount(amount decimal.Decimal, format string) string {
+	if format == "paise" {
+		return amount.Shift(2).Round(0).String()
+	}
+	return amount.StringFixed(2)
+}
+
+// formatTransferDate formats a date with a Go layout, defaulting to DD/MM/YYYY.
+func formatTransferDate(date time.Time, layout string) string {
+	if layout == "" {
+		layout = "02/01/2006"
+	}
+	return date.Format(layout)
+}
+
+// renderBankCSV writes a batch in a bank's CSV layout.
+func renderBankCSV(layout bankCSVLayout, batch *transferBatch) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = true
+
+	header := make([]string, len(layout.Columns))
+	for i, col := range layout.Columns {
+		header[i] = col.Header
+	}
+	if err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("write bank file header: %w", err)
+	}
+
+	for i := range batch.Records {
+		row := make([]string, len(layout.Columns))
+		for j, col := range layout.Columns {
+			row[j] = recordValue(col.Field, col.Format, "", &batch.Records[i], batch)
+		}
+		if err := w.Write(row); err != nil {
+			return nil, fmt.Errorf("write bank file record: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("write bank file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderFixedWidth writes a batch using a fixed-width template.
+func renderFixedWidth(template *models.BankFileTemplate, batch *transferBatch) []byte {
+	newline := "\r\n"
+	if template.LineEnding == "lf" {
+		newline = "\n"
+	}
+
+	var buf bytes.Buffer
+	if len(template.HeaderFields) > 0 {
+		buf.WriteString(fixedWidthLine(template.HeaderFields, nil, batch))
+		buf.WriteString(newline)
+	}
+	for i := range batch.Records {
+		buf.WriteString(fixedWidthLine(template.DetailFields, &batch.Records[i], batch))
+		buf.WriteString(newline)
+	}
+	if len(template.TrailerFields) > 0 {
+		buf.WriteString(fixedWidthLine(template.TrailerFields, nil, batch))
+		buf.WriteString(newline)
+	}
+	return buf.Bytes()
+}
+
+// fixedWidthLine renders one fixed-width record. Header and trailer records have no payment record.
+func fixedWidthLine(fields models.BankFileFields, rec *transferRecord, batch *transferBatch) string {
+	var line strings.Builder
+	for _, f := range fields {
+		var value string
+		if rec != nil {
+			value = recordValue(f.Field, f.Format, f.Value, rec, batch)
+		} else {
+			value = batchValue(f.Field, f.Format, f.Value, batch)
+		}
+		line.WriteString(fitField(value, f))
+	}
+	return line.String()
+}
+
+// fitField pads or truncates a value to the field width.
+func fitField(value string, f models.BankFileField) string {
+	pad := " "
+	if f.Pad != "" {
+		pad = f.Pad
+	}
+
+	runes := []rune(value)
+	if len(runes) > f.Width {
+		if f.Align == "right" {
+			return string(runes[len(runes)-f.Width:])
+		}
+		return string(runes[:f.Width])
+	}
+
+	padding := strings.Repeat(pad, f.Width-len(runes))
+	if f.Align == "right" {
+		return padding + value
+	}
+	return value + padding
+}
+
+// validateBankFileFields checks that every field is known and fits a record.
+func validateBankFileFields(fields models.BankFileFields, allowed map[string]bool) error {
+	for _, f := range fields {
+		if !allowed[f.Field] {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidBankFileTemplate, f.Field)
+		}
+		if f.Width <= 0 || f.Width > 500 {
+			return fmt.Errorf("%w: field %q needs a width between 1 and 500", ErrInvalidBankFileTemplate, f.Field)
+		}
+		if f.Align != "" && f.Align != "left" && f.Align != "right" {
+			return fmt.Errorf("%w: field %q has invalid alignment %q", ErrInvalidBankFileTemplate, f.Field, f.Align)
+		}
+		if f.Pad != "" && utf8.RuneCountInString(f.Pad) != 1 {
+			return fmt.Errorf("%w: field %q padding must be a single character", ErrInvalidBankFileTemplate, f.Field)
+		}
+	}
+	return nil
+}
+
+// ========================================
+// Bank response files
+// ========================================
+
+// bankResponseRow is one payment status reported by the bank.
+type bankResponseRow struct {
+	Line          int
+	Reference     string
+	UTR           string
+	AccountNumber string
+	Amount        *decimal.Decimal
+	Success       bool
+	Status        string
+	Reason        string
+	PaymentDate   *time.Time
+}
+
+// bankResponseColumns maps normalised response file headers to fields.
+var bankResponseColumns = map[string]string{
+	"customerreferencenumber": "reference", "customerreferenceno": "reference", "customerreference": "reference",
+	"customerrefno": "reference",
+	"referenceno":   "reference", "reference": "reference", "paymentrefno": "reference",
+	"paymentreference": "reference", "crnno": "reference", "crn": "reference",
+	"clientreference": "reference", "transactionreference": "reference",
+	"utr": "utr", "utrno": "utr", "utrnumber": "utr", "bankreference": "utr", "bankreferenceno": "utr",
+	"status": "status", "transactionstatus": "status", "paymentstatus": "status",
+	"valuedate": "date", "paymentdate": "date", "transactiondate": "date", "date": "date",
+	"executiondate": "date", "chqtrndate": "date",
+	"reason": "reason", "remarks": "reason", "failurereason": "reason", "rejectionreason": "reason",
+	"errordescription": "reason", "statusdescription": "reason",
+	"beneficiaryaccountnumber": "account", "beneficiaryaccountno": "account", "beneficiaryacno": "account",
+	"accountno": "account", "beneaccno": "account", "beneaccountno": "account",
+	"amount": "amount", "instrumentamount": "amount", "amt": "amount", "paymentamount": "amount",
+	"transactionamount": "amount",
+}
+
+// successStatuses are the bank statuses that mean the salary was credited.
+var successStatuses = map[string]bool{
+	"success": true, "successful": true, "paid": true, "processed": true, "executed": true,
+	"completed": true, "credited": true, "settled": true,
+}
+
+// responseDateLayouts are the date formats banks use in response files.
+var responseDateLayouts = []string{
+	"02/01/2006", "02-01-2006", "2006-01-02", "02-Jan-2006", "02-Jan-06", "02012006", "02/01/06",
+}
+
+// parseBankResponse reads a bank response CSV. The header row identifies the
+// reference, UTR, status, date, reason, account and amount columns.
+func parseBankResponse(r io.Reader) ([]bankResponseRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBankResponse, err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		key := strings.ToLower(nonAlphanumeric.ReplaceAllString(strings.TrimPrefix(name, "\ufeff"), ""))
+		if field, ok := bankResponseColumns[key]; ok {
+			if _, seen := columns[field]; !seen {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["reference"]; !ok {
+		if _, ok := columns["account"]; !ok {
+			return nil, fmt.Errorf("%w: no reference or account number column", ErrInvalidBankResponse)
+		}
+	}
+
+	var rows []bankResponseRow
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidBankResponse, line, err)
+		}
+
+		value := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row := bankResponseRow{
+			Line:          line,
+			Reference:     value("reference"),
+			UTR:           value("utr"),
+			AccountNumber: value("account"),
+			Status:        value("status"),
+			Reason:        value("reason"),
+		}
+		if row.Reference == "" && row.AccountNumber == "" {
+			continue
+		}
+
+		status := strings.ToLower(row.Status)
+		row.Success = successStatuses[status] || (status == "" && row.UTR != "")
+
+		if amount := value("amount"); amount != "" {
+			if parsed, err := decimal.NewFromString(strings.ReplaceAll(amount, ",", "")); err == nil {
+				row.Amount = &parsed
+			}
+		}
+		if date := value("date"); date != "" {
+			for _, layout := range responseDateLayouts {
+				if parsed, err := time.Parse(layout, date); err == nil {
+					row.PaymentDate = &parsed
+					break
+				}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,179 @@
+package payroll
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func testBatch() *transferBatch {
+	return &transferBatch{
+		DebitAccount:   "50200012345678",
+		ValueDate:      time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		BatchReference: "SAL202603",
+		Total:          decimal.RequireFromString("262500.50"),
+		Records: []transferRecord{
+			{
+				Serial: 1, PayslipID: uuid.New(), EmployeeCode: "EMP001", BeneficiaryName: "Asha Rao",
+				AccountNumber: "123456789012", IFSC: "HDFC0001234", BankName: "HDFC Bank", AccountType: "savings",
+				Email: "asha@school.test", Amount: decimal.RequireFromString("42500.50"), PaymentMode: PaymentModeNEFT,
+				Reference: "SAL202603EMP001", Narration: "Salary March 2026",
+			},
+			{
+				Serial: 2, PayslipID: uuid.New(), EmployeeCode: "EMP002", BeneficiaryName: "Ravi, Kumar",
+				AccountNumber: "987654321098", IFSC: "SBIN0004321", BankName: "SBI", AccountType: "current",
+				Amount: decimal.NewFromInt(220000), PaymentMode: PaymentModeRTGS,
+				Reference: "SAL202603EMP002", Narration: "Salary March 2026",
+			},
+		},
+	}
+}
+
+func TestPaymentModeFor(t *testing.T) {
+	small := decimal.NewFromInt(199999)
+	large := decimal.NewFromInt(200000)
+
+	assert.Equal(t, PaymentModeNEFT, paymentModeFor(small, ""))
+	assert.Equal(t, PaymentModeRTGS, paymentModeFor(large, ""))
+	assert.Equal(t, PaymentModeNEFT, paymentModeFor(large, PaymentModeNEFT))
+	assert.Equal(t, PaymentModeNEFT, paymentModeFor(small, PaymentModeRTGS))
+}
+
+func TestTransferReference(t *testing.T) {
+	payRun := &models.PayRun{PayPeriodMonth: 3, PayPeriodYear: 2026}
+
+	assert.Equal(t, "SAL202603EMP001", transferReference(payRun, "emp-001"))
+	ref := transferReference(payRun, "BRANCH-NORTH-EMP-000123")
+	assert.Len(t, ref, 20)
+	assert.True(t, strings.HasSuffix(ref, "EMP000123"))
+}
+
+func TestRenderBankCSV(t *testing.T) {
+	content, err := renderBankCSV(bankCSVLayouts["hdfc"], testBatch())
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\r\n"), "\r\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "Transaction Type,Beneficiary Code,Beneficiary Account Number"))
+	assert.Equal(t, "N,EMP001,123456789012,42500.50,Asha Rao,SAL202603EMP001,Salary March 2026,31/03/2026,HDFC0001234,HDFC Bank,asha@school.test", lines[1])
+	assert.Contains(t, lines[2], `R,EMP002,987654321098,220000.00,"Ravi, Kumar"`)
+}
+
+func TestRenderFixedWidth(t *testing.T) {
+	template := &models.BankFileTemplate{
+		LineEnding: "lf",
+		HeaderFields: models.BankFileFields{
+			{Field: "literal", Value: "H", Width: 1},
+			{Field: "value_date", Format: "20060102", Width: 8},
+			{Field: "record_count", Width: 5, Align: "right", Pad: "0"},
+		},
+		DetailFields: models.BankFileFields{
+			{Field: "serial", Width: 3, Align: "right", Pad: "0"},
+			{Field: "beneficiary_name", Width: 8},
+			{Field: "amount", Format: "paise", Width: 10, Align: "right", Pad: "0"},
+		},
+		TrailerFields: models.BankFileFields{
+			{Field: "total_amount", Width: 12, Align: "right"},
+		},
+	}
+
+	content := string(renderFixedWidth(template, testBatch()))
+	assert.Equal(t, "H2026033100002\n001Asha Rao0004250050\n002Ravi, Ku0022000000\n   262500.50\n", content)
+}
+
+func TestValidateBankFileFields(t *testing.T) {
+	assert.NoError(t, validateBankFileFields(models.BankFileFields{{Field: "amount", Width: 12}}, detailFields))
+
+	err := validateBankFileFields(models.BankFileFields{{Field: "password", Width: 12}}, detailFields)
+	assert.ErrorIs(t, err, ErrInvalidBankFileTemplate)
+
+	err = validateBankFileFields(models.BankFileFields{{Field: "amount", Width: 12}}, batchFields)
+	assert.ErrorIs(t, err, ErrInvalidBankFileTemplate)
+
+	err = validateBankFileFields(models.BankFileFields{{Field: "amount", Width: 0}}, detailFields)
+	assert.ErrorIs(t, err, ErrInvalidBankFileTemplate)
+
+	err = validateBankFileFields(models.BankFileFields{{Field: "amount", Width: 5, Pad: "00"}}, detailFields)
+	assert.ErrorIs(t, err, ErrInvalidBankFileTemplate)
+}
+
+func TestParseBankResponse(t *testing.T) {
+	file := "Customer Reference No,UTR No,Status,Value Date,Reason,Amount\r\n" +
+		"SAL202603EMP001,HDFCN26090123456,Success,31/03/2026,,\"42,500.50\"\r\n" +
+		"SAL202603EMP002,,Rejected,31/03/2026,Invalid account,220000\r\n" +
+		",,,,,\r\n"
+
+	rows, err := parseBankResponse(strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.True(t, rows[0].Success)
+	assert.Equal(t, "HDFCN26090123456", rows[0].UTR)
+	require.NotNil(t, rows[0].Amount)
+	assert.True(t, rows[0].Amount.Equal(decimal.RequireFromString("42500.50")))
+	require.NotNil(t, rows[0].PaymentDate)
+	assert.Equal(t, time.March, rows[0].PaymentDate.Month())
+
+	assert.False(t, rows[1].Success)
+	assert.Equal(t, "Invalid account", rows[1].Reason)
+
+	_, err = parseBankResponse(strings.NewReader("Status,Amount\r\nSuccess,100\r\n"))
+	assert.ErrorIs(t, err, ErrInvalidBankResponse)
+}
+
+func TestMatchByAccount(t *testing.T) {
+	salary := &models.Payslip{ID: uuid.New(), NetSalary: decimal.RequireFromString("42500.50")}
+	arrears := &models.Payslip{ID: uuid.New(), NetSalary: decimal.RequireFromString("8000")}
+	amount := decimal.RequireFromString("8000")
+
+	assert.Equal(t, salary, matchByAccount([]*models.Payslip{salary}, nil))
+	assert.Equal(t, arrears, matchByAccount([]*models.Payslip{salary, arrears}, &amount))
+	assert.Nil(t, matchByAccount([]*models.Payslip{salary, arrears}, nil), "ambiguous without an amount")
+	assert.Nil(t, matchByAccount([]*models.Payslip{salary}, &amount))
+	assert.Nil(t, matchByAccount(nil, nil))
+}
+
+func TestAccountNumberEncryption(t *testing.T) {
+	service := NewService(nil, "test-secret")
+
+	encrypted, err := service.encryptAccountNumber("123456789012")
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "123456789012")
+
+	decrypted, err := service.decryptAccountNumber(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "123456789012", decrypted)
+
+	other := NewService(nil, "another-secret")
+	_, err = other.decryptAccountNumber(encrypted)
+	assert.Error(t, err)
+	assert.NotEqual(t, service.hashAccountNumber("123456789012"), other.hashAccountNumber("123456789012"))
+
+	// The hash is not keyed with the encryption key
+	assert.NotEqual(t, service.encryptionKey, service.hashKey)
+
+	assert.Equal(t, "XXXXXX9012", maskAccountNumber("123456789012"))
+}
+
+func TestNormalizeBankAccountDTO(t *testing.T) {
+	dto := BankAccountDTO{AccountHolderName: " Asha Rao ", BankName: "HDFC Bank", IFSCCode: "hdfc0001234", AccountNumber: "1234 5678 9012"}
+	require.NoError(t, normalizeBankAccountDTO(&dto))
+	assert.Equal(t, "HDFC0001234", dto.IFSCCode)
+	assert.Equal(t, "123456789012", dto.AccountNumber)
+	assert.Equal(t, models.BankAccountSavings, dto.AccountType)
+
+	dto.IFSCCode = "HDFC1001234"
+	assert.ErrorIs(t, normalizeBankAccountDTO(&dto), ErrInvalidIFSC)
+
+	dto.IFSCCode = "HDFC0001234"
+	dto.AccountNumber = "12345"
+	assert.ErrorIs(t, normalizeBankAccountDTO(&dto), ErrInvalidAccountNumber)
+}
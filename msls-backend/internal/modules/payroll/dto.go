@@ -281,6 +281,7 @@ func ToPayslipResponses(payslips []models.Payslip) []PayslipResponse {
 // ========================================
 
 // BankTransferItem represents a single bank transfer entry for export.
+// The account number is masked; bank files carry the full number.
 type BankTransferItem struct {
 	StaffName     string `json:"staffName"`
 	EmployeeCode  string `json:"employeeCode"`
@@ -288,14 +289,237 @@ type BankTransferItem struct {
 	BankAccount   string `json:"bankAccount"`
 	IFSC          string `json:"ifsc"`
 	NetAmount     string `json:"netAmount"`
+	PaymentMode   string `json:"paymentMode"`
+	Reference     string `json:"reference"`
+}
+
+// BankTransferSkipped represents a payslip left out of bank transfers.
+type BankTransferSkipped struct {
+	PayslipID    string `json:"payslipId"`
+	StaffName    string `json:"staffName"`
+	EmployeeCode string `json:"employeeCode"`
+	NetAmount    string `json:"netAmount"`
+	Reason       string `json:"reason"`
 }
 
 // BankTransferExport represents the bank transfer export data.
 type BankTransferExport struct {
-	PayPeriod    string             `json:"payPeriod"`
-	TotalRecords int                `json:"totalRecords"`
-	TotalAmount  string             `json:"totalAmount"`
-	Items        []BankTransferItem `json:"items"`
+	PayPeriod    string                `json:"payPeriod"`
+	TotalRecords int                   `json:"totalRecords"`
+	TotalAmount  string                `json:"totalAmount"`
+	Items        []BankTransferItem    `json:"items"`
+	Skipped      []BankTransferSkipped `json:"skipped"`
+}
+
+// BankFileDTO represents a request to generate a bank transfer file.
+type BankFileDTO struct {
+	TenantID     uuid.UUID
+	PayRunID     uuid.UUID
+	Format       string
+	TemplateID   *uuid.UUID
+	DebitAccount string
+	ValueDate    time.Time
+	PaymentMode  string
+}
+
+// BankFile represents a generated bank transfer file.
+type BankFile struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+	Records     int
+	TotalAmount decimal.Decimal
+	Skipped     []BankTransferSkipped
+}
+
+// BankFormatResponse represents a supported bank file format.
+type BankFormatResponse struct {
+	Code         string   `json:"code"`
+	Name         string   `json:"name"`
+	Columns      []string `json:"columns,omitempty"`
+	TemplateID   string   `json:"templateId,omitempty"`
+	FixedWidth   bool     `json:"fixedWidth"`
+	DebitAccount bool     `json:"requiresDebitAccount"`
+}
+
+// PayslipPayment records a salary payment confirmed by the bank.
+type PayslipPayment struct {
+	PayslipID   uuid.UUID
+	PaymentDate time.Time
+	Reference   string
+}
+
+// BankResponseRowResult represents the outcome of one bank response file row.
+type BankResponseRowResult struct {
+	Line         int    `json:"line"`
+	Reference    string `json:"reference,omitempty"`
+	EmployeeCode string `json:"employeeCode,omitempty"`
+	UTR          string `json:"utr,omitempty"`
+	Result       string `json:"result"`
+	Message      string `json:"message,omitempty"`
+}
+
+// BankResponseImportResult summarises a bank response file import.
+type BankResponseImportResult struct {
+	TotalRows   int                     `json:"totalRows"`
+	Paid        int                     `json:"paid"`
+	AlreadyPaid int                     `json:"alreadyPaid"`
+	Failed      int                     `json:"failed"`
+	Unmatched   int                     `json:"unmatched"`
+	Rows        []BankResponseRowResult `json:"rows"`
+}
+
+// Bank response row results.
+const (
+	BankResponsePaid        = "paid"
+	BankResponseAlreadyPaid = "already_paid"
+	BankResponseFailed      = "failed"
+	BankResponseUnmatched   = "unmatched"
+)
+
+// ========================================
+// Staff Bank Account DTOs
+// ========================================
+
+// BankAccountDTO represents a request to create or update a staff bank account.
+type BankAccountDTO struct {
+	TenantID          uuid.UUID
+	StaffID           uuid.UUID
+	AccountHolderName string
+	BankName          string
+	BranchName        *string
+	IFSCCode          string
+	AccountType       models.BankAccountType
+	AccountNumber     string
+	IsPrimary         bool
+	UpdatedBy         *uuid.UUID
+}
+
+// VerifyBankAccountDTO represents a request to verify or reject a staff bank account.
+type VerifyBankAccountDTO struct {
+	TenantID   uuid.UUID
+	StaffID    uuid.UUID
+	AccountID  uuid.UUID
+	Status     models.BankVerificationStatus
+	Note       *string
+	VerifiedBy uuid.UUID
+}
+
+// BankAccountResponse represents a staff bank account in API responses.
+type BankAccountResponse struct {
+	ID                 string `json:"id"`
+	StaffID            string `json:"staffId"`
+	AccountHolderName  string `json:"accountHolderName"`
+	BankName           string `json:"bankName"`
+	BranchName         string `json:"branchName,omitempty"`
+	IFSCCode           string `json:"ifscCode"`
+	AccountType        string `json:"accountType"`
+	AccountNumber      string `json:"accountNumber"`
+	IsPrimary          bool   `json:"isPrimary"`
+	VerificationStatus string `json:"verificationStatus"`
+	VerifiedAt         string `json:"verifiedAt,omitempty"`
+	VerifiedByName     string `json:"verifiedByName,omitempty"`
+	VerificationNote   string `json:"verificationNote,omitempty"`
+	CreatedAt          string `json:"createdAt"`
+	UpdatedAt          string `json:"updatedAt"`
+}
+
+// ToBankAccountResponse converts a StaffBankAccount model to a response with a masked account number.
+func ToBankAccountResponse(a *models.StaffBankAccount) BankAccountResponse {
+	resp := BankAccountResponse{
+		ID:                 a.ID.String(),
+		StaffID:            a.StaffID.String(),
+		AccountHolderName:  a.AccountHolderName,
+		BankName:           a.BankName,
+		IFSCCode:           a.IFSCCode,
+		AccountType:        string(a.AccountType),
+		AccountNumber:      a.MaskedAccountNumber(),
+		IsPrimary:          a.IsPrimary,
+		VerificationStatus: string(a.VerificationStatus),
+		CreatedAt:          a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          a.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if a.BranchName != nil {
+		resp.BranchName = *a.BranchName
+	}
+
+	if a.VerifiedAt != nil {
+		resp.VerifiedAt = a.VerifiedAt.Format(time.RFC3339)
+	}
+
+	if a.Verifier != nil {
+		resp.VerifiedByName = a.Verifier.FullName()
+	}
+
+	if a.VerificationNote != nil {
+		resp.VerificationNote = *a.VerificationNote
+	}
+
+	return resp
+}
+
+// ========================================
+// Bank File Template DTOs
+// ========================================
+
+// BankFileTemplateDTO represents a request to create or update a bank file template.
+type BankFileTemplateDTO struct {
+	TenantID      uuid.UUID
+	Name          string
+	Description   *string
+	HeaderFields  models.BankFileFields
+	DetailFields  models.BankFileFields
+	TrailerFields models.BankFileFields
+	LineEnding    string
+	FileExtension string
+	IsActive      bool
+	UpdatedBy     *uuid.UUID
+}
+
+// BankFileTemplateResponse represents a bank file template in API responses.
+type BankFileTemplateResponse struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description,omitempty"`
+	HeaderFields  []models.BankFileField `json:"headerFields"`
+	DetailFields  []models.BankFileField `json:"detailFields"`
+	TrailerFields []models.BankFileField `json:"trailerFields"`
+	LineEnding    string                 `json:"lineEnding"`
+	FileExtension string                 `json:"fileExtension"`
+	IsActive      bool                   `json:"isActive"`
+	CreatedAt     string                 `json:"createdAt"`
+	UpdatedAt     string                 `json:"updatedAt"`
+}
+
+// ToBankFileTemplateResponse converts a BankFileTemplate model to a response.
+func ToBankFileTemplateResponse(t *models.BankFileTemplate) BankFileTemplateResponse {
+	resp := BankFileTemplateResponse{
+		ID:            t.ID.String(),
+		Name:          t.Name,
+		HeaderFields:  nonNilFields(t.HeaderFields),
+		DetailFields:  nonNilFields(t.DetailFields),
+		TrailerFields: nonNilFields(t.TrailerFields),
+		LineEnding:    t.LineEnding,
+		FileExtension: t.FileExtension,
+		IsActive:      t.IsActive,
+		CreatedAt:     t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     t.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if t.Description != nil {
+		resp.Description = *t.Description
+	}
+
+	return resp
+}
+
+// nonNilFields returns template fields as a non-nil slice for JSON responses.
+func nonNilFields(fields models.BankFileFields) []models.BankFileField {
+	if fields == nil {
+		return []models.BankFileField{}
+	}
+	return fields
 }
 
 // ========================================
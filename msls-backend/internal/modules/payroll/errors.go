@@ -27,3 +27,27 @@ var (
 	ErrInvalidMonth     = errors.New("month must be between 1 and 12")
 	ErrInvalidYear      = errors.New("invalid year")
 )
+
+// Bank account errors.
+var (
+	ErrStaffNotFound             = errors.New("staff not found")
+	ErrBankAccountNotFound       = errors.New("bank account not found")
+	ErrDuplicateBankAccount      = errors.New("bank account already exists for this staff member")
+	ErrAccountHolderRequired     = errors.New("account holder name is required")
+	ErrBankNameRequired          = errors.New("bank name is required")
+	ErrInvalidIFSC               = errors.New("invalid IFSC code")
+	ErrInvalidAccountNumber      = errors.New("account number must be 9 to 18 digits")
+	ErrInvalidAccountType        = errors.New("invalid bank account type")
+	ErrInvalidVerificationStatus = errors.New("verification status must be verified or rejected")
+)
+
+// Bank file errors.
+var (
+	ErrUnsupportedBankFormat     = errors.New("unsupported bank file format")
+	ErrBankFileTemplateNotFound  = errors.New("bank file template not found")
+	ErrInvalidBankFileTemplate   = errors.New("invalid bank file template")
+	ErrDuplicateBankFileTemplate = errors.New("bank file template with this name already exists")
+	ErrDebitAccountRequired      = errors.New("debit account is required for this bank format")
+	ErrNoPayableStaff            = errors.New("no payslips with a verified bank account to pay")
+	ErrInvalidBankResponse       = errors.New("invalid bank response file")
+)
@@ -567,6 +567,7 @@ func (h *Handler) RegisterRoutes(rg *gin.RouterGroup) {
 		payRunsFinalize.Use(middleware.PermissionRequired("payroll.finalize"))
 		{
 			payRunsFinalize.POST("/:id/finalize", h.FinalizePayRun)
+			payRunsFinalize.POST("/:id/bank-response", h.ImportBankResponse)
 		}
 
 		// Delete operations - require payroll.delete permission
@@ -581,9 +582,21 @@ func (h *Handler) RegisterRoutes(rg *gin.RouterGroup) {
 		payRunsExport.Use(middleware.PermissionRequired("payroll.export"))
 		{
 			payRunsExport.GET("/:id/export", h.ExportBankTransfer)
+			payRunsExport.POST("/:id/bank-file", h.GenerateBankFile)
 		}
 	}
 
+	// Bank file formats and templates - require payroll.export permission
+	bankFiles := rg.Group("/payroll")
+	bankFiles.Use(middleware.PermissionRequired("payroll.export"))
+	{
+		bankFiles.GET("/bank-formats", h.ListBankFormats)
+		bankFiles.GET("/bank-templates", h.ListBankFileTemplates)
+		bankFiles.POST("/bank-templates", h.CreateBankFileTemplate)
+		bankFiles.PUT("/bank-templates/:templateId", h.UpdateBankFileTemplate)
+		bankFiles.DELETE("/bank-templates/:templateId", h.DeleteBankFileTemplate)
+	}
+
 	// Payslips
 	payslips := rg.Group("/payroll/payslips")
 	{
@@ -608,3 +621,12 @@ func (h *Handler) RegisterRoutes(rg *gin.RouterGroup) {
 func (h *Handler) RegisterStaffPayslipRoutes(staffGroup *gin.RouterGroup) {
 	staffGroup.GET("/:id/payslips", h.GetStaffPayslipHistory)
 }
+
+// RegisterStaffBankAccountRoutes registers staff bank account routes.
+func (h *Handler) RegisterStaffBankAccountRoutes(staffGroup *gin.RouterGroup) {
+	staffGroup.GET("/:id/bank-accounts", middleware.PermissionRequired("staff_bank.view"), h.ListStaffBankAccounts)
+	staffGroup.POST("/:id/bank-accounts", middleware.PermissionRequired("staff_bank.manage"), h.CreateStaffBankAccount)
+	staffGroup.PUT("/:id/bank-accounts/:accountId", middleware.PermissionRequired("staff_bank.manage"), h.UpdateStaffBankAccount)
+	staffGroup.DELETE("/:id/bank-accounts/:accountId", middleware.PermissionRequired("staff_bank.manage"), h.DeleteStaffBankAccount)
+	staffGroup.POST("/:id/bank-accounts/:accountId/verify", middleware.PermissionRequired("staff_bank.verify"), h.VerifyStaffBankAccount)
+}
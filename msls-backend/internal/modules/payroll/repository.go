@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -291,6 +292,7 @@ func (r *Repository) GetStaffWithActiveSalary(ctx context.Context, tenantID uuid
 	query := r.db.WithContext(ctx).
 		Preload("Department").
 		Preload("Designation").
+		Preload("Shift").
 		Where("tenant_id = ? AND status = ?", tenantID, models.StaffStatusActive)
 
 	if branchID != nil {
@@ -370,6 +372,202 @@ func (r *Repository) GetDepartmentSummary(ctx context.Context, tenantID, payRunI
 	return items, nil
 }
 
+// ========================================
+// Staff Bank Account Repository Methods
+// ========================================
+
+// GetStaff retrieves a staff member by ID.
+func (r *Repository) GetStaff(ctx context.Context, tenantID, staffID uuid.UUID) (*models.Staff, error) {
+	var staff models.Staff
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, staffID).
+		First(&staff).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStaffNotFound
+		}
+		return nil, fmt.Errorf("get staff: %w", err)
+	}
+	return &staff, nil
+}
+
+// ListStaffBankAccounts retrieves the bank accounts of a staff member, primary first.
+func (r *Repository) ListStaffBankAccounts(ctx context.Context, tenantID, staffID uuid.UUID) ([]models.StaffBankAccount, error) {
+	var accounts []models.StaffBankAccount
+	err := r.db.WithContext(ctx).
+		Preload("Verifier").
+		Where("tenant_id = ? AND staff_id = ?", tenantID, staffID).
+		Order("is_primary DESC, created_at ASC").
+		Find(&accounts).Error
+	if err != nil {
+		return nil, fmt.Errorf("list staff bank accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// GetStaffBankAccount retrieves a bank account of a staff member.
+func (r *Repository) GetStaffBankAccount(ctx context.Context, tenantID, staffID, id uuid.UUID) (*models.StaffBankAccount, error) {
+	var account models.StaffBankAccount
+	err := r.db.WithContext(ctx).
+		Preload("Verifier").
+		Where("tenant_id = ? AND staff_id = ? AND id = ?", tenantID, staffID, id).
+		First(&account).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBankAccountNotFound
+		}
+		return nil, fmt.Errorf("get staff bank account: %w", err)
+	}
+	return &account, nil
+}
+
+// CountStaffBankAccounts counts the bank accounts of a staff member.
+func (r *Repository) CountStaffBankAccounts(ctx context.Context, tenantID, staffID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.StaffBankAccount{}).
+		Where("tenant_id = ? AND staff_id = ?", tenantID, staffID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("count staff bank accounts: %w", err)
+	}
+	return count, nil
+}
+
+// SaveStaffBankAccount creates or updates a bank account. A primary account
+// replaces the staff member's previous primary account.
+func (r *Repository) SaveStaffBankAccount(ctx context.Context, account *models.StaffBankAccount) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if account.IsPrimary {
+			if err := tx.Model(&models.StaffBankAccount{}).
+				Where("tenant_id = ? AND staff_id = ? AND id <> ? AND is_primary = ?", account.TenantID, account.StaffID, account.ID, true).
+				Update("is_primary", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(account).Error
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "uniq_staff_bank_accounts_number") {
+			return ErrDuplicateBankAccount
+		}
+		return fmt.Errorf("save staff bank account: %w", err)
+	}
+	return nil
+}
+
+// DeleteStaffBankAccount deletes a bank account of a staff member.
+func (r *Repository) DeleteStaffBankAccount(ctx context.Context, tenantID, staffID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND staff_id = ? AND id = ?", tenantID, staffID, id).
+		Delete(&models.StaffBankAccount{})
+	if result.Error != nil {
+		return fmt.Errorf("delete staff bank account: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrBankAccountNotFound
+	}
+	return nil
+}
+
+// GetPrimaryBankAccounts retrieves the primary bank accounts of staff members, by staff ID.
+func (r *Repository) GetPrimaryBankAccounts(ctx context.Context, tenantID uuid.UUID, staffIDs []uuid.UUID) (map[uuid.UUID]*models.StaffBankAccount, error) {
+	accounts := make(map[uuid.UUID]*models.StaffBankAccount)
+	if len(staffIDs) == 0 {
+		return accounts, nil
+	}
+
+	var list []models.StaffBankAccount
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND staff_id IN ? AND is_primary = ?", tenantID, staffIDs, true).
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("get primary bank accounts: %w", err)
+	}
+
+	for i := range list {
+		accounts[list[i].StaffID] = &list[i]
+	}
+	return accounts, nil
+}
+
+// ========================================
+// Bank File Template Repository Methods
+// ========================================
+
+// ListBankFileTemplates retrieves the bank file templates of a tenant.
+func (r *Repository) ListBankFileTemplates(ctx context.Context, tenantID uuid.UUID) ([]models.BankFileTemplate, error) {
+	var templates []models.BankFileTemplate
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("list bank file templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetBankFileTemplate retrieves a bank file template by ID.
+func (r *Repository) GetBankFileTemplate(ctx context.Context, tenantID, id uuid.UUID) (*models.BankFileTemplate, error) {
+	var template models.BankFileTemplate
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBankFileTemplateNotFound
+		}
+		return nil, fmt.Errorf("get bank file template: %w", err)
+	}
+	return &template, nil
+}
+
+// SaveBankFileTemplate creates or updates a bank file template.
+func (r *Repository) SaveBankFileTemplate(ctx context.Context, template *models.BankFileTemplate) error {
+	if err := r.db.WithContext(ctx).Save(template).Error; err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "uniq_bank_file_templates_name") {
+			return ErrDuplicateBankFileTemplate
+		}
+		return fmt.Errorf("save bank file template: %w", err)
+	}
+	return nil
+}
+
+// DeleteBankFileTemplate deletes a bank file template.
+func (r *Repository) DeleteBankFileTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		Delete(&models.BankFileTemplate{})
+	if result.Error != nil {
+		return fmt.Errorf("delete bank file template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrBankFileTemplateNotFound
+	}
+	return nil
+}
+
+// MarkPayslipsPaid marks payslips paid with their payment date and reference.
+func (r *Repository) MarkPayslipsPaid(ctx context.Context, payments []PayslipPayment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, payment := range payments {
+			if err := tx.Model(&models.Payslip{}).
+				Where("id = ?", payment.PayslipID).
+				Updates(map[string]interface{}{
+					"status":            models.PayslipStatusPaid,
+					"payment_date":      payment.PaymentDate,
+					"payment_reference": payment.Reference,
+					"updated_at":        now,
+				}).Error; err != nil {
+				return fmt.Errorf("mark payslip paid: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
 // DB returns the underlying database connection.
 func (r *Repository) DB() *gorm.DB {
 	return r.db
@@ -8,17 +8,26 @@ import (
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 
+	"msls-backend/internal/pkg/crypto"
 	"msls-backend/internal/pkg/database/models"
 )
 
 // Service provides business logic for payroll operations.
 type Service struct {
-	repo *Repository
+	repo          *Repository
+	encryptionKey []byte
+	hashKey       []byte
 }
 
-// NewService creates a new payroll service.
-func NewService(repo *Repository) *Service {
-	return &Service{repo: repo}
+// NewService creates a new payroll service. Staff bank account numbers are
+// encrypted, and hashed for duplicate detection, with separate keys derived
+// from the data encryption key.
+func NewService(repo *Repository, dataKey string) *Service {
+	return &Service{
+		repo:          repo,
+		encryptionKey: crypto.DeriveKey(dataKey, "payroll-bank-account"),
+		hashKey:       crypto.DeriveKey(dataKey, "payroll-bank-account-hash"),
+	}
 }
 
 // ========================================
@@ -126,9 +135,6 @@ func (s *Service) CalculatePayroll(ctx context.Context, tenantID, payRunID uuid.
 		return nil, ErrNoStaffForPayroll
 	}
 
-	// Calculate working days for the month
-	workingDays := s.getWorkingDaysInMonth(payRun.PayPeriodYear, payRun.PayPeriodMonth)
-
 	var payslips []models.Payslip
 	totalGross := decimal.Zero
 	totalDeductions := decimal.Zero
@@ -144,6 +150,9 @@ func (s *Service) CalculatePayroll(ctx context.Context, tenantID, payRunID uuid.
 			continue // Skip staff without salary
 		}
 
+		// Calculate working days for the month on the staff member's shift
+		workingDays := staff.Shift.WorkingDaysInMonth(payRun.PayPeriodYear, payRun.PayPeriodMonth)
+
 		// Calculate payslip for this staff
 		payslip := s.calculatePayslip(payRun, &staff, salary, workingDays)
 		payslips = append(payslips, payslip)
@@ -337,42 +346,38 @@ func (s *Service) AdjustPayslip(ctx context.Context, tenantID, payslipID uuid.UU
 // ========================================
 
 // GetBankTransferExport generates bank transfer export data.
+// Payslips without a verified primary bank account are listed as skipped.
 func (s *Service) GetBankTransferExport(ctx context.Context, tenantID, payRunID uuid.UUID) (*BankTransferExport, error) {
 	payRun, err := s.repo.GetPayRunByID(ctx, tenantID, payRunID)
 	if err != nil {
 		return nil, err
 	}
 
-	payslips, _, err := s.repo.ListPayslipsByPayRun(ctx, tenantID, payRunID)
+	batch, skipped, err := s.buildTransferBatch(ctx, payRun, "")
 	if err != nil {
 		return nil, err
 	}
 
-	var items []BankTransferItem
-	totalAmount := decimal.Zero
-
-	for _, ps := range payslips {
-		if ps.Staff == nil {
-			continue
-		}
-
-		item := BankTransferItem{
-			StaffName:    ps.Staff.FullName(),
-			EmployeeCode: ps.Staff.EmployeeID,
-			BankName:     "", // Bank fields not yet implemented in Staff model
-			BankAccount:  "",
-			IFSC:         "",
-			NetAmount:    ps.NetSalary.StringFixed(2),
-		}
-		items = append(items, item)
-		totalAmount = totalAmount.Add(ps.NetSalary)
+	items := make([]BankTransferItem, 0, len(batch.Records))
+	for _, rec := range batch.Records {
+		items = append(items, BankTransferItem{
+			StaffName:    rec.StaffName,
+			EmployeeCode: rec.EmployeeCode,
+			BankName:     rec.BankName,
+			BankAccount:  maskAccountNumber(rec.AccountNumber),
+			IFSC:         rec.IFSC,
+			NetAmount:    rec.Amount.StringFixed(2),
+			PaymentMode:  rec.PaymentMode,
+			Reference:    rec.Reference,
+		})
 	}
 
 	return &BankTransferExport{
 		PayPeriod:    getMonthName(payRun.PayPeriodMonth) + " " + formatYear(payRun.PayPeriodYear),
 		TotalRecords: len(items),
-		TotalAmount:  totalAmount.StringFixed(2),
+		TotalAmount:  batch.Total.StringFixed(2),
 		Items:        items,
+		Skipped:      skipped,
 	}, nil
 }
 
@@ -456,22 +461,3 @@ func (s *Service) calculatePayslip(payRun *models.PayRun, staff *models.Staff, s
 
 	return payslip
 }
-
-// getWorkingDaysInMonth calculates working days in a month (excluding weekends).
-func (s *Service) getWorkingDaysInMonth(year, month int) int {
-	// Get the first day of the month
-	firstDay := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	// Get the last day of the month
-	lastDay := firstDay.AddDate(0, 1, -1)
-
-	workingDays := 0
-	for d := firstDay; !d.After(lastDay); d = d.AddDate(0, 0, 1) {
-		// Count weekdays (Monday to Saturday in Indian context)
-		if d.Weekday() != time.Sunday {
-			workingDays++
-		}
-	}
-
-	return workingDays
-}
-
@@ -366,23 +366,28 @@ func (r *Repository) ReplaceStructureComponents(ctx context.Context, structureID
 // CreateStaffSalary creates a new staff salary assignment.
 func (r *Repository) CreateStaffSalary(ctx context.Context, salary *models.StaffSalary) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Mark previous salary as not current
-		if err := tx.Model(&models.StaffSalary{}).
-			Where("staff_id = ? AND is_current = true", salary.StaffID).
-			Updates(map[string]interface{}{
-				"is_current":   false,
-				"effective_to": salary.EffectiveFrom,
-			}).Error; err != nil {
-			return fmt.Errorf("update previous salary: %w", err)
-		}
+		return r.CreateStaffSalaryWithTx(ctx, tx, salary)
+	})
+}
 
-		// Create new salary
-		if err := tx.Create(salary).Error; err != nil {
-			return fmt.Errorf("create staff salary: %w", err)
-		}
+// CreateStaffSalaryWithTx creates a new staff salary assignment within a transaction.
+func (r *Repository) CreateStaffSalaryWithTx(ctx context.Context, tx *gorm.DB, salary *models.StaffSalary) error {
+	// Mark previous salary as not current
+	if err := tx.WithContext(ctx).Model(&models.StaffSalary{}).
+		Where("staff_id = ? AND is_current = true", salary.StaffID).
+		Updates(map[string]interface{}{
+			"is_current":   false,
+			"effective_to": salary.EffectiveFrom,
+		}).Error; err != nil {
+		return fmt.Errorf("update previous salary: %w", err)
+	}
 
-		return nil
-	})
+	// Create new salary
+	if err := tx.WithContext(ctx).Create(salary).Error; err != nil {
+		return fmt.Errorf("create staff salary: %w", err)
+	}
+
+	return nil
 }
 
 // GetCurrentStaffSalary retrieves the current salary for a staff member.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 
 	"msls-backend/internal/pkg/database/models"
 )
@@ -267,6 +268,35 @@ func (s *Service) GetActiveStructures(ctx context.Context, tenantID uuid.UUID) (
 
 // AssignSalary assigns or revises salary for a staff member.
 func (s *Service) AssignSalary(ctx context.Context, dto AssignSalaryDTO) (*models.StaffSalary, error) {
+	salary, err := s.newStaffSalary(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateStaffSalary(ctx, salary); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCurrentStaffSalary(ctx, dto.TenantID, dto.StaffID)
+}
+
+// AssignSalaryWithTx revises salary for a staff member within a transaction.
+// It returns the new salary without reloading it.
+func (s *Service) AssignSalaryWithTx(ctx context.Context, tx *gorm.DB, dto AssignSalaryDTO) (*models.StaffSalary, error) {
+	salary, err := s.newStaffSalary(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateStaffSalaryWithTx(ctx, tx, salary); err != nil {
+		return nil, err
+	}
+
+	return salary, nil
+}
+
+// newStaffSalary builds a staff salary and its components from an assignment.
+func (s *Service) newStaffSalary(ctx context.Context, dto AssignSalaryDTO) (*models.StaffSalary, error) {
 	// Calculate gross and net salary from components
 	grossSalary := decimal.Zero
 	netSalary := decimal.Zero
@@ -315,11 +345,7 @@ func (s *Service) AssignSalary(ctx context.Context, dto AssignSalaryDTO) (*model
 		})
 	}
 
-	if err := s.repo.CreateStaffSalary(ctx, salary); err != nil {
-		return nil, err
-	}
-
-	return s.repo.GetCurrentStaffSalary(ctx, dto.TenantID, dto.StaffID)
+	return salary, nil
 }
 
 // GetCurrentStaffSalary retrieves the current salary for a staff member.
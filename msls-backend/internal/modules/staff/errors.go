@@ -73,4 +73,7 @@ var (
 
 	// ErrReportingManagerNotFound is returned when the reporting manager is not found.
 	ErrReportingManagerNotFound = errors.New("reporting manager not found")
+
+	// ErrReportingCycle is returned when the reporting manager reports to the staff member.
+	ErrReportingCycle = errors.New("reporting manager would create a cycle in the reporting hierarchy")
 )
@@ -563,6 +563,8 @@ func (h *Handler) Update(c *gin.Context) {
 			apperrors.Abort(c, apperrors.Conflict("Staff was modified by another user, please refresh and try again"))
 		case errors.Is(err, ErrReportingManagerNotFound):
 			apperrors.Abort(c, apperrors.BadRequest("Reporting manager not found"))
+		case errors.Is(err, ErrReportingCycle):
+			apperrors.Abort(c, apperrors.BadRequest("Reporting manager cannot be this staff member or someone who reports to them"))
 		default:
 			logger.Error("Failed to update staff",
 				zap.String("tenant_id", tenantID.String()),
@@ -3,20 +3,33 @@ package staff
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"msls-backend/internal/modules/orgchart"
 	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/logger"
 	"msls-backend/internal/services/branch"
 )
 
+// ChecklistStarter opens onboarding checklists for new staff and exit
+// workflows for staff who leave.
+type ChecklistStarter interface {
+	StartOnboarding(ctx context.Context, tenantID, staffID uuid.UUID, createdBy *uuid.UUID) (*models.StaffChecklist, error)
+	EnsureExit(ctx context.Context, tenantID, staffID uuid.UUID, lastWorkingDay time.Time, reason string, createdBy *uuid.UUID) (*models.StaffChecklist, error)
+}
+
 // Service handles staff business logic.
 type Service struct {
 	repo          *Repository
 	branchService *branch.Service
+	hierarchy     *orgchart.Service
+	checklists    ChecklistStarter
 	db            *gorm.DB
 }
 
@@ -25,10 +38,44 @@ func NewService(db *gorm.DB, branchService *branch.Service) *Service {
 	return &Service{
 		repo:          NewRepository(db),
 		branchService: branchService,
+		hierarchy:     orgchart.NewService(orgchart.NewRepository(db)),
 		db:            db,
 	}
 }
 
+// SetChecklistStarter sets the starter used to open onboarding checklists
+// and exit workflows.
+func (s *Service) SetChecklistStarter(starter ChecklistStarter) {
+	s.checklists = starter
+}
+
+// startOnboarding opens a new staff member's onboarding checklist. Failures
+// are logged rather than returned because the staff member has been created.
+func (s *Service) startOnboarding(ctx context.Context, tenantID, staffID uuid.UUID, createdBy *uuid.UUID) {
+	if s.checklists == nil {
+		return
+	}
+	if _, err := s.checklists.StartOnboarding(ctx, tenantID, staffID, createdBy); err != nil {
+		logger.Warn("Failed to start onboarding checklist",
+			zap.String("staff_id", staffID.String()),
+			zap.Error(err))
+	}
+}
+
+// startExit opens an exit workflow for a terminated staff member unless one
+// is already open. Failures are logged rather than returned because the
+// status change has been saved.
+func (s *Service) startExit(ctx context.Context, tenantID, staffID uuid.UUID, lastWorkingDay time.Time, reason string, createdBy *uuid.UUID) {
+	if s.checklists == nil {
+		return
+	}
+	if _, err := s.checklists.EnsureExit(ctx, tenantID, staffID, lastWorkingDay, reason, createdBy); err != nil {
+		logger.Warn("Failed to start exit workflow",
+			zap.String("staff_id", staffID.String()),
+			zap.Error(err))
+	}
+}
+
 // Create creates a new staff member with auto-generated employee ID.
 func (s *Service) Create(ctx context.Context, dto CreateStaffDTO) (*models.Staff, error) {
 	// Validate required fields
@@ -183,6 +230,8 @@ func (s *Service) Create(ctx context.Context, dto CreateStaffDTO) (*models.Staff
 		return nil, err
 	}
 
+	s.startOnboarding(ctx, dto.TenantID, staff.ID, dto.CreatedBy)
+
 	// Fetch the complete staff with all relations
 	return s.GetByID(ctx, dto.TenantID, staff.ID)
 }
@@ -217,6 +266,13 @@ func (s *Service) Update(ctx context.Context, tenantID, id uuid.UUID, dto Update
 			if err != nil {
 				return nil, ErrReportingManagerNotFound
 			}
+			// Reject a manager who already reports (directly or indirectly) to this staff member
+			if err := s.hierarchy.ValidateReportingManager(ctx, tenantID, id, *dto.ReportingManagerID); err != nil {
+				if errors.Is(err, orgchart.ErrSelfManager) || errors.Is(err, orgchart.ErrReportingCycle) {
+					return nil, ErrReportingCycle
+				}
+				return nil, err
+			}
 		}
 	}
 
@@ -431,6 +487,10 @@ func (s *Service) UpdateStatus(ctx context.Context, tenantID, id uuid.UUID, dto
 		return nil, err
 	}
 
+	if dto.Status == models.StaffStatusTerminated && staff.Status != models.StaffStatusTerminated {
+		s.startExit(ctx, tenantID, id, dto.EffectiveDate, dto.Reason, dto.UpdatedBy)
+	}
+
 	return s.GetByID(ctx, tenantID, id)
 }
 
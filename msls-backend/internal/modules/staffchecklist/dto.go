@@ -0,0 +1,569 @@
+// Package staffchecklist provides staff onboarding checklists and exit workflows.
+package staffchecklist
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// =========================================================================
+// Service DTOs
+// =========================================================================
+
+// ItemDTO is a task on a template, or an ad hoc task added to a checklist.
+type ItemDTO struct {
+	Title          string
+	Description    string
+	TaskType       models.ChecklistTaskType
+	DepartmentID   *uuid.UUID
+	LetterType     *models.StaffLetterType
+	AssigneeRoleID *uuid.UUID
+	DueOffsetDays  int
+}
+
+// CreateTemplateDTO represents a request to create a checklist template.
+type CreateTemplateDTO struct {
+	TenantID  uuid.UUID
+	Name      string
+	Kind      models.ChecklistKind
+	StaffType *models.StaffType
+	Items     []ItemDTO
+	UserID    *uuid.UUID
+}
+
+// UpdateTemplateDTO represents a request to update a checklist template.
+// Items, when given, replace the template's items.
+type UpdateTemplateDTO struct {
+	Name     *string
+	IsActive *bool
+	Items    []ItemDTO
+	UserID   *uuid.UUID
+}
+
+// AddTaskDTO represents a request to add an ad hoc task to a checklist.
+type AddTaskDTO struct {
+	TenantID       uuid.UUID
+	ChecklistID    uuid.UUID
+	Title          string
+	Description    string
+	TaskType       models.ChecklistTaskType
+	DepartmentID   *uuid.UUID
+	LetterType     *models.StaffLetterType
+	AssigneeRoleID *uuid.UUID
+	AssigneeUserID *uuid.UUID
+	DueDate        *time.Time
+}
+
+// AssignTaskDTO represents a request to reassign a task. The assignees and
+// due date replace the task's current values; nil clears them.
+type AssignTaskDTO struct {
+	TenantID       uuid.UUID
+	TaskID         uuid.UUID
+	AssigneeRoleID *uuid.UUID
+	AssigneeUserID *uuid.UUID
+	DueDate        *time.Time
+}
+
+// CompleteTaskDTO represents a request to complete or waive a task.
+// Override lets checklist managers complete tasks assigned to others.
+type CompleteTaskDTO struct {
+	TenantID uuid.UUID
+	TaskID   uuid.UUID
+	UserID   uuid.UUID
+	Notes    string
+	Override bool
+}
+
+// IssueAssetDTO represents a request to record an asset issued to staff.
+type IssueAssetDTO struct {
+	TenantID    uuid.UUID
+	StaffID     uuid.UUID
+	Name        string
+	AssetTag    string
+	Description string
+	IssuedOn    time.Time
+	UserID      *uuid.UUID
+}
+
+// ReturnAssetDTO represents a request to record an asset's return, or its
+// recovery from the settlement when it is not returned.
+type ReturnAssetDTO struct {
+	TenantID       uuid.UUID
+	StaffID        uuid.UUID
+	AssetID        uuid.UUID
+	ReturnedOn     *time.Time
+	Condition      string
+	RecoveryAmount *decimal.Decimal
+	UserID         *uuid.UUID
+}
+
+// SettlementDTO represents the manual inputs to a full-and-final settlement.
+type SettlementDTO struct {
+	TenantID            uuid.UUID
+	ChecklistID         uuid.UUID
+	LeaveEncashmentDays decimal.Decimal
+	OtherEarnings       decimal.Decimal
+	OtherDeductions     decimal.Decimal
+	Notes               string
+	UserID              *uuid.UUID
+}
+
+// SettlementPaymentDTO represents a request to record settlement payment.
+type SettlementPaymentDTO struct {
+	TenantID         uuid.UUID
+	ChecklistID      uuid.UUID
+	PaidOn           time.Time
+	PaymentReference string
+}
+
+// ChecklistFilter contains filter options for listing checklists.
+type ChecklistFilter struct {
+	TenantID uuid.UUID
+	StaffID  *uuid.UUID
+	Kind     *models.ChecklistKind
+	Status   *models.StaffChecklistStatus
+	Limit    int
+	Offset   int
+}
+
+// TaskFilter contains filter options for listing checklist tasks. ForUserID
+// matches tasks assigned to the user directly or to any of their roles.
+type TaskFilter struct {
+	TenantID       uuid.UUID
+	ChecklistID    *uuid.UUID
+	StaffID        *uuid.UUID
+	Kind           *models.ChecklistKind
+	AssigneeRoleID *uuid.UUID
+	AssigneeUserID *uuid.UUID
+	ForUserID      *uuid.UUID
+	Status         *models.ChecklistTaskStatus
+	OpenOnly       bool
+	OverdueOnly    bool
+	Limit          int
+	Offset         int
+}
+
+// =========================================================================
+// Request Types
+// =========================================================================
+
+// ItemRequest represents a task in a template request.
+type ItemRequest struct {
+	Title          string  `json:"title" binding:"required,max=200"`
+	Description    string  `json:"description"`
+	TaskType       string  `json:"taskType" binding:"required"`
+	DepartmentID   *string `json:"departmentId" binding:"omitempty,uuid"`
+	LetterType     *string `json:"letterType" binding:"omitempty,oneof=relieving experience"`
+	AssigneeRoleID *string `json:"assigneeRoleId" binding:"omitempty,uuid"`
+	DueOffsetDays  int     `json:"dueOffsetDays"`
+}
+
+// CreateTemplateRequest represents the request body for creating a template.
+type CreateTemplateRequest struct {
+	Name      string        `json:"name" binding:"required,max=200"`
+	Kind      string        `json:"kind" binding:"required,oneof=onboarding exit"`
+	StaffType *string       `json:"staffType" binding:"omitempty,oneof=teaching non_teaching"`
+	Items     []ItemRequest `json:"items" binding:"dive"`
+}
+
+// UpdateTemplateRequest represents the request body for updating a template.
+type UpdateTemplateRequest struct {
+	Name     *string       `json:"name" binding:"omitempty,max=200"`
+	IsActive *bool         `json:"isActive"`
+	Items    []ItemRequest `json:"items" binding:"omitempty,dive"`
+}
+
+// StartExitRequest represents the request body for starting an exit workflow.
+type StartExitRequest struct {
+	LastWorkingDay string `json:"lastWorkingDay" binding:"required"`
+	Reason         string `json:"reason"`
+}
+
+// AddTaskRequest represents the request body for adding a task to a checklist.
+type AddTaskRequest struct {
+	Title          string  `json:"title" binding:"required,max=200"`
+	Description    string  `json:"description"`
+	TaskType       string  `json:"taskType" binding:"required"`
+	DepartmentID   *string `json:"departmentId" binding:"omitempty,uuid"`
+	LetterType     *string `json:"letterType" binding:"omitempty,oneof=relieving experience"`
+	AssigneeRoleID *string `json:"assigneeRoleId" binding:"omitempty,uuid"`
+	AssigneeUserID *string `json:"assigneeUserId" binding:"omitempty,uuid"`
+	DueDate        *string `json:"dueDate"`
+}
+
+// AssignTaskRequest represents the request body for reassigning a task.
+type AssignTaskRequest struct {
+	AssigneeRoleID *string `json:"assigneeRoleId" binding:"omitempty,uuid"`
+	AssigneeUserID *string `json:"assigneeUserId" binding:"omitempty,uuid"`
+	DueDate        *string `json:"dueDate"`
+}
+
+// TaskNotesRequest represents the request body for completing or waiving a task.
+type TaskNotesRequest struct {
+	Notes string `json:"notes"`
+}
+
+// IssueAssetRequest represents the request body for issuing an asset.
+type IssueAssetRequest struct {
+	Name        string  `json:"name" binding:"required,max=200"`
+	AssetTag    string  `json:"assetTag" binding:"max=100"`
+	Description string  `json:"description"`
+	IssuedOn    *string `json:"issuedOn"`
+}
+
+// ReturnAssetRequest represents the request body for returning an asset. Give
+// a recovery amount without a return date for an asset that was lost.
+type ReturnAssetRequest struct {
+	ReturnedOn     *string `json:"returnedOn"`
+	Condition      string  `json:"condition" binding:"max=200"`
+	RecoveryAmount *string `json:"recoveryAmount"`
+}
+
+// SettlementRequest represents the request body for calculating a settlement.
+type SettlementRequest struct {
+	LeaveEncashmentDays string `json:"leaveEncashmentDays"`
+	OtherEarnings       string `json:"otherEarnings"`
+	OtherDeductions     string `json:"otherDeductions"`
+	Notes               string `json:"notes"`
+}
+
+// SettlementPaymentRequest represents the request body for recording settlement payment.
+type SettlementPaymentRequest struct {
+	PaidOn           *string `json:"paidOn"`
+	PaymentReference string  `json:"paymentReference" binding:"max=100"`
+}
+
+// =========================================================================
+// Response Types
+// =========================================================================
+
+// TemplateItemResponse represents a template item in API responses.
+type TemplateItemResponse struct {
+	ID               string `json:"id"`
+	Title            string `json:"title"`
+	Description      string `json:"description,omitempty"`
+	TaskType         string `json:"taskType"`
+	DepartmentID     string `json:"departmentId,omitempty"`
+	DepartmentName   string `json:"departmentName,omitempty"`
+	LetterType       string `json:"letterType,omitempty"`
+	AssigneeRoleID   string `json:"assigneeRoleId,omitempty"`
+	AssigneeRoleName string `json:"assigneeRoleName,omitempty"`
+	DueOffsetDays    int    `json:"dueOffsetDays"`
+}
+
+// TemplateResponse represents a checklist template in API responses.
+type TemplateResponse struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Kind      string                 `json:"kind"`
+	StaffType string                 `json:"staffType,omitempty"`
+	IsActive  bool                   `json:"isActive"`
+	Items     []TemplateItemResponse `json:"items"`
+}
+
+// TaskResponse represents a checklist task in API responses.
+type TaskResponse struct {
+	ID               string `json:"id"`
+	ChecklistID      string `json:"checklistId"`
+	Kind             string `json:"kind,omitempty"`
+	StaffID          string `json:"staffId,omitempty"`
+	StaffName        string `json:"staffName,omitempty"`
+	EmployeeID       string `json:"employeeId,omitempty"`
+	Title            string `json:"title"`
+	Description      string `json:"description,omitempty"`
+	TaskType         string `json:"taskType"`
+	DocumentTypeID   string `json:"documentTypeId,omitempty"`
+	DocumentTypeName string `json:"documentTypeName,omitempty"`
+	DepartmentID     string `json:"departmentId,omitempty"`
+	DepartmentName   string `json:"departmentName,omitempty"`
+	LetterType       string `json:"letterType,omitempty"`
+	AssigneeRoleID   string `json:"assigneeRoleId,omitempty"`
+	AssigneeRoleName string `json:"assigneeRoleName,omitempty"`
+	AssigneeUserID   string `json:"assigneeUserId,omitempty"`
+	DueDate          string `json:"dueDate,omitempty"`
+	Overdue          bool   `json:"overdue"`
+	Status           string `json:"status"`
+	CompletedAt      string `json:"completedAt,omitempty"`
+	CompletedBy      string `json:"completedBy,omitempty"`
+	Notes            string `json:"notes,omitempty"`
+}
+
+// TaskListResponse represents a page of checklist tasks.
+type TaskListResponse struct {
+	Tasks []TaskResponse `json:"tasks"`
+	Total int64          `json:"total"`
+}
+
+// ChecklistResponse represents a staff checklist in API responses.
+type ChecklistResponse struct {
+	ID            string         `json:"id"`
+	Kind          string         `json:"kind"`
+	StaffID       string         `json:"staffId"`
+	StaffName     string         `json:"staffName,omitempty"`
+	EmployeeID    string         `json:"employeeId,omitempty"`
+	TemplateID    string         `json:"templateId,omitempty"`
+	TemplateName  string         `json:"templateName,omitempty"`
+	Status        string         `json:"status"`
+	EffectiveDate string         `json:"effectiveDate"`
+	ExitReason    string         `json:"exitReason,omitempty"`
+	TotalTasks    int            `json:"totalTasks"`
+	DoneTasks     int            `json:"doneTasks"`
+	OverdueTasks  int            `json:"overdueTasks"`
+	CompletedAt   string         `json:"completedAt,omitempty"`
+	CancelledAt   string         `json:"cancelledAt,omitempty"`
+	CreatedAt     string         `json:"createdAt"`
+	Tasks         []TaskResponse `json:"tasks,omitempty"`
+}
+
+// ChecklistListResponse represents a page of staff checklists.
+type ChecklistListResponse struct {
+	Checklists []ChecklistResponse `json:"checklists"`
+	Total      int64               `json:"total"`
+}
+
+// AssetResponse represents a staff asset in API responses.
+type AssetResponse struct {
+	ID              string `json:"id"`
+	StaffID         string `json:"staffId"`
+	Name            string `json:"name"`
+	AssetTag        string `json:"assetTag,omitempty"`
+	Description     string `json:"description,omitempty"`
+	IssuedOn        string `json:"issuedOn"`
+	ReturnedOn      string `json:"returnedOn,omitempty"`
+	ReturnCondition string `json:"returnCondition,omitempty"`
+	RecoveryAmount  string `json:"recoveryAmount,omitempty"`
+	Cleared         bool   `json:"cleared"`
+}
+
+// SettlementResponse represents a full-and-final settlement in API responses.
+type SettlementResponse struct {
+	ID                  string `json:"id"`
+	ChecklistID         string `json:"checklistId"`
+	StaffID             string `json:"staffId"`
+	LastWorkingDay      string `json:"lastWorkingDay"`
+	MonthlyEarnings     string `json:"monthlyEarnings"`
+	MonthlyDeductions   string `json:"monthlyDeductions"`
+	MonthlyBasic        string `json:"monthlyBasic"`
+	WorkingDays         int    `json:"workingDays"`
+	PayableDays         int    `json:"payableDays"`
+	FinalMonthPaid      bool   `json:"finalMonthPaid"`
+	FinalMonthSalary    string `json:"finalMonthSalary"`
+	ServiceYears        int    `json:"serviceYears"`
+	Gratuity            string `json:"gratuity"`
+	LeaveEncashmentDays string `json:"leaveEncashmentDays"`
+	LeaveEncashment     string `json:"leaveEncashment"`
+	OtherEarnings       string `json:"otherEarnings"`
+	AssetRecovery       string `json:"assetRecovery"`
+	OtherDeductions     string `json:"otherDeductions"`
+	NetPayable          string `json:"netPayable"`
+	Notes               string `json:"notes,omitempty"`
+	Status              string `json:"status"`
+	ApprovedAt          string `json:"approvedAt,omitempty"`
+	PaidOn              string `json:"paidOn,omitempty"`
+	PaymentReference    string `json:"paymentReference,omitempty"`
+}
+
+// ToTemplateResponse converts a ChecklistTemplate model to a TemplateResponse.
+func ToTemplateResponse(t *models.ChecklistTemplate) TemplateResponse {
+	resp := TemplateResponse{
+		ID:       t.ID.String(),
+		Name:     t.Name,
+		Kind:     string(t.Kind),
+		IsActive: t.IsActive,
+		Items:    make([]TemplateItemResponse, len(t.Items)),
+	}
+	if t.StaffType != nil {
+		resp.StaffType = string(*t.StaffType)
+	}
+	for i, item := range t.Items {
+		resp.Items[i] = TemplateItemResponse{
+			ID:            item.ID.String(),
+			Title:         item.Title,
+			Description:   item.Description,
+			TaskType:      string(item.TaskType),
+			DueOffsetDays: item.DueOffsetDays,
+		}
+		if item.DepartmentID != nil {
+			resp.Items[i].DepartmentID = item.DepartmentID.String()
+		}
+		if item.Department != nil {
+			resp.Items[i].DepartmentName = item.Department.Name
+		}
+		if item.LetterType != nil {
+			resp.Items[i].LetterType = string(*item.LetterType)
+		}
+		if item.AssigneeRoleID != nil {
+			resp.Items[i].AssigneeRoleID = item.AssigneeRoleID.String()
+		}
+		if item.AssigneeRole != nil {
+			resp.Items[i].AssigneeRoleName = item.AssigneeRole.Name
+		}
+	}
+	return resp
+}
+
+// ToTaskResponse converts a StaffChecklistTask model to a TaskResponse.
+func ToTaskResponse(t *models.StaffChecklistTask) TaskResponse {
+	resp := TaskResponse{
+		ID:          t.ID.String(),
+		ChecklistID: t.ChecklistID.String(),
+		Title:       t.Title,
+		Description: t.Description,
+		TaskType:    string(t.TaskType),
+		DueDate:     formatDate(t.DueDate),
+		Overdue:     isOverdue(t, today()),
+		Status:      string(t.Status),
+		CompletedAt: formatTimestamp(t.CompletedAt),
+		Notes:       t.Notes,
+	}
+	if t.Checklist != nil {
+		resp.Kind = string(t.Checklist.Kind)
+		resp.StaffID = t.Checklist.StaffID.String()
+		if t.Checklist.Staff != nil {
+			resp.StaffName = t.Checklist.Staff.FullName()
+			resp.EmployeeID = t.Checklist.Staff.EmployeeID
+		}
+	}
+	if t.DocumentTypeID != nil {
+		resp.DocumentTypeID = t.DocumentTypeID.String()
+	}
+	if t.DocumentType != nil {
+		resp.DocumentTypeName = t.DocumentType.Name
+	}
+	if t.DepartmentID != nil {
+		resp.DepartmentID = t.DepartmentID.String()
+	}
+	if t.Department != nil {
+		resp.DepartmentName = t.Department.Name
+	}
+	if t.LetterType != nil {
+		resp.LetterType = string(*t.LetterType)
+	}
+	if t.AssigneeRoleID != nil {
+		resp.AssigneeRoleID = t.AssigneeRoleID.String()
+	}
+	if t.AssigneeRole != nil {
+		resp.AssigneeRoleName = t.AssigneeRole.Name
+	}
+	if t.AssigneeUserID != nil {
+		resp.AssigneeUserID = t.AssigneeUserID.String()
+	}
+	if t.CompletedBy != nil {
+		resp.CompletedBy = t.CompletedBy.String()
+	}
+	return resp
+}
+
+// ToChecklistResponse converts a StaffChecklist model to a ChecklistResponse,
+// including its tasks when withTasks is set.
+func ToChecklistResponse(c *models.StaffChecklist, withTasks bool) ChecklistResponse {
+	resp := ChecklistResponse{
+		ID:            c.ID.String(),
+		Kind:          string(c.Kind),
+		StaffID:       c.StaffID.String(),
+		Status:        string(c.Status),
+		EffectiveDate: c.EffectiveDate.Format("2006-01-02"),
+		ExitReason:    c.ExitReason,
+		TotalTasks:    len(c.Tasks),
+		CompletedAt:   formatTimestamp(c.CompletedAt),
+		CancelledAt:   formatTimestamp(c.CancelledAt),
+		CreatedAt:     c.CreatedAt.Format(time.RFC3339),
+	}
+	if c.Staff != nil {
+		resp.StaffName = c.Staff.FullName()
+		resp.EmployeeID = c.Staff.EmployeeID
+	}
+	if c.TemplateID != nil {
+		resp.TemplateID = c.TemplateID.String()
+	}
+	if c.Template != nil {
+		resp.TemplateName = c.Template.Name
+	}
+	now := today()
+	for i := range c.Tasks {
+		if c.Tasks[i].IsDone() {
+			resp.DoneTasks++
+		} else if isOverdue(&c.Tasks[i], now) {
+			resp.OverdueTasks++
+		}
+	}
+	if withTasks {
+		resp.Tasks = make([]TaskResponse, len(c.Tasks))
+		for i := range c.Tasks {
+			resp.Tasks[i] = ToTaskResponse(&c.Tasks[i])
+		}
+	}
+	return resp
+}
+
+// ToAssetResponse converts a StaffAsset model to an AssetResponse.
+func ToAssetResponse(a *models.StaffAsset) AssetResponse {
+	resp := AssetResponse{
+		ID:              a.ID.String(),
+		StaffID:         a.StaffID.String(),
+		Name:            a.Name,
+		AssetTag:        a.AssetTag,
+		Description:     a.Description,
+		IssuedOn:        a.IssuedOn.Format("2006-01-02"),
+		ReturnedOn:      formatDate(a.ReturnedOn),
+		ReturnCondition: a.ReturnCondition,
+		Cleared:         a.IsCleared(),
+	}
+	if a.RecoveryAmount != nil {
+		resp.RecoveryAmount = a.RecoveryAmount.StringFixed(2)
+	}
+	return resp
+}
+
+// ToSettlementResponse converts an ExitSettlement model to a SettlementResponse.
+func ToSettlementResponse(s *models.ExitSettlement) SettlementResponse {
+	return SettlementResponse{
+		ID:                  s.ID.String(),
+		ChecklistID:         s.ChecklistID.String(),
+		StaffID:             s.StaffID.String(),
+		LastWorkingDay:      s.LastWorkingDay.Format("2006-01-02"),
+		MonthlyEarnings:     s.MonthlyEarnings.StringFixed(2),
+		MonthlyDeductions:   s.MonthlyDeductions.StringFixed(2),
+		MonthlyBasic:        s.MonthlyBasic.StringFixed(2),
+		WorkingDays:         s.WorkingDays,
+		PayableDays:         s.PayableDays,
+		FinalMonthPaid:      s.FinalMonthPaid,
+		FinalMonthSalary:    s.FinalMonthSalary.StringFixed(2),
+		ServiceYears:        s.ServiceYears,
+		Gratuity:            s.Gratuity.StringFixed(2),
+		LeaveEncashmentDays: s.LeaveEncashmentDays.String(),
+		LeaveEncashment:     s.LeaveEncashment.StringFixed(2),
+		OtherEarnings:       s.OtherEarnings.StringFixed(2),
+		AssetRecovery:       s.AssetRecovery.StringFixed(2),
+		OtherDeductions:     s.OtherDeductions.StringFixed(2),
+		NetPayable:          s.NetPayable.StringFixed(2),
+		Notes:               s.Notes,
+		Status:              string(s.Status),
+		ApprovedAt:          formatTimestamp(s.ApprovedAt),
+		PaidOn:              formatDate(s.PaidOn),
+		PaymentReference:    s.PaymentReference,
+	}
+}
+
+// isOverdue reports whether a pending task is past its due date.
+func isOverdue(t *models.StaffChecklistTask, today time.Time) bool {
+	return !t.IsDone() && t.DueDate != nil && dateOnly(*t.DueDate).Before(today)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
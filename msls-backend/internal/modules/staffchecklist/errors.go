@@ -0,0 +1,100 @@
+// Package staffchecklist provides staff onboarding checklists and exit workflows.
+package staffchecklist
+
+import "errors"
+
+// Checklist-related errors.
+var (
+	// ErrTemplateNotFound is returned when a checklist template is not found.
+	ErrTemplateNotFound = errors.New("checklist template not found")
+
+	// ErrChecklistNotFound is returned when a staff checklist is not found.
+	ErrChecklistNotFound = errors.New("staff checklist not found")
+
+	// ErrTaskNotFound is returned when a checklist task is not found.
+	ErrTaskNotFound = errors.New("checklist task not found")
+
+	// ErrAssetNotFound is returned when a staff asset is not found.
+	ErrAssetNotFound = errors.New("staff asset not found")
+
+	// ErrSettlementNotFound is returned when an exit has no settlement yet.
+	ErrSettlementNotFound = errors.New("exit settlement not found")
+
+	// ErrStaffNotFound is returned when the staff member is not found.
+	ErrStaffNotFound = errors.New("staff not found")
+
+	// ErrNameRequired is returned when a template has no name.
+	ErrNameRequired = errors.New("name is required")
+
+	// ErrTitleRequired is returned when a task has no title.
+	ErrTitleRequired = errors.New("task title is required")
+
+	// ErrInvalidKind is returned when the checklist kind is not onboarding or exit.
+	ErrInvalidKind = errors.New("kind must be onboarding or exit")
+
+	// ErrInvalidStaffType is returned when a template's staff type is not valid.
+	ErrInvalidStaffType = errors.New("invalid staff type")
+
+	// ErrInvalidTaskType is returned when a task type is unknown or not allowed on the checklist kind.
+	ErrInvalidTaskType = errors.New("task type is not valid for this checklist")
+
+	// ErrLetterTypeRequired is returned when a letter task has no valid letter type.
+	ErrLetterTypeRequired = errors.New("letter tasks need a letter type of relieving or experience")
+
+	// ErrDuplicateTemplate is returned when a staff type already has an active template of the kind.
+	ErrDuplicateTemplate = errors.New("an active template of this kind already exists for this staff type")
+
+	// ErrChecklistExists is returned when a staff member already has an open checklist of the kind.
+	ErrChecklistExists = errors.New("staff member already has an open checklist of this kind")
+
+	// ErrChecklistClosed is returned when a completed or cancelled checklist is changed.
+	ErrChecklistClosed = errors.New("checklist is no longer open")
+
+	// ErrNotExitChecklist is returned when a settlement or letter is requested for an onboarding checklist.
+	ErrNotExitChecklist = errors.New("checklist is not an exit workflow")
+
+	// ErrLastWorkingDayRequired is returned when an exit is started without a last working day.
+	ErrLastWorkingDayRequired = errors.New("last working day is required")
+
+	// ErrInvalidLastWorkingDay is returned when the last working day is before the join date.
+	ErrInvalidLastWorkingDay = errors.New("last working day must not be before the join date")
+
+	// ErrTaskDone is returned when a completed or waived task is completed again.
+	ErrTaskDone = errors.New("task has already been completed or waived")
+
+	// ErrNotAssignee is returned when a user completes a task assigned to another user or role.
+	ErrNotAssignee = errors.New("task is not assigned to you")
+
+	// ErrWaiverReasonRequired is returned when a task is waived without notes.
+	ErrWaiverReasonRequired = errors.New("a reason is required to waive a task")
+
+	// ErrDocumentNotVerified is returned when a document task is completed before the document is verified.
+	ErrDocumentNotVerified = errors.New("the document has not been uploaded and verified")
+
+	// ErrNoAssetsIssued is returned when an asset issue task is completed before any asset is issued.
+	ErrNoAssetsIssued = errors.New("no assets have been issued to the staff member")
+
+	// ErrNoCurrentSalary is returned when salary is needed but the staff member has none.
+	ErrNoCurrentSalary = errors.New("staff member has no current salary")
+
+	// ErrAssetsOutstanding is returned when assets are neither returned nor marked for recovery.
+	ErrAssetsOutstanding = errors.New("issued assets have not been returned or marked for recovery")
+
+	// ErrAssetReturned is returned when an asset that has been returned is returned again.
+	ErrAssetReturned = errors.New("asset has already been returned")
+
+	// ErrInvalidAmount is returned when a settlement or recovery amount is negative.
+	ErrInvalidAmount = errors.New("amounts must not be negative")
+
+	// ErrSettlementNotDraft is returned when an approved or paid settlement is recalculated or approved again.
+	ErrSettlementNotDraft = errors.New("settlement has already been approved")
+
+	// ErrSettlementNotApproved is returned when a settlement is paid, or a relieving letter issued, before approval.
+	ErrSettlementNotApproved = errors.New("settlement has not been approved")
+
+	// ErrClearancePending is returned when a relieving letter is requested before clearance is complete.
+	ErrClearancePending = errors.New("department clearance and asset return must be complete before relieving")
+
+	// ErrInvalidLetterType is returned when the letter type is not relieving or experience.
+	ErrInvalidLetterType = errors.New("letter type must be relieving or experience")
+)
@@ -0,0 +1,1255 @@
+// Package staffchecklist provides staff onboarding checklists and exit workflows.
+package staffchecklist
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperrors "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/logger"
+	"msls-backend/internal/pkg/response"
+)
+
+// Handler handles staff checklist HTTP requests.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new staff checklist handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers checklist template, checklist and task routes.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup) {
+	// Templates
+	templates := rg.Group("/staff-checklist-templates")
+	{
+		templatesRead := templates.Group("")
+		templatesRead.Use(middleware.PermissionRequired("staff_checklists.view"))
+		{
+			templatesRead.GET("", h.ListTemplates)
+			templatesRead.GET("/:id", h.GetTemplate)
+		}
+
+		templatesManage := templates.Group("")
+		templatesManage.Use(middleware.PermissionRequired("staff_checklists.manage"))
+		{
+			templatesManage.POST("", h.CreateTemplate)
+			templatesManage.PUT("/:id", h.UpdateTemplate)
+			templatesManage.DELETE("/:id", h.DeleteTemplate)
+		}
+	}
+
+	// Checklists
+	checklists := rg.Group("/staff-checklists")
+	{
+		checklistsRead := checklists.Group("")
+		checklistsRead.Use(middleware.PermissionRequired("staff_checklists.view"))
+		{
+			checklistsRead.GET("", h.ListChecklists)
+			checklistsRead.GET("/:id", h.GetChecklist)
+			checklistsRead.GET("/:id/settlement", h.GetSettlement)
+		}
+
+		checklistsManage := checklists.Group("")
+		checklistsManage.Use(middleware.PermissionRequired("staff_checklists.manage"))
+		{
+			checklistsManage.POST("/:id/cancel", h.CancelChecklist)
+			checklistsManage.POST("/:id/tasks", h.AddTask)
+			checklistsManage.PUT("/:id/settlement", h.CalculateSettlement)
+			checklistsManage.POST("/:id/settlement/approve", h.ApproveSettlement)
+			checklistsManage.POST("/:id/settlement/paid", h.MarkSettlementPaid)
+			checklistsManage.GET("/:id/letters/:type", h.GenerateLetter)
+		}
+	}
+
+	// Tasks
+	tasks := rg.Group("/staff-checklist-tasks")
+	{
+		tasks.GET("", middleware.PermissionRequired("staff_checklists.view"), h.ListTasks)
+		tasks.GET("/mine", middleware.PermissionRequired("staff_checklists.complete"), h.ListMyTasks)
+		tasks.POST("/:id/complete", middleware.PermissionRequired("staff_checklists.complete"), h.CompleteTask)
+		tasks.PUT("/:id/assignment", middleware.PermissionRequired("staff_checklists.manage"), h.AssignTask)
+		tasks.POST("/:id/waive", middleware.PermissionRequired("staff_checklists.manage"), h.WaiveTask)
+	}
+}
+
+// RegisterStaffChecklistRoutes registers staff checklist and asset routes.
+func (h *Handler) RegisterStaffChecklistRoutes(staffGroup *gin.RouterGroup) {
+	staffGroup.GET("/:id/checklists", middleware.PermissionRequired("staff_checklists.view"), h.ListStaffChecklists)
+	staffGroup.POST("/:id/onboarding", middleware.PermissionRequired("staff_checklists.manage"), h.StartOnboarding)
+	staffGroup.POST("/:id/exit", middleware.PermissionRequired("staff_checklists.manage"), h.StartExit)
+	staffGroup.GET("/:id/assets", middleware.PermissionRequired("staff_checklists.view"), h.ListStaffAssets)
+	staffGroup.POST("/:id/assets", middleware.PermissionRequired("staff_checklists.manage"), h.IssueAsset)
+	staffGroup.POST("/:id/assets/:assetId/return", middleware.PermissionRequired("staff_checklists.manage"), h.ReturnAsset)
+}
+
+// =========================================================================
+// Templates
+// =========================================================================
+
+// ListTemplates returns checklist templates.
+// @Summary List checklist templates
+// @Description List the onboarding and exit checklist templates configured for each staff type
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param kind query string false "Kind (onboarding, exit)"
+// @Param activeOnly query bool false "Only active templates"
+// @Success 200 {object} response.Success{data=[]TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-templates [get]
+func (h *Handler) ListTemplates(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	var kind *models.ChecklistKind
+	if !parseKindQuery(c, &kind) {
+		return
+	}
+
+	templates, err := h.service.ListTemplates(c.Request.Context(), tenantID, kind, c.Query("activeOnly") == "true")
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := make([]TemplateResponse, len(templates))
+	for i := range templates {
+		resp[i] = ToTemplateResponse(&templates[i])
+	}
+	response.OK(c, resp)
+}
+
+// GetTemplate returns a checklist template.
+// @Summary Get checklist template
+// @Description Get a checklist template with its tasks
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Success 200 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-templates/{id} [get]
+func (h *Handler) GetTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	template, err := h.service.GetTemplate(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTemplateResponse(template))
+}
+
+// CreateTemplate creates a checklist template.
+// @Summary Create checklist template
+// @Description Create an onboarding or exit checklist template for a staff type, or for all staff when no staff type is given. Each task can be assigned to a role with a due date offset in days from the join date or last working day. Mandatory document tasks are added to onboarding checklists automatically.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body CreateTemplateRequest true "Template"
+// @Success 201 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-templates [post]
+func (h *Handler) CreateTemplate(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	items, ok := toItemDTOs(c, req.Items)
+	if !ok {
+		return
+	}
+	dto := CreateTemplateDTO{
+		TenantID: tenantID,
+		Name:     req.Name,
+		Kind:     models.ChecklistKind(req.Kind),
+		Items:    items,
+		UserID:   &userID,
+	}
+	if req.StaffType != nil && *req.StaffType != "" {
+		staffType := models.StaffType(*req.StaffType)
+		dto.StaffType = &staffType
+	}
+
+	template, err := h.service.CreateTemplate(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToTemplateResponse(template))
+}
+
+// UpdateTemplate updates a checklist template.
+// @Summary Update checklist template
+// @Description Update a checklist template. Items, when given, replace the template's tasks; checklists already started keep theirs.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Param request body UpdateTemplateRequest true "Template"
+// @Success 200 {object} response.Success{data=TemplateResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-templates/{id} [put]
+func (h *Handler) UpdateTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	dto := UpdateTemplateDTO{Name: req.Name, IsActive: req.IsActive, UserID: &userID}
+	if req.Items != nil {
+		if dto.Items, ok = toItemDTOs(c, req.Items); !ok {
+			return
+		}
+	}
+
+	template, err := h.service.UpdateTemplate(c.Request.Context(), tenantID, id, dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTemplateResponse(template))
+}
+
+// DeleteTemplate deletes a checklist template.
+// @Summary Delete checklist template
+// @Description Delete a checklist template. Checklists already started from it keep their tasks.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Template ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-templates/{id} [delete]
+func (h *Handler) DeleteTemplate(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTemplate(c.Request.Context(), tenantID, id); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// =========================================================================
+// Checklists
+// =========================================================================
+
+// ListChecklists returns staff checklists.
+// @Summary List staff checklists
+// @Description List onboarding checklists and exit workflows with their progress
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param staffId query string false "Staff ID"
+// @Param kind query string false "Kind (onboarding, exit)"
+// @Param status query string false "Status (open, completed, cancelled)"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=ChecklistListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists [get]
+func (h *Handler) ListChecklists(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := ChecklistFilter{TenantID: tenantID}
+	if !middleware.ParseUUIDQuery(c, "staffId", &filter.StaffID) ||
+		!parseKindQuery(c, &filter.Kind) ||
+		!middleware.ParsePaging(c, &filter.Limit, &filter.Offset) {
+		return
+	}
+	if value := c.Query("status"); value != "" {
+		status := models.StaffChecklistStatus(value)
+		if !status.IsValid() {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid status"))
+			return
+		}
+		filter.Status = &status
+	}
+
+	checklists, total, err := h.service.ListChecklists(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, toChecklistListResponse(checklists, total))
+}
+
+// GetChecklist returns a staff checklist.
+// @Summary Get staff checklist
+// @Description Get an onboarding checklist or exit workflow with its tasks
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Checklist ID"
+// @Success 200 {object} response.Success{data=ChecklistResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists/{id} [get]
+func (h *Handler) GetChecklist(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+
+	checklist, err := h.service.GetChecklist(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToChecklistResponse(checklist, true))
+}
+
+// CancelChecklist cancels a staff checklist.
+// @Summary Cancel staff checklist
+// @Description Cancel an open checklist, for example when a resignation is withdrawn
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Checklist ID"
+// @Success 200 {object} response.Success{data=ChecklistResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists/{id}/cancel [post]
+func (h *Handler) CancelChecklist(c *gin.Context) {
+	tenantID, id, ok := middleware.TenantAndID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+
+	checklist, err := h.service.CancelChecklist(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToChecklistResponse(checklist, true))
+}
+
+// ListStaffChecklists returns a staff member's checklists.
+// @Summary List staff member checklists
+// @Description List a staff member's onboarding checklists and exit workflows
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Success 200 {object} response.Success{data=ChecklistListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/checklists [get]
+func (h *Handler) ListStaffChecklists(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+
+	checklists, total, err := h.service.ListStaffChecklists(c.Request.Context(), tenantID, staffID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, toChecklistListResponse(checklists, total))
+}
+
+// StartOnboarding opens a staff member's onboarding checklist.
+// @Summary Start onboarding
+// @Description Open an onboarding checklist for a staff member. This happens automatically when staff are created; use it for staff added before checklists were configured or after a checklist was cancelled.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Success 201 {object} response.Success{data=ChecklistResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/onboarding [post]
+func (h *Handler) StartOnboarding(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	checklist, err := h.service.StartOnboarding(c.Request.Context(), tenantID, staffID, &userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToChecklistResponse(checklist, true))
+}
+
+// StartExit opens a staff member's exit workflow.
+// @Summary Start exit
+// @Description Open an exit workflow when a staff member resigns or is let go: department clearances, asset return, full-and-final settlement and relieving and experience letters. Terminating staff opens one automatically if none is open.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Param request body StartExitRequest true "Exit details"
+// @Success 201 {object} response.Success{data=ChecklistResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/exit [post]
+func (h *Handler) StartExit(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req StartExitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+	lastWorkingDay, ok := middleware.ParseDate(c, &req.LastWorkingDay, "lastWorkingDay")
+	if !ok {
+		return
+	}
+
+	checklist, err := h.service.StartExit(c.Request.Context(), tenantID, staffID, *lastWorkingDay, req.Reason, &userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToChecklistResponse(checklist, true))
+}
+
+// =========================================================================
+// Tasks
+// =========================================================================
+
+// ListTasks returns checklist tasks.
+// @Summary List checklist tasks
+// @Description List checklist tasks across staff, for example everything assigned to a role or overdue
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param checklistId query string false "Checklist ID"
+// @Param staffId query string false "Staff ID"
+// @Param kind query string false "Kind (onboarding, exit)"
+// @Param roleId query string false "Assigned role ID"
+// @Param userId query string false "Assigned user ID"
+// @Param status query string false "Status (pending, completed, waived)"
+// @Param overdue query bool false "Only pending tasks past their due date"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=TaskListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-tasks [get]
+func (h *Handler) ListTasks(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := TaskFilter{TenantID: tenantID, OverdueOnly: c.Query("overdue") == "true"}
+	if !middleware.ParseUUIDQuery(c, "checklistId", &filter.ChecklistID) ||
+		!middleware.ParseUUIDQuery(c, "staffId", &filter.StaffID) ||
+		!middleware.ParseUUIDQuery(c, "roleId", &filter.AssigneeRoleID) ||
+		!middleware.ParseUUIDQuery(c, "userId", &filter.AssigneeUserID) ||
+		!parseKindQuery(c, &filter.Kind) ||
+		!parseTaskStatusQuery(c, &filter.Status) ||
+		!middleware.ParsePaging(c, &filter.Limit, &filter.Offset) {
+		return
+	}
+
+	h.listTasks(c, filter)
+}
+
+// ListMyTasks returns the current user's checklist tasks.
+// @Summary List my checklist tasks
+// @Description List open checklist tasks assigned to the current user or any of their roles. Defaults to pending tasks.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param status query string false "Status (pending, completed, waived)" default(pending)
+// @Param overdue query bool false "Only tasks past their due date"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Success{data=TaskListResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-tasks/mine [get]
+func (h *Handler) ListMyTasks(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.BadRequest("Tenant ID is required"))
+		return
+	}
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.Unauthorized("User not authenticated"))
+		return
+	}
+
+	filter := TaskFilter{
+		TenantID:    tenantID,
+		ForUserID:   &userID,
+		OpenOnly:    true,
+		OverdueOnly: c.Query("overdue") == "true",
+	}
+	if !parseTaskStatusQuery(c, &filter.Status) || !middleware.ParsePaging(c, &filter.Limit, &filter.Offset) {
+		return
+	}
+	if filter.Status == nil {
+		pending := models.ChecklistTaskStatusPending
+		filter.Status = &pending
+	}
+
+	h.listTasks(c, filter)
+}
+
+func (h *Handler) listTasks(c *gin.Context, filter TaskFilter) {
+	tasks, total, err := h.service.ListTasks(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := TaskListResponse{Tasks: make([]TaskResponse, len(tasks)), Total: total}
+	for i := range tasks {
+		resp.Tasks[i] = ToTaskResponse(&tasks[i])
+	}
+	response.OK(c, resp)
+}
+
+// AddTask adds a task to a checklist.
+// @Summary Add checklist task
+// @Description Add an ad hoc task to an open checklist, such as clearance from another department
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Checklist ID"
+// @Param request body AddTaskRequest true "Task"
+// @Success 201 {object} response.Success{data=TaskResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists/{id}/tasks [post]
+func (h *Handler) AddTask(c *gin.Context) {
+	tenantID, checklistID, ok := middleware.TenantAndID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+
+	var req AddTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	dto := AddTaskDTO{
+		TenantID:    tenantID,
+		ChecklistID: checklistID,
+		Title:       req.Title,
+		Description: req.Description,
+		TaskType:    models.ChecklistTaskType(req.TaskType),
+		LetterType:  toLetterType(req.LetterType),
+	}
+	if dto.DepartmentID, ok = middleware.ParseOptionalUUID(c, req.DepartmentID, "Invalid department ID"); !ok {
+		return
+	}
+	if dto.AssigneeRoleID, ok = middleware.ParseOptionalUUID(c, req.AssigneeRoleID, "Invalid role ID"); !ok {
+		return
+	}
+	if dto.AssigneeUserID, ok = middleware.ParseOptionalUUID(c, req.AssigneeUserID, "Invalid user ID"); !ok {
+		return
+	}
+	if dto.DueDate, ok = middleware.ParseDate(c, req.DueDate, "dueDate"); !ok {
+		return
+	}
+
+	task, err := h.service.AddTask(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToTaskResponse(task))
+}
+
+// AssignTask reassigns a checklist task.
+// @Summary Assign checklist task
+// @Description Set the role and/or user a task is assigned to and its due date. The values replace the task's current ones; omit a field to clear it.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Task ID"
+// @Param request body AssignTaskRequest true "Assignment"
+// @Success 200 {object} response.Success{data=TaskResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-tasks/{id}/assignment [put]
+func (h *Handler) AssignTask(c *gin.Context) {
+	tenantID, taskID, ok := middleware.TenantAndID(c, "id", "Invalid task ID")
+	if !ok {
+		return
+	}
+
+	var req AssignTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	dto := AssignTaskDTO{TenantID: tenantID, TaskID: taskID}
+	if dto.AssigneeRoleID, ok = middleware.ParseOptionalUUID(c, req.AssigneeRoleID, "Invalid role ID"); !ok {
+		return
+	}
+	if dto.AssigneeUserID, ok = middleware.ParseOptionalUUID(c, req.AssigneeUserID, "Invalid user ID"); !ok {
+		return
+	}
+	if dto.DueDate, ok = middleware.ParseDate(c, req.DueDate, "dueDate"); !ok {
+		return
+	}
+
+	task, err := h.service.AssignTask(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTaskResponse(task))
+}
+
+// CompleteTask completes a checklist task.
+// @Summary Complete checklist task
+// @Description Mark a task complete. Only the assigned user or a holder of the assigned role may complete it, unless the caller can manage checklists. Document, asset, salary and settlement tasks are checked against the underlying records.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Task ID"
+// @Param request body TaskNotesRequest false "Notes"
+// @Success 200 {object} response.Success{data=TaskResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 403 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-tasks/{id}/complete [post]
+func (h *Handler) CompleteTask(c *gin.Context) {
+	dto, ok := bindTaskNotes(c)
+	if !ok {
+		return
+	}
+	dto.Override = middleware.HasPermission(c, "staff_checklists.manage")
+
+	task, err := h.service.CompleteTask(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTaskResponse(task))
+}
+
+// WaiveTask waives a checklist task.
+// @Summary Waive checklist task
+// @Description Mark a task as not needed for this staff member, recording the reason in the notes
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Task ID"
+// @Param request body TaskNotesRequest true "Reason"
+// @Success 200 {object} response.Success{data=TaskResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklist-tasks/{id}/waive [post]
+func (h *Handler) WaiveTask(c *gin.Context) {
+	dto, ok := bindTaskNotes(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.WaiveTask(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToTaskResponse(task))
+}
+
+// =========================================================================
+// Assets
+// =========================================================================
+
+// ListStaffAssets returns the assets issued to a staff member.
+// @Summary List staff assets
+// @Description List the school assets issued to a staff member and whether each has been returned
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Success 200 {object} response.Success{data=[]AssetResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/assets [get]
+func (h *Handler) ListStaffAssets(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+
+	assets, err := h.service.ListStaffAssets(c.Request.Context(), tenantID, staffID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := make([]AssetResponse, len(assets))
+	for i := range assets {
+		resp[i] = ToAssetResponse(&assets[i])
+	}
+	response.OK(c, resp)
+}
+
+// IssueAsset records an asset issued to a staff member.
+// @Summary Issue staff asset
+// @Description Record a school asset (laptop, ID card, keys) issued to a staff member. Completes their onboarding asset issue task.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Param request body IssueAssetRequest true "Asset"
+// @Success 201 {object} response.Success{data=AssetResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/assets [post]
+func (h *Handler) IssueAsset(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req IssueAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+	issuedOn, ok := middleware.ParseDate(c, req.IssuedOn, "issuedOn")
+	if !ok {
+		return
+	}
+
+	dto := IssueAssetDTO{
+		TenantID:    tenantID,
+		StaffID:     staffID,
+		Name:        req.Name,
+		AssetTag:    req.AssetTag,
+		Description: req.Description,
+		UserID:      &userID,
+	}
+	if issuedOn != nil {
+		dto.IssuedOn = *issuedOn
+	}
+
+	asset, err := h.service.IssueAsset(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.Created(c, ToAssetResponse(asset))
+}
+
+// ReturnAsset records an asset returned by a staff member.
+// @Summary Return staff asset
+// @Description Record an asset returned, with its condition and any recovery amount for damage. For an asset that was lost, give a recovery amount without a return date; it is deducted in the full-and-final settlement. Completes the exit asset return task once every asset is cleared.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Staff ID"
+// @Param assetId path string true "Asset ID"
+// @Param request body ReturnAssetRequest true "Return details"
+// @Success 200 {object} response.Success{data=AssetResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff/{id}/assets/{assetId}/return [post]
+func (h *Handler) ReturnAsset(c *gin.Context) {
+	tenantID, staffID, ok := middleware.TenantAndID(c, "id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+	assetID, err := uuid.Parse(c.Param("assetId"))
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid asset ID"))
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req ReturnAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	dto := ReturnAssetDTO{
+		TenantID:  tenantID,
+		StaffID:   staffID,
+		AssetID:   assetID,
+		Condition: req.Condition,
+		UserID:    &userID,
+	}
+	if dto.ReturnedOn, ok = middleware.ParseDate(c, req.ReturnedOn, "returnedOn"); !ok {
+		return
+	}
+	if req.RecoveryAmount != nil && *req.RecoveryAmount != "" {
+		amount, ok := parseDecimal(c, *req.RecoveryAmount, "recoveryAmount")
+		if !ok {
+			return
+		}
+		dto.RecoveryAmount = &amount
+	}
+
+	asset, err := h.service.ReturnAsset(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToAssetResponse(asset))
+}
+
+// =========================================================================
+// Settlement and Letters
+// =========================================================================
+
+// GetSettlement returns an exit's full-and-final settlement.
+// @Summary Get exit settlement
+// @Description Get the full-and-final settlement for an exit workflow
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Checklist ID"
+// @Success 200 {object} response.Success{data=SettlementResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists/{id}/settlement [get]
+func (h *Handler) GetSettlement(c *gin.Context) {
+	tenantID, checklistID, ok := middleware.TenantAndID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+
+	settlement, err := h.service.GetSettlement(c.Request.Context(), tenantID, checklistID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToSettlementResponse(settlement))
+}
+
+// CalculateSettlement calculates an exit's full-and-final settlement.
+// @Summary Calculate exit settlement
+// @Description Calculate, or recalculate, the draft full-and-final settlement from the staff member's current salary: pro-rata pay for the final month unless its pay run is already approved, gratuity after five years' service, leave encashment for the days given, other earnings, less recovery for unreturned assets and other deductions.
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Checklist ID"
+// @Param request body SettlementRequest true "Settlement inputs"
+// @Success 200 {object} response.Success{data=SettlementResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists/{id}/settlement [put]
+func (h *Handler) CalculateSettlement(c *gin.Context) {
+	tenantID, checklistID, ok := middleware.TenantAndID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req SettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	dto := SettlementDTO{TenantID: tenantID, ChecklistID: checklistID, Notes: req.Notes, UserID: &userID}
+	if dto.LeaveEncashmentDays, ok = parseDecimal(c, req.LeaveEncashmentDays, "leaveEncashmentDays"); !ok {
+		return
+	}
+	if dto.OtherEarnings, ok = parseDecimal(c, req.OtherEarnings, "otherEarnings"); !ok {
+		return
+	}
+	if dto.OtherDeductions, ok = parseDecimal(c, req.OtherDeductions, "otherDeductions"); !ok {
+		return
+	}
+
+	settlement, err := h.service.CalculateSettlement(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToSettlementResponse(settlement))
+}
+
+// ApproveSettlement approves an exit's full-and-final settlement.
+// @Summary Approve exit settlement
+// @Description Approve the draft settlement, completing the exit's settlement task
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Checklist ID"
+// @Success 200 {object} response.Success{data=SettlementResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists/{id}/settlement/approve [post]
+func (h *Handler) ApproveSettlement(c *gin.Context) {
+	tenantID, checklistID, ok := middleware.TenantAndID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	settlement, err := h.service.ApproveSettlement(c.Request.Context(), tenantID, checklistID, &userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToSettlementResponse(settlement))
+}
+
+// MarkSettlementPaid records payment of an exit's settlement.
+// @Summary Mark exit settlement paid
+// @Description Record payment of an approved full-and-final settlement
+// @Tags Staff Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Checklist ID"
+// @Param request body SettlementPaymentRequest true "Payment"
+// @Success 200 {object} response.Success{data=SettlementResponse}
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists/{id}/settlement/paid [post]
+func (h *Handler) MarkSettlementPaid(c *gin.Context) {
+	tenantID, checklistID, ok := middleware.TenantAndID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+
+	var req SettlementPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+		return
+	}
+	paidOn, ok := middleware.ParseDate(c, req.PaidOn, "paidOn")
+	if !ok {
+		return
+	}
+
+	dto := SettlementPaymentDTO{TenantID: tenantID, ChecklistID: checklistID, PaymentReference: req.PaymentReference}
+	if paidOn != nil {
+		dto.PaidOn = *paidOn
+	}
+
+	settlement, err := h.service.MarkSettlementPaid(c.Request.Context(), dto)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response.OK(c, ToSettlementResponse(settlement))
+}
+
+// GenerateLetter downloads a relieving or experience letter.
+// @Summary Generate exit letter
+// @Description Download a relieving or experience letter as a PDF and complete the matching letter task. A relieving letter needs department clearance, asset return and an approved settlement first.
+// @Tags Staff Checklists
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Checklist ID"
+// @Param type path string true "Letter type (relieving, experience)"
+// @Success 200 {file} binary
+// @Failure 400 {object} apperrors.AppError
+// @Failure 404 {object} apperrors.AppError
+// @Failure 409 {object} apperrors.AppError
+// @Router /api/v1/staff-checklists/{id}/letters/{type} [get]
+func (h *Handler) GenerateLetter(c *gin.Context) {
+	tenantID, checklistID, ok := middleware.TenantAndID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	pdf, filename, err := h.service.GenerateLetter(c.Request.Context(), tenantID, checklistID,
+		models.StaffLetterType(c.Param("type")), &userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// =========================================================================
+// Helpers
+// =========================================================================
+
+// bindTaskNotes reads the task ID, current user and optional notes for
+// completing or waiving a task.
+func bindTaskNotes(c *gin.Context) (CompleteTaskDTO, bool) {
+	tenantID, taskID, ok := middleware.TenantAndID(c, "id", "Invalid task ID")
+	if !ok {
+		return CompleteTaskDTO{}, false
+	}
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperrors.Abort(c, apperrors.Unauthorized("User not authenticated"))
+		return CompleteTaskDTO{}, false
+	}
+
+	var req TaskNotesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apperrors.Abort(c, apperrors.BadRequest("Invalid request body: "+err.Error()))
+			return CompleteTaskDTO{}, false
+		}
+	}
+
+	return CompleteTaskDTO{TenantID: tenantID, TaskID: taskID, UserID: userID, Notes: req.Notes}, true
+}
+
+// parseKindQuery parses the optional kind query parameter.
+func parseKindQuery(c *gin.Context, target **models.ChecklistKind) bool {
+	value := c.Query("kind")
+	if value == "" {
+		return true
+	}
+	kind := models.ChecklistKind(value)
+	if !kind.IsValid() {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid kind"))
+		return false
+	}
+	*target = &kind
+	return true
+}
+
+// parseTaskStatusQuery parses the optional task status query parameter.
+func parseTaskStatusQuery(c *gin.Context, target **models.ChecklistTaskStatus) bool {
+	value := c.Query("status")
+	if value == "" {
+		return true
+	}
+	status := models.ChecklistTaskStatus(value)
+	if !status.IsValid() {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid status"))
+		return false
+	}
+	*target = &status
+	return true
+}
+
+// parseDecimal parses an optional decimal amount from a request body; blank is zero.
+func parseDecimal(c *gin.Context, value, name string) (decimal.Decimal, bool) {
+	if value == "" {
+		return decimal.Zero, true
+	}
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		apperrors.Abort(c, apperrors.BadRequest("Invalid "+name))
+		return decimal.Zero, false
+	}
+	return amount, true
+}
+
+func toLetterType(value *string) *models.StaffLetterType {
+	if value == nil || *value == "" {
+		return nil
+	}
+	letterType := models.StaffLetterType(*value)
+	return &letterType
+}
+
+func toItemDTOs(c *gin.Context, items []ItemRequest) ([]ItemDTO, bool) {
+	result := make([]ItemDTO, len(items))
+	for i, item := range items {
+		result[i] = ItemDTO{
+			Title:         item.Title,
+			Description:   item.Description,
+			TaskType:      models.ChecklistTaskType(item.TaskType),
+			LetterType:    toLetterType(item.LetterType),
+			DueOffsetDays: item.DueOffsetDays,
+		}
+		var ok bool
+		if result[i].DepartmentID, ok = middleware.ParseOptionalUUID(c, item.DepartmentID, "Invalid department ID"); !ok {
+			return nil, false
+		}
+		if result[i].AssigneeRoleID, ok = middleware.ParseOptionalUUID(c, item.AssigneeRoleID, "Invalid role ID"); !ok {
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+func toChecklistListResponse(checklists []models.StaffChecklist, total int64) ChecklistListResponse {
+	resp := ChecklistListResponse{Checklists: make([]ChecklistResponse, len(checklists)), Total: total}
+	for i := range checklists {
+		resp.Checklists[i] = ToChecklistResponse(&checklists[i], false)
+	}
+	return resp
+}
+
+// handleServiceError converts service errors to API errors.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrTemplateNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Checklist template not found"))
+	case errors.Is(err, ErrChecklistNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff checklist not found"))
+	case errors.Is(err, ErrTaskNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Checklist task not found"))
+	case errors.Is(err, ErrAssetNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff asset not found"))
+	case errors.Is(err, ErrSettlementNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Exit settlement not found"))
+	case errors.Is(err, ErrStaffNotFound):
+		apperrors.Abort(c, apperrors.NotFound("Staff not found"))
+	case errors.Is(err, ErrNotAssignee):
+		apperrors.Abort(c, apperrors.Forbidden(err.Error()))
+	case errors.Is(err, ErrNameRequired),
+		errors.Is(err, ErrTitleRequired),
+		errors.Is(err, ErrInvalidKind),
+		errors.Is(err, ErrInvalidStaffType),
+		errors.Is(err, ErrInvalidTaskType),
+		errors.Is(err, ErrLetterTypeRequired),
+		errors.Is(err, ErrNotExitChecklist),
+		errors.Is(err, ErrLastWorkingDayRequired),
+		errors.Is(err, ErrInvalidLastWorkingDay),
+		errors.Is(err, ErrWaiverReasonRequired),
+		errors.Is(err, ErrDocumentNotVerified),
+		errors.Is(err, ErrNoAssetsIssued),
+		errors.Is(err, ErrNoCurrentSalary),
+		errors.Is(err, ErrAssetsOutstanding),
+		errors.Is(err, ErrInvalidAmount),
+		errors.Is(err, ErrInvalidLetterType):
+		apperrors.Abort(c, apperrors.BadRequest(err.Error()))
+	case errors.Is(err, ErrDuplicateTemplate),
+		errors.Is(err, ErrChecklistExists),
+		errors.Is(err, ErrChecklistClosed),
+		errors.Is(err, ErrTaskDone),
+		errors.Is(err, ErrAssetReturned),
+		errors.Is(err, ErrSettlementNotDraft),
+		errors.Is(err, ErrSettlementNotApproved),
+		errors.Is(err, ErrClearancePending):
+		apperrors.Abort(c, apperrors.Conflict(err.Error()))
+	default:
+		logger.Error("Staff checklist operation error", zap.Error(err))
+		apperrors.Abort(c, apperrors.InternalError("Failed to process staff checklist request"))
+	}
+}
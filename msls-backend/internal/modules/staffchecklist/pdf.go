@@ -0,0 +1,112 @@
+// Package staffchecklist provides staff onboarding checklists and exit workflows.
+package staffchecklist
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// letterData holds what a relieving or experience letter is printed from.
+type letterData struct {
+	Type           models.StaffLetterType
+	SchoolName     string
+	Staff          *models.Staff
+	LastWorkingDay time.Time
+	IssuedOn       time.Time
+}
+
+// letterParagraphs returns the body of a letter.
+func letterParagraphs(letter letterData) []string {
+	staff := letter.Staff
+	designation := "staff member"
+	if staff.Designation != nil {
+		designation = staff.Designation.Name
+	}
+	department := ""
+	if staff.Department != nil {
+		department = " in the " + staff.Department.Name + " department"
+	}
+	joined := staff.JoinDate.Format("02 January 2006")
+	lastDay := letter.LastWorkingDay.Format("02 January 2006")
+
+	if letter.Type == models.StaffLetterTypeRelieving {
+		return []string{
+			fmt.Sprintf("This is to certify that %s (Employee ID %s), %s%s, has been relieved of their duties at %s with effect from the close of working hours on %s.",
+				staff.FullName(), staff.EmployeeID, designation, department, letter.SchoolName, lastDay),
+			"They have handed over their responsibilities and school property, obtained clearance from all departments, and their full-and-final settlement has been approved.",
+			"We wish them every success in their future endeavours.",
+		}
+	}
+	return []string{
+		fmt.Sprintf("This is to certify that %s (Employee ID %s) worked with %s from %s to %s. At the time of leaving they held the position of %s%s.",
+			staff.FullName(), staff.EmployeeID, letter.SchoolName, joined, lastDay, designation, department),
+		"During their tenure we found them sincere and hardworking, and their conduct was good.",
+		"We wish them every success in their future endeavours.",
+	}
+}
+
+// renderLetter renders a relieving or experience letter on the school letterhead.
+func renderLetter(letter letterData) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth := 170.0 // 210 - 40 (margins)
+
+	primaryColor := []int{31, 41, 55}
+	accentColor := []int{16, 185, 129}
+	mutedText := []int{107, 114, 128}
+
+	// Letterhead
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	pdf.SetFont("Arial", "B", 18)
+	pdf.CellFormat(pageWidth, 10, tr(letter.SchoolName), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+	pdf.SetDrawColor(accentColor[0], accentColor[1], accentColor[2])
+	pdf.SetLineWidth(0.8)
+	pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+	pdf.Ln(6)
+
+	// Reference and date
+	reference := fmt.Sprintf("%s/%s/%d", strings.ToUpper(string(letter.Type[:3])), letter.Staff.EmployeeID, letter.IssuedOn.Year())
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(pageWidth/2, 6, tr("Ref: "+reference), "", 0, "L", false, 0, "")
+	pdf.CellFormat(pageWidth/2, 6, "Date: "+letter.IssuedOn.Format("02 Jan 2006"), "", 1, "R", false, 0, "")
+	pdf.Ln(8)
+
+	// Title
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(pageWidth, 10, strings.ToUpper(letterTitle(letter.Type)), "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	pdf.CellFormat(pageWidth, 6, "TO WHOMSOEVER IT MAY CONCERN", "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	// Body
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	pdf.SetFont("Arial", "", 11)
+	for _, paragraph := range letterParagraphs(letter) {
+		pdf.MultiCell(pageWidth, 6, tr(paragraph), "", "J", false)
+		pdf.Ln(3)
+	}
+	pdf.Ln(20)
+
+	// Signatory
+	pdf.SetFont("Arial", "B", 11)
+	pdf.CellFormat(pageWidth, 6, "Principal / Authorised Signatory", "", 1, "R", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(pageWidth, 6, tr(letter.SchoolName), "", 1, "R", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("generate %s letter pdf: %w", letter.Type, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,511 @@
+// Package staffchecklist provides staff onboarding checklists and exit workflows.
+package staffchecklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// Repository handles database operations for staff checklists.
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new staff checklist repository.
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// =========================================================================
+// Template Operations
+// =========================================================================
+
+// ListTemplates retrieves the tenant's checklist templates with their items.
+func (r *Repository) ListTemplates(ctx context.Context, tenantID uuid.UUID, kind *models.ChecklistKind, activeOnly bool) ([]models.ChecklistTemplate, error) {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if kind != nil {
+		query = query.Where("kind = ?", *kind)
+	}
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var templates []models.ChecklistTemplate
+	err := query.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Preload("Items.Department").
+		Preload("Items.AssigneeRole").
+		Order("kind ASC, name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("list checklist templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetTemplate retrieves a template with its items.
+func (r *Repository) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*models.ChecklistTemplate, error) {
+	var template models.ChecklistTemplate
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Preload("Items.Department").
+		Preload("Items.AssigneeRole").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("get checklist template: %w", err)
+	}
+	return &template, nil
+}
+
+// ActiveTemplateExists checks whether an active template of the kind already
+// covers a staff type (or all staff when staffType is nil).
+func (r *Repository) ActiveTemplateExists(ctx context.Context, tenantID uuid.UUID, kind models.ChecklistKind, staffType *models.StaffType, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&models.ChecklistTemplate{}).
+		Where("tenant_id = ? AND kind = ? AND is_active = ?", tenantID, kind, true)
+	if staffType != nil {
+		query = query.Where("staff_type = ?", *staffType)
+	} else {
+		query = query.Where("staff_type IS NULL")
+	}
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check checklist template: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CreateTemplate creates a template with its items.
+func (r *Repository) CreateTemplate(ctx context.Context, template *models.ChecklistTemplate) error {
+	if err := r.db.WithContext(ctx).Create(template).Error; err != nil {
+		return fmt.Errorf("create checklist template: %w", err)
+	}
+	return nil
+}
+
+// UpdateTemplate saves a template, replacing its items when replaceItems is set.
+func (r *Repository) UpdateTemplate(ctx context.Context, template *models.ChecklistTemplate, replaceItems bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Save(template).Error; err != nil {
+			return fmt.Errorf("update checklist template: %w", err)
+		}
+		if !replaceItems {
+			return nil
+		}
+		if err := tx.Where("template_id = ?", template.ID).Delete(&models.ChecklistTemplateItem{}).Error; err != nil {
+			return fmt.Errorf("delete template items: %w", err)
+		}
+		if len(template.Items) > 0 {
+			if err := tx.Omit("Department", "AssigneeRole").Create(&template.Items).Error; err != nil {
+				return fmt.Errorf("create template items: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteTemplate deletes a template and its items.
+func (r *Repository) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", id).Delete(&models.ChecklistTemplateItem{}).Error; err != nil {
+			return fmt.Errorf("delete template items: %w", err)
+		}
+		result := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&models.ChecklistTemplate{})
+		if result.Error != nil {
+			return fmt.Errorf("delete checklist template: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrTemplateNotFound
+		}
+		return nil
+	})
+}
+
+// =========================================================================
+// Checklist Operations
+// =========================================================================
+
+// CreateChecklist creates a checklist with its tasks.
+func (r *Repository) CreateChecklist(ctx context.Context, checklist *models.StaffChecklist) error {
+	if err := r.db.WithContext(ctx).Omit("Staff", "Template").Create(checklist).Error; err != nil {
+		return fmt.Errorf("create staff checklist: %w", err)
+	}
+	return nil
+}
+
+// GetChecklist retrieves a checklist with its staff member and tasks.
+func (r *Repository) GetChecklist(ctx context.Context, tenantID, id uuid.UUID) (*models.StaffChecklist, error) {
+	var checklist models.StaffChecklist
+	err := r.db.WithContext(ctx).
+		Preload("Staff").
+		Preload("Staff.Shift").
+		Preload("Template").
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Preload("Tasks.DocumentType").
+		Preload("Tasks.Department").
+		Preload("Tasks.AssigneeRole").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&checklist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrChecklistNotFound
+		}
+		return nil, fmt.Errorf("get staff checklist: %w", err)
+	}
+	return &checklist, nil
+}
+
+// FindOpenChecklist retrieves a staff member's open checklist of a kind with
+// its tasks, or nil if there is none.
+func (r *Repository) FindOpenChecklist(ctx context.Context, tenantID, staffID uuid.UUID, kind models.ChecklistKind) (*models.StaffChecklist, error) {
+	var checklist models.StaffChecklist
+	err := r.db.WithContext(ctx).
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Where("tenant_id = ? AND staff_id = ? AND kind = ? AND status = ?",
+			tenantID, staffID, kind, models.StaffChecklistStatusOpen).
+		First(&checklist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find open staff checklist: %w", err)
+	}
+	return &checklist, nil
+}
+
+// ListChecklists retrieves checklists matching the filter, newest first.
+func (r *Repository) ListChecklists(ctx context.Context, filter ChecklistFilter) ([]models.StaffChecklist, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.StaffChecklist{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.StaffID != nil {
+		query = query.Where("staff_id = ?", *filter.StaffID)
+	}
+	if filter.Kind != nil {
+		query = query.Where("kind = ?", *filter.Kind)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count staff checklists: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var checklists []models.StaffChecklist
+	err := query.
+		Preload("Staff").
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Order("created_at DESC").
+		Find(&checklists).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("list staff checklists: %w", err)
+	}
+	return checklists, total, nil
+}
+
+// UpdateChecklist saves a checklist's own fields.
+func (r *Repository) UpdateChecklist(ctx context.Context, checklist *models.StaffChecklist) error {
+	if err := r.db.WithContext(ctx).Omit("Staff", "Template", "Tasks").Save(checklist).Error; err != nil {
+		return fmt.Errorf("update staff checklist: %w", err)
+	}
+	return nil
+}
+
+// =========================================================================
+// Task Operations
+// =========================================================================
+
+// GetTask retrieves a task with its checklist.
+func (r *Repository) GetTask(ctx context.Context, tenantID, id uuid.UUID) (*models.StaffChecklistTask, error) {
+	var task models.StaffChecklistTask
+	err := r.db.WithContext(ctx).
+		Preload("Checklist").
+		Preload("Checklist.Staff").
+		Preload("DocumentType").
+		Preload("Department").
+		Preload("AssigneeRole").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("get checklist task: %w", err)
+	}
+	return &task, nil
+}
+
+// CreateTask creates a task.
+func (r *Repository) CreateTask(ctx context.Context, task *models.StaffChecklistTask) error {
+	if err := r.db.WithContext(ctx).Create(task).Error; err != nil {
+		return fmt.Errorf("create checklist task: %w", err)
+	}
+	return nil
+}
+
+// UpdateTask saves a task's own fields.
+func (r *Repository) UpdateTask(ctx context.Context, task *models.StaffChecklistTask) error {
+	err := r.db.WithContext(ctx).
+		Omit("Checklist", "DocumentType", "Department", "AssigneeRole").
+		Save(task).Error
+	if err != nil {
+		return fmt.Errorf("update checklist task: %w", err)
+	}
+	return nil
+}
+
+// ListTasks retrieves tasks matching the filter, soonest due first.
+func (r *Repository) ListTasks(ctx context.Context, filter TaskFilter) ([]models.StaffChecklistTask, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.StaffChecklistTask{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.ChecklistID != nil {
+		query = query.Where("checklist_id = ?", *filter.ChecklistID)
+	}
+	if filter.StaffID != nil {
+		query = query.Where("checklist_id IN (SELECT id FROM staff_checklists WHERE staff_id = ?)", *filter.StaffID)
+	}
+	if filter.Kind != nil {
+		query = query.Where("checklist_id IN (SELECT id FROM staff_checklists WHERE kind = ?)", *filter.Kind)
+	}
+	if filter.OpenOnly {
+		query = query.Where("checklist_id IN (SELECT id FROM staff_checklists WHERE status = ?)", models.StaffChecklistStatusOpen)
+	}
+	if filter.AssigneeRoleID != nil {
+		query = query.Where("assignee_role_id = ?", *filter.AssigneeRoleID)
+	}
+	if filter.AssigneeUserID != nil {
+		query = query.Where("assignee_user_id = ?", *filter.AssigneeUserID)
+	}
+	if filter.ForUserID != nil {
+		query = query.Where("(assignee_user_id = ? OR assignee_role_id IN (SELECT role_id FROM user_roles WHERE user_id = ?))",
+			*filter.ForUserID, *filter.ForUserID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.OverdueOnly {
+		query = query.Where("status = ? AND due_date < CURRENT_DATE", models.ChecklistTaskStatusPending)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count checklist tasks: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var tasks []models.StaffChecklistTask
+	err := query.
+		Preload("Checklist").
+		Preload("Checklist.Staff").
+		Preload("DocumentType").
+		Preload("Department").
+		Preload("AssigneeRole").
+		Order("due_date ASC NULLS LAST, display_order ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("list checklist tasks: %w", err)
+	}
+	return tasks, total, nil
+}
+
+// ListUserRoleIDs retrieves the IDs of the roles a user holds.
+func (r *Repository) ListUserRoleIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var roleIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.UserRole{}).
+		Where("user_id = ?", userID).
+		Pluck("role_id", &roleIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("list user roles: %w", err)
+	}
+	return roleIDs, nil
+}
+
+// =========================================================================
+// Staff, Document and Salary Lookups
+// =========================================================================
+
+// GetStaff retrieves a staff member with their department and designation.
+func (r *Repository) GetStaff(ctx context.Context, tenantID, id uuid.UUID) (*models.Staff, error) {
+	var staff models.Staff
+	err := r.db.WithContext(ctx).
+		Preload("Department").
+		Preload("Designation").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&staff).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStaffNotFound
+		}
+		return nil, fmt.Errorf("get staff: %w", err)
+	}
+	return &staff, nil
+}
+
+// GetSchoolName retrieves the tenant's name for letterheads.
+func (r *Repository) GetSchoolName(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	var tenant models.Tenant
+	if err := r.db.WithContext(ctx).First(&tenant, "id = ?", tenantID).Error; err != nil {
+		return "", fmt.Errorf("get tenant: %w", err)
+	}
+	return tenant.Name, nil
+}
+
+// ListMandatoryDocumentTypes retrieves the tenant's active mandatory staff document types.
+func (r *Repository) ListMandatoryDocumentTypes(ctx context.Context, tenantID uuid.UUID) ([]models.StaffDocumentType, error) {
+	var types []models.StaffDocumentType
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND is_mandatory = true AND is_active = true", tenantID).
+		Order("display_order ASC").
+		Find(&types).Error
+	if err != nil {
+		return nil, fmt.Errorf("list mandatory document types: %w", err)
+	}
+	return types, nil
+}
+
+// HasVerifiedDocument checks whether a staff member has a current, verified
+// document of a type.
+func (r *Repository) HasVerifiedDocument(ctx context.Context, tenantID, staffID, documentTypeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.StaffDocument{}).
+		Where("tenant_id = ? AND staff_id = ? AND document_type_id = ?", tenantID, staffID, documentTypeID).
+		Where("is_current = true AND verification_status = ?", models.VerificationStatusVerified).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("check staff document: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetCurrentSalary retrieves a staff member's current salary with its components.
+func (r *Repository) GetCurrentSalary(ctx context.Context, tenantID, staffID uuid.UUID) (*models.StaffSalary, error) {
+	var salary models.StaffSalary
+	err := r.db.WithContext(ctx).
+		Preload("Components").
+		Preload("Components.Component").
+		Where("tenant_id = ? AND staff_id = ? AND is_current = true", tenantID, staffID).
+		First(&salary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoCurrentSalary
+		}
+		return nil, fmt.Errorf("get current staff salary: %w", err)
+	}
+	return &salary, nil
+}
+
+// FinalMonthPaid checks whether an approved or finalized pay run already
+// includes a payslip for the staff member for the month.
+func (r *Repository) FinalMonthPaid(ctx context.Context, tenantID, staffID uuid.UUID, year, month int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Payslip{}).
+		Joins("JOIN pay_runs ON pay_runs.id = payslips.pay_run_id").
+		Where("payslips.tenant_id = ? AND payslips.staff_id = ?", tenantID, staffID).
+		Where("pay_runs.pay_period_year = ? AND pay_runs.pay_period_month = ?", year, month).
+		Where("pay_runs.status IN ?", []models.PayRunStatus{models.PayRunStatusApproved, models.PayRunStatusFinalized}).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("check final month payslip: %w", err)
+	}
+	return count > 0, nil
+}
+
+// =========================================================================
+// Asset Operations
+// =========================================================================
+
+// ListAssets retrieves the assets issued to a staff member.
+func (r *Repository) ListAssets(ctx context.Context, tenantID, staffID uuid.UUID) ([]models.StaffAsset, error) {
+	var assets []models.StaffAsset
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND staff_id = ?", tenantID, staffID).
+		Order("issued_on ASC, created_at ASC").
+		Find(&assets).Error
+	if err != nil {
+		return nil, fmt.Errorf("list staff assets: %w", err)
+	}
+	return assets, nil
+}
+
+// GetAsset retrieves a staff member's asset.
+func (r *Repository) GetAsset(ctx context.Context, tenantID, staffID, id uuid.UUID) (*models.StaffAsset, error) {
+	var asset models.StaffAsset
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND staff_id = ? AND id = ?", tenantID, staffID, id).
+		First(&asset).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAssetNotFound
+		}
+		return nil, fmt.Errorf("get staff asset: %w", err)
+	}
+	return &asset, nil
+}
+
+// CreateAsset creates an asset record.
+func (r *Repository) CreateAsset(ctx context.Context, asset *models.StaffAsset) error {
+	if err := r.db.WithContext(ctx).Create(asset).Error; err != nil {
+		return fmt.Errorf("create staff asset: %w", err)
+	}
+	return nil
+}
+
+// UpdateAsset saves an asset record.
+func (r *Repository) UpdateAsset(ctx context.Context, asset *models.StaffAsset) error {
+	if err := r.db.WithContext(ctx).Save(asset).Error; err != nil {
+		return fmt.Errorf("update staff asset: %w", err)
+	}
+	return nil
+}
+
+// =========================================================================
+// Settlement Operations
+// =========================================================================
+
+// GetSettlement retrieves the settlement for an exit checklist.
+func (r *Repository) GetSettlement(ctx context.Context, tenantID, checklistID uuid.UUID) (*models.ExitSettlement, error) {
+	var settlement models.ExitSettlement
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND checklist_id = ?", tenantID, checklistID).
+		First(&settlement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSettlementNotFound
+		}
+		return nil, fmt.Errorf("get exit settlement: %w", err)
+	}
+	return &settlement, nil
+}
+
+// SaveSettlement creates or updates a settlement.
+func (r *Repository) SaveSettlement(ctx context.Context, settlement *models.ExitSettlement) error {
+	if err := r.db.WithContext(ctx).Save(settlement).Error; err != nil {
+		return fmt.Errorf("save exit settlement: %w", err)
+	}
+	return nil
+}
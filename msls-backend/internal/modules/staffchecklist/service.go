@@ -0,0 +1,1163 @@
+// Package staffchecklist provides staff onboarding checklists and exit workflows.
+package staffchecklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// DocumentDueDays is how many days after joining mandatory documents are due.
+const DocumentDueDays = 7
+
+// GratuityMinimumYears is the continuous service needed before gratuity is payable.
+const GratuityMinimumYears = 5
+
+// GratuityCeiling is the statutory maximum gratuity.
+var GratuityCeiling = decimal.NewFromInt(2000000)
+
+// gratuityWageCodes are the salary components gratuity and leave encashment are paid on.
+var gratuityWageCodes = []string{"BASIC", "DA"}
+
+// Service handles onboarding and exit checklist business logic.
+type Service struct {
+	repo *Repository
+}
+
+// NewService creates a new staff checklist service.
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// =========================================================================
+// Templates
+// =========================================================================
+
+// ListTemplates returns the tenant's checklist templates.
+func (s *Service) ListTemplates(ctx context.Context, tenantID uuid.UUID, kind *models.ChecklistKind, activeOnly bool) ([]models.ChecklistTemplate, error) {
+	return s.repo.ListTemplates(ctx, tenantID, kind, activeOnly)
+}
+
+// GetTemplate returns a checklist template.
+func (s *Service) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*models.ChecklistTemplate, error) {
+	return s.repo.GetTemplate(ctx, tenantID, id)
+}
+
+// CreateTemplate creates an onboarding or exit template for a staff type, or
+// for all staff when no staff type is given.
+func (s *Service) CreateTemplate(ctx context.Context, dto CreateTemplateDTO) (*models.ChecklistTemplate, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if !dto.Kind.IsValid() {
+		return nil, ErrInvalidKind
+	}
+	if dto.StaffType != nil && !dto.StaffType.IsValid() {
+		return nil, ErrInvalidStaffType
+	}
+	if err := validateItems(dto.Kind, dto.Items); err != nil {
+		return nil, err
+	}
+	exists, err := s.repo.ActiveTemplateExists(ctx, dto.TenantID, dto.Kind, dto.StaffType, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrDuplicateTemplate
+	}
+
+	template := &models.ChecklistTemplate{
+		ID:        uuid.New(),
+		TenantID:  dto.TenantID,
+		Name:      name,
+		Kind:      dto.Kind,
+		StaffType: dto.StaffType,
+		IsActive:  true,
+		CreatedBy: dto.UserID,
+		UpdatedBy: dto.UserID,
+	}
+	template.Items = toTemplateItems(template.ID, dto.Items)
+
+	if err := s.repo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTemplate(ctx, dto.TenantID, template.ID)
+}
+
+// UpdateTemplate updates a checklist template. Items, when given, replace the
+// template's items; checklists already started keep their tasks.
+func (s *Service) UpdateTemplate(ctx context.Context, tenantID, id uuid.UUID, dto UpdateTemplateDTO) (*models.ChecklistTemplate, error) {
+	template, err := s.repo.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, ErrNameRequired
+		}
+		template.Name = name
+	}
+	if dto.IsActive != nil && *dto.IsActive && !template.IsActive {
+		exists, err := s.repo.ActiveTemplateExists(ctx, tenantID, template.Kind, template.StaffType, &template.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrDuplicateTemplate
+		}
+	}
+	if dto.IsActive != nil {
+		template.IsActive = *dto.IsActive
+	}
+	replaceItems := dto.Items != nil
+	if replaceItems {
+		if err := validateItems(template.Kind, dto.Items); err != nil {
+			return nil, err
+		}
+		template.Items = toTemplateItems(template.ID, dto.Items)
+	}
+	template.UpdatedBy = dto.UserID
+
+	if err := s.repo.UpdateTemplate(ctx, template, replaceItems); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTemplate(ctx, tenantID, id)
+}
+
+// DeleteTemplate deletes a checklist template. Checklists already started keep their tasks.
+func (s *Service) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return s.repo.DeleteTemplate(ctx, tenantID, id)
+}
+
+// =========================================================================
+// Checklists
+// =========================================================================
+
+// StartOnboarding opens a staff member's onboarding checklist: one task for
+// each mandatory document type that applies to their staff type, followed by
+// the tasks on the onboarding template for their staff type (or a default set
+// of account, asset issue and salary assignment tasks).
+func (s *Service) StartOnboarding(ctx context.Context, tenantID, staffID uuid.UUID, createdBy *uuid.UUID) (*models.StaffChecklist, error) {
+	staff, err := s.repo.GetStaff(ctx, tenantID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.repo.FindOpenChecklist(ctx, tenantID, staffID, models.ChecklistKindOnboarding)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, ErrChecklistExists
+	}
+
+	templates, err := s.repo.ListTemplates(ctx, tenantID, kindPtr(models.ChecklistKindOnboarding), true)
+	if err != nil {
+		return nil, err
+	}
+	documentTypes, err := s.repo.ListMandatoryDocumentTypes(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := pickTemplate(templates, staff.StaffType)
+	items := defaultOnboardingItems()
+	if template != nil {
+		items = template.Items
+	}
+
+	checklist := newChecklist(staff, models.ChecklistKindOnboarding, staff.JoinDate, template, createdBy)
+	checklist.Tasks = buildTasks(checklist, items, applicableDocumentTypes(documentTypes, staff.StaffType))
+	if err := s.repo.CreateChecklist(ctx, checklist); err != nil {
+		return nil, err
+	}
+	return s.repo.GetChecklist(ctx, tenantID, checklist.ID)
+}
+
+// StartExit opens a staff member's exit workflow: department clearances,
+// asset return, full-and-final settlement and relieving and experience
+// letters, from the exit template for their staff type or a default set.
+func (s *Service) StartExit(ctx context.Context, tenantID, staffID uuid.UUID, lastWorkingDay time.Time, reason string, createdBy *uuid.UUID) (*models.StaffChecklist, error) {
+	if lastWorkingDay.IsZero() {
+		return nil, ErrLastWorkingDayRequired
+	}
+	staff, err := s.repo.GetStaff(ctx, tenantID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if lastWorkingDay.Before(staff.JoinDate) {
+		return nil, ErrInvalidLastWorkingDay
+	}
+	open, err := s.repo.FindOpenChecklist(ctx, tenantID, staffID, models.ChecklistKindExit)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, ErrChecklistExists
+	}
+
+	templates, err := s.repo.ListTemplates(ctx, tenantID, kindPtr(models.ChecklistKindExit), true)
+	if err != nil {
+		return nil, err
+	}
+	template := pickTemplate(templates, staff.StaffType)
+	items := defaultExitItems(staff.DepartmentID)
+	if template != nil {
+		items = template.Items
+	}
+
+	checklist := newChecklist(staff, models.ChecklistKindExit, lastWorkingDay, template, createdBy)
+	checklist.ExitReason = strings.TrimSpace(reason)
+	checklist.Tasks = buildTasks(checklist, items, nil)
+	if err := s.repo.CreateChecklist(ctx, checklist); err != nil {
+		return nil, err
+	}
+	return s.repo.GetChecklist(ctx, tenantID, checklist.ID)
+}
+
+// EnsureExit returns a staff member's open exit workflow, starting one if
+// there is none. It is used when staff are terminated directly, who may
+// already have an exit in progress from their resignation.
+func (s *Service) EnsureExit(ctx context.Context, tenantID, staffID uuid.UUID, lastWorkingDay time.Time, reason string, createdBy *uuid.UUID) (*models.StaffChecklist, error) {
+	open, err := s.repo.FindOpenChecklist(ctx, tenantID, staffID, models.ChecklistKindExit)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return open, nil
+	}
+	return s.StartExit(ctx, tenantID, staffID, lastWorkingDay, reason, createdBy)
+}
+
+// ListChecklists returns staff checklists matching the filter.
+func (s *Service) ListChecklists(ctx context.Context, filter ChecklistFilter) ([]models.StaffChecklist, int64, error) {
+	return s.repo.ListChecklists(ctx, filter)
+}
+
+// ListStaffChecklists returns a staff member's onboarding and exit checklists.
+func (s *Service) ListStaffChecklists(ctx context.Context, tenantID, staffID uuid.UUID) ([]models.StaffChecklist, int64, error) {
+	if _, err := s.repo.GetStaff(ctx, tenantID, staffID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListChecklists(ctx, ChecklistFilter{TenantID: tenantID, StaffID: &staffID})
+}
+
+// GetChecklist returns a checklist with its tasks.
+func (s *Service) GetChecklist(ctx context.Context, tenantID, id uuid.UUID) (*models.StaffChecklist, error) {
+	return s.repo.GetChecklist(ctx, tenantID, id)
+}
+
+// CancelChecklist cancels an open checklist, for example when a resignation
+// is withdrawn.
+func (s *Service) CancelChecklist(ctx context.Context, tenantID, id uuid.UUID) (*models.StaffChecklist, error) {
+	checklist, err := s.repo.GetChecklist(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if checklist.Status != models.StaffChecklistStatusOpen {
+		return nil, ErrChecklistClosed
+	}
+	now := time.Now()
+	checklist.Status = models.StaffChecklistStatusCancelled
+	checklist.CancelledAt = &now
+	if err := s.repo.UpdateChecklist(ctx, checklist); err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
+
+// =========================================================================
+// Tasks
+// =========================================================================
+
+// ListTasks returns checklist tasks matching the filter.
+func (s *Service) ListTasks(ctx context.Context, filter TaskFilter) ([]models.StaffChecklistTask, int64, error) {
+	return s.repo.ListTasks(ctx, filter)
+}
+
+// AddTask adds an ad hoc task to an open checklist.
+func (s *Service) AddTask(ctx context.Context, dto AddTaskDTO) (*models.StaffChecklistTask, error) {
+	checklist, err := s.repo.GetChecklist(ctx, dto.TenantID, dto.ChecklistID)
+	if err != nil {
+		return nil, err
+	}
+	if checklist.Status != models.StaffChecklistStatusOpen {
+		return nil, ErrChecklistClosed
+	}
+	item := ItemDTO{Title: dto.Title, TaskType: dto.TaskType, LetterType: dto.LetterType}
+	if err := validateItems(checklist.Kind, []ItemDTO{item}); err != nil {
+		return nil, err
+	}
+
+	task := &models.StaffChecklistTask{
+		ID:             uuid.New(),
+		TenantID:       dto.TenantID,
+		ChecklistID:    checklist.ID,
+		Title:          strings.TrimSpace(dto.Title),
+		Description:    strings.TrimSpace(dto.Description),
+		TaskType:       dto.TaskType,
+		DepartmentID:   dto.DepartmentID,
+		LetterType:     dto.LetterType,
+		AssigneeRoleID: dto.AssigneeRoleID,
+		AssigneeUserID: dto.AssigneeUserID,
+		DueDate:        dto.DueDate,
+		Status:         models.ChecklistTaskStatusPending,
+		DisplayOrder:   len(checklist.Tasks),
+	}
+	if err := s.repo.CreateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTask(ctx, dto.TenantID, task.ID)
+}
+
+// AssignTask replaces a task's assignees and due date.
+func (s *Service) AssignTask(ctx context.Context, dto AssignTaskDTO) (*models.StaffChecklistTask, error) {
+	task, err := s.repo.GetTask(ctx, dto.TenantID, dto.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Checklist.Status != models.StaffChecklistStatusOpen {
+		return nil, ErrChecklistClosed
+	}
+	if task.IsDone() {
+		return nil, ErrTaskDone
+	}
+
+	task.AssigneeRoleID = dto.AssigneeRoleID
+	task.AssigneeUserID = dto.AssigneeUserID
+	task.DueDate = dto.DueDate
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTask(ctx, dto.TenantID, task.ID)
+}
+
+// CompleteTask marks a task complete. Only the assigned user, or a holder of
+// the assigned role, may complete it unless Override is set. Document, asset,
+// salary and settlement tasks are checked against the underlying records.
+func (s *Service) CompleteTask(ctx context.Context, dto CompleteTaskDTO) (*models.StaffChecklistTask, error) {
+	task, err := s.repo.GetTask(ctx, dto.TenantID, dto.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Checklist.Status != models.StaffChecklistStatusOpen {
+		return nil, ErrChecklistClosed
+	}
+	if task.IsDone() {
+		return nil, ErrTaskDone
+	}
+	if !dto.Override {
+		roleIDs, err := s.repo.ListUserRoleIDs(ctx, dto.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !assignedTo(task, dto.UserID, roleIDs) {
+			return nil, ErrNotAssignee
+		}
+	}
+	if err := s.checkRequirement(ctx, task); err != nil {
+		return nil, err
+	}
+
+	if err := s.closeTask(ctx, task, models.ChecklistTaskStatusCompleted, &dto.UserID, dto.Notes); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTask(ctx, dto.TenantID, task.ID)
+}
+
+// WaiveTask marks a task as not needed, recording the reason.
+func (s *Service) WaiveTask(ctx context.Context, dto CompleteTaskDTO) (*models.StaffChecklistTask, error) {
+	if strings.TrimSpace(dto.Notes) == "" {
+		return nil, ErrWaiverReasonRequired
+	}
+	task, err := s.repo.GetTask(ctx, dto.TenantID, dto.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Checklist.Status != models.StaffChecklistStatusOpen {
+		return nil, ErrChecklistClosed
+	}
+	if task.IsDone() {
+		return nil, ErrTaskDone
+	}
+
+	if err := s.closeTask(ctx, task, models.ChecklistTaskStatusWaived, &dto.UserID, dto.Notes); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTask(ctx, dto.TenantID, task.ID)
+}
+
+// checkRequirement verifies that the record behind a task exists.
+func (s *Service) checkRequirement(ctx context.Context, task *models.StaffChecklistTask) error {
+	tenantID, staffID := task.TenantID, task.Checklist.StaffID
+
+	switch task.TaskType {
+	case models.ChecklistTaskTypeDocument:
+		if task.DocumentTypeID == nil {
+			return nil
+		}
+		verified, err := s.repo.HasVerifiedDocument(ctx, tenantID, staffID, *task.DocumentTypeID)
+		if err != nil {
+			return err
+		}
+		if !verified {
+			return ErrDocumentNotVerified
+		}
+	case models.ChecklistTaskTypeAssetIssue:
+		assets, err := s.repo.ListAssets(ctx, tenantID, staffID)
+		if err != nil {
+			return err
+		}
+		if len(assets) == 0 {
+			return ErrNoAssetsIssued
+		}
+	case models.ChecklistTaskTypeSalaryAssignment:
+		if _, err := s.repo.GetCurrentSalary(ctx, tenantID, staffID); err != nil {
+			return err
+		}
+	case models.ChecklistTaskTypeAssetReturn:
+		assets, err := s.repo.ListAssets(ctx, tenantID, staffID)
+		if err != nil {
+			return err
+		}
+		if !assetsCleared(assets) {
+			return ErrAssetsOutstanding
+		}
+	case models.ChecklistTaskTypeSettlement:
+		settlement, err := s.repo.GetSettlement(ctx, tenantID, task.ChecklistID)
+		if err != nil {
+			return err
+		}
+		if settlement.Status == models.ExitSettlementStatusDraft {
+			return ErrSettlementNotApproved
+		}
+	case models.ChecklistTaskTypeLetter:
+		if task.LetterType != nil && *task.LetterType == models.StaffLetterTypeRelieving {
+			checklist, err := s.repo.GetChecklist(ctx, tenantID, task.ChecklistID)
+			if err != nil {
+				return err
+			}
+			return s.checkRelievingReady(ctx, checklist)
+		}
+	}
+	return nil
+}
+
+// closeTask completes or waives a task and completes the checklist once
+// every task is done.
+func (s *Service) closeTask(ctx context.Context, task *models.StaffChecklistTask, status models.ChecklistTaskStatus, userID *uuid.UUID, notes string) error {
+	now := time.Now()
+	task.Status = status
+	task.CompletedAt = &now
+	task.CompletedBy = userID
+	if notes = strings.TrimSpace(notes); notes != "" {
+		task.Notes = notes
+	}
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return err
+	}
+	return s.refreshChecklist(ctx, task.TenantID, task.ChecklistID)
+}
+
+// refreshChecklist marks an open checklist completed when all its tasks are done.
+func (s *Service) refreshChecklist(ctx context.Context, tenantID, checklistID uuid.UUID) error {
+	checklist, err := s.repo.GetChecklist(ctx, tenantID, checklistID)
+	if err != nil {
+		return err
+	}
+	if checklist.Status != models.StaffChecklistStatusOpen {
+		return nil
+	}
+	for i := range checklist.Tasks {
+		if !checklist.Tasks[i].IsDone() {
+			return nil
+		}
+	}
+	now := time.Now()
+	checklist.Status = models.StaffChecklistStatusCompleted
+	checklist.CompletedAt = &now
+	return s.repo.UpdateChecklist(ctx, checklist)
+}
+
+// completeLinkedTasks completes the pending tasks of a type on a staff
+// member's open checklist once the action they track has been done elsewhere,
+// such as issuing an asset or approving the settlement.
+func (s *Service) completeLinkedTasks(ctx context.Context, tenantID, staffID uuid.UUID, kind models.ChecklistKind, taskType models.ChecklistTaskType, letterType *models.StaffLetterType, userID *uuid.UUID, note string) error {
+	checklist, err := s.repo.FindOpenChecklist(ctx, tenantID, staffID, kind)
+	if err != nil || checklist == nil {
+		return err
+	}
+	for i := range checklist.Tasks {
+		task := &checklist.Tasks[i]
+		if task.IsDone() || task.TaskType != taskType {
+			continue
+		}
+		if letterType != nil && (task.LetterType == nil || *task.LetterType != *letterType) {
+			continue
+		}
+		if err := s.closeTask(ctx, task, models.ChecklistTaskStatusCompleted, userID, note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// =========================================================================
+// Assets
+// =========================================================================
+
+// ListStaffAssets returns the assets issued to a staff member.
+func (s *Service) ListStaffAssets(ctx context.Context, tenantID, staffID uuid.UUID) ([]models.StaffAsset, error) {
+	if _, err := s.repo.GetStaff(ctx, tenantID, staffID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAssets(ctx, tenantID, staffID)
+}
+
+// IssueAsset records an asset issued to a staff member and completes their
+// onboarding asset issue task.
+func (s *Service) IssueAsset(ctx context.Context, dto IssueAssetDTO) (*models.StaffAsset, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if _, err := s.repo.GetStaff(ctx, dto.TenantID, dto.StaffID); err != nil {
+		return nil, err
+	}
+
+	asset := &models.StaffAsset{
+		ID:          uuid.New(),
+		TenantID:    dto.TenantID,
+		StaffID:     dto.StaffID,
+		Name:        name,
+		AssetTag:    strings.TrimSpace(dto.AssetTag),
+		Description: strings.TrimSpace(dto.Description),
+		IssuedOn:    dto.IssuedOn,
+		IssuedBy:    dto.UserID,
+	}
+	if asset.IssuedOn.IsZero() {
+		asset.IssuedOn = today()
+	}
+	if err := s.repo.CreateAsset(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	if err := s.completeLinkedTasks(ctx, dto.TenantID, dto.StaffID, models.ChecklistKindOnboarding,
+		models.ChecklistTaskTypeAssetIssue, nil, dto.UserID, "Asset issued: "+name); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// ReturnAsset records an asset being returned, or, with a recovery amount and
+// no return date, written off against the full-and-final settlement. Once all
+// assets are cleared the exit asset return task is completed.
+func (s *Service) ReturnAsset(ctx context.Context, dto ReturnAssetDTO) (*models.StaffAsset, error) {
+	if dto.RecoveryAmount != nil && dto.RecoveryAmount.IsNegative() {
+		return nil, ErrInvalidAmount
+	}
+	asset, err := s.repo.GetAsset(ctx, dto.TenantID, dto.StaffID, dto.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	if asset.ReturnedOn != nil {
+		return nil, ErrAssetReturned
+	}
+
+	asset.ReturnedOn = dto.ReturnedOn
+	if asset.ReturnedOn == nil && dto.RecoveryAmount == nil {
+		returned := today()
+		asset.ReturnedOn = &returned
+	}
+	if asset.ReturnedOn != nil {
+		asset.ReturnedTo = dto.UserID
+	}
+	asset.ReturnCondition = strings.TrimSpace(dto.Condition)
+	asset.RecoveryAmount = dto.RecoveryAmount
+	if err := s.repo.UpdateAsset(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	assets, err := s.repo.ListAssets(ctx, dto.TenantID, dto.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	if assetsCleared(assets) {
+		if err := s.completeLinkedTasks(ctx, dto.TenantID, dto.StaffID, models.ChecklistKindExit,
+			models.ChecklistTaskTypeAssetReturn, nil, dto.UserID, "All assets returned or marked for recovery"); err != nil {
+			return nil, err
+		}
+	}
+	return asset, nil
+}
+
+// =========================================================================
+// Settlement
+// =========================================================================
+
+// GetSettlement returns the full-and-final settlement for an exit.
+func (s *Service) GetSettlement(ctx context.Context, tenantID, checklistID uuid.UUID) (*models.ExitSettlement, error) {
+	if _, err := s.exitChecklist(ctx, tenantID, checklistID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSettlement(ctx, tenantID, checklistID)
+}
+
+// CalculateSettlement computes, or recomputes, the draft full-and-final
+// settlement for an exit from the staff member's current salary, service
+// length, issued assets and the leave encashment and adjustments given.
+func (s *Service) CalculateSettlement(ctx context.Context, dto SettlementDTO) (*models.ExitSettlement, error) {
+	if dto.LeaveEncashmentDays.IsNegative() || dto.OtherEarnings.IsNegative() || dto.OtherDeductions.IsNegative() {
+		return nil, ErrInvalidAmount
+	}
+	checklist, err := s.exitChecklist(ctx, dto.TenantID, dto.ChecklistID)
+	if err != nil {
+		return nil, err
+	}
+	if checklist.Status == models.StaffChecklistStatusCancelled {
+		return nil, ErrChecklistClosed
+	}
+
+	settlement, err := s.repo.GetSettlement(ctx, dto.TenantID, checklist.ID)
+	switch {
+	case errors.Is(err, ErrSettlementNotFound):
+		settlement = &models.ExitSettlement{
+			ID:          uuid.New(),
+			TenantID:    dto.TenantID,
+			ChecklistID: checklist.ID,
+			StaffID:     checklist.StaffID,
+			Status:      models.ExitSettlementStatusDraft,
+			CreatedBy:   dto.UserID,
+		}
+	case err != nil:
+		return nil, err
+	case settlement.Status != models.ExitSettlementStatusDraft:
+		return nil, ErrSettlementNotDraft
+	}
+
+	salary, err := s.repo.GetCurrentSalary(ctx, dto.TenantID, checklist.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	lastDay := checklist.EffectiveDate
+	finalMonthPaid, err := s.repo.FinalMonthPaid(ctx, dto.TenantID, checklist.StaffID, lastDay.Year(), int(lastDay.Month()))
+	if err != nil {
+		return nil, err
+	}
+	assets, err := s.repo.ListAssets(ctx, dto.TenantID, checklist.StaffID)
+	if err != nil {
+		return nil, err
+	}
+
+	computeSettlement(settlement, settlementInput{
+		Salary:              salary,
+		Shift:               checklist.Staff.Shift,
+		JoinDate:            checklist.Staff.JoinDate,
+		LastWorkingDay:      lastDay,
+		FinalMonthPaid:      finalMonthPaid,
+		LeaveEncashmentDays: dto.LeaveEncashmentDays,
+		OtherEarnings:       dto.OtherEarnings,
+		OtherDeductions:     dto.OtherDeductions,
+		Assets:              assets,
+	})
+	settlement.Notes = strings.TrimSpace(dto.Notes)
+
+	if err := s.repo.SaveSettlement(ctx, settlement); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// ApproveSettlement approves a draft settlement and completes the exit's
+// settlement task.
+func (s *Service) ApproveSettlement(ctx context.Context, tenantID, checklistID uuid.UUID, userID *uuid.UUID) (*models.ExitSettlement, error) {
+	checklist, err := s.exitChecklist(ctx, tenantID, checklistID)
+	if err != nil {
+		return nil, err
+	}
+	settlement, err := s.repo.GetSettlement(ctx, tenantID, checklistID)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.Status != models.ExitSettlementStatusDraft {
+		return nil, ErrSettlementNotDraft
+	}
+
+	now := time.Now()
+	settlement.Status = models.ExitSettlementStatusApproved
+	settlement.ApprovedAt = &now
+	settlement.ApprovedBy = userID
+	if err := s.repo.SaveSettlement(ctx, settlement); err != nil {
+		return nil, err
+	}
+
+	if err := s.completeLinkedTasks(ctx, tenantID, checklist.StaffID, models.ChecklistKindExit,
+		models.ChecklistTaskTypeSettlement, nil, userID, "Settlement approved"); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// MarkSettlementPaid records payment of an approved settlement.
+func (s *Service) MarkSettlementPaid(ctx context.Context, dto SettlementPaymentDTO) (*models.ExitSettlement, error) {
+	if _, err := s.exitChecklist(ctx, dto.TenantID, dto.ChecklistID); err != nil {
+		return nil, err
+	}
+	settlement, err := s.repo.GetSettlement(ctx, dto.TenantID, dto.ChecklistID)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.Status != models.ExitSettlementStatusApproved {
+		return nil, ErrSettlementNotApproved
+	}
+
+	paidOn := dto.PaidOn
+	if paidOn.IsZero() {
+		paidOn = today()
+	}
+	settlement.Status = models.ExitSettlementStatusPaid
+	settlement.PaidOn = &paidOn
+	settlement.PaymentReference = strings.TrimSpace(dto.PaymentReference)
+	if err := s.repo.SaveSettlement(ctx, settlement); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// =========================================================================
+// Letters
+// =========================================================================
+
+// GenerateLetter renders a relieving or experience letter for an exit and
+// returns it with a download filename, completing the matching letter task.
+// A relieving letter needs department clearance, asset return and an
+// approved settlement first.
+func (s *Service) GenerateLetter(ctx context.Context, tenantID, checklistID uuid.UUID, letterType models.StaffLetterType, userID *uuid.UUID) ([]byte, string, error) {
+	if !letterType.IsValid() {
+		return nil, "", ErrInvalidLetterType
+	}
+	checklist, err := s.exitChecklist(ctx, tenantID, checklistID)
+	if err != nil {
+		return nil, "", err
+	}
+	if checklist.Status == models.StaffChecklistStatusCancelled {
+		return nil, "", ErrChecklistClosed
+	}
+	if letterType == models.StaffLetterTypeRelieving {
+		if err := s.checkRelievingReady(ctx, checklist); err != nil {
+			return nil, "", err
+		}
+	}
+
+	schoolName, err := s.repo.GetSchoolName(ctx, tenantID)
+	if err != nil {
+		return nil, "", err
+	}
+	staff, err := s.repo.GetStaff(ctx, tenantID, checklist.StaffID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	letter := letterData{
+		Type:           letterType,
+		SchoolName:     schoolName,
+		Staff:          staff,
+		LastWorkingDay: checklist.EffectiveDate,
+		IssuedOn:       today(),
+	}
+	pdf, err := renderLetter(letter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.completeLinkedTasks(ctx, tenantID, checklist.StaffID, models.ChecklistKindExit,
+		models.ChecklistTaskTypeLetter, &letterType, userID, letterTitle(letterType)+" issued"); err != nil {
+		return nil, "", err
+	}
+	return pdf, letterFilename(letter), nil
+}
+
+// checkRelievingReady checks that an exit's clearances, asset return and
+// settlement are done so the staff member can be relieved.
+func (s *Service) checkRelievingReady(ctx context.Context, checklist *models.StaffChecklist) error {
+	assets, err := s.repo.ListAssets(ctx, checklist.TenantID, checklist.StaffID)
+	if err != nil {
+		return err
+	}
+	settlement, err := s.repo.GetSettlement(ctx, checklist.TenantID, checklist.ID)
+	if err != nil && !errors.Is(err, ErrSettlementNotFound) {
+		return err
+	}
+	return relievingBlocker(checklist.Tasks, assets, settlement)
+}
+
+// exitChecklist returns a checklist, checking it is an exit workflow.
+func (s *Service) exitChecklist(ctx context.Context, tenantID, checklistID uuid.UUID) (*models.StaffChecklist, error) {
+	checklist, err := s.repo.GetChecklist(ctx, tenantID, checklistID)
+	if err != nil {
+		return nil, err
+	}
+	if checklist.Kind != models.ChecklistKindExit {
+		return nil, ErrNotExitChecklist
+	}
+	return checklist, nil
+}
+
+// =========================================================================
+// Helpers
+// =========================================================================
+
+// validateItems checks task titles and that each task type belongs on the
+// checklist kind. Document tasks come from the mandatory document types, so
+// they are not added by hand.
+func validateItems(kind models.ChecklistKind, items []ItemDTO) error {
+	for _, item := range items {
+		if strings.TrimSpace(item.Title) == "" {
+			return ErrTitleRequired
+		}
+		if !item.TaskType.IsValid() || !item.TaskType.AllowedFor(kind) ||
+			item.TaskType == models.ChecklistTaskTypeDocument {
+			return ErrInvalidTaskType
+		}
+		if item.TaskType == models.ChecklistTaskTypeLetter && (item.LetterType == nil || !item.LetterType.IsValid()) {
+			return ErrLetterTypeRequired
+		}
+	}
+	return nil
+}
+
+func toTemplateItems(templateID uuid.UUID, items []ItemDTO) []models.ChecklistTemplateItem {
+	result := make([]models.ChecklistTemplateItem, len(items))
+	for i, item := range items {
+		result[i] = models.ChecklistTemplateItem{
+			ID:             uuid.New(),
+			TemplateID:     templateID,
+			Title:          strings.TrimSpace(item.Title),
+			Description:    strings.TrimSpace(item.Description),
+			TaskType:       item.TaskType,
+			DepartmentID:   item.DepartmentID,
+			AssigneeRoleID: item.AssigneeRoleID,
+			DueOffsetDays:  item.DueOffsetDays,
+			DisplayOrder:   i,
+		}
+		if item.TaskType == models.ChecklistTaskTypeLetter {
+			result[i].LetterType = item.LetterType
+		}
+	}
+	return result
+}
+
+// pickTemplate chooses the template for a staff type, falling back to the
+// template for all staff. It returns nil when neither exists.
+func pickTemplate(templates []models.ChecklistTemplate, staffType models.StaffType) *models.ChecklistTemplate {
+	var fallback *models.ChecklistTemplate
+	for i := range templates {
+		template := &templates[i]
+		if template.StaffType == nil {
+			if fallback == nil {
+				fallback = template
+			}
+			continue
+		}
+		if *template.StaffType == staffType {
+			return template
+		}
+	}
+	return fallback
+}
+
+// applicableDocumentTypes filters mandatory document types to those that
+// apply to a staff type. A type with no staff types listed applies to all.
+func applicableDocumentTypes(types []models.StaffDocumentType, staffType models.StaffType) []models.StaffDocumentType {
+	var result []models.StaffDocumentType
+	for _, documentType := range types {
+		if len(documentType.ApplicableTo) == 0 {
+			result = append(result, documentType)
+			continue
+		}
+		for _, applicable := range documentType.ApplicableTo {
+			if applicable == string(staffType) {
+				result = append(result, documentType)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// defaultOnboardingItems are used when no onboarding template applies.
+func defaultOnboardingItems() []models.ChecklistTemplateItem {
+	return []models.ChecklistTemplateItem{
+		{Title: "Create user account", TaskType: models.ChecklistTaskTypeAccount},
+		{Title: "Issue ID card and assets", TaskType: models.ChecklistTaskTypeAssetIssue},
+		{Title: "Assign salary", TaskType: models.ChecklistTaskTypeSalaryAssignment, DueOffsetDays: DocumentDueDays},
+	}
+}
+
+// defaultExitItems are used when no exit template applies: clearance from the
+// staff member's own department, then asset return, settlement and letters.
+func defaultExitItems(departmentID *uuid.UUID) []models.ChecklistTemplateItem {
+	relieving := models.StaffLetterTypeRelieving
+	experience := models.StaffLetterTypeExperience
+	var items []models.ChecklistTemplateItem
+	if departmentID != nil {
+		items = append(items, models.ChecklistTemplateItem{
+			Title: "Department clearance", TaskType: models.ChecklistTaskTypeClearance, DepartmentID: departmentID,
+		})
+	}
+	return append(items,
+		models.ChecklistTemplateItem{Title: "Return school assets", TaskType: models.ChecklistTaskTypeAssetReturn},
+		models.ChecklistTemplateItem{Title: "Full-and-final settlement", TaskType: models.ChecklistTaskTypeSettlement, DueOffsetDays: 30},
+		models.ChecklistTemplateItem{Title: "Issue relieving letter", TaskType: models.ChecklistTaskTypeLetter, LetterType: &relieving, DueOffsetDays: 30},
+		models.ChecklistTemplateItem{Title: "Issue experience letter", TaskType: models.ChecklistTaskTypeLetter, LetterType: &experience, DueOffsetDays: 30},
+	)
+}
+
+func newChecklist(staff *models.Staff, kind models.ChecklistKind, effectiveDate time.Time, template *models.ChecklistTemplate, createdBy *uuid.UUID) *models.StaffChecklist {
+	checklist := &models.StaffChecklist{
+		ID:            uuid.New(),
+		TenantID:      staff.TenantID,
+		StaffID:       staff.ID,
+		Kind:          kind,
+		Status:        models.StaffChecklistStatusOpen,
+		EffectiveDate: effectiveDate,
+		CreatedBy:     createdBy,
+	}
+	if template != nil {
+		checklist.TemplateID = &template.ID
+	}
+	return checklist
+}
+
+// buildTasks creates a checklist's tasks: one per document type, due
+// DocumentDueDays after the effective date, followed by the template items
+// with due dates offset from the effective date.
+func buildTasks(checklist *models.StaffChecklist, items []models.ChecklistTemplateItem, documentTypes []models.StaffDocumentType) []models.StaffChecklistTask {
+	tasks := make([]models.StaffChecklistTask, 0, len(documentTypes)+len(items))
+	newTask := func(title string, taskType models.ChecklistTaskType, offset int) models.StaffChecklistTask {
+		due := checklist.EffectiveDate.AddDate(0, 0, offset)
+		return models.StaffChecklistTask{
+			ID:           uuid.New(),
+			TenantID:     checklist.TenantID,
+			ChecklistID:  checklist.ID,
+			Title:        title,
+			TaskType:     taskType,
+			DueDate:      &due,
+			Status:       models.ChecklistTaskStatusPending,
+			DisplayOrder: len(tasks),
+		}
+	}
+
+	for _, documentType := range documentTypes {
+		task := newTask("Submit "+documentType.Name, models.ChecklistTaskTypeDocument, DocumentDueDays)
+		id := documentType.ID
+		task.DocumentTypeID = &id
+		tasks = append(tasks, task)
+	}
+	for _, item := range items {
+		task := newTask(item.Title, item.TaskType, item.DueOffsetDays)
+		task.Description = item.Description
+		task.DepartmentID = item.DepartmentID
+		task.LetterType = item.LetterType
+		task.AssigneeRoleID = item.AssigneeRoleID
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// assignedTo reports whether a user may complete a task: unassigned tasks are
+// open to anyone, otherwise the user must be the assignee or hold the role.
+func assignedTo(task *models.StaffChecklistTask, userID uuid.UUID, roleIDs []uuid.UUID) bool {
+	if task.AssigneeUserID == nil && task.AssigneeRoleID == nil {
+		return true
+	}
+	if task.AssigneeUserID != nil && *task.AssigneeUserID == userID {
+		return true
+	}
+	if task.AssigneeRoleID != nil {
+		for _, roleID := range roleIDs {
+			if roleID == *task.AssigneeRoleID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// assetsCleared reports whether every issued asset has been returned or
+// marked for recovery.
+func assetsCleared(assets []models.StaffAsset) bool {
+	for i := range assets {
+		if !assets[i].IsCleared() {
+			return false
+		}
+	}
+	return true
+}
+
+// relievingBlocker returns why a staff member cannot yet be relieved, or nil.
+func relievingBlocker(tasks []models.StaffChecklistTask, assets []models.StaffAsset, settlement *models.ExitSettlement) error {
+	for i := range tasks {
+		if tasks[i].TaskType == models.ChecklistTaskTypeClearance && !tasks[i].IsDone() {
+			return ErrClearancePending
+		}
+	}
+	if !assetsCleared(assets) {
+		return ErrClearancePending
+	}
+	if settlement == nil || settlement.Status == models.ExitSettlementStatusDraft {
+		return ErrSettlementNotApproved
+	}
+	return nil
+}
+
+// settlementInput holds what a full-and-final settlement is computed from.
+type settlementInput struct {
+	Salary              *models.StaffSalary
+	Shift               *models.Shift
+	JoinDate            time.Time
+	LastWorkingDay      time.Time
+	FinalMonthPaid      bool
+	LeaveEncashmentDays decimal.Decimal
+	OtherEarnings       decimal.Decimal
+	OtherDeductions     decimal.Decimal
+	Assets              []models.StaffAsset
+}
+
+// computeSettlement fills in a settlement's amounts:
+//   - the final month's net pay, pro-rated over the working days of the
+//     staff member's shift (Monday to Saturday without one, as in payroll)
+//     up to the last working day, unless a pay run for that month has
+//     already been approved;
+//   - gratuity of 15 days' basic and DA per year of service once service
+//     reaches GratuityMinimumYears, counting a final part year over six
+//     months as a year, capped at GratuityCeiling;
+//   - leave encashment at the daily basic and DA rate (monthly / 26);
+//   - less recovery for assets not returned or returned damaged.
+func computeSettlement(settlement *models.ExitSettlement, in settlementInput) {
+	earnings, deductions, wages := decimal.Zero, decimal.Zero, decimal.Zero
+	for _, component := range in.Salary.Components {
+		if component.Component == nil {
+			continue
+		}
+		if component.Component.ComponentType == models.ComponentTypeEarning {
+			earnings = earnings.Add(component.Amount)
+			for _, code := range gratuityWageCodes {
+				if strings.EqualFold(component.Component.Code, code) {
+					wages = wages.Add(component.Amount)
+				}
+			}
+		} else {
+			deductions = deductions.Add(component.Amount)
+		}
+	}
+	salaryID := in.Salary.ID
+	settlement.StaffSalaryID = &salaryID
+	settlement.LastWorkingDay = in.LastWorkingDay
+	settlement.MonthlyEarnings = earnings
+	settlement.MonthlyDeductions = deductions
+	settlement.MonthlyBasic = wages
+
+	monthStart := time.Date(in.LastWorkingDay.Year(), in.LastWorkingDay.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	payableFrom := monthStart
+	if in.JoinDate.After(payableFrom) {
+		payableFrom = in.JoinDate
+	}
+	settlement.WorkingDays = in.Shift.WorkingDaysBetween(monthStart, monthEnd)
+	settlement.FinalMonthPaid = in.FinalMonthPaid
+	settlement.PayableDays = 0
+	settlement.FinalMonthSalary = decimal.Zero
+	if !in.FinalMonthPaid && settlement.WorkingDays > 0 {
+		settlement.PayableDays = in.Shift.WorkingDaysBetween(payableFrom, in.LastWorkingDay)
+		settlement.FinalMonthSalary = earnings.Sub(deductions).
+			Mul(decimal.NewFromInt(int64(settlement.PayableDays))).
+			Div(decimal.NewFromInt(int64(settlement.WorkingDays))).
+			Round(2)
+	}
+
+	completed, rounded := serviceYears(in.JoinDate, in.LastWorkingDay)
+	settlement.ServiceYears = rounded
+	settlement.Gratuity = decimal.Zero
+	if completed >= GratuityMinimumYears {
+		gratuity := wages.Mul(decimal.NewFromInt(15)).Div(decimal.NewFromInt(26)).
+			Mul(decimal.NewFromInt(int64(rounded))).Round(2)
+		if gratuity.GreaterThan(GratuityCeiling) {
+			gratuity = GratuityCeiling
+		}
+		settlement.Gratuity = gratuity
+	}
+
+	settlement.LeaveEncashmentDays = in.LeaveEncashmentDays
+	settlement.LeaveEncashment = wages.Div(decimal.NewFromInt(26)).Mul(in.LeaveEncashmentDays).Round(2)
+	settlement.OtherEarnings = in.OtherEarnings
+	settlement.OtherDeductions = in.OtherDeductions
+
+	recovery := decimal.Zero
+	for _, asset := range in.Assets {
+		if asset.RecoveryAmount != nil {
+			recovery = recovery.Add(*asset.RecoveryAmount)
+		}
+	}
+	settlement.AssetRecovery = recovery
+
+	settlement.NetPayable = settlement.FinalMonthSalary.
+		Add(settlement.Gratuity).
+		Add(settlement.LeaveEncashment).
+		Add(settlement.OtherEarnings).
+		Sub(settlement.AssetRecovery).
+		Sub(settlement.OtherDeductions)
+}
+
+// serviceYears returns the completed years of service up to and including
+// the last working day, and the years rounded up when the final part year is
+// more than six months.
+func serviceYears(joinDate, lastWorkingDay time.Time) (int, int) {
+	start, end := dateOnly(joinDate), dateOnly(lastWorkingDay).AddDate(0, 0, 1)
+	years := end.Year() - start.Year()
+	if start.AddDate(years, 0, 0).After(end) {
+		years--
+	}
+	if years < 0 {
+		return 0, 0
+	}
+	rounded := years
+	if start.AddDate(years, 6, 0).Before(end) {
+		rounded++
+	}
+	return years, rounded
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func today() time.Time {
+	return dateOnly(time.Now())
+}
+
+func kindPtr(kind models.ChecklistKind) *models.ChecklistKind {
+	return &kind
+}
+
+// letterTitle returns the heading of a letter type.
+func letterTitle(letterType models.StaffLetterType) string {
+	if letterType == models.StaffLetterTypeRelieving {
+		return "Relieving letter"
+	}
+	return "Experience letter"
+}
+
+// letterFilename names a letter download, e.g. relieving-letter-EMP00012.pdf.
+func letterFilename(letter letterData) string {
+	return fmt.Sprintf("%s-letter-%s.pdf", letter.Type, letter.Staff.EmployeeID)
+}
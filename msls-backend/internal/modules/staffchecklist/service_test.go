@@ -0,0 +1,299 @@
+package staffchecklist
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func salaryWith(amounts map[string]string) *models.StaffSalary {
+	salary := &models.StaffSalary{ID: uuid.New()}
+	for code, amount := range amounts {
+		componentType := models.ComponentTypeEarning
+		if code == "PF" {
+			componentType = models.ComponentTypeDeduction
+		}
+		salary.Components = append(salary.Components, models.StaffSalaryComponent{
+			Amount:    dec(amount),
+			Component: &models.SalaryComponent{Code: code, ComponentType: componentType},
+		})
+	}
+	return salary
+}
+
+func TestValidateItems(t *testing.T) {
+	relieving := models.StaffLetterTypeRelieving
+
+	assert.NoError(t, validateItems(models.ChecklistKindExit, []ItemDTO{
+		{Title: "Library clearance", TaskType: models.ChecklistTaskTypeClearance},
+		{Title: "Relieving letter", TaskType: models.ChecklistTaskTypeLetter, LetterType: &relieving},
+	}))
+	assert.ErrorIs(t, validateItems(models.ChecklistKindOnboarding, []ItemDTO{{Title: " ", TaskType: models.ChecklistTaskTypeAccount}}), ErrTitleRequired)
+	assert.ErrorIs(t, validateItems(models.ChecklistKindOnboarding, []ItemDTO{{Title: "Clearance", TaskType: models.ChecklistTaskTypeClearance}}), ErrInvalidTaskType)
+	assert.ErrorIs(t, validateItems(models.ChecklistKindOnboarding, []ItemDTO{{Title: "PAN card", TaskType: models.ChecklistTaskTypeDocument}}), ErrInvalidTaskType)
+	assert.ErrorIs(t, validateItems(models.ChecklistKindExit, []ItemDTO{{Title: "Letter", TaskType: models.ChecklistTaskTypeLetter}}), ErrLetterTypeRequired)
+}
+
+func TestPickTemplate(t *testing.T) {
+	teaching := models.StaffTypeTeaching
+	templates := []models.ChecklistTemplate{
+		{Name: "All staff"},
+		{Name: "Teaching", StaffType: &teaching},
+	}
+
+	assert.Equal(t, "Teaching", pickTemplate(templates, models.StaffTypeTeaching).Name)
+	assert.Equal(t, "All staff", pickTemplate(templates, models.StaffTypeNonTeaching).Name)
+	assert.Nil(t, pickTemplate(templates[1:], models.StaffTypeNonTeaching))
+}
+
+func TestApplicableDocumentTypes(t *testing.T) {
+	types := []models.StaffDocumentType{
+		{Name: "PAN card"},
+		{Name: "B.Ed certificate", ApplicableTo: pq.StringArray{"teaching"}},
+		{Name: "Driving licence", ApplicableTo: pq.StringArray{"non_teaching"}},
+	}
+
+	names := func(types []models.StaffDocumentType) []string {
+		var result []string
+		for _, documentType := range types {
+			result = append(result, documentType.Name)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"PAN card", "B.Ed certificate"}, names(applicableDocumentTypes(types, models.StaffTypeTeaching)))
+	assert.Equal(t, []string{"PAN card", "Driving licence"}, names(applicableDocumentTypes(types, models.StaffTypeNonTeaching)))
+}
+
+func TestBuildTasks(t *testing.T) {
+	staff := &models.Staff{ID: uuid.New(), TenantID: uuid.New()}
+	checklist := newChecklist(staff, models.ChecklistKindOnboarding, date(2026, 4, 1), nil, nil)
+	role := uuid.New()
+	items := []models.ChecklistTemplateItem{
+		{Title: "Create user account", TaskType: models.ChecklistTaskTypeAccount, AssigneeRoleID: &role},
+		{Title: "Assign salary", TaskType: models.ChecklistTaskTypeSalaryAssignment, DueOffsetDays: 14},
+	}
+	documentTypes := []models.StaffDocumentType{{ID: uuid.New(), Name: "PAN card"}}
+
+	tasks := buildTasks(checklist, items, documentTypes)
+	require.Len(t, tasks, 3)
+
+	assert.Equal(t, "Submit PAN card", tasks[0].Title)
+	assert.Equal(t, models.ChecklistTaskTypeDocument, tasks[0].TaskType)
+	assert.Equal(t, documentTypes[0].ID, *tasks[0].DocumentTypeID)
+	assert.Equal(t, date(2026, 4, 8), *tasks[0].DueDate)
+
+	assert.Equal(t, role, *tasks[1].AssigneeRoleID)
+	assert.Equal(t, date(2026, 4, 1), *tasks[1].DueDate)
+	assert.Equal(t, date(2026, 4, 15), *tasks[2].DueDate)
+	for i, task := range tasks {
+		assert.Equal(t, i, task.DisplayOrder)
+		assert.Equal(t, checklist.ID, task.ChecklistID)
+		assert.Equal(t, models.ChecklistTaskStatusPending, task.Status)
+	}
+}
+
+func TestDefaultExitItems(t *testing.T) {
+	department := uuid.New()
+
+	withDepartment := defaultExitItems(&department)
+	require.Len(t, withDepartment, 5)
+	assert.Equal(t, models.ChecklistTaskTypeClearance, withDepartment[0].TaskType)
+	assert.Equal(t, department, *withDepartment[0].DepartmentID)
+
+	withoutDepartment := defaultExitItems(nil)
+	require.Len(t, withoutDepartment, 4)
+	assert.Equal(t, models.ChecklistTaskTypeAssetReturn, withoutDepartment[0].TaskType)
+}
+
+func TestAssignedTo(t *testing.T) {
+	user := uuid.New()
+	role := uuid.New()
+	other := uuid.New()
+
+	assert.True(t, assignedTo(&models.StaffChecklistTask{}, user, nil))
+	assert.True(t, assignedTo(&models.StaffChecklistTask{AssigneeUserID: &user}, user, nil))
+	assert.True(t, assignedTo(&models.StaffChecklistTask{AssigneeRoleID: &role}, user, []uuid.UUID{other, role}))
+	assert.False(t, assignedTo(&models.StaffChecklistTask{AssigneeRoleID: &role}, user, []uuid.UUID{other}))
+	assert.False(t, assignedTo(&models.StaffChecklistTask{AssigneeUserID: &other}, user, []uuid.UUID{role}))
+}
+
+func TestRelievingBlocker(t *testing.T) {
+	returned := date(2026, 6, 10)
+	recovery := dec("1500")
+	clearance := models.StaffChecklistTask{TaskType: models.ChecklistTaskTypeClearance, Status: models.ChecklistTaskStatusPending}
+	approved := &models.ExitSettlement{Status: models.ExitSettlementStatusApproved}
+
+	assert.ErrorIs(t, relievingBlocker([]models.StaffChecklistTask{clearance}, nil, approved), ErrClearancePending)
+
+	clearance.Status = models.ChecklistTaskStatusWaived
+	tasks := []models.StaffChecklistTask{clearance}
+	assert.ErrorIs(t, relievingBlocker(tasks, []models.StaffAsset{{Name: "Laptop"}}, approved), ErrClearancePending)
+
+	assets := []models.StaffAsset{{ReturnedOn: &returned}, {RecoveryAmount: &recovery}}
+	assert.ErrorIs(t, relievingBlocker(tasks, assets, nil), ErrSettlementNotApproved)
+	assert.ErrorIs(t, relievingBlocker(tasks, assets, &models.ExitSettlement{Status: models.ExitSettlementStatusDraft}), ErrSettlementNotApproved)
+	assert.NoError(t, relievingBlocker(tasks, assets, approved))
+}
+
+func TestComputeSettlement(t *testing.T) {
+	recovery := dec("2500")
+	settlement := &models.ExitSettlement{}
+	computeSettlement(settlement, settlementInput{
+		Salary:              salaryWith(map[string]string{"BASIC": "30000", "DA": "5000", "HRA": "10000", "PF": "3600"}),
+		JoinDate:            date(2019, 6, 16),
+		LastWorkingDay:      date(2026, 6, 15),
+		LeaveEncashmentDays: dec("10"),
+		OtherEarnings:       dec("1000"),
+		OtherDeductions:     dec("500"),
+		Assets:              []models.StaffAsset{{Name: "Laptop", RecoveryAmount: &recovery}},
+	})
+
+	assert.True(t, dec("45000").Equal(settlement.MonthlyEarnings))
+	assert.True(t, dec("3600").Equal(settlement.MonthlyDeductions))
+	assert.True(t, dec("35000").Equal(settlement.MonthlyBasic))
+
+	// June 2026 has 26 working days; 13 of them fall on or before the 15th.
+	assert.Equal(t, 26, settlement.WorkingDays)
+	assert.Equal(t, 13, settlement.PayableDays)
+	assert.True(t, dec("20700").Equal(settlement.FinalMonthSalary), settlement.FinalMonthSalary.String())
+
+	// Seven completed years: 35000 x 15 / 26 x 7.
+	assert.Equal(t, 7, settlement.ServiceYears)
+	assert.True(t, dec("141346.15").Equal(settlement.Gratuity), settlement.Gratuity.String())
+	assert.True(t, dec("13461.54").Equal(settlement.LeaveEncashment), settlement.LeaveEncashment.String())
+	assert.True(t, dec("2500").Equal(settlement.AssetRecovery))
+	assert.True(t, dec("173507.69").Equal(settlement.NetPayable), settlement.NetPayable.String())
+}
+
+func TestComputeSettlementFinalMonthPaidAndShortService(t *testing.T) {
+	settlement := &models.ExitSettlement{}
+	computeSettlement(settlement, settlementInput{
+		Salary:         salaryWith(map[string]string{"BASIC": "30000"}),
+		JoinDate:       date(2022, 1, 10),
+		LastWorkingDay: date(2026, 6, 30),
+		FinalMonthPaid: true,
+	})
+
+	assert.Equal(t, 0, settlement.PayableDays)
+	assert.True(t, settlement.FinalMonthSalary.IsZero())
+	assert.Equal(t, 4, settlement.ServiceYears)
+	assert.True(t, settlement.Gratuity.IsZero())
+	assert.True(t, settlement.NetPayable.IsZero())
+}
+
+func TestComputeSettlementJoinedInFinalMonth(t *testing.T) {
+	settlement := &models.ExitSettlement{}
+	computeSettlement(settlement, settlementInput{
+		Salary:         salaryWith(map[string]string{"BASIC": "26000"}),
+		JoinDate:       date(2026, 6, 8),
+		LastWorkingDay: date(2026, 6, 15),
+	})
+
+	assert.Equal(t, 7, settlement.PayableDays)
+	assert.True(t, dec("7000").Equal(settlement.FinalMonthSalary), settlement.FinalMonthSalary.String())
+}
+
+func TestComputeSettlementOnShift(t *testing.T) {
+	settlement := &models.ExitSettlement{}
+	computeSettlement(settlement, settlementInput{
+		Salary:         salaryWith(map[string]string{"BASIC": "22000"}),
+		Shift:          &models.Shift{WorkingDays: pq.Int64Array{1, 2, 3, 4, 5}},
+		JoinDate:       date(2024, 1, 1),
+		LastWorkingDay: date(2026, 6, 15),
+	})
+
+	// A Monday to Friday shift works 22 days in June 2026, 11 of them by the 15th.
+	assert.Equal(t, 22, settlement.WorkingDays)
+	assert.Equal(t, 11, settlement.PayableDays)
+	assert.True(t, dec("11000").Equal(settlement.FinalMonthSalary), settlement.FinalMonthSalary.String())
+}
+
+func TestComputeSettlementGratuityCeiling(t *testing.T) {
+	settlement := &models.ExitSettlement{}
+	computeSettlement(settlement, settlementInput{
+		Salary:         salaryWith(map[string]string{"BASIC": "400000"}),
+		JoinDate:       date(1996, 4, 1),
+		LastWorkingDay: date(2026, 3, 31),
+		FinalMonthPaid: true,
+	})
+
+	assert.Equal(t, 30, settlement.ServiceYears)
+	assert.True(t, GratuityCeiling.Equal(settlement.Gratuity), settlement.Gratuity.String())
+}
+
+func TestServiceYears(t *testing.T) {
+	completed, rounded := serviceYears(date(2020, 1, 1), date(2024, 12, 31))
+	assert.Equal(t, 5, completed)
+	assert.Equal(t, 5, rounded)
+
+	completed, rounded = serviceYears(date(2020, 1, 1), date(2025, 6, 30))
+	assert.Equal(t, 5, completed)
+	assert.Equal(t, 5, rounded)
+
+	completed, rounded = serviceYears(date(2020, 1, 1), date(2025, 8, 15))
+	assert.Equal(t, 5, completed)
+	assert.Equal(t, 6, rounded)
+
+	completed, rounded = serviceYears(date(2026, 1, 1), date(2025, 12, 31))
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 0, rounded)
+}
+
+func TestWorkingDaysBetween(t *testing.T) {
+	var noShift *models.Shift
+	assert.Equal(t, 26, noShift.WorkingDaysBetween(date(2026, 6, 1), date(2026, 6, 30)))
+	assert.Equal(t, 6, noShift.WorkingDaysBetween(date(2026, 6, 1), date(2026, 6, 7)))
+	assert.Equal(t, 0, noShift.WorkingDaysBetween(date(2026, 6, 7), date(2026, 6, 7)))
+	assert.Equal(t, 0, noShift.WorkingDaysBetween(date(2026, 6, 10), date(2026, 6, 1)))
+
+	weekdays := &models.Shift{WorkingDays: pq.Int64Array{1, 2, 3, 4, 5}}
+	assert.Equal(t, 22, weekdays.WorkingDaysBetween(date(2026, 6, 1), date(2026, 6, 30)))
+	assert.Equal(t, 22, weekdays.WorkingDaysInMonth(2026, 6))
+}
+
+func TestRenderLetter(t *testing.T) {
+	staff := &models.Staff{
+		EmployeeID:  "EMP00012",
+		FirstName:   "Asha",
+		LastName:    "Rao",
+		JoinDate:    date(2019, 6, 16),
+		Designation: &models.Designation{Name: "PGT Physics"},
+	}
+
+	for _, letterType := range []models.StaffLetterType{models.StaffLetterTypeRelieving, models.StaffLetterTypeExperience} {
+		letter := letterData{
+			Type:           letterType,
+			SchoolName:     "Green Valley School",
+			Staff:          staff,
+			LastWorkingDay: date(2026, 6, 15),
+			IssuedOn:       date(2026, 6, 20),
+		}
+		pdf, err := renderLetter(letter)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+		assert.Equal(t, string(letterType)+"-letter-EMP00012.pdf", letterFilename(letter))
+	}
+
+	paragraphs := letterParagraphs(letterData{
+		Type: models.StaffLetterTypeExperience, SchoolName: "Green Valley School", Staff: staff,
+		LastWorkingDay: date(2026, 6, 15),
+	})
+	assert.Contains(t, paragraphs[0], "from 16 June 2019 to 15 June 2026")
+	assert.Contains(t, paragraphs[0], "PGT Physics")
+}
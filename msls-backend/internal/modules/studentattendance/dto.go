@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"msls-backend/internal/modules/medicalalert"
 )
 
 // AttendanceStatus represents the status of a student attendance record.
@@ -123,6 +125,7 @@ type StudentForAttendance struct {
 	LateArrivalTime  string            `json:"lateArrivalTime,omitempty"`
 	Remarks          string            `json:"remarks,omitempty"`
 	Last5Days        []string          `json:"last5Days"`
+	MedicalAlert     *medicalalert.Badge `json:"medicalAlert,omitempty"`
 }
 
 // ClassAttendanceResponse represents attendance data for a class section.
@@ -275,6 +278,7 @@ type StudentForPeriodAttendance struct {
 	Status           string `json:"status,omitempty"`
 	LateArrivalTime  string `json:"lateArrivalTime,omitempty"`
 	Remarks          string `json:"remarks,omitempty"`
+	MedicalAlert     *medicalalert.Badge `json:"medicalAlert,omitempty"`
 }
 
 // PeriodAttendanceResponse represents period attendance data.
@@ -676,6 +680,9 @@ type UnmarkedClassInfo struct {
 	TeacherID     string `json:"teacherId,omitempty"`
 	TeacherName   string `json:"teacherName,omitempty"`
 	StudentCount  int    `json:"studentCount"`
+	ShiftID       string `json:"shiftId,omitempty"`
+	ShiftName     string `json:"shiftName,omitempty"`
+	Deadline      string `json:"deadline"`
 	IsEscalated   bool   `json:"isEscalated"`
 }
 
@@ -14,6 +14,7 @@ import (
 	"msls-backend/internal/pkg/logger"
 	"msls-backend/internal/pkg/response"
 	"msls-backend/internal/middleware"
+	"msls-backend/internal/modules/medicalalert"
 	"msls-backend/internal/pkg/database/models"
 )
 
@@ -146,6 +147,13 @@ func (h *Handler) GetClassAttendance(c *gin.Context) {
 		return
 	}
 
+	// Staff without health access only see that a student has an alert
+	if !middleware.HasPermission(c, medicalalert.DetailPermission) {
+		for i := range attendance.Students {
+			attendance.Students[i].MedicalAlert = attendance.Students[i].MedicalAlert.Redact()
+		}
+	}
+
 	response.OK(c, attendance)
 }
 
@@ -668,6 +676,13 @@ func (h *Handler) GetPeriodAttendance(c *gin.Context) {
 		return
 	}
 
+	// Staff without health access only see that a student has an alert
+	if !middleware.HasPermission(c, medicalalert.DetailPermission) {
+		for i := range attendance.Students {
+			attendance.Students[i].MedicalAlert = attendance.Students[i].MedicalAlert.Redact()
+		}
+	}
+
 	response.OK(c, attendance)
 }
 
@@ -751,7 +766,7 @@ func (h *Handler) MarkPeriodAttendance(c *gin.Context) {
 
 	// Get timetable entry ID for this period
 	// We need to fetch it from the timetable entries
-	entries, err := h.service.repo.GetTimetableEntriesForSection(c.Request.Context(), tenantID, sectionID, int(date.Weekday()))
+	entries, err := h.service.repo.GetTimetableEntriesForSection(c.Request.Context(), tenantID, sectionID, date)
 	if err != nil {
 		logger.Error("Failed to get timetable entries",
 			zap.String("tenant_id", tenantID.String()),
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"msls-backend/internal/modules/timetable"
 	"msls-backend/internal/pkg/database/models"
 )
 
@@ -245,6 +246,7 @@ func (r *Repository) GetSectionByID(ctx context.Context, tenantID, sectionID uui
 	var section models.Section
 	err := r.db.WithContext(ctx).
 		Preload("Class").
+		Preload("Shift").
 		Where("tenant_id = ? AND id = ?", tenantID, sectionID).
 		First(&section).Error
 	if err != nil {
@@ -428,21 +430,23 @@ func (r *Repository) GetFirstPeriodAttendanceRecord(ctx context.Context, tenantI
 	return &attendance, nil
 }
 
-// GetTimetableEntriesForSection retrieves timetable entries for a section on a specific day.
-func (r *Repository) GetTimetableEntriesForSection(ctx context.Context, tenantID, sectionID uuid.UUID, dayOfWeek int) ([]models.TimetableEntry, error) {
+// GetTimetableEntriesForSection retrieves timetable entries for a section on a
+// date, from the timetable version in force on that date.
+func (r *Repository) GetTimetableEntriesForSection(ctx context.Context, tenantID, sectionID uuid.UUID, date time.Time) ([]models.TimetableEntry, error) {
 	var entries []models.TimetableEntry
 
-	// Find published timetable for section
-	var timetable models.Timetable
+	// Find the timetable version in force for the section
+	day := date.Format("2006-01-02")
+	var inForce models.Timetable
 	err := r.db.WithContext(ctx).
-		Where("tenant_id = ? AND section_id = ? AND status = ? AND deleted_at IS NULL",
-			tenantID, sectionID, models.TimetableStatusPublished).
-		First(&timetable).Error
+		Where("tenant_id = ? AND section_id = ?", tenantID, sectionID).
+		Where("id IN ("+timetable.InForceSQL+")", tenantID, day, day).
+		First(&inForce).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil // No published timetable
+			return nil, nil // No timetable in force
 		}
-		return nil, fmt.Errorf("get published timetable: %w", err)
+		return nil, fmt.Errorf("get timetable in force: %w", err)
 	}
 
 	// Get entries for this day
@@ -451,7 +455,7 @@ func (r *Repository) GetTimetableEntriesForSection(ctx context.Context, tenantID
 		Preload("Subject").
 		Preload("Staff").
 		Where("tenant_id = ? AND timetable_id = ? AND day_of_week = ?",
-			tenantID, timetable.ID, dayOfWeek).
+			tenantID, inForce.ID, int(date.Weekday())).
 		Order("period_slot_id ASC").
 		Find(&entries).Error
 	if err != nil {
@@ -758,6 +762,7 @@ func (r *Repository) GetStudentCurrentSection(ctx context.Context, tenantID, stu
 	var section models.Section
 	err := r.db.WithContext(ctx).
 		Preload("Class").
+		Preload("Shift").
 		Joins("JOIN student_sections ON student_sections.section_id = sections.id").
 		Where("student_sections.student_id = ? AND sections.tenant_id = ?", studentID, tenantID).
 		First(&section).Error
@@ -775,6 +780,7 @@ func (r *Repository) GetAllActiveSections(ctx context.Context, tenantID uuid.UUI
 	var sections []models.Section
 	err := r.db.WithContext(ctx).
 		Preload("Class").
+		Preload("Shift").
 		Where("tenant_id = ? AND is_active = ?", tenantID, true).
 		Order("display_order").
 		Find(&sections).Error
@@ -7,15 +7,24 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"msls-backend/internal/modules/medicalalert"
 	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/logger"
 )
 
+// MedicalAlertProvider looks up the medical alert badges shown on attendance rosters.
+type MedicalAlertProvider interface {
+	BadgesForStudents(ctx context.Context, tenantID uuid.UUID, studentIDs []uuid.UUID) (map[uuid.UUID]*medicalalert.Badge, error)
+}
+
 // Service handles student attendance business logic.
 type Service struct {
-	repo *Repository
-	db   *gorm.DB
+	repo          *Repository
+	db            *gorm.DB
+	medicalAlerts MedicalAlertProvider
 }
 
 // NewService creates a new student attendance service.
@@ -26,6 +35,33 @@ func NewService(db *gorm.DB) *Service {
 	}
 }
 
+// SetMedicalAlertProvider sets the provider used to badge students with
+// medical alerts on class rosters.
+func (s *Service) SetMedicalAlertProvider(provider MedicalAlertProvider) {
+	s.medicalAlerts = provider
+}
+
+// medicalAlertBadges returns the medical alert badges of roster students,
+// keyed by student ID. Failures are logged rather than returned so attendance
+// can still be marked.
+func (s *Service) medicalAlertBadges(ctx context.Context, tenantID uuid.UUID, students []models.Student) map[uuid.UUID]*medicalalert.Badge {
+	if s.medicalAlerts == nil || len(students) == 0 {
+		return nil
+	}
+	studentIDs := make([]uuid.UUID, len(students))
+	for i, student := range students {
+		studentIDs[i] = student.ID
+	}
+	badges, err := s.medicalAlerts.BadgesForStudents(ctx, tenantID, studentIDs)
+	if err != nil {
+		logger.Warn("Failed to load medical alerts for attendance roster",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err))
+		return nil
+	}
+	return badges
+}
+
 // GetTeacherSections returns the sections available for a teacher to mark attendance.
 // For now, returns all sections. In the future, this should be filtered by teacher assignment.
 func (s *Service) GetTeacherSections(ctx context.Context, tenantID, branchID uuid.UUID, date time.Time) ([]TeacherClassResponse, error) {
@@ -123,6 +159,8 @@ func (s *Service) GetClassStudentsForAttendance(ctx context.Context, tenantID, s
 		attendanceMap[a.StudentID] = a
 	}
 
+	badges := s.medicalAlertBadges(ctx, tenantID, students)
+
 	// Get attendance history for each student (last 5 days)
 	studentResponses := make([]StudentForAttendance, len(students))
 	for i, student := range students {
@@ -141,6 +179,7 @@ func (s *Service) GetClassStudentsForAttendance(ctx context.Context, tenantID, s
 			FullName:        student.FullName(),
 			PhotoURL:        student.PhotoURL,
 			Last5Days:       last5Days,
+			MedicalAlert:    badges[student.ID],
 		}
 
 		// Check if attendance already exists
@@ -395,7 +434,7 @@ func (s *Service) GetTeacherPeriods(ctx context.Context, tenantID, sectionID uui
 
 	// Get timetable entries for this day
 	dayOfWeek := int(date.Weekday())
-	entries, err := s.repo.GetTimetableEntriesForSection(ctx, tenantID, sectionID, dayOfWeek)
+	entries, err := s.repo.GetTimetableEntriesForSection(ctx, tenantID, sectionID, date)
 	if err != nil {
 		return nil, err
 	}
@@ -486,8 +525,7 @@ func (s *Service) GetPeriodAttendance(ctx context.Context, tenantID, sectionID,
 	}
 
 	// Get timetable entry for this period on this day
-	dayOfWeek := int(date.Weekday())
-	entries, err := s.repo.GetTimetableEntriesForSection(ctx, tenantID, sectionID, dayOfWeek)
+	entries, err := s.repo.GetTimetableEntriesForSection(ctx, tenantID, sectionID, date)
 	if err != nil {
 		return nil, err
 	}
@@ -528,6 +566,7 @@ func (s *Service) GetPeriodAttendance(ctx context.Context, tenantID, sectionID,
 	}
 
 	// Build student responses
+	badges := s.medicalAlertBadges(ctx, tenantID, students)
 	studentResponses := make([]StudentForPeriodAttendance, len(students))
 	for i, student := range students {
 		resp := StudentForPeriodAttendance{
@@ -537,6 +576,7 @@ func (s *Service) GetPeriodAttendance(ctx context.Context, tenantID, sectionID,
 			LastName:        student.LastName,
 			FullName:        student.FullName(),
 			PhotoURL:        student.PhotoURL,
+			MedicalAlert:    badges[student.ID],
 		}
 
 		if attendance, ok := attendanceMap[student.ID]; ok {
@@ -756,7 +796,7 @@ func (s *Service) GetDailySummary(ctx context.Context, tenantID, sectionID uuid.
 
 	// Get timetable entries for this day
 	dayOfWeek := int(date.Weekday())
-	entries, err := s.repo.GetTimetableEntriesForSection(ctx, tenantID, sectionID, dayOfWeek)
+	entries, err := s.repo.GetTimetableEntriesForSection(ctx, tenantID, sectionID, date)
 	if err != nil {
 		return nil, err
 	}
@@ -1260,7 +1300,11 @@ func (s *Service) GetStudentCalendar(ctx context.Context, tenantID, studentID uu
 		attendanceMap[a.AttendanceDate.Format("2006-01-02")] = a
 	}
 
-	// Generate calendar days
+	// Get student's current section
+	section, _ := s.repo.GetStudentCurrentSection(ctx, tenantID, studentID)
+
+	// Generate calendar days; days the section's shift does not work count
+	// as weekends
 	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	endDate := startDate.AddDate(0, 1, -1)
 
@@ -1271,7 +1315,7 @@ func (s *Service) GetStudentCalendar(ctx context.Context, tenantID, studentID uu
 		day := CalendarDayResponse{
 			Date:      d.Format("2006-01-02"),
 			DayOfWeek: int(d.Weekday()),
-			IsWeekend: d.Weekday() == time.Sunday || d.Weekday() == time.Saturday,
+			IsWeekend: !isSchoolDay(section, d),
 		}
 
 		if day.IsWeekend {
@@ -1302,9 +1346,6 @@ func (s *Service) GetStudentCalendar(ctx context.Context, tenantID, studentID uu
 		summary.Percentage = float64(presentCount) / float64(summary.WorkingDays) * 100
 	}
 
-	// Get student's current section
-	section, _ := s.repo.GetStudentCurrentSection(ctx, tenantID, studentID)
-
 	// Get class average
 	classAverage := 0.0
 	if section != nil {
@@ -1568,12 +1609,12 @@ func (s *Service) GetMonthlyClassReport(ctx context.Context, tenantID, sectionID
 		attendanceMap[a.StudentID][a.AttendanceDate.Format("2006-01-02")] = a
 	}
 
-	// Generate working dates (excluding weekends)
+	// Generate working dates of the section's shift (weekdays without one)
 	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	endDate := startDate.AddDate(0, 1, -1)
 	var dates []string
 	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
-		if d.Weekday() != time.Sunday && d.Weekday() != time.Saturday {
+		if isSchoolDay(section, d) {
 			dates = append(dates, d.Format("2006-01-02"))
 		}
 	}
@@ -1741,6 +1782,8 @@ func (s *Service) GetLowAttendanceDashboard(ctx context.Context, tenantID uuid.U
 }
 
 // GetUnmarkedAttendance returns classes with unmarked attendance for a date.
+// Each section is held to its shift's deadline, or else deadlineTime, and
+// sections whose shift does not work on the date are left out.
 func (s *Service) GetUnmarkedAttendance(ctx context.Context, tenantID uuid.UUID, date time.Time, deadlineTime string) (*UnmarkedAttendanceResponse, error) {
 	if tenantID == uuid.Nil {
 		return nil, ErrTenantIDRequired
@@ -1751,41 +1794,55 @@ func (s *Service) GetUnmarkedAttendance(ctx context.Context, tenantID uuid.UUID,
 	if err != nil {
 		return nil, err
 	}
+	unmarkedMap := make(map[uuid.UUID]bool)
+	for _, id := range unmarkedIDs {
+		unmarkedMap[id] = true
+	}
 
-	// Get all sections for total count
-	allSections, err := s.repo.GetAllActiveSections(ctx, tenantID)
+	// Get the sections in session on the date for the total count
+	sections, err := s.repo.GetAllActiveSections(ctx, tenantID)
 	if err != nil {
 		return nil, err
 	}
+	sections = sectionsInSession(sections, date)
 
-	// Check if past deadline
 	now := time.Now()
-	deadline, _ := time.Parse("15:04", deadlineTime)
-	deadlineToday := time.Date(now.Year(), now.Month(), now.Day(), deadline.Hour(), deadline.Minute(), 0, 0, now.Location())
-	isPastDeadline := now.After(deadlineToday)
+	isPastDeadline := false
 
-	// Build unmarked class info
+	// Build unmarked class info, checking each section against its deadline
 	unmarkedClasses := make([]UnmarkedClassInfo, 0)
-	for _, sectionID := range unmarkedIDs {
-		section, err := s.repo.GetSectionByID(ctx, tenantID, sectionID)
-		if err != nil {
+	for _, section := range sections {
+		if !unmarkedMap[section.ID] {
 			continue
 		}
 
-		studentCount, _ := s.repo.CountStudentsInSection(ctx, tenantID, sectionID)
+		deadline := sectionDeadline(&section, deadlineTime)
+		isEscalated := now.After(deadlineOn(date, deadline, now.Location()))
+		if isEscalated {
+			isPastDeadline = true
+		}
+
+		studentCount, _ := s.repo.CountStudentsInSection(ctx, tenantID, section.ID)
 
 		className := ""
 		if section.Class.ID != uuid.Nil {
 			className = section.Class.Name
 		}
 
-		unmarkedClasses = append(unmarkedClasses, UnmarkedClassInfo{
-			SectionID:    sectionID.String(),
+		info := UnmarkedClassInfo{
+			SectionID:    section.ID.String(),
 			SectionName:  section.Name,
 			ClassName:    className,
 			StudentCount: int(studentCount),
-			IsEscalated:  isPastDeadline,
-		})
+			Deadline:     deadline,
+			IsEscalated:  isEscalated,
+		}
+		if section.Shift != nil {
+			info.ShiftID = section.Shift.ID.String()
+			info.ShiftName = section.Shift.Name
+		}
+
+		unmarkedClasses = append(unmarkedClasses, info)
 	}
 
 	return &UnmarkedAttendanceResponse{
@@ -1793,8 +1850,8 @@ func (s *Service) GetUnmarkedAttendance(ctx context.Context, tenantID uuid.UUID,
 		Deadline:        deadlineTime,
 		IsPostDeadline:  isPastDeadline,
 		UnmarkedClasses: unmarkedClasses,
-		TotalClasses:    len(allSections),
-		MarkedClasses:   len(allSections) - len(unmarkedIDs),
+		TotalClasses:    len(sections),
+		MarkedClasses:   len(sections) - len(unmarkedClasses),
 	}, nil
 }
 
@@ -1815,11 +1872,12 @@ func (s *Service) GetDailyReport(ctx context.Context, tenantID uuid.UUID, date t
 		overallRate = float64(present) / float64(total) * 100
 	}
 
-	// Get all sections
+	// Get the sections in session on the date
 	sections, err := s.repo.GetAllActiveSections(ctx, tenantID)
 	if err != nil {
 		return nil, err
 	}
+	sections = sectionsInSession(sections, date)
 
 	// Get unmarked sections
 	unmarkedIDs, _ := s.repo.GetUnmarkedSections(ctx, tenantID, date)
@@ -1827,6 +1885,12 @@ func (s *Service) GetDailyReport(ctx context.Context, tenantID uuid.UUID, date t
 	for _, id := range unmarkedIDs {
 		unmarkedMap[id] = true
 	}
+	classesMarked := 0
+	for _, section := range sections {
+		if !unmarkedMap[section.ID] {
+			classesMarked++
+		}
+	}
 
 	// Get low attendance classes (below 75%)
 	lowClasses := make([]ClassAttendanceBreakdown, 0)
@@ -1866,7 +1930,7 @@ func (s *Service) GetDailyReport(ctx context.Context, tenantID uuid.UUID, date t
 		TotalStudents:         totalStudents,
 		TotalPresent:          int(present),
 		TotalAbsent:           int(absent),
-		ClassesMarked:         len(sections) - len(unmarkedIDs),
+		ClassesMarked:         classesMarked,
 		ClassesTotal:          len(sections),
 		LowAttendanceClasses:  lowClasses,
 		GeneratedAt:           time.Now().Format(time.RFC3339),
@@ -0,0 +1,46 @@
+// Package studentattendance provides student attendance management functionality.
+package studentattendance
+
+import (
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// isSchoolDay reports whether a section attends on a date: the working days
+// of its shift, or Monday to Friday for a section without a shift.
+func isSchoolDay(section *models.Section, date time.Time) bool {
+	if section != nil && section.Shift != nil {
+		return section.Shift.IsWorkingDay(date.Weekday())
+	}
+	return date.Weekday() != time.Sunday && date.Weekday() != time.Saturday
+}
+
+// sectionsInSession returns the sections that attend on a date.
+func sectionsInSession(sections []models.Section, date time.Time) []models.Section {
+	result := make([]models.Section, 0, len(sections))
+	for i := range sections {
+		if isSchoolDay(&sections[i], date) {
+			result = append(result, sections[i])
+		}
+	}
+	return result
+}
+
+// sectionDeadline returns the time (HH:MM) by which a section's attendance
+// must be marked: its shift's deadline, or else defaultDeadline.
+func sectionDeadline(section *models.Section, defaultDeadline string) string {
+	if section.Shift == nil || section.Shift.AttendanceDeadline == nil {
+		return defaultDeadline
+	}
+	if t, err := time.Parse("15:04:05", *section.Shift.AttendanceDeadline); err == nil {
+		return t.Format("15:04")
+	}
+	return *section.Shift.AttendanceDeadline
+}
+
+// deadlineOn returns the moment a deadline (HH:MM) passes on a date.
+func deadlineOn(date time.Time, deadline string, loc *time.Location) time.Time {
+	t, _ := time.Parse("15:04", deadline)
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+}
@@ -22,9 +22,15 @@ type ShiftResponse struct {
 	EndTime      string    `json:"endTime"`
 	Description  string    `json:"description,omitempty"`
 	DisplayOrder int       `json:"displayOrder"`
-	IsActive     bool      `json:"isActive"`
-	CreatedAt    string    `json:"createdAt"`
-	UpdatedAt    string    `json:"updatedAt"`
+
+	LateThresholdMinutes      *int   `json:"lateThresholdMinutes,omitempty"`
+	EarlyExitThresholdMinutes *int   `json:"earlyExitThresholdMinutes,omitempty"`
+	AttendanceDeadline        string `json:"attendanceDeadline,omitempty"`
+	WorkingDays               []int  `json:"workingDays"`
+
+	IsActive  bool   `json:"isActive"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
 }
 
 // ShiftListResponse represents the response for listing shifts.
@@ -42,6 +48,11 @@ type CreateShiftRequest struct {
 	EndTime      string    `json:"endTime" binding:"required"`
 	Description  string    `json:"description"`
 	DisplayOrder int       `json:"displayOrder"`
+
+	LateThresholdMinutes      *int   `json:"lateThresholdMinutes" binding:"omitempty,min=0,max=120"`
+	EarlyExitThresholdMinutes *int   `json:"earlyExitThresholdMinutes" binding:"omitempty,min=0,max=120"`
+	AttendanceDeadline        string `json:"attendanceDeadline"`                                // HH:MM format
+	WorkingDays               []int  `json:"workingDays" binding:"omitempty,dive,min=0,max=6"` // 0=Sunday, ..., 6=Saturday
 }
 
 // UpdateShiftRequest represents the request body for updating a shift.
@@ -53,6 +64,11 @@ type UpdateShiftRequest struct {
 	Description  *string `json:"description"`
 	DisplayOrder *int    `json:"displayOrder"`
 	IsActive     *bool   `json:"isActive"`
+
+	LateThresholdMinutes      *int    `json:"lateThresholdMinutes" binding:"omitempty,min=0,max=120"`
+	EarlyExitThresholdMinutes *int    `json:"earlyExitThresholdMinutes" binding:"omitempty,min=0,max=120"`
+	AttendanceDeadline        *string `json:"attendanceDeadline"` // HH:MM format, empty to clear
+	WorkingDays               []int   `json:"workingDays" binding:"omitempty,dive,min=0,max=6"`
 }
 
 // ShiftFilter represents filters for listing shifts.
@@ -76,6 +92,18 @@ func ShiftToResponse(s *models.Shift) ShiftResponse {
 		IsActive:     s.IsActive,
 		CreatedAt:    s.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:    s.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+
+		LateThresholdMinutes:      s.LateThresholdMinutes,
+		EarlyExitThresholdMinutes: s.EarlyExitThresholdMinutes,
+		WorkingDays:               make([]int, len(s.WorkingDays)),
+	}
+
+	if s.AttendanceDeadline != nil {
+		resp.AttendanceDeadline = *s.AttendanceDeadline
+	}
+
+	for i, d := range s.WorkingDays {
+		resp.WorkingDays[i] = int(d)
 	}
 
 	if s.Branch != nil {
@@ -320,6 +348,8 @@ type TimetableResponse struct {
 	Name             string                   `json:"name"`
 	Description      string                   `json:"description,omitempty"`
 	Status           string                   `json:"status"`
+	Version          int                      `json:"version"`
+	SupersedesID     *uuid.UUID               `json:"supersedesId,omitempty"`
 	EffectiveFrom    string                   `json:"effectiveFrom,omitempty"`
 	EffectiveTo      string                   `json:"effectiveTo,omitempty"`
 	PublishedAt      string                   `json:"publishedAt,omitempty"`
@@ -372,6 +402,8 @@ func TimetableToResponse(t *models.Timetable) TimetableResponse {
 		Name:           t.Name,
 		Description:    t.Description,
 		Status:         string(t.Status),
+		Version:        t.Version,
+		SupersedesID:   t.SupersedesID,
 		CreatedAt:      t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:      t.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -6,9 +6,13 @@ import "errors"
 // Domain errors for the timetable module.
 var (
 	// Shift errors
-	ErrShiftNotFound   = errors.New("shift not found")
-	ErrShiftCodeExists = errors.New("shift code already exists")
-	ErrShiftInUse      = errors.New("cannot delete shift that is in use")
+	ErrShiftNotFound        = errors.New("shift not found")
+	ErrShiftCodeExists      = errors.New("shift code already exists")
+	ErrShiftInUse           = errors.New("cannot delete shift that is in use")
+	ErrShiftInactive        = errors.New("shift is not active")
+	ErrShiftBranchMismatch  = errors.New("staff and sections can only be assigned to a shift of their own branch")
+	ErrShiftMemberNotFound  = errors.New("staff member or section not found")
+	ErrInvalidShiftDeadline = errors.New("invalid attendance deadline, use HH:MM")
 
 	// Day Pattern errors
 	ErrDayPatternNotFound   = errors.New("day pattern not found")
@@ -37,6 +41,22 @@ var (
 	ErrSubstitutionNotPending   = errors.New("only pending substitutions can be modified")
 	ErrSubstitutionNotCancellable = errors.New("only pending or confirmed substitutions can be cancelled")
 
+	// Calendar feed and print errors
+	ErrFeedNotFound         = errors.New("calendar feed not found")
+	ErrInvalidFeedTarget    = errors.New("section feeds need a section and teacher feeds need a staff member")
+	ErrFeedNotOwned         = errors.New("only the creator of a calendar feed can revoke it")
+	ErrSectionNotFound      = errors.New("section not found")
+	ErrStaffNotFound        = errors.New("staff not found")
+	ErrAcademicYearNotFound = errors.New("academic year not found")
+	ErrNothingToPrint       = errors.New("no published timetables match the filters")
+
+	// Timetable version errors
+	ErrVersionOverlap        = errors.New("another version of this timetable already takes effect on or after this date")
+	ErrInvalidEffectiveDates = errors.New("effective-to date must not be before the effective-from date")
+	ErrTimetableNotReleased  = errors.New("only published or scheduled timetables can be archived")
+	ErrNoPreviousVersion     = errors.New("timetable has no earlier version to compare with")
+	ErrVersionMismatch       = errors.New("versions belong to different sections")
+
 	// General errors
 	ErrInvalidTimeRange = errors.New("end time must be after start time")
 )
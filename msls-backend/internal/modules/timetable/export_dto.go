@@ -0,0 +1,116 @@
+package timetable
+
+import (
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+)
+
+// ========================================
+// Calendar Feed DTOs
+// ========================================
+
+// CreateFeedRequest contains data for creating a calendar feed link.
+type CreateFeedRequest struct {
+	Kind           string     `json:"kind" binding:"required,oneof=section teacher"`
+	SectionID      *uuid.UUID `json:"sectionId"`
+	StaffID        *uuid.UUID `json:"staffId"`
+	AcademicYearID uuid.UUID  `json:"academicYearId" binding:"required"`
+}
+
+// CreateMyFeedRequest contains data for creating the current teacher's feed link.
+type CreateMyFeedRequest struct {
+	AcademicYearID uuid.UUID `json:"academicYearId" binding:"required"`
+}
+
+// FeedFilter contains filter parameters for listing calendar feed links.
+type FeedFilter struct {
+	TenantID       uuid.UUID
+	Kind           *models.TimetableFeedKind
+	SectionID      *uuid.UUID
+	StaffID        *uuid.UUID
+	AcademicYearID *uuid.UUID
+}
+
+// FeedResponse is the API response for a calendar feed link. The token and
+// subscription path are only returned when the link is created.
+type FeedResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Kind             string     `json:"kind"`
+	SectionID        *uuid.UUID `json:"sectionId,omitempty"`
+	SectionName      string     `json:"sectionName,omitempty"`
+	ClassName        string     `json:"className,omitempty"`
+	StaffID          *uuid.UUID `json:"staffId,omitempty"`
+	StaffName        string     `json:"staffName,omitempty"`
+	AcademicYearID   uuid.UUID  `json:"academicYearId"`
+	AcademicYearName string     `json:"academicYearName,omitempty"`
+	Token            string     `json:"token,omitempty"`
+	PublicPath       string     `json:"publicPath,omitempty"`
+	LastAccessedAt   string     `json:"lastAccessedAt,omitempty"`
+	CreatedAt        string     `json:"createdAt"`
+}
+
+// FeedListResponse is the API response for listing calendar feed links.
+type FeedListResponse struct {
+	Feeds []FeedResponse `json:"feeds"`
+	Total int            `json:"total"`
+}
+
+// FeedToResponse converts a TimetableFeed model to FeedResponse.
+func FeedToResponse(f *models.TimetableFeed) FeedResponse {
+	resp := FeedResponse{
+		ID:             f.ID,
+		Kind:           string(f.Kind),
+		SectionID:      f.SectionID,
+		StaffID:        f.StaffID,
+		AcademicYearID: f.AcademicYearID,
+		CreatedAt:      f.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if f.Section != nil {
+		resp.SectionName = f.Section.Name
+		if f.Section.Class.ID != uuid.Nil {
+			resp.ClassName = f.Section.Class.Name
+		}
+	}
+
+	if f.Staff != nil {
+		resp.StaffName = f.Staff.FullName()
+	}
+
+	if f.AcademicYear != nil {
+		resp.AcademicYearName = f.AcademicYear.Name
+	}
+
+	if f.LastAccessedAt != nil {
+		resp.LastAccessedAt = f.LastAccessedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return resp
+}
+
+// FeedPublicPath returns the subscription path for a feed token.
+func FeedPublicPath(token string) string {
+	return "/api/v1/public/timetable-feeds/" + token + ".ics"
+}
+
+// ========================================
+// Printable Timetable DTOs
+// ========================================
+
+// PrintFilter contains filter parameters for printing timetables in bulk.
+type PrintFilter struct {
+	TenantID       uuid.UUID
+	AcademicYearID uuid.UUID
+	BranchID       *uuid.UUID
+	ClassID        *uuid.UUID
+	By             models.TimetableFeedKind
+}
+
+// feedWindow is the date range a calendar feed covers.
+type feedWindow struct {
+	From time.Time
+	To   time.Time
+}
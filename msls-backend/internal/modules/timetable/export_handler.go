@@ -0,0 +1,410 @@
+package timetable
+
+import (
+	"errors"
+	"net/http"
+
+	"msls-backend/internal/middleware"
+	"msls-backend/internal/pkg/database/models"
+	apperr "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RegisterExportRoutes registers calendar feed and printable timetable routes.
+func (h *Handler) RegisterExportRoutes(rg *gin.RouterGroup) {
+	feeds := rg.Group("/timetable-feeds")
+	{
+		// Own feed and revocation - available to all authenticated users;
+		// revoking someone else's link needs timetables:update
+		feeds.POST("/me", h.CreateMyFeed)
+		feeds.DELETE("/:id", h.RevokeFeed)
+
+		feedsView := feeds.Group("")
+		feedsView.Use(middleware.PermissionRequired("timetables:read"))
+		{
+			feedsView.GET("", h.ListFeeds)
+			feedsView.POST("", h.CreateFeed)
+		}
+	}
+
+	timetables := rg.Group("/timetables")
+	{
+		// My timetable - available to all authenticated users
+		timetables.GET("/teacher/me/pdf", h.GetMyTimetablePDF)
+
+		timetablesView := timetables.Group("")
+		timetablesView.Use(middleware.PermissionRequired("timetables:read"))
+		{
+			timetablesView.GET("/print", h.PrintTimetables)
+			timetablesView.GET("/:id/pdf", h.GetTimetablePDF)
+			timetablesView.GET("/teacher/:staffId/pdf", h.GetTeacherTimetablePDF)
+		}
+	}
+}
+
+// ========================================
+// Calendar Feed Handlers
+// ========================================
+
+// ListFeeds returns the active calendar feed links for the tenant.
+func (h *Handler) ListFeeds(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	filter := FeedFilter{TenantID: tenantID}
+
+	if kindStr := c.Query("kind"); kindStr != "" {
+		kind := models.TimetableFeedKind(kindStr)
+		if !kind.IsValid() {
+			apperr.Abort(c, apperr.BadRequest("Invalid feed kind"))
+			return
+		}
+		filter.Kind = &kind
+	}
+
+	if sectionIDStr := c.Query("section_id"); sectionIDStr != "" {
+		sectionID, err := uuid.Parse(sectionIDStr)
+		if err == nil {
+			filter.SectionID = &sectionID
+		}
+	}
+
+	if staffIDStr := c.Query("staff_id"); staffIDStr != "" {
+		staffID, err := uuid.Parse(staffIDStr)
+		if err == nil {
+			filter.StaffID = &staffID
+		}
+	}
+
+	if academicYearIDStr := c.Query("academic_year_id"); academicYearIDStr != "" {
+		academicYearID, err := uuid.Parse(academicYearIDStr)
+		if err == nil {
+			filter.AcademicYearID = &academicYearID
+		}
+	}
+
+	feeds, total, err := h.service.ListFeeds(c.Request.Context(), filter)
+	if err != nil {
+		apperr.Abort(c, apperr.InternalError("Failed to list calendar feeds"))
+		return
+	}
+
+	resp := FeedListResponse{
+		Feeds: make([]FeedResponse, len(feeds)),
+		Total: int(total),
+	}
+	for i := range feeds {
+		resp.Feeds[i] = FeedToResponse(&feeds[i])
+	}
+
+	response.OK(c, resp)
+}
+
+// CreateFeed creates a calendar feed link for a section or a teacher.
+func (h *Handler) CreateFeed(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var req CreateFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperr.Abort(c, apperr.BadRequest(err.Error()))
+		return
+	}
+
+	feed, token, err := h.service.CreateFeed(c.Request.Context(), tenantID, req, userID)
+	if err != nil {
+		handleFeedError(c, err, "Failed to create calendar feed")
+		return
+	}
+
+	response.Created(c, feedCreatedResponse(feed, token))
+}
+
+// CreateMyFeed creates a calendar feed link for the current user's teaching timetable.
+func (h *Handler) CreateMyFeed(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperr.Abort(c, apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req CreateMyFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperr.Abort(c, apperr.BadRequest(err.Error()))
+		return
+	}
+
+	feed, token, err := h.service.CreateMyFeed(c.Request.Context(), tenantID, userID, req)
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			apperr.Abort(c, apperr.NotFound("Staff profile not found for this user"))
+			return
+		}
+		handleFeedError(c, err, "Failed to create calendar feed")
+		return
+	}
+
+	response.Created(c, feedCreatedResponse(feed, token))
+}
+
+// RevokeFeed revokes a calendar feed link.
+func (h *Handler) RevokeFeed(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperr.Abort(c, apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid feed ID"))
+		return
+	}
+
+	canManage := middleware.HasPermission(c, "timetables:update")
+	if err := h.service.RevokeFeed(c.Request.Context(), tenantID, id, userID, canManage); err != nil {
+		if errors.Is(err, ErrFeedNotOwned) {
+			apperr.Abort(c, apperr.Forbidden("Only the creator of a calendar feed can revoke it"))
+			return
+		}
+		handleFeedError(c, err, "Failed to revoke calendar feed")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetFeedCalendar serves the iCalendar document for a feed link. It is public;
+// the token in the link identifies the feed and its tenant.
+func (h *Handler) GetFeedCalendar(c *gin.Context) {
+	calendar, err := h.service.RenderFeed(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		if errors.Is(err, ErrFeedNotFound) {
+			apperr.Abort(c, apperr.NotFound("Calendar feed not found"))
+			return
+		}
+		apperr.Abort(c, apperr.InternalError("Failed to render calendar feed"))
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=\"timetable.ics\"")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+// feedCreatedResponse includes the token and subscription path, which are
+// only ever shown when a link is created.
+func feedCreatedResponse(feed *models.TimetableFeed, token string) FeedResponse {
+	resp := FeedToResponse(feed)
+	resp.Token = token
+	resp.PublicPath = FeedPublicPath(token)
+	return resp
+}
+
+// handleFeedError maps feed and lookup errors to API errors.
+func handleFeedError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrInvalidFeedTarget):
+		apperr.Abort(c, apperr.BadRequest("Section feeds need a sectionId and teacher feeds need a staffId"))
+	case errors.Is(err, ErrFeedNotFound):
+		apperr.Abort(c, apperr.NotFound("Calendar feed not found"))
+	case errors.Is(err, ErrSectionNotFound):
+		apperr.Abort(c, apperr.NotFound("Section not found"))
+	case errors.Is(err, ErrStaffNotFound):
+		apperr.Abort(c, apperr.NotFound("Staff not found"))
+	case errors.Is(err, ErrAcademicYearNotFound):
+		apperr.Abort(c, apperr.NotFound("Academic year not found"))
+	case errors.Is(err, ErrTimetableNotFound):
+		apperr.Abort(c, apperr.NotFound("Timetable not found"))
+	case errors.Is(err, ErrNothingToPrint):
+		apperr.Abort(c, apperr.NotFound("No published timetables match the filters"))
+	default:
+		apperr.Abort(c, apperr.InternalError(fallback))
+	}
+}
+
+// ========================================
+// Printable Timetable Handlers
+// ========================================
+
+// GetTimetablePDF returns a timetable as a printable PDF grid.
+func (h *Handler) GetTimetablePDF(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid timetable ID"))
+		return
+	}
+
+	pdf, filename, err := h.service.SectionTimetablePDF(c.Request.Context(), tenantID, id)
+	if err != nil {
+		handleFeedError(c, err, "Failed to generate timetable PDF")
+		return
+	}
+
+	sendPDF(c, pdf, filename)
+}
+
+// GetTeacherTimetablePDF returns a teacher's timetable as a printable PDF grid.
+func (h *Handler) GetTeacherTimetablePDF(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("staffId"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid staff ID"))
+		return
+	}
+
+	academicYearID, ok := requiredAcademicYear(c)
+	if !ok {
+		return
+	}
+
+	pdf, filename, err := h.service.TeacherTimetablePDF(c.Request.Context(), tenantID, staffID, academicYearID)
+	if err != nil {
+		handleFeedError(c, err, "Failed to generate timetable PDF")
+		return
+	}
+
+	sendPDF(c, pdf, filename)
+}
+
+// GetMyTimetablePDF returns the current user's teaching timetable as a printable PDF grid.
+func (h *Handler) GetMyTimetablePDF(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, ok := middleware.GetCurrentUserID(c)
+	if !ok {
+		apperr.Abort(c, apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	academicYearID, ok := requiredAcademicYear(c)
+	if !ok {
+		return
+	}
+
+	staffID, err := h.service.GetStaffIDByUserID(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		apperr.Abort(c, apperr.NotFound("Staff profile not found for this user"))
+		return
+	}
+
+	pdf, filename, err := h.service.TeacherTimetablePDF(c.Request.Context(), tenantID, staffID, academicYearID)
+	if err != nil {
+		handleFeedError(c, err, "Failed to generate timetable PDF")
+		return
+	}
+
+	sendPDF(c, pdf, filename)
+}
+
+// PrintTimetables returns every published timetable matching the filters as
+// one PDF, a page per section (by=section, the default) or per teacher (by=teacher).
+func (h *Handler) PrintTimetables(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	academicYearID, ok := requiredAcademicYear(c)
+	if !ok {
+		return
+	}
+
+	filter := PrintFilter{
+		TenantID:       tenantID,
+		AcademicYearID: academicYearID,
+		By:             models.TimetableFeedKind(c.DefaultQuery("by", string(models.TimetableFeedKindSection))),
+	}
+	if !filter.By.IsValid() {
+		apperr.Abort(c, apperr.BadRequest("by must be section or teacher"))
+		return
+	}
+
+	if branchIDStr := c.Query("branch_id"); branchIDStr != "" {
+		branchID, err := uuid.Parse(branchIDStr)
+		if err != nil {
+			apperr.Abort(c, apperr.BadRequest("Invalid branch ID"))
+			return
+		}
+		filter.BranchID = &branchID
+	}
+
+	if classIDStr := c.Query("class_id"); classIDStr != "" {
+		classID, err := uuid.Parse(classIDStr)
+		if err != nil {
+			apperr.Abort(c, apperr.BadRequest("Invalid class ID"))
+			return
+		}
+		filter.ClassID = &classID
+	}
+
+	pdf, filename, err := h.service.PrintTimetables(c.Request.Context(), filter)
+	if err != nil {
+		handleFeedError(c, err, "Failed to generate timetable PDF")
+		return
+	}
+
+	sendPDF(c, pdf, filename)
+}
+
+// requiredAcademicYear parses the required academic_year_id query parameter,
+// aborting the request when it is missing or invalid.
+func requiredAcademicYear(c *gin.Context) (uuid.UUID, bool) {
+	academicYearIDStr := c.Query("academic_year_id")
+	if academicYearIDStr == "" {
+		apperr.Abort(c, apperr.BadRequest("Academic year ID is required"))
+		return uuid.Nil, false
+	}
+
+	academicYearID, err := uuid.Parse(academicYearIDStr)
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid academic year ID"))
+		return uuid.Nil, false
+	}
+
+	return academicYearID, true
+}
+
+// sendPDF writes a PDF download.
+func sendPDF(c *gin.Context, pdf []byte, filename string) {
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
@@ -0,0 +1,305 @@
+package timetable
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/google/uuid"
+)
+
+// printDays is the order weekdays are printed in, Monday first.
+var printDays = []int{1, 2, 3, 4, 5, 6, 0}
+
+// timetableGrid is one printed page: a weekly grid of days by period slots.
+type timetableGrid struct {
+	Title    string
+	Subtitle string
+	Columns  []gridColumn
+	Rows     []gridRow
+}
+
+// gridColumn is a period slot column. Slots of different day patterns that
+// share a name and time are printed as one column.
+type gridColumn struct {
+	Label   string
+	Time    string
+	Break   bool
+	slotIDs map[uuid.UUID]bool
+	slots   []*models.PeriodSlot
+	start   string
+}
+
+// gridRow is a weekday row.
+type gridRow struct {
+	Day   string
+	Cells []gridCell
+}
+
+// gridCell is what is printed for a day and period slot. Closed cells are
+// slots that do not run on that day under its day pattern.
+type gridCell struct {
+	Lines  []string
+	Break  bool
+	Closed bool
+}
+
+// buildGrid lays out timetable entries as a weekly grid. Columns come from the
+// branch's period slots plus any slot the entries use; rows are the working
+// days of the branch's day pattern assignments, or Monday to Saturday (and
+// any other day with entries) when the branch has none. Teacher grids show
+// the section in each cell, section grids show the teacher.
+func buildGrid(title, subtitle string, slots []models.PeriodSlot, assignments []models.DayPatternAssignment, entries []models.TimetableEntry, teacher bool) timetableGrid {
+	grid := timetableGrid{Title: title, Subtitle: subtitle}
+
+	columnIndex := make(map[string]int)
+	addSlot := func(slot *models.PeriodSlot) {
+		key := slot.Name + "|" + clockLabel(slot.StartTime) + "|" + clockLabel(slot.EndTime)
+		i, ok := columnIndex[key]
+		if !ok {
+			i = len(grid.Columns)
+			columnIndex[key] = i
+			grid.Columns = append(grid.Columns, gridColumn{
+				Label:   slot.Name,
+				Time:    clockLabel(slot.StartTime) + "-" + clockLabel(slot.EndTime),
+				Break:   !slot.IsTeachingPeriod() && slot.SlotType != models.PeriodSlotTypeActivity && slot.SlotType != models.PeriodSlotTypeZeroPeriod,
+				slotIDs: make(map[uuid.UUID]bool),
+				start:   clockLabel(slot.StartTime),
+			})
+		}
+		if !grid.Columns[i].slotIDs[slot.ID] {
+			grid.Columns[i].slotIDs[slot.ID] = true
+			grid.Columns[i].slots = append(grid.Columns[i].slots, slot)
+		}
+	}
+	for i := range slots {
+		addSlot(&slots[i])
+	}
+	entryDays := make(map[int]bool)
+	for i := range entries {
+		if entries[i].PeriodSlot != nil {
+			addSlot(entries[i].PeriodSlot)
+		}
+		entryDays[entries[i].DayOfWeek] = true
+	}
+	sort.SliceStable(grid.Columns, func(i, j int) bool {
+		return grid.Columns[i].start < grid.Columns[j].start
+	})
+
+	assigned := make(map[int]models.DayPatternAssignment)
+	for _, assignment := range assignments {
+		assigned[assignment.DayOfWeek] = assignment
+	}
+
+	for _, day := range printDays {
+		assignment, ok := assigned[day]
+		working := (ok && assignment.IsWorkingDay) || (len(assignments) == 0 && day != 0)
+		if !working && !entryDays[day] {
+			continue
+		}
+
+		row := gridRow{Day: models.TimetableEntry{DayOfWeek: day}.GetDayName()}
+		for _, column := range grid.Columns {
+			row.Cells = append(row.Cells, gridCellFor(column, day, assignments, entries, teacher))
+		}
+		grid.Rows = append(grid.Rows, row)
+	}
+
+	return grid
+}
+
+// gridCellFor builds the cell for a column on a weekday.
+func gridCellFor(column gridColumn, day int, assignments []models.DayPatternAssignment, entries []models.TimetableEntry, teacher bool) gridCell {
+	var cell gridCell
+	for i := range entries {
+		entry := &entries[i]
+		if entry.DayOfWeek != day || !column.slotIDs[entry.PeriodSlotID] {
+			continue
+		}
+		if entry.IsFreePeriod {
+			cell.Lines = append(cell.Lines, "Free")
+			continue
+		}
+		subject := ""
+		if entry.Subject != nil {
+			subject = entry.Subject.Name
+		}
+		cell.Lines = append(cell.Lines, subject)
+		if teacher {
+			if entry.Timetable != nil {
+				cell.Lines = append(cell.Lines, sectionLabel(entry.Timetable.Section))
+			}
+		} else if entry.Staff != nil {
+			cell.Lines = append(cell.Lines, entry.Staff.FullName())
+		}
+	}
+	if len(cell.Lines) > 0 {
+		return cell
+	}
+
+	open := false
+	for _, slot := range column.slots {
+		if dayPatternAllows(assignments, day, slot) {
+			open = true
+			break
+		}
+	}
+	switch {
+	case !open:
+		cell.Closed = true
+	case column.Break:
+		cell.Break = true
+		cell.Lines = []string{column.Label}
+	}
+	return cell
+}
+
+// clockLabel formats a period slot time as HH:MM.
+func clockLabel(clock string) string {
+	return slotTime(time.Time{}, clock, time.UTC).Format("15:04")
+}
+
+// renderTimetableGrids renders grids as an A4 landscape PDF, one grid per page.
+func renderTimetableGrids(schoolName string, grids []timetableGrid, generated time.Time) ([]byte, error) {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(false, 10)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth := 277.0 // 297 - 20 (margins)
+	dayWidth := 24.0
+	headerHeight := 10.0
+	lineHeight := 3.6
+
+	primaryColor := []int{31, 41, 55}
+	headerBg := []int{243, 244, 246}
+	borderColor := []int{209, 213, 219}
+	mutedText := []int{107, 114, 128}
+	breakBg := []int{254, 243, 199}
+	closedBg := []int{229, 231, 235}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.SetFont("Arial", "I", 7)
+		pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+		pdf.CellFormat(pageWidth/2, 4, "Generated on "+generated.Format("02 Jan 2006 15:04"), "", 0, "L", false, 0, "")
+		pdf.CellFormat(pageWidth/2, 4, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	// fit shortens text with an ellipsis so it fits a cell width.
+	fit := func(text string, width float64) string {
+		text = tr(text)
+		if pdf.GetStringWidth(text) <= width {
+			return text
+		}
+		for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+			text = text[:len(text)-1]
+		}
+		return text + "..."
+	}
+
+	for _, grid := range grids {
+		pdf.AddPage()
+		pdf.SetFont("Arial", "B", 13)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.CellFormat(pageWidth, 7, tr(schoolName), "", 1, "C", false, 0, "")
+		pdf.SetFont("Arial", "B", 11)
+		pdf.CellFormat(pageWidth, 6, tr(grid.Title), "", 1, "C", false, 0, "")
+		if grid.Subtitle != "" {
+			pdf.SetFont("Arial", "", 8)
+			pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+			pdf.CellFormat(pageWidth, 5, tr(grid.Subtitle), "", 1, "C", false, 0, "")
+		}
+		pdf.Ln(3)
+
+		if len(grid.Columns) == 0 || len(grid.Rows) == 0 {
+			pdf.SetFont("Arial", "I", 9)
+			pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+			pdf.CellFormat(pageWidth, 8, "No periods scheduled.", "", 1, "C", false, 0, "")
+			continue
+		}
+
+		columnWidth := (pageWidth - dayWidth) / float64(len(grid.Columns))
+		available := 196.0 - pdf.GetY() - headerHeight // keep clear of the footer
+		rowHeight := available / float64(len(grid.Rows))
+		if rowHeight > 24 {
+			rowHeight = 24
+		}
+
+		pdf.SetDrawColor(borderColor[0], borderColor[1], borderColor[2])
+		pdf.SetFillColor(headerBg[0], headerBg[1], headerBg[2])
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		x, y := pdf.GetX(), pdf.GetY()
+		pdf.Rect(x, y, dayWidth, headerHeight, "FD")
+		pdf.SetFont("Arial", "B", 8)
+		pdf.SetXY(x, y)
+		pdf.CellFormat(dayWidth, headerHeight, "Day", "", 0, "C", false, 0, "")
+		for i, column := range grid.Columns {
+			cx := x + dayWidth + float64(i)*columnWidth
+			pdf.Rect(cx, y, columnWidth, headerHeight, "FD")
+			pdf.SetFont("Arial", "B", 7)
+			pdf.SetXY(cx, y+1)
+			pdf.CellFormat(columnWidth, 4, fit(column.Label, columnWidth-1), "", 0, "C", false, 0, "")
+			pdf.SetFont("Arial", "", 6)
+			pdf.SetXY(cx, y+5)
+			pdf.CellFormat(columnWidth, 4, column.Time, "", 0, "C", false, 0, "")
+		}
+		y += headerHeight
+
+		maxLines := int((rowHeight - 2) / lineHeight)
+		if maxLines < 1 {
+			maxLines = 1
+		}
+		for _, row := range grid.Rows {
+			pdf.SetFillColor(headerBg[0], headerBg[1], headerBg[2])
+			pdf.Rect(x, y, dayWidth, rowHeight, "FD")
+			pdf.SetFont("Arial", "B", 8)
+			pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+			pdf.SetXY(x, y)
+			pdf.CellFormat(dayWidth, rowHeight, row.Day, "", 0, "C", false, 0, "")
+
+			for i, cell := range row.Cells {
+				cx := x + dayWidth + float64(i)*columnWidth
+				style := "D"
+				switch {
+				case cell.Closed:
+					pdf.SetFillColor(closedBg[0], closedBg[1], closedBg[2])
+					style = "FD"
+				case cell.Break:
+					pdf.SetFillColor(breakBg[0], breakBg[1], breakBg[2])
+					style = "FD"
+				}
+				pdf.Rect(cx, y, columnWidth, rowHeight, style)
+
+				lines := cell.Lines
+				if len(lines) > maxLines {
+					lines = lines[:maxLines]
+				}
+				top := y + (rowHeight-float64(len(lines))*lineHeight)/2
+				for j, line := range lines {
+					if j == 0 && !cell.Break {
+						pdf.SetFont("Arial", "B", 7)
+						pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+					} else {
+						pdf.SetFont("Arial", "", 6.5)
+						pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+					}
+					pdf.SetXY(cx, top+float64(j)*lineHeight)
+					pdf.CellFormat(columnWidth, lineHeight, fit(strings.TrimSpace(line), columnWidth-1), "", 0, "C", false, 0, "")
+				}
+			}
+			y += rowHeight
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("generate timetable pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,255 @@
+package timetable
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ========================================
+// Calendar Feed Repository Methods
+// ========================================
+
+// CreateFeed creates a calendar feed link and revokes any earlier active link
+// to the same timetable, so each section or teacher has one live link per year.
+func (r *Repository) CreateFeed(ctx context.Context, feed *models.TimetableFeed) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.TimetableFeed{}).
+			Where("tenant_id = ? AND kind = ? AND academic_year_id = ? AND revoked_at IS NULL",
+				feed.TenantID, feed.Kind, feed.AcademicYearID)
+		if feed.Kind == models.TimetableFeedKindSection {
+			query = query.Where("section_id = ?", *feed.SectionID)
+		} else {
+			query = query.Where("staff_id = ?", *feed.StaffID)
+		}
+		if err := query.Update("revoked_at", gorm.Expr("NOW()")).Error; err != nil {
+			return err
+		}
+		return tx.Create(feed).Error
+	})
+}
+
+// ListFeeds returns the active calendar feed links for a tenant with filters.
+func (r *Repository) ListFeeds(ctx context.Context, filter FeedFilter) ([]models.TimetableFeed, int64, error) {
+	var feeds []models.TimetableFeed
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.TimetableFeed{}).
+		Where("tenant_id = ? AND revoked_at IS NULL", filter.TenantID)
+
+	if filter.Kind != nil {
+		query = query.Where("kind = ?", *filter.Kind)
+	}
+
+	if filter.SectionID != nil {
+		query = query.Where("section_id = ?", *filter.SectionID)
+	}
+
+	if filter.StaffID != nil {
+		query = query.Where("staff_id = ?", *filter.StaffID)
+	}
+
+	if filter.AcademicYearID != nil {
+		query = query.Where("academic_year_id = ?", *filter.AcademicYearID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Preload("Section").
+		Preload("Section.Class").
+		Preload("Staff").
+		Preload("AcademicYear").
+		Order("created_at DESC").
+		Find(&feeds).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return feeds, total, nil
+}
+
+// GetFeedByID returns an active calendar feed link by ID.
+func (r *Repository) GetFeedByID(ctx context.Context, tenantID, id uuid.UUID) (*models.TimetableFeed, error) {
+	var feed models.TimetableFeed
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ? AND revoked_at IS NULL", tenantID, id).
+		First(&feed).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrFeedNotFound
+	}
+	return &feed, err
+}
+
+// RevokeFeed revokes a calendar feed link.
+func (r *Repository) RevokeFeed(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TimetableFeed{}).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
+
+// withoutRLS runs fn against a repository whose queries bypass row level
+// security. Public feed requests carry no tenant; the link token identifies it,
+// and every query made inside fn must still filter on that tenant.
+func (r *Repository) withoutRLS(ctx context.Context, fn func(repo *Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the link identifies the tenant
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return fn(&Repository{db: tx})
+	})
+}
+
+// GetFeedByTokenHash returns an active calendar feed link by its token hash.
+func (r *Repository) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*models.TimetableFeed, error) {
+	var feed models.TimetableFeed
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND revoked_at IS NULL", tokenHash).
+		First(&feed).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrFeedNotFound
+	}
+	return &feed, err
+}
+
+// TouchFeed records when a calendar feed link was last fetched.
+func (r *Repository) TouchFeed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TimetableFeed{}).
+		Where("id = ?", id).
+		Update("last_accessed_at", at).Error
+}
+
+// ========================================
+// Export Lookup Repository Methods
+// ========================================
+
+// GetTenant returns a tenant by ID.
+func (r *Repository) GetTenant(ctx context.Context, tenantID uuid.UUID) (*models.Tenant, error) {
+	var tenant models.Tenant
+	if err := r.db.WithContext(ctx).Where("id = ?", tenantID).First(&tenant).Error; err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// GetAcademicYear returns an academic year by ID.
+func (r *Repository) GetAcademicYear(ctx context.Context, tenantID, id uuid.UUID) (*models.AcademicYear, error) {
+	var year models.AcademicYear
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&year).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAcademicYearNotFound
+	}
+	return &year, err
+}
+
+// GetSection returns a section with its class.
+func (r *Repository) GetSection(ctx context.Context, tenantID, id uuid.UUID) (*models.Section, error) {
+	var section models.Section
+	err := r.db.WithContext(ctx).
+		Preload("Class").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&section).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSectionNotFound
+	}
+	return &section, err
+}
+
+// GetStaff returns a staff member by ID.
+func (r *Repository) GetStaff(ctx context.Context, tenantID, id uuid.UUID) (*models.Staff, error) {
+	var staff models.Staff
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&staff).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrStaffNotFound
+	}
+	return &staff, err
+}
+
+// ListHolidaysInRange returns the holidays between two dates, inclusive.
+func (r *Repository) ListHolidaysInRange(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND date >= ? AND date <= ?", tenantID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date ASC").
+		Find(&holidays).Error
+	return holidays, err
+}
+
+// ListFeedSubstitutions returns the confirmed and completed substitutions
+// between two dates that involve a teacher or cover a section.
+func (r *Repository) ListFeedSubstitutions(ctx context.Context, tenantID uuid.UUID, from, to time.Time, staffID, sectionID *uuid.UUID) ([]models.Substitution, error) {
+	var substitutions []models.Substitution
+
+	query := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND substitution_date >= ? AND substitution_date <= ?",
+			tenantID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Where("status IN ?", []models.SubstitutionStatus{models.SubstitutionStatusConfirmed, models.SubstitutionStatusCompleted})
+
+	if staffID != nil {
+		query = query.Where("original_staff_id = ? OR substitute_staff_id = ?", *staffID, *staffID)
+	}
+
+	if sectionID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM substitution_periods sp WHERE sp.substitution_id = substitutions.id AND sp.section_id = ?)", *sectionID)
+	}
+
+	err := query.
+		Preload("OriginalStaff").
+		Preload("SubstituteStaff").
+		Preload("Periods").
+		Preload("Periods.PeriodSlot").
+		Preload("Periods.Subject").
+		Preload("Periods.Section").
+		Preload("Periods.Section.Class").
+		Order("substitution_date ASC").
+		Find(&substitutions).Error
+	return substitutions, err
+}
+
+// ListPublishedEntries returns the entries of every published timetable in an
+// academic year, optionally limited to a branch or class, for bulk printing.
+func (r *Repository) ListPublishedEntries(ctx context.Context, filter PrintFilter) ([]models.TimetableEntry, error) {
+	var entries []models.TimetableEntry
+
+	query := r.db.WithContext(ctx).
+		Joins("JOIN timetables ON timetables.id = timetable_entries.timetable_id").
+		Where("timetable_entries.tenant_id = ?", filter.TenantID).
+		Where("timetables.academic_year_id = ? AND timetables.status = ? AND timetables.deleted_at IS NULL",
+			filter.AcademicYearID, models.TimetableStatusPublished)
+
+	if filter.BranchID != nil {
+		query = query.Where("timetables.branch_id = ?", *filter.BranchID)
+	}
+
+	if filter.ClassID != nil {
+		query = query.Where("timetables.section_id IN (SELECT id FROM sections WHERE class_id = ?)", *filter.ClassID)
+	}
+
+	err := query.
+		Preload("Timetable").
+		Preload("Timetable.Section").
+		Preload("Timetable.Section.Class").
+		Preload("PeriodSlot").
+		Preload("Subject").
+		Preload("Staff").
+		Order("timetable_entries.day_of_week ASC, timetable_entries.period_slot_id ASC").
+		Find(&entries).Error
+
+	return entries, err
+}
@@ -0,0 +1,553 @@
+package timetable
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FeedPastDays is how many days before today a calendar feed still lists,
+// so recent periods stay visible in subscribed calendars.
+const FeedPastDays = 28
+
+// defaultFeedTimezone is used when the tenant has no timezone configured.
+const defaultFeedTimezone = "Asia/Kolkata"
+
+// ========================================
+// Calendar Feed Service Methods
+// ========================================
+
+// CreateFeed creates a calendar feed link for a section's or a teacher's
+// timetable and returns it with its token. Any earlier link to the same
+// timetable stops working.
+func (s *Service) CreateFeed(ctx context.Context, tenantID uuid.UUID, req CreateFeedRequest, userID uuid.UUID) (*models.TimetableFeed, string, error) {
+	kind := models.TimetableFeedKind(req.Kind)
+	if !kind.IsValid() {
+		return nil, "", ErrInvalidFeedTarget
+	}
+
+	feed := &models.TimetableFeed{
+		TenantID:       tenantID,
+		Kind:           kind,
+		AcademicYearID: req.AcademicYearID,
+		CreatedBy:      &userID,
+	}
+
+	switch kind {
+	case models.TimetableFeedKindSection:
+		if req.SectionID == nil {
+			return nil, "", ErrInvalidFeedTarget
+		}
+		section, err := s.repo.GetSection(ctx, tenantID, *req.SectionID)
+		if err != nil {
+			return nil, "", err
+		}
+		feed.SectionID = req.SectionID
+		feed.Section = section
+	case models.TimetableFeedKindTeacher:
+		if req.StaffID == nil {
+			return nil, "", ErrInvalidFeedTarget
+		}
+		staff, err := s.repo.GetStaff(ctx, tenantID, *req.StaffID)
+		if err != nil {
+			return nil, "", err
+		}
+		feed.StaffID = req.StaffID
+		feed.Staff = staff
+	}
+
+	year, err := s.repo.GetAcademicYear(ctx, tenantID, req.AcademicYearID)
+	if err != nil {
+		return nil, "", err
+	}
+	feed.AcademicYear = year
+
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("generate feed token: %w", err)
+	}
+	feed.TokenHash = hashFeedToken(token)
+
+	if err := s.repo.CreateFeed(ctx, feed); err != nil {
+		return nil, "", err
+	}
+
+	return feed, token, nil
+}
+
+// CreateMyFeed creates a calendar feed link for the current user's own
+// teaching timetable.
+func (s *Service) CreateMyFeed(ctx context.Context, tenantID, userID uuid.UUID, req CreateMyFeedRequest) (*models.TimetableFeed, string, error) {
+	staffID, err := s.repo.GetStaffIDByUserID(ctx, tenantID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrStaffNotFound
+		}
+		return nil, "", err
+	}
+
+	return s.CreateFeed(ctx, tenantID, CreateFeedRequest{
+		Kind:           string(models.TimetableFeedKindTeacher),
+		StaffID:        &staffID,
+		AcademicYearID: req.AcademicYearID,
+	}, userID)
+}
+
+// ListFeeds returns the active calendar feed links with filters.
+func (s *Service) ListFeeds(ctx context.Context, filter FeedFilter) ([]models.TimetableFeed, int64, error) {
+	return s.repo.ListFeeds(ctx, filter)
+}
+
+// RevokeFeed revokes a calendar feed link. Only its creator can revoke it
+// unless the caller can manage timetables.
+func (s *Service) RevokeFeed(ctx context.Context, tenantID, id, userID uuid.UUID, canManage bool) error {
+	feed, err := s.repo.GetFeedByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	if !canManage && (feed.CreatedBy == nil || *feed.CreatedBy != userID) {
+		return ErrFeedNotOwned
+	}
+
+	return s.repo.RevokeFeed(ctx, tenantID, id)
+}
+
+// RenderFeed renders the iCalendar document for a feed link token. The
+// request is unauthenticated, so the token alone identifies the tenant.
+func (s *Service) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	token = strings.TrimSuffix(token, ".ics")
+	if token == "" {
+		return nil, ErrFeedNotFound
+	}
+
+	now := time.Now()
+	var calendar []byte
+	err := s.repo.withoutRLS(ctx, func(repo *Repository) error {
+		feed, err := repo.GetFeedByTokenHash(ctx, hashFeedToken(token))
+		if err != nil {
+			return err
+		}
+
+		tenant, err := repo.GetTenant(ctx, feed.TenantID)
+		if err != nil {
+			return fmt.Errorf("get tenant: %w", err)
+		}
+		timezone := tenant.Settings.Timezone
+		if timezone == "" {
+			timezone = defaultFeedTimezone
+		}
+		location := feedLocation(timezone)
+
+		schedule, name, err := loadFeedSchedule(ctx, repo, feed, now.In(location), location)
+		if err != nil {
+			return err
+		}
+
+		calendar = writeCalendar(tenant.Name+" - "+name, timezone, buildFeedEvents(schedule), now)
+		return repo.TouchFeed(ctx, feed.ID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return calendar, nil
+}
+
+// loadFeedSchedule loads everything a feed's events are built from and
+// returns it with the calendar's display name.
+func loadFeedSchedule(ctx context.Context, repo *Repository, feed *models.TimetableFeed, today time.Time, location *time.Location) (feedSchedule, string, error) {
+	schedule := feedSchedule{
+		Kind:        feed.Kind,
+		Assignments: make(map[uuid.UUID][]models.DayPatternAssignment),
+		Location:    location,
+	}
+
+	year, err := repo.GetAcademicYear(ctx, feed.TenantID, feed.AcademicYearID)
+	if err != nil {
+		return schedule, "", err
+	}
+	schedule.Window = feedWindowFor(year, today)
+
+	var name string
+	switch feed.Kind {
+	case models.TimetableFeedKindSection:
+		schedule.SectionID = *feed.SectionID
+		section, err := repo.GetSection(ctx, feed.TenantID, schedule.SectionID)
+		if err != nil {
+			return schedule, "", err
+		}
+		name = sectionLabel(section) + " Timetable"
+
+		schedule.Entries, err = repo.ListReleasedEntries(ctx, feed.TenantID, feed.AcademicYearID, &schedule.SectionID, nil)
+		if err != nil {
+			return schedule, "", fmt.Errorf("get section timetable: %w", err)
+		}
+	case models.TimetableFeedKindTeacher:
+		schedule.StaffID = *feed.StaffID
+		staff, err := repo.GetStaff(ctx, feed.TenantID, schedule.StaffID)
+		if err != nil {
+			return schedule, "", err
+		}
+		name = staff.FullName() + " Timetable"
+
+		schedule.Entries, err = repo.ListReleasedEntries(ctx, feed.TenantID, feed.AcademicYearID, nil, &schedule.StaffID)
+		if err != nil {
+			return schedule, "", fmt.Errorf("get teacher schedule: %w", err)
+		}
+	}
+
+	for i := range schedule.Entries {
+		if schedule.Entries[i].Timetable == nil {
+			continue
+		}
+		branchID := schedule.Entries[i].Timetable.BranchID
+		if _, ok := schedule.Assignments[branchID]; ok {
+			continue
+		}
+		assignments, err := repo.ListDayPatternAssignments(ctx, feed.TenantID, branchID)
+		if err != nil {
+			return schedule, "", fmt.Errorf("list day pattern assignments: %w", err)
+		}
+		schedule.Assignments[branchID] = assignments
+	}
+
+	if schedule.Window.To.Before(schedule.Window.From) {
+		return schedule, name, nil
+	}
+
+	schedule.Holidays, err = repo.ListHolidaysInRange(ctx, feed.TenantID, schedule.Window.From, schedule.Window.To)
+	if err != nil {
+		return schedule, "", fmt.Errorf("list holidays: %w", err)
+	}
+
+	var staffID, sectionID *uuid.UUID
+	if feed.Kind == models.TimetableFeedKindSection {
+		sectionID = &schedule.SectionID
+	} else {
+		staffID = &schedule.StaffID
+	}
+	schedule.Substitutions, err = repo.ListFeedSubstitutions(ctx, feed.TenantID, schedule.Window.From, schedule.Window.To, staffID, sectionID)
+	if err != nil {
+		return schedule, "", fmt.Errorf("list substitutions: %w", err)
+	}
+
+	return schedule, name, nil
+}
+
+// feedWindowFor returns the dates a feed covers: the academic year, starting
+// no earlier than FeedPastDays before today.
+func feedWindowFor(year *models.AcademicYear, today time.Time) feedWindow {
+	from := dateOf(today).AddDate(0, 0, -FeedPastDays)
+	if start := dateOf(year.StartDate); start.After(from) {
+		from = start
+	}
+	return feedWindow{From: from, To: dateOf(year.EndDate)}
+}
+
+// feedLocation loads a time zone, falling back to Indian Standard Time when
+// the zone database is unavailable.
+func feedLocation(name string) *time.Location {
+	if location, err := time.LoadLocation(name); err == nil {
+		return location
+	}
+	return time.FixedZone("IST", 5*60*60+30*60)
+}
+
+// newFeedToken returns a random URL-safe token for a calendar feed link.
+func newFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashFeedToken returns the SHA-256 hex digest stored in place of a feed token.
+func hashFeedToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// ========================================
+// Printable Timetable Service Methods
+// ========================================
+
+// SectionTimetablePDF renders a timetable as a printable weekly grid.
+func (s *Service) SectionTimetablePDF(ctx context.Context, tenantID, timetableID uuid.UUID) ([]byte, string, error) {
+	timetable, err := s.repo.GetTimetableByID(ctx, tenantID, timetableID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	slots, assignments, err := s.branchLayout(ctx, tenantID, timetable.BranchID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	grid := buildGrid(sectionLabel(timetable.Section), timetableSubtitle(timetable), slots, assignments, timetable.Entries, false)
+	pdf, err := s.renderGrids(ctx, tenantID, []timetableGrid{grid})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pdf, fileName("timetable", sectionLabel(timetable.Section)), nil
+}
+
+// TeacherTimetablePDF renders a teacher's published timetable for an academic
+// year as a printable weekly grid.
+func (s *Service) TeacherTimetablePDF(ctx context.Context, tenantID, staffID, academicYearID uuid.UUID) ([]byte, string, error) {
+	staff, err := s.repo.GetStaff(ctx, tenantID, staffID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	year, err := s.repo.GetAcademicYear(ctx, tenantID, academicYearID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, err := s.repo.GetTeacherSchedule(ctx, tenantID, staffID, academicYearID, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	slots, assignments, err := s.branchLayout(ctx, tenantID, staff.BranchID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	grid := buildGrid(staff.FullName(), teacherSubtitle(staff, year, entries), slots, assignments, entries, true)
+	pdf, err := s.renderGrids(ctx, tenantID, []timetableGrid{grid})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pdf, fileName("timetable", staff.FullName()), nil
+}
+
+// PrintTimetables renders every published timetable matching the filter as a
+// single PDF, one page per section or one page per teacher.
+func (s *Service) PrintTimetables(ctx context.Context, filter PrintFilter) ([]byte, string, error) {
+	year, err := s.repo.GetAcademicYear(ctx, filter.TenantID, filter.AcademicYearID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, err := s.repo.ListPublishedEntries(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	layouts := make(map[uuid.UUID]gridLayout)
+	layoutFor := func(branchID uuid.UUID) (gridLayout, error) {
+		if layout, ok := layouts[branchID]; ok {
+			return layout, nil
+		}
+		slots, assignments, err := s.branchLayout(ctx, filter.TenantID, branchID)
+		if err != nil {
+			return gridLayout{}, err
+		}
+		layouts[branchID] = gridLayout{slots: slots, assignments: assignments}
+		return layouts[branchID], nil
+	}
+
+	var grids []timetableGrid
+	if filter.By == models.TimetableFeedKindTeacher {
+		for _, group := range groupByTeacher(entries) {
+			layout, err := layoutFor(group.staff.BranchID)
+			if err != nil {
+				return nil, "", err
+			}
+			grids = append(grids, buildGrid(group.staff.FullName(), teacherSubtitle(group.staff, year, group.entries), layout.slots, layout.assignments, group.entries, true))
+		}
+	} else {
+		for _, group := range groupBySection(entries) {
+			layout, err := layoutFor(group.timetable.BranchID)
+			if err != nil {
+				return nil, "", err
+			}
+			grids = append(grids, buildGrid(sectionLabel(group.timetable.Section), timetableSubtitle(group.timetable), layout.slots, layout.assignments, group.entries, false))
+		}
+	}
+
+	if len(grids) == 0 {
+		return nil, "", ErrNothingToPrint
+	}
+
+	pdf, err := s.renderGrids(ctx, filter.TenantID, grids)
+	if err != nil {
+		return nil, "", err
+	}
+
+	by := string(filter.By)
+	if by == "" {
+		by = string(models.TimetableFeedKindSection)
+	}
+	return pdf, fileName("timetables-"+by, year.Name), nil
+}
+
+// gridLayout is a branch's active period slots and day pattern assignments.
+type gridLayout struct {
+	slots       []models.PeriodSlot
+	assignments []models.DayPatternAssignment
+}
+
+// branchLayout loads the period slots and day pattern assignments a printed
+// grid for a branch is laid out with.
+func (s *Service) branchLayout(ctx context.Context, tenantID, branchID uuid.UUID) ([]models.PeriodSlot, []models.DayPatternAssignment, error) {
+	isActive := true
+	slots, _, err := s.repo.ListPeriodSlots(ctx, PeriodSlotFilter{
+		TenantID: tenantID,
+		BranchID: &branchID,
+		IsActive: &isActive,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list period slots: %w", err)
+	}
+
+	assignments, err := s.repo.ListDayPatternAssignments(ctx, tenantID, branchID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list day pattern assignments: %w", err)
+	}
+
+	return slots, assignments, nil
+}
+
+// renderGrids renders grids under the tenant's name.
+func (s *Service) renderGrids(ctx context.Context, tenantID uuid.UUID, grids []timetableGrid) ([]byte, error) {
+	tenant, err := s.repo.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("get tenant: %w", err)
+	}
+	return renderTimetableGrids(tenant.Name, grids, time.Now())
+}
+
+// sectionGroup is a published timetable's entries.
+type sectionGroup struct {
+	timetable *models.Timetable
+	entries   []models.TimetableEntry
+}
+
+// teacherGroup is a teacher's entries across published timetables.
+type teacherGroup struct {
+	staff   *models.Staff
+	entries []models.TimetableEntry
+}
+
+// groupBySection groups entries by timetable, ordered by class and section.
+func groupBySection(entries []models.TimetableEntry) []sectionGroup {
+	index := make(map[uuid.UUID]int)
+	var groups []sectionGroup
+	for _, entry := range entries {
+		if entry.Timetable == nil {
+			continue
+		}
+		i, ok := index[entry.TimetableID]
+		if !ok {
+			i = len(groups)
+			index[entry.TimetableID] = i
+			groups = append(groups, sectionGroup{timetable: entry.Timetable})
+		}
+		groups[i].entries = append(groups[i].entries, entry)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].timetable.Section, groups[j].timetable.Section
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		if a.Class.DisplayOrder != b.Class.DisplayOrder {
+			return a.Class.DisplayOrder < b.Class.DisplayOrder
+		}
+		if a.Class.Name != b.Class.Name {
+			return a.Class.Name < b.Class.Name
+		}
+		if a.DisplayOrder != b.DisplayOrder {
+			return a.DisplayOrder < b.DisplayOrder
+		}
+		return a.Name < b.Name
+	})
+	return groups
+}
+
+// groupByTeacher groups entries by teacher, ordered by name. Entries without
+// a teacher are left out.
+func groupByTeacher(entries []models.TimetableEntry) []teacherGroup {
+	index := make(map[uuid.UUID]int)
+	var groups []teacherGroup
+	for _, entry := range entries {
+		if entry.StaffID == nil || entry.Staff == nil {
+			continue
+		}
+		i, ok := index[*entry.StaffID]
+		if !ok {
+			i = len(groups)
+			index[*entry.StaffID] = i
+			groups = append(groups, teacherGroup{staff: entry.Staff})
+		}
+		groups[i].entries = append(groups[i].entries, entry)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].staff.FullName() < groups[j].staff.FullName()
+	})
+	return groups
+}
+
+// timetableSubtitle describes a section timetable, e.g.
+// "Term 1 Timetable - 2025-26 - Main Campus".
+func timetableSubtitle(timetable *models.Timetable) string {
+	parts := []string{timetable.Name}
+	if timetable.AcademicYear != nil {
+		parts = append(parts, timetable.AcademicYear.Name)
+	}
+	if timetable.Branch != nil {
+		parts = append(parts, timetable.Branch.Name)
+	}
+	return strings.Join(parts, " - ")
+}
+
+// teacherSubtitle describes a teacher timetable with their employee ID,
+// academic year and weekly period count.
+func teacherSubtitle(staff *models.Staff, year *models.AcademicYear, entries []models.TimetableEntry) string {
+	periods := 0
+	for _, entry := range entries {
+		if !entry.IsFreePeriod {
+			periods++
+		}
+	}
+	return fmt.Sprintf("%s - %s - %d periods a week", staff.EmployeeID, year.Name, periods)
+}
+
+// fileName builds a download file name such as "timetable-class-5-a.pdf".
+func fileName(prefix, name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if slug == "" {
+		return prefix + ".pdf"
+	}
+	return prefix + "-" + slug + ".pdf"
+}
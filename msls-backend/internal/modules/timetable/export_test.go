@@ -0,0 +1,343 @@
+package timetable
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// exportFixture is a section with a regular weekday pattern and a half-day
+// Saturday pattern, taught by two teachers.
+type exportFixture struct {
+	branchID    uuid.UUID
+	section     *models.Section
+	timetable   *models.Timetable
+	regular     *models.PeriodSlot
+	halfDay     *models.PeriodSlot
+	lunch       *models.PeriodSlot
+	maths       models.TimetableEntry
+	english     models.TimetableEntry
+	science     models.TimetableEntry
+	teacher     *models.Staff
+	substitute  *models.Staff
+	assignments []models.DayPatternAssignment
+}
+
+func newExportFixture() exportFixture {
+	branchID := uuid.New()
+	regularPattern := uuid.New()
+	halfDayPattern := uuid.New()
+
+	f := exportFixture{
+		branchID:   branchID,
+		section:    &models.Section{ID: uuid.New(), Name: "A", Class: models.Class{Name: "Class 5"}},
+		regular:    &models.PeriodSlot{ID: uuid.New(), Name: "Period 1", SlotType: models.PeriodSlotTypeRegular, StartTime: "09:00:00", EndTime: "09:45:00", DayPatternID: &regularPattern},
+		halfDay:    &models.PeriodSlot{ID: uuid.New(), Name: "Period 1", SlotType: models.PeriodSlotTypeShort, StartTime: "09:00:00", EndTime: "09:30:00", DayPatternID: &halfDayPattern},
+		lunch:      &models.PeriodSlot{ID: uuid.New(), Name: "Lunch", SlotType: models.PeriodSlotTypeLunch, StartTime: "12:00", EndTime: "12:30"},
+		teacher:    &models.Staff{ID: uuid.New(), FirstName: "Asha", LastName: "Rao", EmployeeID: "EMP001"},
+		substitute: &models.Staff{ID: uuid.New(), FirstName: "Vikram", LastName: "Das", EmployeeID: "EMP002"},
+		assignments: []models.DayPatternAssignment{
+			{DayOfWeek: 0, IsWorkingDay: false},
+			{DayOfWeek: 1, IsWorkingDay: true, DayPatternID: &regularPattern},
+			{DayOfWeek: 6, IsWorkingDay: true, DayPatternID: &halfDayPattern},
+		},
+	}
+	f.section.Class.ID = uuid.New()
+	f.timetable = &models.Timetable{ID: uuid.New(), BranchID: branchID, SectionID: f.section.ID, Section: f.section, Name: "Term 1"}
+
+	entry := func(weekday int, slot *models.PeriodSlot, subject string, staff *models.Staff) models.TimetableEntry {
+		return models.TimetableEntry{
+			ID:           uuid.New(),
+			TimetableID:  f.timetable.ID,
+			Timetable:    f.timetable,
+			DayOfWeek:    weekday,
+			PeriodSlotID: slot.ID,
+			PeriodSlot:   slot,
+			Subject:      &models.Subject{Name: subject},
+			StaffID:      &staff.ID,
+			Staff:        staff,
+		}
+	}
+	f.maths = entry(1, f.regular, "Maths", f.teacher)
+	f.english = entry(6, f.regular, "English", f.teacher)
+	f.science = entry(6, f.halfDay, "Science", f.substitute)
+	return f
+}
+
+// substitution has the substitute cover the Monday maths period.
+func (f exportFixture) substitution(date time.Time, status models.SubstitutionStatus) models.Substitution {
+	return models.Substitution{
+		ID:                uuid.New(),
+		BranchID:          f.branchID,
+		OriginalStaffID:   f.teacher.ID,
+		SubstituteStaffID: f.substitute.ID,
+		SubstitutionDate:  date,
+		Status:            status,
+		OriginalStaff:     f.teacher,
+		SubstituteStaff:   f.substitute,
+		Periods: []models.SubstitutionPeriod{{
+			ID:               uuid.New(),
+			TimetableEntryID: &f.maths.ID,
+			PeriodSlotID:     f.regular.ID,
+			PeriodSlot:       f.regular,
+			Subject:          &models.Subject{Name: "Maths"},
+			SectionID:        &f.section.ID,
+			Section:          f.section,
+			RoomNumber:       "Lab 2",
+		}},
+	}
+}
+
+func (f exportFixture) schedule(kind models.TimetableFeedKind) feedSchedule {
+	return feedSchedule{
+		Kind:        kind,
+		SectionID:   f.section.ID,
+		Entries:     []models.TimetableEntry{f.maths, f.english, f.science},
+		Assignments: map[uuid.UUID][]models.DayPatternAssignment{f.branchID: f.assignments},
+		// Monday 5 Jan to Monday 12 Jan 2026
+		Window:   feedWindow{From: day(2026, 1, 5), To: day(2026, 1, 12)},
+		Location: time.UTC,
+	}
+}
+
+func summaries(events []calendarEvent) []string {
+	var out []string
+	for _, event := range events {
+		out = append(out, event.Start.Format("Mon 02")+" "+event.Summary)
+	}
+	return out
+}
+
+func TestBuildFeedEventsFollowsDayPatternsAndHolidays(t *testing.T) {
+	f := newExportFixture()
+	s := f.schedule(models.TimetableFeedKindSection)
+	s.Holidays = []models.Holiday{
+		{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "Pongal", Date: day(2026, 1, 12)},
+		{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "Optional", Date: day(2026, 1, 10), IsOptional: true},
+		{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "Other branch", Date: day(2026, 1, 5), BranchID: ptrUUID(uuid.New())},
+	}
+
+	events := buildFeedEvents(s)
+
+	// English is on the regular slot, which the half-day Saturday pattern does not run
+	assert.Equal(t, []string{
+		"Mon 05 Maths",
+		"Sat 10 Science",
+		"Mon 12 Holiday: Pongal",
+	}, summaries(events))
+
+	maths := events[0]
+	assert.Equal(t, time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), maths.Start)
+	assert.Equal(t, time.Date(2026, 1, 5, 9, 45, 0, 0, time.UTC), maths.End)
+	assert.Contains(t, maths.Description, "Teacher: Asha Rao")
+	assert.True(t, events[2].AllDay)
+}
+
+func TestBuildFeedEventsRespectsEffectiveDates(t *testing.T) {
+	f := newExportFixture()
+	from := day(2026, 1, 6)
+	f.timetable.EffectiveFrom = &from
+
+	events := buildFeedEvents(f.schedule(models.TimetableFeedKindSection))
+
+	assert.Equal(t, []string{"Sat 10 Science", "Mon 12 Maths"}, summaries(events))
+}
+
+func TestBuildFeedEventsSectionShowsConfirmedSubstitute(t *testing.T) {
+	f := newExportFixture()
+	s := f.schedule(models.TimetableFeedKindSection)
+	s.Substitutions = []models.Substitution{f.substitution(day(2026, 1, 5), models.SubstitutionStatusConfirmed)}
+
+	events := buildFeedEvents(s)
+
+	require.NotEmpty(t, events)
+	assert.Equal(t, "Maths (substitution)", events[0].Summary)
+	assert.Contains(t, events[0].Description, "Substitute: Vikram Das")
+	assert.Contains(t, events[0].Description, "Regular teacher: Asha Rao")
+	assert.Equal(t, "Lab 2", events[0].Location)
+}
+
+func TestBuildFeedEventsIgnoresPendingSubstitution(t *testing.T) {
+	f := newExportFixture()
+	s := f.schedule(models.TimetableFeedKindSection)
+	s.Substitutions = []models.Substitution{f.substitution(day(2026, 1, 5), models.SubstitutionStatusPending)}
+
+	events := buildFeedEvents(s)
+
+	require.NotEmpty(t, events)
+	assert.Equal(t, "Maths", events[0].Summary)
+}
+
+func TestBuildFeedEventsTeacherFeeds(t *testing.T) {
+	f := newExportFixture()
+	substitution := f.substitution(day(2026, 1, 5), models.SubstitutionStatusConfirmed)
+
+	absent := f.schedule(models.TimetableFeedKindTeacher)
+	absent.StaffID = f.teacher.ID
+	absent.Entries = []models.TimetableEntry{f.maths, f.english}
+	absent.Substitutions = []models.Substitution{substitution}
+	assert.Equal(t, []string{"Mon 12 Maths - Class 5 A"}, summaries(buildFeedEvents(absent)),
+		"the covered period leaves the absent teacher's feed")
+
+	covering := f.schedule(models.TimetableFeedKindTeacher)
+	covering.StaffID = f.substitute.ID
+	covering.Entries = []models.TimetableEntry{f.science}
+	covering.Substitutions = []models.Substitution{substitution}
+	events := buildFeedEvents(covering)
+	assert.Equal(t, []string{
+		"Mon 05 Substitution: Maths - Class 5 A",
+		"Sat 10 Science - Class 5 A",
+	}, summaries(events))
+	assert.Contains(t, events[0].Description, "Covering for: Asha Rao")
+}
+
+func TestSlotTimeUsesLocation(t *testing.T) {
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+
+	start := slotTime(day(2026, 1, 5), "09:00:00", ist)
+	assert.Equal(t, "20260105T033000Z", start.UTC().Format("20060102T150405Z"))
+
+	assert.Equal(t, 14, slotTime(day(2026, 1, 5), "14:05", time.UTC).Hour())
+	assert.Equal(t, 8, slotTime(day(2026, 1, 5), "0000-01-01T08:15:00Z", time.UTC).Hour())
+}
+
+func TestWriteCalendar(t *testing.T) {
+	events := []calendarEvent{
+		{
+			UID:         "entry-20260105@msls",
+			Summary:     "Maths, Science; Lab",
+			Description: "Period: Period 1\nTeacher: Asha Rao",
+			Start:       time.Date(2026, 1, 5, 9, 0, 0, 0, time.FixedZone("IST", 5*60*60+30*60)),
+			End:         time.Date(2026, 1, 5, 9, 45, 0, 0, time.FixedZone("IST", 5*60*60+30*60)),
+		},
+		{UID: "holiday@msls", Summary: "Holiday: Pongal", Start: day(2026, 1, 12), AllDay: true},
+	}
+
+	out := string(writeCalendar("Springfield School - Class 5 A Timetable", "Asia/Kolkata", events, day(2026, 1, 1)))
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "X-WR-TIMEZONE:Asia/Kolkata\r\n")
+	assert.Contains(t, out, "DTSTART:20260105T033000Z\r\n")
+	assert.Contains(t, out, "DTEND:20260105T041500Z\r\n")
+	assert.Contains(t, out, `SUMMARY:Maths\, Science\; Lab`)
+	assert.Contains(t, out, `DESCRIPTION:Period: Period 1\nTeacher: Asha Rao`)
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20260112\r\nDTEND;VALUE=DATE:20260113\r\n")
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VEVENT"))
+}
+
+func TestWriteFoldedKeepsLinesShortAndRunesWhole(t *testing.T) {
+	var buf bytes.Buffer
+	content := "SUMMARY:" + strings.Repeat("गणित ", 40)
+
+	writeFolded(&buf, content)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	assert.Equal(t, content+"\r\n", unfolded)
+}
+
+func TestFeedWindowFor(t *testing.T) {
+	year := &models.AcademicYear{StartDate: day(2025, 6, 1), EndDate: day(2026, 3, 31)}
+
+	window := feedWindowFor(year, time.Date(2026, 1, 29, 18, 0, 0, 0, time.UTC))
+	assert.Equal(t, day(2026, 1, 1), window.From)
+	assert.Equal(t, day(2026, 3, 31), window.To)
+
+	window = feedWindowFor(year, day(2025, 6, 10))
+	assert.Equal(t, day(2025, 6, 1), window.From, "the window never starts before the academic year")
+}
+
+func TestBuildGrid(t *testing.T) {
+	f := newExportFixture()
+	slots := []models.PeriodSlot{*f.lunch, *f.regular, *f.halfDay}
+
+	grid := buildGrid("Class 5 A", "Term 1", slots, f.assignments, []models.TimetableEntry{f.maths, f.science}, false)
+
+	require.Len(t, grid.Columns, 3)
+	assert.Equal(t, "09:00-09:45", grid.Columns[0].Time)
+	assert.Equal(t, "09:00-09:30", grid.Columns[1].Time)
+	assert.Equal(t, "Lunch", grid.Columns[2].Label)
+	assert.True(t, grid.Columns[2].Break)
+
+	// Sunday is a non-working day and the other weekdays are unassigned
+	require.Len(t, grid.Rows, 2)
+	monday, saturday := grid.Rows[0], grid.Rows[1]
+	assert.Equal(t, "Monday", monday.Day)
+	assert.Equal(t, "Saturday", saturday.Day)
+
+	assert.Equal(t, []string{"Maths", "Asha Rao"}, monday.Cells[0].Lines)
+	assert.True(t, monday.Cells[1].Closed, "the half-day slot does not run on Monday")
+	assert.True(t, monday.Cells[2].Break)
+
+	assert.True(t, saturday.Cells[0].Closed, "the regular slot does not run on Saturday")
+	assert.Equal(t, []string{"Science", "Vikram Das"}, saturday.Cells[1].Lines)
+}
+
+func TestBuildGridWithoutAssignmentsPrintsMondayToSaturday(t *testing.T) {
+	f := newExportFixture()
+	f.maths.Timetable = nil
+
+	grid := buildGrid("Asha Rao", "", []models.PeriodSlot{*f.regular}, nil, []models.TimetableEntry{f.maths}, true)
+
+	require.Len(t, grid.Rows, 6)
+	assert.Equal(t, "Monday", grid.Rows[0].Day)
+	assert.Equal(t, "Saturday", grid.Rows[5].Day)
+	assert.Equal(t, []string{"Maths"}, grid.Rows[0].Cells[0].Lines)
+	assert.Empty(t, grid.Rows[1].Cells[0].Lines)
+}
+
+func TestRenderTimetableGrids(t *testing.T) {
+	f := newExportFixture()
+	grids := []timetableGrid{
+		buildGrid("Class 5 A", "Term 1", []models.PeriodSlot{*f.regular, *f.lunch}, f.assignments, []models.TimetableEntry{f.maths}, false),
+		{Title: "Empty"},
+	}
+
+	pdf, err := renderTimetableGrids("Springfield School", grids, day(2026, 1, 1))
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+}
+
+func TestGroupBySectionAndTeacher(t *testing.T) {
+	f := newExportFixture()
+	other := &models.Timetable{ID: uuid.New(), Section: &models.Section{Name: "B", Class: models.Class{Name: "Class 1", DisplayOrder: 1}}}
+	f.section.Class.DisplayOrder = 5
+	history := models.TimetableEntry{TimetableID: other.ID, Timetable: other, StaffID: &f.substitute.ID, Staff: f.substitute}
+
+	sections := groupBySection([]models.TimetableEntry{f.maths, history, f.science})
+	require.Len(t, sections, 2)
+	assert.Equal(t, "B", sections[0].timetable.Section.Name)
+	assert.Len(t, sections[1].entries, 2)
+
+	teachers := groupByTeacher([]models.TimetableEntry{f.science, f.maths, history})
+	require.Len(t, teachers, 2)
+	assert.Equal(t, "Asha Rao", teachers[0].staff.FullName())
+	assert.Len(t, teachers[1].entries, 2)
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "timetable-class-5-a.pdf", fileName("timetable", "Class 5 A"))
+	assert.Equal(t, "timetables-teacher-2025-26.pdf", fileName("timetables-teacher", "2025-26"))
+	assert.Equal(t, "timetable.pdf", fileName("timetable", "  "))
+}
+
+func ptrUUID(id uuid.UUID) *uuid.UUID {
+	return &id
+}
@@ -247,6 +247,10 @@ func (h *Handler) CreateShift(c *gin.Context) {
 			apperr.Abort(c, apperr.Conflict("Shift code already exists"))
 			return
 		}
+		if errors.Is(err, ErrInvalidShiftDeadline) {
+			apperr.Abort(c, apperr.BadRequest(err.Error()))
+			return
+		}
 		apperr.Abort(c, apperr.InternalError("Failed to create shift"))
 		return
 	}
@@ -284,6 +288,10 @@ func (h *Handler) UpdateShift(c *gin.Context) {
 			apperr.Abort(c, apperr.Conflict("Shift code already exists"))
 			return
 		}
+		if errors.Is(err, ErrInvalidShiftDeadline) {
+			apperr.Abort(c, apperr.BadRequest(err.Error()))
+			return
+		}
 		apperr.Abort(c, apperr.InternalError("Failed to update shift"))
 		return
 	}
@@ -904,6 +912,14 @@ func (h *Handler) PublishTimetable(c *gin.Context) {
 			apperr.Abort(c, apperr.Conflict("Timetable is already published"))
 			return
 		}
+		if errors.Is(err, ErrVersionOverlap) {
+			apperr.Abort(c, apperr.Conflict(err.Error()))
+			return
+		}
+		if errors.Is(err, ErrInvalidEffectiveDates) {
+			apperr.Abort(c, apperr.BadRequest(err.Error()))
+			return
+		}
 		apperr.Abort(c, apperr.InternalError("Failed to publish timetable"))
 		return
 	}
@@ -911,7 +927,7 @@ func (h *Handler) PublishTimetable(c *gin.Context) {
 	response.OK(c, TimetableToResponse(timetable))
 }
 
-// ArchiveTimetable archives a published timetable.
+// ArchiveTimetable archives a published timetable or cancels a scheduled one.
 func (h *Handler) ArchiveTimetable(c *gin.Context) {
 	tenantID, ok := middleware.GetCurrentTenantID(c)
 	if !ok {
@@ -931,6 +947,10 @@ func (h *Handler) ArchiveTimetable(c *gin.Context) {
 			apperr.Abort(c, apperr.NotFound("Timetable not found"))
 			return
 		}
+		if errors.Is(err, ErrTimetableNotReleased) {
+			apperr.Abort(c, apperr.BadRequest(err.Error()))
+			return
+		}
 		apperr.Abort(c, apperr.InternalError("Failed to archive timetable"))
 		return
 	}
@@ -1191,7 +1211,12 @@ func (h *Handler) GetTeacherSchedule(c *gin.Context) {
 		return
 	}
 
-	entries, err := h.service.GetTeacherSchedule(c.Request.Context(), tenantID, staffID, academicYearID)
+	on, ok := optionalDate(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.service.GetTeacherSchedule(c.Request.Context(), tenantID, staffID, academicYearID, on)
 	if err != nil {
 		apperr.Abort(c, apperr.InternalError("Failed to get teacher schedule"))
 		return
@@ -1241,7 +1266,12 @@ func (h *Handler) GetMySchedule(c *gin.Context) {
 		return
 	}
 
-	entries, err := h.service.GetTeacherSchedule(c.Request.Context(), tenantID, staffID, academicYearID)
+	on, ok := optionalDate(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.service.GetTeacherSchedule(c.Request.Context(), tenantID, staffID, academicYearID, on)
 	if err != nil {
 		apperr.Abort(c, apperr.InternalError("Failed to get schedule"))
 		return
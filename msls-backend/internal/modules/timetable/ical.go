@@ -0,0 +1,394 @@
+package timetable
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+)
+
+// calendarEvent is a single event in a timetable calendar feed. All-day
+// events (holidays) use Start's date only.
+type calendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+}
+
+// feedSchedule holds what a calendar feed's events are built from. Entries
+// must have their Timetable (with Section and Class) and PeriodSlot loaded;
+// Assignments are keyed by branch.
+type feedSchedule struct {
+	Kind          models.TimetableFeedKind
+	StaffID       uuid.UUID
+	SectionID     uuid.UUID
+	Entries       []models.TimetableEntry
+	Assignments   map[uuid.UUID][]models.DayPatternAssignment
+	Holidays      []models.Holiday
+	Substitutions []models.Substitution
+	Window        feedWindow
+	Location      *time.Location
+}
+
+// buildFeedEvents expands a weekly timetable into dated events across the
+// feed window. A day is skipped when its day pattern assignment marks it as
+// non-working, and a period is skipped when its slot belongs to a different
+// day pattern than the one assigned to that weekday. Non-optional holidays
+// replace the day's periods with an all-day event. Confirmed substitutions
+// show the substitute on a section's feed, drop the period from the absent
+// teacher's feed and add it to the substitute's.
+func buildFeedEvents(s feedSchedule) []calendarEvent {
+	branches := make(map[uuid.UUID]bool)
+	for i := range s.Entries {
+		if s.Entries[i].Timetable != nil {
+			branches[s.Entries[i].Timetable.BranchID] = true
+		}
+	}
+
+	holidays := make(map[string][]models.Holiday)
+	for _, holiday := range s.Holidays {
+		if holiday.IsOptional {
+			continue
+		}
+		key := holiday.Date.Format("2006-01-02")
+		holidays[key] = append(holidays[key], holiday)
+	}
+
+	substitutions := make(map[string][]models.Substitution)
+	for _, substitution := range s.Substitutions {
+		if substitution.Status != models.SubstitutionStatusConfirmed && substitution.Status != models.SubstitutionStatusCompleted {
+			continue
+		}
+		key := substitution.SubstitutionDate.Format("2006-01-02")
+		substitutions[key] = append(substitutions[key], substitution)
+	}
+
+	var events []calendarEvent
+	for day := dateOf(s.Window.From); !day.After(dateOf(s.Window.To)); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		dayHolidays := holidays[key]
+		daySubstitutions := substitutions[key]
+
+		for _, holiday := range dayHolidays {
+			if holiday.BranchID == nil || branches[*holiday.BranchID] {
+				events = append(events, calendarEvent{
+					UID:     fmt.Sprintf("holiday-%s-%s@msls", holiday.ID, day.Format("20060102")),
+					Summary: "Holiday: " + holiday.Name,
+					Start:   day,
+					AllDay:  true,
+				})
+			}
+		}
+
+		weekday := int(day.Weekday())
+		for i := range s.Entries {
+			entry := &s.Entries[i]
+			if entry.DayOfWeek != weekday || entry.IsFreePeriod || entry.PeriodSlot == nil || entry.Timetable == nil {
+				continue
+			}
+			timetable := entry.Timetable
+			if !effectiveOn(timetable, day) ||
+				!dayPatternAllows(s.Assignments[timetable.BranchID], weekday, entry.PeriodSlot) ||
+				holidayFor(dayHolidays, timetable.BranchID) {
+				continue
+			}
+
+			substitution, period := coveringSubstitution(daySubstitutions, entry)
+			if substitution != nil && s.Kind == models.TimetableFeedKindTeacher && substitution.OriginalStaffID == s.StaffID {
+				continue
+			}
+			events = append(events, entryEvent(s, entry, day, substitution, period))
+		}
+
+		if s.Kind == models.TimetableFeedKindTeacher {
+			for i := range daySubstitutions {
+				substitution := &daySubstitutions[i]
+				if substitution.SubstituteStaffID != s.StaffID || holidayFor(dayHolidays, substitution.BranchID) {
+					continue
+				}
+				for j := range substitution.Periods {
+					if event, ok := coverEvent(s, substitution, &substitution.Periods[j], day); ok {
+						events = append(events, event)
+					}
+				}
+			}
+		}
+	}
+
+	return events
+}
+
+// entryEvent builds the event for a timetable entry on a date.
+func entryEvent(s feedSchedule, entry *models.TimetableEntry, day time.Time, substitution *models.Substitution, period *models.SubstitutionPeriod) calendarEvent {
+	subject := entrySubject(entry)
+	event := calendarEvent{
+		UID:      fmt.Sprintf("%s-%s@msls", entry.ID, day.Format("20060102")),
+		Location: entryRoom(entry),
+		Start:    slotTime(day, entry.PeriodSlot.StartTime, s.Location),
+		End:      slotTime(day, entry.PeriodSlot.EndTime, s.Location),
+	}
+
+	var details []string
+	if s.Kind == models.TimetableFeedKindTeacher {
+		event.Summary = subject + " - " + sectionLabel(entry.Timetable.Section)
+	} else {
+		event.Summary = subject
+		if substitution != nil {
+			event.Summary += " (substitution)"
+		}
+	}
+	details = append(details, "Period: "+entry.PeriodSlot.Name)
+
+	if substitution != nil {
+		details = append(details, "Substitute: "+staffName(substitution.SubstituteStaff))
+		if entry.Staff != nil {
+			details = append(details, "Regular teacher: "+staffName(entry.Staff))
+		}
+		if period != nil && period.RoomNumber != "" {
+			event.Location = period.RoomNumber
+		}
+	} else if s.Kind == models.TimetableFeedKindSection && entry.Staff != nil {
+		details = append(details, "Teacher: "+staffName(entry.Staff))
+	}
+	event.Description = strings.Join(details, "\n")
+
+	return event
+}
+
+// coverEvent builds the event for a period a teacher covers as a substitute.
+func coverEvent(s feedSchedule, substitution *models.Substitution, period *models.SubstitutionPeriod, day time.Time) (calendarEvent, bool) {
+	if period.PeriodSlot == nil {
+		return calendarEvent{}, false
+	}
+
+	subject := period.PeriodSlot.Name
+	if period.Subject != nil {
+		subject = period.Subject.Name
+	}
+	summary := "Substitution: " + subject
+	if period.Section != nil {
+		summary += " - " + sectionLabel(period.Section)
+	}
+
+	details := []string{"Period: " + period.PeriodSlot.Name}
+	if substitution.OriginalStaff != nil {
+		details = append(details, "Covering for: "+staffName(substitution.OriginalStaff))
+	}
+
+	location := period.RoomNumber
+	if location == "" && period.Section != nil {
+		location = period.Section.RoomNumber
+	}
+
+	return calendarEvent{
+		UID:         fmt.Sprintf("%s-%s@msls", period.ID, day.Format("20060102")),
+		Summary:     summary,
+		Description: strings.Join(details, "\n"),
+		Location:    location,
+		Start:       slotTime(day, period.PeriodSlot.StartTime, s.Location),
+		End:         slotTime(day, period.PeriodSlot.EndTime, s.Location),
+	}, true
+}
+
+// coveringSubstitution finds the substitution period, if any, that covers a
+// timetable entry: one linked to the entry, or one for the same slot and
+// section whose absent teacher is the entry's teacher.
+func coveringSubstitution(substitutions []models.Substitution, entry *models.TimetableEntry) (*models.Substitution, *models.SubstitutionPeriod) {
+	for i := range substitutions {
+		substitution := &substitutions[i]
+		for j := range substitution.Periods {
+			period := &substitution.Periods[j]
+			if period.TimetableEntryID != nil {
+				if *period.TimetableEntryID == entry.ID {
+					return substitution, period
+				}
+				continue
+			}
+			if period.PeriodSlotID == entry.PeriodSlotID &&
+				period.SectionID != nil && *period.SectionID == entry.Timetable.SectionID &&
+				entry.StaffID != nil && *entry.StaffID == substitution.OriginalStaffID {
+				return substitution, period
+			}
+		}
+	}
+	return nil, nil
+}
+
+// dayPatternAllows reports whether a slot runs on a weekday given the
+// branch's day pattern assignments. Weekdays without an assignment are
+// treated as working days, and slots without a day pattern run every day.
+func dayPatternAllows(assignments []models.DayPatternAssignment, weekday int, slot *models.PeriodSlot) bool {
+	for _, assignment := range assignments {
+		if assignment.DayOfWeek != weekday {
+			continue
+		}
+		if !assignment.IsWorkingDay {
+			return false
+		}
+		if assignment.DayPatternID != nil && slot.DayPatternID != nil {
+			return *assignment.DayPatternID == *slot.DayPatternID
+		}
+		return true
+	}
+	return true
+}
+
+// holidayFor reports whether any of a day's holidays applies to a branch.
+func holidayFor(holidays []models.Holiday, branchID uuid.UUID) bool {
+	for _, holiday := range holidays {
+		if holiday.BranchID == nil || *holiday.BranchID == branchID {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveOn reports whether a timetable is in effect on a date.
+func effectiveOn(timetable *models.Timetable, day time.Time) bool {
+	if timetable.EffectiveFrom != nil && day.Before(dateOf(*timetable.EffectiveFrom)) {
+		return false
+	}
+	if timetable.EffectiveTo != nil && day.After(dateOf(*timetable.EffectiveTo)) {
+		return false
+	}
+	return true
+}
+
+// slotTime combines a date with a period slot's HH:MM[:SS] time.
+func slotTime(day time.Time, clock string, location *time.Location) time.Time {
+	var parsed time.Time
+	for _, layout := range []string{"15:04:05", "15:04", time.RFC3339} {
+		if t, err := time.Parse(layout, clock); err == nil {
+			parsed = t
+			break
+		}
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, location)
+}
+
+func entrySubject(entry *models.TimetableEntry) string {
+	if entry.Subject != nil {
+		return entry.Subject.Name
+	}
+	return entry.PeriodSlot.Name
+}
+
+func entryRoom(entry *models.TimetableEntry) string {
+	if entry.RoomNumber != "" {
+		return entry.RoomNumber
+	}
+	if entry.Timetable != nil && entry.Timetable.Section != nil {
+		return entry.Timetable.Section.RoomNumber
+	}
+	return ""
+}
+
+// sectionLabel names a section with its class, e.g. "Class 5 A".
+func sectionLabel(section *models.Section) string {
+	if section == nil {
+		return ""
+	}
+	if section.Class.Name != "" {
+		return section.Class.Name + " " + section.Name
+	}
+	return section.Name
+}
+
+func staffName(staff *models.Staff) string {
+	if staff == nil {
+		return ""
+	}
+	return staff.FullName()
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// writeCalendar renders events as an iCalendar (RFC 5545) document. Timed
+// events are written in UTC so that clients need no time zone definitions.
+func writeCalendar(name, timezone string, events []calendarEvent, stamp time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(content string) {
+		writeFolded(&buf, content)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//MSLS//Timetable//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeText(name))
+	if timezone != "" {
+		line("X-WR-TIMEZONE:" + timezone)
+	}
+	line("REFRESH-INTERVAL;VALUE=DURATION:PT6H")
+	line("X-PUBLISHED-TTL:PT6H")
+
+	dtstamp := stamp.UTC().Format("20060102T150405Z")
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + event.UID)
+		line("DTSTAMP:" + dtstamp)
+		if event.AllDay {
+			line("DTSTART;VALUE=DATE:" + event.Start.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + event.Start.AddDate(0, 0, 1).Format("20060102"))
+			line("TRANSP:TRANSPARENT")
+		} else {
+			line("DTSTART:" + event.Start.UTC().Format("20060102T150405Z"))
+			line("DTEND:" + event.End.UTC().Format("20060102T150405Z"))
+		}
+		line("SUMMARY:" + escapeText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION:" + escapeText(event.Location))
+		}
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeText escapes a TEXT property value.
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writeFolded writes a content line, folding it at 75 octets without
+// splitting a UTF-8 character.
+func writeFolded(buf *bytes.Buffer, content string) {
+	limit := 75
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		buf.WriteString(content[:cut])
+		buf.WriteString("\r\n ")
+		content = content[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	buf.WriteString(content)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -4,6 +4,7 @@ package timetable
 import (
 	"context"
 	"errors"
+	"time"
 
 	"msls-backend/internal/pkg/database/models"
 
@@ -421,15 +422,6 @@ func (r *Repository) DeleteTimetable(ctx context.Context, tenantID, id uuid.UUID
 		Update("deleted_at", gorm.Expr("NOW()")).Error
 }
 
-// ArchiveOtherTimetables archives all other published timetables for the same section.
-func (r *Repository) ArchiveOtherTimetables(ctx context.Context, tenantID, sectionID, academicYearID, excludeID uuid.UUID) error {
-	return r.db.WithContext(ctx).
-		Model(&models.Timetable{}).
-		Where("tenant_id = ? AND section_id = ? AND academic_year_id = ? AND id != ? AND status = ? AND deleted_at IS NULL",
-			tenantID, sectionID, academicYearID, excludeID, models.TimetableStatusPublished).
-		Update("status", models.TimetableStatusArchived).Error
-}
-
 // ========================================
 // Timetable Entry Repository Methods
 // ========================================
@@ -517,19 +509,22 @@ func (r *Repository) GetTeacherConflicts(ctx context.Context, tenantID, staffID
 			tenantID, staffID, dayOfWeek, periodSlotID).
 		Where("timetables.status = ? AND timetables.deleted_at IS NULL", models.TimetableStatusPublished)
 
+	// Other versions of the excluded timetable's section are replaced by it,
+	// so they do not conflict with it either
 	if excludeTimetableID != nil {
-		query = query.Where("timetables.id != ?", *excludeTimetableID)
+		query = query.Where("timetables.section_id NOT IN (SELECT section_id FROM timetables WHERE id = ?)", *excludeTimetableID)
 	}
 
 	err := query.Find(&entries).Error
 	return entries, err
 }
 
-// GetTeacherSchedule returns all timetable entries for a teacher across published timetables.
-func (r *Repository) GetTeacherSchedule(ctx context.Context, tenantID, staffID, academicYearID uuid.UUID) ([]models.TimetableEntry, error) {
+// GetTeacherSchedule returns all timetable entries for a teacher across
+// published timetables, or across the versions in force on a date.
+func (r *Repository) GetTeacherSchedule(ctx context.Context, tenantID, staffID, academicYearID uuid.UUID, on *time.Time) ([]models.TimetableEntry, error) {
 	var entries []models.TimetableEntry
 
-	err := r.db.WithContext(ctx).
+	query := r.db.WithContext(ctx).
 		Joins("JOIN timetables ON timetables.id = timetable_entries.timetable_id").
 		Preload("Timetable").
 		Preload("Timetable.Section").
@@ -537,8 +532,16 @@ func (r *Repository) GetTeacherSchedule(ctx context.Context, tenantID, staffID,
 		Preload("PeriodSlot").
 		Preload("Subject").
 		Where("timetable_entries.tenant_id = ? AND timetable_entries.staff_id = ?", tenantID, staffID).
-		Where("timetables.academic_year_id = ? AND timetables.status = ? AND timetables.deleted_at IS NULL",
-			academicYearID, models.TimetableStatusPublished).
+		Where("timetables.academic_year_id = ? AND timetables.deleted_at IS NULL", academicYearID)
+
+	if on != nil {
+		day := on.Format("2006-01-02")
+		query = query.Where("timetables.id IN ("+InForceSQL+")", tenantID, day, day)
+	} else {
+		query = query.Where("timetables.status = ?", models.TimetableStatusPublished)
+	}
+
+	err := query.
 		Order("timetable_entries.day_of_week ASC, timetable_entries.period_slot_id ASC").
 		Find(&entries).Error
 
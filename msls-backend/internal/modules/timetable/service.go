@@ -5,14 +5,22 @@ import (
 	"context"
 	"time"
 
+	"msls-backend/internal/modules/medicalalert"
 	"msls-backend/internal/pkg/database/models"
 
 	"github.com/google/uuid"
 )
 
+// MedicalAlertProvider looks up the students with medical alerts in sections
+// a substitute teacher will cover.
+type MedicalAlertProvider interface {
+	StudentsWithAlerts(ctx context.Context, tenantID uuid.UUID, sectionIDs []uuid.UUID) (map[uuid.UUID][]medicalalert.StudentAlert, error)
+}
+
 // Service handles business logic for timetable entities.
 type Service struct {
-	repo *Repository
+	repo          *Repository
+	medicalAlerts MedicalAlertProvider
 }
 
 // NewService creates a new timetable service.
@@ -20,6 +28,12 @@ func NewService(repo *Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// SetMedicalAlertProvider sets the provider used to show substitute teachers
+// the students with medical alerts in the classes they cover.
+func (s *Service) SetMedicalAlertProvider(provider MedicalAlertProvider) {
+	s.medicalAlerts = provider
+}
+
 // ========================================
 // Shift Service Methods
 // ========================================
@@ -45,17 +59,26 @@ func (s *Service) CreateShift(ctx context.Context, tenantID uuid.UUID, req Creat
 		return nil, ErrShiftCodeExists
 	}
 
+	deadline, err := parseShiftDeadline(req.AttendanceDeadline)
+	if err != nil {
+		return nil, err
+	}
+
 	shift := &models.Shift{
-		TenantID:     tenantID,
-		BranchID:     req.BranchID,
-		Name:         req.Name,
-		Code:         req.Code,
-		StartTime:    req.StartTime,
-		EndTime:      req.EndTime,
-		Description:  req.Description,
-		DisplayOrder: req.DisplayOrder,
-		IsActive:     true,
-		CreatedBy:    &userID,
+		TenantID:                  tenantID,
+		BranchID:                  req.BranchID,
+		Name:                      req.Name,
+		Code:                      req.Code,
+		StartTime:                 req.StartTime,
+		EndTime:                   req.EndTime,
+		Description:               req.Description,
+		DisplayOrder:              req.DisplayOrder,
+		LateThresholdMinutes:      req.LateThresholdMinutes,
+		EarlyExitThresholdMinutes: req.EarlyExitThresholdMinutes,
+		AttendanceDeadline:        deadline,
+		WorkingDays:               shiftWorkingDays(req.WorkingDays),
+		IsActive:                  true,
+		CreatedBy:                 &userID,
 	}
 
 	if err := s.repo.CreateShift(ctx, shift); err != nil {
@@ -102,6 +125,22 @@ func (s *Service) UpdateShift(ctx context.Context, tenantID, id uuid.UUID, req U
 	if req.IsActive != nil {
 		shift.IsActive = *req.IsActive
 	}
+	if req.LateThresholdMinutes != nil {
+		shift.LateThresholdMinutes = req.LateThresholdMinutes
+	}
+	if req.EarlyExitThresholdMinutes != nil {
+		shift.EarlyExitThresholdMinutes = req.EarlyExitThresholdMinutes
+	}
+	if req.AttendanceDeadline != nil {
+		deadline, err := parseShiftDeadline(*req.AttendanceDeadline)
+		if err != nil {
+			return nil, err
+		}
+		shift.AttendanceDeadline = deadline
+	}
+	if len(req.WorkingDays) > 0 {
+		shift.WorkingDays = shiftWorkingDays(req.WorkingDays)
+	}
 
 	if err := s.repo.UpdateShift(ctx, shift); err != nil {
 		return nil, err
@@ -110,13 +149,22 @@ func (s *Service) UpdateShift(ctx context.Context, tenantID, id uuid.UUID, req U
 	return s.repo.GetShiftByID(ctx, tenantID, id)
 }
 
-// DeleteShift deletes a shift.
+// DeleteShift deletes a shift. Shifts with staff or sections assigned
+// cannot be deleted.
 func (s *Service) DeleteShift(ctx context.Context, tenantID, id uuid.UUID) error {
 	_, err := s.repo.GetShiftByID(ctx, tenantID, id)
 	if err != nil {
 		return err
 	}
 
+	staffCount, sectionCount, err := s.repo.CountShiftMembers(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if staffCount > 0 || sectionCount > 0 {
+		return ErrShiftInUse
+	}
+
 	return s.repo.DeleteShift(ctx, tenantID, id)
 }
 
@@ -393,6 +441,12 @@ func (s *Service) CreateTimetable(ctx context.Context, tenantID uuid.UUID, req C
 		CreatedBy:      &userID,
 	}
 
+	version, err := s.repo.NextVersion(ctx, tenantID, req.SectionID, req.AcademicYearID)
+	if err != nil {
+		return nil, err
+	}
+	timetable.Version = version
+
 	// Parse dates if provided
 	if req.EffectiveFrom != "" {
 		t, err := time.Parse("2006-01-02", req.EffectiveFrom)
@@ -452,7 +506,9 @@ func (s *Service) UpdateTimetable(ctx context.Context, tenantID, id uuid.UUID, r
 	return s.repo.GetTimetableByID(ctx, tenantID, id)
 }
 
-// PublishTimetable publishes a draft timetable.
+// PublishTimetable publishes a draft timetable. A draft whose effective date
+// is still ahead is scheduled instead and published on that date; the version
+// it replaces stays in force until the day before.
 func (s *Service) PublishTimetable(ctx context.Context, tenantID, id uuid.UUID, userID uuid.UUID) (*models.Timetable, error) {
 	timetable, err := s.repo.GetTimetableByID(ctx, tenantID, id)
 	if err != nil {
@@ -463,35 +519,64 @@ func (s *Service) PublishTimetable(ctx context.Context, tenantID, id uuid.UUID,
 		return nil, ErrTimetableAlreadyPublished
 	}
 
-	// Archive any existing published timetable for this section
-	if err := s.repo.ArchiveOtherTimetables(ctx, tenantID, timetable.SectionID, timetable.AcademicYearID, id); err != nil {
+	versions, err := s.repo.ListVersions(ctx, tenantID, timetable.SectionID, timetable.AcademicYearID)
+	if err != nil {
 		return nil, err
 	}
 
-	// Update status to published
 	now := time.Now()
-	timetable.Status = models.TimetableStatusPublished
-	timetable.PublishedAt = &now
-	timetable.PublishedBy = &userID
+	plan, err := planPublish(timetable, versions, now)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.repo.UpdateTimetable(ctx, timetable); err != nil {
+	if err := s.repo.ApplyPublishPlan(ctx, tenantID, id, plan, userID, now); err != nil {
 		return nil, err
 	}
 
 	return s.repo.GetTimetableByID(ctx, tenantID, id)
 }
 
-// ArchiveTimetable archives a published timetable.
+// ArchiveTimetable archives a published or scheduled timetable. A published
+// timetable stays in force until the end of today; archiving a scheduled one
+// cancels it and leaves the version it would have replaced in force.
 func (s *Service) ArchiveTimetable(ctx context.Context, tenantID, id uuid.UUID) (*models.Timetable, error) {
 	timetable, err := s.repo.GetTimetableByID(ctx, tenantID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	timetable.Status = models.TimetableStatusArchived
+	today := dateOf(time.Now())
 
-	if err := s.repo.UpdateTimetable(ctx, timetable); err != nil {
-		return nil, err
+	switch timetable.Status {
+	case models.TimetableStatusPublished:
+		end := today
+		if timetable.EffectiveTo != nil && timetable.EffectiveTo.Before(end) {
+			end = dateOf(*timetable.EffectiveTo)
+		}
+		if err := s.repo.ArchiveVersion(ctx, tenantID, id, end, nil); err != nil {
+			return nil, err
+		}
+	case models.TimetableStatusScheduled:
+		end := dateOf(*timetable.EffectiveFrom).AddDate(0, 0, -1)
+
+		versions, err := s.repo.ListVersions(ctx, tenantID, timetable.SectionID, timetable.AcademicYearID)
+		if err != nil {
+			return nil, err
+		}
+		var reopen *uuid.UUID
+		for i := range versions {
+			v := &versions[i]
+			if v.ID != id && isReleased(v) && v.EffectiveTo != nil && dateOf(*v.EffectiveTo).Equal(end) {
+				reopen = &v.ID
+			}
+		}
+
+		if err := s.repo.ArchiveVersion(ctx, tenantID, id, end, reopen); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrTimetableNotReleased
 	}
 
 	return s.repo.GetTimetableByID(ctx, tenantID, id)
@@ -656,9 +741,11 @@ func (s *Service) CheckTeacherConflicts(ctx context.Context, tenantID, staffID u
 	return conflicts, nil
 }
 
-// GetTeacherSchedule returns a teacher's full schedule.
-func (s *Service) GetTeacherSchedule(ctx context.Context, tenantID, staffID, academicYearID uuid.UUID) ([]models.TimetableEntry, error) {
-	return s.repo.GetTeacherSchedule(ctx, tenantID, staffID, academicYearID)
+// GetTeacherSchedule returns a teacher's full schedule. With a date, it is the
+// schedule of the timetable versions in force on that date; otherwise that of
+// the currently published timetables.
+func (s *Service) GetTeacherSchedule(ctx context.Context, tenantID, staffID, academicYearID uuid.UUID, on *time.Time) ([]models.TimetableEntry, error) {
+	return s.repo.GetTeacherSchedule(ctx, tenantID, staffID, academicYearID, on)
 }
 
 // GetStaffIDByUserID returns the staff ID for a given user ID.
@@ -0,0 +1,72 @@
+package timetable
+
+import (
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+)
+
+// ========================================
+// Shift Assignment DTOs
+// ========================================
+
+// AssignShiftStaffRequest represents the request body for assigning staff
+// members to a shift.
+type AssignShiftStaffRequest struct {
+	StaffIDs []uuid.UUID `json:"staffIds" binding:"required,min=1"`
+}
+
+// AssignShiftSectionsRequest represents the request body for assigning
+// sections to a shift.
+type AssignShiftSectionsRequest struct {
+	SectionIDs []uuid.UUID `json:"sectionIds" binding:"required,min=1"`
+}
+
+// ShiftStaffMember represents a staff member assigned to a shift.
+type ShiftStaffMember struct {
+	ID         uuid.UUID `json:"id"`
+	EmployeeID string    `json:"employeeId"`
+	Name       string    `json:"name"`
+}
+
+// ShiftSectionMember represents a section assigned to a shift.
+type ShiftSectionMember struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ClassName string    `json:"className,omitempty"`
+}
+
+// ShiftMembersResponse represents a shift with the staff members and
+// sections assigned to it.
+type ShiftMembersResponse struct {
+	Shift    ShiftResponse        `json:"shift"`
+	Staff    []ShiftStaffMember   `json:"staff"`
+	Sections []ShiftSectionMember `json:"sections"`
+}
+
+// ShiftMembersToResponse converts a shift and its members to a response.
+func ShiftMembersToResponse(shift *models.Shift, staff []models.Staff, sections []models.Section) ShiftMembersResponse {
+	resp := ShiftMembersResponse{
+		Shift:    ShiftToResponse(shift),
+		Staff:    make([]ShiftStaffMember, len(staff)),
+		Sections: make([]ShiftSectionMember, len(sections)),
+	}
+
+	for i, st := range staff {
+		resp.Staff[i] = ShiftStaffMember{
+			ID:         st.ID,
+			EmployeeID: st.EmployeeID,
+			Name:       st.FullName(),
+		}
+	}
+
+	for i, sec := range sections {
+		resp.Sections[i] = ShiftSectionMember{
+			ID:        sec.ID,
+			Name:      sec.Name,
+			ClassName: sec.Class.Name,
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,203 @@
+package timetable
+
+import (
+	"errors"
+	"net/http"
+
+	"msls-backend/internal/middleware"
+	apperr "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RegisterShiftAssignmentRoutes registers the routes that assign staff and
+// sections to shifts.
+func (h *Handler) RegisterShiftAssignmentRoutes(rg *gin.RouterGroup) {
+	shifts := rg.Group("/shifts")
+	{
+		shiftsView := shifts.Group("")
+		shiftsView.Use(middleware.PermissionRequired("shift:view"))
+		{
+			shiftsView.GET("/:id/members", h.GetShiftMembers)
+		}
+
+		shiftsManage := shifts.Group("")
+		shiftsManage.Use(middleware.PermissionRequired("shift:manage"))
+		{
+			shiftsManage.POST("/:id/staff", h.AssignShiftStaff)
+			shiftsManage.DELETE("/:id/staff/:staffId", h.UnassignShiftStaff)
+			shiftsManage.POST("/:id/sections", h.AssignShiftSections)
+			shiftsManage.DELETE("/:id/sections/:sectionId", h.UnassignShiftSection)
+		}
+	}
+}
+
+// ========================================
+// Shift Assignment Handlers
+// ========================================
+
+// GetShiftMembers returns the staff members and sections assigned to a shift.
+func (h *Handler) GetShiftMembers(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid shift ID"))
+		return
+	}
+
+	shift, staff, sections, err := h.service.GetShiftMembers(c.Request.Context(), tenantID, id)
+	if err != nil {
+		if errors.Is(err, ErrShiftNotFound) {
+			apperr.Abort(c, apperr.NotFound("Shift not found"))
+			return
+		}
+		apperr.Abort(c, apperr.InternalError("Failed to get shift members"))
+		return
+	}
+
+	response.OK(c, ShiftMembersToResponse(shift, staff, sections))
+}
+
+// AssignShiftStaff assigns staff members to a shift.
+func (h *Handler) AssignShiftStaff(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid shift ID"))
+		return
+	}
+
+	var req AssignShiftStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperr.Abort(c, apperr.BadRequest(err.Error()))
+		return
+	}
+
+	if err := h.service.AssignShiftStaff(c.Request.Context(), tenantID, id, req.StaffIDs); err != nil {
+		abortShiftAssignment(c, err, "Failed to assign staff to shift")
+		return
+	}
+
+	h.respondShiftMembers(c, tenantID, id)
+}
+
+// AssignShiftSections assigns sections to a shift.
+func (h *Handler) AssignShiftSections(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid shift ID"))
+		return
+	}
+
+	var req AssignShiftSectionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperr.Abort(c, apperr.BadRequest(err.Error()))
+		return
+	}
+
+	if err := h.service.AssignShiftSections(c.Request.Context(), tenantID, id, req.SectionIDs); err != nil {
+		abortShiftAssignment(c, err, "Failed to assign sections to shift")
+		return
+	}
+
+	h.respondShiftMembers(c, tenantID, id)
+}
+
+// UnassignShiftStaff removes a staff member from a shift.
+func (h *Handler) UnassignShiftStaff(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid shift ID"))
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("staffId"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid staff ID"))
+		return
+	}
+
+	if err := h.service.UnassignShiftStaff(c.Request.Context(), tenantID, id, staffID); err != nil {
+		abortShiftAssignment(c, err, "Failed to remove staff from shift")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnassignShiftSection removes a section from a shift.
+func (h *Handler) UnassignShiftSection(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid shift ID"))
+		return
+	}
+
+	sectionID, err := uuid.Parse(c.Param("sectionId"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid section ID"))
+		return
+	}
+
+	if err := h.service.UnassignShiftSection(c.Request.Context(), tenantID, id, sectionID); err != nil {
+		abortShiftAssignment(c, err, "Failed to remove section from shift")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondShiftMembers responds with a shift's members after a change.
+func (h *Handler) respondShiftMembers(c *gin.Context, tenantID, id uuid.UUID) {
+	shift, staff, sections, err := h.service.GetShiftMembers(c.Request.Context(), tenantID, id)
+	if err != nil {
+		apperr.Abort(c, apperr.InternalError("Failed to get shift members"))
+		return
+	}
+
+	response.OK(c, ShiftMembersToResponse(shift, staff, sections))
+}
+
+// abortShiftAssignment maps shift assignment errors to API errors.
+func abortShiftAssignment(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrShiftNotFound):
+		apperr.Abort(c, apperr.NotFound("Shift not found"))
+	case errors.Is(err, ErrShiftMemberNotFound):
+		apperr.Abort(c, apperr.NotFound(err.Error()))
+	case errors.Is(err, ErrShiftInactive), errors.Is(err, ErrShiftBranchMismatch):
+		apperr.Abort(c, apperr.BadRequest(err.Error()))
+	default:
+		apperr.Abort(c, apperr.InternalError(message))
+	}
+}
@@ -0,0 +1,140 @@
+package timetable
+
+import (
+	"context"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+)
+
+// ========================================
+// Shift Assignment Repository Methods
+// ========================================
+
+// CountShiftMembers returns how many staff members and sections are assigned
+// to a shift.
+func (r *Repository) CountShiftMembers(ctx context.Context, tenantID, shiftID uuid.UUID) (int64, int64, error) {
+	var staffCount, sectionCount int64
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.Staff{}).
+		Where("tenant_id = ? AND shift_id = ?", tenantID, shiftID).
+		Count(&staffCount).Error; err != nil {
+		return 0, 0, err
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.Section{}).
+		Where("tenant_id = ? AND shift_id = ?", tenantID, shiftID).
+		Count(&sectionCount).Error; err != nil {
+		return 0, 0, err
+	}
+
+	return staffCount, sectionCount, nil
+}
+
+// ListShiftStaff returns the staff members assigned to a shift.
+func (r *Repository) ListShiftStaff(ctx context.Context, tenantID, shiftID uuid.UUID) ([]models.Staff, error) {
+	var staff []models.Staff
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND shift_id = ?", tenantID, shiftID).
+		Order("first_name ASC, last_name ASC").
+		Find(&staff).Error
+	return staff, err
+}
+
+// ListShiftSections returns the sections assigned to a shift with their classes.
+func (r *Repository) ListShiftSections(ctx context.Context, tenantID, shiftID uuid.UUID) ([]models.Section, error) {
+	var sections []models.Section
+	err := r.db.WithContext(ctx).
+		Preload("Class").
+		Where("tenant_id = ? AND shift_id = ?", tenantID, shiftID).
+		Order("display_order ASC, name ASC").
+		Find(&sections).Error
+	return sections, err
+}
+
+// GetStaffBranches returns the branch of each of the given staff members.
+func (r *Repository) GetStaffBranches(ctx context.Context, tenantID uuid.UUID, staffIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	var rows []struct {
+		ID       uuid.UUID
+		BranchID uuid.UUID
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.Staff{}).
+		Select("id, branch_id").
+		Where("tenant_id = ? AND id IN ?", tenantID, staffIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make(map[uuid.UUID]uuid.UUID, len(rows))
+	for _, row := range rows {
+		branches[row.ID] = row.BranchID
+	}
+	return branches, nil
+}
+
+// GetSectionBranches returns the branch of each of the given sections, which
+// is the branch of its class.
+func (r *Repository) GetSectionBranches(ctx context.Context, tenantID uuid.UUID, sectionIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	var rows []struct {
+		ID       uuid.UUID
+		BranchID uuid.UUID
+	}
+	err := r.db.WithContext(ctx).
+		Table("sections").
+		Select("sections.id, classes.branch_id").
+		Joins("JOIN classes ON classes.id = sections.class_id").
+		Where("sections.tenant_id = ? AND sections.id IN ?", tenantID, sectionIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make(map[uuid.UUID]uuid.UUID, len(rows))
+	for _, row := range rows {
+		branches[row.ID] = row.BranchID
+	}
+	return branches, nil
+}
+
+// SetStaffShift assigns staff members to a shift, moving them from any shift
+// they were on.
+func (r *Repository) SetStaffShift(ctx context.Context, tenantID, shiftID uuid.UUID, staffIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Staff{}).
+		Where("tenant_id = ? AND id IN ?", tenantID, staffIDs).
+		Update("shift_id", shiftID).Error
+}
+
+// SetSectionShift assigns sections to a shift, moving them from any shift
+// they were on.
+func (r *Repository) SetSectionShift(ctx context.Context, tenantID, shiftID uuid.UUID, sectionIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Section{}).
+		Where("tenant_id = ? AND id IN ?", tenantID, sectionIDs).
+		Update("shift_id", shiftID).Error
+}
+
+// ClearStaffShift removes a staff member from a shift. It reports whether the
+// staff member was on the shift.
+func (r *Repository) ClearStaffShift(ctx context.Context, tenantID, shiftID, staffID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Staff{}).
+		Where("tenant_id = ? AND id = ? AND shift_id = ?", tenantID, staffID, shiftID).
+		Update("shift_id", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ClearSectionShift removes a section from a shift. It reports whether the
+// section was on the shift.
+func (r *Repository) ClearSectionShift(ctx context.Context, tenantID, shiftID, sectionID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Section{}).
+		Where("tenant_id = ? AND id = ? AND shift_id = ?", tenantID, sectionID, shiftID).
+		Update("shift_id", nil)
+	return result.RowsAffected > 0, result.Error
+}
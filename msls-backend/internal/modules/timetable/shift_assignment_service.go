@@ -0,0 +1,175 @@
+package timetable
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ========================================
+// Shift Assignment Service Methods
+// ========================================
+
+// GetShiftMembers returns a shift with the staff members and sections
+// assigned to it.
+func (s *Service) GetShiftMembers(ctx context.Context, tenantID, id uuid.UUID) (*models.Shift, []models.Staff, []models.Section, error) {
+	shift, err := s.repo.GetShiftByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	staff, err := s.repo.ListShiftStaff(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	sections, err := s.repo.ListShiftSections(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return shift, staff, sections, nil
+}
+
+// AssignShiftStaff assigns staff members of the shift's branch to an active
+// shift. Staff members already on another shift move to this one.
+func (s *Service) AssignShiftStaff(ctx context.Context, tenantID, id uuid.UUID, staffIDs []uuid.UUID) error {
+	shift, err := s.activeShift(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	branches, err := s.repo.GetStaffBranches(ctx, tenantID, staffIDs)
+	if err != nil {
+		return err
+	}
+	if err := checkShiftBranch(shift, staffIDs, branches); err != nil {
+		return err
+	}
+
+	return s.repo.SetStaffShift(ctx, tenantID, id, staffIDs)
+}
+
+// AssignShiftSections assigns sections of the shift's branch to an active
+// shift. Sections already on another shift move to this one.
+func (s *Service) AssignShiftSections(ctx context.Context, tenantID, id uuid.UUID, sectionIDs []uuid.UUID) error {
+	shift, err := s.activeShift(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	branches, err := s.repo.GetSectionBranches(ctx, tenantID, sectionIDs)
+	if err != nil {
+		return err
+	}
+	if err := checkShiftBranch(shift, sectionIDs, branches); err != nil {
+		return err
+	}
+
+	return s.repo.SetSectionShift(ctx, tenantID, id, sectionIDs)
+}
+
+// UnassignShiftStaff removes a staff member from a shift; their attendance
+// then follows the branch settings.
+func (s *Service) UnassignShiftStaff(ctx context.Context, tenantID, id, staffID uuid.UUID) error {
+	if _, err := s.repo.GetShiftByID(ctx, tenantID, id); err != nil {
+		return err
+	}
+
+	removed, err := s.repo.ClearStaffShift(ctx, tenantID, id, staffID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrShiftMemberNotFound
+	}
+	return nil
+}
+
+// UnassignShiftSection removes a section from a shift.
+func (s *Service) UnassignShiftSection(ctx context.Context, tenantID, id, sectionID uuid.UUID) error {
+	if _, err := s.repo.GetShiftByID(ctx, tenantID, id); err != nil {
+		return err
+	}
+
+	removed, err := s.repo.ClearSectionShift(ctx, tenantID, id, sectionID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrShiftMemberNotFound
+	}
+	return nil
+}
+
+func (s *Service) activeShift(ctx context.Context, tenantID, id uuid.UUID) (*models.Shift, error) {
+	shift, err := s.repo.GetShiftByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !shift.IsActive {
+		return nil, ErrShiftInactive
+	}
+	return shift, nil
+}
+
+// ========================================
+// Shift Helpers
+// ========================================
+
+// checkShiftBranch verifies that every staff member or section exists and
+// belongs to the shift's branch; branches maps each found ID to its branch.
+func checkShiftBranch(shift *models.Shift, ids []uuid.UUID, branches map[uuid.UUID]uuid.UUID) error {
+	for _, id := range ids {
+		branchID, ok := branches[id]
+		if !ok {
+			return ErrShiftMemberNotFound
+		}
+		if branchID != shift.BranchID {
+			return ErrShiftBranchMismatch
+		}
+	}
+	return nil
+}
+
+// parseShiftDeadline validates an attendance deadline in HH:MM format. An
+// empty deadline clears it.
+func parseShiftDeadline(value string) (*string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return nil, ErrInvalidShiftDeadline
+	}
+
+	deadline := t.Format("15:04")
+	return &deadline, nil
+}
+
+// shiftWorkingDays converts days of the week to a sorted set for storage. No
+// days leaves the database default in place.
+func shiftWorkingDays(days []int) pq.Int64Array {
+	if len(days) == 0 {
+		return nil
+	}
+
+	seen := make(map[int]bool, len(days))
+	result := make(pq.Int64Array, 0, len(days))
+	for _, d := range days {
+		if seen[d] {
+			continue
+		}
+		seen[d] = true
+		result = append(result, int64(d))
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
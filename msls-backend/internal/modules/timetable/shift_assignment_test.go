@@ -0,0 +1,53 @@
+package timetable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func TestCheckShiftBranch(t *testing.T) {
+	branchID := uuid.New()
+	shift := &models.Shift{ID: uuid.New(), BranchID: branchID}
+	member, other, missing := uuid.New(), uuid.New(), uuid.New()
+	branches := map[uuid.UUID]uuid.UUID{member: branchID, other: uuid.New()}
+
+	assert.NoError(t, checkShiftBranch(shift, []uuid.UUID{member}, branches))
+	assert.ErrorIs(t, checkShiftBranch(shift, []uuid.UUID{member, other}, branches), ErrShiftBranchMismatch)
+	assert.ErrorIs(t, checkShiftBranch(shift, []uuid.UUID{member, missing}, branches), ErrShiftMemberNotFound)
+}
+
+func TestParseShiftDeadline(t *testing.T) {
+	deadline, err := parseShiftDeadline("9:30")
+	require.NoError(t, err)
+	require.NotNil(t, deadline)
+	assert.Equal(t, "09:30", *deadline)
+
+	deadline, err = parseShiftDeadline("")
+	require.NoError(t, err)
+	assert.Nil(t, deadline)
+
+	_, err = parseShiftDeadline("25:00")
+	assert.ErrorIs(t, err, ErrInvalidShiftDeadline)
+}
+
+func TestShiftWorkingDays(t *testing.T) {
+	assert.Equal(t, pq.Int64Array{1, 3, 5}, shiftWorkingDays([]int{5, 1, 3, 1}))
+	assert.Nil(t, shiftWorkingDays(nil))
+}
+
+func TestShiftIsWorkingDay(t *testing.T) {
+	shift := models.Shift{WorkingDays: pq.Int64Array{1, 2, 3, 4, 5, 6}}
+	assert.True(t, shift.IsWorkingDay(time.Saturday))
+	assert.False(t, shift.IsWorkingDay(time.Sunday))
+
+	weekend := models.Shift{WorkingDays: pq.Int64Array{0, 6}}
+	assert.True(t, weekend.IsWorkingDay(time.Sunday))
+	assert.False(t, weekend.IsWorkingDay(time.Monday))
+}
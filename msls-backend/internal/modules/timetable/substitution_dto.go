@@ -3,6 +3,7 @@ package timetable
 import (
 	"time"
 
+	"msls-backend/internal/modules/medicalalert"
 	"msls-backend/internal/pkg/database/models"
 
 	"github.com/google/uuid"
@@ -92,6 +93,7 @@ type SubstitutionPeriodResponse struct {
 	ClassName        string     `json:"className,omitempty"`
 	RoomNumber       string     `json:"roomNumber,omitempty"`
 	Notes            string     `json:"notes,omitempty"`
+	MedicalAlerts    []medicalalert.StudentAlert `json:"medicalAlerts,omitempty"`
 }
 
 // SubstitutionListResponse is the API response for listing substitutions.
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"msls-backend/internal/middleware"
+	"msls-backend/internal/modules/medicalalert"
 	apperr "msls-backend/internal/pkg/errors"
 	"msls-backend/internal/pkg/response"
 
@@ -144,7 +145,8 @@ func (h *Handler) ListSubstitutions(c *gin.Context) {
 	response.OK(c, resp)
 }
 
-// GetSubstitution returns a single substitution by ID.
+// GetSubstitution returns a single substitution by ID, with the students who
+// have medical alerts in each covered period.
 func (h *Handler) GetSubstitution(c *gin.Context) {
 	tenantID, ok := middleware.GetCurrentTenantID(c)
 	if !ok {
@@ -168,7 +170,24 @@ func (h *Handler) GetSubstitution(c *gin.Context) {
 		return
 	}
 
-	response.OK(c, SubstitutionToResponse(substitution))
+	alerts := h.service.GetSubstitutionMedicalAlerts(c.Request.Context(), tenantID, substitution)
+
+	// Staff without health access only see which students have an alert
+	canViewDetails := middleware.HasPermission(c, medicalalert.DetailPermission)
+	resp := SubstitutionToResponse(substitution)
+	for i, period := range resp.Periods {
+		if period.SectionID == nil {
+			continue
+		}
+		for _, alert := range alerts[*period.SectionID] {
+			if !canViewDetails {
+				alert = alert.Redact()
+			}
+			resp.Periods[i].MedicalAlerts = append(resp.Periods[i].MedicalAlerts, alert)
+		}
+	}
+
+	response.OK(c, resp)
 }
 
 // CreateSubstitution creates a new substitution.
@@ -160,8 +160,9 @@ func (r *Repository) CheckSubstitutionConflict(ctx context.Context, tenantID, or
 func (r *Repository) GetSubstituteTeacherConflicts(ctx context.Context, tenantID, substituteStaffID uuid.UUID, date time.Time, periodSlotIDs []uuid.UUID) ([]uuid.UUID, error) {
 	var conflictingPeriods []uuid.UUID
 
-	// Check for conflicts in timetable entries
+	// Check for conflicts in the timetable versions in force on the date
 	dayOfWeek := int(date.Weekday())
+	day := date.Format("2006-01-02")
 	err := r.db.WithContext(ctx).
 		Model(&models.TimetableEntry{}).
 		Joins("JOIN timetables ON timetables.id = timetable_entries.timetable_id").
@@ -169,8 +170,7 @@ func (r *Repository) GetSubstituteTeacherConflicts(ctx context.Context, tenantID
 		Where("timetable_entries.staff_id = ?", substituteStaffID).
 		Where("timetable_entries.day_of_week = ?", dayOfWeek).
 		Where("timetable_entries.period_slot_id IN ?", periodSlotIDs).
-		Where("timetables.status = ?", models.TimetableStatusPublished).
-		Where("timetables.deleted_at IS NULL").
+		Where("timetables.id IN ("+InForceSQL+")", tenantID, day, day).
 		Pluck("timetable_entries.period_slot_id", &conflictingPeriods).Error
 
 	if err != nil {
@@ -216,17 +216,18 @@ func (r *Repository) GetAvailableTeachers(ctx context.Context, tenantID, branchI
 	return staff, err
 }
 
-// GetTeacherTimetableEntries returns timetable entries for a teacher on a specific day.
-func (r *Repository) GetTeacherTimetableEntries(ctx context.Context, tenantID, staffID uuid.UUID, dayOfWeek int) ([]models.TimetableEntry, error) {
+// GetTeacherTimetableEntries returns a teacher's timetable entries on a date,
+// from the timetable versions in force on that date.
+func (r *Repository) GetTeacherTimetableEntries(ctx context.Context, tenantID, staffID uuid.UUID, date time.Time) ([]models.TimetableEntry, error) {
 	var entries []models.TimetableEntry
 
+	day := date.Format("2006-01-02")
 	err := r.db.WithContext(ctx).
 		Joins("JOIN timetables ON timetables.id = timetable_entries.timetable_id").
 		Where("timetable_entries.tenant_id = ?", tenantID).
 		Where("timetable_entries.staff_id = ?", staffID).
-		Where("timetable_entries.day_of_week = ?", dayOfWeek).
-		Where("timetables.status = ?", models.TimetableStatusPublished).
-		Where("timetables.deleted_at IS NULL").
+		Where("timetable_entries.day_of_week = ?", int(date.Weekday())).
+		Where("timetables.id IN ("+InForceSQL+")", tenantID, day, day).
 		Preload("PeriodSlot").
 		Preload("Subject").
 		Preload("Timetable").
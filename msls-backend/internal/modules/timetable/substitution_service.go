@@ -4,9 +4,12 @@ import (
 	"context"
 	"time"
 
+	"msls-backend/internal/modules/medicalalert"
 	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/logger"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // ========================================
@@ -23,6 +26,36 @@ func (s *Service) GetSubstitutionByID(ctx context.Context, tenantID, id uuid.UUI
 	return s.repo.GetSubstitutionByID(ctx, tenantID, id)
 }
 
+// GetSubstitutionMedicalAlerts returns the students with medical alerts in
+// each section covered by a substitution, keyed by section ID. A failed lookup
+// is logged and yields no alerts, so the substitution can still be read.
+func (s *Service) GetSubstitutionMedicalAlerts(ctx context.Context, tenantID uuid.UUID, substitution *models.Substitution) map[uuid.UUID][]medicalalert.StudentAlert {
+	if s.medicalAlerts == nil {
+		return nil
+	}
+
+	var sectionIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, period := range substitution.Periods {
+		if period.SectionID != nil && !seen[*period.SectionID] {
+			seen[*period.SectionID] = true
+			sectionIDs = append(sectionIDs, *period.SectionID)
+		}
+	}
+	if len(sectionIDs) == 0 {
+		return nil
+	}
+	alerts, err := s.medicalAlerts.StudentsWithAlerts(ctx, tenantID, sectionIDs)
+	if err != nil {
+		logger.Warn("Failed to load medical alerts for substitution",
+			zap.String("tenant_id", tenantID.String()),
+			zap.String("substitution_id", substitution.ID.String()),
+			zap.Error(err))
+		return nil
+	}
+	return alerts
+}
+
 // CreateSubstitution creates a new substitution.
 func (s *Service) CreateSubstitution(ctx context.Context, tenantID uuid.UUID, req CreateSubstitutionRequest, userID uuid.UUID) (*models.Substitution, error) {
 	// Parse date
@@ -213,8 +246,6 @@ func (s *Service) GetAvailableTeachers(ctx context.Context, tenantID, branchID u
 		return nil, err
 	}
 
-	dayOfWeek := int(date.Weekday())
-
 	// Get all teaching staff
 	staff, err := s.repo.GetAvailableTeachers(ctx, tenantID, branchID, date, periodSlotIDs, excludeStaffID)
 	if err != nil {
@@ -225,7 +256,7 @@ func (s *Service) GetAvailableTeachers(ctx context.Context, tenantID, branchID u
 
 	for _, st := range staff {
 		// Get their timetable for this day
-		entries, err := s.repo.GetTeacherTimetableEntries(ctx, tenantID, st.ID, dayOfWeek)
+		entries, err := s.repo.GetTeacherTimetableEntries(ctx, tenantID, st.ID, date)
 		if err != nil {
 			continue
 		}
@@ -269,9 +300,7 @@ func (s *Service) GetTeacherAbsencePeriods(ctx context.Context, tenantID, staffI
 		return nil, err
 	}
 
-	dayOfWeek := int(date.Weekday())
-
-	entries, err := s.repo.GetTeacherTimetableEntries(ctx, tenantID, staffID, dayOfWeek)
+	entries, err := s.repo.GetTeacherTimetableEntries(ctx, tenantID, staffID, date)
 	if err != nil {
 		return nil, err
 	}
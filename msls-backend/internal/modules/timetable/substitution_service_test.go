@@ -0,0 +1,43 @@
+package timetable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"msls-backend/internal/modules/medicalalert"
+	"msls-backend/internal/pkg/database/models"
+)
+
+// stubAlertProvider returns fixed alerts, or an error when err is set.
+type stubAlertProvider struct {
+	alerts map[uuid.UUID][]medicalalert.StudentAlert
+	err    error
+}
+
+func (p stubAlertProvider) StudentsWithAlerts(context.Context, uuid.UUID, []uuid.UUID) (map[uuid.UUID][]medicalalert.StudentAlert, error) {
+	return p.alerts, p.err
+}
+
+func TestGetSubstitutionMedicalAlerts(t *testing.T) {
+	ctx := context.Background()
+	sectionID := uuid.New()
+	substitution := &models.Substitution{
+		ID:      uuid.New(),
+		Periods: []models.SubstitutionPeriod{{SectionID: &sectionID}},
+	}
+	alerts := map[uuid.UUID][]medicalalert.StudentAlert{sectionID: {{StudentID: uuid.New()}}}
+
+	service := &Service{}
+	assert.Nil(t, service.GetSubstitutionMedicalAlerts(ctx, uuid.New(), substitution))
+
+	service.SetMedicalAlertProvider(stubAlertProvider{alerts: alerts})
+	assert.Equal(t, alerts, service.GetSubstitutionMedicalAlerts(ctx, uuid.New(), substitution))
+
+	// A failed lookup leaves the substitution without badges
+	service.SetMedicalAlertProvider(stubAlertProvider{err: errors.New("health records unavailable")})
+	assert.Nil(t, service.GetSubstitutionMedicalAlerts(ctx, uuid.New(), substitution))
+}
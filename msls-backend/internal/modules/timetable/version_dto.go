@@ -0,0 +1,120 @@
+package timetable
+
+import (
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+)
+
+// ========================================
+// Timetable Version DTOs
+// ========================================
+
+// CreateVersionRequest represents the request body for starting a new version
+// of a timetable. The new version is a draft copy of the timetable's entries.
+type CreateVersionRequest struct {
+	Name          string  `json:"name" binding:"omitempty,max=100"`
+	Description   *string `json:"description"`
+	EffectiveFrom string  `json:"effectiveFrom"` // YYYY-MM-DD format
+}
+
+// TimetableVersionResponse represents one version of a section's timetable.
+type TimetableVersionResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Version       int        `json:"version"`
+	Name          string     `json:"name"`
+	Status        string     `json:"status"`
+	EffectiveFrom string     `json:"effectiveFrom,omitempty"`
+	EffectiveTo   string     `json:"effectiveTo,omitempty"`
+	SupersedesID  *uuid.UUID `json:"supersedesId,omitempty"`
+	PublishedAt   string     `json:"publishedAt,omitempty"`
+	InForce       bool       `json:"inForce"`
+	CreatedAt     string     `json:"createdAt"`
+}
+
+// TimetableVersionListResponse represents the version history of a section's
+// timetable for an academic year.
+type TimetableVersionListResponse struct {
+	SectionID      uuid.UUID                  `json:"sectionId"`
+	AcademicYearID uuid.UUID                  `json:"academicYearId"`
+	Date           string                     `json:"date"`
+	InForceID      *uuid.UUID                 `json:"inForceId,omitempty"`
+	Versions       []TimetableVersionResponse `json:"versions"`
+}
+
+// Entry change kinds in a timetable diff.
+const (
+	EntryChangeAdded   = "added"
+	EntryChangeRemoved = "removed"
+	EntryChangeChanged = "changed"
+)
+
+// TimetableEntryChange is a period that differs between two versions. Before
+// is the entry in the base version and After the entry in the compared one.
+type TimetableEntryChange struct {
+	Change       string                  `json:"change"`
+	DayOfWeek    int                     `json:"dayOfWeek"`
+	DayName      string                  `json:"dayName"`
+	PeriodSlotID uuid.UUID               `json:"periodSlotId"`
+	Fields       []string                `json:"fields,omitempty"`
+	Before       *TimetableEntryResponse `json:"before,omitempty"`
+	After        *TimetableEntryResponse `json:"after,omitempty"`
+}
+
+// TimetableDiffResponse represents the differences between two versions.
+type TimetableDiffResponse struct {
+	Base      TimetableVersionResponse `json:"base"`
+	Compared  TimetableVersionResponse `json:"compared"`
+	Added     int                      `json:"added"`
+	Removed   int                      `json:"removed"`
+	Changed   int                      `json:"changed"`
+	Unchanged int                      `json:"unchanged"`
+	Changes   []TimetableEntryChange   `json:"changes"`
+}
+
+// publishPlan is what publishing a version changes: the version's own status
+// and window, the windows of the versions it replaces, and the versions it
+// archives because it takes effect straight away.
+type publishPlan struct {
+	Status  models.TimetableStatus
+	From    *time.Time
+	To      *time.Time
+	Close   []versionClose
+	Archive []uuid.UUID
+}
+
+// versionClose ends an earlier version's window the day before a new version
+// takes effect.
+type versionClose struct {
+	ID          uuid.UUID
+	EffectiveTo time.Time
+}
+
+// TimetableVersionToResponse converts a Timetable model to a version summary.
+func TimetableVersionToResponse(t *models.Timetable, inForce bool) TimetableVersionResponse {
+	resp := TimetableVersionResponse{
+		ID:           t.ID,
+		Version:      t.Version,
+		Name:         t.Name,
+		Status:       string(t.Status),
+		SupersedesID: t.SupersedesID,
+		InForce:      inForce,
+		CreatedAt:    t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if t.EffectiveFrom != nil {
+		resp.EffectiveFrom = t.EffectiveFrom.Format("2006-01-02")
+	}
+
+	if t.EffectiveTo != nil {
+		resp.EffectiveTo = t.EffectiveTo.Format("2006-01-02")
+	}
+
+	if t.PublishedAt != nil {
+		resp.PublishedAt = t.PublishedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return resp
+}
@@ -0,0 +1,240 @@
+package timetable
+
+import (
+	"errors"
+	"time"
+
+	"msls-backend/internal/middleware"
+	apperr "msls-backend/internal/pkg/errors"
+	"msls-backend/internal/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RegisterVersionRoutes registers timetable version history, in-force lookup,
+// diff and revision routes.
+func (h *Handler) RegisterVersionRoutes(rg *gin.RouterGroup) {
+	timetables := rg.Group("/timetables")
+	{
+		timetablesView := timetables.Group("")
+		timetablesView.Use(middleware.PermissionRequired("timetables:read"))
+		{
+			timetablesView.GET("/versions", h.ListTimetableVersions)
+			timetablesView.GET("/in-force", h.GetTimetableInForce)
+			timetablesView.GET("/:id/diff", h.DiffTimetables)
+		}
+
+		timetablesManage := timetables.Group("")
+		timetablesManage.Use(middleware.PermissionRequired("timetables:create"))
+		{
+			timetablesManage.POST("/:id/versions", h.CreateTimetableVersion)
+		}
+	}
+}
+
+// ========================================
+// Timetable Version Handlers
+// ========================================
+
+// ListTimetableVersions returns the version history of a section's timetable
+// for an academic year, marking the version in force on a date (default today).
+func (h *Handler) ListTimetableVersions(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	sectionID, ok := requiredSection(c)
+	if !ok {
+		return
+	}
+
+	academicYearID, ok := requiredAcademicYear(c)
+	if !ok {
+		return
+	}
+
+	date, ok := dateOrToday(c)
+	if !ok {
+		return
+	}
+
+	versions, inForceID, err := h.service.ListTimetableVersions(c.Request.Context(), tenantID, sectionID, academicYearID, date)
+	if err != nil {
+		apperr.Abort(c, apperr.InternalError("Failed to list timetable versions"))
+		return
+	}
+
+	resp := TimetableVersionListResponse{
+		SectionID:      sectionID,
+		AcademicYearID: academicYearID,
+		Date:           date.Format("2006-01-02"),
+		InForceID:      inForceID,
+		Versions:       make([]TimetableVersionResponse, len(versions)),
+	}
+	for i := range versions {
+		resp.Versions[i] = TimetableVersionToResponse(&versions[i], inForceID != nil && *inForceID == versions[i].ID)
+	}
+
+	response.OK(c, resp)
+}
+
+// GetTimetableInForce returns the timetable version in force for a section on
+// a date (default today) with its entries.
+func (h *Handler) GetTimetableInForce(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	sectionID, ok := requiredSection(c)
+	if !ok {
+		return
+	}
+
+	date, ok := dateOrToday(c)
+	if !ok {
+		return
+	}
+
+	timetable, err := h.service.GetTimetableInForce(c.Request.Context(), tenantID, sectionID, date)
+	if err != nil {
+		apperr.Abort(c, apperr.InternalError("Failed to get timetable"))
+		return
+	}
+	if timetable == nil {
+		apperr.Abort(c, apperr.NotFound("No timetable in force for this section on "+date.Format("2006-01-02")))
+		return
+	}
+
+	response.OK(c, TimetableToResponse(timetable))
+}
+
+// DiffTimetables compares a timetable with another version of it, by default
+// the version it superseded.
+func (h *Handler) DiffTimetables(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid timetable ID"))
+		return
+	}
+
+	var against *uuid.UUID
+	if againstStr := c.Query("against"); againstStr != "" {
+		againstID, err := uuid.Parse(againstStr)
+		if err != nil {
+			apperr.Abort(c, apperr.BadRequest("Invalid timetable ID to compare against"))
+			return
+		}
+		against = &againstID
+	}
+
+	diff, err := h.service.DiffTimetables(c.Request.Context(), tenantID, id, against)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTimetableNotFound):
+			apperr.Abort(c, apperr.NotFound("Timetable not found"))
+		case errors.Is(err, ErrNoPreviousVersion):
+			apperr.Abort(c, apperr.NotFound(err.Error()))
+		case errors.Is(err, ErrVersionMismatch):
+			apperr.Abort(c, apperr.BadRequest(err.Error()))
+		default:
+			apperr.Abort(c, apperr.InternalError("Failed to compare timetables"))
+		}
+		return
+	}
+
+	response.OK(c, diff)
+}
+
+// CreateTimetableVersion starts a new draft version of a timetable from a
+// copy of its entries.
+func (h *Handler) CreateTimetableVersion(c *gin.Context) {
+	tenantID, ok := middleware.GetCurrentTenantID(c)
+	if !ok {
+		apperr.Abort(c, apperr.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid timetable ID"))
+		return
+	}
+
+	var req CreateVersionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apperr.Abort(c, apperr.BadRequest(err.Error()))
+			return
+		}
+	}
+
+	timetable, err := h.service.CreateTimetableVersion(c.Request.Context(), tenantID, id, req, userID)
+	if err != nil {
+		if errors.Is(err, ErrTimetableNotFound) {
+			apperr.Abort(c, apperr.NotFound("Timetable not found"))
+			return
+		}
+		apperr.Abort(c, apperr.InternalError("Failed to create timetable version"))
+		return
+	}
+
+	response.Created(c, TimetableToResponse(timetable))
+}
+
+// requiredSection reads the required section_id query parameter.
+func requiredSection(c *gin.Context) (uuid.UUID, bool) {
+	sectionIDStr := c.Query("section_id")
+	if sectionIDStr == "" {
+		apperr.Abort(c, apperr.BadRequest("Section ID is required"))
+		return uuid.Nil, false
+	}
+
+	sectionID, err := uuid.Parse(sectionIDStr)
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid section ID"))
+		return uuid.Nil, false
+	}
+
+	return sectionID, true
+}
+
+// optionalDate reads an optional date query parameter (YYYY-MM-DD).
+func optionalDate(c *gin.Context) (*time.Time, bool) {
+	dateStr := c.Query("date")
+	if dateStr == "" {
+		return nil, true
+	}
+
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		apperr.Abort(c, apperr.BadRequest("Invalid date format, use YYYY-MM-DD"))
+		return nil, false
+	}
+
+	return &date, true
+}
+
+// dateOrToday reads an optional date query parameter, defaulting to today.
+func dateOrToday(c *gin.Context) (time.Time, bool) {
+	date, ok := optionalDate(c)
+	if !ok {
+		return time.Time{}, false
+	}
+	if date == nil {
+		return dateOf(time.Now()), true
+	}
+	return *date, true
+}
@@ -0,0 +1,219 @@
+package timetable
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InForceSQL selects the IDs of the timetable versions in force on a date, at
+// most one per section. A version is in force once published (or scheduled)
+// and until archived, within its effective window; a missing effective date
+// falls back to the academic year's. Bind the tenant ID, then the date twice.
+const InForceSQL = `SELECT DISTINCT ON (t.section_id) t.id
+	FROM timetables t
+	JOIN academic_years ay ON ay.id = t.academic_year_id
+	WHERE t.tenant_id = ? AND t.status <> 'draft' AND t.published_at IS NOT NULL AND t.deleted_at IS NULL
+		AND COALESCE(t.effective_from, ay.start_date) <= ?
+		AND COALESCE(t.effective_to, ay.end_date) >= ?
+	ORDER BY t.section_id, t.effective_from DESC NULLS LAST, t.version DESC`
+
+// ========================================
+// Timetable Version Repository Methods
+// ========================================
+
+// ListVersions returns every version of a section's timetable for an academic
+// year, oldest first.
+func (r *Repository) ListVersions(ctx context.Context, tenantID, sectionID, academicYearID uuid.UUID) ([]models.Timetable, error) {
+	var timetables []models.Timetable
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND section_id = ? AND academic_year_id = ? AND deleted_at IS NULL",
+			tenantID, sectionID, academicYearID).
+		Order("version ASC").
+		Find(&timetables).Error
+	return timetables, err
+}
+
+// NextVersion returns the version number for a new timetable of a section and
+// academic year. Deleted drafts keep their numbers so numbers are never reused.
+func (r *Repository) NextVersion(ctx context.Context, tenantID, sectionID, academicYearID uuid.UUID) (int, error) {
+	var latest int
+	err := r.db.WithContext(ctx).
+		Model(&models.Timetable{}).
+		Where("tenant_id = ? AND section_id = ? AND academic_year_id = ?", tenantID, sectionID, academicYearID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	return latest + 1, err
+}
+
+// GetTimetableInForce returns the timetable version in force for a section on
+// a date with its entries, or nil if there is none.
+func (r *Repository) GetTimetableInForce(ctx context.Context, tenantID, sectionID uuid.UUID, date time.Time) (*models.Timetable, error) {
+	day := date.Format("2006-01-02")
+
+	var timetable models.Timetable
+	err := r.db.WithContext(ctx).
+		Preload("Branch").
+		Preload("Section").
+		Preload("Section.Class").
+		Preload("AcademicYear").
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("day_of_week ASC, period_slot_id ASC")
+		}).
+		Preload("Entries.PeriodSlot").
+		Preload("Entries.Subject").
+		Preload("Entries.Staff").
+		Where("tenant_id = ? AND section_id = ?", tenantID, sectionID).
+		Where("id IN ("+InForceSQL+")", tenantID, day, day).
+		First(&timetable).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &timetable, err
+}
+
+// CreateVersion creates a timetable version together with its entries.
+func (r *Repository) CreateVersion(ctx context.Context, timetable *models.Timetable) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entries := timetable.Entries
+		timetable.Entries = nil
+		if err := tx.Create(timetable).Error; err != nil {
+			return err
+		}
+		for i := range entries {
+			entries[i].TimetableID = timetable.ID
+		}
+		if len(entries) > 0 {
+			if err := tx.Create(&entries).Error; err != nil {
+				return err
+			}
+		}
+		timetable.Entries = entries
+		return nil
+	})
+}
+
+// ApplyPublishPlan publishes or schedules a version: it ends the windows of
+// the versions it replaces, archives the version it takes over from when it
+// takes effect now, and then releases the version itself.
+func (r *Repository) ApplyPublishPlan(ctx context.Context, tenantID, id uuid.UUID, plan publishPlan, userID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, closing := range plan.Close {
+			if err := tx.Model(&models.Timetable{}).
+				Where("tenant_id = ? AND id = ?", tenantID, closing.ID).
+				Update("effective_to", closing.EffectiveTo).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(plan.Archive) > 0 {
+			if err := tx.Model(&models.Timetable{}).
+				Where("tenant_id = ? AND id IN ?", tenantID, plan.Archive).
+				Update("status", models.TimetableStatusArchived).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Timetable{}).
+			Where("tenant_id = ? AND id = ?", tenantID, id).
+			Updates(map[string]interface{}{
+				"status":         plan.Status,
+				"effective_from": plan.From,
+				"effective_to":   plan.To,
+				"published_at":   at,
+				"published_by":   userID,
+				"updated_by":     userID,
+			}).Error
+	})
+}
+
+// ArchiveVersion archives a version, ending its window on effectiveTo. When a
+// scheduled version is cancelled, reopen names the version whose window was
+// ended for it; that window is opened again.
+func (r *Repository) ArchiveVersion(ctx context.Context, tenantID, id uuid.UUID, effectiveTo time.Time, reopen *uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Timetable{}).
+			Where("tenant_id = ? AND id = ?", tenantID, id).
+			Updates(map[string]interface{}{
+				"status":       models.TimetableStatusArchived,
+				"effective_to": effectiveTo,
+			}).Error; err != nil {
+			return err
+		}
+
+		if reopen == nil {
+			return nil
+		}
+		return tx.Model(&models.Timetable{}).
+			Where("tenant_id = ? AND id = ?", tenantID, *reopen).
+			Update("effective_to", nil).Error
+	})
+}
+
+// ListDueScheduledTimetables returns the scheduled versions of every tenant
+// whose effective date has arrived. Callers bypass row level security.
+func (r *Repository) ListDueScheduledTimetables(ctx context.Context, today time.Time) ([]models.Timetable, error) {
+	var timetables []models.Timetable
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND deleted_at IS NULL AND effective_from <= ?",
+			models.TimetableStatusScheduled, today.Format("2006-01-02")).
+		Order("effective_from ASC, version ASC").
+		Find(&timetables).Error
+	return timetables, err
+}
+
+// ActivateTimetable makes a scheduled version the published timetable of its
+// section, archiving the version published before it.
+func (r *Repository) ActivateTimetable(ctx context.Context, timetable *models.Timetable) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Timetable{}).
+			Where("tenant_id = ? AND section_id = ? AND academic_year_id = ? AND id != ? AND status = ? AND deleted_at IS NULL",
+				timetable.TenantID, timetable.SectionID, timetable.AcademicYearID, timetable.ID, models.TimetableStatusPublished).
+			Update("status", models.TimetableStatusArchived).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Timetable{}).
+			Where("tenant_id = ? AND id = ? AND status = ?", timetable.TenantID, timetable.ID, models.TimetableStatusScheduled).
+			Update("status", models.TimetableStatusPublished).Error
+	})
+}
+
+// ListReleasedEntries returns the entries of every published, scheduled or
+// since archived version in an academic year for a section or a teacher, with
+// their timetables, so each date can be matched to the version in force then.
+func (r *Repository) ListReleasedEntries(ctx context.Context, tenantID, academicYearID uuid.UUID, sectionID, staffID *uuid.UUID) ([]models.TimetableEntry, error) {
+	var entries []models.TimetableEntry
+
+	query := r.db.WithContext(ctx).
+		Joins("JOIN timetables ON timetables.id = timetable_entries.timetable_id").
+		Where("timetable_entries.tenant_id = ?", tenantID).
+		Where("timetables.academic_year_id = ? AND timetables.status <> ? AND timetables.published_at IS NOT NULL AND timetables.deleted_at IS NULL",
+			academicYearID, models.TimetableStatusDraft)
+
+	if sectionID != nil {
+		query = query.Where("timetables.section_id = ?", *sectionID)
+	}
+
+	if staffID != nil {
+		query = query.Where("timetable_entries.staff_id = ?", *staffID)
+	}
+
+	err := query.
+		Preload("Timetable").
+		Preload("Timetable.Section").
+		Preload("Timetable.Section.Class").
+		Preload("PeriodSlot").
+		Preload("Subject").
+		Preload("Staff").
+		Order("timetable_entries.day_of_week ASC, timetable_entries.period_slot_id ASC").
+		Find(&entries).Error
+
+	return entries, err
+}
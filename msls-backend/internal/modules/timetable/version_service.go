@@ -0,0 +1,363 @@
+package timetable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"msls-backend/internal/pkg/database/models"
+
+	"github.com/google/uuid"
+)
+
+// ScheduledActivationInterval is how often scheduled timetable versions are
+// checked and published once their effective date arrives.
+const ScheduledActivationInterval = time.Hour
+
+// ========================================
+// Timetable Version Service Methods
+// ========================================
+
+// CreateTimetableVersion starts a new version of a timetable: a draft copy of
+// its entries with the next version number. Publishing the draft with an
+// effective date hands the section over to it from that date.
+func (s *Service) CreateTimetableVersion(ctx context.Context, tenantID, id uuid.UUID, req CreateVersionRequest, userID uuid.UUID) (*models.Timetable, error) {
+	source, err := s.repo.GetTimetableByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.repo.NextVersion(ctx, tenantID, source.SectionID, source.AcademicYearID)
+	if err != nil {
+		return nil, err
+	}
+
+	timetable := &models.Timetable{
+		TenantID:       tenantID,
+		BranchID:       source.BranchID,
+		SectionID:      source.SectionID,
+		AcademicYearID: source.AcademicYearID,
+		Name:           source.Name,
+		Description:    source.Description,
+		Status:         models.TimetableStatusDraft,
+		Version:        version,
+		SupersedesID:   &source.ID,
+		CreatedBy:      &userID,
+	}
+
+	if req.Name != "" {
+		timetable.Name = req.Name
+	}
+	if req.Description != nil {
+		timetable.Description = *req.Description
+	}
+	if req.EffectiveFrom != "" {
+		t, err := time.Parse("2006-01-02", req.EffectiveFrom)
+		if err == nil {
+			timetable.EffectiveFrom = &t
+		}
+	}
+
+	timetable.Entries = make([]models.TimetableEntry, len(source.Entries))
+	for i, e := range source.Entries {
+		timetable.Entries[i] = models.TimetableEntry{
+			TenantID:     tenantID,
+			DayOfWeek:    e.DayOfWeek,
+			PeriodSlotID: e.PeriodSlotID,
+			SubjectID:    e.SubjectID,
+			StaffID:      e.StaffID,
+			RoomNumber:   e.RoomNumber,
+			Notes:        e.Notes,
+			IsFreePeriod: e.IsFreePeriod,
+		}
+	}
+
+	if err := s.repo.CreateVersion(ctx, timetable); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTimetableByID(ctx, tenantID, timetable.ID)
+}
+
+// ListTimetableVersions returns the versions of a section's timetable for an
+// academic year and the ID of the version in force on a date, if any.
+func (s *Service) ListTimetableVersions(ctx context.Context, tenantID, sectionID, academicYearID uuid.UUID, date time.Time) ([]models.Timetable, *uuid.UUID, error) {
+	versions, err := s.repo.ListVersions(ctx, tenantID, sectionID, academicYearID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inForce, err := s.repo.GetTimetableInForce(ctx, tenantID, sectionID, date)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inForce == nil || inForce.AcademicYearID != academicYearID {
+		return versions, nil, nil
+	}
+
+	return versions, &inForce.ID, nil
+}
+
+// GetTimetableInForce returns the timetable version in force for a section on
+// a date, or nil if there is none.
+func (s *Service) GetTimetableInForce(ctx context.Context, tenantID, sectionID uuid.UUID, date time.Time) (*models.Timetable, error) {
+	return s.repo.GetTimetableInForce(ctx, tenantID, sectionID, date)
+}
+
+// DiffTimetables compares a timetable with another version of the same
+// section's timetable. Without against, it is compared with the version it
+// superseded, or else the version numbered before it.
+func (s *Service) DiffTimetables(ctx context.Context, tenantID, id uuid.UUID, against *uuid.UUID) (*TimetableDiffResponse, error) {
+	compared, err := s.repo.GetTimetableByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	baseID := against
+	if baseID == nil {
+		baseID = compared.SupersedesID
+	}
+	if baseID == nil {
+		versions, err := s.repo.ListVersions(ctx, tenantID, compared.SectionID, compared.AcademicYearID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range versions {
+			if versions[i].Version < compared.Version {
+				baseID = &versions[i].ID
+			}
+		}
+	}
+	if baseID == nil {
+		return nil, ErrNoPreviousVersion
+	}
+
+	base, err := s.repo.GetTimetableByID(ctx, tenantID, *baseID)
+	if err != nil {
+		return nil, err
+	}
+	if base.SectionID != compared.SectionID {
+		return nil, ErrVersionMismatch
+	}
+
+	today := dateOf(time.Now())
+	resp := diffEntries(base.Entries, compared.Entries)
+	resp.Base = TimetableVersionToResponse(base, inForceOn(base, today))
+	resp.Compared = TimetableVersionToResponse(compared, inForceOn(compared, today))
+
+	return &resp, nil
+}
+
+// ActivateScheduledTimetables publishes, across all tenants, the scheduled
+// timetable versions whose effective date has arrived, archiving the versions
+// they replace. It returns how many versions were activated.
+func (s *Service) ActivateScheduledTimetables(ctx context.Context, now time.Time) (int, error) {
+	activated := 0
+	err := s.repo.withoutRLS(ctx, func(repo *Repository) error {
+		due, err := repo.ListDueScheduledTimetables(ctx, dateOf(now))
+		if err != nil {
+			return fmt.Errorf("list scheduled timetables: %w", err)
+		}
+
+		for i := range due {
+			if err := repo.ActivateTimetable(ctx, &due[i]); err != nil {
+				return fmt.Errorf("activate timetable %s: %w", due[i].ID, err)
+			}
+			activated++
+		}
+		return nil
+	})
+	return activated, err
+}
+
+// ========================================
+// Version Helpers
+// ========================================
+
+// isReleased reports whether a version has been published or scheduled, and
+// so may be in force on some dates.
+func isReleased(t *models.Timetable) bool {
+	return t.Status != models.TimetableStatusDraft && t.PublishedAt != nil
+}
+
+// hasEmptyWindow reports whether a version's window closes before it opens,
+// as it does for a scheduled version that was cancelled.
+func hasEmptyWindow(t *models.Timetable) bool {
+	return t.EffectiveFrom != nil && t.EffectiveTo != nil && dateOf(*t.EffectiveTo).Before(dateOf(*t.EffectiveFrom))
+}
+
+// inForceOn reports whether a version is in force on a day, going by its own
+// effective dates. Lookups in the database also bound open windows by the
+// academic year.
+func inForceOn(t *models.Timetable, day time.Time) bool {
+	return isReleased(t) && effectiveOn(t, day)
+}
+
+// planPublish works out what publishing target changes, given the other
+// versions of the same section and academic year. A version takes effect on
+// its effective-from date; without one it takes effect today, or for the
+// section's first version, for the whole year. The versions before it end
+// the day before. A version taking effect later than today is scheduled.
+func planPublish(target *models.Timetable, versions []models.Timetable, today time.Time) (publishPlan, error) {
+	today = dateOf(today)
+	plan := publishPlan{To: target.EffectiveTo}
+
+	var released []*models.Timetable
+	for i := range versions {
+		v := &versions[i]
+		if v.ID == target.ID || !isReleased(v) || hasEmptyWindow(v) {
+			continue
+		}
+		released = append(released, v)
+	}
+
+	if target.EffectiveFrom != nil {
+		from := dateOf(*target.EffectiveFrom)
+		plan.From = &from
+	} else if len(released) > 0 {
+		from := today
+		plan.From = &from
+	}
+
+	if plan.From != nil && plan.To != nil && dateOf(*plan.To).Before(*plan.From) {
+		return plan, ErrInvalidEffectiveDates
+	}
+
+	for _, v := range released {
+		if v.EffectiveFrom != nil && !dateOf(*v.EffectiveFrom).Before(*plan.From) {
+			return plan, ErrVersionOverlap
+		}
+		if v.EffectiveTo == nil || !dateOf(*v.EffectiveTo).Before(*plan.From) {
+			plan.Close = append(plan.Close, versionClose{ID: v.ID, EffectiveTo: plan.From.AddDate(0, 0, -1)})
+		}
+	}
+
+	plan.Status = models.TimetableStatusPublished
+	if plan.From != nil && plan.From.After(today) {
+		plan.Status = models.TimetableStatusScheduled
+		return plan, nil
+	}
+
+	for _, v := range released {
+		if v.Status == models.TimetableStatusPublished {
+			plan.Archive = append(plan.Archive, v.ID)
+		}
+	}
+	return plan, nil
+}
+
+// diffEntries compares the entries of two versions period by period, keyed
+// by day and period slot.
+func diffEntries(base, compared []models.TimetableEntry) TimetableDiffResponse {
+	type slotKey struct {
+		day  int
+		slot uuid.UUID
+	}
+
+	before := make(map[slotKey]*models.TimetableEntry, len(base))
+	for i := range base {
+		before[slotKey{base[i].DayOfWeek, base[i].PeriodSlotID}] = &base[i]
+	}
+
+	resp := TimetableDiffResponse{Changes: []TimetableEntryChange{}}
+	seen := make(map[slotKey]bool, len(compared))
+	for i := range compared {
+		after := &compared[i]
+		key := slotKey{after.DayOfWeek, after.PeriodSlotID}
+		seen[key] = true
+
+		old, ok := before[key]
+		if !ok {
+			resp.Added++
+			resp.Changes = append(resp.Changes, entryChange(EntryChangeAdded, nil, after, nil))
+			continue
+		}
+
+		fields := changedFields(old, after)
+		if len(fields) == 0 {
+			resp.Unchanged++
+			continue
+		}
+		resp.Changed++
+		resp.Changes = append(resp.Changes, entryChange(EntryChangeChanged, old, after, fields))
+	}
+
+	for i := range base {
+		if seen[slotKey{base[i].DayOfWeek, base[i].PeriodSlotID}] {
+			continue
+		}
+		resp.Removed++
+		resp.Changes = append(resp.Changes, entryChange(EntryChangeRemoved, &base[i], nil, nil))
+	}
+
+	sort.SliceStable(resp.Changes, func(i, j int) bool {
+		a, b := resp.Changes[i], resp.Changes[j]
+		if a.DayOfWeek != b.DayOfWeek {
+			return a.DayOfWeek < b.DayOfWeek
+		}
+		return changeStart(a) < changeStart(b)
+	})
+
+	return resp
+}
+
+// changedFields lists what differs between the entries for the same period.
+func changedFields(before, after *models.TimetableEntry) []string {
+	var fields []string
+	if !sameID(before.SubjectID, after.SubjectID) {
+		fields = append(fields, "subject")
+	}
+	if !sameID(before.StaffID, after.StaffID) {
+		fields = append(fields, "staff")
+	}
+	if before.RoomNumber != after.RoomNumber {
+		fields = append(fields, "room")
+	}
+	if before.IsFreePeriod != after.IsFreePeriod {
+		fields = append(fields, "freePeriod")
+	}
+	if before.Notes != after.Notes {
+		fields = append(fields, "notes")
+	}
+	return fields
+}
+
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func entryChange(change string, before, after *models.TimetableEntry, fields []string) TimetableEntryChange {
+	entry := after
+	if entry == nil {
+		entry = before
+	}
+
+	result := TimetableEntryChange{
+		Change:       change,
+		DayOfWeek:    entry.DayOfWeek,
+		DayName:      entry.GetDayName(),
+		PeriodSlotID: entry.PeriodSlotID,
+		Fields:       fields,
+	}
+	if before != nil {
+		resp := TimetableEntryToResponse(before)
+		result.Before = &resp
+	}
+	if after != nil {
+		resp := TimetableEntryToResponse(after)
+		result.After = &resp
+	}
+	return result
+}
+
+// changeStart is the period start time a change is sorted by.
+func changeStart(change TimetableEntryChange) string {
+	if change.After != nil {
+		return change.After.StartTime
+	}
+	return change.Before.StartTime
+}
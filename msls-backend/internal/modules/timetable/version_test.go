@@ -0,0 +1,206 @@
+package timetable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+// version returns a timetable version. Released versions have been published
+// or scheduled; from and to may be zero for an open window.
+func version(number int, status models.TimetableStatus, from, to time.Time) models.Timetable {
+	v := models.Timetable{ID: uuid.New(), Version: number, Status: status}
+	if status != models.TimetableStatusDraft {
+		v.PublishedAt = ptrTime(day(2026, 6, 1))
+	}
+	if !from.IsZero() {
+		v.EffectiveFrom = ptrTime(from)
+	}
+	if !to.IsZero() {
+		v.EffectiveTo = ptrTime(to)
+	}
+	return v
+}
+
+func TestPlanPublishFirstVersionCoversTheYear(t *testing.T) {
+	draft := version(1, models.TimetableStatusDraft, time.Time{}, time.Time{})
+
+	plan, err := planPublish(&draft, []models.Timetable{draft}, day(2026, 6, 10))
+
+	require.NoError(t, err)
+	assert.Equal(t, models.TimetableStatusPublished, plan.Status)
+	assert.Nil(t, plan.From)
+	assert.Empty(t, plan.Close)
+	assert.Empty(t, plan.Archive)
+}
+
+func TestPlanPublishReplacesCurrentVersionToday(t *testing.T) {
+	current := version(1, models.TimetableStatusPublished, time.Time{}, time.Time{})
+	draft := version(2, models.TimetableStatusDraft, time.Time{}, time.Time{})
+
+	plan, err := planPublish(&draft, []models.Timetable{current, draft}, time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, models.TimetableStatusPublished, plan.Status)
+	require.NotNil(t, plan.From)
+	assert.Equal(t, day(2026, 10, 18), *plan.From)
+	assert.Equal(t, []versionClose{{ID: current.ID, EffectiveTo: day(2026, 10, 17)}}, plan.Close)
+	assert.Equal(t, []uuid.UUID{current.ID}, plan.Archive)
+}
+
+func TestPlanPublishFutureDateSchedules(t *testing.T) {
+	current := version(1, models.TimetableStatusPublished, day(2026, 6, 1), time.Time{})
+	draft := version(2, models.TimetableStatusDraft, day(2026, 11, 2), time.Time{})
+
+	plan, err := planPublish(&draft, []models.Timetable{current, draft}, day(2026, 10, 18))
+
+	require.NoError(t, err)
+	assert.Equal(t, models.TimetableStatusScheduled, plan.Status)
+	assert.Equal(t, []versionClose{{ID: current.ID, EffectiveTo: day(2026, 11, 1)}}, plan.Close)
+	assert.Empty(t, plan.Archive, "the current version stays published until the sweep activates the new one")
+}
+
+func TestPlanPublishBackdatedChange(t *testing.T) {
+	// The change took effect before it was entered; attendance marked since
+	// then now resolves to the new version
+	current := version(1, models.TimetableStatusPublished, time.Time{}, time.Time{})
+	draft := version(2, models.TimetableStatusDraft, day(2026, 10, 12), time.Time{})
+
+	plan, err := planPublish(&draft, []models.Timetable{current, draft}, day(2026, 10, 18))
+
+	require.NoError(t, err)
+	assert.Equal(t, models.TimetableStatusPublished, plan.Status)
+	assert.Equal(t, []versionClose{{ID: current.ID, EffectiveTo: day(2026, 10, 11)}}, plan.Close)
+	assert.Equal(t, []uuid.UUID{current.ID}, plan.Archive)
+}
+
+func TestPlanPublishLeavesClosedVersionsAlone(t *testing.T) {
+	first := version(1, models.TimetableStatusArchived, time.Time{}, day(2026, 8, 31))
+	current := version(2, models.TimetableStatusPublished, day(2026, 9, 1), time.Time{})
+	cancelled := version(3, models.TimetableStatusArchived, day(2026, 12, 1), day(2026, 11, 30))
+	draft := version(4, models.TimetableStatusDraft, day(2026, 11, 2), time.Time{})
+
+	plan, err := planPublish(&draft, []models.Timetable{first, current, cancelled, draft}, day(2026, 10, 18))
+
+	require.NoError(t, err)
+	assert.Equal(t, []versionClose{{ID: current.ID, EffectiveTo: day(2026, 11, 1)}}, plan.Close)
+}
+
+func TestPlanPublishRejectsOverlap(t *testing.T) {
+	current := version(1, models.TimetableStatusPublished, time.Time{}, time.Time{})
+	scheduled := version(2, models.TimetableStatusScheduled, day(2026, 11, 2), time.Time{})
+	draft := version(3, models.TimetableStatusDraft, day(2026, 10, 20), time.Time{})
+
+	_, err := planPublish(&draft, []models.Timetable{current, scheduled, draft}, day(2026, 10, 18))
+	assert.ErrorIs(t, err, ErrVersionOverlap)
+
+	// Taking effect the same day as the current version is also an overlap
+	same := version(3, models.TimetableStatusDraft, day(2026, 9, 1), time.Time{})
+	current.EffectiveFrom = ptrTime(day(2026, 9, 1))
+	_, err = planPublish(&same, []models.Timetable{current, same}, day(2026, 10, 18))
+	assert.ErrorIs(t, err, ErrVersionOverlap)
+}
+
+func TestPlanPublishRejectsInvertedDates(t *testing.T) {
+	draft := version(1, models.TimetableStatusDraft, day(2026, 11, 2), day(2026, 11, 1))
+
+	_, err := planPublish(&draft, []models.Timetable{draft}, day(2026, 10, 18))
+
+	assert.ErrorIs(t, err, ErrInvalidEffectiveDates)
+}
+
+func TestInForceOn(t *testing.T) {
+	archived := version(1, models.TimetableStatusArchived, time.Time{}, day(2026, 10, 11))
+	published := version(2, models.TimetableStatusPublished, day(2026, 10, 12), time.Time{})
+	draft := version(3, models.TimetableStatusDraft, time.Time{}, time.Time{})
+
+	assert.True(t, inForceOn(&archived, day(2026, 10, 11)))
+	assert.False(t, inForceOn(&archived, day(2026, 10, 12)))
+	assert.True(t, inForceOn(&published, day(2026, 10, 12)))
+	assert.False(t, inForceOn(&draft, day(2026, 10, 12)))
+}
+
+func TestDiffEntries(t *testing.T) {
+	p1 := &models.PeriodSlot{ID: uuid.New(), Name: "Period 1", StartTime: "09:00:00", EndTime: "09:45:00"}
+	p2 := &models.PeriodSlot{ID: uuid.New(), Name: "Period 2", StartTime: "09:45:00", EndTime: "10:30:00"}
+	maths, english := uuid.New(), uuid.New()
+	asha, vikram := uuid.New(), uuid.New()
+
+	entry := func(weekday int, slot *models.PeriodSlot, subject, staff uuid.UUID, room string) models.TimetableEntry {
+		return models.TimetableEntry{
+			ID:           uuid.New(),
+			DayOfWeek:    weekday,
+			PeriodSlotID: slot.ID,
+			PeriodSlot:   slot,
+			SubjectID:    ptrUUID(subject),
+			StaffID:      ptrUUID(staff),
+			RoomNumber:   room,
+		}
+	}
+
+	base := []models.TimetableEntry{
+		entry(1, p2, english, vikram, "101"),
+		entry(1, p1, maths, asha, "101"),
+		entry(2, p1, maths, asha, "101"),
+		entry(3, p1, english, vikram, "101"),
+	}
+	compared := []models.TimetableEntry{
+		entry(1, p1, maths, asha, "101"),     // unchanged
+		entry(1, p2, english, asha, "Lab 1"), // new teacher and room
+		entry(2, p1, maths, asha, "101"),     // unchanged
+		entry(4, p1, english, vikram, "101"), // added
+	}
+
+	diff := diffEntries(base, compared)
+
+	assert.Equal(t, 1, diff.Added)
+	assert.Equal(t, 1, diff.Removed)
+	assert.Equal(t, 1, diff.Changed)
+	assert.Equal(t, 2, diff.Unchanged)
+	require.Len(t, diff.Changes, 3)
+
+	changed := diff.Changes[0]
+	assert.Equal(t, EntryChangeChanged, changed.Change)
+	assert.Equal(t, "Monday", changed.DayName)
+	assert.Equal(t, []string{"staff", "room"}, changed.Fields)
+	require.NotNil(t, changed.Before)
+	require.NotNil(t, changed.After)
+	assert.Equal(t, "101", changed.Before.RoomNumber)
+	assert.Equal(t, "Lab 1", changed.After.RoomNumber)
+
+	removed := diff.Changes[1]
+	assert.Equal(t, EntryChangeRemoved, removed.Change)
+	assert.Equal(t, 3, removed.DayOfWeek)
+	assert.Nil(t, removed.After)
+
+	added := diff.Changes[2]
+	assert.Equal(t, EntryChangeAdded, added.Change)
+	assert.Equal(t, 4, added.DayOfWeek)
+	assert.Nil(t, added.Before)
+}
+
+func TestBuildFeedEventsSwitchesVersionsMidWindow(t *testing.T) {
+	f := newExportFixture()
+	f.timetable.EffectiveTo = ptrTime(day(2026, 1, 9))
+
+	revised := &models.Timetable{ID: uuid.New(), BranchID: f.branchID, SectionID: f.section.ID, Section: f.section, EffectiveFrom: ptrTime(day(2026, 1, 10))}
+	physics := f.maths
+	physics.ID = uuid.New()
+	physics.TimetableID = revised.ID
+	physics.Timetable = revised
+	physics.Subject = &models.Subject{Name: "Physics"}
+
+	s := f.schedule(models.TimetableFeedKindSection)
+	s.Entries = append(s.Entries, physics)
+
+	assert.Equal(t, []string{"Mon 05 Maths", "Mon 12 Physics"}, summaries(buildFeedEvents(s)))
+}
@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -11,13 +12,18 @@ import (
 
 // Config holds all application configuration values.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	MinIO    MinIOConfig
-	Log      LogConfig
-	App      AppConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	JWT       JWTConfig
+	MinIO     MinIOConfig
+	Log       LogConfig
+	App       AppConfig
+	RateLimit RateLimitConfig
+	SSO       SSOConfig
+	Payment   PaymentConfig
+	Data      DataConfig
+	Metrics   MetricsConfig
 }
 
 // ServerConfig holds HTTP server configuration.
@@ -63,12 +69,110 @@ func (c RedisConfig) Addr() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
+// RateLimitConfig holds API rate limiting configuration.
+type RateLimitConfig struct {
+	// Store selects the counter store: "memory", "redis" or "postgres".
+	// Redis falls back to PostgreSQL when it is unavailable.
+	Store               string
+	GlobalPerMinute     int
+	LoginPerMinute      int
+	OTPPerMinute        int
+	TenantPerMinute     int
+	UserReadsPerMinute  int
+	UserWritesPerMinute int
+}
+
+// SSOConfig holds single sign-on configuration.
+type SSOConfig struct {
+	// BaseURL is the public URL of this API, used to build IdP callback URLs.
+	BaseURL string
+	// AllowedRedirectOrigins lists the frontend origins a login may return to.
+	AllowedRedirectOrigins []string
+}
+
+// PaymentConfig holds payment gateway configuration.
+type PaymentConfig struct {
+	// Provider selects the gateway: "razorpay", or "fake" for the in-memory
+	// gateway used in development.
+	Provider              string
+	RazorpayKeyID         string
+	RazorpayKeySecret     string
+	RazorpayWebhookSecret string
+}
+
+// Payment providers.
+const (
+	PaymentProviderRazorpay = "razorpay"
+	PaymentProviderFake     = "fake"
+)
+
+// Validate checks that the payment provider is known, that Razorpay has its
+// credentials, and that the fake gateway, which simulates payments in memory,
+// is not used in production.
+func (c PaymentConfig) Validate(app AppConfig) error {
+	switch c.Provider {
+	case PaymentProviderRazorpay:
+		var missing []string
+		if c.RazorpayKeyID == "" {
+			missing = append(missing, "RAZORPAY_KEY_ID")
+		}
+		if c.RazorpayKeySecret == "" {
+			missing = append(missing, "RAZORPAY_KEY_SECRET")
+		}
+		if c.RazorpayWebhookSecret == "" {
+			missing = append(missing, "RAZORPAY_WEBHOOK_SECRET")
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("PAYMENT_PROVIDER=%s requires %s", c.Provider, strings.Join(missing, ", "))
+		}
+		return nil
+	case PaymentProviderFake:
+		if app.IsProduction() {
+			return fmt.Errorf("PAYMENT_PROVIDER=%s cannot be used in production", c.Provider)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown PAYMENT_PROVIDER %q", c.Provider)
+	}
+}
+
+// developmentDataKey is used outside production when DATA_ENCRYPTION_KEY is unset.
+const developmentDataKey = "msls-development-data-key"
+
+// DataConfig holds the encryption of sensitive data at rest.
+type DataConfig struct {
+	// EncryptionKey encrypts stored secrets such as staff bank account numbers
+	// and identity provider client secrets. It is separate from the JWT secret
+	// so that signing keys can be rotated without losing stored data.
+	EncryptionKey string
+}
+
+// Validate checks that a data encryption key is set in production.
+func (c DataConfig) Validate(app AppConfig) error {
+	if c.EncryptionKey == "" && app.IsProduction() {
+		return fmt.Errorf("DATA_ENCRYPTION_KEY must be set in production")
+	}
+	return nil
+}
+
+// MetricsConfig holds access control for the Prometheus metrics endpoint.
+type MetricsConfig struct {
+	// Token lets scrapers in with an "Authorization: Bearer" header. Empty
+	// disables token access.
+	Token string
+	// AllowedNetworks lists the networks that may scrape without a token.
+	AllowedNetworks []*net.IPNet
+}
+
 // JWTConfig holds JWT authentication configuration.
 type JWTConfig struct {
 	Secret           string
 	AccessExpiresIn  time.Duration
 	RefreshExpiresIn time.Duration
 	Issuer           string
+	// KeyRingFile is the path of the RS256/EdDSA key ring managed with cmd/jwtkeys.
+	// When empty, tokens are signed with Secret (HS256).
+	KeyRingFile string
 }
 
 // MinIOConfig holds MinIO object storage configuration.
@@ -153,6 +257,7 @@ func Load() (*Config, error) {
 			AccessExpiresIn:  v.GetDuration("JWT_ACCESS_EXPIRES_IN"),
 			RefreshExpiresIn: v.GetDuration("JWT_REFRESH_EXPIRES_IN"),
 			Issuer:           v.GetString("JWT_ISSUER"),
+			KeyRingFile:      v.GetString("JWT_KEYRING_FILE"),
 		},
 		MinIO: MinIOConfig{
 			Endpoint:        v.GetString("MINIO_ENDPOINT"),
@@ -165,6 +270,41 @@ func Load() (*Config, error) {
 			Level:  v.GetString("LOG_LEVEL"),
 			Format: v.GetString("LOG_FORMAT"),
 		},
+		RateLimit: RateLimitConfig{
+			Store:               v.GetString("RATE_LIMIT_STORE"),
+			GlobalPerMinute:     v.GetInt("RATE_LIMIT_GLOBAL_PER_MINUTE"),
+			LoginPerMinute:      v.GetInt("RATE_LIMIT_LOGIN_PER_MINUTE"),
+			OTPPerMinute:        v.GetInt("RATE_LIMIT_OTP_PER_MINUTE"),
+			TenantPerMinute:     v.GetInt("RATE_LIMIT_TENANT_PER_MINUTE"),
+			UserReadsPerMinute:  v.GetInt("RATE_LIMIT_USER_READS_PER_MINUTE"),
+			UserWritesPerMinute: v.GetInt("RATE_LIMIT_USER_WRITES_PER_MINUTE"),
+		},
+		SSO: SSOConfig{
+			BaseURL:                v.GetString("SSO_BASE_URL"),
+			AllowedRedirectOrigins: splitList(v.GetString("SSO_ALLOWED_REDIRECT_ORIGINS")),
+		},
+		Payment: PaymentConfig{
+			Provider:              v.GetString("PAYMENT_PROVIDER"),
+			RazorpayKeyID:         v.GetString("RAZORPAY_KEY_ID"),
+			RazorpayKeySecret:     v.GetString("RAZORPAY_KEY_SECRET"),
+			RazorpayWebhookSecret: v.GetString("RAZORPAY_WEBHOOK_SECRET"),
+		},
+		Data: DataConfig{
+			EncryptionKey: v.GetString("DATA_ENCRYPTION_KEY"),
+		},
+	}
+
+	metricsNetworks, err := parseNetworks(v.GetString("METRICS_ALLOWED_IPS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid METRICS_ALLOWED_IPS: %w", err)
+	}
+	cfg.Metrics = MetricsConfig{
+		Token:           v.GetString("METRICS_TOKEN"),
+		AllowedNetworks: metricsNetworks,
+	}
+
+	if cfg.Data.EncryptionKey == "" && !cfg.App.IsProduction() {
+		cfg.Data.EncryptionKey = developmentDataKey
 	}
 
 	return cfg, nil
@@ -205,6 +345,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("JWT_ACCESS_EXPIRES_IN", "15m")
 	v.SetDefault("JWT_REFRESH_EXPIRES_IN", "168h") // 7 days
 	v.SetDefault("JWT_ISSUER", "msls-backend")
+	v.SetDefault("JWT_KEYRING_FILE", "")
 
 	// MinIO defaults
 	v.SetDefault("MINIO_ENDPOINT", "localhost:9000")
@@ -216,6 +357,25 @@ func setDefaults(v *viper.Viper) {
 	// Log defaults
 	v.SetDefault("LOG_LEVEL", "info")
 	v.SetDefault("LOG_FORMAT", "json")
+
+	// Rate limit defaults
+	v.SetDefault("RATE_LIMIT_STORE", "memory")
+	v.SetDefault("RATE_LIMIT_GLOBAL_PER_MINUTE", 200)
+	v.SetDefault("RATE_LIMIT_LOGIN_PER_MINUTE", 10)
+	v.SetDefault("RATE_LIMIT_OTP_PER_MINUTE", 5)
+	v.SetDefault("RATE_LIMIT_TENANT_PER_MINUTE", 3000)
+	v.SetDefault("RATE_LIMIT_USER_READS_PER_MINUTE", 300)
+	v.SetDefault("RATE_LIMIT_USER_WRITES_PER_MINUTE", 60)
+
+	// SSO defaults
+	v.SetDefault("SSO_BASE_URL", "http://localhost:8080")
+	v.SetDefault("SSO_ALLOWED_REDIRECT_ORIGINS", "http://localhost:4200")
+
+	// Payment defaults
+	v.SetDefault("PAYMENT_PROVIDER", "fake")
+
+	// Metrics defaults (local scrapes only)
+	v.SetDefault("METRICS_ALLOWED_IPS", "127.0.0.1,::1")
 }
 
 func bindEnvVars(v *viper.Viper) {
@@ -225,12 +385,56 @@ func bindEnvVars(v *viper.Viper) {
 		"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSLMODE",
 		"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME",
 		"REDIS_HOST", "REDIS_PORT", "REDIS_PASSWORD", "REDIS_DB",
-		"JWT_SECRET", "JWT_ACCESS_EXPIRES_IN", "JWT_REFRESH_EXPIRES_IN", "JWT_ISSUER",
+		"JWT_SECRET", "JWT_ACCESS_EXPIRES_IN", "JWT_REFRESH_EXPIRES_IN", "JWT_ISSUER", "JWT_KEYRING_FILE",
 		"MINIO_ENDPOINT", "MINIO_ACCESS_KEY_ID", "MINIO_SECRET_ACCESS_KEY", "MINIO_USE_SSL", "MINIO_BUCKET_NAME",
 		"LOG_LEVEL", "LOG_FORMAT",
+		"RATE_LIMIT_STORE", "RATE_LIMIT_GLOBAL_PER_MINUTE", "RATE_LIMIT_LOGIN_PER_MINUTE", "RATE_LIMIT_OTP_PER_MINUTE",
+		"RATE_LIMIT_TENANT_PER_MINUTE", "RATE_LIMIT_USER_READS_PER_MINUTE", "RATE_LIMIT_USER_WRITES_PER_MINUTE",
+		"SSO_BASE_URL", "SSO_ALLOWED_REDIRECT_ORIGINS",
+		"PAYMENT_PROVIDER", "RAZORPAY_KEY_ID", "RAZORPAY_KEY_SECRET", "RAZORPAY_WEBHOOK_SECRET",
+		"DATA_ENCRYPTION_KEY",
+		"METRICS_TOKEN", "METRICS_ALLOWED_IPS",
 	}
 
 	for _, env := range envVars {
 		_ = v.BindEnv(env)
 	}
 }
+
+// splitList splits a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseNetworks parses a comma-separated list of IP addresses and CIDR
+// networks. A bare address is a network of that single address.
+func parseNetworks(value string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, item := range splitList(value) {
+		if strings.Contains(item, "/") {
+			_, network, err := net.ParseCIDR(item)
+			if err != nil {
+				return nil, err
+			}
+			networks = append(networks, network)
+			continue
+		}
+
+		ip := net.ParseIP(item)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", item)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return networks, nil
+}
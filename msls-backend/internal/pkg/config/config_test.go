@@ -0,0 +1,69 @@
+package config
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentConfig_Validate(t *testing.T) {
+	development := AppConfig{Environment: "development"}
+	production := AppConfig{Environment: "production"}
+	razorpay := PaymentConfig{
+		Provider:              PaymentProviderRazorpay,
+		RazorpayKeyID:         "rzp_key",
+		RazorpayKeySecret:     "rzp_secret",
+		RazorpayWebhookSecret: "rzp_webhook",
+	}
+
+	assert.NoError(t, razorpay.Validate(production))
+	assert.NoError(t, PaymentConfig{Provider: PaymentProviderFake}.Validate(development))
+	assert.Error(t, PaymentConfig{Provider: PaymentProviderFake}.Validate(production))
+	assert.Error(t, PaymentConfig{Provider: "paypal"}.Validate(development))
+
+	missing := razorpay
+	missing.RazorpayWebhookSecret = ""
+	assert.EqualError(t, missing.Validate(development), "PAYMENT_PROVIDER=razorpay requires RAZORPAY_WEBHOOK_SECRET")
+
+	err := PaymentConfig{Provider: PaymentProviderRazorpay}.Validate(production)
+	assert.EqualError(t, err, "PAYMENT_PROVIDER=razorpay requires RAZORPAY_KEY_ID, RAZORPAY_KEY_SECRET, RAZORPAY_WEBHOOK_SECRET")
+}
+
+func TestDataConfig_Validate(t *testing.T) {
+	assert.NoError(t, DataConfig{EncryptionKey: "key"}.Validate(AppConfig{Environment: "production"}))
+	assert.Error(t, DataConfig{}.Validate(AppConfig{Environment: "production"}))
+	assert.NoError(t, DataConfig{}.Validate(AppConfig{Environment: "development"}))
+}
+
+func TestLoad_DevelopmentDataKey(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("DATA_ENCRYPTION_KEY", "")
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, developmentDataKey, cfg.Data.EncryptionKey)
+
+	t.Setenv("APP_ENV", "production")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.Data.EncryptionKey)
+}
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := parseNetworks("127.0.0.1, ::1,10.0.0.0/8")
+	assert.NoError(t, err)
+	assert.Len(t, networks, 3)
+	assert.Equal(t, "127.0.0.1/32", networks[0].String())
+	assert.Equal(t, "::1/128", networks[1].String())
+	assert.True(t, networks[2].Contains(net.ParseIP("10.20.30.40")))
+	assert.False(t, networks[0].Contains(net.ParseIP("127.0.0.2")))
+
+	networks, err = parseNetworks("")
+	assert.NoError(t, err)
+	assert.Empty(t, networks)
+
+	_, err = parseNetworks("localhost")
+	assert.Error(t, err)
+	_, err = parseNetworks("10.0.0.0/33")
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := DeriveKey("secret", "test")
+	ciphertext, err := Encrypt(key, "123456789012")
+	require.NoError(t, err)
+	assert.NotContains(t, ciphertext, "123456789012")
+
+	plaintext, err := Decrypt(key, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "123456789012", plaintext)
+
+	// Each encryption uses a fresh nonce
+	again, err := Encrypt(key, "123456789012")
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again)
+
+	_, err = Decrypt(DeriveKey("secret", "other"), ciphertext)
+	assert.Error(t, err)
+	_, err = Decrypt(key, "c2hvcnQ=")
+	assert.ErrorIs(t, err, ErrCiphertextTooShort)
+}
+
+func TestDeriveKey(t *testing.T) {
+	assert.Len(t, DeriveKey("secret", "a"), 32)
+	assert.Equal(t, DeriveKey("secret", "a"), DeriveKey("secret", "a"))
+	assert.NotEqual(t, DeriveKey("secret", "a"), DeriveKey("secret", "b"))
+	assert.NotEqual(t, DeriveKey("secret", "a"), DeriveKey("other", "a"))
+}
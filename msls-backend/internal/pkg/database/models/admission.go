@@ -86,6 +86,34 @@ func (ss *SessionSettings) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, ss)
 }
 
+// Seat quota categories. Reserved quotas are used as ReservedSeats keys;
+// general covers every seat that is not reserved.
+const (
+	SeatQuotaGeneral    = "general"
+	SeatQuotaRTE        = "rte"
+	SeatQuotaStaffWard  = "staff_ward"
+	SeatQuotaSibling    = "sibling"
+	SeatQuotaManagement = "management"
+)
+
+// ReservedSeatQuotas lists the quotas seats can be reserved for.
+var ReservedSeatQuotas = []string{SeatQuotaRTE, SeatQuotaStaffWard, SeatQuotaSibling, SeatQuotaManagement}
+
+// IsReservedSeatQuota checks if quota is a quota seats can be reserved for.
+func IsReservedSeatQuota(quota string) bool {
+	for _, q := range ReservedSeatQuotas {
+		if q == quota {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidSeatQuota checks if quota is general or a reserved quota.
+func IsValidSeatQuota(quota string) bool {
+	return quota == SeatQuotaGeneral || IsReservedSeatQuota(quota)
+}
+
 // ReservedSeats represents seat reservations by category.
 type ReservedSeats map[string]int
 
@@ -148,8 +176,10 @@ type AdmissionSeat struct {
 	FilledSeats   int           `gorm:"not null;default:0" json:"filled_seats"`
 	WaitlistLimit int           `gorm:"default:10" json:"waitlist_limit"`
 	ReservedSeats ReservedSeats `gorm:"type:jsonb;default:'{}'" json:"reserved_seats"`
-	CreatedAt     time.Time     `gorm:"not null;default:now()" json:"created_at"`
-	UpdatedAt     time.Time     `gorm:"not null;default:now()" json:"updated_at"`
+	// QuotaReleaseDate is the date from which unfilled reserved seats become general seats.
+	QuotaReleaseDate *time.Time `gorm:"type:date" json:"quota_release_date,omitempty"`
+	CreatedAt        time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"not null;default:now()" json:"updated_at"`
 
 	// Relationships
 	Session *AdmissionSession `gorm:"foreignKey:SessionID" json:"session,omitempty"`
@@ -192,6 +222,14 @@ func (as *AdmissionSeat) GeneralSeats() int {
 	return general
 }
 
+// QuotasReleased reports whether unfilled reserved seats have been released to general.
+func (as *AdmissionSeat) QuotasReleased(now time.Time) bool {
+	if as.QuotaReleaseDate == nil {
+		return false
+	}
+	return now.Format("2006-01-02") >= as.QuotaReleaseDate.Format("2006-01-02")
+}
+
 // EnquirySource represents the source of an admission enquiry.
 type EnquirySource string
 
@@ -320,6 +358,15 @@ func (as ApplicationStatus) String() string {
 	return string(as)
 }
 
+// ApplicationSource represents where an admission application was started.
+type ApplicationSource string
+
+// ApplicationSource constants.
+const (
+	ApplicationSourceOffice ApplicationSource = "office"
+	ApplicationSourceOnline ApplicationSource = "online"
+)
+
 // ApplicantDetails represents additional details about the applicant.
 type ApplicantDetails struct {
 	Gender          string `json:"gender,omitempty"`
@@ -406,6 +453,10 @@ type AdmissionApplication struct {
 	Religion            string     `gorm:"column:religion;size:100" json:"religion,omitempty"`
 	Caste               string     `gorm:"column:caste;size:100" json:"caste,omitempty"`
 	Category            string     `gorm:"column:category;size:50" json:"category,omitempty"`
+	QuotaCategory       string     `gorm:"column:quota_category;size:30;not null;default:'general'" json:"quota_category"`
+	FamilyID            *uuid.UUID `gorm:"column:family_id;type:uuid" json:"family_id,omitempty"`
+	HasSibling          bool       `gorm:"column:has_sibling;not null;default:false" json:"has_sibling"`
+	StaffParentID       *uuid.UUID `gorm:"column:staff_parent_id;type:uuid" json:"staff_parent_id,omitempty"`
 	MotherTongue        string     `gorm:"column:mother_tongue;size:100" json:"mother_tongue,omitempty"`
 	AadharNumber        string     `gorm:"column:aadhar_number;size:12" json:"aadhar_number,omitempty"`
 
@@ -455,6 +506,10 @@ type AdmissionApplication struct {
 	// Extra Data (for custom fields)
 	ExtraData map[string]interface{} `gorm:"column:extra_data;type:jsonb;default:'{}';serializer:json" json:"extra_data,omitempty"`
 
+	// Online Application (source "online" applications were started on the public portal)
+	Source        ApplicationSource `gorm:"column:source;size:20;not null;default:'office'" json:"source"`
+	VerifiedPhone string            `gorm:"column:verified_phone;size:20" json:"verified_phone,omitempty"`
+
 	// Audit Fields
 	CreatedAt time.Time  `gorm:"column:created_at;not null;default:now()" json:"created_at"`
 	UpdatedAt time.Time  `gorm:"column:updated_at;not null;default:now()" json:"updated_at"`
@@ -498,6 +553,45 @@ func (d DecisionType) String() string {
 	return string(d)
 }
 
+// OfferFeeItem represents a fee due on acceptance of an admission offer.
+type OfferFeeItem struct {
+	Name   string          `json:"name"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// OfferFeeItems is a slice of OfferFeeItem that implements Scanner/Valuer for JSONB.
+type OfferFeeItems []OfferFeeItem
+
+// Value implements the driver.Valuer interface for database serialization.
+func (f OfferFeeItems) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface for database deserialization.
+func (f *OfferFeeItems) Scan(value interface{}) error {
+	if value == nil {
+		*f = OfferFeeItems{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed for OfferFeeItems")
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+// Total returns the sum of all fee amounts.
+func (f OfferFeeItems) Total() decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range f {
+		total = total.Add(item.Amount)
+	}
+	return total
+}
+
 // AdmissionDecision represents an admission decision for an application.
 type AdmissionDecision struct {
 	ID               uuid.UUID    `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
@@ -513,6 +607,12 @@ type AdmissionDecision struct {
 	OfferValidUntil  *time.Time   `gorm:"type:date" json:"offer_valid_until,omitempty"`
 	OfferAccepted    *bool        `gorm:"type:boolean" json:"offer_accepted,omitempty"`
 	OfferAcceptedAt  *time.Time   `gorm:"type:timestamptz" json:"offer_accepted_at,omitempty"`
+	OfferLetterPath  *string      `gorm:"type:varchar(500)" json:"-"`
+	OfferTokenHash   *string      `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	OfferFees        OfferFeeItems `gorm:"type:jsonb;default:'[]'" json:"offer_fees"`
+	OfferViewedAt    *time.Time   `gorm:"type:timestamptz" json:"offer_viewed_at,omitempty"`
+	OfferLapsedAt    *time.Time   `gorm:"type:timestamptz" json:"offer_lapsed_at,omitempty"`
+	AllocatedQuota   *string      `gorm:"type:varchar(30)" json:"allocated_quota,omitempty"`
 	Remarks          *string      `gorm:"type:text" json:"remarks,omitempty"`
 	CreatedAt        time.Time    `gorm:"not null;default:now()" json:"created_at"`
 	UpdatedAt        time.Time    `gorm:"not null;default:now()" json:"updated_at"`
@@ -528,6 +628,21 @@ func (AdmissionDecision) TableName() string {
 	return "admission_decisions"
 }
 
+// IsOfferAccepted reports whether the offer has been accepted.
+func (d *AdmissionDecision) IsOfferAccepted() bool {
+	return d.OfferAccepted != nil && *d.OfferAccepted
+}
+
+// IsOfferExpired reports whether the offer's validity date has passed.
+// An offer stays valid until the end of its OfferValidUntil date.
+func (d *AdmissionDecision) IsOfferExpired(now time.Time) bool {
+	if d.OfferValidUntil == nil {
+		return false
+	}
+	until := d.OfferValidUntil.Format("2006-01-02")
+	return now.Format("2006-01-02") > until
+}
+
 // MeritListEntry represents a single entry in the merit list.
 type MeritListEntry struct {
 	Rank           int        `json:"rank"`
@@ -956,3 +1071,28 @@ type ApplicationNumberSequence struct {
 func (ApplicationNumberSequence) TableName() string {
 	return "application_number_sequences"
 }
+
+// ============================================================================
+// Online Admission Portal
+// ============================================================================
+
+// AdmissionPortalSession is issued to a parent after their phone number is verified
+// by OTP and grants access to the online applications started with that number.
+type AdmissionPortalSession struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Phone     string    `gorm:"size:20;not null" json:"phone"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time `gorm:"type:timestamptz;not null" json:"expires_at"`
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for AdmissionPortalSession.
+func (AdmissionPortalSession) TableName() string {
+	return "admission_portal_sessions"
+}
+
+// IsExpired returns true if the portal session can no longer be used.
+func (s *AdmissionPortalSession) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
@@ -0,0 +1,213 @@
+// Package models provides GORM model definitions for the MSLS database.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// AppraisalCycleStatus represents the lifecycle of an appraisal cycle.
+type AppraisalCycleStatus string
+
+// AppraisalCycleStatus constants.
+const (
+	AppraisalCycleStatusDraft  AppraisalCycleStatus = "draft"
+	AppraisalCycleStatusActive AppraisalCycleStatus = "active"
+	AppraisalCycleStatusClosed AppraisalCycleStatus = "closed"
+)
+
+// IsValid checks if the cycle status is a valid value.
+func (s AppraisalCycleStatus) IsValid() bool {
+	switch s {
+	case AppraisalCycleStatusDraft, AppraisalCycleStatusActive, AppraisalCycleStatusClosed:
+		return true
+	}
+	return false
+}
+
+// AppraisalType distinguishes periodic appraisals from probation confirmation reviews.
+type AppraisalType string
+
+// AppraisalType constants.
+const (
+	AppraisalTypeCycle     AppraisalType = "cycle"
+	AppraisalTypeProbation AppraisalType = "probation"
+)
+
+// IsValid checks if the appraisal type is a valid value.
+func (t AppraisalType) IsValid() bool {
+	switch t {
+	case AppraisalTypeCycle, AppraisalTypeProbation:
+		return true
+	}
+	return false
+}
+
+// AppraisalStatus is the workflow step an appraisal is waiting on.
+type AppraisalStatus string
+
+// AppraisalStatus constants.
+const (
+	AppraisalStatusSelfAssessment  AppraisalStatus = "self_assessment"
+	AppraisalStatusManagerReview   AppraisalStatus = "manager_review"
+	AppraisalStatusReviewerSignOff AppraisalStatus = "reviewer_signoff"
+	AppraisalStatusCompleted       AppraisalStatus = "completed"
+)
+
+// IsValid checks if the appraisal status is a valid value.
+func (s AppraisalStatus) IsValid() bool {
+	switch s {
+	case AppraisalStatusSelfAssessment, AppraisalStatusManagerReview,
+		AppraisalStatusReviewerSignOff, AppraisalStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// ProbationOutcome is the manager's recommendation at a probation review.
+type ProbationOutcome string
+
+// ProbationOutcome constants.
+const (
+	ProbationOutcomeConfirm      ProbationOutcome = "confirm"
+	ProbationOutcomeExtend       ProbationOutcome = "extend"
+	ProbationOutcomeNotConfirmed ProbationOutcome = "not_confirmed"
+)
+
+// IsValid checks if the probation outcome is a valid value.
+func (o ProbationOutcome) IsValid() bool {
+	switch o {
+	case ProbationOutcomeConfirm, ProbationOutcomeExtend, ProbationOutcomeNotConfirmed:
+		return true
+	}
+	return false
+}
+
+// AppraisalTemplate is a set of KRAs/goals used to appraise staff of a
+// designation. A template without a designation is the tenant's default.
+type AppraisalTemplate struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenantId"`
+	Name          string     `gorm:"type:varchar(200);not null" json:"name"`
+	DesignationID *uuid.UUID `gorm:"type:uuid" json:"designationId,omitempty"`
+	Description   string     `gorm:"type:text" json:"description,omitempty"`
+	IsActive      bool       `gorm:"not null;default:true" json:"isActive"`
+	CreatedAt     time.Time  `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt     time.Time  `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy     *uuid.UUID `gorm:"type:uuid" json:"createdBy,omitempty"`
+	UpdatedBy     *uuid.UUID `gorm:"type:uuid" json:"updatedBy,omitempty"`
+
+	// Relationships
+	Designation *Designation            `gorm:"foreignKey:DesignationID" json:"designation,omitempty"`
+	Goals       []AppraisalTemplateGoal `gorm:"foreignKey:TemplateID" json:"goals,omitempty"`
+}
+
+// TableName returns the table name for the AppraisalTemplate model.
+func (AppraisalTemplate) TableName() string {
+	return "appraisal_templates"
+}
+
+// AppraisalTemplateGoal is one weighted KRA or goal on a template.
+type AppraisalTemplateGoal struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TemplateID   uuid.UUID `gorm:"type:uuid;not null;index" json:"templateId"`
+	Title        string    `gorm:"type:varchar(200);not null" json:"title"`
+	Description  string    `gorm:"type:text" json:"description,omitempty"`
+	Weight       int       `gorm:"not null" json:"weight"`
+	DisplayOrder int       `gorm:"not null;default:0" json:"displayOrder"`
+}
+
+// TableName returns the table name for the AppraisalTemplateGoal model.
+func (AppraisalTemplateGoal) TableName() string {
+	return "appraisal_template_goals"
+}
+
+// AppraisalCycle is an appraisal period (for example an academic year) in
+// which every active staff member is appraised.
+type AppraisalCycle struct {
+	ID                uuid.UUID            `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID          uuid.UUID            `gorm:"type:uuid;not null;index" json:"tenantId"`
+	Name              string               `gorm:"type:varchar(200);not null" json:"name"`
+	BranchID          *uuid.UUID           `gorm:"type:uuid" json:"branchId,omitempty"`
+	PeriodStart       time.Time            `gorm:"type:date;not null" json:"periodStart"`
+	PeriodEnd         time.Time            `gorm:"type:date;not null" json:"periodEnd"`
+	SelfAssessmentDue *time.Time           `gorm:"type:date" json:"selfAssessmentDue,omitempty"`
+	ManagerReviewDue  *time.Time           `gorm:"type:date" json:"managerReviewDue,omitempty"`
+	Status            AppraisalCycleStatus `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
+	LaunchedAt        *time.Time           `gorm:"type:timestamptz" json:"launchedAt,omitempty"`
+	ClosedAt          *time.Time           `gorm:"type:timestamptz" json:"closedAt,omitempty"`
+	CreatedAt         time.Time            `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt         time.Time            `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy         *uuid.UUID           `gorm:"type:uuid" json:"createdBy,omitempty"`
+	UpdatedBy         *uuid.UUID           `gorm:"type:uuid" json:"updatedBy,omitempty"`
+}
+
+// TableName returns the table name for the AppraisalCycle model.
+func (AppraisalCycle) TableName() string {
+	return "appraisal_cycles"
+}
+
+// Appraisal is one staff member's appraisal, either in a cycle or as a
+// probation confirmation review. It moves from self-assessment to manager
+// review to reviewer sign-off.
+type Appraisal struct {
+	ID                     uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID               uuid.UUID         `gorm:"type:uuid;not null;index" json:"tenantId"`
+	CycleID                *uuid.UUID        `gorm:"type:uuid;index" json:"cycleId,omitempty"`
+	StaffID                uuid.UUID         `gorm:"type:uuid;not null;index" json:"staffId"`
+	Type                   AppraisalType     `gorm:"type:varchar(20);not null" json:"type"`
+	TemplateID             *uuid.UUID        `gorm:"type:uuid" json:"templateId,omitempty"`
+	ManagerID              *uuid.UUID        `gorm:"type:uuid;index" json:"managerId,omitempty"`
+	ReviewerID             *uuid.UUID        `gorm:"type:uuid;index" json:"reviewerId,omitempty"`
+	Status                 AppraisalStatus   `gorm:"type:varchar(20);not null;default:'self_assessment'" json:"status"`
+	DueDate                *time.Time        `gorm:"type:date" json:"dueDate,omitempty"`
+	SelfComments           string            `gorm:"type:text" json:"selfComments,omitempty"`
+	SelfSubmittedAt        *time.Time        `gorm:"type:timestamptz" json:"selfSubmittedAt,omitempty"`
+	ManagerComments        string            `gorm:"type:text" json:"managerComments,omitempty"`
+	ManagerSubmittedAt     *time.Time        `gorm:"type:timestamptz" json:"managerSubmittedAt,omitempty"`
+	OverallRating          *decimal.Decimal  `gorm:"type:decimal(3,2)" json:"overallRating,omitempty"`
+	ProbationOutcome       *ProbationOutcome `gorm:"type:varchar(20)" json:"probationOutcome,omitempty"`
+	ExtendProbationTo      *time.Time        `gorm:"type:date" json:"extendProbationTo,omitempty"`
+	IncrementPercent       *decimal.Decimal  `gorm:"type:decimal(5,2)" json:"incrementPercent,omitempty"`
+	IncrementEffectiveFrom *time.Time        `gorm:"type:date" json:"incrementEffectiveFrom,omitempty"`
+	ReviewerComments       string            `gorm:"type:text" json:"reviewerComments,omitempty"`
+	SignedOffAt            *time.Time        `gorm:"type:timestamptz" json:"signedOffAt,omitempty"`
+	SignedOffBy            *uuid.UUID        `gorm:"type:uuid" json:"signedOffBy,omitempty"`
+	SalaryRevisionID       *uuid.UUID        `gorm:"type:uuid" json:"salaryRevisionId,omitempty"`
+	CreatedAt              time.Time         `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt              time.Time         `gorm:"not null;default:now()" json:"updatedAt"`
+
+	// Relationships
+	Cycle    *AppraisalCycle `gorm:"foreignKey:CycleID" json:"cycle,omitempty"`
+	Staff    *Staff          `gorm:"foreignKey:StaffID" json:"staff,omitempty"`
+	Manager  *Staff          `gorm:"foreignKey:ManagerID" json:"manager,omitempty"`
+	Reviewer *Staff          `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+	Goals    []AppraisalGoal `gorm:"foreignKey:AppraisalID" json:"goals,omitempty"`
+}
+
+// TableName returns the table name for the Appraisal model.
+func (Appraisal) TableName() string {
+	return "appraisals"
+}
+
+// AppraisalGoal is a KRA or goal on an appraisal, copied from the template
+// when the appraisal is created, with the self and manager ratings (1-5).
+type AppraisalGoal struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	AppraisalID    uuid.UUID `gorm:"type:uuid;not null;index" json:"appraisalId"`
+	Title          string    `gorm:"type:varchar(200);not null" json:"title"`
+	Description    string    `gorm:"type:text" json:"description,omitempty"`
+	Weight         int       `gorm:"not null" json:"weight"`
+	DisplayOrder   int       `gorm:"not null;default:0" json:"displayOrder"`
+	SelfRating     *int      `json:"selfRating,omitempty"`
+	SelfComment    string    `gorm:"type:text" json:"selfComment,omitempty"`
+	ManagerRating  *int      `json:"managerRating,omitempty"`
+	ManagerComment string    `gorm:"type:text" json:"managerComment,omitempty"`
+}
+
+// TableName returns the table name for the AppraisalGoal model.
+func (AppraisalGoal) TableName() string {
+	return "appraisal_goals"
+}
@@ -170,6 +170,7 @@ type Section struct {
 	AcademicYearID  *uuid.UUID `gorm:"type:uuid;index" json:"academicYearId,omitempty"`
 	StreamID        *uuid.UUID `gorm:"type:uuid;index" json:"streamId,omitempty"`
 	ClassTeacherID  *uuid.UUID `gorm:"type:uuid;index" json:"classTeacherId,omitempty"`
+	ShiftID         *uuid.UUID `gorm:"type:uuid;index" json:"shiftId,omitempty"`
 	Name            string     `gorm:"type:varchar(20);not null" json:"name"`
 	Code            string     `gorm:"type:varchar(20);not null" json:"code"`
 	Capacity        *int       `gorm:"default:40" json:"capacity,omitempty"`
@@ -185,6 +186,7 @@ type Section struct {
 	AcademicYear *AcademicYear `gorm:"foreignKey:AcademicYearID" json:"academicYear,omitempty"`
 	Stream       *Stream       `gorm:"foreignKey:StreamID" json:"stream,omitempty"`
 	ClassTeacher *Staff        `gorm:"foreignKey:ClassTeacherID" json:"classTeacher,omitempty"`
+	Shift        *Shift        `gorm:"foreignKey:ShiftID" json:"-"`
 
 	// Computed fields (not stored)
 	StudentCount int `gorm:"-" json:"studentCount,omitempty"`
@@ -80,6 +80,12 @@ type StaffAttendance struct {
 	IsLate      bool `gorm:"not null;default:false" json:"isLate"`
 	LateMinutes int  `gorm:"default:0" json:"lateMinutes"`
 
+	IsEarlyExit      bool `gorm:"not null;default:false" json:"isEarlyExit"`
+	EarlyExitMinutes int  `gorm:"default:0" json:"earlyExitMinutes"`
+
+	// ShiftID is the shift the record was evaluated against, if any
+	ShiftID *uuid.UUID `gorm:"type:uuid" json:"shiftId,omitempty"`
+
 	HalfDayType *HalfDayType `gorm:"type:varchar(20)" json:"halfDayType,omitempty"`
 	Remarks     string      `gorm:"type:text" json:"remarks,omitempty"`
 
@@ -159,10 +165,11 @@ type StaffAttendanceSettings struct {
 	TenantID uuid.UUID `gorm:"type:uuid;not null;index" json:"tenantId"`
 	BranchID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uniq_attendance_settings" json:"branchId"`
 
-	WorkStartTime         time.Time `gorm:"type:time;not null;default:'09:00'" json:"workStartTime"`
-	WorkEndTime           time.Time `gorm:"type:time;not null;default:'17:00'" json:"workEndTime"`
-	LateThresholdMinutes  int       `gorm:"not null;default:15" json:"lateThresholdMinutes"`
-	HalfDayThresholdHours float64   `gorm:"type:decimal(4,2);not null;default:4.0" json:"halfDayThresholdHours"`
+	WorkStartTime             time.Time `gorm:"type:time;not null;default:'09:00'" json:"workStartTime"`
+	WorkEndTime               time.Time `gorm:"type:time;not null;default:'17:00'" json:"workEndTime"`
+	LateThresholdMinutes      int       `gorm:"not null;default:15" json:"lateThresholdMinutes"`
+	EarlyExitThresholdMinutes int       `gorm:"not null;default:0" json:"earlyExitThresholdMinutes"`
+	HalfDayThresholdHours     float64   `gorm:"type:decimal(4,2);not null;default:4.0" json:"halfDayThresholdHours"`
 
 	AllowSelfCheckout             bool `gorm:"not null;default:true" json:"allowSelfCheckout"`
 	RequireRegularizationApproval bool `gorm:"not null;default:true" json:"requireRegularizationApproval"`
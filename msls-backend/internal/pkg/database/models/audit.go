@@ -41,6 +41,10 @@ const (
 	AuditActionTokenRefresh              AuditAction = "token_refresh"
 	AuditActionTokenRefreshFailed        AuditAction = "token_refresh_failed"
 	AuditActionTokenRevoked              AuditAction = "token_revoked"
+	AuditActionSessionRevoked            AuditAction = "session_revoked"
+	AuditActionSessionsRevokedAll        AuditAction = "sessions_revoked_all"
+	AuditActionSSOLogin                  AuditAction = "sso_login"
+	AuditActionSSOIdentityLinked         AuditAction = "sso_identity_linked"
 )
 
 // String returns the string representation of the audit action.
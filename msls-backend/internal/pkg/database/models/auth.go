@@ -15,6 +15,7 @@ type RefreshToken struct {
 	TokenHash string     `gorm:"type:varchar(255);not null;uniqueIndex" json:"-"`
 	ExpiresAt time.Time  `gorm:"type:timestamptz;not null;index" json:"expires_at"`
 	RevokedAt *time.Time `gorm:"type:timestamptz" json:"revoked_at,omitempty"`
+	SessionID *uuid.UUID `gorm:"type:uuid;index" json:"session_id,omitempty"`
 
 	// Relationships
 	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
@@ -46,6 +47,65 @@ func (r *RefreshToken) Revoke() {
 	r.RevokedAt = &now
 }
 
+// SessionRevokeReason records why a session was revoked.
+type SessionRevokeReason string
+
+const (
+	// SessionRevokeReasonLogout is used when the user logs out.
+	SessionRevokeReasonLogout SessionRevokeReason = "logout"
+	// SessionRevokeReasonUser is used when the user revokes the session.
+	SessionRevokeReasonUser SessionRevokeReason = "user_revoked"
+	// SessionRevokeReasonAdmin is used when an administrator forces logout.
+	SessionRevokeReasonAdmin SessionRevokeReason = "admin_revoked"
+	// SessionRevokeReasonPasswordReset is used when the password is reset.
+	SessionRevokeReasonPasswordReset SessionRevokeReason = "password_reset"
+	// SessionRevokeReasonAccountLocked is used when the account is locked.
+	SessionRevokeReasonAccountLocked SessionRevokeReason = "account_locked"
+)
+
+// IsValid checks if the revoke reason is valid.
+func (r SessionRevokeReason) IsValid() bool {
+	switch r {
+	case SessionRevokeReasonLogout, SessionRevokeReasonUser, SessionRevokeReasonAdmin,
+		SessionRevokeReasonPasswordReset, SessionRevokeReasonAccountLocked:
+		return true
+	}
+	return false
+}
+
+// UserSession represents a login session on one device.
+// Refresh tokens rotate within a session; revoking the session revokes them all.
+type UserSession struct {
+	BaseModel
+	TenantID      uuid.UUID            `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	UserID        uuid.UUID            `gorm:"type:uuid;not null;index" json:"user_id"`
+	Device        string               `gorm:"type:varchar(100)" json:"device"`
+	UserAgent     string               `gorm:"type:text" json:"user_agent"`
+	IPAddress     net.IP               `gorm:"type:inet" json:"ip_address"`
+	Location      string               `gorm:"type:varchar(200)" json:"location"`
+	LastUsedAt    time.Time            `gorm:"type:timestamptz;not null" json:"last_used_at"`
+	ExpiresAt     time.Time            `gorm:"type:timestamptz;not null" json:"expires_at"`
+	RevokedAt     *time.Time           `gorm:"type:timestamptz" json:"revoked_at,omitempty"`
+	RevokedReason *SessionRevokeReason `gorm:"type:varchar(50)" json:"revoked_reason,omitempty"`
+}
+
+// TableName returns the table name for the UserSession model.
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// IsActive returns true if the session is neither revoked nor expired.
+func (s *UserSession) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// Revoke marks the session as revoked.
+func (s *UserSession) Revoke(reason SessionRevokeReason) {
+	now := time.Now()
+	s.RevokedAt = &now
+	s.RevokedReason = &reason
+}
+
 // VerificationTokenType represents the type of verification token.
 type VerificationTokenType string
 
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BehaviorPointRule sets the points an incident of a given type and severity
+// is worth. Positive recognition earns merits (positive points); infractions
+// and violations carry demerits (negative points).
+type BehaviorPointRule struct {
+	ID           uuid.UUID              `gorm:"type:uuid;primaryKey"`
+	TenantID     uuid.UUID              `gorm:"type:uuid;not null;index"`
+	IncidentType BehavioralIncidentType `gorm:"type:varchar(30);not null"`
+	Severity     BehavioralSeverity     `gorm:"type:varchar(20);not null"`
+	Points       int                    `gorm:"not null"`
+	CreatedAt    time.Time              `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt    time.Time              `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+func (BehaviorPointRule) TableName() string {
+	return "behavior_point_rules"
+}
+
+// BehaviorThreshold escalates a student once their demerits in a term reach
+// DemeritPoints.
+type BehaviorThreshold struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID          uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name              string    `gorm:"type:varchar(100);not null"`
+	DemeritPoints     int       `gorm:"not null"`
+	CreateFollowUp    bool      `gorm:"not null;default:true"`
+	FollowUpAfterDays int       `gorm:"not null;default:2"`
+	NotifyGuardian    bool      `gorm:"not null;default:true"`
+	AlertClassTeacher bool      `gorm:"not null;default:true"`
+	IsActive          bool      `gorm:"not null;default:true"`
+	CreatedAt         time.Time `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt         time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+func (BehaviorThreshold) TableName() string {
+	return "behavior_thresholds"
+}
+
+// BehaviorThresholdTrigger records a threshold reached by a student in a
+// term, so that each threshold escalates at most once per term.
+type BehaviorThresholdTrigger struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID            uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ThresholdID         uuid.UUID  `gorm:"type:uuid;not null"`
+	StudentID           uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TermID              uuid.UUID  `gorm:"type:uuid;not null"`
+	IncidentID          uuid.UUID  `gorm:"type:uuid;not null"`
+	DemeritTotal        int        `gorm:"not null"`
+	FollowUpID          *uuid.UUID `gorm:"type:uuid"`
+	GuardianNotified    bool       `gorm:"not null;default:false"`
+	ClassTeacherAlerted bool       `gorm:"not null;default:false"`
+	TriggeredAt         time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Threshold *BehaviorThreshold `gorm:"foreignKey:ThresholdID"`
+}
+
+func (BehaviorThresholdTrigger) TableName() string {
+	return "behavior_threshold_triggers"
+}
+
+// House is a house or team that students earn points for.
+type House struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Color     *string   `gorm:"type:varchar(20)"`
+	IsActive  bool      `gorm:"not null;default:true"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+func (House) TableName() string {
+	return "houses"
+}
+
+// HouseMember assigns a student to a house. A student belongs to one house.
+type HouseMember struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	HouseID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	StudentID uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Student *Student `gorm:"foreignKey:StudentID"`
+}
+
+func (HouseMember) TableName() string {
+	return "house_members"
+}
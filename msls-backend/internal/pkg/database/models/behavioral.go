@@ -50,6 +50,7 @@ type StudentBehavioralIncident struct {
 	StudentResponse       *string          `gorm:"type:text"`
 	ActionTaken           string           `gorm:"type:text;not null"`
 	ParentMeetingRequired bool             `gorm:"not null;default:false"`
+	Points                int              `gorm:"not null;default:0"`
 	ParentNotified        bool             `gorm:"not null;default:false"`
 	ParentNotifiedAt      *time.Time       `gorm:"type:timestamptz"`
 	ReportedBy            uuid.UUID        `gorm:"type:uuid;not null"`
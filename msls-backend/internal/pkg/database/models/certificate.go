@@ -0,0 +1,166 @@
+// Package models provides GORM model definitions for the MSLS database.
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CertificateType represents the kind of student certificate.
+type CertificateType string
+
+// CertificateType constants.
+const (
+	CertificateTypeTransfer  CertificateType = "transfer"
+	CertificateTypeBonafide  CertificateType = "bonafide"
+	CertificateTypeCharacter CertificateType = "character"
+)
+
+// IsValid checks if the certificate type is a valid value.
+func (t CertificateType) IsValid() bool {
+	switch t {
+	case CertificateTypeTransfer, CertificateTypeBonafide, CertificateTypeCharacter:
+		return true
+	}
+	return false
+}
+
+// SerialPrefix returns the prefix used in the certificate's serial number.
+func (t CertificateType) SerialPrefix() string {
+	switch t {
+	case CertificateTypeTransfer:
+		return "TC"
+	case CertificateTypeBonafide:
+		return "BC"
+	case CertificateTypeCharacter:
+		return "CC"
+	}
+	return "CERT"
+}
+
+// CertificateStatus represents where a certificate is in the approval workflow.
+type CertificateStatus string
+
+// CertificateStatus constants.
+const (
+	CertificateStatusPending   CertificateStatus = "pending"
+	CertificateStatusIssued    CertificateStatus = "issued"
+	CertificateStatusRejected  CertificateStatus = "rejected"
+	CertificateStatusCancelled CertificateStatus = "cancelled"
+)
+
+// IsValid checks if the certificate status is a valid value.
+func (s CertificateStatus) IsValid() bool {
+	switch s {
+	case CertificateStatusPending, CertificateStatusIssued, CertificateStatusRejected, CertificateStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CertificateFields holds the values printed on a certificate, keyed by
+// template placeholder name.
+type CertificateFields map[string]string
+
+// Value implements the driver.Valuer interface for database serialization.
+func (f CertificateFields) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface for database deserialization.
+func (f *CertificateFields) Scan(value interface{}) error {
+	if value == nil {
+		*f = CertificateFields{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+// CertificateTemplate is the letterhead and wording used to print a certificate type.
+type CertificateTemplate struct {
+	ID                   uuid.UUID       `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID             uuid.UUID       `gorm:"type:uuid;not null;index" json:"tenantId"`
+	CertificateType      CertificateType `gorm:"type:varchar(20);not null" json:"certificateType"`
+	Name                 string          `gorm:"type:varchar(200);not null" json:"name"`
+	Title                string          `gorm:"type:varchar(200);not null" json:"title"`
+	Body                 string          `gorm:"type:text;not null" json:"body"`
+	SchoolName           string          `gorm:"type:varchar(200)" json:"schoolName,omitempty"`
+	SchoolAddress        string          `gorm:"type:varchar(500)" json:"schoolAddress,omitempty"`
+	Affiliation          string          `gorm:"type:varchar(200)" json:"affiliation,omitempty"`
+	SignatoryName        string          `gorm:"type:varchar(200)" json:"signatoryName,omitempty"`
+	SignatoryDesignation string          `gorm:"type:varchar(100)" json:"signatoryDesignation,omitempty"`
+	FooterText           string          `gorm:"type:text" json:"footerText,omitempty"`
+	IsDefault            bool            `gorm:"not null;default:false" json:"isDefault"`
+	CreatedAt            time.Time       `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt            time.Time       `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy            *uuid.UUID      `gorm:"type:uuid" json:"createdBy,omitempty"`
+	UpdatedBy            *uuid.UUID      `gorm:"type:uuid" json:"updatedBy,omitempty"`
+}
+
+// TableName returns the table name for the CertificateTemplate model.
+func (CertificateTemplate) TableName() string {
+	return "certificate_templates"
+}
+
+// Certificate is an entry in the register of requested and issued certificates.
+// Fields are captured when the certificate is requested and frozen on approval.
+type Certificate struct {
+	ID               uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID         uuid.UUID         `gorm:"type:uuid;not null;index" json:"tenantId"`
+	StudentID        uuid.UUID         `gorm:"type:uuid;not null;index" json:"studentId"`
+	TemplateID       *uuid.UUID        `gorm:"type:uuid" json:"templateId,omitempty"`
+	CertificateType  CertificateType   `gorm:"type:varchar(20);not null" json:"certificateType"`
+	SerialNumber     *string           `gorm:"type:varchar(30)" json:"serialNumber,omitempty"`
+	Status           CertificateStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Purpose          string            `gorm:"type:varchar(500)" json:"purpose,omitempty"`
+	Fields           CertificateFields `gorm:"type:jsonb;not null;default:'{}'" json:"fields"`
+	VerificationCode *string           `gorm:"type:varchar(32)" json:"-"`
+	RequestedBy      *uuid.UUID        `gorm:"type:uuid" json:"requestedBy,omitempty"`
+	RequestedAt      time.Time         `gorm:"not null;default:now()" json:"requestedAt"`
+	ApprovedBy       *uuid.UUID        `gorm:"type:uuid" json:"approvedBy,omitempty"`
+	IssuedAt         *time.Time        `json:"issuedAt,omitempty"`
+	RejectedBy       *uuid.UUID        `gorm:"type:uuid" json:"rejectedBy,omitempty"`
+	RejectedAt       *time.Time        `json:"rejectedAt,omitempty"`
+	RejectionReason  string            `gorm:"type:text" json:"rejectionReason,omitempty"`
+	CancelledBy      *uuid.UUID        `gorm:"type:uuid" json:"cancelledBy,omitempty"`
+	CancelledAt      *time.Time        `json:"cancelledAt,omitempty"`
+	CancelReason     string            `gorm:"type:text" json:"cancelReason,omitempty"`
+	CreatedAt        time.Time         `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt        time.Time         `gorm:"not null;default:now()" json:"updatedAt"`
+
+	// Relationships
+	Student  *Student             `gorm:"foreignKey:StudentID" json:"-"`
+	Template *CertificateTemplate `gorm:"foreignKey:TemplateID" json:"-"`
+}
+
+// TableName returns the table name for the Certificate model.
+func (Certificate) TableName() string {
+	return "certificates"
+}
+
+// CertificateSequence tracks the last serial number issued per certificate type and year.
+type CertificateSequence struct {
+	ID              uuid.UUID       `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID        uuid.UUID       `gorm:"type:uuid;not null" json:"tenantId"`
+	CertificateType CertificateType `gorm:"type:varchar(20);not null" json:"certificateType"`
+	Year            int             `gorm:"not null" json:"year"`
+	LastSequence    int             `gorm:"not null;default:0" json:"lastSequence"`
+	CreatedAt       time.Time       `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt       time.Time       `gorm:"not null;default:now()" json:"updatedAt"`
+}
+
+// TableName returns the table name for the CertificateSequence model.
+func (CertificateSequence) TableName() string {
+	return "certificate_sequences"
+}
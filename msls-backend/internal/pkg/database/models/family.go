@@ -0,0 +1,80 @@
+// Package models provides GORM model definitions for the MSLS database.
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Family groups siblings and the guardians they share into one household.
+type Family struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenantId"`
+	Name      string     `gorm:"type:varchar(200);not null" json:"name"`
+	Notes     string     `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt time.Time  `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt time.Time  `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"createdBy,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updatedBy,omitempty"`
+
+	// Relationships
+	Students  []Student        `gorm:"foreignKey:FamilyID" json:"students,omitempty"`
+	Guardians []FamilyGuardian `gorm:"foreignKey:FamilyID" json:"guardians,omitempty"`
+}
+
+// TableName returns the table name for the Family model.
+func (Family) TableName() string {
+	return "families"
+}
+
+// FamilyGuardian is one guardian of a family, shared by the student guardian
+// rows of every sibling. Phone and email are stored normalized for matching.
+type FamilyGuardian struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenantId"`
+	FamilyID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"familyId"`
+	FirstName string     `gorm:"type:varchar(100);not null" json:"firstName"`
+	LastName  string     `gorm:"type:varchar(100);not null" json:"lastName"`
+	Phone     string     `gorm:"type:varchar(15)" json:"phone,omitempty"`
+	Email     string     `gorm:"type:varchar(255)" json:"email,omitempty"`
+	StaffID   *uuid.UUID `gorm:"type:uuid;index" json:"staffId,omitempty"`
+	UserID    *uuid.UUID `gorm:"type:uuid" json:"userId,omitempty"`
+	CreatedAt time.Time  `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt time.Time  `gorm:"not null;default:now()" json:"updatedAt"`
+
+	// Relationships
+	Staff *Staff `gorm:"foreignKey:StaffID" json:"-"`
+}
+
+// TableName returns the table name for the FamilyGuardian model.
+func (FamilyGuardian) TableName() string {
+	return "family_guardians"
+}
+
+// FullName returns the full name of the family guardian.
+func (g *FamilyGuardian) FullName() string {
+	return strings.TrimSpace(g.FirstName + " " + g.LastName)
+}
+
+// NormalizeContactPhone reduces a phone number to its last ten digits so that
+// "+91 98765-43210" and "09876543210" match. Numbers with fewer digits are kept whole.
+func NormalizeContactPhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	d := digits.String()
+	if len(d) > 10 {
+		return d[len(d)-10:]
+	}
+	return d
+}
+
+// NormalizeContactEmail trims and lower-cases an email address for matching.
+func NormalizeContactEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
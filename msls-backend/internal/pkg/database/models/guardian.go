@@ -74,6 +74,7 @@ type StudentGuardian struct {
 	IsPrimary       bool             `gorm:"not null;default:false" json:"isPrimary"`
 	HasPortalAccess bool             `gorm:"not null;default:false" json:"hasPortalAccess"`
 	UserID          *uuid.UUID       `gorm:"type:uuid" json:"userId,omitempty"`
+	FamilyGuardianID *uuid.UUID      `gorm:"type:uuid;index" json:"familyGuardianId,omitempty"`
 	AddressLine1    string           `gorm:"type:varchar(255)" json:"addressLine1,omitempty"`
 	AddressLine2    string           `gorm:"type:varchar(255)" json:"addressLine2,omitempty"`
 	City            string           `gorm:"type:varchar(100)" json:"city,omitempty"`
@@ -0,0 +1,89 @@
+// Package models provides GORM model definitions for the MSLS database.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImmunizationScheduleItem is one dose of a vaccine on a tenant's immunization
+// schedule, due between a minimum and maximum age.
+type ImmunizationScheduleItem struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenantId"`
+	VaccineName  string     `gorm:"type:varchar(100);not null" json:"vaccineName"`
+	DoseNumber   int        `gorm:"not null;default:1" json:"doseNumber"`
+	MinAgeMonths int        `gorm:"not null;default:0" json:"minAgeMonths"`
+	MaxAgeMonths *int       `json:"maxAgeMonths,omitempty"`
+	IsMandatory  bool       `gorm:"not null;default:true" json:"isMandatory"`
+	Description  string     `gorm:"type:text" json:"description,omitempty"`
+	IsActive     bool       `gorm:"not null;default:true" json:"isActive"`
+	CreatedAt    time.Time  `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt    time.Time  `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy    *uuid.UUID `gorm:"type:uuid" json:"createdBy,omitempty"`
+	UpdatedBy    *uuid.UUID `gorm:"type:uuid" json:"updatedBy,omitempty"`
+}
+
+// TableName returns the table name for the ImmunizationScheduleItem model.
+func (ImmunizationScheduleItem) TableName() string {
+	return "immunization_schedule_items"
+}
+
+// ImmunizationReminderCampaign is a batch of reminders sent to the guardians
+// of students missing vaccinations.
+type ImmunizationReminderCampaign struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenantId"`
+	Name           string     `gorm:"type:varchar(200);not null" json:"name"`
+	BranchID       *uuid.UUID `gorm:"type:uuid" json:"branchId,omitempty"`
+	ClassID        *uuid.UUID `gorm:"type:uuid" json:"classId,omitempty"`
+	SectionID      *uuid.UUID `gorm:"type:uuid" json:"sectionId,omitempty"`
+	IncludeDue     bool       `gorm:"not null;default:false" json:"includeDue"`
+	Message        string     `gorm:"type:text;not null" json:"message"`
+	RecipientCount int        `gorm:"not null;default:0" json:"recipientCount"`
+	SentCount      int        `gorm:"not null;default:0" json:"sentCount"`
+	FailedCount    int        `gorm:"not null;default:0" json:"failedCount"`
+	SkippedCount   int        `gorm:"not null;default:0" json:"skippedCount"`
+	SentAt         time.Time  `gorm:"type:timestamptz;not null;default:now()" json:"sentAt"`
+	CreatedAt      time.Time  `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt      time.Time  `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy      *uuid.UUID `gorm:"type:uuid" json:"createdBy,omitempty"`
+
+	// Relationships
+	Reminders []ImmunizationReminder `gorm:"foreignKey:CampaignID" json:"reminders,omitempty"`
+}
+
+// TableName returns the table name for the ImmunizationReminderCampaign model.
+func (ImmunizationReminderCampaign) TableName() string {
+	return "immunization_reminder_campaigns"
+}
+
+// ImmunizationReminderStatus represents the delivery outcome of a reminder.
+type ImmunizationReminderStatus string
+
+// ImmunizationReminderStatus constants.
+const (
+	ImmunizationReminderSent    ImmunizationReminderStatus = "sent"
+	ImmunizationReminderFailed  ImmunizationReminderStatus = "failed"
+	ImmunizationReminderSkipped ImmunizationReminderStatus = "skipped"
+)
+
+// ImmunizationReminder is a reminder sent to one student's guardian.
+type ImmunizationReminder struct {
+	ID              uuid.UUID                  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID        uuid.UUID                  `gorm:"type:uuid;not null;index" json:"tenantId"`
+	CampaignID      uuid.UUID                  `gorm:"type:uuid;not null;index" json:"campaignId"`
+	StudentID       uuid.UUID                  `gorm:"type:uuid;not null;index" json:"studentId"`
+	GuardianID      *uuid.UUID                 `gorm:"type:uuid" json:"guardianId,omitempty"`
+	Phone           string                     `gorm:"type:varchar(15)" json:"phone,omitempty"`
+	PendingVaccines string                     `gorm:"type:text;not null" json:"pendingVaccines"`
+	Status          ImmunizationReminderStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Error           string                     `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt       time.Time                  `gorm:"not null;default:now()" json:"createdAt"`
+}
+
+// TableName returns the table name for the ImmunizationReminder model.
+func (ImmunizationReminder) TableName() string {
+	return "immunization_reminders"
+}
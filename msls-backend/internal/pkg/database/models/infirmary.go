@@ -0,0 +1,128 @@
+// Package models provides GORM model definitions for the MSLS database.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// InfirmaryDisposition represents where a student went after an infirmary visit.
+type InfirmaryDisposition string
+
+// InfirmaryDisposition constants.
+const (
+	InfirmaryDispositionUnderObservation InfirmaryDisposition = "under_observation"
+	InfirmaryDispositionReturnedToClass  InfirmaryDisposition = "returned_to_class"
+	InfirmaryDispositionSentHome         InfirmaryDisposition = "sent_home"
+	InfirmaryDispositionReferred         InfirmaryDisposition = "referred_to_hospital"
+)
+
+// IsValid checks if the disposition is a valid value.
+func (d InfirmaryDisposition) IsValid() bool {
+	switch d {
+	case InfirmaryDispositionUnderObservation, InfirmaryDispositionReturnedToClass,
+		InfirmaryDispositionSentHome, InfirmaryDispositionReferred:
+		return true
+	}
+	return false
+}
+
+// LeavesSchool reports whether the student left the school premises.
+func (d InfirmaryDisposition) LeavesSchool() bool {
+	return d == InfirmaryDispositionSentHome || d == InfirmaryDispositionReferred
+}
+
+// Label returns a human-readable label for the disposition.
+func (d InfirmaryDisposition) Label() string {
+	switch d {
+	case InfirmaryDispositionUnderObservation:
+		return "under observation in the infirmary"
+	case InfirmaryDispositionReturnedToClass:
+		return "returned to class"
+	case InfirmaryDispositionSentHome:
+		return "sent home"
+	case InfirmaryDispositionReferred:
+		return "referred to hospital"
+	}
+	return string(d)
+}
+
+// InfirmaryVisit is a student's visit to the school clinic.
+type InfirmaryVisit struct {
+	ID                 uuid.UUID            `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID           uuid.UUID            `gorm:"type:uuid;not null;index" json:"tenantId"`
+	StudentID          uuid.UUID            `gorm:"type:uuid;not null;index" json:"studentId"`
+	CheckInAt          time.Time            `gorm:"type:timestamptz;not null" json:"checkInAt"`
+	CheckOutAt         *time.Time           `gorm:"type:timestamptz" json:"checkOutAt,omitempty"`
+	Complaint          string               `gorm:"type:varchar(500);not null" json:"complaint"`
+	Symptoms           string               `gorm:"type:text" json:"symptoms,omitempty"`
+	Temperature        *decimal.Decimal     `gorm:"type:decimal(4,1)" json:"temperature,omitempty"`
+	PulseRate          *int                 `json:"pulseRate,omitempty"`
+	RespiratoryRate    *int                 `json:"respiratoryRate,omitempty"`
+	BloodPressureSys   *int                 `json:"bloodPressureSys,omitempty"`
+	BloodPressureDia   *int                 `json:"bloodPressureDia,omitempty"`
+	OxygenSaturation   *int                 `json:"oxygenSaturation,omitempty"`
+	Treatment          string               `gorm:"type:text" json:"treatment,omitempty"`
+	Disposition        InfirmaryDisposition `gorm:"type:varchar(30);not null;default:'under_observation'" json:"disposition"`
+	ReleasedTo         string               `gorm:"type:varchar(200)" json:"releasedTo,omitempty"`
+	Notes              string               `gorm:"type:text" json:"notes,omitempty"`
+	GuardianNotifiedAt *time.Time           `gorm:"type:timestamptz" json:"guardianNotifiedAt,omitempty"`
+	AttendanceMarkedAt *time.Time           `gorm:"type:timestamptz" json:"attendanceMarkedAt,omitempty"`
+	AttendedBy         *uuid.UUID           `gorm:"type:uuid" json:"attendedBy,omitempty"`
+	CreatedAt          time.Time            `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt          time.Time            `gorm:"not null;default:now()" json:"updatedAt"`
+	UpdatedBy          *uuid.UUID           `gorm:"type:uuid" json:"updatedBy,omitempty"`
+
+	// Relationships
+	Student *Student `gorm:"foreignKey:StudentID" json:"-"`
+}
+
+// TableName returns the table name for the InfirmaryVisit model.
+func (InfirmaryVisit) TableName() string {
+	return "infirmary_visits"
+}
+
+// MedicationAdministrationStatus represents the outcome of a scheduled dose.
+type MedicationAdministrationStatus string
+
+// MedicationAdministrationStatus constants.
+const (
+	MedicationAdministrationGiven   MedicationAdministrationStatus = "given"
+	MedicationAdministrationMissed  MedicationAdministrationStatus = "missed"
+	MedicationAdministrationRefused MedicationAdministrationStatus = "refused"
+)
+
+// IsValid checks if the administration status is a valid value.
+func (s MedicationAdministrationStatus) IsValid() bool {
+	switch s {
+	case MedicationAdministrationGiven, MedicationAdministrationMissed, MedicationAdministrationRefused:
+		return true
+	}
+	return false
+}
+
+// MedicationAdministration records a dose of a student's medication given,
+// missed or refused at school.
+type MedicationAdministration struct {
+	ID                 uuid.UUID                      `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID           uuid.UUID                      `gorm:"type:uuid;not null;index" json:"tenantId"`
+	StudentID          uuid.UUID                      `gorm:"type:uuid;not null;index" json:"studentId"`
+	MedicationID       uuid.UUID                      `gorm:"type:uuid;not null;index" json:"medicationId"`
+	AdministrationDate time.Time                      `gorm:"type:date;not null" json:"administrationDate"`
+	ScheduledTime      string                         `gorm:"type:varchar(5);not null;default:''" json:"scheduledTime"`
+	Status             MedicationAdministrationStatus `gorm:"type:varchar(20);not null" json:"status"`
+	DoseGiven          string                         `gorm:"type:varchar(50)" json:"doseGiven,omitempty"`
+	AdministeredAt     *time.Time                     `gorm:"type:timestamptz" json:"administeredAt,omitempty"`
+	RecordedBy         *uuid.UUID                     `gorm:"type:uuid" json:"recordedBy,omitempty"`
+	VisitID            *uuid.UUID                     `gorm:"type:uuid" json:"visitId,omitempty"`
+	Notes              string                         `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt          time.Time                      `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt          time.Time                      `gorm:"not null;default:now()" json:"updatedAt"`
+}
+
+// TableName returns the table name for the MedicationAdministration model.
+func (MedicationAdministration) TableName() string {
+	return "medication_administrations"
+}
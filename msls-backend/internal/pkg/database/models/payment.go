@@ -0,0 +1,140 @@
+// Package models provides GORM model definitions for the MSLS database.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PaymentPurpose represents what a payment is collected for.
+type PaymentPurpose string
+
+// PaymentPurpose constants.
+const (
+	PaymentPurposeApplicationFee PaymentPurpose = "application_fee"
+	PaymentPurposeFeeInvoice     PaymentPurpose = "fee_invoice"
+)
+
+// IsValid checks if the payment purpose is valid.
+func (p PaymentPurpose) IsValid() bool {
+	switch p {
+	case PaymentPurposeApplicationFee, PaymentPurposeFeeInvoice:
+		return true
+	}
+	return false
+}
+
+// PaymentStatus represents the state of a payment in the ledger.
+type PaymentStatus string
+
+// PaymentStatus constants.
+const (
+	PaymentStatusCreated           PaymentStatus = "created"
+	PaymentStatusCaptured          PaymentStatus = "captured"
+	PaymentStatusFailed            PaymentStatus = "failed"
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+)
+
+// IsValid checks if the payment status is valid.
+func (s PaymentStatus) IsValid() bool {
+	switch s {
+	case PaymentStatusCreated, PaymentStatusCaptured, PaymentStatusFailed,
+		PaymentStatusPartiallyRefunded, PaymentStatusRefunded:
+		return true
+	}
+	return false
+}
+
+// IsPaid returns true if the payer's money was received, even if some of it was refunded since.
+func (s PaymentStatus) IsPaid() bool {
+	return s == PaymentStatusCaptured || s == PaymentStatusPartiallyRefunded || s == PaymentStatusRefunded
+}
+
+// ReconciliationStatus represents the outcome of comparing a payment with the gateway.
+type ReconciliationStatus string
+
+// ReconciliationStatus constants.
+const (
+	ReconciliationPending  ReconciliationStatus = "pending"
+	ReconciliationMatched  ReconciliationStatus = "matched"
+	ReconciliationMismatch ReconciliationStatus = "mismatch"
+)
+
+// Payment is a ledger entry for money collected through a payment gateway.
+// It is linked to the admission application or fee invoice it pays for.
+type Payment struct {
+	TenantModel
+	Purpose           PaymentPurpose  `gorm:"type:varchar(30);not null" json:"purpose"`
+	ApplicationID     *uuid.UUID      `gorm:"type:uuid" json:"application_id,omitempty"`
+	InvoiceID         *uuid.UUID      `gorm:"type:uuid" json:"invoice_id,omitempty"`
+	Provider          string          `gorm:"type:varchar(30);not null" json:"provider"`
+	ProviderOrderID   string          `gorm:"type:varchar(100);not null" json:"provider_order_id"`
+	ProviderPaymentID *string         `gorm:"type:varchar(100)" json:"provider_payment_id,omitempty"`
+	Amount            decimal.Decimal `gorm:"type:decimal(12,2);not null" json:"amount"`
+	Currency          string          `gorm:"type:varchar(3);not null;default:'INR'" json:"currency"`
+	Status            PaymentStatus   `gorm:"type:varchar(20);not null;default:'created'" json:"status"`
+	Method            string          `gorm:"type:varchar(30)" json:"method,omitempty"`
+	CapturedAmount    decimal.Decimal `gorm:"type:decimal(12,2);not null;default:0" json:"captured_amount"`
+	RefundedAmount    decimal.Decimal `gorm:"type:decimal(12,2);not null;default:0" json:"refunded_amount"`
+	FailureReason     string          `gorm:"type:text" json:"failure_reason,omitempty"`
+	PaidAt            *time.Time      `gorm:"type:timestamptz" json:"paid_at,omitempty"`
+	ConfirmedAt       *time.Time      `gorm:"type:timestamptz" json:"confirmed_at,omitempty"`
+	ConfirmedBy       *uuid.UUID      `gorm:"type:uuid" json:"confirmed_by,omitempty"`
+
+	// Reconciliation with the gateway
+	ReconciliationStatus ReconciliationStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"reconciliation_status"`
+	ReconciliationNote   string               `gorm:"type:text" json:"reconciliation_note,omitempty"`
+	ReconciledAt         *time.Time           `gorm:"type:timestamptz" json:"reconciled_at,omitempty"`
+
+	// Relations
+	Application *AdmissionApplication `gorm:"foreignKey:ApplicationID" json:"application,omitempty"`
+	Refunds     []PaymentRefund       `gorm:"foreignKey:PaymentID" json:"refunds,omitempty"`
+}
+
+// TableName returns the table name for the Payment model.
+func (Payment) TableName() string {
+	return "payments"
+}
+
+// RefundableAmount returns the captured amount not yet refunded.
+func (p *Payment) RefundableAmount() decimal.Decimal {
+	return p.CapturedAmount.Sub(p.RefundedAmount)
+}
+
+// PaymentRefund is a refund of a captured payment.
+type PaymentRefund struct {
+	TenantModel
+	PaymentID        uuid.UUID       `gorm:"type:uuid;not null;index" json:"payment_id"`
+	ProviderRefundID string          `gorm:"type:varchar(100);not null" json:"provider_refund_id"`
+	Amount           decimal.Decimal `gorm:"type:decimal(12,2);not null" json:"amount"`
+	Status           string          `gorm:"type:varchar(20);not null" json:"status"`
+	Reason           string          `gorm:"type:text" json:"reason,omitempty"`
+	ProcessedAt      *time.Time      `gorm:"type:timestamptz" json:"processed_at,omitempty"`
+}
+
+// TableName returns the table name for the PaymentRefund model.
+func (PaymentRefund) TableName() string {
+	return "payment_refunds"
+}
+
+// PaymentWebhookEvent records a gateway webhook so redeliveries are applied only once.
+type PaymentWebhookEvent struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	Provider    string     `gorm:"type:varchar(30);not null" json:"provider"`
+	EventID     string     `gorm:"type:varchar(100);not null" json:"event_id"`
+	EventType   string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	TenantID    *uuid.UUID `gorm:"type:uuid" json:"tenant_id,omitempty"`
+	PaymentID   *uuid.UUID `gorm:"type:uuid" json:"payment_id,omitempty"`
+	Payload     string     `gorm:"type:text;not null" json:"-"`
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+	ReceivedAt  time.Time  `gorm:"not null;default:now()" json:"received_at"`
+	ProcessedAt *time.Time `gorm:"type:timestamptz" json:"processed_at,omitempty"`
+}
+
+// TableName returns the table name for the PaymentWebhookEvent model.
+func (PaymentWebhookEvent) TableName() string {
+	return "payment_webhook_events"
+}
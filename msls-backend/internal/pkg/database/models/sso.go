@@ -0,0 +1,108 @@
+// Package models provides GORM model definitions for the MSLS database.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SSOProtocol represents the protocol spoken by an identity provider.
+type SSOProtocol string
+
+// SSOProtocol constants.
+const (
+	SSOProtocolOIDC SSOProtocol = "oidc"
+	SSOProtocolSAML SSOProtocol = "saml"
+)
+
+// IsValid checks if the protocol is valid.
+func (p SSOProtocol) IsValid() bool {
+	switch p {
+	case SSOProtocolOIDC, SSOProtocolSAML:
+		return true
+	}
+	return false
+}
+
+// SSORoleMapping maps an identity provider group to an RBAC role.
+type SSORoleMapping struct {
+	Group  string    `json:"group"`
+	RoleID uuid.UUID `json:"role_id"`
+}
+
+// IdentityProvider is a tenant's OpenID Connect or SAML 2.0 identity provider.
+type IdentityProvider struct {
+	TenantModel
+	Name     string      `gorm:"type:varchar(100);not null" json:"name"`
+	Protocol SSOProtocol `gorm:"type:varchar(10);not null" json:"protocol"`
+	Enabled  bool        `gorm:"not null;default:true" json:"enabled"`
+
+	// OpenID Connect settings
+	IssuerURL             string         `gorm:"type:varchar(500)" json:"issuer_url,omitempty"`
+	ClientID              string         `gorm:"type:varchar(255)" json:"client_id,omitempty"`
+	ClientSecretEncrypted string         `gorm:"type:text" json:"-"`
+	Scopes                pq.StringArray `gorm:"type:text[]" json:"scopes,omitempty"`
+
+	// SAML 2.0 settings; MetadataXML takes precedence over MetadataURL
+	MetadataURL string `gorm:"type:varchar(500)" json:"metadata_url,omitempty"`
+	MetadataXML string `gorm:"type:text" json:"metadata_xml,omitempty"`
+
+	// Account linking and provisioning
+	AllowedDomains       pq.StringArray   `gorm:"type:text[]" json:"allowed_domains"`
+	RequireVerifiedEmail bool             `gorm:"not null;default:true" json:"require_verified_email"`
+	AutoCreateUsers      bool             `gorm:"not null;default:false" json:"auto_create_users"`
+	GroupsClaim          string           `gorm:"type:varchar(255)" json:"groups_claim,omitempty"`
+	RoleMappings         []SSORoleMapping `gorm:"type:jsonb;serializer:json;not null;default:'[]'" json:"role_mappings"`
+	DefaultRoleID        *uuid.UUID       `gorm:"type:uuid" json:"default_role_id,omitempty"`
+}
+
+// TableName returns the table name for the IdentityProvider model.
+func (IdentityProvider) TableName() string {
+	return "identity_providers"
+}
+
+// UserIdentity links a user to their subject at an identity provider.
+type UserIdentity struct {
+	BaseModel
+	TenantID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ProviderID  uuid.UUID  `gorm:"type:uuid;not null" json:"provider_id"`
+	Subject     string     `gorm:"type:varchar(255);not null" json:"subject"`
+	Email       string     `gorm:"type:varchar(255)" json:"email"`
+	LastLoginAt *time.Time `gorm:"type:timestamptz" json:"last_login_at,omitempty"`
+}
+
+// TableName returns the table name for the UserIdentity model.
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// SSOLoginRequest tracks one browser login through an identity provider,
+// from the redirect to the IdP until the frontend exchanges its one-time code.
+type SSOLoginRequest struct {
+	BaseModel
+	TenantID      uuid.UUID  `gorm:"type:uuid;not null" json:"tenant_id"`
+	ProviderID    uuid.UUID  `gorm:"type:uuid;not null" json:"provider_id"`
+	State         string     `gorm:"type:varchar(100);not null;uniqueIndex" json:"-"`
+	Nonce         string     `gorm:"type:varchar(100)" json:"-"`
+	CodeVerifier  string     `gorm:"type:varchar(100)" json:"-"`
+	SAMLRequestID string     `gorm:"column:saml_request_id;type:varchar(100)" json:"-"`
+	RedirectURI   string     `gorm:"type:text;not null" json:"redirect_uri"`
+	UserID        *uuid.UUID `gorm:"type:uuid" json:"user_id,omitempty"`
+	CodeHash      *string    `gorm:"type:varchar(255);uniqueIndex" json:"-"`
+	ExpiresAt     time.Time  `gorm:"type:timestamptz;not null" json:"expires_at"`
+	CompletedAt   *time.Time `gorm:"type:timestamptz" json:"completed_at,omitempty"`
+	ExchangedAt   *time.Time `gorm:"type:timestamptz" json:"exchanged_at,omitempty"`
+}
+
+// TableName returns the table name for the SSOLoginRequest model.
+func (SSOLoginRequest) TableName() string {
+	return "sso_login_requests"
+}
+
+// IsExpired returns true if the login request has expired.
+func (r *SSOLoginRequest) IsExpired() bool {
+	return time.Now().After(r.ExpiresAt)
+}
@@ -120,6 +120,7 @@ type Staff struct {
 	DepartmentID       *uuid.UUID `gorm:"type:uuid;index" json:"departmentId,omitempty"`
 	DesignationID      *uuid.UUID `gorm:"type:uuid;index" json:"designationId,omitempty"`
 	ReportingManagerID *uuid.UUID `gorm:"type:uuid" json:"reportingManagerId,omitempty"`
+	ShiftID            *uuid.UUID `gorm:"type:uuid" json:"shiftId,omitempty"`
 	JoinDate           time.Time  `gorm:"type:date;not null" json:"joinDate"`
 	ConfirmationDate   *time.Time `gorm:"type:date" json:"confirmationDate,omitempty"`
 	ProbationEndDate   *time.Time `gorm:"type:date" json:"probationEndDate,omitempty"`
@@ -147,6 +148,7 @@ type Staff struct {
 	Department       *Department  `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
 	Designation      *Designation `gorm:"foreignKey:DesignationID" json:"designation,omitempty"`
 	ReportingManager *Staff       `gorm:"foreignKey:ReportingManagerID" json:"reportingManager,omitempty"`
+	Shift            *Shift       `gorm:"foreignKey:ShiftID" json:"-"`
 }
 
 // TableName returns the table name for the Staff model.
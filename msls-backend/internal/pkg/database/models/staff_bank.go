@@ -0,0 +1,143 @@
+// Package models contains database model definitions.
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BankVerificationStatus represents the verification state of a staff bank account.
+type BankVerificationStatus string
+
+const (
+	BankVerificationPending  BankVerificationStatus = "pending"
+	BankVerificationVerified BankVerificationStatus = "verified"
+	BankVerificationRejected BankVerificationStatus = "rejected"
+)
+
+// IsValid checks if the verification status is valid.
+func (s BankVerificationStatus) IsValid() bool {
+	switch s {
+	case BankVerificationPending, BankVerificationVerified, BankVerificationRejected:
+		return true
+	}
+	return false
+}
+
+// BankAccountType represents the type of a bank account.
+type BankAccountType string
+
+const (
+	BankAccountSavings BankAccountType = "savings"
+	BankAccountCurrent BankAccountType = "current"
+	BankAccountSalary  BankAccountType = "salary"
+)
+
+// IsValid checks if the account type is valid.
+func (t BankAccountType) IsValid() bool {
+	switch t {
+	case BankAccountSavings, BankAccountCurrent, BankAccountSalary:
+		return true
+	}
+	return false
+}
+
+// StaffBankAccount represents a bank account salary is paid into.
+// The account number is stored encrypted; only the last four digits are kept in clear.
+type StaffBankAccount struct {
+	ID                     uuid.UUID              `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	TenantID               uuid.UUID              `gorm:"type:uuid;not null;index"`
+	StaffID                uuid.UUID              `gorm:"type:uuid;not null;index"`
+	AccountHolderName      string                 `gorm:"type:varchar(200);not null"`
+	BankName               string                 `gorm:"type:varchar(100);not null"`
+	BranchName             *string                `gorm:"type:varchar(100)"`
+	IFSCCode               string                 `gorm:"column:ifsc_code;type:varchar(11);not null"`
+	AccountType            BankAccountType        `gorm:"type:varchar(20);not null;default:'savings'"`
+	AccountNumberEncrypted string                 `gorm:"type:text;not null"`
+	AccountNumberLast4     string                 `gorm:"column:account_number_last4;type:varchar(4);not null"`
+	AccountNumberHash      string                 `gorm:"type:varchar(64);not null"`
+	IsPrimary              bool                   `gorm:"not null;default:false"`
+	VerificationStatus     BankVerificationStatus `gorm:"type:varchar(20);not null;default:'pending'"`
+	VerifiedAt             *time.Time             `gorm:"type:timestamptz"`
+	VerifiedBy             *uuid.UUID             `gorm:"type:uuid"`
+	VerificationNote       *string                `gorm:"type:text"`
+	CreatedAt              time.Time              `gorm:"not null;default:now()"`
+	UpdatedAt              time.Time              `gorm:"not null;default:now()"`
+	CreatedBy              *uuid.UUID             `gorm:"type:uuid"`
+	UpdatedBy              *uuid.UUID             `gorm:"type:uuid"`
+
+	// Relations
+	Staff    *Staff `gorm:"foreignKey:StaffID"`
+	Verifier *User  `gorm:"foreignKey:VerifiedBy"`
+}
+
+// TableName returns the table name for StaffBankAccount.
+func (StaffBankAccount) TableName() string {
+	return "staff_bank_accounts"
+}
+
+// MaskedAccountNumber returns the account number with all but the last four digits hidden.
+func (a *StaffBankAccount) MaskedAccountNumber() string {
+	return "XXXXXX" + a.AccountNumberLast4
+}
+
+// BankFileField describes one fixed-width field of a bank file record.
+type BankFileField struct {
+	Field  string `json:"field"`
+	Value  string `json:"value,omitempty"`
+	Width  int    `json:"width"`
+	Align  string `json:"align,omitempty"`
+	Pad    string `json:"pad,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+// BankFileFields represents the fields of a fixed-width record.
+type BankFileFields []BankFileField
+
+// Value implements the driver.Valuer interface for database serialization.
+func (f BankFileFields) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface for database deserialization.
+func (f *BankFileFields) Scan(value interface{}) error {
+	if value == nil {
+		*f = BankFileFields{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+// BankFileTemplate is a tenant-defined fixed-width salary transfer file layout.
+type BankFileTemplate struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	TenantID      uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name          string         `gorm:"type:varchar(100);not null"`
+	Description   *string        `gorm:"type:text"`
+	HeaderFields  BankFileFields `gorm:"type:jsonb;not null;default:'[]'"`
+	DetailFields  BankFileFields `gorm:"type:jsonb;not null;default:'[]'"`
+	TrailerFields BankFileFields `gorm:"type:jsonb;not null;default:'[]'"`
+	LineEnding    string         `gorm:"type:varchar(4);not null;default:'crlf'"`
+	FileExtension string         `gorm:"type:varchar(10);not null;default:'txt'"`
+	IsActive      bool           `gorm:"not null;default:true"`
+	CreatedAt     time.Time      `gorm:"not null;default:now()"`
+	UpdatedAt     time.Time      `gorm:"not null;default:now()"`
+	CreatedBy     *uuid.UUID     `gorm:"type:uuid"`
+	UpdatedBy     *uuid.UUID     `gorm:"type:uuid"`
+}
+
+// TableName returns the table name for BankFileTemplate.
+func (BankFileTemplate) TableName() string {
+	return "bank_file_templates"
+}
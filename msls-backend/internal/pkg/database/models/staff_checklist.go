@@ -0,0 +1,322 @@
+// Package models provides GORM model definitions for the MSLS database.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ChecklistKind distinguishes onboarding checklists from exit workflows.
+type ChecklistKind string
+
+// ChecklistKind constants.
+const (
+	ChecklistKindOnboarding ChecklistKind = "onboarding"
+	ChecklistKindExit       ChecklistKind = "exit"
+)
+
+// IsValid checks if the checklist kind is a valid value.
+func (k ChecklistKind) IsValid() bool {
+	switch k {
+	case ChecklistKindOnboarding, ChecklistKindExit:
+		return true
+	}
+	return false
+}
+
+// ChecklistTaskType determines how a checklist task is completed.
+type ChecklistTaskType string
+
+// ChecklistTaskType constants.
+const (
+	ChecklistTaskTypeDocument         ChecklistTaskType = "document"
+	ChecklistTaskTypeAccount          ChecklistTaskType = "account"
+	ChecklistTaskTypeAssetIssue       ChecklistTaskType = "asset_issue"
+	ChecklistTaskTypeSalaryAssignment ChecklistTaskType = "salary_assignment"
+	ChecklistTaskTypeClearance        ChecklistTaskType = "clearance"
+	ChecklistTaskTypeAssetReturn      ChecklistTaskType = "asset_return"
+	ChecklistTaskTypeSettlement       ChecklistTaskType = "settlement"
+	ChecklistTaskTypeLetter           ChecklistTaskType = "letter"
+	ChecklistTaskTypeCustom           ChecklistTaskType = "custom"
+)
+
+// IsValid checks if the task type is a valid value.
+func (t ChecklistTaskType) IsValid() bool {
+	switch t {
+	case ChecklistTaskTypeDocument, ChecklistTaskTypeAccount, ChecklistTaskTypeAssetIssue,
+		ChecklistTaskTypeSalaryAssignment, ChecklistTaskTypeClearance, ChecklistTaskTypeAssetReturn,
+		ChecklistTaskTypeSettlement, ChecklistTaskTypeLetter, ChecklistTaskTypeCustom:
+		return true
+	}
+	return false
+}
+
+// AllowedFor reports whether the task type can appear on a checklist of the given kind.
+func (t ChecklistTaskType) AllowedFor(kind ChecklistKind) bool {
+	switch t {
+	case ChecklistTaskTypeDocument, ChecklistTaskTypeAccount, ChecklistTaskTypeAssetIssue,
+		ChecklistTaskTypeSalaryAssignment:
+		return kind == ChecklistKindOnboarding
+	case ChecklistTaskTypeClearance, ChecklistTaskTypeAssetReturn, ChecklistTaskTypeSettlement,
+		ChecklistTaskTypeLetter:
+		return kind == ChecklistKindExit
+	}
+	return t == ChecklistTaskTypeCustom
+}
+
+// StaffLetterType is a letter issued to a staff member who is leaving.
+type StaffLetterType string
+
+// StaffLetterType constants.
+const (
+	StaffLetterTypeRelieving  StaffLetterType = "relieving"
+	StaffLetterTypeExperience StaffLetterType = "experience"
+)
+
+// IsValid checks if the letter type is a valid value.
+func (t StaffLetterType) IsValid() bool {
+	switch t {
+	case StaffLetterTypeRelieving, StaffLetterTypeExperience:
+		return true
+	}
+	return false
+}
+
+// StaffChecklistStatus represents the lifecycle of a staff checklist.
+type StaffChecklistStatus string
+
+// StaffChecklistStatus constants.
+const (
+	StaffChecklistStatusOpen      StaffChecklistStatus = "open"
+	StaffChecklistStatusCompleted StaffChecklistStatus = "completed"
+	StaffChecklistStatusCancelled StaffChecklistStatus = "cancelled"
+)
+
+// IsValid checks if the checklist status is a valid value.
+func (s StaffChecklistStatus) IsValid() bool {
+	switch s {
+	case StaffChecklistStatusOpen, StaffChecklistStatusCompleted, StaffChecklistStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// ChecklistTaskStatus represents the state of a checklist task.
+type ChecklistTaskStatus string
+
+// ChecklistTaskStatus constants.
+const (
+	ChecklistTaskStatusPending   ChecklistTaskStatus = "pending"
+	ChecklistTaskStatusCompleted ChecklistTaskStatus = "completed"
+	ChecklistTaskStatusWaived    ChecklistTaskStatus = "waived"
+)
+
+// IsValid checks if the task status is a valid value.
+func (s ChecklistTaskStatus) IsValid() bool {
+	switch s {
+	case ChecklistTaskStatusPending, ChecklistTaskStatusCompleted, ChecklistTaskStatusWaived:
+		return true
+	}
+	return false
+}
+
+// ExitSettlementStatus represents the lifecycle of a full-and-final settlement.
+type ExitSettlementStatus string
+
+// ExitSettlementStatus constants.
+const (
+	ExitSettlementStatusDraft    ExitSettlementStatus = "draft"
+	ExitSettlementStatusApproved ExitSettlementStatus = "approved"
+	ExitSettlementStatusPaid     ExitSettlementStatus = "paid"
+)
+
+// ChecklistTemplate is a configurable set of onboarding or exit tasks for a
+// staff type. A template without a staff type applies to all staff.
+type ChecklistTemplate struct {
+	ID        uuid.UUID     `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID  uuid.UUID     `gorm:"type:uuid;not null;index" json:"tenantId"`
+	Name      string        `gorm:"type:varchar(200);not null" json:"name"`
+	Kind      ChecklistKind `gorm:"type:varchar(20);not null" json:"kind"`
+	StaffType *StaffType    `gorm:"type:varchar(20)" json:"staffType,omitempty"`
+	IsActive  bool          `gorm:"not null;default:true" json:"isActive"`
+	CreatedAt time.Time     `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt time.Time     `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy *uuid.UUID    `gorm:"type:uuid" json:"createdBy,omitempty"`
+	UpdatedBy *uuid.UUID    `gorm:"type:uuid" json:"updatedBy,omitempty"`
+
+	// Relationships
+	Items []ChecklistTemplateItem `gorm:"foreignKey:TemplateID" json:"items,omitempty"`
+}
+
+// TableName returns the table name for the ChecklistTemplate model.
+func (ChecklistTemplate) TableName() string {
+	return "checklist_templates"
+}
+
+// ChecklistTemplateItem is one task on a template. DueOffsetDays is counted
+// from the join date for onboarding and from the last working day for exits,
+// so exit tasks due before the last day have a negative offset.
+type ChecklistTemplateItem struct {
+	ID             uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TemplateID     uuid.UUID         `gorm:"type:uuid;not null;index" json:"templateId"`
+	Title          string            `gorm:"type:varchar(200);not null" json:"title"`
+	Description    string            `gorm:"type:text" json:"description,omitempty"`
+	TaskType       ChecklistTaskType `gorm:"type:varchar(30);not null" json:"taskType"`
+	DepartmentID   *uuid.UUID        `gorm:"type:uuid" json:"departmentId,omitempty"`
+	LetterType     *StaffLetterType  `gorm:"type:varchar(20)" json:"letterType,omitempty"`
+	AssigneeRoleID *uuid.UUID        `gorm:"type:uuid" json:"assigneeRoleId,omitempty"`
+	DueOffsetDays  int               `gorm:"not null;default:0" json:"dueOffsetDays"`
+	DisplayOrder   int               `gorm:"not null;default:0" json:"displayOrder"`
+
+	// Relationships
+	Department   *Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+	AssigneeRole *Role       `gorm:"foreignKey:AssigneeRoleID" json:"assigneeRole,omitempty"`
+}
+
+// TableName returns the table name for the ChecklistTemplateItem model.
+func (ChecklistTemplateItem) TableName() string {
+	return "checklist_template_items"
+}
+
+// StaffChecklist is a staff member's onboarding checklist or exit workflow.
+// EffectiveDate is the join date for onboarding and the last working day for
+// an exit.
+type StaffChecklist struct {
+	ID            uuid.UUID            `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID      uuid.UUID            `gorm:"type:uuid;not null;index" json:"tenantId"`
+	StaffID       uuid.UUID            `gorm:"type:uuid;not null;index" json:"staffId"`
+	Kind          ChecklistKind        `gorm:"type:varchar(20);not null" json:"kind"`
+	TemplateID    *uuid.UUID           `gorm:"type:uuid" json:"templateId,omitempty"`
+	Status        StaffChecklistStatus `gorm:"type:varchar(20);not null;default:'open'" json:"status"`
+	EffectiveDate time.Time            `gorm:"type:date;not null" json:"effectiveDate"`
+	ExitReason    string               `gorm:"type:text" json:"exitReason,omitempty"`
+	CompletedAt   *time.Time           `gorm:"type:timestamptz" json:"completedAt,omitempty"`
+	CancelledAt   *time.Time           `gorm:"type:timestamptz" json:"cancelledAt,omitempty"`
+	CreatedAt     time.Time            `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt     time.Time            `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy     *uuid.UUID           `gorm:"type:uuid" json:"createdBy,omitempty"`
+
+	// Relationships
+	Staff    *Staff               `gorm:"foreignKey:StaffID" json:"staff,omitempty"`
+	Template *ChecklistTemplate   `gorm:"foreignKey:TemplateID" json:"template,omitempty"`
+	Tasks    []StaffChecklistTask `gorm:"foreignKey:ChecklistID" json:"tasks,omitempty"`
+}
+
+// TableName returns the table name for the StaffChecklist model.
+func (StaffChecklist) TableName() string {
+	return "staff_checklists"
+}
+
+// StaffChecklistTask is one task on a staff checklist, assigned to a role,
+// a user or both.
+type StaffChecklistTask struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID       uuid.UUID           `gorm:"type:uuid;not null;index" json:"tenantId"`
+	ChecklistID    uuid.UUID           `gorm:"type:uuid;not null;index" json:"checklistId"`
+	Title          string              `gorm:"type:varchar(200);not null" json:"title"`
+	Description    string              `gorm:"type:text" json:"description,omitempty"`
+	TaskType       ChecklistTaskType   `gorm:"type:varchar(30);not null" json:"taskType"`
+	DocumentTypeID *uuid.UUID          `gorm:"type:uuid" json:"documentTypeId,omitempty"`
+	DepartmentID   *uuid.UUID          `gorm:"type:uuid" json:"departmentId,omitempty"`
+	LetterType     *StaffLetterType    `gorm:"type:varchar(20)" json:"letterType,omitempty"`
+	AssigneeRoleID *uuid.UUID          `gorm:"type:uuid;index" json:"assigneeRoleId,omitempty"`
+	AssigneeUserID *uuid.UUID          `gorm:"type:uuid;index" json:"assigneeUserId,omitempty"`
+	DueDate        *time.Time          `gorm:"type:date" json:"dueDate,omitempty"`
+	Status         ChecklistTaskStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	CompletedAt    *time.Time          `gorm:"type:timestamptz" json:"completedAt,omitempty"`
+	CompletedBy    *uuid.UUID          `gorm:"type:uuid" json:"completedBy,omitempty"`
+	Notes          string              `gorm:"type:text" json:"notes,omitempty"`
+	DisplayOrder   int                 `gorm:"not null;default:0" json:"displayOrder"`
+	CreatedAt      time.Time           `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt      time.Time           `gorm:"not null;default:now()" json:"updatedAt"`
+
+	// Relationships
+	Checklist    *StaffChecklist    `gorm:"foreignKey:ChecklistID" json:"checklist,omitempty"`
+	DocumentType *StaffDocumentType `gorm:"foreignKey:DocumentTypeID" json:"documentType,omitempty"`
+	Department   *Department        `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+	AssigneeRole *Role              `gorm:"foreignKey:AssigneeRoleID" json:"assigneeRole,omitempty"`
+}
+
+// TableName returns the table name for the StaffChecklistTask model.
+func (StaffChecklistTask) TableName() string {
+	return "staff_checklist_tasks"
+}
+
+// IsDone reports whether the task has been completed or waived.
+func (t *StaffChecklistTask) IsDone() bool {
+	return t.Status == ChecklistTaskStatusCompleted || t.Status == ChecklistTaskStatusWaived
+}
+
+// StaffAsset is school property issued to a staff member, such as a laptop,
+// ID card or keys. Assets not returned on exit carry a recovery amount that
+// is deducted in the full-and-final settlement.
+type StaffAsset struct {
+	ID              uuid.UUID        `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID        uuid.UUID        `gorm:"type:uuid;not null;index" json:"tenantId"`
+	StaffID         uuid.UUID        `gorm:"type:uuid;not null;index" json:"staffId"`
+	Name            string           `gorm:"type:varchar(200);not null" json:"name"`
+	AssetTag        string           `gorm:"type:varchar(100)" json:"assetTag,omitempty"`
+	Description     string           `gorm:"type:text" json:"description,omitempty"`
+	IssuedOn        time.Time        `gorm:"type:date;not null" json:"issuedOn"`
+	IssuedBy        *uuid.UUID       `gorm:"type:uuid" json:"issuedBy,omitempty"`
+	ReturnedOn      *time.Time       `gorm:"type:date" json:"returnedOn,omitempty"`
+	ReturnedTo      *uuid.UUID       `gorm:"type:uuid" json:"returnedTo,omitempty"`
+	ReturnCondition string           `gorm:"type:varchar(200)" json:"returnCondition,omitempty"`
+	RecoveryAmount  *decimal.Decimal `gorm:"type:decimal(12,2)" json:"recoveryAmount,omitempty"`
+	CreatedAt       time.Time        `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt       time.Time        `gorm:"not null;default:now()" json:"updatedAt"`
+}
+
+// TableName returns the table name for the StaffAsset model.
+func (StaffAsset) TableName() string {
+	return "staff_assets"
+}
+
+// IsCleared reports whether the asset has been returned or its cost will be recovered.
+func (a *StaffAsset) IsCleared() bool {
+	return a.ReturnedOn != nil || a.RecoveryAmount != nil
+}
+
+// ExitSettlement is the full-and-final settlement for a staff member's exit,
+// computed from their current salary: pay for the unpaid days of the final
+// month, gratuity, leave encashment and other earnings, less asset recovery
+// and other deductions.
+type ExitSettlement struct {
+	ID                  uuid.UUID            `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID            uuid.UUID            `gorm:"type:uuid;not null;index" json:"tenantId"`
+	ChecklistID         uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex" json:"checklistId"`
+	StaffID             uuid.UUID            `gorm:"type:uuid;not null;index" json:"staffId"`
+	StaffSalaryID       *uuid.UUID           `gorm:"type:uuid" json:"staffSalaryId,omitempty"`
+	LastWorkingDay      time.Time            `gorm:"type:date;not null" json:"lastWorkingDay"`
+	MonthlyEarnings     decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"monthlyEarnings"`
+	MonthlyDeductions   decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"monthlyDeductions"`
+	MonthlyBasic        decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"monthlyBasic"`
+	WorkingDays         int                  `gorm:"not null;default:0" json:"workingDays"`
+	PayableDays         int                  `gorm:"not null;default:0" json:"payableDays"`
+	FinalMonthPaid      bool                 `gorm:"not null;default:false" json:"finalMonthPaid"`
+	FinalMonthSalary    decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"finalMonthSalary"`
+	ServiceYears        int                  `gorm:"not null;default:0" json:"serviceYears"`
+	Gratuity            decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"gratuity"`
+	LeaveEncashmentDays decimal.Decimal      `gorm:"type:decimal(6,2);not null;default:0" json:"leaveEncashmentDays"`
+	LeaveEncashment     decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"leaveEncashment"`
+	OtherEarnings       decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"otherEarnings"`
+	AssetRecovery       decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"assetRecovery"`
+	OtherDeductions     decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"otherDeductions"`
+	NetPayable          decimal.Decimal      `gorm:"type:decimal(12,2);not null;default:0" json:"netPayable"`
+	Notes               string               `gorm:"type:text" json:"notes,omitempty"`
+	Status              ExitSettlementStatus `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
+	ApprovedAt          *time.Time           `gorm:"type:timestamptz" json:"approvedAt,omitempty"`
+	ApprovedBy          *uuid.UUID           `gorm:"type:uuid" json:"approvedBy,omitempty"`
+	PaidOn              *time.Time           `gorm:"type:date" json:"paidOn,omitempty"`
+	PaymentReference    string               `gorm:"type:varchar(100)" json:"paymentReference,omitempty"`
+	CreatedAt           time.Time            `gorm:"not null;default:now()" json:"createdAt"`
+	UpdatedAt           time.Time            `gorm:"not null;default:now()" json:"updatedAt"`
+	CreatedBy           *uuid.UUID           `gorm:"type:uuid" json:"createdBy,omitempty"`
+}
+
+// TableName returns the table name for the ExitSettlement model.
+func (ExitSettlement) TableName() string {
+	return "exit_settlements"
+}
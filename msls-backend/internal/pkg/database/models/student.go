@@ -105,6 +105,7 @@ type Student struct {
 	BirthCertificateURL string         `gorm:"type:varchar(500)" json:"birthCertificateUrl,omitempty"`
 	Status              StudentStatus  `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
 	AdmissionDate       time.Time      `gorm:"type:date;not null;default:CURRENT_DATE" json:"admissionDate"`
+	FamilyID            *uuid.UUID     `gorm:"type:uuid;index" json:"familyId,omitempty"`
 	CreatedAt           time.Time      `gorm:"not null;default:now()" json:"createdAt"`
 	UpdatedAt           time.Time      `gorm:"not null;default:now()" json:"updatedAt"`
 	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
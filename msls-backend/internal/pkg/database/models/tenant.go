@@ -17,6 +17,8 @@ type TenantSettings struct {
 	Locale string `json:"locale,omitempty"`
 	// Features contains feature flags for the tenant.
 	Features map[string]bool `json:"features,omitempty"`
+	// RateLimitPerMinute overrides the default per-tenant API request quota.
+	RateLimitPerMinute int `json:"rate_limit_per_minute,omitempty"`
 }
 
 // Tenant represents an organization using the MSLS system.
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // PeriodSlotType represents the type of a period slot.
@@ -34,6 +35,12 @@ type Shift struct {
 	Description string `gorm:"type:text"`
 	DisplayOrder int   `gorm:"not null;default:0"`
 
+	// Attendance rules; nil thresholds and deadline fall back to the branch settings
+	LateThresholdMinutes      *int          `gorm:"type:int"`
+	EarlyExitThresholdMinutes *int          `gorm:"type:int"`
+	AttendanceDeadline        *string       `gorm:"type:time"`
+	WorkingDays               pq.Int64Array `gorm:"type:smallint[];not null;default:'{1,2,3,4,5,6}'"` // 0=Sunday, ..., 6=Saturday
+
 	IsActive bool `gorm:"not null;default:true"`
 
 	CreatedAt time.Time  `gorm:"not null;default:now()"`
@@ -46,6 +53,42 @@ func (Shift) TableName() string {
 	return "shifts"
 }
 
+// IsWorkingDay returns true if the shift works on the given day of the week.
+func (s Shift) IsWorkingDay(day time.Weekday) bool {
+	for _, d := range s.WorkingDays {
+		if d == int64(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkingDaysBetween counts the days the shift works between two dates,
+// inclusive. Without a shift (a nil receiver), every day but Sunday counts.
+func (s *Shift) WorkingDaysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := 0
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		working := d.Weekday() != time.Sunday
+		if s != nil {
+			working = s.IsWorkingDay(d.Weekday())
+		}
+		if working {
+			days++
+		}
+	}
+	return days
+}
+
+// WorkingDaysInMonth counts the days the shift works in a month. Without a
+// shift (a nil receiver), every day but Sunday counts.
+func (s *Shift) WorkingDaysInMonth(year, month int) int {
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return s.WorkingDaysBetween(first, first.AddDate(0, 1, -1))
+}
+
 // DayPattern represents a day pattern (regular, half-day, etc.).
 type DayPattern struct {
 	ID       uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
@@ -146,6 +189,7 @@ type TimetableStatus string
 const (
 	TimetableStatusDraft     TimetableStatus = "draft"
 	TimetableStatusPublished TimetableStatus = "published"
+	TimetableStatusScheduled TimetableStatus = "scheduled"
 	TimetableStatusArchived  TimetableStatus = "archived"
 )
 
@@ -169,6 +213,11 @@ type Timetable struct {
 	PublishedAt   *time.Time `gorm:"type:timestamptz"`
 	PublishedBy   *uuid.UUID `gorm:"type:uuid"`
 
+	// Version numbers the timetables of a section and year in creation order;
+	// SupersedesID points at the version a revision was copied from.
+	SupersedesID *uuid.UUID `gorm:"type:uuid"`
+	Supersedes   *Timetable `gorm:"foreignKey:SupersedesID"`
+
 	CreatedAt time.Time  `gorm:"not null;default:now()"`
 	UpdatedAt time.Time  `gorm:"not null;default:now()"`
 	DeletedAt *time.Time `gorm:"type:timestamptz"`
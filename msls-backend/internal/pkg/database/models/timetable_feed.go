@@ -0,0 +1,48 @@
+// Package models provides database models for the MSLS application.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimetableFeedKind is whose timetable a calendar feed publishes.
+type TimetableFeedKind string
+
+// TimetableFeedKind constants.
+const (
+	TimetableFeedKindSection TimetableFeedKind = "section"
+	TimetableFeedKindTeacher TimetableFeedKind = "teacher"
+)
+
+// IsValid checks if the feed kind is valid.
+func (k TimetableFeedKind) IsValid() bool {
+	return k == TimetableFeedKindSection || k == TimetableFeedKindTeacher
+}
+
+// TimetableFeed is an iCalendar subscription link to a section's or a
+// teacher's published timetable. Only a hash of the link token is stored.
+type TimetableFeed struct {
+	ID             uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	TenantID       uuid.UUID         `gorm:"type:uuid;not null;index"`
+	Kind           TimetableFeedKind `gorm:"type:varchar(20);not null"`
+	SectionID      *uuid.UUID        `gorm:"type:uuid"`
+	StaffID        *uuid.UUID        `gorm:"type:uuid"`
+	AcademicYearID uuid.UUID         `gorm:"type:uuid;not null"`
+	TokenHash      string            `gorm:"type:varchar(64);not null;uniqueIndex"`
+	LastAccessedAt *time.Time        `gorm:"type:timestamptz"`
+	RevokedAt      *time.Time        `gorm:"type:timestamptz"`
+	CreatedAt      time.Time         `gorm:"not null;default:now()"`
+	CreatedBy      *uuid.UUID        `gorm:"type:uuid"`
+
+	// Relationships
+	Section      *Section      `gorm:"foreignKey:SectionID"`
+	Staff        *Staff        `gorm:"foreignKey:StaffID"`
+	AcademicYear *AcademicYear `gorm:"foreignKey:AcademicYearID"`
+}
+
+// TableName returns the table name for TimetableFeed.
+func (TimetableFeed) TableName() string {
+	return "timetable_feeds"
+}
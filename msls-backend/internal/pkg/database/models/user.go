@@ -33,6 +33,7 @@ type User struct {
 	LockedUntil                *time.Time `gorm:"type:timestamptz" json:"locked_until,omitempty"`
 	FailedLoginAttempts        int        `gorm:"not null;default:0" json:"-"`
 	AccountDeletionRequestedAt *time.Time `gorm:"type:timestamptz" json:"account_deletion_requested_at,omitempty"`
+	TokenVersion               int        `gorm:"<-:create;not null;default:0" json:"-"`
 
 	// Relationships
 	Tenant Tenant `gorm:"foreignKey:TenantID" json:"tenant,omitempty"`
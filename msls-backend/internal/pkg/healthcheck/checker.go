@@ -0,0 +1,180 @@
+// Package healthcheck provides readiness checks for the API's runtime dependencies.
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/storage"
+)
+
+// DefaultTimeout bounds how long a single check may run.
+const DefaultTimeout = 2 * time.Second
+
+// Check statuses.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// ErrPoolExhausted is returned when every connection in the pool is in use.
+var ErrPoolExhausted = errors.New("database connection pool exhausted")
+
+// CheckFunc verifies a single dependency. It returns nil when the dependency is usable.
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the aggregated outcome of all checks.
+type Report struct {
+	Ready  bool                   `json:"ready"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type namedCheck struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs a set of named readiness checks concurrently.
+type Checker struct {
+	timeout time.Duration
+	checks  []namedCheck
+}
+
+// NewChecker creates a checker whose checks are each bounded by timeout.
+// A zero timeout uses DefaultTimeout.
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout}
+}
+
+// Register adds a named check.
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.checks = append(c.checks, namedCheck{name: name, fn: fn})
+}
+
+// Run executes all checks and reports whether every one of them passed.
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{
+		Ready:  true,
+		Checks: make(map[string]CheckResult, len(c.checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check namedCheck) {
+			defer wg.Done()
+
+			result := c.runOne(ctx, check.fn)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.name] = result
+			if result.Status != StatusUp {
+				report.Ready = false
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (c *Checker) runOne(ctx context.Context, fn CheckFunc) CheckResult {
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() { errCh <- fn(checkCtx) }()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-checkCtx.Done():
+		err = checkCtx.Err()
+	}
+
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// =============================================================================
+// Built-in checks
+// =============================================================================
+
+// DatabaseCheck pings PostgreSQL and executes a trivial query.
+func DatabaseCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("get sql.DB: %w", err)
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return fmt.Errorf("ping: %w", err)
+		}
+		var result int
+		if err := db.WithContext(ctx).Raw("SELECT 1").Scan(&result).Error; err != nil {
+			return fmt.Errorf("query: %w", err)
+		}
+		return nil
+	}
+}
+
+// PoolCheck fails when the connection pool is saturated, i.e. every allowed
+// connection is in use and callers are already queueing for one.
+func PoolCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("get sql.DB: %w", err)
+		}
+		stats := sqlDB.Stats()
+		if stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections && stats.WaitCount > 0 {
+			return fmt.Errorf("%w: %d/%d in use", ErrPoolExhausted, stats.InUse, stats.MaxOpenConnections)
+		}
+		return nil
+	}
+}
+
+// StorageCheck verifies the file store is writable by writing and removing a probe file.
+func StorageCheck(store storage.Storage) CheckFunc {
+	return func(ctx context.Context) error {
+		if store == nil {
+			return errors.New("storage not configured")
+		}
+		path := fmt.Sprintf(".healthcheck/%s", uuid.New().String())
+		if err := store.Upload(ctx, path, []byte("ok"), "text/plain"); err != nil {
+			return fmt.Errorf("write probe: %w", err)
+		}
+		if err := store.Delete(ctx, path); err != nil {
+			return fmt.Errorf("delete probe: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"msls-backend/internal/pkg/storage"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestChecker_AllChecksPass(t *testing.T) {
+	db := setupTestDB(t)
+	store, err := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	checker := NewChecker(time.Second)
+	checker.Register("database", DatabaseCheck(db))
+	checker.Register("database_pool", PoolCheck(db))
+	checker.Register("storage", StorageCheck(store))
+
+	report := checker.Run(context.Background())
+
+	assert.True(t, report.Ready)
+	require.Len(t, report.Checks, 3)
+	for name, result := range report.Checks {
+		assert.Equal(t, StatusUp, result.Status, name)
+		assert.Empty(t, result.Error, name)
+	}
+}
+
+func TestChecker_FailingCheckMarksNotReady(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("ok", func(ctx context.Context) error { return nil })
+	checker.Register("broken", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	report := checker.Run(context.Background())
+
+	assert.False(t, report.Ready)
+	assert.Equal(t, StatusUp, report.Checks["ok"].Status)
+	assert.Equal(t, StatusDown, report.Checks["broken"].Status)
+	assert.Equal(t, "connection refused", report.Checks["broken"].Error)
+}
+
+func TestChecker_TimeoutMarksNotReady(t *testing.T) {
+	checker := NewChecker(20 * time.Millisecond)
+	checker.Register("slow", func(ctx context.Context) error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+
+	report := checker.Run(context.Background())
+
+	assert.False(t, report.Ready)
+	assert.Equal(t, StatusDown, report.Checks["slow"].Status)
+	assert.Contains(t, report.Checks["slow"].Error, "deadline exceeded")
+}
+
+func TestDatabaseCheck_ClosedConnection(t *testing.T) {
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	assert.Error(t, DatabaseCheck(db)(context.Background()))
+}
+
+func TestStorageCheck_NilStorage(t *testing.T) {
+	assert.Error(t, StorageCheck(nil)(context.Background()))
+}
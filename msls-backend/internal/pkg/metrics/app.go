@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Notification channels used as the "channel" label of NotificationsSent.
+const (
+	ChannelSMS   = "sms"
+	ChannelEmail = "email"
+)
+
+// Notification outcomes used as the "status" label of NotificationsSent.
+const (
+	StatusSent   = "sent"
+	StatusFailed = "failed"
+)
+
+// Application metrics registered with the Default registry.
+var (
+	// HTTPRequestDuration tracks request latency by method, route template and status code.
+	HTTPRequestDuration = NewHistogramVec(
+		"msls_http_request_duration_seconds",
+		"HTTP request latency in seconds by method, route and status code.",
+		DefaultBuckets,
+		"method", "route", "status",
+	)
+
+	// NotificationsSent counts outbound SMS and email messages by outcome.
+	NotificationsSent = NewCounterVec(
+		"msls_notifications_sent_total",
+		"Outbound notifications by channel and outcome.",
+		"channel", "status",
+	)
+)
+
+func init() {
+	Default.MustRegister(HTTPRequestDuration, NotificationsSent)
+}
+
+// RecordNotification increments the notification counter for a channel.
+func RecordNotification(channel string, err error) {
+	status := StatusSent
+	if err != nil {
+		status = StatusFailed
+	}
+	NotificationsSent.WithLabelValues(channel, status).Inc()
+}
+
+// DBStatsMetrics returns function-backed metrics exposing connection pool statistics.
+func DBStatsMetrics(db *sql.DB) []Metric {
+	stat := func(get func(sql.DBStats) float64) func() []Sample {
+		return func() []Sample {
+			return []Sample{{Value: get(db.Stats())}}
+		}
+	}
+
+	return []Metric{
+		NewGaugeFunc("msls_db_max_open_connections", "Maximum number of open connections to the database.", nil,
+			stat(func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) })),
+		NewGaugeFunc("msls_db_open_connections", "Number of established connections, in use and idle.", nil,
+			stat(func(s sql.DBStats) float64 { return float64(s.OpenConnections) })),
+		NewGaugeFunc("msls_db_in_use_connections", "Number of connections currently in use.", nil,
+			stat(func(s sql.DBStats) float64 { return float64(s.InUse) })),
+		NewGaugeFunc("msls_db_idle_connections", "Number of idle connections.", nil,
+			stat(func(s sql.DBStats) float64 { return float64(s.Idle) })),
+		NewCounterFunc("msls_db_wait_count_total", "Total number of connections waited for.", nil,
+			stat(func(s sql.DBStats) float64 { return float64(s.WaitCount) })),
+		NewCounterFunc("msls_db_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", nil,
+			stat(func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() })),
+	}
+}
+
+// Handler returns a Gin handler that serves the registry in text exposition format.
+func Handler(r *Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", ContentType)
+		c.Status(http.StatusOK)
+		_ = r.WriteText(c.Writer)
+	}
+}
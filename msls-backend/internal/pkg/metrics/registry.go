@@ -0,0 +1,165 @@
+// Package metrics provides a lightweight, dependency-free metrics registry
+// that renders in the Prometheus text exposition format (version 0.0.4).
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metric types as reported in the # TYPE line.
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// Metric is implemented by every metric that can be registered.
+type Metric interface {
+	// Name returns the fully-qualified metric name.
+	Name() string
+
+	// write renders the metric in text exposition format.
+	write(w *bufio.Writer)
+}
+
+// Registry holds a set of metrics and renders them on demand.
+type Registry struct {
+	mu      sync.RWMutex
+	metrics map[string]Metric
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]Metric)}
+}
+
+// Default is the process-wide registry used by the application metrics.
+var Default = NewRegistry()
+
+// Register adds metrics to the registry.
+// It returns an error if a metric with the same name is already registered.
+func (r *Registry) Register(metrics ...Metric) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range metrics {
+		if _, exists := r.metrics[m.Name()]; exists {
+			return fmt.Errorf("metric %q already registered", m.Name())
+		}
+		r.metrics[m.Name()] = m
+	}
+	return nil
+}
+
+// MustRegister adds metrics to the registry and panics on duplicate names.
+func (r *Registry) MustRegister(metrics ...Metric) {
+	if err := r.Register(metrics...); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister removes a metric by name. It is a no-op if the metric is unknown.
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.metrics, name)
+}
+
+// WriteText renders all registered metrics, sorted by name, to w.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	metrics := make([]Metric, len(names))
+	for i, name := range names {
+		metrics[i] = r.metrics[name]
+	}
+	r.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(bw)
+	}
+	return bw.Flush()
+}
+
+// =============================================================================
+// Shared helpers
+// =============================================================================
+
+// labelSeparator joins label values into a series key. It cannot appear in valid UTF-8.
+const labelSeparator = "\xff"
+
+func seriesKey(values []string) string {
+	return strings.Join(values, labelSeparator)
+}
+
+func writeHeader(w *bufio.Writer, name, help, metricType string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+func writeSample(w *bufio.Writer, name string, labelNames, labelValues []string, extraName, extraValue string, value float64) {
+	w.WriteString(name)
+	if len(labelNames) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, ln := range labelNames {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", ln, escapeLabelValue(labelValues[i]))
+		}
+		if extraName != "" {
+			if len(labelNames) > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", extraName, extraValue)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
+
+func escapeLabelValue(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
+
+func checkLabelCount(name string, labelNames, labelValues []string) {
+	if len(labelNames) != len(labelValues) {
+		panic(fmt.Sprintf("metric %q: expected %d label values, got %d", name, len(labelNames), len(labelValues)))
+	}
+}
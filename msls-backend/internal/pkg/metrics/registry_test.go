@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, r *Registry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteText(&buf))
+	return buf.String()
+}
+
+func TestCounterVec_WriteText(t *testing.T) {
+	r := NewRegistry()
+	c := NewCounterVec("test_sent_total", "Sent messages.", "channel", "status")
+	r.MustRegister(c)
+
+	c.WithLabelValues("sms", "sent").Inc()
+	c.WithLabelValues("sms", "sent").Add(2)
+	c.WithLabelValues("email", "failed").Inc()
+	c.WithLabelValues("email", "failed").Add(-5) // ignored
+
+	out := render(t, r)
+	assert.Contains(t, out, "# HELP test_sent_total Sent messages.\n")
+	assert.Contains(t, out, "# TYPE test_sent_total counter\n")
+	assert.Contains(t, out, `test_sent_total{channel="sms",status="sent"} 3`+"\n")
+	assert.Contains(t, out, `test_sent_total{channel="email",status="failed"} 1`+"\n")
+
+	// Series are sorted for stable output
+	assert.Less(t, strings.Index(out, `channel="email"`), strings.Index(out, `channel="sms"`))
+}
+
+func TestHistogramVec_CumulativeBuckets(t *testing.T) {
+	r := NewRegistry()
+	h := NewHistogramVec("test_latency_seconds", "Latency.", []float64{0.5, 0.1, 1}, "route")
+	r.MustRegister(h)
+
+	series := h.WithLabelValues("/api/v1/students/:id")
+	series.Observe(0.05)
+	series.Observe(0.3)
+	series.Observe(2)
+
+	out := render(t, r)
+	assert.Contains(t, out, "# TYPE test_latency_seconds histogram\n")
+	assert.Contains(t, out, `test_latency_seconds_bucket{route="/api/v1/students/:id",le="0.1"} 1`+"\n")
+	assert.Contains(t, out, `test_latency_seconds_bucket{route="/api/v1/students/:id",le="0.5"} 2`+"\n")
+	assert.Contains(t, out, `test_latency_seconds_bucket{route="/api/v1/students/:id",le="1"} 2`+"\n")
+	assert.Contains(t, out, `test_latency_seconds_bucket{route="/api/v1/students/:id",le="+Inf"} 3`+"\n")
+	assert.Contains(t, out, `test_latency_seconds_sum{route="/api/v1/students/:id"} 2.35`+"\n")
+	assert.Contains(t, out, `test_latency_seconds_count{route="/api/v1/students/:id"} 3`+"\n")
+}
+
+func TestGaugeFunc_CollectsAtScrapeTime(t *testing.T) {
+	r := NewRegistry()
+	depth := 0.0
+	r.MustRegister(NewGaugeFunc("test_queue_depth", "Queue depth.", []string{"status"}, func() []Sample {
+		return []Sample{{LabelValues: []string{"pending"}, Value: depth}}
+	}))
+
+	depth = 4
+	assert.Contains(t, render(t, r), `test_queue_depth{status="pending"} 4`+"\n")
+
+	depth = 1
+	assert.Contains(t, render(t, r), `test_queue_depth{status="pending"} 1`+"\n")
+}
+
+func TestRegistry_DuplicateName(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(NewCounterVec("dup_total", "First.")))
+	assert.Error(t, r.Register(NewCounterVec("dup_total", "Second.")))
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	r := NewRegistry()
+	c := NewCounterVec("test_escape_total", "Escaping.", "value")
+	r.MustRegister(c)
+	c.WithLabelValues("a\"b\\c\nd").Inc()
+
+	assert.Contains(t, render(t, r), `test_escape_total{value="a\"b\\c\nd"} 1`)
+}
+
+func TestWithLabelValues_WrongCountPanics(t *testing.T) {
+	c := NewCounterVec("test_panic_total", "Panics.", "a", "b")
+	assert.Panics(t, func() { c.WithLabelValues("only-one") })
+}
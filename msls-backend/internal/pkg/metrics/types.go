@@ -0,0 +1,258 @@
+package metrics
+
+import (
+	"bufio"
+	"math"
+	"sort"
+	"sync"
+)
+
+// =============================================================================
+// Counter
+// =============================================================================
+
+// CounterVec is a monotonically increasing counter partitioned by labels.
+type CounterVec struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labelValues []string
+	value       float64
+}
+
+// Counter is a single labelled series of a CounterVec.
+type Counter struct {
+	vec    *CounterVec
+	series *counterSeries
+}
+
+// NewCounterVec creates a counter with the given label names.
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		series:     make(map[string]*counterSeries),
+	}
+}
+
+// Name returns the metric name.
+func (v *CounterVec) Name() string { return v.name }
+
+// WithLabelValues returns the counter series for the given label values,
+// creating it if necessary. It panics if the number of values is wrong.
+func (v *CounterVec) WithLabelValues(values ...string) *Counter {
+	checkLabelCount(v.name, v.labelNames, values)
+
+	key := seriesKey(values)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	s, ok := v.series[key]
+	if !ok {
+		s = &counterSeries{labelValues: append([]string(nil), values...)}
+		v.series[key] = s
+	}
+	return &Counter{vec: v, series: s}
+}
+
+// Inc increments the counter by one.
+func (c *Counter) Inc() { c.Add(1) }
+
+// Add increments the counter by delta. Negative deltas are ignored.
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.vec.mu.Lock()
+	c.series.value += delta
+	c.vec.mu.Unlock()
+}
+
+// Value returns the current counter value.
+func (c *Counter) Value() float64 {
+	c.vec.mu.Lock()
+	defer c.vec.mu.Unlock()
+	return c.series.value
+}
+
+func (v *CounterVec) write(w *bufio.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	writeHeader(w, v.name, v.help, typeCounter)
+	for _, key := range sortedKeys(v.series) {
+		s := v.series[key]
+		writeSample(w, v.name, v.labelNames, s.labelValues, "", "", s.value)
+	}
+}
+
+// =============================================================================
+// Histogram
+// =============================================================================
+
+// DefaultBuckets are latency buckets in seconds suitable for HTTP handlers.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// HistogramVec samples observations into buckets, partitioned by labels.
+type HistogramVec struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64 // per bucket, non-cumulative
+	sum         float64
+	count       uint64
+}
+
+// Histogram is a single labelled series of a HistogramVec.
+type Histogram struct {
+	vec    *HistogramVec
+	series *histogramSeries
+}
+
+// NewHistogramVec creates a histogram with the given upper bucket bounds and label names.
+// Buckets are sorted; a +Inf bucket is always implied.
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	b := make([]float64, 0, len(buckets))
+	for _, bound := range buckets {
+		if !math.IsInf(bound, 1) {
+			b = append(b, bound)
+		}
+	}
+	sort.Float64s(b)
+
+	return &HistogramVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		buckets:    b,
+		series:     make(map[string]*histogramSeries),
+	}
+}
+
+// Name returns the metric name.
+func (v *HistogramVec) Name() string { return v.name }
+
+// WithLabelValues returns the histogram series for the given label values.
+func (v *HistogramVec) WithLabelValues(values ...string) *Histogram {
+	checkLabelCount(v.name, v.labelNames, values)
+
+	key := seriesKey(values)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	s, ok := v.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), values...),
+			counts:      make([]uint64, len(v.buckets)),
+		}
+		v.series[key] = s
+	}
+	return &Histogram{vec: v, series: s}
+}
+
+// Observe records a single observation.
+func (h *Histogram) Observe(value float64) {
+	h.vec.mu.Lock()
+	defer h.vec.mu.Unlock()
+
+	for i, bound := range h.vec.buckets {
+		if value <= bound {
+			h.series.counts[i]++
+			break
+		}
+	}
+	h.series.sum += value
+	h.series.count++
+}
+
+func (v *HistogramVec) write(w *bufio.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	writeHeader(w, v.name, v.help, typeHistogram)
+	for _, key := range sortedKeys(v.series) {
+		s := v.series[key]
+		var cumulative uint64
+		for i, bound := range v.buckets {
+			cumulative += s.counts[i]
+			writeSample(w, v.name+"_bucket", v.labelNames, s.labelValues, "le", formatFloat(bound), float64(cumulative))
+		}
+		writeSample(w, v.name+"_bucket", v.labelNames, s.labelValues, "le", "+Inf", float64(s.count))
+		writeSample(w, v.name+"_sum", v.labelNames, s.labelValues, "", "", s.sum)
+		writeSample(w, v.name+"_count", v.labelNames, s.labelValues, "", "", float64(s.count))
+	}
+}
+
+// =============================================================================
+// Function-backed metrics
+// =============================================================================
+
+// Sample is a single value reported by a function-backed metric.
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// FuncMetric reports values computed at scrape time, e.g. connection pool
+// statistics or queue depths read from the database.
+type FuncMetric struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+	collect    func() []Sample
+}
+
+// NewGaugeFunc creates a gauge whose samples are produced by collect on every scrape.
+func NewGaugeFunc(name, help string, labelNames []string, collect func() []Sample) *FuncMetric {
+	return &FuncMetric{name: name, help: help, metricType: typeGauge, labelNames: labelNames, collect: collect}
+}
+
+// NewCounterFunc creates a counter whose samples are produced by collect on every scrape.
+// collect must return monotonically increasing values.
+func NewCounterFunc(name, help string, labelNames []string, collect func() []Sample) *FuncMetric {
+	return &FuncMetric{name: name, help: help, metricType: typeCounter, labelNames: labelNames, collect: collect}
+}
+
+// Name returns the metric name.
+func (m *FuncMetric) Name() string { return m.name }
+
+func (m *FuncMetric) write(w *bufio.Writer) {
+	samples := m.collect()
+	sort.Slice(samples, func(i, j int) bool {
+		return seriesKey(samples[i].LabelValues) < seriesKey(samples[j].LabelValues)
+	})
+
+	writeHeader(w, m.name, m.help, m.metricType)
+	for _, s := range samples {
+		if len(s.LabelValues) != len(m.labelNames) {
+			continue
+		}
+		writeSample(w, m.name, m.labelNames, s.LabelValues, "", "", s.Value)
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
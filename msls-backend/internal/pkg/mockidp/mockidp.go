@@ -0,0 +1,356 @@
+// Package mockidp provides a local OpenID Connect and SAML 2.0 identity provider
+// for developing and testing single sign-on without Google or Microsoft accounts.
+//
+// Every authorization request is approved immediately for the configured user,
+// so it must never be exposed outside a development machine or test.
+package mockidp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/logger"
+	"github.com/crewjam/saml/samlsp"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKeyID is the "kid" of the ID token signing key.
+const signingKeyID = "mockidp"
+
+// User is the identity asserted for every login.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Groups        []string
+}
+
+// Config holds mock identity provider configuration.
+type Config struct {
+	// Issuer is the base URL the server is reachable at.
+	// When empty it is derived from each request's host.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	User         User
+}
+
+type authCode struct {
+	user          User
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// Server is a mock OpenID Connect and SAML 2.0 identity provider.
+type Server struct {
+	config Config
+	key    *rsa.PrivateKey
+	cert   *x509.Certificate
+
+	mu    sync.Mutex
+	user  User
+	codes map[string]authCode
+}
+
+// New creates a mock identity provider with a fresh signing key.
+func New(config Config) (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "msls mock idp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		config: config,
+		key:    key,
+		cert:   cert,
+		user:   config.User,
+		codes:  make(map[string]authCode),
+	}, nil
+}
+
+// SetUser changes the identity asserted for subsequent logins.
+func (s *Server) SetUser(user User) {
+	s.mu.Lock()
+	s.user = user
+	s.mu.Unlock()
+}
+
+// Handler returns the HTTP handler serving the OIDC and SAML endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/saml/metadata", s.samlMetadata)
+	mux.HandleFunc("/saml/sso", s.samlSSO)
+	return mux
+}
+
+// SAMLMetadata returns the IdP metadata document for the given issuer.
+func (s *Server) SAMLMetadata(issuer string) ([]byte, error) {
+	return xml.MarshalIndent(s.samlIdentityProvider(issuer).Metadata(), "", "  ")
+}
+
+func (s *Server) issuer(r *http.Request) string {
+	if s.config.Issuer != "" {
+		return s.config.Issuer
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func (s *Server) currentUser() User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.user
+}
+
+// ==================== OpenID Connect ====================
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	issuer := s.issuer(r)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/authorize",
+		"token_endpoint":                        issuer + "/token",
+		"jwks_uri":                              issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "email", "profile", "groups"},
+	})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != s.config.ClientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	if query.Get("response_type") != "code" {
+		http.Error(w, "unsupported response_type", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	s.mu.Lock()
+	s.codes[code] = authCode{
+		user:          s.user,
+		clientID:      s.config.ClientID,
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		expiresAt:     time.Now().Add(time.Minute),
+	}
+	s.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.config.ClientID || clientSecret != s.config.ClientSecret {
+		tokenError(w, "invalid_client")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	s.mu.Lock()
+	code, found := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+	if !found || time.Now().After(code.expiresAt) || code.redirectURI != r.PostForm.Get("redirect_uri") {
+		tokenError(w, "invalid_grant")
+		return
+	}
+	if code.codeChallenge != "" {
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != code.codeChallenge {
+			tokenError(w, "invalid_grant")
+			return
+		}
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.issuer(r),
+		"sub":            code.user.Subject,
+		"aud":            code.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"email":          code.user.Email,
+		"email_verified": code.user.EmailVerified,
+		"given_name":     code.user.GivenName,
+		"family_name":    code.user.FamilyName,
+		"groups":         code.user.Groups,
+	}
+	if code.nonce != "" {
+		claims["nonce"] = code.nonce
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = signingKeyID
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": signingKeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// ==================== SAML 2.0 ====================
+
+func (s *Server) samlIdentityProvider(issuer string) *saml.IdentityProvider {
+	metadataURL, _ := url.Parse(issuer + "/saml/metadata")
+	ssoURL, _ := url.Parse(issuer + "/saml/sso")
+	return &saml.IdentityProvider{
+		Key:                     s.key,
+		Certificate:             s.cert,
+		Logger:                  logger.DefaultLogger,
+		MetadataURL:             *metadataURL,
+		SSOURL:                  *ssoURL,
+		ServiceProviderProvider: serviceProviders{},
+		SessionProvider:         sessions{server: s},
+	}
+}
+
+func (s *Server) samlMetadata(w http.ResponseWriter, r *http.Request) {
+	s.samlIdentityProvider(s.issuer(r)).ServeMetadata(w, r)
+}
+
+func (s *Server) samlSSO(w http.ResponseWriter, r *http.Request) {
+	s.samlIdentityProvider(s.issuer(r)).ServeSSO(w, r)
+}
+
+// serviceProviders trusts any service provider and fetches its metadata from
+// its entity ID, which MSLS sets to the URL of its SP metadata.
+type serviceProviders struct{}
+
+func (serviceProviders) GetServiceProvider(r *http.Request, serviceProviderID string) (*saml.EntityDescriptor, error) {
+	metadataURL, err := url.Parse(serviceProviderID)
+	if err != nil {
+		return nil, err
+	}
+	return samlsp.FetchMetadata(r.Context(), http.DefaultClient, *metadataURL)
+}
+
+// sessions logs every request in as the configured user.
+type sessions struct {
+	server *Server
+}
+
+func (p sessions) GetSession(_ http.ResponseWriter, _ *http.Request, _ *saml.IdpAuthnRequest) *saml.Session {
+	user := p.server.currentUser()
+	now := saml.TimeNow()
+	return &saml.Session{
+		ID:             randomString(),
+		CreateTime:     now,
+		ExpireTime:     now.Add(5 * time.Minute),
+		Index:          randomString(),
+		NameID:         user.Subject,
+		NameIDFormat:   string(saml.PersistentNameIDFormat),
+		UserEmail:      user.Email,
+		UserGivenName:  user.GivenName,
+		UserSurname:    user.FamilyName,
+		UserCommonName: user.GivenName + " " + user.FamilyName,
+		Groups:         user.Groups,
+	}
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,275 @@
+// Package payments provides online payment collection through payment gateways.
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Headers used by the fake gateway's webhooks.
+const (
+	FakeSignatureHeader = "X-Fake-Signature"
+	FakeEventIDHeader   = "X-Fake-Event-Id"
+)
+
+// FakeSecret is the fixed secret the fake gateway signs with in development.
+// It is not secret; the fake gateway never handles real money.
+const FakeSecret = "msls-fake-gateway-secret"
+
+// FakeProvider is an in-memory payment gateway for development and testing.
+// Orders are paid by calling Pay, Capture or Fail instead of going through a
+// real checkout, and Webhook builds signed notifications for the ledger.
+type FakeProvider struct {
+	secret string
+
+	mu       sync.Mutex
+	orders   map[string]*Order
+	payments map[string][]Payment
+	refunds  map[string]*Refund
+}
+
+// fakeWebhook is the body of a fake gateway webhook.
+type fakeWebhook struct {
+	Event   string   `json:"event"`
+	Payment *Payment `json:"payment,omitempty"`
+	Refund  *Refund  `json:"refund,omitempty"`
+}
+
+// NewFakeProvider creates a fake gateway that signs checkout results and webhooks with secret.
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{
+		secret:   secret,
+		orders:   make(map[string]*Order),
+		payments: make(map[string][]Payment),
+		refunds:  make(map[string]*Refund),
+	}
+}
+
+// Name returns the provider name.
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// CheckoutKey returns a placeholder checkout key.
+func (p *FakeProvider) CheckoutKey() string {
+	return "fake_key"
+}
+
+// CreateOrder creates an order in memory.
+func (p *FakeProvider) CreateOrder(ctx context.Context, req OrderRequest) (*Order, error) {
+	if !req.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	order := &Order{
+		ID:        "order_fake_" + uuid.NewString(),
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Receipt:   req.Receipt,
+		Status:    StatusCreated,
+		CreatedAt: time.Now(),
+	}
+	p.orders[order.ID] = order
+
+	result := *order
+	return &result, nil
+}
+
+// FetchOrderPayments returns the payments made against an order.
+func (p *FakeProvider) FetchOrderPayments(ctx context.Context, orderID string) ([]Payment, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.orders[orderID]; !ok {
+		return nil, ErrOrderNotFound
+	}
+	payments := make([]Payment, len(p.payments[orderID]))
+	copy(payments, p.payments[orderID])
+	return payments, nil
+}
+
+// VerifyCheckout checks a confirmation returned by Pay or Capture.
+func (p *FakeProvider) VerifyCheckout(confirmation CheckoutConfirmation) error {
+	return verifyHMAC(p.secret, []byte(confirmation.OrderID+"|"+confirmation.PaymentID), confirmation.Signature)
+}
+
+// ParseWebhook verifies and decodes a webhook built by Webhook.
+func (p *FakeProvider) ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	if err := verifyHMAC(p.secret, body, header.Get(FakeSignatureHeader)); err != nil {
+		return nil, err
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if webhook.Event == "" || header.Get(FakeEventIDHeader) == "" {
+		return nil, fmt.Errorf("%w: missing event", ErrInvalidWebhook)
+	}
+
+	return &WebhookEvent{
+		ID:      header.Get(FakeEventIDHeader),
+		Type:    webhook.Event,
+		Payment: webhook.Payment,
+		Refund:  webhook.Refund,
+	}, nil
+}
+
+// Refund refunds all or part of a captured payment immediately.
+func (p *FakeProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment := p.findPayment(req.PaymentID)
+	if payment == nil {
+		return nil, ErrPaymentNotFound
+	}
+	if payment.Status != StatusCaptured {
+		return nil, fmt.Errorf("%w: payment is %s", ErrGatewayRequest, payment.Status)
+	}
+
+	amount := req.Amount
+	if !amount.IsPositive() {
+		amount = payment.Amount
+	}
+	refunded := amount
+	for _, refund := range p.refunds {
+		if refund.PaymentID == payment.ID {
+			refunded = refunded.Add(refund.Amount)
+		}
+	}
+	if refunded.GreaterThan(payment.Amount) {
+		return nil, fmt.Errorf("%w: refund exceeds payment amount", ErrGatewayRequest)
+	}
+	if refunded.Equal(payment.Amount) {
+		payment.Status = StatusRefunded
+	}
+
+	refund := &Refund{
+		ID:        "rfnd_fake_" + uuid.NewString(),
+		PaymentID: payment.ID,
+		Amount:    amount,
+		Status:    RefundProcessed,
+	}
+	p.refunds[refund.ID] = refund
+
+	result := *refund
+	return &result, nil
+}
+
+// Pay captures the full order amount and returns the signed checkout confirmation.
+func (p *FakeProvider) Pay(orderID, method string) (*CheckoutConfirmation, error) {
+	p.mu.Lock()
+	order, ok := p.orders[orderID]
+	p.mu.Unlock()
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	return p.Capture(orderID, order.Amount, method)
+}
+
+// Capture records a captured payment of amount against an order. Amounts
+// other than the order amount simulate a gateway discrepancy.
+func (p *FakeProvider) Capture(orderID string, amount decimal.Decimal, method string) (*CheckoutConfirmation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	order, ok := p.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	now := time.Now()
+	payment := Payment{
+		ID:         "pay_fake_" + uuid.NewString(),
+		OrderID:    orderID,
+		Amount:     amount,
+		Currency:   order.Currency,
+		Status:     StatusCaptured,
+		Method:     method,
+		CapturedAt: &now,
+	}
+	p.payments[orderID] = append(p.payments[orderID], payment)
+	order.Status = "paid"
+
+	return &CheckoutConfirmation{
+		OrderID:   orderID,
+		PaymentID: payment.ID,
+		Signature: signHMAC(p.secret, []byte(orderID+"|"+payment.ID)),
+	}, nil
+}
+
+// Fail records a failed payment attempt against an order and returns its ID.
+func (p *FakeProvider) Fail(orderID, reason string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	order, ok := p.orders[orderID]
+	if !ok {
+		return "", ErrOrderNotFound
+	}
+
+	payment := Payment{
+		ID:          "pay_fake_" + uuid.NewString(),
+		OrderID:     orderID,
+		Amount:      order.Amount,
+		Currency:    order.Currency,
+		Status:      StatusFailed,
+		ErrorReason: reason,
+	}
+	p.payments[orderID] = append(p.payments[orderID], payment)
+	return payment.ID, nil
+}
+
+// Webhook builds a signed webhook for a payment or refund event. The event ID
+// is derived from the event type and object, so building the same webhook
+// twice simulates a redelivery.
+func (p *FakeProvider) Webhook(event, objectID string) (http.Header, []byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	webhook := fakeWebhook{Event: event}
+	if refund, ok := p.refunds[objectID]; ok {
+		webhook.Refund = refund
+		webhook.Payment = p.findPayment(refund.PaymentID)
+	} else if payment := p.findPayment(objectID); payment != nil {
+		webhook.Payment = payment
+	} else {
+		return nil, nil, ErrPaymentNotFound
+	}
+
+	body, err := json.Marshal(webhook)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	header.Set(FakeSignatureHeader, signHMAC(p.secret, body))
+	header.Set(FakeEventIDHeader, "evt_"+event+"_"+objectID)
+	return header, body, nil
+}
+
+// findPayment returns a payment by ID. The caller must hold the lock.
+func (p *FakeProvider) findPayment(paymentID string) *Payment {
+	for orderID := range p.payments {
+		for i := range p.payments[orderID] {
+			if p.payments[orderID][i].ID == paymentID {
+				return &p.payments[orderID][i]
+			}
+		}
+	}
+	return nil
+}
+
+// Ensure FakeProvider implements Provider interface.
+var _ Provider = (*FakeProvider)(nil)
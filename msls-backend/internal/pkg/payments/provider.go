@@ -0,0 +1,142 @@
+// Package payments provides online payment collection through payment gateways.
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Common errors for payment gateway operations.
+var (
+	ErrProviderNotReady = errors.New("payment provider not ready")
+	ErrInvalidSignature = errors.New("invalid payment signature")
+	ErrInvalidWebhook   = errors.New("invalid webhook payload")
+	ErrOrderNotFound    = errors.New("payment order not found")
+	ErrPaymentNotFound  = errors.New("gateway payment not found")
+	ErrInvalidAmount    = errors.New("invalid payment amount")
+	ErrGatewayRequest   = errors.New("payment gateway request failed")
+)
+
+// Gateway payment statuses.
+const (
+	StatusCreated    = "created"
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusFailed     = "failed"
+	StatusRefunded   = "refunded"
+)
+
+// Gateway refund statuses.
+const (
+	RefundPending   = "pending"
+	RefundProcessed = "processed"
+	RefundFailed    = "failed"
+)
+
+// Webhook event types the ledger acts on. Providers map their own event names to these.
+const (
+	EventPaymentCaptured = "payment.captured"
+	EventPaymentFailed   = "payment.failed"
+	EventRefundProcessed = "refund.processed"
+	EventRefundFailed    = "refund.failed"
+)
+
+// OrderRequest represents an order to collect a payment against.
+type OrderRequest struct {
+	Amount   decimal.Decimal   // Amount in major units (e.g., rupees)
+	Currency string            // ISO 4217 currency code, e.g. INR
+	Receipt  string            // Our reference for the order, at most 40 characters
+	Notes    map[string]string // Key-value notes stored with the order at the gateway
+}
+
+// Order represents an order created at the gateway.
+type Order struct {
+	ID        string
+	Amount    decimal.Decimal
+	Currency  string
+	Receipt   string
+	Status    string
+	CreatedAt time.Time
+}
+
+// Payment represents a payment attempt against an order.
+type Payment struct {
+	ID          string
+	OrderID     string
+	Amount      decimal.Decimal
+	Currency    string
+	Status      string
+	Method      string
+	ErrorReason string
+	CapturedAt  *time.Time
+}
+
+// RefundRequest represents a refund of a captured payment.
+type RefundRequest struct {
+	PaymentID string
+	Amount    decimal.Decimal // Zero refunds the full payment
+	Notes     map[string]string
+}
+
+// Refund represents a refund at the gateway.
+type Refund struct {
+	ID        string
+	PaymentID string
+	Amount    decimal.Decimal
+	Status    string
+}
+
+// CheckoutConfirmation is returned to the browser when checkout completes.
+type CheckoutConfirmation struct {
+	OrderID   string
+	PaymentID string
+	Signature string
+}
+
+// WebhookEvent is a verified gateway notification.
+type WebhookEvent struct {
+	ID      string // Unique event ID used to discard redeliveries
+	Type    string // One of the Event* constants, or the provider's own name for other events
+	Payment *Payment
+	Refund  *Refund
+}
+
+// Provider defines the interface for payment gateways.
+// Implementations include Razorpay and a local fake gateway.
+type Provider interface {
+	// Name returns the provider name stored with ledger entries.
+	Name() string
+
+	// CheckoutKey returns the public key the browser checkout is opened with.
+	CheckoutKey() string
+
+	// CreateOrder creates an order the payer completes through checkout.
+	CreateOrder(ctx context.Context, req OrderRequest) (*Order, error)
+
+	// FetchOrderPayments returns the payment attempts made against an order.
+	FetchOrderPayments(ctx context.Context, orderID string) ([]Payment, error)
+
+	// VerifyCheckout checks the signature returned to the browser by checkout.
+	VerifyCheckout(confirmation CheckoutConfirmation) error
+
+	// ParseWebhook verifies a webhook request and returns its event.
+	// It returns ErrInvalidSignature when the request was not sent by the gateway.
+	ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error)
+
+	// Refund refunds all or part of a captured payment.
+	Refund(ctx context.Context, req RefundRequest) (*Refund, error)
+}
+
+// ToMinorUnits converts an amount in major units to the smallest currency unit (paise).
+func ToMinorUnits(amount decimal.Decimal) int64 {
+	return amount.Shift(2).Round(0).IntPart()
+}
+
+// FromMinorUnits converts an amount in the smallest currency unit to major units.
+func FromMinorUnits(amount int64) decimal.Decimal {
+	return decimal.New(amount, -2)
+}
@@ -0,0 +1,324 @@
+// Package payments provides online payment collection through payment gateways.
+package payments
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRazorpayBaseURL is the Razorpay API endpoint.
+const DefaultRazorpayBaseURL = "https://api.razorpay.com/v1"
+
+// RazorpayConfig holds Razorpay API credentials.
+type RazorpayConfig struct {
+	KeyID         string
+	KeySecret     string
+	WebhookSecret string
+	// BaseURL overrides the API endpoint. Defaults to DefaultRazorpayBaseURL.
+	BaseURL string
+	// HTTPClient is used to call the API. Defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// RazorpayProvider collects payments through Razorpay orders and checkout.
+type RazorpayProvider struct {
+	config RazorpayConfig
+}
+
+// NewRazorpayProvider creates a new Razorpay provider.
+func NewRazorpayProvider(config RazorpayConfig) *RazorpayProvider {
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultRazorpayBaseURL
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &RazorpayProvider{config: config}
+}
+
+// Name returns the provider name.
+func (p *RazorpayProvider) Name() string {
+	return "razorpay"
+}
+
+// CheckoutKey returns the Razorpay key ID used by the checkout script.
+func (p *RazorpayProvider) CheckoutKey() string {
+	return p.config.KeyID
+}
+
+// razorpayOrder is an order as returned by the Razorpay API.
+type razorpayOrder struct {
+	ID        string `json:"id"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Receipt   string `json:"receipt"`
+	Status    string `json:"status"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// razorpayPayment is a payment as returned by the Razorpay API.
+type razorpayPayment struct {
+	ID               string `json:"id"`
+	OrderID          string `json:"order_id"`
+	Amount           int64  `json:"amount"`
+	Currency         string `json:"currency"`
+	Status           string `json:"status"`
+	Method           string `json:"method"`
+	ErrorDescription string `json:"error_description"`
+	CreatedAt        int64  `json:"created_at"`
+}
+
+// razorpayRefund is a refund as returned by the Razorpay API.
+type razorpayRefund struct {
+	ID        string `json:"id"`
+	PaymentID string `json:"payment_id"`
+	Amount    int64  `json:"amount"`
+	Status    string `json:"status"`
+}
+
+// razorpayError is the error body returned by the Razorpay API.
+type razorpayError struct {
+	Error struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+// CreateOrder creates a Razorpay order.
+func (p *RazorpayProvider) CreateOrder(ctx context.Context, req OrderRequest) (*Order, error) {
+	amount := ToMinorUnits(req.Amount)
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	body := map[string]interface{}{
+		"amount":   amount,
+		"currency": req.Currency,
+		"receipt":  req.Receipt,
+	}
+	if len(req.Notes) > 0 {
+		body["notes"] = req.Notes
+	}
+
+	var order razorpayOrder
+	if err := p.do(ctx, http.MethodPost, "/orders", body, &order); err != nil {
+		return nil, err
+	}
+
+	return &Order{
+		ID:        order.ID,
+		Amount:    FromMinorUnits(order.Amount),
+		Currency:  order.Currency,
+		Receipt:   order.Receipt,
+		Status:    order.Status,
+		CreatedAt: time.Unix(order.CreatedAt, 0),
+	}, nil
+}
+
+// FetchOrderPayments returns the payments made against a Razorpay order.
+func (p *RazorpayProvider) FetchOrderPayments(ctx context.Context, orderID string) ([]Payment, error) {
+	var collection struct {
+		Items []razorpayPayment `json:"items"`
+	}
+	if err := p.do(ctx, http.MethodGet, "/orders/"+orderID+"/payments", nil, &collection); err != nil {
+		return nil, err
+	}
+
+	payments := make([]Payment, 0, len(collection.Items))
+	for _, item := range collection.Items {
+		payments = append(payments, *item.toPayment())
+	}
+	return payments, nil
+}
+
+// VerifyCheckout checks the razorpay_signature returned by checkout, an
+// HMAC-SHA256 of "order_id|payment_id" keyed with the key secret.
+func (p *RazorpayProvider) VerifyCheckout(confirmation CheckoutConfirmation) error {
+	if confirmation.OrderID == "" || confirmation.PaymentID == "" {
+		return ErrInvalidSignature
+	}
+	return verifyHMAC(p.config.KeySecret, []byte(confirmation.OrderID+"|"+confirmation.PaymentID), confirmation.Signature)
+}
+
+// ParseWebhook verifies the X-Razorpay-Signature header, an HMAC-SHA256 of
+// the raw body keyed with the webhook secret, and decodes the event.
+func (p *RazorpayProvider) ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	if p.config.WebhookSecret == "" {
+		return nil, ErrProviderNotReady
+	}
+	if err := verifyHMAC(p.config.WebhookSecret, body, header.Get("X-Razorpay-Signature")); err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Event   string `json:"event"`
+		Payload struct {
+			Payment *struct {
+				Entity razorpayPayment `json:"entity"`
+			} `json:"payment"`
+			Refund *struct {
+				Entity razorpayRefund `json:"entity"`
+			} `json:"refund"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if payload.Event == "" {
+		return nil, fmt.Errorf("%w: missing event", ErrInvalidWebhook)
+	}
+
+	// Razorpay sends a unique ID per event and repeats it on redelivery
+	eventID := header.Get("X-Razorpay-Event-Id")
+	if eventID == "" {
+		sum := sha256.Sum256(body)
+		eventID = hex.EncodeToString(sum[:])
+	}
+
+	event := &WebhookEvent{ID: eventID, Type: payload.Event}
+	// order.paid carries the captured payment, like payment.captured
+	if event.Type == "order.paid" {
+		event.Type = EventPaymentCaptured
+	}
+	if payload.Payload.Payment != nil {
+		event.Payment = payload.Payload.Payment.Entity.toPayment()
+	}
+	if payload.Payload.Refund != nil {
+		refund := payload.Payload.Refund.Entity
+		event.Refund = &Refund{
+			ID:        refund.ID,
+			PaymentID: refund.PaymentID,
+			Amount:    FromMinorUnits(refund.Amount),
+			Status:    refund.Status,
+		}
+	}
+
+	return event, nil
+}
+
+// Refund refunds a captured Razorpay payment.
+func (p *RazorpayProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	body := map[string]interface{}{}
+	if req.Amount.IsPositive() {
+		body["amount"] = ToMinorUnits(req.Amount)
+	}
+	if len(req.Notes) > 0 {
+		body["notes"] = req.Notes
+	}
+
+	var refund razorpayRefund
+	if err := p.do(ctx, http.MethodPost, "/payments/"+req.PaymentID+"/refund", body, &refund); err != nil {
+		return nil, err
+	}
+
+	return &Refund{
+		ID:        refund.ID,
+		PaymentID: refund.PaymentID,
+		Amount:    FromMinorUnits(refund.Amount),
+		Status:    refund.Status,
+	}, nil
+}
+
+// do sends an authenticated API request and decodes the JSON response into out.
+func (p *RazorpayProvider) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	if p.config.KeyID == "" || p.config.KeySecret == "" {
+		return ErrProviderNotReady
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.config.BaseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(p.config.KeyID, p.config.KeySecret)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGatewayRequest, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGatewayRequest, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrOrderNotFound
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr razorpayError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Description != "" {
+			return fmt.Errorf("%w: %s", ErrGatewayRequest, apiErr.Error.Description)
+		}
+		return fmt.Errorf("%w: status %d", ErrGatewayRequest, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: invalid response: %v", ErrGatewayRequest, err)
+	}
+	return nil
+}
+
+// toPayment converts a Razorpay payment.
+func (rp razorpayPayment) toPayment() *Payment {
+	payment := &Payment{
+		ID:          rp.ID,
+		OrderID:     rp.OrderID,
+		Amount:      FromMinorUnits(rp.Amount),
+		Currency:    rp.Currency,
+		Status:      rp.Status,
+		Method:      rp.Method,
+		ErrorReason: rp.ErrorDescription,
+	}
+	if rp.Status == StatusCaptured && rp.CreatedAt > 0 {
+		capturedAt := time.Unix(rp.CreatedAt, 0)
+		payment.CapturedAt = &capturedAt
+	}
+	return payment
+}
+
+// verifyHMAC checks a hex HMAC-SHA256 signature in constant time.
+func verifyHMAC(secret string, message []byte, signature string) error {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || secret == "" {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(message)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// signHMAC returns the hex HMAC-SHA256 of a message.
+func signHMAC(secret string, message []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(message)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Ensure RazorpayProvider implements Provider interface.
+var _ Provider = (*RazorpayProvider)(nil)
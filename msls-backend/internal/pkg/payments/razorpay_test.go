@@ -0,0 +1,199 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRazorpay(t *testing.T, handler http.HandlerFunc) *RazorpayProvider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewRazorpayProvider(RazorpayConfig{
+		KeyID:         "rzp_test_key",
+		KeySecret:     "key-secret",
+		WebhookSecret: "webhook-secret",
+		BaseURL:       server.URL,
+	})
+}
+
+func TestMinorUnits(t *testing.T) {
+	assert.Equal(t, int64(50050), ToMinorUnits(decimal.RequireFromString("500.50")))
+	assert.Equal(t, int64(1), ToMinorUnits(decimal.RequireFromString("0.005")))
+	assert.True(t, FromMinorUnits(50050).Equal(decimal.RequireFromString("500.50")))
+}
+
+func TestRazorpayCreateOrder(t *testing.T) {
+	provider := newTestRazorpay(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/orders", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "rzp_test_key", user)
+		assert.Equal(t, "key-secret", pass)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.EqualValues(t, 50000, body["amount"])
+		assert.Equal(t, "INR", body["currency"])
+		assert.Equal(t, "APP-1", body["receipt"])
+
+		_, _ = w.Write([]byte(`{"id":"order_123","amount":50000,"currency":"INR","receipt":"APP-1","status":"created","created_at":1760000000}`))
+	})
+
+	order, err := provider.CreateOrder(context.Background(), OrderRequest{
+		Amount:   decimal.NewFromInt(500),
+		Currency: "INR",
+		Receipt:  "APP-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order_123", order.ID)
+	assert.True(t, order.Amount.Equal(decimal.NewFromInt(500)))
+	assert.Equal(t, StatusCreated, order.Status)
+
+	_, err = provider.CreateOrder(context.Background(), OrderRequest{Amount: decimal.Zero, Currency: "INR"})
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestRazorpayErrors(t *testing.T) {
+	provider := newTestRazorpay(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orders/order_missing/payments" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":"BAD_REQUEST_ERROR","description":"The amount must be at least INR 1.00"}}`))
+	})
+
+	_, err := provider.FetchOrderPayments(context.Background(), "order_missing")
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+
+	_, err = provider.CreateOrder(context.Background(), OrderRequest{Amount: decimal.NewFromInt(1), Currency: "INR"})
+	assert.ErrorIs(t, err, ErrGatewayRequest)
+	assert.Contains(t, err.Error(), "at least INR 1.00")
+
+	unconfigured := NewRazorpayProvider(RazorpayConfig{})
+	_, err = unconfigured.CreateOrder(context.Background(), OrderRequest{Amount: decimal.NewFromInt(1), Currency: "INR"})
+	assert.ErrorIs(t, err, ErrProviderNotReady)
+}
+
+func TestRazorpayFetchOrderPaymentsAndRefund(t *testing.T) {
+	provider := newTestRazorpay(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orders/order_123/payments":
+			_, _ = w.Write([]byte(`{"items":[
+				{"id":"pay_1","order_id":"order_123","amount":50000,"currency":"INR","status":"failed","method":"card","error_description":"Card declined"},
+				{"id":"pay_2","order_id":"order_123","amount":50000,"currency":"INR","status":"captured","method":"upi","created_at":1760000000}
+			]}`))
+		case "/payments/pay_2/refund":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.EqualValues(t, 20000, body["amount"])
+			_, _ = w.Write([]byte(`{"id":"rfnd_1","payment_id":"pay_2","amount":20000,"status":"pending"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	list, err := provider.FetchOrderPayments(context.Background(), "order_123")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "Card declined", list[0].ErrorReason)
+	assert.Nil(t, list[0].CapturedAt)
+	assert.Equal(t, StatusCaptured, list[1].Status)
+	assert.NotNil(t, list[1].CapturedAt)
+
+	refund, err := provider.Refund(context.Background(), RefundRequest{PaymentID: "pay_2", Amount: decimal.NewFromInt(200)})
+	require.NoError(t, err)
+	assert.Equal(t, "rfnd_1", refund.ID)
+	assert.Equal(t, RefundPending, refund.Status)
+	assert.True(t, refund.Amount.Equal(decimal.NewFromInt(200)))
+}
+
+func TestRazorpayVerifyCheckout(t *testing.T) {
+	provider := NewRazorpayProvider(RazorpayConfig{KeyID: "rzp_test_key", KeySecret: "key-secret"})
+
+	signature := signHMAC("key-secret", []byte("order_123|pay_2"))
+	assert.NoError(t, provider.VerifyCheckout(CheckoutConfirmation{OrderID: "order_123", PaymentID: "pay_2", Signature: signature}))
+	assert.ErrorIs(t, provider.VerifyCheckout(CheckoutConfirmation{OrderID: "order_123", PaymentID: "pay_3", Signature: signature}), ErrInvalidSignature)
+	assert.ErrorIs(t, provider.VerifyCheckout(CheckoutConfirmation{OrderID: "order_123", PaymentID: "pay_2", Signature: "not-hex"}), ErrInvalidSignature)
+}
+
+func TestRazorpayParseWebhook(t *testing.T) {
+	provider := NewRazorpayProvider(RazorpayConfig{KeyID: "rzp_test_key", KeySecret: "key-secret", WebhookSecret: "webhook-secret"})
+
+	body := []byte(`{"event":"order.paid","payload":{"payment":{"entity":{"id":"pay_2","order_id":"order_123","amount":50000,"currency":"INR","status":"captured","method":"upi","created_at":1760000000}}}}`)
+	header := http.Header{}
+	header.Set("X-Razorpay-Signature", signHMAC("webhook-secret", body))
+	header.Set("X-Razorpay-Event-Id", "evt_1")
+
+	event, err := provider.ParseWebhook(header, body)
+	require.NoError(t, err)
+	assert.Equal(t, "evt_1", event.ID)
+	assert.Equal(t, EventPaymentCaptured, event.Type)
+	require.NotNil(t, event.Payment)
+	assert.Equal(t, "order_123", event.Payment.OrderID)
+	assert.True(t, event.Payment.Amount.Equal(decimal.NewFromInt(500)))
+
+	t.Run("event ID falls back to a body hash", func(t *testing.T) {
+		header.Del("X-Razorpay-Event-Id")
+		first, err := provider.ParseWebhook(header, body)
+		require.NoError(t, err)
+		second, err := provider.ParseWebhook(header, body)
+		require.NoError(t, err)
+		assert.Len(t, first.ID, 64)
+		assert.Equal(t, first.ID, second.ID)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		tampered := []byte(`{"event":"order.paid","payload":{"payment":{"entity":{"id":"pay_2","order_id":"order_123","amount":1}}}}`)
+		_, err := provider.ParseWebhook(header, tampered)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("refund event", func(t *testing.T) {
+		refundBody := []byte(`{"event":"refund.processed","payload":{"refund":{"entity":{"id":"rfnd_1","payment_id":"pay_2","amount":20000,"status":"processed"}}}}`)
+		refundHeader := http.Header{}
+		refundHeader.Set("X-Razorpay-Signature", signHMAC("webhook-secret", refundBody))
+		event, err := provider.ParseWebhook(refundHeader, refundBody)
+		require.NoError(t, err)
+		assert.Equal(t, EventRefundProcessed, event.Type)
+		require.NotNil(t, event.Refund)
+		assert.Equal(t, "pay_2", event.Refund.PaymentID)
+	})
+}
+
+func TestFakeProvider(t *testing.T) {
+	provider := NewFakeProvider("secret")
+	ctx := context.Background()
+
+	order, err := provider.CreateOrder(ctx, OrderRequest{Amount: decimal.NewFromInt(500), Currency: "INR"})
+	require.NoError(t, err)
+
+	confirmation, err := provider.Pay(order.ID, "upi")
+	require.NoError(t, err)
+	assert.NoError(t, provider.VerifyCheckout(*confirmation))
+
+	header, body, err := provider.Webhook(EventPaymentCaptured, confirmation.PaymentID)
+	require.NoError(t, err)
+	event, err := provider.ParseWebhook(header, body)
+	require.NoError(t, err)
+	assert.Equal(t, "evt_payment.captured_"+confirmation.PaymentID, event.ID)
+	assert.Equal(t, order.ID, event.Payment.OrderID)
+
+	_, err = provider.Refund(ctx, RefundRequest{PaymentID: confirmation.PaymentID, Amount: decimal.NewFromInt(300)})
+	require.NoError(t, err)
+	_, err = provider.Refund(ctx, RefundRequest{PaymentID: confirmation.PaymentID, Amount: decimal.NewFromInt(300)})
+	assert.ErrorIs(t, err, ErrGatewayRequest)
+
+	other := NewFakeProvider("other-secret")
+	_, err = other.ParseWebhook(header, body)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryCleanupInterval is how often expired windows are purged.
+const memoryCleanupInterval = 5 * time.Minute
+
+type memoryWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+// MemoryStore keeps counters in process memory.
+// Limits are per replica and reset on restart; use it for development and tests.
+type MemoryStore struct {
+	mu        sync.Mutex
+	windows   map[string]*memoryWindow
+	lastClean time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		windows:   make(map[string]*memoryWindow),
+		lastClean: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Take records one request for key.
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if err := limit.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.maybeCleanup(now)
+
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &memoryWindow{resetAt: now.Add(limit.Window)}
+		s.windows[key] = w
+	}
+	w.count++
+
+	return newResult(limit, w.count, w.resetAt.Sub(now)), nil
+}
+
+// Name returns the store name.
+func (s *MemoryStore) Name() string {
+	return "memory"
+}
+
+// maybeCleanup removes expired windows periodically. Caller must hold s.mu.
+func (s *MemoryStore) maybeCleanup(now time.Time) {
+	if now.Sub(s.lastClean) < memoryCleanupInterval {
+		return
+	}
+	s.lastClean = now
+	for key, w := range s.windows {
+		if !now.Before(w.resetAt) {
+			delete(s.windows, key)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// postgresCleanupInterval is how often expired counter rows are deleted.
+const postgresCleanupInterval = 5 * time.Minute
+
+// postgresTakeSQL upserts the counter row, restarting the window when it has ended.
+const postgresTakeSQL = `
+INSERT INTO rate_limit_counters (key, count, window_ends_at)
+VALUES (@key, 1, @window_ends_at)
+ON CONFLICT (key) DO UPDATE SET
+	count = CASE WHEN rate_limit_counters.window_ends_at <= @now THEN 1 ELSE rate_limit_counters.count + 1 END,
+	window_ends_at = CASE WHEN rate_limit_counters.window_ends_at <= @now THEN excluded.window_ends_at ELSE rate_limit_counters.window_ends_at END
+RETURNING count, window_ends_at`
+
+// PostgresStore keeps counters in the rate_limit_counters table.
+// It is used as a shared fallback when Redis is not configured or unavailable.
+type PostgresStore struct {
+	db  *gorm.DB
+	now func() time.Time
+
+	mu        sync.Mutex
+	lastClean time.Time
+}
+
+// NewPostgresStore creates a database-backed store.
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{
+		db:        db,
+		now:       time.Now,
+		lastClean: time.Now(),
+	}
+}
+
+// Take records one request for key.
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if err := limit.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	now := s.now().UTC()
+	s.maybeCleanup(ctx, now)
+
+	var row struct {
+		Count        int
+		WindowEndsAt time.Time
+	}
+	err := s.db.WithContext(ctx).Raw(postgresTakeSQL, map[string]interface{}{
+		"key":            key,
+		"now":            now,
+		"window_ends_at": now.Add(limit.Window),
+	}).Scan(&row).Error
+	if err != nil {
+		return Result{}, fmt.Errorf("postgres take: %w", err)
+	}
+
+	return newResult(limit, row.Count, row.WindowEndsAt.Sub(now)), nil
+}
+
+// Name returns the store name.
+func (s *PostgresStore) Name() string {
+	return "postgres"
+}
+
+// maybeCleanup deletes expired counters periodically.
+func (s *PostgresStore) maybeCleanup(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastClean) < postgresCleanupInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastClean = now
+	s.mu.Unlock()
+
+	// Best effort; a failed cleanup only leaves stale rows behind
+	s.db.WithContext(ctx).Exec("DELETE FROM rate_limit_counters WHERE window_ends_at <= ?", now)
+}
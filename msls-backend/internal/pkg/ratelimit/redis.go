@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces rate limit counters in a shared Redis database.
+const redisKeyPrefix = "msls:ratelimit:"
+
+// takeScript atomically increments the window counter, starting the window
+// on the first request, and returns the count and remaining TTL in milliseconds.
+var takeScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+// RedisStore keeps counters in Redis so that limits are shared by all API replicas.
+type RedisStore struct {
+	client redis.Scripter
+}
+
+// NewRedisStore creates a Redis-backed store.
+func NewRedisStore(client redis.Scripter) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Take records one request for key.
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if err := limit.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	values, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key}, limit.Window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("redis take: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("redis take: unexpected reply length %d", len(values))
+	}
+
+	return newResult(limit, int(values[0]), time.Duration(values[1])*time.Millisecond), nil
+}
+
+// Name returns the store name.
+func (s *RedisStore) Name() string {
+	return "redis"
+}
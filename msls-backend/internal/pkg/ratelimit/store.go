@@ -0,0 +1,113 @@
+// Package ratelimit provides fixed-window rate limiting backed by pluggable
+// counter stores (in-memory, Redis and PostgreSQL).
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidLimit is returned when a limit has a non-positive rate or window.
+var ErrInvalidLimit = errors.New("rate limit must have a positive rate and window")
+
+// Limit describes how many requests are allowed per window.
+type Limit struct {
+	Rate   int
+	Window time.Duration
+}
+
+// PerMinute returns a limit of n requests per minute.
+func PerMinute(n int) Limit {
+	return Limit{Rate: n, Window: time.Minute}
+}
+
+// PerHour returns a limit of n requests per hour.
+func PerHour(n int) Limit {
+	return Limit{Rate: n, Window: time.Hour}
+}
+
+// Validate checks that the limit can be enforced.
+func (l Limit) Validate() error {
+	if l.Rate <= 0 || l.Window <= 0 {
+		return ErrInvalidLimit
+	}
+	return nil
+}
+
+// Policy renders the limit in RateLimit-Policy header format, e.g. "100;w=60".
+func (l Limit) Policy() string {
+	return fmt.Sprintf("%d;w=%d", l.Rate, int(l.Window.Seconds()))
+}
+
+// Result is the outcome of taking a request from a window.
+type Result struct {
+	// Allowed reports whether the request is within the limit.
+	Allowed bool
+	// Limit is the number of requests allowed in the window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// ResetAfter is the time until the current window ends.
+	ResetAfter time.Duration
+}
+
+// newResult builds a Result from the post-increment count of a window.
+func newResult(limit Limit, count int, resetAfter time.Duration) Result {
+	remaining := limit.Rate - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	if resetAfter < 0 {
+		resetAfter = 0
+	}
+	return Result{
+		Allowed:    count <= limit.Rate,
+		Limit:      limit.Rate,
+		Remaining:  remaining,
+		ResetAfter: resetAfter,
+	}
+}
+
+// Store counts requests per key in fixed windows.
+// Implementations must be safe for concurrent use and, for shared stores,
+// atomic across processes so that limits hold for every API replica.
+type Store interface {
+	// Take records one request for key and reports whether it is within limit.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+
+	// Name returns the store name for logging purposes.
+	Name() string
+}
+
+// FallbackStore uses a primary store and falls back to a secondary store
+// when the primary returns an error (e.g. Redis is unreachable).
+type FallbackStore struct {
+	primary  Store
+	fallback Store
+	onError  func(err error)
+}
+
+// NewFallbackStore creates a store that prefers primary and uses fallback on error.
+// onError, if non-nil, is called with each primary failure.
+func NewFallbackStore(primary, fallback Store, onError func(err error)) *FallbackStore {
+	return &FallbackStore{primary: primary, fallback: fallback, onError: onError}
+}
+
+// Take records the request in the primary store, or in the fallback if the primary fails.
+func (s *FallbackStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	result, err := s.primary.Take(ctx, key, limit)
+	if err == nil {
+		return result, nil
+	}
+	if s.onError != nil {
+		s.onError(fmt.Errorf("%s store: %w", s.primary.Name(), err))
+	}
+	return s.fallback.Take(ctx, key, limit)
+}
+
+// Name returns the combined store name.
+func (s *FallbackStore) Name() string {
+	return s.primary.Name() + "+" + s.fallback.Name()
+}
@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(`CREATE TABLE rate_limit_counters (
+		key TEXT PRIMARY KEY,
+		count INTEGER NOT NULL DEFAULT 0,
+		window_ends_at TIMESTAMP NOT NULL
+	)`).Error)
+	return db
+}
+
+// assertFixedWindow takes limit+1 requests and checks the counters of a fresh window.
+func assertFixedWindow(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+	limit := Limit{Rate: 3, Window: time.Minute}
+
+	for i := 1; i <= 3; i++ {
+		result, err := store.Take(ctx, "login:10.0.0.1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, 3-i, result.Remaining)
+		assert.True(t, result.ResetAfter > 0 && result.ResetAfter <= time.Minute)
+	}
+
+	result, err := store.Take(ctx, "login:10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// Other keys have their own window
+	result, err = store.Take(ctx, "login:10.0.0.2", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+}
+
+func TestMemoryStore_FixedWindow(t *testing.T) {
+	assertFixedWindow(t, NewMemoryStore())
+}
+
+func TestMemoryStore_WindowResets(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	limit := Limit{Rate: 1, Window: time.Minute}
+
+	result, err := store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	now = now.Add(time.Minute)
+	result, err = store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryStore_InvalidLimit(t *testing.T) {
+	_, err := NewMemoryStore().Take(context.Background(), "k", Limit{})
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestPostgresStore_FixedWindow(t *testing.T) {
+	assertFixedWindow(t, NewPostgresStore(setupTestDB(t)))
+}
+
+func TestPostgresStore_WindowResets(t *testing.T) {
+	store := NewPostgresStore(setupTestDB(t))
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	limit := Limit{Rate: 1, Window: time.Minute}
+
+	result, err := store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	now = now.Add(time.Minute + time.Second)
+	result, err = store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+func TestRedisStore_FixedWindow(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	assertFixedWindow(t, NewRedisStore(client))
+}
+
+func TestRedisStore_WindowResets(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	store := NewRedisStore(client)
+	limit := Limit{Rate: 1, Window: time.Minute}
+
+	result, err := store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	mr.FastForward(time.Minute)
+	result, err = store.Take(context.Background(), "k", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func (failingStore) Name() string { return "failing" }
+
+func TestFallbackStore_UsesFallbackOnError(t *testing.T) {
+	var reported error
+	store := NewFallbackStore(failingStore{}, NewMemoryStore(), func(err error) { reported = err })
+
+	result, err := store.Take(context.Background(), "k", PerMinute(5))
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 4, result.Remaining)
+	require.Error(t, reported)
+	assert.Contains(t, reported.Error(), "failing store")
+	assert.Equal(t, "failing+memory", store.Name())
+}
+
+func TestLimit_Policy(t *testing.T) {
+	assert.Equal(t, "100;w=60", PerMinute(100).Policy())
+	assert.Equal(t, "20;w=3600", PerHour(20).Policy())
+}
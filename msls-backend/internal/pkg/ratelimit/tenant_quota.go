@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// DefaultQuotaCacheTTL is how long a tenant's quota is cached before it is reloaded.
+const DefaultQuotaCacheTTL = 5 * time.Minute
+
+// DefaultQuotaCacheSize caps how many tenant quotas are cached at once.
+const DefaultQuotaCacheSize = 10000
+
+type cachedQuota struct {
+	limit     Limit
+	expiresAt time.Time
+}
+
+// TenantQuotaResolver resolves the per-minute request quota of a tenant.
+// Tenants may override the default through TenantSettings.RateLimitPerMinute.
+type TenantQuotaResolver struct {
+	db           *gorm.DB
+	defaultLimit Limit
+	ttl          time.Duration
+	maxEntries   int
+	now          func() time.Time
+
+	mu        sync.RWMutex
+	cache     map[string]cachedQuota
+	lastClean time.Time
+}
+
+// NewTenantQuotaResolver creates a resolver that falls back to defaultLimit.
+func NewTenantQuotaResolver(db *gorm.DB, defaultLimit Limit) *TenantQuotaResolver {
+	return &TenantQuotaResolver{
+		db:           db,
+		defaultLimit: defaultLimit,
+		ttl:          DefaultQuotaCacheTTL,
+		maxEntries:   DefaultQuotaCacheSize,
+		now:          time.Now,
+		cache:        make(map[string]cachedQuota),
+		lastClean:    time.Now(),
+	}
+}
+
+// Resolve returns the quota for tenantID. Lookup failures return the default limit.
+func (r *TenantQuotaResolver) Resolve(ctx context.Context, tenantID string) Limit {
+	id, err := uuid.Parse(tenantID)
+	if err != nil {
+		return r.defaultLimit
+	}
+
+	now := r.now()
+	r.mu.RLock()
+	cached, ok := r.cache[tenantID]
+	r.mu.RUnlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.limit
+	}
+
+	limit := r.defaultLimit
+	var tenant models.Tenant
+	if err := r.db.WithContext(ctx).Select("id", "settings").Where("id = ?", id).First(&tenant).Error; err == nil {
+		if tenant.Settings.RateLimitPerMinute > 0 {
+			limit = PerMinute(tenant.Settings.RateLimitPerMinute)
+		}
+	}
+
+	r.mu.Lock()
+	r.evict(now)
+	r.cache[tenantID] = cachedQuota{limit: limit, expiresAt: now.Add(r.ttl)}
+	r.mu.Unlock()
+
+	return limit
+}
+
+// evict removes expired quotas once per TTL, or whenever the cache is full.
+// If every cached quota is still fresh when the cache is full, the cache is
+// emptied. Caller must hold r.mu.
+func (r *TenantQuotaResolver) evict(now time.Time) {
+	full := len(r.cache) >= r.maxEntries
+	if !full && now.Sub(r.lastClean) < r.ttl {
+		return
+	}
+	r.lastClean = now
+	for key, cached := range r.cache {
+		if !now.Before(cached.expiresAt) {
+			delete(r.cache, key)
+		}
+	}
+	if len(r.cache) >= r.maxEntries {
+		r.cache = make(map[string]cachedQuota)
+	}
+}
+
+// Invalidate drops the cached quota for tenantID so the next request reloads it.
+func (r *TenantQuotaResolver) Invalidate(tenantID string) {
+	r.mu.Lock()
+	delete(r.cache, tenantID)
+	r.mu.Unlock()
+}
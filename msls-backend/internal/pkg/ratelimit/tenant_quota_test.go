@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupTenantDB(t *testing.T, tenantID uuid.UUID, settings string) *gorm.DB {
+	t.Helper()
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec(`CREATE TABLE tenants (id TEXT PRIMARY KEY, settings TEXT NOT NULL DEFAULT '{}')`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO tenants (id, settings) VALUES (?, ?)`, tenantID.String(), settings).Error)
+	return db
+}
+
+func TestTenantQuotaResolver_Override(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	db := setupTenantDB(t, tenantID, `{"rate_limit_per_minute": 50}`)
+	resolver := NewTenantQuotaResolver(db, PerMinute(3000))
+
+	assert.Equal(t, PerMinute(50), resolver.Resolve(ctx, tenantID.String()))
+	assert.Equal(t, PerMinute(3000), resolver.Resolve(ctx, uuid.New().String()))
+	assert.Equal(t, PerMinute(3000), resolver.Resolve(ctx, "not-a-uuid"))
+
+	// Cached until invalidated
+	require.NoError(t, db.Exec(`UPDATE tenants SET settings = ?`, `{"rate_limit_per_minute": 80}`).Error)
+	assert.Equal(t, PerMinute(50), resolver.Resolve(ctx, tenantID.String()))
+	resolver.Invalidate(tenantID.String())
+	assert.Equal(t, PerMinute(80), resolver.Resolve(ctx, tenantID.String()))
+}
+
+func TestTenantQuotaResolver_EvictsExpired(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewTenantQuotaResolver(setupTestDB(t), PerMinute(3000))
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		resolver.Resolve(ctx, uuid.New().String())
+	}
+	assert.Len(t, resolver.cache, 5)
+
+	// After the TTL, the next lookup sweeps the expired quotas
+	now = now.Add(DefaultQuotaCacheTTL + time.Second)
+	resolver.Resolve(ctx, uuid.New().String())
+	assert.Len(t, resolver.cache, 1)
+}
+
+func TestTenantQuotaResolver_SizeBound(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewTenantQuotaResolver(setupTestDB(t), PerMinute(3000))
+	resolver.maxEntries = 3
+
+	for i := 0; i < 10; i++ {
+		resolver.Resolve(ctx, uuid.New().String())
+		assert.LessOrEqual(t, len(resolver.cache), 3)
+	}
+}
@@ -0,0 +1,29 @@
+// Package sms provides SMS sending capabilities with support for multiple providers.
+package sms
+
+import (
+	"context"
+
+	"msls-backend/internal/pkg/metrics"
+)
+
+// InstrumentedProvider wraps a Provider and counts send outcomes in the
+// application notification metrics.
+type InstrumentedProvider struct {
+	Provider
+}
+
+// NewInstrumentedProvider wraps provider with send counters.
+func NewInstrumentedProvider(provider Provider) *InstrumentedProvider {
+	return &InstrumentedProvider{Provider: provider}
+}
+
+// Send sends the message through the wrapped provider and records the outcome.
+func (p *InstrumentedProvider) Send(ctx context.Context, msg Message) (*SendResult, error) {
+	result, err := p.Provider.Send(ctx, msg)
+	metrics.RecordNotification(metrics.ChannelSMS, err)
+	return result, err
+}
+
+// Ensure InstrumentedProvider implements Provider interface.
+var _ Provider = (*InstrumentedProvider)(nil)
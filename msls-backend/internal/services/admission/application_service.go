@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,6 +39,7 @@ type CreateApplicationRequest struct {
 	Nationality      string
 	Religion         string
 	Category         string
+	QuotaCategory    string
 	AadharNumber     string
 	AddressLine1     string
 	AddressLine2     string
@@ -58,6 +60,8 @@ type CreateApplicationRequest struct {
 	GuardianPhone    string
 	GuardianEmail    string
 	GuardianRelation string
+	Source           models.ApplicationSource
+	VerifiedPhone    string
 	CreatedBy        *uuid.UUID
 }
 
@@ -71,6 +75,7 @@ type UpdateApplicationRequest struct {
 	Nationality      *string
 	Religion         *string
 	Category         *string
+	QuotaCategory    *string
 	AadharNumber     *string
 	AddressLine1     *string
 	AddressLine2     *string
@@ -186,10 +191,14 @@ func (s *ApplicationService) Create(ctx context.Context, req CreateApplicationRe
 	if req.ClassApplying == "" {
 		return nil, ErrClassApplyingRequired
 	}
+	quotaCategory, err := normalizeQuotaCategory(req.QuotaCategory)
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate session exists and is open
 	var session models.AdmissionSession
-	err := s.db.WithContext(ctx).
+	err = s.db.WithContext(ctx).
 		Where("tenant_id = ? AND id = ?", req.TenantID, req.SessionID).
 		First(&session).Error
 	if err != nil {
@@ -222,6 +231,7 @@ func (s *ApplicationService) Create(ctx context.Context, req CreateApplicationRe
 		Nationality:       req.Nationality,
 		Religion:          req.Religion,
 		Category:          req.Category,
+		QuotaCategory:     quotaCategory,
 		AadharNumber:      req.AadharNumber,
 		AddressLine1:      req.AddressLine1,
 		AddressLine2:      req.AddressLine2,
@@ -242,11 +252,25 @@ func (s *ApplicationService) Create(ctx context.Context, req CreateApplicationRe
 		GuardianPhone:     req.GuardianPhone,
 		GuardianEmail:     req.GuardianEmail,
 		GuardianRelation:  req.GuardianRelation,
+		Source:            req.Source,
+		VerifiedPhone:     req.VerifiedPhone,
 		Status:            models.ApplicationStatusDraft,
 		CreatedBy:         req.CreatedBy,
 		UpdatedBy:         req.CreatedBy,
 	}
 
+	// Link the application to an existing family for sibling and staff-ward priority
+	match, err := detectFamily(s.db.WithContext(ctx), application)
+	if err != nil {
+		return nil, err
+	}
+	application.FamilyID = match.FamilyID
+	application.HasSibling = match.HasSibling
+	application.StaffParentID = match.StaffParentID
+	if strings.TrimSpace(req.QuotaCategory) == "" {
+		application.QuotaCategory = defaultQuotaCategory(match)
+	}
+
 	if err := s.db.WithContext(ctx).Create(application).Error; err != nil {
 		return nil, fmt.Errorf("failed to create application: %w", err)
 	}
@@ -373,6 +397,13 @@ func (s *ApplicationService) Update(ctx context.Context, tenantID, id uuid.UUID,
 	if req.Category != nil {
 		updates["category"] = *req.Category
 	}
+	if req.QuotaCategory != nil {
+		quotaCategory, err := normalizeQuotaCategory(*req.QuotaCategory)
+		if err != nil {
+			return nil, err
+		}
+		updates["quota_category"] = quotaCategory
+	}
 	if req.AadharNumber != nil {
 		updates["aadhar_number"] = *req.AadharNumber
 	}
@@ -446,6 +477,36 @@ func (s *ApplicationService) Update(ctx context.Context, tenantID, id uuid.UUID,
 		}
 	}
 
+	// Parent contacts may have changed, so re-detect the family
+	if req.FatherPhone != nil || req.FatherEmail != nil || req.MotherPhone != nil ||
+		req.MotherEmail != nil || req.GuardianPhone != nil || req.GuardianEmail != nil {
+		return s.RefreshFamily(ctx, tenantID, id)
+	}
+
+	return s.GetByID(ctx, tenantID, id)
+}
+
+// RefreshFamily re-matches an application's parent contacts against families,
+// enrolled siblings and staff. The quota category is left unchanged.
+func (s *ApplicationService) RefreshFamily(ctx context.Context, tenantID, id uuid.UUID) (*models.AdmissionApplication, error) {
+	application, err := s.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := detectFamily(s.db.WithContext(ctx), application)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Model(application).Updates(map[string]interface{}{
+		"family_id":       match.FamilyID,
+		"has_sibling":     match.HasSibling,
+		"staff_parent_id": match.StaffParentID,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to update application family: %w", err)
+	}
+
 	return s.GetByID(ctx, tenantID, id)
 }
 
@@ -456,6 +517,16 @@ func (s *ApplicationService) Submit(ctx context.Context, tenantID, id uuid.UUID,
 		return nil, err
 	}
 
+	if err := submitApplication(s.db.WithContext(ctx), application, submittedBy); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, tenantID, id)
+}
+
+// submitApplication moves a loaded application to submitted using db, which
+// may be a transaction.
+func submitApplication(db *gorm.DB, application *models.AdmissionApplication, submittedBy *uuid.UUID) error {
 	// Allow submission from draft or re-submission from certain statuses
 	allowedStatuses := map[models.ApplicationStatus]bool{
 		models.ApplicationStatusDraft:            true,
@@ -464,12 +535,12 @@ func (s *ApplicationService) Submit(ctx context.Context, tenantID, id uuid.UUID,
 		models.ApplicationStatusUnderReview:      true, // If sent back for corrections
 	}
 	if !allowedStatuses[application.Status] {
-		return nil, ErrApplicationNotInDraft
+		return ErrApplicationNotInDraft
 	}
 
 	// Validate required fields
 	if application.StudentName == "" || application.ClassApplying == "" {
-		return nil, ErrMissingRequiredFields
+		return ErrMissingRequiredFields
 	}
 
 	now := time.Now()
@@ -489,16 +560,16 @@ func (s *ApplicationService) Submit(ctx context.Context, tenantID, id uuid.UUID,
 
 	// Get current history and append
 	var currentHistory models.StageHistory
-	if err := s.db.WithContext(ctx).Model(application).Select("stage_history").Scan(&currentHistory).Error; err == nil {
+	if err := db.Model(application).Select("stage_history").Scan(&currentHistory).Error; err == nil {
 		currentHistory = append(currentHistory, historyEntry)
 		updates["stage_history"] = currentHistory
 	}
 
-	if err := s.db.WithContext(ctx).Model(application).Updates(updates).Error; err != nil {
-		return nil, fmt.Errorf("failed to submit application: %w", err)
+	if err := db.Model(application).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to submit application: %w", err)
 	}
 
-	return s.GetByID(ctx, tenantID, id)
+	return nil
 }
 
 // UpdateStage updates the stage of an application.
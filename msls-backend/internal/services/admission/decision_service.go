@@ -5,22 +5,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/storage"
 )
 
+// DefaultOfferValidityDays is how long an offer stays open when no validity date is given.
+const DefaultOfferValidityDays = 30
+
+// OfferExpiryInterval is how often unaccepted offers are checked for expiry.
+const OfferExpiryInterval = time.Hour
+
 // DecisionService handles admission decision operations.
 type DecisionService struct {
-	db *gorm.DB
+	db      *gorm.DB
+	storage storage.Storage
 }
 
 // NewDecisionService creates a new DecisionService instance.
-func NewDecisionService(db *gorm.DB) *DecisionService {
-	return &DecisionService{db: db}
+// Offer letter PDFs are kept in fileStorage.
+func NewDecisionService(db *gorm.DB, fileStorage storage.Storage) *DecisionService {
+	return &DecisionService{db: db, storage: fileStorage}
 }
 
 // CreateDecisionRequest represents a request to create an admission decision.
@@ -105,6 +115,15 @@ func (s *DecisionService) CreateDecision(ctx context.Context, req CreateDecision
 			UpdatedBy:        req.DecidedBy,
 		}
 
+		// Approvals take a seat from the applicant's quota or the general pool
+		if req.Decision == models.DecisionApproved {
+			quota, err := allocateSeat(tx, &application, time.Now())
+			if err != nil {
+				return err
+			}
+			decision.AllocatedQuota = &quota
+		}
+
 		if err := tx.Create(decision).Error; err != nil {
 			return fmt.Errorf("failed to create decision: %w", err)
 		}
@@ -126,16 +145,6 @@ func (s *DecisionService) CreateDecision(ctx context.Context, req CreateDecision
 			"updated_at": time.Now(),
 		}
 
-		if req.Decision == models.DecisionApproved {
-			now := time.Now()
-			updates["approved_at"] = &now
-			updates["approved_by"] = req.DecidedBy
-		}
-
-		if req.Decision == models.DecisionWaitlisted {
-			updates["waitlist_position"] = req.WaitlistPosition
-		}
-
 		if err := tx.Model(&application).Updates(updates).Error; err != nil {
 			return fmt.Errorf("failed to update application status: %w", err)
 		}
@@ -197,55 +206,134 @@ type GenerateOfferLetterRequest struct {
 	TenantID      uuid.UUID
 	ApplicationID uuid.UUID
 	ValidUntil    *time.Time
+	Fees          models.OfferFeeItems
 	GeneratedBy   *uuid.UUID
 }
 
-// GenerateOfferLetter generates an offer letter for an approved application.
-func (s *DecisionService) GenerateOfferLetter(ctx context.Context, req GenerateOfferLetterRequest) (*models.AdmissionDecision, error) {
+// GenerateOfferLetter renders the offer letter PDF for an approved application
+// and issues a new public offer link. Regenerating replaces the previous letter
+// and invalidates the previous link. The returned token is not stored.
+func (s *DecisionService) GenerateOfferLetter(ctx context.Context, req GenerateOfferLetterRequest) (*models.AdmissionDecision, string, error) {
 	if req.TenantID == uuid.Nil {
-		return nil, ErrTenantIDRequired
+		return nil, "", ErrTenantIDRequired
 	}
 	if req.ApplicationID == uuid.Nil {
-		return nil, ErrApplicationIDRequired
+		return nil, "", ErrApplicationIDRequired
+	}
+	for _, fee := range req.Fees {
+		if strings.TrimSpace(fee.Name) == "" || fee.Amount.IsNegative() {
+			return nil, "", ErrInvalidOfferFee
+		}
 	}
 
 	// Get the decision
 	decision, err := s.GetDecisionByApplication(ctx, req.TenantID, req.ApplicationID)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	// Verify decision is approved
+	// Verify decision is approved and the offer is still open
 	if decision.Decision != models.DecisionApproved {
-		return nil, ErrOfferNotFound
+		return nil, "", ErrOfferNotFound
+	}
+	if decision.IsOfferAccepted() {
+		return nil, "", ErrOfferAlreadyAccepted
+	}
+	if decision.OfferLapsedAt != nil {
+		return nil, "", ErrOfferLapsed
 	}
 
-	// Set default validity if not provided (30 days)
+	// Set default validity if not provided
 	validUntil := req.ValidUntil
 	if validUntil == nil {
-		defaultValidity := time.Now().AddDate(0, 0, 30)
+		defaultValidity := time.Now().AddDate(0, 0, DefaultOfferValidityDays)
 		validUntil = &defaultValidity
 	}
+	decision.OfferValidUntil = validUntil
+	if decision.IsOfferExpired(time.Now()) {
+		return nil, "", ErrOfferExpired
+	}
+
+	fees := req.Fees
+	if fees == nil {
+		fees = models.OfferFeeItems{}
+	}
+
+	var application models.AdmissionApplication
+	err = s.db.WithContext(ctx).
+		Preload("Session").
+		Where("tenant_id = ? AND id = ?", req.TenantID, req.ApplicationID).
+		First(&application).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrApplicationNotFound
+		}
+		return nil, "", fmt.Errorf("failed to get application: %w", err)
+	}
+
+	data, err := s.offerLetterData(ctx, decision, &application)
+	if err != nil {
+		return nil, "", err
+	}
+	data.Fees = fees
+	data.ValidUntil = *validUntil
+
+	pdf, err := renderOfferLetter(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filePath := fmt.Sprintf("%s/admissions/%s/offer-letter-%s.pdf", req.TenantID, req.ApplicationID, uuid.New())
+	if err := s.storage.Upload(ctx, filePath, pdf, "application/pdf"); err != nil {
+		return nil, "", fmt.Errorf("failed to store offer letter: %w", err)
+	}
 
-	// TODO: Implement actual PDF generation
-	// For now, we just set a placeholder URL
+	token, err := newPublicToken()
+	if err != nil {
+		_ = s.storage.Delete(ctx, filePath)
+		return nil, "", fmt.Errorf("failed to generate offer link: %w", err)
+	}
+	tokenHash := hashPublicToken(token)
+
+	// Staff download the letter through the authenticated endpoint
 	offerLetterURL := fmt.Sprintf("/api/v1/applications/%s/offer-letter.pdf", req.ApplicationID)
+	previousPath := decision.OfferLetterPath
 
 	updates := map[string]interface{}{
 		"offer_letter_url":  offerLetterURL,
+		"offer_letter_path": filePath,
+		"offer_token_hash":  tokenHash,
+		"offer_fees":        fees,
 		"offer_valid_until": validUntil,
+		"offer_viewed_at":   nil,
 		"updated_by":        req.GeneratedBy,
 		"updated_at":        time.Now(),
 	}
 
 	if err := s.db.WithContext(ctx).Model(decision).Updates(updates).Error; err != nil {
-		return nil, fmt.Errorf("failed to update decision with offer letter: %w", err)
+		_ = s.storage.Delete(ctx, filePath)
+		return nil, "", fmt.Errorf("failed to update decision with offer letter: %w", err)
+	}
+	if previousPath != nil && *previousPath != filePath {
+		_ = s.storage.Delete(ctx, *previousPath)
 	}
 
 	decision.OfferLetterURL = &offerLetterURL
-	decision.OfferValidUntil = validUntil
+	decision.OfferLetterPath = &filePath
+	decision.OfferTokenHash = &tokenHash
+	decision.OfferFees = fees
+	decision.OfferViewedAt = nil
 
-	return decision, nil
+	return decision, token, nil
+}
+
+// GetOfferLetter returns the stored offer letter PDF of an application.
+func (s *DecisionService) GetOfferLetter(ctx context.Context, tenantID, applicationID uuid.UUID) ([]byte, error) {
+	decision, err := s.GetDecisionByApplication(ctx, tenantID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.downloadOfferLetter(ctx, decision)
 }
 
 // AcceptOfferRequest represents a request to accept an offer.
@@ -256,6 +344,7 @@ type AcceptOfferRequest struct {
 }
 
 // AcceptOffer accepts an offer for an application.
+// An offer found past its validity date lapses instead and ErrOfferExpired is returned.
 func (s *DecisionService) AcceptOffer(ctx context.Context, req AcceptOfferRequest) (*models.AdmissionDecision, error) {
 	if req.TenantID == uuid.Nil {
 		return nil, ErrTenantIDRequired
@@ -276,17 +365,23 @@ func (s *DecisionService) AcceptOffer(ctx context.Context, req AcceptOfferReques
 	}
 
 	// Check if offer is already accepted
-	if decision.OfferAccepted != nil && *decision.OfferAccepted {
+	if decision.IsOfferAccepted() {
 		return nil, ErrOfferAlreadyAccepted
 	}
 
-	// Check if offer has expired
-	if decision.OfferValidUntil != nil && decision.OfferValidUntil.Before(time.Now()) {
+	// Check if offer has lapsed or expired
+	if decision.OfferLapsedAt != nil {
+		return nil, ErrOfferLapsed
+	}
+	now := time.Now()
+	if decision.IsOfferExpired(now) {
+		if err := s.lapseOffer(ctx, decision, now); err != nil {
+			return nil, err
+		}
 		return nil, ErrOfferExpired
 	}
 
 	accepted := true
-	now := time.Now()
 
 	updates := map[string]interface{}{
 		"offer_accepted":    accepted,
@@ -305,6 +400,271 @@ func (s *DecisionService) AcceptOffer(ctx context.Context, req AcceptOfferReques
 	return decision, nil
 }
 
+// OfferView is an offer as shown to parents through the public offer link.
+type OfferView struct {
+	Decision    *models.AdmissionDecision
+	Application *models.AdmissionApplication
+	SchoolName  string
+	Currency    string
+}
+
+// GetOfferByToken returns the offer behind a public offer link and records the first view.
+// Offers found past their validity date lapse before they are returned.
+func (s *DecisionService) GetOfferByToken(ctx context.Context, token string) (*OfferView, error) {
+	decision, err := s.decisionByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !decision.IsOfferAccepted() && decision.OfferLapsedAt == nil && decision.IsOfferExpired(now) {
+		if err := s.lapseOffer(ctx, decision, now); err != nil {
+			return nil, err
+		}
+		accepted := false
+		decision.OfferAccepted = &accepted
+		decision.OfferLapsedAt = &now
+	}
+
+	if decision.OfferViewedAt == nil {
+		if err := s.db.WithContext(ctx).Model(decision).Update("offer_viewed_at", now).Error; err != nil {
+			return nil, fmt.Errorf("failed to record offer view: %w", err)
+		}
+		decision.OfferViewedAt = &now
+	}
+
+	var application models.AdmissionApplication
+	err = s.db.WithContext(ctx).
+		Preload("Session").
+		Where("tenant_id = ? AND id = ?", decision.TenantID, decision.ApplicationID).
+		First(&application).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	data, err := s.offerLetterData(ctx, decision, &application)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OfferView{
+		Decision:    decision,
+		Application: &application,
+		SchoolName:  data.SchoolName,
+		Currency:    data.Currency,
+	}, nil
+}
+
+// GetOfferLetterByToken returns the offer letter PDF behind a public offer link.
+func (s *DecisionService) GetOfferLetterByToken(ctx context.Context, token string) ([]byte, error) {
+	decision, err := s.decisionByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.downloadOfferLetter(ctx, decision)
+}
+
+// AcceptOfferByToken accepts the offer behind a public offer link.
+func (s *DecisionService) AcceptOfferByToken(ctx context.Context, token string) (*models.AdmissionDecision, error) {
+	decision, err := s.decisionByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.AcceptOffer(ctx, AcceptOfferRequest{
+		TenantID:      decision.TenantID,
+		ApplicationID: decision.ApplicationID,
+	})
+}
+
+// LapseExpiredOffers lapses every unaccepted offer past its validity date across
+// all tenants and promotes the next waitlisted application into each released seat.
+// It returns the number of offers lapsed.
+func (s *DecisionService) LapseExpiredOffers(ctx context.Context, now time.Time) (int, error) {
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var decisions []models.AdmissionDecision
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the sweep runs outside any tenant request
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Where("decision = ? AND offer_valid_until < ? AND offer_lapsed_at IS NULL", models.DecisionApproved, startOfDay).
+			Where("offer_accepted IS NULL OR offer_accepted = ?", false).
+			Order("offer_valid_until ASC").
+			Find(&decisions).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired offers: %w", err)
+	}
+
+	lapsed := 0
+	var errs []error
+	for i := range decisions {
+		if err := s.lapseOffer(ctx, &decisions[i], now); err != nil {
+			errs = append(errs, fmt.Errorf("application %s: %w", decisions[i].ApplicationID, err))
+			continue
+		}
+		lapsed++
+	}
+	return lapsed, errors.Join(errs...)
+}
+
+// lapseOffer marks an unaccepted offer as lapsed, withdraws the application and
+// promotes the next waitlisted application for the same session and class.
+// Offers already lapsed concurrently are left alone.
+func (s *DecisionService) lapseOffer(ctx context.Context, decision *models.AdmissionDecision, now time.Time) error {
+	var application models.AdmissionApplication
+	released := false
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AdmissionDecision{}).
+			Where("id = ? AND offer_lapsed_at IS NULL", decision.ID).
+			Updates(map[string]interface{}{
+				"offer_accepted":  false,
+				"offer_lapsed_at": now,
+				"updated_at":      now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to lapse offer: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Where("tenant_id = ? AND id = ?", decision.TenantID, decision.ApplicationID).
+			First(&application).Error; err != nil {
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+		if application.Status != models.ApplicationStatusApproved {
+			return nil
+		}
+
+		if err := tx.Model(&application).Updates(map[string]interface{}{
+			"status":     models.ApplicationStatusWithdrawn,
+			"updated_at": now,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to withdraw application: %w", err)
+		}
+		released = true
+		return nil
+	})
+	if err != nil || !released {
+		return err
+	}
+
+	// Release the seat to the first waitlisted application whose quota or the
+	// general pool still has room; a freed reserved seat skips applicants it cannot take.
+	var waitlisted []models.AdmissionDecision
+	err = s.db.WithContext(ctx).
+		Joins("JOIN admission_applications ON admission_applications.id = admission_decisions.application_id").
+		Where("admission_decisions.tenant_id = ? AND admission_decisions.decision = ?", application.TenantID, models.DecisionWaitlisted).
+		Where("admission_applications.session_id = ? AND admission_applications.class_applying = ? AND admission_applications.status = ?",
+			application.SessionID, application.ClassApplying, models.ApplicationStatusWaitlisted).
+		Order("admission_decisions.waitlist_position ASC, admission_decisions.created_at ASC").
+		Find(&waitlisted).Error
+	if err != nil {
+		return fmt.Errorf("failed to find waitlisted applications: %w", err)
+	}
+
+	for _, next := range waitlisted {
+		_, err = s.PromoteFromWaitlist(ctx, next.TenantID, next.ApplicationID, nil, nil)
+		if errors.Is(err, ErrNoSeatsAvailable) {
+			continue
+		}
+		return err
+	}
+	return nil
+}
+
+// decisionByToken finds the decision behind a public offer link.
+func (s *DecisionService) decisionByToken(ctx context.Context, token string) (*models.AdmissionDecision, error) {
+	if token == "" {
+		return nil, ErrOfferLinkInvalid
+	}
+
+	var decision models.AdmissionDecision
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the link identifies the tenant
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Where("offer_token_hash = ?", hashPublicToken(token)).First(&decision).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOfferLinkInvalid
+		}
+		return nil, fmt.Errorf("failed to get offer: %w", err)
+	}
+	return &decision, nil
+}
+
+// downloadOfferLetter reads a decision's offer letter PDF from storage.
+func (s *DecisionService) downloadOfferLetter(ctx context.Context, decision *models.AdmissionDecision) ([]byte, error) {
+	if decision.OfferLetterPath == nil {
+		return nil, ErrOfferLetterNotGenerated
+	}
+	data, err := s.storage.Download(ctx, *decision.OfferLetterPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offer letter: %w", err)
+	}
+	return data, nil
+}
+
+// offerLetterData collects the school branding and seat details for an offer letter.
+// The school is named after the application's branch when it has one.
+func (s *DecisionService) offerLetterData(ctx context.Context, decision *models.AdmissionDecision, application *models.AdmissionApplication) (*OfferLetterData, error) {
+	var tenant models.Tenant
+	if err := s.db.WithContext(ctx).First(&tenant, "id = ?", application.TenantID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	data := &OfferLetterData{
+		SchoolName:        tenant.Name,
+		Currency:          tenant.Settings.Currency,
+		ApplicationNumber: application.ApplicationNumber,
+		StudentName:       application.StudentName,
+		ParentName:        firstNonEmpty(application.FatherName, application.MotherName, application.GuardianName),
+		ClassName:         application.ClassApplying,
+		IssuedAt:          time.Now(),
+	}
+	if data.Currency == "" {
+		data.Currency = "INR"
+	}
+	if decision.SectionAssigned != nil {
+		data.Section = *decision.SectionAssigned
+	}
+	if application.Session != nil {
+		data.SessionName = application.Session.Name
+	}
+
+	if application.BranchID != nil {
+		var branch models.Branch
+		err := s.db.WithContext(ctx).First(&branch, "tenant_id = ? AND id = ?", application.TenantID, *application.BranchID).Error
+		if err == nil {
+			if !branch.IsPrimary {
+				data.SchoolName = fmt.Sprintf("%s - %s", tenant.Name, branch.Name)
+			}
+			address := branch.Address
+			var parts []string
+			for _, part := range []string{address.Street, address.City, address.State, address.PostalCode} {
+				if part != "" {
+					parts = append(parts, part)
+				}
+			}
+			data.SchoolAddress = strings.Join(parts, ", ")
+		}
+	}
+
+	return data, nil
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
 // EnrollRequest represents a request to complete enrollment.
 type EnrollRequest struct {
 	TenantID      uuid.UUID
@@ -479,14 +839,6 @@ func (s *DecisionService) UpdateWaitlistPosition(ctx context.Context, tenantID,
 		return nil, fmt.Errorf("failed to update waitlist position: %w", err)
 	}
 
-	// Also update the application's waitlist position
-	if err := s.db.WithContext(ctx).
-		Model(&models.AdmissionApplication{}).
-		Where("tenant_id = ? AND id = ?", tenantID, applicationID).
-		Update("waitlist_position", newPosition).Error; err != nil {
-		return nil, fmt.Errorf("failed to update application waitlist position: %w", err)
-	}
-
 	decision.WaitlistPosition = &newPosition
 
 	return decision, nil
@@ -510,14 +862,31 @@ func (s *DecisionService) PromoteFromWaitlist(ctx context.Context, tenantID, app
 		return nil, ErrInvalidDecisionType
 	}
 
+	var quota string
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now()
 
+		var application models.AdmissionApplication
+		if err := tx.Where("tenant_id = ? AND id = ?", tenantID, applicationID).
+			First(&application).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrApplicationNotFound
+			}
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+
+		var err error
+		quota, err = allocateSeat(tx, &application, now)
+		if err != nil {
+			return err
+		}
+
 		// Update decision
 		decisionUpdates := map[string]interface{}{
 			"decision":          models.DecisionApproved,
 			"section_assigned":  sectionAssigned,
 			"waitlist_position": nil,
+			"allocated_quota":   quota,
 			"updated_by":        promotedBy,
 			"updated_at":        now,
 		}
@@ -528,12 +897,9 @@ func (s *DecisionService) PromoteFromWaitlist(ctx context.Context, tenantID, app
 
 		// Update application status
 		appUpdates := map[string]interface{}{
-			"status":            models.ApplicationStatusApproved,
-			"waitlist_position": nil,
-			"approved_at":       &now,
-			"approved_by":       promotedBy,
-			"updated_by":        promotedBy,
-			"updated_at":        now,
+			"status":     models.ApplicationStatusApproved,
+			"updated_by": promotedBy,
+			"updated_at": now,
 		}
 
 		if err := tx.Model(&models.AdmissionApplication{}).
@@ -551,6 +917,7 @@ func (s *DecisionService) PromoteFromWaitlist(ctx context.Context, tenantID, app
 	decision.Decision = models.DecisionApproved
 	decision.SectionAssigned = sectionAssigned
 	decision.WaitlistPosition = nil
+	decision.AllocatedQuota = &quota
 
 	return decision, nil
 }
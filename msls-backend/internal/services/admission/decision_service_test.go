@@ -0,0 +1,241 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/storage"
+)
+
+type offerFixture struct {
+	db        *gorm.DB
+	service   *DecisionService
+	tenantID  uuid.UUID
+	sessionID uuid.UUID
+}
+
+// setupOffers creates a decision service for a tenant with one admission session.
+func setupOffers(t *testing.T) *offerFixture {
+	t.Helper()
+
+	db := newAdmissionTestDB(t,
+		&models.Tenant{}, &models.Branch{},
+		&models.AdmissionSession{}, &models.AdmissionSeat{}, &models.AdmissionApplication{}, &models.AdmissionDecision{},
+	)
+	session := seedAdmissionSession(t, db, models.SessionSettings{})
+
+	fileStorage, err := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	return &offerFixture{
+		db:        db,
+		service:   NewDecisionService(db, fileStorage),
+		tenantID:  session.TenantID,
+		sessionID: session.ID,
+	}
+}
+
+// createApplication creates a submitted application for Class 1 and records a decision for it.
+func (f *offerFixture) createApplication(t *testing.T, name string, decision models.DecisionType, waitlistPosition *int) uuid.UUID {
+	t.Helper()
+
+	application := &models.AdmissionApplication{
+		ID:                uuid.New(),
+		TenantID:          f.tenantID,
+		SessionID:         f.sessionID,
+		ApplicationNumber: "APP-" + name,
+		StudentName:       name,
+		ClassApplying:     "Class 1",
+		FatherName:        "Father of " + name,
+		Status:            models.ApplicationStatusSubmitted,
+	}
+	require.NoError(t, f.db.Create(application).Error)
+
+	_, err := f.service.CreateDecision(context.Background(), CreateDecisionRequest{
+		TenantID:         f.tenantID,
+		ApplicationID:    application.ID,
+		Decision:         decision,
+		DecisionDate:     time.Now(),
+		WaitlistPosition: waitlistPosition,
+	})
+	require.NoError(t, err)
+
+	return application.ID
+}
+
+func TestDecisionService_GenerateOfferLetter(t *testing.T) {
+	f := setupOffers(t)
+	ctx := context.Background()
+	applicationID := f.createApplication(t, "Asha", models.DecisionApproved, nil)
+
+	fees := models.OfferFeeItems{
+		{Name: "Admission Fee", Amount: decimal.NewFromInt(25000)},
+		{Name: "Term 1 Tuition", Amount: decimal.NewFromInt(18000)},
+	}
+	decision, token, err := f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{
+		TenantID:      f.tenantID,
+		ApplicationID: applicationID,
+		Fees:          fees,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	require.NotNil(t, decision.OfferLetterURL)
+	assert.Equal(t, "/api/v1/applications/"+applicationID.String()+"/offer-letter.pdf", *decision.OfferLetterURL)
+	require.NotNil(t, decision.OfferValidUntil)
+
+	pdf, err := f.service.GetOfferLetter(ctx, f.tenantID, applicationID)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+
+	offer, err := f.service.GetOfferByToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "Green Valley School", offer.SchoolName)
+	assert.Equal(t, "INR", offer.Currency)
+	assert.Equal(t, "Asha", offer.Application.StudentName)
+	assert.True(t, offer.Decision.OfferFees.Total().Equal(decimal.NewFromInt(43000)))
+	assert.NotNil(t, offer.Decision.OfferViewedAt)
+
+	// Regenerating invalidates the previous link
+	_, newToken, err := f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{
+		TenantID:      f.tenantID,
+		ApplicationID: applicationID,
+		Fees:          fees,
+	})
+	require.NoError(t, err)
+	_, err = f.service.GetOfferByToken(ctx, token)
+	assert.ErrorIs(t, err, ErrOfferLinkInvalid)
+	_, err = f.service.GetOfferLetterByToken(ctx, newToken)
+	assert.NoError(t, err)
+}
+
+func TestDecisionService_GenerateOfferLetterValidation(t *testing.T) {
+	f := setupOffers(t)
+	ctx := context.Background()
+	position := 1
+	waitlistedID := f.createApplication(t, "Ravi", models.DecisionWaitlisted, &position)
+	approvedID := f.createApplication(t, "Meera", models.DecisionApproved, nil)
+
+	_, _, err := f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{TenantID: f.tenantID, ApplicationID: waitlistedID})
+	assert.ErrorIs(t, err, ErrOfferNotFound)
+
+	_, _, err = f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{
+		TenantID:      f.tenantID,
+		ApplicationID: approvedID,
+		Fees:          models.OfferFeeItems{{Name: "Refund", Amount: decimal.NewFromInt(-100)}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidOfferFee)
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	_, _, err = f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{
+		TenantID:      f.tenantID,
+		ApplicationID: approvedID,
+		ValidUntil:    &yesterday,
+	})
+	assert.ErrorIs(t, err, ErrOfferExpired)
+
+	_, err = f.service.GetOfferLetter(ctx, f.tenantID, approvedID)
+	assert.ErrorIs(t, err, ErrOfferLetterNotGenerated)
+}
+
+func TestDecisionService_AcceptOfferByToken(t *testing.T) {
+	f := setupOffers(t)
+	ctx := context.Background()
+	applicationID := f.createApplication(t, "Asha", models.DecisionApproved, nil)
+
+	_, token, err := f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{TenantID: f.tenantID, ApplicationID: applicationID})
+	require.NoError(t, err)
+
+	decision, err := f.service.AcceptOfferByToken(ctx, token)
+	require.NoError(t, err)
+	assert.True(t, decision.IsOfferAccepted())
+	assert.NotNil(t, decision.OfferAcceptedAt)
+
+	_, err = f.service.AcceptOfferByToken(ctx, token)
+	assert.ErrorIs(t, err, ErrOfferAlreadyAccepted)
+
+	_, err = f.service.AcceptOfferByToken(ctx, "not-a-token")
+	assert.ErrorIs(t, err, ErrOfferLinkInvalid)
+}
+
+func TestDecisionService_LapseExpiredOffers(t *testing.T) {
+	f := setupOffers(t)
+	ctx := context.Background()
+	expiredID := f.createApplication(t, "Asha", models.DecisionApproved, nil)
+	openID := f.createApplication(t, "Meera", models.DecisionApproved, nil)
+	second, first := 2, 1
+	laterID := f.createApplication(t, "Ravi", models.DecisionWaitlisted, &second)
+	nextID := f.createApplication(t, "Kiran", models.DecisionWaitlisted, &first)
+
+	_, expiredToken, err := f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{TenantID: f.tenantID, ApplicationID: expiredID})
+	require.NoError(t, err)
+	_, _, err = f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{TenantID: f.tenantID, ApplicationID: openID})
+	require.NoError(t, err)
+
+	// Both offers are valid for DefaultOfferValidityDays; only the first is backdated
+	require.NoError(t, f.db.Model(&models.AdmissionDecision{}).
+		Where("application_id = ?", expiredID).
+		Update("offer_valid_until", time.Now().AddDate(0, 0, -2)).Error)
+
+	lapsed, err := f.service.LapseExpiredOffers(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, lapsed)
+
+	expired, err := f.service.GetDecisionByApplication(ctx, f.tenantID, expiredID)
+	require.NoError(t, err)
+	assert.NotNil(t, expired.OfferLapsedAt)
+	assert.False(t, expired.IsOfferAccepted())
+
+	var application models.AdmissionApplication
+	require.NoError(t, f.db.First(&application, "id = ?", expiredID).Error)
+	assert.Equal(t, models.ApplicationStatusWithdrawn, application.Status)
+
+	// The seat goes to the top of the waitlist
+	promoted, err := f.service.GetDecisionByApplication(ctx, f.tenantID, nextID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DecisionApproved, promoted.Decision)
+	stillWaiting, err := f.service.GetDecisionByApplication(ctx, f.tenantID, laterID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DecisionWaitlisted, stillWaiting.Decision)
+
+	open, err := f.service.GetDecisionByApplication(ctx, f.tenantID, openID)
+	require.NoError(t, err)
+	assert.Nil(t, open.OfferLapsedAt)
+
+	// A lapsed offer can no longer be accepted, and a second sweep finds nothing
+	_, err = f.service.AcceptOfferByToken(ctx, expiredToken)
+	assert.ErrorIs(t, err, ErrOfferLapsed)
+	lapsed, err = f.service.LapseExpiredOffers(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, lapsed)
+}
+
+func TestDecisionService_AcceptExpiredOfferLapsesIt(t *testing.T) {
+	f := setupOffers(t)
+	ctx := context.Background()
+	applicationID := f.createApplication(t, "Asha", models.DecisionApproved, nil)
+	position := 1
+	waitlistedID := f.createApplication(t, "Kiran", models.DecisionWaitlisted, &position)
+
+	_, token, err := f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{TenantID: f.tenantID, ApplicationID: applicationID})
+	require.NoError(t, err)
+	require.NoError(t, f.db.Model(&models.AdmissionDecision{}).
+		Where("application_id = ?", applicationID).
+		Update("offer_valid_until", time.Now().AddDate(0, 0, -1)).Error)
+
+	_, err = f.service.AcceptOfferByToken(ctx, token)
+	assert.ErrorIs(t, err, ErrOfferExpired)
+
+	promoted, err := f.service.GetDecisionByApplication(ctx, f.tenantID, waitlistedID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DecisionApproved, promoted.Decision)
+}
@@ -0,0 +1,591 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// DefaultEnquiryAutoCloseDays is the inactivity period after which open enquiries
+// are closed when a tenant has not configured its own.
+const DefaultEnquiryAutoCloseDays = 60
+
+// EnquiryAutoCloseInterval is how often inactive enquiries are swept.
+const EnquiryAutoCloseInterval = time.Hour
+
+// EnquiryClosedReasonInactive marks enquiries closed by the inactivity sweep.
+const EnquiryClosedReasonInactive = "inactive"
+
+// openEnquiryStatuses are the statuses counsellors still work on.
+var openEnquiryStatuses = []EnquiryStatus{
+	EnquiryStatusNew,
+	EnquiryStatusContacted,
+	EnquiryStatusIntersted,
+}
+
+// Lead score weights. The three components add up to 100.
+const (
+	leadScoreResponsivenessMax  = 40
+	leadScoreResponsivenessBase = 20
+	leadScoreSeatsMax           = 30
+)
+
+// leadScoreBySource scores how likely an enquiry source is to convert.
+var leadScoreBySource = map[EnquirySource]int{
+	EnquirySourceReferral:      30,
+	EnquirySourceWalkIn:        25,
+	EnquirySourceWebsite:       20,
+	EnquirySourcePhone:         20,
+	EnquirySourceSocialMedia:   15,
+	EnquirySourceAdvertisement: 10,
+	EnquirySourceOther:         10,
+}
+
+// leadScoreByOutcome adjusts responsiveness for each follow-up outcome.
+var leadScoreByOutcome = map[FollowUpOutcome]int{
+	FollowUpOutcomeInterested:  10,
+	FollowUpOutcomeFollowUpReq: 5,
+	FollowUpOutcomeNoResponse:  -10,
+}
+
+// EnquiryCounsellor is a user in the tenant's counsellor pool for enquiry assignment.
+type EnquiryCounsellor struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	TenantID       uuid.UUID  `gorm:"type:uuid;not null"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null"`
+	LastAssignedAt *time.Time `gorm:"type:timestamptz"`
+	CreatedAt      time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"not null;default:now()"`
+	CreatedBy      *uuid.UUID `gorm:"type:uuid"`
+}
+
+// TableName returns the table name for the model.
+func (EnquiryCounsellor) TableName() string {
+	return "enquiry_counsellors"
+}
+
+// EnquiryCRMSettings holds a tenant's enquiry assignment and auto-close settings.
+type EnquiryCRMSettings struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	TenantID           uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	AutoAssign         bool       `gorm:"not null;default:false"`
+	AutoCloseAfterDays int        `gorm:"not null;default:60"`
+	CreatedAt          time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt          time.Time  `gorm:"not null;default:now()"`
+	UpdatedBy          *uuid.UUID `gorm:"type:uuid"`
+}
+
+// TableName returns the table name for the model.
+func (EnquiryCRMSettings) TableName() string {
+	return "enquiry_crm_settings"
+}
+
+// UpdateCRMSettingsRequest represents a request to update enquiry CRM settings.
+type UpdateCRMSettingsRequest struct {
+	TenantID           uuid.UUID
+	AutoAssign         *bool
+	AutoCloseAfterDays *int
+	UpdatedBy          *uuid.UUID
+}
+
+// CounsellorSummary is a counsellor with their current open workload.
+type CounsellorSummary struct {
+	UserID         uuid.UUID
+	Name           string
+	LastAssignedAt *time.Time
+	OpenEnquiries  int64
+}
+
+// WorkQueueFilter selects the follow-ups for a counsellor work queue.
+type WorkQueueFilter struct {
+	TenantID   uuid.UUID
+	AssignedTo *uuid.UUID
+	BranchID   *uuid.UUID
+	Date       time.Time
+}
+
+// WorkQueue lists open enquiries whose follow-up is overdue or due on the queue date.
+type WorkQueue struct {
+	Date     time.Time
+	Overdue  []AdmissionEnquiry
+	DueToday []AdmissionEnquiry
+}
+
+// LeadScore is the breakdown of an enquiry's lead score.
+type LeadScore struct {
+	Source           int
+	Responsiveness   int
+	SeatAvailability int
+	Total            int
+}
+
+// GetCRMSettings returns the tenant's enquiry CRM settings, or the defaults if none are saved.
+func (s *EnquiryService) GetCRMSettings(ctx context.Context, tenantID uuid.UUID) (*EnquiryCRMSettings, error) {
+	if tenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+	return crmSettings(s.db.WithContext(ctx), tenantID)
+}
+
+// UpdateCRMSettings creates or updates the tenant's enquiry CRM settings.
+func (s *EnquiryService) UpdateCRMSettings(ctx context.Context, req UpdateCRMSettingsRequest) (*EnquiryCRMSettings, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+	if req.AutoCloseAfterDays != nil && *req.AutoCloseAfterDays < 0 {
+		return nil, ErrInvalidAutoCloseDays
+	}
+
+	settings, err := crmSettings(s.db.WithContext(ctx), req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if req.AutoAssign != nil {
+		settings.AutoAssign = *req.AutoAssign
+	}
+	if req.AutoCloseAfterDays != nil {
+		settings.AutoCloseAfterDays = *req.AutoCloseAfterDays
+	}
+	settings.UpdatedBy = req.UpdatedBy
+
+	if settings.ID == uuid.Nil {
+		err = s.db.WithContext(ctx).Create(settings).Error
+	} else {
+		err = s.db.WithContext(ctx).Model(settings).Updates(map[string]interface{}{
+			"auto_assign":           settings.AutoAssign,
+			"auto_close_after_days": settings.AutoCloseAfterDays,
+			"updated_by":            settings.UpdatedBy,
+		}).Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save enquiry settings: %w", err)
+	}
+
+	return crmSettings(s.db.WithContext(ctx), req.TenantID)
+}
+
+// ListCounsellors returns the tenant's counsellor pool with each counsellor's open enquiry count.
+func (s *EnquiryService) ListCounsellors(ctx context.Context, tenantID uuid.UUID) ([]CounsellorSummary, error) {
+	if tenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	var rows []struct {
+		UserID         uuid.UUID
+		FirstName      string
+		LastName       string
+		LastAssignedAt *time.Time
+		OpenEnquiries  int64
+	}
+	err := s.db.WithContext(ctx).
+		Table("enquiry_counsellors c").
+		Select(`c.user_id, u.first_name, u.last_name, c.last_assigned_at,
+			(SELECT COUNT(*) FROM admission_enquiries e
+			  WHERE e.tenant_id = c.tenant_id AND e.assigned_to = c.user_id
+			    AND e.status IN ? AND e.deleted_at IS NULL) AS open_enquiries`, openEnquiryStatuses).
+		Joins("JOIN users u ON u.id = c.user_id").
+		Where("c.tenant_id = ?", tenantID).
+		Order("u.first_name, u.last_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list counsellors: %w", err)
+	}
+
+	counsellors := make([]CounsellorSummary, len(rows))
+	for i, r := range rows {
+		counsellors[i] = CounsellorSummary{
+			UserID:         r.UserID,
+			Name:           fullName(r.FirstName, r.LastName),
+			LastAssignedAt: r.LastAssignedAt,
+			OpenEnquiries:  r.OpenEnquiries,
+		}
+	}
+	return counsellors, nil
+}
+
+// AddCounsellor adds an active tenant user to the counsellor pool.
+func (s *EnquiryService) AddCounsellor(ctx context.Context, tenantID, userID uuid.UUID, createdBy *uuid.UUID) (*EnquiryCounsellor, error) {
+	if tenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	var user models.User
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ? AND status = ?", tenantID, userID, models.StatusActive).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var existing int64
+	if err := s.db.WithContext(ctx).Model(&EnquiryCounsellor{}).
+		Where("tenant_id = ? AND user_id = ?", tenantID, userID).
+		Count(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to check counsellor: %w", err)
+	}
+	if existing > 0 {
+		return nil, ErrCounsellorAlreadyAdded
+	}
+
+	counsellor := &EnquiryCounsellor{
+		TenantID:  tenantID,
+		UserID:    userID,
+		CreatedBy: createdBy,
+	}
+	if err := s.db.WithContext(ctx).Create(counsellor).Error; err != nil {
+		return nil, fmt.Errorf("failed to add counsellor: %w", err)
+	}
+	return counsellor, nil
+}
+
+// RemoveCounsellor removes a user from the counsellor pool.
+// Enquiries already assigned to them stay assigned.
+func (s *EnquiryService) RemoveCounsellor(ctx context.Context, tenantID, userID uuid.UUID) error {
+	result := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND user_id = ?", tenantID, userID).
+		Delete(&EnquiryCounsellor{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove counsellor: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCounsellorNotFound
+	}
+	return nil
+}
+
+// AssignEnquiry assigns an open enquiry to a counsellor in the pool.
+func (s *EnquiryService) AssignEnquiry(ctx context.Context, tenantID, enquiryID, counsellorID uuid.UUID, assignedBy *uuid.UUID) (*AdmissionEnquiry, error) {
+	enquiry, err := s.GetByID(ctx, tenantID, enquiryID)
+	if err != nil {
+		return nil, err
+	}
+	if enquiry.Status == EnquiryStatusClosed || enquiry.Status == EnquiryStatusConverted {
+		return nil, ErrEnquiryClosed
+	}
+
+	var counsellor EnquiryCounsellor
+	err = s.db.WithContext(ctx).
+		Where("tenant_id = ? AND user_id = ?", tenantID, counsellorID).
+		First(&counsellor).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCounsellorNotFound
+		}
+		return nil, fmt.Errorf("failed to get counsellor: %w", err)
+	}
+
+	now := time.Now()
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(enquiry).Updates(map[string]interface{}{
+			"assigned_to": counsellorID,
+			"assigned_at": now,
+			"updated_by":  assignedBy,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to assign enquiry: %w", err)
+		}
+		if err := tx.Model(&counsellor).Update("last_assigned_at", now).Error; err != nil {
+			return fmt.Errorf("failed to update counsellor: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, tenantID, enquiryID)
+}
+
+// DistributeUnassigned assigns every open unassigned enquiry round-robin across the
+// counsellor pool, highest lead score first. It returns the number of enquiries assigned.
+func (s *EnquiryService) DistributeUnassigned(ctx context.Context, tenantID uuid.UUID, branchID *uuid.UUID) (int, error) {
+	if tenantID == uuid.Nil {
+		return 0, ErrTenantIDRequired
+	}
+
+	assigned := 0
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var counsellors int64
+		if err := tx.Model(&EnquiryCounsellor{}).Where("tenant_id = ?", tenantID).Count(&counsellors).Error; err != nil {
+			return fmt.Errorf("failed to count counsellors: %w", err)
+		}
+		if counsellors == 0 {
+			return ErrNoCounsellors
+		}
+
+		query := tx.Where("tenant_id = ? AND assigned_to IS NULL AND status IN ?", tenantID, openEnquiryStatuses)
+		if branchID != nil {
+			query = query.Where("branch_id = ?", *branchID)
+		}
+		var enquiries []AdmissionEnquiry
+		if err := query.Order("lead_score DESC, created_at ASC").Find(&enquiries).Error; err != nil {
+			return fmt.Errorf("failed to list unassigned enquiries: %w", err)
+		}
+
+		now := time.Now()
+		for i := range enquiries {
+			counsellorID, err := nextCounsellor(tx, tenantID)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&enquiries[i]).Updates(map[string]interface{}{
+				"assigned_to": counsellorID,
+				"assigned_at": now,
+			}).Error; err != nil {
+				return fmt.Errorf("failed to assign enquiry: %w", err)
+			}
+			assigned++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return assigned, nil
+}
+
+// WorkQueue returns the open enquiries with a follow-up overdue or due on the filter
+// date, highest lead score first.
+func (s *EnquiryService) WorkQueue(ctx context.Context, filter WorkQueueFilter) (*WorkQueue, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+	date := filter.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	query := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND status IN ? AND follow_up_date IS NOT NULL AND follow_up_date <= ?",
+			filter.TenantID, openEnquiryStatuses, day)
+	if filter.AssignedTo != nil {
+		query = query.Where("assigned_to = ?", *filter.AssignedTo)
+	}
+	if filter.BranchID != nil {
+		query = query.Where("branch_id = ?", *filter.BranchID)
+	}
+
+	var enquiries []AdmissionEnquiry
+	if err := query.Order("lead_score DESC, follow_up_date ASC, created_at ASC").Find(&enquiries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get work queue: %w", err)
+	}
+
+	queue := &WorkQueue{
+		Date:     day,
+		Overdue:  make([]AdmissionEnquiry, 0),
+		DueToday: make([]AdmissionEnquiry, 0),
+	}
+	for _, e := range enquiries {
+		if e.FollowUpDate.Before(day) {
+			queue.Overdue = append(queue.Overdue, e)
+		} else {
+			queue.DueToday = append(queue.DueToday, e)
+		}
+	}
+	return queue, nil
+}
+
+// GetLeadScore computes the current lead score breakdown for an enquiry.
+func (s *EnquiryService) GetLeadScore(ctx context.Context, tenantID, enquiryID uuid.UUID) (*LeadScore, error) {
+	enquiry, err := s.GetByID(ctx, tenantID, enquiryID)
+	if err != nil {
+		return nil, err
+	}
+	return computeLeadScore(s.db.WithContext(ctx), enquiry)
+}
+
+// RecalculateLeadScores refreshes the stored lead score of every open enquiry, for
+// example after seat configurations change. It returns the number of enquiries scored.
+func (s *EnquiryService) RecalculateLeadScores(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	if tenantID == uuid.Nil {
+		return 0, ErrTenantIDRequired
+	}
+
+	var ids []uuid.UUID
+	if err := s.db.WithContext(ctx).Model(&AdmissionEnquiry{}).
+		Where("tenant_id = ? AND status IN ?", tenantID, openEnquiryStatuses).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("failed to list enquiries: %w", err)
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			if err := refreshLeadScore(tx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// CloseInactiveEnquiries closes open enquiries across all tenants that have had no
+// activity for the tenant's auto-close period and have no follow-up still to come.
+// It returns the number of enquiries closed.
+func (s *EnquiryService) CloseInactiveEnquiries(ctx context.Context, now time.Time) (int, error) {
+	var tenantIDs []uuid.UUID
+	var settings []EnquiryCRMSettings
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the sweep runs outside any tenant request
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		if err := tx.Model(&AdmissionEnquiry{}).
+			Where("status IN ?", openEnquiryStatuses).
+			Distinct().Pluck("tenant_id", &tenantIDs).Error; err != nil {
+			return err
+		}
+		if len(tenantIDs) == 0 {
+			return nil
+		}
+		return tx.Where("tenant_id IN ?", tenantIDs).Find(&settings).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tenants with open enquiries: %w", err)
+	}
+
+	days := make(map[uuid.UUID]int, len(settings))
+	for _, st := range settings {
+		days[st.TenantID] = st.AutoCloseAfterDays
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	closed := 0
+	var errs []error
+	for _, tenantID := range tenantIDs {
+		after, ok := days[tenantID]
+		if !ok {
+			after = DefaultEnquiryAutoCloseDays
+		}
+		if after == 0 {
+			continue
+		}
+		cutoff := now.AddDate(0, 0, -after)
+
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+			result := tx.Model(&AdmissionEnquiry{}).
+				Where("tenant_id = ? AND status IN ?", tenantID, openEnquiryStatuses).
+				Where("COALESCE(last_activity_at, created_at) < ?", cutoff).
+				Where("follow_up_date IS NULL OR follow_up_date < ?", today).
+				Updates(map[string]interface{}{
+					"status":        EnquiryStatusClosed,
+					"closed_reason": EnquiryClosedReasonInactive,
+					"updated_at":    now,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			closed += int(result.RowsAffected)
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenantID, err))
+		}
+	}
+	return closed, errors.Join(errs...)
+}
+
+// crmSettings loads the tenant's CRM settings, returning unsaved defaults if none exist.
+func crmSettings(tx *gorm.DB, tenantID uuid.UUID) (*EnquiryCRMSettings, error) {
+	var settings EnquiryCRMSettings
+	err := tx.Where("tenant_id = ?", tenantID).First(&settings).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &EnquiryCRMSettings{
+				TenantID:           tenantID,
+				AutoCloseAfterDays: DefaultEnquiryAutoCloseDays,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get enquiry settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// nextCounsellor picks the counsellor who has gone longest without an assignment and
+// marks them as assigned now. It returns nil when the pool is empty.
+func nextCounsellor(tx *gorm.DB, tenantID uuid.UUID) (*uuid.UUID, error) {
+	var counsellor EnquiryCounsellor
+	err := tx.Where("tenant_id = ?", tenantID).
+		Order("last_assigned_at ASC NULLS FIRST, created_at ASC, id ASC").
+		First(&counsellor).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to pick counsellor: %w", err)
+	}
+	// Each pick gets its own timestamp so a batch keeps rotating through the pool
+	if err := tx.Model(&counsellor).Update("last_assigned_at", time.Now()).Error; err != nil {
+		return nil, fmt.Errorf("failed to update counsellor: %w", err)
+	}
+	return &counsellor.UserID, nil
+}
+
+// refreshLeadScore recomputes and stores an enquiry's lead score.
+func refreshLeadScore(tx *gorm.DB, enquiryID uuid.UUID) error {
+	var enquiry AdmissionEnquiry
+	if err := tx.Where("id = ?", enquiryID).First(&enquiry).Error; err != nil {
+		return fmt.Errorf("failed to get enquiry: %w", err)
+	}
+	score, err := computeLeadScore(tx, &enquiry)
+	if err != nil {
+		return err
+	}
+	if err := tx.Model(&enquiry).UpdateColumn("lead_score", score.Total).Error; err != nil {
+		return fmt.Errorf("failed to update lead score: %w", err)
+	}
+	return nil
+}
+
+// computeLeadScore scores an enquiry from 0 to 100 on its source, how the family has
+// responded to follow-ups and how many seats remain in the class applied for.
+func computeLeadScore(tx *gorm.DB, enquiry *AdmissionEnquiry) (*LeadScore, error) {
+	score := &LeadScore{Source: leadScoreBySource[enquiry.Source]}
+
+	var outcomes []FollowUpOutcome
+	if err := tx.Model(&EnquiryFollowUp{}).
+		Where("enquiry_id = ? AND outcome IS NOT NULL", enquiry.ID).
+		Pluck("outcome", &outcomes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get follow-up outcomes: %w", err)
+	}
+	responsiveness := leadScoreResponsivenessBase
+	for _, o := range outcomes {
+		responsiveness += leadScoreByOutcome[o]
+	}
+	score.Responsiveness = min(max(responsiveness, 0), leadScoreResponsivenessMax)
+
+	// Without a session or seat configuration the class counts as half full
+	score.SeatAvailability = leadScoreSeatsMax / 2
+	if enquiry.SessionID != nil {
+		var seat models.AdmissionSeat
+		err := tx.Where("tenant_id = ? AND session_id = ? AND class_name = ?",
+			enquiry.TenantID, *enquiry.SessionID, enquiry.ClassApplying).
+			First(&seat).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get seat configuration: %w", err)
+		}
+		if err == nil && seat.TotalSeats > 0 {
+			score.SeatAvailability = max(seat.AvailableSeats(), 0) * leadScoreSeatsMax / seat.TotalSeats
+		}
+	}
+
+	score.Total = score.Source + score.Responsiveness + score.SeatAvailability
+	return score, nil
+}
+
+// fullName joins a user's first and last name.
+func fullName(firstName, lastName string) string {
+	return strings.TrimSpace(firstName + " " + lastName)
+}
@@ -0,0 +1,319 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+type enquiryCRMFixture struct {
+	db        *gorm.DB
+	service   *EnquiryService
+	tenantID  uuid.UUID
+	sessionID uuid.UUID
+	users     []uuid.UUID
+}
+
+// setupEnquiryCRM creates an enquiry service for a tenant with one admission session,
+// a Class 1 seat configuration (40 seats, 30 filled) and three active users.
+func setupEnquiryCRM(t *testing.T) *enquiryCRMFixture {
+	t.Helper()
+
+	db := newAdmissionTestDB(t,
+		&models.Tenant{}, &models.User{}, &models.AdmissionSession{}, &models.AdmissionSeat{},
+		&AdmissionEnquiry{}, &EnquiryFollowUp{}, &EnquiryCounsellor{}, &EnquiryCRMSettings{},
+	)
+	session := seedAdmissionSession(t, db, models.SessionSettings{})
+
+	require.NoError(t, db.Create(&models.AdmissionSeat{
+		ID:            uuid.New(),
+		TenantID:      session.TenantID,
+		SessionID:     session.ID,
+		ClassName:     "Class 1",
+		TotalSeats:    40,
+		FilledSeats:   30,
+		ReservedSeats: models.ReservedSeats{},
+	}).Error)
+
+	f := &enquiryCRMFixture{
+		db:        db,
+		service:   NewEnquiryService(db),
+		tenantID:  session.TenantID,
+		sessionID: session.ID,
+	}
+	for _, name := range []string{"Anita", "Bharat", "Chitra"} {
+		user := &models.User{TenantID: session.TenantID, FirstName: name, LastName: "Counsellor", Status: models.StatusActive}
+		user.ID = uuid.New()
+		require.NoError(t, db.Create(user).Error)
+		f.users = append(f.users, user.ID)
+	}
+	return f
+}
+
+// createEnquiry inserts an open enquiry directly, bypassing the Postgres enquiry number function.
+func (f *enquiryCRMFixture) createEnquiry(t *testing.T, e AdmissionEnquiry) *AdmissionEnquiry {
+	t.Helper()
+	e.ID = uuid.New()
+	e.TenantID = f.tenantID
+	e.EnquiryNumber = "ENQ-" + e.ID.String()[:8]
+	if e.StudentName == "" {
+		e.StudentName = "Student"
+	}
+	if e.ClassApplying == "" {
+		e.ClassApplying = "Class 1"
+	}
+	e.ParentName = "Parent"
+	e.ParentPhone = "9800000000"
+	if e.Source == "" {
+		e.Source = EnquirySourceWalkIn
+	}
+	if e.Status == "" {
+		e.Status = EnquiryStatusNew
+	}
+	require.NoError(t, f.db.Create(&e).Error)
+	return &e
+}
+
+func dateOnly(t time.Time) *time.Time {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestEnquiryCRM_LeadScore(t *testing.T) {
+	f := setupEnquiryCRM(t)
+	ctx := context.Background()
+
+	enquiry := f.createEnquiry(t, AdmissionEnquiry{SessionID: &f.sessionID, Source: EnquirySourceReferral})
+	for _, outcome := range []FollowUpOutcome{FollowUpOutcomeInterested, FollowUpOutcomeNoResponse} {
+		o := outcome
+		require.NoError(t, f.db.Create(&EnquiryFollowUp{
+			ID:           uuid.New(),
+			TenantID:     f.tenantID,
+			EnquiryID:    enquiry.ID,
+			FollowUpDate: time.Now(),
+			ContactMode:  ContactModePhone,
+			Outcome:      &o,
+		}).Error)
+	}
+
+	score, err := f.service.GetLeadScore(ctx, f.tenantID, enquiry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 30, score.Source)
+	assert.Equal(t, 20, score.Responsiveness)
+	assert.Equal(t, 7, score.SeatAvailability, "10 of 40 seats left")
+	assert.Equal(t, 57, score.Total)
+
+	// Logging an interested follow-up stores the refreshed score
+	interested := FollowUpOutcomeInterested
+	_, err = f.service.AddFollowUp(ctx, CreateFollowUpRequest{
+		TenantID:     f.tenantID,
+		EnquiryID:    enquiry.ID,
+		FollowUpDate: time.Now(),
+		Outcome:      &interested,
+	})
+	require.NoError(t, err)
+
+	stored, err := f.service.GetByID(ctx, f.tenantID, enquiry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 67, stored.LeadScore)
+	assert.NotNil(t, stored.LastActivityAt)
+
+	t.Run("no session scores seats as half full", func(t *testing.T) {
+		e := f.createEnquiry(t, AdmissionEnquiry{Source: EnquirySourceAdvertisement})
+		score, err := f.service.GetLeadScore(ctx, f.tenantID, e.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 10+20+15, score.Total)
+	})
+
+	t.Run("full class scores no seats", func(t *testing.T) {
+		require.NoError(t, f.db.Model(&models.AdmissionSeat{}).
+			Where("session_id = ?", f.sessionID).Update("filled_seats", 40).Error)
+		score, err := f.service.GetLeadScore(ctx, f.tenantID, enquiry.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, score.SeatAvailability)
+	})
+}
+
+func TestEnquiryCRM_Counsellors(t *testing.T) {
+	f := setupEnquiryCRM(t)
+	ctx := context.Background()
+
+	_, err := f.service.DistributeUnassigned(ctx, f.tenantID, nil)
+	assert.ErrorIs(t, err, ErrNoCounsellors)
+
+	_, err = f.service.AddCounsellor(ctx, f.tenantID, uuid.New(), nil)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	for _, userID := range f.users {
+		_, err := f.service.AddCounsellor(ctx, f.tenantID, userID, nil)
+		require.NoError(t, err)
+	}
+	_, err = f.service.AddCounsellor(ctx, f.tenantID, f.users[0], nil)
+	assert.ErrorIs(t, err, ErrCounsellorAlreadyAdded)
+
+	t.Run("distributes round-robin by lead score", func(t *testing.T) {
+		scores := []int{40, 90, 60, 70}
+		enquiries := make([]*AdmissionEnquiry, len(scores))
+		for i, score := range scores {
+			enquiries[i] = f.createEnquiry(t, AdmissionEnquiry{LeadScore: score})
+		}
+		f.createEnquiry(t, AdmissionEnquiry{Status: EnquiryStatusClosed})
+
+		assigned, err := f.service.DistributeUnassigned(ctx, f.tenantID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 4, assigned)
+
+		owner := func(e *AdmissionEnquiry) uuid.UUID {
+			stored, err := f.service.GetByID(ctx, f.tenantID, e.ID)
+			require.NoError(t, err)
+			require.NotNil(t, stored.AssignedTo)
+			return *stored.AssignedTo
+		}
+		// Scores 90, 70, 60 go to one counsellor each, then 40 wraps to the first
+		first := owner(enquiries[1])
+		assert.NotEqual(t, first, owner(enquiries[3]))
+		assert.NotEqual(t, owner(enquiries[3]), owner(enquiries[2]))
+		assert.NotEqual(t, first, owner(enquiries[2]))
+		assert.Equal(t, first, owner(enquiries[0]))
+
+		counsellors, err := f.service.ListCounsellors(ctx, f.tenantID)
+		require.NoError(t, err)
+		require.Len(t, counsellors, 3)
+		open := map[uuid.UUID]int64{}
+		for _, c := range counsellors {
+			open[c.UserID] = c.OpenEnquiries
+		}
+		assert.Equal(t, int64(2), open[first])
+	})
+
+	t.Run("manual assignment", func(t *testing.T) {
+		e := f.createEnquiry(t, AdmissionEnquiry{})
+		assigned, err := f.service.AssignEnquiry(ctx, f.tenantID, e.ID, f.users[2], nil)
+		require.NoError(t, err)
+		assert.Equal(t, f.users[2], *assigned.AssignedTo)
+		assert.NotNil(t, assigned.AssignedAt)
+
+		require.NoError(t, f.service.RemoveCounsellor(ctx, f.tenantID, f.users[2]))
+		_, err = f.service.AssignEnquiry(ctx, f.tenantID, e.ID, f.users[2], nil)
+		assert.ErrorIs(t, err, ErrCounsellorNotFound)
+
+		closed := f.createEnquiry(t, AdmissionEnquiry{Status: EnquiryStatusConverted})
+		_, err = f.service.AssignEnquiry(ctx, f.tenantID, closed.ID, f.users[0], nil)
+		assert.ErrorIs(t, err, ErrEnquiryClosed)
+	})
+}
+
+func TestEnquiryCRM_WorkQueue(t *testing.T) {
+	f := setupEnquiryCRM(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	counsellor, other := f.users[0], f.users[1]
+
+	overdue := f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &counsellor, FollowUpDate: dateOnly(now.AddDate(0, 0, -2))})
+	lowToday := f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &counsellor, FollowUpDate: dateOnly(now), LeadScore: 30})
+	highToday := f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &counsellor, FollowUpDate: dateOnly(now), LeadScore: 80})
+	f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &counsellor, FollowUpDate: dateOnly(now.AddDate(0, 0, 1))})
+	f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &counsellor, FollowUpDate: dateOnly(now.AddDate(0, 0, -1)), Status: EnquiryStatusClosed})
+	f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &other, FollowUpDate: dateOnly(now)})
+
+	queue, err := f.service.WorkQueue(ctx, WorkQueueFilter{TenantID: f.tenantID, AssignedTo: &counsellor, Date: now})
+	require.NoError(t, err)
+	require.Len(t, queue.Overdue, 1)
+	assert.Equal(t, overdue.ID, queue.Overdue[0].ID)
+	require.Len(t, queue.DueToday, 2)
+	assert.Equal(t, highToday.ID, queue.DueToday[0].ID)
+	assert.Equal(t, lowToday.ID, queue.DueToday[1].ID)
+}
+
+func TestEnquiryCRM_CloseInactiveEnquiries(t *testing.T) {
+	f := setupEnquiryCRM(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	ago := func(days int) *time.Time {
+		t := now.AddDate(0, 0, -days)
+		return &t
+	}
+
+	stale := f.createEnquiry(t, AdmissionEnquiry{LastActivityAt: ago(70)})
+	scheduled := f.createEnquiry(t, AdmissionEnquiry{LastActivityAt: ago(70), FollowUpDate: dateOnly(now.AddDate(0, 0, 3))})
+	recent := f.createEnquiry(t, AdmissionEnquiry{LastActivityAt: ago(10)})
+
+	status := func(e *AdmissionEnquiry) (EnquiryStatus, string) {
+		var stored AdmissionEnquiry
+		require.NoError(t, f.db.First(&stored, "id = ?", e.ID).Error)
+		return stored.Status, stored.ClosedReason
+	}
+
+	closed, err := f.service.CloseInactiveEnquiries(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	s, reason := status(stale)
+	assert.Equal(t, EnquiryStatusClosed, s)
+	assert.Equal(t, EnquiryClosedReasonInactive, reason)
+	s, _ = status(scheduled)
+	assert.Equal(t, EnquiryStatusNew, s)
+	s, _ = status(recent)
+	assert.Equal(t, EnquiryStatusNew, s)
+
+	t.Run("tenant setting shortens the period", func(t *testing.T) {
+		days := 7
+		_, err := f.service.UpdateCRMSettings(ctx, UpdateCRMSettingsRequest{TenantID: f.tenantID, AutoCloseAfterDays: &days})
+		require.NoError(t, err)
+		closed, err := f.service.CloseInactiveEnquiries(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, closed)
+		s, _ := status(recent)
+		assert.Equal(t, EnquiryStatusClosed, s)
+	})
+
+	t.Run("zero days disables auto-close", func(t *testing.T) {
+		days := 0
+		_, err := f.service.UpdateCRMSettings(ctx, UpdateCRMSettingsRequest{TenantID: f.tenantID, AutoCloseAfterDays: &days})
+		require.NoError(t, err)
+		f.createEnquiry(t, AdmissionEnquiry{LastActivityAt: ago(400)})
+		closed, err := f.service.CloseInactiveEnquiries(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, closed)
+
+		days = -1
+		_, err = f.service.UpdateCRMSettings(ctx, UpdateCRMSettingsRequest{TenantID: f.tenantID, AutoCloseAfterDays: &days})
+		assert.ErrorIs(t, err, ErrInvalidAutoCloseDays)
+	})
+}
+
+func TestReportService_GetCounsellorPerformance(t *testing.T) {
+	f := setupEnquiryCRM(t)
+	ctx := context.Background()
+	anita, bharat := f.users[0], f.users[1]
+
+	for _, status := range []EnquiryStatus{EnquiryStatusConverted, EnquiryStatusConverted, EnquiryStatusClosed, EnquiryStatusNew, EnquiryStatusNew} {
+		f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &anita, Status: status, LeadScore: 50})
+	}
+	f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &bharat, Status: EnquiryStatusConverted, LeadScore: 80})
+	f.createEnquiry(t, AdmissionEnquiry{AssignedTo: &bharat, Status: EnquiryStatusContacted, LeadScore: 40})
+	f.createEnquiry(t, AdmissionEnquiry{Status: EnquiryStatusConverted})
+
+	report, err := NewReportService(f.db).GetCounsellorPerformance(ctx, DashboardFilter{TenantID: f.tenantID})
+	require.NoError(t, err)
+	require.Len(t, report.Counsellors, 2)
+
+	first, second := report.Counsellors[0], report.Counsellors[1]
+	assert.Equal(t, anita, second.CounsellorID)
+	assert.Equal(t, "Anita Counsellor", second.CounsellorName)
+	assert.Equal(t, int64(5), second.Assigned)
+	assert.Equal(t, int64(2), second.Open)
+	assert.Equal(t, int64(2), second.Converted)
+	assert.Equal(t, int64(1), second.Closed)
+	assert.Equal(t, 40.0, second.ConversionRate)
+
+	assert.Equal(t, bharat, first.CounsellorID)
+	assert.Equal(t, 50.0, first.ConversionRate)
+	assert.Equal(t, 60.0, first.AvgLeadScore)
+}
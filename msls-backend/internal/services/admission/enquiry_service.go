@@ -143,6 +143,10 @@ type AdmissionEnquiry struct {
 	Status                 EnquiryStatus  `gorm:"type:enquiry_status;not null;default:'new'"`
 	FollowUpDate           *time.Time     `gorm:"type:date"`
 	AssignedTo             *uuid.UUID     `gorm:"type:uuid"`
+	AssignedAt             *time.Time     `gorm:"type:timestamptz"`
+	LeadScore              int            `gorm:"not null;default:0"`
+	LastActivityAt         *time.Time     `gorm:"type:timestamptz"`
+	ClosedReason           string         `gorm:"type:varchar(50)"`
 	ConvertedApplicationID *uuid.UUID     `gorm:"type:uuid"`
 	CreatedAt              time.Time      `gorm:"not null;default:now()"`
 	UpdatedAt              time.Time      `gorm:"not null;default:now()"`
@@ -306,6 +310,7 @@ func (s *EnquiryService) Create(ctx context.Context, req CreateEnquiryRequest) (
 		return nil, fmt.Errorf("failed to generate enquiry number: %w", err)
 	}
 
+	now := time.Now()
 	enquiry := &AdmissionEnquiry{
 		TenantID:        req.TenantID,
 		BranchID:        req.BranchID,
@@ -324,12 +329,40 @@ func (s *EnquiryService) Create(ctx context.Context, req CreateEnquiryRequest) (
 		Status:          EnquiryStatusNew,
 		FollowUpDate:    req.FollowUpDate,
 		AssignedTo:      req.AssignedTo,
+		LastActivityAt:  &now,
 		CreatedBy:       req.CreatedBy,
 		UpdatedBy:       req.CreatedBy,
 	}
+	if req.AssignedTo != nil {
+		enquiry.AssignedAt = &now
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Hand new enquiries to the next counsellor when auto-assignment is on
+		if enquiry.AssignedTo == nil {
+			settings, err := crmSettings(tx, req.TenantID)
+			if err != nil {
+				return err
+			}
+			if settings.AutoAssign {
+				counsellorID, err := nextCounsellor(tx, req.TenantID)
+				if err != nil {
+					return err
+				}
+				if counsellorID != nil {
+					enquiry.AssignedTo = counsellorID
+					enquiry.AssignedAt = &now
+				}
+			}
+		}
 
-	if err := s.db.WithContext(ctx).Create(enquiry).Error; err != nil {
-		return nil, fmt.Errorf("failed to create enquiry: %w", err)
+		if err := tx.Create(enquiry).Error; err != nil {
+			return fmt.Errorf("failed to create enquiry: %w", err)
+		}
+		return refreshLeadScore(tx, enquiry.ID)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return s.GetByID(ctx, req.TenantID, enquiry.ID)
@@ -506,14 +539,24 @@ func (s *EnquiryService) Update(ctx context.Context, tenantID, id uuid.UUID, req
 	}
 	if req.AssignedTo != nil {
 		updates["assigned_to"] = req.AssignedTo
+		if enquiry.AssignedTo == nil || *enquiry.AssignedTo != *req.AssignedTo {
+			updates["assigned_at"] = time.Now()
+		}
 	}
 	if req.UpdatedBy != nil {
 		updates["updated_by"] = req.UpdatedBy
 	}
 
 	if len(updates) > 0 {
-		if err := s.db.WithContext(ctx).Model(enquiry).Updates(updates).Error; err != nil {
-			return nil, fmt.Errorf("failed to update enquiry: %w", err)
+		updates["last_activity_at"] = time.Now()
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(enquiry).Updates(updates).Error; err != nil {
+				return fmt.Errorf("failed to update enquiry: %w", err)
+			}
+			return refreshLeadScore(tx, enquiry.ID)
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -619,16 +662,16 @@ func (s *EnquiryService) AddFollowUp(ctx context.Context, req CreateFollowUpRequ
 			updates["follow_up_date"] = req.NextFollowUp
 		}
 
-		if len(updates) > 0 {
-			updates["updated_by"] = req.CreatedBy
-			if err := tx.Model(&AdmissionEnquiry{}).
-				Where("id = ?", req.EnquiryID).
-				Updates(updates).Error; err != nil {
-				return fmt.Errorf("failed to update enquiry: %w", err)
-			}
+		// Record the contact for inactivity tracking
+		updates["last_activity_at"] = time.Now()
+		updates["updated_by"] = req.CreatedBy
+		if err := tx.Model(&AdmissionEnquiry{}).
+			Where("id = ?", req.EnquiryID).
+			Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update enquiry: %w", err)
 		}
 
-		return nil
+		return refreshLeadScore(tx, req.EnquiryID)
 	})
 	if err != nil {
 		return nil, err
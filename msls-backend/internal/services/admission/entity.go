@@ -6,10 +6,13 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
 )
 
 // ApplicationStatus represents the status of an admission application.
@@ -398,6 +401,8 @@ type EntranceTest struct {
 	Subjects          TestSubjects       `gorm:"type:jsonb;default:'[]'" json:"subjects"`
 	Instructions      string             `gorm:"type:text" json:"instructions,omitempty"`
 	PassingPercentage decimal.Decimal    `gorm:"type:decimal(5,2);default:33.00" json:"passing_percentage"`
+	OnlineEnabled     bool               `gorm:"not null;default:false" json:"online_enabled"`
+	LateEntryMinutes  int                `gorm:"not null;default:15" json:"late_entry_minutes"`
 	CreatedAt         time.Time          `gorm:"not null;default:now()" json:"created_at"`
 	UpdatedAt         time.Time          `gorm:"not null;default:now()" json:"updated_at"`
 	CreatedBy         *uuid.UUID         `gorm:"type:uuid" json:"created_by,omitempty"`
@@ -412,6 +417,19 @@ func (EntranceTest) TableName() string {
 	return "entrance_tests"
 }
 
+// StartsAt returns the moment the test starts, combining TestDate with StartTime
+// in the server's local time zone.
+func (t *EntranceTest) StartsAt() (time.Time, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		clock, err := time.Parse(layout, t.StartTime)
+		if err == nil {
+			return time.Date(t.TestDate.Year(), t.TestDate.Month(), t.TestDate.Day(),
+				clock.Hour(), clock.Minute(), clock.Second(), 0, time.Local), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid test start time %q", t.StartTime)
+}
+
 // TestRegistration represents a student's registration for an entrance test.
 type TestRegistration struct {
 	ID                    uuid.UUID              `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
@@ -456,3 +474,376 @@ type ApplicationReview struct {
 func (ApplicationReview) TableName() string {
 	return "application_reviews"
 }
+
+// QuestionType represents how a question is answered and scored.
+type QuestionType string
+
+// Question type constants.
+const (
+	QuestionTypeMCQ         QuestionType = "mcq"
+	QuestionTypeNumeric     QuestionType = "numeric"
+	QuestionTypeDescriptive QuestionType = "descriptive"
+)
+
+// IsValid checks if the question type is valid.
+func (t QuestionType) IsValid() bool {
+	switch t {
+	case QuestionTypeMCQ, QuestionTypeNumeric, QuestionTypeDescriptive:
+		return true
+	}
+	return false
+}
+
+// IsObjective returns true if answers to the question are scored automatically.
+func (t QuestionType) IsObjective() bool {
+	return t == QuestionTypeMCQ || t == QuestionTypeNumeric
+}
+
+// QuestionOption is an answer choice of a multiple-choice question.
+type QuestionOption struct {
+	Key  string `json:"key"`
+	Text string `json:"text"`
+}
+
+// QuestionOptions represents the answer choices of a question.
+type QuestionOptions []QuestionOption
+
+// Value implements the driver.Valuer interface.
+func (qo QuestionOptions) Value() (driver.Value, error) {
+	if qo == nil {
+		return "[]", nil
+	}
+	return json.Marshal(qo)
+}
+
+// Scan implements the sql.Scanner interface.
+func (qo *QuestionOptions) Scan(value interface{}) error {
+	if value == nil {
+		*qo = QuestionOptions{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, qo)
+}
+
+// TestQuestion is a question in the tenant's entrance test question bank.
+type TestQuestion struct {
+	ID               uuid.UUID        `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID         uuid.UUID        `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Subject          string           `gorm:"size:100;not null" json:"subject"`
+	Topic            string           `gorm:"size:200" json:"topic,omitempty"`
+	QuestionType     QuestionType     `gorm:"size:20;not null" json:"question_type"`
+	QuestionText     string           `gorm:"type:text;not null" json:"question_text"`
+	Options          QuestionOptions  `gorm:"type:jsonb;default:'[]'" json:"options"`
+	CorrectOptions   StringArray      `gorm:"type:jsonb;default:'[]'" json:"correct_options"`
+	NumericAnswer    *decimal.Decimal `gorm:"type:decimal(12,4)" json:"numeric_answer,omitempty"`
+	NumericTolerance decimal.Decimal  `gorm:"type:decimal(12,4);not null;default:0" json:"numeric_tolerance"`
+	Marks            decimal.Decimal  `gorm:"type:decimal(6,2);not null;default:1" json:"marks"`
+	NegativeMarks    decimal.Decimal  `gorm:"type:decimal(6,2);not null;default:0" json:"negative_marks"`
+	IsActive         bool             `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt        time.Time        `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt        time.Time        `gorm:"not null;default:now()" json:"updated_at"`
+	CreatedBy        *uuid.UUID       `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy        *uuid.UUID       `gorm:"type:uuid" json:"updated_by,omitempty"`
+}
+
+// TableName specifies the table name for TestQuestion.
+func (TestQuestion) TableName() string {
+	return "test_questions"
+}
+
+// TestSection is a section of an entrance test's question paper. Its marks
+// count towards one of the test's subjects.
+type TestSection struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID     uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	TestID       uuid.UUID `gorm:"type:uuid;not null;index" json:"test_id"`
+	Name         string    `gorm:"size:100;not null" json:"name"`
+	Subject      string    `gorm:"size:100;not null" json:"subject"`
+	Sequence     int       `gorm:"not null;default:0" json:"sequence"`
+	Instructions string    `gorm:"type:text" json:"instructions,omitempty"`
+
+	// Marking scheme for every question in the section. The question's own
+	// marks and negative marks apply when these are not set.
+	MarksPerQuestion *decimal.Decimal `gorm:"type:decimal(6,2)" json:"marks_per_question,omitempty"`
+	NegativeMarks    *decimal.Decimal `gorm:"type:decimal(6,2)" json:"negative_marks,omitempty"`
+
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+
+	// Relationships
+	Questions []TestSectionQuestion `gorm:"foreignKey:SectionID" json:"questions,omitempty"`
+}
+
+// TableName specifies the table name for TestSection.
+func (TestSection) TableName() string {
+	return "test_sections"
+}
+
+// QuestionMarks returns the marks and negative marks a question carries in this section.
+func (s *TestSection) QuestionMarks(q *TestQuestion) (decimal.Decimal, decimal.Decimal) {
+	marks, negative := q.Marks, q.NegativeMarks
+	if s.MarksPerQuestion != nil {
+		marks = *s.MarksPerQuestion
+	}
+	if s.NegativeMarks != nil {
+		negative = *s.NegativeMarks
+	}
+	// Descriptive answers are graded between zero and full marks
+	if !q.QuestionType.IsObjective() {
+		negative = decimal.Zero
+	}
+	return marks, negative
+}
+
+// TestSectionQuestion places a question bank question in a paper section.
+type TestSectionQuestion struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	SectionID  uuid.UUID `gorm:"type:uuid;not null;index" json:"section_id"`
+	QuestionID uuid.UUID `gorm:"type:uuid;not null;index" json:"question_id"`
+	Sequence   int       `gorm:"not null;default:0" json:"sequence"`
+	CreatedAt  time.Time `gorm:"not null;default:now()" json:"created_at"`
+
+	// Relationships
+	Question *TestQuestion `gorm:"foreignKey:QuestionID" json:"question,omitempty"`
+}
+
+// TableName specifies the table name for TestSectionQuestion.
+func (TestSectionQuestion) TableName() string {
+	return "test_section_questions"
+}
+
+// TestAttemptStatus represents the status of an online test attempt.
+type TestAttemptStatus string
+
+// Test attempt status constants.
+const (
+	TestAttemptInProgress TestAttemptStatus = "in_progress"
+	TestAttemptSubmitted  TestAttemptStatus = "submitted"
+	TestAttemptGraded     TestAttemptStatus = "graded"
+)
+
+// TestAttempt is a candidate's sitting of an online entrance test.
+type TestAttempt struct {
+	ID               uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID         uuid.UUID         `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	TestID           uuid.UUID         `gorm:"type:uuid;not null;index" json:"test_id"`
+	RegistrationID   uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex" json:"registration_id"`
+	Status           TestAttemptStatus `gorm:"size:20;not null;default:'in_progress'" json:"status"`
+	TokenHash        string            `gorm:"size:64;not null" json:"-"`
+	StartedAt        time.Time         `gorm:"not null" json:"started_at"`
+	Deadline         time.Time         `gorm:"not null" json:"deadline"`
+	SubmittedAt      *time.Time        `gorm:"type:timestamptz" json:"submitted_at,omitempty"`
+	AutoSubmitted    bool              `gorm:"not null;default:false" json:"auto_submitted"`
+	ObjectiveScore   decimal.Decimal   `gorm:"type:decimal(8,2);not null;default:0" json:"objective_score"`
+	DescriptiveScore decimal.Decimal   `gorm:"type:decimal(8,2);not null;default:0" json:"descriptive_score"`
+	PendingGrading   int               `gorm:"not null;default:0" json:"pending_grading"`
+	GradedAt         *time.Time        `gorm:"type:timestamptz" json:"graded_at,omitempty"`
+	CreatedAt        time.Time         `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt        time.Time         `gorm:"not null;default:now()" json:"updated_at"`
+
+	// Relationships
+	Registration *TestRegistration `gorm:"foreignKey:RegistrationID" json:"registration,omitempty"`
+	Answers      []TestAnswer      `gorm:"foreignKey:AttemptID" json:"answers,omitempty"`
+}
+
+// TableName specifies the table name for TestAttempt.
+func (TestAttempt) TableName() string {
+	return "test_attempts"
+}
+
+// TestAnswer is a candidate's answer to one question of an online test.
+type TestAnswer struct {
+	ID              uuid.UUID        `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID        uuid.UUID        `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	AttemptID       uuid.UUID        `gorm:"type:uuid;not null;index" json:"attempt_id"`
+	SectionID       uuid.UUID        `gorm:"type:uuid;not null" json:"section_id"`
+	QuestionID      uuid.UUID        `gorm:"type:uuid;not null" json:"question_id"`
+	SelectedOptions StringArray      `gorm:"type:jsonb;default:'[]'" json:"selected_options"`
+	NumericAnswer   *decimal.Decimal `gorm:"type:decimal(12,4)" json:"numeric_answer,omitempty"`
+	TextAnswer      string           `gorm:"type:text" json:"text_answer,omitempty"`
+	IsCorrect       *bool            `json:"is_correct,omitempty"`
+	MarksAwarded    *decimal.Decimal `gorm:"type:decimal(6,2)" json:"marks_awarded,omitempty"`
+	Feedback        string           `gorm:"type:text" json:"feedback,omitempty"`
+	GradedBy        *uuid.UUID       `gorm:"type:uuid" json:"graded_by,omitempty"`
+	GradedAt        *time.Time       `gorm:"type:timestamptz" json:"graded_at,omitempty"`
+	AnsweredAt      time.Time        `gorm:"not null;default:now()" json:"answered_at"`
+	CreatedAt       time.Time        `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt       time.Time        `gorm:"not null;default:now()" json:"updated_at"`
+
+	// Relationships
+	Question *TestQuestion `gorm:"foreignKey:QuestionID" json:"question,omitempty"`
+	Section  *TestSection  `gorm:"foreignKey:SectionID" json:"section,omitempty"`
+}
+
+// TableName specifies the table name for TestAnswer.
+func (TestAnswer) TableName() string {
+	return "test_answers"
+}
+
+// InterviewCriterion is one criterion of an interview rubric.
+type InterviewCriterion struct {
+	Key      string          `json:"key"`
+	Name     string          `json:"name"`
+	MaxScore decimal.Decimal `json:"max_score"`
+}
+
+// InterviewCriteria represents the criteria of an interview rubric.
+type InterviewCriteria []InterviewCriterion
+
+// Value implements the driver.Valuer interface.
+func (ic InterviewCriteria) Value() (driver.Value, error) {
+	if ic == nil {
+		return "[]", nil
+	}
+	return json.Marshal(ic)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ic *InterviewCriteria) Scan(value interface{}) error {
+	if value == nil {
+		*ic = InterviewCriteria{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, ic)
+}
+
+// MaxTotal returns the highest total score the criteria allow.
+func (ic InterviewCriteria) MaxTotal() decimal.Decimal {
+	total := decimal.Zero
+	for _, c := range ic {
+		total = total.Add(c.MaxScore)
+	}
+	return total
+}
+
+// InterviewRubric is the scoring rubric panelists use in an admission session's interviews.
+type InterviewRubric struct {
+	ID        uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID  uuid.UUID         `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	SessionID uuid.UUID         `gorm:"type:uuid;not null;index" json:"session_id"`
+	Name      string            `gorm:"size:100;not null" json:"name"`
+	Criteria  InterviewCriteria `gorm:"type:jsonb;default:'[]'" json:"criteria"`
+	CreatedAt time.Time         `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time         `gorm:"not null;default:now()" json:"updated_at"`
+	CreatedBy *uuid.UUID        `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID        `gorm:"type:uuid" json:"updated_by,omitempty"`
+}
+
+// TableName specifies the table name for InterviewRubric.
+func (InterviewRubric) TableName() string {
+	return "interview_rubrics"
+}
+
+// InterviewSlot is a time window in which a panel interviews up to Capacity candidates.
+type InterviewSlot struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	SessionID uuid.UUID  `gorm:"type:uuid;not null;index" json:"session_id"`
+	RubricID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"rubric_id"`
+	ClassName string     `gorm:"size:50" json:"class_name,omitempty"`
+	StartsAt  time.Time  `gorm:"not null" json:"starts_at"`
+	EndsAt    time.Time  `gorm:"not null" json:"ends_at"`
+	Venue     string     `gorm:"size:200" json:"venue,omitempty"`
+	Capacity  int        `gorm:"not null;default:1" json:"capacity"`
+	CreatedAt time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"not null;default:now()" json:"updated_at"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+
+	// Relationships
+	Rubric     *InterviewRubric    `gorm:"foreignKey:RubricID" json:"rubric,omitempty"`
+	Panelists  []InterviewPanelist `gorm:"foreignKey:SlotID" json:"panelists,omitempty"`
+	Interviews []Interview         `gorm:"foreignKey:SlotID" json:"interviews,omitempty"`
+}
+
+// TableName specifies the table name for InterviewSlot.
+func (InterviewSlot) TableName() string {
+	return "interview_slots"
+}
+
+// InterviewPanelist assigns a staff member to an interview slot's panel.
+type InterviewPanelist struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	SlotID    uuid.UUID `gorm:"type:uuid;not null;index" json:"slot_id"`
+	StaffID   uuid.UUID `gorm:"type:uuid;not null;index" json:"staff_id"`
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+
+	// Relationships
+	Staff *models.Staff `gorm:"foreignKey:StaffID" json:"staff,omitempty"`
+}
+
+// TableName specifies the table name for InterviewPanelist.
+func (InterviewPanelist) TableName() string {
+	return "interview_panelists"
+}
+
+// InterviewStatus represents the status of an application's interview.
+type InterviewStatus string
+
+// Interview status constants.
+const (
+	InterviewStatusScheduled InterviewStatus = "scheduled"
+	InterviewStatusCompleted InterviewStatus = "completed"
+	InterviewStatusCancelled InterviewStatus = "cancelled"
+	InterviewStatusNoShow    InterviewStatus = "no_show"
+)
+
+// Interview is an application's booking in an interview slot.
+type Interview struct {
+	ID                  uuid.UUID        `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID            uuid.UUID        `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	SlotID              uuid.UUID        `gorm:"type:uuid;not null;index" json:"slot_id"`
+	ApplicationID       uuid.UUID        `gorm:"type:uuid;not null;index" json:"application_id"`
+	Status              InterviewStatus  `gorm:"size:20;not null;default:'scheduled'" json:"status"`
+	RescheduleTokenHash *string          `gorm:"size:64" json:"-"`
+	RescheduleCount     int              `gorm:"not null;default:0" json:"reschedule_count"`
+	NotifiedAt          *time.Time       `gorm:"type:timestamptz" json:"notified_at,omitempty"`
+	ScorePercentage     *decimal.Decimal `gorm:"type:decimal(5,2)" json:"score_percentage,omitempty"`
+	CompletedAt         *time.Time       `gorm:"type:timestamptz" json:"completed_at,omitempty"`
+	Remarks             string           `gorm:"type:text" json:"remarks,omitempty"`
+	CreatedAt           time.Time        `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt           time.Time        `gorm:"not null;default:now()" json:"updated_at"`
+	CreatedBy           *uuid.UUID       `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy           *uuid.UUID       `gorm:"type:uuid" json:"updated_by,omitempty"`
+
+	// Relationships
+	Slot        *InterviewSlot               `gorm:"foreignKey:SlotID" json:"slot,omitempty"`
+	Application *models.AdmissionApplication `gorm:"foreignKey:ApplicationID" json:"application,omitempty"`
+	Scores      []InterviewScore             `gorm:"foreignKey:InterviewID" json:"scores,omitempty"`
+}
+
+// TableName specifies the table name for Interview.
+func (Interview) TableName() string {
+	return "interviews"
+}
+
+// InterviewScore is one panelist's rubric scores for an interview.
+type InterviewScore struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	TenantID    uuid.UUID       `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	InterviewID uuid.UUID       `gorm:"type:uuid;not null;index" json:"interview_id"`
+	StaffID     uuid.UUID       `gorm:"type:uuid;not null" json:"staff_id"`
+	Scores      MarksMap        `gorm:"type:jsonb;default:'{}'" json:"scores"`
+	TotalScore  decimal.Decimal `gorm:"type:decimal(8,2);not null" json:"total_score"`
+	MaxScore    decimal.Decimal `gorm:"type:decimal(8,2);not null" json:"max_score"`
+	Remarks     string          `gorm:"type:text" json:"remarks,omitempty"`
+	SubmittedBy *uuid.UUID      `gorm:"type:uuid" json:"submitted_by,omitempty"`
+	SubmittedAt time.Time       `gorm:"not null;default:now()" json:"submitted_at"`
+	CreatedAt   time.Time       `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for InterviewScore.
+func (InterviewScore) TableName() string {
+	return "interview_scores"
+}
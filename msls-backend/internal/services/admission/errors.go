@@ -1,7 +1,10 @@
 // Package admission provides admission management services.
 package admission
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // Service-level errors for admission operations.
 var (
@@ -141,6 +144,18 @@ var (
 	// ErrOfferNotAccepted is returned when trying to enroll without accepting the offer.
 	ErrOfferNotAccepted = errors.New("offer must be accepted before enrollment")
 
+	// ErrOfferLapsed is returned when an unaccepted offer lapsed after its validity date.
+	ErrOfferLapsed = errors.New("offer has lapsed")
+
+	// ErrOfferLetterNotGenerated is returned when no offer letter PDF exists yet.
+	ErrOfferLetterNotGenerated = errors.New("offer letter has not been generated")
+
+	// ErrOfferLinkInvalid is returned when a public offer link token is unknown.
+	ErrOfferLinkInvalid = errors.New("offer link is invalid")
+
+	// ErrInvalidOfferFee is returned when an offer fee has no name or a negative amount.
+	ErrInvalidOfferFee = errors.New("offer fees must have a name and a non-negative amount")
+
 	// ErrAlreadyEnrolled is returned when the application is already enrolled.
 	ErrAlreadyEnrolled = errors.New("application is already enrolled")
 
@@ -270,6 +285,193 @@ var (
 
 	// ErrApplicationNotInDraft is returned when trying to submit a non-draft application.
 	ErrApplicationNotInDraft = errors.New("application is not in draft status")
+
+	// Online admission portal errors
+
+	// ErrOnlineApplicationsClosed is returned when a session does not accept online applications today.
+	ErrOnlineApplicationsClosed = errors.New("online applications are not open for this session")
+
+	// ErrDailyApplicationLimitReached is returned when a session's MaxApplicationsPerDay is used up.
+	ErrDailyApplicationLimitReached = errors.New("daily online application limit reached")
+
+	// ErrPortalSessionInvalid is returned when a portal token is unknown or expired.
+	ErrPortalSessionInvalid = errors.New("portal session is invalid or has expired")
+
+	// ErrCaptchaFailed is returned when the CAPTCHA response is rejected.
+	ErrCaptchaFailed = errors.New("CAPTCHA verification failed")
+
+	// ErrClassNotOffered is returned when the session has no seats configured for the class.
+	ErrClassNotOffered = errors.New("class is not offered in this admission session")
+
+	// ErrFileTooLarge is returned when an uploaded document exceeds MaxPortalDocumentSize.
+	ErrFileTooLarge = errors.New("file size exceeds maximum allowed")
+
+	// ErrInvalidFileType is returned when an uploaded document is not a PDF or image.
+	ErrInvalidFileType = errors.New("invalid file type")
+
+	// ErrOnlinePaymentUnavailable is returned when the portal has no payment gateway configured.
+	ErrOnlinePaymentUnavailable = errors.New("online payment is not available")
+
+	// Question bank and online test errors
+
+	// ErrQuestionNotFound is returned when a question bank question is not found.
+	ErrQuestionNotFound = errors.New("question not found")
+
+	// ErrInvalidQuestion is returned when a question's type, options or answer key are inconsistent.
+	ErrInvalidQuestion = errors.New("invalid question")
+
+	// ErrQuestionInUse is returned when deleting a question that is on a question paper.
+	ErrQuestionInUse = errors.New("question is used on a question paper")
+
+	// ErrSectionNotFound is returned when a question paper section is not found.
+	ErrSectionNotFound = errors.New("question paper section not found")
+
+	// ErrSubjectNotInTest is returned when a section's subject is not one of the test's subjects.
+	ErrSubjectNotInTest = errors.New("subject is not one of the test's subjects")
+
+	// ErrQuestionAlreadyOnPaper is returned when a question is added to the same paper twice.
+	ErrQuestionAlreadyOnPaper = errors.New("question is already on the question paper")
+
+	// ErrQuestionNotOnPaper is returned when a question is not part of the test's question paper.
+	ErrQuestionNotOnPaper = errors.New("question is not on the question paper")
+
+	// ErrPaperPublished is returned when changing a question paper that is published for online delivery.
+	ErrPaperPublished = errors.New("question paper is published and cannot be changed")
+
+	// ErrPaperLocked is returned when unpublishing a paper after candidates have started the test.
+	ErrPaperLocked = errors.New("candidates have already started the online test")
+
+	// ErrPaperIncomplete is returned when publishing a question paper that does not match the test.
+	ErrPaperIncomplete = errors.New("question paper is incomplete")
+
+	// ErrTestLoginFailed is returned when no open online test matches the roll number and date of birth.
+	ErrTestLoginFailed = errors.New("roll number or date of birth is incorrect, or no online test is open")
+
+	// ErrTestNotStarted is returned when a candidate logs in before the test starts.
+	ErrTestNotStarted = errors.New("online test has not started yet")
+
+	// ErrTestEntryClosed is returned when a candidate first logs in after the late entry window.
+	ErrTestEntryClosed = errors.New("entry to the online test has closed")
+
+	// ErrTestSessionInvalid is returned when an online test token is unknown.
+	ErrTestSessionInvalid = errors.New("online test session is invalid")
+
+	// ErrTestTimeUp is returned when the attempt's deadline has passed.
+	ErrTestTimeUp = errors.New("online test time is over")
+
+	// ErrAttemptSubmitted is returned when changing an attempt that has been submitted.
+	ErrAttemptSubmitted = errors.New("online test has already been submitted")
+
+	// ErrInvalidAnswer is returned when an answer does not fit the question type.
+	ErrInvalidAnswer = errors.New("invalid answer")
+
+	// ErrAnswerNotFound is returned when an answer is not found.
+	ErrAnswerNotFound = errors.New("answer not found")
+
+	// ErrAnswerNotGradable is returned when grading an objective answer or an answer whose result is published.
+	ErrAnswerNotGradable = errors.New("only descriptive answers awaiting results can be graded")
+
+	// ErrInvalidGradeMarks is returned when awarded marks are negative or exceed the question's marks.
+	ErrInvalidGradeMarks = errors.New("awarded marks must be between zero and the question's marks")
+
+	// Interview errors
+
+	// ErrRubricNotFound is returned when an interview rubric is not found.
+	ErrRubricNotFound = errors.New("interview rubric not found")
+
+	// ErrInvalidRubric is returned when a rubric has no criteria, duplicate keys or non-positive maximum scores.
+	ErrInvalidRubric = errors.New("rubric needs criteria with unique keys and positive maximum scores")
+
+	// ErrRubricInUse is returned when deleting a rubric used by interview slots.
+	ErrRubricInUse = errors.New("rubric is used by interview slots")
+
+	// ErrInterviewSlotNotFound is returned when an interview slot is not found.
+	ErrInterviewSlotNotFound = errors.New("interview slot not found")
+
+	// ErrInvalidSlotTime is returned when a slot ends before it starts.
+	ErrInvalidSlotTime = errors.New("interview slot must end after it starts")
+
+	// ErrSlotInPast is returned when booking or moving into a slot that has already started.
+	ErrSlotInPast = errors.New("interview slot has already started")
+
+	// ErrSlotFull is returned when a slot has no room for another candidate.
+	ErrSlotFull = errors.New("interview slot is full")
+
+	// ErrSlotClassMismatch is returned when the application's class differs from the slot's class.
+	ErrSlotClassMismatch = errors.New("interview slot is for a different class")
+
+	// ErrSlotHasInterviews is returned when deleting a slot with scheduled or completed interviews.
+	ErrSlotHasInterviews = errors.New("interview slot has interviews")
+
+	// ErrPanelistNotFound is returned when a staff member is not on the slot's panel.
+	ErrPanelistNotFound = errors.New("staff member is not on the interview panel")
+
+	// ErrPanelistAlreadyAssigned is returned when a staff member is already on the slot's panel.
+	ErrPanelistAlreadyAssigned = errors.New("staff member is already on the interview panel")
+
+	// ErrPanelistHasScores is returned when removing a panelist who has scored interviews in the slot.
+	ErrPanelistHasScores = errors.New("panelist has already scored interviews in this slot")
+
+	// ErrPanelistConflict is returned when a panelist is on another overlapping slot.
+	ErrPanelistConflict = errors.New("panelist has another interview slot at this time")
+
+	// ErrStaffNotFound is returned when a panelist is not an active staff member of the tenant.
+	ErrStaffNotFound = errors.New("staff member not found")
+
+	// ErrInterviewNotFound is returned when an interview is not found.
+	ErrInterviewNotFound = errors.New("interview not found")
+
+	// ErrInterviewAlreadyScheduled is returned when the application already has an open interview.
+	ErrInterviewAlreadyScheduled = errors.New("application already has an interview")
+
+	// ErrInterviewNotScheduled is returned when changing an interview that is completed, cancelled or a no-show.
+	ErrInterviewNotScheduled = errors.New("interview is not scheduled")
+
+	// ErrInterviewNotScorable is returned when scoring a cancelled or no-show interview.
+	ErrInterviewNotScorable = errors.New("interview cannot be scored")
+
+	// ErrInvalidInterviewScore is returned when scores miss a criterion or exceed its maximum.
+	ErrInvalidInterviewScore = errors.New("scores must cover every rubric criterion within its maximum")
+
+	// ErrApplicationNotInterviewable is returned when the application cannot move to interview_scheduled.
+	ErrApplicationNotInterviewable = errors.New("application cannot be scheduled for an interview")
+
+	// ErrRescheduleLinkInvalid is returned when a reschedule link token is unknown.
+	ErrRescheduleLinkInvalid = errors.New("reschedule link is invalid")
+
+	// ErrInvalidInterviewWeight is returned when the merit list interview weight is outside 0-100.
+	ErrInvalidInterviewWeight = errors.New("interview weight must be between 0 and 100")
+
+	// ErrRescheduleClosed is returned when a parent reschedules too close to the interview or too often.
+	ErrRescheduleClosed = errors.New("interview can no longer be rescheduled online")
+
+	// Enquiry CRM errors
+
+	// ErrUserNotFound is returned when a user is not an active user of the tenant.
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrCounsellorNotFound is returned when a user is not in the enquiry counsellor pool.
+	ErrCounsellorNotFound = errors.New("user is not an enquiry counsellor")
+
+	// ErrCounsellorAlreadyAdded is returned when a user is already in the counsellor pool.
+	ErrCounsellorAlreadyAdded = errors.New("user is already an enquiry counsellor")
+
+	// ErrNoCounsellors is returned when distributing enquiries with an empty counsellor pool.
+	ErrNoCounsellors = errors.New("no enquiry counsellors have been added")
+
+	// ErrInvalidAutoCloseDays is returned when the auto-close period is negative.
+	ErrInvalidAutoCloseDays = errors.New("auto-close days must be zero or more")
+
+	// Seat quota errors
+
+	// ErrNoSeatsAvailable is returned when neither the applicant's quota nor the general pool has a free seat.
+	ErrNoSeatsAvailable = errors.New("no seats available in the applicant's quota or the general pool")
+
+	// ErrInvalidReservedSeats is returned when reserved seats use unknown quotas, negative counts or exceed total seats.
+	ErrInvalidReservedSeats = errors.New("reserved seats must use known quotas, be zero or more and not exceed total seats")
+
+	// ErrInvalidQuotaCategory is returned when an application's quota category is not recognised.
+	ErrInvalidQuotaCategory = errors.New("invalid quota category")
 )
 
 // StageTransitionError provides detailed information about invalid stage transitions.
@@ -283,6 +485,20 @@ func (e *StageTransitionError) Error() string {
 	return "invalid stage transition"
 }
 
+// MissingDocumentsError lists the required documents an application has not uploaded.
+type MissingDocumentsError struct {
+	DocumentTypes []string
+}
+
+func (e *MissingDocumentsError) Error() string {
+	return "missing required documents: " + strings.Join(e.DocumentTypes, ", ")
+}
+
+// Unwrap allows errors.Is(err, ErrMissingRequiredDocuments).
+func (e *MissingDocumentsError) Unwrap() error {
+	return ErrMissingRequiredDocuments
+}
+
 // NewStageTransitionError creates a new StageTransitionError with the given details.
 func NewStageTransitionError(current, requested string, validTransitions []string) *StageTransitionError {
 	return &StageTransitionError{
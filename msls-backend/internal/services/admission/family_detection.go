@@ -0,0 +1,149 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// familyMatch is the result of matching an application's parent contacts
+// against existing families, students and staff.
+type familyMatch struct {
+	FamilyID      *uuid.UUID
+	HasSibling    bool
+	StaffParentID *uuid.UUID
+}
+
+// applicationContacts returns the normalized phone numbers and emails of an
+// application's parents and guardian.
+func applicationContacts(app *models.AdmissionApplication) (phones, emails []string) {
+	seen := make(map[string]bool)
+	for _, phone := range []string{app.FatherPhone, app.MotherPhone, app.GuardianPhone, app.VerifiedPhone} {
+		if p := models.NormalizeContactPhone(phone); p != "" && !seen["p"+p] {
+			seen["p"+p] = true
+			phones = append(phones, p)
+		}
+	}
+	for _, email := range []string{app.FatherEmail, app.MotherEmail, app.GuardianEmail} {
+		if e := models.NormalizeContactEmail(email); e != "" && !seen["e"+e] {
+			seen["e"+e] = true
+			emails = append(emails, e)
+		}
+	}
+	return phones, emails
+}
+
+// phoneVariants expands normalized phone numbers into the formats commonly
+// stored on unnormalized records.
+func phoneVariants(phones []string) []string {
+	variants := make([]string, 0, len(phones)*4)
+	for _, p := range phones {
+		variants = append(variants, p, "+91"+p, "91"+p, "0"+p)
+	}
+	return variants
+}
+
+// detectFamily matches an application's parent contacts to an existing family,
+// reporting whether an enrolled sibling exists and whether a parent is staff.
+func detectFamily(db *gorm.DB, app *models.AdmissionApplication) (*familyMatch, error) {
+	match := &familyMatch{}
+	phones, emails := applicationContacts(app)
+	if len(phones) == 0 && len(emails) == 0 {
+		return match, nil
+	}
+
+	// Family guardians store normalized contacts
+	var guardians []models.FamilyGuardian
+	query := db.Where("tenant_id = ?", app.TenantID)
+	switch {
+	case len(phones) > 0 && len(emails) > 0:
+		query = query.Where("phone IN ? OR email IN ?", phones, emails)
+	case len(phones) > 0:
+		query = query.Where("phone IN ?", phones)
+	default:
+		query = query.Where("email IN ?", emails)
+	}
+	if err := query.Order("created_at ASC").Find(&guardians).Error; err != nil {
+		return nil, fmt.Errorf("failed to match family guardians: %w", err)
+	}
+
+	if len(guardians) > 0 {
+		familyID := guardians[0].FamilyID
+		match.FamilyID = &familyID
+		for _, g := range guardians {
+			if g.FamilyID == familyID && g.StaffID != nil {
+				match.StaffParentID = g.StaffID
+				break
+			}
+		}
+
+		var siblings int64
+		if err := db.Model(&models.Student{}).
+			Where("tenant_id = ? AND family_id = ? AND status = ?", app.TenantID, familyID, models.StudentStatusActive).
+			Count(&siblings).Error; err != nil {
+			return nil, fmt.Errorf("failed to count siblings: %w", err)
+		}
+		match.HasSibling = siblings > 0
+	}
+
+	// Students not yet grouped into a family are matched on their own guardians
+	if !match.HasSibling {
+		var siblings int64
+		sibQuery := db.Model(&models.StudentGuardian{}).
+			Joins("JOIN students ON students.id = student_guardians.student_id AND students.deleted_at IS NULL").
+			Where("student_guardians.tenant_id = ? AND students.status = ?", app.TenantID, models.StudentStatusActive)
+		switch {
+		case len(phones) > 0 && len(emails) > 0:
+			sibQuery = sibQuery.Where("student_guardians.phone IN ? OR LOWER(student_guardians.email) IN ?", phoneVariants(phones), emails)
+		case len(phones) > 0:
+			sibQuery = sibQuery.Where("student_guardians.phone IN ?", phoneVariants(phones))
+		default:
+			sibQuery = sibQuery.Where("LOWER(student_guardians.email) IN ?", emails)
+		}
+		if err := sibQuery.Count(&siblings).Error; err != nil {
+			return nil, fmt.Errorf("failed to match sibling guardians: %w", err)
+		}
+		match.HasSibling = siblings > 0
+	}
+
+	if match.StaffParentID == nil {
+		var staff []models.Staff
+		staffQuery := db.Select("id").
+			Where("tenant_id = ? AND status = ?", app.TenantID, models.StaffStatusActive)
+		variants := phoneVariants(phones)
+		switch {
+		case len(phones) > 0 && len(emails) > 0:
+			staffQuery = staffQuery.Where("work_phone IN ? OR personal_phone IN ? OR LOWER(work_email) IN ? OR LOWER(personal_email) IN ?",
+				variants, variants, emails, emails)
+		case len(phones) > 0:
+			staffQuery = staffQuery.Where("work_phone IN ? OR personal_phone IN ?", variants, variants)
+		default:
+			staffQuery = staffQuery.Where("LOWER(work_email) IN ? OR LOWER(personal_email) IN ?", emails, emails)
+		}
+		if err := staffQuery.Limit(1).Find(&staff).Error; err != nil {
+			return nil, fmt.Errorf("failed to match staff parents: %w", err)
+		}
+		if len(staff) > 0 {
+			match.StaffParentID = &staff[0].ID
+		}
+	}
+
+	return match, nil
+}
+
+// defaultQuotaCategory picks the quota an application qualifies for from its
+// family match when none was chosen.
+func defaultQuotaCategory(match *familyMatch) string {
+	switch {
+	case match.StaffParentID != nil:
+		return models.SeatQuotaStaffWard
+	case match.HasSibling:
+		return models.SeatQuotaSibling
+	default:
+		return models.SeatQuotaGeneral
+	}
+}
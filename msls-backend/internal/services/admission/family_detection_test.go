@@ -0,0 +1,78 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/testutil"
+)
+
+func TestDetectFamily(t *testing.T) {
+	f := setupOffers(t)
+	for _, model := range []interface{}{
+		&models.Student{}, &models.StudentGuardian{}, &models.Staff{},
+		&models.Family{}, &models.FamilyGuardian{},
+	} {
+		testutil.CreateSQLiteTable(t, f.db, model)
+	}
+
+	branchID := uuid.New()
+	family := &models.Family{ID: uuid.New(), TenantID: f.tenantID, Name: "Sharma Family"}
+	require.NoError(t, f.db.Create(family).Error)
+	require.NoError(t, f.db.Create(&models.FamilyGuardian{
+		ID: uuid.New(), TenantID: f.tenantID, FamilyID: family.ID,
+		FirstName: "Priya", LastName: "Sharma", Phone: "9876543210",
+	}).Error)
+	require.NoError(t, f.db.Create(&models.Student{
+		ID: uuid.New(), TenantID: f.tenantID, BranchID: branchID, AdmissionNumber: "ADM-1", FirstName: "Asha", LastName: "Sharma",
+		DateOfBirth: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), Gender: models.GenderFemale,
+		Status: models.StudentStatusActive, FamilyID: &family.ID,
+	}).Error)
+
+	staff := &models.Staff{
+		ID: uuid.New(), TenantID: f.tenantID, BranchID: branchID, EmployeeID: "EMP-1", FirstName: "Amit", LastName: "Rao",
+		DateOfBirth: time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC), Gender: models.GenderMale, StaffType: models.StaffTypeTeaching,
+		JoinDate: time.Now(), WorkEmail: "amit.rao@school.example", WorkPhone: "+919000000001", Status: models.StaffStatusActive,
+	}
+	require.NoError(t, f.db.Create(staff).Error)
+
+	t.Run("sibling through a family guardian", func(t *testing.T) {
+		match, err := detectFamily(f.db, &models.AdmissionApplication{TenantID: f.tenantID, MotherPhone: "+91 98765 43210"})
+		require.NoError(t, err)
+		require.NotNil(t, match.FamilyID)
+		assert.Equal(t, family.ID, *match.FamilyID)
+		assert.True(t, match.HasSibling)
+		assert.Nil(t, match.StaffParentID)
+		assert.Equal(t, models.SeatQuotaSibling, defaultQuotaCategory(match))
+	})
+
+	t.Run("staff parent", func(t *testing.T) {
+		match, err := detectFamily(f.db, &models.AdmissionApplication{TenantID: f.tenantID, FatherEmail: "Amit.Rao@school.example"})
+		require.NoError(t, err)
+		assert.Nil(t, match.FamilyID)
+		assert.False(t, match.HasSibling)
+		require.NotNil(t, match.StaffParentID)
+		assert.Equal(t, staff.ID, *match.StaffParentID)
+		assert.Equal(t, models.SeatQuotaStaffWard, defaultQuotaCategory(match))
+
+		match, err = detectFamily(f.db, &models.AdmissionApplication{TenantID: f.tenantID, FatherPhone: "9000000001"})
+		require.NoError(t, err)
+		require.NotNil(t, match.StaffParentID)
+		assert.Equal(t, staff.ID, *match.StaffParentID)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		match, err := detectFamily(f.db, &models.AdmissionApplication{TenantID: f.tenantID, FatherPhone: "9111111111"})
+		require.NoError(t, err)
+		assert.Nil(t, match.FamilyID)
+		assert.False(t, match.HasSibling)
+		assert.Nil(t, match.StaffParentID)
+		assert.Equal(t, models.SeatQuotaGeneral, defaultQuotaCategory(match))
+	})
+}
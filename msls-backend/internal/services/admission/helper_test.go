@@ -0,0 +1,56 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/testutil"
+)
+
+// newAdmissionTestDB opens an in-memory database with a table for each model.
+// It uses a single connection so every query sees the same database.
+func newAdmissionTestDB(t *testing.T, tables ...interface{}) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	for _, model := range tables {
+		testutil.CreateSQLiteTable(t, db, model)
+	}
+	return db
+}
+
+// seedAdmissionSession creates the "Green Valley School" tenant with an open
+// "Admission 2026-27" session. The database needs the tenant and session tables.
+func seedAdmissionSession(t *testing.T, db *gorm.DB, settings models.SessionSettings, requiredDocuments ...string) *models.AdmissionSession {
+	t.Helper()
+
+	tenant := &models.Tenant{Name: "Green Valley School", Slug: "green-valley"}
+	tenant.ID = uuid.New()
+	require.NoError(t, db.Create(tenant).Error)
+
+	session := &models.AdmissionSession{
+		ID:                uuid.New(),
+		TenantID:          tenant.ID,
+		Name:              "Admission 2026-27",
+		StartDate:         time.Now().AddDate(0, 0, -10),
+		EndDate:           time.Now().AddDate(0, 0, 30),
+		Status:            models.SessionStatusOpen,
+		RequiredDocuments: append(models.RequiredDocuments{}, requiredDocuments...),
+		Settings:          settings,
+	}
+	require.NoError(t, db.Create(session).Error)
+	return session
+}
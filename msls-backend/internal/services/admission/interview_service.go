@@ -0,0 +1,1187 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/sms"
+)
+
+// InterviewRescheduleCutoff is how long before an interview parents can still
+// reschedule it through their link.
+const InterviewRescheduleCutoff = 24 * time.Hour
+
+// MaxInterviewReschedules caps how many times an interview can be rescheduled
+// through the parent link.
+const MaxInterviewReschedules = 2
+
+// InterviewService handles interview rubrics, slots and panels, bookings and
+// panel scoring for admission interviews.
+type InterviewService struct {
+	db            *gorm.DB
+	smsProvider   sms.Provider
+	publicBaseURL string
+}
+
+// InterviewConfig holds the dependencies of the InterviewService.
+type InterviewConfig struct {
+	// SMSProvider sends schedule notifications to parents. Notifications are
+	// skipped when nil.
+	SMSProvider sms.Provider
+	// PublicBaseURL is the public URL of this API, used to build reschedule links.
+	PublicBaseURL string
+}
+
+// NewInterviewService creates a new InterviewService instance.
+func NewInterviewService(db *gorm.DB, config InterviewConfig) *InterviewService {
+	return &InterviewService{
+		db:            db,
+		smsProvider:   config.SMSProvider,
+		publicBaseURL: strings.TrimRight(config.PublicBaseURL, "/"),
+	}
+}
+
+// RubricRequest represents a request to create or replace an interview rubric.
+type RubricRequest struct {
+	TenantID  uuid.UUID
+	SessionID uuid.UUID
+	Name      string
+	Criteria  InterviewCriteria
+	UserID    *uuid.UUID
+}
+
+// CreateSlotRequest represents a request to create an interview slot.
+type CreateSlotRequest struct {
+	TenantID  uuid.UUID
+	SessionID uuid.UUID
+	RubricID  uuid.UUID
+	ClassName string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Venue     string
+	Capacity  int
+	StaffIDs  []uuid.UUID
+	CreatedBy *uuid.UUID
+}
+
+// UpdateSlotRequest represents a request to update an interview slot.
+type UpdateSlotRequest struct {
+	StartsAt  *time.Time
+	EndsAt    *time.Time
+	Venue     *string
+	Capacity  *int
+	UpdatedBy *uuid.UUID
+}
+
+// SlotListFilter contains filters for listing interview slots.
+type SlotListFilter struct {
+	TenantID      uuid.UUID
+	SessionID     *uuid.UUID
+	ClassName     string
+	FromDate      *time.Time
+	ToDate        *time.Time
+	AvailableOnly bool
+}
+
+// ScheduleInterviewRequest represents a request to book an application into a slot.
+type ScheduleInterviewRequest struct {
+	TenantID      uuid.UUID
+	ApplicationID uuid.UUID
+	SlotID        uuid.UUID
+	ScheduledBy   *uuid.UUID
+}
+
+// InterviewListFilter contains filters for listing interviews.
+type InterviewListFilter struct {
+	TenantID      uuid.UUID
+	SessionID     *uuid.UUID
+	SlotID        *uuid.UUID
+	ApplicationID *uuid.UUID
+	Status        *InterviewStatus
+}
+
+// SubmitScoreRequest represents a panelist's rubric scores for an interview.
+type SubmitScoreRequest struct {
+	TenantID    uuid.UUID
+	InterviewID uuid.UUID
+	StaffID     uuid.UUID
+	Scores      map[string]decimal.Decimal
+	Remarks     string
+	SubmittedBy *uuid.UUID
+}
+
+// ScheduledInterview is a booked interview together with the reschedule link
+// token sent to the parent. The token is not stored.
+type ScheduledInterview struct {
+	Interview *Interview
+	Token     string
+}
+
+// InterviewView is an interview as shown to a parent through the reschedule link.
+type InterviewView struct {
+	Interview      *Interview
+	StudentName    string
+	SchoolName     string
+	CanReschedule  bool
+	AvailableSlots []InterviewSlot
+}
+
+// =====================================================================
+// Rubrics
+// =====================================================================
+
+// CreateRubric creates an interview rubric for an admission session.
+func (s *InterviewService) CreateRubric(ctx context.Context, req RubricRequest) (*InterviewRubric, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+	if err := validateCriteria(req.Criteria); err != nil {
+		return nil, err
+	}
+	if err := s.checkSession(ctx, req.TenantID, req.SessionID); err != nil {
+		return nil, err
+	}
+
+	rubric := &InterviewRubric{
+		TenantID:  req.TenantID,
+		SessionID: req.SessionID,
+		Name:      strings.TrimSpace(req.Name),
+		Criteria:  req.Criteria,
+		CreatedBy: req.UserID,
+		UpdatedBy: req.UserID,
+	}
+	if err := s.db.WithContext(ctx).Create(rubric).Error; err != nil {
+		return nil, fmt.Errorf("failed to create rubric: %w", err)
+	}
+	return rubric, nil
+}
+
+// GetRubric retrieves an interview rubric by ID.
+func (s *InterviewService) GetRubric(ctx context.Context, tenantID, id uuid.UUID) (*InterviewRubric, error) {
+	var rubric InterviewRubric
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&rubric).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRubricNotFound
+		}
+		return nil, fmt.Errorf("failed to get rubric: %w", err)
+	}
+	return &rubric, nil
+}
+
+// ListRubrics lists the interview rubrics of an admission session.
+func (s *InterviewService) ListRubrics(ctx context.Context, tenantID, sessionID uuid.UUID) ([]InterviewRubric, error) {
+	var rubrics []InterviewRubric
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND session_id = ?", tenantID, sessionID).
+		Order("name").
+		Find(&rubrics).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rubrics: %w", err)
+	}
+	return rubrics, nil
+}
+
+// UpdateRubric replaces a rubric's name and criteria. Criteria are locked once
+// any interview on the rubric has been scored.
+func (s *InterviewService) UpdateRubric(ctx context.Context, id uuid.UUID, req RubricRequest) (*InterviewRubric, error) {
+	if err := validateCriteria(req.Criteria); err != nil {
+		return nil, err
+	}
+	rubric, err := s.GetRubric(ctx, req.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var scored int64
+	err = s.db.WithContext(ctx).
+		Model(&InterviewScore{}).
+		Joins("JOIN interviews ON interviews.id = interview_scores.interview_id").
+		Joins("JOIN interview_slots ON interview_slots.id = interviews.slot_id").
+		Where("interview_slots.rubric_id = ?", id).
+		Count(&scored).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rubric scores: %w", err)
+	}
+	if scored > 0 {
+		return nil, ErrRubricInUse
+	}
+
+	updates := map[string]interface{}{
+		"name":       strings.TrimSpace(req.Name),
+		"criteria":   req.Criteria,
+		"updated_by": req.UserID,
+		"updated_at": time.Now(),
+	}
+	if err := s.db.WithContext(ctx).Model(rubric).Updates(updates).Error; err != nil {
+		return nil, fmt.Errorf("failed to update rubric: %w", err)
+	}
+	return s.GetRubric(ctx, req.TenantID, id)
+}
+
+// DeleteRubric deletes a rubric no interview slot uses.
+func (s *InterviewService) DeleteRubric(ctx context.Context, tenantID, id uuid.UUID) error {
+	rubric, err := s.GetRubric(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	var slots int64
+	if err := s.db.WithContext(ctx).Model(&InterviewSlot{}).Where("rubric_id = ?", id).Count(&slots).Error; err != nil {
+		return fmt.Errorf("failed to check rubric slots: %w", err)
+	}
+	if slots > 0 {
+		return ErrRubricInUse
+	}
+
+	if err := s.db.WithContext(ctx).Delete(rubric).Error; err != nil {
+		return fmt.Errorf("failed to delete rubric: %w", err)
+	}
+	return nil
+}
+
+// =====================================================================
+// Slots and panels
+// =====================================================================
+
+// CreateSlot creates an interview slot with its panel. Panelists must be active
+// staff without another slot at an overlapping time.
+func (s *InterviewService) CreateSlot(ctx context.Context, req CreateSlotRequest) (*InterviewSlot, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, ErrInvalidSlotTime
+	}
+	if err := s.checkSession(ctx, req.TenantID, req.SessionID); err != nil {
+		return nil, err
+	}
+	rubric, err := s.GetRubric(ctx, req.TenantID, req.RubricID)
+	if err != nil {
+		return nil, err
+	}
+	if rubric.SessionID != req.SessionID {
+		return nil, ErrRubricNotFound
+	}
+
+	capacity := req.Capacity
+	if capacity <= 0 {
+		capacity = 1
+	}
+	slot := &InterviewSlot{
+		TenantID:  req.TenantID,
+		SessionID: req.SessionID,
+		RubricID:  req.RubricID,
+		ClassName: strings.TrimSpace(req.ClassName),
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Venue:     req.Venue,
+		Capacity:  capacity,
+		CreatedBy: req.CreatedBy,
+		UpdatedBy: req.CreatedBy,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(slot).Error; err != nil {
+			return fmt.Errorf("failed to create interview slot: %w", err)
+		}
+		seen := make(map[uuid.UUID]bool, len(req.StaffIDs))
+		for _, staffID := range req.StaffIDs {
+			if seen[staffID] {
+				continue
+			}
+			seen[staffID] = true
+			if err := addPanelist(tx, slot, staffID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSlot(ctx, req.TenantID, slot.ID)
+}
+
+// GetSlot retrieves an interview slot with its rubric, panel and open interviews.
+func (s *InterviewService) GetSlot(ctx context.Context, tenantID, id uuid.UUID) (*InterviewSlot, error) {
+	var slot InterviewSlot
+	err := s.db.WithContext(ctx).
+		Preload("Rubric").
+		Preload("Panelists.Staff").
+		Preload("Interviews", "status IN ?", []InterviewStatus{InterviewStatusScheduled, InterviewStatusCompleted}).
+		Preload("Interviews.Application").
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&slot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInterviewSlotNotFound
+		}
+		return nil, fmt.Errorf("failed to get interview slot: %w", err)
+	}
+	return &slot, nil
+}
+
+// ListSlots lists interview slots in time order with their panels and open interviews.
+func (s *InterviewService) ListSlots(ctx context.Context, filter SlotListFilter) ([]InterviewSlot, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	query := s.db.WithContext(ctx).
+		Preload("Rubric").
+		Preload("Panelists.Staff").
+		Preload("Interviews", "status IN ?", []InterviewStatus{InterviewStatusScheduled, InterviewStatusCompleted}).
+		Preload("Interviews.Application").
+		Where("tenant_id = ?", filter.TenantID)
+
+	if filter.SessionID != nil {
+		query = query.Where("session_id = ?", *filter.SessionID)
+	}
+	if filter.ClassName != "" {
+		query = query.Where("(class_name = ? OR class_name = '' OR class_name IS NULL)", filter.ClassName)
+	}
+	if filter.FromDate != nil {
+		query = query.Where("starts_at >= ?", *filter.FromDate)
+	}
+	if filter.ToDate != nil {
+		query = query.Where("starts_at < ?", *filter.ToDate)
+	}
+	if filter.AvailableOnly {
+		query = query.Where("starts_at > ?", time.Now()).
+			Where("capacity > (SELECT COUNT(*) FROM interviews WHERE interviews.slot_id = interview_slots.id AND interviews.status IN ?)",
+				[]InterviewStatus{InterviewStatusScheduled, InterviewStatusCompleted})
+	}
+
+	var slots []InterviewSlot
+	if err := query.Order("starts_at ASC").Find(&slots).Error; err != nil {
+		return nil, fmt.Errorf("failed to list interview slots: %w", err)
+	}
+	return slots, nil
+}
+
+// UpdateSlot updates a slot's time, venue or capacity. A new time is checked
+// against the panel's other slots, and parents of booked candidates are sent
+// the new schedule.
+func (s *InterviewService) UpdateSlot(ctx context.Context, tenantID, id uuid.UUID, req UpdateSlotRequest) (*InterviewSlot, error) {
+	slot, err := s.GetSlot(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt, endsAt := slot.StartsAt, slot.EndsAt
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		endsAt = *req.EndsAt
+	}
+	if !endsAt.After(startsAt) {
+		return nil, ErrInvalidSlotTime
+	}
+	moved := !startsAt.Equal(slot.StartsAt) || !endsAt.Equal(slot.EndsAt)
+
+	updates := map[string]interface{}{
+		"starts_at":  startsAt,
+		"ends_at":    endsAt,
+		"updated_by": req.UpdatedBy,
+		"updated_at": time.Now(),
+	}
+	venueChanged := false
+	if req.Venue != nil {
+		venueChanged = *req.Venue != slot.Venue
+		updates["venue"] = *req.Venue
+	}
+	if req.Capacity != nil {
+		if *req.Capacity < 1 || *req.Capacity < openInterviewCount(slot) {
+			return nil, ErrSlotFull
+		}
+		updates["capacity"] = *req.Capacity
+	}
+
+	if moved {
+		for _, panelist := range slot.Panelists {
+			if err := checkPanelistFree(s.db.WithContext(ctx), tenantID, panelist.StaffID, slot.ID, startsAt, endsAt); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.db.WithContext(ctx).Model(slot).Updates(updates).Error; err != nil {
+		return nil, fmt.Errorf("failed to update interview slot: %w", err)
+	}
+
+	updated, err := s.GetSlot(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if moved || venueChanged {
+		for i := range updated.Interviews {
+			interview := &updated.Interviews[i]
+			if interview.Status != InterviewStatusScheduled {
+				continue
+			}
+			interview.Slot = updated
+			if _, err := s.issueLinkAndNotify(ctx, interview, "has been moved to"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return updated, nil
+}
+
+// DeleteSlot deletes a slot that has no scheduled or completed interviews.
+func (s *InterviewService) DeleteSlot(ctx context.Context, tenantID, id uuid.UUID) error {
+	slot, err := s.GetSlot(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if openInterviewCount(slot) > 0 {
+		return ErrSlotHasInterviews
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("slot_id = ?", id).Delete(&InterviewPanelist{}).Error; err != nil {
+			return fmt.Errorf("failed to delete panelists: %w", err)
+		}
+		if err := tx.Where("slot_id = ?", id).Delete(&Interview{}).Error; err != nil {
+			return fmt.Errorf("failed to delete cancelled interviews: %w", err)
+		}
+		if err := tx.Delete(slot).Error; err != nil {
+			return fmt.Errorf("failed to delete interview slot: %w", err)
+		}
+		return nil
+	})
+	return err
+}
+
+// AddPanelist assigns a staff member to a slot's panel.
+func (s *InterviewService) AddPanelist(ctx context.Context, tenantID, slotID, staffID uuid.UUID) (*InterviewSlot, error) {
+	slot, err := s.GetSlot(ctx, tenantID, slotID)
+	if err != nil {
+		return nil, err
+	}
+	if err := addPanelist(s.db.WithContext(ctx), slot, staffID); err != nil {
+		return nil, err
+	}
+	return s.GetSlot(ctx, tenantID, slotID)
+}
+
+// RemovePanelist removes a staff member who has not scored anyone from a slot's
+// panel. Interviews already scored by the rest of the panel are completed.
+func (s *InterviewService) RemovePanelist(ctx context.Context, tenantID, slotID, staffID uuid.UUID) (*InterviewSlot, error) {
+	slot, err := s.GetSlot(ctx, tenantID, slotID)
+	if err != nil {
+		return nil, err
+	}
+
+	var scored int64
+	err = s.db.WithContext(ctx).
+		Model(&InterviewScore{}).
+		Joins("JOIN interviews ON interviews.id = interview_scores.interview_id").
+		Where("interviews.slot_id = ? AND interview_scores.staff_id = ?", slotID, staffID).
+		Count(&scored).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check panelist scores: %w", err)
+	}
+	if scored > 0 {
+		return nil, ErrPanelistHasScores
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("slot_id = ? AND staff_id = ?", slotID, staffID).Delete(&InterviewPanelist{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to remove panelist: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrPanelistNotFound
+		}
+		for i := range slot.Interviews {
+			if err := refreshInterviewResult(tx, &slot.Interviews[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSlot(ctx, tenantID, slotID)
+}
+
+// =====================================================================
+// Interviews
+// =====================================================================
+
+// ScheduleInterview books an application into an interview slot, moves the
+// application to interview_scheduled and sends the parent the schedule with a
+// reschedule link.
+func (s *InterviewService) ScheduleInterview(ctx context.Context, req ScheduleInterviewRequest) (*ScheduledInterview, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+	if req.ApplicationID == uuid.Nil {
+		return nil, ErrApplicationIDRequired
+	}
+
+	var application models.AdmissionApplication
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ? AND deleted_at IS NULL", req.TenantID, req.ApplicationID).
+		First(&application).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if application.Status != models.ApplicationStatusInterviewScheduled &&
+		!application.Status.CanTransitionTo(models.ApplicationStatusInterviewScheduled) {
+		return nil, ErrApplicationNotInterviewable
+	}
+
+	var open int64
+	err = s.db.WithContext(ctx).
+		Model(&Interview{}).
+		Where("application_id = ? AND status IN ?", req.ApplicationID, []InterviewStatus{InterviewStatusScheduled, InterviewStatusCompleted}).
+		Count(&open).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing interviews: %w", err)
+	}
+	if open > 0 {
+		return nil, ErrInterviewAlreadyScheduled
+	}
+
+	interview := &Interview{
+		TenantID:      req.TenantID,
+		SlotID:        req.SlotID,
+		ApplicationID: req.ApplicationID,
+		Status:        InterviewStatusScheduled,
+		CreatedBy:     req.ScheduledBy,
+		UpdatedBy:     req.ScheduledBy,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := bookableSlot(tx, req.TenantID, req.SlotID, &application, uuid.Nil); err != nil {
+			return err
+		}
+		if err := tx.Create(interview).Error; err != nil {
+			return fmt.Errorf("failed to create interview: %w", err)
+		}
+		if application.Status != models.ApplicationStatusInterviewScheduled {
+			if err := tx.Model(&models.AdmissionApplication{}).
+				Where("id = ?", application.ID).
+				Updates(map[string]interface{}{
+					"status":     models.ApplicationStatusInterviewScheduled,
+					"updated_by": req.ScheduledBy,
+					"updated_at": time.Now(),
+				}).Error; err != nil {
+				return fmt.Errorf("failed to update application status: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.notifyScheduled(ctx, req.TenantID, interview.ID, "is scheduled for")
+}
+
+// GetInterview retrieves an interview with its slot, application and scores.
+func (s *InterviewService) GetInterview(ctx context.Context, tenantID, id uuid.UUID) (*Interview, error) {
+	var interview Interview
+	err := s.db.WithContext(ctx).
+		Preload("Slot.Rubric").
+		Preload("Slot.Panelists.Staff").
+		Preload("Application").
+		Preload("Scores", func(db *gorm.DB) *gorm.DB { return db.Order("submitted_at") }).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&interview).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get interview: %w", err)
+	}
+	return &interview, nil
+}
+
+// ListInterviews lists interviews in slot order.
+func (s *InterviewService) ListInterviews(ctx context.Context, filter InterviewListFilter) ([]Interview, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	query := s.db.WithContext(ctx).
+		Preload("Slot").
+		Preload("Application").
+		Preload("Scores").
+		Joins("JOIN interview_slots ON interview_slots.id = interviews.slot_id").
+		Where("interviews.tenant_id = ?", filter.TenantID)
+
+	if filter.SessionID != nil {
+		query = query.Where("interview_slots.session_id = ?", *filter.SessionID)
+	}
+	if filter.SlotID != nil {
+		query = query.Where("interviews.slot_id = ?", *filter.SlotID)
+	}
+	if filter.ApplicationID != nil {
+		query = query.Where("interviews.application_id = ?", *filter.ApplicationID)
+	}
+	if filter.Status != nil {
+		query = query.Where("interviews.status = ?", *filter.Status)
+	}
+
+	var interviews []Interview
+	if err := query.Order("interview_slots.starts_at ASC, interviews.created_at ASC").Find(&interviews).Error; err != nil {
+		return nil, fmt.Errorf("failed to list interviews: %w", err)
+	}
+	return interviews, nil
+}
+
+// RescheduleInterview moves a scheduled interview to another slot and sends the
+// parent the new schedule.
+func (s *InterviewService) RescheduleInterview(ctx context.Context, tenantID, id, slotID uuid.UUID, updatedBy *uuid.UUID) (*ScheduledInterview, error) {
+	interview, err := s.GetInterview(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.moveInterview(ctx, interview, slotID, updatedBy); err != nil {
+		return nil, err
+	}
+	return s.notifyScheduled(ctx, tenantID, id, "has been moved to")
+}
+
+// CancelInterview cancels a scheduled interview. The application keeps its
+// interview_scheduled status so it can be booked into another slot.
+func (s *InterviewService) CancelInterview(ctx context.Context, tenantID, id uuid.UUID, remarks string, updatedBy *uuid.UUID) (*Interview, error) {
+	return s.closeInterview(ctx, tenantID, id, InterviewStatusCancelled, remarks, updatedBy)
+}
+
+// MarkNoShow records that the candidate did not attend a scheduled interview.
+func (s *InterviewService) MarkNoShow(ctx context.Context, tenantID, id uuid.UUID, remarks string, updatedBy *uuid.UUID) (*Interview, error) {
+	return s.closeInterview(ctx, tenantID, id, InterviewStatusNoShow, remarks, updatedBy)
+}
+
+// SubmitScore records a panelist's rubric scores for an interview, replacing
+// any earlier scores from the same panelist. Once every panelist has scored,
+// the interview is completed with the panel's average percentage and the
+// application moves to interview_completed.
+func (s *InterviewService) SubmitScore(ctx context.Context, req SubmitScoreRequest) (*Interview, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	interview, err := s.GetInterview(ctx, req.TenantID, req.InterviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Status != InterviewStatusScheduled && interview.Status != InterviewStatusCompleted {
+		return nil, ErrInterviewNotScorable
+	}
+
+	onPanel := false
+	for _, panelist := range interview.Slot.Panelists {
+		if panelist.StaffID == req.StaffID {
+			onPanel = true
+			break
+		}
+	}
+	if !onPanel {
+		return nil, ErrPanelistNotFound
+	}
+
+	criteria := interview.Slot.Rubric.Criteria
+	scores, total, err := scoreRubric(criteria, req.Scores)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var score InterviewScore
+		err := tx.Where("interview_id = ? AND staff_id = ?", interview.ID, req.StaffID).First(&score).Error
+		switch {
+		case err == nil:
+			if err := tx.Model(&score).Updates(map[string]interface{}{
+				"scores":       scores,
+				"total_score":  total,
+				"max_score":    criteria.MaxTotal(),
+				"remarks":      req.Remarks,
+				"submitted_by": req.SubmittedBy,
+				"submitted_at": now,
+				"updated_at":   now,
+			}).Error; err != nil {
+				return fmt.Errorf("failed to update interview score: %w", err)
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			score = InterviewScore{
+				TenantID:    req.TenantID,
+				InterviewID: interview.ID,
+				StaffID:     req.StaffID,
+				Scores:      scores,
+				TotalScore:  total,
+				MaxScore:    criteria.MaxTotal(),
+				Remarks:     req.Remarks,
+				SubmittedBy: req.SubmittedBy,
+				SubmittedAt: now,
+			}
+			if err := tx.Create(&score).Error; err != nil {
+				return fmt.Errorf("failed to create interview score: %w", err)
+			}
+		default:
+			return fmt.Errorf("failed to get interview score: %w", err)
+		}
+		return refreshInterviewResult(tx, interview)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetInterview(ctx, req.TenantID, req.InterviewID)
+}
+
+// =====================================================================
+// Parent reschedule links
+// =====================================================================
+
+// GetInterviewByToken returns the interview behind a parent's reschedule link
+// with the slots it can move to.
+func (s *InterviewService) GetInterviewByToken(ctx context.Context, token string) (*InterviewView, error) {
+	interview, err := s.interviewByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &InterviewView{
+		Interview:      interview,
+		StudentName:    interview.Application.StudentName,
+		SchoolName:     s.schoolName(ctx, interview.TenantID),
+		CanReschedule:  canParentReschedule(interview, time.Now()),
+		AvailableSlots: []InterviewSlot{},
+	}
+	if view.CanReschedule {
+		slots, err := s.ListSlots(ctx, SlotListFilter{
+			TenantID:      interview.TenantID,
+			SessionID:     &interview.Slot.SessionID,
+			ClassName:     interview.Application.ClassApplying,
+			AvailableOnly: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range slots {
+			if slot.ID != interview.SlotID {
+				view.AvailableSlots = append(view.AvailableSlots, slot)
+			}
+		}
+	}
+	return view, nil
+}
+
+// RescheduleByToken moves the interview behind a parent's reschedule link to
+// another slot. Parents can reschedule until InterviewRescheduleCutoff before
+// the interview and at most MaxInterviewReschedules times. The previous link
+// stops working and the new one is sent with the confirmation.
+func (s *InterviewService) RescheduleByToken(ctx context.Context, token string, slotID uuid.UUID) (*ScheduledInterview, error) {
+	interview, err := s.interviewByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !canParentReschedule(interview, time.Now()) {
+		return nil, ErrRescheduleClosed
+	}
+	if err := s.moveInterview(ctx, interview, slotID, nil); err != nil {
+		return nil, err
+	}
+	return s.notifyScheduled(ctx, interview.TenantID, interview.ID, "has been moved to")
+}
+
+// =====================================================================
+// Helpers
+// =====================================================================
+
+// checkSession verifies that an admission session exists for the tenant.
+func (s *InterviewService) checkSession(ctx context.Context, tenantID, sessionID uuid.UUID) error {
+	if sessionID == uuid.Nil {
+		return ErrSessionIDRequired
+	}
+	var count int64
+	err := s.db.WithContext(ctx).
+		Model(&models.AdmissionSession{}).
+		Where("tenant_id = ? AND id = ?", tenantID, sessionID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if count == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// moveInterview moves a scheduled interview to another bookable slot.
+func (s *InterviewService) moveInterview(ctx context.Context, interview *Interview, slotID uuid.UUID, updatedBy *uuid.UUID) error {
+	if interview.Status != InterviewStatusScheduled {
+		return ErrInterviewNotScheduled
+	}
+	if slotID == interview.SlotID {
+		return nil
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := bookableSlot(tx, interview.TenantID, slotID, interview.Application, interview.ID); err != nil {
+			return err
+		}
+		result := tx.Model(&Interview{}).
+			Where("id = ? AND status = ?", interview.ID, InterviewStatusScheduled).
+			Updates(map[string]interface{}{
+				"slot_id":          slotID,
+				"reschedule_count": gorm.Expr("reschedule_count + 1"),
+				"updated_by":       updatedBy,
+				"updated_at":       time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to reschedule interview: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrInterviewNotScheduled
+		}
+		return nil
+	})
+}
+
+// closeInterview ends a scheduled interview without a result.
+func (s *InterviewService) closeInterview(ctx context.Context, tenantID, id uuid.UUID, status InterviewStatus, remarks string, updatedBy *uuid.UUID) (*Interview, error) {
+	interview, err := s.GetInterview(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Status != InterviewStatusScheduled {
+		return nil, ErrInterviewNotScheduled
+	}
+
+	result := s.db.WithContext(ctx).
+		Model(&Interview{}).
+		Where("id = ? AND status = ?", id, InterviewStatusScheduled).
+		Updates(map[string]interface{}{
+			"status":                status,
+			"remarks":               remarks,
+			"reschedule_token_hash": nil,
+			"updated_by":            updatedBy,
+			"updated_at":            time.Now(),
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update interview: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInterviewNotScheduled
+	}
+
+	return s.GetInterview(ctx, tenantID, id)
+}
+
+// notifyScheduled reloads an interview and sends the parent its schedule with a fresh reschedule link.
+func (s *InterviewService) notifyScheduled(ctx context.Context, tenantID, id uuid.UUID, verb string) (*ScheduledInterview, error) {
+	interview, err := s.GetInterview(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.issueLinkAndNotify(ctx, interview, verb)
+	if err != nil {
+		return nil, err
+	}
+	return &ScheduledInterview{Interview: interview, Token: token}, nil
+}
+
+// issueLinkAndNotify replaces the interview's reschedule link and sends the
+// schedule to the parent by SMS. Delivery failures are counted in metrics but
+// do not fail the booking.
+func (s *InterviewService) issueLinkAndNotify(ctx context.Context, interview *Interview, verb string) (string, error) {
+	token, err := newPublicToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate reschedule link: %w", err)
+	}
+	tokenHash := hashPublicToken(token)
+	now := time.Now()
+
+	if err := s.db.WithContext(ctx).Model(&Interview{}).
+		Where("id = ?", interview.ID).
+		Updates(map[string]interface{}{
+			"reschedule_token_hash": tokenHash,
+			"notified_at":           now,
+		}).Error; err != nil {
+		return "", fmt.Errorf("failed to update reschedule link: %w", err)
+	}
+	interview.RescheduleTokenHash = &tokenHash
+	interview.NotifiedAt = &now
+
+	application := interview.Application
+	slot := interview.Slot
+	if application == nil || slot == nil {
+		return token, nil
+	}
+
+	body := fmt.Sprintf("The admission interview of %s (application %s) at %s %s %s",
+		application.StudentName, application.ApplicationNumber, s.schoolName(ctx, interview.TenantID),
+		verb, slot.StartsAt.Local().Format("Mon 2 Jan 2006, 3:04 PM"))
+	if slot.Venue != "" {
+		body += " at " + slot.Venue
+	}
+	body += ". To reschedule, visit " + s.publicBaseURL + "/api/v1/public/interviews/" + token
+
+	phone := firstNonEmpty(application.VerifiedPhone, application.FatherPhone, application.MotherPhone, application.GuardianPhone)
+	if phone != "" && s.smsProvider != nil && s.smsProvider.IsReady() {
+		_, _ = s.smsProvider.Send(ctx, sms.Message{To: phone, Body: body})
+	}
+
+	// TODO: Email the schedule once an email service is integrated
+
+	return token, nil
+}
+
+// interviewByToken finds the scheduled interview behind a reschedule link.
+func (s *InterviewService) interviewByToken(ctx context.Context, token string) (*Interview, error) {
+	if token == "" {
+		return nil, ErrRescheduleLinkInvalid
+	}
+
+	var interview Interview
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the link identifies the tenant
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Where("reschedule_token_hash = ? AND status = ?", hashPublicToken(token), InterviewStatusScheduled).
+			First(&interview).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRescheduleLinkInvalid
+		}
+		return nil, fmt.Errorf("failed to get interview: %w", err)
+	}
+	return s.GetInterview(ctx, interview.TenantID, interview.ID)
+}
+
+// schoolName returns the tenant's name for parent notifications.
+func (s *InterviewService) schoolName(ctx context.Context, tenantID uuid.UUID) string {
+	var tenant models.Tenant
+	if err := s.db.WithContext(ctx).Select("name").First(&tenant, "id = ?", tenantID).Error; err != nil {
+		return "the school"
+	}
+	return tenant.Name
+}
+
+// canParentReschedule reports whether the parent link may still move the interview.
+func canParentReschedule(interview *Interview, now time.Time) bool {
+	return interview.Status == InterviewStatusScheduled &&
+		interview.RescheduleCount < MaxInterviewReschedules &&
+		interview.Slot != nil &&
+		now.Add(InterviewRescheduleCutoff).Before(interview.Slot.StartsAt)
+}
+
+// bookableSlot loads a slot and checks an application can be booked into it.
+// exceptID excludes the interview being moved from the capacity count. It must
+// run in the booking transaction, which then holds the slot until it commits.
+func bookableSlot(tx *gorm.DB, tenantID, slotID uuid.UUID, application *models.AdmissionApplication, exceptID uuid.UUID) (*InterviewSlot, error) {
+	var slot InterviewSlot
+	if err := tx.Where("tenant_id = ? AND id = ?", tenantID, slotID).First(&slot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInterviewSlotNotFound
+		}
+		return nil, fmt.Errorf("failed to get interview slot: %w", err)
+	}
+	if application == nil || slot.SessionID != application.SessionID {
+		return nil, ErrInterviewSlotNotFound
+	}
+	if slot.ClassName != "" && slot.ClassName != application.ClassApplying {
+		return nil, ErrSlotClassMismatch
+	}
+	if !slot.StartsAt.After(time.Now()) {
+		return nil, ErrSlotInPast
+	}
+
+	// Touch the slot row so concurrent bookings for the slot queue behind this one.
+	if err := tx.Model(&slot).Update("updated_at", time.Now()).Error; err != nil {
+		return nil, fmt.Errorf("failed to lock interview slot: %w", err)
+	}
+
+	var booked int64
+	if err := tx.Model(&Interview{}).
+		Where("slot_id = ? AND id <> ? AND status IN ?", slotID, exceptID, []InterviewStatus{InterviewStatusScheduled, InterviewStatusCompleted}).
+		Count(&booked).Error; err != nil {
+		return nil, fmt.Errorf("failed to count slot bookings: %w", err)
+	}
+	if int(booked) >= slot.Capacity {
+		return nil, ErrSlotFull
+	}
+	return &slot, nil
+}
+
+// addPanelist adds an active staff member to a slot's panel after checking
+// they are free for the slot's time.
+func addPanelist(tx *gorm.DB, slot *InterviewSlot, staffID uuid.UUID) error {
+	var staff models.Staff
+	err := tx.Select("id").
+		Where("tenant_id = ? AND id = ? AND status = ?", slot.TenantID, staffID, models.StaffStatusActive).
+		First(&staff).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrStaffNotFound
+		}
+		return fmt.Errorf("failed to get staff: %w", err)
+	}
+
+	var existing int64
+	if err := tx.Model(&InterviewPanelist{}).Where("slot_id = ? AND staff_id = ?", slot.ID, staffID).Count(&existing).Error; err != nil {
+		return fmt.Errorf("failed to check panel: %w", err)
+	}
+	if existing > 0 {
+		return ErrPanelistAlreadyAssigned
+	}
+	if err := checkPanelistFree(tx, slot.TenantID, staffID, slot.ID, slot.StartsAt, slot.EndsAt); err != nil {
+		return err
+	}
+
+	panelist := &InterviewPanelist{TenantID: slot.TenantID, SlotID: slot.ID, StaffID: staffID}
+	if err := tx.Create(panelist).Error; err != nil {
+		return fmt.Errorf("failed to add panelist: %w", err)
+	}
+	return nil
+}
+
+// checkPanelistFree returns ErrPanelistConflict when the staff member sits on
+// another slot overlapping [startsAt, endsAt).
+func checkPanelistFree(tx *gorm.DB, tenantID, staffID, slotID uuid.UUID, startsAt, endsAt time.Time) error {
+	var conflicts int64
+	err := tx.Model(&InterviewPanelist{}).
+		Joins("JOIN interview_slots ON interview_slots.id = interview_panelists.slot_id").
+		Where("interview_panelists.tenant_id = ? AND interview_panelists.staff_id = ? AND interview_slots.id <> ?", tenantID, staffID, slotID).
+		Where("interview_slots.starts_at < ? AND interview_slots.ends_at > ?", endsAt, startsAt).
+		Count(&conflicts).Error
+	if err != nil {
+		return fmt.Errorf("failed to check panelist schedule: %w", err)
+	}
+	if conflicts > 0 {
+		return ErrPanelistConflict
+	}
+	return nil
+}
+
+// refreshInterviewResult completes an interview once every panelist on its
+// slot has scored it, recording the average of the panel's percentages.
+func refreshInterviewResult(tx *gorm.DB, interview *Interview) error {
+	if interview.Status != InterviewStatusScheduled && interview.Status != InterviewStatusCompleted {
+		return nil
+	}
+
+	var panelists []InterviewPanelist
+	if err := tx.Where("slot_id = ?", interview.SlotID).Find(&panelists).Error; err != nil {
+		return fmt.Errorf("failed to get panel: %w", err)
+	}
+	var scores []InterviewScore
+	if err := tx.Where("interview_id = ?", interview.ID).Find(&scores).Error; err != nil {
+		return fmt.Errorf("failed to get interview scores: %w", err)
+	}
+	if len(panelists) == 0 {
+		return nil
+	}
+
+	byStaff := make(map[uuid.UUID]InterviewScore, len(scores))
+	for _, score := range scores {
+		byStaff[score.StaffID] = score
+	}
+	sum := decimal.Zero
+	for _, panelist := range panelists {
+		score, ok := byStaff[panelist.StaffID]
+		if !ok {
+			return nil
+		}
+		if score.MaxScore.IsPositive() {
+			sum = sum.Add(score.TotalScore.Div(score.MaxScore).Mul(decimal.NewFromInt(100)))
+		}
+	}
+	percentage := sum.Div(decimal.NewFromInt(int64(len(panelists)))).Round(2)
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":                InterviewStatusCompleted,
+		"score_percentage":      percentage,
+		"reschedule_token_hash": nil,
+		"updated_at":            now,
+	}
+	if interview.CompletedAt == nil {
+		updates["completed_at"] = now
+	}
+	if err := tx.Model(&Interview{}).Where("id = ?", interview.ID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to complete interview: %w", err)
+	}
+
+	if err := tx.Model(&models.AdmissionApplication{}).
+		Where("id = ? AND status = ?", interview.ApplicationID, models.ApplicationStatusInterviewScheduled).
+		Updates(map[string]interface{}{
+			"status":     models.ApplicationStatusInterviewCompleted,
+			"updated_at": now,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update application status: %w", err)
+	}
+	return nil
+}
+
+// scoreRubric checks a panelist's scores against the rubric and returns them
+// with their total.
+func scoreRubric(criteria InterviewCriteria, scores map[string]decimal.Decimal) (MarksMap, decimal.Decimal, error) {
+	if len(scores) != len(criteria) {
+		return nil, decimal.Zero, ErrInvalidInterviewScore
+	}
+
+	result := MarksMap{}
+	total := decimal.Zero
+	for _, criterion := range criteria {
+		score, ok := scores[criterion.Key]
+		if !ok || score.IsNegative() || score.GreaterThan(criterion.MaxScore) {
+			return nil, decimal.Zero, fmt.Errorf("%w: %s", ErrInvalidInterviewScore, criterion.Name)
+		}
+		result[criterion.Key] = score
+		total = total.Add(score)
+	}
+	return result, total, nil
+}
+
+// validateCriteria checks that a rubric has criteria with unique keys and positive maximum scores.
+func validateCriteria(criteria InterviewCriteria) error {
+	if len(criteria) == 0 {
+		return ErrInvalidRubric
+	}
+	keys := make(map[string]bool, len(criteria))
+	for _, criterion := range criteria {
+		if strings.TrimSpace(criterion.Key) == "" || strings.TrimSpace(criterion.Name) == "" ||
+			!criterion.MaxScore.IsPositive() || keys[criterion.Key] {
+			return ErrInvalidRubric
+		}
+		keys[criterion.Key] = true
+	}
+	return nil
+}
+
+// openInterviewCount counts a slot's scheduled and completed interviews.
+func openInterviewCount(slot *InterviewSlot) int {
+	count := 0
+	for _, interview := range slot.Interviews {
+		if interview.Status == InterviewStatusScheduled || interview.Status == InterviewStatusCompleted {
+			count++
+		}
+	}
+	return count
+}
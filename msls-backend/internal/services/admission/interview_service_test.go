@@ -0,0 +1,324 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+type interviewFixture struct {
+	db        *gorm.DB
+	service   *InterviewService
+	tenantID  uuid.UUID
+	sessionID uuid.UUID
+	rubric    *InterviewRubric
+	staff     []uuid.UUID
+}
+
+// setupInterviews creates an interview service for a tenant with one admission
+// session, a two-criterion rubric (communication 10, reasoning 10) and three
+// active staff members.
+func setupInterviews(t *testing.T) *interviewFixture {
+	t.Helper()
+
+	db := newAdmissionTestDB(t,
+		&models.Tenant{}, &models.AdmissionSession{}, &models.AdmissionApplication{}, &models.Staff{},
+		&InterviewRubric{}, &InterviewSlot{}, &InterviewPanelist{}, &Interview{}, &InterviewScore{},
+	)
+	session := seedAdmissionSession(t, db, models.SessionSettings{})
+
+	f := &interviewFixture{
+		db:        db,
+		service:   NewInterviewService(db, InterviewConfig{PublicBaseURL: "https://api.example.com/"}),
+		tenantID:  session.TenantID,
+		sessionID: session.ID,
+	}
+
+	branchID := uuid.New()
+	for _, name := range []string{"Meera", "Ravi", "Farah"} {
+		staff := &models.Staff{
+			TenantID:    session.TenantID,
+			BranchID:    branchID,
+			EmployeeID:  "EMP-" + name,
+			FirstName:   name,
+			LastName:    "Teacher",
+			DateOfBirth: time.Date(1985, time.June, 1, 0, 0, 0, 0, time.UTC),
+			Gender:      models.GenderFemale,
+			WorkEmail:   name + "@example.com",
+			WorkPhone:   "9800000000",
+			StaffType:   models.StaffTypeTeaching,
+			JoinDate:    time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC),
+		}
+		require.NoError(t, db.Create(staff).Error)
+		f.staff = append(f.staff, staff.ID)
+	}
+
+	var err error
+	f.rubric, err = f.service.CreateRubric(context.Background(), RubricRequest{
+		TenantID:  session.TenantID,
+		SessionID: session.ID,
+		Name:      "Class 1 Interview",
+		Criteria: InterviewCriteria{
+			{Key: "communication", Name: "Communication", MaxScore: decimal.NewFromInt(10)},
+			{Key: "reasoning", Name: "Reasoning", MaxScore: decimal.NewFromInt(10)},
+		},
+	})
+	require.NoError(t, err)
+
+	return f
+}
+
+// createSlot creates a one-hour Class 1 slot starting in the given time with the given panel.
+func (f *interviewFixture) createSlot(t *testing.T, startsIn time.Duration, capacity int, staffIDs ...uuid.UUID) *InterviewSlot {
+	t.Helper()
+
+	startsAt := time.Now().Add(startsIn).Truncate(time.Second)
+	slot, err := f.service.CreateSlot(context.Background(), CreateSlotRequest{
+		TenantID:  f.tenantID,
+		SessionID: f.sessionID,
+		RubricID:  f.rubric.ID,
+		ClassName: "Class 1",
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(time.Hour),
+		Venue:     "Room 12",
+		Capacity:  capacity,
+		StaffIDs:  staffIDs,
+	})
+	require.NoError(t, err)
+	return slot
+}
+
+// createApplication creates a documents-verified Class 1 application.
+func (f *interviewFixture) createApplication(t *testing.T, name string) *models.AdmissionApplication {
+	t.Helper()
+
+	application := &models.AdmissionApplication{
+		ID:                uuid.New(),
+		TenantID:          f.tenantID,
+		SessionID:         f.sessionID,
+		ApplicationNumber: "APP-" + name,
+		StudentName:       name,
+		ClassApplying:     "Class 1",
+		FatherName:        "Father of " + name,
+		FatherPhone:       "9876543210",
+		Status:            models.ApplicationStatusDocumentsVerified,
+	}
+	require.NoError(t, f.db.Create(application).Error)
+	return application
+}
+
+func (f *interviewFixture) applicationStatus(t *testing.T, id uuid.UUID) models.ApplicationStatus {
+	t.Helper()
+
+	var application models.AdmissionApplication
+	require.NoError(t, f.db.First(&application, "id = ?", id).Error)
+	return application.Status
+}
+
+func TestInterviewService_PanelistConflict(t *testing.T) {
+	f := setupInterviews(t)
+	ctx := context.Background()
+
+	f.createSlot(t, 72*time.Hour, 1, f.staff[0])
+
+	// Overlapping slot with the same panelist is rejected
+	startsAt := time.Now().Add(72*time.Hour + 30*time.Minute)
+	_, err := f.service.CreateSlot(ctx, CreateSlotRequest{
+		TenantID:  f.tenantID,
+		SessionID: f.sessionID,
+		RubricID:  f.rubric.ID,
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(time.Hour),
+		StaffIDs:  []uuid.UUID{f.staff[1], f.staff[0]},
+	})
+	assert.ErrorIs(t, err, ErrPanelistConflict)
+
+	var slots int64
+	require.NoError(t, f.db.Model(&InterviewSlot{}).Count(&slots).Error)
+	assert.Equal(t, int64(1), slots, "the conflicting slot is rolled back")
+
+	// A back-to-back slot is fine
+	next := f.createSlot(t, 73*time.Hour, 1, f.staff[0])
+	require.Len(t, next.Panelists, 1)
+
+	// Adding the same panelist twice is rejected
+	_, err = f.service.AddPanelist(ctx, f.tenantID, next.ID, f.staff[0])
+	assert.ErrorIs(t, err, ErrPanelistAlreadyAssigned)
+
+	// Moving a slot onto a panelist's other slot is rejected
+	overlap := time.Now().Add(72 * time.Hour)
+	overlapEnd := overlap.Add(time.Hour)
+	_, err = f.service.UpdateSlot(ctx, f.tenantID, next.ID, UpdateSlotRequest{StartsAt: &overlap, EndsAt: &overlapEnd})
+	assert.ErrorIs(t, err, ErrPanelistConflict)
+}
+
+func TestInterviewService_ScheduleInterview(t *testing.T) {
+	f := setupInterviews(t)
+	ctx := context.Background()
+
+	slot := f.createSlot(t, 72*time.Hour, 1, f.staff[0])
+	asha := f.createApplication(t, "Asha")
+	kabir := f.createApplication(t, "Kabir")
+
+	scheduled, err := f.service.ScheduleInterview(ctx, ScheduleInterviewRequest{
+		TenantID:      f.tenantID,
+		ApplicationID: asha.ID,
+		SlotID:        slot.ID,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, scheduled.Token)
+	assert.Equal(t, InterviewStatusScheduled, scheduled.Interview.Status)
+	require.NotNil(t, scheduled.Interview.RescheduleTokenHash)
+	assert.Equal(t, hashPublicToken(scheduled.Token), *scheduled.Interview.RescheduleTokenHash)
+	assert.NotNil(t, scheduled.Interview.NotifiedAt)
+	assert.Equal(t, models.ApplicationStatusInterviewScheduled, f.applicationStatus(t, asha.ID))
+
+	// The application cannot be booked twice
+	_, err = f.service.ScheduleInterview(ctx, ScheduleInterviewRequest{TenantID: f.tenantID, ApplicationID: asha.ID, SlotID: slot.ID})
+	assert.ErrorIs(t, err, ErrInterviewAlreadyScheduled)
+
+	// The slot is full
+	_, err = f.service.ScheduleInterview(ctx, ScheduleInterviewRequest{TenantID: f.tenantID, ApplicationID: kabir.ID, SlotID: slot.ID})
+	assert.ErrorIs(t, err, ErrSlotFull)
+	assert.Equal(t, models.ApplicationStatusDocumentsVerified, f.applicationStatus(t, kabir.ID))
+
+	// Cancelling frees the seat
+	_, err = f.service.CancelInterview(ctx, f.tenantID, scheduled.Interview.ID, "Parent requested", nil)
+	require.NoError(t, err)
+	_, err = f.service.ScheduleInterview(ctx, ScheduleInterviewRequest{TenantID: f.tenantID, ApplicationID: kabir.ID, SlotID: slot.ID})
+	require.NoError(t, err)
+
+	// Slots in the past cannot be booked
+	past := f.createSlot(t, -2*time.Hour, 1, f.staff[1])
+	_, err = f.service.ScheduleInterview(ctx, ScheduleInterviewRequest{TenantID: f.tenantID, ApplicationID: asha.ID, SlotID: past.ID})
+	assert.ErrorIs(t, err, ErrSlotInPast)
+}
+
+func TestInterviewService_SubmitScore(t *testing.T) {
+	f := setupInterviews(t)
+	ctx := context.Background()
+
+	slot := f.createSlot(t, 72*time.Hour, 1, f.staff[0], f.staff[1])
+	asha := f.createApplication(t, "Asha")
+	scheduled, err := f.service.ScheduleInterview(ctx, ScheduleInterviewRequest{TenantID: f.tenantID, ApplicationID: asha.ID, SlotID: slot.ID})
+	require.NoError(t, err)
+	interviewID := scheduled.Interview.ID
+
+	score := func(staffID uuid.UUID, communication, reasoning int64) (*Interview, error) {
+		return f.service.SubmitScore(ctx, SubmitScoreRequest{
+			TenantID:    f.tenantID,
+			InterviewID: interviewID,
+			StaffID:     staffID,
+			Scores: map[string]decimal.Decimal{
+				"communication": decimal.NewFromInt(communication),
+				"reasoning":     decimal.NewFromInt(reasoning),
+			},
+		})
+	}
+
+	// Scores above a criterion's maximum are rejected
+	_, err = score(f.staff[0], 11, 5)
+	assert.ErrorIs(t, err, ErrInvalidInterviewScore)
+
+	// Staff outside the panel cannot score
+	_, err = score(f.staff[2], 5, 5)
+	assert.ErrorIs(t, err, ErrPanelistNotFound)
+
+	// First panelist: 16/20 = 80%. The interview waits for the rest of the panel.
+	interview, err := score(f.staff[0], 8, 8)
+	require.NoError(t, err)
+	assert.Equal(t, InterviewStatusScheduled, interview.Status)
+	assert.Nil(t, interview.ScorePercentage)
+
+	// Second panelist: 12/20 = 60%. The interview completes at the average, 70%.
+	interview, err = score(f.staff[1], 5, 7)
+	require.NoError(t, err)
+	assert.Equal(t, InterviewStatusCompleted, interview.Status)
+	require.NotNil(t, interview.ScorePercentage)
+	assert.True(t, decimal.NewFromInt(70).Equal(*interview.ScorePercentage), "got %s", interview.ScorePercentage)
+	assert.NotNil(t, interview.CompletedAt)
+	assert.Nil(t, interview.RescheduleTokenHash)
+	assert.Len(t, interview.Scores, 2)
+	assert.Equal(t, models.ApplicationStatusInterviewCompleted, f.applicationStatus(t, asha.ID))
+
+	// A panelist can revise their score
+	interview, err = score(f.staff[1], 8, 8)
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(80).Equal(*interview.ScorePercentage), "got %s", interview.ScorePercentage)
+
+	// Scored panelists cannot leave the panel, and the rubric is locked
+	_, err = f.service.RemovePanelist(ctx, f.tenantID, slot.ID, f.staff[1])
+	assert.ErrorIs(t, err, ErrPanelistHasScores)
+	_, err = f.service.UpdateRubric(ctx, f.rubric.ID, RubricRequest{
+		TenantID: f.tenantID,
+		Name:     "Changed",
+		Criteria: InterviewCriteria{{Key: "overall", Name: "Overall", MaxScore: decimal.NewFromInt(10)}},
+	})
+	assert.ErrorIs(t, err, ErrRubricInUse)
+}
+
+func TestInterviewService_RescheduleByToken(t *testing.T) {
+	f := setupInterviews(t)
+	ctx := context.Background()
+
+	first := f.createSlot(t, 72*time.Hour, 1, f.staff[0])
+	second := f.createSlot(t, 96*time.Hour, 1, f.staff[0])
+	third := f.createSlot(t, 120*time.Hour, 1, f.staff[0])
+	soon := f.createSlot(t, 12*time.Hour, 1, f.staff[1])
+	asha := f.createApplication(t, "Asha")
+
+	scheduled, err := f.service.ScheduleInterview(ctx, ScheduleInterviewRequest{TenantID: f.tenantID, ApplicationID: asha.ID, SlotID: first.ID})
+	require.NoError(t, err)
+
+	view, err := f.service.GetInterviewByToken(ctx, scheduled.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "Asha", view.StudentName)
+	assert.Equal(t, "Green Valley School", view.SchoolName)
+	assert.True(t, view.CanReschedule)
+	assert.Len(t, view.AvailableSlots, 3, "the other open slots, excluding the booked one")
+
+	moved, err := f.service.RescheduleByToken(ctx, scheduled.Token, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, moved.Interview.SlotID)
+	assert.Equal(t, 1, moved.Interview.RescheduleCount)
+	assert.NotEqual(t, scheduled.Token, moved.Token)
+
+	// The old link stops working
+	_, err = f.service.GetInterviewByToken(ctx, scheduled.Token)
+	assert.ErrorIs(t, err, ErrRescheduleLinkInvalid)
+
+	moved, err = f.service.RescheduleByToken(ctx, moved.Token, soon.ID)
+	require.NoError(t, err)
+	assert.Equal(t, soon.ID, moved.Interview.SlotID)
+
+	// Within the cutoff, and past the reschedule limit, the link is read-only
+	view, err = f.service.GetInterviewByToken(ctx, moved.Token)
+	require.NoError(t, err)
+	assert.False(t, view.CanReschedule)
+	assert.Empty(t, view.AvailableSlots)
+	_, err = f.service.RescheduleByToken(ctx, moved.Token, third.ID)
+	assert.ErrorIs(t, err, ErrRescheduleClosed)
+
+	// Staff can still move it
+	_, err = f.service.RescheduleInterview(ctx, f.tenantID, moved.Interview.ID, third.ID, nil)
+	require.NoError(t, err)
+}
+
+func TestMeritScore(t *testing.T) {
+	test, interview := 80.0, 60.0
+
+	assert.Equal(t, 74.0, meritScore(&test, &interview, 30))
+	assert.Equal(t, 80.0, meritScore(&test, &interview, 0))
+	assert.Equal(t, 60.0, meritScore(&test, &interview, 100))
+	assert.Equal(t, 80.0, meritScore(&test, nil, 30))
+	assert.Equal(t, 60.0, meritScore(nil, &interview, 30))
+	assert.Equal(t, 0.0, meritScore(nil, nil, 30))
+}
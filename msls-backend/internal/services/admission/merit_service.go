@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"time"
 
@@ -24,6 +25,10 @@ func NewMeritService(db *gorm.DB) *MeritService {
 	return &MeritService{db: db}
 }
 
+// DefaultInterviewWeight is the percentage of the merit score taken from the
+// interview when a candidate has both test and interview results.
+const DefaultInterviewWeight = 30.0
+
 // GenerateMeritListRequest represents a request to generate a merit list.
 type GenerateMeritListRequest struct {
 	TenantID    uuid.UUID
@@ -31,7 +36,10 @@ type GenerateMeritListRequest struct {
 	ClassName   string
 	TestID      *uuid.UUID
 	CutoffScore *float64
-	GeneratedBy *uuid.UUID
+	// InterviewWeight is the percentage (0-100) of the score taken from the
+	// interview. Defaults to DefaultInterviewWeight.
+	InterviewWeight *float64
+	GeneratedBy     *uuid.UUID
 }
 
 // GenerateMeritList generates a merit list for a session and class.
@@ -45,6 +53,13 @@ func (s *MeritService) GenerateMeritList(ctx context.Context, req GenerateMeritL
 	if req.ClassName == "" {
 		return nil, ErrClassNameRequired
 	}
+	interviewWeight := DefaultInterviewWeight
+	if req.InterviewWeight != nil {
+		interviewWeight = *req.InterviewWeight
+	}
+	if interviewWeight < 0 || interviewWeight > 100 {
+		return nil, ErrInvalidInterviewWeight
+	}
 
 	// Verify session exists
 	var session models.AdmissionSession
@@ -89,6 +104,12 @@ func (s *MeritService) GenerateMeritList(ctx context.Context, req GenerateMeritL
 		Where("status IN ?", []string{
 			string(models.ApplicationStatusSubmitted),
 			string(models.ApplicationStatusUnderReview),
+			string(models.ApplicationStatusDocumentsVerified),
+			string(models.ApplicationStatusTestScheduled),
+			string(models.ApplicationStatusTestCompleted),
+			string(models.ApplicationStatusInterviewScheduled),
+			string(models.ApplicationStatusInterviewCompleted),
+			string(models.ApplicationStatusShortlisted),
 			string(models.ApplicationStatusApproved),
 			string(models.ApplicationStatusWaitlisted),
 		}).
@@ -101,6 +122,19 @@ func (s *MeritService) GenerateMeritList(ctx context.Context, req GenerateMeritL
 		return nil, ErrNoApplicantsForMeritList
 	}
 
+	applicationIDs := make([]uuid.UUID, len(applications))
+	for i, app := range applications {
+		applicationIDs[i] = app.ID
+	}
+	testScores, err := s.testScores(ctx, req.SessionID, req.TestID, applicationIDs)
+	if err != nil {
+		return nil, err
+	}
+	interviewScores, err := s.interviewScores(ctx, applicationIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build merit list entries
 	entries := make(models.MeritListEntries, 0, len(applications))
 	for _, app := range applications {
@@ -124,10 +158,17 @@ func (s *MeritService) GenerateMeritList(ctx context.Context, req GenerateMeritL
 			entry.ParentEmail = &app.MotherEmail
 		}
 
-		// TODO: Get test scores from entrance_tests table when available
-		// For now, we use a placeholder score calculation
-		// In a real implementation, you would join with test_results table
-		entry.Score = calculateMockScore(&app)
+		if score, ok := testScores[app.ID]; ok {
+			entry.TestScore = &score
+		}
+		if score, ok := interviewScores[app.ID]; ok {
+			entry.InterviewScore = &score
+		}
+		if app.PreviousPercentage != nil {
+			previous := app.PreviousPercentage.InexactFloat64()
+			entry.PreviousMarks = &previous
+		}
+		entry.Score = meritScore(entry.TestScore, entry.InterviewScore, interviewWeight)
 
 		entries = append(entries, entry)
 	}
@@ -173,28 +214,70 @@ func (s *MeritService) GenerateMeritList(ctx context.Context, req GenerateMeritL
 	return meritList, nil
 }
 
-// calculateMockScore calculates a mock score for an application.
-// This is a placeholder until test scores are integrated.
-func calculateMockScore(app *models.AdmissionApplication) float64 {
-	// In a real implementation, this would:
-	// 1. Get test scores from entrance_tests table
-	// 2. Calculate weighted average based on test weights
-	// 3. Include interview scores if applicable
-	// 4. Include previous academic marks if applicable
+// testScores returns each application's entrance test percentage: the result
+// of the given test, or the average of its results across the session's tests.
+func (s *MeritService) testScores(ctx context.Context, sessionID uuid.UUID, testID *uuid.UUID, applicationIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	query := s.db.WithContext(ctx).
+		Model(&TestRegistration{}).
+		Select("test_registrations.application_id, AVG(test_registrations.percentage) AS percentage").
+		Joins("JOIN entrance_tests ON entrance_tests.id = test_registrations.test_id").
+		Where("entrance_tests.session_id = ? AND test_registrations.application_id IN ?", sessionID, applicationIDs).
+		Where("test_registrations.percentage IS NOT NULL").
+		Group("test_registrations.application_id")
+	if testID != nil {
+		query = query.Where("test_registrations.test_id = ?", *testID)
+	}
 
-	// For now, return a deterministic score based on application data
-	// This ensures consistent ordering
-	score := float64(50) // Base score
+	var rows []struct {
+		ApplicationID uuid.UUID
+		Percentage    float64
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get test scores: %w", err)
+	}
 
-	// Add some variation based on application details
-	if app.Category != "" {
-		score += 5
+	scores := make(map[uuid.UUID]float64, len(rows))
+	for _, row := range rows {
+		scores[row.ApplicationID] = round2(row.Percentage)
 	}
-	if app.PreviousSchool != "" {
-		score += 10
+	return scores, nil
+}
+
+// interviewScores returns the panel percentage of each application's completed interview.
+func (s *MeritService) interviewScores(ctx context.Context, applicationIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	var interviews []Interview
+	err := s.db.WithContext(ctx).
+		Where("application_id IN ? AND status = ? AND score_percentage IS NOT NULL", applicationIDs, InterviewStatusCompleted).
+		Find(&interviews).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interview scores: %w", err)
+	}
+
+	scores := make(map[uuid.UUID]float64, len(interviews))
+	for _, interview := range interviews {
+		scores[interview.ApplicationID] = interview.ScorePercentage.InexactFloat64()
 	}
+	return scores, nil
+}
+
+// meritScore combines the test and interview percentages, giving the interview
+// interviewWeight percent of the score. A candidate with only one result is
+// ranked on it alone; one with neither scores zero.
+func meritScore(testScore, interviewScore *float64, interviewWeight float64) float64 {
+	switch {
+	case testScore != nil && interviewScore != nil:
+		return round2(*testScore*(100-interviewWeight)/100 + *interviewScore*interviewWeight/100)
+	case testScore != nil:
+		return *testScore
+	case interviewScore != nil:
+		return *interviewScore
+	}
+	return 0
+}
 
-	return score
+// round2 rounds a score to two decimal places.
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
 }
 
 // GetMeritListRequest represents a request to get a merit list.
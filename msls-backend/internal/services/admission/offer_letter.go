@@ -0,0 +1,191 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// OfferLetterData contains everything printed on an offer letter.
+type OfferLetterData struct {
+	SchoolName        string
+	SchoolAddress     string
+	Currency          string
+	ApplicationNumber string
+	StudentName       string
+	ParentName        string
+	ClassName         string
+	Section           string
+	SessionName       string
+	Fees              models.OfferFeeItems
+	ValidUntil        time.Time
+	IssuedAt          time.Time
+}
+
+// renderOfferLetter renders a single-page A4 offer letter.
+func renderOfferLetter(data *OfferLetterData) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth := 170.0 // 210 - 40 (margins)
+
+	primaryColor := []int{31, 41, 55}
+	accentColor := []int{16, 185, 129}
+	lightBg := []int{249, 250, 251}
+	borderColor := []int{229, 231, 235}
+	mutedText := []int{107, 114, 128}
+
+	// Letterhead
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	pdf.SetFont("Arial", "B", 18)
+	pdf.CellFormat(pageWidth, 10, tr(data.SchoolName), "", 1, "C", false, 0, "")
+	if data.SchoolAddress != "" {
+		pdf.SetFont("Arial", "", 10)
+		pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+		pdf.CellFormat(pageWidth, 5, tr(data.SchoolAddress), "", 1, "C", false, 0, "")
+	}
+	pdf.Ln(4)
+	pdf.SetDrawColor(accentColor[0], accentColor[1], accentColor[2])
+	pdf.SetLineWidth(0.8)
+	pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+	pdf.Ln(6)
+
+	// Reference and date
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	pdf.CellFormat(pageWidth/2, 6, "Ref: "+data.ApplicationNumber, "", 0, "L", false, 0, "")
+	pdf.CellFormat(pageWidth/2, 6, "Date: "+data.IssuedAt.Format("02 Jan 2006"), "", 1, "R", false, 0, "")
+	pdf.Ln(4)
+
+	// Title
+	pdf.SetFillColor(accentColor[0], accentColor[1], accentColor[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(pageWidth, 10, "OFFER OF ADMISSION", "0", 1, "C", true, 0, "")
+	pdf.Ln(6)
+
+	// Body
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	pdf.SetFont("Arial", "", 11)
+	salutation := "Dear Parent/Guardian,"
+	if data.ParentName != "" {
+		salutation = fmt.Sprintf("Dear %s,", data.ParentName)
+	}
+	pdf.MultiCell(pageWidth, 6, tr(salutation), "", "L", false)
+	pdf.Ln(2)
+	pdf.MultiCell(pageWidth, 6, tr(fmt.Sprintf(
+		"We are pleased to offer admission to %s in %s for the %s admission session. "+
+			"The seat is reserved until %s and will be released to the waitlist if the offer is not accepted by then.",
+		data.StudentName, data.ClassName, data.SessionName, data.ValidUntil.Format("02 Jan 2006"))), "", "L", false)
+	pdf.Ln(4)
+
+	// Seat details
+	pdf.SetFont("Arial", "B", 11)
+	pdf.CellFormat(pageWidth, 8, "Seat Details", "", 1, "L", false, 0, "")
+	pdf.SetDrawColor(borderColor[0], borderColor[1], borderColor[2])
+	pdf.SetLineWidth(0.2)
+	details := [][2]string{
+		{"Student", data.StudentName},
+		{"Application No.", data.ApplicationNumber},
+		{"Admission Session", data.SessionName},
+		{"Class", data.ClassName},
+	}
+	if data.Section != "" {
+		details = append(details, [2]string{"Section", data.Section})
+	}
+	for i, row := range details {
+		fill := i%2 == 0
+		pdf.SetFillColor(lightBg[0], lightBg[1], lightBg[2])
+		pdf.SetFont("Arial", "", 10)
+		pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+		pdf.CellFormat(50, 7, row[0], "1", 0, "L", fill, 0, "")
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.CellFormat(pageWidth-50, 7, tr(row[1]), "1", 1, "L", fill, 0, "")
+	}
+	pdf.Ln(6)
+
+	// Fees due
+	if len(data.Fees) > 0 {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.CellFormat(pageWidth, 8, "Fees Due on Acceptance", "", 1, "L", false, 0, "")
+
+		pdf.SetFillColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(pageWidth-50, 7, "Fee", "1", 0, "L", true, 0, "")
+		pdf.CellFormat(50, 7, "Amount ("+data.Currency+")", "1", 1, "R", true, 0, "")
+
+		pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pdf.SetFont("Arial", "", 10)
+		for _, fee := range data.Fees {
+			pdf.CellFormat(pageWidth-50, 7, tr(fee.Name), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(50, 7, formatAmount(fee.Amount.StringFixed(2)), "1", 1, "R", false, 0, "")
+		}
+		pdf.SetFillColor(lightBg[0], lightBg[1], lightBg[2])
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(pageWidth-50, 7, "Total", "1", 0, "L", true, 0, "")
+		pdf.CellFormat(50, 7, formatAmount(data.Fees.Total().StringFixed(2)), "1", 1, "R", true, 0, "")
+		pdf.Ln(6)
+	}
+
+	// Validity and acceptance
+	pdf.SetFont("Arial", "B", 11)
+	pdf.SetTextColor(accentColor[0], accentColor[1], accentColor[2])
+	pdf.CellFormat(pageWidth, 7, "Valid until "+data.ValidUntil.Format("02 January 2006"), "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(primaryColor[0], primaryColor[1], primaryColor[2])
+	pdf.MultiCell(pageWidth, 5,
+		"Please accept this offer online using the link shared with you, or visit the school office, "+
+			"before the date above. Admission is confirmed once the offer is accepted and the fees are paid.",
+		"", "L", false)
+	pdf.Ln(14)
+
+	// Signature
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(pageWidth, 5, "Admissions Office", "", 1, "R", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(mutedText[0], mutedText[1], mutedText[2])
+	pdf.CellFormat(pageWidth, 5, tr(data.SchoolName), "", 1, "R", false, 0, "")
+
+	// Footer
+	pdf.SetY(-25)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.CellFormat(pageWidth, 4, "This is a computer-generated letter and does not require a signature.", "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("generate offer letter pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatAmount adds thousands separators to a fixed-point amount string.
+func formatAmount(amount string) string {
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+	whole, fraction, _ := strings.Cut(amount, ".")
+
+	var grouped []string
+	for len(whole) > 3 {
+		grouped = append([]string{whole[len(whole)-3:]}, grouped...)
+		whole = whole[:len(whole)-3]
+	}
+	grouped = append([]string{whole}, grouped...)
+
+	result := sign + strings.Join(grouped, ",")
+	if fraction != "" {
+		result += "." + fraction
+	}
+	return result
+}
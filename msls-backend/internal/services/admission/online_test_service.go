@@ -0,0 +1,736 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// OnlineTestSweepInterval is how often expired online test attempts are auto-submitted.
+const OnlineTestSweepInterval = time.Minute
+
+// MaxDescriptiveAnswerLength caps the length of a descriptive answer.
+const MaxDescriptiveAnswerLength = 20000
+
+// onlineResultRemarks is recorded on registrations whose result came from an online test.
+const onlineResultRemarks = "Scored from the online test"
+
+// OnlineTestService delivers published question papers to candidates, scores
+// their answers and manages the grading queue for descriptive answers.
+type OnlineTestService struct {
+	db    *gorm.DB
+	tests *TestService
+}
+
+// NewOnlineTestService creates a new OnlineTestService instance. Final scores are
+// recorded through the TestService so they follow the same result rules as
+// manually entered marks.
+func NewOnlineTestService(db *gorm.DB, tests *TestService) *OnlineTestService {
+	return &OnlineTestService{db: db, tests: tests}
+}
+
+// TestLoginRequest represents a candidate's login to an online test.
+type TestLoginRequest struct {
+	TenantID    uuid.UUID
+	RollNumber  string
+	DateOfBirth time.Time
+}
+
+// TestLogin is the outcome of a successful candidate login.
+type TestLogin struct {
+	Token   string
+	Attempt *TestAttempt
+	Test    *EntranceTest
+}
+
+// CandidatePaper is the question paper as served to a candidate, together with
+// the answers saved so far.
+type CandidatePaper struct {
+	Attempt  *TestAttempt
+	Test     EntranceTest
+	Sections []TestSection
+	Answers  []TestAnswer
+}
+
+// AnswerRequest represents a candidate's answer to a question. Empty values
+// clear a previously saved answer.
+type AnswerRequest struct {
+	QuestionID      uuid.UUID
+	SelectedOptions []string
+	NumericAnswer   *decimal.Decimal
+	TextAnswer      string
+}
+
+// GradeAnswerRequest represents a grader's marks for a descriptive answer.
+type GradeAnswerRequest struct {
+	TenantID uuid.UUID
+	TestID   uuid.UUID
+	AnswerID uuid.UUID
+	Marks    decimal.Decimal
+	Feedback string
+	GradedBy *uuid.UUID
+}
+
+// GradingQueueItem is a descriptive answer waiting for a grader. Candidates are
+// identified by roll number only so grading stays blind.
+type GradingQueueItem struct {
+	Answer     TestAnswer
+	RollNumber string
+	MaxMarks   decimal.Decimal
+}
+
+// Login authenticates a candidate by hall ticket roll number and date of birth
+// and starts, or resumes, their attempt at the online test that is open now.
+// Logging in again issues a new token and invalidates the previous one.
+func (s *OnlineTestService) Login(ctx context.Context, req TestLoginRequest) (*TestLogin, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+	rollNumber := strings.ToUpper(strings.TrimSpace(req.RollNumber))
+	if rollNumber == "" || req.DateOfBirth.IsZero() {
+		return nil, ErrTestLoginFailed
+	}
+
+	// Roll numbers are unique per test, so a candidate can hold the same roll
+	// number on several tests. The date of birth and the open test pick one.
+	var registrations []TestRegistration
+	err := s.db.WithContext(ctx).
+		Preload("Test").
+		Preload("Application").
+		Where("tenant_id = ? AND roll_number = ? AND status <> ?", req.TenantID, rollNumber, TestRegStatusCancelled).
+		Find(&registrations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find registration: %w", err)
+	}
+
+	now := time.Now()
+	var windowErr error
+	for i := range registrations {
+		reg := &registrations[i]
+		if reg.Test == nil || reg.Application == nil || !reg.Test.OnlineEnabled {
+			continue
+		}
+		if reg.Test.Status == TestStatusCancelled || reg.Test.Status == TestStatusCompleted {
+			continue
+		}
+		if reg.Application.DateOfBirth.Format("2006-01-02") != req.DateOfBirth.Format("2006-01-02") {
+			continue
+		}
+
+		login, err := s.startAttempt(ctx, reg, now)
+		if errors.Is(err, ErrTestNotStarted) || errors.Is(err, ErrTestEntryClosed) {
+			windowErr = err
+			continue
+		}
+		return login, err
+	}
+
+	if windowErr != nil {
+		return nil, windowErr
+	}
+	return nil, ErrTestLoginFailed
+}
+
+// startAttempt creates the candidate's attempt when the entry window is open, or
+// resumes the existing one with a fresh token.
+func (s *OnlineTestService) startAttempt(ctx context.Context, reg *TestRegistration, now time.Time) (*TestLogin, error) {
+	token, err := newPublicToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate test token: %w", err)
+	}
+
+	var attempt TestAttempt
+	err = s.db.WithContext(ctx).
+		Where("tenant_id = ? AND registration_id = ?", reg.TenantID, reg.ID).
+		First(&attempt).Error
+	if err == nil {
+		if attempt.Status != TestAttemptInProgress {
+			return nil, ErrAttemptSubmitted
+		}
+		if now.After(attempt.Deadline) {
+			if err := s.submitAttempt(ctx, &attempt, true); err != nil && !errors.Is(err, ErrAttemptSubmitted) {
+				return nil, err
+			}
+			return nil, ErrTestTimeUp
+		}
+		attempt.TokenHash = hashPublicToken(token)
+		if err := s.db.WithContext(ctx).Model(&TestAttempt{}).
+			Where("id = ?", attempt.ID).
+			Update("token_hash", attempt.TokenHash).Error; err != nil {
+			return nil, fmt.Errorf("failed to resume attempt: %w", err)
+		}
+		return &TestLogin{Token: token, Attempt: &attempt, Test: reg.Test}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	startsAt, err := reg.Test.StartsAt()
+	if err != nil {
+		return nil, err
+	}
+	if now.Before(startsAt) {
+		return nil, ErrTestNotStarted
+	}
+	if now.After(startsAt.Add(time.Duration(reg.Test.LateEntryMinutes) * time.Minute)) {
+		return nil, ErrTestEntryClosed
+	}
+
+	attempt = TestAttempt{
+		TenantID:       reg.TenantID,
+		TestID:         reg.TestID,
+		RegistrationID: reg.ID,
+		Status:         TestAttemptInProgress,
+		TokenHash:      hashPublicToken(token),
+		StartedAt:      now,
+		Deadline:       now.Add(time.Duration(reg.Test.DurationMinutes) * time.Minute),
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Registration", "Answers").Create(&attempt).Error; err != nil {
+			return fmt.Errorf("failed to start attempt: %w", err)
+		}
+		if err := tx.Model(&TestRegistration{}).
+			Where("id = ?", reg.ID).
+			Update("status", TestRegStatusAppeared).Error; err != nil {
+			return fmt.Errorf("failed to mark candidate as appeared: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TestLogin{Token: token, Attempt: &attempt, Test: reg.Test}, nil
+}
+
+// Authenticate resolves an online test token to the candidate's attempt. An
+// attempt past its deadline is submitted on the spot and ErrTestTimeUp returned.
+func (s *OnlineTestService) Authenticate(ctx context.Context, tenantID uuid.UUID, token string) (*TestAttempt, error) {
+	if token == "" {
+		return nil, ErrTestSessionInvalid
+	}
+
+	var attempt TestAttempt
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND token_hash = ?", tenantID, hashPublicToken(token)).
+		First(&attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTestSessionInvalid
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	if attempt.Status != TestAttemptInProgress {
+		return nil, ErrAttemptSubmitted
+	}
+	if time.Now().After(attempt.Deadline) {
+		if err := s.submitAttempt(ctx, &attempt, true); err != nil && !errors.Is(err, ErrAttemptSubmitted) {
+			return nil, err
+		}
+		return nil, ErrTestTimeUp
+	}
+	return &attempt, nil
+}
+
+// GetPaper returns the question paper and saved answers for an attempt.
+func (s *OnlineTestService) GetPaper(ctx context.Context, attempt *TestAttempt) (*CandidatePaper, error) {
+	paper, err := loadQuestionPaper(s.db.WithContext(ctx), attempt.TenantID, attempt.TestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var answers []TestAnswer
+	if err := s.db.WithContext(ctx).
+		Where("attempt_id = ?", attempt.ID).
+		Find(&answers).Error; err != nil {
+		return nil, fmt.Errorf("failed to load answers: %w", err)
+	}
+
+	return &CandidatePaper{
+		Attempt:  attempt,
+		Test:     paper.Test,
+		Sections: paper.Sections,
+		Answers:  answers,
+	}, nil
+}
+
+// SaveAnswer records or replaces the candidate's answer to a question on the paper.
+func (s *OnlineTestService) SaveAnswer(ctx context.Context, attempt *TestAttempt, req AnswerRequest) (*TestAnswer, error) {
+	if attempt.Status != TestAttemptInProgress {
+		return nil, ErrAttemptSubmitted
+	}
+	if time.Now().After(attempt.Deadline) {
+		return nil, ErrTestTimeUp
+	}
+
+	var placement TestSectionQuestion
+	err := s.db.WithContext(ctx).
+		Preload("Question").
+		Joins("JOIN test_sections ON test_sections.id = test_section_questions.section_id").
+		Where("test_sections.tenant_id = ? AND test_sections.test_id = ? AND test_section_questions.question_id = ?", attempt.TenantID, attempt.TestID, req.QuestionID).
+		First(&placement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuestionNotOnPaper
+		}
+		return nil, fmt.Errorf("failed to find question on paper: %w", err)
+	}
+	if placement.Question == nil {
+		return nil, ErrQuestionNotOnPaper
+	}
+
+	answer := TestAnswer{
+		TenantID:        attempt.TenantID,
+		AttemptID:       attempt.ID,
+		SectionID:       placement.SectionID,
+		QuestionID:      req.QuestionID,
+		SelectedOptions: StringArray{},
+	}
+	err = s.db.WithContext(ctx).
+		Where("attempt_id = ? AND question_id = ?", attempt.ID, req.QuestionID).
+		First(&answer).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	if err := applyAnswer(&answer, placement.Question, req); err != nil {
+		return nil, err
+	}
+	answer.AnsweredAt = time.Now()
+
+	if err := s.db.WithContext(ctx).Omit("Question", "Section").Save(&answer).Error; err != nil {
+		return nil, fmt.Errorf("failed to save answer: %w", err)
+	}
+	return &answer, nil
+}
+
+// Submit ends the candidate's attempt and scores it.
+func (s *OnlineTestService) Submit(ctx context.Context, attempt *TestAttempt) (*TestAttempt, error) {
+	if err := s.submitAttempt(ctx, attempt, false); err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// SubmitExpiredAttempts submits every in-progress attempt past its deadline
+// across all tenants. It returns the number of attempts submitted.
+func (s *OnlineTestService) SubmitExpiredAttempts(ctx context.Context, now time.Time) (int, error) {
+	var attempts []TestAttempt
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the sweep runs outside any tenant request
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Where("status = ? AND deadline < ?", TestAttemptInProgress, now).
+			Order("deadline ASC").
+			Find(&attempts).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired attempts: %w", err)
+	}
+
+	submitted := 0
+	var errs []error
+	for i := range attempts {
+		err := s.submitAttempt(ctx, &attempts[i], true)
+		if errors.Is(err, ErrAttemptSubmitted) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("attempt %s: %w", attempts[i].ID, err))
+			continue
+		}
+		submitted++
+	}
+	return submitted, errors.Join(errs...)
+}
+
+// ListAttempts retrieves the attempts at a test with their registrations.
+func (s *OnlineTestService) ListAttempts(ctx context.Context, tenantID, testID uuid.UUID) ([]TestAttempt, error) {
+	var attempts []TestAttempt
+	err := s.db.WithContext(ctx).
+		Preload("Registration").
+		Where("tenant_id = ? AND test_id = ?", tenantID, testID).
+		Order("started_at ASC").
+		Find(&attempts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// GradingQueue lists the descriptive answers of submitted attempts that still
+// need marks, oldest first.
+func (s *OnlineTestService) GradingQueue(ctx context.Context, tenantID, testID uuid.UUID) ([]GradingQueueItem, error) {
+	var answers []TestAnswer
+	err := s.db.WithContext(ctx).
+		Preload("Question").
+		Preload("Section").
+		Joins("JOIN test_attempts ON test_attempts.id = test_answers.attempt_id").
+		Where("test_answers.tenant_id = ? AND test_attempts.test_id = ? AND test_attempts.status = ? AND test_answers.marks_awarded IS NULL",
+			tenantID, testID, TestAttemptSubmitted).
+		Order("test_answers.created_at ASC").
+		Find(&answers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grading queue: %w", err)
+	}
+
+	var rows []struct {
+		ID         uuid.UUID
+		RollNumber string
+	}
+	err = s.db.WithContext(ctx).
+		Table("test_attempts").
+		Select("test_attempts.id, test_registrations.roll_number").
+		Joins("JOIN test_registrations ON test_registrations.id = test_attempts.registration_id").
+		Where("test_attempts.tenant_id = ? AND test_attempts.test_id = ?", tenantID, testID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roll numbers: %w", err)
+	}
+	rollNumbers := make(map[uuid.UUID]string, len(rows))
+	for _, row := range rows {
+		rollNumbers[row.ID] = row.RollNumber
+	}
+
+	queue := make([]GradingQueueItem, 0, len(answers))
+	for _, answer := range answers {
+		if answer.Question == nil || answer.Section == nil {
+			continue
+		}
+		maxMarks, _ := answer.Section.QuestionMarks(answer.Question)
+		queue = append(queue, GradingQueueItem{
+			Answer:     answer,
+			RollNumber: rollNumbers[answer.AttemptID],
+			MaxMarks:   maxMarks,
+		})
+	}
+	return queue, nil
+}
+
+// GradeAnswer awards marks to a descriptive answer. Once the last pending answer
+// of an attempt is graded, the attempt's result is recorded.
+func (s *OnlineTestService) GradeAnswer(ctx context.Context, req GradeAnswerRequest) (*TestAnswer, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	var answer TestAnswer
+	var attempt TestAttempt
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Preload("Question").Preload("Section").
+			Where("tenant_id = ? AND id = ?", req.TenantID, req.AnswerID).
+			First(&answer).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAnswerNotFound
+			}
+			return fmt.Errorf("failed to get answer: %w", err)
+		}
+		if answer.Question == nil || answer.Section == nil || answer.Section.TestID != req.TestID {
+			return ErrAnswerNotFound
+		}
+
+		if err := tx.Where("id = ?", answer.AttemptID).First(&attempt).Error; err != nil {
+			return fmt.Errorf("failed to get attempt: %w", err)
+		}
+		if answer.Question.QuestionType.IsObjective() || attempt.Status != TestAttemptSubmitted {
+			return ErrAnswerNotGradable
+		}
+
+		maxMarks, _ := answer.Section.QuestionMarks(answer.Question)
+		if req.Marks.IsNegative() || req.Marks.GreaterThan(maxMarks) {
+			return ErrInvalidGradeMarks
+		}
+
+		previous := decimal.Zero
+		wasPending := answer.MarksAwarded == nil
+		if !wasPending {
+			previous = *answer.MarksAwarded
+		}
+
+		now := time.Now()
+		marks := req.Marks
+		answer.MarksAwarded = &marks
+		answer.Feedback = req.Feedback
+		answer.GradedBy = req.GradedBy
+		answer.GradedAt = &now
+		if err := tx.Model(&TestAnswer{}).
+			Where("id = ?", answer.ID).
+			Updates(map[string]interface{}{
+				"marks_awarded": answer.MarksAwarded,
+				"feedback":      answer.Feedback,
+				"graded_by":     answer.GradedBy,
+				"graded_at":     answer.GradedAt,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to grade answer: %w", err)
+		}
+
+		attempt.DescriptiveScore = attempt.DescriptiveScore.Add(marks).Sub(previous)
+		if wasPending {
+			attempt.PendingGrading--
+		}
+		if err := tx.Model(&TestAttempt{}).
+			Where("id = ?", attempt.ID).
+			Updates(map[string]interface{}{
+				"descriptive_score": attempt.DescriptiveScore,
+				"pending_grading":   attempt.PendingGrading,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to update attempt: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt.PendingGrading <= 0 {
+		if err := s.publishResult(ctx, &attempt); err != nil {
+			return nil, err
+		}
+	}
+	return &answer, nil
+}
+
+// submitAttempt closes an in-progress attempt and scores its objective answers.
+// When nothing is left for graders, the result is recorded straight away.
+func (s *OnlineTestService) submitAttempt(ctx context.Context, attempt *TestAttempt, auto bool) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&TestAttempt{}).
+			Where("id = ? AND status = ?", attempt.ID, TestAttemptInProgress).
+			Updates(map[string]interface{}{
+				"status":         TestAttemptSubmitted,
+				"submitted_at":   now,
+				"auto_submitted": auto,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to submit attempt: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrAttemptSubmitted
+		}
+
+		paper, err := loadQuestionPaper(tx, attempt.TenantID, attempt.TestID)
+		if err != nil {
+			return err
+		}
+
+		var answers []TestAnswer
+		if err := tx.Where("attempt_id = ?", attempt.ID).Find(&answers).Error; err != nil {
+			return fmt.Errorf("failed to load answers: %w", err)
+		}
+		byQuestion := make(map[uuid.UUID]*TestAnswer, len(answers))
+		for i := range answers {
+			byQuestion[answers[i].QuestionID] = &answers[i]
+		}
+
+		objective := decimal.Zero
+		pending := 0
+		for i := range paper.Sections {
+			section := &paper.Sections[i]
+			for _, sq := range section.Questions {
+				answer, ok := byQuestion[sq.QuestionID]
+				if !ok || sq.Question == nil {
+					continue
+				}
+				if scoreAnswer(answer, section, sq.Question) {
+					pending++
+					continue
+				}
+				if sq.Question.QuestionType.IsObjective() {
+					objective = objective.Add(*answer.MarksAwarded)
+				}
+				if err := tx.Model(&TestAnswer{}).
+					Where("id = ?", answer.ID).
+					Updates(map[string]interface{}{
+						"is_correct":    answer.IsCorrect,
+						"marks_awarded": answer.MarksAwarded,
+					}).Error; err != nil {
+					return fmt.Errorf("failed to score answer: %w", err)
+				}
+			}
+		}
+
+		attempt.Status = TestAttemptSubmitted
+		attempt.SubmittedAt = &now
+		attempt.AutoSubmitted = auto
+		attempt.ObjectiveScore = objective
+		attempt.PendingGrading = pending
+		return tx.Model(&TestAttempt{}).
+			Where("id = ?", attempt.ID).
+			Updates(map[string]interface{}{
+				"objective_score": objective,
+				"pending_grading": pending,
+			}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if attempt.PendingGrading == 0 {
+		return s.publishResult(ctx, attempt)
+	}
+	return nil
+}
+
+// publishResult records the attempt's subject-wise marks as the candidate's test
+// result. Each subject's total is floored at zero so negative marking cannot
+// push it below nothing. A result entered by hand in the meantime is kept.
+func (s *OnlineTestService) publishResult(ctx context.Context, attempt *TestAttempt) error {
+	var test EntranceTest
+	if err := s.db.WithContext(ctx).Where("id = ?", attempt.TestID).First(&test).Error; err != nil {
+		return fmt.Errorf("failed to get test: %w", err)
+	}
+
+	var answers []TestAnswer
+	if err := s.db.WithContext(ctx).
+		Preload("Section").
+		Where("attempt_id = ?", attempt.ID).
+		Find(&answers).Error; err != nil {
+		return fmt.Errorf("failed to load answers: %w", err)
+	}
+
+	marks := make(map[string]decimal.Decimal, len(test.Subjects))
+	for _, subject := range test.Subjects {
+		marks[subject.Subject] = decimal.Zero
+	}
+	for _, answer := range answers {
+		if answer.Section == nil || answer.MarksAwarded == nil {
+			continue
+		}
+		marks[answer.Section.Subject] = marks[answer.Section.Subject].Add(*answer.MarksAwarded)
+	}
+	for subject, total := range marks {
+		if total.IsNegative() {
+			marks[subject] = decimal.Zero
+		}
+	}
+
+	_, err := s.tests.SubmitResults(ctx, SubmitResultsRequest{
+		TenantID:       attempt.TenantID,
+		TestID:         attempt.TestID,
+		RegistrationID: attempt.RegistrationID,
+		Marks:          marks,
+		Remarks:        onlineResultRemarks,
+	})
+	if err != nil && !errors.Is(err, ErrResultsAlreadySubmitted) {
+		return fmt.Errorf("failed to record result: %w", err)
+	}
+
+	now := time.Now()
+	attempt.Status = TestAttemptGraded
+	attempt.GradedAt = &now
+	if err := s.db.WithContext(ctx).Model(&TestAttempt{}).
+		Where("id = ?", attempt.ID).
+		Updates(map[string]interface{}{
+			"status":    TestAttemptGraded,
+			"graded_at": now,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update attempt: %w", err)
+	}
+	return nil
+}
+
+// scoreAnswer scores an objective answer in place, or clears the marks of a
+// descriptive answer for grading. It reports whether the answer needs a grader.
+// Unanswered questions score zero; wrong objective answers lose the negative marks.
+func scoreAnswer(answer *TestAnswer, section *TestSection, question *TestQuestion) bool {
+	marks, negative := section.QuestionMarks(question)
+	zero := decimal.Zero
+	answer.IsCorrect = nil
+	answer.MarksAwarded = &zero
+
+	var correct bool
+	switch question.QuestionType {
+	case QuestionTypeMCQ:
+		if len(answer.SelectedOptions) == 0 {
+			return false
+		}
+		correct = sameOptions(answer.SelectedOptions, question.CorrectOptions)
+	case QuestionTypeNumeric:
+		if answer.NumericAnswer == nil || question.NumericAnswer == nil {
+			return false
+		}
+		correct = answer.NumericAnswer.Sub(*question.NumericAnswer).Abs().LessThanOrEqual(question.NumericTolerance)
+	default:
+		if strings.TrimSpace(answer.TextAnswer) == "" {
+			return false
+		}
+		answer.MarksAwarded = nil
+		return true
+	}
+
+	awarded := negative.Neg()
+	if correct {
+		awarded = marks
+	}
+	answer.IsCorrect = &correct
+	answer.MarksAwarded = &awarded
+	return false
+}
+
+// applyAnswer validates an answer against the question type and copies it onto the answer.
+func applyAnswer(answer *TestAnswer, question *TestQuestion, req AnswerRequest) error {
+	answer.SelectedOptions = StringArray{}
+	answer.NumericAnswer = nil
+	answer.TextAnswer = ""
+
+	switch question.QuestionType {
+	case QuestionTypeMCQ:
+		if req.NumericAnswer != nil || req.TextAnswer != "" {
+			return fmt.Errorf("%w: select options for a multiple-choice question", ErrInvalidAnswer)
+		}
+		keys := make(map[string]bool, len(question.Options))
+		for _, option := range question.Options {
+			keys[option.Key] = true
+		}
+		seen := make(map[string]bool, len(req.SelectedOptions))
+		for _, key := range req.SelectedOptions {
+			if !keys[key] {
+				return fmt.Errorf("%w: %q is not an option", ErrInvalidAnswer, key)
+			}
+			if !seen[key] {
+				seen[key] = true
+				answer.SelectedOptions = append(answer.SelectedOptions, key)
+			}
+		}
+	case QuestionTypeNumeric:
+		if len(req.SelectedOptions) > 0 || req.TextAnswer != "" {
+			return fmt.Errorf("%w: enter a number for a numeric question", ErrInvalidAnswer)
+		}
+		answer.NumericAnswer = req.NumericAnswer
+	case QuestionTypeDescriptive:
+		if len(req.SelectedOptions) > 0 || req.NumericAnswer != nil {
+			return fmt.Errorf("%w: write the answer for a descriptive question", ErrInvalidAnswer)
+		}
+		if len(req.TextAnswer) > MaxDescriptiveAnswerLength {
+			return fmt.Errorf("%w: answer exceeds %d characters", ErrInvalidAnswer, MaxDescriptiveAnswerLength)
+		}
+		answer.TextAnswer = req.TextAnswer
+	}
+	return nil
+}
+
+// sameOptions reports whether two option key sets are equal, ignoring order.
+func sameOptions(selected, correct []string) bool {
+	if len(selected) != len(correct) {
+		return false
+	}
+	a := append([]string(nil), selected...)
+	b := append([]string(nil), correct...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,423 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var candidateDOB = time.Date(2015, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+type onlineTestFixture struct {
+	db       *gorm.DB
+	bank     *QuestionBankService
+	online   *OnlineTestService
+	tenantID uuid.UUID
+	test     *EntranceTest
+
+	// Mathematics: mcq (4, -1), numeric (2), multi-answer mcq (4, -1) = 10 marks
+	// English: descriptive (5) = 5 marks
+	mcq, numeric, multi, essay uuid.UUID
+}
+
+// setupOnlineTest creates a test with Mathematics (10) and English (5) that
+// started startedAgo ago, with a complete but unpublished question paper.
+func setupOnlineTest(t *testing.T, startedAgo time.Duration) *onlineTestFixture {
+	t.Helper()
+
+	db := newAdmissionTestDB(t,
+		&AdmissionApplication{}, &EntranceTest{}, &TestRegistration{},
+		&TestQuestion{}, &TestSection{}, &TestSectionQuestion{}, &TestAttempt{}, &TestAnswer{},
+	)
+
+	tenantID := uuid.New()
+	start := time.Now().Add(-startedAgo)
+	test := &EntranceTest{
+		ID:              uuid.New(),
+		TenantID:        tenantID,
+		SessionID:       uuid.New(),
+		TestName:        "Class 6 Entrance Test",
+		TestDate:        time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local),
+		StartTime:       start.Format("15:04:05"),
+		DurationMinutes: 60,
+		ClassNames:      StringArray{"Class 6"},
+		Status:          TestStatusScheduled,
+		Subjects: TestSubjects{
+			{Subject: "Mathematics", MaxMarks: decimal.NewFromInt(10)},
+			{Subject: "English", MaxMarks: decimal.NewFromInt(5)},
+		},
+		PassingPercentage: decimal.NewFromInt(33),
+		LateEntryMinutes:  15,
+	}
+	require.NoError(t, db.Create(test).Error)
+
+	f := &onlineTestFixture{
+		db:       db,
+		bank:     NewQuestionBankService(db),
+		online:   NewOnlineTestService(db, NewTestService(db)),
+		tenantID: tenantID,
+		test:     test,
+	}
+
+	ctx := context.Background()
+	options := []QuestionOption{{Key: "A", Text: "2"}, {Key: "B", Text: "4"}, {Key: "C", Text: "6"}, {Key: "D", Text: "8"}}
+	f.mcq = f.createQuestion(t, QuestionRequest{
+		Subject: "Mathematics", QuestionType: QuestionTypeMCQ, QuestionText: "What is 2 + 2?",
+		Options: options, CorrectOptions: []string{"B"},
+		Marks: decimal.NewFromInt(4), NegativeMarks: decimal.NewFromInt(1),
+	})
+	pi := decimal.RequireFromString("3.14")
+	f.numeric = f.createQuestion(t, QuestionRequest{
+		Subject: "Mathematics", QuestionType: QuestionTypeNumeric, QuestionText: "Value of pi to two decimals?",
+		NumericAnswer: &pi, NumericTolerance: decimal.RequireFromString("0.01"),
+		Marks: decimal.NewFromInt(2),
+	})
+	f.multi = f.createQuestion(t, QuestionRequest{
+		Subject: "Mathematics", QuestionType: QuestionTypeMCQ, QuestionText: "Which are less than 5?",
+		Options: options, CorrectOptions: []string{"A", "B"},
+		Marks: decimal.NewFromInt(4), NegativeMarks: decimal.NewFromInt(1),
+	})
+	f.essay = f.createQuestion(t, QuestionRequest{
+		Subject: "English", QuestionType: QuestionTypeDescriptive, QuestionText: "Describe your favourite book.",
+		Marks: decimal.NewFromInt(5),
+	})
+
+	maths, err := f.bank.CreateSection(ctx, SectionRequest{TenantID: tenantID, TestID: test.ID, Name: "Section A", Subject: "Mathematics", Sequence: 1})
+	require.NoError(t, err)
+	english, err := f.bank.CreateSection(ctx, SectionRequest{TenantID: tenantID, TestID: test.ID, Name: "Section B", Subject: "English", Sequence: 2})
+	require.NoError(t, err)
+	for i, id := range []uuid.UUID{f.mcq, f.numeric, f.multi} {
+		_, err := f.bank.AddSectionQuestion(ctx, tenantID, test.ID, maths.ID, id, i+1)
+		require.NoError(t, err)
+	}
+	_, err = f.bank.AddSectionQuestion(ctx, tenantID, test.ID, english.ID, f.essay, 1)
+	require.NoError(t, err)
+
+	return f
+}
+
+func (f *onlineTestFixture) createQuestion(t *testing.T, req QuestionRequest) uuid.UUID {
+	t.Helper()
+	req.TenantID = f.tenantID
+	question, err := f.bank.CreateQuestion(context.Background(), req)
+	require.NoError(t, err)
+	return question.ID
+}
+
+func (f *onlineTestFixture) publish(t *testing.T) {
+	t.Helper()
+	_, err := f.bank.PublishPaper(context.Background(), f.tenantID, f.test.ID, nil)
+	require.NoError(t, err)
+}
+
+// registerCandidate creates an application and a registration with the given roll number.
+func (f *onlineTestFixture) registerCandidate(t *testing.T, rollNumber string) *TestRegistration {
+	t.Helper()
+
+	application := &AdmissionApplication{
+		ID:                uuid.New(),
+		TenantID:          f.tenantID,
+		SessionID:         f.test.SessionID,
+		ApplicationNumber: "APP-" + rollNumber,
+		StudentName:       "Candidate " + rollNumber,
+		DateOfBirth:       candidateDOB,
+		Gender:            "female",
+		ClassApplying:     "Class 6",
+		ExtraData:         ExtraData{},
+	}
+	require.NoError(t, f.db.Create(application).Error)
+
+	registration := &TestRegistration{
+		ID:            uuid.New(),
+		TenantID:      f.tenantID,
+		TestID:        f.test.ID,
+		ApplicationID: application.ID,
+		RollNumber:    rollNumber,
+		Status:        TestRegStatusHallTicketGenerated,
+		Marks:         MarksMap{},
+	}
+	require.NoError(t, f.db.Create(registration).Error)
+	return registration
+}
+
+func (f *onlineTestFixture) login(t *testing.T, rollNumber string) (*TestLogin, *TestAttempt) {
+	t.Helper()
+	ctx := context.Background()
+
+	login, err := f.online.Login(ctx, TestLoginRequest{TenantID: f.tenantID, RollNumber: rollNumber, DateOfBirth: candidateDOB})
+	require.NoError(t, err)
+	attempt, err := f.online.Authenticate(ctx, f.tenantID, login.Token)
+	require.NoError(t, err)
+	return login, attempt
+}
+
+func TestQuestionBankService_ValidatesQuestions(t *testing.T) {
+	f := setupOnlineTest(t, 0)
+	ctx := context.Background()
+
+	_, err := f.bank.CreateQuestion(ctx, QuestionRequest{
+		TenantID: f.tenantID, Subject: "Mathematics", QuestionType: QuestionTypeMCQ, QuestionText: "Pick one",
+		Options:        []QuestionOption{{Key: "A", Text: "1"}, {Key: "B", Text: "2"}},
+		CorrectOptions: []string{"C"},
+		Marks:          decimal.NewFromInt(1),
+	})
+	assert.ErrorIs(t, err, ErrInvalidQuestion)
+
+	_, err = f.bank.CreateQuestion(ctx, QuestionRequest{
+		TenantID: f.tenantID, Subject: "Mathematics", QuestionType: QuestionTypeNumeric, QuestionText: "How many?",
+		Marks: decimal.NewFromInt(1),
+	})
+	assert.ErrorIs(t, err, ErrInvalidQuestion)
+
+	_, err = f.bank.CreateQuestion(ctx, QuestionRequest{
+		TenantID: f.tenantID, Subject: "Mathematics", QuestionType: QuestionTypeMCQ, QuestionText: "Pick one",
+		Options:        []QuestionOption{{Key: "A", Text: "1"}, {Key: "B", Text: "2"}},
+		CorrectOptions: []string{"A"},
+		Marks:          decimal.NewFromInt(1), NegativeMarks: decimal.NewFromInt(2),
+	})
+	assert.ErrorIs(t, err, ErrInvalidQuestion)
+
+	essay, err := f.bank.CreateQuestion(ctx, QuestionRequest{
+		TenantID: f.tenantID, Subject: "English", QuestionType: QuestionTypeDescriptive, QuestionText: "Write a letter.",
+		Marks: decimal.NewFromInt(5), NegativeMarks: decimal.NewFromInt(1),
+	})
+	require.NoError(t, err)
+	assert.True(t, essay.NegativeMarks.IsZero(), "descriptive questions carry no negative marks")
+}
+
+func TestQuestionBankService_PublishPaper(t *testing.T) {
+	f := setupOnlineTest(t, 0)
+	ctx := context.Background()
+
+	_, err := f.bank.CreateSection(ctx, SectionRequest{TenantID: f.tenantID, TestID: f.test.ID, Name: "Science", Subject: "Science"})
+	assert.ErrorIs(t, err, ErrSubjectNotInTest)
+
+	paper, err := f.bank.GetPaper(ctx, f.tenantID, f.test.ID)
+	require.NoError(t, err)
+	require.Len(t, paper.Sections, 2)
+	assert.True(t, paper.SubjectTotals()["Mathematics"].Equal(decimal.NewFromInt(10)))
+
+	// A section-wide marking scheme overrides the questions' own marks
+	three := decimal.NewFromInt(3)
+	_, err = f.bank.UpdateSection(ctx, paper.Sections[0].ID, SectionRequest{
+		TenantID: f.tenantID, TestID: f.test.ID, Name: "Section A", Subject: "Mathematics", MarksPerQuestion: &three,
+	})
+	require.NoError(t, err)
+	_, err = f.bank.PublishPaper(ctx, f.tenantID, f.test.ID, nil)
+	assert.ErrorIs(t, err, ErrPaperIncomplete)
+	assert.Contains(t, err.Error(), "Mathematics questions carry 9.00 of 10.00 marks")
+
+	_, err = f.bank.UpdateSection(ctx, paper.Sections[0].ID, SectionRequest{
+		TenantID: f.tenantID, TestID: f.test.ID, Name: "Section A", Subject: "Mathematics",
+	})
+	require.NoError(t, err)
+
+	_, err = f.bank.AddSectionQuestion(ctx, f.tenantID, f.test.ID, paper.Sections[0].ID, f.mcq, 9)
+	assert.ErrorIs(t, err, ErrQuestionAlreadyOnPaper)
+	assert.ErrorIs(t, f.bank.DeleteQuestion(ctx, f.tenantID, f.mcq), ErrQuestionInUse)
+
+	lateEntry := 10
+	published, err := f.bank.PublishPaper(ctx, f.tenantID, f.test.ID, &lateEntry)
+	require.NoError(t, err)
+	assert.True(t, published.Test.OnlineEnabled)
+	assert.Equal(t, 10, published.Test.LateEntryMinutes)
+
+	_, err = f.bank.CreateSection(ctx, SectionRequest{TenantID: f.tenantID, TestID: f.test.ID, Name: "Extra", Subject: "English"})
+	assert.ErrorIs(t, err, ErrPaperPublished)
+	_, err = f.bank.UpdateQuestion(ctx, f.mcq, QuestionRequest{
+		TenantID: f.tenantID, Subject: "Mathematics", QuestionType: QuestionTypeNumeric, QuestionText: "Changed",
+		Marks: decimal.NewFromInt(4),
+	})
+	assert.ErrorIs(t, err, ErrPaperPublished)
+
+	// Unpublishing is allowed until a candidate starts
+	require.NoError(t, f.bank.UnpublishPaper(ctx, f.tenantID, f.test.ID))
+	f.publish(t)
+	f.registerCandidate(t, "ROLL-00001")
+	f.login(t, "ROLL-00001")
+	assert.ErrorIs(t, f.bank.UnpublishPaper(ctx, f.tenantID, f.test.ID), ErrPaperLocked)
+}
+
+func TestOnlineTestService_ScoresAndGrades(t *testing.T) {
+	f := setupOnlineTest(t, 5*time.Minute)
+	f.publish(t)
+	registration := f.registerCandidate(t, "ROLL-00001")
+	ctx := context.Background()
+
+	_, err := f.online.Login(ctx, TestLoginRequest{TenantID: f.tenantID, RollNumber: "ROLL-00001", DateOfBirth: candidateDOB.AddDate(0, 0, 1)})
+	assert.ErrorIs(t, err, ErrTestLoginFailed)
+
+	login, attempt := f.login(t, "roll-00001")
+	assert.Equal(t, attempt.StartedAt.Add(60*time.Minute).Unix(), attempt.Deadline.Unix())
+	assert.NotEmpty(t, login.Token)
+
+	paper, err := f.online.GetPaper(ctx, attempt)
+	require.NoError(t, err)
+	require.Len(t, paper.Sections, 2)
+	assert.Len(t, paper.Sections[0].Questions, 3)
+
+	_, err = f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: f.mcq, SelectedOptions: []string{"E"}})
+	assert.ErrorIs(t, err, ErrInvalidAnswer)
+	_, err = f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: uuid.New(), SelectedOptions: []string{"A"}})
+	assert.ErrorIs(t, err, ErrQuestionNotOnPaper)
+
+	// Changing an answer replaces it
+	_, err = f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: f.mcq, SelectedOptions: []string{"A"}})
+	require.NoError(t, err)
+	_, err = f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: f.mcq, SelectedOptions: []string{"B"}})
+	require.NoError(t, err)
+	close := decimal.RequireFromString("3.145")
+	_, err = f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: f.numeric, NumericAnswer: &close})
+	require.NoError(t, err)
+	_, err = f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: f.multi, SelectedOptions: []string{"A"}})
+	require.NoError(t, err)
+	_, err = f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: f.essay, TextAnswer: "A story about a dragon."})
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, f.db.Model(&TestAnswer{}).Where("attempt_id = ?", attempt.ID).Count(&count).Error)
+	assert.Equal(t, int64(4), count)
+
+	submitted, err := f.online.Submit(ctx, attempt)
+	require.NoError(t, err)
+	assert.Equal(t, TestAttemptSubmitted, submitted.Status)
+	// 4 (correct) + 2 (within tolerance) - 1 (partly right multi-answer)
+	assert.True(t, submitted.ObjectiveScore.Equal(decimal.NewFromInt(5)), submitted.ObjectiveScore.String())
+	assert.Equal(t, 1, submitted.PendingGrading)
+
+	_, err = f.online.Authenticate(ctx, f.tenantID, login.Token)
+	assert.ErrorIs(t, err, ErrAttemptSubmitted)
+
+	var reg TestRegistration
+	require.NoError(t, f.db.First(&reg, "id = ?", registration.ID).Error)
+	assert.Nil(t, reg.Result, "result waits for the descriptive answer")
+	assert.Equal(t, TestRegStatusAppeared, reg.Status)
+
+	queue, err := f.online.GradingQueue(ctx, f.tenantID, f.test.ID)
+	require.NoError(t, err)
+	require.Len(t, queue, 1)
+	assert.Equal(t, "ROLL-00001", queue[0].RollNumber)
+	assert.True(t, queue[0].MaxMarks.Equal(decimal.NewFromInt(5)))
+
+	grader := uuid.New()
+	_, err = f.online.GradeAnswer(ctx, GradeAnswerRequest{TenantID: f.tenantID, TestID: f.test.ID, AnswerID: queue[0].Answer.ID, Marks: decimal.NewFromInt(6)})
+	assert.ErrorIs(t, err, ErrInvalidGradeMarks)
+
+	graded, err := f.online.GradeAnswer(ctx, GradeAnswerRequest{
+		TenantID: f.tenantID, TestID: f.test.ID, AnswerID: queue[0].Answer.ID,
+		Marks: decimal.NewFromInt(3), Feedback: "Good imagination", GradedBy: &grader,
+	})
+	require.NoError(t, err)
+	assert.True(t, graded.MarksAwarded.Equal(decimal.NewFromInt(3)))
+
+	require.NoError(t, f.db.First(&reg, "id = ?", registration.ID).Error)
+	require.NotNil(t, reg.Result)
+	assert.True(t, reg.Marks["Mathematics"].Equal(decimal.NewFromInt(5)))
+	assert.True(t, reg.Marks["English"].Equal(decimal.NewFromInt(3)))
+	assert.True(t, reg.TotalMarks.Equal(decimal.NewFromInt(8)))
+	assert.True(t, reg.MaxMarks.Equal(decimal.NewFromInt(15)))
+
+	var stored TestAttempt
+	require.NoError(t, f.db.First(&stored, "id = ?", attempt.ID).Error)
+	assert.Equal(t, TestAttemptGraded, stored.Status)
+	assert.True(t, stored.DescriptiveScore.Equal(decimal.NewFromInt(3)))
+
+	queue, err = f.online.GradingQueue(ctx, f.tenantID, f.test.ID)
+	require.NoError(t, err)
+	assert.Empty(t, queue)
+
+	_, err = f.online.GradeAnswer(ctx, GradeAnswerRequest{TenantID: f.tenantID, TestID: f.test.ID, AnswerID: graded.ID, Marks: decimal.NewFromInt(4)})
+	assert.ErrorIs(t, err, ErrAnswerNotGradable)
+}
+
+func TestOnlineTestService_SubmitExpiredAttempts(t *testing.T) {
+	f := setupOnlineTest(t, 5*time.Minute)
+	f.publish(t)
+	registration := f.registerCandidate(t, "ROLL-00001")
+	f.registerCandidate(t, "ROLL-00002")
+	ctx := context.Background()
+
+	login, attempt := f.login(t, "ROLL-00001")
+	_, err := f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: f.mcq, SelectedOptions: []string{"A"}})
+	require.NoError(t, err)
+	// A blank descriptive answer needs no grading
+	_, err = f.online.SaveAnswer(ctx, attempt, AnswerRequest{QuestionID: f.essay, TextAnswer: "  "})
+	require.NoError(t, err)
+
+	_, other := f.login(t, "ROLL-00002")
+
+	require.NoError(t, f.db.Model(&TestAttempt{}).Where("id = ?", attempt.ID).
+		Update("deadline", time.Now().Add(-time.Minute)).Error)
+
+	submitted, err := f.online.SubmitExpiredAttempts(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, submitted)
+
+	var stored TestAttempt
+	require.NoError(t, f.db.First(&stored, "id = ?", attempt.ID).Error)
+	assert.True(t, stored.AutoSubmitted)
+	assert.Equal(t, TestAttemptGraded, stored.Status)
+	assert.True(t, stored.ObjectiveScore.Equal(decimal.NewFromInt(-1)))
+
+	// Negative marking cannot push a subject below zero
+	var reg TestRegistration
+	require.NoError(t, f.db.First(&reg, "id = ?", registration.ID).Error)
+	require.NotNil(t, reg.Result)
+	assert.Equal(t, TestResultFail, *reg.Result)
+	assert.True(t, reg.Marks["Mathematics"].IsZero())
+	assert.True(t, reg.TotalMarks.IsZero())
+
+	var running TestAttempt
+	require.NoError(t, f.db.First(&running, "id = ?", other.ID).Error)
+	assert.Equal(t, TestAttemptInProgress, running.Status)
+
+	_, err = f.online.Authenticate(ctx, f.tenantID, login.Token)
+	assert.ErrorIs(t, err, ErrAttemptSubmitted)
+}
+
+func TestOnlineTestService_EntryWindow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("before the start time", func(t *testing.T) {
+		f := setupOnlineTest(t, -time.Hour)
+		f.publish(t)
+		f.registerCandidate(t, "ROLL-00001")
+		_, err := f.online.Login(ctx, TestLoginRequest{TenantID: f.tenantID, RollNumber: "ROLL-00001", DateOfBirth: candidateDOB})
+		assert.ErrorIs(t, err, ErrTestNotStarted)
+	})
+
+	t.Run("after late entry closes", func(t *testing.T) {
+		f := setupOnlineTest(t, 30*time.Minute)
+		f.publish(t)
+		f.registerCandidate(t, "ROLL-00001")
+		_, err := f.online.Login(ctx, TestLoginRequest{TenantID: f.tenantID, RollNumber: "ROLL-00001", DateOfBirth: candidateDOB})
+		assert.ErrorIs(t, err, ErrTestEntryClosed)
+	})
+
+	t.Run("unpublished paper", func(t *testing.T) {
+		f := setupOnlineTest(t, 5*time.Minute)
+		f.registerCandidate(t, "ROLL-00001")
+		_, err := f.online.Login(ctx, TestLoginRequest{TenantID: f.tenantID, RollNumber: "ROLL-00001", DateOfBirth: candidateDOB})
+		assert.ErrorIs(t, err, ErrTestLoginFailed)
+	})
+
+	t.Run("logging in again resumes the attempt with a new token", func(t *testing.T) {
+		f := setupOnlineTest(t, 5*time.Minute)
+		f.publish(t)
+		f.registerCandidate(t, "ROLL-00001")
+		first, attempt := f.login(t, "ROLL-00001")
+		second, resumed := f.login(t, "ROLL-00001")
+
+		assert.Equal(t, attempt.ID, resumed.ID)
+		assert.Equal(t, attempt.Deadline.Unix(), resumed.Deadline.Unix())
+		assert.NotEqual(t, first.Token, second.Token)
+		_, err := f.online.Authenticate(ctx, f.tenantID, first.Token)
+		assert.ErrorIs(t, err, ErrTestSessionInvalid)
+	})
+}
@@ -0,0 +1,569 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/payments"
+	"msls-backend/internal/pkg/sms"
+	"msls-backend/internal/pkg/storage"
+	"msls-backend/internal/services/auth"
+	"msls-backend/internal/services/payment"
+)
+
+// PortalSessionTTL is how long a parent stays signed in to the portal after OTP verification.
+const PortalSessionTTL = 2 * time.Hour
+
+// MaxPortalDocumentSize is the largest document a parent may upload (5MB).
+const MaxPortalDocumentSize = 5 * 1024 * 1024
+
+// portalDocumentMimeTypes are the document formats accepted from the public portal.
+var portalDocumentMimeTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// CaptchaVerifier checks the CAPTCHA response sent with public portal requests.
+// Implementations wrap a provider such as reCAPTCHA, hCaptcha or Turnstile and
+// return an error when the response is missing or rejected.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, response, remoteIP string) error
+}
+
+// PortalService handles the public online admission application flow.
+type PortalService struct {
+	db           *gorm.DB
+	applications *ApplicationService
+	otpService   *auth.OTPService
+	smsProvider  sms.Provider
+	storage      storage.Storage
+	captcha      CaptchaVerifier
+	payments     *payment.Service
+}
+
+// PortalConfig holds the dependencies of the PortalService.
+type PortalConfig struct {
+	OTPService  *auth.OTPService
+	SMSProvider sms.Provider
+	Storage     storage.Storage
+	// Captcha is checked before sending OTPs and submitting applications.
+	// CAPTCHA checks are skipped when nil.
+	Captcha CaptchaVerifier
+	// Payments collects application fees online. Online payment is unavailable when nil.
+	Payments *payment.Service
+}
+
+// NewPortalService creates a new PortalService instance.
+func NewPortalService(db *gorm.DB, applications *ApplicationService, config PortalConfig) *PortalService {
+	return &PortalService{
+		db:           db,
+		applications: applications,
+		otpService:   config.OTPService,
+		smsProvider:  config.SMSProvider,
+		storage:      config.Storage,
+		captcha:      config.Captcha,
+		payments:     config.Payments,
+	}
+}
+
+// PortalDocumentUpload represents a document uploaded from the portal.
+type PortalDocumentUpload struct {
+	DocumentType string
+	FileName     string
+	MimeType     string
+	Data         []byte
+}
+
+// ListOpenSessions returns the sessions accepting online applications today, with their seats.
+func (s *PortalService) ListOpenSessions(ctx context.Context, tenantID uuid.UUID) ([]models.AdmissionSession, error) {
+	var sessions []models.AdmissionSession
+	err := s.db.WithContext(ctx).
+		Preload("Seats", func(db *gorm.DB) *gorm.DB { return db.Order("class_name") }).
+		Where("tenant_id = ? AND status = ?", tenantID, models.SessionStatusOpen).
+		Order("end_date").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	open := make([]models.AdmissionSession, 0, len(sessions))
+	for _, session := range sessions {
+		if acceptsOnlineApplications(&session, now) {
+			open = append(open, session)
+		}
+	}
+	return open, nil
+}
+
+// RequestOTP sends a phone verification OTP to a parent.
+// Only tenants with a session open for online applications send OTPs.
+func (s *PortalService) RequestOTP(ctx context.Context, tenantID uuid.UUID, phone, captchaResponse, remoteIP string) (*auth.RequestOTPResponse, error) {
+	if err := s.verifyCaptcha(ctx, captchaResponse, remoteIP); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.ListOpenSessions(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, ErrOnlineApplicationsClosed
+	}
+
+	return s.otpService.RequestOTP(ctx, auth.RequestOTPRequest{
+		Identifier: phone,
+		Type:       models.OTPTypePhoneVerify,
+		Channel:    models.OTPChannelSMS,
+		TenantID:   tenantID,
+	})
+}
+
+// VerifyPhone checks the OTP and starts a portal session for the phone number.
+// The returned token is only ever handed to the parent; its hash is stored.
+func (s *PortalService) VerifyPhone(ctx context.Context, tenantID uuid.UUID, phone, code string) (string, *models.AdmissionPortalSession, error) {
+	verifiedPhone, err := s.otpService.VerifyPhone(ctx, phone, code)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, err := newPublicToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate portal token: %w", err)
+	}
+
+	session := &models.AdmissionPortalSession{
+		TenantID:  tenantID,
+		Phone:     verifiedPhone,
+		TokenHash: hashPublicToken(token),
+		ExpiresAt: time.Now().Add(PortalSessionTTL),
+	}
+	if err := s.db.WithContext(ctx).Create(session).Error; err != nil {
+		return "", nil, fmt.Errorf("failed to create portal session: %w", err)
+	}
+
+	return token, session, nil
+}
+
+// Authenticate resolves a portal token to its session.
+func (s *PortalService) Authenticate(ctx context.Context, tenantID uuid.UUID, token string) (*models.AdmissionPortalSession, error) {
+	if token == "" {
+		return nil, ErrPortalSessionInvalid
+	}
+
+	var session models.AdmissionPortalSession
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND token_hash = ?", tenantID, hashPublicToken(token)).
+		First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPortalSessionInvalid
+		}
+		return nil, fmt.Errorf("failed to get portal session: %w", err)
+	}
+	if session.IsExpired() {
+		return nil, ErrPortalSessionInvalid
+	}
+
+	return &session, nil
+}
+
+// ListApplications returns the online applications started with the session's phone number.
+func (s *PortalService) ListApplications(ctx context.Context, portal *models.AdmissionPortalSession) ([]models.AdmissionApplication, error) {
+	var applications []models.AdmissionApplication
+	err := s.db.WithContext(ctx).
+		Preload("Session").
+		Where("tenant_id = ? AND verified_phone = ? AND source = ?", portal.TenantID, portal.Phone, models.ApplicationSourceOnline).
+		Order("created_at DESC").
+		Find(&applications).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	return applications, nil
+}
+
+// GetApplication returns one of the parent's online applications.
+func (s *PortalService) GetApplication(ctx context.Context, portal *models.AdmissionPortalSession, id uuid.UUID) (*models.AdmissionApplication, error) {
+	application, err := s.applications.GetByID(ctx, portal.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	// Applications of other parents are reported as not found
+	if application.Source != models.ApplicationSourceOnline || application.VerifiedPhone != portal.Phone {
+		return nil, ErrApplicationNotFound
+	}
+	return application, nil
+}
+
+// CreateFeeOrder opens a gateway order for the application fee of one of the parent's applications.
+func (s *PortalService) CreateFeeOrder(ctx context.Context, portal *models.AdmissionPortalSession, id uuid.UUID) (*payment.Checkout, error) {
+	if s.payments == nil {
+		return nil, ErrOnlinePaymentUnavailable
+	}
+	if _, err := s.GetApplication(ctx, portal, id); err != nil {
+		return nil, err
+	}
+	return s.payments.CreateApplicationFeeOrder(ctx, portal.TenantID, id)
+}
+
+// ConfirmFeePayment records the checkout result for one of the parent's applications.
+func (s *PortalService) ConfirmFeePayment(ctx context.Context, portal *models.AdmissionPortalSession, id uuid.UUID, confirmation payments.CheckoutConfirmation) (*models.Payment, error) {
+	if s.payments == nil {
+		return nil, ErrOnlinePaymentUnavailable
+	}
+	if _, err := s.GetApplication(ctx, portal, id); err != nil {
+		return nil, err
+	}
+	return s.payments.ConfirmApplicationFeeCheckout(ctx, portal.TenantID, id, confirmation)
+}
+
+// StartApplication creates a draft online application.
+// Tenant, branch, source and verified phone are taken from the session, not the request.
+func (s *PortalService) StartApplication(ctx context.Context, portal *models.AdmissionPortalSession, req CreateApplicationRequest) (*models.AdmissionApplication, error) {
+	session, err := s.onlineSession(ctx, portal.TenantID, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkClassOffered(session, req.ClassApplying); err != nil {
+		return nil, err
+	}
+	// Fail early rather than after the parent has filled in the whole form
+	if err := checkDailyLimit(s.db.WithContext(ctx), session); err != nil {
+		return nil, err
+	}
+
+	req.TenantID = portal.TenantID
+	req.BranchID = session.BranchID
+	req.EnquiryID = nil
+	req.Source = models.ApplicationSourceOnline
+	req.VerifiedPhone = portal.Phone
+	req.CreatedBy = nil
+	return s.applications.Create(ctx, req)
+}
+
+// UpdateApplication updates a draft online application.
+func (s *PortalService) UpdateApplication(ctx context.Context, portal *models.AdmissionPortalSession, id uuid.UUID, req UpdateApplicationRequest) (*models.AdmissionApplication, error) {
+	application, err := s.draftApplication(ctx, portal, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.ClassApplying != nil {
+		session, err := s.onlineSession(ctx, portal.TenantID, application.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkClassOffered(session, *req.ClassApplying); err != nil {
+			return nil, err
+		}
+	}
+
+	req.UpdatedBy = nil
+	return s.applications.Update(ctx, portal.TenantID, id, req)
+}
+
+// UploadDocument stores a document for a draft online application.
+// The document type must be a known type or one of the session's required documents.
+func (s *PortalService) UploadDocument(ctx context.Context, portal *models.AdmissionPortalSession, id uuid.UUID, upload PortalDocumentUpload) (*models.ApplicationDocument, error) {
+	application, err := s.draftApplication(ctx, portal, id)
+	if err != nil {
+		return nil, err
+	}
+
+	documentType := models.DocumentType(upload.DocumentType)
+	if !documentType.IsValid() && !requiresDocument(application.Session, upload.DocumentType) {
+		return nil, ErrInvalidDocumentType
+	}
+	if len(upload.Data) > MaxPortalDocumentSize {
+		return nil, ErrFileTooLarge
+	}
+	if !portalDocumentMimeTypes[upload.MimeType] {
+		return nil, ErrInvalidFileType
+	}
+
+	filePath := fmt.Sprintf("%s/admissions/%s/documents/%s%s",
+		portal.TenantID, application.ID, uuid.New(), strings.ToLower(filepath.Ext(upload.FileName)))
+	if err := s.storage.Upload(ctx, filePath, upload.Data, upload.MimeType); err != nil {
+		return nil, fmt.Errorf("failed to upload document: %w", err)
+	}
+
+	document := &models.ApplicationDocument{
+		TenantID:      portal.TenantID,
+		ApplicationID: application.ID,
+		DocumentType:  documentType,
+		FileName:      filepath.Base(upload.FileName),
+		FileURL:       filePath,
+		FileSize:      int64(len(upload.Data)),
+		MimeType:      upload.MimeType,
+	}
+	if err := s.db.WithContext(ctx).Create(document).Error; err != nil {
+		_ = s.storage.Delete(ctx, filePath)
+		return nil, fmt.Errorf("failed to add document: %w", err)
+	}
+
+	return document, nil
+}
+
+// DeleteDocument removes a document from a draft online application.
+func (s *PortalService) DeleteDocument(ctx context.Context, portal *models.AdmissionPortalSession, id, documentID uuid.UUID) error {
+	if _, err := s.draftApplication(ctx, portal, id); err != nil {
+		return err
+	}
+
+	document, err := s.applications.GetDocument(ctx, portal.TenantID, id, documentID)
+	if err != nil {
+		return err
+	}
+	if err := s.applications.DeleteDocument(ctx, portal.TenantID, id, documentID); err != nil {
+		return err
+	}
+	_ = s.storage.Delete(ctx, document.FileURL)
+	return nil
+}
+
+// Submit validates and submits a draft online application, then sends the
+// confirmation when the session has NotifyOnApplication enabled.
+func (s *PortalService) Submit(ctx context.Context, portal *models.AdmissionPortalSession, id uuid.UUID, captchaResponse, remoteIP string) (*models.AdmissionApplication, error) {
+	if err := s.verifyCaptcha(ctx, captchaResponse, remoteIP); err != nil {
+		return nil, err
+	}
+
+	application, err := s.draftApplication(ctx, portal, id)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.onlineSession(ctx, portal.TenantID, application.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRequiredFields(application); err != nil {
+		return nil, err
+	}
+	if err := checkRequiredDocuments(session, application.Documents); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Re-read the draft under a row lock so that a second submit of the
+		// same application waits for this one and then finds it submitted.
+		var current models.AdmissionApplication
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status").
+			Where("tenant_id = ? AND id = ?", portal.TenantID, id).
+			First(&current).Error; err != nil {
+			return fmt.Errorf("failed to lock application: %w", err)
+		}
+		if current.Status != models.ApplicationStatusDraft {
+			return ErrApplicationAlreadySubmitted
+		}
+
+		// Touch the session row so concurrent submissions queue behind this one
+		// and each counts the ones committed before it.
+		if err := tx.Model(session).Update("updated_at", time.Now()).Error; err != nil {
+			return fmt.Errorf("failed to lock session: %w", err)
+		}
+		if err := checkDailyLimit(tx, session); err != nil {
+			return err
+		}
+		return submitApplication(tx, application, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	submitted, err := s.applications.GetByID(ctx, portal.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Settings.NotifyOnApplication {
+		s.sendConfirmation(ctx, submitted)
+	}
+	return submitted, nil
+}
+
+// draftApplication returns one of the parent's applications if it can still be edited.
+func (s *PortalService) draftApplication(ctx context.Context, portal *models.AdmissionPortalSession, id uuid.UUID) (*models.AdmissionApplication, error) {
+	application, err := s.GetApplication(ctx, portal, id)
+	if err != nil {
+		return nil, err
+	}
+	if application.Status != models.ApplicationStatusDraft {
+		return nil, ErrApplicationAlreadySubmitted
+	}
+	return application, nil
+}
+
+// onlineSession loads a session with its seats and checks it accepts online applications today.
+func (s *PortalService) onlineSession(ctx context.Context, tenantID, sessionID uuid.UUID) (*models.AdmissionSession, error) {
+	if sessionID == uuid.Nil {
+		return nil, ErrSessionIDRequired
+	}
+
+	var session models.AdmissionSession
+	err := s.db.WithContext(ctx).
+		Preload("Seats").
+		Where("tenant_id = ? AND id = ?", tenantID, sessionID).
+		First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if !acceptsOnlineApplications(&session, time.Now()) {
+		return nil, ErrOnlineApplicationsClosed
+	}
+	return &session, nil
+}
+
+// checkDailyLimit enforces MaxApplicationsPerDay against today's online submissions.
+func checkDailyLimit(db *gorm.DB, session *models.AdmissionSession) error {
+	limit := session.Settings.MaxApplicationsPerDay
+	if limit <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var count int64
+	err := db.Model(&models.AdmissionApplication{}).
+		Where("session_id = ? AND source = ? AND submitted_at >= ?", session.ID, models.ApplicationSourceOnline, startOfDay).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to count applications: %w", err)
+	}
+	if count >= int64(limit) {
+		return ErrDailyApplicationLimitReached
+	}
+	return nil
+}
+
+// verifyCaptcha runs the configured CAPTCHA check, if any.
+func (s *PortalService) verifyCaptcha(ctx context.Context, response, remoteIP string) error {
+	if s.captcha == nil {
+		return nil
+	}
+	if err := s.captcha.Verify(ctx, response, remoteIP); err != nil {
+		return fmt.Errorf("%w: %v", ErrCaptchaFailed, err)
+	}
+	return nil
+}
+
+// sendConfirmation notifies the parent that the application was received.
+// Delivery failures are counted in metrics but do not fail the submission.
+func (s *PortalService) sendConfirmation(ctx context.Context, application *models.AdmissionApplication) {
+	schoolName := "the school"
+	var tenant models.Tenant
+	if err := s.db.WithContext(ctx).Select("name").First(&tenant, "id = ?", application.TenantID).Error; err == nil {
+		schoolName = tenant.Name
+	}
+
+	body := fmt.Sprintf("Your application %s for %s (class %s) has been received by %s. Use this number to track its status.",
+		application.ApplicationNumber, application.StudentName, application.ClassApplying, schoolName)
+
+	if s.smsProvider != nil && s.smsProvider.IsReady() {
+		_, _ = s.smsProvider.Send(ctx, sms.Message{To: application.VerifiedPhone, Body: body})
+	}
+
+	// TODO: Email the confirmation once an email service is integrated
+}
+
+// acceptsOnlineApplications reports whether a session takes online applications at the given time.
+func acceptsOnlineApplications(session *models.AdmissionSession, now time.Time) bool {
+	if session.Status != models.SessionStatusOpen || !session.Settings.AllowOnlineApplication {
+		return false
+	}
+	today := now.Format("2006-01-02")
+	return session.StartDate.Format("2006-01-02") <= today && today <= session.EndDate.Format("2006-01-02")
+}
+
+// checkClassOffered rejects classes without a seat configuration when the session has any.
+func checkClassOffered(session *models.AdmissionSession, className string) error {
+	if className == "" {
+		return ErrClassApplyingRequired
+	}
+	if session == nil || len(session.Seats) == 0 {
+		return nil
+	}
+	for _, seat := range session.Seats {
+		if strings.EqualFold(seat.ClassName, className) {
+			return nil
+		}
+	}
+	return ErrClassNotOffered
+}
+
+// checkRequiredFields checks the fields the school needs before reviewing an application.
+func checkRequiredFields(application *models.AdmissionApplication) error {
+	if application.StudentName == "" || application.ClassApplying == "" ||
+		application.DateOfBirth == nil || application.Gender == "" {
+		return ErrMissingRequiredFields
+	}
+	if application.FatherName == "" && application.MotherName == "" && application.GuardianName == "" {
+		return ErrParentNameRequired
+	}
+	return nil
+}
+
+// checkRequiredDocuments checks that every document the session requires was uploaded.
+func checkRequiredDocuments(session *models.AdmissionSession, documents []models.ApplicationDocument) error {
+	uploaded := make(map[string]bool, len(documents))
+	for _, document := range documents {
+		uploaded[string(document.DocumentType)] = true
+	}
+
+	var missing []string
+	for _, documentType := range session.RequiredDocuments {
+		if !uploaded[documentType] {
+			missing = append(missing, documentType)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingDocumentsError{DocumentTypes: missing}
+	}
+	return nil
+}
+
+// requiresDocument reports whether the session lists the document type as required.
+func requiresDocument(session *models.AdmissionSession, documentType string) bool {
+	if session == nil {
+		return false
+	}
+	for _, required := range session.RequiredDocuments {
+		if required == documentType {
+			return true
+		}
+	}
+	return false
+}
+
+// newPublicToken returns a random URL-safe token for portal sessions and offer links.
+func newPublicToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashPublicToken returns the SHA-256 hex digest stored in place of a public token.
+func hashPublicToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,325 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/sms"
+	"msls-backend/internal/pkg/storage"
+	"msls-backend/internal/services/auth"
+)
+
+var otpCodePattern = regexp.MustCompile(`\d{6}`)
+
+type portalFixture struct {
+	db        *gorm.DB
+	service   *PortalService
+	sms       *sms.MockProvider
+	tenantID  uuid.UUID
+	sessionID uuid.UUID
+}
+
+// setupPortal creates a portal service with an open session offering Class 1.
+func setupPortal(t *testing.T, settings models.SessionSettings, requiredDocuments ...string) *portalFixture {
+	t.Helper()
+
+	db := newAdmissionTestDB(t,
+		&models.Tenant{}, &models.OTPCode{}, &models.OTPRateLimit{},
+		&models.AdmissionSession{}, &models.AdmissionSeat{},
+		&models.AdmissionApplication{}, &models.ApplicationParent{}, &models.ApplicationDocument{},
+		&models.ApplicationNumberSequence{}, &models.AdmissionPortalSession{},
+		&models.Student{}, &models.StudentGuardian{}, &models.Staff{}, &models.FamilyGuardian{},
+	)
+	session := seedAdmissionSession(t, db, settings, requiredDocuments...)
+	require.NoError(t, db.Create(&models.AdmissionSeat{
+		ID:            uuid.New(),
+		TenantID:      session.TenantID,
+		SessionID:     session.ID,
+		ClassName:     "Class 1",
+		TotalSeats:    40,
+		ReservedSeats: models.ReservedSeats{},
+	}).Error)
+
+	provider, err := sms.NewMockProvider("")
+	require.NoError(t, err)
+	fileStorage, err := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	otpService := auth.NewOTPService(db, nil, auth.OTPConfig{SMSProvider: provider})
+	service := NewPortalService(db, NewApplicationService(db), PortalConfig{
+		OTPService:  otpService,
+		SMSProvider: provider,
+		Storage:     fileStorage,
+	})
+
+	return &portalFixture{db: db, service: service, sms: provider, tenantID: session.TenantID, sessionID: session.ID}
+}
+
+// signIn verifies a phone through the OTP flow and returns its portal session.
+func (f *portalFixture) signIn(t *testing.T, phone string) *models.AdmissionPortalSession {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := f.service.RequestOTP(ctx, f.tenantID, phone, "", "")
+	require.NoError(t, err)
+	message := f.sms.GetLastMessage()
+	require.NotNil(t, message)
+
+	token, _, err := f.service.VerifyPhone(ctx, f.tenantID, phone, otpCodePattern.FindString(message.Body))
+	require.NoError(t, err)
+
+	portal, err := f.service.Authenticate(ctx, f.tenantID, token)
+	require.NoError(t, err)
+	return portal
+}
+
+// startCompleteApplication starts a draft with every required field filled in.
+func (f *portalFixture) startCompleteApplication(t *testing.T, portal *models.AdmissionPortalSession) *models.AdmissionApplication {
+	t.Helper()
+
+	dob := time.Date(2020, 5, 10, 0, 0, 0, 0, time.UTC)
+	application, err := f.service.StartApplication(context.Background(), portal, CreateApplicationRequest{
+		SessionID:     f.sessionID,
+		StudentName:   "Asha Rao",
+		ClassApplying: "Class 1",
+		DateOfBirth:   &dob,
+		Gender:        "female",
+		MotherName:    "Meera Rao",
+	})
+	require.NoError(t, err)
+	return application
+}
+
+func TestPortalService_SignIn(t *testing.T) {
+	f := setupPortal(t, models.SessionSettings{AllowOnlineApplication: true})
+	ctx := context.Background()
+
+	portal := f.signIn(t, "+919876543210")
+	assert.Equal(t, "+919876543210", portal.Phone)
+	assert.Equal(t, f.tenantID, portal.TenantID)
+
+	// The token is scoped to the tenant it was issued for
+	_, err := f.service.Authenticate(ctx, uuid.New(), "not-a-token")
+	assert.ErrorIs(t, err, ErrPortalSessionInvalid)
+
+	// Wrong codes are rejected
+	_, err = f.service.RequestOTP(ctx, f.tenantID, "+919876500000", "", "")
+	require.NoError(t, err)
+	_, _, err = f.service.VerifyPhone(ctx, f.tenantID, "+919876500000", "000000")
+	assert.Error(t, err)
+}
+
+func TestPortalService_ClosedForOnlineApplications(t *testing.T) {
+	f := setupPortal(t, models.SessionSettings{AllowOnlineApplication: false})
+
+	sessions, err := f.service.ListOpenSessions(context.Background(), f.tenantID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	_, err = f.service.RequestOTP(context.Background(), f.tenantID, "+919876543210", "", "")
+	assert.ErrorIs(t, err, ErrOnlineApplicationsClosed)
+}
+
+func TestPortalService_SubmitApplication(t *testing.T) {
+	f := setupPortal(t, models.SessionSettings{AllowOnlineApplication: true, NotifyOnApplication: true}, "birth_certificate")
+	ctx := context.Background()
+	portal := f.signIn(t, "+919876543210")
+
+	sessions, err := f.service.ListOpenSessions(ctx, f.tenantID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Len(t, sessions[0].Seats, 1)
+
+	_, err = f.service.StartApplication(ctx, portal, CreateApplicationRequest{
+		SessionID:     f.sessionID,
+		StudentName:   "Asha Rao",
+		ClassApplying: "Class 12",
+	})
+	assert.ErrorIs(t, err, ErrClassNotOffered)
+
+	application := f.startCompleteApplication(t, portal)
+	assert.Equal(t, models.ApplicationSourceOnline, application.Source)
+	assert.Equal(t, portal.Phone, application.VerifiedPhone)
+	assert.Equal(t, models.ApplicationStatusDraft, application.Status)
+
+	// Required documents must be uploaded before submitting
+	_, err = f.service.Submit(ctx, portal, application.ID, "", "")
+	var missing *MissingDocumentsError
+	require.True(t, errors.As(err, &missing))
+	assert.Equal(t, []string{"birth_certificate"}, missing.DocumentTypes)
+	assert.ErrorIs(t, err, ErrMissingRequiredDocuments)
+
+	_, err = f.service.UploadDocument(ctx, portal, application.ID, PortalDocumentUpload{
+		DocumentType: "birth_certificate",
+		FileName:     "birth.exe",
+		MimeType:     "application/octet-stream",
+		Data:         []byte("MZ"),
+	})
+	assert.ErrorIs(t, err, ErrInvalidFileType)
+
+	document, err := f.service.UploadDocument(ctx, portal, application.ID, PortalDocumentUpload{
+		DocumentType: "birth_certificate",
+		FileName:     "birth.pdf",
+		MimeType:     "application/pdf",
+		Data:         []byte("%PDF-1.4"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), document.FileSize)
+
+	f.sms.ClearMessages()
+	submitted, err := f.service.Submit(ctx, portal, application.ID, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, models.ApplicationStatusSubmitted, submitted.Status)
+	assert.NotNil(t, submitted.SubmittedAt)
+
+	confirmation := f.sms.GetLastMessage()
+	require.NotNil(t, confirmation)
+	assert.Equal(t, portal.Phone, confirmation.To)
+	assert.Contains(t, confirmation.Body, submitted.ApplicationNumber)
+
+	// Submitted applications can no longer be edited
+	name := "Changed"
+	_, err = f.service.UpdateApplication(ctx, portal, application.ID, UpdateApplicationRequest{StudentName: &name})
+	assert.ErrorIs(t, err, ErrApplicationAlreadySubmitted)
+}
+
+func TestPortalService_RequiredFields(t *testing.T) {
+	f := setupPortal(t, models.SessionSettings{AllowOnlineApplication: true})
+	ctx := context.Background()
+	portal := f.signIn(t, "+919876543210")
+
+	application, err := f.service.StartApplication(ctx, portal, CreateApplicationRequest{
+		SessionID:     f.sessionID,
+		StudentName:   "Asha Rao",
+		ClassApplying: "Class 1",
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.Submit(ctx, portal, application.ID, "", "")
+	assert.ErrorIs(t, err, ErrMissingRequiredFields)
+}
+
+func TestPortalService_DailyLimit(t *testing.T) {
+	f := setupPortal(t, models.SessionSettings{AllowOnlineApplication: true, MaxApplicationsPerDay: 1})
+	ctx := context.Background()
+	portal := f.signIn(t, "+919876543210")
+
+	first := f.startCompleteApplication(t, portal)
+	second := f.startCompleteApplication(t, portal)
+
+	_, err := f.service.Submit(ctx, portal, first.ID, "", "")
+	require.NoError(t, err)
+
+	_, err = f.service.Submit(ctx, portal, second.ID, "", "")
+	assert.ErrorIs(t, err, ErrDailyApplicationLimitReached)
+
+	_, err = f.service.StartApplication(ctx, portal, CreateApplicationRequest{
+		SessionID:     f.sessionID,
+		StudentName:   "Ravi Rao",
+		ClassApplying: "Class 1",
+	})
+	assert.ErrorIs(t, err, ErrDailyApplicationLimitReached)
+}
+
+func TestPortalService_ConcurrentSubmit(t *testing.T) {
+	f := setupPortal(t, models.SessionSettings{AllowOnlineApplication: true, MaxApplicationsPerDay: 2})
+	ctx := context.Background()
+	portal := f.signIn(t, "+919876543210")
+	application := f.startCompleteApplication(t, portal)
+
+	// Hold each submit once it has read the draft, until both have, so that
+	// both reach the transaction believing the application is a draft.
+	var mu sync.Mutex
+	arrived := 0
+	release := make(chan struct{})
+	require.NoError(t, f.db.Callback().Query().After("gorm:after_query").Register("test:hold_submits", func(db *gorm.DB) {
+		if db.Statement.Table != "admission_sessions" {
+			return
+		}
+		mu.Lock()
+		arrived++
+		if arrived == 2 {
+			close(release)
+		}
+		held := arrived <= 2
+		mu.Unlock()
+		if held {
+			<-release
+		}
+	}))
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := f.service.Submit(ctx, portal, application.ID, "", "")
+			errs <- err
+		}()
+	}
+
+	var failures []error
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			failures = append(failures, err)
+		}
+	}
+	require.Len(t, failures, 1)
+	assert.ErrorIs(t, failures[0], ErrApplicationAlreadySubmitted)
+
+	var submitted models.AdmissionApplication
+	require.NoError(t, f.db.First(&submitted, "id = ?", application.ID).Error)
+	assert.Equal(t, models.ApplicationStatusSubmitted, submitted.Status)
+}
+
+func TestPortalService_ApplicationsAreScopedToPhone(t *testing.T) {
+	f := setupPortal(t, models.SessionSettings{AllowOnlineApplication: true})
+	ctx := context.Background()
+
+	owner := f.signIn(t, "+919876543210")
+	application := f.startCompleteApplication(t, owner)
+
+	other := f.signIn(t, "+919123456780")
+	_, err := f.service.GetApplication(ctx, other, application.ID)
+	assert.ErrorIs(t, err, ErrApplicationNotFound)
+
+	_, err = f.service.Submit(ctx, other, application.ID, "", "")
+	assert.ErrorIs(t, err, ErrApplicationNotFound)
+
+	applications, err := f.service.ListApplications(ctx, other)
+	require.NoError(t, err)
+	assert.Empty(t, applications)
+
+	applications, err = f.service.ListApplications(ctx, owner)
+	require.NoError(t, err)
+	assert.Len(t, applications, 1)
+}
+
+type rejectingCaptcha struct{}
+
+func (rejectingCaptcha) Verify(ctx context.Context, response, remoteIP string) error {
+	if response == "" {
+		return errors.New("missing response")
+	}
+	return nil
+}
+
+func TestPortalService_Captcha(t *testing.T) {
+	f := setupPortal(t, models.SessionSettings{AllowOnlineApplication: true})
+	f.service.captcha = rejectingCaptcha{}
+
+	_, err := f.service.RequestOTP(context.Background(), f.tenantID, "+919876543210", "", "127.0.0.1")
+	assert.ErrorIs(t, err, ErrCaptchaFailed)
+
+	_, err = f.service.RequestOTP(context.Background(), f.tenantID, "+919876543210", "token", "127.0.0.1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,621 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// QuestionBankService manages the entrance test question bank and the question
+// papers built from it.
+type QuestionBankService struct {
+	db *gorm.DB
+}
+
+// NewQuestionBankService creates a new QuestionBankService instance.
+func NewQuestionBankService(db *gorm.DB) *QuestionBankService {
+	return &QuestionBankService{db: db}
+}
+
+// QuestionRequest represents a request to create or replace a question bank question.
+type QuestionRequest struct {
+	TenantID         uuid.UUID
+	Subject          string
+	Topic            string
+	QuestionType     QuestionType
+	QuestionText     string
+	Options          []QuestionOption
+	CorrectOptions   []string
+	NumericAnswer    *decimal.Decimal
+	NumericTolerance decimal.Decimal
+	Marks            decimal.Decimal
+	NegativeMarks    decimal.Decimal
+	IsActive         *bool
+	UserID           *uuid.UUID
+}
+
+// QuestionListFilter contains filters for listing question bank questions.
+type QuestionListFilter struct {
+	TenantID     uuid.UUID
+	Subject      string
+	QuestionType *QuestionType
+	IsActive     *bool
+	Search       string
+}
+
+// SectionRequest represents a request to create or update a question paper section.
+type SectionRequest struct {
+	TenantID         uuid.UUID
+	TestID           uuid.UUID
+	Name             string
+	Subject          string
+	Sequence         int
+	Instructions     string
+	MarksPerQuestion *decimal.Decimal
+	NegativeMarks    *decimal.Decimal
+}
+
+// QuestionPaper is an entrance test together with its ordered paper sections.
+type QuestionPaper struct {
+	Test     EntranceTest
+	Sections []TestSection
+}
+
+// SubjectTotals returns the maximum marks the paper carries for each subject.
+func (p *QuestionPaper) SubjectTotals() map[string]decimal.Decimal {
+	totals := make(map[string]decimal.Decimal)
+	for i := range p.Sections {
+		section := &p.Sections[i]
+		for _, sq := range section.Questions {
+			if sq.Question == nil {
+				continue
+			}
+			marks, _ := section.QuestionMarks(sq.Question)
+			totals[section.Subject] = totals[section.Subject].Add(marks)
+		}
+	}
+	return totals
+}
+
+// Validate checks that the paper can be delivered online: every section has
+// questions, and each subject's questions add up to the subject's maximum marks
+// so auto-scored results line up with the test's marking.
+func (p *QuestionPaper) Validate() error {
+	if p.Test.DurationMinutes <= 0 {
+		return fmt.Errorf("%w: test has no duration", ErrPaperIncomplete)
+	}
+	if _, err := p.Test.StartsAt(); err != nil {
+		return fmt.Errorf("%w: %v", ErrPaperIncomplete, err)
+	}
+	if len(p.Sections) == 0 {
+		return fmt.Errorf("%w: paper has no sections", ErrPaperIncomplete)
+	}
+	for _, section := range p.Sections {
+		if len(section.Questions) == 0 {
+			return fmt.Errorf("%w: section %q has no questions", ErrPaperIncomplete, section.Name)
+		}
+		if !testHasSubject(&p.Test, section.Subject) {
+			return fmt.Errorf("%w: section %q is for %s, which the test does not cover", ErrPaperIncomplete, section.Name, section.Subject)
+		}
+	}
+
+	totals := p.SubjectTotals()
+	for _, subject := range p.Test.Subjects {
+		if !totals[subject.Subject].Equal(subject.MaxMarks) {
+			return fmt.Errorf("%w: %s questions carry %s of %s marks", ErrPaperIncomplete,
+				subject.Subject, totals[subject.Subject].StringFixed(2), subject.MaxMarks.StringFixed(2))
+		}
+	}
+	return nil
+}
+
+// CreateQuestion adds a question to the question bank.
+func (s *QuestionBankService) CreateQuestion(ctx context.Context, req QuestionRequest) (*TestQuestion, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	question := &TestQuestion{
+		TenantID:  req.TenantID,
+		IsActive:  true,
+		CreatedBy: req.UserID,
+		UpdatedBy: req.UserID,
+	}
+	if err := applyQuestionRequest(question, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(question).Error; err != nil {
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+	return question, nil
+}
+
+// GetQuestion retrieves a question bank question by ID.
+func (s *QuestionBankService) GetQuestion(ctx context.Context, tenantID, questionID uuid.UUID) (*TestQuestion, error) {
+	var question TestQuestion
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, questionID).
+		First(&question).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	return &question, nil
+}
+
+// ListQuestions retrieves question bank questions with optional filtering.
+func (s *QuestionBankService) ListQuestions(ctx context.Context, filter QuestionListFilter) ([]TestQuestion, error) {
+	query := s.db.WithContext(ctx).
+		Model(&TestQuestion{}).
+		Where("tenant_id = ?", filter.TenantID)
+
+	if filter.Subject != "" {
+		query = query.Where("subject = ?", filter.Subject)
+	}
+	if filter.QuestionType != nil {
+		query = query.Where("question_type = ?", *filter.QuestionType)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.Search != "" {
+		search := "%" + strings.ToLower(filter.Search) + "%"
+		query = query.Where("LOWER(question_text) LIKE ? OR LOWER(topic) LIKE ?", search, search)
+	}
+
+	var questions []TestQuestion
+	if err := query.Order("subject ASC, created_at ASC").Find(&questions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+	return questions, nil
+}
+
+// UpdateQuestion replaces a question's content. Questions on a published paper
+// cannot change, since candidates may already be answering them.
+func (s *QuestionBankService) UpdateQuestion(ctx context.Context, questionID uuid.UUID, req QuestionRequest) (*TestQuestion, error) {
+	question, err := s.GetQuestion(ctx, req.TenantID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var published int64
+	err = s.db.WithContext(ctx).Model(&TestSectionQuestion{}).
+		Joins("JOIN test_sections ON test_sections.id = test_section_questions.section_id").
+		Joins("JOIN entrance_tests ON entrance_tests.id = test_sections.test_id").
+		Where("test_section_questions.tenant_id = ? AND test_section_questions.question_id = ? AND entrance_tests.online_enabled = ?", req.TenantID, questionID, true).
+		Count(&published).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check question papers: %w", err)
+	}
+	if published > 0 {
+		return nil, ErrPaperPublished
+	}
+
+	if err := applyQuestionRequest(question, req); err != nil {
+		return nil, err
+	}
+	question.UpdatedBy = req.UserID
+
+	if err := s.db.WithContext(ctx).Save(question).Error; err != nil {
+		return nil, fmt.Errorf("failed to update question: %w", err)
+	}
+	return question, nil
+}
+
+// DeleteQuestion removes a question that is not on any question paper.
+// Questions already used should be deactivated instead.
+func (s *QuestionBankService) DeleteQuestion(ctx context.Context, tenantID, questionID uuid.UUID) error {
+	if _, err := s.GetQuestion(ctx, tenantID, questionID); err != nil {
+		return err
+	}
+
+	var used int64
+	if err := s.db.WithContext(ctx).Model(&TestSectionQuestion{}).
+		Where("tenant_id = ? AND question_id = ?", tenantID, questionID).
+		Count(&used).Error; err != nil {
+		return fmt.Errorf("failed to check question papers: %w", err)
+	}
+	if used > 0 {
+		return ErrQuestionInUse
+	}
+
+	if err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, questionID).
+		Delete(&TestQuestion{}).Error; err != nil {
+		return fmt.Errorf("failed to delete question: %w", err)
+	}
+	return nil
+}
+
+// GetPaper retrieves a test's question paper with sections and questions in order.
+func (s *QuestionBankService) GetPaper(ctx context.Context, tenantID, testID uuid.UUID) (*QuestionPaper, error) {
+	return loadQuestionPaper(s.db.WithContext(ctx), tenantID, testID)
+}
+
+// CreateSection adds a section to a test's question paper.
+func (s *QuestionBankService) CreateSection(ctx context.Context, req SectionRequest) (*TestSection, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	test, err := s.editableTest(ctx, req.TenantID, req.TestID)
+	if err != nil {
+		return nil, err
+	}
+
+	section := &TestSection{TenantID: req.TenantID, TestID: req.TestID}
+	if err := applySectionRequest(section, test, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(section).Error; err != nil {
+		return nil, fmt.Errorf("failed to create section: %w", err)
+	}
+	return section, nil
+}
+
+// UpdateSection updates a question paper section.
+func (s *QuestionBankService) UpdateSection(ctx context.Context, sectionID uuid.UUID, req SectionRequest) (*TestSection, error) {
+	section, err := s.getSection(ctx, req.TenantID, req.TestID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	test, err := s.editableTest(ctx, req.TenantID, section.TestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applySectionRequest(section, test, req); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Model(&TestSection{}).
+		Where("tenant_id = ? AND id = ?", req.TenantID, sectionID).
+		Updates(map[string]interface{}{
+			"name":               section.Name,
+			"subject":            section.Subject,
+			"sequence":           section.Sequence,
+			"instructions":       section.Instructions,
+			"marks_per_question": section.MarksPerQuestion,
+			"negative_marks":     section.NegativeMarks,
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to update section: %w", err)
+	}
+	return section, nil
+}
+
+// DeleteSection removes a section and its question placements from a paper.
+func (s *QuestionBankService) DeleteSection(ctx context.Context, tenantID, testID, sectionID uuid.UUID) error {
+	section, err := s.getSection(ctx, tenantID, testID, sectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.editableTest(ctx, tenantID, section.TestID); err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("section_id = ?", sectionID).Delete(&TestSectionQuestion{}).Error; err != nil {
+			return fmt.Errorf("failed to remove section questions: %w", err)
+		}
+		if err := tx.Where("tenant_id = ? AND id = ?", tenantID, sectionID).Delete(&TestSection{}).Error; err != nil {
+			return fmt.Errorf("failed to delete section: %w", err)
+		}
+		return nil
+	})
+}
+
+// AddSectionQuestion places a question bank question in a paper section. A
+// question can appear only once per paper and must match the section's subject.
+func (s *QuestionBankService) AddSectionQuestion(ctx context.Context, tenantID, testID, sectionID, questionID uuid.UUID, sequence int) (*TestSectionQuestion, error) {
+	section, err := s.getSection(ctx, tenantID, testID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.editableTest(ctx, tenantID, section.TestID); err != nil {
+		return nil, err
+	}
+
+	question, err := s.GetQuestion(ctx, tenantID, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !question.IsActive {
+		return nil, fmt.Errorf("%w: question is inactive", ErrInvalidQuestion)
+	}
+	if !strings.EqualFold(question.Subject, section.Subject) {
+		return nil, fmt.Errorf("%w: question is for %s but the section is for %s", ErrInvalidQuestion, question.Subject, section.Subject)
+	}
+
+	var existing int64
+	err = s.db.WithContext(ctx).Model(&TestSectionQuestion{}).
+		Joins("JOIN test_sections ON test_sections.id = test_section_questions.section_id").
+		Where("test_sections.test_id = ? AND test_section_questions.question_id = ?", section.TestID, questionID).
+		Count(&existing).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check question paper: %w", err)
+	}
+	if existing > 0 {
+		return nil, ErrQuestionAlreadyOnPaper
+	}
+
+	placement := &TestSectionQuestion{
+		TenantID:   tenantID,
+		SectionID:  sectionID,
+		QuestionID: questionID,
+		Sequence:   sequence,
+	}
+	if err := s.db.WithContext(ctx).Omit("Question").Create(placement).Error; err != nil {
+		return nil, fmt.Errorf("failed to add question to section: %w", err)
+	}
+	placement.Question = question
+	return placement, nil
+}
+
+// RemoveSectionQuestion removes a question from a paper section.
+func (s *QuestionBankService) RemoveSectionQuestion(ctx context.Context, tenantID, testID, sectionID, questionID uuid.UUID) error {
+	section, err := s.getSection(ctx, tenantID, testID, sectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.editableTest(ctx, tenantID, section.TestID); err != nil {
+		return err
+	}
+
+	result := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND section_id = ? AND question_id = ?", tenantID, sectionID, questionID).
+		Delete(&TestSectionQuestion{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove question from section: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrQuestionNotOnPaper
+	}
+	return nil
+}
+
+// PublishPaper validates a test's question paper and opens the test for online
+// delivery. lateEntryMinutes, when set, changes how long after the start time
+// candidates may still begin.
+func (s *QuestionBankService) PublishPaper(ctx context.Context, tenantID, testID uuid.UUID, lateEntryMinutes *int) (*QuestionPaper, error) {
+	paper, err := s.GetPaper(ctx, tenantID, testID)
+	if err != nil {
+		return nil, err
+	}
+	if paper.Test.Status != TestStatusScheduled {
+		return nil, ErrTestNotScheduled
+	}
+	if err := paper.Validate(); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{"online_enabled": true}
+	if lateEntryMinutes != nil {
+		if *lateEntryMinutes < 0 || *lateEntryMinutes > paper.Test.DurationMinutes {
+			return nil, fmt.Errorf("%w: late entry must be between 0 and %d minutes", ErrPaperIncomplete, paper.Test.DurationMinutes)
+		}
+		updates["late_entry_minutes"] = *lateEntryMinutes
+		paper.Test.LateEntryMinutes = *lateEntryMinutes
+	}
+
+	if err := s.db.WithContext(ctx).Model(&EntranceTest{}).
+		Where("tenant_id = ? AND id = ?", tenantID, testID).
+		Updates(updates).Error; err != nil {
+		return nil, fmt.Errorf("failed to publish question paper: %w", err)
+	}
+	paper.Test.OnlineEnabled = true
+	return paper, nil
+}
+
+// UnpublishPaper withdraws a test from online delivery so its paper can be
+// edited. It is refused once any candidate has started the test.
+func (s *QuestionBankService) UnpublishPaper(ctx context.Context, tenantID, testID uuid.UUID) error {
+	var test EntranceTest
+	if err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, testID).
+		First(&test).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTestNotFound
+		}
+		return fmt.Errorf("failed to get test: %w", err)
+	}
+
+	var attempts int64
+	if err := s.db.WithContext(ctx).Model(&TestAttempt{}).
+		Where("tenant_id = ? AND test_id = ?", tenantID, testID).
+		Count(&attempts).Error; err != nil {
+		return fmt.Errorf("failed to count attempts: %w", err)
+	}
+	if attempts > 0 {
+		return ErrPaperLocked
+	}
+
+	if err := s.db.WithContext(ctx).Model(&EntranceTest{}).
+		Where("tenant_id = ? AND id = ?", tenantID, testID).
+		Update("online_enabled", false).Error; err != nil {
+		return fmt.Errorf("failed to unpublish question paper: %w", err)
+	}
+	return nil
+}
+
+// editableTest returns the test if its paper may still change.
+func (s *QuestionBankService) editableTest(ctx context.Context, tenantID, testID uuid.UUID) (*EntranceTest, error) {
+	var test EntranceTest
+	if err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, testID).
+		First(&test).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTestNotFound
+		}
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test.Status == TestStatusCompleted {
+		return nil, ErrCannotModifyCompletedTest
+	}
+	if test.OnlineEnabled {
+		return nil, ErrPaperPublished
+	}
+	return &test, nil
+}
+
+// getSection retrieves a section of a test's paper by ID.
+func (s *QuestionBankService) getSection(ctx context.Context, tenantID, testID, sectionID uuid.UUID) (*TestSection, error) {
+	var section TestSection
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND test_id = ? AND id = ?", tenantID, testID, sectionID).
+		First(&section).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSectionNotFound
+		}
+		return nil, fmt.Errorf("failed to get section: %w", err)
+	}
+	return &section, nil
+}
+
+// loadQuestionPaper loads a test with its sections and questions in paper order.
+func loadQuestionPaper(db *gorm.DB, tenantID, testID uuid.UUID) (*QuestionPaper, error) {
+	var test EntranceTest
+	if err := db.Where("tenant_id = ? AND id = ?", tenantID, testID).First(&test).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTestNotFound
+		}
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+
+	var sections []TestSection
+	err := db.
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC, created_at ASC")
+		}).
+		Preload("Questions.Question").
+		Where("tenant_id = ? AND test_id = ?", tenantID, testID).
+		Order("sequence ASC, created_at ASC").
+		Find(&sections).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load question paper: %w", err)
+	}
+
+	return &QuestionPaper{Test: test, Sections: sections}, nil
+}
+
+// applyQuestionRequest validates a question request and copies it onto the question.
+func applyQuestionRequest(q *TestQuestion, req QuestionRequest) error {
+	subject := strings.TrimSpace(req.Subject)
+	text := strings.TrimSpace(req.QuestionText)
+	if subject == "" || text == "" {
+		return fmt.Errorf("%w: subject and question text are required", ErrInvalidQuestion)
+	}
+	if !req.QuestionType.IsValid() {
+		return fmt.Errorf("%w: unknown question type %q", ErrInvalidQuestion, req.QuestionType)
+	}
+	if !req.Marks.IsPositive() {
+		return fmt.Errorf("%w: marks must be greater than zero", ErrInvalidQuestion)
+	}
+	if req.NegativeMarks.IsNegative() || req.NegativeMarks.GreaterThan(req.Marks) {
+		return fmt.Errorf("%w: negative marks must be between zero and the question's marks", ErrInvalidQuestion)
+	}
+
+	options := QuestionOptions{}
+	correct := StringArray{}
+	var numericAnswer *decimal.Decimal
+	tolerance := decimal.Zero
+
+	switch req.QuestionType {
+	case QuestionTypeMCQ:
+		if len(req.Options) < 2 {
+			return fmt.Errorf("%w: multiple-choice questions need at least two options", ErrInvalidQuestion)
+		}
+		keys := make(map[string]bool, len(req.Options))
+		for _, option := range req.Options {
+			key := strings.TrimSpace(option.Key)
+			if key == "" || strings.TrimSpace(option.Text) == "" || keys[key] {
+				return fmt.Errorf("%w: options need unique keys and text", ErrInvalidQuestion)
+			}
+			keys[key] = true
+			options = append(options, QuestionOption{Key: key, Text: strings.TrimSpace(option.Text)})
+		}
+		if len(req.CorrectOptions) == 0 {
+			return fmt.Errorf("%w: at least one correct option is required", ErrInvalidQuestion)
+		}
+		for _, key := range req.CorrectOptions {
+			key = strings.TrimSpace(key)
+			if !keys[key] {
+				return fmt.Errorf("%w: correct option %q is not one of the options", ErrInvalidQuestion, key)
+			}
+			correct = append(correct, key)
+		}
+	case QuestionTypeNumeric:
+		if req.NumericAnswer == nil {
+			return fmt.Errorf("%w: numeric questions need an answer", ErrInvalidQuestion)
+		}
+		if req.NumericTolerance.IsNegative() {
+			return fmt.Errorf("%w: tolerance cannot be negative", ErrInvalidQuestion)
+		}
+		answer := *req.NumericAnswer
+		numericAnswer = &answer
+		tolerance = req.NumericTolerance
+	case QuestionTypeDescriptive:
+		// Descriptive answers are graded by hand and carry no negative marking
+		req.NegativeMarks = decimal.Zero
+	}
+
+	q.Subject = subject
+	q.Topic = strings.TrimSpace(req.Topic)
+	q.QuestionType = req.QuestionType
+	q.QuestionText = text
+	q.Options = options
+	q.CorrectOptions = correct
+	q.NumericAnswer = numericAnswer
+	q.NumericTolerance = tolerance
+	q.Marks = req.Marks
+	q.NegativeMarks = req.NegativeMarks
+	if req.IsActive != nil {
+		q.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// applySectionRequest validates a section request against the test and copies it onto the section.
+func applySectionRequest(section *TestSection, test *EntranceTest, req SectionRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: section name is required", ErrInvalidQuestion)
+	}
+	if !testHasSubject(test, req.Subject) {
+		return ErrSubjectNotInTest
+	}
+	if req.MarksPerQuestion != nil && !req.MarksPerQuestion.IsPositive() {
+		return fmt.Errorf("%w: section marks must be greater than zero", ErrInvalidQuestion)
+	}
+	if req.NegativeMarks != nil && req.NegativeMarks.IsNegative() {
+		return fmt.Errorf("%w: section negative marks cannot be negative", ErrInvalidQuestion)
+	}
+
+	section.Name = name
+	section.Subject = req.Subject
+	section.Sequence = req.Sequence
+	section.Instructions = req.Instructions
+	section.MarksPerQuestion = req.MarksPerQuestion
+	section.NegativeMarks = req.NegativeMarks
+	return nil
+}
+
+// testHasSubject reports whether subject is one of the test's subjects.
+func testHasSubject(test *EntranceTest, subject string) bool {
+	for _, s := range test.Subjects {
+		if s.Subject == subject {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ package admission
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -610,6 +611,168 @@ func (s *ReportService) GetDailyTrend(ctx context.Context, filter DashboardFilte
 	return response, nil
 }
 
+// CounsellorPerformance represents enquiry outcomes for one assigned counsellor.
+type CounsellorPerformance struct {
+	CounsellorID   uuid.UUID `json:"counsellorId"`
+	CounsellorName string    `json:"counsellorName"`
+	Assigned       int64     `json:"assigned"`
+	Open           int64     `json:"open"`
+	Converted      int64     `json:"converted"`
+	Closed         int64     `json:"closed"`
+	ConversionRate float64   `json:"conversionRate"`
+	AvgLeadScore   float64   `json:"avgLeadScore"`
+}
+
+// CounsellorPerformanceResponse wraps the counsellor performance report.
+type CounsellorPerformanceResponse struct {
+	Counsellors []CounsellorPerformance `json:"counsellors"`
+}
+
+// GetCounsellorPerformance retrieves enquiry conversion rates per assigned counsellor.
+func (s *ReportService) GetCounsellorPerformance(ctx context.Context, filter DashboardFilter) (*CounsellorPerformanceResponse, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+
+	type counsellorRow struct {
+		CounsellorID uuid.UUID
+		FirstName    string
+		LastName     string
+		Assigned     int64
+		Open         int64
+		Converted    int64
+		Closed       int64
+		AvgLeadScore float64
+	}
+
+	query := s.db.WithContext(ctx).
+		Table("admission_enquiries e").
+		Select(`e.assigned_to AS counsellor_id, u.first_name, u.last_name,
+			COUNT(*) AS assigned,
+			SUM(CASE WHEN e.status IN ('new', 'contacted', 'interested') THEN 1 ELSE 0 END) AS open,
+			SUM(CASE WHEN e.status = 'converted' THEN 1 ELSE 0 END) AS converted,
+			SUM(CASE WHEN e.status = 'closed' THEN 1 ELSE 0 END) AS closed,
+			AVG(e.lead_score) AS avg_lead_score`).
+		Joins("LEFT JOIN users u ON u.id = e.assigned_to").
+		Where("e.tenant_id = ? AND e.assigned_to IS NOT NULL AND e.deleted_at IS NULL", filter.TenantID)
+
+	if filter.BranchID != nil {
+		query = query.Where("e.branch_id = ?", *filter.BranchID)
+	}
+	if filter.SessionID != nil {
+		query = query.Where("e.session_id = ?", *filter.SessionID)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("e.created_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("e.created_at < ?", filter.EndDate.AddDate(0, 0, 1))
+	}
+
+	var rows []counsellorRow
+	if err := query.Group("e.assigned_to, u.first_name, u.last_name").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get counsellor performance: %w", err)
+	}
+
+	response := &CounsellorPerformanceResponse{
+		Counsellors: make([]CounsellorPerformance, 0, len(rows)),
+	}
+	for _, r := range rows {
+		rate := float64(0)
+		if r.Assigned > 0 {
+			rate = float64(r.Converted) / float64(r.Assigned) * 100
+		}
+		response.Counsellors = append(response.Counsellors, CounsellorPerformance{
+			CounsellorID:   r.CounsellorID,
+			CounsellorName: fullName(r.FirstName, r.LastName),
+			Assigned:       r.Assigned,
+			Open:           r.Open,
+			Converted:      r.Converted,
+			Closed:         r.Closed,
+			ConversionRate: roundToDecimal(rate, 1),
+			AvgLeadScore:   roundToDecimal(r.AvgLeadScore, 1),
+		})
+	}
+
+	// Best converters first
+	sort.SliceStable(response.Counsellors, func(i, j int) bool {
+		return response.Counsellors[i].ConversionRate > response.Counsellors[j].ConversionRate
+	})
+
+	return response, nil
+}
+
+// SeatMatrixResponse represents the seat matrix for an admission session.
+type SeatMatrixResponse struct {
+	SessionID          uuid.UUID       `json:"sessionId"`
+	Classes            []SeatMatrixRow `json:"classes"`
+	TotalSeats         int             `json:"totalSeats"`
+	Allocated          int             `json:"allocated"`
+	Available          int             `json:"available"`
+	ConvertedToGeneral int             `json:"convertedToGeneral"`
+}
+
+// GetSeatMatrix retrieves allocated versus available seats per quota for each class in a session.
+func (s *ReportService) GetSeatMatrix(ctx context.Context, filter DashboardFilter) (*SeatMatrixResponse, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, ErrTenantIDRequired
+	}
+	if filter.SessionID == nil {
+		return nil, ErrSessionIDRequired
+	}
+
+	var seats []models.AdmissionSeat
+	if err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND session_id = ?", filter.TenantID, *filter.SessionID).
+		Order("class_name").
+		Find(&seats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get seat configurations: %w", err)
+	}
+
+	allocations, err := seatAllocations(s.db.WithContext(ctx), filter.TenantID, *filter.SessionID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	type waitlistRow struct {
+		ClassApplying string
+		QuotaCategory string
+		Count         int
+	}
+	var waitlistRows []waitlistRow
+	if err := s.db.WithContext(ctx).
+		Model(&models.AdmissionApplication{}).
+		Select("class_applying, quota_category, COUNT(*) AS count").
+		Where("tenant_id = ? AND session_id = ? AND status = ?", filter.TenantID, *filter.SessionID, models.ApplicationStatusWaitlisted).
+		Group("class_applying, quota_category").
+		Scan(&waitlistRows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count waitlisted applications: %w", err)
+	}
+	waitlisted := make(map[string]map[string]int)
+	for _, r := range waitlistRows {
+		if waitlisted[r.ClassApplying] == nil {
+			waitlisted[r.ClassApplying] = make(map[string]int)
+		}
+		waitlisted[r.ClassApplying][r.QuotaCategory] += r.Count
+	}
+
+	now := time.Now()
+	response := &SeatMatrixResponse{
+		SessionID: *filter.SessionID,
+		Classes:   make([]SeatMatrixRow, 0, len(seats)),
+	}
+	for i := range seats {
+		row := buildSeatMatrixRow(&seats[i], allocations[seats[i].ClassName], waitlisted[seats[i].ClassName], now)
+		response.Classes = append(response.Classes, row)
+		response.TotalSeats += row.TotalSeats
+		response.Allocated += row.Allocated
+		response.Available += row.Available
+		response.ConvertedToGeneral += row.ConvertedToGeneral
+	}
+
+	return response, nil
+}
+
 // roundToDecimal rounds a float to the specified number of decimal places.
 func roundToDecimal(val float64, places int) float64 {
 	pow := 1.0
@@ -0,0 +1,217 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// QuotaSeats represents seat usage for one quota of a class.
+type QuotaSeats struct {
+	Quota string `json:"quota"`
+	// Reserved is the configured reservation; Effective is what remains
+	// reserved once unfilled seats have been released to general.
+	Reserved   int `json:"reserved"`
+	Effective  int `json:"effective"`
+	Allocated  int `json:"allocated"`
+	Available  int `json:"available"`
+	Waitlisted int `json:"waitlisted"`
+}
+
+// SeatMatrixRow represents allocated versus available seats per quota for a class.
+type SeatMatrixRow struct {
+	ClassName          string       `json:"className"`
+	TotalSeats         int          `json:"totalSeats"`
+	FilledSeats        int          `json:"filledSeats"`
+	QuotaReleaseDate   *time.Time   `json:"quotaReleaseDate,omitempty"`
+	QuotasReleased     bool         `json:"quotasReleased"`
+	ConvertedToGeneral int          `json:"convertedToGeneral"`
+	Quotas             []QuotaSeats `json:"quotas"`
+	General            QuotaSeats   `json:"general"`
+	Allocated          int          `json:"allocated"`
+	Available          int          `json:"available"`
+}
+
+// applicationQuota returns the quota an application competes for.
+// Anything other than a reserved quota competes for general seats.
+func applicationQuota(application *models.AdmissionApplication) string {
+	quota := strings.ToLower(strings.TrimSpace(application.QuotaCategory))
+	if models.IsReservedSeatQuota(quota) {
+		return quota
+	}
+	return models.SeatQuotaGeneral
+}
+
+// normalizeQuotaCategory validates a requested quota category, defaulting to general.
+func normalizeQuotaCategory(quota string) (string, error) {
+	quota = strings.ToLower(strings.TrimSpace(quota))
+	if quota == "" {
+		return models.SeatQuotaGeneral, nil
+	}
+	if !models.IsValidSeatQuota(quota) {
+		return "", ErrInvalidQuotaCategory
+	}
+	return quota, nil
+}
+
+// validateReservedSeats checks reserved seats use known quotas and fit within total seats.
+func validateReservedSeats(reserved models.ReservedSeats, totalSeats int) error {
+	sum := 0
+	for quota, count := range reserved {
+		if !models.IsReservedSeatQuota(quota) || count < 0 {
+			return ErrInvalidReservedSeats
+		}
+		sum += count
+	}
+	if sum > totalSeats {
+		return ErrInvalidReservedSeats
+	}
+	return nil
+}
+
+// seatAllocations counts approved and enrolled applications per class and allocated quota.
+// Decisions made before quotas were tracked count as general.
+func seatAllocations(tx *gorm.DB, tenantID, sessionID uuid.UUID, className string) (map[string]map[string]int, error) {
+	type allocationRow struct {
+		ClassApplying string
+		Quota         string
+		Count         int
+	}
+
+	query := tx.Table("admission_decisions d").
+		Select("a.class_applying, COALESCE(d.allocated_quota, ?) AS quota, COUNT(*) AS count", models.SeatQuotaGeneral).
+		Joins("JOIN admission_applications a ON a.id = d.application_id").
+		Where("d.tenant_id = ? AND d.decision = ? AND a.session_id = ?", tenantID, models.DecisionApproved, sessionID).
+		Where("a.status IN ?", []models.ApplicationStatus{models.ApplicationStatusApproved, models.ApplicationStatusEnrolled})
+	if className != "" {
+		query = query.Where("a.class_applying = ?", className)
+	}
+
+	var rows []allocationRow
+	if err := query.Group("a.class_applying, quota").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count seat allocations: %w", err)
+	}
+
+	allocations := make(map[string]map[string]int)
+	for _, r := range rows {
+		if allocations[r.ClassApplying] == nil {
+			allocations[r.ClassApplying] = make(map[string]int)
+		}
+		allocations[r.ClassApplying][r.Quota] += r.Count
+	}
+	return allocations, nil
+}
+
+// buildSeatMatrixRow works out the seats allocated and available per quota for a class.
+// From the quota release date, reserved seats not yet allocated count as general seats.
+func buildSeatMatrixRow(seat *models.AdmissionSeat, allocated, waitlisted map[string]int, now time.Time) SeatMatrixRow {
+	row := SeatMatrixRow{
+		ClassName:        seat.ClassName,
+		TotalSeats:       seat.TotalSeats,
+		FilledSeats:      seat.FilledSeats,
+		QuotaReleaseDate: seat.QuotaReleaseDate,
+		QuotasReleased:   seat.QuotasReleased(now),
+		Quotas:           make([]QuotaSeats, 0, len(models.ReservedSeatQuotas)),
+		General:          QuotaSeats{Quota: models.SeatQuotaGeneral},
+	}
+
+	effectiveReserved := 0
+	for _, quota := range models.ReservedSeatQuotas {
+		reserved, ok := seat.ReservedSeats[quota]
+		if !ok && allocated[quota] == 0 && waitlisted[quota] == 0 {
+			continue
+		}
+		q := QuotaSeats{
+			Quota:      quota,
+			Reserved:   reserved,
+			Effective:  reserved,
+			Allocated:  allocated[quota],
+			Waitlisted: waitlisted[quota],
+		}
+		if row.QuotasReleased && q.Allocated < q.Reserved {
+			q.Effective = q.Allocated
+		}
+		row.ConvertedToGeneral += q.Reserved - q.Effective
+		effectiveReserved += q.Effective
+
+		// Allocations beyond the quota's reservation were taken from the general pool.
+		if q.Allocated > q.Effective {
+			row.General.Allocated += q.Allocated - q.Effective
+		} else {
+			q.Available = q.Effective - q.Allocated
+		}
+		row.Allocated += q.Allocated
+		row.Quotas = append(row.Quotas, q)
+	}
+
+	for quota, count := range allocated {
+		if !models.IsReservedSeatQuota(quota) {
+			row.General.Allocated += count
+			row.Allocated += count
+		}
+	}
+	for quota, count := range waitlisted {
+		if !models.IsReservedSeatQuota(quota) {
+			row.General.Waitlisted += count
+		}
+	}
+
+	row.General.Reserved = max(seat.TotalSeats-seat.TotalReservedSeats(), 0)
+	row.General.Effective = max(seat.TotalSeats-effectiveReserved, 0)
+	row.General.Available = max(row.General.Effective-row.General.Allocated, 0)
+
+	// Seats filled outside the decision workflow still take up capacity.
+	row.Available = max(seat.TotalSeats-max(row.Allocated, seat.FilledSeats), 0)
+	row.General.Available = min(row.General.Available, row.Available)
+	for i := range row.Quotas {
+		row.Quotas[i].Available = min(row.Quotas[i].Available, row.Available)
+	}
+
+	return row
+}
+
+// allocateSeat picks the quota an approved application takes a seat from: its own
+// quota when a reserved seat is free, otherwise the general pool. Classes without a
+// seat configuration are not limited and allocate from general.
+func allocateSeat(tx *gorm.DB, application *models.AdmissionApplication, now time.Time) (string, error) {
+	var seat models.AdmissionSeat
+	err := tx.Where("tenant_id = ? AND session_id = ? AND class_name = ?",
+		application.TenantID, application.SessionID, application.ClassApplying).
+		First(&seat).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.SeatQuotaGeneral, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get seat configuration: %w", err)
+	}
+
+	// Touch the seat row so concurrent allocations for the class queue behind this one.
+	if err := tx.Model(&seat).Update("updated_at", now).Error; err != nil {
+		return "", fmt.Errorf("failed to lock seat configuration: %w", err)
+	}
+
+	allocations, err := seatAllocations(tx, application.TenantID, application.SessionID, application.ClassApplying)
+	if err != nil {
+		return "", err
+	}
+
+	row := buildSeatMatrixRow(&seat, allocations[application.ClassApplying], nil, now)
+	if quota := applicationQuota(application); quota != models.SeatQuotaGeneral {
+		for _, q := range row.Quotas {
+			if q.Quota == quota && q.Available > 0 {
+				return quota, nil
+			}
+		}
+	}
+	if row.General.Available > 0 {
+		return models.SeatQuotaGeneral, nil
+	}
+	return "", ErrNoSeatsAvailable
+}
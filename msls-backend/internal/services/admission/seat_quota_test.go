@@ -0,0 +1,177 @@
+// Package admission provides admission management services.
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// createSeat configures Class 1 seats for the fixture's session.
+func (f *offerFixture) createSeat(t *testing.T, total int, reserved models.ReservedSeats, releaseDate *time.Time) {
+	t.Helper()
+
+	seat := &models.AdmissionSeat{
+		ID:               uuid.New(),
+		TenantID:         f.tenantID,
+		SessionID:        f.sessionID,
+		ClassName:        "Class 1",
+		TotalSeats:       total,
+		ReservedSeats:    reserved,
+		QuotaReleaseDate: releaseDate,
+	}
+	require.NoError(t, f.db.Create(seat).Error)
+}
+
+// newQuotaApplication creates a submitted Class 1 application for a quota without a decision.
+func (f *offerFixture) newQuotaApplication(t *testing.T, name, quota string) uuid.UUID {
+	t.Helper()
+
+	application := &models.AdmissionApplication{
+		ID:                uuid.New(),
+		TenantID:          f.tenantID,
+		SessionID:         f.sessionID,
+		ApplicationNumber: "APP-" + name,
+		StudentName:       name,
+		ClassApplying:     "Class 1",
+		QuotaCategory:     quota,
+		Status:            models.ApplicationStatusSubmitted,
+	}
+	require.NoError(t, f.db.Create(application).Error)
+	return application.ID
+}
+
+func (f *offerFixture) decide(applicationID uuid.UUID, decision models.DecisionType, waitlistPosition *int) (*models.AdmissionDecision, error) {
+	return f.service.CreateDecision(context.Background(), CreateDecisionRequest{
+		TenantID:         f.tenantID,
+		ApplicationID:    applicationID,
+		Decision:         decision,
+		DecisionDate:     time.Now(),
+		WaitlistPosition: waitlistPosition,
+	})
+}
+
+func TestDecisionService_SeatQuotas(t *testing.T) {
+	f := setupOffers(t)
+	f.createSeat(t, 3, models.ReservedSeats{models.SeatQuotaRTE: 1}, nil)
+
+	// General applicants fill the two unreserved seats only
+	for _, name := range []string{"Asha", "Meera"} {
+		decision, err := f.decide(f.newQuotaApplication(t, name, models.SeatQuotaGeneral), models.DecisionApproved, nil)
+		require.NoError(t, err)
+		require.NotNil(t, decision.AllocatedQuota)
+		assert.Equal(t, models.SeatQuotaGeneral, *decision.AllocatedQuota)
+	}
+	_, err := f.decide(f.newQuotaApplication(t, "Ravi", models.SeatQuotaGeneral), models.DecisionApproved, nil)
+	assert.ErrorIs(t, err, ErrNoSeatsAvailable)
+
+	// The reserved seat is still open to an RTE applicant
+	decision, err := f.decide(f.newQuotaApplication(t, "Kiran", models.SeatQuotaRTE), models.DecisionApproved, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.SeatQuotaRTE, *decision.AllocatedQuota)
+
+	_, err = f.decide(f.newQuotaApplication(t, "Dev", models.SeatQuotaRTE), models.DecisionApproved, nil)
+	assert.ErrorIs(t, err, ErrNoSeatsAvailable)
+
+	// Waitlisting and rejections do not take seats
+	position := 1
+	_, err = f.decide(f.newQuotaApplication(t, "Sara", models.SeatQuotaSibling), models.DecisionWaitlisted, &position)
+	assert.NoError(t, err)
+}
+
+func TestDecisionService_SeatQuotasFallBackToGeneral(t *testing.T) {
+	f := setupOffers(t)
+	f.createSeat(t, 2, models.ReservedSeats{models.SeatQuotaStaffWard: 1}, nil)
+
+	decision, err := f.decide(f.newQuotaApplication(t, "Asha", models.SeatQuotaStaffWard), models.DecisionApproved, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.SeatQuotaStaffWard, *decision.AllocatedQuota)
+
+	// Once the quota is full a staff ward takes a general seat
+	decision, err = f.decide(f.newQuotaApplication(t, "Meera", models.SeatQuotaStaffWard), models.DecisionApproved, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.SeatQuotaGeneral, *decision.AllocatedQuota)
+}
+
+func TestDecisionService_SeatQuotaRelease(t *testing.T) {
+	f := setupOffers(t)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	f.createSeat(t, 2, models.ReservedSeats{models.SeatQuotaRTE: 1}, &yesterday)
+
+	// After the release date the unfilled RTE seat is available to general applicants
+	for _, name := range []string{"Asha", "Meera"} {
+		decision, err := f.decide(f.newQuotaApplication(t, name, models.SeatQuotaGeneral), models.DecisionApproved, nil)
+		require.NoError(t, err)
+		assert.Equal(t, models.SeatQuotaGeneral, *decision.AllocatedQuota)
+	}
+
+	matrix, err := NewReportService(f.db).GetSeatMatrix(context.Background(), DashboardFilter{TenantID: f.tenantID, SessionID: &f.sessionID})
+	require.NoError(t, err)
+	require.Len(t, matrix.Classes, 1)
+	row := matrix.Classes[0]
+	assert.True(t, row.QuotasReleased)
+	assert.Equal(t, 1, row.ConvertedToGeneral)
+	assert.Equal(t, 2, row.General.Allocated)
+	assert.Zero(t, row.Available)
+	require.Len(t, row.Quotas, 1)
+	assert.Equal(t, 1, row.Quotas[0].Reserved)
+	assert.Zero(t, row.Quotas[0].Effective)
+}
+
+func TestDecisionService_LapsePromotesWithinQuota(t *testing.T) {
+	f := setupOffers(t)
+	ctx := context.Background()
+	f.createSeat(t, 2, models.ReservedSeats{models.SeatQuotaRTE: 1}, nil)
+
+	rteID := f.newQuotaApplication(t, "Asha", models.SeatQuotaRTE)
+	_, err := f.decide(rteID, models.DecisionApproved, nil)
+	require.NoError(t, err)
+	_, err = f.decide(f.newQuotaApplication(t, "Meera", models.SeatQuotaGeneral), models.DecisionApproved, nil)
+	require.NoError(t, err)
+
+	first, second := 1, 2
+	generalID := f.newQuotaApplication(t, "Ravi", models.SeatQuotaGeneral)
+	_, err = f.decide(generalID, models.DecisionWaitlisted, &first)
+	require.NoError(t, err)
+	rteWaitingID := f.newQuotaApplication(t, "Kiran", models.SeatQuotaRTE)
+	_, err = f.decide(rteWaitingID, models.DecisionWaitlisted, &second)
+	require.NoError(t, err)
+
+	_, _, err = f.service.GenerateOfferLetter(ctx, GenerateOfferLetterRequest{TenantID: f.tenantID, ApplicationID: rteID})
+	require.NoError(t, err)
+	require.NoError(t, f.db.Model(&models.AdmissionDecision{}).
+		Where("application_id = ?", rteID).
+		Update("offer_valid_until", time.Now().AddDate(0, 0, -2)).Error)
+
+	lapsed, err := f.service.LapseExpiredOffers(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, lapsed)
+
+	// The freed RTE seat skips the general applicant at the top of the waitlist
+	skipped, err := f.service.GetDecisionByApplication(ctx, f.tenantID, generalID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DecisionWaitlisted, skipped.Decision)
+
+	promoted, err := f.service.GetDecisionByApplication(ctx, f.tenantID, rteWaitingID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DecisionApproved, promoted.Decision)
+	require.NotNil(t, promoted.AllocatedQuota)
+	assert.Equal(t, models.SeatQuotaRTE, *promoted.AllocatedQuota)
+
+	_, err = f.service.PromoteFromWaitlist(ctx, f.tenantID, generalID, nil, nil)
+	assert.ErrorIs(t, err, ErrNoSeatsAvailable)
+}
+
+func TestValidateReservedSeats(t *testing.T) {
+	assert.NoError(t, validateReservedSeats(models.ReservedSeats{models.SeatQuotaRTE: 5, models.SeatQuotaSibling: 2}, 40))
+	assert.NoError(t, validateReservedSeats(nil, 0))
+	assert.ErrorIs(t, validateReservedSeats(models.ReservedSeats{"sports": 2}, 40), ErrInvalidReservedSeats)
+	assert.ErrorIs(t, validateReservedSeats(models.ReservedSeats{models.SeatQuotaRTE: -1}, 40), ErrInvalidReservedSeats)
+	assert.ErrorIs(t, validateReservedSeats(models.ReservedSeats{models.SeatQuotaRTE: 30, models.SeatQuotaManagement: 15}, 40), ErrInvalidReservedSeats)
+}
@@ -407,19 +407,22 @@ func isValidStatusTransition(from, to models.AdmissionSessionStatus) bool {
 
 // CreateSeatRequest represents a request to create a seat configuration.
 type CreateSeatRequest struct {
-	TenantID      uuid.UUID
-	SessionID     uuid.UUID
-	ClassName     string
-	TotalSeats    int
-	WaitlistLimit int
-	ReservedSeats models.ReservedSeats
+	TenantID         uuid.UUID
+	SessionID        uuid.UUID
+	ClassName        string
+	TotalSeats       int
+	WaitlistLimit    int
+	ReservedSeats    models.ReservedSeats
+	QuotaReleaseDate *time.Time
 }
 
 // UpdateSeatRequest represents a request to update a seat configuration.
 type UpdateSeatRequest struct {
-	TotalSeats    *int
-	WaitlistLimit *int
-	ReservedSeats *models.ReservedSeats
+	TotalSeats            *int
+	WaitlistLimit         *int
+	ReservedSeats         *models.ReservedSeats
+	QuotaReleaseDate      *time.Time
+	ClearQuotaReleaseDate bool
 }
 
 // CreateSeat creates a new seat configuration for a session.
@@ -436,6 +439,9 @@ func (s *SessionService) CreateSeat(ctx context.Context, req CreateSeatRequest)
 	if req.TotalSeats < 0 {
 		return nil, ErrInvalidTotalSeats
 	}
+	if err := validateReservedSeats(req.ReservedSeats, req.TotalSeats); err != nil {
+		return nil, err
+	}
 
 	// Verify session exists and belongs to tenant
 	session, err := s.GetByID(ctx, req.TenantID, req.SessionID, false)
@@ -472,13 +478,14 @@ func (s *SessionService) CreateSeat(ctx context.Context, req CreateSeatRequest)
 	}
 
 	seat := &models.AdmissionSeat{
-		TenantID:      req.TenantID,
-		SessionID:     req.SessionID,
-		ClassName:     req.ClassName,
-		TotalSeats:    req.TotalSeats,
-		FilledSeats:   0,
-		WaitlistLimit: waitlistLimit,
-		ReservedSeats: reservedSeats,
+		TenantID:         req.TenantID,
+		SessionID:        req.SessionID,
+		ClassName:        req.ClassName,
+		TotalSeats:       req.TotalSeats,
+		FilledSeats:      0,
+		WaitlistLimit:    waitlistLimit,
+		ReservedSeats:    reservedSeats,
+		QuotaReleaseDate: req.QuotaReleaseDate,
 	}
 
 	if err := s.db.WithContext(ctx).Create(seat).Error; err != nil {
@@ -555,10 +562,30 @@ func (s *SessionService) UpdateSeat(ctx context.Context, tenantID, seatID uuid.U
 		updates["waitlist_limit"] = *req.WaitlistLimit
 	}
 
+	if req.ReservedSeats != nil || req.TotalSeats != nil {
+		totalSeats := seat.TotalSeats
+		if req.TotalSeats != nil {
+			totalSeats = *req.TotalSeats
+		}
+		reservedSeats := seat.ReservedSeats
+		if req.ReservedSeats != nil {
+			reservedSeats = *req.ReservedSeats
+		}
+		if err := validateReservedSeats(reservedSeats, totalSeats); err != nil {
+			return nil, err
+		}
+	}
+
 	if req.ReservedSeats != nil {
 		updates["reserved_seats"] = *req.ReservedSeats
 	}
 
+	if req.ClearQuotaReleaseDate {
+		updates["quota_release_date"] = nil
+	} else if req.QuotaReleaseDate != nil {
+		updates["quota_release_date"] = *req.QuotaReleaseDate
+	}
+
 	if len(updates) > 0 {
 		if err := s.db.WithContext(ctx).Model(seat).Updates(updates).Error; err != nil {
 			return nil, fmt.Errorf("failed to update seat configuration: %w", err)
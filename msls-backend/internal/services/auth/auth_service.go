@@ -67,6 +67,7 @@ type AuthService struct {
 	jwtService      *JWTService
 	passwordService *PasswordService
 	totpService     *TOTPService
+	sessions        *SessionService
 }
 
 // NewAuthService creates a new AuthService instance.
@@ -76,6 +77,7 @@ func NewAuthService(db *gorm.DB, jwtService *JWTService) *AuthService {
 		jwtService:      jwtService,
 		passwordService: NewPasswordService(),
 		totpService:     nil, // Set via SetTOTPService
+		sessions:        NewSessionService(db),
 	}
 }
 
@@ -84,6 +86,11 @@ func (s *AuthService) SetTOTPService(totpService *TOTPService) {
 	s.totpService = totpService
 }
 
+// Sessions returns the session service.
+func (s *AuthService) Sessions() *SessionService {
+	return s.sessions
+}
+
 // GetJWTService returns the JWT service.
 func (s *AuthService) GetJWTService() *JWTService {
 	return s.jwtService
@@ -216,6 +223,10 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*LoginResult
 			tx.Exec("SET LOCAL app.bypass_rls = 'true'")
 			return tx.Save(&user).Error
 		})
+		// A locked account loses all of its sessions
+		if user.IsLocked() {
+			_, _ = s.sessions.RevokeAll(ctx, user.ID, models.SessionRevokeReasonAccountLocked, nil)
+		}
 		s.recordLoginAttempt(ctx, &user.ID, req.Email, req.IPAddress, req.UserAgent, false, models.LoginFailureInvalidCredentials)
 		return nil, ErrInvalidCredentials
 	}
@@ -249,8 +260,12 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*LoginResult
 		return tx.Save(&user).Error
 	})
 
-	// Generate token pair
-	tokenPair, err := s.generateTokenPair(ctx, &user)
+	// Start a session and generate token pair
+	session, err := s.sessions.Start(ctx, &user, req.IPAddress, req.UserAgent, s.jwtService.GetRefreshTTL())
+	if err != nil {
+		return nil, err
+	}
+	tokenPair, err := s.generateTokenPair(ctx, &user, session)
 	if err != nil {
 		return nil, err
 	}
@@ -323,8 +338,12 @@ func (s *AuthService) ValidateTwoFactorLogin(ctx context.Context, partialToken,
 	user.RecordLogin()
 	s.db.WithContext(ctx).Save(user)
 
-	// Generate full token pair
-	tokenPair, err := s.generateTokenPair(ctx, user)
+	// Start a session and generate full token pair
+	session, err := s.sessions.Start(ctx, user, ipAddress, userAgent, s.jwtService.GetRefreshTTL())
+	if err != nil {
+		return nil, nil, err
+	}
+	tokenPair, err := s.generateTokenPair(ctx, user, session)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -340,6 +359,45 @@ func (s *AuthService) ValidateTwoFactorLogin(ctx context.Context, partialToken,
 	return tokenPair, user, nil
 }
 
+// CompleteExternalLogin logs in a user already authenticated by an external
+// identity provider. Local 2FA is skipped; the identity provider owns MFA.
+// The user must be loaded with Roles.Permissions.
+func (s *AuthService) CompleteExternalLogin(ctx context.Context, user *models.User, ipAddress net.IP, userAgent string) (*TokenPair, error) {
+	email := ""
+	if user.Email != nil {
+		email = *user.Email
+	}
+
+	if user.IsLocked() {
+		s.recordLoginAttempt(ctx, &user.ID, email, ipAddress, userAgent, false, models.LoginFailureAccountLocked)
+		return nil, ErrAccountLocked
+	}
+	if !user.IsActive() {
+		s.recordLoginAttempt(ctx, &user.ID, email, ipAddress, userAgent, false, models.LoginFailureAccountInactive)
+		return nil, ErrAccountInactive
+	}
+
+	user.RecordLogin()
+	_ = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Save(user).Error
+	})
+
+	session, err := s.sessions.Start(ctx, user, ipAddress, userAgent, s.jwtService.GetRefreshTTL())
+	if err != nil {
+		return nil, err
+	}
+	tokenPair, err := s.generateTokenPair(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordLoginAttempt(ctx, &user.ID, email, ipAddress, userAgent, true, "")
+	s.createAuditLog(ctx, user, models.AuditActionSSOLogin, ipAddress, userAgent)
+
+	return tokenPair, nil
+}
+
 // RefreshToken refreshes an access token using a refresh token.
 func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string, ipAddress net.IP, userAgent string) (*TokenPair, error) {
 	// Hash the refresh token
@@ -371,6 +429,27 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string, ipA
 		return nil, ErrRefreshTokenExpired
 	}
 
+	// Resolve the session; tokens issued before sessions existed start a new one
+	var session *models.UserSession
+	if storedToken.SessionID != nil {
+		session, err = s.sessions.Get(ctx, *storedToken.SessionID)
+		if err != nil && !errors.Is(err, ErrSessionNotFound) {
+			return nil, err
+		}
+		if session == nil || !session.IsActive() {
+			s.createTokenRefreshAuditLog(ctx, storedToken.User, models.AuditActionTokenRefreshFailed, ipAddress, userAgent, "session_revoked")
+			return nil, ErrRefreshTokenRevoked
+		}
+		if err := s.sessions.Touch(ctx, session, ipAddress, s.jwtService.GetRefreshTTL()); err != nil {
+			return nil, err
+		}
+	} else {
+		session, err = s.sessions.Start(ctx, storedToken.User, ipAddress, userAgent, s.jwtService.GetRefreshTTL())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Revoke the old refresh token (rotation)
 	storedToken.Revoke()
 	s.db.WithContext(ctx).Save(&storedToken)
@@ -379,7 +458,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string, ipA
 	s.createTokenRefreshAuditLog(ctx, storedToken.User, models.AuditActionTokenRevoked, ipAddress, userAgent, "rotation")
 
 	// Generate new token pair
-	tokenPair, err := s.generateTokenPair(ctx, storedToken.User)
+	tokenPair, err := s.generateTokenPair(ctx, storedToken.User, session)
 	if err != nil {
 		return nil, err
 	}
@@ -428,6 +507,14 @@ func (s *AuthService) Logout(ctx context.Context, refreshToken string, user *mod
 		return err
 	}
 
+	// End the session the token belongs to
+	if storedToken.SessionID != nil {
+		err := s.sessions.Revoke(ctx, storedToken.UserID, *storedToken.SessionID, models.SessionRevokeReasonLogout)
+		if err != nil && !errors.Is(err, ErrSessionNotFound) {
+			return err
+		}
+	}
+
 	// Create audit log
 	if user != nil {
 		s.createAuditLog(ctx, user, models.AuditActionLogout, ipAddress, userAgent)
@@ -568,10 +655,10 @@ func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword stri
 		return err
 	}
 
-	// Revoke all existing refresh tokens for this user
-	s.db.WithContext(ctx).Model(&models.RefreshToken{}).
-		Where("user_id = ? AND revoked_at IS NULL", verificationToken.User.ID).
-		Update("revoked_at", time.Now())
+	// Revoke all existing sessions, refresh tokens and access tokens for this user
+	if _, err := s.sessions.RevokeAll(ctx, verificationToken.User.ID, models.SessionRevokeReasonPasswordReset, nil); err != nil {
+		return err
+	}
 
 	// Create audit log
 	s.createAuditLog(ctx, verificationToken.User, models.AuditActionPasswordReset, ipAddress, userAgent)
@@ -618,8 +705,8 @@ func (s *AuthService) GetUserByID(ctx context.Context, userID uuid.UUID) (*model
 	return &user, nil
 }
 
-// generateTokenPair generates a new access and refresh token pair.
-func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User) (*TokenPair, error) {
+// generateTokenPair generates a new access and refresh token pair for a session.
+func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User, session *models.UserSession) (*TokenPair, error) {
 	// Get user permissions
 	permissions := user.GetPermissions()
 
@@ -630,7 +717,7 @@ func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User)
 	}
 
 	// Generate access token
-	accessToken, expiresAt, err := s.jwtService.GenerateAccessToken(user.ID, user.TenantID, email, permissions)
+	accessToken, expiresAt, err := s.jwtService.GenerateSessionAccessToken(user.ID, user.TenantID, email, permissions, session.ID, user.TokenVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -646,6 +733,7 @@ func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User)
 		UserID:    user.ID,
 		TokenHash: s.jwtService.HashRefreshToken(refreshToken),
 		ExpiresAt: refreshExpiresAt,
+		SessionID: &session.ID,
 	}
 	if err := s.db.WithContext(ctx).Create(storedToken).Error; err != nil {
 		return nil, err
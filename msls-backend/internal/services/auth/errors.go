@@ -20,6 +20,7 @@ var (
 	ErrVerificationTokenExpired  = errors.New("verification token has expired")
 	ErrEmailAlreadyExists        = errors.New("email already exists")
 	ErrRoleNotFound              = errors.New("role not found")
+	ErrSessionNotFound           = errors.New("session not found")
 )
 
 // Password validation errors.
@@ -40,6 +41,16 @@ var (
 	ErrExpiredToken     = errors.New("token has expired")
 	ErrInvalidClaims    = errors.New("invalid token claims")
 	ErrTokenNotYetValid = errors.New("token is not yet valid")
+	ErrTokenRevoked     = errors.New("token has been revoked")
+	ErrSessionRevoked   = errors.New("session has been revoked")
+)
+
+// Signing key errors.
+var (
+	ErrNoSigningKey            = errors.New("no active signing key in key ring")
+	ErrKeyNotFound             = errors.New("signing key not found")
+	ErrInvalidKeyMaterial      = errors.New("invalid signing key material")
+	ErrUnsupportedKeyAlgorithm = errors.New("unsupported signing key algorithm")
 )
 
 // OTP-related errors.
@@ -2,6 +2,7 @@
 package auth
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
@@ -21,14 +22,28 @@ type Claims struct {
 	TenantID    uuid.UUID `json:"tenant_id"`
 	Email       string    `json:"email,omitempty"`
 	Permissions []string  `json:"permissions,omitempty"`
+	// SessionID identifies the login session the token belongs to.
+	SessionID uuid.UUID `json:"sid,omitempty"`
+	// TokenVersion must match the user's current token version for the token to be accepted.
+	TokenVersion int `json:"tv,omitempty"`
+}
+
+// RevocationChecker decides whether a validly signed access token has been revoked.
+type RevocationChecker interface {
+	CheckAccessToken(ctx context.Context, claims *Claims) error
 }
 
 // JWTService handles JWT token generation and validation.
+// Tokens are signed with HS256 using the shared secret unless a key ring is
+// loaded with UseKeyRing, in which case they are signed with the ring's active
+// RS256/EdDSA key and carry its "kid" header.
 type JWTService struct {
 	secret     []byte
 	issuer     string
 	accessTTL  time.Duration
 	refreshTTL time.Duration
+	keys       *keyRingSource
+	revocation RevocationChecker
 }
 
 // JWTConfig holds JWT service configuration.
@@ -49,8 +64,41 @@ func NewJWTService(config JWTConfig) *JWTService {
 	}
 }
 
+// UseKeyRing loads the key ring file at path and switches to asymmetric signing.
+// HS256 tokens are rejected from then on. The file is re-read when it changes.
+func (s *JWTService) UseKeyRing(path string) error {
+	source, err := newKeyRingSource(path)
+	if err != nil {
+		return err
+	}
+	if _, err := source.current().signingKey(time.Now()); err != nil {
+		return err
+	}
+	s.keys = source
+	return nil
+}
+
+// JWKS returns the public keys used to verify access tokens.
+// The set is empty when tokens are signed with the shared secret.
+func (s *JWTService) JWKS() JWKS {
+	if s.keys == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return s.keys.current().JWKS(time.Now())
+}
+
+// SetRevocationChecker sets the checker consulted by ValidateAccessTokenContext.
+func (s *JWTService) SetRevocationChecker(checker RevocationChecker) {
+	s.revocation = checker
+}
+
 // GenerateAccessToken generates a new JWT access token for a user.
 func (s *JWTService) GenerateAccessToken(userID, tenantID uuid.UUID, email string, permissions []string) (string, time.Time, error) {
+	return s.GenerateSessionAccessToken(userID, tenantID, email, permissions, uuid.Nil, 0)
+}
+
+// GenerateSessionAccessToken generates an access token bound to a login session and token version.
+func (s *JWTService) GenerateSessionAccessToken(userID, tenantID uuid.UUID, email string, permissions []string, sessionID uuid.UUID, tokenVersion int) (string, time.Time, error) {
 	now := time.Now()
 	expiresAt := now.Add(s.accessTTL)
 
@@ -63,14 +111,15 @@ func (s *JWTService) GenerateAccessToken(userID, tenantID uuid.UUID, email strin
 			NotBefore: jwt.NewNumericDate(now),
 			ID:        uuid.New().String(),
 		},
-		UserID:      userID,
-		TenantID:    tenantID,
-		Email:       email,
-		Permissions: permissions,
+		UserID:       userID,
+		TenantID:     tenantID,
+		Email:        email,
+		Permissions:  permissions,
+		SessionID:    sessionID,
+		TokenVersion: tokenVersion,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.secret)
+	tokenString, err := s.sign(claims, now)
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -78,6 +127,42 @@ func (s *JWTService) GenerateAccessToken(userID, tenantID uuid.UUID, email strin
 	return tokenString, expiresAt, nil
 }
 
+// sign signs claims with the active key ring key, or the shared secret.
+func (s *JWTService) sign(claims jwt.Claims, now time.Time) (string, error) {
+	if s.keys == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	}
+
+	key, err := s.keys.current().signingKey(now)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.record.KID
+	return token.SignedString(key.private)
+}
+
+// verificationKey resolves the key that verifies token.
+func (s *JWTService) verificationKey(token *jwt.Token) (interface{}, error) {
+	if s.keys == nil {
+		// Verify signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys.current().verificationKey(kid, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.private.Public(), nil
+}
+
 // GenerateRefreshToken generates a cryptographically secure refresh token.
 func (s *JWTService) GenerateRefreshToken() (string, time.Time, error) {
 	ps := NewPasswordService()
@@ -91,13 +176,7 @@ func (s *JWTService) GenerateRefreshToken() (string, time.Time, error) {
 
 // ValidateAccessToken validates an access token and returns its claims.
 func (s *JWTService) ValidateAccessToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.verificationKey)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -117,6 +196,21 @@ func (s *JWTService) ValidateAccessToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// ValidateAccessTokenContext validates an access token and, when a revocation
+// checker is set, rejects tokens whose session or token version was revoked.
+func (s *JWTService) ValidateAccessTokenContext(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := s.ValidateAccessToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if s.revocation != nil {
+		if err := s.revocation.CheckAccessToken(ctx, claims); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
 // HashRefreshToken hashes a refresh token using SHA-256.
 func (s *JWTService) HashRefreshToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
@@ -0,0 +1,405 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported asymmetric signing algorithms.
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// rsaKeyBits is the modulus size of generated RSA keys.
+const rsaKeyBits = 2048
+
+// keyRingReloadInterval is how often the key ring file is checked for changes.
+const keyRingReloadInterval = 30 * time.Second
+
+// KeyRecord is a signing key as stored in the key ring file.
+//
+// A key is published in the JWKS as soon as it is added, signs tokens from
+// ActiveFrom until it is retired (the newest active key wins), and keeps
+// verifying tokens until ExpiresAt so that tokens issued before rotation stay valid.
+type KeyRecord struct {
+	KID        string     `json:"kid"`
+	Algorithm  string     `json:"alg"`
+	PrivateKey string     `json:"private_key"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ActiveFrom time.Time  `json:"active_from"`
+	RetiredAt  *time.Time `json:"retired_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// canSign reports whether the key may sign tokens at t.
+func (r *KeyRecord) canSign(t time.Time) bool {
+	if t.Before(r.ActiveFrom) {
+		return false
+	}
+	if r.RetiredAt != nil && !t.Before(*r.RetiredAt) {
+		return false
+	}
+	return r.canVerify(t)
+}
+
+// canVerify reports whether tokens signed with the key are accepted at t.
+func (r *KeyRecord) canVerify(t time.Time) bool {
+	return r.ExpiresAt == nil || t.Before(*r.ExpiresAt)
+}
+
+// keyRingFile is the on-disk format of the key ring.
+type keyRingFile struct {
+	Keys []KeyRecord `json:"keys"`
+}
+
+// ringKey is a parsed key ring entry.
+type ringKey struct {
+	record  KeyRecord
+	private crypto.Signer
+	method  jwt.SigningMethod
+}
+
+// KeyRing holds the asymmetric keys used to sign and verify access tokens.
+type KeyRing struct {
+	keys []*ringKey
+}
+
+// NewKeyRing creates an empty key ring.
+func NewKeyRing() *KeyRing {
+	return &KeyRing{}
+}
+
+// LoadKeyRing reads a key ring from a JSON file.
+func LoadKeyRing(path string) (*KeyRing, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key ring: %w", err)
+	}
+
+	var file keyRingFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse key ring: %w", err)
+	}
+
+	ring := NewKeyRing()
+	for _, record := range file.Keys {
+		key, err := parseKeyRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", record.KID, err)
+		}
+		ring.keys = append(ring.keys, key)
+	}
+	return ring, nil
+}
+
+// Save writes the key ring to a JSON file readable only by its owner.
+// The file is replaced atomically so running servers never read a partial ring.
+func (k *KeyRing) Save(path string) error {
+	file := keyRingFile{Keys: k.Records()}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode key ring: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".keyring-*")
+	if err != nil {
+		return fmt.Errorf("failed to write key ring: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write key ring: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write key ring: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write key ring: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write key ring: %w", err)
+	}
+	return nil
+}
+
+// Records returns the key records in the ring.
+func (k *KeyRing) Records() []KeyRecord {
+	records := make([]KeyRecord, 0, len(k.keys))
+	for _, key := range k.keys {
+		records = append(records, key.record)
+	}
+	return records
+}
+
+// Generate creates a new key that starts signing at activeFrom and adds it to the ring.
+func (k *KeyRing) Generate(alg string, activeFrom time.Time) (*KeyRecord, error) {
+	var (
+		signer crypto.Signer
+		err    error
+	)
+	switch alg {
+	case AlgRS256:
+		signer, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyAlgorithm, alg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	kid, err := newKeyID()
+	if err != nil {
+		return nil, err
+	}
+
+	record := KeyRecord{
+		KID:        kid,
+		Algorithm:  alg,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		CreatedAt:  time.Now().UTC(),
+		ActiveFrom: activeFrom.UTC(),
+	}
+	key, err := parseKeyRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	k.keys = append(k.keys, key)
+
+	return &key.record, nil
+}
+
+// Retire stops a key from signing at retireAt and keeps it verifying for overlap afterwards.
+func (k *KeyRing) Retire(kid string, retireAt time.Time, overlap time.Duration) error {
+	key := k.find(kid)
+	if key == nil {
+		return ErrKeyNotFound
+	}
+	retired := retireAt.UTC()
+	expires := retired.Add(overlap)
+	key.record.RetiredAt = &retired
+	key.record.ExpiresAt = &expires
+	return nil
+}
+
+// Prune removes keys that no longer verify tokens and returns how many were removed.
+func (k *KeyRing) Prune(now time.Time) int {
+	kept := k.keys[:0]
+	removed := 0
+	for _, key := range k.keys {
+		if key.record.canVerify(now) {
+			kept = append(kept, key)
+			continue
+		}
+		removed++
+	}
+	k.keys = kept
+	return removed
+}
+
+// signingKey returns the newest key allowed to sign at now.
+func (k *KeyRing) signingKey(now time.Time) (*ringKey, error) {
+	var current *ringKey
+	for _, key := range k.keys {
+		if !key.record.canSign(now) {
+			continue
+		}
+		if current == nil || key.record.ActiveFrom.After(current.record.ActiveFrom) {
+			current = key
+		}
+	}
+	if current == nil {
+		return nil, ErrNoSigningKey
+	}
+	return current, nil
+}
+
+// verificationKey returns the key identified by kid if it still verifies tokens at now.
+func (k *KeyRing) verificationKey(kid string, now time.Time) (*ringKey, bool) {
+	key := k.find(kid)
+	if key == nil || !key.record.canVerify(now) {
+		return nil, false
+	}
+	return key, true
+}
+
+// find returns the key identified by kid, or nil.
+func (k *KeyRing) find(kid string) *ringKey {
+	for _, key := range k.keys {
+		if key.record.KID == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that verify tokens at now, including keys
+// published ahead of activation, ordered by activation time.
+func (k *KeyRing) JWKS(now time.Time) JWKS {
+	keys := make([]*ringKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		if key.record.canVerify(now) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].record.ActiveFrom.Before(keys[j].record.ActiveFrom)
+	})
+
+	set := JWKS{Keys: make([]JWK, 0, len(keys))}
+	for _, key := range keys {
+		set.Keys = append(set.Keys, publicJWK(key))
+	}
+	return set
+}
+
+// publicJWK encodes the public half of a key.
+func publicJWK(key *ringKey) JWK {
+	jwk := JWK{Kid: key.record.KID, Use: "sig", Alg: key.record.Algorithm}
+	switch pub := key.private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return jwk
+}
+
+// parseKeyRecord decodes the private key of a record and checks it matches the algorithm.
+func parseKeyRecord(record KeyRecord) (*ringKey, error) {
+	block, _ := pem.Decode([]byte(record.PrivateKey))
+	if block == nil {
+		return nil, ErrInvalidKeyMaterial
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyMaterial, err)
+	}
+
+	key := &ringKey{record: record}
+	switch record.Algorithm {
+	case AlgRS256:
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, ErrInvalidKeyMaterial
+		}
+		key.private = rsaKey
+		key.method = jwt.SigningMethodRS256
+	case AlgEdDSA:
+		edKey, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, ErrInvalidKeyMaterial
+		}
+		key.private = edKey
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyAlgorithm, record.Algorithm)
+	}
+	return key, nil
+}
+
+// newKeyID returns a key ID of the form YYYYMMDD-<random hex>.
+func newKeyID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate key ID: %w", err)
+	}
+	return time.Now().UTC().Format("20060102") + "-" + hex.EncodeToString(b), nil
+}
+
+// keyRingSource serves a key ring from a file and reloads it when the file changes,
+// so keys rotated with the jwtkeys tool take effect without a restart.
+type keyRingSource struct {
+	path string
+
+	mu        sync.Mutex
+	ring      *KeyRing
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// newKeyRingSource loads the key ring at path.
+func newKeyRingSource(path string) (*keyRingSource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key ring: %w", err)
+	}
+	ring, err := LoadKeyRing(path)
+	if err != nil {
+		return nil, err
+	}
+	return &keyRingSource{
+		path:      path,
+		ring:      ring,
+		modTime:   info.ModTime(),
+		checkedAt: time.Now(),
+	}, nil
+}
+
+// current returns the latest key ring. A ring that fails to load is ignored
+// and the previous one stays in use.
+func (s *keyRingSource) current() *KeyRing {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.checkedAt) < keyRingReloadInterval {
+		return s.ring
+	}
+	s.checkedAt = time.Now()
+
+	info, err := os.Stat(s.path)
+	if err != nil || info.ModTime().Equal(s.modTime) {
+		return s.ring
+	}
+	if ring, err := LoadKeyRing(s.path); err == nil {
+		s.ring = ring
+		s.modTime = info.ModTime()
+	}
+	return s.ring
+}
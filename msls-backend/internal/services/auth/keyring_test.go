@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeyRingJWTService(t *testing.T, ring *KeyRing) (*JWTService, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, ring.Save(path))
+
+	svc := NewJWTService(JWTConfig{
+		Secret:     "test-secret",
+		Issuer:     "msls-test",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: time.Hour,
+	})
+	require.NoError(t, svc.UseKeyRing(path))
+	return svc, path
+}
+
+func tokenHeader(t *testing.T, tokenString string) map[string]interface{} {
+	t.Helper()
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+	require.NoError(t, err)
+	return token.Header
+}
+
+func TestJWTService_KeyRingSignsWithKid(t *testing.T) {
+	for _, alg := range []string{AlgRS256, AlgEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			ring := NewKeyRing()
+			record, err := ring.Generate(alg, time.Now().Add(-time.Minute))
+			require.NoError(t, err)
+			svc, _ := newKeyRingJWTService(t, ring)
+
+			userID, tenantID := uuid.New(), uuid.New()
+			tokenString, _, err := svc.GenerateAccessToken(userID, tenantID, "user@example.com", []string{"students:read"})
+			require.NoError(t, err)
+
+			header := tokenHeader(t, tokenString)
+			assert.Equal(t, record.KID, header["kid"])
+			assert.Equal(t, alg, header["alg"])
+
+			claims, err := svc.ValidateAccessToken(tokenString)
+			require.NoError(t, err)
+			assert.Equal(t, userID, claims.UserID)
+			assert.Equal(t, tenantID, claims.TenantID)
+		})
+	}
+}
+
+func TestJWTService_KeyRingRejectsHS256(t *testing.T) {
+	hmacSvc := NewJWTService(JWTConfig{Secret: "test-secret", AccessTTL: time.Minute})
+	tokenString, _, err := hmacSvc.GenerateAccessToken(uuid.New(), uuid.New(), "", nil)
+	require.NoError(t, err)
+
+	ring := NewKeyRing()
+	_, err = ring.Generate(AlgEdDSA, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	svc, _ := newKeyRingJWTService(t, ring)
+
+	_, err = svc.ValidateAccessToken(tokenString)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestKeyRing_RotationOverlap(t *testing.T) {
+	now := time.Now()
+	ring := NewKeyRing()
+	oldKey, err := ring.Generate(AlgRS256, now.Add(-time.Hour))
+	require.NoError(t, err)
+	newKey, err := ring.Generate(AlgEdDSA, now.Add(time.Hour))
+	require.NoError(t, err)
+
+	// Pending key is published but does not sign yet
+	signer, err := ring.signingKey(now)
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.KID, signer.record.KID)
+	assert.Len(t, ring.JWKS(now).Keys, 2)
+
+	// Once active, the newest key signs while the old one still verifies
+	later := now.Add(2 * time.Hour)
+	signer, err = ring.signingKey(later)
+	require.NoError(t, err)
+	assert.Equal(t, newKey.KID, signer.record.KID)
+
+	require.NoError(t, ring.Retire(oldKey.KID, later, 30*time.Minute))
+	_, ok := ring.verificationKey(oldKey.KID, later.Add(10*time.Minute))
+	assert.True(t, ok)
+
+	// After the overlap the old key is gone
+	afterOverlap := later.Add(time.Hour)
+	_, ok = ring.verificationKey(oldKey.KID, afterOverlap)
+	assert.False(t, ok)
+	assert.Equal(t, 1, ring.Prune(afterOverlap))
+	assert.Len(t, ring.Records(), 1)
+}
+
+func TestKeyRing_NoSigningKey(t *testing.T) {
+	ring := NewKeyRing()
+	_, err := ring.Generate(AlgEdDSA, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	_, err = ring.signingKey(time.Now())
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, ring.Save(path))
+	svc := NewJWTService(JWTConfig{Secret: "test-secret"})
+	assert.ErrorIs(t, svc.UseKeyRing(path), ErrNoSigningKey)
+}
+
+func TestKeyRing_UnsupportedAlgorithm(t *testing.T) {
+	_, err := NewKeyRing().Generate("HS512", time.Now())
+	assert.ErrorIs(t, err, ErrUnsupportedKeyAlgorithm)
+}
+
+func TestKeyRing_SaveAndLoad(t *testing.T) {
+	ring := NewKeyRing()
+	rsaKey, err := ring.Generate(AlgRS256, time.Now())
+	require.NoError(t, err)
+	edKey, err := ring.Generate(AlgEdDSA, time.Now())
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, ring.Save(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := LoadKeyRing(path)
+	require.NoError(t, err)
+
+	jwks := loaded.JWKS(time.Now())
+	require.Len(t, jwks.Keys, 2)
+	byKid := map[string]JWK{}
+	for _, k := range jwks.Keys {
+		byKid[k.Kid] = k
+	}
+	assert.Equal(t, "RSA", byKid[rsaKey.KID].Kty)
+	assert.Equal(t, "AQAB", byKid[rsaKey.KID].E)
+	assert.NotEmpty(t, byKid[rsaKey.KID].N)
+	assert.Equal(t, "OKP", byKid[edKey.KID].Kty)
+	assert.Equal(t, "Ed25519", byKid[edKey.KID].Crv)
+	assert.NotEmpty(t, byKid[edKey.KID].X)
+}
+
+func TestJWTService_JWKSEmptyWithoutKeyRing(t *testing.T) {
+	svc := NewJWTService(JWTConfig{Secret: "test-secret"})
+	assert.Empty(t, svc.JWKS().Keys)
+}
+
+func TestKeyRingSource_ReloadsChangedFile(t *testing.T) {
+	ring := NewKeyRing()
+	first, err := ring.Generate(AlgEdDSA, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	svc, path := newKeyRingJWTService(t, ring)
+
+	second, err := ring.Generate(AlgEdDSA, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, ring.Save(path))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+
+	// Force the next lookup to check the file
+	svc.keys.checkedAt = time.Time{}
+
+	tokenString, _, err := svc.GenerateAccessToken(uuid.New(), uuid.New(), "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.KID, tokenHeader(t, tokenString)["kid"])
+	assert.NotEqual(t, first.KID, second.KID)
+}
@@ -18,6 +18,7 @@ import (
 	"gorm.io/gorm"
 
 	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/metrics"
 	"msls-backend/internal/pkg/sms"
 )
 
@@ -26,19 +27,28 @@ type OTPService struct {
 	db          *gorm.DB
 	jwtService  *JWTService
 	smsProvider sms.Provider
+	sessions    *SessionService
 }
 
 // OTPConfig holds OTP service configuration.
 type OTPConfig struct {
 	SMSProvider sms.Provider
+	// Sessions is shared with AuthService so revocation caches stay consistent.
+	// A new SessionService is created when nil.
+	Sessions *SessionService
 }
 
 // NewOTPService creates a new OTPService instance.
 func NewOTPService(db *gorm.DB, jwtService *JWTService, config OTPConfig) *OTPService {
+	sessions := config.Sessions
+	if sessions == nil {
+		sessions = NewSessionService(db)
+	}
 	return &OTPService{
 		db:          db,
 		jwtService:  jwtService,
 		smsProvider: config.SMSProvider,
+		sessions:    sessions,
 	}
 }
 
@@ -146,47 +156,9 @@ func (s *OTPService) VerifyOTP(ctx context.Context, req VerifyOTPRequest) (*Toke
 	// Normalize identifier
 	identifier := s.normalizeIdentifier(req.Identifier, channel)
 
-	// Hash the provided code
-	codeHash := s.hashOTPCode(req.Code)
-
-	// Find the most recent valid OTP for this identifier
-	var otp models.OTPCode
-	err := s.db.WithContext(ctx).
-		Where("identifier = ? AND type = ? AND verified_at IS NULL AND expires_at > ?",
-			identifier, models.OTPTypeLogin, time.Now()).
-		Order("created_at DESC").
-		First(&otp).Error
-
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, ErrOTPExpired
-		}
-		return nil, nil, fmt.Errorf("failed to find OTP: %w", err)
-	}
-
-	// Check if max attempts exceeded
-	if otp.HasExceededMaxAttempts() {
-		return nil, nil, ErrOTPMaxAttempts
-	}
-
-	// Increment attempts
-	otp.IncrementAttempts()
-	if err := s.db.WithContext(ctx).Save(&otp).Error; err != nil {
-		return nil, nil, fmt.Errorf("failed to update OTP attempts: %w", err)
-	}
-
-	// Verify the code
-	if otp.CodeHash != codeHash {
-		if otp.HasExceededMaxAttempts() {
-			return nil, nil, ErrOTPMaxAttempts
-		}
-		return nil, nil, ErrOTPInvalid
-	}
-
-	// Mark OTP as verified
-	otp.MarkVerified()
-	if err := s.db.WithContext(ctx).Save(&otp).Error; err != nil {
-		return nil, nil, fmt.Errorf("failed to mark OTP as verified: %w", err)
+	// Check the code against the most recent login OTP
+	if err := s.consumeOTP(ctx, identifier, models.OTPTypeLogin, req.Code); err != nil {
+		return nil, nil, err
 	}
 
 	// Find or create user
@@ -229,8 +201,12 @@ func (s *OTPService) VerifyOTP(ctx context.Context, req VerifyOTPRequest) (*Toke
 		return nil, nil, fmt.Errorf("failed to load user roles: %w", err)
 	}
 
-	// Generate token pair
-	tokenPair, err := s.generateTokenPair(ctx, user)
+	// Start a session and generate token pair
+	session, err := s.sessions.Start(ctx, user, req.IPAddress, req.UserAgent, s.jwtService.GetRefreshTTL())
+	if err != nil {
+		return nil, nil, err
+	}
+	tokenPair, err := s.generateTokenPair(ctx, user, session)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -242,6 +218,66 @@ func (s *OTPService) VerifyOTP(ctx context.Context, req VerifyOTPRequest) (*Toke
 	return tokenPair, user, nil
 }
 
+// VerifyPhone checks a phone_verify OTP without logging anyone in.
+// It returns the normalized phone number the code was sent to.
+func (s *OTPService) VerifyPhone(ctx context.Context, phone, code string) (string, error) {
+	if err := s.validateIdentifier(phone, models.OTPChannelSMS); err != nil {
+		return "", err
+	}
+
+	identifier := s.normalizeIdentifier(phone, models.OTPChannelSMS)
+	if err := s.consumeOTP(ctx, identifier, models.OTPTypePhoneVerify, code); err != nil {
+		return "", err
+	}
+	return identifier, nil
+}
+
+// consumeOTP checks a code against the most recent unexpired OTP of the given type
+// and marks it verified on success. Every attempt counts towards MaxOTPAttempts.
+func (s *OTPService) consumeOTP(ctx context.Context, identifier string, otpType models.OTPType, code string) error {
+	// Find the most recent valid OTP for this identifier
+	var otp models.OTPCode
+	err := s.db.WithContext(ctx).
+		Where("identifier = ? AND type = ? AND verified_at IS NULL AND expires_at > ?",
+			identifier, otpType, time.Now()).
+		Order("created_at DESC").
+		First(&otp).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrOTPExpired
+		}
+		return fmt.Errorf("failed to find OTP: %w", err)
+	}
+
+	// Check if max attempts exceeded
+	if otp.HasExceededMaxAttempts() {
+		return ErrOTPMaxAttempts
+	}
+
+	// Increment attempts
+	otp.IncrementAttempts()
+	if err := s.db.WithContext(ctx).Save(&otp).Error; err != nil {
+		return fmt.Errorf("failed to update OTP attempts: %w", err)
+	}
+
+	// Verify the code
+	if otp.CodeHash != s.hashOTPCode(code) {
+		if otp.HasExceededMaxAttempts() {
+			return ErrOTPMaxAttempts
+		}
+		return ErrOTPInvalid
+	}
+
+	// Mark OTP as verified
+	otp.MarkVerified()
+	if err := s.db.WithContext(ctx).Save(&otp).Error; err != nil {
+		return fmt.Errorf("failed to mark OTP as verified: %w", err)
+	}
+
+	return nil
+}
+
 // ResendOTP resends an OTP to the specified identifier.
 func (s *OTPService) ResendOTP(ctx context.Context, req RequestOTPRequest) (*RequestOTPResponse, error) {
 	// Validate identifier
@@ -517,11 +553,12 @@ func (s *OTPService) sendEmail(ctx context.Context, email, code string) error {
 	// For now, just log the email (mock implementation)
 	// In production, integrate with email service like SendGrid, SES, etc.
 	fmt.Printf("[EMAIL MOCK] OTP to %s: %s\n", email, code)
+	metrics.RecordNotification(metrics.ChannelEmail, nil)
 	return nil
 }
 
-// generateTokenPair generates a new access and refresh token pair.
-func (s *OTPService) generateTokenPair(ctx context.Context, user *models.User) (*TokenPair, error) {
+// generateTokenPair generates a new access and refresh token pair for a session.
+func (s *OTPService) generateTokenPair(ctx context.Context, user *models.User, session *models.UserSession) (*TokenPair, error) {
 	// Get user permissions
 	permissions := user.GetPermissions()
 
@@ -532,7 +569,7 @@ func (s *OTPService) generateTokenPair(ctx context.Context, user *models.User) (
 	}
 
 	// Generate access token
-	accessToken, expiresAt, err := s.jwtService.GenerateAccessToken(user.ID, user.TenantID, email, permissions)
+	accessToken, expiresAt, err := s.jwtService.GenerateSessionAccessToken(user.ID, user.TenantID, email, permissions, session.ID, user.TokenVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -548,6 +585,7 @@ func (s *OTPService) generateTokenPair(ctx context.Context, user *models.User) (
 		UserID:    user.ID,
 		TokenHash: s.jwtService.HashRefreshToken(refreshToken),
 		ExpiresAt: refreshExpiresAt,
+		SessionID: &session.ID,
 	}
 	if err := s.db.WithContext(ctx).Create(storedToken).Error; err != nil {
 		return nil, err
@@ -66,6 +66,7 @@ func setupRefreshTokenTestDB(t *testing.T) *gorm.DB {
 			locked_until DATETIME,
 			failed_login_attempts INTEGER DEFAULT 0,
 			account_deletion_requested_at DATETIME,
+			token_version INTEGER NOT NULL DEFAULT 0,
 			FOREIGN KEY (tenant_id) REFERENCES tenants(id)
 		)
 	`).Error
@@ -80,6 +81,27 @@ func setupRefreshTokenTestDB(t *testing.T) *gorm.DB {
 			token_hash TEXT NOT NULL UNIQUE,
 			expires_at DATETIME NOT NULL,
 			revoked_at DATETIME,
+			session_id TEXT,
+			FOREIGN KEY (user_id) REFERENCES users(id)
+		)
+	`).Error
+	require.NoError(t, err)
+
+	err = db.Exec(`
+		CREATE TABLE user_sessions (
+			id TEXT PRIMARY KEY,
+			tenant_id TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			device TEXT,
+			user_agent TEXT,
+			ip_address TEXT,
+			location TEXT,
+			last_used_at DATETIME NOT NULL,
+			expires_at DATETIME NOT NULL,
+			revoked_at DATETIME,
+			revoked_reason TEXT,
 			FOREIGN KEY (user_id) REFERENCES users(id)
 		)
 	`).Error
@@ -0,0 +1,406 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// sessionCheckTTL is how long token version and session state are cached per replica.
+// It bounds how long a revocation made on one replica takes to reach the others.
+const sessionCheckTTL = 15 * time.Second
+
+// sessionCacheCleanupInterval is how often expired token version and session
+// state is purged from the cache.
+const sessionCacheCleanupInterval = time.Minute
+
+// GeoLocator resolves an approximate location for an IP address.
+type GeoLocator interface {
+	Locate(ip net.IP) string
+}
+
+// localGeoLocator labels private and loopback addresses and leaves public ones blank.
+// Replace it with SetGeoLocator when a GeoIP database is available.
+type localGeoLocator struct{}
+
+// Locate returns "Local network" for private and loopback addresses.
+func (localGeoLocator) Locate(ip net.IP) string {
+	if ip != nil && (ip.IsLoopback() || ip.IsPrivate()) {
+		return "Local network"
+	}
+	return ""
+}
+
+type cachedVersion struct {
+	version   int
+	expiresAt time.Time
+}
+
+type cachedSession struct {
+	userID    uuid.UUID
+	active    bool
+	expiresAt time.Time
+}
+
+// SessionService manages login sessions and access token revocation.
+type SessionService struct {
+	db  *gorm.DB
+	geo GeoLocator
+
+	mu           sync.Mutex
+	versions     map[uuid.UUID]cachedVersion
+	sessions     map[uuid.UUID]cachedSession
+	userSessions map[uuid.UUID]map[uuid.UUID]struct{}
+	lastClean    time.Time
+	now          func() time.Time
+}
+
+// NewSessionService creates a new SessionService instance.
+func NewSessionService(db *gorm.DB) *SessionService {
+	return &SessionService{
+		db:           db,
+		geo:          localGeoLocator{},
+		versions:     make(map[uuid.UUID]cachedVersion),
+		sessions:     make(map[uuid.UUID]cachedSession),
+		userSessions: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		lastClean:    time.Now(),
+		now:          time.Now,
+	}
+}
+
+// SetGeoLocator sets the resolver used to record session locations.
+func (s *SessionService) SetGeoLocator(geo GeoLocator) {
+	s.geo = geo
+}
+
+// Start creates a session for a user logging in from the given client.
+func (s *SessionService) Start(ctx context.Context, user *models.User, ipAddress net.IP, userAgent string, ttl time.Duration) (*models.UserSession, error) {
+	now := time.Now()
+	session := &models.UserSession{
+		TenantID:   user.TenantID,
+		UserID:     user.ID,
+		Device:     describeDevice(userAgent),
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		Location:   s.geo.Locate(ipAddress),
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+	if err := s.db.WithContext(ctx).Create(session).Error; err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Touch records use of a session (token refresh) and extends its expiry.
+func (s *SessionService) Touch(ctx context.Context, session *models.UserSession, ipAddress net.IP, ttl time.Duration) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"last_used_at": now,
+		"expires_at":   now.Add(ttl),
+	}
+	if ipAddress != nil && !ipAddress.Equal(session.IPAddress) {
+		updates["ip_address"] = ipAddress
+		updates["location"] = s.geo.Locate(ipAddress)
+	}
+	return s.db.WithContext(ctx).Model(session).Updates(updates).Error
+}
+
+// Get retrieves a session by ID.
+func (s *SessionService) Get(ctx context.Context, sessionID uuid.UUID) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := s.db.WithContext(ctx).First(&session, "id = ?", sessionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListActive returns a user's sessions that are neither revoked nor expired, most recently used first.
+func (s *SessionService) ListActive(ctx context.Context, userID uuid.UUID) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	err := s.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Revoke revokes one of a user's sessions and its refresh tokens.
+// Access tokens issued for the session stop working on the next request.
+func (s *SessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID, reason models.SessionRevokeReason) error {
+	now := time.Now()
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.UserSession{}).
+			Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+			Updates(map[string]interface{}{"revoked_at": now, "revoked_reason": reason})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSessionNotFound
+		}
+		return tx.Model(&models.RefreshToken{}).
+			Where("session_id = ? AND revoked_at IS NULL", sessionID).
+			Update("revoked_at", now).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	s.forgetSession(sessionID)
+	return nil
+}
+
+// RevokeAll revokes all of a user's sessions and refresh tokens, except keep if non-nil.
+// When no session is kept the user's token version is bumped as well, so that
+// every outstanding access token, including ones issued before sessions existed, is rejected.
+func (s *SessionService) RevokeAll(ctx context.Context, userID uuid.UUID, reason models.SessionRevokeReason, keep *uuid.UUID) (int64, error) {
+	now := time.Now()
+	var revoked int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sessions := tx.Model(&models.UserSession{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+		tokens := tx.Model(&models.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+		if keep != nil {
+			sessions = sessions.Where("id <> ?", *keep)
+			tokens = tokens.Where("session_id IS NULL OR session_id <> ?", *keep)
+		}
+
+		result := sessions.Updates(map[string]interface{}{"revoked_at": now, "revoked_reason": reason})
+		if result.Error != nil {
+			return result.Error
+		}
+		revoked = result.RowsAffected
+
+		if err := tokens.Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		if keep == nil {
+			return bumpTokenVersion(tx, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.forgetUser(userID)
+	return revoked, nil
+}
+
+// InvalidateAccessTokens bumps the token version of the given users so their
+// access tokens are rejected and must be refreshed, picking up new permissions.
+// Sessions and refresh tokens stay valid.
+func (s *SessionService) InvalidateAccessTokens(ctx context.Context, userIDs ...uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if err := bumpTokenVersion(s.db.WithContext(ctx), userIDs...); err != nil {
+		return err
+	}
+	for _, id := range userIDs {
+		s.forgetUser(id)
+	}
+	return nil
+}
+
+// CheckAccessToken rejects access tokens whose session was revoked or whose
+// token version is older than the user's current version.
+func (s *SessionService) CheckAccessToken(ctx context.Context, claims *Claims) error {
+	version, err := s.tokenVersion(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+	if claims.TokenVersion != version {
+		return ErrTokenRevoked
+	}
+
+	if claims.SessionID == uuid.Nil {
+		return nil
+	}
+	active, err := s.sessionActive(ctx, claims.UserID, claims.SessionID)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// tokenVersion returns the user's current token version, cached for sessionCheckTTL.
+func (s *SessionService) tokenVersion(ctx context.Context, userID uuid.UUID) (int, error) {
+	now := s.now()
+	s.mu.Lock()
+	cached, ok := s.versions[userID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.version, nil
+	}
+
+	var user models.User
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Token checks run before tenant context is applied to the connection
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Select("id", "token_version").First(&user, "id = ?", userID).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrUserNotFound
+		}
+		return 0, err
+	}
+
+	s.mu.Lock()
+	s.maybeCleanup(now)
+	s.versions[userID] = cachedVersion{version: user.TokenVersion, expiresAt: now.Add(sessionCheckTTL)}
+	s.mu.Unlock()
+	return user.TokenVersion, nil
+}
+
+// sessionActive reports whether a user's session is usable, cached for sessionCheckTTL.
+func (s *SessionService) sessionActive(ctx context.Context, userID, sessionID uuid.UUID) (bool, error) {
+	now := s.now()
+	s.mu.Lock()
+	cached, ok := s.sessions[sessionID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.active, nil
+	}
+
+	session, err := s.Get(ctx, sessionID)
+	active := err == nil && session.IsActive()
+	if err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return false, err
+	}
+
+	s.mu.Lock()
+	s.maybeCleanup(now)
+	s.sessions[sessionID] = cachedSession{userID: userID, active: active, expiresAt: now.Add(sessionCheckTTL)}
+	if s.userSessions[userID] == nil {
+		s.userSessions[userID] = make(map[uuid.UUID]struct{})
+	}
+	s.userSessions[userID][sessionID] = struct{}{}
+	s.mu.Unlock()
+	return active, nil
+}
+
+// forgetUser drops the cached token version and sessions of a user after a
+// revocation on this replica.
+func (s *SessionService) forgetUser(userID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.versions, userID)
+	for sessionID := range s.userSessions[userID] {
+		delete(s.sessions, sessionID)
+	}
+	delete(s.userSessions, userID)
+	s.mu.Unlock()
+}
+
+// forgetSession drops the cached state of one session.
+func (s *SessionService) forgetSession(sessionID uuid.UUID) {
+	s.mu.Lock()
+	s.removeSession(sessionID)
+	s.mu.Unlock()
+}
+
+// removeSession drops a cached session and its user index entry. Caller must hold s.mu.
+func (s *SessionService) removeSession(sessionID uuid.UUID) {
+	cached, ok := s.sessions[sessionID]
+	if !ok {
+		return
+	}
+	delete(s.sessions, sessionID)
+	if ids := s.userSessions[cached.userID]; ids != nil {
+		delete(ids, sessionID)
+		if len(ids) == 0 {
+			delete(s.userSessions, cached.userID)
+		}
+	}
+}
+
+// maybeCleanup removes expired entries periodically so the caches stay
+// bounded by the users and sessions seen within the cleanup interval.
+// Caller must hold s.mu.
+func (s *SessionService) maybeCleanup(now time.Time) {
+	if now.Sub(s.lastClean) < sessionCacheCleanupInterval {
+		return
+	}
+	s.lastClean = now
+	for userID, cached := range s.versions {
+		if !now.Before(cached.expiresAt) {
+			delete(s.versions, userID)
+		}
+	}
+	for sessionID, cached := range s.sessions {
+		if !now.Before(cached.expiresAt) {
+			s.removeSession(sessionID)
+		}
+	}
+}
+
+// bumpTokenVersion increments users.token_version. The column is create-only in the
+// model so that saving a stale user record can never roll the version back.
+func bumpTokenVersion(db *gorm.DB, userIDs ...uuid.UUID) error {
+	return db.Exec("UPDATE users SET token_version = token_version + 1 WHERE id IN ?", userIDs).Error
+}
+
+// describeDevice summarises a user agent as "<browser> on <os>".
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := "Unknown browser"
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	case strings.Contains(userAgent, "okhttp"), strings.Contains(userAgent, "Dart/"):
+		browser = "Mobile app"
+	}
+
+	os := "Unknown OS"
+	switch {
+	case strings.Contains(userAgent, "Windows"):
+		os = "Windows"
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"):
+		os = "iOS"
+	case strings.Contains(userAgent, "Mac OS X"):
+		os = "macOS"
+	case strings.Contains(userAgent, "Android"):
+		os = "Android"
+	case strings.Contains(userAgent, "CrOS"):
+		os = "ChromeOS"
+	case strings.Contains(userAgent, "Linux"):
+		os = "Linux"
+	}
+
+	if browser == "Unknown browser" && os == "Unknown OS" {
+		if len(userAgent) > 100 {
+			return userAgent[:100]
+		}
+		return userAgent
+	}
+	return browser + " on " + os
+}
@@ -0,0 +1,214 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// loginForSession refreshes a legacy token to obtain a session-bound token pair.
+func loginForSession(t *testing.T, authService *AuthService, jwtService *JWTService, rawToken string) (*TokenPair, *Claims) {
+	t.Helper()
+
+	pair, err := authService.RefreshToken(context.Background(), rawToken, net.ParseIP("10.0.0.5"), "Mozilla/5.0 (Windows NT 10.0) Chrome/120.0")
+	require.NoError(t, err)
+
+	claims, err := jwtService.ValidateAccessTokenContext(context.Background(), pair.AccessToken)
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, claims.SessionID)
+	return pair, claims
+}
+
+func TestSessionService_LegacyRefreshStartsSession(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	tenant := createTestTenantForRefresh(t, db)
+	user := createTestUserForRefresh(t, db, tenant)
+	authService, jwtService := createTestAuthService(t, db)
+	jwtService.SetRevocationChecker(authService.Sessions())
+
+	rawToken, _ := createTestRefreshToken(t, db, user, jwtService, false, false)
+	_, claims := loginForSession(t, authService, jwtService, rawToken)
+
+	sessions, err := authService.Sessions().ListActive(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, claims.SessionID, sessions[0].ID)
+	assert.Equal(t, "Chrome on Windows", sessions[0].Device)
+	assert.Equal(t, "Local network", sessions[0].Location)
+}
+
+func TestSessionService_RevokeRejectsAccessAndRefreshTokens(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	tenant := createTestTenantForRefresh(t, db)
+	user := createTestUserForRefresh(t, db, tenant)
+	authService, jwtService := createTestAuthService(t, db)
+	jwtService.SetRevocationChecker(authService.Sessions())
+
+	rawToken, _ := createTestRefreshToken(t, db, user, jwtService, false, false)
+	pair, claims := loginForSession(t, authService, jwtService, rawToken)
+
+	err := authService.Sessions().Revoke(context.Background(), user.ID, claims.SessionID, models.SessionRevokeReasonUser)
+	require.NoError(t, err)
+
+	_, err = jwtService.ValidateAccessTokenContext(context.Background(), pair.AccessToken)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+
+	_, err = authService.RefreshToken(context.Background(), pair.RefreshToken, nil, "")
+	assert.ErrorIs(t, err, ErrRefreshTokenRevoked)
+
+	// Revoking again reports the session as gone
+	err = authService.Sessions().Revoke(context.Background(), user.ID, claims.SessionID, models.SessionRevokeReasonUser)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestSessionService_RevokeAllKeepsCurrentSession(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	tenant := createTestTenantForRefresh(t, db)
+	user := createTestUserForRefresh(t, db, tenant)
+	authService, jwtService := createTestAuthService(t, db)
+	jwtService.SetRevocationChecker(authService.Sessions())
+
+	firstToken, _ := createTestRefreshToken(t, db, user, jwtService, false, false)
+	current, currentClaims := loginForSession(t, authService, jwtService, firstToken)
+	secondToken, _ := createTestRefreshToken(t, db, user, jwtService, false, false)
+	other, _ := loginForSession(t, authService, jwtService, secondToken)
+
+	revoked, err := authService.Sessions().RevokeAll(context.Background(), user.ID, models.SessionRevokeReasonUser, &currentClaims.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+
+	_, err = jwtService.ValidateAccessTokenContext(context.Background(), current.AccessToken)
+	assert.NoError(t, err)
+	_, err = jwtService.ValidateAccessTokenContext(context.Background(), other.AccessToken)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+}
+
+func TestSessionService_RevokeAllBumpsTokenVersion(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	tenant := createTestTenantForRefresh(t, db)
+	user := createTestUserForRefresh(t, db, tenant)
+	authService, jwtService := createTestAuthService(t, db)
+	jwtService.SetRevocationChecker(authService.Sessions())
+
+	// Access token issued without a session, as before session tracking
+	legacyToken, _, err := jwtService.GenerateAccessToken(user.ID, user.TenantID, "", nil)
+	require.NoError(t, err)
+	_, err = jwtService.ValidateAccessTokenContext(context.Background(), legacyToken)
+	require.NoError(t, err)
+
+	_, err = authService.Sessions().RevokeAll(context.Background(), user.ID, models.SessionRevokeReasonAccountLocked, nil)
+	require.NoError(t, err)
+
+	_, err = jwtService.ValidateAccessTokenContext(context.Background(), legacyToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	var reloaded models.User
+	require.NoError(t, db.First(&reloaded, "id = ?", user.ID).Error)
+	assert.Equal(t, 1, reloaded.TokenVersion)
+}
+
+func TestSessionService_InvalidateAccessTokensForcesRefresh(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	tenant := createTestTenantForRefresh(t, db)
+	user := createTestUserForRefresh(t, db, tenant)
+	authService, jwtService := createTestAuthService(t, db)
+	jwtService.SetRevocationChecker(authService.Sessions())
+
+	rawToken, _ := createTestRefreshToken(t, db, user, jwtService, false, false)
+	pair, _ := loginForSession(t, authService, jwtService, rawToken)
+
+	require.NoError(t, authService.Sessions().InvalidateAccessTokens(context.Background(), user.ID))
+
+	_, err := jwtService.ValidateAccessTokenContext(context.Background(), pair.AccessToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	// The session survives, so a refresh issues a token with the new version
+	refreshed, err := authService.RefreshToken(context.Background(), pair.RefreshToken, nil, "")
+	require.NoError(t, err)
+	claims, err := jwtService.ValidateAccessTokenContext(context.Background(), refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claims.TokenVersion)
+}
+
+func TestSessionService_SaveDoesNotRollBackTokenVersion(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	tenant := createTestTenantForRefresh(t, db)
+	user := createTestUserForRefresh(t, db, tenant)
+	sessions := NewSessionService(db)
+
+	require.NoError(t, sessions.InvalidateAccessTokens(context.Background(), user.ID))
+
+	// user still holds the stale version in memory
+	user.FirstName = "Renamed"
+	require.NoError(t, db.Save(user).Error)
+
+	var reloaded models.User
+	require.NoError(t, db.First(&reloaded, "id = ?", user.ID).Error)
+	assert.Equal(t, 1, reloaded.TokenVersion)
+	assert.Equal(t, "Renamed", reloaded.FirstName)
+}
+
+func TestSessionService_CacheEviction(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	tenant := createTestTenantForRefresh(t, db)
+	user := createTestUserForRefresh(t, db, tenant)
+	other := &models.User{TenantID: tenant.ID}
+	other.ID = uuid.New()
+	sessions := NewSessionService(db)
+	ctx := context.Background()
+
+	mine, err := sessions.Start(ctx, user, nil, "", time.Hour)
+	require.NoError(t, err)
+	theirs, err := sessions.Start(ctx, other, nil, "", time.Hour)
+	require.NoError(t, err)
+
+	for _, session := range []*models.UserSession{mine, theirs} {
+		active, err := sessions.sessionActive(ctx, session.UserID, session.ID)
+		require.NoError(t, err)
+		assert.True(t, active)
+	}
+	require.Len(t, sessions.sessions, 2)
+
+	// Revoking a user only drops that user's cached sessions
+	sessions.forgetUser(user.ID)
+	assert.NotContains(t, sessions.sessions, mine.ID)
+	assert.Contains(t, sessions.sessions, theirs.ID)
+	assert.NotContains(t, sessions.userSessions, user.ID)
+
+	// Expired entries are purged once the cleanup interval has passed
+	now := time.Now().Add(sessionCacheCleanupInterval + time.Second)
+	sessions.now = func() time.Time { return now }
+	_, err = sessions.sessionActive(ctx, user.ID, mine.ID)
+	require.NoError(t, err)
+	assert.Len(t, sessions.sessions, 1)
+	assert.Contains(t, sessions.sessions, mine.ID)
+	assert.NotContains(t, sessions.userSessions, other.ID)
+}
+
+func TestDescribeDevice(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"", "Unknown device"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36", "Chrome on Windows"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36 Edg/120.0", "Edge on Windows"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1", "Safari on iOS"},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:121.0) Gecko/20100101 Firefox/121.0", "Firefox on macOS"},
+		{"Dart/3.2 (dart:io) Android", "Mobile app on Android"},
+		{"curl/8.4.0", "curl/8.4.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, describeDevice(tt.userAgent))
+		})
+	}
+}
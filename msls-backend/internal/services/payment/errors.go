@@ -0,0 +1,27 @@
+// Package payment provides the payments ledger: gateway orders for
+// application fees and invoices, webhook processing, refunds and reconciliation.
+package payment
+
+import "errors"
+
+// Service errors.
+var (
+	// Ledger errors
+	ErrPaymentNotFound     = errors.New("payment not found")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrNoFeeDue            = errors.New("no application fee is due")
+	ErrAlreadyPaid         = errors.New("fee has already been paid")
+	ErrPaymentNotCaptured  = errors.New("payment has not been captured")
+	ErrAlreadyConfirmed    = errors.New("payment has already been confirmed")
+
+	// Gateway errors
+	ErrInvalidSignature = errors.New("payment signature verification failed")
+	ErrGatewayFailed    = errors.New("payment gateway request failed")
+
+	// Refund errors
+	ErrPaymentNotRefundable = errors.New("payment cannot be refunded")
+	ErrInvalidRefundAmount  = errors.New("refund amount exceeds the refundable amount")
+
+	// Reconciliation errors
+	ErrInvalidDateRange = errors.New("invalid reconciliation date range")
+)
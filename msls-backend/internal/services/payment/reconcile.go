@@ -0,0 +1,142 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/payments"
+)
+
+// ReconciliationReport summarises a reconciliation run.
+type ReconciliationReport struct {
+	From       time.Time
+	To         time.Time
+	Checked    int
+	Matched    int
+	Mismatched int
+	Errors     int
+	Mismatches []Mismatch
+}
+
+// Mismatch describes a payment whose ledger entry disagrees with the gateway.
+type Mismatch struct {
+	PaymentID     uuid.UUID
+	OrderID       string
+	ApplicationID *uuid.UUID
+	Notes         []string
+}
+
+// Reconcile compares the payments created in [from, to) with the gateway and
+// flags every payment whose captured payments or amounts disagree with the ledger.
+func (s *Service) Reconcile(ctx context.Context, tenantID uuid.UUID, from, to time.Time) (*ReconciliationReport, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidDateRange
+	}
+
+	var list []models.Payment
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND provider = ? AND created_at >= ? AND created_at < ?", tenantID, s.provider.Name(), from, to).
+		Order("created_at ASC").
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	report := &ReconciliationReport{From: from, To: to}
+	for i := range list {
+		payment := &list[i]
+		report.Checked++
+
+		attempts, err := s.provider.FetchOrderPayments(ctx, payment.ProviderOrderID)
+		if err != nil && !errors.Is(err, payments.ErrOrderNotFound) {
+			report.Errors++
+			continue
+		}
+
+		var notes []string
+		if errors.Is(err, payments.ErrOrderNotFound) {
+			notes = []string{"order not found at gateway"}
+		} else {
+			notes = compareWithGateway(payment, attempts)
+		}
+
+		now := time.Now()
+		payment.ReconciledAt = &now
+		if len(notes) == 0 {
+			payment.ReconciliationStatus = models.ReconciliationMatched
+			payment.ReconciliationNote = ""
+			report.Matched++
+		} else {
+			payment.ReconciliationStatus = models.ReconciliationMismatch
+			payment.ReconciliationNote = strings.Join(notes, "; ")
+			report.Mismatched++
+			report.Mismatches = append(report.Mismatches, Mismatch{
+				PaymentID:     payment.ID,
+				OrderID:       payment.ProviderOrderID,
+				ApplicationID: payment.ApplicationID,
+				Notes:         notes,
+			})
+		}
+
+		err = s.db.WithContext(ctx).Model(&models.Payment{}).
+			Where("id = ? AND tenant_id = ?", payment.ID, tenantID).
+			Updates(map[string]interface{}{
+				"reconciliation_status": payment.ReconciliationStatus,
+				"reconciliation_note":   payment.ReconciliationNote,
+				"reconciled_at":         payment.ReconciledAt,
+			}).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to update payment reconciliation: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// compareWithGateway returns the differences between a ledger payment and the
+// payments the gateway holds for its order. Refunded gateway payments count as
+// captured, since the money was collected before it was returned.
+func compareWithGateway(payment *models.Payment, attempts []payments.Payment) []string {
+	var captured []payments.Payment
+	for _, attempt := range attempts {
+		if attempt.Status == payments.StatusCaptured || attempt.Status == payments.StatusRefunded {
+			captured = append(captured, attempt)
+		}
+	}
+
+	var notes []string
+	switch {
+	case len(captured) == 0 && payment.Status.IsPaid():
+		notes = append(notes, "ledger shows a captured payment but the gateway has none")
+	case len(captured) > 0 && !payment.Status.IsPaid():
+		notes = append(notes, fmt.Sprintf("gateway captured payment %s but the ledger shows %s", captured[0].ID, payment.Status))
+	case len(captured) > 1:
+		notes = append(notes, fmt.Sprintf("gateway has %d captured payments for the order", len(captured)))
+	}
+
+	if len(captured) == 0 || !payment.Status.IsPaid() {
+		return notes
+	}
+
+	total := decimal.Zero
+	for _, c := range captured {
+		total = total.Add(c.Amount)
+	}
+	if payment.ProviderPaymentID != nil && len(captured) == 1 && captured[0].ID != *payment.ProviderPaymentID {
+		notes = append(notes, fmt.Sprintf("gateway payment %s differs from ledger payment %s", captured[0].ID, *payment.ProviderPaymentID))
+	}
+	if !total.Equal(payment.CapturedAmount) {
+		notes = append(notes, fmt.Sprintf("gateway captured %s but the ledger recorded %s", total.StringFixed(2), payment.CapturedAmount.StringFixed(2)))
+	}
+	if !payment.CapturedAmount.Equal(payment.Amount) {
+		notes = append(notes, fmt.Sprintf("captured amount %s differs from order amount %s", payment.CapturedAmount.StringFixed(2), payment.Amount.StringFixed(2)))
+	}
+	return notes
+}
@@ -0,0 +1,494 @@
+// Package payment provides the payments ledger: gateway orders for
+// application fees and invoices, webhook processing, refunds and reconciliation.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/payments"
+)
+
+// DefaultCurrency is the currency fees are collected in.
+const DefaultCurrency = "INR"
+
+// Service manages payments collected through a payment gateway.
+type Service struct {
+	db       *gorm.DB
+	provider payments.Provider
+}
+
+// NewService creates a new payment service using the given gateway.
+func NewService(db *gorm.DB, provider payments.Provider) *Service {
+	return &Service{db: db, provider: provider}
+}
+
+// Checkout holds what the browser needs to open the gateway checkout for a payment.
+type Checkout struct {
+	Payment     *models.Payment
+	Provider    string
+	CheckoutKey string
+}
+
+// ListFilter contains filters for listing payments.
+type ListFilter struct {
+	TenantID             uuid.UUID
+	Status               *models.PaymentStatus
+	Purpose              *models.PaymentPurpose
+	ApplicationID        *uuid.UUID
+	ReconciliationStatus *models.ReconciliationStatus
+	From                 *time.Time
+	To                   *time.Time
+	Limit                int
+	Offset               int
+}
+
+// RefundRequest represents a request to refund a payment.
+type RefundRequest struct {
+	TenantID   uuid.UUID
+	PaymentID  uuid.UUID
+	Amount     decimal.Decimal // Zero refunds the whole refundable amount
+	Reason     string
+	RefundedBy *uuid.UUID
+}
+
+// ListPayments returns payments matching the filter, newest first.
+func (s *Service) ListPayments(ctx context.Context, filter ListFilter) ([]models.Payment, int64, error) {
+	query := s.db.WithContext(ctx).Model(&models.Payment{}).Where("tenant_id = ?", filter.TenantID)
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.Purpose != nil {
+		query = query.Where("purpose = ?", *filter.Purpose)
+	}
+	if filter.ApplicationID != nil {
+		query = query.Where("application_id = ?", *filter.ApplicationID)
+	}
+	if filter.ReconciliationStatus != nil {
+		query = query.Where("reconciliation_status = ?", *filter.ReconciliationStatus)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count payments: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var list []models.Payment
+	if err := query.Order("created_at DESC").Find(&list).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list payments: %w", err)
+	}
+	return list, total, nil
+}
+
+// GetPayment returns a payment with its refunds.
+func (s *Service) GetPayment(ctx context.Context, tenantID, id uuid.UUID) (*models.Payment, error) {
+	var payment models.Payment
+	err := s.db.WithContext(ctx).
+		Preload("Refunds", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return &payment, nil
+}
+
+// CreateApplicationFeeOrder opens a gateway order for an application's fee.
+// An open order for the same amount is reused, so retrying checkout does not
+// create duplicate orders.
+func (s *Service) CreateApplicationFeeOrder(ctx context.Context, tenantID, applicationID uuid.UUID) (*Checkout, error) {
+	var application models.AdmissionApplication
+	err := s.db.WithContext(ctx).
+		Preload("Session").
+		Where("tenant_id = ? AND id = ?", tenantID, applicationID).
+		First(&application).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	if application.FeePaid {
+		return nil, ErrAlreadyPaid
+	}
+	if application.Session == nil || !application.Session.ApplicationFee.IsPositive() {
+		return nil, ErrNoFeeDue
+	}
+	fee := application.Session.ApplicationFee
+
+	var existing []models.Payment
+	err = s.db.WithContext(ctx).
+		Where("tenant_id = ? AND application_id = ? AND purpose = ?", tenantID, applicationID, models.PaymentPurposeApplicationFee).
+		Order("created_at DESC").
+		Find(&existing).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application payments: %w", err)
+	}
+	for i := range existing {
+		// A captured payment waiting for office confirmation still counts as paid
+		if existing[i].Status == models.PaymentStatusCaptured {
+			return nil, ErrAlreadyPaid
+		}
+	}
+	for i := range existing {
+		open := existing[i].Status == models.PaymentStatusCreated || existing[i].Status == models.PaymentStatusFailed
+		if open && existing[i].Provider == s.provider.Name() && existing[i].Amount.Equal(fee) {
+			return s.checkout(&existing[i]), nil
+		}
+	}
+
+	order, err := s.provider.CreateOrder(ctx, payments.OrderRequest{
+		Amount:   fee,
+		Currency: DefaultCurrency,
+		Receipt:  application.ApplicationNumber,
+		Notes: map[string]string{
+			"tenant_id":      tenantID.String(),
+			"application_id": applicationID.String(),
+			"purpose":        string(models.PaymentPurposeApplicationFee),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGatewayFailed, err)
+	}
+
+	payment := &models.Payment{
+		Purpose:              models.PaymentPurposeApplicationFee,
+		ApplicationID:        &applicationID,
+		Provider:             s.provider.Name(),
+		ProviderOrderID:      order.ID,
+		Amount:               fee,
+		Currency:             DefaultCurrency,
+		Status:               models.PaymentStatusCreated,
+		ReconciliationStatus: models.ReconciliationPending,
+	}
+	payment.TenantID = tenantID
+	if err := s.db.WithContext(ctx).Create(payment).Error; err != nil {
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	return s.checkout(payment), nil
+}
+
+// ConfirmApplicationFeeCheckout records the payment the browser reports after
+// checkout. The checkout signature is verified and the payment is read back
+// from the gateway, so a webhook arriving first or later changes nothing.
+func (s *Service) ConfirmApplicationFeeCheckout(ctx context.Context, tenantID, applicationID uuid.UUID, confirmation payments.CheckoutConfirmation) (*models.Payment, error) {
+	if err := s.provider.VerifyCheckout(confirmation); err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	var payment models.Payment
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND application_id = ? AND provider = ? AND provider_order_id = ?",
+			tenantID, applicationID, s.provider.Name(), confirmation.OrderID).
+		First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	attempts, err := s.provider.FetchOrderPayments(ctx, confirmation.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGatewayFailed, err)
+	}
+	var captured *payments.Payment
+	for i := range attempts {
+		if attempts[i].ID == confirmation.PaymentID && attempts[i].Status == payments.StatusCaptured {
+			captured = &attempts[i]
+		}
+	}
+	if captured == nil {
+		return nil, ErrPaymentNotCaptured
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyCapture(tx, &payment, captured)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// ConfirmPayment applies a captured payment to its application when the
+// session does not confirm payments automatically, or after a mismatch was reviewed.
+func (s *Service) ConfirmPayment(ctx context.Context, tenantID, paymentID, confirmedBy uuid.UUID) (*models.Payment, error) {
+	payment, err := s.GetPayment(ctx, tenantID, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != models.PaymentStatusCaptured {
+		return nil, ErrPaymentNotCaptured
+	}
+	if payment.ConfirmedAt != nil {
+		return nil, ErrAlreadyConfirmed
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := confirmPayment(tx, payment, &confirmedBy); err != nil {
+			return err
+		}
+		return tx.Save(payment).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm payment: %w", err)
+	}
+	return payment, nil
+}
+
+// Refund refunds all or part of a captured payment at the gateway. A fully
+// refunded application fee marks the application unpaid again.
+func (s *Service) Refund(ctx context.Context, req RefundRequest) (*models.Payment, error) {
+	payment, err := s.GetPayment(ctx, req.TenantID, req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != models.PaymentStatusCaptured && payment.Status != models.PaymentStatusPartiallyRefunded {
+		return nil, ErrPaymentNotRefundable
+	}
+	if payment.ProviderPaymentID == nil {
+		return nil, ErrPaymentNotRefundable
+	}
+
+	refundable := payment.RefundableAmount()
+	amount := req.Amount
+	if amount.IsZero() {
+		amount = refundable
+	}
+	if !amount.IsPositive() || amount.GreaterThan(refundable) {
+		return nil, ErrInvalidRefundAmount
+	}
+
+	notes := map[string]string{"payment_id": payment.ID.String()}
+	if req.Reason != "" {
+		notes["reason"] = req.Reason
+	}
+	gatewayRefund, err := s.provider.Refund(ctx, payments.RefundRequest{
+		PaymentID: *payment.ProviderPaymentID,
+		Amount:    amount,
+		Notes:     notes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGatewayFailed, err)
+	}
+
+	refund := models.PaymentRefund{
+		PaymentID:        payment.ID,
+		ProviderRefundID: gatewayRefund.ID,
+		Amount:           amount,
+		Status:           gatewayRefund.Status,
+		Reason:           req.Reason,
+	}
+	refund.TenantID = payment.TenantID
+	refund.CreatedBy = req.RefundedBy
+	if refund.Status == "" {
+		refund.Status = payments.RefundPending
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyRefund(tx, payment, &refund)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPayment(ctx, req.TenantID, req.PaymentID)
+}
+
+// checkout returns the checkout details of a payment.
+func (s *Service) checkout(payment *models.Payment) *Checkout {
+	return &Checkout{
+		Payment:     payment,
+		Provider:    s.provider.Name(),
+		CheckoutKey: s.provider.CheckoutKey(),
+	}
+}
+
+// applyCapture records a captured gateway payment. It is idempotent: capturing
+// the same gateway payment again changes nothing. A second captured payment
+// for the same order, or a captured amount that differs from the order, is
+// flagged for reconciliation instead of being applied.
+func applyCapture(tx *gorm.DB, payment *models.Payment, captured *payments.Payment) error {
+	if payment.Status.IsPaid() {
+		if payment.ProviderPaymentID != nil && *payment.ProviderPaymentID == captured.ID {
+			return nil
+		}
+		flagMismatch(payment, fmt.Sprintf("additional captured payment %s for order %s", captured.ID, payment.ProviderOrderID))
+		return savePayment(tx, payment)
+	}
+
+	paidAt := time.Now()
+	if captured.CapturedAt != nil {
+		paidAt = *captured.CapturedAt
+	}
+
+	paymentID := captured.ID
+	payment.Status = models.PaymentStatusCaptured
+	payment.ProviderPaymentID = &paymentID
+	payment.Method = captured.Method
+	payment.CapturedAmount = captured.Amount
+	payment.FailureReason = ""
+	payment.PaidAt = &paidAt
+
+	if !captured.Amount.Equal(payment.Amount) {
+		flagMismatch(payment, fmt.Sprintf("captured amount %s differs from order amount %s",
+			captured.Amount.StringFixed(2), payment.Amount.StringFixed(2)))
+		return savePayment(tx, payment)
+	}
+
+	autoConfirm, err := autoConfirms(tx, payment)
+	if err != nil {
+		return err
+	}
+	if autoConfirm {
+		if err := confirmPayment(tx, payment, nil); err != nil {
+			return err
+		}
+	}
+	return savePayment(tx, payment)
+}
+
+// applyFailure records a failed payment attempt. Failures after a successful
+// capture are retries by the payer and are ignored.
+func applyFailure(tx *gorm.DB, payment *models.Payment, failed *payments.Payment) error {
+	if payment.Status != models.PaymentStatusCreated {
+		return nil
+	}
+	payment.Status = models.PaymentStatusFailed
+	payment.FailureReason = failed.ErrorReason
+	return savePayment(tx, payment)
+}
+
+// applyRefund records a refund and updates the refunded amount. Pending refunds
+// count as refunded so the same money cannot be refunded twice.
+func applyRefund(tx *gorm.DB, payment *models.Payment, refund *models.PaymentRefund) error {
+	if refund.Status == payments.RefundProcessed && refund.ProcessedAt == nil {
+		now := time.Now()
+		refund.ProcessedAt = &now
+	}
+	if err := tx.Create(refund).Error; err != nil {
+		return fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	if refund.Status != payments.RefundFailed {
+		payment.RefundedAmount = payment.RefundedAmount.Add(refund.Amount)
+	}
+	return updateRefundStatus(tx, payment)
+}
+
+// updateRefundStatus sets the payment status from the refunded amount and
+// marks the application unpaid once its fee is fully refunded.
+func updateRefundStatus(tx *gorm.DB, payment *models.Payment) error {
+	switch {
+	case payment.RefundedAmount.IsZero():
+		payment.Status = models.PaymentStatusCaptured
+	case payment.RefundedAmount.GreaterThanOrEqual(payment.CapturedAmount):
+		payment.Status = models.PaymentStatusRefunded
+	default:
+		payment.Status = models.PaymentStatusPartiallyRefunded
+	}
+
+	if payment.Status == models.PaymentStatusRefunded && payment.ConfirmedAt != nil && payment.ApplicationID != nil {
+		err := tx.Model(&models.AdmissionApplication{}).
+			Where("id = ? AND tenant_id = ?", *payment.ApplicationID, payment.TenantID).
+			Updates(map[string]interface{}{
+				"fee_paid":   false,
+				"updated_at": time.Now(),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update application: %w", err)
+		}
+	}
+	return savePayment(tx, payment)
+}
+
+// autoConfirms reports whether a captured payment is applied without office
+// confirmation. Application fees follow the session's AutoConfirmPayment setting.
+func autoConfirms(tx *gorm.DB, payment *models.Payment) (bool, error) {
+	if payment.Purpose != models.PaymentPurposeApplicationFee || payment.ApplicationID == nil {
+		return false, nil
+	}
+
+	var application models.AdmissionApplication
+	err := tx.Preload("Session").
+		Where("id = ? AND tenant_id = ?", *payment.ApplicationID, payment.TenantID).
+		First(&application).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get application: %w", err)
+	}
+	return application.Session != nil && application.Session.Settings.AutoConfirmPayment, nil
+}
+
+// confirmPayment marks the application the payment is for as paid.
+func confirmPayment(tx *gorm.DB, payment *models.Payment, confirmedBy *uuid.UUID) error {
+	now := time.Now()
+	payment.ConfirmedAt = &now
+	payment.ConfirmedBy = confirmedBy
+
+	if payment.ApplicationID == nil {
+		return nil
+	}
+
+	updates := map[string]interface{}{
+		"fee_paid":     true,
+		"payment_date": payment.PaidAt,
+		"updated_at":   now,
+	}
+	if payment.ProviderPaymentID != nil {
+		updates["payment_reference"] = *payment.ProviderPaymentID
+	}
+	err := tx.Model(&models.AdmissionApplication{}).
+		Where("id = ? AND tenant_id = ?", *payment.ApplicationID, payment.TenantID).
+		Updates(updates).Error
+	if err != nil {
+		return fmt.Errorf("failed to update application: %w", err)
+	}
+	return nil
+}
+
+// flagMismatch marks a payment for review during reconciliation.
+func flagMismatch(payment *models.Payment, note string) {
+	now := time.Now()
+	payment.ReconciliationStatus = models.ReconciliationMismatch
+	payment.ReconciliationNote = note
+	payment.ReconciledAt = &now
+}
+
+// savePayment saves a payment without its associations.
+func savePayment(tx *gorm.DB, payment *models.Payment) error {
+	payment.UpdatedAt = time.Now()
+	if err := tx.Omit("Application", "Refunds").Save(payment).Error; err != nil {
+		return fmt.Errorf("failed to save payment: %w", err)
+	}
+	return nil
+}
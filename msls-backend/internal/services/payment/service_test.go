@@ -0,0 +1,412 @@
+package payment
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/payments"
+	"msls-backend/internal/testutil"
+)
+
+type paymentFixture struct {
+	db            *gorm.DB
+	service       *Service
+	gateway       *payments.FakeProvider
+	tenantID      uuid.UUID
+	applicationID uuid.UUID
+}
+
+// setupPayments creates a payment service over the fake gateway with one
+// application in a session charging a 500.00 application fee.
+func setupPayments(t *testing.T, autoConfirm bool) *paymentFixture {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	for _, model := range []interface{}{
+		&models.AdmissionSession{}, &models.AdmissionApplication{},
+		&models.Payment{}, &models.PaymentRefund{}, &models.PaymentWebhookEvent{},
+	} {
+		testutil.CreateSQLiteTable(t, db, model)
+	}
+
+	tenantID := uuid.New()
+	session := &models.AdmissionSession{
+		ID:                uuid.New(),
+		TenantID:          tenantID,
+		Name:              "Admission 2026-27",
+		StartDate:         time.Now().AddDate(0, 0, -1),
+		EndDate:           time.Now().AddDate(0, 0, 30),
+		Status:            models.SessionStatusOpen,
+		ApplicationFee:    decimal.NewFromInt(500),
+		RequiredDocuments: models.RequiredDocuments{},
+		Settings:          models.SessionSettings{AutoConfirmPayment: autoConfirm},
+	}
+	require.NoError(t, db.Create(session).Error)
+
+	application := &models.AdmissionApplication{
+		ID:                uuid.New(),
+		TenantID:          tenantID,
+		SessionID:         session.ID,
+		ApplicationNumber: "APP-2026-0001",
+		StudentName:       "Asha Rao",
+		ClassApplying:     "Class 1",
+	}
+	require.NoError(t, db.Create(application).Error)
+
+	gateway := payments.NewFakeProvider("test-secret")
+	return &paymentFixture{
+		db:            db,
+		service:       NewService(db, gateway),
+		gateway:       gateway,
+		tenantID:      tenantID,
+		applicationID: application.ID,
+	}
+}
+
+func (f *paymentFixture) application(t *testing.T) *models.AdmissionApplication {
+	t.Helper()
+	var application models.AdmissionApplication
+	require.NoError(t, f.db.First(&application, "id = ?", f.applicationID).Error)
+	return &application
+}
+
+func (f *paymentFixture) payment(t *testing.T, id uuid.UUID) *models.Payment {
+	t.Helper()
+	payment, err := f.service.GetPayment(context.Background(), f.tenantID, id)
+	require.NoError(t, err)
+	return payment
+}
+
+func TestCreateApplicationFeeOrder(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	assert.Equal(t, "fake", checkout.Provider)
+	assert.Equal(t, "fake_key", checkout.CheckoutKey)
+	assert.True(t, checkout.Payment.Amount.Equal(decimal.NewFromInt(500)))
+	assert.Equal(t, models.PaymentStatusCreated, checkout.Payment.Status)
+
+	t.Run("reuses the open order", func(t *testing.T) {
+		again, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+		require.NoError(t, err)
+		assert.Equal(t, checkout.Payment.ID, again.Payment.ID)
+		assert.Equal(t, checkout.Payment.ProviderOrderID, again.Payment.ProviderOrderID)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		_, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, uuid.New())
+		assert.ErrorIs(t, err, ErrApplicationNotFound)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		_, err := f.service.CreateApplicationFeeOrder(ctx, uuid.New(), f.applicationID)
+		assert.ErrorIs(t, err, ErrApplicationNotFound)
+	})
+}
+
+func TestCreateApplicationFeeOrder_NoFeeDue(t *testing.T) {
+	f := setupPayments(t, true)
+	require.NoError(t, f.db.Model(&models.AdmissionSession{}).Where("tenant_id = ?", f.tenantID).
+		Update("application_fee", decimal.Zero).Error)
+
+	_, err := f.service.CreateApplicationFeeOrder(context.Background(), f.tenantID, f.applicationID)
+	assert.ErrorIs(t, err, ErrNoFeeDue)
+}
+
+func TestConfirmApplicationFeeCheckout(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	confirmation, err := f.gateway.Pay(checkout.Payment.ProviderOrderID, "upi")
+	require.NoError(t, err)
+
+	t.Run("rejects a forged signature", func(t *testing.T) {
+		forged := *confirmation
+		forged.Signature = strings.Repeat("0", 64)
+		_, err := f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, forged)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	payment, err := f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, *confirmation)
+	require.NoError(t, err)
+	assert.Equal(t, models.PaymentStatusCaptured, payment.Status)
+	assert.Equal(t, "upi", payment.Method)
+	require.NotNil(t, payment.ProviderPaymentID)
+	assert.Equal(t, confirmation.PaymentID, *payment.ProviderPaymentID)
+	assert.NotNil(t, payment.ConfirmedAt)
+
+	application := f.application(t)
+	assert.True(t, application.FeePaid)
+	assert.Equal(t, confirmation.PaymentID, application.PaymentReference)
+	assert.NotNil(t, application.PaymentDate)
+
+	t.Run("confirming again changes nothing", func(t *testing.T) {
+		again, err := f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, *confirmation)
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentStatusCaptured, again.Status)
+		assert.Equal(t, models.ReconciliationPending, again.ReconciliationStatus)
+	})
+
+	t.Run("no second order once paid", func(t *testing.T) {
+		_, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+		assert.ErrorIs(t, err, ErrAlreadyPaid)
+	})
+}
+
+func TestConfirmPayment_Manual(t *testing.T) {
+	f := setupPayments(t, false)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	confirmation, err := f.gateway.Pay(checkout.Payment.ProviderOrderID, "card")
+	require.NoError(t, err)
+
+	payment, err := f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, *confirmation)
+	require.NoError(t, err)
+	assert.Nil(t, payment.ConfirmedAt)
+	assert.False(t, f.application(t).FeePaid)
+
+	userID := uuid.New()
+	payment, err = f.service.ConfirmPayment(ctx, f.tenantID, payment.ID, userID)
+	require.NoError(t, err)
+	assert.NotNil(t, payment.ConfirmedAt)
+	assert.Equal(t, &userID, payment.ConfirmedBy)
+	assert.True(t, f.application(t).FeePaid)
+
+	_, err = f.service.ConfirmPayment(ctx, f.tenantID, payment.ID, userID)
+	assert.ErrorIs(t, err, ErrAlreadyConfirmed)
+}
+
+func TestHandleWebhook_Idempotent(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	confirmation, err := f.gateway.Pay(checkout.Payment.ProviderOrderID, "netbanking")
+	require.NoError(t, err)
+
+	header, body, err := f.gateway.Webhook(payments.EventPaymentCaptured, confirmation.PaymentID)
+	require.NoError(t, err)
+
+	result, err := f.service.HandleWebhook(ctx, header, body)
+	require.NoError(t, err)
+	assert.False(t, result.Duplicate)
+
+	payment := f.payment(t, checkout.Payment.ID)
+	assert.Equal(t, models.PaymentStatusCaptured, payment.Status)
+	assert.True(t, f.application(t).FeePaid)
+
+	// Redelivery is acknowledged without being applied again
+	result, err = f.service.HandleWebhook(ctx, header, body)
+	require.NoError(t, err)
+	assert.True(t, result.Duplicate)
+
+	var events []models.PaymentWebhookEvent
+	require.NoError(t, f.db.Find(&events).Error)
+	require.Len(t, events, 1)
+	assert.NotNil(t, events[0].ProcessedAt)
+	assert.Equal(t, &f.tenantID, events[0].TenantID)
+
+	// The browser confirmation arriving after the webhook changes nothing
+	again, err := f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, *confirmation)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReconciliationPending, again.ReconciliationStatus)
+}
+
+func TestHandleWebhook_InvalidSignature(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	confirmation, err := f.gateway.Pay(checkout.Payment.ProviderOrderID, "upi")
+	require.NoError(t, err)
+
+	header, body, err := f.gateway.Webhook(payments.EventPaymentCaptured, confirmation.PaymentID)
+	require.NoError(t, err)
+	header.Set(payments.FakeSignatureHeader, strings.Repeat("0", 64))
+
+	_, err = f.service.HandleWebhook(ctx, header, body)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	assert.Equal(t, models.PaymentStatusCreated, f.payment(t, checkout.Payment.ID).Status)
+}
+
+func TestHandleWebhook_Failure(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	failedID, err := f.gateway.Fail(checkout.Payment.ProviderOrderID, "card declined")
+	require.NoError(t, err)
+
+	header, body, err := f.gateway.Webhook(payments.EventPaymentFailed, failedID)
+	require.NoError(t, err)
+	_, err = f.service.HandleWebhook(ctx, header, body)
+	require.NoError(t, err)
+
+	payment := f.payment(t, checkout.Payment.ID)
+	assert.Equal(t, models.PaymentStatusFailed, payment.Status)
+	assert.Equal(t, "card declined", payment.FailureReason)
+
+	// The payer retries on the same order and succeeds
+	again, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	assert.Equal(t, checkout.Payment.ID, again.Payment.ID)
+
+	confirmation, err := f.gateway.Pay(checkout.Payment.ProviderOrderID, "upi")
+	require.NoError(t, err)
+	paid, err := f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, *confirmation)
+	require.NoError(t, err)
+	assert.Equal(t, models.PaymentStatusCaptured, paid.Status)
+	assert.Empty(t, paid.FailureReason)
+}
+
+func TestAmountMismatch_NotApplied(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	confirmation, err := f.gateway.Capture(checkout.Payment.ProviderOrderID, decimal.NewFromInt(50), "upi")
+	require.NoError(t, err)
+
+	payment, err := f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, *confirmation)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReconciliationMismatch, payment.ReconciliationStatus)
+	assert.Contains(t, payment.ReconciliationNote, "captured amount 50.00")
+	assert.Nil(t, payment.ConfirmedAt)
+	assert.False(t, f.application(t).FeePaid)
+}
+
+func TestRefund(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	confirmation, err := f.gateway.Pay(checkout.Payment.ProviderOrderID, "upi")
+	require.NoError(t, err)
+	_, err = f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, *confirmation)
+	require.NoError(t, err)
+
+	_, err = f.service.Refund(ctx, RefundRequest{
+		TenantID:  f.tenantID,
+		PaymentID: checkout.Payment.ID,
+		Amount:    decimal.NewFromInt(600),
+		Reason:    "too much",
+	})
+	assert.ErrorIs(t, err, ErrInvalidRefundAmount)
+
+	payment, err := f.service.Refund(ctx, RefundRequest{
+		TenantID:  f.tenantID,
+		PaymentID: checkout.Payment.ID,
+		Amount:    decimal.NewFromInt(200),
+		Reason:    "processing fee waived",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.PaymentStatusPartiallyRefunded, payment.Status)
+	assert.True(t, payment.RefundedAmount.Equal(decimal.NewFromInt(200)))
+	require.Len(t, payment.Refunds, 1)
+	assert.Equal(t, payments.RefundProcessed, payment.Refunds[0].Status)
+	assert.True(t, f.application(t).FeePaid)
+
+	// The refund webhook for a refund already recorded changes nothing
+	header, body, err := f.gateway.Webhook(payments.EventRefundProcessed, payment.Refunds[0].ProviderRefundID)
+	require.NoError(t, err)
+	_, err = f.service.HandleWebhook(ctx, header, body)
+	require.NoError(t, err)
+	assert.True(t, f.payment(t, payment.ID).RefundedAmount.Equal(decimal.NewFromInt(200)))
+
+	// A zero amount refunds the rest
+	payment, err = f.service.Refund(ctx, RefundRequest{
+		TenantID:  f.tenantID,
+		PaymentID: checkout.Payment.ID,
+		Reason:    "application withdrawn",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.PaymentStatusRefunded, payment.Status)
+	assert.True(t, payment.RefundedAmount.Equal(decimal.NewFromInt(500)))
+	assert.Len(t, payment.Refunds, 2)
+	assert.False(t, f.application(t).FeePaid)
+
+	_, err = f.service.Refund(ctx, RefundRequest{TenantID: f.tenantID, PaymentID: checkout.Payment.ID, Reason: "again"})
+	assert.ErrorIs(t, err, ErrPaymentNotRefundable)
+}
+
+func TestReconcile(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	confirmation, err := f.gateway.Pay(checkout.Payment.ProviderOrderID, "upi")
+	require.NoError(t, err)
+	_, err = f.service.ConfirmApplicationFeeCheckout(ctx, f.tenantID, f.applicationID, *confirmation)
+	require.NoError(t, err)
+
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+
+	report, err := f.service.Reconcile(ctx, f.tenantID, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, models.ReconciliationMatched, f.payment(t, checkout.Payment.ID).ReconciliationStatus)
+
+	// A second capture on the same order at the gateway is flagged
+	_, err = f.gateway.Pay(checkout.Payment.ProviderOrderID, "card")
+	require.NoError(t, err)
+
+	report, err = f.service.Reconcile(ctx, f.tenantID, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Mismatched)
+	require.Len(t, report.Mismatches, 1)
+	assert.Equal(t, checkout.Payment.ID, report.Mismatches[0].PaymentID)
+	assert.Contains(t, strings.Join(report.Mismatches[0].Notes, "; "), "2 captured payments")
+
+	payment := f.payment(t, checkout.Payment.ID)
+	assert.Equal(t, models.ReconciliationMismatch, payment.ReconciliationStatus)
+	assert.NotNil(t, payment.ReconciledAt)
+
+	_, err = f.service.Reconcile(ctx, f.tenantID, to, from)
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+}
+
+func TestReconcile_CaptureMissingFromLedger(t *testing.T) {
+	f := setupPayments(t, true)
+	ctx := context.Background()
+
+	checkout, err := f.service.CreateApplicationFeeOrder(ctx, f.tenantID, f.applicationID)
+	require.NoError(t, err)
+	// Paid at the gateway, but neither the checkout result nor the webhook reached us
+	_, err = f.gateway.Pay(checkout.Payment.ProviderOrderID, "upi")
+	require.NoError(t, err)
+
+	report, err := f.service.Reconcile(ctx, f.tenantID, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, report.Mismatches, 1)
+	assert.Contains(t, report.Mismatches[0].Notes[0], "but the ledger shows created")
+}
@@ -0,0 +1,217 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/payments"
+)
+
+// WebhookResult describes how a webhook was handled.
+type WebhookResult struct {
+	EventID   string
+	Type      string
+	Duplicate bool
+	PaymentID *string
+}
+
+// HandleWebhook verifies and applies a gateway webhook. Each event is recorded
+// by its gateway event ID, so redeliveries are acknowledged without being
+// applied again. Events for orders the ledger does not know are recorded and ignored.
+func (s *Service) HandleWebhook(ctx context.Context, header http.Header, body []byte) (*WebhookResult, error) {
+	event, err := s.provider.ParseWebhook(header, body)
+	if err != nil {
+		if errors.Is(err, payments.ErrInvalidSignature) {
+			return nil, ErrInvalidSignature
+		}
+		return nil, err
+	}
+
+	result := &WebhookResult{EventID: event.ID, Type: event.Type}
+
+	var existing models.PaymentWebhookEvent
+	err = s.db.WithContext(ctx).
+		Where("provider = ? AND event_id = ?", s.provider.Name(), event.ID).
+		First(&existing).Error
+	switch {
+	case err == nil:
+		if existing.ProcessedAt != nil {
+			result.Duplicate = true
+			return result, nil
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("failed to get webhook event: %w", err)
+	}
+
+	record := existing
+	record.Provider = s.provider.Name()
+	record.EventID = event.ID
+	record.EventType = event.Type
+	record.Payload = string(body)
+	if record.ReceivedAt.IsZero() {
+		record.ReceivedAt = time.Now()
+	}
+
+	// Webhooks arrive without a tenant, so the lookup by gateway order bypasses RLS
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_ = tx.Exec("SET LOCAL app.bypass_rls = 'true'").Error
+
+		payment, err := s.applyEvent(tx, event)
+		if err != nil {
+			return err
+		}
+		if payment != nil {
+			record.TenantID = &payment.TenantID
+			record.PaymentID = &payment.ID
+		}
+
+		now := time.Now()
+		record.ProcessedAt = &now
+		record.Error = ""
+		return saveWebhookEvent(tx, &record)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			result.Duplicate = true
+			return result, nil
+		}
+		record.Error = err.Error()
+		record.ProcessedAt = nil
+		_ = saveWebhookEvent(s.db.WithContext(ctx), &record)
+		return nil, err
+	}
+
+	if event.Payment != nil {
+		result.PaymentID = &event.Payment.ID
+	}
+	return result, nil
+}
+
+// applyEvent applies a webhook event to the ledger and returns the payment it concerned.
+func (s *Service) applyEvent(tx *gorm.DB, event *payments.WebhookEvent) (*models.Payment, error) {
+	switch event.Type {
+	case payments.EventPaymentCaptured, payments.EventPaymentFailed:
+		if event.Payment == nil {
+			return nil, fmt.Errorf("%w: missing payment", payments.ErrInvalidWebhook)
+		}
+		payment, err := s.paymentByOrder(tx, event.Payment.OrderID)
+		if err != nil || payment == nil {
+			return nil, err
+		}
+		if event.Type == payments.EventPaymentCaptured {
+			return payment, applyCapture(tx, payment, event.Payment)
+		}
+		return payment, applyFailure(tx, payment, event.Payment)
+
+	case payments.EventRefundProcessed, payments.EventRefundFailed:
+		if event.Refund == nil {
+			return nil, fmt.Errorf("%w: missing refund", payments.ErrInvalidWebhook)
+		}
+		payment, err := s.paymentByProviderPayment(tx, event.Refund.PaymentID)
+		if err != nil || payment == nil {
+			return nil, err
+		}
+		return payment, applyRefundEvent(tx, payment, event)
+	}
+
+	return nil, nil
+}
+
+// applyRefundEvent updates a refund from its gateway outcome. Refunds made
+// from the gateway dashboard are not in the ledger yet and are added.
+func applyRefundEvent(tx *gorm.DB, payment *models.Payment, event *payments.WebhookEvent) error {
+	status := payments.RefundProcessed
+	if event.Type == payments.EventRefundFailed {
+		status = payments.RefundFailed
+	}
+
+	var refund models.PaymentRefund
+	err := tx.Where("payment_id = ? AND provider_refund_id = ?", payment.ID, event.Refund.ID).First(&refund).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		refund = models.PaymentRefund{
+			PaymentID:        payment.ID,
+			ProviderRefundID: event.Refund.ID,
+			Amount:           event.Refund.Amount,
+			Status:           status,
+			Reason:           "refunded at gateway",
+		}
+		refund.TenantID = payment.TenantID
+		return applyRefund(tx, payment, &refund)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get refund: %w", err)
+	}
+	if refund.Status == status {
+		return nil
+	}
+
+	// A failed refund returns its amount to the refundable balance
+	if status == payments.RefundFailed {
+		payment.RefundedAmount = payment.RefundedAmount.Sub(refund.Amount)
+	} else if refund.Status == payments.RefundFailed {
+		payment.RefundedAmount = payment.RefundedAmount.Add(refund.Amount)
+	}
+
+	now := time.Now()
+	refund.Status = status
+	refund.UpdatedAt = now
+	if status == payments.RefundProcessed {
+		refund.ProcessedAt = &now
+	}
+	if err := tx.Save(&refund).Error; err != nil {
+		return fmt.Errorf("failed to update refund: %w", err)
+	}
+	return updateRefundStatus(tx, payment)
+}
+
+// paymentByOrder returns the payment for a gateway order, or nil if the ledger does not know it.
+func (s *Service) paymentByOrder(tx *gorm.DB, orderID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.Where("provider = ? AND provider_order_id = ?", s.provider.Name(), orderID).First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return &payment, nil
+}
+
+// paymentByProviderPayment returns the payment for a gateway payment ID, or nil if the ledger does not know it.
+func (s *Service) paymentByProviderPayment(tx *gorm.DB, providerPaymentID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.Where("provider = ? AND provider_payment_id = ?", s.provider.Name(), providerPaymentID).First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return &payment, nil
+}
+
+// saveWebhookEvent inserts or updates a webhook event record.
+func saveWebhookEvent(tx *gorm.DB, record *models.PaymentWebhookEvent) error {
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+		return tx.Create(record).Error
+	}
+	return tx.Save(record).Error
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "duplicate key") || strings.Contains(msg, "UNIQUE constraint failed")
+}
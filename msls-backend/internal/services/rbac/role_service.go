@@ -16,6 +16,7 @@ import (
 type RoleService struct {
 	db                *gorm.DB
 	permissionService *PermissionService
+	tokens            TokenInvalidator
 }
 
 // NewRoleService creates a new RoleService instance.
@@ -26,6 +27,11 @@ func NewRoleService(db *gorm.DB, permissionService *PermissionService) *RoleServ
 	}
 }
 
+// SetTokenInvalidator sets the invalidator called when a role's permissions change.
+func (s *RoleService) SetTokenInvalidator(tokens TokenInvalidator) {
+	s.tokens = tokens
+}
+
 // CreateRoleRequest represents a request to create a role.
 type CreateRoleRequest struct {
 	TenantID      *uuid.UUID
@@ -279,6 +285,9 @@ func (s *RoleService) AssignPermissions(ctx context.Context, roleID uuid.UUID, p
 	if err := s.db.WithContext(ctx).Model(role).Association("Permissions").Append(permissions); err != nil {
 		return nil, err
 	}
+	if err := s.invalidateRoleTokens(ctx, roleID); err != nil {
+		return nil, err
+	}
 
 	return s.GetByID(ctx, roleID)
 }
@@ -305,6 +314,9 @@ func (s *RoleService) RemovePermissions(ctx context.Context, roleID uuid.UUID, p
 	if err := s.db.WithContext(ctx).Model(role).Association("Permissions").Delete(permissions); err != nil {
 		return nil, err
 	}
+	if err := s.invalidateRoleTokens(ctx, roleID); err != nil {
+		return nil, err
+	}
 
 	return s.GetByID(ctx, roleID)
 }
@@ -334,6 +346,9 @@ func (s *RoleService) SetPermissions(ctx context.Context, roleID uuid.UUID, perm
 	if err := s.db.WithContext(ctx).Model(role).Association("Permissions").Replace(permissions); err != nil {
 		return nil, err
 	}
+	if err := s.invalidateRoleTokens(ctx, roleID); err != nil {
+		return nil, err
+	}
 
 	return s.GetByID(ctx, roleID)
 }
@@ -464,3 +479,19 @@ func (s *RoleService) SeedSystemRoles(ctx context.Context) error {
 
 	return nil
 }
+
+// invalidateRoleTokens invalidates the access tokens of every user holding the role.
+func (s *RoleService) invalidateRoleTokens(ctx context.Context, roleID uuid.UUID) error {
+	if s.tokens == nil {
+		return nil
+	}
+
+	var userIDs []uuid.UUID
+	if err := s.db.WithContext(ctx).Table("user_roles").Where("role_id = ?", roleID).Pluck("user_id", &userIDs).Error; err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return s.tokens.InvalidateAccessTokens(ctx, userIDs...)
+}
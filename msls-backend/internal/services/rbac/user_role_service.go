@@ -11,10 +11,17 @@ import (
 	"msls-backend/internal/pkg/database/models"
 )
 
+// TokenInvalidator invalidates users' access tokens so that permission changes
+// take effect on their next token refresh instead of when the token expires.
+type TokenInvalidator interface {
+	InvalidateAccessTokens(ctx context.Context, userIDs ...uuid.UUID) error
+}
+
 // UserRoleService handles user-role assignment operations.
 type UserRoleService struct {
 	db          *gorm.DB
 	roleService *RoleService
+	tokens      TokenInvalidator
 }
 
 // NewUserRoleService creates a new UserRoleService instance.
@@ -25,6 +32,11 @@ func NewUserRoleService(db *gorm.DB, roleService *RoleService) *UserRoleService
 	}
 }
 
+// SetTokenInvalidator sets the invalidator called when a user's roles change.
+func (s *UserRoleService) SetTokenInvalidator(tokens TokenInvalidator) {
+	s.tokens = tokens
+}
+
 // UserRolesResponse represents a user with their roles.
 type UserRolesResponse struct {
 	UserID uuid.UUID     `json:"user_id"`
@@ -89,6 +101,9 @@ func (s *UserRoleService) AssignRoles(ctx context.Context, userID uuid.UUID, rol
 	if err := s.db.WithContext(ctx).Model(&user).Association("Roles").Append(roles); err != nil {
 		return nil, err
 	}
+	if err := s.invalidateTokens(ctx, userID); err != nil {
+		return nil, err
+	}
 
 	return s.GetUserRoles(ctx, userID)
 }
@@ -115,6 +130,9 @@ func (s *UserRoleService) RemoveRoles(ctx context.Context, userID uuid.UUID, rol
 	if err := s.db.WithContext(ctx).Model(&user).Association("Roles").Delete(roles); err != nil {
 		return nil, err
 	}
+	if err := s.invalidateTokens(ctx, userID); err != nil {
+		return nil, err
+	}
 
 	return s.GetUserRoles(ctx, userID)
 }
@@ -155,6 +173,9 @@ func (s *UserRoleService) SetRoles(ctx context.Context, userID uuid.UUID, roleID
 	if err := s.db.WithContext(ctx).Model(&user).Association("Roles").Replace(roles); err != nil {
 		return nil, err
 	}
+	if err := s.invalidateTokens(ctx, userID); err != nil {
+		return nil, err
+	}
 
 	return s.GetUserRoles(ctx, userID)
 }
@@ -246,3 +267,11 @@ func (s *UserRoleService) GetUsersWithRole(ctx context.Context, roleID uuid.UUID
 
 	return users, nil
 }
+
+// invalidateTokens invalidates the access tokens of users whose roles changed.
+func (s *UserRoleService) invalidateTokens(ctx context.Context, userIDs ...uuid.UUID) error {
+	if s.tokens == nil || len(userIDs) == 0 {
+		return nil
+	}
+	return s.tokens.InvalidateAccessTokens(ctx, userIDs...)
+}
@@ -0,0 +1,32 @@
+// Package sso provides single sign-on through tenant-configured OpenID Connect
+// and SAML 2.0 identity providers.
+package sso
+
+import "errors"
+
+// Service errors.
+var (
+	// Provider configuration errors
+	ErrProviderNotFound     = errors.New("identity provider not found")
+	ErrProviderDisabled     = errors.New("identity provider is disabled")
+	ErrProviderNameRequired = errors.New("identity provider name is required")
+	ErrInvalidProtocol      = errors.New("invalid identity provider protocol")
+	ErrOIDCConfigRequired   = errors.New("issuer URL and client ID are required for OpenID Connect")
+	ErrSAMLConfigRequired   = errors.New("metadata URL or metadata XML is required for SAML")
+	ErrInvalidSAMLMetadata  = errors.New("invalid SAML metadata")
+	ErrInvalidRoleMapping   = errors.New("role mapping refers to an unknown role")
+
+	// Login flow errors
+	ErrInvalidRedirectURI  = errors.New("redirect URI is not allowed")
+	ErrLoginRequestInvalid = errors.New("login request not found or already used")
+	ErrLoginRequestExpired = errors.New("login request has expired")
+	ErrInvalidExchangeCode = errors.New("invalid or expired exchange code")
+	ErrIdentityRejected    = errors.New("identity provider rejected the login")
+
+	// Account linking errors
+	ErrEmailMissing       = errors.New("identity provider did not return an email address")
+	ErrEmailNotVerified   = errors.New("email address is not verified by the identity provider")
+	ErrDomainNotAllowed   = errors.New("email domain is not allowed for this identity provider")
+	ErrUserNotProvisioned = errors.New("no account exists for this email address")
+	ErrIdentityConflict   = errors.New("identity is linked to a user in another tenant")
+)
@@ -0,0 +1,465 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/services/auth"
+)
+
+// identity is a user as asserted by an identity provider.
+type identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Groups        []string
+}
+
+// BeginLogin starts a login through an identity provider and returns the URL
+// to send the browser to. redirectURI is the frontend page that receives the
+// one-time exchange code once the identity provider returns.
+func (s *Service) BeginLogin(ctx context.Context, providerID uuid.UUID, redirectURI string) (string, error) {
+	if err := s.validateRedirectURI(redirectURI); err != nil {
+		return "", err
+	}
+
+	provider, err := s.loadEnabledProvider(ctx, providerID)
+	if err != nil {
+		return "", err
+	}
+
+	loginRequest := &models.SSOLoginRequest{
+		TenantID:    provider.TenantID,
+		ProviderID:  provider.ID,
+		State:       randomToken(),
+		Nonce:       randomToken(),
+		RedirectURI: redirectURI,
+		ExpiresAt:   time.Now().Add(LoginRequestTTL),
+	}
+
+	var authURL string
+	switch provider.Protocol {
+	case models.SSOProtocolOIDC:
+		authURL, err = s.oidcAuthURL(ctx, provider, loginRequest)
+	case models.SSOProtocolSAML:
+		authURL, err = s.samlAuthURL(ctx, provider, loginRequest)
+	default:
+		err = ErrInvalidProtocol
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.db.WithContext(ctx).Create(loginRequest).Error; err != nil {
+		return "", err
+	}
+	return authURL, nil
+}
+
+// CompleteOIDC handles the OpenID Connect callback and returns the frontend URL
+// to redirect to, carrying either a one-time exchange code or an error code.
+// An error is returned only when the login request itself cannot be found.
+func (s *Service) CompleteOIDC(ctx context.Context, providerID uuid.UUID, state, code, idpError string, ipAddress net.IP, userAgent string) (string, error) {
+	loginRequest, provider, err := s.pendingLogin(ctx, providerID, state)
+	if err != nil {
+		return "", err
+	}
+	if idpError != "" {
+		return s.fail(ctx, loginRequest, ErrIdentityRejected), nil
+	}
+
+	ident, err := s.oidcIdentity(ctx, provider, loginRequest, code)
+	if err != nil {
+		return s.fail(ctx, loginRequest, err), nil
+	}
+	return s.complete(ctx, loginRequest, provider, ident, ipAddress, userAgent), nil
+}
+
+// CompleteSAML handles a SAML response posted to the assertion consumer service.
+// The relay state carries the login request's state.
+func (s *Service) CompleteSAML(ctx context.Context, providerID uuid.UUID, r *http.Request, ipAddress net.IP, userAgent string) (string, error) {
+	if err := r.ParseForm(); err != nil {
+		return "", ErrLoginRequestInvalid
+	}
+
+	loginRequest, provider, err := s.pendingLogin(ctx, providerID, r.PostForm.Get("RelayState"))
+	if err != nil {
+		return "", err
+	}
+
+	ident, err := s.samlIdentity(ctx, provider, loginRequest, r)
+	if err != nil {
+		return s.fail(ctx, loginRequest, err), nil
+	}
+	return s.complete(ctx, loginRequest, provider, ident, ipAddress, userAgent), nil
+}
+
+// Exchange redeems a one-time exchange code for a token pair.
+func (s *Service) Exchange(ctx context.Context, code string, ipAddress net.IP, userAgent string) (*auth.TokenPair, *models.User, error) {
+	if code == "" {
+		return nil, nil, ErrInvalidExchangeCode
+	}
+
+	var loginRequest models.SSOLoginRequest
+	err := s.db.WithContext(ctx).
+		Where("code_hash = ? AND exchanged_at IS NULL", hashCode(code)).
+		First(&loginRequest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidExchangeCode
+		}
+		return nil, nil, err
+	}
+	if loginRequest.IsExpired() || loginRequest.UserID == nil {
+		return nil, nil, ErrInvalidExchangeCode
+	}
+
+	// Mark the code used; the condition makes concurrent redemptions lose
+	now := time.Now()
+	result := s.db.WithContext(ctx).Model(&models.SSOLoginRequest{}).
+		Where("id = ? AND exchanged_at IS NULL", loginRequest.ID).
+		Update("exchanged_at", now)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	if result.RowsAffected != 1 {
+		return nil, nil, ErrInvalidExchangeCode
+	}
+
+	user, err := s.loadUser(ctx, *loginRequest.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenPair, err := s.authService.CompleteExternalLogin(ctx, user, ipAddress, userAgent)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokenPair, user, nil
+}
+
+// pendingLogin loads an unfinished login request for the given provider.
+func (s *Service) pendingLogin(ctx context.Context, providerID uuid.UUID, state string) (*models.SSOLoginRequest, *models.IdentityProvider, error) {
+	if state == "" {
+		return nil, nil, ErrLoginRequestInvalid
+	}
+
+	var loginRequest models.SSOLoginRequest
+	err := s.db.WithContext(ctx).
+		Where("state = ? AND provider_id = ? AND completed_at IS NULL", state, providerID).
+		First(&loginRequest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrLoginRequestInvalid
+		}
+		return nil, nil, err
+	}
+
+	provider, err := s.loadEnabledProvider(ctx, providerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &loginRequest, provider, nil
+}
+
+// complete links the identity to a user, syncs roles and issues an exchange code.
+func (s *Service) complete(ctx context.Context, loginRequest *models.SSOLoginRequest, provider *models.IdentityProvider, ident *identity, ipAddress net.IP, userAgent string) string {
+	if loginRequest.IsExpired() {
+		return s.fail(ctx, loginRequest, ErrLoginRequestExpired)
+	}
+
+	user, err := s.resolveUser(ctx, provider, ident, ipAddress, userAgent)
+	if err != nil {
+		return s.fail(ctx, loginRequest, err)
+	}
+
+	code := randomToken()
+	codeHash := hashCode(code)
+	now := time.Now()
+	loginRequest.UserID = &user.ID
+	loginRequest.CodeHash = &codeHash
+	loginRequest.CompletedAt = &now
+	loginRequest.ExpiresAt = now.Add(ExchangeCodeTTL)
+	if err := s.db.WithContext(ctx).Save(loginRequest).Error; err != nil {
+		return s.fail(ctx, loginRequest, err)
+	}
+
+	return withQuery(loginRequest.RedirectURI, "code", code)
+}
+
+// fail closes the login request and returns the frontend URL carrying the error code.
+func (s *Service) fail(ctx context.Context, loginRequest *models.SSOLoginRequest, err error) string {
+	now := time.Now()
+	s.db.WithContext(ctx).Model(loginRequest).Update("completed_at", now)
+	return withQuery(loginRequest.RedirectURI, "error", ErrorCode(err))
+}
+
+// resolveUser finds the user for an identity, linking or creating it when
+// allowed, and brings the user's roles in line with their IdP groups.
+func (s *Service) resolveUser(ctx context.Context, provider *models.IdentityProvider, ident *identity, ipAddress net.IP, userAgent string) (*models.User, error) {
+	email := strings.ToLower(strings.TrimSpace(ident.Email))
+	if email == "" {
+		return nil, ErrEmailMissing
+	}
+	if provider.RequireVerifiedEmail && !ident.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+	if !domainAllowed(provider.AllowedDomains, email) {
+		return nil, ErrDomainNotAllowed
+	}
+
+	var (
+		user        models.User
+		linked      bool
+		rolesSynced bool
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the user is not authenticated yet
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+
+		now := time.Now()
+		var link models.UserIdentity
+		err := tx.Where("provider_id = ? AND subject = ?", provider.ID, ident.Subject).First(&link).Error
+		switch {
+		case err == nil:
+			if link.TenantID != provider.TenantID {
+				return ErrIdentityConflict
+			}
+			if err := tx.First(&user, "id = ?", link.UserID).Error; err != nil {
+				return err
+			}
+			link.Email = email
+			link.LastLoginAt = &now
+			if err := tx.Save(&link).Error; err != nil {
+				return err
+			}
+
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			created := false
+			err := tx.Where("tenant_id = ? AND LOWER(email) = ?", provider.TenantID, email).First(&user).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				if !provider.AutoCreateUsers {
+					return ErrUserNotProvisioned
+				}
+				user = models.User{
+					TenantID:        provider.TenantID,
+					Email:           &email,
+					FirstName:       ident.GivenName,
+					LastName:        ident.FamilyName,
+					Status:          models.StatusActive,
+					EmailVerifiedAt: &now,
+				}
+				if err := tx.Create(&user).Error; err != nil {
+					return err
+				}
+				created = true
+			} else if err != nil {
+				return err
+			}
+
+			link = models.UserIdentity{
+				TenantID:    provider.TenantID,
+				UserID:      user.ID,
+				ProviderID:  provider.ID,
+				Subject:     ident.Subject,
+				Email:       email,
+				LastLoginAt: &now,
+			}
+			if err := tx.Create(&link).Error; err != nil {
+				return err
+			}
+			linked = true
+
+			if created && provider.DefaultRoleID != nil && len(mappedRoles(provider, ident.Groups)) == 0 {
+				if err := tx.Exec("INSERT INTO user_roles (user_id, role_id) VALUES (?, ?)", user.ID, *provider.DefaultRoleID).Error; err != nil {
+					return err
+				}
+			}
+
+		default:
+			return err
+		}
+
+		rolesSynced, err = syncRoles(tx, provider, user.ID, ident.Groups)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if rolesSynced {
+		// Tokens issued before the sync carry the old permissions
+		if err := s.authService.Sessions().InvalidateAccessTokens(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	if linked {
+		s.authService.CreateAuditLog(ctx, &user, models.AuditActionSSOIdentityLinked, ipAddress, userAgent)
+	}
+	return &user, nil
+}
+
+// syncRoles grants the roles mapped from the user's groups and revokes mapped
+// roles whose groups the user has left. Roles no mapping mentions are untouched.
+func syncRoles(tx *gorm.DB, provider *models.IdentityProvider, userID uuid.UUID, groups []string) (bool, error) {
+	if len(provider.RoleMappings) == 0 {
+		return false, nil
+	}
+
+	var current []uuid.UUID
+	if err := tx.Table("user_roles").Where("user_id = ?", userID).Pluck("role_id", &current).Error; err != nil {
+		return false, err
+	}
+	held := make(map[uuid.UUID]bool, len(current))
+	for _, id := range current {
+		held[id] = true
+	}
+
+	wanted := mappedRoles(provider, groups)
+	changed := false
+	for _, mapping := range provider.RoleMappings {
+		roleID := mapping.RoleID
+		switch {
+		case wanted[roleID] && !held[roleID]:
+			if err := tx.Exec("INSERT INTO user_roles (user_id, role_id) VALUES (?, ?)", userID, roleID).Error; err != nil {
+				return false, err
+			}
+		case !wanted[roleID] && held[roleID]:
+			if err := tx.Exec("DELETE FROM user_roles WHERE user_id = ? AND role_id = ?", userID, roleID).Error; err != nil {
+				return false, err
+			}
+		default:
+			continue
+		}
+		held[roleID] = wanted[roleID]
+		changed = true
+	}
+	return changed, nil
+}
+
+// mappedRoles returns the roles the provider maps the given groups to.
+func mappedRoles(provider *models.IdentityProvider, groups []string) map[uuid.UUID]bool {
+	member := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		member[strings.ToLower(strings.TrimSpace(group))] = true
+	}
+
+	roles := make(map[uuid.UUID]bool)
+	for _, mapping := range provider.RoleMappings {
+		if member[strings.ToLower(strings.TrimSpace(mapping.Group))] {
+			roles[mapping.RoleID] = true
+		}
+	}
+	return roles
+}
+
+// loadUser loads a user with roles and permissions, bypassing RLS.
+func (s *Service) loadUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Preload("Roles.Permissions").First(&user, "id = ?", userID).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, auth.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// validateRedirectURI checks the redirect URI is an absolute URL on an allowed origin.
+func (s *Service) validateRedirectURI(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return ErrInvalidRedirectURI
+	}
+
+	origin := strings.ToLower(u.Scheme + "://" + u.Host)
+	for _, allowed := range s.config.AllowedRedirectOrigins {
+		if strings.ToLower(strings.TrimRight(allowed, "/")) == origin {
+			return nil
+		}
+	}
+	return ErrInvalidRedirectURI
+}
+
+// ErrorCode returns the error code passed to the frontend for a failed login.
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrEmailMissing):
+		return "email_missing"
+	case errors.Is(err, ErrEmailNotVerified):
+		return "email_not_verified"
+	case errors.Is(err, ErrDomainNotAllowed):
+		return "domain_not_allowed"
+	case errors.Is(err, ErrUserNotProvisioned):
+		return "user_not_provisioned"
+	case errors.Is(err, ErrLoginRequestExpired):
+		return "login_expired"
+	case errors.Is(err, ErrIdentityRejected):
+		return "access_denied"
+	default:
+		return "login_failed"
+	}
+}
+
+// domainAllowed reports whether the email's domain is in the allow list.
+// An empty allow list accepts every domain.
+func domainAllowed(domains []string, email string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	for _, allowed := range domains {
+		if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// withQuery returns rawURL with the query parameter set.
+func withQuery(rawURL, key, value string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// randomToken returns a random URL-safe token.
+func randomToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// hashCode hashes an exchange code for storage.
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,153 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// defaultOIDCScopes are requested when a provider does not configure scopes.
+var defaultOIDCScopes = []string{oidc.ScopeOpenID, "email", "profile"}
+
+// defaultGroupsClaim is the ID token claim read for group membership.
+const defaultGroupsClaim = "groups"
+
+// oidcAuthURL builds the authorization URL and records the PKCE verifier on the login request.
+func (s *Service) oidcAuthURL(ctx context.Context, provider *models.IdentityProvider, loginRequest *models.SSOLoginRequest) (string, error) {
+	config, _, err := s.oauth2Config(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+
+	loginRequest.CodeVerifier = oauth2.GenerateVerifier()
+	return config.AuthCodeURL(loginRequest.State,
+		oidc.Nonce(loginRequest.Nonce),
+		oauth2.S256ChallengeOption(loginRequest.CodeVerifier),
+	), nil
+}
+
+// oidcIdentity exchanges the authorization code and verifies the ID token.
+func (s *Service) oidcIdentity(ctx context.Context, provider *models.IdentityProvider, loginRequest *models.SSOLoginRequest, code string) (*identity, error) {
+	config, oidcProvider, err := s.oauth2Config(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = oidc.ClientContext(ctx, s.config.HTTPClient)
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(loginRequest.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	idToken, err := oidcProvider.Verifier(&oidc.Config{ClientID: provider.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify id_token: %w", err)
+	}
+	if idToken.Nonce != loginRequest.Nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+
+	groupsClaim := provider.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = defaultGroupsClaim
+	}
+
+	ident := &identity{
+		Subject:    idToken.Subject,
+		Email:      stringClaim(claims, "email"),
+		GivenName:  stringClaim(claims, "given_name"),
+		FamilyName: stringClaim(claims, "family_name"),
+		Groups:     stringsClaim(claims, groupsClaim),
+	}
+	// Some providers send email_verified as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		ident.EmailVerified = verified
+	case string:
+		ident.EmailVerified = verified == "true"
+	}
+	return ident, nil
+}
+
+// oauth2Config returns the OAuth2 client configuration for a provider.
+func (s *Service) oauth2Config(ctx context.Context, provider *models.IdentityProvider) (*oauth2.Config, *oidc.Provider, error) {
+	oidcProvider, err := s.discover(ctx, provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientSecret, err := s.decrypt(provider.ClientSecretEncrypted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scopes := []string(provider.Scopes)
+	if len(scopes) == 0 {
+		scopes = defaultOIDCScopes
+	}
+
+	return &oauth2.Config{
+		ClientID:     provider.ClientID,
+		ClientSecret: clientSecret,
+		Endpoint:     oidcProvider.Endpoint(),
+		RedirectURL:  s.callbackURL(provider.ID, "callback"),
+		Scopes:       scopes,
+	}, oidcProvider, nil
+}
+
+// discover fetches and caches the provider's OpenID configuration.
+func (s *Service) discover(ctx context.Context, provider *models.IdentityProvider) (*oidc.Provider, error) {
+	s.mu.Lock()
+	cached, ok := s.oidcProviders[provider.ID]
+	s.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	oidcProvider, err := oidc.NewProvider(oidc.ClientContext(ctx, s.config.HTTPClient), provider.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("discover OpenID provider: %w", err)
+	}
+
+	s.mu.Lock()
+	s.oidcProviders[provider.ID] = oidcProvider
+	s.mu.Unlock()
+	return oidcProvider, nil
+}
+
+// stringClaim returns a string claim, or "" when absent.
+func stringClaim(claims map[string]interface{}, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// stringsClaim returns a claim holding a string or a list of strings.
+func stringsClaim(claims map[string]interface{}, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package sso
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	"github.com/google/uuid"
+
+	"msls-backend/internal/pkg/database/models"
+)
+
+// SAML attribute names checked, in order, when the provider sends no configured name.
+var (
+	samlEmailAttributes = []string{
+		"urn:oid:0.9.2342.19200300.100.1.3",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"mail",
+		"email",
+	}
+	samlGivenNameAttributes = []string{
+		"urn:oid:2.5.4.42",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname",
+		"givenName",
+	}
+	samlSurnameAttributes = []string{
+		"urn:oid:2.5.4.4",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname",
+		"sn",
+		"surname",
+	}
+	samlGroupAttributes = []string{
+		"http://schemas.microsoft.com/ws/2008/06/identity/claims/groups",
+		"groups",
+		"memberOf",
+		"urn:oid:1.3.6.1.4.1.5923.1.1.1.1",
+		"eduPersonAffiliation",
+	}
+)
+
+// ServiceProviderMetadata returns the SAML service provider metadata to register with the IdP.
+func (s *Service) ServiceProviderMetadata(ctx context.Context, providerID uuid.UUID) ([]byte, error) {
+	provider, err := s.loadEnabledProvider(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Protocol != models.SSOProtocolSAML {
+		return nil, ErrProviderNotFound
+	}
+
+	sp, err := s.serviceProvider(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	return xml.MarshalIndent(sp.Metadata(), "", "  ")
+}
+
+// samlAuthURL builds the redirect-binding AuthnRequest URL and records its ID on the login request.
+func (s *Service) samlAuthURL(ctx context.Context, provider *models.IdentityProvider, loginRequest *models.SSOLoginRequest) (string, error) {
+	sp, err := s.serviceProvider(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+
+	authnRequest, err := sp.MakeAuthenticationRequest(
+		sp.GetSSOBindingLocation(saml.HTTPRedirectBinding),
+		saml.HTTPRedirectBinding,
+		saml.HTTPPostBinding,
+	)
+	if err != nil {
+		return "", err
+	}
+	redirectURL, err := authnRequest.Redirect(loginRequest.State, sp)
+	if err != nil {
+		return "", err
+	}
+
+	loginRequest.SAMLRequestID = authnRequest.ID
+	return redirectURL.String(), nil
+}
+
+// samlIdentity validates the posted SAML response and reads the asserted identity.
+// Email addresses asserted by a SAML IdP are treated as verified.
+func (s *Service) samlIdentity(ctx context.Context, provider *models.IdentityProvider, loginRequest *models.SSOLoginRequest, r *http.Request) (*identity, error) {
+	sp, err := s.serviceProvider(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	assertion, err := sp.ParseResponse(r, []string{loginRequest.SAMLRequestID})
+	if err != nil {
+		return nil, fmt.Errorf("parse SAML response: %w", err)
+	}
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		return nil, fmt.Errorf("SAML assertion has no subject")
+	}
+
+	groupAttributes := samlGroupAttributes
+	if provider.GroupsClaim != "" {
+		groupAttributes = []string{provider.GroupsClaim}
+	}
+
+	nameID := assertion.Subject.NameID.Value
+	ident := &identity{
+		Subject:       nameID,
+		Email:         firstAttribute(assertion, samlEmailAttributes),
+		EmailVerified: true,
+		GivenName:     firstAttribute(assertion, samlGivenNameAttributes),
+		FamilyName:    firstAttribute(assertion, samlSurnameAttributes),
+		Groups:        attributeValues(assertion, groupAttributes),
+	}
+	if ident.Email == "" && strings.Contains(nameID, "@") {
+		ident.Email = nameID
+	}
+	return ident, nil
+}
+
+// serviceProvider builds the SAML service provider for an identity provider.
+func (s *Service) serviceProvider(ctx context.Context, provider *models.IdentityProvider) (*saml.ServiceProvider, error) {
+	idpMetadata, err := s.idpMetadata(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataURL, err := url.Parse(s.callbackURL(provider.ID, "metadata"))
+	if err != nil {
+		return nil, err
+	}
+	acsURL, err := url.Parse(s.callbackURL(provider.ID, "acs"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &saml.ServiceProvider{
+		EntityID:          metadataURL.String(),
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		IDPMetadata:       idpMetadata,
+		HTTPClient:        s.config.HTTPClient,
+		AuthnNameIDFormat: saml.PersistentNameIDFormat,
+	}, nil
+}
+
+// idpMetadata parses or fetches and caches the identity provider's metadata.
+func (s *Service) idpMetadata(ctx context.Context, provider *models.IdentityProvider) (*saml.EntityDescriptor, error) {
+	s.mu.Lock()
+	cached, ok := s.samlMetadata[provider.ID]
+	s.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var (
+		metadata *saml.EntityDescriptor
+		err      error
+	)
+	if provider.MetadataXML != "" {
+		metadata, err = parseIDPMetadata([]byte(provider.MetadataXML))
+	} else {
+		var metadataURL *url.URL
+		metadataURL, err = url.Parse(provider.MetadataURL)
+		if err == nil {
+			metadata, err = samlsp.FetchMetadata(ctx, s.config.HTTPClient, *metadataURL)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load SAML metadata: %w", err)
+	}
+
+	s.mu.Lock()
+	s.samlMetadata[provider.ID] = metadata
+	s.mu.Unlock()
+	return metadata, nil
+}
+
+// forgetProvider drops cached discovery documents after a provider changes.
+func (s *Service) forgetProvider(id uuid.UUID) {
+	s.mu.Lock()
+	delete(s.oidcProviders, id)
+	delete(s.samlMetadata, id)
+	s.mu.Unlock()
+}
+
+// parseIDPMetadata parses IdP metadata and checks it offers single sign-on.
+func parseIDPMetadata(data []byte) (*saml.EntityDescriptor, error) {
+	metadata, err := samlsp.ParseMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata.IDPSSODescriptors) == 0 {
+		return nil, ErrInvalidSAMLMetadata
+	}
+	return metadata, nil
+}
+
+// firstAttribute returns the first value of the first present attribute.
+func firstAttribute(assertion *saml.Assertion, names []string) string {
+	for _, name := range names {
+		if values := attributeValues(assertion, []string{name}); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// attributeValues returns the values of the attributes matching any of the
+// names, compared case-insensitively against Name and FriendlyName.
+func attributeValues(assertion *saml.Assertion, names []string) []string {
+	var values []string
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			if !matchesAttribute(attribute, names) {
+				continue
+			}
+			for _, value := range attribute.Values {
+				if value.Value != "" {
+					values = append(values, value.Value)
+				}
+			}
+		}
+	}
+	return values
+}
+
+func matchesAttribute(attribute saml.Attribute, names []string) bool {
+	for _, name := range names {
+		if strings.EqualFold(attribute.Name, name) || strings.EqualFold(attribute.FriendlyName, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,425 @@
+// Package sso provides single sign-on through tenant-configured OpenID Connect
+// and SAML 2.0 identity providers.
+package sso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/crewjam/saml"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/crypto"
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/services/auth"
+)
+
+// Login flow lifetimes.
+const (
+	// LoginRequestTTL bounds the time a user may spend at the identity provider.
+	LoginRequestTTL = 10 * time.Minute
+	// ExchangeCodeTTL bounds the time the frontend has to redeem its one-time code.
+	ExchangeCodeTTL = time.Minute
+)
+
+// Config holds configuration for the SSO service.
+type Config struct {
+	// BaseURL is the public URL of the API, used to build callback URLs.
+	BaseURL string
+	// AllowedRedirectOrigins lists the frontend origins a login may return to.
+	AllowedRedirectOrigins []string
+	// EncryptionKey is the data encryption key; client secrets are encrypted
+	// at rest with a key derived from it.
+	EncryptionKey string
+	// HTTPClient is used to reach identity providers. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Service manages identity providers and the SSO login flow.
+type Service struct {
+	db            *gorm.DB
+	authService   *auth.AuthService
+	config        Config
+	encryptionKey []byte
+
+	// Identity provider discovery documents, by provider ID
+	mu            sync.Mutex
+	oidcProviders map[uuid.UUID]*oidc.Provider
+	samlMetadata  map[uuid.UUID]*saml.EntityDescriptor
+}
+
+// NewService creates a new SSO service.
+func NewService(db *gorm.DB, authService *auth.AuthService, config Config) *Service {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+
+	return &Service{
+		db:            db,
+		authService:   authService,
+		config:        config,
+		encryptionKey: crypto.DeriveKey(config.EncryptionKey, "sso-client-secret"),
+		oidcProviders: make(map[uuid.UUID]*oidc.Provider),
+		samlMetadata:  make(map[uuid.UUID]*saml.EntityDescriptor),
+	}
+}
+
+// CreateProviderRequest represents a request to create an identity provider.
+type CreateProviderRequest struct {
+	TenantID             uuid.UUID
+	Name                 string
+	Protocol             models.SSOProtocol
+	Enabled              bool
+	IssuerURL            string
+	ClientID             string
+	ClientSecret         string
+	Scopes               []string
+	MetadataURL          string
+	MetadataXML          string
+	AllowedDomains       []string
+	RequireVerifiedEmail bool
+	AutoCreateUsers      bool
+	GroupsClaim          string
+	RoleMappings         []models.SSORoleMapping
+	DefaultRoleID        *uuid.UUID
+}
+
+// UpdateProviderRequest represents a request to update an identity provider.
+// Nil fields are left unchanged.
+type UpdateProviderRequest struct {
+	Name                 *string
+	Enabled              *bool
+	IssuerURL            *string
+	ClientID             *string
+	ClientSecret         *string
+	Scopes               []string
+	MetadataURL          *string
+	MetadataXML          *string
+	AllowedDomains       []string
+	RequireVerifiedEmail *bool
+	AutoCreateUsers      *bool
+	GroupsClaim          *string
+	RoleMappings         []models.SSORoleMapping
+	DefaultRoleID        *uuid.UUID
+	ClearDefaultRole     bool
+}
+
+// ListProviders returns a tenant's identity providers.
+func (s *Service) ListProviders(ctx context.Context, tenantID uuid.UUID) ([]models.IdentityProvider, error) {
+	var providers []models.IdentityProvider
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&providers).Error
+	return providers, err
+}
+
+// ListEnabledProviders returns the identity providers offered on a tenant's login page.
+func (s *Service) ListEnabledProviders(ctx context.Context, tenantID uuid.UUID) ([]models.IdentityProvider, error) {
+	var providers []models.IdentityProvider
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bypass RLS - the login page is shown before authentication
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.Where("tenant_id = ? AND enabled = ?", tenantID, true).
+			Order("name ASC").
+			Find(&providers).Error
+	})
+	return providers, err
+}
+
+// GetProvider returns one of a tenant's identity providers.
+func (s *Service) GetProvider(ctx context.Context, tenantID, id uuid.UUID) (*models.IdentityProvider, error) {
+	var provider models.IdentityProvider
+	err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&provider).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProviderNotFound
+		}
+		return nil, err
+	}
+	return &provider, nil
+}
+
+// CreateProvider creates an identity provider.
+func (s *Service) CreateProvider(ctx context.Context, req CreateProviderRequest) (*models.IdentityProvider, error) {
+	provider := &models.IdentityProvider{
+		Name:                 strings.TrimSpace(req.Name),
+		Protocol:             req.Protocol,
+		Enabled:              req.Enabled,
+		IssuerURL:            strings.TrimSpace(req.IssuerURL),
+		ClientID:             strings.TrimSpace(req.ClientID),
+		Scopes:               req.Scopes,
+		MetadataURL:          strings.TrimSpace(req.MetadataURL),
+		MetadataXML:          req.MetadataXML,
+		AllowedDomains:       normalizeDomains(req.AllowedDomains),
+		RequireVerifiedEmail: req.RequireVerifiedEmail,
+		AutoCreateUsers:      req.AutoCreateUsers,
+		GroupsClaim:          strings.TrimSpace(req.GroupsClaim),
+		RoleMappings:         req.RoleMappings,
+		DefaultRoleID:        req.DefaultRoleID,
+	}
+	provider.TenantID = req.TenantID
+	if provider.RoleMappings == nil {
+		provider.RoleMappings = []models.SSORoleMapping{}
+	}
+
+	if req.ClientSecret != "" {
+		encrypted, err := s.encrypt(req.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+		provider.ClientSecretEncrypted = encrypted
+	}
+
+	if err := s.validateProvider(ctx, provider); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(provider).Error; err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// UpdateProvider updates an identity provider.
+func (s *Service) UpdateProvider(ctx context.Context, tenantID, id uuid.UUID, req UpdateProviderRequest) (*models.IdentityProvider, error) {
+	provider, err := s.GetProvider(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		provider.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Enabled != nil {
+		provider.Enabled = *req.Enabled
+	}
+	if req.IssuerURL != nil {
+		provider.IssuerURL = strings.TrimSpace(*req.IssuerURL)
+	}
+	if req.ClientID != nil {
+		provider.ClientID = strings.TrimSpace(*req.ClientID)
+	}
+	if req.ClientSecret != nil {
+		provider.ClientSecretEncrypted = ""
+		if *req.ClientSecret != "" {
+			encrypted, err := s.encrypt(*req.ClientSecret)
+			if err != nil {
+				return nil, err
+			}
+			provider.ClientSecretEncrypted = encrypted
+		}
+	}
+	if req.Scopes != nil {
+		provider.Scopes = req.Scopes
+	}
+	if req.MetadataURL != nil {
+		provider.MetadataURL = strings.TrimSpace(*req.MetadataURL)
+	}
+	if req.MetadataXML != nil {
+		provider.MetadataXML = *req.MetadataXML
+	}
+	if req.AllowedDomains != nil {
+		provider.AllowedDomains = normalizeDomains(req.AllowedDomains)
+	}
+	if req.RequireVerifiedEmail != nil {
+		provider.RequireVerifiedEmail = *req.RequireVerifiedEmail
+	}
+	if req.AutoCreateUsers != nil {
+		provider.AutoCreateUsers = *req.AutoCreateUsers
+	}
+	if req.GroupsClaim != nil {
+		provider.GroupsClaim = strings.TrimSpace(*req.GroupsClaim)
+	}
+	if req.RoleMappings != nil {
+		provider.RoleMappings = req.RoleMappings
+	}
+	if req.DefaultRoleID != nil {
+		provider.DefaultRoleID = req.DefaultRoleID
+	}
+	if req.ClearDefaultRole {
+		provider.DefaultRoleID = nil
+	}
+
+	if err := s.validateProvider(ctx, provider); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Save(provider).Error; err != nil {
+		return nil, err
+	}
+	s.forgetProvider(provider.ID)
+	return provider, nil
+}
+
+// DeleteProvider deletes an identity provider and the identities linked through it.
+func (s *Service) DeleteProvider(ctx context.Context, tenantID, id uuid.UUID) error {
+	provider, err := s.GetProvider(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("provider_id = ?", provider.ID).Delete(&models.UserIdentity{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("provider_id = ?", provider.ID).Delete(&models.SSOLoginRequest{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(provider).Error
+	})
+	if err != nil {
+		return err
+	}
+	s.forgetProvider(provider.ID)
+	return nil
+}
+
+// validateProvider checks protocol settings and that mapped roles belong to the tenant.
+func (s *Service) validateProvider(ctx context.Context, provider *models.IdentityProvider) error {
+	if provider.Name == "" {
+		return ErrProviderNameRequired
+	}
+
+	switch provider.Protocol {
+	case models.SSOProtocolOIDC:
+		if provider.IssuerURL == "" || provider.ClientID == "" {
+			return ErrOIDCConfigRequired
+		}
+	case models.SSOProtocolSAML:
+		if provider.MetadataURL == "" && provider.MetadataXML == "" {
+			return ErrSAMLConfigRequired
+		}
+		if provider.MetadataXML != "" {
+			if _, err := parseIDPMetadata([]byte(provider.MetadataXML)); err != nil {
+				return ErrInvalidSAMLMetadata
+			}
+		}
+	default:
+		return ErrInvalidProtocol
+	}
+
+	roleIDs := make([]uuid.UUID, 0, len(provider.RoleMappings)+1)
+	for _, mapping := range provider.RoleMappings {
+		if strings.TrimSpace(mapping.Group) == "" {
+			return ErrInvalidRoleMapping
+		}
+		roleIDs = append(roleIDs, mapping.RoleID)
+	}
+	if provider.DefaultRoleID != nil {
+		roleIDs = append(roleIDs, *provider.DefaultRoleID)
+	}
+	if len(roleIDs) == 0 {
+		return nil
+	}
+
+	// Roles must be system roles or belong to the provider's tenant
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.Role{}).
+		Where("id IN ? AND (tenant_id IS NULL OR tenant_id = ?)", uniqueIDs(roleIDs), provider.TenantID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(uniqueIDs(roleIDs)) {
+		return ErrInvalidRoleMapping
+	}
+	return nil
+}
+
+// loadEnabledProvider loads a provider for the login flow, which runs before authentication.
+func (s *Service) loadEnabledProvider(ctx context.Context, id uuid.UUID) (*models.IdentityProvider, error) {
+	var provider models.IdentityProvider
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx.Exec("SET LOCAL app.bypass_rls = 'true'")
+		return tx.First(&provider, "id = ?", id).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProviderNotFound
+		}
+		return nil, err
+	}
+	if !provider.Enabled {
+		return nil, ErrProviderDisabled
+	}
+
+	var tenant models.Tenant
+	if err := s.db.WithContext(ctx).First(&tenant, "id = ?", provider.TenantID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, auth.ErrTenantNotFound
+		}
+		return nil, err
+	}
+	if tenant.Status != models.StatusActive {
+		return nil, auth.ErrTenantInactive
+	}
+	return &provider, nil
+}
+
+// CallbackURL returns the URL to register with the identity provider: the OIDC
+// redirect URI or the SAML assertion consumer service URL.
+func (s *Service) CallbackURL(provider *models.IdentityProvider) string {
+	if provider.Protocol == models.SSOProtocolSAML {
+		return s.callbackURL(provider.ID, "acs")
+	}
+	return s.callbackURL(provider.ID, "callback")
+}
+
+// ServiceProviderMetadataURL returns the SAML service provider metadata URL,
+// which is also the service provider's entity ID.
+func (s *Service) ServiceProviderMetadataURL(provider *models.IdentityProvider) string {
+	return s.callbackURL(provider.ID, "metadata")
+}
+
+// callbackURL returns the URL the identity provider sends the user back to.
+func (s *Service) callbackURL(providerID uuid.UUID, endpoint string) string {
+	return fmt.Sprintf("%s/api/v1/auth/sso/%s/%s", s.config.BaseURL, providerID, endpoint)
+}
+
+// encrypt encrypts a client secret for storage.
+func (s *Service) encrypt(plaintext string) (string, error) {
+	return crypto.Encrypt(s.encryptionKey, plaintext)
+}
+
+// decrypt decrypts a client secret encrypted with encrypt.
+func (s *Service) decrypt(ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+	return crypto.Decrypt(s.encryptionKey, ciphertext)
+}
+
+// normalizeDomains lower-cases domains and strips any leading "@".
+func normalizeDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain != "" {
+			normalized = append(normalized, domain)
+		}
+	}
+	return normalized
+}
+
+// uniqueIDs removes duplicate IDs, preserving order.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,442 @@
+package sso
+
+import (
+	"context"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"msls-backend/internal/pkg/database/models"
+	"msls-backend/internal/pkg/mockidp"
+	"msls-backend/internal/services/auth"
+)
+
+const (
+	testClientID     = "msls"
+	testClientSecret = "mock-secret"
+	testFrontend     = "http://app.test/auth/sso"
+)
+
+// samlFormField matches the hidden inputs of the IdP's auto-submitting POST form.
+var samlFormField = regexp.MustCompile(`name="(SAMLResponse|RelayState)" value="([^"]*)"`)
+
+// ssoTestEnv wires the SSO service to a mock identity provider and a minimal API server.
+type ssoTestEnv struct {
+	db      *gorm.DB
+	tenant  *models.Tenant
+	service *Service
+	idp     *mockidp.Server
+	idpURL  string
+	client  *http.Client
+}
+
+// setupSSOTestDB creates an in-memory SQLite database for testing.
+func setupSSOTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// The API test server runs on other goroutines; one connection keeps one database
+	sqlDB.SetMaxOpenConns(1)
+
+	statements := []string{
+		`CREATE TABLE tenants (
+			id TEXT PRIMARY KEY,
+			created_at DATETIME, updated_at DATETIME,
+			name TEXT NOT NULL, slug TEXT NOT NULL UNIQUE,
+			settings TEXT DEFAULT '{}', status TEXT NOT NULL DEFAULT 'active'
+		)`,
+		`CREATE TABLE users (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL,
+			created_at DATETIME, updated_at DATETIME, created_by TEXT, updated_by TEXT,
+			email TEXT, phone TEXT, password_hash TEXT,
+			first_name TEXT DEFAULT '', last_name TEXT DEFAULT '',
+			avatar_url TEXT, bio TEXT, timezone TEXT DEFAULT 'UTC', locale TEXT DEFAULT 'en',
+			notification_preferences TEXT DEFAULT '{}',
+			status TEXT NOT NULL DEFAULT 'active',
+			two_factor_enabled INTEGER DEFAULT 0, two_factor_secret TEXT, totp_verified_at DATETIME,
+			email_verified_at DATETIME, phone_verified_at DATETIME,
+			last_login_at DATETIME, last_login_ip TEXT, locked_until DATETIME,
+			failed_login_attempts INTEGER DEFAULT 0, account_deletion_requested_at DATETIME,
+			token_version INTEGER NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE roles (
+			id TEXT PRIMARY KEY, tenant_id TEXT,
+			created_at DATETIME, updated_at DATETIME,
+			name TEXT NOT NULL, description TEXT, is_system INTEGER DEFAULT 0
+		)`,
+		`CREATE TABLE permissions (
+			id TEXT PRIMARY KEY, created_at DATETIME, updated_at DATETIME,
+			code TEXT NOT NULL UNIQUE, name TEXT NOT NULL, description TEXT, module TEXT NOT NULL
+		)`,
+		`CREATE TABLE user_roles (user_id TEXT NOT NULL, role_id TEXT NOT NULL, PRIMARY KEY (user_id, role_id))`,
+		`CREATE TABLE role_permissions (role_id TEXT NOT NULL, permission_id TEXT NOT NULL, PRIMARY KEY (role_id, permission_id))`,
+		`CREATE TABLE refresh_tokens (
+			id TEXT PRIMARY KEY, user_id TEXT NOT NULL,
+			created_at DATETIME, updated_at DATETIME,
+			token_hash TEXT NOT NULL UNIQUE, expires_at DATETIME NOT NULL,
+			revoked_at DATETIME, session_id TEXT
+		)`,
+		`CREATE TABLE user_sessions (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, user_id TEXT NOT NULL,
+			created_at DATETIME, updated_at DATETIME,
+			device TEXT, user_agent TEXT, ip_address TEXT, location TEXT,
+			last_used_at DATETIME NOT NULL, expires_at DATETIME NOT NULL,
+			revoked_at DATETIME, revoked_reason TEXT
+		)`,
+		`CREATE TABLE login_attempts (
+			id TEXT PRIMARY KEY, user_id TEXT, email TEXT, ip_address TEXT, user_agent TEXT,
+			success INTEGER, failure_reason TEXT, created_at DATETIME
+		)`,
+		`CREATE TABLE audit_logs (
+			id TEXT PRIMARY KEY, tenant_id TEXT, user_id TEXT,
+			action TEXT NOT NULL, entity_type TEXT NOT NULL, entity_id TEXT,
+			old_data TEXT, new_data TEXT, ip_address TEXT, user_agent TEXT, created_at DATETIME
+		)`,
+		`CREATE TABLE identity_providers (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL,
+			created_at DATETIME, updated_at DATETIME, created_by TEXT, updated_by TEXT,
+			name TEXT NOT NULL, protocol TEXT NOT NULL, enabled INTEGER NOT NULL DEFAULT 1,
+			issuer_url TEXT, client_id TEXT, client_secret_encrypted TEXT, scopes TEXT,
+			metadata_url TEXT, metadata_xml TEXT,
+			allowed_domains TEXT, require_verified_email INTEGER NOT NULL DEFAULT 1,
+			auto_create_users INTEGER NOT NULL DEFAULT 0, groups_claim TEXT,
+			role_mappings TEXT NOT NULL DEFAULT '[]', default_role_id TEXT
+		)`,
+		`CREATE TABLE user_identities (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, user_id TEXT NOT NULL,
+			provider_id TEXT NOT NULL, subject TEXT NOT NULL, email TEXT, last_login_at DATETIME,
+			created_at DATETIME, updated_at DATETIME,
+			UNIQUE (provider_id, subject)
+		)`,
+		`CREATE TABLE sso_login_requests (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, provider_id TEXT NOT NULL,
+			state TEXT NOT NULL UNIQUE, nonce TEXT, code_verifier TEXT, saml_request_id TEXT,
+			redirect_uri TEXT NOT NULL, user_id TEXT, code_hash TEXT UNIQUE,
+			expires_at DATETIME NOT NULL, completed_at DATETIME, exchanged_at DATETIME,
+			created_at DATETIME, updated_at DATETIME
+		)`,
+	}
+	for _, statement := range statements {
+		require.NoError(t, db.Exec(statement).Error)
+	}
+	return db
+}
+
+// setupSSOTestEnv starts a mock identity provider and an API server exposing the SSO endpoints.
+func setupSSOTestEnv(t *testing.T, user mockidp.User) *ssoTestEnv {
+	t.Helper()
+
+	db := setupSSOTestDB(t)
+	tenant := &models.Tenant{Name: "Test School", Slug: "test-school", Status: models.StatusActive}
+	require.NoError(t, db.Create(tenant).Error)
+
+	idp, err := mockidp.New(mockidp.Config{ClientID: testClientID, ClientSecret: testClientSecret, User: user})
+	require.NoError(t, err)
+	idpServer := httptest.NewServer(idp.Handler())
+	t.Cleanup(idpServer.Close)
+
+	env := &ssoTestEnv{db: db, tenant: tenant, idp: idp, idpURL: idpServer.URL}
+
+	// The mock IdP fetches SP metadata from our entity ID, so the API must be reachable
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/auth/sso/{id}/metadata", func(w http.ResponseWriter, r *http.Request) {
+		metadata, err := env.service.ServiceProviderMetadata(r.Context(), uuid.MustParse(r.PathValue("id")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(metadata)
+	})
+	mux.HandleFunc("GET /api/v1/auth/sso/{id}/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		redirectURL, err := env.service.CompleteOIDC(r.Context(), uuid.MustParse(r.PathValue("id")),
+			query.Get("state"), query.Get("code"), query.Get("error"), nil, "test")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+	})
+	mux.HandleFunc("POST /api/v1/auth/sso/{id}/acs", func(w http.ResponseWriter, r *http.Request) {
+		redirectURL, err := env.service.CompleteSAML(r.Context(), uuid.MustParse(r.PathValue("id")), r, nil, "test")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+	})
+	apiServer := httptest.NewServer(mux)
+	t.Cleanup(apiServer.Close)
+
+	jwtService := auth.NewJWTService(auth.JWTConfig{
+		Secret:     "test-secret-key-at-least-32-bytes-long",
+		Issuer:     "msls-test",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 24 * time.Hour,
+	})
+	env.service = NewService(db, auth.NewAuthService(db, jwtService), Config{
+		BaseURL:                apiServer.URL,
+		AllowedRedirectOrigins: []string{"http://app.test"},
+		EncryptionKey:          "test-encryption-key",
+	})
+
+	// Browser stand-in that stops at each redirect so the test can follow the flow
+	env.client = &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	return env
+}
+
+func (e *ssoTestEnv) createUser(t *testing.T, email string) *models.User {
+	t.Helper()
+	user := &models.User{TenantID: e.tenant.ID, Email: &email, FirstName: "Asha", Status: models.StatusActive}
+	require.NoError(t, e.db.Create(user).Error)
+	return user
+}
+
+func (e *ssoTestEnv) createRole(t *testing.T, name string) *models.Role {
+	t.Helper()
+	role := &models.Role{TenantID: &e.tenant.ID, Name: name}
+	require.NoError(t, e.db.Create(role).Error)
+	return role
+}
+
+func (e *ssoTestEnv) createOIDCProvider(t *testing.T, req CreateProviderRequest) *models.IdentityProvider {
+	t.Helper()
+	req.TenantID = e.tenant.ID
+	req.Name = "Mock OIDC"
+	req.Protocol = models.SSOProtocolOIDC
+	req.Enabled = true
+	req.IssuerURL = e.idpURL
+	req.ClientID = testClientID
+	req.ClientSecret = testClientSecret
+	provider, err := e.service.CreateProvider(context.Background(), req)
+	require.NoError(t, err)
+	return provider
+}
+
+// login runs the browser side of a login and returns the frontend redirect's query.
+func (e *ssoTestEnv) login(t *testing.T, provider *models.IdentityProvider) url.Values {
+	t.Helper()
+
+	authURL, err := e.service.BeginLogin(context.Background(), provider.ID, testFrontend)
+	require.NoError(t, err)
+
+	resp, err := e.client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if provider.Protocol == models.SSOProtocolSAML {
+		// The IdP answers with an auto-submitting form posting to the ACS
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		form := url.Values{}
+		for _, match := range samlFormField.FindAllStringSubmatch(string(body), -1) {
+			form.Set(match[1], html.UnescapeString(match[2]))
+		}
+		require.NotEmpty(t, form.Get("SAMLResponse"), string(body))
+		resp, err = e.client.PostForm(e.service.CallbackURL(provider), form)
+	} else {
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		resp, err = e.client.Get(resp.Header.Get("Location"))
+	}
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "app.test", location.Host)
+	return location.Query()
+}
+
+func TestOIDCLogin_LinksUserByEmailAndMapsGroups(t *testing.T) {
+	env := setupSSOTestEnv(t, mockidp.User{
+		Subject: "idp-123", Email: "Asha@School.edu", EmailVerified: true, Groups: []string{"Teachers"},
+	})
+	user := env.createUser(t, "asha@school.edu")
+	teacher := env.createRole(t, "Teacher")
+	librarian := env.createRole(t, "Librarian")
+	provider := env.createOIDCProvider(t, CreateProviderRequest{
+		AllowedDomains:       []string{"@School.edu"},
+		RequireVerifiedEmail: true,
+		RoleMappings:         []models.SSORoleMapping{{Group: "teachers", RoleID: teacher.ID}, {Group: "library", RoleID: librarian.ID}},
+	})
+
+	query := env.login(t, provider)
+	require.Empty(t, query.Get("error"))
+
+	pair, loggedIn, err := env.service.Exchange(context.Background(), query.Get("code"), nil, "test")
+	require.NoError(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.Equal(t, user.ID, loggedIn.ID)
+	require.Len(t, loggedIn.Roles, 1)
+	assert.Equal(t, teacher.ID, loggedIn.Roles[0].ID)
+
+	var link models.UserIdentity
+	require.NoError(t, env.db.First(&link, "provider_id = ? AND subject = ?", provider.ID, "idp-123").Error)
+	assert.Equal(t, user.ID, link.UserID)
+
+	// The code is single use
+	_, _, err = env.service.Exchange(context.Background(), query.Get("code"), nil, "test")
+	assert.ErrorIs(t, err, ErrInvalidExchangeCode)
+}
+
+func TestOIDCLogin_RemovesRolesOfLeftGroupsOnly(t *testing.T) {
+	env := setupSSOTestEnv(t, mockidp.User{Subject: "idp-123", Email: "asha@school.edu", EmailVerified: true, Groups: []string{"teachers"}})
+	user := env.createUser(t, "asha@school.edu")
+	teacher := env.createRole(t, "Teacher")
+	manual := env.createRole(t, "Exam Coordinator")
+	require.NoError(t, env.db.Exec("INSERT INTO user_roles (user_id, role_id) VALUES (?, ?)", user.ID, manual.ID).Error)
+	provider := env.createOIDCProvider(t, CreateProviderRequest{
+		RoleMappings: []models.SSORoleMapping{{Group: "teachers", RoleID: teacher.ID}},
+	})
+
+	_, first, err := env.service.Exchange(context.Background(), env.login(t, provider).Get("code"), nil, "test")
+	require.NoError(t, err)
+	assert.Len(t, first.Roles, 2)
+
+	env.idp.SetUser(mockidp.User{Subject: "idp-123", Email: "asha@school.edu", EmailVerified: true})
+	_, second, err := env.service.Exchange(context.Background(), env.login(t, provider).Get("code"), nil, "test")
+	require.NoError(t, err)
+	require.Len(t, second.Roles, 1)
+	assert.Equal(t, manual.ID, second.Roles[0].ID)
+	assert.Greater(t, second.TokenVersion, first.TokenVersion)
+}
+
+func TestOIDCLogin_Rejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     mockidp.User
+		provider CreateProviderRequest
+		want     string
+	}{
+		{
+			name:     "domain not allowed",
+			user:     mockidp.User{Subject: "a", Email: "asha@gmail.com", EmailVerified: true},
+			provider: CreateProviderRequest{AllowedDomains: []string{"school.edu"}},
+			want:     "domain_not_allowed",
+		},
+		{
+			name:     "email not verified",
+			user:     mockidp.User{Subject: "b", Email: "asha@school.edu"},
+			provider: CreateProviderRequest{RequireVerifiedEmail: true},
+			want:     "email_not_verified",
+		},
+		{
+			name:     "no account and no auto-create",
+			user:     mockidp.User{Subject: "c", Email: "new@school.edu", EmailVerified: true},
+			provider: CreateProviderRequest{},
+			want:     "user_not_provisioned",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupSSOTestEnv(t, tt.user)
+			env.createUser(t, "asha@school.edu")
+			provider := env.createOIDCProvider(t, tt.provider)
+
+			query := env.login(t, provider)
+			assert.Equal(t, tt.want, query.Get("error"))
+			assert.Empty(t, query.Get("code"))
+		})
+	}
+}
+
+func TestOIDCLogin_AutoCreatesUserWithDefaultRole(t *testing.T) {
+	env := setupSSOTestEnv(t, mockidp.User{
+		Subject: "idp-new", Email: "ravi@school.edu", EmailVerified: true, GivenName: "Ravi", FamilyName: "Kumar",
+	})
+	staff := env.createRole(t, "Staff")
+	provider := env.createOIDCProvider(t, CreateProviderRequest{AutoCreateUsers: true, DefaultRoleID: &staff.ID})
+
+	_, user, err := env.service.Exchange(context.Background(), env.login(t, provider).Get("code"), nil, "test")
+	require.NoError(t, err)
+	assert.Equal(t, "ravi@school.edu", *user.Email)
+	assert.Equal(t, "Ravi", user.FirstName)
+	assert.True(t, user.IsEmailVerified())
+	assert.Nil(t, user.PasswordHash)
+	require.Len(t, user.Roles, 1)
+	assert.Equal(t, staff.ID, user.Roles[0].ID)
+}
+
+func TestSAMLLogin_LinksUserAndMapsGroups(t *testing.T) {
+	env := setupSSOTestEnv(t, mockidp.User{
+		Subject: "saml-42", Email: "asha@school.edu", Groups: []string{"Staff", "teachers"},
+	})
+	user := env.createUser(t, "asha@school.edu")
+	teacher := env.createRole(t, "Teacher")
+	provider, err := env.service.CreateProvider(context.Background(), CreateProviderRequest{
+		TenantID:       env.tenant.ID,
+		Name:           "Mock SAML",
+		Protocol:       models.SSOProtocolSAML,
+		Enabled:        true,
+		MetadataURL:    env.idpURL + "/saml/metadata",
+		AllowedDomains: []string{"school.edu"},
+		RoleMappings:   []models.SSORoleMapping{{Group: "teachers", RoleID: teacher.ID}},
+	})
+	require.NoError(t, err)
+
+	query := env.login(t, provider)
+	require.Empty(t, query.Get("error"))
+
+	_, loggedIn, err := env.service.Exchange(context.Background(), query.Get("code"), nil, "test")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, loggedIn.ID)
+	require.Len(t, loggedIn.Roles, 1)
+	assert.Equal(t, teacher.ID, loggedIn.Roles[0].ID)
+}
+
+func TestBeginLogin_RejectsUnknownRedirectOrigin(t *testing.T) {
+	env := setupSSOTestEnv(t, mockidp.User{Subject: "a"})
+	provider := env.createOIDCProvider(t, CreateProviderRequest{})
+
+	for _, redirectURI := range []string{"http://evil.test/auth/sso", "/relative", "javascript:alert(1)", "http://user@app.test/x"} {
+		_, err := env.service.BeginLogin(context.Background(), provider.ID, redirectURI)
+		assert.ErrorIs(t, err, ErrInvalidRedirectURI, redirectURI)
+	}
+}
+
+func TestCreateProvider_Validation(t *testing.T) {
+	env := setupSSOTestEnv(t, mockidp.User{Subject: "a"})
+
+	otherTenant := uuid.New()
+	foreign := &models.Role{TenantID: &otherTenant, Name: "Foreign"}
+	require.NoError(t, env.db.Create(foreign).Error)
+
+	_, err := env.service.CreateProvider(context.Background(), CreateProviderRequest{
+		TenantID: env.tenant.ID, Name: "Bad", Protocol: models.SSOProtocolOIDC, IssuerURL: env.idpURL, ClientID: testClientID,
+		RoleMappings: []models.SSORoleMapping{{Group: "staff", RoleID: foreign.ID}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidRoleMapping)
+
+	_, err = env.service.CreateProvider(context.Background(), CreateProviderRequest{
+		TenantID: env.tenant.ID, Name: "Bad", Protocol: models.SSOProtocolSAML, MetadataXML: "<not-metadata/>",
+	})
+	assert.ErrorIs(t, err, ErrInvalidSAMLMetadata)
+
+	_, err = env.service.CreateProvider(context.Background(), CreateProviderRequest{
+		TenantID: env.tenant.ID, Name: "Bad", Protocol: "ldap",
+	})
+	assert.ErrorIs(t, err, ErrInvalidProtocol)
+
+	provider := env.createOIDCProvider(t, CreateProviderRequest{})
+	assert.NotContains(t, provider.ClientSecretEncrypted, testClientSecret)
+	secret, err := env.service.decrypt(provider.ClientSecretEncrypted)
+	require.NoError(t, err)
+	assert.Equal(t, testClientSecret, secret)
+}
@@ -0,0 +1,71 @@
+// Package testutil provides helpers for tests that run services against an
+// in-memory SQLite database.
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// sqliteUUID generates a random UUID string as a sqlite column default.
+const sqliteUUID = "(lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || hex(randomblob(2)) || '-' || hex(randomblob(2)) || '-' || hex(randomblob(6))))"
+
+// CreateSQLiteTable creates a table for a model without the Postgres-only
+// defaults (uuid_generate_v7, now(), jsonb) that AutoMigrate would emit.
+func CreateSQLiteTable(t *testing.T, db *gorm.DB, model interface{}) {
+	t.Helper()
+
+	stmt := &gorm.Statement{DB: db}
+	require.NoError(t, stmt.Parse(model))
+
+	var columns []string
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if field.PrimaryKey {
+			columns = append(columns, field.DBName+" TEXT PRIMARY KEY DEFAULT "+sqliteUUID)
+			continue
+		}
+
+		column := field.DBName + " TEXT"
+		if strings.EqualFold(field.TagSettings["TYPE"], "jsonb") {
+			// JSON types scan from []byte, which sqlite only returns for BLOB values
+			column = field.DBName + " BLOB"
+			if value := strings.Trim(field.DefaultValue, "'"); value != "" {
+				column += fmt.Sprintf(" DEFAULT X'%x'", value)
+			}
+			columns = append(columns, column)
+			continue
+		}
+		switch field.GORMDataType {
+		case schema.Time:
+			column = field.DBName + " DATETIME"
+		case schema.Bool:
+			column = field.DBName + " BOOLEAN"
+		case schema.Int, schema.Uint:
+			column = field.DBName + " INTEGER"
+		case schema.Float:
+			column = field.DBName + " REAL"
+		}
+
+		switch value := field.DefaultValueInterface.(type) {
+		case nil:
+			if field.DefaultValue == "now()" {
+				column += " DEFAULT CURRENT_TIMESTAMP"
+			}
+		case string:
+			column += " DEFAULT '" + strings.ReplaceAll(value, "'", "''") + "'"
+		default:
+			column += fmt.Sprintf(" DEFAULT %v", value)
+		}
+		columns = append(columns, column)
+	}
+
+	require.NoError(t, db.Exec(fmt.Sprintf("CREATE TABLE %s (%s)", stmt.Schema.Table, strings.Join(columns, ", "))).Error)
+}
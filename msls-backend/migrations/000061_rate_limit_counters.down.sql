@@ -0,0 +1,3 @@
+-- Reverse Rate Limit Counters migration
+
+DROP TABLE IF EXISTS rate_limit_counters;
@@ -0,0 +1,14 @@
+-- Rate Limit Counters
+-- Shared fixed-window counters used when Redis is not configured or unavailable.
+-- Not tenant-scoped: keys already embed the tenant, user or client IP.
+
+CREATE TABLE rate_limit_counters (
+    key VARCHAR(255) PRIMARY KEY,
+    count INTEGER NOT NULL DEFAULT 0,
+    window_ends_at TIMESTAMPTZ NOT NULL
+);
+
+-- Index for purging expired windows
+CREATE INDEX idx_rate_limit_counters_window_ends_at ON rate_limit_counters(window_ends_at);
+
+COMMENT ON TABLE rate_limit_counters IS 'Fixed-window API rate limit counters shared across replicas';
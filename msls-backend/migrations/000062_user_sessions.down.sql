@@ -0,0 +1,9 @@
+-- Reverse User Sessions migration
+
+DROP INDEX IF EXISTS idx_refresh_tokens_session_id;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS session_id;
+
+DROP TRIGGER IF EXISTS set_updated_at_user_sessions ON user_sessions;
+DROP TABLE IF EXISTS user_sessions;
+
+ALTER TABLE users DROP COLUMN IF EXISTS token_version;
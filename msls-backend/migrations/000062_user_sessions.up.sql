@@ -0,0 +1,43 @@
+-- User Sessions
+-- Tracks login sessions (device, IP, location) so users and admins can revoke them,
+-- and a per-user token version that invalidates outstanding access tokens.
+
+-- Bumped on password reset, role change and account lock; access tokens carry it as "tv"
+ALTER TABLE users ADD COLUMN token_version INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE user_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device VARCHAR(100),
+    user_agent TEXT,
+    ip_address INET,
+    location VARCHAR(200),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    revoked_reason VARCHAR(50),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Like refresh_tokens, sessions are looked up before tenant context exists (login, refresh),
+-- so access is scoped by user_id in the service layer instead of RLS.
+
+-- Indexes
+CREATE INDEX idx_user_sessions_user_id ON user_sessions(user_id);
+CREATE INDEX idx_user_sessions_tenant_id ON user_sessions(tenant_id);
+CREATE INDEX idx_user_sessions_active ON user_sessions(user_id, expires_at) WHERE revoked_at IS NULL;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_user_sessions
+    BEFORE UPDATE ON user_sessions
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Link refresh tokens to their session; rotation keeps the same session
+ALTER TABLE refresh_tokens ADD COLUMN session_id UUID REFERENCES user_sessions(id) ON DELETE CASCADE;
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens(session_id);
+
+COMMENT ON TABLE user_sessions IS 'Login sessions per device, revocable by the user or an administrator';
+COMMENT ON COLUMN users.token_version IS 'Incremented to invalidate all outstanding access tokens of the user';
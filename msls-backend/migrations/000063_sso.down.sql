@@ -0,0 +1,24 @@
+-- Reverse Single Sign-On migration
+
+-- Remove permissions from roles
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code IN ('sso:read', 'sso:write')
+);
+
+-- Remove permissions
+DELETE FROM permissions WHERE code IN ('sso:read', 'sso:write');
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS set_updated_at_sso_login_requests ON sso_login_requests;
+DROP TRIGGER IF EXISTS set_updated_at_user_identities ON user_identities;
+DROP TRIGGER IF EXISTS set_updated_at_identity_providers ON identity_providers;
+
+-- Drop policies
+DROP POLICY IF EXISTS bypass_rls_identity_providers ON identity_providers;
+DROP POLICY IF EXISTS tenant_isolation_identity_providers ON identity_providers;
+
+-- Drop tables (order matters due to references)
+DROP TABLE IF EXISTS sso_login_requests;
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS identity_providers;
@@ -0,0 +1,149 @@
+-- Single Sign-On
+-- Per-tenant OpenID Connect and SAML 2.0 identity providers for staff login,
+-- the links between users and their IdP subjects, and in-flight browser logins.
+
+-- ============================================================
+-- Identity Providers
+-- ============================================================
+
+CREATE TABLE identity_providers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    protocol VARCHAR(10) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    issuer_url VARCHAR(500),
+    client_id VARCHAR(255),
+    client_secret_encrypted TEXT,
+    scopes TEXT[],
+    metadata_url VARCHAR(500),
+    metadata_xml TEXT,
+    allowed_domains TEXT[] NOT NULL DEFAULT '{}',
+    require_verified_email BOOLEAN NOT NULL DEFAULT true,
+    auto_create_users BOOLEAN NOT NULL DEFAULT false,
+    groups_claim VARCHAR(255),
+    role_mappings JSONB NOT NULL DEFAULT '[]',
+    default_role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+
+    CONSTRAINT chk_identity_provider_protocol CHECK (protocol IN ('oidc', 'saml'))
+);
+
+-- Enable RLS
+ALTER TABLE identity_providers ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_identity_providers ON identity_providers
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+-- Providers are resolved before login, when no tenant context exists yet
+CREATE POLICY bypass_rls_identity_providers ON identity_providers
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_identity_providers_tenant ON identity_providers(tenant_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_identity_providers
+    BEFORE UPDATE ON identity_providers
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- User Identities
+-- ============================================================
+
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider_id UUID NOT NULL REFERENCES identity_providers(id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    last_login_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_user_identities_provider_subject UNIQUE (provider_id, subject)
+);
+
+-- Indexes
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
+CREATE INDEX idx_user_identities_tenant ON user_identities(tenant_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_user_identities
+    BEFORE UPDATE ON user_identities
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- SSO Login Requests
+-- ============================================================
+
+CREATE TABLE sso_login_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    provider_id UUID NOT NULL REFERENCES identity_providers(id) ON DELETE CASCADE,
+    state VARCHAR(100) NOT NULL UNIQUE,
+    nonce VARCHAR(100),
+    code_verifier VARCHAR(100),
+    saml_request_id VARCHAR(100),
+    redirect_uri TEXT NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    exchanged_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Like user_sessions, identities and login requests are used before tenant context exists,
+-- so access is scoped by provider and user in the service layer instead of RLS.
+
+-- Indexes
+CREATE INDEX idx_sso_login_requests_expires_at ON sso_login_requests(expires_at);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_sso_login_requests
+    BEFORE UPDATE ON sso_login_requests
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Permissions
+-- ============================================================
+
+INSERT INTO permissions (id, code, name, description, module, created_at, updated_at)
+VALUES
+    (uuid_generate_v7(), 'sso:read', 'View SSO Providers', 'Permission to view single sign-on identity providers', 'sso', NOW(), NOW()),
+    (uuid_generate_v7(), 'sso:write', 'Manage SSO Providers', 'Permission to configure single sign-on identity providers', 'sso', NOW(), NOW())
+ON CONFLICT (code) DO NOTHING;
+
+-- Super admin, admin - full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('super_admin', 'admin')
+AND p.code IN ('sso:read', 'sso:write')
+ON CONFLICT DO NOTHING;
+
+-- Principals can view
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'principal'
+AND p.code IN ('sso:read')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE identity_providers IS 'Per-tenant OpenID Connect and SAML 2.0 identity providers';
+COMMENT ON COLUMN identity_providers.role_mappings IS 'IdP group to role mappings: [{"group": "...", "role_id": "..."}]';
+COMMENT ON TABLE user_identities IS 'Links users to their subject at an identity provider';
+COMMENT ON TABLE sso_login_requests IS 'In-flight SSO logins: state, nonce and PKCE verifier, then a one-time exchange code';
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS admission_portal_sessions;
+
+DROP INDEX IF EXISTS idx_admission_applications_online_submitted;
+DROP INDEX IF EXISTS idx_admission_applications_verified_phone;
+
+ALTER TABLE admission_applications
+    DROP CONSTRAINT IF EXISTS chk_admission_applications_source,
+    DROP COLUMN IF EXISTS verified_phone,
+    DROP COLUMN IF EXISTS source;
@@ -0,0 +1,55 @@
+-- Online Admission Portal
+-- Parents start and submit applications without an account after verifying
+-- their phone number by OTP. Portal sessions hold the resulting access token.
+
+-- ============================================================
+-- Application source and verified phone
+-- ============================================================
+
+ALTER TABLE admission_applications
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'office',
+    ADD COLUMN verified_phone VARCHAR(20),
+    ADD CONSTRAINT chk_admission_applications_source CHECK (source IN ('office', 'online'));
+
+-- Parents list their own applications by verified phone
+CREATE INDEX idx_admission_applications_verified_phone
+    ON admission_applications(tenant_id, verified_phone)
+    WHERE verified_phone IS NOT NULL;
+
+-- Daily caps count online submissions per session
+CREATE INDEX idx_admission_applications_online_submitted
+    ON admission_applications(session_id, submitted_at)
+    WHERE source = 'online';
+
+-- ============================================================
+-- Admission Portal Sessions
+-- ============================================================
+
+CREATE TABLE admission_portal_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    phone VARCHAR(20) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uniq_admission_portal_sessions_token UNIQUE (token_hash)
+);
+
+-- Enable RLS
+ALTER TABLE admission_portal_sessions ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_admission_portal_sessions ON admission_portal_sessions
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_admission_portal_sessions ON admission_portal_sessions
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_admission_portal_sessions_tenant ON admission_portal_sessions(tenant_id);
+CREATE INDEX idx_admission_portal_sessions_expires ON admission_portal_sessions(expires_at);
+
+COMMENT ON TABLE admission_portal_sessions IS 'OTP-verified parent sessions on the public admission portal';
+COMMENT ON COLUMN admission_portal_sessions.token_hash IS 'SHA-256 hex of the portal access token';
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_admission_decisions_open_offers;
+DROP INDEX IF EXISTS idx_admission_decisions_offer_token;
+
+ALTER TABLE admission_decisions
+    DROP COLUMN IF EXISTS offer_lapsed_at,
+    DROP COLUMN IF EXISTS offer_viewed_at,
+    DROP COLUMN IF EXISTS offer_fees,
+    DROP COLUMN IF EXISTS offer_token_hash,
+    DROP COLUMN IF EXISTS offer_letter_path;
@@ -0,0 +1,27 @@
+-- Offer Letters
+-- Offer letter PDFs are stored in file storage and parents view and accept the
+-- offer through a tokenized public link. Unaccepted offers lapse after
+-- offer_valid_until and the seat passes to the next waitlisted application.
+
+ALTER TABLE admission_decisions
+    ADD COLUMN offer_letter_path VARCHAR(500),
+    ADD COLUMN offer_token_hash VARCHAR(64),
+    ADD COLUMN offer_fees JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN offer_viewed_at TIMESTAMPTZ,
+    ADD COLUMN offer_lapsed_at TIMESTAMPTZ;
+
+-- Public offer links are looked up by token hash
+CREATE UNIQUE INDEX idx_admission_decisions_offer_token
+    ON admission_decisions(offer_token_hash)
+    WHERE offer_token_hash IS NOT NULL;
+
+-- The expiry sweep scans open offers by validity date
+CREATE INDEX idx_admission_decisions_open_offers
+    ON admission_decisions(offer_valid_until)
+    WHERE decision = 'approved' AND offer_lapsed_at IS NULL AND offer_accepted IS NOT TRUE;
+
+COMMENT ON COLUMN admission_decisions.offer_letter_path IS 'Storage path of the generated offer letter PDF';
+COMMENT ON COLUMN admission_decisions.offer_token_hash IS 'SHA-256 hex digest of the public offer link token';
+COMMENT ON COLUMN admission_decisions.offer_fees IS 'Fees due on acceptance: [{name, amount}]';
+COMMENT ON COLUMN admission_decisions.offer_viewed_at IS 'When the parent first opened the public offer link';
+COMMENT ON COLUMN admission_decisions.offer_lapsed_at IS 'When the unaccepted offer lapsed after its validity date';
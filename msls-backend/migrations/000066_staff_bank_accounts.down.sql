@@ -0,0 +1,24 @@
+-- Reverse Staff Bank Accounts migration
+
+-- Remove permissions from roles
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code IN ('staff_bank.view', 'staff_bank.manage', 'staff_bank.verify')
+);
+
+-- Remove permissions
+DELETE FROM permissions WHERE code IN ('staff_bank.view', 'staff_bank.manage', 'staff_bank.verify');
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS set_updated_at_bank_file_templates ON bank_file_templates;
+DROP TRIGGER IF EXISTS set_updated_at_staff_bank_accounts ON staff_bank_accounts;
+
+-- Drop policies
+DROP POLICY IF EXISTS bypass_rls_bank_file_templates ON bank_file_templates;
+DROP POLICY IF EXISTS tenant_isolation_bank_file_templates ON bank_file_templates;
+DROP POLICY IF EXISTS bypass_rls_staff_bank_accounts ON staff_bank_accounts;
+DROP POLICY IF EXISTS tenant_isolation_staff_bank_accounts ON staff_bank_accounts;
+
+-- Drop tables
+DROP TABLE IF EXISTS bank_file_templates;
+DROP TABLE IF EXISTS staff_bank_accounts;
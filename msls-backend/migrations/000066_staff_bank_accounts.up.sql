@@ -0,0 +1,140 @@
+-- Staff Bank Accounts and Salary Transfer Files
+-- Salary accounts are stored with the account number encrypted by the application.
+-- Bank file templates describe tenant-specific fixed-width transfer file layouts.
+
+-- ============================================================
+-- Staff Bank Accounts
+-- ============================================================
+
+CREATE TABLE staff_bank_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    account_holder_name VARCHAR(200) NOT NULL,
+    bank_name VARCHAR(100) NOT NULL,
+    branch_name VARCHAR(100),
+    ifsc_code VARCHAR(11) NOT NULL,
+    account_type VARCHAR(20) NOT NULL DEFAULT 'savings',
+    account_number_encrypted TEXT NOT NULL,
+    account_number_last4 VARCHAR(4) NOT NULL,
+    account_number_hash VARCHAR(64) NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    verification_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    verified_at TIMESTAMPTZ,
+    verified_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    verification_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_staff_bank_accounts_type CHECK (account_type IN ('savings', 'current', 'salary')),
+    CONSTRAINT chk_staff_bank_accounts_verification CHECK (verification_status IN ('pending', 'verified', 'rejected')),
+    CONSTRAINT chk_staff_bank_accounts_ifsc CHECK (ifsc_code ~ '^[A-Z]{4}0[A-Z0-9]{6}$'),
+    CONSTRAINT uniq_staff_bank_accounts_number UNIQUE (tenant_id, staff_id, account_number_hash)
+);
+
+-- Enable RLS
+ALTER TABLE staff_bank_accounts ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_staff_bank_accounts ON staff_bank_accounts
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_staff_bank_accounts ON staff_bank_accounts
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_staff_bank_accounts_tenant ON staff_bank_accounts(tenant_id);
+CREATE INDEX idx_staff_bank_accounts_staff ON staff_bank_accounts(staff_id);
+
+-- One primary account per staff member
+CREATE UNIQUE INDEX idx_staff_bank_accounts_primary
+    ON staff_bank_accounts(tenant_id, staff_id)
+    WHERE is_primary;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_staff_bank_accounts
+    BEFORE UPDATE ON staff_bank_accounts
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Bank File Templates
+-- ============================================================
+
+CREATE TABLE bank_file_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    header_fields JSONB NOT NULL DEFAULT '[]',
+    detail_fields JSONB NOT NULL DEFAULT '[]',
+    trailer_fields JSONB NOT NULL DEFAULT '[]',
+    line_ending VARCHAR(4) NOT NULL DEFAULT 'crlf',
+    file_extension VARCHAR(10) NOT NULL DEFAULT 'txt',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_bank_file_templates_line_ending CHECK (line_ending IN ('crlf', 'lf')),
+    CONSTRAINT uniq_bank_file_templates_name UNIQUE (tenant_id, name)
+);
+
+-- Enable RLS
+ALTER TABLE bank_file_templates ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_bank_file_templates ON bank_file_templates
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_bank_file_templates ON bank_file_templates
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_bank_file_templates_tenant ON bank_file_templates(tenant_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_bank_file_templates
+    BEFORE UPDATE ON bank_file_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Permissions
+-- ============================================================
+
+INSERT INTO permissions (id, code, name, description, module, created_at, updated_at)
+VALUES
+    (uuid_generate_v7(), 'staff_bank.view', 'View Staff Bank Accounts', 'Permission to view masked staff salary bank accounts', 'payroll', NOW(), NOW()),
+    (uuid_generate_v7(), 'staff_bank.manage', 'Manage Staff Bank Accounts', 'Permission to add, change and remove staff salary bank accounts', 'payroll', NOW(), NOW()),
+    (uuid_generate_v7(), 'staff_bank.verify', 'Verify Staff Bank Accounts', 'Permission to verify or reject staff salary bank accounts', 'payroll', NOW(), NOW())
+ON CONFLICT (code) DO NOTHING;
+
+-- Super admin, admin - full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('super_admin', 'admin')
+AND p.code IN ('staff_bank.view', 'staff_bank.manage', 'staff_bank.verify')
+ON CONFLICT DO NOTHING;
+
+-- Accountants can view and verify
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'accountant'
+AND p.code IN ('staff_bank.view', 'staff_bank.verify')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE staff_bank_accounts IS 'Staff salary bank accounts with verification state';
+COMMENT ON COLUMN staff_bank_accounts.account_number_encrypted IS 'AES-GCM encrypted account number (base64)';
+COMMENT ON COLUMN staff_bank_accounts.account_number_hash IS 'HMAC-SHA256 hex of the account number, for duplicate detection';
+COMMENT ON TABLE bank_file_templates IS 'Tenant-defined fixed-width salary transfer file layouts';
+COMMENT ON COLUMN bank_file_templates.detail_fields IS 'Per-payment record fields: [{"field": "...", "width": 0, "align": "left|right", "pad": " ", "format": "..."}]';
@@ -0,0 +1,20 @@
+-- Rollback Payments
+
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code IN ('payments.view', 'payments.manage', 'payments.reconcile')
+);
+
+DELETE FROM permissions WHERE code IN ('payments.view', 'payments.manage', 'payments.reconcile');
+
+DROP TABLE IF EXISTS payment_webhook_events;
+
+DROP TRIGGER IF EXISTS set_updated_at_payment_refunds ON payment_refunds;
+DROP POLICY IF EXISTS bypass_rls_payment_refunds ON payment_refunds;
+DROP POLICY IF EXISTS tenant_isolation_payment_refunds ON payment_refunds;
+DROP TABLE IF EXISTS payment_refunds;
+
+DROP TRIGGER IF EXISTS set_updated_at_payments ON payments;
+DROP POLICY IF EXISTS bypass_rls_payments ON payments;
+DROP POLICY IF EXISTS tenant_isolation_payments ON payments;
+DROP TABLE IF EXISTS payments;
@@ -0,0 +1,176 @@
+-- Payments
+-- Ledger of money collected through payment gateways (Razorpay). Payments are
+-- linked to admission applications and, later, student fee invoices. Webhook
+-- events are recorded by their gateway event ID so redeliveries apply once.
+
+-- ============================================================
+-- Payments
+-- ============================================================
+
+CREATE TABLE payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    purpose VARCHAR(30) NOT NULL,
+    application_id UUID REFERENCES admission_applications(id) ON DELETE SET NULL,
+    invoice_id UUID,
+    provider VARCHAR(30) NOT NULL,
+    provider_order_id VARCHAR(100) NOT NULL,
+    provider_payment_id VARCHAR(100),
+    amount DECIMAL(12,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'INR',
+    status VARCHAR(20) NOT NULL DEFAULT 'created',
+    method VARCHAR(30),
+    captured_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    refunded_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    failure_reason TEXT,
+    paid_at TIMESTAMPTZ,
+    confirmed_at TIMESTAMPTZ,
+    confirmed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reconciliation_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reconciliation_note TEXT,
+    reconciled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_payments_purpose CHECK (purpose IN ('application_fee', 'fee_invoice')),
+    CONSTRAINT chk_payments_status CHECK (status IN ('created', 'captured', 'failed', 'partially_refunded', 'refunded')),
+    CONSTRAINT chk_payments_reconciliation CHECK (reconciliation_status IN ('pending', 'matched', 'mismatch')),
+    CONSTRAINT chk_payments_amount CHECK (amount > 0),
+    CONSTRAINT chk_payments_refunded CHECK (refunded_amount >= 0 AND refunded_amount <= captured_amount),
+    CONSTRAINT uniq_payments_provider_order UNIQUE (provider, provider_order_id)
+);
+
+-- Enable RLS
+ALTER TABLE payments ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_payments ON payments
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_payments ON payments
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_payments_tenant ON payments(tenant_id);
+CREATE INDEX idx_payments_application ON payments(application_id) WHERE application_id IS NOT NULL;
+CREATE INDEX idx_payments_invoice ON payments(invoice_id) WHERE invoice_id IS NOT NULL;
+CREATE INDEX idx_payments_status ON payments(tenant_id, status);
+CREATE INDEX idx_payments_created ON payments(tenant_id, created_at);
+
+-- A gateway payment is recorded against one ledger entry only
+CREATE UNIQUE INDEX idx_payments_provider_payment
+    ON payments(provider, provider_payment_id)
+    WHERE provider_payment_id IS NOT NULL;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_payments
+    BEFORE UPDATE ON payments
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Payment Refunds
+-- ============================================================
+
+CREATE TABLE payment_refunds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    provider_refund_id VARCHAR(100) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    reason TEXT,
+    processed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_payment_refunds_status CHECK (status IN ('pending', 'processed', 'failed')),
+    CONSTRAINT chk_payment_refunds_amount CHECK (amount > 0),
+    CONSTRAINT uniq_payment_refunds_provider_refund UNIQUE (payment_id, provider_refund_id)
+);
+
+-- Enable RLS
+ALTER TABLE payment_refunds ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_payment_refunds ON payment_refunds
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_payment_refunds ON payment_refunds
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_payment_refunds_tenant ON payment_refunds(tenant_id);
+CREATE INDEX idx_payment_refunds_payment ON payment_refunds(payment_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_payment_refunds
+    BEFORE UPDATE ON payment_refunds
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Payment Webhook Events
+-- Not tenant-scoped: the tenant is only known once the event is matched to a payment.
+-- ============================================================
+
+CREATE TABLE payment_webhook_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    provider VARCHAR(30) NOT NULL,
+    event_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE,
+    payment_id UUID REFERENCES payments(id) ON DELETE SET NULL,
+    payload TEXT NOT NULL,
+    error TEXT,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMPTZ,
+
+    CONSTRAINT uniq_payment_webhook_events_event UNIQUE (provider, event_id)
+);
+
+-- Indexes
+CREATE INDEX idx_payment_webhook_events_payment ON payment_webhook_events(payment_id) WHERE payment_id IS NOT NULL;
+CREATE INDEX idx_payment_webhook_events_unprocessed ON payment_webhook_events(received_at) WHERE processed_at IS NULL;
+
+-- ============================================================
+-- Permissions
+-- ============================================================
+
+INSERT INTO permissions (id, code, name, description, module, created_at, updated_at)
+VALUES
+    (uuid_generate_v7(), 'payments.view', 'View Payments', 'Permission to view the payments ledger', 'payments', NOW(), NOW()),
+    (uuid_generate_v7(), 'payments.manage', 'Manage Payments', 'Permission to confirm and refund payments', 'payments', NOW(), NOW()),
+    (uuid_generate_v7(), 'payments.reconcile', 'Reconcile Payments', 'Permission to reconcile payments with the payment gateway', 'payments', NOW(), NOW())
+ON CONFLICT (code) DO NOTHING;
+
+-- Super admin, admin, accountant - full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('super_admin', 'admin', 'accountant')
+AND p.code IN ('payments.view', 'payments.manage', 'payments.reconcile')
+ON CONFLICT DO NOTHING;
+
+-- Principals can view
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'principal'
+AND p.code IN ('payments.view')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE payments IS 'Ledger of payments collected through payment gateways';
+COMMENT ON COLUMN payments.invoice_id IS 'Student fee invoice paid by this payment (purpose fee_invoice)';
+COMMENT ON COLUMN payments.captured_amount IS 'Amount the gateway reported as captured';
+COMMENT ON COLUMN payments.confirmed_at IS 'When the payment was applied to the application or invoice';
+COMMENT ON COLUMN payments.reconciliation_note IS 'Differences found between the ledger and the gateway';
+COMMENT ON TABLE payment_webhook_events IS 'Gateway webhook events, unique per provider event ID';
@@ -0,0 +1,30 @@
+-- Rollback Online Entrance Tests
+
+DROP TRIGGER IF EXISTS set_updated_at_test_answers ON test_answers;
+DROP POLICY IF EXISTS bypass_rls_test_answers ON test_answers;
+DROP POLICY IF EXISTS tenant_isolation_test_answers ON test_answers;
+DROP TABLE IF EXISTS test_answers;
+
+DROP TRIGGER IF EXISTS set_updated_at_test_attempts ON test_attempts;
+DROP POLICY IF EXISTS bypass_rls_test_attempts ON test_attempts;
+DROP POLICY IF EXISTS tenant_isolation_test_attempts ON test_attempts;
+DROP TABLE IF EXISTS test_attempts;
+
+DROP POLICY IF EXISTS bypass_rls_test_section_questions ON test_section_questions;
+DROP POLICY IF EXISTS tenant_isolation_test_section_questions ON test_section_questions;
+DROP TABLE IF EXISTS test_section_questions;
+
+DROP TRIGGER IF EXISTS set_updated_at_test_sections ON test_sections;
+DROP POLICY IF EXISTS bypass_rls_test_sections ON test_sections;
+DROP POLICY IF EXISTS tenant_isolation_test_sections ON test_sections;
+DROP TABLE IF EXISTS test_sections;
+
+DROP TRIGGER IF EXISTS set_updated_at_test_questions ON test_questions;
+DROP POLICY IF EXISTS bypass_rls_test_questions ON test_questions;
+DROP POLICY IF EXISTS tenant_isolation_test_questions ON test_questions;
+DROP TABLE IF EXISTS test_questions;
+
+ALTER TABLE entrance_tests DROP CONSTRAINT IF EXISTS chk_entrance_tests_late_entry;
+ALTER TABLE entrance_tests
+    DROP COLUMN IF EXISTS late_entry_minutes,
+    DROP COLUMN IF EXISTS online_enabled;
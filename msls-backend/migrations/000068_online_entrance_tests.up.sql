@@ -0,0 +1,239 @@
+-- Online Entrance Tests
+-- Question bank of MCQ, numeric and descriptive questions, question papers made
+-- of subject sections with a marking scheme, and timed online attempts whose
+-- objective answers are scored automatically. Descriptive answers wait in a
+-- grading queue before the result is recorded on the test registration.
+
+-- ============================================================
+-- Entrance Tests: online delivery
+-- ============================================================
+
+ALTER TABLE entrance_tests
+    ADD COLUMN online_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN late_entry_minutes INTEGER NOT NULL DEFAULT 15;
+
+ALTER TABLE entrance_tests
+    ADD CONSTRAINT chk_entrance_tests_late_entry CHECK (late_entry_minutes >= 0);
+
+-- ============================================================
+-- Question Bank
+-- ============================================================
+
+CREATE TABLE test_questions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    subject VARCHAR(100) NOT NULL,
+    topic VARCHAR(200),
+    question_type VARCHAR(20) NOT NULL,
+    question_text TEXT NOT NULL,
+    options JSONB NOT NULL DEFAULT '[]',
+    correct_options JSONB NOT NULL DEFAULT '[]',
+    numeric_answer DECIMAL(12,4),
+    numeric_tolerance DECIMAL(12,4) NOT NULL DEFAULT 0,
+    marks DECIMAL(6,2) NOT NULL DEFAULT 1,
+    negative_marks DECIMAL(6,2) NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_test_questions_type CHECK (question_type IN ('mcq', 'numeric', 'descriptive')),
+    CONSTRAINT chk_test_questions_marks CHECK (marks > 0 AND negative_marks >= 0 AND negative_marks <= marks),
+    CONSTRAINT chk_test_questions_tolerance CHECK (numeric_tolerance >= 0),
+    CONSTRAINT chk_test_questions_numeric CHECK (question_type <> 'numeric' OR numeric_answer IS NOT NULL)
+);
+
+-- Enable RLS
+ALTER TABLE test_questions ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_test_questions ON test_questions
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_test_questions ON test_questions
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_test_questions_tenant ON test_questions(tenant_id);
+CREATE INDEX idx_test_questions_subject ON test_questions(tenant_id, subject, question_type);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_test_questions
+    BEFORE UPDATE ON test_questions
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Question Paper Sections
+-- ============================================================
+
+CREATE TABLE test_sections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    test_id UUID NOT NULL REFERENCES entrance_tests(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    subject VARCHAR(100) NOT NULL,
+    sequence INTEGER NOT NULL DEFAULT 0,
+    instructions TEXT,
+    marks_per_question DECIMAL(6,2),
+    negative_marks DECIMAL(6,2),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_test_sections_marks CHECK (marks_per_question IS NULL OR marks_per_question > 0),
+    CONSTRAINT chk_test_sections_negative CHECK (negative_marks IS NULL OR negative_marks >= 0)
+);
+
+-- Enable RLS
+ALTER TABLE test_sections ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_test_sections ON test_sections
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_test_sections ON test_sections
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_test_sections_tenant ON test_sections(tenant_id);
+CREATE INDEX idx_test_sections_test ON test_sections(test_id, sequence);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_test_sections
+    BEFORE UPDATE ON test_sections
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Section Questions
+-- ============================================================
+
+CREATE TABLE test_section_questions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    section_id UUID NOT NULL REFERENCES test_sections(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL REFERENCES test_questions(id) ON DELETE RESTRICT,
+    sequence INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uniq_test_section_questions UNIQUE (section_id, question_id)
+);
+
+-- Enable RLS
+ALTER TABLE test_section_questions ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_test_section_questions ON test_section_questions
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_test_section_questions ON test_section_questions
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_test_section_questions_tenant ON test_section_questions(tenant_id);
+CREATE INDEX idx_test_section_questions_section ON test_section_questions(section_id, sequence);
+CREATE INDEX idx_test_section_questions_question ON test_section_questions(question_id);
+
+-- ============================================================
+-- Test Attempts
+-- ============================================================
+
+CREATE TABLE test_attempts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    test_id UUID NOT NULL REFERENCES entrance_tests(id) ON DELETE CASCADE,
+    registration_id UUID NOT NULL REFERENCES test_registrations(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'in_progress',
+    token_hash VARCHAR(64) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    deadline TIMESTAMPTZ NOT NULL,
+    submitted_at TIMESTAMPTZ,
+    auto_submitted BOOLEAN NOT NULL DEFAULT FALSE,
+    objective_score DECIMAL(8,2) NOT NULL DEFAULT 0,
+    descriptive_score DECIMAL(8,2) NOT NULL DEFAULT 0,
+    pending_grading INTEGER NOT NULL DEFAULT 0,
+    graded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_test_attempts_status CHECK (status IN ('in_progress', 'submitted', 'graded')),
+    CONSTRAINT chk_test_attempts_deadline CHECK (deadline > started_at),
+    CONSTRAINT uniq_test_attempts_registration UNIQUE (registration_id)
+);
+
+-- Enable RLS
+ALTER TABLE test_attempts ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_test_attempts ON test_attempts
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_test_attempts ON test_attempts
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_test_attempts_tenant ON test_attempts(tenant_id);
+CREATE INDEX idx_test_attempts_test ON test_attempts(test_id, status);
+CREATE UNIQUE INDEX idx_test_attempts_token ON test_attempts(token_hash);
+
+-- Auto-submit sweep looks for attempts past their deadline
+CREATE INDEX idx_test_attempts_deadline ON test_attempts(deadline) WHERE status = 'in_progress';
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_test_attempts
+    BEFORE UPDATE ON test_attempts
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Test Answers
+-- ============================================================
+
+CREATE TABLE test_answers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    attempt_id UUID NOT NULL REFERENCES test_attempts(id) ON DELETE CASCADE,
+    section_id UUID NOT NULL REFERENCES test_sections(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL REFERENCES test_questions(id) ON DELETE RESTRICT,
+    selected_options JSONB NOT NULL DEFAULT '[]',
+    numeric_answer DECIMAL(12,4),
+    text_answer TEXT,
+    is_correct BOOLEAN,
+    marks_awarded DECIMAL(6,2),
+    feedback TEXT,
+    graded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    graded_at TIMESTAMPTZ,
+    answered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uniq_test_answers_question UNIQUE (attempt_id, question_id)
+);
+
+-- Enable RLS
+ALTER TABLE test_answers ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_test_answers ON test_answers
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_test_answers ON test_answers
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_test_answers_tenant ON test_answers(tenant_id);
+
+-- Grading queue: descriptive answers still without marks
+CREATE INDEX idx_test_answers_ungraded ON test_answers(attempt_id) WHERE marks_awarded IS NULL;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_test_answers
+    BEFORE UPDATE ON test_answers
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
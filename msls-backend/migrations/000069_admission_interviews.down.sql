@@ -0,0 +1,32 @@
+-- Rollback Admission Interviews
+
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code IN ('interviews:read', 'interviews:manage', 'interviews:score')
+);
+
+DELETE FROM permissions WHERE code IN ('interviews:read', 'interviews:manage', 'interviews:score');
+
+DROP TRIGGER IF EXISTS set_updated_at_interview_scores ON interview_scores;
+DROP POLICY IF EXISTS bypass_rls_interview_scores ON interview_scores;
+DROP POLICY IF EXISTS tenant_isolation_interview_scores ON interview_scores;
+DROP TABLE IF EXISTS interview_scores;
+
+DROP TRIGGER IF EXISTS set_updated_at_interviews ON interviews;
+DROP POLICY IF EXISTS bypass_rls_interviews ON interviews;
+DROP POLICY IF EXISTS tenant_isolation_interviews ON interviews;
+DROP TABLE IF EXISTS interviews;
+
+DROP POLICY IF EXISTS bypass_rls_interview_panelists ON interview_panelists;
+DROP POLICY IF EXISTS tenant_isolation_interview_panelists ON interview_panelists;
+DROP TABLE IF EXISTS interview_panelists;
+
+DROP TRIGGER IF EXISTS set_updated_at_interview_slots ON interview_slots;
+DROP POLICY IF EXISTS bypass_rls_interview_slots ON interview_slots;
+DROP POLICY IF EXISTS tenant_isolation_interview_slots ON interview_slots;
+DROP TABLE IF EXISTS interview_slots;
+
+DROP TRIGGER IF EXISTS set_updated_at_interview_rubrics ON interview_rubrics;
+DROP POLICY IF EXISTS bypass_rls_interview_rubrics ON interview_rubrics;
+DROP POLICY IF EXISTS tenant_isolation_interview_rubrics ON interview_rubrics;
+DROP TABLE IF EXISTS interview_rubrics;
@@ -0,0 +1,241 @@
+-- Admission Interviews
+-- Interview slots with a panel of staff, scoring rubrics, interview bookings
+-- with parent reschedule links, and per-panelist rubric scores. A completed
+-- interview's average percentage feeds the merit list.
+
+-- ============================================================
+-- Interview Rubrics
+-- ============================================================
+
+CREATE TABLE interview_rubrics (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL REFERENCES admission_sessions(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    criteria JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Enable RLS
+ALTER TABLE interview_rubrics ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_interview_rubrics ON interview_rubrics
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_interview_rubrics ON interview_rubrics
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_interview_rubrics_tenant ON interview_rubrics(tenant_id);
+CREATE INDEX idx_interview_rubrics_session ON interview_rubrics(session_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_interview_rubrics
+    BEFORE UPDATE ON interview_rubrics
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Interview Slots
+-- ============================================================
+
+CREATE TABLE interview_slots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL REFERENCES admission_sessions(id) ON DELETE CASCADE,
+    rubric_id UUID NOT NULL REFERENCES interview_rubrics(id) ON DELETE RESTRICT,
+    class_name VARCHAR(50),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    venue VARCHAR(200),
+    capacity INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_interview_slots_time CHECK (ends_at > starts_at),
+    CONSTRAINT chk_interview_slots_capacity CHECK (capacity >= 1)
+);
+
+-- Enable RLS
+ALTER TABLE interview_slots ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_interview_slots ON interview_slots
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_interview_slots ON interview_slots
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_interview_slots_tenant ON interview_slots(tenant_id);
+CREATE INDEX idx_interview_slots_session ON interview_slots(session_id, starts_at);
+CREATE INDEX idx_interview_slots_rubric ON interview_slots(rubric_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_interview_slots
+    BEFORE UPDATE ON interview_slots
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Interview Panelists
+-- ============================================================
+
+CREATE TABLE interview_panelists (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    slot_id UUID NOT NULL REFERENCES interview_slots(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uniq_interview_panelists_staff UNIQUE (slot_id, staff_id)
+);
+
+-- Enable RLS
+ALTER TABLE interview_panelists ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_interview_panelists ON interview_panelists
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_interview_panelists ON interview_panelists
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_interview_panelists_tenant ON interview_panelists(tenant_id);
+
+-- Panel conflict checks look up a staff member's other slots
+CREATE INDEX idx_interview_panelists_staff ON interview_panelists(staff_id);
+
+-- ============================================================
+-- Interviews
+-- ============================================================
+
+CREATE TABLE interviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    slot_id UUID NOT NULL REFERENCES interview_slots(id) ON DELETE RESTRICT,
+    application_id UUID NOT NULL REFERENCES admission_applications(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    reschedule_token_hash VARCHAR(64),
+    reschedule_count INTEGER NOT NULL DEFAULT 0,
+    notified_at TIMESTAMPTZ,
+    score_percentage DECIMAL(5,2),
+    completed_at TIMESTAMPTZ,
+    remarks TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_interviews_status CHECK (status IN ('scheduled', 'completed', 'cancelled', 'no_show')),
+    CONSTRAINT chk_interviews_score CHECK (score_percentage IS NULL OR (score_percentage >= 0 AND score_percentage <= 100)),
+    CONSTRAINT chk_interviews_reschedule_count CHECK (reschedule_count >= 0)
+);
+
+-- Enable RLS
+ALTER TABLE interviews ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_interviews ON interviews
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_interviews ON interviews
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_interviews_tenant ON interviews(tenant_id);
+CREATE INDEX idx_interviews_slot ON interviews(slot_id, status);
+
+-- One scheduled or completed interview per application
+CREATE UNIQUE INDEX uniq_interviews_application_open ON interviews(application_id)
+    WHERE status IN ('scheduled', 'completed');
+
+-- Reschedule link lookup
+CREATE UNIQUE INDEX uniq_interviews_reschedule_token ON interviews(reschedule_token_hash)
+    WHERE reschedule_token_hash IS NOT NULL;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_interviews
+    BEFORE UPDATE ON interviews
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Interview Scores
+-- ============================================================
+
+CREATE TABLE interview_scores (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    interview_id UUID NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staff(id) ON DELETE RESTRICT,
+    scores JSONB NOT NULL DEFAULT '{}',
+    total_score DECIMAL(8,2) NOT NULL,
+    max_score DECIMAL(8,2) NOT NULL,
+    remarks TEXT,
+    submitted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uniq_interview_scores_panelist UNIQUE (interview_id, staff_id),
+    CONSTRAINT chk_interview_scores_total CHECK (max_score > 0 AND total_score >= 0 AND total_score <= max_score)
+);
+
+-- Enable RLS
+ALTER TABLE interview_scores ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_interview_scores ON interview_scores
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_interview_scores ON interview_scores
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_interview_scores_tenant ON interview_scores(tenant_id);
+CREATE INDEX idx_interview_scores_staff ON interview_scores(staff_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_interview_scores
+    BEFORE UPDATE ON interview_scores
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Permissions
+-- ============================================================
+
+INSERT INTO permissions (code, name, module, description) VALUES
+    ('interviews:read', 'View Interviews', 'admissions', 'View interview rubrics, slots, panels and scores'),
+    ('interviews:manage', 'Manage Interviews', 'admissions', 'Manage interview rubrics and slots, and schedule interviews'),
+    ('interviews:score', 'Score Interviews', 'admissions', 'Submit interview panel scores')
+ON CONFLICT (code) DO NOTHING;
+
+-- Super admin, admin, principal - full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('super_admin', 'admin', 'principal')
+AND p.code IN ('interviews:read', 'interviews:manage', 'interviews:score')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE interview_rubrics IS 'Interview scoring criteria with the maximum score of each';
+COMMENT ON TABLE interview_slots IS 'Interview time slots; capacity is the number of candidates per slot';
+COMMENT ON TABLE interview_panelists IS 'Staff on an interview slot''s panel';
+COMMENT ON COLUMN interviews.reschedule_token_hash IS 'SHA-256 of the parent reschedule link token; rotated on every notification';
+COMMENT ON COLUMN interviews.score_percentage IS 'Average of the panelists'' rubric percentages once every panelist has scored';
+COMMENT ON TABLE interview_scores IS 'One panelist''s rubric scores for an interview';
@@ -0,0 +1,28 @@
+-- Rollback Enquiry CRM
+
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code IN ('enquiries:assign')
+);
+
+DELETE FROM permissions WHERE code IN ('enquiries:assign');
+
+DROP TRIGGER IF EXISTS set_updated_at_enquiry_crm_settings ON enquiry_crm_settings;
+DROP POLICY IF EXISTS bypass_rls_enquiry_crm_settings ON enquiry_crm_settings;
+DROP POLICY IF EXISTS tenant_isolation_enquiry_crm_settings ON enquiry_crm_settings;
+DROP TABLE IF EXISTS enquiry_crm_settings;
+
+DROP TRIGGER IF EXISTS set_updated_at_enquiry_counsellors ON enquiry_counsellors;
+DROP POLICY IF EXISTS bypass_rls_enquiry_counsellors ON enquiry_counsellors;
+DROP POLICY IF EXISTS tenant_isolation_enquiry_counsellors ON enquiry_counsellors;
+DROP TABLE IF EXISTS enquiry_counsellors;
+
+DROP INDEX IF EXISTS idx_enquiries_last_activity;
+DROP INDEX IF EXISTS idx_enquiries_work_queue;
+
+ALTER TABLE admission_enquiries
+    DROP CONSTRAINT IF EXISTS chk_admission_enquiries_lead_score,
+    DROP COLUMN IF EXISTS closed_reason,
+    DROP COLUMN IF EXISTS last_activity_at,
+    DROP COLUMN IF EXISTS lead_score,
+    DROP COLUMN IF EXISTS assigned_at;
@@ -0,0 +1,126 @@
+-- Enquiry CRM
+-- Counsellor assignment (manual and round-robin), lead scores, inactivity
+-- tracking and per-tenant auto-assign / auto-close settings for admission
+-- enquiries.
+
+-- ============================================================
+-- Admission Enquiries
+-- ============================================================
+
+ALTER TABLE admission_enquiries
+    ADD COLUMN assigned_at TIMESTAMPTZ,
+    ADD COLUMN lead_score INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN last_activity_at TIMESTAMPTZ,
+    ADD COLUMN closed_reason VARCHAR(50);
+
+ALTER TABLE admission_enquiries
+    ADD CONSTRAINT chk_admission_enquiries_lead_score CHECK (lead_score BETWEEN 0 AND 100);
+
+-- Last activity is the later of the enquiry's own update and its latest follow-up
+UPDATE admission_enquiries e
+SET last_activity_at = GREATEST(e.updated_at, COALESCE(
+        (SELECT MAX(f.created_at) FROM enquiry_follow_ups f WHERE f.enquiry_id = e.id),
+        e.updated_at
+    )),
+    assigned_at = CASE WHEN e.assigned_to IS NOT NULL THEN e.updated_at END;
+
+-- Work queue: open enquiries by assignee and follow-up date
+CREATE INDEX idx_enquiries_work_queue ON admission_enquiries(tenant_id, assigned_to, follow_up_date)
+    WHERE status IN ('new', 'contacted', 'interested') AND deleted_at IS NULL;
+
+-- Auto-close sweep
+CREATE INDEX idx_enquiries_last_activity ON admission_enquiries(tenant_id, last_activity_at)
+    WHERE status IN ('new', 'contacted', 'interested') AND deleted_at IS NULL;
+
+-- ============================================================
+-- Enquiry Counsellors
+-- ============================================================
+
+CREATE TABLE enquiry_counsellors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_assigned_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT uniq_enquiry_counsellors_user UNIQUE (tenant_id, user_id)
+);
+
+-- Enable RLS
+ALTER TABLE enquiry_counsellors ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_enquiry_counsellors ON enquiry_counsellors
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_enquiry_counsellors ON enquiry_counsellors
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_enquiry_counsellors_tenant ON enquiry_counsellors(tenant_id, last_assigned_at);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_enquiry_counsellors
+    BEFORE UPDATE ON enquiry_counsellors
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Enquiry CRM Settings
+-- ============================================================
+
+CREATE TABLE enquiry_crm_settings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    auto_assign BOOLEAN NOT NULL DEFAULT FALSE,
+    auto_close_after_days INTEGER NOT NULL DEFAULT 60,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT uniq_enquiry_crm_settings_tenant UNIQUE (tenant_id),
+    CONSTRAINT chk_enquiry_crm_settings_auto_close CHECK (auto_close_after_days >= 0)
+);
+
+-- Enable RLS
+ALTER TABLE enquiry_crm_settings ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_enquiry_crm_settings ON enquiry_crm_settings
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_enquiry_crm_settings ON enquiry_crm_settings
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_enquiry_crm_settings
+    BEFORE UPDATE ON enquiry_crm_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Permissions
+-- ============================================================
+
+INSERT INTO permissions (code, name, module, description) VALUES
+    ('enquiries:assign', 'Assign Enquiries', 'admissions', 'Manage enquiry counsellors and settings, and assign enquiries')
+ON CONFLICT (code) DO NOTHING;
+
+-- Super admin, admin, principal - full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('super_admin', 'admin', 'principal')
+AND p.code IN ('enquiries:assign')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON COLUMN admission_enquiries.lead_score IS 'Conversion likelihood 0-100: source (30), follow-up responsiveness (40), class seat availability (30)';
+COMMENT ON COLUMN admission_enquiries.last_activity_at IS 'Last update or follow-up; drives inactivity auto-close';
+COMMENT ON COLUMN admission_enquiries.closed_reason IS 'Why the enquiry closed, e.g. inactive for the auto-close sweep';
+COMMENT ON TABLE enquiry_counsellors IS 'Counsellor pool for enquiry assignment; round-robin picks the longest since last_assigned_at';
+COMMENT ON COLUMN enquiry_crm_settings.auto_close_after_days IS 'Days without activity before open enquiries close; 0 disables auto-close';
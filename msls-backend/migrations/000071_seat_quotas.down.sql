@@ -0,0 +1,13 @@
+-- Rollback Seat Quotas
+
+ALTER TABLE admission_seats
+    DROP COLUMN IF EXISTS quota_release_date;
+
+ALTER TABLE admission_decisions
+    DROP COLUMN IF EXISTS allocated_quota;
+
+DROP INDEX IF EXISTS idx_applications_session_class_status;
+
+ALTER TABLE admission_applications
+    DROP CONSTRAINT IF EXISTS chk_admission_applications_quota_category,
+    DROP COLUMN IF EXISTS quota_category;
@@ -0,0 +1,42 @@
+-- Seat Quotas
+-- Quota categories for applications (RTE/EWS, staff ward, sibling, management),
+-- the quota each approval took its seat from, and the date from which unfilled
+-- reserved seats are released to the general pool.
+
+-- ============================================================
+-- Admission Applications
+-- ============================================================
+
+ALTER TABLE admission_applications
+    ADD COLUMN quota_category VARCHAR(30) NOT NULL DEFAULT 'general';
+
+ALTER TABLE admission_applications
+    ADD CONSTRAINT chk_admission_applications_quota_category
+    CHECK (quota_category IN ('general', 'rte', 'staff_ward', 'sibling', 'management'));
+
+-- Waitlist promotion and seat matrix: applications by class and status
+CREATE INDEX idx_applications_session_class_status
+    ON admission_applications(tenant_id, session_id, class_applying, status);
+
+-- ============================================================
+-- Admission Decisions
+-- ============================================================
+
+ALTER TABLE admission_decisions
+    ADD COLUMN allocated_quota VARCHAR(30);
+
+-- Approvals made before quotas were enforced took general seats
+UPDATE admission_decisions
+SET allocated_quota = 'general'
+WHERE decision = 'approved';
+
+-- ============================================================
+-- Admission Seats
+-- ============================================================
+
+ALTER TABLE admission_seats
+    ADD COLUMN quota_release_date DATE;
+
+COMMENT ON COLUMN admission_applications.quota_category IS 'Seat quota the applicant is eligible for; general when none';
+COMMENT ON COLUMN admission_decisions.allocated_quota IS 'Quota the approved application took its seat from';
+COMMENT ON COLUMN admission_seats.quota_release_date IS 'Date from which unfilled reserved seats count as general seats';
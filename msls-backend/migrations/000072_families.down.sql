@@ -0,0 +1,22 @@
+-- Rollback Families
+
+ALTER TABLE admission_applications
+    DROP COLUMN IF EXISTS staff_parent_id,
+    DROP COLUMN IF EXISTS has_sibling,
+    DROP COLUMN IF EXISTS family_id;
+
+DROP INDEX IF EXISTS idx_student_guardians_family_guardian;
+ALTER TABLE student_guardians DROP COLUMN IF EXISTS family_guardian_id;
+
+DROP INDEX IF EXISTS idx_students_family;
+ALTER TABLE students DROP COLUMN IF EXISTS family_id;
+
+DROP TRIGGER IF EXISTS set_updated_at_family_guardians ON family_guardians;
+DROP POLICY IF EXISTS bypass_rls_family_guardians ON family_guardians;
+DROP POLICY IF EXISTS tenant_isolation_family_guardians ON family_guardians;
+DROP TABLE IF EXISTS family_guardians;
+
+DROP TRIGGER IF EXISTS set_updated_at_families ON families;
+DROP POLICY IF EXISTS bypass_rls_families ON families;
+DROP POLICY IF EXISTS tenant_isolation_families ON families;
+DROP TABLE IF EXISTS families;
@@ -0,0 +1,112 @@
+-- Families
+-- Households linking siblings to the guardians they share. Family guardians are
+-- deduplicated by phone/email and can be linked to staff for staff-ward
+-- benefits. Applications record the family, sibling and staff parent detected
+-- from the parents' contacts.
+
+-- ============================================================
+-- Families
+-- ============================================================
+
+CREATE TABLE families (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Enable RLS
+ALTER TABLE families ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_families ON families
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_families ON families
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_families_tenant ON families(tenant_id, name);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_families
+    BEFORE UPDATE ON families
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Family Guardians
+-- ============================================================
+
+CREATE TABLE family_guardians (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL REFERENCES families(id) ON DELETE CASCADE,
+    first_name VARCHAR(100) NOT NULL,
+    last_name VARCHAR(100) NOT NULL,
+    phone VARCHAR(15),
+    email VARCHAR(255),
+    staff_id UUID REFERENCES staff(id) ON DELETE SET NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Enable RLS
+ALTER TABLE family_guardians ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_family_guardians ON family_guardians
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_family_guardians ON family_guardians
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_family_guardians_family ON family_guardians(family_id);
+CREATE INDEX idx_family_guardians_phone ON family_guardians(tenant_id, phone) WHERE phone IS NOT NULL AND phone <> '';
+CREATE INDEX idx_family_guardians_email ON family_guardians(tenant_id, email) WHERE email IS NOT NULL AND email <> '';
+CREATE INDEX idx_family_guardians_staff ON family_guardians(staff_id) WHERE staff_id IS NOT NULL;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_family_guardians
+    BEFORE UPDATE ON family_guardians
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Students and Student Guardians
+-- ============================================================
+
+ALTER TABLE students
+    ADD COLUMN family_id UUID REFERENCES families(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_students_family ON students(family_id) WHERE family_id IS NOT NULL;
+
+ALTER TABLE student_guardians
+    ADD COLUMN family_guardian_id UUID REFERENCES family_guardians(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_student_guardians_family_guardian ON student_guardians(family_guardian_id)
+    WHERE family_guardian_id IS NOT NULL;
+
+-- ============================================================
+-- Admission Applications
+-- ============================================================
+
+ALTER TABLE admission_applications
+    ADD COLUMN family_id UUID REFERENCES families(id) ON DELETE SET NULL,
+    ADD COLUMN has_sibling BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN staff_parent_id UUID REFERENCES staff(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE families IS 'Households linking siblings and the guardians they share';
+COMMENT ON TABLE family_guardians IS 'Deduplicated guardians of a family; phone and email are stored normalized';
+COMMENT ON COLUMN family_guardians.staff_id IS 'Staff member who is this guardian; makes the family''s students staff wards';
+COMMENT ON COLUMN student_guardians.family_guardian_id IS 'Family guardian this per-student guardian record belongs to';
+COMMENT ON COLUMN admission_applications.has_sibling IS 'An active student shares a guardian with the applicant';
+COMMENT ON COLUMN admission_applications.staff_parent_id IS 'Staff member detected as the applicant''s parent';
@@ -0,0 +1,23 @@
+-- Rollback Certificates
+
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code IN ('certificates:read', 'certificates:write', 'certificates:approve')
+);
+
+DELETE FROM permissions WHERE code IN ('certificates:read', 'certificates:write', 'certificates:approve');
+
+DROP TRIGGER IF EXISTS set_updated_at_certificate_sequences ON certificate_sequences;
+DROP POLICY IF EXISTS bypass_rls_certificate_sequences ON certificate_sequences;
+DROP POLICY IF EXISTS tenant_isolation_certificate_sequences ON certificate_sequences;
+DROP TABLE IF EXISTS certificate_sequences;
+
+DROP TRIGGER IF EXISTS set_updated_at_certificates ON certificates;
+DROP POLICY IF EXISTS bypass_rls_certificates ON certificates;
+DROP POLICY IF EXISTS tenant_isolation_certificates ON certificates;
+DROP TABLE IF EXISTS certificates;
+
+DROP TRIGGER IF EXISTS set_updated_at_certificate_templates ON certificate_templates;
+DROP POLICY IF EXISTS bypass_rls_certificate_templates ON certificate_templates;
+DROP POLICY IF EXISTS tenant_isolation_certificate_templates ON certificate_templates;
+DROP TABLE IF EXISTS certificate_templates;
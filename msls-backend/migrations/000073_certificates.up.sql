@@ -0,0 +1,167 @@
+-- Certificates
+-- Transfer, bonafide and character certificates. Requests capture the values
+-- to print from the student's records and wait for approval; approval assigns
+-- a serial number per type and year and a code for the public QR
+-- verification link. The register keeps cancelled certificates.
+
+-- ============================================================
+-- Certificate Templates
+-- ============================================================
+
+CREATE TABLE certificate_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    certificate_type VARCHAR(20) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    school_name VARCHAR(200),
+    school_address VARCHAR(500),
+    affiliation VARCHAR(200),
+    signatory_name VARCHAR(200),
+    signatory_designation VARCHAR(100),
+    footer_text TEXT,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_certificate_templates_type CHECK (certificate_type IN ('transfer', 'bonafide', 'character'))
+);
+
+-- Enable RLS
+ALTER TABLE certificate_templates ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_certificate_templates ON certificate_templates
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_certificate_templates ON certificate_templates
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_certificate_templates_tenant ON certificate_templates(tenant_id, certificate_type);
+CREATE UNIQUE INDEX idx_certificate_templates_default ON certificate_templates(tenant_id, certificate_type)
+    WHERE is_default;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_certificate_templates
+    BEFORE UPDATE ON certificate_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Certificates
+-- ============================================================
+
+CREATE TABLE certificates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    template_id UUID REFERENCES certificate_templates(id) ON DELETE SET NULL,
+    certificate_type VARCHAR(20) NOT NULL,
+    serial_number VARCHAR(30),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    purpose VARCHAR(500),
+    fields JSONB NOT NULL DEFAULT '{}',
+    verification_code VARCHAR(32),
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    approved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    issued_at TIMESTAMPTZ,
+    rejected_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    rejected_at TIMESTAMPTZ,
+    rejection_reason TEXT,
+    cancelled_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    cancelled_at TIMESTAMPTZ,
+    cancel_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_certificates_type CHECK (certificate_type IN ('transfer', 'bonafide', 'character')),
+    CONSTRAINT chk_certificates_status CHECK (status IN ('pending', 'issued', 'rejected', 'cancelled')),
+    CONSTRAINT uniq_certificates_serial UNIQUE (tenant_id, certificate_type, serial_number),
+    CONSTRAINT uniq_certificates_verification_code UNIQUE (verification_code)
+);
+
+-- Enable RLS
+ALTER TABLE certificates ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_certificates ON certificates
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_certificates ON certificates
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_certificates_tenant ON certificates(tenant_id, requested_at DESC);
+CREATE INDEX idx_certificates_student ON certificates(student_id);
+CREATE INDEX idx_certificates_status ON certificates(tenant_id, status);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_certificates
+    BEFORE UPDATE ON certificates
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Certificate Sequences
+-- ============================================================
+
+CREATE TABLE certificate_sequences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    certificate_type VARCHAR(20) NOT NULL,
+    year INTEGER NOT NULL,
+    last_sequence INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uniq_certificate_sequences UNIQUE (tenant_id, certificate_type, year)
+);
+
+-- Enable RLS
+ALTER TABLE certificate_sequences ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_certificate_sequences ON certificate_sequences
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_certificate_sequences ON certificate_sequences
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_certificate_sequences
+    BEFORE UPDATE ON certificate_sequences
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Permissions
+-- ============================================================
+
+INSERT INTO permissions (code, name, module, description) VALUES
+    ('certificates:read', 'View Certificates', 'students', 'View the certificate register and download issued certificates'),
+    ('certificates:write', 'Request Certificates', 'students', 'Request certificates and manage certificate templates'),
+    ('certificates:approve', 'Approve Certificates', 'students', 'Approve, reject and cancel certificates')
+ON CONFLICT (code) DO NOTHING;
+
+-- Super admin, admin, principal - full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('super_admin', 'admin', 'principal')
+AND p.code IN ('certificates:read', 'certificates:write', 'certificates:approve')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE certificate_templates IS 'Letterhead and wording per certificate type; the body uses {{placeholder}} fields';
+COMMENT ON TABLE certificates IS 'Certificate register; fields, wording and letterhead are frozen when issued';
+COMMENT ON COLUMN certificates.serial_number IS 'Assigned on approval, e.g. TC/2026/0001';
+COMMENT ON COLUMN certificates.verification_code IS 'Random code in the QR verification link';
+COMMENT ON TABLE certificate_sequences IS 'Last serial number issued per certificate type and year';
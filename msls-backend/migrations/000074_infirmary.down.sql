@@ -0,0 +1,11 @@
+-- Rollback Infirmary
+
+DROP TRIGGER IF EXISTS set_updated_at_medication_administrations ON medication_administrations;
+DROP POLICY IF EXISTS bypass_rls_medication_administrations ON medication_administrations;
+DROP POLICY IF EXISTS tenant_isolation_medication_administrations ON medication_administrations;
+DROP TABLE IF EXISTS medication_administrations;
+
+DROP TRIGGER IF EXISTS set_updated_at_infirmary_visits ON infirmary_visits;
+DROP POLICY IF EXISTS bypass_rls_infirmary_visits ON infirmary_visits;
+DROP POLICY IF EXISTS tenant_isolation_infirmary_visits ON infirmary_visits;
+DROP TABLE IF EXISTS infirmary_visits;
@@ -0,0 +1,113 @@
+-- Infirmary
+-- Clinic visits with vitals, treatment and where the student went afterwards,
+-- and the doses of school-administered medications given, missed or refused.
+-- The daily medication schedule is generated from student_medications; only
+-- recorded outcomes are stored.
+
+-- ============================================================
+-- Infirmary Visits
+-- ============================================================
+
+CREATE TABLE infirmary_visits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    check_in_at TIMESTAMPTZ NOT NULL,
+    check_out_at TIMESTAMPTZ,
+    complaint VARCHAR(500) NOT NULL,
+    symptoms TEXT,
+    temperature DECIMAL(4,1),
+    pulse_rate INTEGER,
+    respiratory_rate INTEGER,
+    blood_pressure_sys INTEGER,
+    blood_pressure_dia INTEGER,
+    oxygen_saturation INTEGER,
+    treatment TEXT,
+    disposition VARCHAR(30) NOT NULL DEFAULT 'under_observation',
+    released_to VARCHAR(200),
+    notes TEXT,
+    guardian_notified_at TIMESTAMPTZ,
+    attendance_marked_at TIMESTAMPTZ,
+    attended_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_infirmary_visits_disposition CHECK (disposition IN ('under_observation', 'returned_to_class', 'sent_home', 'referred_to_hospital')),
+    CONSTRAINT chk_infirmary_visits_check_out CHECK (check_out_at IS NULL OR check_out_at >= check_in_at)
+);
+
+-- Enable RLS
+ALTER TABLE infirmary_visits ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_infirmary_visits ON infirmary_visits
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_infirmary_visits ON infirmary_visits
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_infirmary_visits_tenant ON infirmary_visits(tenant_id, check_in_at DESC);
+CREATE INDEX idx_infirmary_visits_student ON infirmary_visits(student_id, check_in_at DESC);
+CREATE INDEX idx_infirmary_visits_open ON infirmary_visits(tenant_id)
+    WHERE check_out_at IS NULL;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_infirmary_visits
+    BEFORE UPDATE ON infirmary_visits
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Medication Administrations
+-- ============================================================
+
+CREATE TABLE medication_administrations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    medication_id UUID NOT NULL REFERENCES student_medications(id) ON DELETE CASCADE,
+    administration_date DATE NOT NULL,
+    scheduled_time VARCHAR(5) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    dose_given VARCHAR(50),
+    administered_at TIMESTAMPTZ,
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    visit_id UUID REFERENCES infirmary_visits(id) ON DELETE SET NULL,
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_medication_administrations_status CHECK (status IN ('given', 'missed', 'refused'))
+);
+
+-- Enable RLS
+ALTER TABLE medication_administrations ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_medication_administrations ON medication_administrations
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_medication_administrations ON medication_administrations
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE UNIQUE INDEX idx_medication_administrations_dose
+    ON medication_administrations(medication_id, administration_date, scheduled_time);
+CREATE INDEX idx_medication_administrations_date ON medication_administrations(tenant_id, administration_date);
+CREATE INDEX idx_medication_administrations_student ON medication_administrations(student_id, administration_date DESC);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_medication_administrations
+    BEFORE UPDATE ON medication_administrations
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+COMMENT ON TABLE infirmary_visits IS 'Student visits to the school infirmary';
+COMMENT ON COLUMN infirmary_visits.disposition IS 'Where the student went: under_observation until discharged';
+COMMENT ON COLUMN infirmary_visits.attendance_marked_at IS 'When period attendance was updated for the visit';
+COMMENT ON TABLE medication_administrations IS 'Outcome of each scheduled or as-needed dose given at school';
+COMMENT ON COLUMN medication_administrations.scheduled_time IS 'Scheduled HH:MM; the time given for as-needed medications';
@@ -0,0 +1,15 @@
+-- Rollback Immunization
+
+DROP POLICY IF EXISTS bypass_rls_immunization_reminders ON immunization_reminders;
+DROP POLICY IF EXISTS tenant_isolation_immunization_reminders ON immunization_reminders;
+DROP TABLE IF EXISTS immunization_reminders;
+
+DROP TRIGGER IF EXISTS set_updated_at_immunization_reminder_campaigns ON immunization_reminder_campaigns;
+DROP POLICY IF EXISTS bypass_rls_immunization_reminder_campaigns ON immunization_reminder_campaigns;
+DROP POLICY IF EXISTS tenant_isolation_immunization_reminder_campaigns ON immunization_reminder_campaigns;
+DROP TABLE IF EXISTS immunization_reminder_campaigns;
+
+DROP TRIGGER IF EXISTS set_updated_at_immunization_schedule_items ON immunization_schedule_items;
+DROP POLICY IF EXISTS bypass_rls_immunization_schedule_items ON immunization_schedule_items;
+DROP POLICY IF EXISTS tenant_isolation_immunization_schedule_items ON immunization_schedule_items;
+DROP TABLE IF EXISTS immunization_schedule_items;
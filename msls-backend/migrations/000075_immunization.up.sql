@@ -0,0 +1,133 @@
+-- Immunization
+-- A tenant-configurable immunization schedule of vaccine doses and the age
+-- window in which each is due. Student compliance is computed from date of
+-- birth and student_vaccinations; reminder campaigns record the SMS sent to
+-- each guardian of a student missing doses.
+
+-- ============================================================
+-- Immunization Schedule
+-- ============================================================
+
+CREATE TABLE immunization_schedule_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    vaccine_name VARCHAR(100) NOT NULL,
+    dose_number INTEGER NOT NULL DEFAULT 1,
+    min_age_months INTEGER NOT NULL DEFAULT 0,
+    max_age_months INTEGER,
+    is_mandatory BOOLEAN NOT NULL DEFAULT TRUE,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_immunization_schedule_items_dose CHECK (dose_number >= 1),
+    CONSTRAINT chk_immunization_schedule_items_age CHECK (
+        min_age_months >= 0 AND (max_age_months IS NULL OR max_age_months >= min_age_months)
+    )
+);
+
+-- Enable RLS
+ALTER TABLE immunization_schedule_items ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_immunization_schedule_items ON immunization_schedule_items
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_immunization_schedule_items ON immunization_schedule_items
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE UNIQUE INDEX idx_immunization_schedule_items_dose
+    ON immunization_schedule_items(tenant_id, LOWER(vaccine_name), dose_number);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_immunization_schedule_items
+    BEFORE UPDATE ON immunization_schedule_items
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Reminder Campaigns
+-- ============================================================
+
+CREATE TABLE immunization_reminder_campaigns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    branch_id UUID REFERENCES branches(id) ON DELETE SET NULL,
+    class_id UUID REFERENCES classes(id) ON DELETE SET NULL,
+    section_id UUID REFERENCES sections(id) ON DELETE SET NULL,
+    include_due BOOLEAN NOT NULL DEFAULT FALSE,
+    message TEXT NOT NULL,
+    recipient_count INTEGER NOT NULL DEFAULT 0,
+    sent_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Enable RLS
+ALTER TABLE immunization_reminder_campaigns ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_immunization_reminder_campaigns ON immunization_reminder_campaigns
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_immunization_reminder_campaigns ON immunization_reminder_campaigns
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_immunization_reminder_campaigns_tenant ON immunization_reminder_campaigns(tenant_id, sent_at DESC);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_immunization_reminder_campaigns
+    BEFORE UPDATE ON immunization_reminder_campaigns
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Reminders
+-- ============================================================
+
+CREATE TABLE immunization_reminders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    campaign_id UUID NOT NULL REFERENCES immunization_reminder_campaigns(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    guardian_id UUID REFERENCES student_guardians(id) ON DELETE SET NULL,
+    phone VARCHAR(15),
+    pending_vaccines TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_immunization_reminders_status CHECK (status IN ('sent', 'failed', 'skipped'))
+);
+
+-- Enable RLS
+ALTER TABLE immunization_reminders ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_immunization_reminders ON immunization_reminders
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_immunization_reminders ON immunization_reminders
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_immunization_reminders_campaign ON immunization_reminders(campaign_id);
+CREATE INDEX idx_immunization_reminders_student ON immunization_reminders(student_id);
+
+COMMENT ON TABLE immunization_schedule_items IS 'Vaccine doses children are expected to have, with the age window in months';
+COMMENT ON COLUMN immunization_schedule_items.max_age_months IS 'Dose is overdue after this age; NULL means it never becomes overdue';
+COMMENT ON TABLE immunization_reminder_campaigns IS 'SMS reminders sent to guardians of students missing vaccinations';
+COMMENT ON TABLE immunization_reminders IS 'Delivery outcome of each reminder in a campaign';
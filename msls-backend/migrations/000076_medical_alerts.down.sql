@@ -0,0 +1,4 @@
+-- Rollback Medical Alerts
+
+DROP INDEX IF EXISTS idx_conditions_alerts;
+DROP INDEX IF EXISTS idx_allergies_alerts;
@@ -0,0 +1,20 @@
+-- Medical Alerts
+-- Life-threatening and severe allergies and severe chronic conditions are
+-- looked up for whole rosters (attendance, substitutions, hall tickets), so
+-- the alert rows get partial indexes keyed by tenant and student.
+
+-- ============================================================
+-- Allergies
+-- ============================================================
+
+CREATE INDEX idx_allergies_alerts
+    ON student_allergies(tenant_id, student_id)
+    WHERE is_active = true AND severity IN ('severe', 'life_threatening');
+
+-- ============================================================
+-- Chronic Conditions
+-- ============================================================
+
+CREATE INDEX idx_conditions_alerts
+    ON student_chronic_conditions(tenant_id, student_id)
+    WHERE is_active = true AND severity = 'severe';
@@ -0,0 +1,27 @@
+-- Rollback Behaviour Points
+
+DROP POLICY IF EXISTS bypass_rls_house_members ON house_members;
+DROP POLICY IF EXISTS tenant_isolation_house_members ON house_members;
+DROP TABLE IF EXISTS house_members;
+
+DROP TRIGGER IF EXISTS set_updated_at_houses ON houses;
+DROP POLICY IF EXISTS bypass_rls_houses ON houses;
+DROP POLICY IF EXISTS tenant_isolation_houses ON houses;
+DROP TABLE IF EXISTS houses;
+
+DROP POLICY IF EXISTS bypass_rls_behavior_threshold_triggers ON behavior_threshold_triggers;
+DROP POLICY IF EXISTS tenant_isolation_behavior_threshold_triggers ON behavior_threshold_triggers;
+DROP TABLE IF EXISTS behavior_threshold_triggers;
+
+DROP TRIGGER IF EXISTS set_updated_at_behavior_thresholds ON behavior_thresholds;
+DROP POLICY IF EXISTS bypass_rls_behavior_thresholds ON behavior_thresholds;
+DROP POLICY IF EXISTS tenant_isolation_behavior_thresholds ON behavior_thresholds;
+DROP TABLE IF EXISTS behavior_thresholds;
+
+DROP TRIGGER IF EXISTS set_updated_at_behavior_point_rules ON behavior_point_rules;
+DROP POLICY IF EXISTS bypass_rls_behavior_point_rules ON behavior_point_rules;
+DROP POLICY IF EXISTS tenant_isolation_behavior_point_rules ON behavior_point_rules;
+DROP TABLE IF EXISTS behavior_point_rules;
+
+DROP INDEX IF EXISTS idx_student_behavioral_incidents_student_date;
+ALTER TABLE student_behavioral_incidents DROP COLUMN IF EXISTS points;
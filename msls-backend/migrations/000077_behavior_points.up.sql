@@ -0,0 +1,205 @@
+-- Behaviour Points
+-- Incidents carry merit (positive) or demerit (negative) points from a
+-- tenant-configurable matrix by incident type and severity. Demerit totals
+-- per academic term are checked against thresholds that schedule a follow-up
+-- meeting, notify the guardian and alert the class teacher once per term.
+-- Students can be grouped into houses for a points leaderboard.
+
+-- ============================================================
+-- Incident Points
+-- ============================================================
+
+ALTER TABLE student_behavioral_incidents
+    ADD COLUMN points INTEGER NOT NULL DEFAULT 0;
+
+-- Backfill existing incidents with the default points matrix
+UPDATE student_behavioral_incidents SET points = CASE incident_type
+    WHEN 'positive_recognition' THEN
+        CASE severity WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'critical' THEN 5 ELSE 0 END
+    WHEN 'minor_infraction' THEN
+        CASE severity WHEN 'low' THEN -1 WHEN 'medium' THEN -2 WHEN 'high' THEN -3 WHEN 'critical' THEN -5 ELSE 0 END
+    WHEN 'major_violation' THEN
+        CASE severity WHEN 'low' THEN -5 WHEN 'medium' THEN -10 WHEN 'high' THEN -15 WHEN 'critical' THEN -20 ELSE 0 END
+    ELSE 0
+END;
+
+CREATE INDEX idx_student_behavioral_incidents_student_date
+    ON student_behavioral_incidents(tenant_id, student_id, incident_date);
+
+-- ============================================================
+-- Point Rules
+-- ============================================================
+
+CREATE TABLE behavior_point_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    incident_type VARCHAR(30) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    points INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_behavior_point_rules_sign CHECK (
+        (incident_type = 'positive_recognition' AND points >= 0) OR
+        (incident_type <> 'positive_recognition' AND points <= 0)
+    )
+);
+
+-- Enable RLS
+ALTER TABLE behavior_point_rules ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_behavior_point_rules ON behavior_point_rules
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_behavior_point_rules ON behavior_point_rules
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE UNIQUE INDEX idx_behavior_point_rules_type_severity
+    ON behavior_point_rules(tenant_id, incident_type, severity);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_behavior_point_rules
+    BEFORE UPDATE ON behavior_point_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Thresholds
+-- ============================================================
+
+CREATE TABLE behavior_thresholds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    demerit_points INTEGER NOT NULL,
+    create_follow_up BOOLEAN NOT NULL DEFAULT TRUE,
+    follow_up_after_days INTEGER NOT NULL DEFAULT 2,
+    notify_guardian BOOLEAN NOT NULL DEFAULT TRUE,
+    alert_class_teacher BOOLEAN NOT NULL DEFAULT TRUE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_behavior_thresholds_points CHECK (demerit_points > 0),
+    CONSTRAINT chk_behavior_thresholds_days CHECK (follow_up_after_days >= 0)
+);
+
+-- Enable RLS
+ALTER TABLE behavior_thresholds ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_behavior_thresholds ON behavior_thresholds
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_behavior_thresholds ON behavior_thresholds
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE UNIQUE INDEX idx_behavior_thresholds_points
+    ON behavior_thresholds(tenant_id, demerit_points);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_behavior_thresholds
+    BEFORE UPDATE ON behavior_thresholds
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Threshold Triggers
+-- ============================================================
+
+CREATE TABLE behavior_threshold_triggers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    threshold_id UUID NOT NULL REFERENCES behavior_thresholds(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    term_id UUID NOT NULL REFERENCES academic_terms(id) ON DELETE CASCADE,
+    incident_id UUID NOT NULL REFERENCES student_behavioral_incidents(id) ON DELETE CASCADE,
+    demerit_total INTEGER NOT NULL,
+    follow_up_id UUID REFERENCES incident_follow_ups(id) ON DELETE SET NULL,
+    guardian_notified BOOLEAN NOT NULL DEFAULT FALSE,
+    class_teacher_alerted BOOLEAN NOT NULL DEFAULT FALSE,
+    triggered_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Enable RLS
+ALTER TABLE behavior_threshold_triggers ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_behavior_threshold_triggers ON behavior_threshold_triggers
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_behavior_threshold_triggers ON behavior_threshold_triggers
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE UNIQUE INDEX idx_behavior_threshold_triggers_once
+    ON behavior_threshold_triggers(tenant_id, threshold_id, student_id, term_id);
+CREATE INDEX idx_behavior_threshold_triggers_student
+    ON behavior_threshold_triggers(tenant_id, student_id, term_id);
+
+-- ============================================================
+-- Houses
+-- ============================================================
+
+CREATE TABLE houses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    color VARCHAR(20),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Enable RLS
+ALTER TABLE houses ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_houses ON houses
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_houses ON houses
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE UNIQUE INDEX idx_houses_name ON houses(tenant_id, LOWER(name));
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_houses
+    BEFORE UPDATE ON houses
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- House Members
+-- ============================================================
+
+CREATE TABLE house_members (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    house_id UUID NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Enable RLS
+ALTER TABLE house_members ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_house_members ON house_members
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_house_members ON house_members
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes (a student belongs to one house)
+CREATE UNIQUE INDEX idx_house_members_student ON house_members(tenant_id, student_id);
+CREATE INDEX idx_house_members_house ON house_members(house_id);
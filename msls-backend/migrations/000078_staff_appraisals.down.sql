@@ -0,0 +1,30 @@
+-- Reverse Staff Appraisals migration
+
+-- Remove permissions from roles
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code IN ('appraisals.view', 'appraisals.manage', 'appraisals.self_assess', 'appraisals.review', 'appraisals.signoff')
+);
+
+-- Remove permissions
+DELETE FROM permissions WHERE code IN ('appraisals.view', 'appraisals.manage', 'appraisals.self_assess', 'appraisals.review', 'appraisals.signoff');
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS set_updated_at_appraisals ON appraisals;
+DROP TRIGGER IF EXISTS set_updated_at_appraisal_cycles ON appraisal_cycles;
+DROP TRIGGER IF EXISTS set_updated_at_appraisal_templates ON appraisal_templates;
+
+-- Drop policies
+DROP POLICY IF EXISTS bypass_rls_appraisals ON appraisals;
+DROP POLICY IF EXISTS tenant_isolation_appraisals ON appraisals;
+DROP POLICY IF EXISTS bypass_rls_appraisal_cycles ON appraisal_cycles;
+DROP POLICY IF EXISTS tenant_isolation_appraisal_cycles ON appraisal_cycles;
+DROP POLICY IF EXISTS bypass_rls_appraisal_templates ON appraisal_templates;
+DROP POLICY IF EXISTS tenant_isolation_appraisal_templates ON appraisal_templates;
+
+-- Drop tables
+DROP TABLE IF EXISTS appraisal_goals;
+DROP TABLE IF EXISTS appraisals;
+DROP TABLE IF EXISTS appraisal_cycles;
+DROP TABLE IF EXISTS appraisal_template_goals;
+DROP TABLE IF EXISTS appraisal_templates;
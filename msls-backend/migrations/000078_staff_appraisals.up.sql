@@ -0,0 +1,232 @@
+-- Staff Appraisals
+-- Appraisal cycles appraise each active staff member against the KRA/goal
+-- template for their designation: self-assessment, then review by the
+-- reporting manager, then sign-off by the manager's own manager. Probation
+-- confirmation reviews are opened automatically ahead of each probation end
+-- date. An approved increment is applied as a new staff salary revision.
+
+-- ============================================================
+-- Templates
+-- ============================================================
+
+CREATE TABLE appraisal_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    designation_id UUID REFERENCES designations(id) ON DELETE CASCADE,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id)
+);
+
+-- Enable RLS
+ALTER TABLE appraisal_templates ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_appraisal_templates ON appraisal_templates
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_appraisal_templates ON appraisal_templates
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- One template per designation, plus one default (no designation)
+CREATE UNIQUE INDEX idx_appraisal_templates_designation
+    ON appraisal_templates(tenant_id, COALESCE(designation_id, '00000000-0000-0000-0000-000000000000'::UUID));
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_appraisal_templates
+    BEFORE UPDATE ON appraisal_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Template goals (KRAs)
+CREATE TABLE appraisal_template_goals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    template_id UUID NOT NULL REFERENCES appraisal_templates(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    weight INTEGER NOT NULL,
+    display_order INTEGER NOT NULL DEFAULT 0,
+
+    CONSTRAINT chk_appraisal_template_goals_weight CHECK (weight BETWEEN 1 AND 100)
+);
+
+CREATE INDEX idx_appraisal_template_goals_template ON appraisal_template_goals(template_id);
+
+-- ============================================================
+-- Cycles
+-- ============================================================
+
+CREATE TABLE appraisal_cycles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    branch_id UUID REFERENCES branches(id) ON DELETE SET NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    self_assessment_due DATE,
+    manager_review_due DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    launched_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+
+    CONSTRAINT chk_appraisal_cycles_status CHECK (status IN ('draft', 'active', 'closed')),
+    CONSTRAINT chk_appraisal_cycles_period CHECK (period_end >= period_start)
+);
+
+-- Enable RLS
+ALTER TABLE appraisal_cycles ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_appraisal_cycles ON appraisal_cycles
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_appraisal_cycles ON appraisal_cycles
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_appraisal_cycles_tenant_status ON appraisal_cycles(tenant_id, status);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_appraisal_cycles
+    BEFORE UPDATE ON appraisal_cycles
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Appraisals
+-- ============================================================
+
+CREATE TABLE appraisals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    cycle_id UUID REFERENCES appraisal_cycles(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    template_id UUID REFERENCES appraisal_templates(id) ON DELETE SET NULL,
+    manager_id UUID REFERENCES staff(id) ON DELETE SET NULL,
+    reviewer_id UUID REFERENCES staff(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'self_assessment',
+    due_date DATE,
+    self_comments TEXT,
+    self_submitted_at TIMESTAMPTZ,
+    manager_comments TEXT,
+    manager_submitted_at TIMESTAMPTZ,
+    overall_rating DECIMAL(3,2),
+    probation_outcome VARCHAR(20),
+    extend_probation_to DATE,
+    increment_percent DECIMAL(5,2),
+    increment_effective_from DATE,
+    reviewer_comments TEXT,
+    signed_off_at TIMESTAMPTZ,
+    signed_off_by UUID REFERENCES users(id),
+    salary_revision_id UUID REFERENCES staff_salaries(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_appraisals_type CHECK (type IN ('cycle', 'probation')),
+    CONSTRAINT chk_appraisals_cycle CHECK ((type = 'cycle') = (cycle_id IS NOT NULL)),
+    CONSTRAINT chk_appraisals_status CHECK (status IN ('self_assessment', 'manager_review', 'reviewer_signoff', 'completed')),
+    CONSTRAINT chk_appraisals_outcome CHECK (probation_outcome IS NULL OR probation_outcome IN ('confirm', 'extend', 'not_confirmed')),
+    CONSTRAINT chk_appraisals_increment CHECK (increment_percent IS NULL OR increment_percent BETWEEN 0 AND 100)
+);
+
+-- Enable RLS
+ALTER TABLE appraisals ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_appraisals ON appraisals
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_appraisals ON appraisals
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE UNIQUE INDEX idx_appraisals_cycle_staff
+    ON appraisals(cycle_id, staff_id) WHERE cycle_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_appraisals_probation_due
+    ON appraisals(tenant_id, staff_id, due_date) WHERE type = 'probation';
+CREATE INDEX idx_appraisals_staff ON appraisals(tenant_id, staff_id);
+CREATE INDEX idx_appraisals_manager_status ON appraisals(tenant_id, manager_id, status);
+CREATE INDEX idx_appraisals_reviewer_status ON appraisals(tenant_id, reviewer_id, status);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_appraisals
+    BEFORE UPDATE ON appraisals
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Appraisal goals, copied from the template with self and manager ratings
+CREATE TABLE appraisal_goals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    appraisal_id UUID NOT NULL REFERENCES appraisals(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    weight INTEGER NOT NULL,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    self_rating INTEGER,
+    self_comment TEXT,
+    manager_rating INTEGER,
+    manager_comment TEXT,
+
+    CONSTRAINT chk_appraisal_goals_weight CHECK (weight BETWEEN 1 AND 100),
+    CONSTRAINT chk_appraisal_goals_self_rating CHECK (self_rating IS NULL OR self_rating BETWEEN 1 AND 5),
+    CONSTRAINT chk_appraisal_goals_manager_rating CHECK (manager_rating IS NULL OR manager_rating BETWEEN 1 AND 5)
+);
+
+CREATE INDEX idx_appraisal_goals_appraisal ON appraisal_goals(appraisal_id);
+
+-- ============================================================
+-- Permissions
+-- ============================================================
+
+INSERT INTO permissions (id, code, name, description, module, created_at, updated_at)
+VALUES
+    (uuid_generate_v7(), 'appraisals.view', 'View Appraisals', 'Permission to view appraisal templates, cycles and appraisals', 'staff', NOW(), NOW()),
+    (uuid_generate_v7(), 'appraisals.manage', 'Manage Appraisals', 'Permission to manage appraisal templates and launch and close cycles', 'staff', NOW(), NOW()),
+    (uuid_generate_v7(), 'appraisals.self_assess', 'Submit Self-Assessment', 'Permission to complete an appraisal self-assessment', 'staff', NOW(), NOW()),
+    (uuid_generate_v7(), 'appraisals.review', 'Review Appraisals', 'Permission to complete the reporting manager review', 'staff', NOW(), NOW()),
+    (uuid_generate_v7(), 'appraisals.signoff', 'Sign Off Appraisals', 'Permission to sign off manager reviews and apply outcomes', 'staff', NOW(), NOW())
+ON CONFLICT (code) DO NOTHING;
+
+-- Super admin, admin - full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('super_admin', 'admin')
+AND p.code IN ('appraisals.view', 'appraisals.manage', 'appraisals.self_assess', 'appraisals.review', 'appraisals.signoff')
+ON CONFLICT DO NOTHING;
+
+-- Principals review and sign off
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'principal'
+AND p.code IN ('appraisals.view', 'appraisals.self_assess', 'appraisals.review', 'appraisals.signoff')
+ON CONFLICT DO NOTHING;
+
+-- Teachers complete their self-assessment
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'teacher'
+AND p.code IN ('appraisals.view', 'appraisals.self_assess')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE appraisal_templates IS 'KRA/goal templates per designation; a NULL designation is the default template';
+COMMENT ON TABLE appraisals IS 'Cycle appraisals and probation confirmation reviews';
+COMMENT ON COLUMN appraisals.overall_rating IS 'Weighted average of manager goal ratings (1-5)';
+COMMENT ON COLUMN appraisals.salary_revision_id IS 'Staff salary revision created when an increment was approved';
@@ -0,0 +1,37 @@
+-- Reverse Staff Onboarding and Exit Checklists migration
+
+-- Remove permissions from roles
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code IN ('staff_checklists.view', 'staff_checklists.manage', 'staff_checklists.complete')
+);
+
+-- Remove permissions
+DELETE FROM permissions WHERE code IN ('staff_checklists.view', 'staff_checklists.manage', 'staff_checklists.complete');
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS set_updated_at_exit_settlements ON exit_settlements;
+DROP TRIGGER IF EXISTS set_updated_at_staff_assets ON staff_assets;
+DROP TRIGGER IF EXISTS set_updated_at_staff_checklist_tasks ON staff_checklist_tasks;
+DROP TRIGGER IF EXISTS set_updated_at_staff_checklists ON staff_checklists;
+DROP TRIGGER IF EXISTS set_updated_at_checklist_templates ON checklist_templates;
+
+-- Drop policies
+DROP POLICY IF EXISTS bypass_rls_exit_settlements ON exit_settlements;
+DROP POLICY IF EXISTS tenant_isolation_exit_settlements ON exit_settlements;
+DROP POLICY IF EXISTS bypass_rls_staff_assets ON staff_assets;
+DROP POLICY IF EXISTS tenant_isolation_staff_assets ON staff_assets;
+DROP POLICY IF EXISTS bypass_rls_staff_checklist_tasks ON staff_checklist_tasks;
+DROP POLICY IF EXISTS tenant_isolation_staff_checklist_tasks ON staff_checklist_tasks;
+DROP POLICY IF EXISTS bypass_rls_staff_checklists ON staff_checklists;
+DROP POLICY IF EXISTS tenant_isolation_staff_checklists ON staff_checklists;
+DROP POLICY IF EXISTS bypass_rls_checklist_templates ON checklist_templates;
+DROP POLICY IF EXISTS tenant_isolation_checklist_templates ON checklist_templates;
+
+-- Drop tables
+DROP TABLE IF EXISTS exit_settlements;
+DROP TABLE IF EXISTS staff_assets;
+DROP TABLE IF EXISTS staff_checklist_tasks;
+DROP TABLE IF EXISTS staff_checklists;
+DROP TABLE IF EXISTS checklist_template_items;
+DROP TABLE IF EXISTS checklist_templates;
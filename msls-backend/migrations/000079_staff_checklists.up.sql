@@ -0,0 +1,311 @@
+-- Staff Onboarding and Exit Checklists
+-- Checklist templates per staff type list the tasks to open when a staff
+-- member joins or leaves, each assigned to a role with a due date offset.
+-- Onboarding checklists also get a task per mandatory document type. Exit
+-- workflows track department clearances, the return of issued assets, the
+-- full-and-final settlement and the relieving and experience letters.
+
+-- ============================================================
+-- Templates
+-- ============================================================
+
+CREATE TABLE checklist_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    staff_type VARCHAR(20),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+
+    CONSTRAINT chk_checklist_templates_kind CHECK (kind IN ('onboarding', 'exit')),
+    CONSTRAINT chk_checklist_templates_staff_type CHECK (staff_type IS NULL OR staff_type IN ('teaching', 'non_teaching'))
+);
+
+-- Enable RLS
+ALTER TABLE checklist_templates ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_checklist_templates ON checklist_templates
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_checklist_templates ON checklist_templates
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- One active template per kind and staff type, plus one for all staff (no staff type)
+CREATE UNIQUE INDEX idx_checklist_templates_active
+    ON checklist_templates(tenant_id, kind, COALESCE(staff_type, ''))
+    WHERE is_active = TRUE;
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_checklist_templates
+    BEFORE UPDATE ON checklist_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Template items
+CREATE TABLE checklist_template_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    template_id UUID NOT NULL REFERENCES checklist_templates(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    task_type VARCHAR(30) NOT NULL,
+    department_id UUID REFERENCES departments(id) ON DELETE SET NULL,
+    letter_type VARCHAR(20),
+    assignee_role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    due_offset_days INTEGER NOT NULL DEFAULT 0,
+    display_order INTEGER NOT NULL DEFAULT 0,
+
+    CONSTRAINT chk_checklist_template_items_letter_type CHECK (letter_type IS NULL OR letter_type IN ('relieving', 'experience'))
+);
+
+CREATE INDEX idx_checklist_template_items_template ON checklist_template_items(template_id);
+
+-- ============================================================
+-- Checklists
+-- ============================================================
+
+CREATE TABLE staff_checklists (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    template_id UUID REFERENCES checklist_templates(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    effective_date DATE NOT NULL,
+    exit_reason TEXT,
+    completed_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id),
+
+    CONSTRAINT chk_staff_checklists_kind CHECK (kind IN ('onboarding', 'exit')),
+    CONSTRAINT chk_staff_checklists_status CHECK (status IN ('open', 'completed', 'cancelled'))
+);
+
+-- Enable RLS
+ALTER TABLE staff_checklists ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_staff_checklists ON staff_checklists
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_staff_checklists ON staff_checklists
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_staff_checklists_tenant_status ON staff_checklists(tenant_id, kind, status);
+CREATE INDEX idx_staff_checklists_staff ON staff_checklists(staff_id);
+
+-- One open checklist of each kind per staff member
+CREATE UNIQUE INDEX idx_staff_checklists_open
+    ON staff_checklists(tenant_id, staff_id, kind)
+    WHERE status = 'open';
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_staff_checklists
+    BEFORE UPDATE ON staff_checklists
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Checklist tasks
+CREATE TABLE staff_checklist_tasks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    checklist_id UUID NOT NULL REFERENCES staff_checklists(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    task_type VARCHAR(30) NOT NULL,
+    document_type_id UUID REFERENCES staff_document_types(id) ON DELETE SET NULL,
+    department_id UUID REFERENCES departments(id) ON DELETE SET NULL,
+    letter_type VARCHAR(20),
+    assignee_role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    assignee_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    due_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    completed_at TIMESTAMPTZ,
+    completed_by UUID REFERENCES users(id),
+    notes TEXT,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_staff_checklist_tasks_status CHECK (status IN ('pending', 'completed', 'waived')),
+    CONSTRAINT chk_staff_checklist_tasks_letter_type CHECK (letter_type IS NULL OR letter_type IN ('relieving', 'experience'))
+);
+
+-- Enable RLS
+ALTER TABLE staff_checklist_tasks ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_staff_checklist_tasks ON staff_checklist_tasks
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_staff_checklist_tasks ON staff_checklist_tasks
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_staff_checklist_tasks_checklist ON staff_checklist_tasks(checklist_id);
+CREATE INDEX idx_staff_checklist_tasks_role ON staff_checklist_tasks(tenant_id, assignee_role_id) WHERE status = 'pending';
+CREATE INDEX idx_staff_checklist_tasks_user ON staff_checklist_tasks(tenant_id, assignee_user_id) WHERE status = 'pending';
+CREATE INDEX idx_staff_checklist_tasks_due ON staff_checklist_tasks(tenant_id, due_date) WHERE status = 'pending';
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_staff_checklist_tasks
+    BEFORE UPDATE ON staff_checklist_tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Assets
+-- ============================================================
+
+CREATE TABLE staff_assets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    asset_tag VARCHAR(100),
+    description TEXT,
+    issued_on DATE NOT NULL,
+    issued_by UUID REFERENCES users(id),
+    returned_on DATE,
+    returned_to UUID REFERENCES users(id),
+    return_condition VARCHAR(200),
+    recovery_amount DECIMAL(12,2),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_staff_assets_recovery CHECK (recovery_amount IS NULL OR recovery_amount >= 0)
+);
+
+-- Enable RLS
+ALTER TABLE staff_assets ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_staff_assets ON staff_assets
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_staff_assets ON staff_assets
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_staff_assets_staff ON staff_assets(tenant_id, staff_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_staff_assets
+    BEFORE UPDATE ON staff_assets
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Settlements
+-- ============================================================
+
+CREATE TABLE exit_settlements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    checklist_id UUID NOT NULL UNIQUE REFERENCES staff_checklists(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    staff_salary_id UUID REFERENCES staff_salaries(id) ON DELETE SET NULL,
+    last_working_day DATE NOT NULL,
+    monthly_earnings DECIMAL(12,2) NOT NULL DEFAULT 0,
+    monthly_deductions DECIMAL(12,2) NOT NULL DEFAULT 0,
+    monthly_basic DECIMAL(12,2) NOT NULL DEFAULT 0,
+    working_days INTEGER NOT NULL DEFAULT 0,
+    payable_days INTEGER NOT NULL DEFAULT 0,
+    final_month_paid BOOLEAN NOT NULL DEFAULT FALSE,
+    final_month_salary DECIMAL(12,2) NOT NULL DEFAULT 0,
+    service_years INTEGER NOT NULL DEFAULT 0,
+    gratuity DECIMAL(12,2) NOT NULL DEFAULT 0,
+    leave_encashment_days DECIMAL(6,2) NOT NULL DEFAULT 0,
+    leave_encashment DECIMAL(12,2) NOT NULL DEFAULT 0,
+    other_earnings DECIMAL(12,2) NOT NULL DEFAULT 0,
+    asset_recovery DECIMAL(12,2) NOT NULL DEFAULT 0,
+    other_deductions DECIMAL(12,2) NOT NULL DEFAULT 0,
+    net_payable DECIMAL(12,2) NOT NULL DEFAULT 0,
+    notes TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    approved_at TIMESTAMPTZ,
+    approved_by UUID REFERENCES users(id),
+    paid_on DATE,
+    payment_reference VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id),
+
+    CONSTRAINT chk_exit_settlements_status CHECK (status IN ('draft', 'approved', 'paid'))
+);
+
+-- Enable RLS
+ALTER TABLE exit_settlements ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_exit_settlements ON exit_settlements
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_exit_settlements ON exit_settlements
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_exit_settlements_staff ON exit_settlements(tenant_id, staff_id);
+
+-- Updated at trigger
+CREATE TRIGGER set_updated_at_exit_settlements
+    BEFORE UPDATE ON exit_settlements
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_updated_at();
+
+-- ============================================================
+-- Permissions
+-- ============================================================
+
+INSERT INTO permissions (id, code, name, description, module, created_at, updated_at)
+VALUES
+    (uuid_generate_v7(), 'staff_checklists.view', 'View Staff Checklists', 'Permission to view onboarding checklists, exit workflows, staff assets and settlements', 'staff', NOW(), NOW()),
+    (uuid_generate_v7(), 'staff_checklists.manage', 'Manage Staff Checklists', 'Permission to manage checklist templates, exits, assets, settlements and letters', 'staff', NOW(), NOW()),
+    (uuid_generate_v7(), 'staff_checklists.complete', 'Complete Checklist Tasks', 'Permission to complete onboarding and exit tasks assigned to you or your role', 'staff', NOW(), NOW())
+ON CONFLICT (code) DO NOTHING;
+
+-- Super admin, admin - full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('super_admin', 'admin')
+AND p.code IN ('staff_checklists.view', 'staff_checklists.manage', 'staff_checklists.complete')
+ON CONFLICT DO NOTHING;
+
+-- Principals follow progress and complete their own tasks
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'principal'
+AND p.code IN ('staff_checklists.view', 'staff_checklists.complete')
+ON CONFLICT DO NOTHING;
+
+-- Teachers complete tasks assigned to them, such as department clearance
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'teacher'
+AND p.code IN ('staff_checklists.complete')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE checklist_templates IS 'Onboarding and exit task templates per staff type; a NULL staff type applies to all staff';
+COMMENT ON TABLE staff_checklists IS 'Onboarding checklists and exit workflows opened for staff members';
+COMMENT ON COLUMN staff_checklists.effective_date IS 'Join date for onboarding, last working day for exit; task due dates are offset from it';
+COMMENT ON TABLE staff_assets IS 'School assets issued to staff, returned or recovered on exit';
+COMMENT ON COLUMN staff_assets.recovery_amount IS 'Amount recovered for a lost or damaged asset, deducted in the exit settlement';
+COMMENT ON TABLE exit_settlements IS 'Full-and-final settlement for a staff exit: final month pay, gratuity, leave encashment and recoveries';
@@ -0,0 +1,8 @@
+-- Reverse Timetable Calendar Feeds migration
+
+-- Drop policies
+DROP POLICY IF EXISTS bypass_rls_timetable_feeds ON timetable_feeds;
+DROP POLICY IF EXISTS tenant_isolation_timetable_feeds ON timetable_feeds;
+
+-- Drop table
+DROP TABLE IF EXISTS timetable_feeds;
@@ -0,0 +1,51 @@
+-- Timetable Calendar Feeds
+-- iCalendar subscription links to a section's or a teacher's published
+-- timetable. Links are fetched without authentication, so only a SHA-256
+-- hash of each link token is stored. Creating a new link for the same
+-- section or teacher and academic year revokes the previous one.
+
+-- ============================================================
+-- Feeds
+-- ============================================================
+
+CREATE TABLE timetable_feeds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    section_id UUID REFERENCES sections(id) ON DELETE CASCADE,
+    staff_id UUID REFERENCES staff(id) ON DELETE CASCADE,
+    academic_year_id UUID NOT NULL REFERENCES academic_years(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL,
+    last_accessed_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id),
+
+    CONSTRAINT uniq_timetable_feeds_token_hash UNIQUE (token_hash),
+    CONSTRAINT chk_timetable_feeds_kind CHECK (kind IN ('section', 'teacher')),
+    CONSTRAINT chk_timetable_feeds_target CHECK (
+        (kind = 'section' AND section_id IS NOT NULL AND staff_id IS NULL) OR
+        (kind = 'teacher' AND staff_id IS NOT NULL AND section_id IS NULL)
+    )
+);
+
+-- Enable RLS
+ALTER TABLE timetable_feeds ENABLE ROW LEVEL SECURITY;
+
+-- RLS Policies
+CREATE POLICY tenant_isolation_timetable_feeds ON timetable_feeds
+    USING (tenant_id = current_setting('app.tenant_id', true)::UUID);
+
+CREATE POLICY bypass_rls_timetable_feeds ON timetable_feeds
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE INDEX idx_timetable_feeds_tenant ON timetable_feeds(tenant_id);
+CREATE INDEX idx_timetable_feeds_section ON timetable_feeds(section_id, academic_year_id) WHERE revoked_at IS NULL;
+CREATE INDEX idx_timetable_feeds_staff ON timetable_feeds(staff_id, academic_year_id) WHERE revoked_at IS NULL;
+
+COMMENT ON TABLE timetable_feeds IS 'iCalendar subscription links to section and teacher timetables';
+COMMENT ON COLUMN timetable_feeds.token_hash IS 'SHA-256 hex digest of the link token; the token itself is only shown when the link is created';
+COMMENT ON COLUMN timetable_feeds.last_accessed_at IS 'When a calendar client last fetched the feed';
+COMMENT ON COLUMN timetable_feeds.revoked_at IS 'When the link was revoked or replaced by a newer one; revoked links return 404';
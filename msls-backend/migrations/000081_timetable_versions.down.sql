@@ -0,0 +1,15 @@
+-- Rollback Timetable Versions
+-- The 'scheduled' value stays on the timetable_status type; enum values
+-- cannot be removed without recreating the type. Scheduled versions go back
+-- to drafts.
+
+UPDATE timetables SET status = 'draft', published_at = NULL, published_by = NULL
+WHERE status = 'scheduled';
+
+DROP INDEX IF EXISTS idx_timetables_supersedes;
+DROP INDEX IF EXISTS idx_timetables_effective;
+DROP INDEX IF EXISTS uniq_timetables_version;
+
+DROP POLICY IF EXISTS bypass_rls_timetables ON timetables;
+
+ALTER TABLE timetables DROP COLUMN IF EXISTS supersedes_id;
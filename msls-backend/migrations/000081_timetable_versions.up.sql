@@ -0,0 +1,47 @@
+-- Timetable Versions
+-- A section's timetable can change mid-year. Each timetable of a section and
+-- academic year is a numbered version with an effective-from/effective-to
+-- window, so lookups for a past date use the version that was in force then.
+-- Versions published with a future effective date wait in the 'scheduled'
+-- status until a background sweep activates them.
+
+ALTER TYPE timetable_status ADD VALUE IF NOT EXISTS 'scheduled';
+
+-- ============================================================
+-- Version lineage
+-- ============================================================
+
+ALTER TABLE timetables
+    ADD COLUMN supersedes_id UUID REFERENCES timetables(id) ON DELETE SET NULL;
+
+-- Number existing timetables per section and academic year in creation order
+UPDATE timetables t
+SET version = numbered.version
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY section_id, academic_year_id ORDER BY created_at, id) AS version
+    FROM timetables
+) numbered
+WHERE numbered.id = t.id;
+
+-- Close the window of previously archived timetables so they no longer
+-- count as in force for dates after they were archived
+UPDATE timetables
+SET effective_to = updated_at::date - 1
+WHERE status = 'archived' AND effective_to IS NULL;
+
+-- The sweep that activates scheduled versions runs across tenants
+CREATE POLICY bypass_rls_timetables ON timetables
+    FOR ALL
+    USING (current_setting('app.bypass_rls', true) = 'true');
+
+-- Indexes
+CREATE UNIQUE INDEX uniq_timetables_version ON timetables(section_id, academic_year_id, version)
+    WHERE deleted_at IS NULL;
+CREATE INDEX idx_timetables_effective ON timetables(section_id, effective_from, effective_to)
+    WHERE deleted_at IS NULL AND published_at IS NOT NULL;
+CREATE INDEX idx_timetables_supersedes ON timetables(supersedes_id) WHERE supersedes_id IS NOT NULL;
+
+COMMENT ON COLUMN timetables.version IS 'Version number within the section and academic year, in creation order';
+COMMENT ON COLUMN timetables.supersedes_id IS 'Version this timetable was copied from';
+COMMENT ON COLUMN timetables.effective_from IS 'First date the version is in force; NULL means from the start of the academic year';
+COMMENT ON COLUMN timetables.effective_to IS 'Last date the version is in force; set when a later version takes over or the version is archived';
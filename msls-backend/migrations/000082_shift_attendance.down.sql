@@ -0,0 +1,20 @@
+-- Rollback Shift Attendance
+
+ALTER TABLE staff_attendance
+    DROP COLUMN IF EXISTS early_exit_minutes,
+    DROP COLUMN IF EXISTS is_early_exit,
+    DROP COLUMN IF EXISTS shift_id;
+
+ALTER TABLE staff_attendance_settings DROP COLUMN IF EXISTS early_exit_threshold_minutes;
+
+DROP INDEX IF EXISTS idx_sections_shift;
+DROP INDEX IF EXISTS idx_staff_shift;
+
+ALTER TABLE sections DROP COLUMN IF EXISTS shift_id;
+ALTER TABLE staff DROP COLUMN IF EXISTS shift_id;
+
+ALTER TABLE shifts
+    DROP COLUMN IF EXISTS working_days,
+    DROP COLUMN IF EXISTS attendance_deadline,
+    DROP COLUMN IF EXISTS early_exit_threshold_minutes,
+    DROP COLUMN IF EXISTS late_threshold_minutes;
@@ -0,0 +1,53 @@
+-- Shift Attendance
+-- Schools running morning and afternoon shifts in the same building need
+-- attendance rules per shift rather than per branch. Staff and sections are
+-- assigned to a shift; a shift carries its own late and early-exit
+-- thresholds, the deadline for marking student attendance and the weekdays
+-- it works. Staff and sections without a shift keep the branch-wide rules.
+
+-- ============================================================
+-- Shift attendance rules
+-- ============================================================
+
+ALTER TABLE shifts
+    ADD COLUMN late_threshold_minutes INTEGER CHECK (late_threshold_minutes BETWEEN 0 AND 120),
+    ADD COLUMN early_exit_threshold_minutes INTEGER CHECK (early_exit_threshold_minutes BETWEEN 0 AND 120),
+    ADD COLUMN attendance_deadline TIME,
+    ADD COLUMN working_days SMALLINT[] NOT NULL DEFAULT '{1,2,3,4,5,6}'
+        CHECK (working_days <@ '{0,1,2,3,4,5,6}'::SMALLINT[]);
+
+COMMENT ON COLUMN shifts.late_threshold_minutes IS 'Minutes after the shift start before staff check-in counts as late; NULL uses the branch attendance settings';
+COMMENT ON COLUMN shifts.early_exit_threshold_minutes IS 'Minutes before the shift end that staff may check out without counting as an early exit; NULL uses the branch attendance settings';
+COMMENT ON COLUMN shifts.attendance_deadline IS 'Time by which student attendance must be marked for sections of this shift; NULL uses the default deadline';
+COMMENT ON COLUMN shifts.working_days IS 'Days of the week the shift works (0=Sunday, ..., 6=Saturday)';
+
+-- ============================================================
+-- Shift assignment
+-- ============================================================
+
+ALTER TABLE staff
+    ADD COLUMN shift_id UUID REFERENCES shifts(id) ON DELETE SET NULL;
+
+ALTER TABLE sections
+    ADD COLUMN shift_id UUID REFERENCES shifts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_staff_shift ON staff(shift_id) WHERE shift_id IS NOT NULL;
+CREATE INDEX idx_sections_shift ON sections(shift_id) WHERE shift_id IS NOT NULL;
+
+COMMENT ON COLUMN staff.shift_id IS 'Shift the staff member works; attendance is evaluated against its timings';
+COMMENT ON COLUMN sections.shift_id IS 'Shift the section attends; its attendance deadline and working days apply';
+
+-- ============================================================
+-- Early exits
+-- ============================================================
+
+ALTER TABLE staff_attendance_settings
+    ADD COLUMN early_exit_threshold_minutes INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE staff_attendance
+    ADD COLUMN shift_id UUID REFERENCES shifts(id) ON DELETE SET NULL,
+    ADD COLUMN is_early_exit BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN early_exit_minutes INTEGER DEFAULT 0;
+
+COMMENT ON COLUMN staff_attendance.shift_id IS 'Shift the record was evaluated against, if any';
+COMMENT ON COLUMN staff_attendance.is_early_exit IS 'Whether the staff member checked out before the end of their working hours';